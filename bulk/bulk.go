@@ -0,0 +1,269 @@
+package bulk
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+	"github.com/cacack/gedcom-go/v2/gedzip"
+)
+
+// Options configures a bulk load.
+type Options struct {
+	// Decode configures the decoding of each file. If nil, decoder
+	// defaults are used. OnProgress and TotalSize apply to each file
+	// separately, and with Workers above one OnProgress must be safe for
+	// concurrent use.
+	Decode *decoder.DecodeOptions
+
+	// Workers is the number of files decoded at once.
+	// Default: 0 (one file at a time).
+	Workers int
+}
+
+// Result holds the outcome of a bulk load, keyed by file path.
+type Result struct {
+	// Documents holds the decoded document of each file that could be
+	// decoded, including partial documents returned with an error.
+	Documents map[string]*gedcom.Document
+
+	// Diagnostics holds the decoder diagnostics of each file that has any.
+	Diagnostics map[string]decoder.Diagnostics
+
+	// Errors holds the error of each file that failed to decode.
+	Errors map[string]error
+}
+
+// Files returns the paths of every file found, decoded or not, sorted.
+func (r *Result) Files() []string {
+	files := make([]string, 0, len(r.Documents)+len(r.Errors))
+	for name := range r.Documents {
+		files = append(files, name)
+	}
+	for name := range r.Errors {
+		if _, ok := r.Documents[name]; !ok {
+			files = append(files, name)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// Load decodes the GEDCOM files in the directory or archive at name. ZIP
+// archives are recognized by a .zip extension and tar archives by .tar,
+// .tar.gz, or .tgz. The error is non-nil only if the directory or archive
+// itself cannot be read; per-file failures are reported in Result.Errors.
+func Load(name string, opts *Options) (*Result, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, fmt.Errorf("bulk: %w", err)
+	}
+	if info.IsDir() {
+		return LoadFS(os.DirFS(name), opts)
+	}
+
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return LoadZip(name, opts)
+	case strings.HasSuffix(lower, ".tar"), strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		f, err := os.Open(name) //nolint:gosec // caller-supplied archive path
+		if err != nil {
+			return nil, fmt.Errorf("bulk: %w", err)
+		}
+		defer f.Close()
+		return LoadTar(f, opts)
+	default:
+		return nil, fmt.Errorf("bulk: %s is not a directory, ZIP, or tar archive", name)
+	}
+}
+
+// LoadZip decodes the GEDCOM files in the ZIP archive at name.
+func LoadZip(name string, opts *Options) (*Result, error) {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, fmt.Errorf("bulk: %w", err)
+	}
+	defer zr.Close()
+	return LoadFS(zr, opts)
+}
+
+// LoadFS decodes the GEDCOM files in fsys, such as an os.DirFS or a
+// *zip.Reader.
+func LoadFS(fsys fs.FS, opts *Options) (*Result, error) {
+	var files []file
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != "." && skipped(d.Name()) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() && isGEDCOM(p) {
+			files = append(files, file{name: p, open: func() (io.ReadCloser, error) { return fsys.Open(p) }})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bulk: %w", err)
+	}
+	return decodeFiles(files, opts), nil
+}
+
+// LoadTar decodes the GEDCOM files in a tar stream, which may be
+// gzip-compressed. Matching files are read into memory before decoding.
+func LoadTar(r io.Reader, opts *Options) (*Result, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("bulk: %w", err)
+		}
+		defer zr.Close()
+		r = zr
+	} else {
+		r = br
+	}
+
+	var files []file
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("bulk: %w", err)
+		}
+		name := strings.TrimPrefix(path.Clean(hdr.Name), "./")
+		if hdr.Typeflag != tar.TypeReg || !isGEDCOM(name) || hiddenPath(name) {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("bulk: %s: %w", name, err)
+		}
+		files = append(files, file{name: name, open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}})
+	}
+	return decodeFiles(files, opts), nil
+}
+
+// file is a GEDCOM file found in a directory or archive.
+type file struct {
+	name string
+	open func() (io.ReadCloser, error)
+}
+
+// decodeFiles decodes files, Workers at a time.
+func decodeFiles(files []file, opts *Options) *Result {
+	res := &Result{
+		Documents:   make(map[string]*gedcom.Document),
+		Diagnostics: make(map[string]decoder.Diagnostics),
+		Errors:      make(map[string]error),
+	}
+	var decodeOpts *decoder.DecodeOptions
+	workers := 1
+	if opts != nil {
+		decodeOpts = opts.Decode
+		if opts.Workers > 1 {
+			workers = opts.Workers
+		}
+	}
+
+	var (
+		mu    sync.Mutex // guards res
+		wg    sync.WaitGroup
+		slots = make(chan struct{}, workers)
+	)
+	for _, f := range files {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(f file) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			result, err := decodeFile(f, decodeOpts)
+			mu.Lock()
+			defer mu.Unlock()
+			if result != nil {
+				res.Documents[f.name] = result.Document
+				if len(result.Diagnostics) > 0 {
+					res.Diagnostics[f.name] = result.Diagnostics
+				}
+			}
+			if err != nil {
+				res.Errors[f.name] = err
+			}
+		}(f)
+	}
+	wg.Wait()
+	return res
+}
+
+// decodeFile decodes one file, reading the dataset of a GEDZip archive.
+func decodeFile(f file, opts *decoder.DecodeOptions) (*decoder.DecodeResult, error) {
+	rc, err := f.open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var r io.Reader = rc
+	if strings.HasSuffix(strings.ToLower(f.name), ".gdz") {
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return nil, err
+		}
+		zr, err := gedzip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, err
+		}
+		dataset, err := zr.Open(gedzip.DatasetPath)
+		if err != nil {
+			return nil, err
+		}
+		defer dataset.Close()
+		r = dataset
+	}
+	return decoder.DecodeWithDiagnostics(r, opts)
+}
+
+// isGEDCOM reports whether name has a .ged, .ged.gz, or .gdz extension.
+func isGEDCOM(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.HasSuffix(lower, ".ged") || strings.HasSuffix(lower, ".ged.gz") || strings.HasSuffix(lower, ".gdz")
+}
+
+// skipped reports whether a file or directory name is hidden or a macOS
+// resource-fork folder.
+func skipped(name string) bool {
+	return strings.HasPrefix(name, ".") || name == "__MACOSX"
+}
+
+// hiddenPath reports whether any element of the slash-separated path is
+// skipped.
+func hiddenPath(p string) bool {
+	for _, elem := range strings.Split(p, "/") {
+		if skipped(elem) {
+			return true
+		}
+	}
+	return false
+}
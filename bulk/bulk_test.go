@@ -0,0 +1,264 @@
+package bulk
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+	"github.com/cacack/gedcom-go/v2/gedzip"
+)
+
+const validGedcom = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /Doe/
+0 TRLR
+`
+
+// invalidGedcom has a line without a level number.
+const invalidGedcom = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+not a gedcom line
+0 TRLR
+`
+
+// gdzBytes returns a GEDZip archive of validGedcom.
+func gdzBytes(t *testing.T) []byte {
+	t.Helper()
+	doc, err := decoder.Decode(bytes.NewReader([]byte(validGedcom)))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := gedzip.Write(&buf, doc, nil, nil); err != nil {
+		t.Fatalf("gedzip.Write() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+// gzipBytes returns data gzip-compressed.
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// testFiles returns the files of a sample collection, keyed by path.
+func testFiles(t *testing.T) map[string][]byte {
+	t.Helper()
+	return map[string][]byte{
+		"a.ged":               []byte(validGedcom),
+		"sub/b.GED":           []byte(validGedcom),
+		"sub/c.ged.gz":        gzipBytes(t, []byte(validGedcom)),
+		"d.gdz":               gdzBytes(t),
+		"bad.ged":             []byte(invalidGedcom),
+		"notes.txt":           []byte("not GEDCOM"),
+		".hidden/e.ged":       []byte(validGedcom),
+		"__MACOSX/._a.ged":    []byte("resource fork"),
+		"sub/.f.ged":          []byte(validGedcom),
+		"sub/deeper/empty.md": nil,
+	}
+}
+
+var wantFiles = []string{"a.ged", "bad.ged", "d.gdz", "sub/b.GED", "sub/c.ged.gz"}
+
+// checkResult verifies a load of testFiles.
+func checkResult(t *testing.T, res *Result) {
+	t.Helper()
+	if got := res.Files(); !reflect.DeepEqual(got, wantFiles) {
+		t.Errorf("Files() = %v, want %v", got, wantFiles)
+	}
+	for _, name := range []string{"a.ged", "d.gdz", "sub/b.GED", "sub/c.ged.gz"} {
+		if err := res.Errors[name]; err != nil {
+			t.Errorf("Errors[%s] = %v", name, err)
+		}
+		doc := res.Documents[name]
+		if doc == nil || doc.GetIndividual("@I1@") == nil {
+			t.Errorf("Documents[%s] has no @I1@", name)
+		}
+	}
+	if res.Errors["bad.ged"] == nil {
+		t.Error("Errors[bad.ged] = nil, want a syntax error")
+	}
+}
+
+func writeDir(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoad_Directory(t *testing.T) {
+	res, err := Load(writeDir(t, testFiles(t)), &Options{Decode: strictDecode()})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	checkResult(t, res)
+}
+
+func TestLoad_Zip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range testFiles(t) {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Join(t.TempDir(), "exports.ZIP")
+	if err := os.WriteFile(name, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := Load(name, &Options{Decode: strictDecode(), Workers: 4})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	checkResult(t, res)
+}
+
+func TestLoad_TarGz(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, data := range testFiles(t) {
+		hdr := &tar.Header{Name: "./" + name, Mode: 0o600, Size: int64(len(data)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	name := filepath.Join(t.TempDir(), "exports.tar.gz")
+	if err := os.WriteFile(name, gzipBytes(t, buf.Bytes()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	res, err := Load(name, &Options{Decode: strictDecode(), Workers: 2})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	checkResult(t, res)
+
+	// Uncompressed tar streams are read too.
+	res, err = LoadTar(bytes.NewReader(buf.Bytes()), &Options{Decode: strictDecode()})
+	if err != nil {
+		t.Fatalf("LoadTar() error = %v", err)
+	}
+	checkResult(t, res)
+}
+
+func TestLoad_LenientDiagnostics(t *testing.T) {
+	dir := writeDir(t, map[string][]byte{"bad.ged": []byte(invalidGedcom)})
+	opts := decoder.DefaultOptions()
+	opts.Strictness = gedcom.StrictnessNormal
+
+	res, err := Load(dir, &Options{Decode: opts})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := res.Errors["bad.ged"]; err != nil {
+		t.Errorf("Errors[bad.ged] = %v, want nil below strict", err)
+	}
+	if res.Documents["bad.ged"] == nil {
+		t.Error("Documents[bad.ged] = nil, want a recovered document")
+	}
+	if len(res.Diagnostics["bad.ged"]) == 0 {
+		t.Error("Diagnostics[bad.ged] is empty, want the syntax error")
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing"), nil); err == nil {
+		t.Error("Load(missing) error = nil")
+	}
+
+	plain := filepath.Join(t.TempDir(), "a.ged")
+	if err := os.WriteFile(plain, []byte(validGedcom), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(plain, nil); err == nil {
+		t.Error("Load(a.ged) error = nil, want unsupported container")
+	}
+
+	corrupt := filepath.Join(t.TempDir(), "corrupt.zip")
+	if err := os.WriteFile(corrupt, []byte("not a zip"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(corrupt, nil); err == nil {
+		t.Error("Load(corrupt.zip) error = nil")
+	}
+
+	if _, err := LoadTar(bytes.NewReader(gzipBytes(t, []byte("not a tar archive at all"))), nil); err == nil {
+		t.Error("LoadTar(garbage) error = nil")
+	}
+}
+
+func TestLoadFS_Empty(t *testing.T) {
+	res, err := LoadFS(os.DirFS(t.TempDir()), nil)
+	if err != nil {
+		t.Fatalf("LoadFS() error = %v", err)
+	}
+	if files := res.Files(); len(files) != 0 {
+		t.Errorf("Files() = %v, want none", files)
+	}
+}
+
+func TestIsGEDCOM(t *testing.T) {
+	tests := map[string]bool{
+		"a.ged":     true,
+		"A.GED":     true,
+		"a.ged.gz":  true,
+		"a.gdz":     true,
+		"a.gedcom":  false,
+		"a.gz":      false,
+		"ged":       false,
+		"dir/x.Ged": true,
+	}
+	for name, want := range tests {
+		if got := isGEDCOM(name); got != want {
+			t.Errorf("isGEDCOM(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func strictDecode() *decoder.DecodeOptions {
+	opts := decoder.DefaultOptions()
+	opts.Strictness = gedcom.StrictnessStrict
+	return opts
+}
@@ -0,0 +1,28 @@
+// Package bulk decodes every GEDCOM file in a directory or archive, for
+// batch jobs over collections of exports.
+//
+// Load accepts a directory, a ZIP archive, or a tar archive (optionally
+// gzip-compressed), finds the .ged, .ged.gz, and .gdz (GEDZip) files in it,
+// and decodes each one:
+//
+//	res, err := bulk.Load("exports.zip", &bulk.Options{Workers: 8})
+//	if err != nil {
+//	    log.Fatal(err) // the archive itself could not be read
+//	}
+//	for _, name := range res.Files() {
+//	    if err := res.Errors[name]; err != nil {
+//	        log.Printf("%s: %v", name, err)
+//	        continue
+//	    }
+//	    fmt.Println(name, len(res.Documents[name].Individuals()))
+//	}
+//
+// Files are keyed by their slash-separated path within the directory or
+// archive. Hidden files and directories (names starting with ".") and
+// "__MACOSX" folders are skipped. LoadFS, LoadZip, and LoadTar read from an
+// fs.FS, a ZIP file, and a tar stream directly.
+//
+// Each file is decoded with decoder.DecodeWithDiagnostics, so the decode
+// options' Strictness applies: below gedcom.StrictnessStrict, files with
+// syntax errors are still decoded and their diagnostics reported.
+package bulk
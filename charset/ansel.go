@@ -4,15 +4,20 @@
 // This file implements an io.Reader that converts ANSEL-encoded bytes to UTF-8.
 //
 // IMPORTANT: ANSEL places combining diacritical marks BEFORE the base character,
-// while Unicode places them AFTER. This decoder handles the reordering automatically.
-// For example, ANSEL bytes [0xE2, 0x65] (acute + e) become UTF-8 "e\u0301" (e + combining acute).
+// while Unicode places them AFTER. This decoder handles the reordering automatically
+// and composes the result (Unicode NFC), so names compare and search as they would
+// in a UTF-8 file. For example, ANSEL bytes [0xE2, 0x65] (acute + e) become UTF-8
+// "é" (U+00E9); marks with no precomposed form stay as combining characters.
 
 package charset
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // ErrInvalidANSEL is returned when an invalid ANSEL byte sequence is encountered.
@@ -38,11 +43,19 @@ type anselReader struct {
 	err     error  // Stored error from processing
 }
 
+// DecodeANSEL converts ANSEL-encoded bytes to a UTF-8 string, as the decoder
+// does for files declaring CHAR ANSEL.
+func DecodeANSEL(b []byte) (string, error) {
+	out, err := io.ReadAll(newAnselReader(bytes.NewReader(b)))
+	return string(out), err
+}
+
 // newAnselReader creates a new reader that converts ANSEL-encoded input to UTF-8.
 // The returned reader handles:
 //   - ASCII passthrough (0x00-0x7F)
 //   - Extended Latin character mapping (0xA1-0xC8)
-//   - Combining diacritical reordering (0xE0-0xFE placed after base character)
+//   - Combining diacritical reordering (0xE0-0xFE placed after base character),
+//     composed to precomposed characters where Unicode has them
 func newAnselReader(r io.Reader) io.Reader {
 	return &anselReader{
 		reader:  r,
@@ -156,6 +169,11 @@ func (r *anselReader) handleEOF(p []byte) (int, error) {
 func (r *anselReader) processByte(b byte) error {
 	// Check for combining diacritical (0xE0-0xFE)
 	if IsCombiningDiacritical(b) {
+		if anselSecondHalves[b] {
+			// Written once, with the first half
+			r.column++
+			return nil
+		}
 		combining, ok := anselCombining[b]
 		if !ok {
 			// Undefined combining mark in range (0xFC, 0xFD)
@@ -166,6 +184,11 @@ func (r *anselReader) processByte(b byte) error {
 		return nil
 	}
 
+	if anselIgnored[b] {
+		r.column++
+		return nil
+	}
+
 	// Get the base character
 	var baseRune rune
 
@@ -177,21 +200,24 @@ func (r *anselReader) processByte(b byte) error {
 		baseRune = mapped
 	} else {
 		// Invalid ANSEL byte:
-		// - 0x80-0xA0: C1 control characters and undefined (not used in ANSEL)
+		// - 0x80-0xA0: C1 control characters and undefined (not used in ANSEL),
+		//   except the joiners and non-sort markers above
 		// - 0xAF, 0xBB: Undefined in ANSEL extended Latin range
 		// - 0xC9-0xCC, 0xD0-0xDF: Undefined in ANSEL
 		// - 0xFF: Undefined in ANSEL
 		return &ErrInvalidANSEL{Line: r.line, Column: r.column, Byte: b}
 	}
 
-	// Emit base character as UTF-8
-	r.emitRune(baseRune)
-
-	// Emit any pending combining marks (now AFTER the base character)
-	for _, combining := range r.pending {
-		r.emitRune(combining)
+	if baseRune < 0x20 {
+		// Marks cannot combine with a line break or other control
+		// character; keep them on their own line
+		r.flushPendingCombining()
+		r.emitRune(baseRune)
+	} else {
+		// Emit the base character followed by any pending combining marks
+		// (now AFTER the base character), composed
+		r.emitComposed(baseRune)
 	}
-	r.pending = r.pending[:0] // Clear the buffer
 
 	// Update line/column tracking
 	if b == '\n' {
@@ -204,6 +230,22 @@ func (r *anselReader) processByte(b byte) error {
 	return nil
 }
 
+// emitComposed appends base and the pending combining marks to the output
+// buffer in Unicode NFC, and clears the pending marks.
+func (r *anselReader) emitComposed(base rune) {
+	if len(r.pending) == 0 {
+		r.emitRune(base)
+		return
+	}
+	cluster := make([]byte, 0, utf8.UTFMax*(len(r.pending)+1))
+	cluster = utf8.AppendRune(cluster, base)
+	for _, combining := range r.pending {
+		cluster = utf8.AppendRune(cluster, combining)
+	}
+	r.outBuf = norm.NFC.Append(r.outBuf, cluster...)
+	r.pending = r.pending[:0]
+}
+
 // emitRune appends a rune to the output buffer as UTF-8 bytes.
 func (r *anselReader) emitRune(ru rune) {
 	var buf [utf8.UTFMax]byte
@@ -0,0 +1,220 @@
+// ANSEL encoder for GEDCOM character encoding support.
+//
+// This file converts UTF-8 text to ANSEL, the reverse of ansel.go: text is
+// decomposed (Unicode NFD) and each combining mark is written BEFORE its
+// base character, as ANSEL requires. Characters ANSEL cannot represent are
+// written as ANSELReplacement.
+
+package charset
+
+import (
+	"bytes"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ANSELReplacement is written in place of characters ANSEL cannot represent.
+const ANSELReplacement = '?'
+
+// unicodeToANSEL maps Unicode characters to ANSEL bytes. It is the reverse
+// of anselToUnicode without the LDS midline letters, which duplicate ASCII,
+// and with the standard eszett preferred over the LDS alternate.
+var unicodeToANSEL = func() map[rune]byte {
+	m := make(map[rune]byte, len(anselToUnicode))
+	for b, r := range anselToUnicode {
+		if r < 0x80 {
+			continue
+		}
+		if prev, ok := m[r]; !ok || b < prev {
+			m[r] = b
+		}
+	}
+	return m
+}()
+
+// unicodeCombiningToANSEL maps Unicode combining marks to ANSEL bytes, the
+// reverse of anselCombining. The deprecated half marks (U+FE20, U+FE22) are
+// accepted too.
+var unicodeCombiningToANSEL = func() map[rune]byte {
+	m := make(map[rune]byte, len(anselCombining)+4)
+	for b, r := range anselCombining {
+		m[r] = b
+	}
+	m['\uFE20'] = 0xEB // Ligature left half
+	m['\uFE22'] = 0xFA // Double tilde left half
+	return m
+}()
+
+// anselSecondHalfOf maps the first half of a double-width ANSEL mark to the
+// second half written before the next base character.
+var anselSecondHalfOf = map[byte]byte{
+	0xEB: 0xEC, // Ligature
+	0xFA: 0xFB, // Double tilde
+}
+
+// droppedCombining are Unicode marks with no ANSEL byte of their own because
+// they only end a double-width mark written from its first half.
+var droppedCombining = map[rune]bool{
+	'\uFE21': true, // Ligature right half
+	'\uFE23': true, // Double tilde right half
+}
+
+// EncodeANSEL converts UTF-8 text to ANSEL. It returns the encoded bytes and
+// the characters that ANSEL cannot represent, in order of appearance (each
+// written as ANSELReplacement). Invalid UTF-8 bytes count as U+FFFD.
+func EncodeANSEL(s string) ([]byte, []rune) {
+	var enc anselEncoder
+	out := enc.encode(nil, []byte(s))
+	return out, enc.unmappable
+}
+
+// ANSELUnmappable returns the characters of s that ANSEL cannot represent,
+// without duplicates, in order of first appearance.
+func ANSELUnmappable(s string) []rune {
+	_, unmappable := EncodeANSEL(s)
+	var unique []rune
+	seen := make(map[rune]bool, len(unmappable))
+	for _, r := range unmappable {
+		if !seen[r] {
+			seen[r] = true
+			unique = append(unique, r)
+		}
+	}
+	return unique
+}
+
+// ANSELWriter is an io.Writer that converts UTF-8 input to ANSEL. It
+// converts whole lines, so that a character and its combining marks are
+// never split across writes; call Flush to convert a final unterminated
+// line.
+type ANSELWriter struct {
+	w       io.Writer
+	pending []byte
+	enc     anselEncoder
+}
+
+// NewANSELWriter returns an ANSELWriter that writes ANSEL to w.
+func NewANSELWriter(w io.Writer) *ANSELWriter {
+	return &ANSELWriter{w: w}
+}
+
+// Write converts the complete lines of p, and any earlier partial line, to
+// ANSEL and writes them to the underlying writer. It reports len(p) on
+// success.
+func (a *ANSELWriter) Write(p []byte) (int, error) {
+	a.pending = append(a.pending, p...)
+	end := bytes.LastIndexAny(a.pending, "\r\n")
+	if end < 0 {
+		return len(p), nil
+	}
+	if err := a.convert(a.pending[:end+1]); err != nil {
+		return 0, err
+	}
+	a.pending = append(a.pending[:0], a.pending[end+1:]...)
+	return len(p), nil
+}
+
+// Flush converts and writes any partial line held back by Write.
+func (a *ANSELWriter) Flush() error {
+	if len(a.pending) == 0 {
+		return nil
+	}
+	err := a.convert(a.pending)
+	a.pending = a.pending[:0]
+	return err
+}
+
+// Unmappable returns the characters written so far that ANSEL cannot
+// represent, in order of appearance.
+func (a *ANSELWriter) Unmappable() []rune {
+	return a.enc.unmappable
+}
+
+func (a *ANSELWriter) convert(p []byte) error {
+	_, err := a.w.Write(a.enc.encode(nil, p))
+	return err
+}
+
+// anselEncoder converts UTF-8 to ANSEL and records the characters it could
+// not represent.
+type anselEncoder struct {
+	unmappable []rune
+}
+
+// encode appends the ANSEL form of the UTF-8 text src to dst.
+func (e *anselEncoder) encode(dst, src []byte) []byte {
+	src = norm.NFD.Bytes(src)
+	var marks []byte      // combining marks of the current character
+	var secondHalf []byte // second halves due before the next base
+	for len(src) > 0 {
+		r, size := utf8.DecodeRune(src)
+		src = src[size:]
+
+		if droppedCombining[r] {
+			continue
+		}
+		if _, ok := unicodeCombiningToANSEL[r]; ok {
+			// Marks are collected with their base below, so this one
+			// has no base character to be placed before
+			e.unmappable = append(e.unmappable, r)
+			dst = append(dst, ANSELReplacement)
+			continue
+		}
+
+		// Collect the marks that follow the base character. A mark ANSEL
+		// lacks may still compose with the base into a character it has,
+		// such as o and the horn into o-horn.
+		for len(src) > 0 {
+			next, n := utf8.DecodeRune(src)
+			if b, ok := unicodeCombiningToANSEL[next]; ok {
+				marks = append(marks, b)
+			} else if composed, ok := composeBase(r, next); ok {
+				r = composed
+			} else if !droppedCombining[next] {
+				break
+			}
+			src = src[n:]
+		}
+		base, ok := encodeBase(r)
+		if !ok {
+			e.unmappable = append(e.unmappable, r)
+			base = ANSELReplacement
+		}
+
+		// Second halves of double-width marks go before this base; marks
+		// that started a double-width pair add theirs for the next one
+		dst = append(dst, secondHalf...)
+		secondHalf = secondHalf[:0]
+		dst = append(dst, marks...)
+		for _, m := range marks {
+			if half, ok := anselSecondHalfOf[m]; ok {
+				secondHalf = append(secondHalf, half)
+			}
+		}
+		marks = marks[:0]
+		dst = append(dst, base)
+	}
+	return dst
+}
+
+// composeBase returns the ANSEL character that base and mark compose into.
+func composeBase(base, mark rune) (rune, bool) {
+	s := norm.NFC.String(string([]rune{base, mark}))
+	composed, size := utf8.DecodeRuneInString(s)
+	if size != len(s) {
+		return 0, false
+	}
+	_, ok := unicodeToANSEL[composed]
+	return composed, ok
+}
+
+// encodeBase returns the ANSEL byte of a non-combining character.
+func encodeBase(r rune) (byte, bool) {
+	if r < 0x80 {
+		return byte(r), true
+	}
+	b, ok := unicodeToANSEL[r]
+	return b, ok
+}
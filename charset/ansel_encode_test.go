@@ -0,0 +1,134 @@
+package charset
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeANSEL(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		want       []byte
+		unmappable []rune
+	}{
+		{"ASCII", "Hello\r\n", []byte("Hello\r\n"), nil},
+		{"precomposed acute", "José", []byte{'J', 'o', 's', 0xE2, 'e'}, nil},
+		{"decomposed acute", "José", []byte{'J', 'o', 's', 0xE2, 'e'}, nil},
+		{"two marks", "ệ", []byte{0xF2, 0xE3, 'e'}, nil}, // dot below, circumflex
+		{"horn", "ợ", []byte{0xF2, 0xBC}, nil},
+		{"spacing character", "Łódź", []byte{0xA1, 0xE2, 'o', 'd', 0xE2, 'z'}, nil},
+		{"eszett", "Straße", []byte{'S', 't', 'r', 'a', 0xC7, 'e'}, nil},
+		{"ligature", "t͡s", []byte{0xEB, 't', 0xEC, 's'}, nil},
+		{"double tilde", "n͠g", []byte{0xFA, 'n', 0xFB, 'g'}, nil},
+		{"deprecated half marks", "t︠s︡", []byte{0xEB, 't', 0xEC, 's'}, nil},
+		{"zero width joiner", "a‍b", []byte{'a', 0x8D, 'b'}, nil},
+		{"unmappable", "Иван — 李", []byte("???? ? ?"), []rune{'И', 'в', 'а', 'н', '—', '李'}},
+		{"orphan mark", "́a", []byte("?a"), []rune{'́'}},
+		{"invalid UTF-8", "a\xffb", []byte("a?b"), []rune{'�'}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unmappable := EncodeANSEL(tt.input)
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("EncodeANSEL(%q) = % X, want % X", tt.input, got, tt.want)
+			}
+			if !reflect.DeepEqual(unmappable, tt.unmappable) {
+				t.Errorf("EncodeANSEL(%q) unmappable = %q, want %q", tt.input, unmappable, tt.unmappable)
+			}
+		})
+	}
+}
+
+func TestEncodeANSEL_RoundTrip(t *testing.T) {
+	inputs := []string{
+		"René Müller", "Łódź", "Ångström", "Crème brûlée", "Straße",
+		"t͡s", "n͠g", "© ® ℓ ♭ ♯ ¿ ¡ °", "Nguyễn Thị Hợp",
+	}
+	for _, input := range inputs {
+		encoded, unmappable := EncodeANSEL(input)
+		if len(unmappable) > 0 {
+			t.Errorf("EncodeANSEL(%q) unmappable = %q", input, unmappable)
+		}
+		got, err := DecodeANSEL(encoded)
+		if err != nil {
+			t.Fatalf("DecodeANSEL(% X) error = %v", encoded, err)
+		}
+		if got != input {
+			t.Errorf("round trip of %q = %q", input, got)
+		}
+	}
+}
+
+func TestEncodeANSEL_EveryCharacter(t *testing.T) {
+	for b, r := range anselToUnicode {
+		if r < 0x80 || b == 0xCF {
+			continue // LDS duplicates are written in their standard form
+		}
+		got, unmappable := EncodeANSEL(string(r))
+		if len(unmappable) > 0 || !bytes.Equal(got, []byte{b}) {
+			t.Errorf("EncodeANSEL(%U) = % X (unmappable %q), want %02X", r, got, unmappable, b)
+		}
+	}
+}
+
+func TestANSELUnmappable(t *testing.T) {
+	got := ANSELUnmappable("Иван Иванович")
+	want := []rune{'И', 'в', 'а', 'н', 'о', 'и', 'ч'}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ANSELUnmappable() = %q, want %q", got, want)
+	}
+	if got := ANSELUnmappable("José"); got != nil {
+		t.Errorf("ANSELUnmappable(José) = %q, want nil", got)
+	}
+}
+
+func TestANSELWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewANSELWriter(&buf)
+	// Split a character and its mark across writes, and leave the last
+	// line unterminated.
+	for _, chunk := range []string{"1 NAME Jose", "́\n1 NOTE \xc3", "\xa9 —\r\n0 TRLR"} {
+		if n, err := w.Write([]byte(chunk)); err != nil || n != len(chunk) {
+			t.Fatalf("Write(%q) = %d, %v", chunk, n, err)
+		}
+	}
+	want := "1 NAME Jos\xe2e\n1 NOTE \xe2e ?\r\n"
+	if buf.String() != want {
+		t.Errorf("before Flush = %q, want %q", buf.String(), want)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("second Flush() error = %v", err)
+	}
+	want += "0 TRLR"
+	if buf.String() != want {
+		t.Errorf("after Flush = %q, want %q", buf.String(), want)
+	}
+	if got := w.Unmappable(); !reflect.DeepEqual(got, []rune{'—'}) {
+		t.Errorf("Unmappable() = %q, want [—]", got)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestANSELWriter_Error(t *testing.T) {
+	w := NewANSELWriter(failingWriter{})
+	if _, err := w.Write([]byte("line\n")); err == nil {
+		t.Error("Write() error = nil, want error")
+	}
+
+	w = NewANSELWriter(failingWriter{})
+	if _, err := w.Write([]byte("partial")); err != nil {
+		t.Errorf("Write() of a partial line error = %v, want nil", err)
+	}
+	if err := w.Flush(); err == nil {
+		t.Error("Flush() error = nil, want error")
+	}
+}
@@ -8,14 +8,20 @@
 //
 // IMPORTANT: ANSEL places combining diacritical marks BEFORE the base character,
 // while Unicode places them AFTER. The decoder must handle this reordering.
-// For example, ANSEL "´e" (acute + e) becomes Unicode "é" (e + combining acute).
+// For example, ANSEL "´e" (acute + e) becomes Unicode "e" + combining acute,
+// which the decoder then composes to "é" (U+00E9).
 
 package charset
 
-// anselToUnicode maps ANSEL extended Latin characters (0xA1-0xC8) to Unicode code points.
+// anselToUnicode maps ANSEL extended Latin characters (0xA1-0xC8) and joiners
+// (0x8D, 0x8E) to Unicode code points.
 // These are single-character mappings for special letters and symbols used in
 // European languages and other contexts found in genealogical records.
 var anselToUnicode = map[byte]rune{
+	// Joiners
+	0x8D: '\u200D', // Zero width joiner
+	0x8E: '\u200C', // Zero width non-joiner
+
 	// Uppercase special letters
 	0xA1: '\u0141', // Ł - Uppercase Polish L with stroke
 	0xA2: '\u00D8', // Ø - Uppercase Scandinavian O with stroke
@@ -87,8 +93,7 @@ var anselCombining = map[byte]rune{
 	0xE8: '\u0308', // Combining diaeresis (umlaut)
 	0xE9: '\u030C', // Combining caron (hacek)
 	0xEA: '\u030A', // Combining ring above
-	0xEB: '\uFE20', // Combining ligature left half (deprecated, prefer U+0361)
-	0xEC: '\uFE21', // Combining ligature right half (deprecated, prefer U+0361)
+	0xEB: '\u0361', // Combining double inverted breve (ligature, first half; see anselSecondHalves)
 	0xED: '\u0315', // Combining comma above right
 	0xEE: '\u030B', // Combining double acute accent
 	0xEF: '\u0310', // Combining candrabindu
@@ -102,12 +107,28 @@ var anselCombining = map[byte]rune{
 	0xF7: '\u0326', // Combining comma below
 	0xF8: '\u031C', // Combining left half ring below
 	0xF9: '\u032E', // Combining breve below
-	0xFA: '\u0360', // Combining double tilde (first half)
-	0xFB: '\u0361', // Combining double inverted breve (ligature tie)
+	0xFA: '\u0360', // Combining double tilde (first half; see anselSecondHalves)
 	// 0xFC, 0xFD are undefined in ANSEL
 	0xFE: '\u0313', // Combining comma above (high comma off center)
 }
 
+// anselSecondHalves are the second halves of ANSEL's double-width marks,
+// which span two base characters: ligature (0xEB, 0xEC) and double tilde
+// (0xFA, 0xFB). Unicode writes such a mark once, after the first base
+// character, so the first half maps to the whole mark and the second half
+// produces no output.
+var anselSecondHalves = map[byte]bool{
+	0xEC: true, // Ligature, second half
+	0xFB: true, // Double tilde, second half
+}
+
+// anselIgnored are ANSEL control codes with no Unicode text equivalent.
+// They are dropped from the output.
+var anselIgnored = map[byte]bool{
+	0x88: true, // Non-sort character sequence begin
+	0x89: true, // Non-sort character sequence end
+}
+
 // IsCombiningDiacritical returns true if the given byte is an ANSEL combining
 // diacritical mark (0xE0-0xFE range). These marks modify the character that
 // follows them in ANSEL encoding.
@@ -2,6 +2,7 @@ package charset
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"testing"
 )
@@ -110,37 +111,37 @@ func TestNewAnselReader_CombiningDiacriticals(t *testing.T) {
 		{
 			name:  "Acute accent + e = é",
 			input: []byte{0xE2, 'e'},
-			want:  "e\u0301", // e + combining acute
+			want:  "\u00E9", // composed e with acute
 		},
 		{
 			name:  "Grave accent + a = à",
 			input: []byte{0xE1, 'a'},
-			want:  "a\u0300", // a + combining grave
+			want:  "\u00E0", // composed a with grave
 		},
 		{
 			name:  "Circumflex + o = ô",
 			input: []byte{0xE3, 'o'},
-			want:  "o\u0302", // o + combining circumflex
+			want:  "\u00F4", // composed o with circumflex
 		},
 		{
 			name:  "Tilde + n = ñ",
 			input: []byte{0xE4, 'n'},
-			want:  "n\u0303", // n + combining tilde
+			want:  "\u00F1", // composed n with tilde
 		},
 		{
 			name:  "Umlaut + u = ü",
 			input: []byte{0xE8, 'u'},
-			want:  "u\u0308", // u + combining diaeresis
+			want:  "\u00FC", // composed u with diaeresis
 		},
 		{
 			name:  "Cedilla + c = ç",
 			input: []byte{0xF0, 'c'},
-			want:  "c\u0327", // c + combining cedilla
+			want:  "\u00E7", // composed c with cedilla
 		},
 		{
 			name:  "Multiple combining marks",
 			input: []byte{0xE2, 0xE8, 'a'}, // acute + diaeresis + a
-			want:  "a\u0301\u0308",
+			want:  "\u00E1\u0308",          // a with acute, then combining diaeresis (no precomposed form)
 		},
 	}
 
@@ -169,7 +170,7 @@ func TestNewAnselReader_GEDCOMText(t *testing.T) {
 			name: "Name with accented character",
 			// "José" where é is acute + e
 			input: append([]byte("Jos"), append([]byte{0xE2, 'e'}, []byte(" /Smith/")...)...),
-			want:  "Jose\u0301 /Smith/",
+			want:  "Jos\u00E9 /Smith/",
 		},
 		{
 			name: "Place with Polish L",
@@ -214,7 +215,7 @@ func TestNewAnselReader_SmallBufferReads(t *testing.T) {
 		}
 	}
 
-	want := "Hello e\u0301 World"
+	want := "Hello \u00E9 World"
 	if string(result) != want {
 		t.Errorf("Small buffer reads = %q, want %q", result, want)
 	}
@@ -424,3 +425,63 @@ func TestNewAnselReader_LargeInput(t *testing.T) {
 		t.Errorf("Got %d lines, want 100", lines)
 	}
 }
+
+func TestNewAnselReader_DoubleWidthMarks(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  string
+	}{
+		{
+			name:  "ligature spans two letters",
+			input: []byte{0xEB, 't', 0xEC, 's'},
+			want:  "t\u0361s",
+		},
+		{
+			name:  "double tilde spans two letters",
+			input: []byte{0xFA, 'n', 0xFB, 'g'},
+			want:  "n\u0360g",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeANSEL(tt.input)
+			if err != nil {
+				t.Fatalf("DecodeANSEL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DecodeANSEL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAnselReader_ControlCodes(t *testing.T) {
+	// Joiners map to Unicode; non-sort markers are dropped
+	got, err := DecodeANSEL([]byte{0x88, 'T', 'h', 'e', ' ', 0x89, 'a', 0x8D, 'b', 0x8E, 'c'})
+	if err != nil {
+		t.Fatalf("DecodeANSEL() error = %v", err)
+	}
+	if want := "The a\u200Db\u200Cc"; got != want {
+		t.Errorf("DecodeANSEL() = %q, want %q", got, want)
+	}
+}
+
+func TestNewAnselReader_MarkBeforeLineBreak(t *testing.T) {
+	// A mark with no base character stays on its own line
+	got, err := DecodeANSEL([]byte{'a', 0xE2, '\n', 'b'})
+	if err != nil {
+		t.Fatalf("DecodeANSEL() error = %v", err)
+	}
+	if want := "a\u0301\nb"; got != want {
+		t.Errorf("DecodeANSEL() = %q, want %q", got, want)
+	}
+}
+
+func TestDecodeANSEL_Invalid(t *testing.T) {
+	_, err := DecodeANSEL([]byte{'a', 0xAF})
+	var anselErr *ErrInvalidANSEL
+	if !errors.As(err, &anselErr) || anselErr.Byte != 0xAF {
+		t.Errorf("DecodeANSEL() error = %v, want ErrInvalidANSEL for 0xAF", err)
+	}
+}
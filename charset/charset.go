@@ -50,6 +50,8 @@ func (e *ErrInvalidUTF8) Error() string {
 // Supported encodings:
 //   - UTF-16 LE (BOM: 0xFF 0xFE) - Converted to UTF-8
 //   - UTF-16 BE (BOM: 0xFE 0xFF) - Converted to UTF-8
+//   - UTF-16 LE or BE without a BOM (detected from NUL bytes in the first
+//     line, as in some Family Tree Maker exports) - Converted to UTF-8
 //   - UTF-8 (BOM: 0xEF 0xBB 0xBF) - BOM removed, validated
 //   - ANSEL (CHAR tag: ANSEL) - Converted to UTF-8, validated
 //   - No BOM or CHAR tag - Assumed UTF-8, validated
@@ -388,21 +390,26 @@ func newUTF16Reader(r io.Reader, bigEndian bool) io.Reader {
 	return transform.NewReader(r, decoder)
 }
 
-// headerPeekSize is the number of bytes to read when looking for the CHAR tag.
-// GEDCOM headers are typically small, so 1000 bytes should be sufficient.
-const headerPeekSize = 1000
-
 // charTagPattern matches the GEDCOM CHAR tag that declares the character encoding.
 // Pattern handles both CR and LF line endings, and is case-insensitive.
 // Matches: "1 CHAR ANSEL", "1 CHAR UTF-8", "1 CHAR ASCII", etc.
 var charTagPattern = regexp.MustCompile(`(?i)[\r\n]1\s+CHAR\s+(\S+)`)
 
+// recordStartPattern matches the start of a level 0 line after the first,
+// which ends the header record.
+var recordStartPattern = regexp.MustCompile(`[\r\n][ \t]*0[ \t]`)
+
 // DetectEncodingFromHeader peeks at GEDCOM header to find the CHAR tag.
 // It returns a new reader with all bytes preserved, the detected encoding,
 // and any error encountered.
 //
-// If the CHAR tag is not found within the first headerPeekSize bytes,
-// EncodingUnknown is returned and the caller should assume UTF-8.
+// The whole header record is searched, since legacy exports (PAF, FTM)
+// often put CHAR after long SOUR, ADDR, or NOTE structures. If the header
+// has no CHAR tag, EncodingUnknown is returned and the caller should assume
+// UTF-8. UTF-16 input without a byte order mark is recognized from its
+// first characters ("0 HEAD" with a NUL byte after or before each one) and
+// returned as EncodingUTF16LE or EncodingUTF16BE whatever CHAR says, since
+// the tag cannot be read without decoding it first.
 //
 // Note: This function reads the entire remaining content to avoid issues with
 // multi-byte UTF-8 sequences being split at arbitrary boundaries.
@@ -418,10 +425,14 @@ func DetectEncodingFromHeader(r io.Reader) (io.Reader, Encoding, error) {
 		return bytes.NewReader(nil), EncodingUnknown, nil
 	}
 
-	// Search for CHAR tag in the first headerPeekSize bytes (or less)
-	searchLen := headerPeekSize
-	if len(allContent) < searchLen {
-		searchLen = len(allContent)
+	if enc := detectUTF16(allContent); enc != EncodingUnknown {
+		return bytes.NewReader(allContent), enc, nil
+	}
+
+	// Search for CHAR tag in the header record only
+	searchLen := len(allContent)
+	if loc := recordStartPattern.FindIndex(allContent); loc != nil {
+		searchLen = loc[0]
 	}
 
 	encoding := EncodingUnknown
@@ -436,7 +447,8 @@ func DetectEncodingFromHeader(r io.Reader) (io.Reader, Encoding, error) {
 		case "UTF-8":
 			encoding = EncodingUTF8
 		case "UNICODE":
-			// UNICODE typically means UTF-8 in GEDCOM context
+			// UNICODE means UTF-16, but when the header was readable
+			// as ASCII the file is actually UTF-8
 			encoding = EncodingUTF8
 		// UTF-16 is handled by BOM detection, but recognize the tag
 		case "UTF-16", "UTF-16LE":
@@ -452,6 +464,24 @@ func DetectEncodingFromHeader(r io.Reader) (io.Reader, Encoding, error) {
 	return bytes.NewReader(allContent), encoding, nil
 }
 
+// detectUTF16 recognizes UTF-16 text without a byte order mark from its
+// first two characters, which in a GEDCOM file are ASCII ("0 " or leading
+// whitespace): each is a NUL byte and an ASCII byte, in an order that gives
+// the byte order.
+func detectUTF16(b []byte) Encoding {
+	if len(b) < 4 {
+		return EncodingUnknown
+	}
+	isASCII := func(c byte) bool { return c != 0 && c < 0x80 }
+	switch {
+	case isASCII(b[0]) && b[1] == 0 && isASCII(b[2]) && b[3] == 0:
+		return EncodingUTF16LE
+	case b[0] == 0 && isASCII(b[1]) && b[2] == 0 && isASCII(b[3]):
+		return EncodingUTF16BE
+	}
+	return EncodingUnknown
+}
+
 // NewReaderWithEncoding wraps a reader with the specified encoding converter.
 // It converts the input from the given encoding to UTF-8 and validates the result.
 //
@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"unicode/utf16"
 )
 
 // errorReader returns an error on read
@@ -948,6 +949,16 @@ func TestDetectEncodingFromHeader(t *testing.T) {
 			input:        "",
 			wantEncoding: EncodingUnknown,
 		},
+		{
+			name:         "CHAR after a long header",
+			input:        "0 HEAD\n1 SOUR PAF\n" + strings.Repeat("1 NOTE a long header line of legacy export metadata\n", 40) + "1 CHAR ANSEL\n0 TRLR\n",
+			wantEncoding: EncodingANSEL,
+		},
+		{
+			name:         "CHAR outside the header is ignored",
+			input:        "0 HEAD\n1 SOUR Test\n0 @N1@ NOTE\n1 CHAR ANSEL\n0 TRLR\n",
+			wantEncoding: EncodingUnknown,
+		},
 		{
 			name:         "CHAR with CR line ending",
 			input:        "0 HEAD\r1 CHAR ANSEL\r0 TRLR\r",
@@ -1049,7 +1060,7 @@ func TestNewReaderWithEncoding(t *testing.T) {
 			// 0xE2 (acute accent) + 'e' should become 'e' + combining acute
 			input:    []byte{0xE2, 'e'},
 			encoding: EncodingANSEL,
-			want:     "e\u0301", // e + combining acute accent
+			want:     "\u00E9", // composed e with acute
 		},
 	}
 
@@ -1096,7 +1107,7 @@ func TestNewReader_ANSEL_AutoDetection(t *testing.T) {
 				[]byte("0 HEAD\n1 CHAR ANSEL\n0 @I1@ INDI\n1 NAME Ren"),
 				append([]byte{0xE2, 0x65}, []byte(" /Dubois/\n0 TRLR\n")...)...,
 			),
-			want: "0 HEAD\n1 CHAR ANSEL\n0 @I1@ INDI\n1 NAME Rene\u0301 /Dubois/\n0 TRLR\n",
+			want: "0 HEAD\n1 CHAR ANSEL\n0 @I1@ INDI\n1 NAME Ren\u00E9 /Dubois/\n0 TRLR\n",
 		},
 	}
 
@@ -1674,3 +1685,57 @@ func TestNewReader_LATIN1_AutoDetection(t *testing.T) {
 		})
 	}
 }
+
+// encodeUTF16 returns text as UTF-16 without a byte order mark.
+func encodeUTF16(text string, bigEndian bool) []byte {
+	var buf bytes.Buffer
+	for _, u := range utf16.Encode([]rune(text)) {
+		if bigEndian {
+			buf.WriteByte(byte(u >> 8))
+			buf.WriteByte(byte(u))
+		} else {
+			buf.WriteByte(byte(u))
+			buf.WriteByte(byte(u >> 8))
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestNewReader_UTF16WithoutBOM(t *testing.T) {
+	text := "0 HEAD\r\n1 CHAR UNICODE\r\n0 @I1@ INDI\r\n1 NAME José /Müller/ 𝔊\r\n0 TRLR\r\n"
+	for _, bigEndian := range []bool{false, true} {
+		got, err := io.ReadAll(NewReader(bytes.NewReader(encodeUTF16(text, bigEndian))))
+		if err != nil {
+			t.Fatalf("ReadAll(bigEndian=%v) error = %v", bigEndian, err)
+		}
+		if string(got) != text {
+			t.Errorf("NewReader(UTF-16, bigEndian=%v) = %q, want %q", bigEndian, got, text)
+		}
+	}
+}
+
+func TestDetectEncodingFromHeader_UTF16(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  Encoding
+	}{
+		{"little endian", encodeUTF16("0 HEAD\n1 CHAR UNICODE\n", false), EncodingUTF16LE},
+		{"big endian", encodeUTF16("0 HEAD\n1 CHAR UNICODE\n", true), EncodingUTF16BE},
+		{"leading whitespace", encodeUTF16(" 0 HEAD\n", false), EncodingUTF16LE},
+		{"UTF-8 UNICODE header", []byte("0 HEAD\n1 CHAR UNICODE\n"), EncodingUTF8},
+		{"too short", []byte{'0', 0}, EncodingUnknown},
+		{"non-ASCII start", []byte{0xC3, 0x00, 0xA9, 0x00}, EncodingUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got, err := DetectEncodingFromHeader(bytes.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("DetectEncodingFromHeader() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectEncodingFromHeader() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
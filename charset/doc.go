@@ -1,6 +1,8 @@
 // Package charset provides character encoding utilities for GEDCOM files.
 //
 // This package handles UTF-8 validation and Byte Order Mark (BOM) removal
-// for GEDCOM file parsing. It ensures that GEDCOM data is properly encoded
+// for GEDCOM file parsing, and converts the legacy encodings a header's
+// CHAR tag may declare (ANSEL, LATIN1, UTF-16) to UTF-8, and UTF-8 back to
+// ANSEL for legacy output. It ensures that GEDCOM data is properly encoded
 // and provides detailed error reporting for encoding issues.
 package charset
@@ -81,9 +81,9 @@ func ExampleNewReader_ansel() {
 		return
 	}
 
-	// The ANSEL combining mark has been converted to Unicode
-	// In Unicode, combining marks follow the base character
-	fmt.Printf("Contains converted name: %v\n", strings.Contains(string(content), "Rene"))
+	// The ANSEL combining mark has been moved after its base character
+	// and composed, as Unicode expects
+	fmt.Printf("Contains converted name: %v\n", strings.Contains(string(content), "René"))
 
 	// Output:
 	// Contains converted name: true
@@ -0,0 +1,277 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// inlineCitationReason explains why embedded citations are rewritten.
+const inlineCitationReason = "GEDCOM 7.0 does not allow embedded source citations; promoted to a SOUR record"
+
+// promoteInlineCitations rewrites GEDCOM 5.5/5.5.1 embedded citations
+// ("n SOUR <description>" with no pointer) into pointer citations to newly
+// created SOUR records, since GEDCOM 7.0 only allows pointers:
+//
+//	1 BIRT                          1 BIRT
+//	2 SOUR Parish register of X       2 SOUR @S3@
+//	3 TEXT Baptized 3 May       ->    3 QUAY 2
+//	3 QUAY 2                        0 @S3@ SOUR
+//	                                1 TITL Parish register of X
+//	                                1 TEXT Baptized 3 May
+//
+// The description becomes the new record's TITL and any TEXT subordinates
+// its TEXT; all other citation subordinates (PAGE, QUAY, NOTE, ...) stay on
+// the citation. Citations with the same description and text share one
+// record. Both raw tags and typed entities are rewritten.
+//
+// It must run before transformTextForVersion so the CONT lines it emits are
+// consolidated with the rest of the document.
+func promoteInlineCitations(doc *gedcom.Document, report *gedcom.ConversionReport) {
+	p := &citationPromoter{
+		doc:     doc,
+		sources: make(map[string]string),
+		used:    make(map[string]bool),
+	}
+	for xref := range doc.XRefMap {
+		p.used[strings.ToUpper(xref)] = true
+	}
+	for _, record := range doc.Records {
+		if record != nil && record.XRef != "" {
+			p.used[strings.ToUpper(record.XRef)] = true
+		}
+	}
+
+	count := 0
+	records := doc.Records // new SOUR records are appended; don't revisit them
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		if len(record.Tags) > 0 {
+			var n int
+			record.Tags, n = p.rewriteTags(record, report)
+			count += n
+			syncEntityTags(record)
+		}
+		n := p.rewriteEntity(record, len(record.Tags) == 0, report)
+		count += n
+	}
+
+	if count > 0 {
+		report.AddTransformation(gedcom.Transformation{
+			Type:        "INLINE_CITATION_PROMOTED",
+			Description: fmt.Sprintf("Promoted embedded source citations to %d SOUR record(s)", len(p.sources)),
+			Count:       count,
+		})
+	}
+}
+
+// citationPromoter creates and deduplicates SOUR records for embedded citations.
+type citationPromoter struct {
+	doc     *gedcom.Document
+	sources map[string]string // description+texts key -> new source XRef
+	used    map[string]bool   // uppercased XRefs already in the document
+	next    int
+}
+
+// sourceFor returns the XRef of the SOUR record for an embedded citation,
+// creating the record on first use.
+func (p *citationPromoter) sourceFor(description string, texts []string) string {
+	key := description + "\x00" + strings.Join(texts, "\x00")
+	if xref, ok := p.sources[key]; ok {
+		return xref
+	}
+
+	var xref string
+	for {
+		p.next++
+		xref = fmt.Sprintf("@S%d@", p.next)
+		if !p.used[xref] {
+			break
+		}
+	}
+	p.used[xref] = true
+	p.sources[key] = xref
+
+	text := strings.Join(texts, "\n")
+	tags := multilineTags(1, "TITL", description)
+	if text != "" {
+		tags = append(tags, multilineTags(1, "TEXT", text)...)
+	}
+	source := &gedcom.Source{
+		XRef:  xref,
+		Title: description,
+		Text:  text,
+		Tags:  tags,
+	}
+	record := &gedcom.Record{
+		XRef:   xref,
+		Type:   gedcom.RecordTypeSource,
+		Tags:   tags,
+		Entity: source,
+	}
+	p.doc.Records = append(p.doc.Records, record)
+	if p.doc.XRefMap == nil {
+		p.doc.XRefMap = make(map[string]*gedcom.Record)
+	}
+	p.doc.XRefMap[xref] = record
+	return xref
+}
+
+// rewriteTags replaces each embedded citation in record.Tags with a pointer
+// citation and returns the new slice and the number of citations rewritten.
+func (p *citationPromoter) rewriteTags(record *gedcom.Record, report *gedcom.ConversionReport) (rewritten []*gedcom.Tag, converted int) {
+	tags := record.Tags
+	out := make([]*gedcom.Tag, 0, len(tags))
+	count := 0
+	for i := 0; i < len(tags); i++ {
+		tag := tags[i]
+		if tag.Tag != "SOUR" || !isInlineCitationValue(tag.Value) {
+			out = append(out, tag)
+			continue
+		}
+
+		// Delimit the citation's subordinate block.
+		j := i + 1
+		for j < len(tags) && tags[j].Level > tag.Level {
+			j++
+		}
+
+		description := gedcom.FoldText(tag.Value, tag.Level, tags[i+1:j])
+		var texts []string
+		var rest []*gedcom.Tag
+		for k := i + 1; k < j; k++ {
+			sub := tags[k]
+			if sub.Level != tag.Level+1 {
+				rest = append(rest, sub)
+				continue
+			}
+			switch sub.Tag {
+			case "CONT", "CONC":
+				// Folded into description
+			case "TEXT":
+				texts = append(texts, gedcom.FoldText(sub.Value, sub.Level, tags[k+1:j]))
+				k = subtreeEnd(tags, k) - 1
+			default:
+				rest = append(rest, sub)
+			}
+		}
+
+		xref := p.sourceFor(description, texts)
+		out = append(out, &gedcom.Tag{Level: tag.Level, Tag: "SOUR", Value: xref, LineNumber: tag.LineNumber})
+		out = append(out, rest...)
+		report.AddNormalized(gedcom.ConversionNote{
+			Path:     BuildNestedPath(string(record.Type), record.XRef, "SOUR"),
+			Original: description,
+			Result:   xref,
+			Reason:   inlineCitationReason,
+		})
+		count++
+		i = j - 1
+	}
+	return out, count
+}
+
+// rewriteEntity points the typed embedded citations of record at their
+// promoted SOUR records. Notes are added to report only when addNotes is
+// set (records without raw tags, whose citations rewriteTags did not see);
+// it returns the number of notes added.
+func (p *citationPromoter) rewriteEntity(record *gedcom.Record, addNotes bool, report *gedcom.ConversionReport) int {
+	count := 0
+	forEachCitation(record.Entity, func(cite *gedcom.SourceCitation) {
+		if !cite.IsInline() {
+			return
+		}
+		xref := p.sourceFor(cite.InlineText, cite.Text)
+		if addNotes {
+			report.AddNormalized(gedcom.ConversionNote{
+				Path:     BuildNestedPath(string(record.Type), record.XRef, "SOUR"),
+				Original: cite.InlineText,
+				Result:   xref,
+				Reason:   inlineCitationReason,
+			})
+			count++
+		}
+		cite.SourceXRef = xref
+		cite.InlineText = ""
+		cite.Text = nil
+	})
+	return count
+}
+
+// isInlineCitationValue reports whether a SOUR citation value is embedded
+// text rather than a pointer (or the 7.0 @VOID@ sentinel).
+func isInlineCitationValue(value string) bool {
+	return value != "" && value != "@VOID@" && !gedcom.IsPointerXRef(value)
+}
+
+// multilineTags returns a tag carrying the first line of value, followed by
+// CONT subordinates for any further lines.
+func multilineTags(level int, tag, value string) []*gedcom.Tag {
+	lines := strings.Split(value, "\n")
+	tags := []*gedcom.Tag{{Level: level, Tag: tag, Value: lines[0]}}
+	for _, line := range lines[1:] {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "CONT", Value: line})
+	}
+	return tags
+}
+
+// syncEntityTags points the typed entity's Tags at record.Tags after the
+// raw tags were replaced, preserving the decoder's shared-slice invariant.
+func syncEntityTags(record *gedcom.Record) {
+	switch e := record.Entity.(type) {
+	case *gedcom.Individual:
+		e.Tags = record.Tags
+	case *gedcom.Family:
+		e.Tags = record.Tags
+	case *gedcom.Source:
+		e.Tags = record.Tags
+	case *gedcom.MediaObject:
+		e.Tags = record.Tags
+	case *gedcom.SharedNote:
+		e.Tags = record.Tags
+	}
+}
+
+// forEachCitation calls fn for every source citation held by a typed entity.
+func forEachCitation(entity interface{}, fn func(*gedcom.SourceCitation)) {
+	visit := func(citations []*gedcom.SourceCitation) {
+		for _, cite := range citations {
+			if cite != nil {
+				fn(cite)
+			}
+		}
+	}
+	visitEvents := func(events []*gedcom.Event) {
+		for _, e := range events {
+			if e != nil {
+				visit(e.SourceCitations)
+			}
+		}
+	}
+
+	switch e := entity.(type) {
+	case *gedcom.Individual:
+		visit(e.SourceCitations)
+		visitEvents(e.Events)
+		for _, a := range e.Attributes {
+			if a != nil {
+				visit(a.SourceCitations)
+			}
+		}
+		for _, a := range e.Associations {
+			if a != nil {
+				visit(a.SourceCitations)
+			}
+		}
+	case *gedcom.Family:
+		visit(e.SourceCitations)
+		visitEvents(e.Events)
+	case *gedcom.MediaObject:
+		visit(e.SourceCitations)
+	case *gedcom.SharedNote:
+		visit(e.SourceCitations)
+	}
+}
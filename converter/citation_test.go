@@ -0,0 +1,142 @@
+package converter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const inlineCitationGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /Doe/
+1 SOUR Family Bible of John Doe
+2 CONT held by Mary Doe
+2 TEXT John born 1850
+2 QUAY 2
+1 BIRT
+2 DATE 1850
+2 SOUR Family Bible of John Doe
+3 CONT held by Mary Doe
+3 TEXT John born 1850
+2 SOUR @S1@
+3 PAGE p. 4
+0 @F1@ FAM
+1 HUSB @I1@
+1 SOUR Parish register
+0 @S1@ SOUR
+1 TITL Census
+0 TRLR
+`
+
+func TestPromoteInlineCitations(t *testing.T) {
+	doc, err := decoder.Decode(strings.NewReader(inlineCitationGEDCOM))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	converted, report, err := Convert(doc, gedcom.Version70)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	// Input is untouched.
+	if !doc.GetIndividual("@I1@").SourceCitations[0].IsInline() {
+		t.Error("Convert mutated the input document")
+	}
+
+	indi := converted.GetIndividual("@I1@")
+	cite := indi.SourceCitations[0]
+	if cite.IsInline() || cite.SourceXRef == "" || cite.Quality != 2 {
+		t.Fatalf("individual citation = %+v, want promoted pointer with QUAY 2", cite)
+	}
+	if got := indi.BirthEvent().SourceCitations[0].SourceXRef; got != cite.SourceXRef {
+		t.Errorf("identical embedded citations should share a source: %s vs %s", got, cite.SourceXRef)
+	}
+	if got := indi.BirthEvent().SourceCitations[1].SourceXRef; got != "@S1@" {
+		t.Errorf("pointer citation changed to %s", got)
+	}
+
+	src := converted.GetSource(cite.SourceXRef)
+	if src == nil {
+		t.Fatalf("promoted source %s not found", cite.SourceXRef)
+	}
+	if src.Title != "Family Bible of John Doe\nheld by Mary Doe" || src.Text != "John born 1850" {
+		t.Errorf("promoted source = %+v", src)
+	}
+	if cite.SourceXRef == "@S1@" {
+		t.Error("promoted source reused an existing XRef")
+	}
+
+	famCite := converted.GetFamily("@F1@").SourceCitations[0]
+	if famCite.IsInline() || famCite.SourceXRef == cite.SourceXRef {
+		t.Errorf("family citation = %+v, want its own promoted source", famCite)
+	}
+
+	if len(converted.Sources()) != 3 {
+		t.Errorf("sources = %d, want 3 (S1 plus two promoted)", len(converted.Sources()))
+	}
+	found := false
+	for _, tr := range report.Transformations {
+		if tr.Type == "INLINE_CITATION_PROMOTED" {
+			found = tr.Count == 3
+		}
+	}
+	if !found {
+		t.Errorf("report missing INLINE_CITATION_PROMOTED with count 3: %+v", report.Transformations)
+	}
+
+	var buf bytes.Buffer
+	opts := encoder.DefaultOptions()
+	opts.TargetVersion = gedcom.Version70
+	if err := encoder.EncodeWithOptions(&buf, converted, opts); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "SOUR Family Bible") || strings.Contains(out, "SOUR Parish") {
+		t.Errorf("encoded 7.0 output still has embedded citations:\n%s", out)
+	}
+	if !strings.Contains(out, "0 "+cite.SourceXRef+" SOUR\n1 TITL Family Bible of John Doe") {
+		t.Errorf("encoded output missing promoted source title:\n%s", out)
+	}
+}
+
+func TestPromoteInlineCitations_EntityOnly(t *testing.T) {
+	doc := &gedcom.Document{
+		Header:  &gedcom.Header{Version: gedcom.Version551},
+		XRefMap: map[string]*gedcom.Record{},
+	}
+	rec := &gedcom.Record{
+		XRef: "@I1@",
+		Type: gedcom.RecordTypeIndividual,
+		Entity: &gedcom.Individual{
+			XRef: "@I1@",
+			SourceCitations: []*gedcom.SourceCitation{
+				{InlineText: "Letter from Aunt May", Text: []string{"dated 1901"}},
+			},
+		},
+	}
+	doc.Records = append(doc.Records, rec)
+	doc.XRefMap["@I1@"] = rec
+
+	converted, report, err := Convert(doc, gedcom.Version70)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	cite := converted.GetIndividual("@I1@").SourceCitations[0]
+	if cite.SourceXRef != "@S1@" || cite.InlineText != "" || cite.Text != nil {
+		t.Errorf("citation = %+v, want pointer to @S1@", cite)
+	}
+	if src := converted.GetSource("@S1@"); src == nil || src.Text != "dated 1901" {
+		t.Errorf("promoted source = %+v", src)
+	}
+	if len(report.Normalized) == 0 {
+		t.Error("entity-only promotion should be reported")
+	}
+}
@@ -79,7 +79,7 @@ func ConvertWithOptions(doc *gedcom.Document, targetVersion gedcom.Version, opts
 	}
 
 	// Check for data loss in strict mode
-	if opts.StrictDataLoss && report.HasDataLoss() {
+	if opts.strictDataLoss() && report.HasDataLoss() {
 		report.Success = false
 		return nil, report, fmt.Errorf("conversion would result in data loss (strict mode enabled)")
 	}
@@ -116,9 +116,14 @@ func convert55To551(doc *gedcom.Document, report *gedcom.ConversionReport, opts
 //
 //nolint:unparam // error return kept for API consistency with other converters
 func convert55To70(doc *gedcom.Document, report *gedcom.ConversionReport, opts *ConvertOptions) error {
+	promoteInlineCitations(doc, report)
+	dropSubmissions(doc, report)
 	transformTextForVersion(doc, gedcom.Version70, report)
+	transformRemovedTags(doc, report)
+	transformInterpretedDates(doc, gedcom.Version70, report)
 	normalizeXRefsToUppercase(doc, report)
 	transformMediaTypes(doc, gedcom.Version70, report)
+	transformLanguages(doc, gedcom.Version70, report)
 	transformHeader(doc, gedcom.Version70, report)
 	if opts.PreserveUnknownTags {
 		recordPreservedUnknownTags(doc, report)
@@ -152,9 +157,14 @@ func convert551To55(doc *gedcom.Document, report *gedcom.ConversionReport, opts
 //
 //nolint:unparam // error return kept for API consistency with other converters
 func convert551To70(doc *gedcom.Document, report *gedcom.ConversionReport, opts *ConvertOptions) error {
+	promoteInlineCitations(doc, report)
+	dropSubmissions(doc, report)
 	transformTextForVersion(doc, gedcom.Version70, report)
+	transformRemovedTags(doc, report)
+	transformInterpretedDates(doc, gedcom.Version70, report)
 	normalizeXRefsToUppercase(doc, report)
 	transformMediaTypes(doc, gedcom.Version70, report)
+	transformLanguages(doc, gedcom.Version70, report)
 	transformHeader(doc, gedcom.Version70, report)
 	if opts.PreserveUnknownTags {
 		recordPreservedUnknownTags(doc, report)
@@ -175,7 +185,9 @@ func convert70To55(doc *gedcom.Document, report *gedcom.ConversionReport, opts *
 		transformEXIDToVendorTags(doc, report, gedcom.Version55)
 	}
 	transformTextForVersion(doc, gedcom.Version55, report)
+	transformInterpretedDates(doc, gedcom.Version55, report)
 	transformMediaTypes(doc, gedcom.Version55, report)
+	transformLanguages(doc, gedcom.Version55, report)
 	transformHeader(doc, gedcom.Version55, report)
 	record70DataLoss(doc, report, gedcom.Version55)
 	if opts.PreserveUnknownTags {
@@ -197,7 +209,9 @@ func convert70To551(doc *gedcom.Document, report *gedcom.ConversionReport, opts
 		transformEXIDToVendorTags(doc, report, gedcom.Version551)
 	}
 	transformTextForVersion(doc, gedcom.Version551, report)
+	transformInterpretedDates(doc, gedcom.Version551, report)
 	transformMediaTypes(doc, gedcom.Version551, report)
+	transformLanguages(doc, gedcom.Version551, report)
 	transformHeader(doc, gedcom.Version551, report)
 	record70DataLoss(doc, report, gedcom.Version551)
 	if opts.PreserveUnknownTags {
@@ -254,6 +268,51 @@ func record551Tags(doc *gedcom.Document, report *gedcom.ConversionReport) {
 	}
 }
 
+// dropSubmissions removes the SUBN submission records, and the header's
+// SUBN link to them, that GEDCOM 7.0 removed along with the TempleReady
+// submission process they served.
+func dropSubmissions(doc *gedcom.Document, report *gedcom.ConversionReport) {
+	const reason = "SUBN submission records were removed in GEDCOM 7.0"
+	var xrefs []string
+	kept := doc.Records[:0]
+	for _, record := range doc.Records {
+		if record != nil && record.Type == "SUBN" {
+			xrefs = append(xrefs, record.XRef)
+			report.AddDropped(gedcom.ConversionNote{
+				Path:     BuildRecordPath("SUBN", record.XRef),
+				Original: "SUBN",
+				Reason:   reason,
+			})
+			continue
+		}
+		kept = append(kept, record)
+	}
+	if len(xrefs) == 0 {
+		return
+	}
+	doc.Records = kept
+	doc.Reindex()
+
+	if doc.Header != nil {
+		var tags []*gedcom.Tag
+		skip := false
+		for _, tag := range doc.Header.Tags {
+			if tag.Level <= 1 {
+				skip = tag.Tag == "SUBN"
+			}
+			if !skip {
+				tags = append(tags, tag)
+			}
+		}
+		doc.Header.Tags = tags
+	}
+	report.AddDataLoss(gedcom.DataLossItem{
+		Feature:         "SUBN records",
+		Reason:          reason,
+		AffectedRecords: xrefs,
+	})
+}
+
 // record70DataLoss records data loss for GEDCOM 7.0-specific features.
 func record70DataLoss(doc *gedcom.Document, report *gedcom.ConversionReport, targetVersion gedcom.Version) {
 	tags70 := []string{"EXID", "NO", "TRAN", "PHRASE", "UID", "CREA", "SNOTE"}
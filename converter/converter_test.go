@@ -1,8 +1,14 @@
 package converter
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/encoder"
 	"github.com/cacack/gedcom-go/v2/gedcom"
 )
 
@@ -193,6 +199,29 @@ func TestConvertWithOptions(t *testing.T) {
 		}
 	})
 
+	t.Run("StrictnessStrict fails on data loss", func(t *testing.T) {
+		doc := &gedcom.Document{
+			Header: &gedcom.Header{Version: gedcom.Version70},
+			Records: []*gedcom.Record{
+				{
+					XRef: "@I1@",
+					Tags: []*gedcom.Tag{
+						{Level: 0, Tag: "INDI"},
+						{Level: 1, Tag: "EXID", Value: "external-id"},
+					},
+				},
+			},
+		}
+		_, _, err := ConvertWithOptions(doc, gedcom.Version55, &ConvertOptions{Strictness: gedcom.StrictnessNormal})
+		if err != nil {
+			t.Errorf("ConvertWithOptions(StrictnessNormal) error = %v", err)
+		}
+		_, report, err := ConvertWithOptions(doc, gedcom.Version55, &ConvertOptions{Strictness: gedcom.StrictnessStrict})
+		if err == nil || report == nil || report.Success {
+			t.Errorf("ConvertWithOptions(StrictnessStrict) = %v, %v, want a failed report and error", report, err)
+		}
+	})
+
 	t.Run("Validate option runs validation", func(t *testing.T) {
 		doc := &gedcom.Document{
 			Header:  &gedcom.Header{Version: gedcom.Version55},
@@ -400,6 +429,39 @@ func TestRecord70DataLoss(t *testing.T) {
 	}
 }
 
+func TestDropSubmissions(t *testing.T) {
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version551, Tags: []*gedcom.Tag{
+			{Level: 1, Tag: "SUBN", Value: "@SUB1@"},
+			{Level: 1, Tag: "SOUR", Value: "App"},
+		}},
+		Records: []*gedcom.Record{
+			{XRef: "@I1@", Type: gedcom.RecordTypeIndividual},
+			{XRef: "@SUB1@", Type: "SUBN", Tags: []*gedcom.Tag{{Level: 1, Tag: "FAMF", Value: "family.ged"}}},
+		},
+	}
+
+	for _, from := range []gedcom.Version{gedcom.Version55, gedcom.Version551} {
+		doc.Header.Version = from
+		converted, report, err := Convert(doc, gedcom.Version70)
+		if err != nil {
+			t.Fatalf("Convert(%s) error = %v", from, err)
+		}
+		if len(converted.Records) != 1 || converted.GetRecord("@SUB1@") != nil {
+			t.Errorf("Convert(%s) kept the SUBN record", from)
+		}
+		if len(converted.Header.Tags) != 1 || converted.Header.Tags[0].Tag != "SOUR" {
+			t.Errorf("Convert(%s) header tags = %+v", from, converted.Header.Tags)
+		}
+		if !report.HasDataLoss() || len(report.Dropped) == 0 || report.Dropped[0].Path != BuildRecordPath("SUBN", "@SUB1@") {
+			t.Errorf("Convert(%s) report = %+v", from, report)
+		}
+	}
+	if len(doc.Records) != 2 {
+		t.Error("Convert() modified the original document")
+	}
+}
+
 func TestCountTagsInRecord(t *testing.T) {
 	tags := []*gedcom.Tag{
 		{Tag: "NAME"},
@@ -998,3 +1060,48 @@ func TestConversionNotes_NewlinesToCONT(t *testing.T) {
 		t.Error("expected normalized note for newline-to-CONT expansion")
 	}
 }
+
+// TestConvertThenEncodeTestdata converts every testdata file to each other
+// version and encodes the result with the default options, which fail on
+// anything the target version cannot hold, such as tags 7.0 removed.
+func TestConvertThenEncodeTestdata(t *testing.T) {
+	var files []string
+	err := filepath.WalkDir(filepath.Join("..", "testdata"), func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.HasSuffix(path, ".ged") {
+			files = append(files, path)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("no testdata files found")
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc, err := decoder.Decode(bytes.NewReader(data))
+		if err != nil {
+			continue // malformed-input fixtures
+		}
+		for _, target := range []gedcom.Version{gedcom.Version55, gedcom.Version551, gedcom.Version70} {
+			if doc.Header != nil && doc.Header.Version == target {
+				continue
+			}
+			name, _ := filepath.Rel(filepath.Join("..", "testdata"), file)
+			t.Run(name+"->"+string(target), func(t *testing.T) {
+				converted, _, err := Convert(doc, target)
+				if err != nil {
+					t.Fatalf("Convert() error = %v", err)
+				}
+				if err := encoder.Encode(&bytes.Buffer{}, converted); err != nil {
+					t.Errorf("Encode() error = %v", err)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// transformInterpretedDates maps interpreted dates between versions.
+// GEDCOM 5.5.x keeps the phrase a date was interpreted from in the value
+// ("INT 1850 (from age at census)"); 7.0 has no INT and puts the phrase in
+// a PHRASE substructure of DATE. Upgrading splits the value; downgrading
+// joins a PHRASE under a plain date back into an INT date, and a PHRASE
+// under an empty DATE into a date phrase. Events of typed entities that
+// held the old value are updated to match. Other PHRASE substructures are
+// left for record70DataLoss to report.
+func transformInterpretedDates(doc *gedcom.Document, targetVersion gedcom.Version, report *gedcom.ConversionReport) {
+	m := &interpretedDateMapper{target: targetVersion, report: report}
+	for _, record := range doc.Records {
+		if record != nil && len(record.Tags) > 0 {
+			m.mapRecord(record)
+		}
+	}
+
+	if m.count > 0 {
+		description := "Moved INT date phrases to DATE.PHRASE"
+		if targetVersion != gedcom.Version70 {
+			description = "Moved DATE.PHRASE into INT dates and date phrases"
+		}
+		report.AddTransformation(gedcom.Transformation{
+			Type:        "INTERPRETED_DATE_MAPPED",
+			Description: description,
+			Count:       m.count,
+		})
+	}
+}
+
+// interpretedDateMapper maps the interpreted dates of one document and
+// records each change in the report.
+type interpretedDateMapper struct {
+	target gedcom.Version
+	report *gedcom.ConversionReport
+	count  int
+}
+
+// mapRecord maps the DATE values of record's raw tags, and the typed
+// events that held them.
+func (m *interpretedDateMapper) mapRecord(record *gedcom.Record) {
+	base := BuildRecordPath(string(record.Type), record.XRef)
+	result := make([]*gedcom.Tag, 0, len(record.Tags))
+	skip := make(map[int]bool)
+	changed := make(map[string]dateChange) // keyed by the old DATE value
+	var parents []string
+	for i, tag := range record.Tags {
+		if skip[i] {
+			continue
+		}
+		result = append(result, tag)
+		if tag == nil || tag.Level < 1 {
+			continue
+		}
+		parents = tagPath(parents, tag)
+		if tag.Tag != "DATE" {
+			continue
+		}
+
+		original := tag.Value
+		var phrase string
+		if m.target == gedcom.Version70 {
+			var ok bool
+			if phrase, ok = splitDateTag(record.Tags, i); !ok {
+				continue
+			}
+			if phrase != "" {
+				result = append(result, &gedcom.Tag{Level: tag.Level + 1, Tag: "PHRASE", Value: phrase})
+			}
+		} else {
+			phraseIdx := joinDateTag(record.Tags, i)
+			if phraseIdx < 0 {
+				continue
+			}
+			skip[phraseIdx] = true
+		}
+
+		changed[original] = dateChange{value: tag.Value, phrase: phrase}
+		m.note(BuildPath(append([]string{base}, parents...)...), original, tag.Value)
+	}
+	if len(changed) == 0 {
+		return
+	}
+	record.Tags = result
+	syncEntityTags(record)
+	syncEventDates(record.Entity, changed)
+}
+
+// note counts and reports the change of a DATE at path.
+func (m *interpretedDateMapper) note(path, original, result string) {
+	m.count++
+	reason := "GEDCOM 7.0 has no INT dates; the interpretation phrase moved to DATE.PHRASE"
+	if m.target != gedcom.Version70 {
+		reason = "GEDCOM " + m.target.String() + " has no DATE.PHRASE; the phrase moved into the date value"
+	}
+	m.report.AddNormalized(gedcom.ConversionNote{
+		Path:     path,
+		Original: original,
+		Result:   result,
+		Reason:   reason,
+	})
+}
+
+// splitDateTag splits the interpreted date tags[i] without a PHRASE into a
+// plain date and returns its phrase, reporting whether it changed.
+func splitDateTag(tags []*gedcom.Tag, i int) (string, bool) {
+	value, phrase, ok := splitInterpretedDate(tags[i].Value)
+	if !ok || childIndex(tags, i, "PHRASE") >= 0 {
+		return "", false
+	}
+	tags[i].Value = value
+	return phrase, true
+}
+
+// joinDateTag joins the PHRASE of the date tags[i] into its value and
+// returns the index of the PHRASE, or -1 if it did not change.
+func joinDateTag(tags []*gedcom.Tag, i int) int {
+	phraseIdx := childIndex(tags, i, "PHRASE")
+	if phraseIdx < 0 {
+		return -1
+	}
+	value, ok := joinInterpretedDate(tags[i].Value, tags[phraseIdx].Value)
+	if !ok {
+		return -1
+	}
+	tags[i].Value = value
+	return phraseIdx
+}
+
+// childIndex returns the index of the first direct child of tags[parent]
+// with the given tag, or -1.
+func childIndex(tags []*gedcom.Tag, parent int, name string) int {
+	level := tags[parent].Level
+	for i := parent + 1; i < len(tags); i++ {
+		if tags[i] == nil {
+			continue
+		}
+		if tags[i].Level <= level {
+			break
+		}
+		if tags[i].Level == level+1 && tags[i].Tag == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitInterpretedDate splits a 5.5.x interpreted date into the date
+// without INT, keeping any calendar escape, and its phrase:
+// "INT 1850 (from age)" gives "1850" and "from age". ok is false if value
+// is not an interpreted date.
+func splitInterpretedDate(value string) (date, phrase string, ok bool) {
+	parsed, err := gedcom.ParseDate(value)
+	if err != nil || parsed.Modifier != gedcom.ModifierInterpreted {
+		return "", "", false
+	}
+	if i := strings.Index(value, "("); i >= 0 {
+		value = value[:i]
+	}
+	fields := strings.Fields(value)
+	for i, field := range fields {
+		if strings.EqualFold(field, "INT") {
+			fields = append(fields[:i], fields[i+1:]...)
+			break
+		}
+	}
+	return strings.Join(fields, " "), parsed.InterpretedFrom, true
+}
+
+// joinInterpretedDate combines a 7.0 date and its PHRASE into a 5.5.x
+// value: an INT date for a plain date, or a date phrase for an empty one.
+// ok is false for dates with other modifiers, ranges, or periods, whose
+// phrase 5.5.x cannot hold.
+func joinInterpretedDate(value, phrase string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "(" + phrase + ")", true
+	}
+	parsed, err := gedcom.ParseDate(value)
+	if err != nil || parsed.IsPhrase || parsed.Modifier != gedcom.ModifierNone {
+		return "", false
+	}
+	return "INT " + value + " (" + phrase + ")", true
+}
+
+// dateChange is a rewritten DATE value and the PHRASE added under it.
+type dateChange struct {
+	value, phrase string
+}
+
+// syncEventDates updates the events of an individual or family whose Date
+// is a key of changed, re-parsing ParsedDate. An added PHRASE is set as
+// ParsedDate.Phrase, as the decoder does for 7.0 dates.
+func syncEventDates(entity interface{}, changed map[string]dateChange) {
+	var events []*gedcom.Event
+	switch e := entity.(type) {
+	case *gedcom.Individual:
+		events = e.Events
+	case *gedcom.Family:
+		events = e.Events
+	}
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+		change, ok := changed[event.Date]
+		if !ok {
+			continue
+		}
+		event.Date = change.value
+		event.ParsedDate = nil
+		if parsed, err := gedcom.ParseDate(change.value); err == nil {
+			parsed.Phrase = change.phrase
+			event.ParsedDate = parsed
+		}
+	}
+}
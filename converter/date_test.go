@@ -0,0 +1,157 @@
+package converter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func TestSplitInterpretedDate(t *testing.T) {
+	tests := []struct {
+		value      string
+		wantDate   string
+		wantPhrase string
+		wantOK     bool
+	}{
+		{"INT 1850 (from age at census)", "1850", "from age at census", true},
+		{"int 25 DEC 1850 (Christmas)", "25 DEC 1850", "Christmas", true},
+		{"@#DJULIAN@ INT 1750 (x)", "@#DJULIAN@ 1750", "x", true},
+		{"INT 1850", "1850", "", true},
+		{"ABT 1850", "", "", false},
+		{"(unknown)", "", "", false},
+	}
+	for _, tt := range tests {
+		date, phrase, ok := splitInterpretedDate(tt.value)
+		if date != tt.wantDate || phrase != tt.wantPhrase || ok != tt.wantOK {
+			t.Errorf("splitInterpretedDate(%q) = %q, %q, %v; want %q, %q, %v",
+				tt.value, date, phrase, ok, tt.wantDate, tt.wantPhrase, tt.wantOK)
+		}
+	}
+}
+
+func TestJoinInterpretedDate(t *testing.T) {
+	tests := []struct {
+		value, phrase string
+		want          string
+		wantOK        bool
+	}{
+		{"1850", "from age at census", "INT 1850 (from age at census)", true},
+		{"", "sometime in spring", "(sometime in spring)", true},
+		{"ABT 1850", "about then", "", false},
+		{"BET 1850 AND 1860", "in the fifties", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := joinInterpretedDate(tt.value, tt.phrase)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("joinInterpretedDate(%q, %q) = %q, %v; want %q, %v", tt.value, tt.phrase, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestConvert_InterpretedDatesUpgrade(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE INT 1850 (from age at census)
+1 DEAT
+2 DATE ABT 1900
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	converted, report, err := Convert(doc, gedcom.Version70)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, converted); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if want := "2 DATE 1850\n3 PHRASE from age at census\n1 DEAT\n2 DATE ABT 1900\n"; !strings.Contains(buf.String(), want) {
+		t.Errorf("encoded output:\n%s\nwant it to contain\n%s", buf.String(), want)
+	}
+
+	ind, _ := converted.GetRecord("@I1@").GetIndividual()
+	birth := ind.Events[0]
+	if birth.Date != "1850" || birth.ParsedDate == nil || birth.ParsedDate.Phrase != "from age at census" {
+		t.Errorf("birth Date = %q, ParsedDate = %+v", birth.Date, birth.ParsedDate)
+	}
+	if !hasTransformation(report, "INTERPRETED_DATE_MAPPED") {
+		t.Error("report has no INTERPRETED_DATE_MAPPED transformation")
+	}
+	if len(report.Normalized) == 0 || report.Normalized[0].Path != "Individual @I1@ > BIRT > DATE" {
+		t.Errorf("Normalized = %+v, want a note at BIRT > DATE", report.Normalized)
+	}
+}
+
+func TestConvert_InterpretedDatesDowngrade(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 BIRT
+2 DATE 1850
+3 PHRASE from age at census
+1 BAPM
+2 DATE
+3 PHRASE spring, before the flood
+1 DEAT
+2 DATE ABT 1900
+3 PHRASE around the turn of the century
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	converted, report, err := Convert(doc, gedcom.Version551)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	var dates []string
+	phrases := 0
+	for _, tag := range converted.GetRecord("@I1@").Tags {
+		switch tag.Tag {
+		case "DATE":
+			dates = append(dates, tag.Value)
+		case "PHRASE":
+			phrases++
+		}
+	}
+	want := "INT 1850 (from age at census)|(spring, before the flood)|ABT 1900"
+	if got := strings.Join(dates, "|"); got != want {
+		t.Errorf("DATE values = %q, want %q", got, want)
+	}
+	if phrases != 1 {
+		t.Errorf("%d PHRASE tags left, want only the one under ABT 1900", phrases)
+	}
+
+	ind, _ := converted.GetRecord("@I1@").GetIndividual()
+	if d := ind.Events[0].ParsedDate; d == nil || !d.IsInterpreted || d.InterpretedFrom != "from age at census" {
+		t.Errorf("birth ParsedDate = %+v, want interpreted from the phrase", d)
+	}
+	if len(report.DataLoss) == 0 {
+		t.Error("the PHRASE under ABT 1900 should be reported as data loss")
+	}
+}
+
+func hasTransformation(report *gedcom.ConversionReport, kind string) bool {
+	for _, tr := range report.Transformations {
+		if tr.Type == kind {
+			return true
+		}
+	}
+	return false
+}
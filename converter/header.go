@@ -42,7 +42,8 @@ func upgradeHeaderTo70(header *gedcom.Header, report *gedcom.ConversionReport) {
 	}
 
 	// Note: SCHMA is only required if there are extension tags.
-	// Future enhancement could analyze for custom tags and add SCHMA as needed.
+	// transforms.DeclareExtensions adds the declarations a converted
+	// document is missing.
 	_ = hasSCHMA // Used in potential future enhancements
 }
 
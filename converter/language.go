@@ -0,0 +1,188 @@
+package converter
+
+import (
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// languageOutcome classifies how a LANG value was converted.
+type languageOutcome int
+
+const (
+	languageUnchanged languageOutcome = iota
+	languageMapped
+	languageApproximated
+	languageUnmappable
+)
+
+// convertLanguage converts one LANG value for the target version: 5.5
+// language names become BCP 47 tags for 7.0 (gedcom.NormalizeLanguage), and
+// tags become 5.5 names for 5.5/5.5.1 (gedcom.LanguageName), approximated
+// by the primary language when the tag is more specific. Unmappable values
+// are returned unchanged.
+func convertLanguage(value string, targetVersion gedcom.Version) (string, languageOutcome) {
+	if value == "" {
+		return value, languageUnchanged
+	}
+	if targetVersion == gedcom.Version70 {
+		tag, ok := gedcom.NormalizeLanguage(value)
+		switch {
+		case !ok:
+			return value, languageUnmappable
+		case tag == value:
+			return value, languageUnchanged
+		}
+		return tag, languageMapped
+	}
+
+	if _, ok := gedcom.LanguageTag(value); ok {
+		return value, languageUnchanged // already a 5.5 name
+	}
+	name, exact, ok := gedcom.LanguageName(value)
+	switch {
+	case !ok:
+		return value, languageUnmappable
+	case !exact:
+		return name, languageApproximated
+	}
+	return name, languageMapped
+}
+
+// languageConverter converts the LANG values of one document and records
+// each change in the report.
+type languageConverter struct {
+	targetVersion gedcom.Version
+	report        *gedcom.ConversionReport
+	count         int
+}
+
+// convert converts value, recording the outcome under path when report is
+// true, and returns the new value.
+func (c *languageConverter) convert(value, path string, report bool) string {
+	result, outcome := convertLanguage(value, c.targetVersion)
+	if !report {
+		return result
+	}
+	note := gedcom.ConversionNote{Path: path, Original: value, Result: result}
+	switch outcome {
+	case languageMapped:
+		c.count++
+		if c.targetVersion == gedcom.Version70 {
+			note.Reason = "Language name mapped to a BCP 47 tag for GEDCOM 7.0"
+		} else {
+			note.Reason = "BCP 47 language tag mapped to a language name for GEDCOM " + c.targetVersion.String()
+		}
+		c.report.AddNormalized(note)
+	case languageApproximated:
+		c.count++
+		note.Reason = "GEDCOM " + c.targetVersion.String() + " has no language name for the region, script, or variant; mapped to the primary language"
+		c.report.AddApproximated(note)
+	case languageUnmappable:
+		if c.targetVersion == gedcom.Version70 {
+			note.Reason = "Not a BCP 47 language tag or a known language name; kept unchanged but invalid in GEDCOM 7.0"
+		} else {
+			note.Reason = "No GEDCOM " + c.targetVersion.String() + " language name for this tag; kept unchanged"
+		}
+		c.report.AddPreserved(note)
+	}
+	return result
+}
+
+// convertTags converts the LANG tags in tags, whose paths start at base.
+func (c *languageConverter) convertTags(tags []*gedcom.Tag, base string) {
+	var parents []string
+	for _, tag := range tags {
+		if tag == nil || tag.Level < 1 {
+			continue
+		}
+		if len(parents) >= tag.Level {
+			parents = parents[:tag.Level-1]
+		}
+		for len(parents) < tag.Level-1 {
+			parents = append(parents, "")
+		}
+		parents = append(parents, tag.Tag)
+		if tag.Tag == "LANG" {
+			tag.Value = c.convert(tag.Value, BuildPath(append([]string{base}, parents...)...), true)
+		}
+	}
+}
+
+// transformLanguages converts LANG values between 5.5 language names and
+// 7.0 BCP 47 tags: every LANG tag of the header and records, and the parsed
+// HEAD.LANG, SUBM.LANG, shared note (and translation) LANG, and
+// NAME.TRAN.LANG. Changes are reported from the raw tags, or from the
+// entities of records built without them. Values that cannot be mapped are
+// kept and listed as Preserved.
+func transformLanguages(doc *gedcom.Document, targetVersion gedcom.Version, report *gedcom.ConversionReport) {
+	c := &languageConverter{targetVersion: targetVersion, report: report}
+	if doc.Header != nil {
+		c.convertHeader(doc.Header)
+	}
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		c.convertTags(record.Tags, BuildRecordPath(string(record.Type), record.XRef))
+		c.convertEntity(record)
+	}
+
+	if c.count > 0 {
+		description := "Mapped language names to BCP 47 tags"
+		if targetVersion != gedcom.Version70 {
+			description = "Mapped BCP 47 language tags to language names"
+		}
+		report.AddTransformation(gedcom.Transformation{
+			Type:        "LANGUAGE_MAPPED",
+			Description: description,
+			Count:       c.count,
+		})
+	}
+}
+
+// convertHeader converts the LANG tags of header and its parsed Language,
+// reporting the latter only when the header has no LANG tag.
+func (c *languageConverter) convertHeader(header *gedcom.Header) {
+	fromTags := false
+	for _, tag := range header.Tags {
+		if tag != nil && tag.Level == 1 && tag.Tag == "LANG" {
+			fromTags = true
+		}
+	}
+	c.convertTags(header.Tags, BuildRecordPath("HEAD", ""))
+	header.Language = c.convert(header.Language, BuildNestedPath("HEAD", "", "LANG"), !fromTags)
+}
+
+// convertEntity converts the parsed languages of record's entity,
+// reporting them only when the record has no raw tags.
+func (c *languageConverter) convertEntity(record *gedcom.Record) {
+	base := BuildRecordPath(string(record.Type), record.XRef)
+	fromEntity := len(record.Tags) == 0
+	convert := func(value string, tags ...string) string {
+		return c.convert(value, BuildPath(append([]string{base}, tags...)...), fromEntity)
+	}
+
+	switch entity := record.Entity.(type) {
+	case *gedcom.Submitter:
+		for i, lang := range entity.Language {
+			entity.Language[i] = convert(lang, "LANG")
+		}
+	case *gedcom.SharedNote:
+		entity.Language = convert(entity.Language, "LANG")
+		for _, tran := range entity.Translations {
+			if tran != nil {
+				tran.Language = convert(tran.Language, "TRAN", "LANG")
+			}
+		}
+	case *gedcom.Individual:
+		for _, name := range entity.Names {
+			if name == nil {
+				continue
+			}
+			for _, tran := range name.Transliterations {
+				if tran != nil {
+					tran.Language = convert(tran.Language, "NAME", "TRAN", "LANG")
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func TestConvertLanguage(t *testing.T) {
+	tests := []struct {
+		value   string
+		target  gedcom.Version
+		want    string
+		outcome languageOutcome
+	}{
+		{"English", gedcom.Version70, "en", languageMapped},
+		{"Catalan_Spn", gedcom.Version70, "ca-ES", languageMapped},
+		{"en_us", gedcom.Version70, "en-US", languageMapped},
+		{"en", gedcom.Version70, "en", languageUnchanged},
+		{"Klingon", gedcom.Version70, "Klingon", languageUnmappable},
+		{"de", gedcom.Version551, "German", languageMapped},
+		{"ca-ES", gedcom.Version55, "Catalan_Spn", languageMapped},
+		{"en-GB", gedcom.Version551, "English", languageApproximated},
+		{"English", gedcom.Version551, "English", languageUnchanged},
+		{"zh-Hans", gedcom.Version551, "zh-Hans", languageUnmappable},
+		{"", gedcom.Version551, "", languageUnchanged},
+	}
+	for _, tt := range tests {
+		got, outcome := convertLanguage(tt.value, tt.target)
+		if got != tt.want || outcome != tt.outcome {
+			t.Errorf("convertLanguage(%q, %s) = %q, %v; want %q, %v", tt.value, tt.target, got, outcome, tt.want, tt.outcome)
+		}
+	}
+}
+
+func TestConvert_LanguagesUpgrade(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 LANG English
+1 SUBM @U1@
+0 @U1@ SUBM
+1 NAME Ann Archivist
+1 LANG German
+1 LANG Klingon
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	converted, report, err := Convert(doc, gedcom.Version70)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	if converted.Header.Language != "en" {
+		t.Errorf("Header.Language = %q, want en", converted.Header.Language)
+	}
+	subm, _ := converted.GetRecord("@U1@").GetSubmitter()
+	if got := strings.Join(subm.Language, ","); got != "de,Klingon" {
+		t.Errorf("Submitter.Language = %q", got)
+	}
+	var langs []string
+	for _, tag := range converted.GetRecord("@U1@").Tags {
+		if tag.Tag == "LANG" {
+			langs = append(langs, tag.Value)
+		}
+	}
+	if got := strings.Join(langs, ","); got != "de,Klingon" {
+		t.Errorf("SUBM LANG tags = %q", got)
+	}
+
+	var normalized []string
+	for _, n := range report.Normalized {
+		if strings.HasSuffix(n.Path, "LANG") {
+			normalized = append(normalized, n.Original+"->"+n.Result)
+		}
+	}
+	if got := strings.Join(normalized, ","); got != "English->en,German->de" {
+		t.Errorf("Normalized LANG notes = %q", got)
+	}
+	if len(report.Preserved) == 0 || report.Preserved[len(report.Preserved)-1].Original != "Klingon" {
+		t.Errorf("Preserved = %+v, want the unmappable Klingon", report.Preserved)
+	}
+	if doc.Header.Language != "English" {
+		t.Error("Convert() modified the input document")
+	}
+}
+
+func TestConvert_LanguagesDowngrade(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+1 LANG en-GB
+0 @I1@ INDI
+1 NAME Ivan /Petrov/
+2 TRAN Иван /Петров/
+3 LANG ru
+0 @N1@ SNOTE Text
+1 LANG zh-Hans
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	converted, report, err := Convert(doc, gedcom.Version551)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if converted.Header.Language != "English" {
+		t.Errorf("Header.Language = %q, want English", converted.Header.Language)
+	}
+	ind, _ := converted.GetRecord("@I1@").GetIndividual()
+	if got := ind.Names[0].Transliterations[0].Language; got != "Russian" {
+		t.Errorf("TRAN language = %q, want Russian", got)
+	}
+
+	if len(report.Approximated) == 0 {
+		t.Error("en-GB -> English should be reported as approximated")
+	}
+	found := false
+	for _, n := range report.Preserved {
+		if n.Original == "zh-Hans" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Preserved = %+v, want the unmappable zh-Hans", report.Preserved)
+	}
+}
+
+func TestTransformLanguages_EntityOnly(t *testing.T) {
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Language: "French"},
+		Records: []*gedcom.Record{{
+			XRef:   "@U1@",
+			Type:   gedcom.RecordTypeSubmitter,
+			Entity: &gedcom.Submitter{XRef: "@U1@", Language: []string{"Dutch"}},
+		}},
+	}
+	report := &gedcom.ConversionReport{}
+	transformLanguages(doc, gedcom.Version70, report)
+	subm, _ := doc.Records[0].GetSubmitter()
+	if doc.Header.Language != "fr" || subm.Language[0] != "nl" {
+		t.Errorf("languages = %q, %q", doc.Header.Language, subm.Language[0])
+	}
+	if len(report.Normalized) != 2 || len(report.Transformations) != 1 || report.Transformations[0].Count != 2 {
+		t.Errorf("report = %+v", report)
+	}
+}
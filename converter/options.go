@@ -1,5 +1,7 @@
 package converter
 
+import "github.com/cacack/gedcom-go/v2/gedcom"
+
 // ConvertOptions configures the conversion behavior.
 type ConvertOptions struct {
 	// Validate runs validation on the converted document.
@@ -13,6 +15,12 @@ type ConvertOptions struct {
 	// PreserveUnknownTags keeps vendor extensions and unknown tags.
 	// Default: true
 	PreserveUnknownTags bool
+
+	// Strictness is the strictness shared with the decoder, validator, and
+	// encoder options. gedcom.StrictnessStrict implies StrictDataLoss; the
+	// other levels record data loss in the report only.
+	// Default: StrictnessNormal
+	Strictness gedcom.Strictness
 }
 
 // DefaultOptions returns the default conversion options.
@@ -21,5 +29,12 @@ func DefaultOptions() *ConvertOptions {
 		Validate:            true,
 		StrictDataLoss:      false,
 		PreserveUnknownTags: true,
+		Strictness:          gedcom.StrictnessNormal,
 	}
 }
+
+// strictDataLoss reports whether data loss fails a conversion, by
+// StrictDataLoss or Strictness.
+func (opts *ConvertOptions) strictDataLoss() bool {
+	return opts.StrictDataLoss || opts.Strictness == gedcom.StrictnessStrict
+}
@@ -2,6 +2,8 @@ package converter
 
 import (
 	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
 )
 
 func TestDefaultOptions(t *testing.T) {
@@ -22,6 +24,10 @@ func TestDefaultOptions(t *testing.T) {
 	if !opts.PreserveUnknownTags {
 		t.Error("PreserveUnknownTags should be true by default")
 	}
+
+	if opts.Strictness != gedcom.StrictnessNormal {
+		t.Errorf("Strictness = %v, want normal by default", opts.Strictness)
+	}
 }
 
 func TestConvertOptions(t *testing.T) {
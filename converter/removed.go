@@ -0,0 +1,299 @@
+package converter
+
+import (
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// EXID types given to the identifiers of the 5.5.1 AFN and RFN tags when
+// they are rewritten as GEDCOM 7.0 EXID structures.
+const (
+	afnEXIDType = "https://gedcom.io/exid-type/FamilySearch-AFN"
+	rfnEXIDType = "https://gedcom.io/terms/v7/RFN"
+)
+
+// romanizationLanguages maps 5.5.1 ROMN TYPE values to the BCP 47 tag of
+// the TRAN LANG that replaces them; other romanizations become und-Latn.
+var romanizationLanguages = map[string]string{
+	"PINYIN":    "zh-Latn-pinyin",
+	"ROMAJI":    "ja-Latn",
+	"WADEGILES": "zh-Latn-wadegile",
+}
+
+// phoneticLanguages maps 5.5.1 FONE TYPE values to the BCP 47 tag of the
+// TRAN LANG that replaces them; other phonetic forms become und.
+var phoneticLanguages = map[string]string{
+	"HANGUL": "ko-Hang",
+	"KANA":   "ja-Hrkt",
+}
+
+// associationRoles maps 5.5 and 5.5.1 RELA words to the GEDCOM 7.0 ROLE
+// enumeration; other relationships become OTHER.
+var associationRoles = map[string]string{
+	"CHILD":      "CHIL",
+	"CLERGY":     "CLERGY",
+	"FATHER":     "FATH",
+	"FRIEND":     "FRIEND",
+	"GODFATHER":  "GODP",
+	"GODMOTHER":  "GODP",
+	"GODPARENT":  "GODP",
+	"HUSBAND":    "HUSB",
+	"MOTHER":     "MOTH",
+	"NEIGHBOR":   "NGHBR",
+	"NEIGHBOUR":  "NGHBR",
+	"OFFICIATOR": "OFFICIATOR",
+	"PARENT":     "PARENT",
+	"PARTNER":    "SPOU",
+	"SPOUSE":     "SPOU",
+	"WIFE":       "WIFE",
+	"WITNESS":    "WITN",
+}
+
+// removedTagRewriter rewrites the tags of one document and records each
+// change in the report.
+type removedTagRewriter struct {
+	report *gedcom.ConversionReport
+	count  int
+	blobs  []string
+}
+
+// transformRemovedTags rewrites the standard 5.5 and 5.5.1 tags that GEDCOM
+// 7.0 removed, so an upgraded document can be encoded as 7.0:
+//   - NAME and PLAC ROMN and FONE become TRAN, with a LANG naming the
+//     language and script their TYPE described;
+//   - AFN and RFN become EXID with a TYPE naming the identifier's source;
+//   - ASSO RELA becomes ROLE, with the original wording as its PHRASE when
+//     it is not one of the 7.0 roles;
+//   - OBJE BLOB, embedded binary data 7.0 has no place for, is dropped.
+//
+// Only raw tags are rewritten; the encoder writes typed entities without
+// these tags. Extension subtrees are left alone. SUBN records are handled
+// by dropSubmissions.
+func transformRemovedTags(doc *gedcom.Document, report *gedcom.ConversionReport) {
+	r := &removedTagRewriter{report: report}
+	for _, record := range doc.Records {
+		if record == nil || len(record.Tags) == 0 || strings.HasPrefix(string(record.Type), "_") {
+			continue
+		}
+		base := BuildRecordPath(string(record.Type), record.XRef)
+		if tags, changed := r.rewrite(record.Tags, string(record.Type), base, record.XRef); changed {
+			record.Tags = tags
+			syncEntityTags(record)
+		}
+	}
+
+	if r.count > 0 {
+		report.AddTransformation(gedcom.Transformation{
+			Type:        "REMOVED_TAGS_REPLACED",
+			Description: "Replaced tags removed in GEDCOM 7.0 with their 7.0 equivalents",
+			Count:       r.count,
+		})
+	}
+	if len(r.blobs) > 0 {
+		report.AddDataLoss(gedcom.DataLossItem{
+			Feature:         "BLOB embedded media",
+			Reason:          "GEDCOM 7.0 removed embedded binary media; link the media file with FILE instead",
+			AffectedRecords: r.blobs,
+		})
+	}
+}
+
+// rewrite returns the tags of a record of type recordType, whose paths
+// start at base, with the removed tags replaced, and whether anything
+// changed.
+func (r *removedTagRewriter) rewrite(tags []*gedcom.Tag, recordType, base, xref string) ([]*gedcom.Tag, bool) {
+	out := make([]*gedcom.Tag, 0, len(tags))
+	changed := false
+	var parents []string
+	for i := 0; i < len(tags); {
+		tag := tags[i]
+		if tag == nil || tag.Level < 1 {
+			out = append(out, tag)
+			i++
+			continue
+		}
+		parents = tagPath(parents, tag)
+		parent := recordType
+		if len(parents) > 1 {
+			parent = parents[len(parents)-2]
+		}
+		end := subtreeEnd(tags, i)
+		if strings.HasPrefix(tag.Tag, "_") {
+			out = append(out, tags[i:end]...)
+			i = end
+			continue
+		}
+		path := BuildPath(append([]string{base}, parents...)...)
+		if replaced, ok := r.replace(tags[i:end], parent, path, xref); ok {
+			out = append(out, replaced...)
+			changed = true
+			i = end
+			continue
+		}
+		out = append(out, tag)
+		i++
+	}
+	return out, changed
+}
+
+// replace returns the replacement of subtree, a structure under parent at
+// path in record xref, and whether it is a removed tag that was replaced.
+func (r *removedTagRewriter) replace(subtree []*gedcom.Tag, parent, path, xref string) ([]*gedcom.Tag, bool) {
+	head := subtree[0]
+	switch {
+	case (head.Tag == "ROMN" || head.Tag == "FONE") && (parent == "NAME" || parent == "PLAC"):
+		return r.translation(subtree, path), true
+	case (head.Tag == "AFN" || head.Tag == "RFN") && (parent == "INDI" || parent == "SUBM"):
+		return r.externalID(subtree, path), true
+	case head.Tag == "RELA" && parent == "ASSO":
+		return r.role(subtree, path), true
+	case head.Tag == "BLOB" && parent == "OBJE":
+		r.report.AddDropped(gedcom.ConversionNote{
+			Path:     path,
+			Original: "BLOB",
+			Reason:   "GEDCOM 7.0 removed embedded binary media",
+		})
+		r.blobs = appendOnce(r.blobs, xref)
+		return nil, true
+	}
+	return nil, false
+}
+
+// tagPath returns the tags from the record down to tag, given those down
+// to the tag before it.
+func tagPath(parents []string, tag *gedcom.Tag) []string {
+	if len(parents) >= tag.Level {
+		parents = parents[:tag.Level-1]
+	}
+	for len(parents) < tag.Level-1 {
+		parents = append(parents, "")
+	}
+	return append(parents, tag.Tag)
+}
+
+// translation rewrites a ROMN or FONE subtree as a TRAN subtree, turning its
+// TYPE into a LANG.
+func (r *removedTagRewriter) translation(subtree []*gedcom.Tag, path string) []*gedcom.Tag {
+	head := subtree[0]
+	languages, fallback := romanizationLanguages, "und-Latn"
+	if head.Tag == "FONE" {
+		languages, fallback = phoneticLanguages, "und"
+	}
+
+	tran := *head
+	tran.Tag = "TRAN"
+	out := []*gedcom.Tag{&tran}
+	method := ""
+	for _, tag := range subtree[1:] {
+		if tag.Level == head.Level+1 && tag.Tag == "TYPE" {
+			method = tag.Value
+			continue
+		}
+		out = append(out, tag)
+	}
+	lang, ok := languages[strings.ToUpper(strings.ReplaceAll(method, " ", ""))]
+	if !ok {
+		lang = fallback
+	}
+	out = append(out[:1], append([]*gedcom.Tag{{Level: head.Level + 1, Tag: "LANG", Value: lang}}, out[1:]...)...)
+
+	r.count++
+	note := gedcom.ConversionNote{Path: path, Original: head.Tag, Result: "TRAN"}
+	if method != "" && !ok {
+		note.Reason = "GEDCOM 7.0 replaced " + head.Tag + " with TRAN; TYPE " + method + " has no BCP 47 equivalent and was recorded as LANG " + lang
+		r.report.AddApproximated(note)
+	} else {
+		note.Reason = "GEDCOM 7.0 replaced " + head.Tag + " with TRAN and LANG " + lang
+		r.report.AddNormalized(note)
+	}
+	return out
+}
+
+// externalID rewrites an AFN or RFN subtree as an EXID with a TYPE.
+func (r *removedTagRewriter) externalID(subtree []*gedcom.Tag, path string) []*gedcom.Tag {
+	head := subtree[0]
+	exidType := afnEXIDType
+	if head.Tag == "RFN" {
+		exidType = rfnEXIDType
+	}
+	exid := *head
+	exid.Tag = "EXID"
+	out := append([]*gedcom.Tag{&exid, {Level: head.Level + 1, Tag: "TYPE", Value: exidType}}, subtree[1:]...)
+
+	r.count++
+	r.report.AddNormalized(gedcom.ConversionNote{
+		Path:     path,
+		Original: head.Tag + " " + head.Value,
+		Result:   "EXID " + head.Value + " (TYPE " + exidType + ")",
+		Reason:   "GEDCOM 7.0 replaced " + head.Tag + " with EXID",
+	})
+	return out
+}
+
+// role rewrites a RELA subtree as a ROLE, keeping a relationship that is
+// not a 7.0 role as its PHRASE.
+func (r *removedTagRewriter) role(subtree []*gedcom.Tag, path string) []*gedcom.Tag {
+	head := subtree[0]
+	word := strings.ToUpper(strings.TrimSpace(head.Value))
+	enum, ok := associationRoles[word]
+	if !ok && isRole(word) {
+		enum, ok = word, true
+	}
+	if !ok {
+		enum = "OTHER"
+	}
+	role := *head
+	role.Tag = "ROLE"
+	role.Value = enum
+	out := []*gedcom.Tag{&role}
+	if word != enum && head.Value != "" {
+		out = append(out, &gedcom.Tag{Level: head.Level + 1, Tag: "PHRASE", Value: head.Value})
+	}
+	out = append(out, subtree[1:]...)
+
+	r.count++
+	note := gedcom.ConversionNote{
+		Path:     path,
+		Original: "RELA " + head.Value,
+		Result:   "ROLE " + enum,
+		Reason:   "GEDCOM 7.0 replaced RELA with the ROLE enumeration",
+	}
+	if ok {
+		r.report.AddNormalized(note)
+	} else {
+		note.Reason += "; the relationship is kept as the PHRASE of ROLE OTHER"
+		r.report.AddApproximated(note)
+	}
+	return out
+}
+
+// isRole reports whether word is already a GEDCOM 7.0 ROLE value.
+func isRole(word string) bool {
+	if word == "MULTIPLE" || word == "OTHER" {
+		return true
+	}
+	for _, role := range associationRoles {
+		if role == word {
+			return true
+		}
+	}
+	return false
+}
+
+// subtreeEnd returns the index just past the subtree of tags[i].
+func subtreeEnd(tags []*gedcom.Tag, i int) int {
+	end := i + 1
+	for end < len(tags) && tags[end] != nil && tags[end].Level > tags[i].Level {
+		end++
+	}
+	return end
+}
+
+// appendOnce appends s to list unless it is already the last element.
+func appendOnce(list []string, s string) []string {
+	if len(list) > 0 && list[len(list)-1] == s {
+		return list
+	}
+	return append(list, s)
+}
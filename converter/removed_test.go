@@ -0,0 +1,109 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func TestTransformRemovedTags(t *testing.T) {
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version551},
+		Records: []*gedcom.Record{
+			{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Tags: []*gedcom.Tag{
+				{Level: 1, Tag: "NAME", Value: "王 /李/"},
+				{Level: 2, Tag: "ROMN", Value: "Wang /Li/"},
+				{Level: 3, Tag: "TYPE", Value: "pinyin"},
+				{Level: 3, Tag: "SURN", Value: "Li"},
+				{Level: 2, Tag: "FONE", Value: "Wan /Ri/"},
+				{Level: 1, Tag: "AFN", Value: "1234-567"},
+				{Level: 1, Tag: "ASSO", Value: "@I2@"},
+				{Level: 2, Tag: "RELA", Value: "Godfather"},
+				{Level: 1, Tag: "ASSO", Value: "@I3@"},
+				{Level: 2, Tag: "RELA", Value: "Best friend"},
+				{Level: 1, Tag: "OBJE"},
+				{Level: 2, Tag: "BLOB"},
+				{Level: 3, Tag: "CONT", Value: ".HM.......k.1..F.jwA.Dzzzz/.0"},
+				{Level: 2, Tag: "TITL", Value: "Photo"},
+				{Level: 1, Tag: "_CUSTOM"},
+				{Level: 2, Tag: "RELA", Value: "kept"},
+			}},
+			{XRef: "@U1@", Type: gedcom.RecordTypeSubmitter, Tags: []*gedcom.Tag{
+				{Level: 1, Tag: "RFN", Value: "42"},
+			}},
+			{Type: "_EVDEF", Tags: []*gedcom.Tag{{Level: 1, Tag: "AFN", Value: "kept"}}},
+		},
+	}
+	report := &gedcom.ConversionReport{}
+	transformRemovedTags(doc, report)
+
+	want := []string{
+		"1 NAME 王 /李/",
+		"2 TRAN Wang /Li/",
+		"3 LANG zh-Latn-pinyin",
+		"3 SURN Li",
+		"2 TRAN Wan /Ri/",
+		"3 LANG und",
+		"1 EXID 1234-567",
+		"2 TYPE " + afnEXIDType,
+		"1 ASSO @I2@",
+		"2 ROLE GODP",
+		"3 PHRASE Godfather",
+		"1 ASSO @I3@",
+		"2 ROLE OTHER",
+		"3 PHRASE Best friend",
+		"1 OBJE",
+		"2 TITL Photo",
+		"1 _CUSTOM",
+		"2 RELA kept",
+	}
+	if got := tagStrings(doc.Records[0].Tags); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("INDI tags =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if got := tagStrings(doc.Records[1].Tags); strings.Join(got, "|") != "1 EXID 42|2 TYPE "+rfnEXIDType {
+		t.Errorf("SUBM tags = %v", got)
+	}
+	if got := tagStrings(doc.Records[2].Tags); got[0] != "1 AFN kept" {
+		t.Errorf("extension record tags = %v", got)
+	}
+
+	if len(report.Transformations) != 1 || report.Transformations[0].Count != 6 {
+		t.Errorf("Transformations = %+v", report.Transformations)
+	}
+	if len(report.Approximated) != 1 || !strings.Contains(report.Approximated[0].Path, "RELA") {
+		t.Errorf("Approximated = %+v", report.Approximated)
+	}
+	if len(report.Dropped) != 1 || len(report.DataLoss) != 1 || report.DataLoss[0].AffectedRecords[0] != "@I1@" {
+		t.Errorf("Dropped = %+v, DataLoss = %+v", report.Dropped, report.DataLoss)
+	}
+}
+
+func TestTransformRemovedTags_Unchanged(t *testing.T) {
+	tags := []*gedcom.Tag{
+		{Level: 1, Tag: "NAME", Value: "John /Smith/"},
+		{Level: 1, Tag: "ASSO", Value: "@I2@"},
+		{Level: 2, Tag: "ROLE", Value: "WITN"},
+	}
+	doc := &gedcom.Document{Records: []*gedcom.Record{{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Tags: tags}}}
+	report := &gedcom.ConversionReport{}
+	transformRemovedTags(doc, report)
+	if &doc.Records[0].Tags[0] != &tags[0] || len(report.Transformations) != 0 {
+		t.Errorf("unchanged record was rewritten: %v", tagStrings(doc.Records[0].Tags))
+	}
+
+	// A RELA already naming a 7.0 role needs no PHRASE
+	doc.Records[0].Tags[2].Tag = "RELA"
+	transformRemovedTags(doc, report)
+	if got := tagStrings(doc.Records[0].Tags); got[2] != "2 ROLE WITN" || len(got) != 3 {
+		t.Errorf("tags = %v", got)
+	}
+}
+
+func tagStrings(tags []*gedcom.Tag) []string {
+	out := make([]string, len(tags))
+	for i, tag := range tags {
+		out[i] = strings.TrimSpace(strings.Join([]string{string(rune('0' + tag.Level)), tag.Tag, tag.Value}, " "))
+	}
+	return out
+}
@@ -98,93 +98,112 @@ func consolidateCONCAndCONTInTags(tags []*gedcom.Tag) (result []*gedcom.Tag, con
 }
 
 // consolidateCONCAndCONTInTagsWithNotes processes a flat slice of tags, consolidating both
-// CONC and CONT tags into their parent values in a single pass.
+// CONC and CONT tags into their parent values in a single pass, at every depth.
+// A stray CONC that does not follow its parent line, such as "1 CONC" after
+// "1 NOTE", is joined onto the line before it, since GEDCOM 7.0 has no CONC
+// to keep it in. Continuations of the record line itself are kept.
 // Returns the new tag slice, CONC count, CONT count, and per-item notes.
 func consolidateCONCAndCONTInTagsWithNotes(tags []*gedcom.Tag) (result []*gedcom.Tag, concCount, contCount int, notes []consolidationNote) {
 	if len(tags) == 0 {
 		return tags, 0, 0, nil
 	}
 
-	i := 0
-	for i < len(tags) {
-		tag := tags[i]
-		baseLevel := tag.Level
-		originalValue := tag.Value
-
-		// Look ahead for CONC/CONT children at baseLevel+1
-		var valueBuilder strings.Builder
-		valueBuilder.WriteString(tag.Value)
-		j := i + 1
-		foundContinuation := false
-		localConcCount := 0
-		localContCount := 0
+	// open[l] is the most recent line at level l; lines are only ever
+	// continued by lines one level below them.
+	var open []*gedcom.Tag
+	consolidated := make(map[*gedcom.Tag]*consolidation)
+	var order []*gedcom.Tag
+	result = make([]*gedcom.Tag, 0, len(tags))
 
-		// Collect non-continuation children to preserve
-		var otherChildren []*gedcom.Tag
-
-		for j < len(tags) {
-			nextTag := tags[j]
-			// If level goes back to baseLevel or lower, we're done with children
-			if nextTag.Level <= baseLevel {
-				break
-			}
-			// Process CONC/CONT at exactly baseLevel+1
-			if nextTag.Level == baseLevel+1 {
-				switch nextTag.Tag {
-				case "CONC":
-					valueBuilder.WriteString(nextTag.Value)
-					concCount++
-					localConcCount++
-					foundContinuation = true
-					j++
-					continue
-				case "CONT":
-					valueBuilder.WriteString("\n")
-					valueBuilder.WriteString(nextTag.Value)
-					contCount++
-					localContCount++
-					foundContinuation = true
-					j++
-					continue
-				}
-			}
-			// Not a continuation tag at immediate child level, keep it
-			otherChildren = append(otherChildren, nextTag)
-			j++
+	for _, tag := range tags {
+		if tag.Tag != "CONC" && tag.Tag != "CONT" {
+			open = openLine(open, tag)
+			result = append(result, tag)
+			continue
 		}
 
-		// Update tag value if we found continuation tags
-		if foundContinuation {
-			tag.Value = valueBuilder.String()
+		target := continuedLine(open, tag.Level)
+		stray := false
+		if target == nil && tag.Tag == "CONC" && len(result) > 0 {
+			target = result[len(result)-1]
+			stray = true
+		}
+		if target == nil {
+			result = append(result, tag)
+			continue
+		}
 
-			// Create a note for this consolidation
-			var reason string
-			switch {
-			case localConcCount > 0 && localContCount > 0:
-				reason = "GEDCOM 7.0 removes CONC tags and uses embedded newlines instead of CONT tags"
-			case localConcCount > 0:
-				reason = "GEDCOM 7.0 removes CONC tags; text concatenated into parent value"
-			default:
-				reason = "GEDCOM 7.0 uses embedded newlines instead of CONT tags"
-			}
-			notes = append(notes, consolidationNote{
-				parentTag: tag.Tag,
-				original:  originalValue,
-				result:    tag.Value,
-				reason:    reason,
-			})
+		c := consolidated[target]
+		if c == nil {
+			c = &consolidation{original: target.Value}
+			consolidated[target] = c
+			order = append(order, target)
+		}
+		if tag.Tag == "CONC" {
+			target.Value += tag.Value
+			concCount++
+			c.conc++
+		} else {
+			target.Value += "\n" + tag.Value
+			contCount++
+			c.cont++
 		}
+		c.stray = c.stray || stray
+	}
 
-		result = append(result, tag)
+	for _, tag := range order {
+		notes = append(notes, consolidated[tag].note(tag))
+	}
+	return result, concCount, contCount, notes
+}
 
-		// Add other children back (non-CONC/CONT tags)
-		result = append(result, otherChildren...)
+// consolidation counts the continuations joined onto one line.
+type consolidation struct {
+	original   string
+	conc, cont int
+	stray      bool
+}
 
-		// Move to next unprocessed tag
-		i = j
+// note describes the consolidation of line.
+func (c *consolidation) note(line *gedcom.Tag) consolidationNote {
+	var reason string
+	switch {
+	case c.stray:
+		reason = "GEDCOM 7.0 removes CONC tags; stray CONC text joined onto the line before it"
+	case c.conc > 0 && c.cont > 0:
+		reason = "GEDCOM 7.0 removes CONC tags and uses embedded newlines instead of CONT tags"
+	case c.conc > 0:
+		reason = "GEDCOM 7.0 removes CONC tags; text concatenated into parent value"
+	default:
+		reason = "GEDCOM 7.0 uses embedded newlines instead of CONT tags"
 	}
+	return consolidationNote{
+		parentTag: line.Tag,
+		original:  c.original,
+		result:    line.Value,
+		reason:    reason,
+	}
+}
 
-	return result, concCount, contCount, notes
+// openLine records tag as the most recent line at its level, closing the
+// lines below it.
+func openLine(open []*gedcom.Tag, tag *gedcom.Tag) []*gedcom.Tag {
+	if tag.Level < len(open) {
+		open = open[:tag.Level]
+	}
+	for len(open) < tag.Level {
+		open = append(open, nil)
+	}
+	return append(open, tag)
+}
+
+// continuedLine returns the line a continuation at level continues: the
+// open line one level up, or nil if there is none.
+func continuedLine(open []*gedcom.Tag, level int) *gedcom.Tag {
+	if level < 1 || level-1 >= len(open) {
+		return nil
+	}
+	return open[level-1]
 }
 
 // consolidateCONC is provided for explicit CONC-only consolidation.
@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/cacack/gedcom-go/v2/gedcom"
@@ -242,6 +243,35 @@ func TestConsolidateCONCAndCONTInTags(t *testing.T) {
 	}
 }
 
+// TestConsolidateCONCAndCONTInTags_Nested checks continuations are joined
+// at every depth, and that a stray CONC is joined onto the line before it.
+func TestConsolidateCONCAndCONTInTags_Nested(t *testing.T) {
+	tags := []*gedcom.Tag{
+		{Level: 1, Tag: "CONC", Value: "record line"},
+		{Level: 1, Tag: "BIRT"},
+		{Level: 2, Tag: "NOTE", Value: "Born at "},
+		{Level: 3, Tag: "CONC", Value: "home"},
+		{Level: 3, Tag: "SOUR", Value: "@S1@"},
+		{Level: 4, Tag: "PAGE", Value: "p. "},
+		{Level: 5, Tag: "CONC", Value: "12"},
+		{Level: 3, Tag: "CONT", Value: "in winter"},
+		{Level: 1, Tag: "NOTE", Value: "Stray "},
+		{Level: 1, Tag: "CONC", Value: "text"},
+	}
+	result, concCount, contCount, notes := consolidateCONCAndCONTInTagsWithNotes(tags)
+
+	want := "1 CONC record line|1 BIRT|2 NOTE Born at home\nin winter|3 SOUR @S1@|4 PAGE p. 12|1 NOTE Stray text"
+	if got := strings.Join(tagStrings(result), "|"); got != want {
+		t.Errorf("result = %q\nwant %q", got, want)
+	}
+	if concCount != 3 || contCount != 1 {
+		t.Errorf("counts = %d CONC, %d CONT, want 3 and 1", concCount, contCount)
+	}
+	if len(notes) != 3 || !strings.Contains(notes[2].reason, "stray") {
+		t.Errorf("notes = %+v", notes)
+	}
+}
+
 func TestConsolidateCONC(t *testing.T) {
 	doc := &gedcom.Document{
 		Header: &gedcom.Header{
@@ -0,0 +1,419 @@
+package csvimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// Column is a column the importer understands.
+type Column string
+
+// Columns understood by Decode.
+const (
+	ColumnID            Column = "ID"
+	ColumnName          Column = "Name"
+	ColumnGiven         Column = "Given"
+	ColumnSurname       Column = "Surname"
+	ColumnSex           Column = "Sex"
+	ColumnBirthDate     Column = "BirthDate"
+	ColumnBirthPlace    Column = "BirthPlace"
+	ColumnDeathDate     Column = "DeathDate"
+	ColumnDeathPlace    Column = "DeathPlace"
+	ColumnFather        Column = "Father"
+	ColumnMother        Column = "Mother"
+	ColumnSpouse        Column = "Spouse"
+	ColumnMarriageDate  Column = "MarriageDate"
+	ColumnMarriagePlace Column = "MarriagePlace"
+	ColumnNote          Column = "Note"
+)
+
+// columnNames maps normalized header names to columns.
+var columnNames = map[string]Column{
+	"id":            ColumnID,
+	"name":          ColumnName,
+	"fullname":      ColumnName,
+	"given":         ColumnGiven,
+	"givenname":     ColumnGiven,
+	"givennames":    ColumnGiven,
+	"firstname":     ColumnGiven,
+	"surname":       ColumnSurname,
+	"lastname":      ColumnSurname,
+	"familyname":    ColumnSurname,
+	"sex":           ColumnSex,
+	"gender":        ColumnSex,
+	"birthdate":     ColumnBirthDate,
+	"birthplace":    ColumnBirthPlace,
+	"deathdate":     ColumnDeathDate,
+	"deathplace":    ColumnDeathPlace,
+	"father":        ColumnFather,
+	"mother":        ColumnMother,
+	"spouse":        ColumnSpouse,
+	"spouses":       ColumnSpouse,
+	"marriagedate":  ColumnMarriageDate,
+	"marriageplace": ColumnMarriagePlace,
+	"note":          ColumnNote,
+	"notes":         ColumnNote,
+}
+
+// sexes maps lowercase Sex values to SEX values.
+var sexes = map[string]string{
+	"m": "M", "male": "M",
+	"f": "F", "female": "F",
+	"x": "X", "intersex": "X",
+	"u": "U", "unknown": "U",
+}
+
+// Options configures Decode.
+type Options struct {
+	// Comma is the field delimiter. Default: ','.
+	Comma rune
+
+	// Columns maps header names to columns, for spreadsheets whose headers
+	// are not the standard names ("Vater": ColumnFather). Names are
+	// matched like standard ones, ignoring case, spaces, underscores, and
+	// hyphens.
+	Columns map[string]Column
+
+	// Version is the GEDCOM version of the document header. Default:
+	// gedcom.Version70.
+	Version gedcom.Version
+}
+
+// Decode reads a CSV spreadsheet of individuals from r and builds a
+// document from it. It returns an error, naming the row, for a repeated
+// ID, an unknown Sex value, or a Father, Mother, or Spouse that names no
+// row.
+func Decode(r io.Reader, opts *Options) (*gedcom.Document, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	cr := csv.NewReader(r)
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("csvimport: no header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("csvimport: %w", err)
+	}
+	index := columnIndex(header, opts.Columns)
+
+	version := opts.Version
+	if version == "" {
+		version = gedcom.Version70
+	}
+	b := &builder{
+		doc: &gedcom.Document{
+			Header:  &gedcom.Header{Version: version, Encoding: gedcom.EncodingUTF8},
+			XRefMap: make(map[string]*gedcom.Record),
+		},
+		ids:      make(map[string]*gedcom.Individual),
+		families: make(map[string]*gedcom.Family),
+	}
+	for line := 2; ; line++ {
+		fields, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("csvimport: %w", err)
+		}
+		row := row{line: line, fields: fields, index: index}
+		if row.empty() {
+			continue
+		}
+		if err := b.individual(row); err != nil {
+			return nil, fmt.Errorf("csvimport: row %d: %w", line, err)
+		}
+	}
+	for _, row := range b.rows {
+		if err := b.link(row); err != nil {
+			return nil, fmt.Errorf("csvimport: row %d: %w", row.line, err)
+		}
+	}
+	for _, fam := range b.familyList {
+		if _, err := b.doc.AddFamily(fam); err != nil {
+			return nil, fmt.Errorf("csvimport: %w", err)
+		}
+	}
+	return b.doc, nil
+}
+
+// columnIndex returns the position of each known column in header. The
+// first of repeated columns wins.
+func columnIndex(header []string, custom map[string]Column) map[Column]int {
+	names := make(map[string]Column, len(custom))
+	for name, col := range custom {
+		names[normalizeHeader(name)] = col
+	}
+	index := make(map[Column]int)
+	for i, name := range header {
+		key := normalizeHeader(name)
+		col, ok := names[key]
+		if !ok {
+			col, ok = columnNames[key]
+		}
+		if _, seen := index[col]; ok && !seen {
+			index[col] = i
+		}
+	}
+	return index
+}
+
+// normalizeHeader lowercases name and removes spaces, underscores,
+// hyphens, and a leading byte order mark.
+func normalizeHeader(name string) string {
+	name = strings.TrimPrefix(name, "\ufeff")
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '_', '-', '\t':
+			return -1
+		}
+		return r
+	}, strings.ToLower(name))
+}
+
+// row is one data row of the spreadsheet.
+type row struct {
+	line   int
+	fields []string
+	index  map[Column]int
+	indi   *gedcom.Individual
+}
+
+// get returns the trimmed value of col, or "" when the column is absent.
+func (r row) get(col Column) string {
+	i, ok := r.index[col]
+	if !ok || i >= len(r.fields) {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[i])
+}
+
+// empty reports whether every field of r is blank.
+func (r row) empty() bool {
+	for _, f := range r.fields {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// builder accumulates the records of a decoded document. Individuals are
+// added as rows are read; links are resolved once every ID is known, since
+// rows may name people further down the sheet.
+type builder struct {
+	doc  *gedcom.Document
+	rows []row
+	ids  map[string]*gedcom.Individual // row ID -> individual
+
+	families   map[string]*gedcom.Family // parent key -> family
+	familyList []*gedcom.Family          // in creation order
+}
+
+// individual adds the INDI record of r.
+func (b *builder) individual(r row) error {
+	id := r.get(ColumnID)
+	if id == "" {
+		id = strconv.Itoa(r.line)
+	}
+	if _, dup := b.ids[id]; dup {
+		return fmt.Errorf("duplicate ID %q", id)
+	}
+
+	indi := &gedcom.Individual{}
+	if n := personalName(r.get(ColumnName), r.get(ColumnGiven), r.get(ColumnSurname)); n != nil {
+		indi.Names = []*gedcom.PersonalName{n}
+	}
+	if sex := r.get(ColumnSex); sex != "" {
+		indi.Sex = sexes[strings.ToLower(sex)]
+		if indi.Sex == "" {
+			return fmt.Errorf("unknown %s %q (want M, F, X, or U)", ColumnSex, sex)
+		}
+	}
+	if e := event(gedcom.EventBirth, r.get(ColumnBirthDate), r.get(ColumnBirthPlace)); e != nil {
+		indi.Events = append(indi.Events, e)
+	}
+	if e := event(gedcom.EventDeath, r.get(ColumnDeathDate), r.get(ColumnDeathPlace)); e != nil {
+		indi.Events = append(indi.Events, e)
+	}
+	if note := r.get(ColumnNote); note != "" {
+		indi.InlineNotes = []string{note}
+		indi.Notes = indi.InlineNotes
+	}
+
+	if _, err := b.doc.AddIndividual(indi); err != nil {
+		return err
+	}
+	b.ids[id] = indi
+	r.indi = indi
+	b.rows = append(b.rows, r)
+	return nil
+}
+
+// link adds the parent and spouse families of r.
+func (b *builder) link(r row) error {
+	father, err := b.lookup(r, ColumnFather, r.get(ColumnFather))
+	if err != nil {
+		return err
+	}
+	mother, err := b.lookup(r, ColumnMother, r.get(ColumnMother))
+	if err != nil {
+		return err
+	}
+	if father != nil || mother != nil {
+		fam := b.family(father, mother)
+		fam.Children = append(fam.Children, r.indi.XRef)
+	}
+
+	for i, id := range strings.Split(r.get(ColumnSpouse), ";") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		spouse, err := b.lookup(r, ColumnSpouse, id)
+		if err != nil {
+			return err
+		}
+		husband, wife := r.indi, spouse
+		if husband.Sex == "F" || wife.Sex == "M" {
+			husband, wife = wife, husband
+		}
+		fam := b.family(husband, wife)
+		if i == 0 && !hasEvent(fam, gedcom.EventMarriage) {
+			if e := event(gedcom.EventMarriage, r.get(ColumnMarriageDate), r.get(ColumnMarriagePlace)); e != nil {
+				fam.Events = append(fam.Events, e)
+			}
+		}
+	}
+	return nil
+}
+
+// lookup returns the individual of the row with the given ID, or nil for
+// an empty ID.
+func (b *builder) lookup(r row, col Column, id string) (*gedcom.Individual, error) {
+	if id == "" {
+		return nil, nil
+	}
+	indi, ok := b.ids[id]
+	if !ok {
+		return nil, fmt.Errorf("%s %q: no row has this ID", col, id)
+	}
+	if indi == r.indi {
+		return nil, fmt.Errorf("%s %q: the row refers to itself", col, id)
+	}
+	return indi, nil
+}
+
+// family returns the family of the given parents, either of which may be
+// nil, creating it when it does not exist yet. A couple has one family
+// whichever role each partner was named in.
+func (b *builder) family(husband, wife *gedcom.Individual) *gedcom.Family {
+	var xrefs []string
+	for _, p := range []*gedcom.Individual{husband, wife} {
+		if p != nil {
+			xrefs = append(xrefs, p.XRef)
+		}
+	}
+	sort.Strings(xrefs)
+	key := strings.Join(xrefs, "\x00")
+	if fam, ok := b.families[key]; ok {
+		return fam
+	}
+
+	fam := &gedcom.Family{}
+	if husband != nil {
+		fam.Husband = husband.XRef
+	}
+	if wife != nil {
+		fam.Wife = wife.XRef
+	}
+	b.families[key] = fam
+	b.familyList = append(b.familyList, fam)
+	return fam
+}
+
+// hasEvent reports whether fam has an event of type t.
+func hasEvent(fam *gedcom.Family, t gedcom.EventType) bool {
+	for _, e := range fam.Events {
+		if e.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// personalName builds a name from the Name, Given, and Surname columns,
+// or returns nil when all are empty. A full name with slashes is split
+// into given name and surname; one without is kept whole.
+func personalName(full, given, surname string) *gedcom.PersonalName {
+	if full == "" && given == "" && surname == "" {
+		return nil
+	}
+	if full == "" {
+		full = strings.TrimSpace(given + " /" + surname + "/")
+		if surname == "" {
+			full = given
+		}
+	} else if before, rest, ok := strings.Cut(full, "/"); ok && given == "" && surname == "" {
+		given = strings.TrimSpace(before)
+		surname, _, _ = strings.Cut(rest, "/")
+		surname = strings.TrimSpace(surname)
+	}
+	return &gedcom.PersonalName{Full: full, Given: given, Surname: surname}
+}
+
+// event returns an event of type t, or nil when date and place are empty.
+func event(t gedcom.EventType, date, place string) *gedcom.Event {
+	if date == "" && place == "" {
+		return nil
+	}
+	e := &gedcom.Event{Type: t, Date: gedcomDate(date), Place: place}
+	if e.Date != "" {
+		e.ParsedDate, _ = gedcom.ParseDate(e.Date)
+	}
+	return e
+}
+
+// months are the GEDCOM month abbreviations.
+var months = [...]string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+
+// gedcomDate converts an ISO date ("1900-01-02" or "1900-01") to GEDCOM
+// date text and returns other values unchanged.
+func gedcomDate(s string) string {
+	parts := strings.Split(s, "-")
+	if len(parts) < 2 || len(parts) > 3 || len(parts[0]) != 4 {
+		return s
+	}
+	nums := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 1 {
+			return s
+		}
+		nums[i] = n
+	}
+	if nums[1] > 12 {
+		return s
+	}
+	if len(nums) == 2 {
+		return fmt.Sprintf("%s %d", months[nums[1]-1], nums[0])
+	}
+	if nums[2] > 31 {
+		return s
+	}
+	return fmt.Sprintf("%d %s %d", nums[2], months[nums[1]-1], nums[0])
+}
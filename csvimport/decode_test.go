@@ -0,0 +1,162 @@
+package csvimport
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+	"github.com/cacack/gedcom-go/v2/validator"
+)
+
+const sampleCSV = `ID,Name,Sex,Birth Date,Birth Place,Death Date,Father,Mother,Spouse,Marriage Date,Notes
+P1,John /Doe/,M,1900-01-02,Springfield,1970,,,P2,1925-06,Farmer
+P2,Jane /Smith/,F,ABT 1902,,,,,P1,,
+P3,Jim /Doe/,male,1926,,,P1,P2,,,
+P4,Ann /Doe/,F,1928,,,P1,P2,,,
+
+P5,Sam /Doe/,M,1950,,,P3,,,,
+`
+
+func TestDecode(t *testing.T) {
+	doc, err := Decode(strings.NewReader(sampleCSV), nil)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if doc.Header.Version != gedcom.Version70 {
+		t.Errorf("Version = %q, want 7.0", doc.Header.Version)
+	}
+	if got := len(doc.Individuals()); got != 5 {
+		t.Fatalf("Individuals() = %d, want 5", got)
+	}
+
+	john := doc.GetIndividual("@I1@")
+	if n := john.Names[0]; n.Full != "John /Doe/" || n.Given != "John" || n.Surname != "Doe" {
+		t.Errorf("name = %+v", n)
+	}
+	birth := john.Events[0]
+	if birth.Type != gedcom.EventBirth || birth.Date != "2 JAN 1900" || birth.Place != "Springfield" || birth.ParsedDate == nil {
+		t.Errorf("birth = %+v", birth)
+	}
+	if len(john.Events) != 2 || john.Events[1].Date != "1970" || john.Notes[0] != "Farmer" {
+		t.Errorf("John = %+v", john)
+	}
+
+	families := doc.Families()
+	if len(families) != 2 {
+		t.Fatalf("Families() = %d, want 2", len(families))
+	}
+	parents := families[0]
+	if parents.Husband != "@I1@" || parents.Wife != "@I2@" || strings.Join(parents.Children, " ") != "@I3@ @I4@" {
+		t.Errorf("couple family = HUSB %s WIFE %s CHIL %v", parents.Husband, parents.Wife, parents.Children)
+	}
+	if len(parents.Events) != 1 || parents.Events[0].Date != "JUN 1925" {
+		t.Errorf("marriage = %+v", parents.Events)
+	}
+	single := families[1]
+	if single.Husband != "@I3@" || single.Wife != "" || len(single.Children) != 1 {
+		t.Errorf("single-parent family = %+v", single)
+	}
+	if got := doc.GetIndividual("@I5@").ChildInFamilies; len(got) != 1 || got[0].FamilyXRef != single.XRef {
+		t.Errorf("FAMC of @I5@ = %+v", got)
+	}
+	if doc.GetIndividual("@I3@").Sex != "M" {
+		t.Error("Sex \"male\" not normalized")
+	}
+
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	decoded, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode(encoded) error = %v", err)
+	}
+	for _, issue := range validator.New().ValidateAll(decoded) {
+		if issue.Severity == validator.SeverityError {
+			t.Errorf("validation error: %v", issue)
+		}
+	}
+}
+
+func TestDecode_Options(t *testing.T) {
+	input := "Vorname;Nachname;Vater\nKarl;Muster;3\nErika;Muster;\nHans;Muster;\n"
+	doc, err := Decode(strings.NewReader(input), &Options{
+		Comma:   ';',
+		Columns: map[string]Column{"Vorname": ColumnGiven, "Nachname": ColumnSurname, "Vater": ColumnFather},
+		Version: gedcom.Version551,
+	})
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if doc.Header.Version != gedcom.Version551 {
+		t.Errorf("Version = %q", doc.Header.Version)
+	}
+	if n := doc.GetIndividual("@I1@").Names[0]; n.Full != "Karl /Muster/" {
+		t.Errorf("name = %+v", n)
+	}
+	// Without an ID column, rows are identified by their row number.
+	fam := doc.Families()
+	if len(fam) != 1 || fam[0].Husband != "@I2@" || fam[0].Children[0] != "@I1@" {
+		t.Errorf("families = %+v", fam)
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"empty", "", "no header row"},
+		{"duplicate ID", "ID,Name\nP1,A\nP1,B\n", `row 3: duplicate ID "P1"`},
+		{"unknown sex", "ID,Sex\nP1,Q\n", `row 2: unknown Sex "Q"`},
+		{"unknown father", "ID,Father\nP1,P9\n", `row 2: Father "P9": no row has this ID`},
+		{"self spouse", "ID,Spouse\nP1,P1\n", `row 2: Spouse "P1": the row refers to itself`},
+		{"bad quoting", "ID,Name\nP1,\"A\n", "csvimport:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(tt.input), nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Decode() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPersonalName(t *testing.T) {
+	tests := []struct {
+		full, given, surname string
+		want                 gedcom.PersonalName
+	}{
+		{"Mary Ann Jones", "", "", gedcom.PersonalName{Full: "Mary Ann Jones"}},
+		{"", "Mary", "Jones", gedcom.PersonalName{Full: "Mary /Jones/", Given: "Mary", Surname: "Jones"}},
+		{"", "", "Jones", gedcom.PersonalName{Full: "/Jones/", Surname: "Jones"}},
+		{"", "Mary", "", gedcom.PersonalName{Full: "Mary", Given: "Mary"}},
+	}
+	for _, tt := range tests {
+		if got := personalName(tt.full, tt.given, tt.surname); !reflect.DeepEqual(*got, tt.want) {
+			t.Errorf("personalName(%q, %q, %q) = %+v, want %+v", tt.full, tt.given, tt.surname, *got, tt.want)
+		}
+	}
+	if personalName("", "", "") != nil {
+		t.Error("personalName of empty columns should be nil")
+	}
+}
+
+func TestGEDCOMDate(t *testing.T) {
+	for in, want := range map[string]string{
+		"1900-01-02": "2 JAN 1900",
+		"1900-12":    "DEC 1900",
+		"1900":       "1900",
+		"1900-13-01": "1900-13-01",
+		"ABT 1900":   "ABT 1900",
+		"01-02-1900": "01-02-1900",
+	} {
+		if got := gedcomDate(in); got != want {
+			t.Errorf("gedcomDate(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
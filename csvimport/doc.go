@@ -0,0 +1,41 @@
+// Package csvimport builds GEDCOM documents from spreadsheets saved as
+// CSV, one individual per row, so that data kept in a spreadsheet can be
+// exported as GEDCOM without writing record assembly code.
+//
+// The first row names the columns. Column names are matched ignoring case,
+// spaces, underscores, and hyphens ("Birth Date" is BirthDate); unknown
+// columns are ignored, and Options.Columns maps other names to these:
+//
+//	ID             row identifier used by Father, Mother, and Spouse
+//	               (default: the row number)
+//	Name           full name, "John /Doe/" or "John Doe"
+//	Given          given names (also "First Name")
+//	Surname        surname (also "Last Name", "Family Name")
+//	Sex            M, F, X, or U (also "Male", "Female", ...)
+//	BirthDate      BIRT date
+//	BirthPlace     BIRT place
+//	DeathDate      DEAT date
+//	DeathPlace     DEAT place
+//	Father         ID of the father
+//	Mother         ID of the mother
+//	Spouse         IDs of spouses, separated by ";"
+//	MarriageDate   MARR date of the family with the first spouse
+//	MarriagePlace  MARR place of the family with the first spouse
+//	Note           note text
+//
+// Every row becomes an INDI record with a generated XRef (@I1@, @I2@, ...).
+// Each couple, and each single parent, becomes one FAM record: the
+// children naming the same Father and Mother share a family, which is also
+// the family of that couple's Spouse links. Dates are kept as written, with
+// ISO dates ("1900-01-02") converted to GEDCOM dates ("2 JAN 1900").
+//
+// Example usage:
+//
+//	f, _ := os.Open("family.csv")
+//	defer f.Close()
+//	doc, err := csvimport.Decode(f, nil)
+//	if err != nil {
+//	    log.Fatal(err) // e.g. "csvimport: row 4: Father "P9": no row has this ID"
+//	}
+//	_ = encoder.Encode(out, doc)
+package csvimport
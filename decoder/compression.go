@@ -0,0 +1,29 @@
+package decoder
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// gzipMagic is the two-byte header of a gzip stream. No GEDCOM file can
+// start with it, so compressed input is detected without an option.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// decompress returns a reader of the decompressed contents of r if it is a
+// gzip stream (such as a .ged.gz archive member), or of r unchanged.
+func decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(gzipMagic))
+	if err != nil || !bytes.Equal(magic, gzipMagic) {
+		// Short or unreadable input is left for the parser to report.
+		return br, nil
+	}
+	zr, err := gzip.NewReader(br)
+	if err != nil {
+		return nil, fmt.Errorf("reading gzip header: %w", err)
+	}
+	return zr, nil
+}
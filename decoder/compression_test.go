@@ -0,0 +1,72 @@
+package decoder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+)
+
+func gzipBytes(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		t.Fatalf("gzip write error = %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("gzip close error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecode_Gzip(t *testing.T) {
+	input := "\uFEFF0 HEAD\r\n1 GEDC\r\n2 VERS 5.5.1\r\n1 CHAR UTF-8\r\n" +
+		"0 @I1@ INDI\r\n1 NAME John /Doe/\r\n1 NOTE First part \r\n2 CONC second part\r\n" +
+		"0 TRLR\r\n"
+	compressed := gzipBytes(t, input)
+
+	var lastRead, lastTotal int64
+	opts := DefaultOptions()
+	opts.PreserveFormatting = true
+	opts.TotalSize = int64(len(compressed))
+	opts.OnProgress = func(bytesRead, totalBytes int64) {
+		lastRead, lastTotal = bytesRead, totalBytes
+	}
+	doc, err := DecodeWithOptions(bytes.NewReader(compressed), opts)
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+
+	ind := doc.GetIndividual("@I1@")
+	if ind == nil || len(ind.InlineNotes) != 1 || ind.InlineNotes[0] != "First part second part" {
+		t.Fatalf("individual = %+v", ind)
+	}
+	// The BOM and line endings are those of the decompressed text.
+	if doc.Formatting == nil || !doc.Formatting.BOM || doc.Formatting.LineEnding != "\r\n" {
+		t.Errorf("Formatting = %+v", doc.Formatting)
+	}
+	// Progress counts compressed bytes, matching a compressed TotalSize.
+	if lastRead != int64(len(compressed)) || lastTotal != int64(len(compressed)) {
+		t.Errorf("progress = %d/%d, want %d/%d", lastRead, lastTotal, len(compressed), len(compressed))
+	}
+
+	result, err := DecodeWithDiagnostics(bytes.NewReader(compressed), nil)
+	if err != nil {
+		t.Fatalf("DecodeWithDiagnostics() error = %v", err)
+	}
+	if result.Document.GetIndividual("@I1@") == nil {
+		t.Error("DecodeWithDiagnostics() did not decompress input")
+	}
+}
+
+func TestDecode_GzipCorrupt(t *testing.T) {
+	if _, err := Decode(bytes.NewReader([]byte{0x1f, 0x8b, 0x00})); err == nil || !strings.Contains(err.Error(), "gzip") {
+		t.Errorf("Decode(truncated gzip) error = %v, want gzip error", err)
+	}
+
+	// Input too short to hold the magic number is not mistaken for gzip.
+	if _, err := Decode(strings.NewReader("0")); err != nil && strings.Contains(err.Error(), "gzip") {
+		t.Errorf("Decode(short input) error = %v", err)
+	}
+}
@@ -21,6 +21,11 @@ type DecodeResult struct {
 	// Diagnostics contains all issues encountered during parsing.
 	// Empty if parsing was successful or StrictMode was enabled.
 	Diagnostics Diagnostics
+
+	// UnmappedTags lists, in file order, the record tags that were not
+	// mapped into typed entity fields and whose data is only available
+	// from raw Tags. Collected in both lenient and strict mode.
+	UnmappedTags []UnmappedTag
 }
 
 // Decode parses a GEDCOM file from an io.Reader and returns a Document.
@@ -44,26 +49,24 @@ func DecodeWithOptions(r io.Reader, opts *DecodeOptions) (*gedcom.Document, erro
 		}
 	}
 
-	// Wrap reader with UTF-8 validation
-	validatedReader := charset.NewReader(r)
-
-	// Wrap with progress tracking if callback provided
-	finalReader := validatedReader
-	if opts.OnProgress != nil {
-		finalReader = &progressReader{
-			reader:    validatedReader,
-			totalSize: opts.TotalSize,
-			callback:  opts.OnProgress,
-		}
+	finalReader, sniffer, err := openInput(r, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	// Parse all lines
 	p := parser.NewParser()
-	lines, err := p.Parse(finalReader)
+	lines, _, err := p.ParseWithOptions(finalReader, parseOptions(opts, false))
 	if err != nil {
+		if le := limitError(err, opts); le != nil {
+			return nil, le
+		}
 		// Preserve charset errors in the error message
 		return nil, err
 	}
+	if err := checkLineLimits(lines, opts); err != nil {
+		return nil, err
+	}
 
 	// Check context after parsing
 	if opts.Context != nil {
@@ -82,16 +85,22 @@ func DecodeWithOptions(r io.Reader, opts *DecodeOptions) (*gedcom.Document, erro
 
 	// Build document from lines
 	doc := buildDocument(lines, detectedVersion)
+	applyFormatting(doc, sniffer, p.TrailingTrivia())
 
-	// Convert raw tags to proper entity types
-	// Pass nil collector for existing API (no diagnostics collection)
-	populateEntities(doc, nil)
+	// Convert raw tags to proper entity types. Diagnostics are not
+	// collected here; a collector is only needed to log unmapped tags.
+	var collector *diagnosticCollector
+	if opts.Logger != nil {
+		collector = &diagnosticCollector{logger: opts.Logger, ctx: opts.Context}
+	}
+	populateEntities(doc, collector)
 
 	return doc, nil
 }
 
 // DecodeWithDiagnostics parses a GEDCOM file and returns both the document and any diagnostics.
-// This function enables lenient parsing mode when StrictMode is false (the default).
+// This function enables lenient parsing mode unless StrictMode is set or
+// Strictness is gedcom.StrictnessStrict.
 //
 // In lenient mode:
 //   - Parse errors are collected as diagnostics rather than stopping parsing
@@ -104,7 +113,7 @@ func DecodeWithOptions(r io.Reader, opts *DecodeOptions) (*gedcom.Document, erro
 //   - An error is returned with a nil *DecodeResult only when no valid lines
 //     could be parsed at all
 //
-// In strict mode (StrictMode=true):
+// In strict mode (StrictMode=true or Strictness=gedcom.StrictnessStrict):
 //   - Parsing fails on the first error (current behavior)
 //   - Diagnostics will be empty on success
 //
@@ -123,17 +132,9 @@ func DecodeWithDiagnostics(r io.Reader, opts *DecodeOptions) (*DecodeResult, err
 		}
 	}
 
-	// Wrap reader with UTF-8 validation
-	validatedReader := charset.NewReader(r)
-
-	// Wrap with progress tracking if callback provided
-	finalReader := validatedReader
-	if opts.OnProgress != nil {
-		finalReader = &progressReader{
-			reader:    validatedReader,
-			totalSize: opts.TotalSize,
-			callback:  opts.OnProgress,
-		}
+	finalReader, sniffer, err := openInput(r, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	// Parse with appropriate mode
@@ -146,20 +147,28 @@ func DecodeWithDiagnostics(r io.Reader, opts *DecodeOptions) (*DecodeResult, err
 	// population) and surface fatalErr alongside the result at the end.
 	var fatalErr error
 
-	if opts.StrictMode {
-		// Strict mode: use existing Parse behavior
-		parsedLines, err := p.Parse(finalReader)
+	if opts.strict() {
+		// Strict mode: fail on the first error
+		parsedLines, _, err := p.ParseWithOptions(finalReader, parseOptions(opts, false))
 		if err != nil {
+			if le := limitError(err, opts); le != nil {
+				return nil, le
+			}
 			return nil, err
 		}
 		lines = parsedLines
 	} else {
-		// Lenient mode: collect errors and continue
-		parseOpts := &parser.ParseOptions{
-			Lenient:   true,
-			MaxErrors: 0, // Collect all errors
+		// Lenient mode: collect all errors and continue, except for
+		// exceeded limits, which stop decoding in every mode
+		parsedLines, parseErrors, fe := p.ParseWithOptions(finalReader, parseOptions(opts, true))
+		if le := limitError(fe, opts); le != nil {
+			return nil, le
+		}
+		for _, pe := range parseErrors {
+			if le := limitError(pe, opts); le != nil {
+				return nil, le
+			}
 		}
-		parsedLines, parseErrors, fe := p.ParseWithOptions(finalReader, parseOpts)
 
 		// Convert parse errors to diagnostics
 		diagnostics = convertParseErrors(parseErrors)
@@ -186,6 +195,10 @@ func DecodeWithDiagnostics(r io.Reader, opts *DecodeOptions) (*DecodeResult, err
 		}
 	}
 
+	if err := checkLineLimits(lines, opts); err != nil {
+		return nil, err
+	}
+
 	// Check if we have any data to work with
 	if len(lines) == 0 {
 		// No valid lines parsed - return empty document with diagnostics
@@ -215,34 +228,36 @@ func DecodeWithDiagnostics(r io.Reader, opts *DecodeOptions) (*DecodeResult, err
 		detectedVersion = ""
 	}
 
-	// Create a collector for entity-level diagnostics if in lenient mode
-	var collector *diagnosticCollector
-	if !opts.StrictMode {
-		collector = &diagnosticCollector{
-			lenient: true,
-		}
+	// Create a collector for entity-level diagnostics (kept in lenient
+	// mode only) and unmapped tags
+	collector := &diagnosticCollector{
+		lenient: !opts.strict(),
+		logger:  opts.Logger,
+		ctx:     opts.Context,
 	}
 
 	// Lenient mode: recover from malformed indentation in real-world exports by
 	// clamping over-jumped levels to prevLevel+1 and emitting CodeBadLevelJump.
-	if !opts.StrictMode {
+	if !opts.strict() {
 		normalizeLevelJumps(lines, collector)
 	}
 
 	// Build document from lines
 	doc := buildDocument(lines, detectedVersion)
+	applyFormatting(doc, sniffer, p.TrailingTrivia())
 
 	// Convert raw tags to proper entity types
 	populateEntities(doc, collector)
 
 	// Merge entity-level diagnostics with parser diagnostics
-	if collector != nil {
+	if collector.lenient {
 		diagnostics = append(diagnostics, collector.diagnostics...)
 	}
 
 	return &DecodeResult{
-		Document:    doc,
-		Diagnostics: diagnostics,
+		Document:     doc,
+		Diagnostics:  diagnostics,
+		UnmappedTags: collector.unmappedTags,
 	}, fatalErr
 }
 
@@ -305,22 +320,27 @@ func buildDocument(lines []*parser.Line, ver gedcom.Version) *gedcom.Document {
 func buildHeader(doc *gedcom.Document, lines []*parser.Line, ver gedcom.Version) {
 	inHead := false
 	inSour := false
+	inPlac := false
 
 	for i, line := range lines {
 		if line.Level == 0 && line.Tag == "HEAD" {
 			inHead = true
+			doc.Header.Trivia = appendTrivia(doc.Header.Trivia, line.Trivia, 0)
 			continue
 		}
 
 		if line.Level == 0 {
 			inHead = false
 			inSour = false
+			inPlac = false
 		}
 
 		if !inHead {
 			continue
 		}
 
+		doc.Header.Trivia = appendTrivia(doc.Header.Trivia, line.Trivia, len(doc.Header.Tags)+1)
+
 		// Preserve every header sub-tag in raw form (lossless dual storage,
 		// mirroring how buildRecords populates Record.Tags). Typed fields are
 		// still extracted below; this guarantees custom/unmapped header tags
@@ -333,6 +353,11 @@ func buildHeader(doc *gedcom.Document, lines []*parser.Line, ver gedcom.Version)
 			LineNumber: line.LineNumber,
 		})
 
+		// Track when we're inside the PLAC structure
+		if line.Level == 1 {
+			inPlac = line.Tag == "PLAC"
+		}
+
 		// Track when we're inside SOUR structure
 		if line.Level == 1 && line.Tag == "SOUR" {
 			inSour = true
@@ -366,6 +391,15 @@ func buildHeader(doc *gedcom.Document, lines []*parser.Line, ver gedcom.Version)
 			doc.Header.Language = line.Value
 		case "COPR":
 			doc.Header.Copyright = line.Value
+		case "SUBM":
+			if line.Level == 1 {
+				doc.Header.Submitter = line.Value
+			}
+		case "FORM":
+			// Default place hierarchy (HEAD.PLAC.FORM)
+			if inPlac && line.Level == 2 {
+				doc.Header.PlaceForm = line.Value
+			}
 		case "_TREE":
 			// Ancestry.com tree identifier (subordinate of SOUR)
 			if inSour && line.Level == 2 {
@@ -427,6 +461,9 @@ func buildRecords(doc *gedcom.Document, lines []*parser.Line) {
 
 			// Skip HEAD and TRLR
 			if line.Tag == "HEAD" || line.Tag == "TRLR" {
+				if line.Tag == "TRLR" {
+					doc.Trailer.Trivia = appendTrivia(doc.Trailer.Trivia, line.Trivia, 0)
+				}
 				currentRecord = nil
 				continue
 			}
@@ -437,6 +474,7 @@ func buildRecords(doc *gedcom.Document, lines []*parser.Line) {
 				Type:       gedcom.RecordType(line.Tag),
 				Value:      line.Value,
 				LineNumber: line.LineNumber,
+				Trivia:     appendTrivia(nil, line.Trivia, 0),
 			}
 
 			// Index in XRefMap if it has an XRef
@@ -449,6 +487,7 @@ func buildRecords(doc *gedcom.Document, lines []*parser.Line) {
 
 		// Add tags to current record
 		if currentRecord != nil {
+			currentRecord.Trivia = appendTrivia(currentRecord.Trivia, line.Trivia, len(currentTags)+1)
 			tag := &gedcom.Tag{
 				Level:      line.Level,
 				Tag:        line.Tag,
@@ -465,3 +504,39 @@ func buildRecords(doc *gedcom.Document, lines []*parser.Line) {
 		doc.Records = append(doc.Records, currentRecord)
 	}
 }
+
+// openInput wraps r with the readers every decode uses: gzip decompression
+// when the input starts with the gzip magic number, the MaxTotalSize limit,
+// the format sniffer when preserving formatting, charset normalization, and
+// progress reporting.
+// Progress counts the bytes read from r, so for compressed input it is
+// measured against the compressed TotalSize.
+func openInput(r io.Reader, opts *DecodeOptions) (io.Reader, *formatSniffer, error) {
+	var progress *progressReader
+	if opts.OnProgress != nil {
+		progress = &progressReader{
+			reader:    r,
+			totalSize: opts.TotalSize,
+			callback:  opts.OnProgress,
+		}
+		r = progress
+	}
+
+	r, err := decompress(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.MaxTotalSize > 0 {
+		r = &sizeLimitReader{reader: r, max: opts.MaxTotalSize}
+	}
+
+	// Record line endings and BOM before the charset reader strips them
+	var sniffer *formatSniffer
+	if opts.PreserveFormatting {
+		sniffer = &formatSniffer{reader: r}
+		r = sniffer
+	}
+
+	// Wrap reader with UTF-8 validation
+	return charset.NewReader(r), sniffer, nil
+}
@@ -1,8 +1,11 @@
 package decoder
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -275,6 +278,25 @@ func TestDecodeHeaderVersionFromGEDC(t *testing.T) {
 	}
 }
 
+// Legacy exports often declare CHAR after a long header; ANSEL names must
+// still be converted, with their diacritics composed.
+func TestDecodeANSELLongHeader(t *testing.T) {
+	var input []byte
+	input = append(input, "0 HEAD\r\n1 SOUR PAF\r\n2 NAME Personal Ancestral File\r\n"...)
+	for i := 0; i < 30; i++ {
+		input = append(input, "1 NOTE Exported from a legacy application with a long header\r\n"...)
+	}
+	input = append(input, "1 GEDC\r\n2 VERS 5.5\r\n1 CHAR ANSEL\r\n0 @I1@ INDI\r\n1 NAME Jos\xE2e /M\xE8uller/\r\n0 TRLR\r\n"...)
+
+	doc, err := Decode(strings.NewReader(string(input)))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got, want := doc.GetIndividual("@I1@").Names[0].Full, "Jos\u00E9 /M\u00FCller/"; got != want {
+		t.Errorf("Names[0].Full = %q, want %q", got, want)
+	}
+}
+
 // Test header with all optional fields
 func TestDecodeHeaderComplete(t *testing.T) {
 	input := `0 HEAD
@@ -282,6 +304,7 @@ func TestDecodeHeaderComplete(t *testing.T) {
 2 VERS 5.5.1
 1 CHAR ANSEL
 1 SOUR FamilyTreeMaker
+1 SUBM @U1@
 1 LANG French
 0 TRLR`
 
@@ -302,6 +325,28 @@ func TestDecodeHeaderComplete(t *testing.T) {
 	if doc.Header.Language != "French" {
 		t.Errorf("Header.Language = %q, want %q", doc.Header.Language, "French")
 	}
+	if doc.Header.Submitter != "@U1@" {
+		t.Errorf("Header.Submitter = %q, want %q", doc.Header.Submitter, "@U1@")
+	}
+}
+
+func TestDecodeHeaderPlaceForm(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 SOUR App
+2 FORM ignored
+1 PLAC
+2 FORM City, County, State, Country
+0 TRLR`
+
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got, want := doc.Header.PlaceForm, "City, County, State, Country"; got != want {
+		t.Errorf("Header.PlaceForm = %q, want %q", got, want)
+	}
 }
 
 // TestDecodeHeaderPreservesRawTags verifies that all header sub-tags are kept
@@ -744,3 +789,55 @@ func TestDecodeSchemaDefinition(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodePreserveFormatting(t *testing.T) {
+	input := "0 HEAD\r\n1 GEDC\r\n2 VERS 5.5.1\r\n\r\n0 @I1@ INDI\r\n1 NAME John /Smith/\r\n# census\r\n1 BIRT\r\n0 TRLR"
+
+	opts := DefaultOptions()
+	opts.PreserveFormatting = true
+	result, err := DecodeWithDiagnostics(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatalf("DecodeWithDiagnostics() error = %v", err)
+	}
+	if len(result.Diagnostics) != 0 {
+		t.Errorf("Diagnostics = %v, want none", result.Diagnostics)
+	}
+	doc := result.Document
+	want := []gedcom.Trivia{{Offset: 0, Text: ""}, {Offset: 2, Text: "# census"}}
+	if got := doc.Records[0].Trivia; len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("record Trivia = %+v, want %+v", got, want)
+	}
+	if f := doc.Formatting; f == nil || f.LineEnding != "\r\n" || f.BOM || !f.NoFinalLineEnding {
+		t.Errorf("Formatting = %+v", f)
+	}
+
+	// By default blank lines are skipped with a diagnostic and nothing is kept.
+	result, err = DecodeWithDiagnostics(strings.NewReader(input), DefaultOptions())
+	if err != nil {
+		t.Fatalf("DecodeWithDiagnostics() error = %v", err)
+	}
+	if result.Document.Formatting != nil || result.Document.Records[0].Trivia != nil {
+		t.Error("formatting kept without PreserveFormatting")
+	}
+	if len(result.Diagnostics) != 2 {
+		t.Errorf("Diagnostics = %v, want 2", result.Diagnostics)
+	}
+}
+
+func TestDecodePreserveFormatting_UTF16(t *testing.T) {
+	for _, name := range []string{"utf16le.ged", "utf16be-nobom.ged"} {
+		data, err := os.ReadFile(filepath.Join("../testdata/encoding", name))
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		opts := DefaultOptions()
+		opts.PreserveFormatting = true
+		doc, err := DecodeWithOptions(bytes.NewReader(data), opts)
+		if err != nil {
+			t.Fatalf("%s: DecodeWithOptions() error = %v", name, err)
+		}
+		if f := doc.Formatting; f == nil || f.LineEnding != "\r\n" || f.BOM || f.NoFinalLineEnding {
+			t.Errorf("%s: Formatting = %+v", name, f)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/cacack/gedcom-go/v2/gedcom"
 	"github.com/cacack/gedcom-go/v2/parser"
 )
 
@@ -120,6 +121,32 @@ invalid line here
 	}
 }
 
+// TestDecodeWithDiagnosticsStrictness tests that Strictness selects strict
+// mode only at gedcom.StrictnessStrict.
+func TestDecodeWithDiagnosticsStrictness(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5
+invalid line here
+0 @I1@ INDI
+0 TRLR`
+
+	for _, s := range []gedcom.Strictness{gedcom.StrictnessLenient, gedcom.StrictnessNormal} {
+		result, err := DecodeWithDiagnostics(strings.NewReader(input), &DecodeOptions{Strictness: s})
+		if err != nil {
+			t.Fatalf("DecodeWithDiagnostics(%v) error = %v", s, err)
+		}
+		if !result.Diagnostics.HasErrors() {
+			t.Errorf("DecodeWithDiagnostics(%v) should report the invalid line", s)
+		}
+	}
+
+	result, err := DecodeWithDiagnostics(strings.NewReader(input), &DecodeOptions{Strictness: gedcom.StrictnessStrict})
+	if err == nil || result != nil {
+		t.Errorf("DecodeWithDiagnostics(strict) = %v, %v, want an error", result, err)
+	}
+}
+
 // TestDecodeWithDiagnosticsEmptyInput tests handling of empty input
 func TestDecodeWithDiagnosticsEmptyInput(t *testing.T) {
 	input := ""
@@ -19,4 +19,18 @@
 //	}
 //
 //	fmt.Printf("Found %d individuals\n", len(doc.Individuals()))
+//
+// Gzip-compressed input, such as a .ged.gz file, is detected by its magic
+// number and decompressed transparently.
+//
+// The Max* fields of DecodeOptions limit the records, nesting depth, line
+// length, total size, and note size a decode accepts; exceeding one returns
+// a *LimitError. ServerOptions sets limits suited to untrusted uploads.
+//
+// EventFromTags, CitationFromTags, and the other FromTags functions build
+// typed values from any tag subtree, as the decoder does for INDI and FAM
+// records, so that structures the library does not model (such as events
+// nested in vendor extensions) can be read without walking tags by hand.
+// ExtractEvents and ExtractCitations collect every event or citation among
+// the top-level structures of a tag slice.
 package decoder
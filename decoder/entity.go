@@ -1,18 +1,26 @@
 package decoder
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"strconv"
 	"strings"
 
 	"github.com/cacack/gedcom-go/v2/gedcom"
 )
 
-// diagnosticCollector accumulates diagnostics during entity population.
-// It is nil-safe: all methods check for nil receiver before acting.
+// diagnosticCollector accumulates diagnostics and unmapped tags during
+// entity population. It is nil-safe: all methods check for nil receiver
+// before acting.
 type diagnosticCollector struct {
 	diagnostics Diagnostics
 	lenient     bool
+
+	unmapped     map[*gedcom.Tag]bool // tags of the current record left unmapped
+	unmappedTags []UnmappedTag
+	logger       *slog.Logger // optional; receives each unmapped tag
+	ctx          context.Context
 }
 
 // add appends a diagnostic to the collector if the collector is non-nil.
@@ -52,6 +60,7 @@ func (c *diagnosticCollector) addInvalidValue(lineNumber int, tag, value, reason
 // If collector is nil, no diagnostics are collected (backward compatible behavior).
 func populateEntities(doc *gedcom.Document, collector *diagnosticCollector) {
 	for _, record := range doc.Records {
+		populated := true
 		switch record.Type {
 		case gedcom.RecordTypeIndividual:
 			record.Entity = parseIndividual(record, collector)
@@ -69,7 +78,11 @@ func populateEntities(doc *gedcom.Document, collector *diagnosticCollector) {
 			record.Entity = parseMediaObject(record, collector)
 		case gedcom.RecordTypeSharedNote:
 			record.Entity = parseSharedNote(record, collector)
+		default:
+			populated = false
 		}
+		record.PopulateExtensionData()
+		collector.collectUnmapped(record, populated)
 	}
 }
 
@@ -96,11 +109,8 @@ func parseIndividual(record *gedcom.Record, collector *diagnosticCollector) *ged
 		case "SEX":
 			indi.Sex = tag.Value
 
-		case "BIRT", "DEAT", "BAPM", "BURI", "CENS", "CHR", "ADOP", "RESI", "IMMI", "EMIG",
-			"BARM", "BASM", "BLES", "CHRA", "CONF", "FCOM",
-			"GRAD", "RETI", "NATU", "ORDN", "PROB", "WILL", "CREM":
-			event := parseEvent(record.Tags, i, tag.Tag, collector)
-			indi.Events = append(indi.Events, event)
+		case "RESN":
+			indi.Restriction = gedcom.Restriction(tag.Value)
 
 		case "NO":
 			// GEDCOM 7.0: NO tag indicates event did not occur
@@ -132,6 +142,9 @@ func parseIndividual(record *gedcom.Record, collector *diagnosticCollector) *ged
 			assoc := parseAssociation(record.Tags, i, collector)
 			indi.Associations = append(indi.Associations, assoc)
 
+		case "ALIA":
+			indi.Aliases = append(indi.Aliases, tag.Value)
+
 		case "SOUR":
 			cite := parseSourceCitation(record.Tags, i, tag.Level, collector)
 			indi.SourceCitations = append(indi.SourceCitations, cite)
@@ -150,7 +163,13 @@ func parseIndividual(record *gedcom.Record, collector *diagnosticCollector) *ged
 			indi.CreationDate = parseChangeDate(record.Tags, i, collector)
 
 		case "REFN":
-			indi.RefNumber = tag.Value
+			indi.UserReferences = append(indi.UserReferences, parseUserReference(record.Tags, i))
+			if indi.RefNumber == "" {
+				indi.RefNumber = tag.Value
+			}
+
+		case "RIN":
+			indi.AutomatedRecordID = tag.Value
 
 		case "UID":
 			indi.UID = tag.Value
@@ -165,12 +184,20 @@ func parseIndividual(record *gedcom.Record, collector *diagnosticCollector) *ged
 		case "EXID":
 			indi.ExternalIDs = append(indi.ExternalIDs, parseExternalID(record.Tags, i))
 
+		case gedcom.TaskTagTodo, gedcom.TaskTagResearch:
+			indi.Tasks = append(indi.Tasks, gedcom.TaskFromTags(record.Tags, i))
+
 		default:
+			// Events (BIRT, DEAT, ...) come from the event-type registry so
+			// that applications can register vendor events such as _MILT.
+			if gedcom.IsEventType(tag.Tag, gedcom.EventScopeIndividual) {
+				event := parseEvent(record.Tags, i, tag.Tag, collector)
+				indi.Events = append(indi.Events, event)
+				continue
+			}
 			// Unknown tag - record diagnostic but continue processing
 			// Tags starting with _ are vendor extensions and expected
-			if !strings.HasPrefix(tag.Tag, "_") {
-				collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-			}
+			collector.addUnmappedTag(tag)
 		}
 	}
 
@@ -198,13 +225,14 @@ func parsePersonalName(tags []*gedcom.Tag, nameIdx int, collector *diagnosticCol
 		name.Given = strings.TrimSpace(full)
 	}
 
-	// Look for subordinate tags (level 2)
+	// Look for subordinate tags at baseLevel+1 (level 2 for INDI.NAME)
+	baseLevel := tags[nameIdx].Level
 	for i := nameIdx + 1; i < len(tags); i++ {
 		tag := tags[i]
-		if tag.Level <= 1 {
+		if tag.Level <= baseLevel {
 			break
 		}
-		if tag.Level == 2 {
+		if tag.Level == baseLevel+1 {
 			switch tag.Tag {
 			case "GIVN":
 				name.Given = tag.Value
@@ -226,10 +254,9 @@ func parsePersonalName(tags []*gedcom.Tag, nameIdx int, collector *diagnosticCol
 			case "SOUR", "NOTE", "FONE", "ROMN":
 				// Known tags that we don't parse into typed fields (yet)
 				// SOUR/NOTE are common, FONE/ROMN are GEDCOM 5.5.1 phonetic/romanized variants
+				collector.markUnmapped(tag)
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -269,9 +296,7 @@ func parseNameTransliteration(tags []*gedcom.Tag, tranIdx int, collector *diagno
 			case "SPFX":
 				tran.SurnamePrefix = tag.Value
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -294,13 +319,12 @@ func parseFamilyLink(tags []*gedcom.Tag, famcIdx int, collector *diagnosticColle
 		if tag.Level == 2 {
 			switch tag.Tag {
 			case "PEDI":
-				famLink.Pedigree = tag.Value
+				famLink.Pedigree = gedcom.Pedigree(tag.Value)
 			case "STAT", "NOTE":
 				// Known tags not yet parsed into typed fields
+				collector.markUnmapped(tag)
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -329,14 +353,12 @@ func parseAssociation(tags []*gedcom.Tag, assoIdx int, collector *diagnosticColl
 			case "PHRASE":
 				assoc.Phrase = tag.Value
 			case "NOTE":
-				assoc.Notes = append(assoc.Notes, tag.Value)
+				assoc.Notes = append(assoc.Notes, gedcom.FoldText(tag.Value, tag.Level, tags[i+1:]))
 			case "SOUR":
 				cite := parseSourceCitation(tags, i, tag.Level, collector)
 				assoc.SourceCitations = append(assoc.SourceCitations, cite)
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -365,10 +387,36 @@ func parseExternalID(tags []*gedcom.Tag, exidIdx int) *gedcom.ExternalID {
 	return exid
 }
 
+// parseUserReference parses a REFN tag with optional TYPE subordinate.
+func parseUserReference(tags []*gedcom.Tag, refnIdx int) *gedcom.UserReference {
+	baseLevel := tags[refnIdx].Level
+	ref := &gedcom.UserReference{
+		Number: tags[refnIdx].Value,
+	}
+	for i := refnIdx + 1; i < len(tags); i++ {
+		tag := tags[i]
+		if tag.Level <= baseLevel {
+			break
+		}
+		if tag.Level == baseLevel+1 && tag.Tag == "TYPE" {
+			ref.Type = tag.Value
+			break
+		}
+	}
+	return ref
+}
+
 // parseSourceCitation extracts a source citation from tags starting at sourIdx.
 func parseSourceCitation(tags []*gedcom.Tag, sourIdx, baseLevel int, collector *diagnosticCollector) *gedcom.SourceCitation {
-	cite := &gedcom.SourceCitation{
-		SourceXRef: tags[sourIdx].Value,
+	cite := &gedcom.SourceCitation{}
+	value := tags[sourIdx].Value
+	inline := value != "" && value != "@VOID@" && !gedcom.IsPointerXRef(value)
+	if inline {
+		// GEDCOM 5.5/5.5.1 embedded citation: the value is a source
+		// description, continued by CONT/CONC subordinates.
+		cite.InlineText = gedcom.FoldText(value, tags[sourIdx].Level, tags[sourIdx+1:])
+	} else {
+		cite.SourceXRef = value
 	}
 
 	// Look for subordinate tags at baseLevel+1
@@ -378,38 +426,75 @@ func parseSourceCitation(tags []*gedcom.Tag, sourIdx, baseLevel int, collector *
 			break
 		}
 		if tag.Level == baseLevel+1 {
-			switch tag.Tag {
-			case "PAGE":
-				cite.Page = tag.Value
-			case "QUAY":
-				// Parse quality as integer (0-3)
-				if q, err := strconv.Atoi(tag.Value); err == nil && q >= 0 && q <= 3 {
-					cite.Quality = q
-				} else {
-					collector.addInvalidValue(tag.LineNumber, "QUAY", tag.Value, "expected integer 0-3")
-				}
-			case "DATA":
-				// Parse DATA subordinates at baseLevel+2
-				cite.Data = parseSourceCitationData(tags, i, baseLevel+1, collector)
-			case "_APID":
-				// Parse Ancestry Permanent Identifier (vendor extension)
-				cite.AncestryAPID = gedcom.ParseAPID(tag.Value)
-			case "NOTE", "OBJE", "EVEN", "TEXT":
-				// Known tags not yet parsed into typed fields
-			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
-			}
+			parseSourceCitationField(cite, tags, i, inline, collector)
 		}
 	}
 
 	return cite
 }
 
+// parseSourceCitationField sets the field of cite given by the subordinate
+// tag at idx. inline reports whether the citation is embedded, so CONT and
+// CONC continue its text.
+func parseSourceCitationField(cite *gedcom.SourceCitation, tags []*gedcom.Tag, idx int, inline bool, collector *diagnosticCollector) {
+	tag := tags[idx]
+	switch tag.Tag {
+	case "PAGE":
+		cite.Page = gedcom.FoldText(tag.Value, tag.Level, tags[idx+1:])
+	case "QUAY":
+		// Parse quality as integer (0-3)
+		if q, ok := gedcom.ParseQuality(tag.Value); ok {
+			cite.Quality = q
+			cite.HasQuality = true
+		} else {
+			collector.addInvalidValue(tag.LineNumber, "QUAY", tag.Value, "expected integer 0-3")
+		}
+	case "DATA":
+		// Parse DATA subordinates
+		cite.Data = parseSourceCitationData(tags, idx, tag.Level, collector)
+	case "_APID":
+		// Parse Ancestry Permanent Identifier (vendor extension)
+		cite.AncestryAPID = gedcom.ParseAPID(tag.Value)
+	case "TEXT":
+		cite.Text = append(cite.Text, gedcom.FoldText(tag.Value, tag.Level, tags[idx+1:]))
+	case "CONT", "CONC":
+		if !inline {
+			collector.addUnmappedTag(tag)
+		}
+	case "EVEN":
+		cite.Event = tag.Value
+		cite.Role = parseCitationRole(tags, idx)
+	case "OBJE":
+		cite.Media = append(cite.Media, parseMediaLink(tags, idx, tag.Level, collector))
+	case "NOTE":
+		// Known tags not yet parsed into typed fields
+		collector.markUnmapped(tag)
+	default:
+		if gedcom.IsSuretyTag(tag.Tag) {
+			cite.Surety = tag.Value
+			cite.SuretyTag = tag.Tag
+		} else {
+			collector.addUnmappedTag(tag)
+		}
+	}
+}
+
+// parseCitationRole returns the ROLE under the citation EVEN at evenIdx.
+func parseCitationRole(tags []*gedcom.Tag, evenIdx int) string {
+	role := ""
+	level := tags[evenIdx].Level
+	for j := evenIdx + 1; j < len(tags) && tags[j].Level > level; j++ {
+		if tags[j].Level == level+1 && tags[j].Tag == "ROLE" {
+			role = tags[j].Value
+		}
+	}
+	return role
+}
+
 // parseSourceCitationData extracts source citation data from tags starting at dataIdx.
 func parseSourceCitationData(tags []*gedcom.Tag, dataIdx, baseLevel int, collector *diagnosticCollector) *gedcom.SourceCitationData {
 	data := &gedcom.SourceCitationData{}
+	hasText := false
 
 	// Look for subordinate tags at baseLevel+1
 	for i := dataIdx + 1; i < len(tags); i++ {
@@ -422,11 +507,16 @@ func parseSourceCitationData(tags []*gedcom.Tag, dataIdx, baseLevel int, collect
 			case "DATE":
 				data.Date = tag.Value
 			case "TEXT":
-				data.Text = tag.Value
-			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
+				if hasText {
+					data.AdditionalText = append(data.AdditionalText, gedcom.FoldText(tag.Value, tag.Level, tags[i+1:]))
+				} else {
+					data.Text = gedcom.FoldText(tag.Value, tag.Level, tags[i+1:])
+					hasText = true
 				}
+			case "WWW":
+				data.Website = append(data.Website, gedcom.FoldText(tag.Value, tag.Level, tags[i+1:]))
+			default:
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -479,7 +569,7 @@ func parseEvent(tags []*gedcom.Tag, eventIdx int, eventTag string, collector *di
 			case "TYPE":
 				event.EventTypeDetail = tag.Value
 			case "CAUS":
-				event.Cause = tag.Value
+				event.Cause = gedcom.FoldText(tag.Value, tag.Level, tags[i+1:])
 			case "AGE":
 				event.Age = tag.Value
 			case "AGNC":
@@ -493,28 +583,39 @@ func parseEvent(tags []*gedcom.Tag, eventIdx int, eventTag string, collector *di
 			case "FAX":
 				event.Fax = append(event.Fax, tag.Value)
 			case "WWW":
-				event.Website = append(event.Website, tag.Value)
+				event.Website = append(event.Website, gedcom.FoldText(tag.Value, tag.Level, tags[i+1:]))
 			case "RESN":
-				event.Restriction = tag.Value
+				event.Restriction = gedcom.Restriction(tag.Value)
 			case "UID":
 				event.UID = tag.Value
 			case "SDATE":
 				event.SortDate = tag.Value
+			case "_SDATE":
+				event.VendorSortDate = tag.Value
+			case "_SORT":
+				// Some programs keep a sort date here, others a sort
+				// string; only dates order events.
+				if _, err := gedcom.ParseSortDate(tag.Value); err == nil && event.VendorSortDate == "" {
+					event.VendorSortDate = tag.Value
+				} else {
+					collector.addUnmappedTag(tag)
+				}
 			case "NOTE":
-				event.Notes = append(event.Notes, tag.Value)
+				event.Notes = append(event.Notes, gedcom.FoldText(tag.Value, tag.Level, tags[i+1:]))
 			case "SOUR":
 				cite := parseSourceCitation(tags, i, tag.Level, collector)
 				event.SourceCitations = append(event.SourceCitations, cite)
 			case "OBJE":
 				link := parseMediaLink(tags, i, tag.Level, collector)
 				event.Media = append(event.Media, link)
+			case "ASSO":
+				event.Associations = append(event.Associations, parseAssociation(tags, i, collector))
 			case "HUSB", "WIFE":
 				// These appear in family events (marriage, etc.) for spouse ages
 				// Known tags not yet parsed into typed fields
+				collector.markUnmapped(tag)
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -562,9 +663,7 @@ func parseEventAddress(tags []*gedcom.Tag, addrIdx, baseLevel int, collector *di
 				// Concatenate to address
 				addr.Line1 += tag.Value
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -592,10 +691,9 @@ func parsePlaceDetail(tags []*gedcom.Tag, placIdx, baseLevel int, collector *dia
 				place.Coordinates = parseCoordinates(tags, i, tag.Level, collector)
 			case "FONE", "ROMN", "TRAN", "NOTE", "EXID", "LANG":
 				// Known tags not yet parsed into typed fields
+				collector.markUnmapped(tag)
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -620,9 +718,7 @@ func parseCoordinates(tags []*gedcom.Tag, mapIdx, baseLevel int, collector *diag
 			case "LONG":
 				coords.Longitude = tag.Value
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -634,16 +730,17 @@ func parseCoordinates(tags []*gedcom.Tag, mapIdx, baseLevel int, collector *diag
 func parseAttribute(tags []*gedcom.Tag, attrIdx int, attrTag string, collector *diagnosticCollector) *gedcom.Attribute {
 	attr := &gedcom.Attribute{
 		Type:  attrTag,
-		Value: tags[attrIdx].Value,
+		Value: gedcom.FoldText(tags[attrIdx].Value, tags[attrIdx].Level, tags[attrIdx+1:]),
 	}
 
-	// Look for subordinate tags (level 2)
+	// Look for subordinate tags at baseLevel+1 (level 2 for INDI attributes)
+	baseLevel := tags[attrIdx].Level
 	for i := attrIdx + 1; i < len(tags); i++ {
 		tag := tags[i]
-		if tag.Level <= 1 {
+		if tag.Level <= baseLevel {
 			break
 		}
-		if tag.Level == 2 {
+		if tag.Level == baseLevel+1 {
 			switch tag.Tag {
 			case "DATE":
 				attr.Date = tag.Value
@@ -657,12 +754,13 @@ func parseAttribute(tags []*gedcom.Tag, attrIdx int, attrTag string, collector *
 			case "SOUR":
 				cite := parseSourceCitation(tags, i, tag.Level, collector)
 				attr.SourceCitations = append(attr.SourceCitations, cite)
+			case "CONT", "CONC":
+				// Continuation of the attribute value, folded above
 			case "TYPE", "NOTE", "AGE":
 				// Known tags not yet parsed into typed fields
+				collector.markUnmapped(tag)
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -721,10 +819,9 @@ func parseLDSOrdinance(tags []*gedcom.Tag, ordIdx int, ordType gedcom.LDSOrdinan
 				ord.FamilyXRef = tag.Value
 			case "NOTE", "SOUR":
 				// Known tags not yet parsed into typed fields
+				collector.markUnmapped(tag)
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -748,11 +845,22 @@ func parseFamily(record *gedcom.Record, collector *diagnosticCollector) *gedcom.
 		}
 
 		switch tag.Tag {
-		case "HUSB":
-			fam.Husband = tag.Value
+		case "HUSB", "WIFE":
+			// The first HUSB and WIFE fill Husband and Wife; repeats (two
+			// HUSB for a same-sex couple) are kept in Spouses.
+			if tag.Tag == "HUSB" && fam.Husband == "" {
+				fam.Husband = tag.Value
+			} else if tag.Tag == "WIFE" && fam.Wife == "" {
+				fam.Wife = tag.Value
+			}
+			fam.Spouses = append(fam.Spouses, &gedcom.FamilySpouse{XRef: tag.Value, Tag: tag.Tag})
 
-		case "WIFE":
-			fam.Wife = tag.Value
+		case "ASSO":
+			assoc := parseAssociation(record.Tags, i, collector)
+			fam.Associations = append(fam.Associations, assoc)
+			if gedcom.IsPartnerRole(assoc.Role) {
+				fam.Spouses = append(fam.Spouses, &gedcom.FamilySpouse{XRef: assoc.IndividualXRef, Tag: "ASSO", Role: assoc.Role})
+			}
 
 		case "CHIL":
 			fam.Children = append(fam.Children, tag.Value)
@@ -760,10 +868,6 @@ func parseFamily(record *gedcom.Record, collector *diagnosticCollector) *gedcom.
 		case "NCHI":
 			fam.NumberOfChildren = tag.Value
 
-		case "MARR", "DIV", "ENGA", "ANUL", "MARB", "MARC", "MARL", "MARS", "DIVF", "EVEN":
-			event := parseEvent(record.Tags, i, tag.Tag, collector)
-			fam.Events = append(fam.Events, event)
-
 		case "NO":
 			// GEDCOM 7.0: NO tag indicates event did not occur
 			// tag.Value contains the event type (e.g., "MARR", "DIV")
@@ -797,7 +901,13 @@ func parseFamily(record *gedcom.Record, collector *diagnosticCollector) *gedcom.
 			fam.CreationDate = parseChangeDate(record.Tags, i, collector)
 
 		case "REFN":
-			fam.RefNumber = tag.Value
+			fam.UserReferences = append(fam.UserReferences, parseUserReference(record.Tags, i))
+			if fam.RefNumber == "" {
+				fam.RefNumber = tag.Value
+			}
+
+		case "RIN":
+			fam.AutomatedRecordID = tag.Value
 
 		case "UID":
 			fam.UID = tag.Value
@@ -805,13 +915,21 @@ func parseFamily(record *gedcom.Record, collector *diagnosticCollector) *gedcom.
 		case "EXID":
 			fam.ExternalIDs = append(fam.ExternalIDs, parseExternalID(record.Tags, i))
 
-		case "RESN", "SUBM", "ASSO":
+		case "RESN":
+			fam.Restriction = gedcom.Restriction(tag.Value)
+
+		case "SUBM":
 			// Known tags not yet parsed into typed fields
+			collector.markUnmapped(tag)
 
 		default:
-			if !strings.HasPrefix(tag.Tag, "_") {
-				collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
+			// Events (MARR, DIV, ...) come from the event-type registry.
+			if gedcom.IsEventType(tag.Tag, gedcom.EventScopeFamily) {
+				event := parseEvent(record.Tags, i, tag.Tag, collector)
+				fam.Events = append(fam.Events, event)
+				continue
 			}
+			collector.addUnmappedTag(tag)
 		}
 	}
 
@@ -833,18 +951,22 @@ func parseSource(record *gedcom.Record, collector *diagnosticCollector) *gedcom.
 
 		switch tag.Tag {
 		case "TITL":
-			src.Title = tag.Value
+			src.Title = gedcom.FoldText(tag.Value, tag.Level, record.Tags[i+1:])
 		case "AUTH":
-			src.Author = tag.Value
+			src.Author = gedcom.FoldText(tag.Value, tag.Level, record.Tags[i+1:])
 		case "PUBL":
-			src.Publication = tag.Value
+			src.Publication = gedcom.FoldText(tag.Value, tag.Level, record.Tags[i+1:])
 		case "TEXT":
-			src.Text = tag.Value
+			src.Text = gedcom.FoldText(tag.Value, tag.Level, record.Tags[i+1:])
 		case "REPO":
-			src.RepositoryLink = parseSourceRepositoryLink(record.Tags, i, collector)
-			// Populate deprecated fields for backward compatibility.
-			src.RepositoryRef = src.RepositoryLink.XRef
-			src.Repository = src.RepositoryLink.Inline
+			link := parseSourceRepositoryLink(record.Tags, i, collector)
+			src.RepositoryLinks = append(src.RepositoryLinks, link)
+			if src.RepositoryLink == nil {
+				src.RepositoryLink = link
+				// Populate deprecated fields for backward compatibility.
+				src.RepositoryRef = link.XRef
+				src.Repository = link.Inline
+			}
 		case "NOTE", "SNOTE":
 			src.NoteXRefs, src.InlineNotes, src.Notes = appendRecordNote(record.Tags, i, src.NoteXRefs, src.InlineNotes, src.Notes)
 		case "OBJE":
@@ -855,17 +977,21 @@ func parseSource(record *gedcom.Record, collector *diagnosticCollector) *gedcom.
 		case "CREA":
 			src.CreationDate = parseChangeDate(record.Tags, i, collector)
 		case "REFN":
-			src.RefNumber = tag.Value
+			src.UserReferences = append(src.UserReferences, parseUserReference(record.Tags, i))
+			if src.RefNumber == "" {
+				src.RefNumber = tag.Value
+			}
+		case "RIN":
+			src.AutomatedRecordID = tag.Value
 		case "UID":
 			src.UID = tag.Value
 		case "EXID":
 			src.ExternalIDs = append(src.ExternalIDs, parseExternalID(record.Tags, i))
 		case "DATA", "ABBR":
 			// Known tags not yet parsed into typed fields
+			collector.markUnmapped(tag)
 		default:
-			if !strings.HasPrefix(tag.Tag, "_") {
-				collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-			}
+			collector.addUnmappedTag(tag)
 		}
 	}
 
@@ -912,7 +1038,9 @@ func parseSourceRepositoryLink(tags []*gedcom.Tag, repoIdx int, collector *diagn
 		case "CALN":
 			link.CallNumbers = append(link.CallNumbers, tag.Value)
 			// MEDI is a subordinate of CALN at baseLevel+2.
-			if medi := findSubordinate(tags, i, "MEDI"); medi != "" {
+			medi := findSubordinate(tags, i, "MEDI")
+			link.Calls = append(link.Calls, gedcom.CallNumber{Number: tag.Value, Media: medi})
+			if medi != "" {
 				if link.MediaType == "" {
 					link.MediaType = medi
 				}
@@ -925,11 +1053,9 @@ func parseSourceRepositoryLink(tags []*gedcom.Tag, repoIdx int, collector *diagn
 				link.CallNumberMedia[tag.Value] = medi
 			}
 		case "NOTE":
-			link.Notes = append(link.Notes, tag.Value)
+			link.Notes = append(link.Notes, gedcom.FoldText(tag.Value, tag.Level, tags[i+1:]))
 		default:
-			if !strings.HasPrefix(tag.Tag, "_") {
-				collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-			}
+			collector.addUnmappedTag(tag)
 		}
 	}
 
@@ -982,10 +1108,9 @@ func parseChangeDate(tags []*gedcom.Tag, chanIdx int, collector *diagnosticColle
 				}
 			case "NOTE":
 				// Known tag not yet parsed into typed fields
+				collector.markUnmapped(tag)
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -1028,13 +1153,15 @@ func parseSubmitter(record *gedcom.Record, collector *diagnosticCollector) *gedc
 		case "EXID":
 			subm.ExternalIDs = append(subm.ExternalIDs, parseExternalID(record.Tags, i))
 
-		case "CHAN", "FAX", "WWW", "OBJE", "RIN", "UID":
+		case "RIN":
+			subm.AutomatedRecordID = tag.Value
+
+		case "CHAN", "FAX", "WWW", "OBJE", "UID":
 			// Known tags not yet parsed into typed fields
+			collector.markUnmapped(tag)
 
 		default:
-			if !strings.HasPrefix(tag.Tag, "_") {
-				collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-			}
+			collector.addUnmappedTag(tag)
 		}
 	}
 
@@ -1077,7 +1204,7 @@ func parseRepository(record *gedcom.Record, collector *diagnosticCollector) *ged
 			if repo.Address == nil {
 				repo.Address = &gedcom.Address{}
 			}
-			repo.Address.Website = tag.Value
+			repo.Address.Website = gedcom.FoldText(tag.Value, tag.Level, record.Tags[i+1:])
 
 		case "NOTE", "SNOTE":
 			repo.NoteXRefs, repo.InlineNotes, repo.Notes = appendRecordNote(record.Tags, i, repo.NoteXRefs, repo.InlineNotes, repo.Notes)
@@ -1085,13 +1212,15 @@ func parseRepository(record *gedcom.Record, collector *diagnosticCollector) *ged
 		case "EXID":
 			repo.ExternalIDs = append(repo.ExternalIDs, parseExternalID(record.Tags, i))
 
+		case "RIN":
+			repo.AutomatedRecordID = tag.Value
+
 		case "CHAN", "REFN", "UID", "FAX":
 			// Known tags not yet parsed into typed fields
+			collector.markUnmapped(tag)
 
 		default:
-			if !strings.HasPrefix(tag.Tag, "_") {
-				collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-			}
+			collector.addUnmappedTag(tag)
 		}
 	}
 
@@ -1130,20 +1259,7 @@ func appendRecordNote(tags []*gedcom.Tag, noteIdx int, xrefs, inline, legacy []s
 		return append(xrefs, tag.Value), inline, append(legacy, tag.Value)
 	}
 
-	var b strings.Builder
-	b.WriteString(tag.Value)
-	baseLevel := tag.Level
-	for i := noteIdx + 1; i < len(tags); i++ {
-		sub := tags[i]
-		if sub.Level <= baseLevel {
-			break
-		}
-		if sub.Level != baseLevel+1 {
-			continue
-		}
-		foldContinuation(&b, sub)
-	}
-	text := b.String()
+	text := gedcom.FoldText(tag.Value, tag.Level, tags[noteIdx+1:])
 	return xrefs, append(inline, text), append(legacy, text)
 }
 
@@ -1181,13 +1297,15 @@ func parseNote(record *gedcom.Record, collector *diagnosticCollector) *gedcom.No
 		case "EXID":
 			note.ExternalIDs = append(note.ExternalIDs, parseExternalID(record.Tags, i))
 
+		case "RIN":
+			note.AutomatedRecordID = tag.Value
+
 		case "MIME", "LANG", "TRAN", "SOUR", "REFN", "UID", "CHAN":
 			// Known tags not yet parsed into typed fields
+			collector.markUnmapped(tag)
 
 		default:
-			if !strings.HasPrefix(tag.Tag, "_") {
-				collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-			}
+			collector.addUnmappedTag(tag)
 		}
 	}
 
@@ -1243,9 +1361,7 @@ func parseSharedNote(record *gedcom.Record, collector *diagnosticCollector) *ged
 			foldContinuation(&b, tag)
 
 		default:
-			if !strings.HasPrefix(tag.Tag, "_") {
-				collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-			}
+			collector.addUnmappedTag(tag)
 		}
 	}
 
@@ -1328,14 +1444,14 @@ func parseMediaObject(record *gedcom.Record, collector *diagnosticCollector) *ge
 			media.RefNumbers = append(media.RefNumbers, tag.Value)
 		case "UID":
 			media.UIDs = append(media.UIDs, tag.Value)
+		case "RIN":
+			media.AutomatedRecordID = tag.Value
 		case "RESN":
-			media.Restriction = tag.Value
+			media.Restriction = gedcom.Restriction(tag.Value)
 		case "EXID":
 			media.ExternalIDs = append(media.ExternalIDs, parseExternalID(record.Tags, i))
 		default:
-			if !strings.HasPrefix(tag.Tag, "_") {
-				collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-			}
+			collector.addUnmappedTag(tag)
 		}
 	}
 
@@ -1369,14 +1485,12 @@ func parseMediaFile(tags []*gedcom.Tag, fileIdx, baseLevel int, collector *diagn
 					}
 				}
 			case "TITL":
-				file.Title = tag.Value
+				file.Title = gedcom.FoldText(tag.Value, tag.Level, tags[i+1:])
 			case "TRAN":
 				tran := parseMediaTranslation(tags, i, tag.Level, collector)
 				file.Translations = append(file.Translations, tran)
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -1400,9 +1514,7 @@ func parseMediaTranslation(tags []*gedcom.Tag, tranIdx, baseLevel int, collector
 			case "FORM":
 				tran.Form = tag.Value
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -1426,13 +1538,11 @@ func parseMediaLink(tags []*gedcom.Tag, objeIdx, baseLevel int, collector *diagn
 			case "CROP":
 				link.Crop = parseCropRegion(tags, i, tag.Level, collector)
 			case "TITL":
-				link.Title = tag.Value
+				link.Title = gedcom.FoldText(tag.Value, tag.Level, tags[i+1:])
 			case "FILE":
 				// Known tag for inline media references
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -1476,9 +1586,7 @@ func parseCropRegion(tags []*gedcom.Tag, cropIdx, baseLevel int, collector *diag
 					collector.addInvalidValue(tag.LineNumber, "WIDTH", tag.Value, "expected integer")
 				}
 			default:
-				if !strings.HasPrefix(tag.Tag, "_") {
-					collector.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
-				}
+				collector.addUnmappedTag(tag)
 			}
 		}
 	}
@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
 )
 
 const entityTestGedcom = `0 HEAD
@@ -295,7 +297,7 @@ func TestParsePedigreeLinks(t *testing.T) {
 		if link.FamilyXRef != tt.familyXR {
 			t.Errorf("ChildInFamilies[%d].FamilyXRef = %s, want %s", tt.idx, link.FamilyXRef, tt.familyXR)
 		}
-		if link.Pedigree != tt.pedigree {
+		if string(link.Pedigree) != tt.pedigree {
 			t.Errorf("ChildInFamilies[%d].Pedigree = %s, want %s", tt.idx, link.Pedigree, tt.pedigree)
 		}
 	}
@@ -1061,6 +1063,53 @@ func TestFamilyEvents(t *testing.T) {
 	}
 }
 
+func TestFamilyAndGenericEvents(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @F1@ FAM
+1 ENGA
+2 DATE 1874
+1 CENS
+2 DATE 1880
+1 ANUL
+1 EVEN Elopement
+2 TYPE Elopement
+0 @I1@ INDI
+1 EVEN
+2 TYPE Shipwreck survivor
+2 DATE 1890
+0 TRLR
+`
+	result, err := DecodeWithDiagnostics(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := result.Document
+
+	fam := doc.GetFamily("@F1@")
+	var got []string
+	for _, event := range fam.Events {
+		got = append(got, string(event.Type))
+	}
+	if want := "ENGA CENS ANUL EVEN"; strings.Join(got, " ") != want {
+		t.Errorf("family events = %v, want %s", got, want)
+	}
+	if len(fam.Events) == 4 && fam.Events[3].EventTypeDetail != "Elopement" {
+		t.Errorf("EVEN TYPE = %q, want Elopement", fam.Events[3].EventTypeDetail)
+	}
+
+	ind := doc.GetIndividual("@I1@")
+	if len(ind.Events) != 1 || ind.Events[0].Type != gedcom.EventGeneric || ind.Events[0].Date != "1890" {
+		t.Errorf("individual events = %+v, want one EVEN dated 1890", ind.Events)
+	}
+	for _, d := range result.Diagnostics {
+		if strings.Contains(d.Error(), "EVEN") || strings.Contains(d.Error(), "CENS") {
+			t.Errorf("unexpected diagnostic: %v", d)
+		}
+	}
+}
+
 // === Integration Tests ===
 // These tests validate parsing against real-world GEDCOM 7.0 test data.
 
@@ -1159,7 +1208,7 @@ func TestMaximal70Individual(t *testing.T) {
 		pediFound := make(map[string]bool)
 		for _, link := range indi.ChildInFamilies {
 			if link.Pedigree != "" {
-				pediFound[link.Pedigree] = true
+				pediFound[string(link.Pedigree)] = true
 			}
 		}
 		expectedPedi := []string{"FOSTER", "ADOPTED", "BIRTH"}
@@ -1581,6 +1630,129 @@ func TestPlaceWithoutCoordinates(t *testing.T) {
 }
 
 // TestSourceCitationInvalidQuay tests source citation with non-numeric QUAY.
+func TestInlineSourceCitation(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5
+0 @I1@ INDI
+1 NAME John /Doe/
+1 SOUR Family Bible of John D
+2 CONC oe, held by Mary Doe
+2 CONT Springfield
+2 TEXT John born 1850
+3 CONT at home
+2 QUAY 2
+1 SOUR @VOID@
+0 TRLR
+`
+	result, err := DecodeWithDiagnostics(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := result.Document
+
+	cites := doc.GetIndividual("@I1@").SourceCitations
+	if len(cites) != 2 {
+		t.Fatalf("len(SourceCitations) = %d, want 2", len(cites))
+	}
+
+	cite := cites[0]
+	if !cite.IsInline() || cite.SourceXRef != "" {
+		t.Errorf("citation should be inline, got SourceXRef %q", cite.SourceXRef)
+	}
+	if want := "Family Bible of John Doe, held by Mary Doe\nSpringfield"; cite.InlineText != want {
+		t.Errorf("InlineText = %q, want %q", cite.InlineText, want)
+	}
+	if len(cite.Text) != 1 || cite.Text[0] != "John born 1850\nat home" {
+		t.Errorf("Text = %q", cite.Text)
+	}
+	if cite.Quality != 2 {
+		t.Errorf("Quality = %d, want 2", cite.Quality)
+	}
+	if cites[1].IsInline() || cites[1].SourceXRef != "@VOID@" {
+		t.Errorf("@VOID@ citation = %+v, want pointer", cites[1])
+	}
+	for _, d := range result.Diagnostics {
+		if d.Code == CodeUnknownTag {
+			t.Errorf("unexpected unknown-tag diagnostic: %v", d)
+		}
+	}
+}
+
+func TestLongValueContinuations(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Doe/
+1 OCCU Black
+2 CONC smith and farrier
+2 CONT of the parish
+1 DEAT
+2 CAUS Fever contracted
+3 CONT during the flood
+2 SOUR @S1@
+3 PAGE Vol. 2,
+4 CONC p. 17
+3 DATA
+4 TEXT Died of a
+5 CONT fever
+0 @S1@ SOUR
+1 TITL Parish Register of
+2 CONC St. Mary
+1 AUTH Church of
+2 CONT St. Mary
+1 PUBL Publ
+2 CONC ished 1901
+1 TEXT Entry one
+2 CONT Entry two
+0 TRLR
+`
+	result, err := DecodeWithDiagnostics(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := result.Document
+
+	indi := doc.GetIndividual("@I1@")
+	if len(indi.Attributes) != 1 || indi.Attributes[0].Value != "Blacksmith and farrier\nof the parish" {
+		t.Errorf("OCCU attributes = %d, want one with continued value", len(indi.Attributes))
+	}
+	death := indi.DeathEvent()
+	if death == nil {
+		t.Fatal("DeathEvent() = nil")
+	}
+	if want := "Fever contracted\nduring the flood"; death.Cause != want {
+		t.Errorf("Cause = %q, want %q", death.Cause, want)
+	}
+	cite := death.SourceCitations[0]
+	if want := "Vol. 2,p. 17"; cite.Page != want {
+		t.Errorf("Page = %q, want %q", cite.Page, want)
+	}
+	if want := "Died of a\nfever"; cite.Data == nil || cite.Data.Text != want {
+		t.Errorf("Data.Text = %+v, want %q", cite.Data, want)
+	}
+
+	src := doc.GetSource("@S1@")
+	tests := []struct{ name, got, want string }{
+		{"Title", src.Title, "Parish Register ofSt. Mary"},
+		{"Author", src.Author, "Church of\nSt. Mary"},
+		{"Publication", src.Publication, "Published 1901"},
+		{"Text", src.Text, "Entry one\nEntry two"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	for _, d := range result.Diagnostics {
+		if d.Code == CodeUnknownTag {
+			t.Errorf("unexpected unknown-tag diagnostic: %v", d)
+		}
+	}
+}
+
 func TestSourceCitationInvalidQuay(t *testing.T) {
 	gedcom := `0 HEAD
 1 GEDC
@@ -1613,6 +1785,38 @@ func TestSourceCitationInvalidQuay(t *testing.T) {
 	}
 }
 
+func TestSourceCitationEventRole(t *testing.T) {
+	gedcom := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 CHR
+2 SOUR @S1@
+3 PAGE f. 12
+3 EVEN BIRT
+4 ROLE (Godmother)
+3 QUAY 2
+0 @S1@ SOUR
+1 TITL Parish register
+0 TRLR
+`
+	result, err := DecodeWithDiagnostics(strings.NewReader(gedcom), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Diagnostics) != 0 {
+		t.Errorf("Diagnostics = %v, want none", result.Diagnostics)
+	}
+
+	cite := result.Document.GetIndividual("@I1@").Events[0].SourceCitations[0]
+	if cite.Event != "BIRT" || cite.Role != "(Godmother)" {
+		t.Errorf("Event, Role = %q, %q, want BIRT, (Godmother)", cite.Event, cite.Role)
+	}
+	if cite.Page != "f. 12" || cite.Quality != 2 {
+		t.Errorf("Page, Quality = %q, %d", cite.Page, cite.Quality)
+	}
+}
+
 // TestMultipleSourceCitationsOnEvent tests multiple source citations on same event.
 func TestMultipleSourceCitationsOnEvent(t *testing.T) {
 	gedcom := `0 HEAD
@@ -2124,6 +2328,98 @@ func TestChangeDateWithoutTime(t *testing.T) {
 }
 
 // TestMetadataEdgeCases tests edge cases for metadata parsing.
+func TestParseUserReferences(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 REFN 1042
+2 TYPE Ahnentafel
+1 REFN A-7
+0 @F1@ FAM
+1 REFN F-1
+2 TYPE family
+0 @S1@ SOUR
+1 REFN S-1
+1 REFN S-2
+2 TYPE box
+0 TRLR
+`
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(name string, got []*gedcom.UserReference, legacy string, want []gedcom.UserReference) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s: %d UserReferences, want %d", name, len(got), len(want))
+		}
+		for i := range want {
+			if *got[i] != want[i] {
+				t.Errorf("%s: UserReferences[%d] = %+v, want %+v", name, i, *got[i], want[i])
+			}
+		}
+		if legacy != want[0].Number {
+			t.Errorf("%s: RefNumber = %q, want %q", name, legacy, want[0].Number)
+		}
+	}
+	indi := doc.GetIndividual("@I1@")
+	check("individual", indi.UserReferences, indi.RefNumber, []gedcom.UserReference{
+		{Number: "1042", Type: "Ahnentafel"}, {Number: "A-7"},
+	})
+	fam := doc.GetFamily("@F1@")
+	check("family", fam.UserReferences, fam.RefNumber, []gedcom.UserReference{{Number: "F-1", Type: "family"}})
+	src := doc.GetSource("@S1@")
+	check("source", src.UserReferences, src.RefNumber, []gedcom.UserReference{
+		{Number: "S-1"}, {Number: "S-2", Type: "box"},
+	})
+}
+
+func TestParseAutomatedRecordID(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 RIN 101
+0 @F1@ FAM
+1 RIN 102
+0 @S1@ SOUR
+1 RIN 103
+0 @R1@ REPO
+1 RIN 104
+0 @U1@ SUBM
+1 RIN 105
+0 @N1@ NOTE Text
+1 RIN 106
+0 @O1@ OBJE
+1 RIN 107
+0 TRLR
+`
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{
+		"INDI": doc.GetIndividual("@I1@").AutomatedRecordID,
+		"FAM":  doc.GetFamily("@F1@").AutomatedRecordID,
+		"SOUR": doc.GetSource("@S1@").AutomatedRecordID,
+		"REPO": doc.GetRepository("@R1@").AutomatedRecordID,
+		"SUBM": doc.GetSubmitter("@U1@").AutomatedRecordID,
+		"NOTE": doc.GetNote("@N1@").AutomatedRecordID,
+		"OBJE": doc.GetMediaObject("@O1@").AutomatedRecordID,
+	}
+	want := map[string]string{
+		"INDI": "101", "FAM": "102", "SOUR": "103", "REPO": "104",
+		"SUBM": "105", "NOTE": "106", "OBJE": "107",
+	}
+	for tag, w := range want {
+		if got[tag] != w {
+			t.Errorf("%s AutomatedRecordID = %q, want %q", tag, got[tag], w)
+		}
+	}
+}
+
 func TestMetadataEdgeCases(t *testing.T) {
 	gedcom := `0 HEAD
 1 GEDC
@@ -2405,6 +2701,47 @@ func TestEventAdministrativeTags(t *testing.T) {
 	}
 }
 
+// TestEventVendorSortDate tests parsing of vendor sort dates (_SDATE, and
+// _SORT when it holds a date) into Event.VendorSortDate.
+func TestEventVendorSortDate(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 RESI
+2 DATE FROM 1900 TO 1920
+2 _SDATE 1 JAN 1910
+1 CENS
+2 DATE 1910
+2 _SORT 1910-04-15
+1 OCCU Farmer
+1 EVEN Air Force
+2 _SORT Main
+0 TRLR
+`
+	result, err := DecodeWithDiagnostics(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := result.Document.GetIndividual("@I1@").Events
+	if len(events) != 3 {
+		t.Fatalf("len(Events) = %d, want 3", len(events))
+	}
+	for i, want := range []string{"1 JAN 1910", "1910-04-15", ""} {
+		if events[i].VendorSortDate != want {
+			t.Errorf("Events[%d].VendorSortDate = %q, want %q", i, events[i].VendorSortDate, want)
+		}
+	}
+
+	var unmapped []string
+	for _, u := range result.UnmappedTags {
+		unmapped = append(unmapped, u.Tag+" "+u.Value)
+	}
+	if got := strings.Join(unmapped, ", "); got != "_SORT Main" {
+		t.Errorf("unmapped tags = %q, want only the non-date _SORT", got)
+	}
+}
+
 func TestSubmitterParsing(t *testing.T) {
 	gedcom := `0 HEAD
 1 SOUR Test
@@ -3592,7 +3929,7 @@ func TestFamilySearchIDEscapedValue(t *testing.T) {
 
 // TestFamilySearchIDWithFile tests parsing _FSFTID from a GEDCOM file.
 func TestFamilySearchIDWithFile(t *testing.T) {
-	f, err := os.Open("../testdata/edge-cases/familysearch-extensions.ged")
+	f, err := os.Open("../testdata/vendors/familysearch/familysearch-extensions.ged")
 	if err != nil {
 		t.Skip("Test file not available: ", err)
 	}
@@ -4989,3 +5326,69 @@ func TestNOTagMissingEventTypeFamily(t *testing.T) {
 		}
 	}
 }
+
+func TestParseIndividualTasks(t *testing.T) {
+	f, err := os.Open("../testdata/vendors/legacy/vendor-legacy.ged")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	doc, err := Decode(f)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	var tasks []*gedcom.Task
+	for _, indi := range doc.Individuals() {
+		tasks = append(tasks, indi.Tasks...)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("tasks = %d, want 2", len(tasks))
+	}
+	open, done := tasks[0], tasks[1]
+	if open.Description != "Obtain" || open.Category != "Birth Certificate" || open.Status != "Open" ||
+		open.Priority != "5" || open.RepositoryXRef != "@R0@" || open.Result != "Still haven't gotten it" {
+		t.Errorf("first task = %+v", open)
+	}
+	if !open.IsOpen() || done.IsOpen() || done.CompletedDate != "1 Oct 2016" {
+		t.Errorf("IsOpen: first = %v, second = %v", open.IsOpen(), done.IsOpen())
+	}
+}
+
+func TestFamilyMultipleSpouses(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @F1@ FAM
+1 HUSB @I1@
+1 HUSB @I2@
+1 ASSO @I3@
+2 ROLE SPOU
+1 ASSO @I4@
+2 ROLE WITN
+1 CHIL @I5@
+0 TRLR
+`
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	fam := doc.GetFamily("@F1@")
+	if fam.Husband != "@I1@" || fam.Wife != "" {
+		t.Errorf("Husband = %q, Wife = %q; want the first HUSB and no wife", fam.Husband, fam.Wife)
+	}
+	var got []string
+	for _, s := range fam.Spouses {
+		got = append(got, s.Tag+" "+s.XRef+" "+s.Role)
+	}
+	want := "HUSB @I1@ |HUSB @I2@ |ASSO @I3@ SPOU"
+	if strings.Join(got, "|") != want {
+		t.Errorf("Spouses = %q, want %q", strings.Join(got, "|"), want)
+	}
+	if len(fam.Associations) != 2 || fam.Associations[1].Role != "WITN" {
+		t.Errorf("Associations = %+v, want both ASSO lines", fam.Associations)
+	}
+	if partners := strings.Join(fam.Partners(), " "); partners != "@I1@ @I2@ @I3@" {
+		t.Errorf("Partners() = %q", partners)
+	}
+}
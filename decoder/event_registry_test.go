@@ -0,0 +1,77 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func TestDecodeRegisteredEventType(t *testing.T) {
+	if err := gedcom.RegisterEventType("_MILT", gedcom.EventTypeOptions{Description: "Military service"}); err != nil {
+		t.Fatal(err)
+	}
+	defer gedcom.UnregisterEventType("_MILT")
+	if err := gedcom.RegisterEventType("_SEPR", gedcom.EventTypeOptions{Scope: gedcom.EventScopeFamily}); err != nil {
+		t.Fatal(err)
+	}
+	defer gedcom.UnregisterEventType("_SEPR")
+
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Doe/
+1 _MILT
+2 DATE 1917
+2 PLAC France
+2 TYPE Army
+1 _SEPR
+2 DATE 1920
+0 @F1@ FAM
+1 HUSB @I1@
+1 _SEPR
+2 DATE 1925
+1 _MILT
+0 TRLR
+`
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	indi := doc.GetIndividual("@I1@")
+	if len(indi.Events) != 1 {
+		t.Fatalf("individual events = %d, want 1 (family-scoped _SEPR ignored)", len(indi.Events))
+	}
+	milt := indi.Events[0]
+	if milt.Type != "_MILT" || milt.Date != "1917" || milt.Place != "France" || milt.EventTypeDetail != "Army" {
+		t.Errorf("_MILT event = %+v", milt)
+	}
+	if milt.ParsedDate == nil || milt.ParsedDate.Year != 1917 {
+		t.Errorf("_MILT ParsedDate = %+v", milt.ParsedDate)
+	}
+
+	fam := doc.GetFamily("@F1@")
+	if len(fam.Events) != 1 || fam.Events[0].Type != "_SEPR" || fam.Events[0].Date != "1925" {
+		t.Errorf("family events = %+v", fam.Events)
+	}
+}
+
+func TestDecodeUnregisteredVendorEvent(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 _UNREG
+2 DATE 1917
+0 TRLR
+`
+	doc, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if n := len(doc.GetIndividual("@I1@").Events); n != 0 {
+		t.Errorf("unregistered vendor tag parsed as %d events", n)
+	}
+}
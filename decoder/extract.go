@@ -0,0 +1,116 @@
+package decoder
+
+import "github.com/cacack/gedcom-go/v2/gedcom"
+
+// EventFromTags builds an Event from the structure at tags[idx] and its
+// subordinates, as the decoder does for INDI and FAM events but at any
+// level, with the tag as its type. Use it for events nested where the
+// library does not model them, such as under a vendor extension:
+//
+//	for i, tag := range record.Tags {
+//	    if tag.Tag == "_MILT" {
+//	        service := decoder.EventFromTags(record.Tags, i)
+//	        fmt.Println(service.Date, service.Place)
+//	    }
+//	}
+//
+// Subordinates are found by level relative to tags[idx]. Invalid values are
+// kept raw, as in a decode, without diagnostics. Like the other FromTags
+// functions, it returns nil if idx is out of range.
+func EventFromTags(tags []*gedcom.Tag, idx int) *gedcom.Event {
+	if !validIndex(tags, idx) {
+		return nil
+	}
+	return parseEvent(tags, idx, tags[idx].Tag, nil)
+}
+
+// AttributeFromTags builds an Attribute from the structure at idx, with the
+// tag as its type.
+func AttributeFromTags(tags []*gedcom.Tag, idx int) *gedcom.Attribute {
+	if !validIndex(tags, idx) {
+		return nil
+	}
+	return parseAttribute(tags, idx, tags[idx].Tag, nil)
+}
+
+// CitationFromTags builds a SourceCitation from the SOUR structure at idx.
+func CitationFromTags(tags []*gedcom.Tag, idx int) *gedcom.SourceCitation {
+	if !validIndex(tags, idx) {
+		return nil
+	}
+	return parseSourceCitation(tags, idx, tags[idx].Level, nil)
+}
+
+// NameFromTags builds a PersonalName from the NAME structure at idx.
+func NameFromTags(tags []*gedcom.Tag, idx int) *gedcom.PersonalName {
+	if !validIndex(tags, idx) {
+		return nil
+	}
+	return parsePersonalName(tags, idx, nil)
+}
+
+// AddressFromTags builds an Address from the ADDR structure at idx.
+func AddressFromTags(tags []*gedcom.Tag, idx int) *gedcom.Address {
+	if !validIndex(tags, idx) {
+		return nil
+	}
+	return parseEventAddress(tags, idx, tags[idx].Level, nil)
+}
+
+// MediaLinkFromTags builds a MediaLink from the OBJE structure at idx.
+func MediaLinkFromTags(tags []*gedcom.Tag, idx int) *gedcom.MediaLink {
+	if !validIndex(tags, idx) {
+		return nil
+	}
+	return parseMediaLink(tags, idx, tags[idx].Level, nil)
+}
+
+// ExtractEvents returns the events among the top-level structures of tags
+// (those at the lowest level in the slice): every structure whose tag is a
+// registered event type, individual or family. Pass a record's Tags or the
+// subordinates of any structure:
+//
+//	events := decoder.ExtractEvents(record.Tags[i+1 : end])
+func ExtractEvents(tags []*gedcom.Tag) []*gedcom.Event {
+	var events []*gedcom.Event
+	for _, idx := range topLevel(tags) {
+		if _, ok := gedcom.LookupEventType(tags[idx].Tag); ok {
+			events = append(events, parseEvent(tags, idx, tags[idx].Tag, nil))
+		}
+	}
+	return events
+}
+
+// ExtractCitations returns the source citations among the top-level
+// structures of tags (those at the lowest level in the slice): every SOUR
+// structure.
+func ExtractCitations(tags []*gedcom.Tag) []*gedcom.SourceCitation {
+	var citations []*gedcom.SourceCitation
+	for _, idx := range topLevel(tags) {
+		if tags[idx].Tag == "SOUR" {
+			citations = append(citations, parseSourceCitation(tags, idx, tags[idx].Level, nil))
+		}
+	}
+	return citations
+}
+
+// topLevel returns the indexes of the tags at the lowest level in tags.
+func topLevel(tags []*gedcom.Tag) []int {
+	minLevel := -1
+	for _, tag := range tags {
+		if tag != nil && (minLevel < 0 || tag.Level < minLevel) {
+			minLevel = tag.Level
+		}
+	}
+	var idxs []int
+	for i, tag := range tags {
+		if tag != nil && tag.Level == minLevel {
+			idxs = append(idxs, i)
+		}
+	}
+	return idxs
+}
+
+func validIndex(tags []*gedcom.Tag, idx int) bool {
+	return idx >= 0 && idx < len(tags) && tags[idx] != nil
+}
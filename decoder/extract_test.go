@@ -0,0 +1,176 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const extractTestGedcom = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /Doe/
+1 _MILT Infantry
+2 BIRT
+3 DATE 1 JAN 1900
+3 PLAC Camp Dodge, Iowa
+3 SOUR @S1@
+4 PAGE Roll 12
+2 RESI
+3 DATE 1917
+3 ADDR 1 Barracks Row
+4 CITY Des Moines
+2 OCCU Private
+3 DATE 1918
+2 SOUR @S2@
+3 PAGE p. 4
+2 OBJE @O1@
+2 NAME Johnny /Doe/
+3 GIVN Johnny
+1 DEAT
+2 DATE 1950
+0 @S1@ SOUR
+0 @S2@ SOUR
+0 @O1@ OBJE
+0 TRLR
+`
+
+// milTags returns the INDI record's tags and the index of its _MILT tag.
+func milTags(t *testing.T) ([]*gedcom.Tag, int) {
+	t.Helper()
+	doc, err := Decode(strings.NewReader(extractTestGedcom))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	tags := doc.GetRecord("@I1@").Tags
+	for i, tag := range tags {
+		if tag.Tag == "_MILT" {
+			return tags, i
+		}
+	}
+	t.Fatal("no _MILT tag")
+	return nil, 0
+}
+
+// indexOf returns the index of the first tag after from with the given tag.
+func indexOf(t *testing.T, tags []*gedcom.Tag, from int, name string) int {
+	t.Helper()
+	for i := from; i < len(tags); i++ {
+		if tags[i].Tag == name {
+			return i
+		}
+	}
+	t.Fatalf("no %s tag after %d", name, from)
+	return -1
+}
+
+func TestEventFromTags(t *testing.T) {
+	tags, mil := milTags(t)
+	event := EventFromTags(tags, indexOf(t, tags, mil, "BIRT"))
+	if event == nil {
+		t.Fatal("EventFromTags() = nil")
+	}
+	if event.Type != gedcom.EventBirth {
+		t.Errorf("Type = %q, want %q", event.Type, gedcom.EventBirth)
+	}
+	if event.Date != "1 JAN 1900" || event.ParsedDate == nil {
+		t.Errorf("Date = %q (parsed %v), want 1 JAN 1900", event.Date, event.ParsedDate)
+	}
+	if event.Place != "Camp Dodge, Iowa" {
+		t.Errorf("Place = %q, want Camp Dodge, Iowa", event.Place)
+	}
+	if len(event.SourceCitations) != 1 || event.SourceCitations[0].Page != "Roll 12" {
+		t.Errorf("SourceCitations = %+v, want one with PAGE Roll 12", event.SourceCitations)
+	}
+}
+
+func TestFromTags_Structures(t *testing.T) {
+	tags, mil := milTags(t)
+
+	addr := AddressFromTags(tags, indexOf(t, tags, mil, "ADDR"))
+	if addr == nil || addr.Line1 != "1 Barracks Row" || addr.City != "Des Moines" {
+		t.Errorf("AddressFromTags() = %+v, want 1 Barracks Row, Des Moines", addr)
+	}
+
+	attr := AttributeFromTags(tags, indexOf(t, tags, mil, "OCCU"))
+	if attr == nil || attr.Type != "OCCU" || attr.Value != "Private" || attr.Date != "1918" {
+		t.Errorf("AttributeFromTags() = %+v, want OCCU Private in 1918", attr)
+	}
+
+	cite := CitationFromTags(tags, indexOf(t, tags, mil, "SOUR"))
+	if cite == nil || cite.SourceXRef != "@S1@" || cite.Page != "Roll 12" {
+		t.Errorf("CitationFromTags() = %+v, want @S1@ Roll 12", cite)
+	}
+
+	media := MediaLinkFromTags(tags, indexOf(t, tags, mil, "OBJE"))
+	if media == nil || media.MediaXRef != "@O1@" {
+		t.Errorf("MediaLinkFromTags() = %+v, want @O1@", media)
+	}
+
+	name := NameFromTags(tags, indexOf(t, tags, mil, "NAME"))
+	if name == nil || name.Full != "Johnny /Doe/" || name.Given != "Johnny" {
+		t.Errorf("NameFromTags() = %+v, want Johnny /Doe/", name)
+	}
+}
+
+func TestFromTags_InvalidIndex(t *testing.T) {
+	tags, _ := milTags(t)
+	for _, idx := range []int{-1, len(tags)} {
+		if got := EventFromTags(tags, idx); got != nil {
+			t.Errorf("EventFromTags(%d) = %+v, want nil", idx, got)
+		}
+		if got := CitationFromTags(tags, idx); got != nil {
+			t.Errorf("CitationFromTags(%d) = %+v, want nil", idx, got)
+		}
+		if got := NameFromTags(tags, idx); got != nil {
+			t.Errorf("NameFromTags(%d) = %+v, want nil", idx, got)
+		}
+	}
+	if got := AddressFromTags(nil, 0); got != nil {
+		t.Errorf("AddressFromTags(nil, 0) = %+v, want nil", got)
+	}
+}
+
+func TestExtractEvents(t *testing.T) {
+	tags, mil := milTags(t)
+
+	// The whole record: only level-1 events, not those under _MILT.
+	events := ExtractEvents(tags)
+	if len(events) != 1 || events[0].Type != gedcom.EventDeath {
+		t.Errorf("ExtractEvents(record) = %+v, want only DEAT", events)
+	}
+
+	// The _MILT subtree: BIRT and RESI at level 2, not OCCU or SOUR.
+	end := indexOf(t, tags, mil, "DEAT")
+	events = ExtractEvents(tags[mil+1 : end])
+	if len(events) != 2 {
+		t.Fatalf("ExtractEvents(_MILT) returned %d events, want 2", len(events))
+	}
+	if events[0].Type != gedcom.EventBirth || events[1].Type != gedcom.EventResidence {
+		t.Errorf("ExtractEvents(_MILT) types = %q, %q, want BIRT, RESI", events[0].Type, events[1].Type)
+	}
+	if events[1].Address == nil || events[1].Address.City != "Des Moines" {
+		t.Errorf("RESI Address = %+v, want Des Moines", events[1].Address)
+	}
+
+	if got := ExtractEvents(nil); got != nil {
+		t.Errorf("ExtractEvents(nil) = %+v, want nil", got)
+	}
+}
+
+func TestExtractCitations(t *testing.T) {
+	tags, mil := milTags(t)
+	end := indexOf(t, tags, mil, "DEAT")
+
+	// Only the level-2 SOUR, not the one under BIRT.
+	citations := ExtractCitations(tags[mil+1 : end])
+	if len(citations) != 1 {
+		t.Fatalf("ExtractCitations() returned %d citations, want 1", len(citations))
+	}
+	if citations[0].SourceXRef != "@S2@" || citations[0].Page != "p. 4" {
+		t.Errorf("citation = %+v, want @S2@ p. 4", citations[0])
+	}
+}
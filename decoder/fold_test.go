@@ -8,7 +8,7 @@ import (
 )
 
 // TestFoldContinuation documents the CONT/CONC fold contract shared by
-// appendRecordNote and parseSharedNote (issue #331).
+// parseSharedNote and parseSharedNoteTranslation (issue #331).
 func TestFoldContinuation(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,78 @@
+package decoder
+
+import (
+	"io"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// formatSniffer passes input through unchanged while recording the byte
+// order mark, the first line terminator, and whether the input ends with a
+// line terminator. NUL bytes are skipped, so that the terminators of UTF-16
+// input are recognized too.
+type formatSniffer struct {
+	reader io.Reader
+	head   []byte
+	ending string
+	prevCR bool
+	last   byte
+}
+
+func (s *formatSniffer) Read(p []byte) (int, error) {
+	n, err := s.reader.Read(p)
+	for _, b := range p[:n] {
+		if len(s.head) < 3 {
+			s.head = append(s.head, b)
+		}
+		if b == 0 {
+			continue
+		}
+		s.last = b
+		if s.ending == "" {
+			switch {
+			case s.prevCR && b == '\n':
+				s.ending = "\r\n"
+			case s.prevCR:
+				s.ending = "\r"
+			case b == '\n':
+				s.ending = "\n"
+			}
+			s.prevCR = b == '\r'
+		}
+	}
+	return n, err
+}
+
+// formatting returns the layout recorded from the input.
+func (s *formatSniffer) formatting() *gedcom.Formatting {
+	ending := s.ending
+	if ending == "" && s.prevCR {
+		ending = "\r"
+	}
+	if ending == "" {
+		ending = "\n"
+	}
+	return &gedcom.Formatting{
+		LineEnding:        ending,
+		BOM:               len(s.head) == 3 && s.head[0] == 0xEF && s.head[1] == 0xBB && s.head[2] == 0xBF,
+		NoFinalLineEnding: s.last != '\n' && s.last != '\r',
+	}
+}
+
+// applyFormatting stores the sniffed layout and the trivia after the last
+// line on doc. It does nothing when formatting is not being preserved.
+func applyFormatting(doc *gedcom.Document, sniffer *formatSniffer, trailing []string) {
+	if sniffer == nil {
+		return
+	}
+	doc.Formatting = sniffer.formatting()
+	doc.Trailer.Trivia = appendTrivia(doc.Trailer.Trivia, trailing, 1)
+}
+
+// appendTrivia appends lines to trivia at the given offset.
+func appendTrivia(trivia []gedcom.Trivia, lines []string, offset int) []gedcom.Trivia {
+	for _, text := range lines {
+		trivia = append(trivia, gedcom.Trivia{Offset: offset, Text: text})
+	}
+	return trivia
+}
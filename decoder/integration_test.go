@@ -689,6 +689,16 @@ func TestCharacterEncodings(t *testing.T) {
 			description: "UTF-16 Big Endian with BOM",
 			encoding:    gedcom.EncodingUNICODE,
 		},
+		{
+			path:        "../testdata/encoding/utf16le-nobom.ged",
+			description: "UTF-16 Little Endian without BOM",
+			encoding:    gedcom.EncodingUNICODE,
+		},
+		{
+			path:        "../testdata/encoding/utf16be-nobom.ged",
+			description: "UTF-16 Big Endian without BOM",
+			encoding:    gedcom.EncodingUNICODE,
+		},
 		{
 			path:        "../testdata/encoding/ansel-lf.ged",
 			description: "ANSEL encoding with LF line endings (Gramps test)",
@@ -744,7 +754,7 @@ func TestEdgeCases(t *testing.T) {
 			description: "CONT/CONC line continuation tests",
 		},
 		{
-			path:        "../testdata/edge-cases/ancestry-extensions.ged",
+			path:        "../testdata/vendors/ancestry/ancestry-extensions.ged",
 			description: "Ancestry.com vendor extensions (_APID, _TREE)",
 		},
 	}
@@ -774,9 +784,9 @@ func TestEdgeCases(t *testing.T) {
 
 // TestAncestryExtensions tests Ancestry.com specific extensions (_APID, _TREE)
 func TestAncestryExtensions(t *testing.T) {
-	f, err := os.Open("../testdata/edge-cases/ancestry-extensions.ged")
+	f, err := os.Open("../testdata/vendors/ancestry/ancestry-extensions.ged")
 	if err != nil {
-		t.Skipf("Test file not found: %s", "../testdata/edge-cases/ancestry-extensions.ged")
+		t.Skipf("Test file not found: %s", "../testdata/vendors/ancestry/ancestry-extensions.ged")
 		return
 	}
 	defer f.Close()
@@ -808,13 +818,7 @@ func TestAncestryExtensions(t *testing.T) {
 	}
 
 	// Check birth event source citation
-	var birthEvent *gedcom.Event
-	for _, event := range individual.Events {
-		if event.Type == gedcom.EventBirth {
-			birthEvent = event
-			break
-		}
-	}
+	birthEvent := individual.BirthEvent()
 
 	if birthEvent == nil {
 		t.Fatal("Could not find birth event for @I1@")
@@ -847,13 +851,7 @@ func TestAncestryExtensions(t *testing.T) {
 	}
 
 	// Check death event source citation
-	var deathEvent *gedcom.Event
-	for _, event := range individual.Events {
-		if event.Type == gedcom.EventDeath {
-			deathEvent = event
-			break
-		}
-	}
+	deathEvent := individual.DeathEvent()
 
 	if deathEvent == nil {
 		t.Fatal("Could not find death event for @I1@")
@@ -879,9 +877,9 @@ func TestAncestryExtensions(t *testing.T) {
 // TestAncestryRealExport tests a real Ancestry.com 2025 export (not synthetic)
 // This verifies actual export behavior including XRef format, vendor detection, and tree ID extraction.
 func TestAncestryRealExport(t *testing.T) {
-	f, err := os.Open("../testdata/edge-cases/ancestry-2025-export.ged")
+	f, err := os.Open("../testdata/vendors/ancestry/ancestry-2025-export.ged")
 	if err != nil {
-		t.Skipf("Test file not found: %s", "../testdata/edge-cases/ancestry-2025-export.ged")
+		t.Skipf("Test file not found: %s", "../testdata/vendors/ancestry/ancestry-2025-export.ged")
 		return
 	}
 	defer f.Close()
@@ -951,9 +949,9 @@ func TestAncestryRealExport(t *testing.T) {
 // This verifies actual export behavior including _UID tags, _TMPLT source templates,
 // _EVDEF event definitions, and complex address structures.
 func TestRootsMagicRealExport(t *testing.T) {
-	f, err := os.Open("../testdata/edge-cases/rootsmagic-2026-export.ged")
+	f, err := os.Open("../testdata/vendors/rootsmagic/rootsmagic-2026-export.ged")
 	if err != nil {
-		t.Skipf("Test file not found: %s", "../testdata/edge-cases/rootsmagic-2026-export.ged")
+		t.Skipf("Test file not found: %s", "../testdata/vendors/rootsmagic/rootsmagic-2026-export.ged")
 		return
 	}
 	defer f.Close()
@@ -1068,9 +1066,9 @@ func individualHasTag(ind *gedcom.Individual, name string) bool {
 // authoring system) but by per-record _HASH/_LHASH change-detection checksums
 // and a header standardizer NOTE. See docs/governance/policies/compatibility.md.
 func TestFamilySearchRealExport(t *testing.T) {
-	f, err := os.Open("../testdata/edge-cases/familysearch-2025-export.ged")
+	f, err := os.Open("../testdata/vendors/familysearch/familysearch-2025-export.ged")
 	if err != nil {
-		t.Skipf("Test file not found: %s", "../testdata/edge-cases/familysearch-2025-export.ged")
+		t.Skipf("Test file not found: %s", "../testdata/vendors/familysearch/familysearch-2025-export.ged")
 		return
 	}
 	defer f.Close()
@@ -1133,9 +1131,9 @@ func TestFamilySearchRealExport(t *testing.T) {
 // verifying _UID/RIN identifiers, header extensions, and the documented
 // behavior of stripping REPO records. See docs/governance/policies/compatibility.md.
 func TestMyHeritageRealExport(t *testing.T) {
-	f, err := os.Open("../testdata/edge-cases/myheritage-2025-export.ged")
+	f, err := os.Open("../testdata/vendors/myheritage/myheritage-2025-export.ged")
 	if err != nil {
-		t.Skipf("Test file not found: %s", "../testdata/edge-cases/myheritage-2025-export.ged")
+		t.Skipf("Test file not found: %s", "../testdata/vendors/myheritage/myheritage-2025-export.ged")
 		return
 	}
 	defer f.Close()
@@ -1190,9 +1188,9 @@ func TestMyHeritageRealExport(t *testing.T) {
 // CHAN change-tracking records, NAME TYPE birth subrecords, note references,
 // and header copyright preservation. See docs/governance/policies/compatibility.md.
 func TestGrampsRealExport(t *testing.T) {
-	f, err := os.Open("../testdata/edge-cases/gramps-2025-export.ged")
+	f, err := os.Open("../testdata/vendors/gramps/gramps-2025-export.ged")
 	if err != nil {
-		t.Skipf("Test file not found: %s", "../testdata/edge-cases/gramps-2025-export.ged")
+		t.Skipf("Test file not found: %s", "../testdata/vendors/gramps/gramps-2025-export.ged")
 		return
 	}
 	defer f.Close()
@@ -1261,17 +1259,17 @@ func TestVendorSpecificFiles(t *testing.T) {
 		minRecords  int
 	}{
 		{
-			path:        "../testdata/edge-cases/vendor-legacy.ged",
+			path:        "../testdata/vendors/legacy/vendor-legacy.ged",
 			description: "Legacy Family Tree 8.0 - custom tags (_TODO, _UID, _PRIV, _EVENT_DEFN, _PLAC_DEFN)",
 			minRecords:  10,
 		},
 		{
-			path:        "../testdata/edge-cases/vendor-ftm.ged",
+			path:        "../testdata/vendors/ftm/vendor-ftm.ged",
 			description: "Family Tree Maker 22.2.5 - custom tags (_MISN, _DNA, _FUN, _MILT, _EMPLOY, etc.)",
 			minRecords:  5,
 		},
 		{
-			path:        "../testdata/edge-cases/ftm-general.ged",
+			path:        "../testdata/vendors/ftm/ftm-general.ged",
 			description: "Family Tree Maker 22.2.5 - general export (Gramps test)",
 			minRecords:  5,
 		},
@@ -1296,7 +1294,7 @@ func TestVendorSpecificFiles(t *testing.T) {
 			minRecords:  2,
 		},
 		{
-			path:        "../testdata/edge-cases/vendor-familyhistorian.ged",
+			path:        "../testdata/vendors/familyhistorian/vendor-familyhistorian.ged",
 			description: "Family Historian 6.2.2 - custom tags (_ATTR, _USED, _SHAN, _SHAR, _FLGS, _PLAC)",
 			minRecords:  5,
 		},
@@ -1311,47 +1309,47 @@ func TestVendorSpecificFiles(t *testing.T) {
 			minRecords:  30, // 45 individuals, 18 families
 		},
 		{
-			path:        "../testdata/edge-cases/vendor-myheritage.ged",
+			path:        "../testdata/vendors/myheritage/vendor-myheritage.ged",
 			description: "MyHeritage Family Tree Builder - custom tags (_MHID, _MHTAG, _MHPID, _UID)",
 			minRecords:  5,
 		},
 		{
-			path:        "../testdata/edge-cases/vendor-gramps.ged",
+			path:        "../testdata/vendors/gramps/vendor-gramps.ged",
 			description: "Gramps 5.1.6 - custom tags (_GRAMPS_ID, _GRAMPS_PLACE_ID, _GRAMPS_ATTR)",
 			minRecords:  8,
 		},
 		{
-			path:        "../testdata/edge-cases/vendor-rootsmagic.ged",
+			path:        "../testdata/vendors/rootsmagic/vendor-rootsmagic.ged",
 			description: "RootsMagic 7.0.2.2 - mixed inline/xref notes (Gramps test)",
 			minRecords:  3,
 		},
 		{
-			path:        "../testdata/edge-cases/vendor-heredis.ged",
+			path:        "../testdata/vendors/heredis/vendor-heredis.ged",
 			description: "HEREDIS 14 PC - French genealogy software with Paris data (Gramps test)",
 			minRecords:  15,
 		},
 		{
-			path:        "../testdata/edge-cases/ancestry-2025-export.ged",
+			path:        "../testdata/vendors/ancestry/ancestry-2025-export.ged",
 			description: "Ancestry.com 2025.08 - real export with _TREE, long XRefs, nickname handling",
 			minRecords:  20,
 		},
 		{
-			path:        "../testdata/edge-cases/familysearch-2025-export.ged",
+			path:        "../testdata/vendors/familysearch/familysearch-2025-export.ged",
 			description: "FamilySearch 2025 - real export with _HASH/_LHASH tags, standardizer note",
 			minRecords:  20,
 		},
 		{
-			path:        "../testdata/edge-cases/myheritage-2025-export.ged",
+			path:        "../testdata/vendors/myheritage/myheritage-2025-export.ged",
 			description: "MyHeritage 2025 - real export with _UID, RIN, HTML notes, QUAY tags",
 			minRecords:  18,
 		},
 		{
-			path:        "../testdata/edge-cases/gramps-2025-export.ged",
+			path:        "../testdata/vendors/gramps/gramps-2025-export.ged",
 			description: "Gramps 6.0.6 - real export with CHAN records, TYPE birth, note references",
 			minRecords:  25,
 		},
 		{
-			path:        "../testdata/edge-cases/rootsmagic-2026-export.ged",
+			path:        "../testdata/vendors/rootsmagic/rootsmagic-2026-export.ged",
 			description: "RootsMagic 11 Essentials - real export with _UID, _TMPLT, _EVDEF, nested source templates",
 			minRecords:  20,
 		},
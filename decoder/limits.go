@@ -0,0 +1,166 @@
+package decoder
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cacack/gedcom-go/v2/parser"
+)
+
+// Limit names one of the resource limits in DecodeOptions.
+type Limit string
+
+// Limits that can stop a decode. Each is named after its DecodeOptions field.
+const (
+	LimitRecords      Limit = "MaxRecords"
+	LimitNestingDepth Limit = "MaxNestingDepth"
+	LimitLineLength   Limit = "MaxLineLength"
+	LimitTotalSize    Limit = "MaxTotalSize"
+	LimitNoteSize     Limit = "MaxNoteSize"
+)
+
+// LimitError is returned when the input exceeds one of the limits set in
+// DecodeOptions. Limits are hard: decoding stops even in lenient mode, and
+// no partial document is returned.
+type LimitError struct {
+	// Limit identifies the limit that was exceeded.
+	Limit Limit
+
+	// Max is the configured value of the limit.
+	Max int64
+
+	// Line is the line at which the limit was exceeded, or 0 if the limit
+	// is not tied to a line (MaxTotalSize).
+	Line int
+}
+
+// Error describes the exceeded limit.
+func (e *LimitError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("decode limit %s (%d) exceeded at line %d", e.Limit, e.Max, e.Line)
+	}
+	return fmt.Sprintf("decode limit %s (%d) exceeded", e.Limit, e.Max)
+}
+
+// ServerOptions returns decoding options with conservative limits for
+// services that decode untrusted uploads, so a malicious or corrupt file
+// fails fast with a *LimitError instead of exhausting memory. The limits
+// comfortably fit large real-world trees; raise them as needed.
+func ServerOptions() *DecodeOptions {
+	opts := DefaultOptions()
+	opts.MaxRecords = 1_000_000
+	opts.MaxNestingDepth = 32
+	opts.MaxLineLength = 64 << 10
+	opts.MaxTotalSize = 256 << 20
+	opts.MaxNoteSize = 1 << 20
+	return opts
+}
+
+// sizeLimitReader fails with a *LimitError once more than max bytes have
+// been read. It reads decompressed input, so gzip bombs are caught too.
+type sizeLimitReader struct {
+	reader io.Reader
+	read   int64
+	max    int64
+}
+
+// Read implements io.Reader.
+func (s *sizeLimitReader) Read(buf []byte) (int, error) {
+	if s.read > s.max {
+		return 0, &LimitError{Limit: LimitTotalSize, Max: s.max}
+	}
+	// Read at most one byte past the limit to detect that it was exceeded.
+	if remaining := s.max - s.read + 1; int64(len(buf)) > remaining {
+		buf = buf[:remaining]
+	}
+	n, err := s.reader.Read(buf)
+	s.read += int64(n)
+	if s.read > s.max {
+		return n, &LimitError{Limit: LimitTotalSize, Max: s.max}
+	}
+	return n, err
+}
+
+// parseOptions returns the parser options for opts.
+func parseOptions(opts *DecodeOptions, lenient bool) *parser.ParseOptions {
+	return &parser.ParseOptions{
+		Lenient:         lenient,
+		PreserveTrivia:  opts.PreserveFormatting,
+		MaxNestingDepth: opts.MaxNestingDepth,
+		MaxLineLength:   opts.MaxLineLength,
+	}
+}
+
+// limitError converts a parse failure caused by a decode limit into a
+// *LimitError, returning nil for any other error.
+func limitError(err error, opts *DecodeOptions) error {
+	var le *LimitError
+	if errors.As(err, &le) {
+		return le
+	}
+	var pe *parser.ParseError
+	if !errors.As(err, &pe) {
+		return nil
+	}
+	switch {
+	case errors.Is(err, parser.ErrNestingTooDeep):
+		depth := opts.MaxNestingDepth
+		if depth <= 0 {
+			depth = parser.MaxNestingDepth
+		}
+		return &LimitError{Limit: LimitNestingDepth, Max: int64(depth), Line: pe.Line}
+	case errors.Is(err, bufio.ErrTooLong):
+		length := opts.MaxLineLength
+		if length <= 0 {
+			length = parser.MaxLineBytes
+		}
+		return &LimitError{Limit: LimitLineLength, Max: int64(length), Line: pe.Line + 1}
+	}
+	return nil
+}
+
+// checkLineLimits enforces the limits that depend on parsed lines: the
+// number of records and the size of each note, counting its CONT and CONC
+// continuations. It runs before the document is built.
+func checkLineLimits(lines []*parser.Line, opts *DecodeOptions) error {
+	if opts.MaxRecords <= 0 && opts.MaxNoteSize <= 0 {
+		return nil
+	}
+	records := 0
+	for i, line := range lines {
+		if line.Level == 0 && line.Tag != "HEAD" && line.Tag != "TRLR" {
+			records++
+			if opts.MaxRecords > 0 && records > opts.MaxRecords {
+				return &LimitError{Limit: LimitRecords, Max: int64(opts.MaxRecords), Line: line.LineNumber}
+			}
+		}
+		if opts.MaxNoteSize <= 0 || (line.Tag != "NOTE" && line.Tag != "SNOTE") {
+			continue
+		}
+		if noteSize(line, lines[i+1:]) > opts.MaxNoteSize {
+			return &LimitError{Limit: LimitNoteSize, Max: int64(opts.MaxNoteSize), Line: line.LineNumber}
+		}
+	}
+	return nil
+}
+
+// noteSize returns the size of the note text of line, counting the CONT
+// and CONC continuations among the lines that follow it.
+func noteSize(line *parser.Line, following []*parser.Line) int {
+	size := len(line.Value)
+	for _, next := range following {
+		if next.Level != line.Level+1 || (next.Tag != "CONT" && next.Tag != "CONC") {
+			if next.Level <= line.Level {
+				break
+			}
+			continue
+		}
+		size += len(next.Value)
+		if next.Tag == "CONT" {
+			size++ // the newline it stands for
+		}
+	}
+	return size
+}
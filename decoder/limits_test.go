@@ -0,0 +1,120 @@
+package decoder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const limitsInput = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 NOTE First line
+2 CONT second line
+2 CONC , continued
+0 @I2@ INDI
+1 NAME Jane /Smith/
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+0 TRLR
+`
+
+func TestDecodeLimits(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  DecodeOptions
+		want  LimitError
+	}{
+		{
+			name:  "records",
+			input: limitsInput,
+			opts:  DecodeOptions{MaxRecords: 2},
+			want:  LimitError{Limit: LimitRecords, Max: 2, Line: 11},
+		},
+		{
+			name:  "nesting depth",
+			input: "0 HEAD\n0 @I1@ INDI\n1 BIRT\n2 DATE 1900\n3 TIME 12:00\n0 TRLR\n",
+			opts:  DecodeOptions{MaxNestingDepth: 2},
+			want:  LimitError{Limit: LimitNestingDepth, Max: 2, Line: 5},
+		},
+		{
+			name:  "line length",
+			input: "0 HEAD\n0 @I1@ INDI\n1 NOTE " + strings.Repeat("x", 100) + "\n0 TRLR\n",
+			opts:  DecodeOptions{MaxLineLength: 64},
+			want:  LimitError{Limit: LimitLineLength, Max: 64, Line: 3},
+		},
+		{
+			name:  "total size",
+			input: limitsInput,
+			opts:  DecodeOptions{MaxTotalSize: 50},
+			want:  LimitError{Limit: LimitTotalSize, Max: 50},
+		},
+		{
+			name:  "note size",
+			input: limitsInput,
+			opts:  DecodeOptions{MaxNoteSize: 30},
+			want:  LimitError{Limit: LimitNoteSize, Max: 30, Line: 6},
+		},
+	}
+	for _, tt := range tests {
+		for _, strict := range []bool{false, true} {
+			opts := tt.opts
+			opts.StrictMode = strict
+
+			_, err := DecodeWithOptions(strings.NewReader(tt.input), &opts)
+			var le *LimitError
+			if !errors.As(err, &le) || *le != tt.want {
+				t.Errorf("%s (strict=%v): DecodeWithOptions() error = %v, want %v", tt.name, strict, err, &tt.want)
+			}
+
+			result, err := DecodeWithDiagnostics(strings.NewReader(tt.input), &opts)
+			if !errors.As(err, &le) || *le != tt.want || result != nil {
+				t.Errorf("%s (strict=%v): DecodeWithDiagnostics() = %v, %v, want %v", tt.name, strict, result, err, &tt.want)
+			}
+		}
+	}
+}
+
+func TestDecodeLimits_WithinLimits(t *testing.T) {
+	opts := &DecodeOptions{
+		MaxRecords:      3,
+		MaxNestingDepth: 2,
+		MaxLineLength:   20,
+		MaxTotalSize:    int64(len(limitsInput)),
+		MaxNoteSize:     len("First line\nsecond line, continued"),
+	}
+	doc, err := DecodeWithOptions(strings.NewReader(limitsInput), opts)
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+	if len(doc.Records) != 3 {
+		t.Errorf("got %d records, want 3", len(doc.Records))
+	}
+
+	if _, err := DecodeWithOptions(strings.NewReader(limitsInput), ServerOptions()); err != nil {
+		t.Errorf("DecodeWithOptions(ServerOptions()) error = %v", err)
+	}
+}
+
+func TestDecodeLimits_GzipBomb(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write([]byte(limitsInput))
+	_, _ = zw.Write(bytes.Repeat([]byte("0 @N1@ NOTE padding\n"), 10000))
+	_ = zw.Close()
+
+	_, err := DecodeWithOptions(&buf, &DecodeOptions{MaxTotalSize: 4096})
+	var le *LimitError
+	if !errors.As(err, &le) || le.Limit != LimitTotalSize {
+		t.Fatalf("DecodeWithOptions() error = %v, want MaxTotalSize limit", err)
+	}
+	if got := le.Error(); got != "decode limit MaxTotalSize (4096) exceeded" {
+		t.Errorf("Error() = %q", got)
+	}
+}
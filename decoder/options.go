@@ -1,6 +1,12 @@
 package decoder
 
-import "context"
+import (
+	"context"
+	"log/slog"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+	"github.com/cacack/gedcom-go/v2/parser"
+)
 
 // ProgressCallback reports parsing progress during GEDCOM decoding.
 // bytesRead is the cumulative bytes read so far.
@@ -8,6 +14,10 @@ import "context"
 type ProgressCallback func(bytesRead, totalBytes int64)
 
 // DecodeOptions provides configuration options for decoding GEDCOM files.
+//
+// The Max* fields bound the resources a decode may use. Exceeding one stops
+// decoding with a *LimitError naming it; see ServerOptions for limits
+// suited to untrusted input.
 type DecodeOptions struct {
 	// Context allows cancellation and timeout control
 	Context context.Context
@@ -16,6 +26,22 @@ type DecodeOptions struct {
 	// This prevents stack overflow with malformed files
 	MaxNestingDepth int
 
+	// MaxRecords limits the number of level 0 records, not counting HEAD
+	// and TRLR. 0 means unlimited.
+	MaxRecords int
+
+	// MaxLineLength limits the length of a single line in bytes
+	// (default: parser.MaxLineBytes, 1 MiB).
+	MaxLineLength int
+
+	// MaxTotalSize limits the size of the input in bytes, measured after
+	// gzip decompression. 0 means unlimited.
+	MaxTotalSize int64
+
+	// MaxNoteSize limits the size in bytes of one note's text, including
+	// its CONT and CONC continuation lines. 0 means unlimited.
+	MaxNoteSize int
+
 	// StrictMode controls how parsing errors are handled.
 	//
 	// When StrictMode is true:
@@ -37,6 +63,12 @@ type DecodeOptions struct {
 	// GEDCOMs, which often contain non-standard extensions or formatting quirks.
 	StrictMode bool
 
+	// Strictness is the strictness shared with the validator, converter,
+	// and encoder options. gedcom.StrictnessStrict implies StrictMode;
+	// StrictnessNormal and StrictnessLenient both recover from errors in
+	// DecodeWithDiagnostics as described above. Default: StrictnessNormal.
+	Strictness gedcom.Strictness
+
 	// OnProgress is called periodically during parsing to report progress.
 	// If nil, no progress reporting occurs (zero overhead).
 	OnProgress ProgressCallback
@@ -44,6 +76,20 @@ type DecodeOptions struct {
 	// TotalSize is the expected total size of the input in bytes.
 	// Set to 0 (default) if unknown; will be reported as -1 to the callback.
 	TotalSize int64
+
+	// PreserveFormatting keeps blank lines and vendor comment lines
+	// (starting with "#" or "//") as gedcom.Trivia on the header, records,
+	// and trailer, and records the file's line endings and byte order mark
+	// in Document.Formatting. Encoding with EncodeOptions.PreserveFormatting
+	// then reproduces an unchanged file byte-for-byte. Without it, blank
+	// lines are skipped with a diagnostic (or rejected in strict mode).
+	PreserveFormatting bool
+
+	// Logger, if set, receives a debug-level record ("unmapped tag", with
+	// record, path, tag, and line attributes) for each record tag left
+	// unmapped by entity population; see UnmappedTag. DecodeWithDiagnostics
+	// also returns them in DecodeResult.UnmappedTags.
+	Logger *slog.Logger
 }
 
 // DefaultOptions returns the default decoding options.
@@ -51,6 +97,14 @@ func DefaultOptions() *DecodeOptions {
 	return &DecodeOptions{
 		Context:         context.Background(),
 		MaxNestingDepth: 100,
+		MaxLineLength:   parser.MaxLineBytes,
 		StrictMode:      false,
+		Strictness:      gedcom.StrictnessNormal,
 	}
 }
+
+// strict reports whether decoding stops at the first error, by StrictMode
+// or Strictness.
+func (opts *DecodeOptions) strict() bool {
+	return opts.StrictMode || opts.Strictness == gedcom.StrictnessStrict
+}
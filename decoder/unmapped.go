@@ -0,0 +1,130 @@
+package decoder
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// UnmappedTag is a tag that entity population did not map into a typed
+// field, so that its data (and that of its subordinate tags) lives only in
+// the raw Tags of its record. Unknown standard tags, vendor extensions
+// without a registered schema or parser, and standard tags the decoder
+// does not parse yet are all unmapped.
+type UnmappedTag struct {
+	gedcom.TagLocation
+
+	// Tag is the tag name (e.g., "_PRIM").
+	Tag string
+
+	// Value is the tag's value, if any.
+	Value string
+}
+
+// String returns a human-friendly description of the unmapped tag, e.g.
+// `unmapped tag _PRIM at INDI.BIRT._PRIM in record @I1@ (line 12)`.
+//
+//nolint:gocritic // Value receiver intentional for immutability
+func (u UnmappedTag) String() string {
+	s := fmt.Sprintf("unmapped tag %s at %s", u.Tag, u.Path)
+	if u.RecordXRef != "" {
+		s += " in record " + u.RecordXRef
+	}
+	if u.LineNumber > 0 {
+		s += fmt.Sprintf(" (line %d)", u.LineNumber)
+	}
+	return s
+}
+
+// markUnmapped records a tag that entity population left in raw form.
+func (c *diagnosticCollector) markUnmapped(tag *gedcom.Tag) {
+	if c == nil {
+		return
+	}
+	if c.unmapped == nil {
+		c.unmapped = make(map[*gedcom.Tag]bool)
+	}
+	c.unmapped[tag] = true
+}
+
+// addUnmappedTag records a tag that entity population does not recognize,
+// with an unknown tag diagnostic unless it is a vendor extension (tags
+// starting with "_" are expected).
+func (c *diagnosticCollector) addUnmappedTag(tag *gedcom.Tag) {
+	if c == nil {
+		return
+	}
+	c.markUnmapped(tag)
+	if !strings.HasPrefix(tag.Tag, "_") {
+		c.addUnknownTag(tag.LineNumber, tag.Tag, tag.Value)
+	}
+}
+
+// collectUnmapped turns the tags of record marked during its population
+// into UnmappedTags, in file order, and logs each one. Level-1 extensions
+// with a registered schema are mapped into the record's ExtensionData and
+// so are left out. A record of a type without an entity is unmapped as a
+// whole.
+func (c *diagnosticCollector) collectUnmapped(record *gedcom.Record, populated bool) {
+	if c == nil {
+		return
+	}
+	root := string(record.Type)
+	if !populated {
+		c.addUnmapped(UnmappedTag{
+			TagLocation: gedcom.TagLocation{RecordXRef: record.XRef, Path: root, LineNumber: record.LineNumber},
+			Tag:         root,
+			Value:       record.Value,
+		})
+		c.unmapped = nil
+		return
+	}
+	if len(c.unmapped) == 0 {
+		return
+	}
+
+	extensions := record.ExtensionData()
+	path := []string{root}
+	for _, tag := range record.Tags {
+		if tag == nil || tag.Level < 1 {
+			continue
+		}
+		if tag.Level < len(path) {
+			path = path[:tag.Level]
+		}
+		for len(path) < tag.Level {
+			path = append(path, "")
+		}
+		path = append(path, tag.Tag)
+		if !c.unmapped[tag] || (tag.Level == 1 && extensions.Get(tag.Tag) != nil) {
+			continue
+		}
+		c.addUnmapped(UnmappedTag{
+			TagLocation: gedcom.TagLocation{RecordXRef: record.XRef, Path: strings.Join(path, "."), LineNumber: tag.LineNumber},
+			Tag:         tag.Tag,
+			Value:       tag.Value,
+		})
+	}
+	c.unmapped = nil
+}
+
+// addUnmapped appends an unmapped tag and logs it at debug level.
+func (c *diagnosticCollector) addUnmapped(u UnmappedTag) {
+	c.unmappedTags = append(c.unmappedTags, u)
+	if c.logger == nil {
+		return
+	}
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "unmapped tag",
+		slog.String("record", u.RecordXRef),
+		slog.String("path", u.Path),
+		slog.String("tag", u.Tag),
+		slog.Int("line", u.LineNumber),
+	)
+}
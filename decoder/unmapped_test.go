@@ -0,0 +1,97 @@
+package decoder
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const unmappedInput = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+2 FONE Jon Smit
+1 BIRT
+2 DATE 1850
+2 _PRIM Y
+1 _MYEXT value
+2 _SUB nested
+1 FOOB unknown
+1 _UNMAP kept raw
+0 @P1@ _PLAC Springfield
+1 MAP
+0 TRLR
+`
+
+func TestDecodeWithDiagnostics_UnmappedTags(t *testing.T) {
+	if err := gedcom.RegisterExtension(gedcom.ExtensionSchema{Tag: "_MYEXT"}); err != nil {
+		t.Fatalf("RegisterExtension() error = %v", err)
+	}
+	defer gedcom.UnregisterExtension("_MYEXT")
+
+	for _, strict := range []bool{false, true} {
+		opts := DefaultOptions()
+		opts.StrictMode = strict
+		result, err := DecodeWithDiagnostics(strings.NewReader(unmappedInput), opts)
+		if err != nil {
+			t.Fatalf("DecodeWithDiagnostics(strict=%v) error = %v", strict, err)
+		}
+
+		var got []string
+		for _, u := range result.UnmappedTags {
+			got = append(got, u.String())
+		}
+		want := []string{
+			"unmapped tag FONE at INDI.NAME.FONE in record @I1@ (line 6)",
+			"unmapped tag _PRIM at INDI.BIRT._PRIM in record @I1@ (line 9)",
+			"unmapped tag FOOB at INDI.FOOB in record @I1@ (line 12)",
+			"unmapped tag _UNMAP at INDI._UNMAP in record @I1@ (line 13)",
+			"unmapped tag _PLAC at _PLAC in record @P1@ (line 14)",
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("strict=%v UnmappedTags =\n%s\nwant\n%s", strict, strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+		if u := result.UnmappedTags[3]; u.RecordXRef != "@I1@" || u.Value != "kept raw" {
+			t.Errorf("UnmappedTags[3] = %+v", u)
+		}
+
+		// Only unknown standard tags are diagnosed, and only leniently
+		var unknown Diagnostics
+		for _, d := range result.Diagnostics {
+			if d.Code == CodeUnknownTag {
+				unknown = append(unknown, d)
+			}
+		}
+		if wantN := map[bool]int{false: 1, true: 0}[strict]; len(unknown) != wantN {
+			t.Errorf("strict=%v UNKNOWN_TAG diagnostics = %v, want %d", strict, unknown, wantN)
+		}
+	}
+}
+
+func TestDecodeOptions_Logger(t *testing.T) {
+	var buf bytes.Buffer
+	opts := DefaultOptions()
+	opts.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	if _, err := DecodeWithOptions(strings.NewReader(unmappedInput), opts); err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+	logged := buf.String()
+	// _MYEXT is not registered here, so it is unmapped too
+	if n := strings.Count(logged, `msg="unmapped tag"`); n != 6 {
+		t.Errorf("logged %d unmapped tags, want 6:\n%s", n, logged)
+	}
+	if !strings.Contains(logged, "record=@I1@ path=INDI.BIRT._PRIM tag=_PRIM line=9") {
+		t.Errorf("log lacks the _PRIM record:\n%s", logged)
+	}
+}
+
+func TestUnmappedTag_String(t *testing.T) {
+	u := UnmappedTag{TagLocation: gedcom.TagLocation{Path: "INDI._X"}, Tag: "_X"}
+	if got := u.String(); got != "unmapped tag _X at INDI._X" {
+		t.Errorf("String() = %q", got)
+	}
+}
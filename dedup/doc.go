@@ -0,0 +1,36 @@
+// Package dedup finds and merges duplicate records in GEDCOM documents.
+//
+// The merge package deliberately stops at XRef bookkeeping; this package
+// holds the opinionated part: deciding that two records describe the same
+// thing, and folding one into the other. Every operation is split in two
+// so that callers can review proposals before applying them:
+//
+//   - FindDuplicateSources: group SOUR records that are likely the same
+//     source, either because their title, author, and publication match
+//     after normalization, or because they are tied to the same Ancestry
+//     database by an _APID identifier. Trees built from repeated Ancestry
+//     imports typically carry one SOUR record per import.
+//   - MergeSources: fold each group's duplicates into its kept record,
+//     re-point every citation and reference at the kept record, and
+//     report what was merged.
+//   - DedupSources: both steps in one call.
+//   - FindDuplicateIndividuals: rank pairs of INDI records that may be
+//     the same person, scoring name phonetics (Soundex and Metaphone),
+//     date proximity, places, and shared relatives, with an explanation
+//     per field. ScoreIndividuals scores a single pair.
+//   - MergeAliasGroups: fold each group of INDI records found by
+//     gedcom.Document.ResolveAliases into its primary record.
+//     MergeIndividuals does the same for an explicit set of XRefs.
+//   - MergeFamilies: fold one FAM record into another, such as a pair
+//     found by validator.Validator.FindPotentialDuplicateFamilies,
+//     reporting differing spouses as conflicts.
+//   - SplitIndividual: the reverse of a merge; move selected names,
+//     events, citations, and family links of one individual to a new
+//     record.
+//
+// To deduplicate sources across several files, combine them first with
+// merge.Combine, or use DedupSourcesAcross, which does so with
+// merge.RenumberDoc2.
+//
+// All operations return a new document; inputs are never mutated.
+package dedup
@@ -0,0 +1,254 @@
+package dedup
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// FamilyReport summarizes a MergeFamilies call.
+type FamilyReport struct {
+	// Kept is the XRef of the surviving FAM record.
+	Kept string
+
+	// Merged is the XRef of the FAM record folded into Kept and removed.
+	Merged string
+
+	// AddedChildren lists the children of Merged that Kept did not have.
+	AddedChildren []string
+
+	// Conflicts lists single-valued tags (e.g. "HUSB", "WIFE") whose values
+	// differed between Kept and Merged. The kept record's value was
+	// retained; a conflicting HUSB or WIFE needs manual handling, since that
+	// individual's FAMS link is re-pointed at Kept like every other link.
+	Conflicts []string
+
+	// RepointedReferences counts the pointers (FAMS, FAMC, ...) re-pointed
+	// from Merged to Kept.
+	RepointedReferences int
+}
+
+// MergeFamilies folds the family drop into keep and returns the merged
+// document. Candidate pairs come from
+// validator.Validator.FindPotentialDuplicateFamilies.
+//
+// Level-1 structures keep lacks are copied from drop: HUSB, WIFE, RESN,
+// and NCHI only when keep has none, CHIL for children keep does not list,
+// and other repeatable ones (events, SOUR, NOTE, OBJE, REFN, extension
+// tags, ...) unless an identical structure is already present. CHAN and
+// CREA are never copied. Typed Family fields are merged the same way. The
+// drop record is then removed, every FAMS and FAMC link to it is
+// re-pointed at keep, and the duplicate links this leaves on individuals
+// are dropped.
+//
+// Returns an error if doc is nil, if keep and drop are equal, or if either
+// is not a FAM record of doc.
+func MergeFamilies(doc *gedcom.Document, keep, drop string) (*gedcom.Document, *FamilyReport, error) {
+	if doc == nil {
+		return nil, nil, errors.New("dedup: document is nil")
+	}
+	if keep == drop {
+		return nil, nil, fmt.Errorf("dedup: cannot merge family %s into itself", keep)
+	}
+
+	out := doc.Clone()
+	keepRecord, dropRecord, err := familyPair(out, keep, drop)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &FamilyReport{Kept: keep, Merged: drop}
+	report.AddedChildren, report.Conflicts = mergeFamilyRecord(keepRecord, dropRecord)
+
+	kept := out.Records[:0]
+	for _, record := range out.Records {
+		if record != dropRecord {
+			kept = append(kept, record)
+		}
+	}
+	out.Records = kept
+	delete(out.XRefMap, drop)
+
+	mapping := map[string]string{drop: keep}
+	for _, record := range out.Records {
+		report.RepointedReferences += countReferences(record, mapping)
+	}
+	gedcom.Apply(out, mapping)
+
+	for _, record := range out.Records {
+		if record != nil && record.Type == gedcom.RecordTypeIndividual {
+			dropSelfLinks(record)
+		}
+	}
+	dropDuplicateMembers(keepRecord)
+
+	return out, report, nil
+}
+
+// familyPair returns the FAM records keep and drop of doc.
+func familyPair(doc *gedcom.Document, keep, drop string) (keepRecord, dropRecord *gedcom.Record, err error) {
+	for _, record := range doc.Records {
+		if record == nil || record.Type != gedcom.RecordTypeFamily {
+			continue
+		}
+		switch record.XRef {
+		case keep:
+			keepRecord = record
+		case drop:
+			dropRecord = record
+		}
+	}
+	if keepRecord == nil {
+		return nil, nil, fmt.Errorf("dedup: %s is not a family record", keep)
+	}
+	if dropRecord == nil {
+		return nil, nil, fmt.Errorf("dedup: %s is not a family record", drop)
+	}
+	return keepRecord, dropRecord, nil
+}
+
+// singleValuedFamilyTags are level-1 FAM substructures copied from a
+// duplicate only when the kept record has none.
+var singleValuedFamilyTags = map[string]bool{"HUSB": true, "WIFE": true, "RESN": true, "NCHI": true}
+
+// skippedFamilyTags are never copied from a duplicate.
+var skippedFamilyTags = map[string]bool{"CHAN": true, "CREA": true}
+
+// mergeFamilyRecord folds dup into keep and returns the children added and
+// the single-valued tags whose values conflicted.
+func mergeFamilyRecord(keep, dup *gedcom.Record) (added, conflicts []string) {
+	keepFam, _ := keep.Entity.(*gedcom.Family)
+	dupFam, _ := dup.Entity.(*gedcom.Family)
+
+	children := &childSet{seen: make(map[string]bool)}
+	if keepFam != nil {
+		for _, child := range keepFam.Children {
+			children.seen[child] = true
+		}
+	}
+	for _, tag := range keep.Tags {
+		if tag.Level == 1 && tag.Tag == "CHIL" {
+			children.seen[tag.Value] = true
+		}
+	}
+
+	if len(dup.Tags) > 0 {
+		conflicts = mergeFamilyTags(keep, dup, children)
+	}
+	if keepFam != nil && dupFam != nil {
+		for _, child := range dupFam.Children {
+			if !containsString(keepFam.Children, child) {
+				children.add(child)
+				keepFam.Children = append(keepFam.Children, child)
+			}
+		}
+		conflicts = appendUnique(conflicts, mergeFamilyEntity(keepFam, dupFam)...)
+		if len(keep.Tags) > 0 {
+			keepFam.Tags = keep.Tags
+			keep.PopulateExtensionData()
+		}
+	}
+	return children.added, conflicts
+}
+
+// childSet tracks the children of a kept family and those added to it.
+type childSet struct {
+	seen  map[string]bool
+	added []string
+}
+
+// add records child as added unless the family already has it.
+func (c *childSet) add(child string) {
+	if child != "" && !c.seen[child] {
+		c.seen[child] = true
+		c.added = append(c.added, child)
+	}
+}
+
+// mergeFamilyTags copies the level-1 structures of dup's raw tags that
+// keep lacks and returns the single-valued tags whose values conflicted.
+func mergeFamilyTags(keep, dup *gedcom.Record, children *childSet) []string {
+	var conflicts []string
+	keepBlocks := splitBlocks(keep.Tags)
+	for _, block := range splitBlocks(dup.Tags) {
+		tag := block[0].Tag
+		switch {
+		case skippedFamilyTags[tag]:
+			continue
+		case tag == "CHIL":
+			if children.seen[block[0].Value] {
+				continue
+			}
+			children.add(block[0].Value)
+		case singleValuedFamilyTags[tag]:
+			if existing := findBlock(keepBlocks, tag); existing != nil {
+				if renderBlock(existing) != renderBlock(block) {
+					conflicts = appendUnique(conflicts, tag)
+				}
+				continue
+			}
+		case containsBlock(keepBlocks, block):
+			continue
+		}
+		copied := gedcom.CloneTags(block)
+		keep.Tags = append(keep.Tags, copied...)
+		keepBlocks = append(keepBlocks, copied)
+	}
+	return conflicts
+}
+
+// mergeFamilyEntity folds the typed fields of dup, other than Children,
+// into keep and returns the single-valued fields whose values conflicted.
+func mergeFamilyEntity(keep, dup *gedcom.Family) []string {
+	var conflicts []string
+	fill := func(tag string, dst *string, src string) {
+		switch {
+		case src == "":
+		case *dst == "":
+			*dst = src
+		case *dst != src:
+			conflicts = append(conflicts, tag)
+		}
+	}
+	husband, wife := keep.Husband, keep.Wife
+	fill("HUSB", &husband, dup.Husband)
+	fill("WIFE", &wife, dup.Wife)
+	if husband != keep.Husband {
+		keep.SetHusband(husband)
+	}
+	if wife != keep.Wife {
+		keep.SetWife(wife)
+	}
+	fill("RESN", (*string)(&keep.Restriction), string(dup.Restriction))
+	fill("NCHI", &keep.NumberOfChildren, dup.NumberOfChildren)
+	fill("UID", &keep.UID, dup.UID)
+
+	keep.Events = appendDistinct(keep.Events, dup.Events...)
+	keep.SourceCitations = appendDistinct(keep.SourceCitations, dup.SourceCitations...)
+	keep.NoteXRefs = appendUnique(keep.NoteXRefs, dup.NoteXRefs...)
+	keep.InlineNotes = appendUnique(keep.InlineNotes, dup.InlineNotes...)
+	keep.Notes = appendUnique(keep.Notes, dup.Notes...)
+	keep.Media = appendDistinct(keep.Media, dup.Media...)
+	keep.LDSOrdinances = appendDistinct(keep.LDSOrdinances, dup.LDSOrdinances...)
+	keep.UserReferences = appendDistinct(keep.AllUserReferences(), dup.AllUserReferences()...)
+	if keep.RefNumber == "" && len(keep.UserReferences) > 0 {
+		keep.RefNumber = keep.UserReferences[0].Number
+	}
+	keep.ExternalIDs = appendDistinct(keep.ExternalIDs, dup.ExternalIDs...)
+	for tag, exts := range dup.ExtensionData {
+		for _, ext := range exts {
+			keep.ExtensionData = addExtension(keep.ExtensionData, tag, ext)
+		}
+	}
+	return conflicts
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package dedup_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/dedup"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func familyDoc(t *testing.T) *gedcom.Document {
+	t.Helper()
+	return decode(t,
+		"0 @I1@ INDI",
+		"1 NAME John /Doe/",
+		"1 FAMS @F1@",
+		"1 FAMS @F2@",
+		"0 @I2@ INDI",
+		"1 NAME Jane /Roe/",
+		"1 FAMS @F1@",
+		"0 @I3@ INDI",
+		"1 NAME Ann /Doe/",
+		"1 FAMC @F1@",
+		"1 FAMC @F2@",
+		"0 @I4@ INDI",
+		"1 NAME Bob /Doe/",
+		"1 FAMC @F2@",
+		"0 @I5@ INDI",
+		"1 NAME Mary /Poe/",
+		"1 FAMS @F3@",
+		"0 @F1@ FAM",
+		"1 HUSB @I1@",
+		"1 WIFE @I2@",
+		"1 CHIL @I3@",
+		"1 MARR",
+		"2 DATE 1 JUN 1900",
+		"0 @F2@ FAM",
+		"1 HUSB @I1@",
+		"1 CHIL @I3@",
+		"1 CHIL @I4@",
+		"1 MARR",
+		"2 DATE 1 JUN 1900",
+		"1 DIV",
+		"2 DATE 1920",
+		"1 CHAN",
+		"2 DATE 1 JAN 2020",
+		"0 @F3@ FAM",
+		"1 HUSB @I1@",
+		"1 WIFE @I5@",
+	)
+}
+
+func TestMergeFamilies(t *testing.T) {
+	doc := familyDoc(t)
+	out, report, err := dedup.MergeFamilies(doc, "@F1@", "@F2@")
+	if err != nil {
+		t.Fatalf("MergeFamilies() error = %v", err)
+	}
+
+	if out.GetFamily("@F2@") != nil {
+		t.Error("merged family @F2@ still present")
+	}
+	if doc.GetFamily("@F2@") == nil {
+		t.Error("input document was mutated")
+	}
+
+	fam := out.GetFamily("@F1@")
+	if !reflect.DeepEqual(fam.Children, []string{"@I3@", "@I4@"}) {
+		t.Errorf("Children = %v", fam.Children)
+	}
+	if len(fam.Events) != 2 {
+		t.Errorf("Events = %d, want MARR and DIV", len(fam.Events))
+	}
+	if !reflect.DeepEqual(report.AddedChildren, []string{"@I4@"}) {
+		t.Errorf("AddedChildren = %v", report.AddedChildren)
+	}
+	if len(report.Conflicts) != 0 {
+		t.Errorf("Conflicts = %v", report.Conflicts)
+	}
+	// I1 FAMS, I3 FAMC, I4 FAMC.
+	if report.RepointedReferences != 3 {
+		t.Errorf("RepointedReferences = %d, want 3", report.RepointedReferences)
+	}
+
+	if got := out.GetIndividual("@I1@").SpouseInFamilies; !reflect.DeepEqual(got, []string{"@F1@"}) {
+		t.Errorf("I1 FAMS = %v", got)
+	}
+	if got := out.GetIndividual("@I3@").ChildInFamilies; len(got) != 1 || got[0].FamilyXRef != "@F1@" {
+		t.Errorf("I3 FAMC = %v", got)
+	}
+	if got := out.GetIndividual("@I4@").ChildInFamilies; len(got) != 1 || got[0].FamilyXRef != "@F1@" {
+		t.Errorf("I4 FAMC = %v", got)
+	}
+
+	text := encode(t, out)
+	for _, want := range []string{"1 CHIL @I4@", "1 DIV"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("output missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Count(text, "1 CHIL @I3@") != 1 || strings.Count(text, "1 MARR") != 1 {
+		t.Errorf("duplicate CHIL or MARR in output:\n%s", text)
+	}
+	if strings.Contains(text, "@F2@") || strings.Contains(text, "1 CHAN") {
+		t.Errorf("output still references @F2@ or copied CHAN:\n%s", text)
+	}
+}
+
+func TestMergeFamilies_Conflicts(t *testing.T) {
+	out, report, err := dedup.MergeFamilies(familyDoc(t), "@F1@", "@F3@")
+	if err != nil {
+		t.Fatalf("MergeFamilies() error = %v", err)
+	}
+	if !reflect.DeepEqual(report.Conflicts, []string{"WIFE"}) {
+		t.Errorf("Conflicts = %v", report.Conflicts)
+	}
+	if got := out.GetFamily("@F1@").Wife; got != "@I2@" {
+		t.Errorf("Wife = %q, want kept @I2@", got)
+	}
+	// The conflicting wife is re-pointed too and left for manual handling.
+	if got := out.GetIndividual("@I5@").SpouseInFamilies; !reflect.DeepEqual(got, []string{"@F1@"}) {
+		t.Errorf("I5 FAMS = %v", got)
+	}
+}
+
+// TestMergeFamilies_Spouses merges a family with a wife into one without
+// and checks the kept family's Spouses gain the WIFE too.
+func TestMergeFamilies_Spouses(t *testing.T) {
+	out, _, err := dedup.MergeFamilies(familyDoc(t), "@F2@", "@F1@")
+	if err != nil {
+		t.Fatalf("MergeFamilies() error = %v", err)
+	}
+	fam := out.GetFamily("@F2@")
+	if fam.Wife != "@I2@" {
+		t.Errorf("Wife = %q, want @I2@", fam.Wife)
+	}
+	var wives []string
+	for _, spouse := range fam.Spouses {
+		if spouse != nil && spouse.Tag == "WIFE" {
+			wives = append(wives, spouse.XRef)
+		}
+	}
+	if !reflect.DeepEqual(wives, []string{"@I2@"}) {
+		t.Errorf("Spouses WIFE = %v, want [@I2@]", wives)
+	}
+}
+
+func TestMergeFamilies_Errors(t *testing.T) {
+	doc := familyDoc(t)
+	tests := []struct {
+		name       string
+		doc        *gedcom.Document
+		keep, drop string
+	}{
+		{"nil document", nil, "@F1@", "@F2@"},
+		{"same family", doc, "@F1@", "@F1@"},
+		{"missing keep", doc, "@F9@", "@F2@"},
+		{"not a family", doc, "@F1@", "@I1@"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := dedup.MergeFamilies(tt.doc, tt.keep, tt.drop); err == nil {
+				t.Error("MergeFamilies() error = nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package dedup
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// IndividualMerge describes the individuals folded into one kept record.
+type IndividualMerge struct {
+	// Kept is the XRef of the surviving INDI record.
+	Kept string
+
+	// Merged are the XRefs of the records folded into Kept and removed.
+	Merged []string
+
+	// Conflicts lists single-valued tags (e.g. "SEX") whose values differed
+	// between Kept and a merged record. The kept record's value was retained.
+	Conflicts []string
+}
+
+// IndividualReport summarizes a MergeIndividuals or MergeAliasGroups call.
+type IndividualReport struct {
+	// Merges has one entry per merged group.
+	Merges []IndividualMerge
+
+	// RepointedReferences counts the pointers (FAMC, FAMS, HUSB, WIFE,
+	// CHIL, ASSO, ALIA, ...) re-pointed from a merged record to its kept
+	// record.
+	RepointedReferences int
+}
+
+// RemovedCount returns the number of INDI records removed by merging.
+func (r *IndividualReport) RemovedCount() int {
+	n := 0
+	for _, m := range r.Merges {
+		n += len(m.Merged)
+	}
+	return n
+}
+
+// MergeIndividuals folds the individuals drop into keep and returns the
+// merged document. It is MergeAliasGroups with a single group whose
+// Primary is keep.
+func MergeIndividuals(doc *gedcom.Document, keep string, drop ...string) (*gedcom.Document, *IndividualReport, error) {
+	group := gedcom.AliasGroup{XRefs: append([]string{keep}, drop...), Primary: keep}
+	return MergeAliasGroups(doc, []gedcom.AliasGroup{group})
+}
+
+// MergeAliasGroups folds the members of each group into its Primary, as
+// found by gedcom.Document.ResolveAliases. A group with no Primary keeps
+// its first member.
+//
+// For each merged record, level-1 structures the kept record lacks are
+// copied over: SEX, RESN, and _FSFTID only when the kept record has none,
+// and repeatable ones (NAME, events, FAMC, FAMS, SOUR, NOTE, OBJE, REFN,
+// extension tags, ...) unless an identical structure is already present.
+// CHAN and CREA are never copied. Typed Individual fields are merged the
+// same way. The merged records are then removed and every reference to
+// them is re-pointed at the kept record; ALIA links that now point at the
+// kept record itself are dropped, as are the duplicate HUSB, WIFE, and
+// CHIL links this leaves in families.
+//
+// Returns an error if doc is nil, or if a group names a record that is not
+// an INDI record of doc or names a record more than once.
+func MergeAliasGroups(doc *gedcom.Document, groups []gedcom.AliasGroup) (*gedcom.Document, *IndividualReport, error) {
+	if doc == nil {
+		return nil, nil, errors.New("dedup: document is nil")
+	}
+
+	out := doc.Clone()
+	report := &IndividualReport{}
+	if len(groups) == 0 {
+		return out, report, nil
+	}
+
+	records := make(map[string]*gedcom.Record)
+	for _, record := range out.Records {
+		if record != nil && record.Type == gedcom.RecordTypeIndividual && record.XRef != "" {
+			records[record.XRef] = record
+		}
+	}
+
+	mapping := make(map[string]string)
+	seen := make(map[string]bool)
+	var keptRecords []*gedcom.Record
+	for _, group := range groups {
+		merge, keep, err := mergeAliasGroup(group, records, seen, mapping)
+		if err != nil {
+			return nil, nil, err
+		}
+		report.Merges = append(report.Merges, merge)
+		keptRecords = append(keptRecords, keep)
+	}
+
+	removeMergedRecords(out, gedcom.RecordTypeIndividual, mapping)
+	for _, record := range out.Records {
+		report.RepointedReferences += countReferences(record, mapping)
+	}
+	gedcom.Apply(out, mapping)
+
+	for _, record := range keptRecords {
+		dropSelfLinks(record)
+	}
+	for _, record := range out.Records {
+		if record != nil && record.Type == gedcom.RecordTypeFamily {
+			dropDuplicateMembers(record)
+		}
+	}
+
+	return out, report, nil
+}
+
+// mergeAliasGroup folds the members of group into its primary, among the
+// individual records by XRef, and returns the merge and the kept record.
+// It adds the merged XRefs to mapping and every member to seen, failing if
+// one was already seen.
+func mergeAliasGroup(group gedcom.AliasGroup, records map[string]*gedcom.Record, seen map[string]bool, mapping map[string]string) (IndividualMerge, *gedcom.Record, error) {
+	primary := group.Primary
+	if primary == "" && len(group.XRefs) > 0 {
+		primary = group.XRefs[0]
+	}
+	keep, ok := records[primary]
+	if !ok {
+		return IndividualMerge{}, nil, fmt.Errorf("dedup: %s is not an individual record", primary)
+	}
+	members := append([]string{primary}, group.Others()...)
+	for _, xref := range members {
+		if seen[xref] {
+			return IndividualMerge{}, nil, fmt.Errorf("dedup: individual %s appears in more than one group", xref)
+		}
+		seen[xref] = true
+	}
+
+	merge := IndividualMerge{Kept: primary}
+	for _, xref := range members[1:] {
+		dup, ok := records[xref]
+		if !ok {
+			return IndividualMerge{}, nil, fmt.Errorf("dedup: %s is not an individual record", xref)
+		}
+		merge.Conflicts = appendUnique(merge.Conflicts, mergeIndividualRecord(keep, dup)...)
+		mapping[xref] = primary
+		merge.Merged = append(merge.Merged, xref)
+	}
+	return merge, keep, nil
+}
+
+// removeMergedRecords removes the records of type t that mapping merged
+// away from doc's Records and XRefMap.
+func removeMergedRecords(doc *gedcom.Document, t gedcom.RecordType, mapping map[string]string) {
+	kept := doc.Records[:0]
+	for _, record := range doc.Records {
+		if record != nil && record.Type == t {
+			if _, merged := mapping[record.XRef]; merged {
+				delete(doc.XRefMap, record.XRef)
+				continue
+			}
+		}
+		kept = append(kept, record)
+	}
+	doc.Records = kept
+}
+
+// singleValuedIndividualTags are level-1 INDI substructures copied from a
+// duplicate only when the kept record has none.
+var singleValuedIndividualTags = map[string]bool{"SEX": true, "RESN": true, "_FSFTID": true}
+
+// skippedIndividualTags are never copied from a duplicate: change dates
+// are the kept record's own.
+var skippedIndividualTags = map[string]bool{"CHAN": true, "CREA": true}
+
+// mergeIndividualRecord folds dup into keep and returns the single-valued
+// tags whose values conflicted.
+func mergeIndividualRecord(keep, dup *gedcom.Record) []string {
+	var conflicts []string
+	if len(dup.Tags) > 0 {
+		keepBlocks := splitBlocks(keep.Tags)
+		for _, block := range splitBlocks(dup.Tags) {
+			tag := block[0].Tag
+			if skippedIndividualTags[tag] {
+				continue
+			}
+			if singleValuedIndividualTags[tag] {
+				if existing := findBlock(keepBlocks, tag); existing != nil {
+					if renderBlock(existing) != renderBlock(block) {
+						conflicts = appendUnique(conflicts, tag)
+					}
+					continue
+				}
+			} else if containsBlock(keepBlocks, block) {
+				continue
+			}
+			copied := gedcom.CloneTags(block)
+			keep.Tags = append(keep.Tags, copied...)
+			keepBlocks = append(keepBlocks, copied)
+		}
+	}
+
+	keepInd, _ := keep.Entity.(*gedcom.Individual)
+	dupInd, _ := dup.Entity.(*gedcom.Individual)
+	if keepInd != nil && dupInd != nil {
+		conflicts = appendUnique(conflicts, mergeIndividualEntity(keepInd, dupInd)...)
+		if len(keep.Tags) > 0 {
+			keepInd.Tags = keep.Tags
+			keep.PopulateExtensionData()
+		}
+	}
+	return conflicts
+}
+
+// mergeIndividualEntity folds the typed fields of dup into keep and returns
+// the single-valued fields whose values conflicted.
+func mergeIndividualEntity(keep, dup *gedcom.Individual) []string {
+	var conflicts []string
+	fill := func(tag string, dst *string, src string) {
+		switch {
+		case src == "":
+		case *dst == "":
+			*dst = src
+		case *dst != src:
+			conflicts = append(conflicts, tag)
+		}
+	}
+	fill("SEX", &keep.Sex, dup.Sex)
+	fill("RESN", (*string)(&keep.Restriction), string(dup.Restriction))
+	fill("UID", &keep.UID, dup.UID)
+	fill("_FSFTID", &keep.FamilySearchID, dup.FamilySearchID)
+
+	keep.Names = appendDistinct(keep.Names, dup.Names...)
+	keep.Events = appendDistinct(keep.Events, dup.Events...)
+	keep.Attributes = appendDistinct(keep.Attributes, dup.Attributes...)
+	keep.ChildInFamilies = appendDistinct(keep.ChildInFamilies, dup.ChildInFamilies...)
+	keep.SpouseInFamilies = appendUnique(keep.SpouseInFamilies, dup.SpouseInFamilies...)
+	keep.Associations = appendDistinct(keep.Associations, dup.Associations...)
+	keep.Aliases = appendUnique(keep.Aliases, dup.Aliases...)
+	keep.SourceCitations = appendDistinct(keep.SourceCitations, dup.SourceCitations...)
+	keep.NoteXRefs = appendUnique(keep.NoteXRefs, dup.NoteXRefs...)
+	keep.InlineNotes = appendUnique(keep.InlineNotes, dup.InlineNotes...)
+	keep.Notes = appendUnique(keep.Notes, dup.Notes...)
+	keep.Media = appendDistinct(keep.Media, dup.Media...)
+	keep.LDSOrdinances = appendDistinct(keep.LDSOrdinances, dup.LDSOrdinances...)
+	keep.UserReferences = appendDistinct(keep.AllUserReferences(), dup.AllUserReferences()...)
+	if keep.RefNumber == "" && len(keep.UserReferences) > 0 {
+		keep.RefNumber = keep.UserReferences[0].Number
+	}
+	keep.ExternalIDs = appendDistinct(keep.ExternalIDs, dup.ExternalIDs...)
+	keep.Tasks = appendDistinct(keep.Tasks, dup.Tasks...)
+	for tag, exts := range dup.ExtensionData {
+		for _, ext := range exts {
+			keep.ExtensionData = addExtension(keep.ExtensionData, tag, ext)
+		}
+	}
+	return conflicts
+}
+
+// dropSelfLinks removes ALIA links from record to itself and duplicate
+// FAMC/FAMS links, both of which re-pointing can leave behind.
+func dropSelfLinks(record *gedcom.Record) {
+	ind, _ := record.Entity.(*gedcom.Individual)
+	if len(record.Tags) > 0 {
+		var blocks [][]*gedcom.Tag
+		for _, block := range splitBlocks(record.Tags) {
+			head := block[0]
+			if head.Tag == "ALIA" && head.Value == record.XRef {
+				continue
+			}
+			if (head.Tag == "FAMS" || head.Tag == "FAMC") && containsBlock(blocks, block) {
+				continue
+			}
+			blocks = append(blocks, block)
+		}
+		tags := make([]*gedcom.Tag, 0, len(record.Tags))
+		for _, block := range blocks {
+			tags = append(tags, block...)
+		}
+		record.Tags = tags
+		if ind != nil {
+			ind.Tags = tags
+		}
+	}
+	if ind != nil {
+		aliases := ind.Aliases[:0]
+		for _, alias := range ind.Aliases {
+			if alias != record.XRef {
+				aliases = append(aliases, alias)
+			}
+		}
+		ind.Aliases = aliases
+		ind.SpouseInFamilies = appendUnique(nil, ind.SpouseInFamilies...)
+		ind.ChildInFamilies = appendDistinct(nil, ind.ChildInFamilies...)
+	}
+}
+
+// dropDuplicateMembers removes repeated CHIL links from a family, and a
+// WIFE link equal to HUSB, after merged individuals have been re-pointed.
+func dropDuplicateMembers(record *gedcom.Record) {
+	fam, _ := record.Entity.(*gedcom.Family)
+	if len(record.Tags) > 0 {
+		seen := make(map[string]bool)
+		var husband string
+		var blocks [][]*gedcom.Tag
+		for _, block := range splitBlocks(record.Tags) {
+			head := block[0]
+			switch head.Tag {
+			case "HUSB":
+				husband = head.Value
+			case "WIFE":
+				if head.Value != "" && head.Value == husband {
+					continue
+				}
+			case "CHIL":
+				if seen[head.Value] {
+					continue
+				}
+				seen[head.Value] = true
+			}
+			blocks = append(blocks, block)
+		}
+		tags := make([]*gedcom.Tag, 0, len(record.Tags))
+		for _, block := range blocks {
+			tags = append(tags, block...)
+		}
+		record.Tags = tags
+		if fam != nil {
+			fam.Tags = tags
+		}
+	}
+	if fam != nil {
+		fam.Children = appendUnique(nil, fam.Children...)
+		if fam.Wife != "" && fam.Wife == fam.Husband {
+			fam.SetWife("")
+		}
+	}
+}
+
+// appendDistinct appends the values of src that are not deeply equal to a
+// value already in dst.
+func appendDistinct[T any](dst []T, src ...T) []T {
+	for _, v := range src {
+		found := false
+		for _, existing := range dst {
+			if reflect.DeepEqual(existing, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,213 @@
+package dedup_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/dedup"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func aliasDoc(t *testing.T) *gedcom.Document {
+	t.Helper()
+	return decode(t,
+		"0 @I1@ INDI",
+		"1 NAME John /Doe/",
+		"1 SEX M",
+		"1 FAMS @F1@",
+		"1 ALIA @I2@",
+		"0 @I2@ INDI",
+		"1 NAME John /Doe/",
+		"1 NAME Johnny /Doe/",
+		"1 SEX F",
+		"1 BIRT",
+		"2 DATE 1 JAN 1900",
+		"1 FAMC @F2@",
+		"1 CHAN",
+		"2 DATE 1 JAN 2020",
+		"0 @I3@ INDI",
+		"1 NAME Jane /Roe/",
+		"1 ASSO @I2@",
+		"2 RELA Friend",
+		"0 @I4@ INDI",
+		"1 NAME Child /Doe/",
+		"1 FAMC @F2@",
+		"0 @F1@ FAM",
+		"1 HUSB @I1@",
+		"1 WIFE @I3@",
+		"0 @F2@ FAM",
+		"1 CHIL @I2@",
+		"1 CHIL @I4@",
+	)
+}
+
+func TestMergeIndividuals(t *testing.T) {
+	doc := aliasDoc(t)
+	out, report, err := dedup.MergeIndividuals(doc, "@I1@", "@I2@")
+	if err != nil {
+		t.Fatalf("MergeIndividuals() error = %v", err)
+	}
+
+	if out.GetIndividual("@I2@") != nil {
+		t.Error("merged individual @I2@ still present")
+	}
+	if doc.GetIndividual("@I2@") == nil {
+		t.Error("input document was mutated")
+	}
+
+	ind := out.GetIndividual("@I1@")
+	if len(ind.Names) != 2 {
+		t.Errorf("Names = %d, want 2 (identical name not duplicated)", len(ind.Names))
+	}
+	if ind.Sex != "M" {
+		t.Errorf("Sex = %q, want kept value M", ind.Sex)
+	}
+	if len(ind.Events) != 1 || len(ind.ChildInFamilies) != 1 || len(ind.SpouseInFamilies) != 1 {
+		t.Errorf("Events/FAMC/FAMS = %d/%d/%d, want 1/1/1",
+			len(ind.Events), len(ind.ChildInFamilies), len(ind.SpouseInFamilies))
+	}
+	if len(ind.Aliases) != 0 {
+		t.Errorf("Aliases = %v, want self-alias dropped", ind.Aliases)
+	}
+	if ind.ChangeDate != nil {
+		t.Error("CHAN copied from merged record")
+	}
+
+	if got := out.GetIndividual("@I3@").Associations[0].IndividualXRef; got != "@I1@" {
+		t.Errorf("ASSO = %s, want @I1@", got)
+	}
+	if got := out.GetFamily("@F2@").Children; !reflect.DeepEqual(got, []string{"@I1@", "@I4@"}) {
+		t.Errorf("F2 children = %v, want [@I1@ @I4@]", got)
+	}
+
+	want := dedup.IndividualMerge{Kept: "@I1@", Merged: []string{"@I2@"}, Conflicts: []string{"SEX"}}
+	if len(report.Merges) != 1 || !reflect.DeepEqual(report.Merges[0], want) {
+		t.Errorf("Merges = %+v, want %+v", report.Merges, want)
+	}
+	if report.RemovedCount() != 1 {
+		t.Errorf("RemovedCount() = %d, want 1", report.RemovedCount())
+	}
+	// ALIA on I1, ASSO on I3, CHIL on F2.
+	if report.RepointedReferences != 3 {
+		t.Errorf("RepointedReferences = %d, want 3", report.RepointedReferences)
+	}
+
+	got := encode(t, out)
+	for _, line := range []string{"1 NAME Johnny /Doe/", "1 FAMC @F2@", "1 ASSO @I1@"} {
+		if !strings.Contains(got, line) {
+			t.Errorf("encoded output missing %q:\n%s", line, got)
+		}
+	}
+	for _, line := range []string{"@I2@", "ALIA", "1 SEX F", "2 DATE 1 JAN 2020"} {
+		if strings.Contains(got, line) {
+			t.Errorf("encoded output contains %q:\n%s", line, got)
+		}
+	}
+	if strings.Count(got, "1 CHIL @I1@") != 1 {
+		t.Errorf("encoded output should have one CHIL @I1@:\n%s", got)
+	}
+}
+
+func TestMergeIndividuals_DuplicateChild(t *testing.T) {
+	doc := decode(t,
+		"0 @I1@ INDI",
+		"1 FAMC @F1@",
+		"0 @I2@ INDI",
+		"1 FAMC @F1@",
+		"0 @F1@ FAM",
+		"1 CHIL @I1@",
+		"1 CHIL @I2@",
+	)
+	out, _, err := dedup.MergeIndividuals(doc, "@I1@", "@I2@")
+	if err != nil {
+		t.Fatalf("MergeIndividuals() error = %v", err)
+	}
+	if got := out.GetFamily("@F1@").Children; !reflect.DeepEqual(got, []string{"@I1@"}) {
+		t.Errorf("Children = %v, want [@I1@]", got)
+	}
+	if got := encode(t, out); strings.Count(got, "CHIL") != 1 || strings.Count(got, "FAMC") != 1 {
+		t.Errorf("encoded output has duplicate links:\n%s", got)
+	}
+}
+
+// TestMergeIndividuals_Spouses merges a husband and wife and checks the
+// dropped WIFE link is gone from the family's Spouses too.
+func TestMergeIndividuals_Spouses(t *testing.T) {
+	doc := decode(t,
+		"0 @I1@ INDI",
+		"1 FAMS @F1@",
+		"0 @I2@ INDI",
+		"1 FAMS @F1@",
+		"0 @F1@ FAM",
+		"1 HUSB @I1@",
+		"1 WIFE @I2@",
+	)
+	out, _, err := dedup.MergeIndividuals(doc, "@I1@", "@I2@")
+	if err != nil {
+		t.Fatalf("MergeIndividuals() error = %v", err)
+	}
+	fam := out.GetFamily("@F1@")
+	if got := fam.Partners(); !reflect.DeepEqual(got, []string{"@I1@"}) {
+		t.Errorf("Partners() = %v, want [@I1@]", got)
+	}
+	for _, spouse := range fam.Spouses {
+		if spouse.Tag == "WIFE" {
+			t.Errorf("Spouses still has WIFE %s", spouse.XRef)
+		}
+	}
+	if got := encode(t, out); strings.Count(got, "@I1@") != 2 || strings.Contains(got, "WIFE") {
+		t.Errorf("encoded output =\n%s", got)
+	}
+}
+
+func TestMergeAliasGroups(t *testing.T) {
+	doc := aliasDoc(t)
+	groups := doc.ResolveAliases()
+	if len(groups) != 1 {
+		t.Fatalf("ResolveAliases() returned %d groups, want 1", len(groups))
+	}
+	groups[0].ChoosePrimary(doc, gedcom.DetailScore)
+
+	out, report, err := dedup.MergeAliasGroups(doc, groups)
+	if err != nil {
+		t.Fatalf("MergeAliasGroups() error = %v", err)
+	}
+	if report.Merges[0].Kept != "@I2@" {
+		t.Errorf("Kept = %s, want @I2@", report.Merges[0].Kept)
+	}
+	if out.GetIndividual("@I1@") != nil {
+		t.Error("@I1@ still present")
+	}
+	if got := out.GetFamily("@F1@").Husband; got != "@I2@" {
+		t.Errorf("F1 husband = %s, want @I2@", got)
+	}
+	if got := out.GetIndividual("@I2@").SpouseInFamilies; !reflect.DeepEqual(got, []string{"@F1@"}) {
+		t.Errorf("SpouseInFamilies = %v, want [@F1@]", got)
+	}
+}
+
+func TestMergeAliasGroups_Errors(t *testing.T) {
+	doc := aliasDoc(t)
+	if _, _, err := dedup.MergeAliasGroups(nil, nil); err == nil {
+		t.Error("nil document: expected error")
+	}
+	if _, _, err := dedup.MergeIndividuals(doc, "@F1@", "@I2@"); err == nil {
+		t.Error("non-individual kept record: expected error")
+	}
+	if _, _, err := dedup.MergeIndividuals(doc, "@I1@", "@I9@"); err == nil {
+		t.Error("missing merged record: expected error")
+	}
+	groups := []gedcom.AliasGroup{
+		{XRefs: []string{"@I1@", "@I2@"}, Primary: "@I1@"},
+		{XRefs: []string{"@I2@", "@I3@"}, Primary: "@I3@"},
+	}
+	if _, _, err := dedup.MergeAliasGroups(doc, groups); err == nil {
+		t.Error("overlapping groups: expected error")
+	}
+
+	out, report, err := dedup.MergeAliasGroups(doc, nil)
+	if err != nil || out == doc || report.RemovedCount() != 0 {
+		t.Errorf("no groups: got (%p, %+v, %v), want a clone and empty report", out, report, err)
+	}
+}
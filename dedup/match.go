@@ -0,0 +1,510 @@
+package dedup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// Match fields, the names of the FieldScore entries of an IndividualMatch.
+const (
+	FieldSurname   = "surname"
+	FieldGiven     = "given"
+	FieldBirth     = "birth"
+	FieldDeath     = "death"
+	FieldPlaces    = "places"
+	FieldRelatives = "relatives"
+)
+
+// MatchWeights are the relative weights of the match fields.
+type MatchWeights struct {
+	Surname   float64
+	Given     float64
+	Birth     float64
+	Death     float64
+	Places    float64
+	Relatives float64
+}
+
+// DefaultMatchWeights returns the weights used when MatchOptions.Weights
+// is nil.
+func DefaultMatchWeights() MatchWeights {
+	return MatchWeights{Surname: 0.2, Given: 0.2, Birth: 0.2, Death: 0.1, Places: 0.1, Relatives: 0.2}
+}
+
+// MatchOptions configures FindDuplicateIndividuals and ScoreIndividuals.
+// The zero value, like nil, uses the defaults.
+type MatchOptions struct {
+	// MinScore is the lowest score FindDuplicateIndividuals reports.
+	// Default: 0.7.
+	MinScore float64
+
+	// MaxYearDiff is the difference in years at which two dates stop
+	// counting as close. Default: 5.
+	MaxYearDiff int
+
+	// MinEvidence is the least total weight a score is divided by, so
+	// that a pair compared on names alone cannot score as high as a pair
+	// whose dates and relatives also agree. Default: 0.6.
+	MinEvidence float64
+
+	// Weights are the field weights. Default: DefaultMatchWeights().
+	Weights *MatchWeights
+}
+
+// FieldScore is how well one field of two individuals agrees.
+type FieldScore struct {
+	// Field is one of the Field constants.
+	Field string
+
+	// Score is the agreement, from 0 (contradicts) to 1 (identical).
+	Score float64
+
+	// Weight is the weight of the field in the match score.
+	Weight float64
+
+	// Explanation says what was compared, e.g.
+	// `"Smith" and "Smyth" sound alike (Metaphone SM0)`.
+	Explanation string
+}
+
+// IndividualMatch is a pair of INDI records that may describe the same
+// person.
+type IndividualMatch struct {
+	// XRef1 and XRef2 are the individuals, in document order.
+	XRef1, XRef2 string
+
+	// Score is the weighted agreement of the compared fields, from 0 to 1.
+	Score float64
+
+	// Fields explains the score: one entry per field that both
+	// individuals have, in the order of the Field constants.
+	Fields []FieldScore
+}
+
+// Explain returns the explanations of the match's fields, one per line.
+func (m IndividualMatch) Explain() string {
+	lines := make([]string, len(m.Fields))
+	for i, f := range m.Fields {
+		lines[i] = fmt.Sprintf("%s %.2f: %s", f.Field, f.Score, f.Explanation)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FindDuplicateIndividuals scores pairs of INDI records that may be
+// duplicates and returns those scoring at least opts.MinScore, best
+// first (ties in document order).
+//
+// To keep large trees tractable, only individuals whose surnames share a
+// Soundex code are compared (individuals without a surname are compared
+// by given name). Pairs with conflicting sexes, and pairs that are
+// spouses, parent and child, or the same record, are never matched.
+//
+// Each pair is scored by ScoreIndividuals. The result can be reviewed and
+// passed to MergeIndividuals. Returns nil if doc is nil.
+func FindDuplicateIndividuals(doc *gedcom.Document, opts *MatchOptions) []IndividualMatch {
+	if doc == nil {
+		return nil
+	}
+	o := matchDefaults(opts)
+
+	keys, blocks := matchBlocks(doc)
+	positions := make(map[string]int)
+	for i, record := range doc.Records {
+		if record != nil && record.XRef != "" {
+			if _, ok := positions[record.XRef]; !ok {
+				positions[record.XRef] = i
+			}
+		}
+	}
+
+	var matches []IndividualMatch
+	for _, key := range keys {
+		matches = append(matches, blockMatches(doc, blocks[key], positions, o)...)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if p, q := positions[matches[i].XRef1], positions[matches[j].XRef1]; p != q {
+			return p < q
+		}
+		return positions[matches[i].XRef2] < positions[matches[j].XRef2]
+	})
+	return matches
+}
+
+// matchBlocks groups the individuals of doc by the Soundex code of their
+// surname, or of their first given name when they have no surname, and
+// returns the keys in order of first appearance. Individuals with neither
+// are left out.
+func matchBlocks(doc *gedcom.Document) ([]string, map[string][]*gedcom.Individual) {
+	blocks := make(map[string][]*gedcom.Individual)
+	var keys []string
+	for _, ind := range doc.Individuals() {
+		surname, given := nameParts(ind)
+		key := "S" + Soundex(surname)
+		if surname == "" {
+			key = "G" + Soundex(firstWord(given))
+		}
+		if key == "S" || key == "G" {
+			continue
+		}
+		if _, ok := blocks[key]; !ok {
+			keys = append(keys, key)
+		}
+		blocks[key] = append(blocks[key], ind)
+	}
+	return keys, blocks
+}
+
+// blockMatches scores every pair of individuals in block and returns those
+// scoring at least o.MinScore, each with its XRefs in document order.
+func blockMatches(doc *gedcom.Document, block []*gedcom.Individual, positions map[string]int, o MatchOptions) []IndividualMatch {
+	var matches []IndividualMatch
+	for i := 0; i < len(block); i++ {
+		for j := i + 1; j < len(block); j++ {
+			m, ok := scorePair(doc, block[i], block[j], o)
+			if !ok || m.Score < o.MinScore {
+				continue
+			}
+			if positions[m.XRef1] > positions[m.XRef2] {
+				m.XRef1, m.XRef2 = m.XRef2, m.XRef1
+			}
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// ScoreIndividuals scores one pair of individuals of doc, whatever their
+// surnames. It reports false if the pair can never match: either is nil,
+// they are the same record, their sexes conflict, or they are spouses or
+// parent and child.
+//
+// The score is the weighted mean of the fields both individuals have,
+// with the weights divided by at least opts.MinEvidence:
+//
+//   - surname and given: 1 for the same name after folding case and
+//     diacritics, 0.85 for names that sound alike (Soundex or Metaphone),
+//     0.6 for a given name that is an initial of the other, else the
+//     edit-distance similarity if at least 0.8, else 0;
+//   - birth and death: 1 for the same exact date, 0.9 for the same year,
+//     decreasing to 0 at MaxYearDiff years apart, and at least 0.7 for
+//     uncertain dates that overlap ("ABT 1850" and "1853");
+//   - places: the mean agreement of birth and death places: 1 for the
+//     same place, 0.8 when the first jurisdictions agree (the town), 0.4
+//     when only the last agree (the country);
+//   - relatives: the share of parents and spouses that are the same
+//     record or have the same name.
+func ScoreIndividuals(doc *gedcom.Document, a, b *gedcom.Individual, opts *MatchOptions) (IndividualMatch, bool) {
+	return scorePair(doc, a, b, matchDefaults(opts))
+}
+
+// matchDefaults returns opts with defaults filled in.
+func matchDefaults(opts *MatchOptions) MatchOptions {
+	var o MatchOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.MinScore == 0 {
+		o.MinScore = 0.7
+	}
+	if o.MaxYearDiff == 0 {
+		o.MaxYearDiff = 5
+	}
+	if o.MinEvidence == 0 {
+		o.MinEvidence = 0.6
+	}
+	if o.Weights == nil {
+		w := DefaultMatchWeights()
+		o.Weights = &w
+	}
+	return o
+}
+
+// scorePair implements ScoreIndividuals.
+func scorePair(doc *gedcom.Document, a, b *gedcom.Individual, o MatchOptions) (IndividualMatch, bool) {
+	if a == nil || b == nil || a == b || (a.XRef != "" && a.XRef == b.XRef) {
+		return IndividualMatch{}, false
+	}
+	if sexesConflict(a.Sex, b.Sex) || related(doc, a, b) {
+		return IndividualMatch{}, false
+	}
+
+	m := IndividualMatch{XRef1: a.XRef, XRef2: b.XRef, Fields: pairFields(doc, a, b, o)}
+	var total, weight float64
+	for _, f := range m.Fields {
+		total += f.Score * f.Weight
+		weight += f.Weight
+	}
+	if weight < o.MinEvidence {
+		weight = o.MinEvidence
+	}
+	if weight > 0 {
+		m.Score = total / weight
+	}
+	return m, true
+}
+
+// pairFields scores the fields both a and b have and a positive weight.
+func pairFields(doc *gedcom.Document, a, b *gedcom.Individual, o MatchOptions) []FieldScore {
+	var fields []FieldScore
+	add := func(field string, weight, score float64, explanation string) {
+		if weight > 0 {
+			fields = append(fields, FieldScore{Field: field, Score: score, Weight: weight, Explanation: explanation})
+		}
+	}
+
+	surnameA, givenA := nameParts(a)
+	surnameB, givenB := nameParts(b)
+	if surnameA != "" && surnameB != "" {
+		score, why := compareNames(surnameA, surnameB, false)
+		add(FieldSurname, o.Weights.Surname, score, why)
+	}
+	if givenA != "" && givenB != "" {
+		score, why := compareNames(givenA, givenB, true)
+		add(FieldGiven, o.Weights.Given, score, why)
+	}
+	if da, db := a.BirthDate(), b.BirthDate(); da != nil && db != nil {
+		score, why := compareDates(da, db, o.MaxYearDiff)
+		add(FieldBirth, o.Weights.Birth, score, why)
+	}
+	if da, db := a.DeathDate(), b.DeathDate(); da != nil && db != nil {
+		score, why := compareDates(da, db, o.MaxYearDiff)
+		add(FieldDeath, o.Weights.Death, score, why)
+	}
+	if score, why, ok := comparePlaces(a, b); ok {
+		add(FieldPlaces, o.Weights.Places, score, why)
+	}
+	if score, why, ok := compareRelatives(doc, a, b); ok {
+		add(FieldRelatives, o.Weights.Relatives, score, why)
+	}
+	return fields
+}
+
+// nameParts returns the surname and given names of an individual's first
+// name.
+func nameParts(ind *gedcom.Individual) (surname, given string) {
+	if len(ind.Names) == 0 || ind.Names[0] == nil {
+		return "", ""
+	}
+	name := ind.Names[0]
+	surname, given = name.Surname, name.Given
+	if surname == "" || given == "" {
+		full := name.Full
+		if start := strings.Index(full, "/"); start >= 0 {
+			if surname == "" {
+				if end := strings.LastIndex(full, "/"); end > start {
+					surname = full[start+1 : end]
+				}
+			}
+			if given == "" {
+				given = full[:start]
+			}
+		} else if given == "" {
+			given = full
+		}
+	}
+	return strings.TrimSpace(surname), strings.TrimSpace(given)
+}
+
+// firstWord returns the first whitespace-separated word of s.
+func firstWord(s string) string {
+	if fields := strings.Fields(s); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// compareNames scores two surnames or, if given is true, two given names.
+// Given names are compared by their first name.
+func compareNames(a, b string, given bool) (float64, string) {
+	fa, fb := foldLetters(a), foldLetters(b)
+	if given {
+		fa, fb = foldLetters(firstWord(a)), foldLetters(firstWord(b))
+	}
+	if fa == "" || fb == "" {
+		// Names in other scripts have no phonetic key.
+		if strings.EqualFold(a, b) {
+			return 1, fmt.Sprintf("%q and %q are the same name", a, b)
+		}
+		return 0, fmt.Sprintf("%q and %q differ", a, b)
+	}
+	switch {
+	case fa == fb:
+		return 1, fmt.Sprintf("%q and %q are the same name", a, b)
+	case Metaphone(fa) == Metaphone(fb):
+		return 0.85, fmt.Sprintf("%q and %q sound alike (Metaphone %s)", a, b, Metaphone(fa))
+	case Soundex(fa) == Soundex(fb):
+		return 0.85, fmt.Sprintf("%q and %q sound alike (Soundex %s)", a, b, Soundex(fa))
+	case given && (len(fa) == 1 || len(fb) == 1) && fa[0] == fb[0]:
+		return 0.6, fmt.Sprintf("%q and %q share the initial %c", a, b, fa[0])
+	}
+	if s := similarity(fa, fb); s >= 0.8 {
+		return s, fmt.Sprintf("%q and %q are spelled alike (%.0f%%)", a, b, s*100)
+	}
+	return 0, fmt.Sprintf("%q and %q differ", a, b)
+}
+
+// compareDates scores two dates.
+func compareDates(a, b *gedcom.Date, maxYearDiff int) (float64, string) {
+	diff := a.Year - b.Year
+	if diff < 0 {
+		diff = -diff
+	}
+	score, why := 0.0, fmt.Sprintf("%s and %s are %d years apart", a, b, diff)
+	switch {
+	case a.Year == 0 || b.Year == 0:
+		why = fmt.Sprintf("%s and %s cannot be compared", a, b)
+	case diff == 0 && a.Month == b.Month && a.Day == b.Day && a.Day != 0 && a.Modifier == b.Modifier:
+		score, why = 1, fmt.Sprintf("same date %s", a)
+	case diff == 0:
+		score, why = 0.9, fmt.Sprintf("same year %d", a.Year)
+	case diff < maxYearDiff:
+		score = 0.8 * float64(maxYearDiff-diff) / float64(maxYearDiff)
+	}
+	if score < 0.7 && a.Matches(b, gedcom.DefaultDateTolerance()) {
+		score, why = 0.7, fmt.Sprintf("%s and %s overlap", a, b)
+	}
+	return score, why
+}
+
+// comparePlaces scores the birth and death places of two individuals. It
+// reports false if neither pair of places can be compared.
+func comparePlaces(a, b *gedcom.Individual) (float64, string, bool) {
+	var scores []float64
+	var whys []string
+	compare := func(kind string, ea, eb *gedcom.Event) {
+		if ea == nil || eb == nil || ea.Place == "" || eb.Place == "" {
+			return
+		}
+		pa, pb := placeParts(ea.Place), placeParts(eb.Place)
+		switch {
+		case strings.Join(pa, ",") == strings.Join(pb, ","):
+			scores = append(scores, 1)
+			whys = append(whys, fmt.Sprintf("same %s place %q", kind, ea.Place))
+		case pa[0] != "" && pa[0] == pb[0]:
+			scores = append(scores, 0.8)
+			whys = append(whys, fmt.Sprintf("%s places %q and %q name the same town", kind, ea.Place, eb.Place))
+		case pa[len(pa)-1] != "" && pa[len(pa)-1] == pb[len(pb)-1]:
+			scores = append(scores, 0.4)
+			whys = append(whys, fmt.Sprintf("%s places %q and %q share only %q", kind, ea.Place, eb.Place, strings.TrimSpace(ea.Place[strings.LastIndex(ea.Place, ",")+1:])))
+		default:
+			scores = append(scores, 0)
+			whys = append(whys, fmt.Sprintf("%s places %q and %q differ", kind, ea.Place, eb.Place))
+		}
+	}
+	compare("birth", a.BirthEvent(), b.BirthEvent())
+	compare("death", a.DeathEvent(), b.DeathEvent())
+	if len(scores) == 0 {
+		return 0, "", false
+	}
+	var sum float64
+	for _, s := range scores {
+		sum += s
+	}
+	return sum / float64(len(scores)), strings.Join(whys, "; "), true
+}
+
+// placeParts returns the normalized jurisdictions of a place.
+func placeParts(place string) []string {
+	parts := strings.Split(place, ",")
+	for i, part := range parts {
+		parts[i] = foldLetters(part)
+	}
+	return parts
+}
+
+// compareRelatives scores the parents and spouses of two individuals. It
+// reports false if they have no relatives to compare.
+func compareRelatives(doc *gedcom.Document, a, b *gedcom.Individual) (float64, string, bool) {
+	if doc == nil {
+		return 0, "", false
+	}
+	var matched, compared int
+	var shared []string
+	compare := func(role string, ra, rb []*gedcom.Individual) {
+		if len(ra) == 0 || len(rb) == 0 {
+			return
+		}
+		for _, x := range ra {
+			compared++
+			for _, y := range rb {
+				if x == y || sameName(x, y) {
+					matched++
+					shared = append(shared, fmt.Sprintf("%s %s", role, gedcom.DefaultNameFormatter.FormatIndividual(x)))
+					break
+				}
+			}
+		}
+	}
+	compare("parent", a.Parents(doc), b.Parents(doc))
+	compare("spouse", a.Spouses(doc), b.Spouses(doc))
+	if compared == 0 {
+		return 0, "", false
+	}
+	why := fmt.Sprintf("no shared parents or spouses among %d compared", compared)
+	if matched > 0 {
+		why = fmt.Sprintf("%d of %d parents and spouses shared: %s", matched, compared, strings.Join(shared, ", "))
+	}
+	return float64(matched) / float64(compared), why, true
+}
+
+// sameName reports whether two individuals have the same folded surname
+// and first given name.
+func sameName(a, b *gedcom.Individual) bool {
+	sa, ga := nameParts(a)
+	sb, gb := nameParts(b)
+	if sa == "" && ga == "" {
+		return false
+	}
+	return foldLetters(sa) == foldLetters(sb) && foldLetters(firstWord(ga)) == foldLetters(firstWord(gb))
+}
+
+// sexesConflict reports whether two SEX values are known and differ.
+func sexesConflict(a, b string) bool {
+	known := func(s string) bool { return s == "M" || s == "F" }
+	return known(a) && known(b) && a != b
+}
+
+// related reports whether a and b are spouses or parent and child.
+func related(doc *gedcom.Document, a, b *gedcom.Individual) bool {
+	if doc == nil || a.XRef == "" || b.XRef == "" {
+		return false
+	}
+	for _, xrefs := range [][]string{doc.Spouses(a.XRef), doc.Parents(a.XRef), doc.Children(a.XRef)} {
+		if containsString(xrefs, b.XRef) {
+			return true
+		}
+	}
+	return false
+}
+
+// similarity returns 1 minus the edit distance of a and b divided by the
+// longer length.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(max(len(ra), len(rb)))
+}
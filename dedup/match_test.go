@@ -0,0 +1,144 @@
+package dedup_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/dedup"
+)
+
+var matchLines = []string{
+	"0 @I1@ INDI",
+	"1 NAME John /Smith/",
+	"1 SEX M",
+	"1 BIRT",
+	"2 DATE 12 MAR 1852",
+	"2 PLAC Boston, Suffolk, Massachusetts, USA",
+	"1 FAMC @F1@",
+	"0 @I2@ INDI",
+	"1 NAME Jon /Smyth/",
+	"1 SEX M",
+	"1 BIRT",
+	"2 DATE ABT 1852",
+	"2 PLAC Boston, Massachusetts, USA",
+	"1 FAMC @F2@",
+	"0 @I3@ INDI",
+	"1 NAME John /Smith/",
+	"1 SEX F",
+	"1 BIRT",
+	"2 DATE 1852",
+	"0 @I4@ INDI",
+	"1 NAME William /Smith/",
+	"1 SEX M",
+	"1 BIRT",
+	"2 DATE 1790",
+	"2 PLAC Leeds, England",
+	"0 @I5@ INDI",
+	"1 NAME William /Smith/",
+	"1 SEX M",
+	"1 FAMS @F1@",
+	"0 @I6@ INDI",
+	"1 NAME William /Smith/",
+	"1 FAMS @F2@",
+	"0 @I7@ INDI",
+	"1 NAME Mary /Jones/",
+	"0 @F1@ FAM",
+	"1 HUSB @I5@",
+	"1 CHIL @I1@",
+	"0 @F2@ FAM",
+	"1 HUSB @I6@",
+	"1 CHIL @I2@",
+}
+
+func TestFindDuplicateIndividuals(t *testing.T) {
+	doc := decode(t, matchLines...)
+	matches := dedup.FindDuplicateIndividuals(doc, nil)
+	if len(matches) == 0 {
+		t.Fatal("FindDuplicateIndividuals() found nothing")
+	}
+
+	best := matches[0]
+	if best.XRef1 != "@I1@" || best.XRef2 != "@I2@" {
+		t.Fatalf("best match = %s/%s (%.2f), want @I1@/@I2@\n%s", best.XRef1, best.XRef2, best.Score, best.Explain())
+	}
+	fields := make(map[string]dedup.FieldScore)
+	for _, f := range best.Fields {
+		fields[f.Field] = f
+	}
+	for field, want := range map[string]string{
+		dedup.FieldSurname:   "sound alike",
+		dedup.FieldGiven:     "sound alike",
+		dedup.FieldBirth:     "same year 1852",
+		dedup.FieldPlaces:    "same town",
+		dedup.FieldRelatives: "parent William Smith",
+	} {
+		if !strings.Contains(fields[field].Explanation, want) {
+			t.Errorf("%s explanation = %q, want it to mention %q", field, fields[field].Explanation, want)
+		}
+	}
+	if _, ok := fields[dedup.FieldDeath]; ok {
+		t.Error("death scored although neither individual has a death date")
+	}
+
+	for _, m := range matches {
+		pair := m.XRef1 + m.XRef2
+		switch pair {
+		case "@I1@@I3@", "@I2@@I3@":
+			t.Errorf("%s matched despite conflicting sexes", pair)
+		case "@I5@@I1@", "@I1@@I5@":
+			t.Errorf("%s matched a parent with a child", pair)
+		}
+		if m.Score < 0.7 {
+			t.Errorf("%s scored %.2f, below the default minimum", pair, m.Score)
+		}
+	}
+	for i := 1; i < len(matches); i++ {
+		if matches[i].Score > matches[i-1].Score {
+			t.Errorf("matches not ranked: %.2f after %.2f", matches[i].Score, matches[i-1].Score)
+		}
+	}
+
+	if dedup.FindDuplicateIndividuals(nil, nil) != nil {
+		t.Error("FindDuplicateIndividuals(nil) != nil")
+	}
+}
+
+func TestScoreIndividuals(t *testing.T) {
+	doc := decode(t, matchLines...)
+	john, jon := doc.GetIndividual("@I1@"), doc.GetIndividual("@I2@")
+	william, mary := doc.GetIndividual("@I4@"), doc.GetIndividual("@I7@")
+
+	near, ok := dedup.ScoreIndividuals(doc, john, jon, nil)
+	if !ok || near.Score < 0.8 {
+		t.Errorf("John/Jon score = %.2f, %v; want >= 0.8\n%s", near.Score, ok, near.Explain())
+	}
+	far, ok := dedup.ScoreIndividuals(doc, john, william, nil)
+	if !ok || far.Score >= near.Score {
+		t.Errorf("John/William score = %.2f, want below %.2f", far.Score, near.Score)
+	}
+	if !strings.Contains(far.Explain(), "62 years apart") {
+		t.Errorf("explanation = %q, want the year difference", far.Explain())
+	}
+
+	// Names alone cannot reach a full score.
+	namesOnly, _ := dedup.ScoreIndividuals(doc, mary, mary.Clone(), nil)
+	if namesOnly.Score > 0.7 {
+		t.Errorf("names-only score = %.2f, want <= 0.7", namesOnly.Score)
+	}
+
+	if _, ok := dedup.ScoreIndividuals(doc, john, doc.GetIndividual("@I3@"), nil); ok {
+		t.Error("ScoreIndividuals matched conflicting sexes")
+	}
+	if _, ok := dedup.ScoreIndividuals(doc, john, doc.GetIndividual("@I5@"), nil); ok {
+		t.Error("ScoreIndividuals matched a parent and child")
+	}
+	if _, ok := dedup.ScoreIndividuals(doc, john, john, nil); ok {
+		t.Error("ScoreIndividuals matched a record with itself")
+	}
+
+	weights := dedup.MatchWeights{Surname: 1}
+	surnameOnly, _ := dedup.ScoreIndividuals(doc, john, jon, &dedup.MatchOptions{Weights: &weights, MinEvidence: 1})
+	if len(surnameOnly.Fields) != 1 || surnameOnly.Score != 0.85 {
+		t.Errorf("surname-only match = %+v", surnameOnly)
+	}
+}
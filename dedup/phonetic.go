@@ -0,0 +1,191 @@
+package dedup
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// foldLetters returns the ASCII letters of s in uppercase, with diacritics
+// removed ("Müller" becomes "MULLER") and other characters dropped.
+func foldLetters(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	if folded, _, err := transform.String(t, s); err == nil {
+		s = folded
+	}
+	var b strings.Builder
+	for _, r := range s {
+		r = unicode.ToUpper(r)
+		if r >= 'A' && r <= 'Z' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// soundexCodes maps letters to American Soundex digits; vowels, H, W, and
+// Y map to 0.
+var soundexCodes = [26]byte{
+	'0', '1', '2', '3', '0', '1', '2', '0', '0', '2', '2', '4', '5',
+	'5', '0', '1', '2', '6', '2', '3', '0', '1', '0', '2', '0', '2',
+}
+
+// Soundex returns the American Soundex code of a name: its first letter
+// and three digits, e.g. "R163" for Robert and Rupert. Letters with
+// diacritics are folded to ASCII and other characters ignored; a name
+// without letters has the code "".
+func Soundex(name string) string {
+	letters := foldLetters(name)
+	if letters == "" {
+		return ""
+	}
+	code := []byte{letters[0]}
+	last := soundexCodes[letters[0]-'A']
+	for i := 1; i < len(letters) && len(code) < 4; i++ {
+		c := letters[i]
+		digit := soundexCodes[c-'A']
+		switch {
+		case c == 'H' || c == 'W':
+			// H and W do not separate letters with the same code.
+			continue
+		case digit == '0':
+			last = '0'
+		case digit != last:
+			code = append(code, digit)
+			last = digit
+		}
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return string(code)
+}
+
+// Metaphone returns the Metaphone key of a name (Lawrence Philips, 1990),
+// which groups names by their English pronunciation more closely than
+// Soundex: Smith and Smyth are both "SM0", where "0" stands for "th".
+// Letters with diacritics are folded to ASCII and other characters
+// ignored.
+//
+//nolint:gocyclo // One case per letter, as in the published rules
+func Metaphone(name string) string {
+	w := foldLetters(name)
+	if w == "" {
+		return ""
+	}
+	switch {
+	case strings.HasPrefix(w, "AE"), strings.HasPrefix(w, "GN"), strings.HasPrefix(w, "KN"),
+		strings.HasPrefix(w, "PN"), strings.HasPrefix(w, "WR"):
+		w = w[1:]
+	case w[0] == 'X':
+		w = "S" + w[1:]
+	case strings.HasPrefix(w, "WH"):
+		w = "W" + w[2:]
+	}
+
+	at := func(i int) byte {
+		if i < 0 || i >= len(w) {
+			return 0
+		}
+		return w[i]
+	}
+	isVowel := func(c byte) bool { return strings.IndexByte("AEIOU", c) >= 0 }
+	frontVowel := func(c byte) bool { return c == 'E' || c == 'I' || c == 'Y' }
+
+	var key strings.Builder
+	for i := 0; i < len(w); i++ {
+		c := w[i]
+		if c != 'C' && i > 0 && at(i-1) == c {
+			continue
+		}
+		next, prev := at(i+1), at(i-1)
+		switch c {
+		case 'A', 'E', 'I', 'O', 'U':
+			if i == 0 {
+				key.WriteByte(c)
+			}
+		case 'B':
+			if !(prev == 'M' && i == len(w)-1) {
+				key.WriteByte('B')
+			}
+		case 'C':
+			switch {
+			case prev == 'S' && frontVowel(next):
+				// SCI, SCE, SCY: silent
+			case next == 'I' && at(i+2) == 'A', next == 'H' && prev != 'S':
+				key.WriteByte('X')
+			case frontVowel(next):
+				key.WriteByte('S')
+			default:
+				key.WriteByte('K')
+			}
+		case 'D':
+			if next == 'G' && frontVowel(at(i+2)) {
+				key.WriteByte('J')
+			} else {
+				key.WriteByte('T')
+			}
+		case 'G':
+			switch {
+			case next == 'H' && i+2 < len(w) && !isVowel(at(i+2)):
+				// GH before a consonant is silent, as in "Knight"
+			case next == 'N' && (i+2 == len(w) || strings.HasPrefix(w[i+2:], "ED") && i+4 == len(w)):
+				// GN, GNED at the end: silent
+			case frontVowel(next) && prev != 'G':
+				key.WriteByte('J')
+			default:
+				key.WriteByte('K')
+			}
+		case 'H':
+			if isVowel(next) && !strings.ContainsRune("CSPTG", rune(prev)) {
+				key.WriteByte('H')
+			}
+		case 'K':
+			if prev != 'C' {
+				key.WriteByte('K')
+			}
+		case 'P':
+			if next == 'H' {
+				key.WriteByte('F')
+			} else {
+				key.WriteByte('P')
+			}
+		case 'Q':
+			key.WriteByte('K')
+		case 'S':
+			switch {
+			case next == 'H', next == 'I' && (at(i+2) == 'O' || at(i+2) == 'A'):
+				key.WriteByte('X')
+			default:
+				key.WriteByte('S')
+			}
+		case 'T':
+			switch {
+			case next == 'I' && (at(i+2) == 'O' || at(i+2) == 'A'):
+				key.WriteByte('X')
+			case next == 'H':
+				key.WriteByte('0')
+			case next == 'C' && at(i+2) == 'H':
+				// TCH: the CH gives the sound
+			default:
+				key.WriteByte('T')
+			}
+		case 'V':
+			key.WriteByte('F')
+		case 'W', 'Y':
+			if isVowel(next) {
+				key.WriteByte(c)
+			}
+		case 'X':
+			key.WriteString("KS")
+		case 'Z':
+			key.WriteByte('S')
+		default: // F, J, L, M, N, R
+			key.WriteByte(c)
+		}
+	}
+	return key.String()
+}
@@ -0,0 +1,48 @@
+package dedup_test
+
+import (
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/dedup"
+)
+
+func TestSoundex(t *testing.T) {
+	for name, want := range map[string]string{
+		"Robert":   "R163",
+		"Rupert":   "R163",
+		"Rubin":    "R150",
+		"Ashcraft": "A261",
+		"Tymczak":  "T522",
+		"Pfister":  "P236",
+		"Honeyman": "H555",
+		"Lee":      "L000",
+		"Müller":   "M460",
+		"O'Brien":  "O165",
+		"":         "",
+		"123":      "",
+	} {
+		if got := dedup.Soundex(name); got != want {
+			t.Errorf("Soundex(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestMetaphone(t *testing.T) {
+	for name, want := range map[string]string{
+		"Smith":     "SM0",
+		"Smyth":     "SM0",
+		"Knight":    "NT",
+		"Wright":    "RT",
+		"Phillips":  "FLPS",
+		"Catherine": "K0RN",
+		"Kathryn":   "K0RN",
+		"Schmidt":   "SKMTT",
+		"Xavier":    "SFR",
+		"Charles":   "XRLS",
+		"":          "",
+	} {
+		if got := dedup.Metaphone(name); got != want {
+			t.Errorf("Metaphone(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
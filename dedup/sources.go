@@ -0,0 +1,648 @@
+package dedup
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+	"github.com/cacack/gedcom-go/v2/merge"
+)
+
+// MatchReason explains why records were grouped as duplicates.
+type MatchReason string
+
+const (
+	// MatchTitle means the normalized title, author, and publication match.
+	MatchTitle MatchReason = "title"
+
+	// MatchAPID means the sources share an Ancestry database identifier,
+	// from a record-level _APID or an _APID on a citation of the source.
+	MatchAPID MatchReason = "apid"
+)
+
+// SourceGroup is a set of SOUR records judged to be the same source.
+type SourceGroup struct {
+	// Keep is the XRef of the record the others are merged into: the
+	// first of the group in document order.
+	Keep string
+
+	// Duplicates are the XRefs of the records to merge into Keep, in
+	// document order.
+	Duplicates []string
+
+	// Reasons lists the kinds of match that linked the group, sorted.
+	Reasons []MatchReason
+}
+
+// SourceMerge records one applied SourceGroup.
+type SourceMerge struct {
+	// Kept is the XRef of the surviving record.
+	Kept string
+
+	// Merged are the XRefs of the records folded into Kept and removed.
+	Merged []string
+
+	// Reasons is copied from the SourceGroup.
+	Reasons []MatchReason
+
+	// Conflicts lists single-valued fields (e.g. "AUTH") where a merged
+	// record had a different non-empty value than Kept; Kept's value was
+	// retained.
+	Conflicts []string
+}
+
+// SourceReport describes what MergeSources changed.
+type SourceReport struct {
+	// Merges lists each applied group, in the order given.
+	Merges []SourceMerge
+
+	// RepointedReferences is the number of pointers (citations and
+	// other references) rewritten from a merged record to its kept record.
+	RepointedReferences int
+}
+
+// RemovedCount returns the number of SOUR records removed.
+func (r *SourceReport) RemovedCount() int {
+	n := 0
+	for _, m := range r.Merges {
+		n += len(m.Merged)
+	}
+	return n
+}
+
+// FindDuplicateSources groups the SOUR records of doc that are likely
+// duplicates. Two sources match when:
+//
+//   - their titles are non-empty and their title, author, and publication
+//     are equal after normalization (case, punctuation, and runs of
+//     whitespace are ignored), or
+//   - they share an Ancestry database ID, taken from a record-level _APID
+//     tag or from the _APID of any citation pointing at the source.
+//
+// Matching is transitive, so a group may be linked by several reasons.
+// Only groups with at least one duplicate are returned, ordered by the
+// position of their kept record. Returns nil if doc is nil.
+func FindDuplicateSources(doc *gedcom.Document) []SourceGroup {
+	if doc == nil {
+		return nil
+	}
+
+	var xrefs []string
+	index := make(map[string]int)
+	for _, record := range doc.Records {
+		if record == nil || record.Type != gedcom.RecordTypeSource || record.XRef == "" {
+			continue
+		}
+		if _, dup := index[record.XRef]; dup {
+			continue
+		}
+		index[record.XRef] = len(xrefs)
+		xrefs = append(xrefs, record.XRef)
+	}
+	if len(xrefs) < 2 {
+		return nil
+	}
+
+	m := &sourceMatcher{
+		uf:        newUnionFind(len(xrefs)),
+		reasons:   make([]map[MatchReason]bool, len(xrefs)),
+		titleKeys: make(map[string]int),
+		apidKeys:  make(map[string]int),
+	}
+	for _, record := range doc.Records {
+		if record == nil || record.Type != gedcom.RecordTypeSource {
+			continue
+		}
+		if i, ok := index[record.XRef]; ok {
+			m.linkRecord(record, i)
+		}
+	}
+	for xref, dbs := range citedAPIDDatabases(doc) {
+		i, ok := index[xref]
+		if !ok {
+			continue
+		}
+		for _, db := range dbs {
+			m.link(m.apidKeys, db, i, MatchAPID)
+		}
+	}
+	return m.groups(xrefs)
+}
+
+// sourceMatcher links sources, by position, that share a match key.
+type sourceMatcher struct {
+	uf      *unionFind
+	reasons []map[MatchReason]bool // per source, why it was linked
+
+	// titleKeys and apidKeys map each key to the first source with it.
+	titleKeys map[string]int
+	apidKeys  map[string]int
+}
+
+// link joins source i with the first source that had key among keys.
+func (m *sourceMatcher) link(keys map[string]int, key string, i int, reason MatchReason) {
+	if j, ok := keys[key]; ok {
+		m.uf.union(i, j)
+		m.reasons[i] = addReason(m.reasons[i], reason)
+		m.reasons[j] = addReason(m.reasons[j], reason)
+		return
+	}
+	keys[key] = i
+}
+
+// linkRecord links the source record at position i by its title and its
+// record-level _APID.
+func (m *sourceMatcher) linkRecord(record *gedcom.Record, i int) {
+	if key := sourceTitleKey(record); key != "" {
+		m.link(m.titleKeys, key, i, MatchTitle)
+	}
+	if db := recordAPIDDatabase(record); db != "" {
+		m.link(m.apidKeys, db, i, MatchAPID)
+	}
+}
+
+// groups returns the groups of xrefs with at least one duplicate, ordered
+// by the position of their kept record.
+func (m *sourceMatcher) groups(xrefs []string) []SourceGroup {
+	byRoot := make(map[int]*SourceGroup)
+	var order []int
+	for i, xref := range xrefs {
+		root := m.uf.find(i)
+		group, ok := byRoot[root]
+		if !ok {
+			group = &SourceGroup{Keep: xref}
+			byRoot[root] = group
+			order = append(order, root)
+		} else {
+			group.Duplicates = append(group.Duplicates, xref)
+		}
+		for reason := range m.reasons[i] {
+			if !hasReason(group.Reasons, reason) {
+				group.Reasons = append(group.Reasons, reason)
+			}
+		}
+	}
+
+	var groups []SourceGroup
+	for _, root := range order {
+		if group := byRoot[root]; len(group.Duplicates) > 0 {
+			sort.Slice(group.Reasons, func(i, j int) bool { return group.Reasons[i] < group.Reasons[j] })
+			groups = append(groups, *group)
+		}
+	}
+	return groups
+}
+
+// MergeSources returns a copy of doc with each group's duplicates folded
+// into its kept record. The input document is never mutated.
+//
+// For each duplicate, level-1 structures the kept record lacks are copied
+// over: single-valued structures (TITL, AUTH, PUBL, TEXT, ABBR, REPO, DATA)
+// only when the kept record has none, and repeatable ones (NOTE, OBJE,
+// REFN, EXID, extension tags, ...) unless an identical structure is
+// already present. CHAN, CREA, and _APID are never copied. Typed Source
+// fields are merged the same way. The duplicate is then removed and every
+// reference to it, in citations, raw tags, and typed entities, is
+// re-pointed at the kept record.
+//
+// Returns an error if doc is nil, or if a group names a record that is not
+// a SOUR record of doc or names a record more than once.
+func MergeSources(doc *gedcom.Document, groups []SourceGroup) (*gedcom.Document, *SourceReport, error) {
+	if doc == nil {
+		return nil, nil, errors.New("dedup: document is nil")
+	}
+
+	out := doc.Clone()
+	report := &SourceReport{}
+	if len(groups) == 0 {
+		return out, report, nil
+	}
+
+	records := make(map[string]*gedcom.Record)
+	for _, record := range out.Records {
+		if record != nil && record.Type == gedcom.RecordTypeSource && record.XRef != "" {
+			records[record.XRef] = record
+		}
+	}
+
+	mapping := make(map[string]string)
+	seen := make(map[string]bool)
+	for _, group := range groups {
+		sourceMerge, err := mergeSourceGroup(group, records, seen, mapping)
+		if err != nil {
+			return nil, nil, err
+		}
+		report.Merges = append(report.Merges, sourceMerge)
+	}
+
+	removeMergedRecords(out, gedcom.RecordTypeSource, mapping)
+	for _, record := range out.Records {
+		report.RepointedReferences += countReferences(record, mapping)
+	}
+	gedcom.Apply(out, mapping)
+
+	return out, report, nil
+}
+
+// mergeSourceGroup folds the duplicates of group into its kept record,
+// among the source records by XRef. It adds the duplicates to mapping and
+// every member to seen, failing if one was already seen.
+func mergeSourceGroup(group SourceGroup, records map[string]*gedcom.Record, seen map[string]bool, mapping map[string]string) (SourceMerge, error) {
+	keep, ok := records[group.Keep]
+	if !ok {
+		return SourceMerge{}, fmt.Errorf("dedup: %s is not a source record", group.Keep)
+	}
+	for _, xref := range append([]string{group.Keep}, group.Duplicates...) {
+		if seen[xref] {
+			return SourceMerge{}, fmt.Errorf("dedup: source %s appears in more than one group", xref)
+		}
+		seen[xref] = true
+	}
+
+	sourceMerge := SourceMerge{Kept: group.Keep, Reasons: group.Reasons}
+	for _, xref := range group.Duplicates {
+		dup, ok := records[xref]
+		if !ok {
+			return SourceMerge{}, fmt.Errorf("dedup: %s is not a source record", xref)
+		}
+		sourceMerge.Conflicts = appendUnique(sourceMerge.Conflicts, mergeSourceRecord(keep, dup)...)
+		mapping[xref] = group.Keep
+		sourceMerge.Merged = append(sourceMerge.Merged, xref)
+	}
+	return sourceMerge, nil
+}
+
+// DedupSources finds duplicate sources in doc and merges them. It is
+// FindDuplicateSources followed by MergeSources.
+func DedupSources(doc *gedcom.Document) (*gedcom.Document, *SourceReport, error) {
+	if doc == nil {
+		return nil, nil, errors.New("dedup: document is nil")
+	}
+	return MergeSources(doc, FindDuplicateSources(doc))
+}
+
+// DedupSourcesAcross combines docs into one document with merge.Combine,
+// renumbering colliding XRefs (merge.RenumberDoc2), and then deduplicates
+// its sources. Header fields of the first document win. Returns an error
+// if docs is empty, any document is nil, or the headers are incompatible.
+func DedupSourcesAcross(docs ...*gedcom.Document) (*gedcom.Document, *SourceReport, error) {
+	if len(docs) == 0 {
+		return nil, nil, errors.New("dedup: no documents")
+	}
+	combined := docs[0]
+	if combined == nil {
+		return nil, nil, errors.New("dedup: document is nil")
+	}
+	for _, doc := range docs[1:] {
+		var err error
+		combined, _, err = merge.Combine(combined, doc, merge.CombineOptions{CollisionStrategy: merge.RenumberDoc2})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return DedupSources(combined)
+}
+
+// singleValuedSourceTags are level-1 SOUR substructures copied from a
+// duplicate only when the kept record has none.
+var singleValuedSourceTags = map[string]bool{
+	"TITL": true, "AUTH": true, "PUBL": true, "TEXT": true,
+	"ABBR": true, "REPO": true, "DATA": true,
+}
+
+// skippedSourceTags are never copied from a duplicate: change dates are
+// the kept record's own, and _APID only identified the duplicate.
+var skippedSourceTags = map[string]bool{"CHAN": true, "CREA": true, "_APID": true}
+
+// mergeSourceRecord folds dup into keep and returns the single-valued
+// tags whose values conflicted.
+func mergeSourceRecord(keep, dup *gedcom.Record) []string {
+	var conflicts []string
+	if len(dup.Tags) > 0 {
+		keepBlocks := splitBlocks(keep.Tags)
+		for _, block := range splitBlocks(dup.Tags) {
+			tag := block[0].Tag
+			if skippedSourceTags[tag] {
+				continue
+			}
+			if singleValuedSourceTags[tag] {
+				if existing := findBlock(keepBlocks, tag); existing != nil {
+					if renderBlock(existing) != renderBlock(block) {
+						conflicts = appendUnique(conflicts, tag)
+					}
+					continue
+				}
+			} else if containsBlock(keepBlocks, block) {
+				continue
+			}
+			copied := gedcom.CloneTags(block)
+			keep.Tags = append(keep.Tags, copied...)
+			keepBlocks = append(keepBlocks, copied)
+		}
+	}
+
+	keepSrc, _ := keep.Entity.(*gedcom.Source)
+	dupSrc, _ := dup.Entity.(*gedcom.Source)
+	if keepSrc != nil && dupSrc != nil {
+		conflicts = appendUnique(conflicts, mergeSourceEntity(keepSrc, dupSrc)...)
+		if len(keep.Tags) > 0 {
+			keepSrc.Tags = keep.Tags
+			keep.PopulateExtensionData()
+		}
+	}
+	return conflicts
+}
+
+// mergeSourceEntity folds the typed fields of dup into keep and returns
+// the single-valued fields whose values conflicted.
+func mergeSourceEntity(keep, dup *gedcom.Source) []string {
+	var conflicts []string
+	fill := func(tag string, dst *string, src string) {
+		switch {
+		case src == "":
+		case *dst == "":
+			*dst = src
+		case *dst != src:
+			conflicts = append(conflicts, tag)
+		}
+	}
+	fill("TITL", &keep.Title, dup.Title)
+	fill("AUTH", &keep.Author, dup.Author)
+	fill("PUBL", &keep.Publication, dup.Publication)
+	fill("TEXT", &keep.Text, dup.Text)
+
+	if keep.RepositoryLink == nil && dup.RepositoryLink != nil {
+		keep.RepositoryLink = dup.RepositoryLink
+		keep.RepositoryRef = dup.RepositoryRef
+		keep.Repository = dup.Repository
+	}
+	keep.RepositoryLinks = appendDistinct(keep.RepositoryLinks, dup.RepositoryLinks...)
+
+	keep.NoteXRefs = appendUnique(keep.NoteXRefs, dup.NoteXRefs...)
+	keep.InlineNotes = appendUnique(keep.InlineNotes, dup.InlineNotes...)
+	keep.Notes = appendUnique(keep.Notes, dup.Notes...)
+	keep.Media = append(keep.Media, dup.Media...)
+	keep.UserReferences = appendDistinct(keep.AllUserReferences(), dup.AllUserReferences()...)
+	if keep.RefNumber == "" && len(keep.UserReferences) > 0 {
+		keep.RefNumber = keep.UserReferences[0].Number
+	}
+	keep.ExternalIDs = append(keep.ExternalIDs, dup.ExternalIDs...)
+	for tag, exts := range dup.ExtensionData {
+		for _, ext := range exts {
+			keep.ExtensionData = addExtension(keep.ExtensionData, tag, ext)
+		}
+	}
+	return conflicts
+}
+
+// addExtension adds ext under tag unless data already holds it.
+func addExtension(data gedcom.ExtensionData, tag string, ext *gedcom.Extension) gedcom.ExtensionData {
+	for _, existing := range data[tag] {
+		if existing.Value == ext.Value && len(existing.Children) == 0 && len(ext.Children) == 0 {
+			return data
+		}
+	}
+	if data == nil {
+		data = make(gedcom.ExtensionData)
+	}
+	data.Add(ext)
+	return data
+}
+
+// countReferences counts the pointers in record that mapping rewrites.
+// Raw tags are authoritative when present, so each pointer is counted once
+// rather than once per representation.
+func countReferences(record *gedcom.Record, mapping map[string]string) int {
+	n := 0
+	if len(record.Tags) == 0 {
+		gedcom.Visit(record, func(ref string) {
+			if _, ok := mapping[ref]; ok {
+				n++
+			}
+		})
+		return n
+	}
+	for _, tag := range record.Tags {
+		if _, ok := mapping[tag.Value]; ok && gedcom.IsPointerXRef(tag.Value) {
+			n++
+		} else if _, ok := mapping[tag.XRef]; ok && gedcom.IsPointerXRef(tag.XRef) {
+			n++
+		}
+	}
+	return n
+}
+
+// sourceTitleKey returns the normalized title/author/publication key of a
+// source record, or "" if it has no title.
+func sourceTitleKey(record *gedcom.Record) string {
+	var title, author, publ string
+	if src, ok := record.Entity.(*gedcom.Source); ok {
+		title, author, publ = src.Title, src.Author, src.Publication
+	} else {
+		for _, tag := range record.Tags {
+			if tag.Level != 1 {
+				continue
+			}
+			switch tag.Tag {
+			case "TITL":
+				title = tag.Value
+			case "AUTH":
+				author = tag.Value
+			case "PUBL":
+				publ = tag.Value
+			}
+		}
+	}
+	title = normalizeText(title)
+	if title == "" {
+		return ""
+	}
+	return title + "\x00" + normalizeText(author) + "\x00" + normalizeText(publ)
+}
+
+// normalizeText lowercases s, treats punctuation as whitespace, and
+// collapses runs of whitespace.
+func normalizeText(s string) string {
+	mapped := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(mapped), " ")
+}
+
+// recordAPIDDatabase returns the Ancestry database ID of a record-level
+// _APID tag, or "".
+func recordAPIDDatabase(record *gedcom.Record) string {
+	for _, tag := range record.Tags {
+		if tag.Level == 1 && tag.Tag == "_APID" {
+			if apid := gedcom.ParseAPID(tag.Value); apid != nil && apid.Database != "" {
+				return apid.Database
+			}
+		}
+	}
+	return ""
+}
+
+// citedAPIDDatabases maps each cited source XRef to the Ancestry database
+// IDs found on citations of it.
+func citedAPIDDatabases(doc *gedcom.Document) map[string][]string {
+	result := make(map[string][]string)
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		for _, cite := range entityCitations(record.Entity) {
+			if cite == nil || cite.AncestryAPID == nil || cite.AncestryAPID.Database == "" || cite.SourceXRef == "" {
+				continue
+			}
+			result[cite.SourceXRef] = appendUnique(result[cite.SourceXRef], cite.AncestryAPID.Database)
+		}
+	}
+	return result
+}
+
+// entityCitations returns the source citations of an individual or family
+// entity: its own, then those of its events and, for individuals, its
+// attributes.
+func entityCitations(entity interface{}) []*gedcom.SourceCitation {
+	var cites []*gedcom.SourceCitation
+	switch e := entity.(type) {
+	case *gedcom.Individual:
+		cites = append(cites, e.SourceCitations...)
+		cites = appendEventCitations(cites, e.Events)
+		for _, a := range e.Attributes {
+			if a != nil {
+				cites = append(cites, a.SourceCitations...)
+			}
+		}
+	case *gedcom.Family:
+		cites = append(cites, e.SourceCitations...)
+		cites = appendEventCitations(cites, e.Events)
+	}
+	return cites
+}
+
+// appendEventCitations appends the source citations of events to cites.
+func appendEventCitations(cites []*gedcom.SourceCitation, events []*gedcom.Event) []*gedcom.SourceCitation {
+	for _, e := range events {
+		if e != nil {
+			cites = append(cites, e.SourceCitations...)
+		}
+	}
+	return cites
+}
+
+// splitBlocks splits record tags into level-1 structures, each with its
+// subordinates.
+func splitBlocks(tags []*gedcom.Tag) [][]*gedcom.Tag {
+	var blocks [][]*gedcom.Tag
+	for i := 0; i < len(tags); {
+		j := i + 1
+		for j < len(tags) && tags[j].Level > tags[i].Level {
+			j++
+		}
+		blocks = append(blocks, tags[i:j])
+		i = j
+	}
+	return blocks
+}
+
+// findBlock returns the first block whose top tag is tag, or nil.
+func findBlock(blocks [][]*gedcom.Tag, tag string) []*gedcom.Tag {
+	for _, block := range blocks {
+		if block[0].Tag == tag {
+			return block
+		}
+	}
+	return nil
+}
+
+// containsBlock reports whether blocks holds a structure identical to block.
+func containsBlock(blocks [][]*gedcom.Tag, block []*gedcom.Tag) bool {
+	rendered := renderBlock(block)
+	for _, b := range blocks {
+		if renderBlock(b) == rendered {
+			return true
+		}
+	}
+	return false
+}
+
+// renderBlock renders a structure for comparison, ignoring line numbers.
+func renderBlock(block []*gedcom.Tag) string {
+	var sb strings.Builder
+	for _, tag := range block {
+		fmt.Fprintf(&sb, "%d %s %s %s\n", tag.Level, tag.XRef, tag.Tag, tag.Value)
+	}
+	return sb.String()
+}
+
+// appendUnique appends the values of add not already in list.
+func appendUnique(list []string, add ...string) []string {
+	for _, s := range add {
+		found := false
+		for _, existing := range list {
+			if existing == s {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+func addReason(set map[MatchReason]bool, reason MatchReason) map[MatchReason]bool {
+	if set == nil {
+		set = make(map[MatchReason]bool)
+	}
+	set[reason] = true
+	return set
+}
+
+func hasReason(list []MatchReason, reason MatchReason) bool {
+	for _, r := range list {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// unionFind is a disjoint-set forest over indices.
+type unionFind struct {
+	parent []int
+}
+
+func newUnionFind(n int) *unionFind {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	return &unionFind{parent: parent}
+}
+
+func (u *unionFind) find(i int) int {
+	for u.parent[i] != i {
+		u.parent[i] = u.parent[u.parent[i]]
+		i = u.parent[i]
+	}
+	return i
+}
+
+// union joins the sets of i and j, keeping the smaller root (earlier
+// record) as the representative.
+func (u *unionFind) union(i, j int) {
+	ri, rj := u.find(i), u.find(j)
+	if rj < ri {
+		ri, rj = rj, ri
+	}
+	u.parent[rj] = ri
+}
@@ -0,0 +1,282 @@
+package dedup_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/dedup"
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func decode(t *testing.T, lines ...string) *gedcom.Document {
+	t.Helper()
+	src := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 CHAR UTF-8\n" + strings.Join(lines, "\n") + "\n0 TRLR\n"
+	doc, err := decoder.Decode(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func encode(t *testing.T, doc *gedcom.Document) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	return buf.String()
+}
+
+func TestFindDuplicateSources(t *testing.T) {
+	doc := decode(t,
+		"0 @I1@ INDI",
+		"1 BIRT",
+		"2 SOUR @S3@",
+		"3 _APID 1,7602::2771226",
+		"1 SOUR @S4@",
+		"2 _APID 1,7602::9999",
+		"0 @S1@ SOUR",
+		"1 TITL 1900 United States Federal Census",
+		"1 AUTH Ancestry.com",
+		"0 @S2@ SOUR",
+		"1 TITL 1900 united states federal census.",
+		"1 AUTH Ancestry.com",
+		"0 @S3@ SOUR",
+		"1 TITL Census 1900",
+		"0 @S4@ SOUR",
+		"1 TITL Census 1900 (copy)",
+		"0 @S5@ SOUR",
+		"1 TITL 1900 United States Federal Census",
+		"1 AUTH Someone Else",
+		"0 @S6@ SOUR",
+		"1 _APID 1,7602::0",
+		"1 TITL Ancestry 7602",
+	)
+
+	groups := dedup.FindDuplicateSources(doc)
+	want := []dedup.SourceGroup{
+		{Keep: "@S1@", Duplicates: []string{"@S2@"}, Reasons: []dedup.MatchReason{dedup.MatchTitle}},
+		{Keep: "@S3@", Duplicates: []string{"@S4@", "@S6@"}, Reasons: []dedup.MatchReason{dedup.MatchAPID}},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("FindDuplicateSources() = %+v, want %+v", groups, want)
+	}
+
+	if got := dedup.FindDuplicateSources(nil); got != nil {
+		t.Errorf("FindDuplicateSources(nil) = %v, want nil", got)
+	}
+}
+
+func TestFindDuplicateSources_Transitive(t *testing.T) {
+	doc := decode(t,
+		"0 @I1@ INDI",
+		"1 SOUR @S2@",
+		"2 _APID 1,100::1",
+		"1 SOUR @S3@",
+		"2 _APID 1,100::2",
+		"0 @S1@ SOUR",
+		"1 TITL Parish Register",
+		"0 @S2@ SOUR",
+		"1 TITL Parish register",
+		"0 @S3@ SOUR",
+		"1 TITL Something Unrelated",
+	)
+
+	groups := dedup.FindDuplicateSources(doc)
+	if len(groups) != 1 {
+		t.Fatalf("groups = %+v, want 1", groups)
+	}
+	g := groups[0]
+	if g.Keep != "@S1@" || !reflect.DeepEqual(g.Duplicates, []string{"@S2@", "@S3@"}) {
+		t.Errorf("group = %+v", g)
+	}
+	if !reflect.DeepEqual(g.Reasons, []dedup.MatchReason{dedup.MatchAPID, dedup.MatchTitle}) {
+		t.Errorf("Reasons = %v", g.Reasons)
+	}
+}
+
+func TestDedupSources(t *testing.T) {
+	doc := decode(t,
+		"0 @I1@ INDI",
+		"1 NAME John /Doe/",
+		"1 SOUR @S2@",
+		"2 PAGE p. 4",
+		"1 BIRT",
+		"2 SOUR @S1@",
+		"0 @F1@ FAM",
+		"1 SOUR @S2@",
+		"0 @N1@ NOTE Shared note",
+		"0 @S1@ SOUR",
+		"1 TITL Parish Register",
+		"1 NOTE @N1@",
+		"0 @S2@ SOUR",
+		"1 TITL Parish Register",
+		"1 AUTH St. Mary's",
+		"1 PUBL Diocese",
+		"1 NOTE @N1@",
+		"1 NOTE Filmed 1950",
+		"1 _APID 1,100::0",
+	)
+
+	out, report, err := dedup.DedupSources(doc)
+	if err != nil {
+		t.Fatalf("DedupSources() error = %v", err)
+	}
+	if report.RemovedCount() != 0 {
+		// Title keys differ: S2 has an author and publication, S1 does not.
+		t.Fatalf("unexpected merges: %+v", report.Merges)
+	}
+
+	groups := []dedup.SourceGroup{{Keep: "@S1@", Duplicates: []string{"@S2@"}, Reasons: []dedup.MatchReason{dedup.MatchTitle}}}
+	out, report, err = dedup.MergeSources(doc, groups)
+	if err != nil {
+		t.Fatalf("MergeSources() error = %v", err)
+	}
+
+	if len(report.Merges) != 1 || report.RemovedCount() != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	if report.RepointedReferences != 2 {
+		t.Errorf("RepointedReferences = %d, want 2", report.RepointedReferences)
+	}
+	if out.GetRecord("@S2@") != nil || out.GetSource("@S2@") != nil {
+		t.Error("@S2@ should be removed")
+	}
+
+	src := out.GetSource("@S1@")
+	if src == nil {
+		t.Fatal("@S1@ missing")
+	}
+	if src.Author != "St. Mary's" || src.Publication != "Diocese" {
+		t.Errorf("Author = %q, Publication = %q", src.Author, src.Publication)
+	}
+
+	indi := out.GetIndividual("@I1@")
+	if indi.SourceCitations[0].SourceXRef != "@S1@" || indi.SourceCitations[0].Page != "p. 4" {
+		t.Errorf("citation = %+v", indi.SourceCitations[0])
+	}
+	if fam := out.GetFamily("@F1@"); fam.SourceCitations[0].SourceXRef != "@S1@" {
+		t.Errorf("family citation = %+v", fam.SourceCitations[0])
+	}
+
+	text := encode(t, out)
+	for _, want := range []string{"1 SOUR @S1@\n2 PAGE p. 4", "1 AUTH St. Mary's", "1 NOTE Filmed 1950"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("output missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "@S2@") || strings.Contains(text, "_APID") {
+		t.Errorf("output still references the merged source:\n%s", text)
+	}
+	if n := strings.Count(text, "1 NOTE @N1@"); n != 1 {
+		t.Errorf("shared note written %d times, want 1", n)
+	}
+
+	if doc.GetSource("@S2@") == nil || doc.GetIndividual("@I1@").SourceCitations[0].SourceXRef != "@S2@" {
+		t.Error("input document was mutated")
+	}
+}
+
+func TestMergeSources_Conflicts(t *testing.T) {
+	doc := decode(t,
+		"0 @S1@ SOUR",
+		"1 TITL Census",
+		"1 AUTH Bureau",
+		"0 @S2@ SOUR",
+		"1 TITL Census",
+		"1 AUTH Census Bureau",
+	)
+
+	out, report, err := dedup.MergeSources(doc, []dedup.SourceGroup{{Keep: "@S1@", Duplicates: []string{"@S2@"}}})
+	if err != nil {
+		t.Fatalf("MergeSources() error = %v", err)
+	}
+	if got := report.Merges[0].Conflicts; !reflect.DeepEqual(got, []string{"AUTH"}) {
+		t.Errorf("Conflicts = %v, want [AUTH]", got)
+	}
+	if src := out.GetSource("@S1@"); src.Author != "Bureau" {
+		t.Errorf("Author = %q, kept record should win", src.Author)
+	}
+}
+
+func TestMergeSources_Errors(t *testing.T) {
+	doc := decode(t,
+		"0 @I1@ INDI",
+		"0 @S1@ SOUR",
+		"1 TITL A",
+		"0 @S2@ SOUR",
+		"1 TITL A",
+	)
+
+	tests := []struct {
+		name   string
+		groups []dedup.SourceGroup
+		want   string
+	}{
+		{"unknown keep", []dedup.SourceGroup{{Keep: "@S9@", Duplicates: []string{"@S1@"}}}, "@S9@ is not a source"},
+		{"not a source", []dedup.SourceGroup{{Keep: "@S1@", Duplicates: []string{"@I1@"}}}, "@I1@ is not a source"},
+		{"repeated", []dedup.SourceGroup{
+			{Keep: "@S1@", Duplicates: []string{"@S2@"}},
+			{Keep: "@S2@"},
+		}, "more than one group"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := dedup.MergeSources(doc, tt.groups)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, _, err := dedup.MergeSources(nil, nil); err == nil {
+		t.Error("MergeSources(nil) should fail")
+	}
+	if _, _, err := dedup.DedupSources(nil); err == nil {
+		t.Error("DedupSources(nil) should fail")
+	}
+}
+
+func TestDedupSourcesAcross(t *testing.T) {
+	first := decode(t,
+		"0 @I1@ INDI",
+		"1 SOUR @S1@",
+		"2 _APID 1,7602::1",
+		"0 @S1@ SOUR",
+		"1 TITL 1900 Census",
+	)
+	second := decode(t,
+		"0 @I1@ INDI",
+		"1 SOUR @S1@",
+		"2 _APID 1,7602::2",
+		"0 @S1@ SOUR",
+		"1 TITL 1900 U.S. Census",
+	)
+
+	out, report, err := dedup.DedupSourcesAcross(first, second)
+	if err != nil {
+		t.Fatalf("DedupSourcesAcross() error = %v", err)
+	}
+	if report.RemovedCount() != 1 || report.RepointedReferences != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	if n := len(out.Sources()); n != 1 {
+		t.Errorf("sources = %d, want 1", n)
+	}
+	for _, indi := range out.Individuals() {
+		if got := indi.SourceCitations[0].SourceXRef; got != "@S1@" {
+			t.Errorf("%s cites %s, want @S1@", indi.XRef, got)
+		}
+	}
+
+	if _, _, err := dedup.DedupSourcesAcross(); err == nil {
+		t.Error("DedupSourcesAcross() with no documents should fail")
+	}
+	if _, _, err := dedup.DedupSourcesAcross(first, nil); err == nil {
+		t.Error("DedupSourcesAcross() with a nil document should fail")
+	}
+}
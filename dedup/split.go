@@ -0,0 +1,374 @@
+package dedup
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// SplitPartition selects the structures of an individual that move to the
+// new record in SplitIndividual. Indexes refer to the individual's typed
+// slices (Individual.Names, Events, Attributes, SourceCitations); family
+// links are selected by family XRef. Everything not selected stays.
+type SplitPartition struct {
+	// Names are indexes into Individual.Names.
+	Names []int
+
+	// Events are indexes into Individual.Events.
+	Events []int
+
+	// Attributes are indexes into Individual.Attributes.
+	Attributes []int
+
+	// SourceCitations are indexes into Individual.SourceCitations
+	// (record-level SOUR citations).
+	SourceCitations []int
+
+	// ChildInFamilies are the XRefs of FAMC links to move. The families'
+	// CHIL links are re-pointed at the new record.
+	ChildInFamilies []string
+
+	// SpouseInFamilies are the XRefs of FAMS links to move. The families'
+	// HUSB or WIFE links are re-pointed at the new record.
+	SpouseInFamilies []string
+}
+
+// isEmpty reports whether the partition selects nothing.
+func (p SplitPartition) isEmpty() bool {
+	return len(p.Names) == 0 && len(p.Events) == 0 && len(p.Attributes) == 0 &&
+		len(p.SourceCitations) == 0 && len(p.ChildInFamilies) == 0 && len(p.SpouseInFamilies) == 0
+}
+
+// SplitReport summarizes a SplitIndividual call.
+type SplitReport struct {
+	// Original is the XRef of the individual that was split.
+	Original string
+
+	// New is the XRef of the record created for the moved structures.
+	New string
+
+	// UID is the unique identifier generated for the new record.
+	UID string
+
+	// RepointedReferences counts the CHIL, HUSB, and WIFE links re-pointed
+	// from Original to New.
+	RepointedReferences int
+}
+
+// individualAttributeTags are the level-1 INDI tags decoded into
+// Individual.Attributes.
+var individualAttributeTags = map[string]bool{
+	"OCCU": true, "CAST": true, "DSCR": true, "EDUC": true, "IDNO": true, "NATI": true,
+	"SSN": true, "TITL": true, "RELI": true, "NCHI": true, "NMR": true, "PROP": true,
+}
+
+// SplitIndividual splits the individual xref into two, for repairing trees
+// where two people were wrongly merged upstream. The structures selected
+// by partition move to a new INDI record, placed after the original, with
+// a fresh XRef and a fresh UID; SEX and RESN are copied to it. Families
+// whose FAMC or FAMS link moved have their CHIL, HUSB, or WIFE link
+// re-pointed at the new record. Everything else, including references to
+// the individual from other records, stays with the original.
+//
+// Returns an error if doc is nil, if xref is not an INDI record of doc,
+// or if partition is empty or selects a structure the individual does not
+// have. The input document is not mutated.
+func SplitIndividual(doc *gedcom.Document, xref string, partition SplitPartition) (*gedcom.Document, *SplitReport, error) {
+	if doc == nil {
+		return nil, nil, errors.New("dedup: document is nil")
+	}
+	if partition.isEmpty() {
+		return nil, nil, errors.New("dedup: split partition selects nothing")
+	}
+
+	out := doc.Clone()
+	index := individualIndex(out, xref)
+	if index < 0 {
+		return nil, nil, fmt.Errorf("dedup: %s is not an individual record", xref)
+	}
+	record := out.Records[index]
+	ind, ok := record.Entity.(*gedcom.Individual)
+	if !ok {
+		return nil, nil, fmt.Errorf("dedup: %s has no individual entity", xref)
+	}
+	sel, err := newSplitSelection(xref, ind, partition)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newXRef := unusedIndividualXRef(out)
+	uid, err := newUID()
+	if err != nil {
+		return nil, nil, fmt.Errorf("dedup: generating UID: %w", err)
+	}
+
+	split := &gedcom.Individual{
+		XRef:        newXRef,
+		Sex:         ind.Sex,
+		Restriction: ind.Restriction,
+		UID:         uid,
+	}
+	sel.moveEntities(ind, split)
+
+	newRecord := &gedcom.Record{XRef: newXRef, Type: gedcom.RecordTypeIndividual, Entity: split}
+	if len(record.Tags) > 0 {
+		kept, moved := sel.splitTags(record.Tags)
+		moved = append(moved, &gedcom.Tag{Level: 1, Tag: "UID", Value: uid})
+		record.Tags = kept
+		ind.Tags = kept
+		newRecord.Tags = moved
+		split.Tags = moved
+		record.PopulateExtensionData()
+		newRecord.PopulateExtensionData()
+	}
+
+	out.Records = append(out.Records[:index+1], append([]*gedcom.Record{newRecord}, out.Records[index+1:]...)...)
+	if out.XRefMap != nil {
+		out.XRefMap[newXRef] = newRecord
+	}
+
+	report := &SplitReport{Original: xref, New: newXRef, UID: uid}
+	report.RepointedReferences = sel.repointFamilies(out, xref, newXRef)
+	return out, report, nil
+}
+
+// individualIndex returns the index in doc.Records of the INDI record
+// xref, or -1.
+func individualIndex(doc *gedcom.Document, xref string) int {
+	for i, record := range doc.Records {
+		if record != nil && record.Type == gedcom.RecordTypeIndividual && record.XRef == xref {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitSelection is a validated SplitPartition: the indexes and family
+// XRefs of the structures that move.
+type splitSelection struct {
+	names, events, attrs, cites map[int]bool
+	famc, fams                  map[string]bool
+}
+
+// newSplitSelection validates partition against ind, the individual xref.
+func newSplitSelection(xref string, ind *gedcom.Individual, partition SplitPartition) (*splitSelection, error) {
+	sel := &splitSelection{}
+	var err error
+	if sel.names, err = indexSet("name", partition.Names, len(ind.Names)); err != nil {
+		return nil, err
+	}
+	if sel.events, err = indexSet("event", partition.Events, len(ind.Events)); err != nil {
+		return nil, err
+	}
+	if sel.attrs, err = indexSet("attribute", partition.Attributes, len(ind.Attributes)); err != nil {
+		return nil, err
+	}
+	if sel.cites, err = indexSet("citation", partition.SourceCitations, len(ind.SourceCitations)); err != nil {
+		return nil, err
+	}
+	if sel.famc, err = familySet(xref, "FAMC", partition.ChildInFamilies, func(fam string) bool { return hasChildLink(ind, fam) }); err != nil {
+		return nil, err
+	}
+	sel.fams, err = familySet(xref, "FAMS", partition.SpouseInFamilies, func(fam string) bool { return containsString(ind.SpouseInFamilies, fam) })
+	return sel, err
+}
+
+// familySet validates that the individual xref has a link of the given
+// tag to each of families and returns them as a set.
+func familySet(xref, tag string, families []string, linked func(string) bool) (map[string]bool, error) {
+	set := make(map[string]bool, len(families))
+	for _, fam := range families {
+		if !linked(fam) {
+			return nil, fmt.Errorf("dedup: %s has no %s link to %s", xref, tag, fam)
+		}
+		set[fam] = true
+	}
+	return set, nil
+}
+
+// moveEntities moves the selected typed structures from ind to split.
+func (sel *splitSelection) moveEntities(ind, split *gedcom.Individual) {
+	ind.Names, split.Names = partitionSlice(ind.Names, func(i int, _ *gedcom.PersonalName) bool { return sel.names[i] })
+	ind.Events, split.Events = partitionSlice(ind.Events, func(i int, _ *gedcom.Event) bool { return sel.events[i] })
+	ind.Attributes, split.Attributes = partitionSlice(ind.Attributes, func(i int, _ *gedcom.Attribute) bool { return sel.attrs[i] })
+	ind.SourceCitations, split.SourceCitations = partitionSlice(ind.SourceCitations, func(i int, _ *gedcom.SourceCitation) bool { return sel.cites[i] })
+	ind.ChildInFamilies, split.ChildInFamilies = partitionSlice(ind.ChildInFamilies, func(_ int, l gedcom.FamilyLink) bool { return sel.famc[l.FamilyXRef] })
+	ind.SpouseInFamilies, split.SpouseInFamilies = partitionSlice(ind.SpouseInFamilies, func(_ int, fam string) bool { return sel.fams[fam] })
+}
+
+// splitTags divides an INDI record's raw tags between the original and the
+// new record. SEX and RESN are copied to both.
+func (sel *splitSelection) splitTags(tags []*gedcom.Tag) (kept, moved []*gedcom.Tag) {
+	var counts structureCounts
+	for _, block := range splitBlocks(tags) {
+		head := block[0]
+		if head.Tag == "SEX" || head.Tag == "RESN" {
+			moved = append(moved, gedcom.CloneTags(block)...)
+			kept = append(kept, block...)
+			continue
+		}
+		if sel.moves(head, &counts) {
+			moved = append(moved, block...)
+		} else {
+			kept = append(kept, block...)
+		}
+	}
+	return kept, moved
+}
+
+// structureCounts counts the level-1 structures of each typed slice seen
+// so far, to map raw tags onto the partition's indexes.
+type structureCounts struct {
+	names, events, attrs, cites int
+}
+
+// moves reports whether the level-1 structure head is selected, counting
+// it in counts.
+func (sel *splitSelection) moves(head *gedcom.Tag, counts *structureCounts) bool {
+	switch {
+	case head.Tag == "NAME":
+		counts.names++
+		return sel.names[counts.names-1]
+	case head.Tag == "SOUR":
+		counts.cites++
+		return sel.cites[counts.cites-1]
+	case head.Tag == "FAMC":
+		return sel.famc[head.Value]
+	case head.Tag == "FAMS":
+		return sel.fams[head.Value]
+	case individualAttributeTags[head.Tag]:
+		counts.attrs++
+		return sel.attrs[counts.attrs-1]
+	case head.Tag == "NO" || gedcom.IsEventType(head.Tag, gedcom.EventScopeIndividual):
+		counts.events++
+		return sel.events[counts.events-1]
+	}
+	return false
+}
+
+// repointFamilies re-points the member links of the families whose FAMC
+// or FAMS link moved from xref to newXRef, and returns the number of links
+// changed.
+func (sel *splitSelection) repointFamilies(doc *gedcom.Document, xref, newXRef string) int {
+	n := 0
+	for _, fam := range doc.Records {
+		if fam == nil || fam.Type != gedcom.RecordTypeFamily {
+			continue
+		}
+		if sel.famc[fam.XRef] {
+			n += repointFamilyMember(fam, "CHIL", xref, newXRef)
+		}
+		if sel.fams[fam.XRef] {
+			n += repointFamilyMember(fam, "HUSB", xref, newXRef)
+			n += repointFamilyMember(fam, "WIFE", xref, newXRef)
+		}
+	}
+	return n
+}
+
+// indexSet validates indexes into a slice of length n and returns them as a set.
+func indexSet(kind string, indexes []int, n int) (map[int]bool, error) {
+	set := make(map[int]bool, len(indexes))
+	for _, i := range indexes {
+		if i < 0 || i >= n {
+			return nil, fmt.Errorf("dedup: %s index %d out of range (individual has %d)", kind, i, n)
+		}
+		set[i] = true
+	}
+	return set, nil
+}
+
+// partitionSlice splits s into the elements move rejects and those it selects.
+func partitionSlice[T any](s []T, move func(int, T) bool) (kept, moved []T) {
+	for i, v := range s {
+		if move(i, v) {
+			moved = append(moved, v)
+		} else {
+			kept = append(kept, v)
+		}
+	}
+	return kept, moved
+}
+
+func hasChildLink(ind *gedcom.Individual, fam string) bool {
+	for _, link := range ind.ChildInFamilies {
+		if link.FamilyXRef == fam {
+			return true
+		}
+	}
+	return false
+}
+
+// repointFamilyMember changes the family's member links of the given tag
+// (CHIL, HUSB, or WIFE) from one individual to another and returns the
+// number of raw links changed, or of typed links if the record has no
+// raw tags.
+func repointFamilyMember(record *gedcom.Record, tag, from, to string) int {
+	n := 0
+	for _, t := range record.Tags {
+		if t.Level == 1 && t.Tag == tag && t.Value == from {
+			t.Value = to
+			n++
+		}
+	}
+	fam, ok := record.Entity.(*gedcom.Family)
+	if !ok {
+		return n
+	}
+	typed := 0
+	switch tag {
+	case "CHIL":
+		for i, child := range fam.Children {
+			if child == from {
+				fam.Children[i] = to
+				typed++
+			}
+		}
+	case "HUSB":
+		if fam.Husband == from {
+			fam.SetHusband(to)
+			typed++
+		}
+	case "WIFE":
+		if fam.Wife == from {
+			fam.SetWife(to)
+			typed++
+		}
+	}
+	if len(record.Tags) == 0 {
+		return typed
+	}
+	return n
+}
+
+// unusedIndividualXRef returns the first "@I<n>@" XRef not used by any
+// record of doc, compared case-insensitively.
+func unusedIndividualXRef(doc *gedcom.Document) string {
+	used := make(map[string]bool, len(doc.Records))
+	for _, record := range doc.Records {
+		if record != nil && record.XRef != "" {
+			used[strings.ToUpper(record.XRef)] = true
+		}
+	}
+	for n := 1; ; n++ {
+		xref := fmt.Sprintf("@I%d@", n)
+		if !used[xref] {
+			return xref
+		}
+	}
+}
+
+// newUID returns a random (version 4) UUID, the form GEDCOM 7.0 recommends
+// for UID values.
+func newUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
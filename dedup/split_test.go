@@ -0,0 +1,162 @@
+package dedup_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/dedup"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func mergedPersonDoc(t *testing.T) *gedcom.Document {
+	t.Helper()
+	return decode(t,
+		"0 @I1@ INDI",
+		"1 NAME John /Smith/",
+		"1 NAME John Henry /Smith/",
+		"1 SEX M",
+		"1 BIRT",
+		"2 DATE 1850",
+		"1 BIRT",
+		"2 DATE 1872",
+		"1 OCCU Farmer",
+		"1 OCCU Clerk",
+		"1 SOUR @S1@",
+		"1 SOUR @S2@",
+		"1 FAMC @F1@",
+		"1 FAMS @F2@",
+		"1 _CUSTOM kept",
+		"0 @I2@ INDI",
+		"1 NAME Mary /Smith/",
+		"1 FAMS @F2@",
+		"0 @F1@ FAM",
+		"1 CHIL @I1@",
+		"0 @F2@ FAM",
+		"1 HUSB @I1@",
+		"1 WIFE @I2@",
+		"0 @S1@ SOUR",
+		"1 TITL Census 1860",
+		"0 @S2@ SOUR",
+		"1 TITL Census 1880",
+	)
+}
+
+func TestSplitIndividual(t *testing.T) {
+	doc := mergedPersonDoc(t)
+	out, report, err := dedup.SplitIndividual(doc, "@I1@", dedup.SplitPartition{
+		Names:            []int{1},
+		Events:           []int{1},
+		Attributes:       []int{1},
+		SourceCitations:  []int{1},
+		SpouseInFamilies: []string{"@F2@"},
+	})
+	if err != nil {
+		t.Fatalf("SplitIndividual() error = %v", err)
+	}
+
+	if report.Original != "@I1@" || report.New != "@I3@" {
+		t.Errorf("report = %+v", report)
+	}
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(report.UID) {
+		t.Errorf("UID = %q, want a version 4 UUID", report.UID)
+	}
+	if report.RepointedReferences != 1 {
+		t.Errorf("RepointedReferences = %d, want 1", report.RepointedReferences)
+	}
+	if len(doc.GetIndividual("@I1@").Names) != 2 {
+		t.Error("input document was mutated")
+	}
+
+	orig := out.GetIndividual("@I1@")
+	split := out.GetIndividual("@I3@")
+	if split == nil {
+		t.Fatal("new individual @I3@ missing")
+	}
+	if len(orig.Names) != 1 || orig.Names[0].Full != "John /Smith/" {
+		t.Errorf("original names = %v", orig.Names)
+	}
+	if len(split.Names) != 1 || split.Names[0].Full != "John Henry /Smith/" {
+		t.Errorf("split names = %v", split.Names)
+	}
+	if len(orig.Events) != 1 || orig.Events[0].Date != "1850" || len(split.Events) != 1 || split.Events[0].Date != "1872" {
+		t.Errorf("events = %v / %v", orig.Events, split.Events)
+	}
+	if split.Sex != "M" || split.UID != report.UID {
+		t.Errorf("split Sex = %q, UID = %q", split.Sex, split.UID)
+	}
+	if len(orig.ChildInFamilies) != 1 || len(split.ChildInFamilies) != 0 {
+		t.Errorf("FAMC = %v / %v", orig.ChildInFamilies, split.ChildInFamilies)
+	}
+	if len(orig.SpouseInFamilies) != 0 || len(split.SpouseInFamilies) != 1 {
+		t.Errorf("FAMS = %v / %v", orig.SpouseInFamilies, split.SpouseInFamilies)
+	}
+	if got := out.GetFamily("@F2@").Husband; got != "@I3@" {
+		t.Errorf("F2 husband = %q, want @I3@", got)
+	}
+	if got := out.GetFamily("@F1@").Children; len(got) != 1 || got[0] != "@I1@" {
+		t.Errorf("F1 children = %v", got)
+	}
+
+	text := encode(t, out)
+	wantOrder := []string{
+		"0 @I1@ INDI", "1 NAME John /Smith/", "1 SEX M", "2 DATE 1850", "1 OCCU Farmer", "1 SOUR @S1@", "1 FAMC @F1@", "1 _CUSTOM kept",
+		"0 @I3@ INDI", "1 NAME John Henry /Smith/", "1 SEX M", "2 DATE 1872", "1 OCCU Clerk", "1 SOUR @S2@", "1 FAMS @F2@", "1 UID " + report.UID,
+		"0 @I2@ INDI",
+	}
+	pos := 0
+	for _, want := range wantOrder {
+		i := strings.Index(text[pos:], want+"\n")
+		if i < 0 {
+			t.Fatalf("output missing %q after offset %d:\n%s", want, pos, text)
+		}
+		pos += i + len(want)
+	}
+}
+
+// TestSplitIndividual_Spouses moves a husband's family to the new record
+// and checks the family's Spouses follow HUSB.
+func TestSplitIndividual_Spouses(t *testing.T) {
+	doc := decode(t,
+		"0 @I1@ INDI",
+		"1 FAMS @F1@",
+		"0 @I2@ INDI",
+		"1 FAMS @F1@",
+		"0 @F1@ FAM",
+		"1 HUSB @I1@",
+		"1 WIFE @I2@",
+	)
+	out, _, err := dedup.SplitIndividual(doc, "@I1@", dedup.SplitPartition{SpouseInFamilies: []string{"@F1@"}})
+	if err != nil {
+		t.Fatalf("SplitIndividual() error = %v", err)
+	}
+	if got := out.GetFamily("@F1@").Partners(); strings.Join(got, " ") != "@I3@ @I2@" {
+		t.Errorf("Partners() = %v, want [@I3@ @I2@]", got)
+	}
+	if got := encode(t, out); strings.Contains(got, "HUSB @I1@") || strings.Count(got, "HUSB") != 1 {
+		t.Errorf("encoded output has a stale HUSB:\n%s", got)
+	}
+}
+
+func TestSplitIndividual_Errors(t *testing.T) {
+	doc := mergedPersonDoc(t)
+	tests := []struct {
+		name      string
+		doc       *gedcom.Document
+		xref      string
+		partition dedup.SplitPartition
+	}{
+		{"nil document", nil, "@I1@", dedup.SplitPartition{Names: []int{0}}},
+		{"empty partition", doc, "@I1@", dedup.SplitPartition{}},
+		{"not an individual", doc, "@F1@", dedup.SplitPartition{Names: []int{0}}},
+		{"name out of range", doc, "@I1@", dedup.SplitPartition{Names: []int{2}}},
+		{"unknown family link", doc, "@I1@", dedup.SplitPartition{ChildInFamilies: []string{"@F2@"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := dedup.SplitIndividual(tt.doc, tt.xref, tt.partition); err == nil {
+				t.Error("SplitIndividual() error = nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package encoder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// ErrCardinality is wrapped by the error returned when
+// EncodeOptions.StrictCardinality is set and a record repeats a tag more
+// often than the GEDCOM grammar allows.
+var ErrCardinality = errors.New("tag repeated beyond its maximum cardinality")
+
+// outputVersion returns the GEDCOM version the output is written as.
+func outputVersion(header *gedcom.Header, opts *EncodeOptions) gedcom.Version {
+	if opts.TargetVersion != "" {
+		return opts.TargetVersion
+	}
+	if header != nil {
+		return header.Version
+	}
+	return ""
+}
+
+// checkCardinality checks the tags that would be written for the header
+// and records. The header is only written from its raw tags when
+// preserving formatting; otherwise it is generated without repeats.
+func checkCardinality(header *gedcom.Header, records []*gedcom.Record, opts *EncodeOptions) error {
+	version := outputVersion(header, opts)
+	if opts.PreserveFormatting && header != nil {
+		if err := cardinalityError(gedcom.CheckTagCardinality(version, "HEAD", header.Tags)); err != nil {
+			return err
+		}
+	}
+	for _, record := range records {
+		if err := checkRecordCardinality(record, version, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRecordCardinality checks the tags that would be written for record.
+func checkRecordCardinality(record *gedcom.Record, version gedcom.Version, opts *EncodeOptions) error {
+	if record == nil {
+		return nil
+	}
+	tags, _ := recordTags(record, opts)
+	violations := gedcom.CheckTagCardinality(version, string(record.Type), tags)
+	for i := range violations {
+		violations[i].XRef = record.XRef
+	}
+	return cardinalityError(violations)
+}
+
+// cardinalityError describes the first violation, noting how many more
+// there are, or returns nil if there are none.
+func cardinalityError(violations []gedcom.CardinalityViolation) error {
+	switch len(violations) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("%w: %s", ErrCardinality, violations[0])
+	default:
+		return fmt.Errorf("%w: %s (and %d more)", ErrCardinality, violations[0], len(violations)-1)
+	}
+}
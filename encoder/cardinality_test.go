@@ -0,0 +1,108 @@
+package encoder
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// repeatedSexDocument has an individual with two SEX lines and two GIVN
+// name pieces, which only GEDCOM 5.5.1 limits to one.
+func repeatedSexDocument() *gedcom.Document {
+	return &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version551, Encoding: gedcom.EncodingUTF8},
+		Records: []*gedcom.Record{
+			{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Tags: []*gedcom.Tag{
+				{Level: 1, Tag: "NAME", Value: "John /Smith/"},
+				{Level: 2, Tag: "GIVN", Value: "John"},
+				{Level: 2, Tag: "GIVN", Value: "Jack"},
+				{Level: 1, Tag: "SEX", Value: "M"},
+				{Level: 1, Tag: "SEX", Value: "F"},
+			}},
+		},
+	}
+}
+
+func TestEncode_StrictCardinality(t *testing.T) {
+	doc := repeatedSexDocument()
+
+	// By default repeated singletons are written as they are.
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if strings.Count(buf.String(), "1 SEX") != 2 {
+		t.Errorf("output = %s", buf.String())
+	}
+
+	opts := DefaultOptions()
+	opts.StrictCardinality = true
+	buf.Reset()
+	err := EncodeWithOptions(&buf, doc, opts)
+	if !errors.Is(err, ErrCardinality) {
+		t.Fatalf("EncodeWithOptions(StrictCardinality) error = %v, want ErrCardinality", err)
+	}
+	if !strings.Contains(err.Error(), "@I1@: INDI.NAME.GIVN appears 2 times") || !strings.Contains(err.Error(), "and 1 more") {
+		t.Errorf("error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("output written despite the error: %q", buf.String())
+	}
+
+	// The shared strictness implies StrictCardinality.
+	strict := DefaultOptions()
+	strict.Strictness = gedcom.StrictnessStrict
+	if err := EncodeWithOptions(io.Discard, doc, strict); !errors.Is(err, ErrCardinality) {
+		t.Errorf("EncodeWithOptions(StrictnessStrict) error = %v, want ErrCardinality", err)
+	}
+
+	// GEDCOM 7.0 allows repeated name pieces, but not a second SEX.
+	opts.TargetVersion = gedcom.Version70
+	err = EncodeWithOptions(&buf, doc, opts)
+	if err == nil || strings.Contains(err.Error(), "GIVN") || !strings.Contains(err.Error(), "INDI.SEX") {
+		t.Errorf("7.0 error = %v", err)
+	}
+
+	doc.Records[0].Tags = doc.Records[0].Tags[:4]
+	opts.TargetVersion = ""
+	if err := EncodeWithOptions(&buf, doc, opts); !errors.Is(err, ErrCardinality) {
+		t.Errorf("5.5.1 error = %v, want the GIVN violation", err)
+	}
+	opts.TargetVersion = gedcom.Version70
+	buf.Reset()
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Errorf("EncodeWithOptions(valid 7.0) error = %v", err)
+	}
+}
+
+func TestStreamEncoder_StrictCardinality(t *testing.T) {
+	doc := repeatedSexDocument()
+	opts := DefaultOptions()
+	opts.StrictCardinality = true
+
+	var buf bytes.Buffer
+	enc := NewStreamEncoderWithOptions(&buf, opts)
+	if err := enc.WriteHeader(doc.Header); err != nil {
+		t.Fatalf("WriteHeader() error = %v", err)
+	}
+	if err := enc.WriteRecord(doc.Records[0]); !errors.Is(err, ErrCardinality) {
+		t.Fatalf("WriteRecord() error = %v, want ErrCardinality", err)
+	}
+	valid := &gedcom.Record{XRef: "@I2@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Individual{XRef: "@I2@", Sex: "F"}}
+	if err := enc.WriteRecord(valid); err != nil {
+		t.Fatalf("WriteRecord(valid) error = %v", err)
+	}
+	if err := enc.WriteTrailer(); err != nil {
+		t.Fatalf("WriteTrailer() error = %v", err)
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "@I1@") || !strings.Contains(buf.String(), "0 @I2@ INDI") {
+		t.Errorf("output = %s", buf.String())
+	}
+}
@@ -0,0 +1,80 @@
+package encoder
+
+import "github.com/cacack/gedcom-go/v2/gedcom"
+
+// CompatibilityTarget names a genealogy product whose GEDCOM import the
+// encoder output should be tuned for. See EncodeOptions.CompatibilityTarget.
+type CompatibilityTarget string
+
+const (
+	// TargetNone applies no product preset (the default).
+	TargetNone CompatibilityTarget = ""
+
+	// TargetFamilySearch tunes output for FamilySearch Family Tree import:
+	// GEDCOM 5.5.1, CRLF line endings, no BOM, EMAIL, and CONC splits that
+	// never fall next to a space.
+	TargetFamilySearch CompatibilityTarget = "familysearch"
+
+	// TargetAncestry tunes output for Ancestry tree upload: GEDCOM 5.5.1,
+	// CRLF line endings, a UTF-8 BOM (as Ancestry's own exports carry),
+	// EMAIL, and CONC splits that never fall next to a space.
+	TargetAncestry CompatibilityTarget = "ancestry"
+
+	// TargetGramps tunes output for Gramps: GEDCOM 5.5.1, LF line endings,
+	// no BOM, EMAIL, and word-boundary CONC splits (Gramps keeps the
+	// spaces around a CONC boundary).
+	TargetGramps CompatibilityTarget = "gramps"
+
+	// TargetLegacy9 tunes output for Legacy Family Tree 9: GEDCOM 5.5.1,
+	// CRLF line endings, a UTF-8 BOM, the _EMAIL extension tag Legacy
+	// reads and writes, and CONC splits that never fall next to a space.
+	TargetLegacy9 CompatibilityTarget = "legacy9"
+)
+
+// compatibilityPreset is the set of options a CompatibilityTarget sets.
+type compatibilityPreset struct {
+	version      gedcom.Version
+	lineEnding   string
+	writeBOM     bool
+	emailTag     string
+	splitMidWord bool
+}
+
+// compatibilityPresets holds the documented import quirks of each target.
+// Many products trim trailing whitespace from every line, so a CONC split
+// next to a space silently joins two words; GEDCOM 5.5.1 itself advises
+// splitting mid-word for that reason.
+var compatibilityPresets = map[CompatibilityTarget]compatibilityPreset{
+	TargetFamilySearch: {version: gedcom.Version551, lineEnding: "\r\n", emailTag: "EMAIL", splitMidWord: true},
+	TargetAncestry:     {version: gedcom.Version551, lineEnding: "\r\n", writeBOM: true, emailTag: "EMAIL", splitMidWord: true},
+	TargetGramps:       {version: gedcom.Version551, lineEnding: "\n", emailTag: "EMAIL"},
+	TargetLegacy9:      {version: gedcom.Version551, lineEnding: "\r\n", writeBOM: true, emailTag: "_EMAIL", splitMidWord: true},
+}
+
+// CompatibilityTargets returns the supported targets other than TargetNone,
+// in a stable order.
+func CompatibilityTargets() []CompatibilityTarget {
+	return []CompatibilityTarget{TargetFamilySearch, TargetAncestry, TargetGramps, TargetLegacy9}
+}
+
+// resolved returns the options to encode with: opts itself when it names
+// no compatibility target, otherwise a copy with the target's preset
+// applied. A nil opts resolves to DefaultOptions.
+func (opts *EncodeOptions) resolved() *EncodeOptions {
+	if opts == nil {
+		return DefaultOptions()
+	}
+	preset, ok := compatibilityPresets[opts.CompatibilityTarget]
+	if !ok {
+		return opts
+	}
+	out := *opts
+	out.LineEnding = preset.lineEnding
+	out.WriteBOM = preset.writeBOM
+	out.EmailTag = preset.emailTag
+	out.SplitMidWord = preset.splitMidWord
+	if out.TargetVersion == "" {
+		out.TargetVersion = preset.version
+	}
+	return &out
+}
@@ -0,0 +1,118 @@
+package encoder
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func compatDocument() *gedcom.Document {
+	return &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version70, Encoding: "UTF-8"},
+		Records: []*gedcom.Record{
+			{XRef: "@U1@", Type: gedcom.RecordTypeSubmitter, Tags: []*gedcom.Tag{
+				{Level: 1, Tag: "NAME", Value: "Jane Doe"},
+				{Level: 1, Tag: "EMAIL", Value: "jane@example.com"},
+			}},
+		},
+	}
+}
+
+func TestEncode_CompatibilityTargets(t *testing.T) {
+	tests := []struct {
+		target     CompatibilityTarget
+		lineEnding string
+		bom        bool
+		email      string
+	}{
+		{TargetFamilySearch, "\r\n", false, "EMAIL"},
+		{TargetAncestry, "\r\n", true, "EMAIL"},
+		{TargetGramps, "\n", false, "EMAIL"},
+		{TargetLegacy9, "\r\n", true, "_EMAIL"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.target), func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.CompatibilityTarget = tt.target
+			var buf bytes.Buffer
+			if err := EncodeWithOptions(&buf, compatDocument(), opts); err != nil {
+				t.Fatalf("EncodeWithOptions() error = %v", err)
+			}
+			out := buf.String()
+			if got := strings.HasPrefix(out, "\uFEFF"); got != tt.bom {
+				t.Errorf("BOM = %v, want %v", got, tt.bom)
+			}
+			out = strings.TrimPrefix(out, "\uFEFF")
+			if !strings.HasPrefix(out, "0 HEAD"+tt.lineEnding+"1 GEDC"+tt.lineEnding+"2 VERS 5.5.1"+tt.lineEnding) {
+				t.Errorf("header = %q", out)
+			}
+			if !strings.Contains(out, "1 "+tt.email+" jane@example.com"+tt.lineEnding) {
+				t.Errorf("email tag not written as %s:\n%q", tt.email, out)
+			}
+			if tt.lineEnding == "\n" && strings.Contains(out, "\r") {
+				t.Error("unexpected CR in LF output")
+			}
+		})
+	}
+	if opts := DefaultOptions(); opts.CompatibilityTarget != TargetNone || opts.WriteBOM || opts.EmailTag != "" {
+		t.Error("DefaultOptions() should not apply a compatibility preset")
+	}
+	if len(CompatibilityTargets()) != len(compatibilityPresets) {
+		t.Error("CompatibilityTargets() does not list every preset")
+	}
+}
+
+func TestEncode_CompatibilityTargetKeepsExplicitVersion(t *testing.T) {
+	opts := DefaultOptions()
+	opts.CompatibilityTarget = TargetGramps
+	opts.TargetVersion = gedcom.Version55
+	var buf bytes.Buffer
+	if err := EncodeWithOptions(&buf, compatDocument(), opts); err != nil {
+		t.Fatalf("EncodeWithOptions() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "2 VERS 5.5\n") {
+		t.Errorf("explicit TargetVersion overridden:\n%s", buf.String())
+	}
+	if opts.LineEnding != "\n" || opts.EmailTag != "" {
+		t.Error("resolving a preset mutated the caller's options")
+	}
+}
+
+func TestEncodeStreaming_CompatibilityTarget(t *testing.T) {
+	opts := DefaultOptions()
+	opts.CompatibilityTarget = TargetLegacy9
+	var buf bytes.Buffer
+	if err := EncodeStreamingWithOptions(&buf, compatDocument(), opts); err != nil {
+		t.Fatalf("EncodeStreamingWithOptions() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "\uFEFF0 HEAD\r\n") || !strings.Contains(buf.String(), "1 _EMAIL ") {
+		t.Errorf("streaming output ignores preset:\n%q", buf.String())
+	}
+}
+
+func TestSplitLineForLength_MidWord(t *testing.T) {
+	opts := &EncodeOptions{MaxLineLength: 10, SplitMidWord: true}
+	line := "alpha beta gamma delta"
+	segments := splitLineForLength(line, opts)
+	if strings.Join(segments, "") != line {
+		t.Fatalf("segments %q do not rejoin to the line", segments)
+	}
+	for i, seg := range segments {
+		if len(seg) > 10 {
+			t.Errorf("segment %d %q longer than 10", i, seg)
+		}
+		if i > 0 && (strings.HasPrefix(seg, " ") || strings.HasSuffix(segments[i-1], " ")) {
+			t.Errorf("split next to a space between %q and %q", segments[i-1], seg)
+		}
+	}
+
+	utf := splitLineForLength("ééééééééé", &EncodeOptions{MaxLineLength: 5, SplitMidWord: true})
+	for _, seg := range utf {
+		if !utf8.ValidString(seg) {
+			t.Errorf("segment %q splits a UTF-8 sequence", seg)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package encoder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// gzipDocument has a note long enough to be split with CONC, and non-ASCII
+// text straddling the split point.
+func gzipDocument() *gedcom.Document {
+	note := strings.Repeat("Größe ", 100)
+	return &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version551, Encoding: gedcom.EncodingUTF8},
+		Records: []*gedcom.Record{
+			{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Individual{
+				XRef:        "@I1@",
+				Names:       []*gedcom.PersonalName{{Full: "Jürgen /Müller/"}},
+				InlineNotes: []string{note},
+			}},
+		},
+	}
+}
+
+func gunzip(t *testing.T, data []byte) []byte {
+	t.Helper()
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	out, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("reading gzip stream: %v", err)
+	}
+	return out
+}
+
+func TestEncode_Gzip(t *testing.T) {
+	opts := DefaultOptions()
+	opts.WriteBOM = true
+	opts.LineEnding = "\r\n"
+	opts.MaxLineLength = 50
+
+	var plain bytes.Buffer
+	if err := EncodeWithOptions(&plain, gzipDocument(), opts); err != nil {
+		t.Fatalf("EncodeWithOptions() error = %v", err)
+	}
+	if !strings.Contains(plain.String(), "CONC") {
+		t.Fatal("test document was not split with CONC")
+	}
+
+	opts.Gzip = true
+	var compressed bytes.Buffer
+	if err := EncodeWithOptions(&compressed, gzipDocument(), opts); err != nil {
+		t.Fatalf("EncodeWithOptions(Gzip) error = %v", err)
+	}
+	if !bytes.HasPrefix(compressed.Bytes(), []byte{0x1f, 0x8b}) {
+		t.Fatal("output is not a gzip stream")
+	}
+	// The BOM and CONC splits are inside the stream, unchanged.
+	if got := gunzip(t, compressed.Bytes()); !bytes.Equal(got, plain.Bytes()) {
+		t.Errorf("decompressed output differs from uncompressed output:\n%q\n%q", got, plain.Bytes())
+	}
+
+	doc, err := decoder.Decode(bytes.NewReader(compressed.Bytes()))
+	if err != nil {
+		t.Fatalf("Decode(gzip) error = %v", err)
+	}
+	ind := doc.GetIndividual("@I1@")
+	if ind == nil || len(ind.InlineNotes) != 1 || ind.InlineNotes[0] != gzipDocument().Records[0].Entity.(*gedcom.Individual).InlineNotes[0] {
+		t.Errorf("round-tripped note = %v", ind)
+	}
+}
+
+func TestStreamEncoder_Gzip(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Gzip = true
+	doc := gzipDocument()
+
+	var buf bytes.Buffer
+	enc := NewStreamEncoderWithOptions(&buf, opts)
+	if err := enc.WriteHeader(doc.Header); err != nil {
+		t.Fatalf("WriteHeader() error = %v", err)
+	}
+	if err := enc.WriteRecord(doc.Records[0]); err != nil {
+		t.Fatalf("WriteRecord() error = %v", err)
+	}
+	// After Flush, everything written so far can be decompressed.
+	if err := enc.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	partial, _ := io.ReadAll(zr) // unexpected EOF: the stream is not finished
+	if !strings.Contains(string(partial), "0 @I1@ INDI") {
+		t.Errorf("flushed output = %q", partial)
+	}
+
+	if err := enc.WriteTrailer(); err != nil {
+		t.Fatalf("WriteTrailer() error = %v", err)
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	var want bytes.Buffer
+	plain := DefaultOptions()
+	if err := EncodeWithOptions(&want, doc, plain); err != nil {
+		t.Fatalf("EncodeWithOptions() error = %v", err)
+	}
+	if got := gunzip(t, buf.Bytes()); !bytes.Equal(got, want.Bytes()) {
+		t.Errorf("streamed output differs from Encode:\n%s\n%s", got, want.Bytes())
+	}
+}
@@ -0,0 +1,200 @@
+package encoder
+
+import (
+	"errors"
+	"io"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// Delta file layout. A delta is an ordinary GEDCOM file holding the header
+// and the added and changed records of the modified document, followed by
+// one extension record listing the XRefs of removed records:
+//
+//	0 _DELTA
+//	1 _DEL @I7@
+const (
+	// DeltaRecordTag is the tag of the record listing removed records.
+	DeltaRecordTag = "_DELTA"
+
+	// DeltaRemoveTag is the tag, under DeltaRecordTag, whose value is the
+	// XRef of a removed record.
+	DeltaRemoveTag = "_DEL"
+)
+
+// Delta lists the record-level differences between two documents, by XRef.
+type Delta struct {
+	// Added are the records only in the modified document, in its order.
+	Added []string
+
+	// Changed are the records in both documents whose GEDCOM text differs,
+	// in the modified document's order.
+	Changed []string
+
+	// Removed are the records only in the base document, in its order.
+	Removed []string
+
+	// Context are unchanged records written by EncodeDelta because an
+	// added or changed record, or the header, points to them.
+	Context []string
+}
+
+// IsEmpty reports whether the documents have the same records.
+func (d *Delta) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// DiffRecords compares the records of base and modified by XRef. Two
+// records are the same when EncodeRecord writes the same text for them in
+// the modified document's version, so a change to the raw tags or to the
+// typed entity of a record counts. Records without an XRef are not
+// compared. Context is left empty.
+func DiffRecords(base, modified *gedcom.Document) (*Delta, error) {
+	if base == nil || modified == nil {
+		return nil, errors.New("base and modified documents are required")
+	}
+	version := documentVersion(modified)
+
+	baseText, err := recordTexts(base, version)
+	if err != nil {
+		return nil, err
+	}
+
+	delta := &Delta{}
+	seen := make(map[string]bool, len(modified.Records))
+	for _, record := range modified.Records {
+		if record == nil || record.XRef == "" || seen[record.XRef] {
+			continue
+		}
+		seen[record.XRef] = true
+		old, ok := baseText[record.XRef]
+		if !ok {
+			delta.Added = append(delta.Added, record.XRef)
+			continue
+		}
+		text, err := EncodeRecord(record, version)
+		if err != nil {
+			return nil, err
+		}
+		if text != old {
+			delta.Changed = append(delta.Changed, record.XRef)
+		}
+	}
+	for _, record := range base.Records {
+		if record != nil && record.XRef != "" && !seen[record.XRef] {
+			seen[record.XRef] = true
+			delta.Removed = append(delta.Removed, record.XRef)
+		}
+	}
+	return delta, nil
+}
+
+// recordTexts returns the text EncodeRecord writes in version for the
+// first record of each XRef of doc.
+func recordTexts(doc *gedcom.Document, version gedcom.Version) (map[string]string, error) {
+	texts := make(map[string]string, len(doc.Records))
+	for _, record := range doc.Records {
+		if record == nil || record.XRef == "" {
+			continue
+		}
+		if _, dup := texts[record.XRef]; dup {
+			continue
+		}
+		text, err := EncodeRecord(record, version)
+		if err != nil {
+			return nil, err
+		}
+		texts[record.XRef] = text
+	}
+	return texts, nil
+}
+
+// EncodeDelta writes an update file that turns base into modified: the
+// header of modified, its added and changed records, the unchanged
+// records they or the header point to so the file has no dangling
+// pointers, and a DeltaRecordTag record listing the removed records. The
+// records are written in the modified document's order. Apply the file
+// with merge.ApplyDelta.
+//
+// It returns the differences found; nothing but the header, the removal
+// record, and the trailer is written when there are none.
+func EncodeDelta(w io.Writer, base, modified *gedcom.Document, opts *EncodeOptions) (*Delta, error) {
+	delta, err := DiffRecords(base, modified)
+	if err != nil {
+		return nil, err
+	}
+
+	include := make(map[string]bool)
+	for _, xref := range delta.Added {
+		include[xref] = true
+	}
+	for _, xref := range delta.Changed {
+		include[xref] = true
+	}
+	context := deltaContext(modified, include)
+
+	var records []*gedcom.Record
+	for _, record := range modified.Records {
+		if record == nil || record.XRef == "" {
+			continue
+		}
+		switch {
+		case include[record.XRef]:
+			include[record.XRef] = false
+			records = append(records, record)
+		case context[record.XRef]:
+			context[record.XRef] = false
+			delta.Context = append(delta.Context, record.XRef)
+			records = append(records, record)
+		}
+	}
+
+	removals := &gedcom.Record{Type: gedcom.RecordType(DeltaRecordTag)}
+	for _, xref := range delta.Removed {
+		removals.Tags = append(removals.Tags, &gedcom.Tag{Level: 1, Tag: DeltaRemoveTag, Value: xref})
+	}
+	records = append(records, removals)
+
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	if err := encodeRecords(w, modified.Header, modified.Schema, records, nil, nil, opts); err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
+
+// deltaContext returns the XRefs of the records of modified, other than
+// those included, that the header or the included records point to.
+func deltaContext(modified *gedcom.Document, include map[string]bool) map[string]bool {
+	context := make(map[string]bool)
+	addContext := func(xref string) {
+		if !include[xref] && modified.GetRecord(xref) != nil {
+			context[xref] = true
+		}
+	}
+	if modified.Header != nil {
+		if modified.Header.Submitter != "" {
+			addContext(modified.Header.Submitter)
+		}
+		for _, tag := range modified.Header.Tags {
+			if tag != nil && gedcom.IsPointerXRef(tag.Value) {
+				addContext(tag.Value)
+			}
+		}
+	}
+	for _, record := range modified.Records {
+		if record != nil && include[record.XRef] {
+			gedcom.Visit(record, addContext)
+		}
+	}
+	return context
+}
+
+// documentVersion returns the header version of doc, or "" if it has none.
+func documentVersion(doc *gedcom.Document) gedcom.Version {
+	if doc.Header == nil || !doc.Header.Version.IsValid() {
+		return ""
+	}
+	return doc.Header.Version
+}
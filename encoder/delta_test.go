@@ -0,0 +1,107 @@
+package encoder
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const deltaBase = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 SUBM @U1@
+0 @U1@ SUBM
+1 NAME Ann Archivist
+0 @I1@ INDI
+1 NAME John /Smith/
+1 SOUR @S1@
+0 @I2@ INDI
+1 NAME Mary /Jones/
+0 @I3@ INDI
+1 NAME Old /Entry/
+0 @S1@ SOUR
+1 TITL Parish Registers
+0 TRLR
+`
+
+const deltaModified = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 SUBM @U1@
+0 @U1@ SUBM
+1 NAME Ann Archivist
+0 @I1@ INDI
+1 NAME John /Smith/
+1 SOUR @S1@
+1 BIRT
+2 DATE 1850
+0 @I2@ INDI
+1 NAME Mary /Jones/
+0 @S1@ SOUR
+1 TITL Parish Registers
+0 @I4@ INDI
+1 NAME New /Person/
+0 TRLR
+`
+
+func decodeDeltaDoc(t *testing.T, text string) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestDiffRecords(t *testing.T) {
+	delta, err := DiffRecords(decodeDeltaDoc(t, deltaBase), decodeDeltaDoc(t, deltaModified))
+	if err != nil {
+		t.Fatalf("DiffRecords() error = %v", err)
+	}
+	want := &Delta{Added: []string{"@I4@"}, Changed: []string{"@I1@"}, Removed: []string{"@I3@"}}
+	if !reflect.DeepEqual(delta, want) {
+		t.Errorf("DiffRecords() = %+v, want %+v", delta, want)
+	}
+
+	same, err := DiffRecords(decodeDeltaDoc(t, deltaBase), decodeDeltaDoc(t, deltaBase))
+	if err != nil || !same.IsEmpty() {
+		t.Errorf("DiffRecords() of equal documents = %+v, %v", same, err)
+	}
+	if _, err := DiffRecords(nil, decodeDeltaDoc(t, deltaBase)); err == nil {
+		t.Error("DiffRecords(nil) should fail")
+	}
+}
+
+func TestEncodeDelta(t *testing.T) {
+	var buf bytes.Buffer
+	delta, err := EncodeDelta(&buf, decodeDeltaDoc(t, deltaBase), decodeDeltaDoc(t, deltaModified), nil)
+	if err != nil {
+		t.Fatalf("EncodeDelta() error = %v", err)
+	}
+	if want := []string{"@U1@", "@S1@"}; !reflect.DeepEqual(delta.Context, want) {
+		t.Errorf("Context = %v, want %v", delta.Context, want)
+	}
+
+	want := `0 @U1@ SUBM
+1 NAME Ann Archivist
+0 @I1@ INDI
+1 NAME John /Smith/
+1 SOUR @S1@
+1 BIRT
+2 DATE 1850
+0 @S1@ SOUR
+1 TITL Parish Registers
+0 @I4@ INDI
+1 NAME New /Person/
+0 _DELTA
+1 _DEL @I3@
+0 TRLR
+`
+	if got := buf.String(); !strings.HasPrefix(got, "0 HEAD\n") || !strings.HasSuffix(got, want) {
+		t.Errorf("EncodeDelta() wrote:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -21,17 +21,38 @@
 //	    log.Fatal(err)
 //	}
 //
+// [EncodeRecord] returns the text of a single record, for previews and
+// debugging.
+//
 // # Options
 //
 // Use [EncodeWithOptions] together with [EncodeOptions] to customize output.
 // Call [DefaultOptions] for a populated starting point.
 //
 //   - LineEnding          — "\n" (default) or "\r\n" (CRLF for legacy tooling)
-//   - MaxLineLength       — split long values with CONC when writing from typed
-//     entities (default: 248). Pre-built [gedcom.Tag] values are written verbatim.
+//   - MaxLineLength       — split long text values with CONC (default: 248),
+//     from typed entities and raw [gedcom.Tag] text payloads alike. GEDCOM 7.0
+//     output and PreserveFormatting never split.
 //   - DisableLineWrap     — disable CONC splitting entirely
-//   - TargetVersion       — override the document's GEDCOM version in output
+//   - TargetVersion       — override the document's GEDCOM version in output;
+//     7.0 output joins CONC lines, writes line breaks as CONT, drops CHAR,
+//     and fails with [ErrBannedTag] on tags 7.0 removed
 //   - PreserveUnknownTags — true (default) keeps custom _UNDERSCORE tags
+//   - WriteBOM            — prefix the output with a UTF-8 byte order mark
+//   - EmailTag            — write EMAIL and _EMAIL tags under one spelling
+//   - SplitMidWord        — CONC splits between non-space characters
+//   - PreserveFormatting  — write the blank lines, comments, line endings,
+//     and BOM kept by decoder.DecodeOptions.PreserveFormatting
+//   - Gzip                — compress the output as a .ged.gz stream
+//   - StrictCardinality   — fail with [ErrCardinality] instead of writing
+//     repeated singleton tags (two SEX lines, two HUSB lines, ...)
+//   - Strictness          — shared [gedcom.Strictness]; StrictnessStrict
+//     implies StrictCardinality
+//   - Encoding            — CHAR value; [gedcom.EncodingANSEL] converts the
+//     output to ANSEL for readers that reject UTF-8
+//   - CompatibilityTarget — product preset ([TargetFamilySearch],
+//     [TargetAncestry], [TargetGramps], [TargetLegacy9]) that sets the
+//     options above plus LineEnding and, when unset, TargetVersion
 //
 // Example with CRLF line endings:
 //
@@ -39,4 +60,38 @@
 //	if err := encoder.EncodeWithOptions(f, doc, opts); err != nil {
 //	    log.Fatal(err)
 //	}
+//
+// # Dry Runs
+//
+// [Plan] reports what encoding with a set of options would drop (header
+// fields the encoder does not write, custom tags removed by
+// PreserveUnknownTags), alter (renamed email tags, a changed header
+// version), or write in a form readers may mishandle (embedded line breaks,
+// over-long lines, 7.0-only features under a 5.5.1 header), without writing
+// anything:
+//
+//	report := encoder.Plan(doc, opts)
+//	if report.HasLoss() {
+//	    fmt.Print(report)
+//	}
+//
+// # Restricted Views
+//
+// [EncodeView] writes a [gedcom.View], the RESN-filtered snapshot returned
+// by [gedcom.Document.View], so confidential records can be withheld from
+// an export without copying the document again.
+//
+// # Delta Files
+//
+// [EncodeDelta] writes only the records added or changed between a base
+// and a modified document, with the records they point to and a list of
+// removed records, as an update file that merge.ApplyDelta applies to the
+// base. [DiffRecords] reports the same differences without writing.
+//
+// # Record Manifests
+//
+// When the two copies live on different machines, [BuildManifest] lists
+// each record's UID, XRef, and [RecordHash] as JSON-encodable data, and
+// [CompareManifests] pairs two manifests to report which records the other
+// side needs, so a sync layer exchanges manifests before any records.
 package encoder
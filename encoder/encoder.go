@@ -1,37 +1,132 @@
 package encoder
 
 import (
+	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"sort"
 	"strings"
 
+	"github.com/cacack/gedcom-go/v2/charset"
 	"github.com/cacack/gedcom-go/v2/gedcom"
 )
 
-// Encode writes a GEDCOM document to a writer.
+// Encode writes a GEDCOM document to a writer. Output is buffered
+// internally, so w need not be.
 func Encode(w io.Writer, doc *gedcom.Document) error {
 	return EncodeWithOptions(w, doc, DefaultOptions())
 }
 
 // EncodeWithOptions writes a GEDCOM document with custom options.
 func EncodeWithOptions(w io.Writer, doc *gedcom.Document, opts *EncodeOptions) error {
-	if opts == nil {
-		opts = DefaultOptions()
+	return encodeRecords(w, doc.Header, doc.Schema, doc.Records, doc.Trailer, doc.Formatting, opts)
+}
+
+// EncodeView writes a restriction-filtered view of a document (see
+// gedcom.Document.View) without copying it.
+func EncodeView(w io.Writer, view *gedcom.View, opts *EncodeOptions) error {
+	return encodeRecords(w, view.Header(), view.Schema(), view.Records(), nil, nil, opts)
+}
+
+// EncodeRecord returns the GEDCOM text of a single record, exactly as
+// EncodeWithOptions would write it into a document of the given version:
+// raw tags verbatim, typed entities with the usual CONC/CONT splitting and
+// tag order, and LF line endings. It is meant for previews, clipboard copy,
+// and debugging. An empty version encodes with the default options. Like
+// EncodeWithOptions, it fails with an error wrapping ErrBannedTag on tags
+// GEDCOM 7.0 removed when version is 7.0.
+func EncodeRecord(record *gedcom.Record, version gedcom.Version) (string, error) {
+	if record == nil {
+		return "", errors.New("record is nil")
+	}
+	if version != "" && !version.IsValid() {
+		return "", fmt.Errorf("unsupported GEDCOM version %q", version)
+	}
+	opts := DefaultOptions()
+	opts.TargetVersion = version
+	opts = opts.forVersion(version)
+	if opts.rejectsBannedTags() {
+		if err := bannedTagError(recordBannedTags(record, opts)); err != nil {
+			return "", err
+		}
+	}
+	var sb strings.Builder
+	if err := writeRecord(&sb, record, opts); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// encodeRecords writes the header, records, and trailer of a document.
+// The schema, trailer, and formatting may be nil; the trailer and
+// formatting are only consulted when preserving formatting.
+func encodeRecords(w io.Writer, header *gedcom.Header, schema *gedcom.SchemaDefinition, records []*gedcom.Record, trailer *gedcom.Trailer, formatting *gedcom.Formatting, opts *EncodeOptions) error {
+	opts = opts.resolved()
+	if opts.Gzip {
+		zw := gzip.NewWriter(w)
+		uncompressed := *opts
+		uncompressed.Gzip = false
+		if err := encodeRecords(zw, header, schema, records, trailer, formatting, &uncompressed); err != nil {
+			return err
+		}
+		return zw.Close()
+	}
+	if opts.PreserveFormatting && formatting != nil {
+		preserved := *opts
+		preserved.LineEnding = formatting.LineEnding
+		preserved.WriteBOM = formatting.BOM
+		opts = &preserved
 	}
+	opts = opts.forVersion(outputVersion(header, opts))
 
+	if opts.strictCardinality() {
+		if err := checkCardinality(header, records, opts); err != nil {
+			return err
+		}
+	}
+	if opts.rejectsBannedTags() {
+		if err := checkBannedTags(header, records, opts); err != nil {
+			return err
+		}
+	}
+	if err := checkEncoding(outputVersion(header, opts), opts); err != nil {
+		return err
+	}
+	if opts.Encoding == gedcom.EncodingANSEL {
+		aw := charset.NewANSELWriter(w)
+		if err := writeBuffered(aw, func(bw io.Writer) error {
+			return writeDocument(bw, header, schema, records, trailer, formatting, opts)
+		}); err != nil {
+			return err
+		}
+		return aw.Flush()
+	}
+	return writeBuffered(w, func(bw io.Writer) error {
+		return writeDocument(bw, header, schema, records, trailer, formatting, opts)
+	})
+}
+
+// writeDocument writes the header, records, and trailer of a document with
+// resolved options.
+func writeDocument(w io.Writer, header *gedcom.Header, schema *gedcom.SchemaDefinition, records []*gedcom.Record, trailer *gedcom.Trailer, formatting *gedcom.Formatting, opts *EncodeOptions) error {
 	// Write header
-	if err := writeHeader(w, doc.Header, opts); err != nil {
+	if err := writeHeader(w, withSubmitter(header, records, opts), schema, opts); err != nil {
 		return err
 	}
 
 	// Write records
-	for _, record := range doc.Records {
+	for _, record := range records {
 		if err := writeRecord(w, record, opts); err != nil {
 			return err
 		}
 	}
 
 	// Write trailer
+	if opts.PreserveFormatting && trailer != nil {
+		return writePreservedTrailer(w, trailer, formatting != nil && formatting.NoFinalLineEnding, opts)
+	}
 	if err := writeTrailer(w, opts); err != nil {
 		return err
 	}
@@ -39,11 +134,28 @@ func EncodeWithOptions(w io.Writer, doc *gedcom.Document, opts *EncodeOptions) e
 	return nil
 }
 
-func writeHeader(w io.Writer, header *gedcom.Header, opts *EncodeOptions) error {
-	if _, err := fmt.Fprintf(w, "0 HEAD%s", opts.LineEnding); err != nil {
+func writeHeader(w io.Writer, header *gedcom.Header, schema *gedcom.SchemaDefinition, opts *EncodeOptions) error {
+	if opts.writesBOM() {
+		if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+			return err
+		}
+	}
+	if opts.PreserveFormatting && len(header.Tags) > 0 {
+		return writePreservedHeader(w, header, opts)
+	}
+	if err := writeLine(w, 0, "", "HEAD", "", opts.LineEnding); err != nil {
 		return err
 	}
 
+	if err := writeHeaderVersion(w, header, schema, opts); err != nil {
+		return err
+	}
+	return writeHeaderFields(w, header, opts)
+}
+
+// writeHeaderVersion writes the GEDC, CHAR, and SCHMA structures of the
+// header, which depend on the version written.
+func writeHeaderVersion(w io.Writer, header *gedcom.Header, schema *gedcom.SchemaDefinition, opts *EncodeOptions) error {
 	// Use TargetVersion if set, otherwise use header.Version
 	version := header.Version
 	if opts.TargetVersion != "" {
@@ -51,28 +163,49 @@ func writeHeader(w io.Writer, header *gedcom.Header, opts *EncodeOptions) error
 	}
 
 	if version != "" {
-		if _, err := fmt.Fprintf(w, "1 GEDC%s", opts.LineEnding); err != nil {
+		if err := writeLine(w, 1, "", "GEDC", "", opts.LineEnding); err != nil {
 			return err
 		}
-		if _, err := fmt.Fprintf(w, "2 VERS %s%s", version, opts.LineEnding); err != nil {
+		if err := writeLine(w, 2, "", "VERS", string(version), opts.LineEnding); err != nil {
 			return err
 		}
 	}
 
-	if header.Encoding != "" {
-		if _, err := fmt.Fprintf(w, "1 CHAR %s%s", header.Encoding, opts.LineEnding); err != nil {
+	// GEDCOM 7.0 files are always UTF-8 and have no CHAR
+	if encoding := outputEncoding(header, opts); encoding != "" && !opts.gedcom7 {
+		if err := writeLine(w, 1, "", "CHAR", string(encoding), opts.LineEnding); err != nil {
 			return err
 		}
 	}
 
-	if header.SourceSystem != "" {
-		if _, err := fmt.Fprintf(w, "1 SOUR %s%s", header.SourceSystem, opts.LineEnding); err != nil {
+	if version == gedcom.Version70 {
+		return writeSchema(w, schema, opts)
+	}
+	return nil
+}
+
+// writeHeaderFields writes the SOUR, SUBM, LANG, and PLAC.FORM structures
+// of the header that are set.
+func writeHeaderFields(w io.Writer, header *gedcom.Header, opts *EncodeOptions) error {
+	fields := []struct{ tag, value string }{
+		{"SOUR", header.SourceSystem},
+		{"SUBM", header.Submitter},
+		{"LANG", header.Language},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		if err := writeLine(w, 1, "", field.tag, field.value, opts.LineEnding); err != nil {
 			return err
 		}
 	}
 
-	if header.Language != "" {
-		if _, err := fmt.Fprintf(w, "1 LANG %s%s", header.Language, opts.LineEnding); err != nil {
+	if header.PlaceForm != "" {
+		if err := writeLine(w, 1, "", "PLAC", "", opts.LineEnding); err != nil {
+			return err
+		}
+		if err := writeLine(w, 2, "", "FORM", header.PlaceForm, opts.LineEnding); err != nil {
 			return err
 		}
 	}
@@ -80,16 +213,60 @@ func writeHeader(w io.Writer, header *gedcom.Header, opts *EncodeOptions) error
 	return nil
 }
 
-func writeRecord(w io.Writer, record *gedcom.Record, opts *EncodeOptions) error {
-	// Determine the tags to write and the level-0 line value together:
-	// - If record.Tags has content, use those (preserves lossless behavior) and the
-	//   stored record.Value.
-	// - If record.Tags is empty/nil but Entity is set, convert the entity to tags.
-	//   Some records (NOTE, SNOTE) carry text on the level-0 line; when record.Value
-	//   is empty, derive that value (and, for SNOTE, its CONT/CONC continuation) from
-	//   the entity so a hand-built note's text is not lost.
-	tags := record.Tags
-	value := record.Value
+// writeSchema writes the HEAD.SCHMA structure of a GEDCOM 7.0 document,
+// one TAG line per extension tag in tag order, or nothing if schema
+// declares no tags.
+func writeSchema(w io.Writer, schema *gedcom.SchemaDefinition, opts *EncodeOptions) error {
+	if schema == nil || len(schema.TagMappings) == 0 {
+		return nil
+	}
+	tags := make([]string, 0, len(schema.TagMappings))
+	for tag := range schema.TagMappings {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	if err := writeLine(w, 1, "", "SCHMA", "", opts.LineEnding); err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if err := writeLine(w, 2, "", "TAG", tag+" "+schema.TagMappings[tag], opts.LineEnding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withSubmitter returns header with Submitter pointing at the first
+// submitter record when it has none, so the written header carries the
+// SUBM link GEDCOM 5.5.x requires. A header written from its raw Tags
+// under PreserveFormatting is returned unchanged.
+func withSubmitter(header *gedcom.Header, records []*gedcom.Record, opts *EncodeOptions) *gedcom.Header {
+	if header.Submitter != "" || (opts.PreserveFormatting && len(header.Tags) > 0) {
+		return header
+	}
+	for _, record := range records {
+		if record != nil && record.Type == gedcom.RecordTypeSubmitter && record.XRef != "" {
+			linked := *header
+			linked.Submitter = record.XRef
+			return &linked
+		}
+	}
+	return header
+}
+
+// recordTags returns the tags and level-0 line value to write for record:
+//   - If record.Tags has content, use those (preserves lossless behavior) and the
+//     stored record.Value.
+//   - If record.Tags is empty/nil but Entity is set, convert the entity to tags.
+//     Some records (NOTE, SNOTE) carry text on the level-0 line; when record.Value
+//     is empty, derive that value (and, for SNOTE, its CONT/CONC continuation) from
+//     the entity so a hand-built note's text is not lost.
+//
+// Custom tags are filtered out if PreserveUnknownTags is false.
+func recordTags(record *gedcom.Record, opts *EncodeOptions) (tags []*gedcom.Tag, value string) {
+	tags = record.Tags
+	value = record.Value
 	if len(tags) == 0 && record.Entity != nil {
 		tags = entityToTags(record, opts)
 		if value == "" {
@@ -98,53 +275,60 @@ func writeRecord(w io.Writer, record *gedcom.Record, opts *EncodeOptions) error
 			tags = append(contTags, tags...)
 		}
 	}
+	return filterTags(tags, opts.PreserveUnknownTags), value
+}
+
+func writeRecord(w io.Writer, record *gedcom.Record, opts *EncodeOptions) error {
+	tags, value := recordTags(record, opts)
+	if opts.gedcom7 {
+		value, tags = gedcom7Tags(value, tags)
+	} else if opts.wrapsRawLines() {
+		value, tags = wrapLongLines(value, tags, string(record.Type), opts)
+	}
 
-	// Write record line
-	if record.XRef != "" {
-		if value != "" {
-			if _, err := fmt.Fprintf(w, "0 %s %s %s%s", record.XRef, record.Type, value, opts.LineEnding); err != nil {
-				return err
-			}
-		} else {
-			if _, err := fmt.Fprintf(w, "0 %s %s%s", record.XRef, record.Type, opts.LineEnding); err != nil {
-				return err
-			}
-		}
-	} else {
-		if _, err := fmt.Fprintf(w, "0 %s%s", record.Type, opts.LineEnding); err != nil {
+	if opts.PreserveFormatting && len(record.Trivia) > 0 {
+		if err := writeTrivia(w, record.Trivia, 0, 0, opts); err != nil {
 			return err
 		}
 	}
 
-	// Filter out custom tags if PreserveUnknownTags is false
-	tags = filterTags(tags, opts.PreserveUnknownTags)
+	// Write record line; a record without an XRef has no value
+	if record.XRef == "" {
+		value = ""
+	}
+	if err := writeLine(w, 0, record.XRef, string(record.Type), value, opts.LineEnding); err != nil {
+		return err
+	}
 
-	// Write tags
-	for _, tag := range tags {
+	// Write tags, with any preserved blank and comment lines between them
+	preserve := opts.PreserveFormatting && len(record.Trivia) > 0
+	for i, tag := range tags {
+		if preserve {
+			if err := writeTrivia(w, record.Trivia, i+1, i+1, opts); err != nil {
+				return err
+			}
+		}
 		if err := writeTag(w, tag, opts); err != nil {
 			return err
 		}
 	}
+	if preserve {
+		return writeTrivia(w, record.Trivia, len(tags)+1, math.MaxInt, opts)
+	}
 
 	return nil
 }
 
 func writeTag(w io.Writer, tag *gedcom.Tag, opts *EncodeOptions) error {
-	if tag.Value != "" {
-		if _, err := fmt.Fprintf(w, "%d %s %s%s", tag.Level, tag.Tag, tag.Value, opts.LineEnding); err != nil {
-			return err
-		}
-	} else {
-		if _, err := fmt.Fprintf(w, "%d %s%s", tag.Level, tag.Tag, opts.LineEnding); err != nil {
-			return err
-		}
+	name := tag.Tag
+	if opts.EmailTag != "" && (name == "EMAIL" || name == "_EMAIL") {
+		name = opts.EmailTag
 	}
-	return nil
+	return writeLine(w, tag.Level, "", name, tag.Value, opts.LineEnding)
 }
 
 func writeTrailer(w io.Writer, opts *EncodeOptions) error {
-	_, err := fmt.Fprintf(w, "0 TRLR%s", opts.LineEnding)
-	return err
+	return writeLine(w, 0, "", "TRLR", "", opts.LineEnding)
 }
 
 // isCustomTag returns true if the tag name is a custom/extension tag.
@@ -355,6 +355,26 @@ func TestEncodeHeaderFields(t *testing.T) {
 				"1 LANG French",
 			},
 		},
+		{
+			name: "header with submitter",
+			header: &gedcom.Header{
+				SourceSystem: "TestApp",
+				Submitter:    "@U1@",
+			},
+			want: []string{
+				"1 SOUR TestApp\n1 SUBM @U1@",
+			},
+		},
+		{
+			name: "header with place form",
+			header: &gedcom.Header{
+				PlaceForm: "City, County, State, Country",
+			},
+			want: []string{
+				"0 HEAD",
+				"1 PLAC\n2 FORM City, County, State, Country",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -379,6 +399,50 @@ func TestEncodeHeaderFields(t *testing.T) {
 	}
 }
 
+func TestEncodeHeaderSubmitterLink(t *testing.T) {
+	records := []*gedcom.Record{
+		{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Individual{XRef: "@I1@"}},
+		{XRef: "@U1@", Type: gedcom.RecordTypeSubmitter, Entity: &gedcom.Submitter{XRef: "@U1@", Name: "Jane"}},
+		{XRef: "@U2@", Type: gedcom.RecordTypeSubmitter, Entity: &gedcom.Submitter{XRef: "@U2@", Name: "John"}},
+	}
+	encode := func(doc *gedcom.Document, opts *EncodeOptions) string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+			t.Fatalf("EncodeWithOptions() error = %v", err)
+		}
+		return buf.String()
+	}
+
+	header := &gedcom.Header{Version: gedcom.Version551}
+	out := encode(&gedcom.Document{Header: header, Records: records}, nil)
+	if !strings.Contains(out, "1 SUBM @U1@\n") {
+		t.Errorf("missing link to first submitter:\n%s", out)
+	}
+	if header.Submitter != "" {
+		t.Errorf("Encode modified Header.Submitter to %q", header.Submitter)
+	}
+
+	out = encode(&gedcom.Document{Header: &gedcom.Header{Version: gedcom.Version551, Submitter: "@U2@"}, Records: records}, nil)
+	if strings.Count(out, "1 SUBM ") != 1 || !strings.Contains(out, "1 SUBM @U2@\n") {
+		t.Errorf("explicit submitter not kept:\n%s", out)
+	}
+
+	out = encode(&gedcom.Document{Header: &gedcom.Header{Version: gedcom.Version551}, Records: records[:1]}, nil)
+	if strings.Contains(out, "1 SUBM") {
+		t.Errorf("SUBM written without a submitter:\n%s", out)
+	}
+
+	preserved := &gedcom.Header{
+		Version: gedcom.Version551,
+		Tags:    []*gedcom.Tag{{Level: 1, Tag: "GEDC"}, {Level: 2, Tag: "VERS", Value: "5.5.1"}},
+	}
+	out = encode(&gedcom.Document{Header: preserved, Records: records}, &EncodeOptions{PreserveFormatting: true})
+	if strings.Contains(out, "1 SUBM") {
+		t.Errorf("preserved header gained a SUBM:\n%s", out)
+	}
+}
+
 func TestEncodeRecords(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -614,15 +678,22 @@ func TestEncodeWriteErrors(t *testing.T) {
 		{"fail on trailer", 8},
 	}
 
+	// Encode buffers its output, so write each line straight to the
+	// failing writer to reach every error path.
+	opts := DefaultOptions().resolved()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			w := &failWriter{failAfter: tt.failAfter}
-			err := Encode(w, doc)
+			err := writeDocument(w, doc.Header, nil, doc.Records, nil, nil, opts)
 			if err == nil {
-				t.Error("Expected error from Encode(), got nil")
+				t.Error("Expected error from writeDocument(), got nil")
 			}
 		})
 	}
+
+	if err := Encode(&failWriter{}, doc); err == nil {
+		t.Error("Expected error from Encode() when flushing, got nil")
+	}
 }
 
 func TestDefaultOptions(t *testing.T) {
@@ -1499,12 +1570,13 @@ func TestRoundtripComplexIndividual(t *testing.T) {
 					SourceCitations: []*gedcom.SourceCitation{
 						{SourceXRef: "@S1@", Page: "Entire file"},
 					},
-					Notes:        []string{"Famous composer"},
-					Media:        []*gedcom.MediaLink{{MediaXRef: "@O1@", Title: "Portrait"}},
-					ChangeDate:   &gedcom.ChangeDate{Date: "1 JAN 2024", Time: "12:00:00"},
-					CreationDate: &gedcom.ChangeDate{Date: "1 JAN 2020"},
-					RefNumber:    "REF001",
-					UID:          "UID-BEETHOVEN",
+					Notes:             []string{"Famous composer"},
+					Media:             []*gedcom.MediaLink{{MediaXRef: "@O1@", Title: "Portrait"}},
+					ChangeDate:        &gedcom.ChangeDate{Date: "1 JAN 2024", Time: "12:00:00"},
+					CreationDate:      &gedcom.ChangeDate{Date: "1 JAN 2020"},
+					RefNumber:         "REF001",
+					AutomatedRecordID: "1770",
+					UID:               "UID-BEETHOVEN",
 				},
 			},
 		},
@@ -1571,6 +1643,7 @@ func TestRoundtripComplexIndividual(t *testing.T) {
 		"3 TIME 12:00:00",
 		"1 CREA",
 		"1 REFN REF001",
+		"1 RIN 1770",
 		"1 UID UID-BEETHOVEN",
 	}
 
@@ -1770,6 +1843,35 @@ func TestEncodeTargetVersion(t *testing.T) {
 	})
 }
 
+// TestEncodeSchema tests that GEDCOM 7.0 output declares the document's
+// extension tags in HEAD.SCHMA.
+func TestEncodeSchema(t *testing.T) {
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version70, Encoding: "UTF-8"},
+		Schema: &gedcom.SchemaDefinition{TagMappings: map[string]string{
+			"_SKYPEID": "http://xmlns.com/foaf/0.1/skypeID",
+			"_MILT":    "https://example.com/gedcom/extensions/_MILT",
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeWithOptions(&buf, doc, &EncodeOptions{LineEnding: "\n"}); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want := "1 SCHMA\n2 TAG _MILT https://example.com/gedcom/extensions/_MILT\n2 TAG _SKYPEID http://xmlns.com/foaf/0.1/skypeID\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("output should declare tags in order, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := EncodeWithOptions(&buf, doc, &EncodeOptions{LineEnding: "\n", TargetVersion: gedcom.Version551}); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "SCHMA") {
+		t.Errorf("5.5.1 output should have no SCHMA, got:\n%s", buf.String())
+	}
+}
+
 // TestEncodePreserveUnknownTags tests the PreserveUnknownTags option.
 func TestEncodePreserveUnknownTags(t *testing.T) {
 	doc := &gedcom.Document{
@@ -1974,3 +2076,39 @@ func TestIsCustomTag(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeRecord(t *testing.T) {
+	raw := &gedcom.Record{XRef: "@N1@", Type: gedcom.RecordTypeNote, Value: "Raw note", Tags: []*gedcom.Tag{
+		{Level: 1, Tag: "CONT", Value: "second line"},
+	}}
+	got, err := EncodeRecord(raw, gedcom.Version551)
+	if err != nil {
+		t.Fatalf("EncodeRecord() error = %v", err)
+	}
+	if want := "0 @N1@ NOTE Raw note\n1 CONT second line\n"; got != want {
+		t.Errorf("EncodeRecord(raw) = %q, want %q", got, want)
+	}
+
+	indi := &gedcom.Record{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Individual{
+		XRef:        "@I1@",
+		Names:       []*gedcom.PersonalName{{Full: "John /Smith/"}},
+		Sex:         "M",
+		InlineNotes: []string{"first\n" + strings.Repeat("x", 300)},
+	}}
+	got, err = EncodeRecord(indi, "")
+	if err != nil {
+		t.Fatalf("EncodeRecord() error = %v", err)
+	}
+	for _, want := range []string{"0 @I1@ INDI\n1 NAME John /Smith/\n", "1 SEX M\n", "1 NOTE first\n2 CONT x", "\n2 CONC x"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("EncodeRecord(entity) missing %q:\n%s", want, got)
+		}
+	}
+
+	if _, err := EncodeRecord(nil, gedcom.Version70); err == nil {
+		t.Error("EncodeRecord(nil) error = nil")
+	}
+	if _, err := EncodeRecord(raw, "6.0"); err == nil {
+		t.Error("EncodeRecord(version 6.0) error = nil")
+	}
+}
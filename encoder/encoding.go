@@ -0,0 +1,71 @@
+package encoder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/charset"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// ErrUnsupportedEncoding is returned, wrapped, when EncodeOptions.Encoding
+// names a character set the encoder cannot write, or one the output version
+// does not allow.
+var ErrUnsupportedEncoding = errors.New("unsupported output encoding")
+
+// checkEncoding reports whether opts.Encoding can be written for version.
+func checkEncoding(version gedcom.Version, opts *EncodeOptions) error {
+	switch opts.Encoding {
+	case "", gedcom.EncodingUTF8, gedcom.EncodingUNICODE:
+		return nil
+	case gedcom.EncodingANSEL:
+		if version == gedcom.Version70 {
+			return fmt.Errorf("%w: GEDCOM 7.0 files must be UTF-8, not %s", ErrUnsupportedEncoding, opts.Encoding)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrUnsupportedEncoding, opts.Encoding)
+}
+
+// outputEncoding returns the character set the header declares: the
+// Encoding option when set, otherwise the header's own.
+func outputEncoding(header *gedcom.Header, opts *EncodeOptions) gedcom.Encoding {
+	if opts.Encoding != "" {
+		return opts.Encoding
+	}
+	if header != nil {
+		return header.Encoding
+	}
+	return ""
+}
+
+// writesBOM reports whether a UTF-8 byte order mark is written, which it
+// never is before ANSEL output.
+func (opts *EncodeOptions) writesBOM() bool {
+	return opts.WriteBOM && opts.Encoding != gedcom.EncodingANSEL
+}
+
+// planEncoding notes the characters of value that the output encoding
+// cannot represent.
+func planEncoding(report *PlanReport, path, value string, opts *EncodeOptions) {
+	if opts.Encoding != gedcom.EncodingANSEL {
+		return
+	}
+	unmappable := charset.ANSELUnmappable(value)
+	if len(unmappable) == 0 {
+		return
+	}
+	encoded, _ := charset.EncodeANSEL(value)
+	result, _ := charset.DecodeANSEL(encoded)
+	chars := make([]string, len(unmappable))
+	for i, r := range unmappable {
+		chars[i] = fmt.Sprintf("%q (U+%04X)", r, r)
+	}
+	report.Altered = append(report.Altered, gedcom.ConversionNote{
+		Path:     path,
+		Original: value,
+		Result:   result,
+		Reason:   fmt.Sprintf("ANSEL cannot represent %s; written as %q", strings.Join(chars, ", "), charset.ANSELReplacement),
+	})
+}
@@ -0,0 +1,222 @@
+package encoder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const anselInput = "0 HEAD\n" +
+	"1 GEDC\n" +
+	"2 VERS 5.5\n" +
+	"1 CHAR UTF-8\n" +
+	"0 @I1@ INDI\n" +
+	"1 NAME René /Müller/\n" +
+	"1 NOTE Born in Łódź — or so he said\n" +
+	"0 TRLR\n"
+
+const anselOutput = "0 HEAD\n" +
+	"1 GEDC\n" +
+	"2 VERS 5.5\n" +
+	"1 CHAR ANSEL\n" +
+	"0 @I1@ INDI\n" +
+	"1 NAME Ren\xe2e /M\xe8uller/\n" +
+	"1 NOTE Born in \xa1\xe2od\xe2z ? or so he said\n" +
+	"0 TRLR\n"
+
+func decodeANSELInput(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(anselInput))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestEncodeWithOptions_ANSEL(t *testing.T) {
+	doc := decodeANSELInput(t)
+	opts := DefaultOptions()
+	opts.Encoding = gedcom.EncodingANSEL
+	opts.WriteBOM = true // never written before ANSEL
+
+	var buf bytes.Buffer
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Fatalf("EncodeWithOptions() error = %v", err)
+	}
+	if buf.String() != anselOutput {
+		t.Errorf("output =\n%q\nwant\n%q", buf.String(), anselOutput)
+	}
+
+	// The ANSEL output decodes back to the original text
+	back, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode(ANSEL) error = %v", err)
+	}
+	if back.Header.Encoding != gedcom.EncodingANSEL {
+		t.Errorf("decoded CHAR = %q, want ANSEL", back.Header.Encoding)
+	}
+	if got := back.GetIndividual("@I1@").Names[0].Full; got != "René /Müller/" {
+		t.Errorf("decoded NAME = %q", got)
+	}
+}
+
+func TestEncodeWithOptions_ANSELPreserveFormatting(t *testing.T) {
+	doc, err := decoder.DecodeWithOptions(strings.NewReader(anselInput), &decoder.DecodeOptions{PreserveFormatting: true})
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	opts := DefaultOptions()
+	opts.PreserveFormatting = true
+	opts.Encoding = gedcom.EncodingANSEL
+	var buf bytes.Buffer
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Fatalf("EncodeWithOptions() error = %v", err)
+	}
+	if buf.String() != anselOutput {
+		t.Errorf("output =\n%q\nwant\n%q", buf.String(), anselOutput)
+	}
+
+	// A header without CHAR gains one
+	doc.Header.Tags = doc.Header.Tags[:2]
+	buf.Reset()
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Fatalf("EncodeWithOptions() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "0 HEAD\n1 GEDC\n2 VERS 5.5\n1 CHAR ANSEL\n0 @I1@") {
+		t.Errorf("output without CHAR =\n%s", buf.String())
+	}
+}
+
+func TestEncodeWithOptions_ANSELGzip(t *testing.T) {
+	doc := decodeANSELInput(t)
+	opts := DefaultOptions()
+	opts.Encoding = gedcom.EncodingANSEL
+	opts.Gzip = true
+	var buf bytes.Buffer
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Fatalf("EncodeWithOptions() error = %v", err)
+	}
+	zr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	got, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(got) != anselOutput {
+		t.Errorf("output =\n%q\nwant\n%q", got, anselOutput)
+	}
+}
+
+func TestEncodeWithOptions_Encoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding gedcom.Encoding
+		version  gedcom.Version
+		wantErr  bool
+		wantCHAR string
+	}{
+		{"default keeps header", "", "", false, "1 CHAR UTF-8\n"},
+		{"UTF-8", gedcom.EncodingUTF8, "", false, "1 CHAR UTF-8\n"},
+		{"UNICODE relabels only", gedcom.EncodingUNICODE, "", false, "1 CHAR UNICODE\n"},
+		{"ANSEL for 5.5.1", gedcom.EncodingANSEL, gedcom.Version551, false, "1 CHAR ANSEL\n"},
+		{"ANSEL for 7.0", gedcom.EncodingANSEL, gedcom.Version70, true, ""},
+		{"LATIN1", gedcom.EncodingLATIN1, "", true, ""},
+		{"unknown", "EBCDIC", "", true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := decodeANSELInput(t)
+			opts := DefaultOptions()
+			opts.Encoding = tt.encoding
+			opts.TargetVersion = tt.version
+			var buf bytes.Buffer
+			err := EncodeWithOptions(&buf, doc, opts)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupportedEncoding) {
+					t.Errorf("EncodeWithOptions() error = %v, want ErrUnsupportedEncoding", err)
+				}
+				if buf.Len() != 0 {
+					t.Errorf("output written before the error: %q", buf.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EncodeWithOptions() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.wantCHAR) {
+				t.Errorf("output lacks %q:\n%s", tt.wantCHAR, buf.String())
+			}
+		})
+	}
+}
+
+func TestStreamEncoder_ANSEL(t *testing.T) {
+	doc := decodeANSELInput(t)
+	opts := DefaultOptions()
+	opts.Encoding = gedcom.EncodingANSEL
+	var buf bytes.Buffer
+	if err := EncodeStreamingWithOptions(&buf, doc, opts); err != nil {
+		t.Fatalf("EncodeStreamingWithOptions() error = %v", err)
+	}
+	if buf.String() != anselOutput {
+		t.Errorf("output =\n%q\nwant\n%q", buf.String(), anselOutput)
+	}
+
+	// Flush writes every complete line
+	buf.Reset()
+	enc := NewStreamEncoderWithOptions(&buf, opts)
+	if err := enc.WriteHeader(doc.Header); err != nil {
+		t.Fatalf("WriteHeader() error = %v", err)
+	}
+	if err := enc.WriteRecord(doc.Records[0]); err != nil {
+		t.Fatalf("WriteRecord() error = %v", err)
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if want := strings.TrimSuffix(anselOutput, "0 TRLR\n"); buf.String() != want {
+		t.Errorf("after Flush =\n%q\nwant\n%q", buf.String(), want)
+	}
+
+	// An unsupported encoding fails at WriteHeader
+	opts.TargetVersion = gedcom.Version70
+	enc = NewStreamEncoderWithOptions(io.Discard, opts)
+	if err := enc.WriteHeader(doc.Header); !errors.Is(err, ErrUnsupportedEncoding) {
+		t.Errorf("WriteHeader() error = %v, want ErrUnsupportedEncoding", err)
+	}
+}
+
+func TestPlan_Encoding(t *testing.T) {
+	doc := decodeANSELInput(t)
+	opts := DefaultOptions()
+	opts.Encoding = gedcom.EncodingANSEL
+	report := Plan(doc, opts)
+
+	if got := planPaths(report.Altered); got != "Header > CHAR; Individual @I1@ > NOTE" {
+		t.Fatalf("Altered = %q", got)
+	}
+	note := report.Altered[1]
+	if note.Result != "Born in Łódź ? or so he said" {
+		t.Errorf("Result = %q", note.Result)
+	}
+	if !strings.Contains(note.Reason, "U+2014") {
+		t.Errorf("Reason = %q", note.Reason)
+	}
+	if len(report.Warnings) != 0 {
+		t.Errorf("Warnings = %v", report.Warnings)
+	}
+
+	opts.TargetVersion = gedcom.Version70
+	report = Plan(doc, opts)
+	if !strings.Contains(planPaths(report.Warnings), "Header > CHAR") {
+		t.Errorf("7.0 Warnings = %v", report.Warnings)
+	}
+}
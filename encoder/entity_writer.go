@@ -3,6 +3,7 @@ package encoder
 import (
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/cacack/gedcom-go/v2/gedcom"
 )
@@ -110,8 +111,9 @@ func splitMatchesNotes(noteXRefs, inlineNotes, notes []string) bool {
 // Returns a slice with at least one element (the original line if no splitting needed).
 // Attempts to split at word boundaries (spaces) when possible.
 func splitLineForLength(line string, opts *EncodeOptions) []string {
-	// If line wrapping is disabled or line is short enough, return as-is
-	if opts != nil && opts.DisableLineWrap {
+	// If line wrapping is disabled or line is short enough, return as-is;
+	// GEDCOM 7.0 has no CONC, so its lines are never split
+	if opts != nil && (opts.DisableLineWrap || opts.gedcom7) {
 		return []string{line}
 	}
 
@@ -128,8 +130,12 @@ func splitLineForLength(line string, opts *EncodeOptions) []string {
 	remaining := line
 
 	for len(remaining) > maxLen {
-		// Find the best split point - prefer word boundary (space)
+		// Find the best split point - prefer word boundary (space), or
+		// the inside of a word when SplitMidWord is set
 		splitAt := findWordBoundary(remaining, maxLen)
+		if opts != nil && opts.SplitMidWord {
+			splitAt = findMidWordSplit(remaining, maxLen)
+		}
 
 		segments = append(segments, remaining[:splitAt])
 		remaining = remaining[splitAt:]
@@ -144,7 +150,8 @@ func splitLineForLength(line string, opts *EncodeOptions) []string {
 }
 
 // findWordBoundary finds the best position to split a line at or before maxLen.
-// Prefers splitting at a space (word boundary) but falls back to maxLen if no space found.
+// Prefers splitting at a space (word boundary) but falls back to maxLen, or
+// the start of the character it falls in, if no space found.
 func findWordBoundary(line string, maxLen int) int {
 	if len(line) <= maxLen {
 		return len(line)
@@ -158,7 +165,26 @@ func findWordBoundary(line string, maxLen int) int {
 		return lastSpace + 1
 	}
 
-	// No word boundary found, split at maxLen exactly
+	// No word boundary found, split at maxLen, backing off to the start of
+	// a UTF-8 sequence so no character is cut in two
+	for i := maxLen; i > 0; i-- {
+		if utf8.RuneStart(line[i]) {
+			return i
+		}
+	}
+	return maxLen
+}
+
+// findMidWordSplit finds the last position at or before maxLen with a
+// non-space character on both sides that does not fall inside a UTF-8
+// sequence, so readers that trim line ends cannot lose a space. Falls back
+// to maxLen if there is no such position.
+func findMidWordSplit(line string, maxLen int) int {
+	for i := maxLen; i > 0; i-- {
+		if line[i-1] != ' ' && line[i] != ' ' && utf8.RuneStart(line[i]) {
+			return i
+		}
+	}
 	return maxLen
 }
 
@@ -215,6 +241,11 @@ func entityToTags(record *gedcom.Record, opts *EncodeOptions) []*gedcom.Tag {
 func individualToTags(indi *gedcom.Individual, opts *EncodeOptions) []*gedcom.Tag {
 	var tags []*gedcom.Tag
 
+	// Restriction (level 1) - RESN
+	if indi.Restriction != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "RESN", Value: string(indi.Restriction)})
+	}
+
 	// Names (level 1)
 	for _, name := range indi.Names {
 		tags = append(tags, nameToTags(name, 1)...)
@@ -255,6 +286,11 @@ func individualToTags(indi *gedcom.Individual, opts *EncodeOptions) []*gedcom.Ta
 		tags = append(tags, associationToTags(assoc, 1, opts)...)
 	}
 
+	// Aliases (level 1) - ALIA
+	for _, alias := range indi.Aliases {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "ALIA", Value: alias})
+	}
+
 	// Source citations (level 1) - SOUR
 	for _, cite := range indi.SourceCitations {
 		tags = append(tags, sourceCitationToTags(cite, 1, opts)...)
@@ -267,7 +303,7 @@ func individualToTags(indi *gedcom.Individual, opts *EncodeOptions) []*gedcom.Ta
 
 	// Media links (level 1) - OBJE
 	for _, media := range indi.Media {
-		tags = append(tags, mediaLinkToTags(media, 1)...)
+		tags = append(tags, mediaLinkToTags(media, 1, opts)...)
 	}
 
 	// Change date (level 1) - CHAN
@@ -280,9 +316,12 @@ func individualToTags(indi *gedcom.Individual, opts *EncodeOptions) []*gedcom.Ta
 		tags = append(tags, changeDateToTags(indi.CreationDate, 1, "CREA")...)
 	}
 
-	// Reference number (level 1) - REFN
-	if indi.RefNumber != "" {
-		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "REFN", Value: indi.RefNumber})
+	// Reference numbers (level 1) - REFN with TYPE
+	tags = append(tags, userReferencesToTags(indi.AllUserReferences(), 1)...)
+
+	// Automated record ID (level 1) - RIN
+	if indi.AutomatedRecordID != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "RIN", Value: indi.AutomatedRecordID})
 	}
 
 	// UID (level 1)
@@ -300,6 +339,16 @@ func individualToTags(indi *gedcom.Individual, opts *EncodeOptions) []*gedcom.Ta
 		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "_FSFTID", Value: gedcom.EscapeLeadingAt(indi.FamilySearchID)})
 	}
 
+	// Research tasks (level 1) - _TODO / _RESEARCH vendor tags
+	for _, task := range indi.Tasks {
+		if task != nil {
+			tags = append(tags, task.ToTags(1)...)
+		}
+	}
+
+	// Application extensions (level 1)
+	tags = append(tags, extensionDataToTags(indi.ExtensionData, 1, opts)...)
+
 	return tags
 }
 
@@ -309,6 +358,11 @@ func individualToTags(indi *gedcom.Individual, opts *EncodeOptions) []*gedcom.Ta
 func familyToTags(fam *gedcom.Family, opts *EncodeOptions) []*gedcom.Tag {
 	var tags []*gedcom.Tag
 
+	// Restriction (level 1) - RESN
+	if fam.Restriction != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "RESN", Value: string(fam.Restriction)})
+	}
+
 	// Husband (level 1) - HUSB
 	if fam.Husband != "" {
 		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "HUSB", Value: fam.Husband})
@@ -319,6 +373,9 @@ func familyToTags(fam *gedcom.Family, opts *EncodeOptions) []*gedcom.Tag {
 		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "WIFE", Value: fam.Wife})
 	}
 
+	// Further spouses (level 1) - repeated HUSB/WIFE
+	tags = append(tags, extraSpouseTags(fam)...)
+
 	// Children (level 1) - CHIL
 	for _, child := range fam.Children {
 		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "CHIL", Value: child})
@@ -329,6 +386,11 @@ func familyToTags(fam *gedcom.Family, opts *EncodeOptions) []*gedcom.Tag {
 		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "NCHI", Value: fam.NumberOfChildren})
 	}
 
+	// Associations (level 1) - ASSO
+	for _, assoc := range fam.Associations {
+		tags = append(tags, associationToTags(assoc, 1, opts)...)
+	}
+
 	// Events (level 1) - MARR, DIV, etc.
 	for _, event := range fam.Events {
 		tags = append(tags, eventToTags(event, 1, opts)...)
@@ -351,7 +413,7 @@ func familyToTags(fam *gedcom.Family, opts *EncodeOptions) []*gedcom.Tag {
 
 	// Media links (level 1) - OBJE
 	for _, media := range fam.Media {
-		tags = append(tags, mediaLinkToTags(media, 1)...)
+		tags = append(tags, mediaLinkToTags(media, 1, opts)...)
 	}
 
 	// Change date (level 1) - CHAN
@@ -364,9 +426,12 @@ func familyToTags(fam *gedcom.Family, opts *EncodeOptions) []*gedcom.Tag {
 		tags = append(tags, changeDateToTags(fam.CreationDate, 1, "CREA")...)
 	}
 
-	// Reference number (level 1) - REFN
-	if fam.RefNumber != "" {
-		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "REFN", Value: fam.RefNumber})
+	// Reference numbers (level 1) - REFN with TYPE
+	tags = append(tags, userReferencesToTags(fam.AllUserReferences(), 1)...)
+
+	// Automated record ID (level 1) - RIN
+	if fam.AutomatedRecordID != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "RIN", Value: fam.AutomatedRecordID})
 	}
 
 	// UID (level 1)
@@ -377,6 +442,9 @@ func familyToTags(fam *gedcom.Family, opts *EncodeOptions) []*gedcom.Tag {
 	// External IDs (level 1) - EXID (GEDCOM 7.0)
 	tags = append(tags, externalIDsToTags(fam.ExternalIDs, 1)...)
 
+	// Application extensions (level 1)
+	tags = append(tags, extensionDataToTags(fam.ExtensionData, 1, opts)...)
+
 	return tags
 }
 
@@ -386,17 +454,17 @@ func sourceToTags(src *gedcom.Source, opts *EncodeOptions) []*gedcom.Tag {
 
 	// Title (level 1) - TITL
 	if src.Title != "" {
-		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "TITL", Value: src.Title})
+		tags = append(tags, textToTags(src.Title, 1, "TITL", opts)...)
 	}
 
 	// Author (level 1) - AUTH
 	if src.Author != "" {
-		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "AUTH", Value: src.Author})
+		tags = append(tags, textToTags(src.Author, 1, "AUTH", opts)...)
 	}
 
 	// Publication (level 1) - PUBL
 	if src.Publication != "" {
-		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "PUBL", Value: src.Publication})
+		tags = append(tags, textToTags(src.Publication, 1, "PUBL", opts)...)
 	}
 
 	// Text (level 1) - TEXT (with CONT/CONC for multiline/long)
@@ -404,23 +472,12 @@ func sourceToTags(src *gedcom.Source, opts *EncodeOptions) []*gedcom.Tag {
 		tags = append(tags, textToTags(src.Text, 1, "TEXT", opts)...)
 	}
 
-	// Repository link (level 1) - REPO. Prefer the structured RepositoryLink;
-	// fall back to the legacy RepositoryRef/Repository fields.
-	switch {
-	case src.RepositoryLink != nil:
-		tags = append(tags, sourceRepositoryLinkToTags(src.RepositoryLink, opts)...)
-	case src.RepositoryRef != "":
-		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "REPO", Value: src.RepositoryRef})
-	case src.Repository != nil && src.Repository.Name != "":
-		tags = append(tags,
-			&gedcom.Tag{Level: 1, Tag: "REPO"},
-			&gedcom.Tag{Level: 2, Tag: "NAME", Value: src.Repository.Name},
-		)
-	}
+	// Repository links (level 1) - REPO
+	tags = append(tags, sourceRepositoriesToTags(src, opts)...)
 
 	// Media links (level 1) - OBJE
 	for _, media := range src.Media {
-		tags = append(tags, mediaLinkToTags(media, 1)...)
+		tags = append(tags, mediaLinkToTags(media, 1, opts)...)
 	}
 
 	// Notes (level 1) - NOTE (with CONT/CONC for multiline/long)
@@ -438,9 +495,12 @@ func sourceToTags(src *gedcom.Source, opts *EncodeOptions) []*gedcom.Tag {
 		tags = append(tags, changeDateToTags(src.CreationDate, 1, "CREA")...)
 	}
 
-	// Reference number (level 1) - REFN
-	if src.RefNumber != "" {
-		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "REFN", Value: src.RefNumber})
+	// Reference numbers (level 1) - REFN with TYPE
+	tags = append(tags, userReferencesToTags(src.AllUserReferences(), 1)...)
+
+	// Automated record ID (level 1) - RIN
+	if src.AutomatedRecordID != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "RIN", Value: src.AutomatedRecordID})
 	}
 
 	// UID (level 1)
@@ -451,6 +511,30 @@ func sourceToTags(src *gedcom.Source, opts *EncodeOptions) []*gedcom.Tag {
 	// External IDs (level 1) - EXID (GEDCOM 7.0)
 	tags = append(tags, externalIDsToTags(src.ExternalIDs, 1)...)
 
+	// Application extensions (level 1)
+	tags = append(tags, extensionDataToTags(src.ExtensionData, 1, opts)...)
+
+	return tags
+}
+
+// sourceRepositoriesToTags converts the repository links of a Source to
+// GEDCOM tags. It prefers the structured RepositoryLink and RepositoryLinks
+// and falls back to the legacy RepositoryRef/Repository fields.
+func sourceRepositoriesToTags(src *gedcom.Source, opts *EncodeOptions) []*gedcom.Tag {
+	var tags []*gedcom.Tag
+	switch {
+	case src.RepositoryLink != nil || len(src.RepositoryLinks) > 0:
+		for _, link := range src.AllRepositoryLinks() {
+			tags = append(tags, sourceRepositoryLinkToTags(link, opts)...)
+		}
+	case src.RepositoryRef != "":
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "REPO", Value: src.RepositoryRef})
+	case src.Repository != nil && src.Repository.Name != "":
+		tags = append(tags,
+			&gedcom.Tag{Level: 1, Tag: "REPO"},
+			&gedcom.Tag{Level: 2, Tag: "NAME", Value: src.Repository.Name},
+		)
+	}
 	return tags
 }
 
@@ -464,7 +548,7 @@ func sourceRepositoryLinkToTags(link *gedcom.SourceRepositoryLink, opts *EncodeO
 	// subordinate data would emit a meaningless bare `1 REPO` that round-trips
 	// as an empty inline repository. Skip it entirely.
 	hasInlineName := link.Inline != nil && link.Inline.Name != ""
-	if link.XRef == "" && !hasInlineName && len(link.CallNumbers) == 0 && len(link.Notes) == 0 {
+	if link.XRef == "" && !hasInlineName && len(link.CallNumbers) == 0 && len(link.Calls) == 0 && len(link.Notes) == 0 {
 		return nil
 	}
 
@@ -478,15 +562,10 @@ func sourceRepositoryLinkToTags(link *gedcom.SourceRepositoryLink, opts *EncodeO
 	}
 
 	// Call numbers (level 2) - CALN, each with an optional MEDI (level 3).
-	for _, caln := range link.CallNumbers {
-		tags = append(tags, &gedcom.Tag{Level: 2, Tag: "CALN", Value: caln})
-		medi := link.CallNumberMedia[caln]
-		if medi == "" && len(link.CallNumbers) == 1 {
-			// Single CALN: fall back to the flat MediaType field.
-			medi = link.MediaType
-		}
-		if medi != "" {
-			tags = append(tags, &gedcom.Tag{Level: 3, Tag: "MEDI", Value: medi})
+	for _, call := range link.CallNumberEntries() {
+		tags = append(tags, &gedcom.Tag{Level: 2, Tag: "CALN", Value: call.Number})
+		if call.Media != "" {
+			tags = append(tags, &gedcom.Tag{Level: 3, Tag: "MEDI", Value: call.Media})
 		}
 	}
 
@@ -532,11 +611,19 @@ func submitterToTags(subm *gedcom.Submitter, opts *EncodeOptions) []*gedcom.Tag
 	// precedes NOTE_STRUCTURE) and the placement used by the other record writers.
 	tags = append(tags, externalIDsToTags(subm.ExternalIDs, 1)...)
 
+	// Automated record ID (level 1) - RIN
+	if subm.AutomatedRecordID != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "RIN", Value: subm.AutomatedRecordID})
+	}
+
 	// Notes (level 1) - NOTE (with CONT/CONC for multiline/long)
 	for _, note := range recordNotesToEncode(subm.NoteXRefs, subm.InlineNotes, subm.Notes) {
 		tags = append(tags, textToTags(note, 1, "NOTE", opts)...)
 	}
 
+	// Application extensions (level 1)
+	tags = append(tags, extensionDataToTags(subm.ExtensionData, 1, opts)...)
+
 	return tags
 }
 
@@ -559,11 +646,19 @@ func repositoryToTags(repo *gedcom.Repository, opts *EncodeOptions) []*gedcom.Ta
 	// precedes NOTE_STRUCTURE) and the placement used by the other record writers.
 	tags = append(tags, externalIDsToTags(repo.ExternalIDs, 1)...)
 
+	// Automated record ID (level 1) - RIN
+	if repo.AutomatedRecordID != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "RIN", Value: repo.AutomatedRecordID})
+	}
+
 	// Notes (level 1) - NOTE (with CONT/CONC for multiline/long)
 	for _, note := range recordNotesToEncode(repo.NoteXRefs, repo.InlineNotes, repo.Notes) {
 		tags = append(tags, textToTags(note, 1, "NOTE", opts)...)
 	}
 
+	// Application extensions (level 1)
+	tags = append(tags, extensionDataToTags(repo.ExtensionData, 1, opts)...)
+
 	return tags
 }
 
@@ -576,6 +671,11 @@ func noteToTags(note *gedcom.Note) []*gedcom.Tag {
 		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "CONT", Value: cont})
 	}
 
+	// Automated record ID (level 1) - RIN
+	if note.AutomatedRecordID != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "RIN", Value: note.AutomatedRecordID})
+	}
+
 	return tags
 }
 
@@ -585,7 +685,7 @@ func mediaObjectToTags(media *gedcom.MediaObject, opts *EncodeOptions) []*gedcom
 
 	// Files (level 1) - FILE
 	for _, file := range media.Files {
-		tags = append(tags, mediaFileToTags(file, 1)...)
+		tags = append(tags, mediaFileToTags(file, 1, opts)...)
 	}
 
 	// Notes (level 1) - NOTE (with CONT/CONC for multiline/long)
@@ -613,6 +713,11 @@ func mediaObjectToTags(media *gedcom.MediaObject, opts *EncodeOptions) []*gedcom
 		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "REFN", Value: refn})
 	}
 
+	// Automated record ID (level 1) - RIN
+	if media.AutomatedRecordID != "" {
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "RIN", Value: media.AutomatedRecordID})
+	}
+
 	// UIDs (level 1)
 	for _, uid := range media.UIDs {
 		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "UID", Value: uid})
@@ -623,9 +728,12 @@ func mediaObjectToTags(media *gedcom.MediaObject, opts *EncodeOptions) []*gedcom
 
 	// Restriction (level 1) - RESN
 	if media.Restriction != "" {
-		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "RESN", Value: media.Restriction})
+		tags = append(tags, &gedcom.Tag{Level: 1, Tag: "RESN", Value: string(media.Restriction)})
 	}
 
+	// Application extensions (level 1)
+	tags = append(tags, extensionDataToTags(media.ExtensionData, 1, opts)...)
+
 	return tags
 }
 
@@ -728,7 +836,7 @@ func eventToTags(event *gedcom.Event, level int, opts *EncodeOptions) []*gedcom.
 	}
 
 	if event.Cause != "" {
-		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "CAUS", Value: event.Cause})
+		tags = append(tags, textToTags(event.Cause, level+1, "CAUS", opts)...)
 	}
 
 	if event.Age != "" {
@@ -755,11 +863,11 @@ func eventToTags(event *gedcom.Event, level int, opts *EncodeOptions) []*gedcom.
 		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "FAX", Value: fax})
 	}
 	for _, www := range event.Website {
-		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "WWW", Value: www})
+		tags = append(tags, textToTags(www, level+1, "WWW", opts)...)
 	}
 
 	if event.Restriction != "" {
-		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "RESN", Value: event.Restriction})
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "RESN", Value: string(event.Restriction)})
 	}
 
 	if event.UID != "" {
@@ -770,6 +878,10 @@ func eventToTags(event *gedcom.Event, level int, opts *EncodeOptions) []*gedcom.
 		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "SDATE", Value: event.SortDate})
 	}
 
+	if event.VendorSortDate != "" {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "_SDATE", Value: event.VendorSortDate})
+	}
+
 	// Notes (with CONT/CONC for multiline/long)
 	for _, note := range event.Notes {
 		tags = append(tags, textToTags(note, level+1, "NOTE", opts)...)
@@ -782,7 +894,12 @@ func eventToTags(event *gedcom.Event, level int, opts *EncodeOptions) []*gedcom.
 
 	// Media links
 	for _, media := range event.Media {
-		tags = append(tags, mediaLinkToTags(media, level+1)...)
+		tags = append(tags, mediaLinkToTags(media, level+1, opts)...)
+	}
+
+	// Associations (witnesses, godparents, ...)
+	for _, assoc := range event.Associations {
+		tags = append(tags, associationToTags(assoc, level+1, opts)...)
 	}
 
 	return tags
@@ -793,7 +910,7 @@ func attributeToTags(attr *gedcom.Attribute, level int, opts *EncodeOptions) []*
 	var tags []*gedcom.Tag
 
 	// Attribute tag (OCCU, EDUC, etc.) with value
-	tags = append(tags, &gedcom.Tag{Level: level, Tag: attr.Type, Value: attr.Value})
+	tags = append(tags, textToTags(attr.Value, level, attr.Type, opts)...)
 
 	// Subordinate tags at level+1
 	if attr.Date != "" {
@@ -816,16 +933,42 @@ func attributeToTags(attr *gedcom.Attribute, level int, opts *EncodeOptions) []*
 func sourceCitationToTags(cite *gedcom.SourceCitation, level int, opts *EncodeOptions) []*gedcom.Tag {
 	var tags []*gedcom.Tag
 
-	// SOUR tag with source XRef
-	tags = append(tags, &gedcom.Tag{Level: level, Tag: "SOUR", Value: cite.SourceXRef})
+	// SOUR tag with source XRef, or the description of an embedded citation
+	if cite.IsInline() {
+		tags = append(tags, textToTags(cite.InlineText, level, "SOUR", opts)...)
+	} else {
+		tags = append(tags, &gedcom.Tag{Level: level, Tag: "SOUR", Value: cite.SourceXRef})
+	}
 
 	// Subordinate tags at level+1
 	if cite.Page != "" {
-		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "PAGE", Value: cite.Page})
+		tags = append(tags, textToTags(cite.Page, level+1, "PAGE", opts)...)
 	}
 
-	if cite.Quality > 0 {
-		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "QUAY", Value: strconv.Itoa(cite.Quality)})
+	// EVEN with ROLE
+	if cite.Event != "" {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "EVEN", Value: cite.Event})
+		if cite.Role != "" {
+			tags = append(tags, &gedcom.Tag{Level: level + 2, Tag: "ROLE", Value: cite.Role})
+		}
+	}
+
+	if cite.Quality > 0 || cite.HasQuality {
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "QUAY", Value: cite.Quality.String()})
+	}
+
+	// Vendor surety (e.g. _SURE)
+	if cite.Surety != "" {
+		suretyTag := cite.SuretyTag
+		if suretyTag == "" {
+			suretyTag = gedcom.SuretyTags[0]
+		}
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: suretyTag, Value: cite.Surety})
+	}
+
+	// TEXT subordinates (embedded citations)
+	for _, text := range cite.Text {
+		tags = append(tags, textToTags(text, level+1, "TEXT", opts)...)
 	}
 
 	// DATA subordinate
@@ -833,6 +976,11 @@ func sourceCitationToTags(cite *gedcom.SourceCitation, level int, opts *EncodeOp
 		tags = append(tags, sourceCitationDataToTags(cite.Data, level+1, opts)...)
 	}
 
+	// OBJE links
+	for _, media := range cite.Media {
+		tags = append(tags, mediaLinkToTags(media, level+1, opts)...)
+	}
+
 	// Ancestry APID (vendor extension)
 	if cite.AncestryAPID != nil {
 		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "_APID", Value: cite.AncestryAPID.Raw})
@@ -857,6 +1005,13 @@ func sourceCitationDataToTags(data *gedcom.SourceCitationData, level int, opts *
 	if data.Text != "" {
 		tags = append(tags, textToTags(data.Text, level+1, "TEXT", opts)...)
 	}
+	for _, text := range data.AdditionalText {
+		tags = append(tags, textToTags(text, level+1, "TEXT", opts)...)
+	}
+
+	for _, url := range data.Website {
+		tags = append(tags, textToTags(url, level+1, "WWW", opts)...)
+	}
 
 	return tags
 }
@@ -975,12 +1130,25 @@ func familyLinkToTags(link *gedcom.FamilyLink, level int) []*gedcom.Tag {
 
 	// Subordinate tags at level+1
 	if link.Pedigree != "" {
-		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "PEDI", Value: link.Pedigree})
+		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "PEDI", Value: string(link.Pedigree)})
 	}
 
 	return tags
 }
 
+// extraSpouseTags returns the HUSB and WIFE lines of fam.OtherSpouses;
+// the first HUSB and WIFE are written from Husband and Wife. ASSO
+// partners are written with the family's Associations.
+func extraSpouseTags(fam *gedcom.Family) []*gedcom.Tag {
+	var tags []*gedcom.Tag
+	for _, spouse := range fam.OtherSpouses() {
+		if spouse.XRef != "" && (spouse.Tag == "HUSB" || spouse.Tag == "WIFE") {
+			tags = append(tags, &gedcom.Tag{Level: 1, Tag: spouse.Tag, Value: spouse.XRef})
+		}
+	}
+	return tags
+}
+
 // associationToTags converts an Association to GEDCOM tags at the specified level.
 func associationToTags(assoc *gedcom.Association, level int, opts *EncodeOptions) []*gedcom.Tag {
 	var tags []*gedcom.Tag
@@ -1033,7 +1201,7 @@ func changeDateToTags(cd *gedcom.ChangeDate, level int, tagName string) []*gedco
 }
 
 // mediaLinkToTags converts a MediaLink to GEDCOM tags at the specified level.
-func mediaLinkToTags(link *gedcom.MediaLink, level int) []*gedcom.Tag {
+func mediaLinkToTags(link *gedcom.MediaLink, level int, opts *EncodeOptions) []*gedcom.Tag {
 	var tags []*gedcom.Tag
 
 	// OBJE tag with media XRef
@@ -1045,7 +1213,7 @@ func mediaLinkToTags(link *gedcom.MediaLink, level int) []*gedcom.Tag {
 	}
 
 	if link.Title != "" {
-		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "TITL", Value: link.Title})
+		tags = append(tags, textToTags(link.Title, level+1, "TITL", opts)...)
 	}
 
 	return tags
@@ -1076,7 +1244,7 @@ func cropRegionToTags(crop *gedcom.CropRegion, level int) []*gedcom.Tag {
 }
 
 // mediaFileToTags converts a MediaFile to GEDCOM tags at the specified level.
-func mediaFileToTags(file *gedcom.MediaFile, level int) []*gedcom.Tag {
+func mediaFileToTags(file *gedcom.MediaFile, level int, opts *EncodeOptions) []*gedcom.Tag {
 	var tags []*gedcom.Tag
 
 	// FILE tag with file reference
@@ -1094,7 +1262,7 @@ func mediaFileToTags(file *gedcom.MediaFile, level int) []*gedcom.Tag {
 
 	// TITL subordinate at level+1
 	if file.Title != "" {
-		tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "TITL", Value: file.Title})
+		tags = append(tags, textToTags(file.Title, level+1, "TITL", opts)...)
 	}
 
 	// Translations (TRAN)
@@ -1228,3 +1396,43 @@ func externalIDsToTags(externalIDs []*gedcom.ExternalID, level int) []*gedcom.Ta
 
 	return tags
 }
+
+// userReferencesToTags converts user reference numbers to REFN tags with
+// their TYPE subordinates at the specified level.
+func userReferencesToTags(refs []*gedcom.UserReference, level int) []*gedcom.Tag {
+	var tags []*gedcom.Tag
+	for _, ref := range refs {
+		if ref == nil || ref.Number == "" {
+			continue
+		}
+		tags = append(tags, &gedcom.Tag{Level: level, Tag: "REFN", Value: ref.Number})
+		if ref.Type != "" {
+			tags = append(tags, &gedcom.Tag{Level: level + 1, Tag: "TYPE", Value: ref.Type})
+		}
+	}
+	return tags
+}
+
+// extensionDataToTags converts ExtensionData to GEDCOM tags at the specified
+// level. Root tags are emitted in sorted order, occurrences in slice order.
+func extensionDataToTags(data gedcom.ExtensionData, level int, opts *EncodeOptions) []*gedcom.Tag {
+	var tags []*gedcom.Tag
+	for _, tag := range data.SortedTags() {
+		for _, ext := range data[tag] {
+			tags = append(tags, extensionToTags(ext, level, opts)...)
+		}
+	}
+	return tags
+}
+
+// extensionToTags converts an Extension and its children to GEDCOM tags.
+func extensionToTags(ext *gedcom.Extension, level int, opts *EncodeOptions) []*gedcom.Tag {
+	if ext == nil {
+		return nil
+	}
+	tags := textToTags(ext.Value, level, ext.Tag, opts)
+	for _, child := range ext.Children {
+		tags = append(tags, extensionToTags(child, level+1, opts)...)
+	}
+	return tags
+}
@@ -2,6 +2,7 @@ package encoder
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -806,6 +807,15 @@ func TestEventToTags(t *testing.T) {
 			level:    1,
 			contains: []string{"DEAT", "RESN", "UID", "SDATE"},
 		},
+		{
+			name: "event with vendor sort date",
+			event: &gedcom.Event{
+				Type:           gedcom.EventResidence,
+				VendorSortDate: "1 JAN 1910",
+			},
+			level:    1,
+			contains: []string{"RESI", "_SDATE"},
+		},
 		{
 			name: "event with notes and citations",
 			event: &gedcom.Event{
@@ -942,6 +952,16 @@ func TestSourceCitationToTags(t *testing.T) {
 			level:    2,
 			contains: []string{"SOUR", "DATA", "DATE", "TEXT"},
 		},
+		{
+			name: "citation with event and role",
+			cite: &gedcom.SourceCitation{
+				SourceXRef: "@S1@",
+				Event:      "BIRT",
+				Role:       "WITN",
+			},
+			level:    2,
+			contains: []string{"SOUR", "EVEN", "ROLE"},
+		},
 		{
 			name: "citation with Ancestry APID",
 			cite: &gedcom.SourceCitation{
@@ -955,6 +975,15 @@ func TestSourceCitationToTags(t *testing.T) {
 			level:    2,
 			contains: []string{"SOUR", "_APID"},
 		},
+		{
+			name: "embedded citation",
+			cite: &gedcom.SourceCitation{
+				InlineText: "Family Bible\nheld by Mary",
+				Text:       []string{"John born 1850"},
+			},
+			level:    2,
+			contains: []string{"SOUR", "CONT", "TEXT"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1306,7 +1335,7 @@ func TestMediaLinkToTags(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tags := mediaLinkToTags(tt.link, tt.level)
+			tags := mediaLinkToTags(tt.link, tt.level, nil)
 			tagMap := tagNamesToMap(tags)
 
 			for _, expected := range tt.contains {
@@ -1415,7 +1444,7 @@ func TestMediaFileToTags(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tags := mediaFileToTags(tt.file, tt.level)
+			tags := mediaFileToTags(tt.file, tt.level, nil)
 			tagMap := tagNamesToMap(tags)
 
 			for _, expected := range tt.contains {
@@ -1688,6 +1717,17 @@ func tagNamesToMap(tags []*gedcom.Tag) map[string]bool {
 	return result
 }
 
+// countTag counts the tags named name.
+func countTag(tags []*gedcom.Tag, name string) int {
+	n := 0
+	for _, tag := range tags {
+		if tag.Tag == name {
+			n++
+		}
+	}
+	return n
+}
+
 // TestTextToTags tests the textToTags helper function for CONT continuation handling
 func TestTextToTags(t *testing.T) {
 	tests := []struct {
@@ -2188,6 +2228,12 @@ func TestFindWordBoundary(t *testing.T) {
 			maxLen:   14,
 			expected: 14, // After "one two three " (index 14 is right after the space)
 		},
+		{
+			name:     "no space - backs off to a character start",
+			line:     "ééééé",
+			maxLen:   5,
+			expected: 4, // Before the third two-byte é
+		},
 	}
 
 	for _, tt := range tests {
@@ -3207,6 +3253,39 @@ func TestExternalIDsToTags(t *testing.T) {
 	}
 }
 
+func TestUserReferencesToTags(t *testing.T) {
+	refs := []*gedcom.UserReference{
+		{Number: "1042", Type: "Ahnentafel"},
+		{Number: "A-7"},
+		nil,
+		{Number: "", Type: "ignored"},
+	}
+	tags := userReferencesToTags(refs, 1)
+	want := []gedcom.Tag{
+		{Level: 1, Tag: "REFN", Value: "1042"},
+		{Level: 2, Tag: "TYPE", Value: "Ahnentafel"},
+		{Level: 1, Tag: "REFN", Value: "A-7"},
+	}
+	if len(tags) != len(want) {
+		t.Fatalf("userReferencesToTags() returned %d tags, want %d", len(tags), len(want))
+	}
+	for i, tag := range tags {
+		if tag.Level != want[i].Level || tag.Tag != want[i].Tag || tag.Value != want[i].Value {
+			t.Errorf("tag %d = %d %s %s, want %d %s %s", i, tag.Level, tag.Tag, tag.Value, want[i].Level, want[i].Tag, want[i].Value)
+		}
+	}
+
+	// The deprecated RefNumber is written only without UserReferences.
+	indi := &gedcom.Individual{RefNumber: "OLD"}
+	if tags := individualToTags(indi, nil); countTag(tags, "REFN") != 1 {
+		t.Errorf("legacy RefNumber not written: %d REFN tags", countTag(tags, "REFN"))
+	}
+	indi.UserReferences = []*gedcom.UserReference{{Number: "OLD"}, {Number: "NEW", Type: "user"}}
+	if tags := individualToTags(indi, nil); countTag(tags, "REFN") != 2 {
+		t.Errorf("got %d REFN tags, want 2", countTag(tags, "REFN"))
+	}
+}
+
 func TestEntityToTags_SharedNote(t *testing.T) {
 	record := &gedcom.Record{
 		XRef: "@SN1@",
@@ -3407,13 +3486,14 @@ func TestRoundTripSharedNoteEmptyValueCONC(t *testing.T) {
 	}
 	output := buf.String()
 
-	// "something" must appear exactly once — on the CONC line, not also folded
-	// onto the level-0 SNOTE line.
+	// "something" must appear exactly once — GEDCOM 7.0 output joins the
+	// CONC line onto the level-0 SNOTE line, which must not also gain the
+	// text derived from the entity.
 	if got := strings.Count(output, "something"); got != 1 {
 		t.Errorf("'something' appears %d times, want 1 (double-emission):\n%s", got, output)
 	}
-	if strings.Contains(output, "SNOTE something") {
-		t.Errorf("level-0 value was wrongly derived from Text:\n%s", output)
+	if !strings.Contains(output, "0 @SN1@ SNOTE something\n0 TRLR") {
+		t.Errorf("CONC was not joined onto the SNOTE line:\n%s", output)
 	}
 }
 
@@ -4392,3 +4472,508 @@ func TestRoundTripExternalIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisteredEventType_EntityRoundTrip(t *testing.T) {
+	if err := gedcom.RegisterEventType("_MILT", gedcom.EventTypeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	defer gedcom.UnregisterEventType("_MILT")
+
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version551},
+		Records: []*gedcom.Record{{
+			XRef: "@I1@",
+			Type: gedcom.RecordTypeIndividual,
+			Entity: &gedcom.Individual{
+				XRef:   "@I1@",
+				Events: []*gedcom.Event{{Type: "_MILT", Date: "1917", Place: "France"}},
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "1 _MILT\n2 DATE 1917\n2 PLAC France\n") {
+		t.Fatalf("encoded output missing _MILT event:\n%s", buf.String())
+	}
+
+	decoded, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	events := decoded.GetIndividual("@I1@").Events
+	if len(events) != 1 || events[0].Type != "_MILT" || events[0].Place != "France" {
+		t.Errorf("round-tripped events = %+v", events)
+	}
+}
+
+func TestInlineSourceCitation_RoundTrip(t *testing.T) {
+	cite := &gedcom.SourceCitation{
+		InlineText: "Family Bible\nheld by Mary",
+		Text:       []string{"John born 1850"},
+		Quality:    2,
+	}
+	tags := sourceCitationToTags(cite, 1, DefaultOptions())
+	want := []string{"1 SOUR Family Bible", "2 CONT held by Mary", "2 QUAY 2", "2 TEXT John born 1850"}
+	if len(tags) != len(want) {
+		t.Fatalf("got %d tags, want %d", len(tags), len(want))
+	}
+	for _, tag := range tags {
+		line := fmt.Sprintf("%d %s %s", tag.Level, tag.Tag, tag.Value)
+		found := false
+		for _, w := range want {
+			if w == line {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("unexpected tag %q", line)
+		}
+	}
+}
+
+func TestSourceCitationMediaAndData_EntityRoundTrip(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 CHAR UTF-8\n" +
+		"0 @I1@ INDI\n1 BIRT\n2 SOUR @S1@\n3 PAGE Year: 1880\n3 DATA\n4 DATE 1880\n" +
+		"4 TEXT Household of John\n4 TEXT Born in Ohio\n4 WWW https://search.ancestry.com/1880\n" +
+		"3 OBJE @O1@\n3 _APID 1,6742::1234\n" +
+		"0 @S1@ SOUR\n1 TITL 1880 Census\n0 @O1@ OBJE\n1 FILE census.jpg\n2 FORM jpg\n0 TRLR\n"
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	cite := doc.GetIndividual("@I1@").Events[0].SourceCitations[0]
+	if cite.Data.Text != "Household of John" || len(cite.Data.AdditionalText) != 1 || cite.Data.AdditionalText[0] != "Born in Ohio" {
+		t.Errorf("Data text = %q, %v", cite.Data.Text, cite.Data.AdditionalText)
+	}
+	if len(cite.Data.Website) != 1 || len(cite.Media) != 1 || cite.Media[0].MediaXRef != "@O1@" {
+		t.Errorf("Website = %v, Media = %v", cite.Data.Website, cite.Media)
+	}
+
+	// Encode from the entities alone, as after editing them.
+	for _, record := range doc.Records {
+		record.Tags = nil
+	}
+	if ind := doc.GetIndividual("@I1@"); ind != nil {
+		ind.Tags = nil
+	}
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want := "2 SOUR @S1@\n3 PAGE Year: 1880\n3 DATA\n4 DATE 1880\n4 TEXT Household of John\n4 TEXT Born in Ohio\n" +
+		"4 WWW https://search.ancestry.com/1880\n3 OBJE @O1@\n3 _APID 1,6742::1234\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("encoded citation missing, want\n%s\ngot\n%s", want, buf.String())
+	}
+}
+
+func TestLongValueTags_EntityRoundTrip(t *testing.T) {
+	longTitle := strings.TrimSpace(strings.Repeat("Register of baptisms and burials ", 10))
+	source := &gedcom.Source{
+		XRef:        "@S1@",
+		Title:       longTitle,
+		Author:      "Church of\nSt. Mary",
+		Publication: "Published 1901",
+		Text:        "Entry one\nEntry two",
+	}
+	indi := &gedcom.Individual{
+		XRef:       "@I1@",
+		Attributes: []*gedcom.Attribute{{Type: "OCCU", Value: "Blacksmith\nand farrier"}},
+		Events: []*gedcom.Event{{
+			Type:  gedcom.EventDeath,
+			Cause: "Fever contracted\nduring the flood",
+			SourceCitations: []*gedcom.SourceCitation{{
+				SourceXRef: "@S1@",
+				Page:       "Vol. 2\np. 17",
+			}},
+		}},
+	}
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version551},
+		Records: []*gedcom.Record{
+			{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: indi},
+			{XRef: "@S1@", Type: gedcom.RecordTypeSource, Entity: source},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	for _, want := range []string{"2 CONC ", "1 AUTH Church of\n2 CONT St. Mary\n", "2 CAUS Fever contracted\n3 CONT during the flood\n"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("encoded output missing %q:\n%s", want, buf.String())
+		}
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if len(line) > DefaultMaxLineLength+10 {
+			t.Errorf("line not split: %d chars", len(line))
+		}
+	}
+
+	decoded, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	gotSrc := decoded.GetSource("@S1@")
+	if gotSrc.Title != source.Title || gotSrc.Author != source.Author ||
+		gotSrc.Publication != source.Publication || gotSrc.Text != source.Text {
+		t.Errorf("round-tripped source = %+v", gotSrc)
+	}
+	gotIndi := decoded.GetIndividual("@I1@")
+	if gotIndi.Attributes[0].Value != "Blacksmith\nand farrier" {
+		t.Errorf("OCCU value = %q", gotIndi.Attributes[0].Value)
+	}
+	death := gotIndi.DeathEvent()
+	if death.Cause != "Fever contracted\nduring the flood" {
+		t.Errorf("Cause = %q", death.Cause)
+	}
+	if death.SourceCitations[0].Page != "Vol. 2\np. 17" {
+		t.Errorf("Page = %q", death.SourceCitations[0].Page)
+	}
+}
+
+func TestExtensionData_EntityRoundTrip(t *testing.T) {
+	err := gedcom.RegisterExtension(gedcom.ExtensionSchema{
+		Tag: "_MYAPP",
+		Children: []gedcom.ExtensionSchema{
+			{Tag: "_ID"},
+			{Tag: "_NOTE"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gedcom.UnregisterExtension("_MYAPP")
+
+	indi := &gedcom.Individual{
+		XRef: "@I1@",
+		ExtensionData: gedcom.ExtensionData{
+			"_MYAPP": {{
+				Tag: "_MYAPP",
+				Children: []*gedcom.Extension{
+					{Tag: "_ID", Value: "abc"},
+					{Tag: "_NOTE", Value: "line one\nline two"},
+				},
+			}},
+		},
+	}
+	doc := &gedcom.Document{
+		Header:  &gedcom.Header{Version: gedcom.Version551},
+		Records: []*gedcom.Record{{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: indi}},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if want := "1 _MYAPP\n2 _ID abc\n2 _NOTE line one\n3 CONT line two\n"; !strings.Contains(buf.String(), want) {
+		t.Fatalf("encoded output missing extension:\n%s", buf.String())
+	}
+
+	decoded, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	ext := decoded.GetIndividual("@I1@").ExtensionData.Get("_MYAPP")
+	if ext == nil || ext.ChildValue("_ID") != "abc" || ext.ChildValue("_NOTE") != "line one\nline two" {
+		t.Errorf("round-tripped extension = %+v", ext)
+	}
+}
+
+func TestIndividualTasks_EntityRoundTrip(t *testing.T) {
+	indi := &gedcom.Individual{
+		XRef: "@I1@",
+		Tasks: []*gedcom.Task{
+			{Description: "Obtain birth certificate", Category: "Vital records", Status: "Open", Result: "Wrote\nWaiting"},
+			{Tag: gedcom.TaskTagResearch, Description: "Check census", CompletedDate: "1 JAN 2020"},
+		},
+	}
+	doc := &gedcom.Document{
+		Header:  &gedcom.Header{Version: gedcom.Version551},
+		Records: []*gedcom.Record{{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: indi}},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	for _, want := range []string{
+		"1 _TODO\n2 DESC Obtain birth certificate\n2 _CAT Vital records\n2 STAT Open\n2 DATA Wrote\n3 CONT Waiting\n",
+		"1 _RESEARCH\n2 DESC Check census\n2 _CDATE 1 JAN 2020\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("encoded output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	decoded, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	got := decoded.GetIndividual("@I1@").Tasks
+	if len(got) != 2 || got[0].Result != "Wrote\nWaiting" || got[1].Tag != gedcom.TaskTagResearch {
+		t.Errorf("decoded tasks = %+v", got)
+	}
+}
+
+func TestIndividualAliases_EntityRoundTrip(t *testing.T) {
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version551},
+		Records: []*gedcom.Record{
+			{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Individual{XRef: "@I1@", Aliases: []string{"@I2@"}}},
+			{XRef: "@I2@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Individual{XRef: "@I2@"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "0 @I1@ INDI\n1 ALIA @I2@\n") {
+		t.Errorf("encoded output missing ALIA:\n%s", buf.String())
+	}
+
+	decoded, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got := decoded.GetIndividual("@I1@").Aliases; len(got) != 1 || got[0] != "@I2@" {
+		t.Errorf("decoded Aliases = %v, want [@I2@]", got)
+	}
+}
+
+func TestEventAssociations_EntityRoundTrip(t *testing.T) {
+	fam := &gedcom.Family{
+		XRef: "@F1@",
+		Events: []*gedcom.Event{{
+			Type:         gedcom.EventMarriage,
+			Date:         "1875",
+			Associations: []*gedcom.Association{{IndividualXRef: "@I3@", Role: "WITN"}},
+		}},
+	}
+	doc := &gedcom.Document{
+		Header:  &gedcom.Header{Version: gedcom.Version70},
+		Records: []*gedcom.Record{{XRef: "@F1@", Type: gedcom.RecordTypeFamily, Entity: fam}},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "1 MARR\n2 DATE 1875\n2 ASSO @I3@\n3 ROLE WITN\n") {
+		t.Errorf("encoded output missing event ASSO:\n%s", buf.String())
+	}
+
+	decoded, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	got := decoded.GetFamily("@F1@").Events[0].Associations
+	if len(got) != 1 || got[0].IndividualXRef != "@I3@" || got[0].Role != "WITN" {
+		t.Errorf("decoded event associations = %+v", got)
+	}
+}
+
+func TestSourceCitationConfidence_EntityRoundTrip(t *testing.T) {
+	indi := &gedcom.Individual{
+		XRef: "@I1@",
+		Events: []*gedcom.Event{{
+			Type: gedcom.EventBirth,
+			SourceCitations: []*gedcom.SourceCitation{
+				{SourceXRef: "@S1@", HasQuality: true},
+				{SourceXRef: "@S2@", Surety: "Primary", SuretyTag: "_SURETY"},
+				{SourceXRef: "@S3@", Surety: "2"},
+			},
+		}},
+	}
+	doc := &gedcom.Document{
+		Header:  &gedcom.Header{Version: gedcom.Version551},
+		Records: []*gedcom.Record{{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: indi}},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	for _, want := range []string{
+		"2 SOUR @S1@\n3 QUAY 0\n",
+		"2 SOUR @S2@\n3 _SURETY Primary\n",
+		"2 SOUR @S3@\n3 _SURE 2\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("encoded output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	decoded, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	cites := decoded.GetIndividual("@I1@").Events[0].SourceCitations
+	want := []gedcom.Confidence{gedcom.ConfidenceUnreliable, gedcom.ConfidencePrimary, gedcom.ConfidenceSecondary}
+	for i, cite := range cites {
+		if got := cite.Confidence(); got != want[i] {
+			t.Errorf("citation %d Confidence() = %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestSourceRepositoryLinks_EntityRoundTrip(t *testing.T) {
+	first := &gedcom.SourceRepositoryLink{
+		XRef: "@R1@",
+		Calls: []gedcom.CallNumber{
+			{Number: "MS-12", Media: "Manuscript"},
+			{Number: "MS-12", Media: "Microfilm"},
+		},
+	}
+	src := &gedcom.Source{
+		XRef:           "@S1@",
+		RepositoryLink: first,
+		RepositoryLinks: []*gedcom.SourceRepositoryLink{
+			first,
+			{XRef: "@R2@", CallNumbers: []string{"F-7"}},
+		},
+	}
+	doc := &gedcom.Document{
+		Header:  &gedcom.Header{Version: gedcom.Version551},
+		Records: []*gedcom.Record{{XRef: "@S1@", Type: gedcom.RecordTypeSource, Entity: src}},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want := "1 REPO @R1@\n2 CALN MS-12\n3 MEDI Manuscript\n2 CALN MS-12\n3 MEDI Microfilm\n1 REPO @R2@\n2 CALN F-7\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("encoded output missing repository links:\n%s", buf.String())
+	}
+
+	decoded, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	got := decoded.GetSource("@S1@")
+	if len(got.RepositoryLinks) != 2 || got.RepositoryLink != got.RepositoryLinks[0] {
+		t.Fatalf("decoded RepositoryLinks = %+v", got.RepositoryLinks)
+	}
+	if calls := got.RepositoryLink.Calls; len(calls) != 2 || calls[1].Media != "Microfilm" {
+		t.Errorf("decoded Calls = %+v", calls)
+	}
+	if got.RepositoryRef != "@R1@" || got.RepositoryLinks[1].XRef != "@R2@" {
+		t.Errorf("RepositoryRef = %q, second link = %q", got.RepositoryRef, got.RepositoryLinks[1].XRef)
+	}
+}
+
+func TestRecordRestriction_EntityRoundTrip(t *testing.T) {
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version551},
+		Records: []*gedcom.Record{
+			{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Individual{XRef: "@I1@", Restriction: "privacy", Sex: "F"}},
+			{XRef: "@F1@", Type: gedcom.RecordTypeFamily, Entity: &gedcom.Family{XRef: "@F1@", Restriction: "locked", Wife: "@I1@"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	for _, want := range []string{"0 @I1@ INDI\n1 RESN privacy\n", "0 @F1@ FAM\n1 RESN locked\n"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("encoded output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	decoded, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got := decoded.GetIndividual("@I1@").Restriction; got != "privacy" {
+		t.Errorf("decoded individual Restriction = %q, want privacy", got)
+	}
+	if got := decoded.GetFamily("@F1@").Restriction; got != "locked" {
+		t.Errorf("decoded family Restriction = %q, want locked", got)
+	}
+}
+
+func TestEncodeView(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /Doe/
+1 FAMS @F1@
+0 @I2@ INDI
+1 RESN CONFIDENTIAL
+1 NAME Jane /Doe/
+1 FAMS @F1@
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 MARR
+2 DATE 1925
+2 RESN PRIVACY
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeView(&buf, doc.View(gedcom.RestrictionLocked), nil); err != nil {
+		t.Fatalf("EncodeView() error = %v", err)
+	}
+	out := buf.String()
+	for _, unwanted := range []string{"@I2@", "Jane", "MARR"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("view output contains %q:\n%s", unwanted, out)
+		}
+	}
+	if !strings.Contains(out, "0 @F1@ FAM\n1 HUSB @I1@\n0 TRLR") {
+		t.Errorf("view output missing filtered family:\n%s", out)
+	}
+}
+
+func TestFamilyToTags_MultipleSpouses(t *testing.T) {
+	fam := &gedcom.Family{
+		Husband: "@I1@",
+		Wife:    "@I9@",
+		Spouses: []*gedcom.FamilySpouse{
+			{XRef: "@I1@", Tag: "HUSB"},
+			{XRef: "@I2@", Tag: "HUSB"},
+			{XRef: "@I3@", Tag: "ASSO", Role: "SPOU"},
+		},
+		Associations: []*gedcom.Association{{IndividualXRef: "@I3@", Role: "SPOU"}},
+	}
+	var got []string
+	for _, tag := range familyToTags(fam, nil) {
+		got = append(got, fmt.Sprintf("%d %s %s", tag.Level, tag.Tag, tag.Value))
+	}
+	want := "1 HUSB @I1@|1 WIFE @I9@|1 HUSB @I2@|1 ASSO @I3@|2 ROLE SPOU"
+	if strings.Join(got, "|") != want {
+		t.Errorf("familyToTags() = %q, want %q", strings.Join(got, "|"), want)
+	}
+}
+
+func TestFamilyToTags_DirectSpouseAssignment(t *testing.T) {
+	doc, err := decoder.Decode(strings.NewReader("0 HEAD\n1 GEDC\n2 VERS 5.5.1\n0 @F1@ FAM\n1 HUSB @I1@\n1 WIFE @I2@\n1 HUSB @I4@\n0 TRLR\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fam := doc.GetFamily("@F1@")
+	fam.Husband = "@I3@"
+	var got []string
+	for _, tag := range familyToTags(fam, nil) {
+		got = append(got, fmt.Sprintf("%d %s %s", tag.Level, tag.Tag, tag.Value))
+	}
+	want := "1 HUSB @I3@|1 WIFE @I2@|1 HUSB @I4@"
+	if strings.Join(got, "|") != want {
+		t.Errorf("familyToTags() = %q, want %q", strings.Join(got, "|"), want)
+	}
+}
@@ -0,0 +1,139 @@
+package encoder
+
+import (
+	"io"
+	"math"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// writeTrivia writes the preserved blank and comment lines whose offset is
+// in [from, to].
+func writeTrivia(w io.Writer, trivia []gedcom.Trivia, from, to int, opts *EncodeOptions) error {
+	for _, t := range trivia {
+		if t.Offset < from || t.Offset > to {
+			continue
+		}
+		if err := writeRaw(w, t.Text, opts.LineEnding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writePreservedHeader writes the header from its raw tags, with its
+// preserved blank and comment lines, instead of from the typed fields.
+// TargetVersion still replaces the GEDC VERS value, and Encoding the CHAR
+// value (adding a CHAR line when the header has none). GEDCOM 7.0 headers
+// follow the 7.0 line rules, drop CHAR, and gain the GEDC VERS structure
+// 7.0 requires when they lack it.
+func writePreservedHeader(w io.Writer, header *gedcom.Header, opts *EncodeOptions) error {
+	if err := writeTrivia(w, header.Trivia, 0, 0, opts); err != nil {
+		return err
+	}
+	if err := writeLine(w, 0, "", "HEAD", "", opts.LineEnding); err != nil {
+		return err
+	}
+	tags := header.Tags
+	if opts.gedcom7 {
+		_, tags = gedcom7Tags("", tags)
+		if err := writeMissingVersion(w, tags, opts); err != nil {
+			return err
+		}
+	}
+	inGEDC := false
+	hasCHAR := false
+	for i, tag := range tags {
+		if err := writeTrivia(w, header.Trivia, i+1, i+1, opts); err != nil {
+			return err
+		}
+		if tag.Level == 1 {
+			inGEDC = tag.Tag == "GEDC"
+			hasCHAR = hasCHAR || tag.Tag == "CHAR"
+		}
+		tag = preservedHeaderTag(tag, inGEDC, opts)
+		if tag == nil {
+			continue
+		}
+		if err := writeTag(w, tag, opts); err != nil {
+			return err
+		}
+	}
+	if !hasCHAR && opts.Encoding != "" && !opts.gedcom7 {
+		if err := writeLine(w, 1, "", "CHAR", string(opts.Encoding), opts.LineEnding); err != nil {
+			return err
+		}
+	}
+	return writeTrivia(w, header.Trivia, len(tags)+1, math.MaxInt, opts)
+}
+
+// preservedHeaderTag returns a preserved header tag as it is written, with
+// the GEDC VERS or CHAR value replaced as opts asks, or nil to leave it
+// out. inGEDC reports whether the tag is within the GEDC structure.
+func preservedHeaderTag(tag *gedcom.Tag, inGEDC bool, opts *EncodeOptions) *gedcom.Tag {
+	switch {
+	case inGEDC && tag.Level == 2 && tag.Tag == "VERS" && opts.TargetVersion != "":
+		return &gedcom.Tag{Level: tag.Level, Tag: tag.Tag, Value: string(opts.TargetVersion)}
+	case tag.Level != 1 || tag.Tag != "CHAR":
+		return tag
+	case opts.gedcom7:
+		return nil
+	case opts.Encoding != "":
+		return &gedcom.Tag{Level: tag.Level, Tag: tag.Tag, Value: string(opts.Encoding)}
+	}
+	return tag
+}
+
+// writeMissingVersion writes the GEDC VERS 7.0 structure GEDCOM 7.0
+// requires when the header tags lack one.
+func writeMissingVersion(w io.Writer, tags []*gedcom.Tag, opts *EncodeOptions) error {
+	if hasVersion(tags) {
+		return nil
+	}
+	if err := writeLine(w, 1, "", "GEDC", "", opts.LineEnding); err != nil {
+		return err
+	}
+	return writeLine(w, 2, "", "VERS", string(gedcom.Version70), opts.LineEnding)
+}
+
+// hasVersion reports whether header tags hold a GEDC VERS line.
+func hasVersion(tags []*gedcom.Tag) bool {
+	inGEDC := false
+	for _, tag := range tags {
+		if tag.Level == 1 {
+			inGEDC = tag.Tag == "GEDC"
+		}
+		if inGEDC && tag.Level == 2 && tag.Tag == "VERS" {
+			return true
+		}
+	}
+	return false
+}
+
+// writePreservedTrailer writes the trailer with its preserved blank and
+// comment lines, leaving the last line unterminated when the source file
+// was.
+func writePreservedTrailer(w io.Writer, trailer *gedcom.Trailer, noFinalLineEnding bool, opts *EncodeOptions) error {
+	var lines []string
+	for _, t := range trailer.Trivia {
+		if t.Offset <= 0 {
+			lines = append(lines, t.Text)
+		}
+	}
+	lines = append(lines, "0 TRLR")
+	for _, t := range trailer.Trivia {
+		if t.Offset > 0 {
+			lines = append(lines, t.Text)
+		}
+	}
+	for i, line := range lines {
+		ending := opts.LineEnding
+		if noFinalLineEnding && i == len(lines)-1 {
+			ending = ""
+		}
+		if err := writeRaw(w, line, ending); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package encoder
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func TestEncode_PreserveFormatting(t *testing.T) {
+	inputs := map[string]string{
+		"crlf with bom":       "\uFEFF# Exported by FamilyApp\r\n0 HEAD\r\n1 GEDC\r\n2 VERS 5.5.1\r\n2 FORM LINEAGE-LINKED\r\n1 CHAR UTF-8\r\n1 DATE 1 JAN 2020\r\n\r\n0 @I1@ INDI\r\n1 NAME John /Smith/\r\n// birth from census\r\n1 BIRT\r\n2 DATE 1850\r\n\r\n\r\n0 @N1@ NOTE A note\r\n1 CONT second line\r\n\r\n0 TRLR\r\n",
+		"lf no final newline": "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 _RTLSAVE RTL\n0 @I1@ INDI\n1 NAME Jane /Doe/\n0 TRLR\n\n# end",
+	}
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			opts := decoder.DefaultOptions()
+			opts.PreserveFormatting = true
+			doc, err := decoder.DecodeWithOptions(strings.NewReader(input), opts)
+			if err != nil {
+				t.Fatalf("DecodeWithOptions() error = %v", err)
+			}
+			enc := DefaultOptions()
+			enc.PreserveFormatting = true
+			var buf bytes.Buffer
+			if err := EncodeWithOptions(&buf, doc, enc); err != nil {
+				t.Fatalf("EncodeWithOptions() error = %v", err)
+			}
+			if buf.String() != input {
+				t.Errorf("output differs from input:\ngot  %q\nwant %q", buf.String(), input)
+			}
+
+			// Clones keep the layout.
+			buf.Reset()
+			if err := EncodeWithOptions(&buf, doc.Clone(), enc); err != nil {
+				t.Fatalf("EncodeWithOptions(clone) error = %v", err)
+			}
+			if buf.String() != input {
+				t.Errorf("clone output differs from input:\ngot  %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestEncode_PreserveFormattingOff(t *testing.T) {
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version551, Tags: []*gedcom.Tag{{Level: 1, Tag: "_RTLSAVE", Value: "RTL"}},
+			Trivia: []gedcom.Trivia{{Offset: 0, Text: "# header comment"}}},
+		Records: []*gedcom.Record{{XRef: "@I1@", Type: gedcom.RecordTypeIndividual,
+			Trivia: []gedcom.Trivia{{Offset: 0, Text: ""}},
+			Tags:   []*gedcom.Tag{{Level: 1, Tag: "SEX", Value: "M"}}}},
+		Trailer:    &gedcom.Trailer{Trivia: []gedcom.Trivia{{Offset: 1, Text: "# end"}}},
+		Formatting: &gedcom.Formatting{LineEnding: "\r\n", BOM: true},
+	}
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n0 @I1@ INDI\n1 SEX M\n0 TRLR\n"
+	if buf.String() != want {
+		t.Errorf("Encode() = %q, want %q", buf.String(), want)
+	}
+}
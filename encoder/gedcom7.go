@@ -0,0 +1,252 @@
+package encoder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// ErrBannedTag is wrapped by the error returned when GEDCOM 7.0 output
+// would contain a standard tag that GEDCOM 7.0 removed, such as AFN, ROMN,
+// or a SUBN record. Convert the document with the converter package first,
+// or encode with gedcom.StrictnessLenient to write such tags as they are.
+var ErrBannedTag = errors.New("tag removed in GEDCOM 7.0")
+
+// forVersion returns opts marked for writing version, which switches on
+// the GEDCOM 7.0 line rules (see gedcom7Tags) for 7.0 output.
+func (opts *EncodeOptions) forVersion(version gedcom.Version) *EncodeOptions {
+	v7 := version == gedcom.Version70
+	if opts.gedcom7 == v7 {
+		return opts
+	}
+	out := *opts
+	out.gedcom7 = v7
+	return &out
+}
+
+// rejectsBannedTags reports whether GEDCOM 7.0 output fails on tags 7.0
+// removed, which it does unless Strictness is lenient.
+func (opts *EncodeOptions) rejectsBannedTags() bool {
+	return opts.gedcom7 && opts.Strictness >= gedcom.StrictnessNormal
+}
+
+// gedcom7Tags returns the record line value and tags of a record as
+// GEDCOM 7.0 writes them:
+//   - CONC lines, which 7.0 removed, are joined onto the line they
+//     continue, so long values are written on one line;
+//   - values with line breaks are split into CONT lines, the only
+//     continuation 7.0 keeps, so the payload survives unchanged;
+//   - a level-1 _UID holding a UUID is written as the standard UID when
+//     the record has no UID of its own.
+//
+// Changed tags are copies; tags is not modified.
+func gedcom7Tags(value string, tags []*gedcom.Tag) (string, []*gedcom.Tag) {
+	if !needsGEDCOM7Rewrite(value, tags) {
+		return value, tags
+	}
+
+	value, joined := joinCONC(value, tags)
+	value, out := splitLineBreaks(value, 1, nil)
+	return value, splitTagLineBreaks(joined, out)
+}
+
+// joinCONC returns the record line value and tags with the CONC lines
+// joined onto the lines they continue and a UUID _UID turned into UID.
+func joinCONC(value string, tags []*gedcom.Tag) (string, []*gedcom.Tag) {
+	hasUID := false
+	for _, tag := range tags {
+		if tag.Level == 1 && tag.Tag == "UID" {
+			hasUID = true
+			break
+		}
+	}
+
+	joined := make([]*gedcom.Tag, 0, len(tags))
+	for _, tag := range tags {
+		if tag.Tag == "CONC" {
+			n := len(joined)
+			switch {
+			case n == 0 && tag.Level == 1:
+				value += tag.Value
+				continue
+			case n > 0 && continues(joined[n-1], tag.Level):
+				last := *joined[n-1]
+				last.Value += tag.Value
+				joined[n-1] = &last
+				continue
+			}
+		}
+		if tag.Level == 1 && tag.Tag == "_UID" && !hasUID && isUUID(tag.Value) {
+			uid := *tag
+			uid.Tag = "UID"
+			tag = &uid
+			hasUID = true
+		}
+		joined = append(joined, tag)
+	}
+	return value, joined
+}
+
+// splitTagLineBreaks appends tags to out, splitting values with line
+// breaks into CONT lines.
+func splitTagLineBreaks(tags, out []*gedcom.Tag) []*gedcom.Tag {
+	for _, tag := range tags {
+		if !strings.ContainsAny(tag.Value, "\r\n") {
+			out = append(out, tag)
+			continue
+		}
+		level := tag.Level + 1
+		if tag.Tag == "CONT" {
+			level = tag.Level
+		}
+		first := *tag
+		out = append(out, &first)
+		first.Value, out = splitLineBreaks(tag.Value, level, out)
+	}
+	return out
+}
+
+// needsGEDCOM7Rewrite reports whether gedcom7Tags would change anything.
+func needsGEDCOM7Rewrite(value string, tags []*gedcom.Tag) bool {
+	if strings.ContainsAny(value, "\r\n") {
+		return true
+	}
+	for _, tag := range tags {
+		if tag.Tag == "CONC" || tag.Tag == "_UID" || strings.ContainsAny(tag.Value, "\r\n") {
+			return true
+		}
+	}
+	return false
+}
+
+// continues reports whether a CONC at level continues the payload of
+// line: the line it is subordinate to, or a CONT of that line.
+func continues(line *gedcom.Tag, level int) bool {
+	return line.Level == level-1 || (line.Level == level && line.Tag == "CONT")
+}
+
+// splitLineBreaks returns the first line of value, appending the others to
+// tags as CONT lines at level. CRLF and lone CR count as line breaks.
+func splitLineBreaks(value string, level int, tags []*gedcom.Tag) (string, []*gedcom.Tag) {
+	if !strings.ContainsAny(value, "\r\n") {
+		return value, tags
+	}
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	value = strings.ReplaceAll(value, "\r", "\n")
+	lines := strings.Split(value, "\n")
+	for _, line := range lines[1:] {
+		tags = append(tags, &gedcom.Tag{Level: level, Tag: "CONT", Value: line})
+	}
+	return lines[0], tags
+}
+
+// isUUID reports whether s is a UUID in its 8-4-4-4-12 hexadecimal form,
+// the form GEDCOM 7.0 asks UID payloads to take.
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// checkBannedTags checks the tags that would be written for the header and
+// records of GEDCOM 7.0 output. The header is only written from its raw
+// tags when preserving formatting; otherwise it is generated from 7.0
+// structures.
+func checkBannedTags(header *gedcom.Header, records []*gedcom.Record, opts *EncodeOptions) error {
+	var banned []string
+	if opts.PreserveFormatting && header != nil {
+		_, tags := gedcom7Tags("", header.Tags)
+		for _, tag := range standardTags(tags) {
+			if tag.Level == 1 && tag.Tag == "CHAR" {
+				continue // dropped from 7.0 headers
+			}
+			if isBannedTag(tag.Tag) {
+				banned = append(banned, fmt.Sprintf("%s in HEAD", tag.Tag))
+			}
+		}
+	}
+	for _, record := range records {
+		banned = append(banned, recordBannedTags(record, opts)...)
+	}
+	return bannedTagError(banned)
+}
+
+// recordBannedTags describes the tags removed in GEDCOM 7.0 that would be
+// written for record. Extension records and the substructures of extension
+// tags are not checked: their tags only mean what the extension defines.
+func recordBannedTags(record *gedcom.Record, opts *EncodeOptions) []string {
+	if record == nil || strings.HasPrefix(string(record.Type), "_") {
+		return nil
+	}
+	label := string(record.Type)
+	if record.XRef != "" {
+		label += " " + record.XRef
+	}
+	var banned []string
+	if isBannedTag(string(record.Type)) {
+		banned = append(banned, fmt.Sprintf("%s record %s", record.Type, record.XRef))
+	}
+	tags, value := recordTags(record, opts)
+	_, tags = gedcom7Tags(value, tags)
+	for _, tag := range standardTags(tags) {
+		if isBannedTag(tag.Tag) {
+			banned = append(banned, fmt.Sprintf("%s in %s", tag.Tag, label))
+		}
+	}
+	return banned
+}
+
+// standardTags returns tags without the subtrees of extension tags, whose
+// substructures are defined by the extension rather than the standard.
+func standardTags(tags []*gedcom.Tag) []*gedcom.Tag {
+	out := make([]*gedcom.Tag, 0, len(tags))
+	extensionLevel := -1
+	for _, tag := range tags {
+		if extensionLevel >= 0 && tag.Level > extensionLevel {
+			continue
+		}
+		extensionLevel = -1
+		if strings.HasPrefix(tag.Tag, "_") {
+			extensionLevel = tag.Level
+			continue
+		}
+		out = append(out, tag)
+	}
+	return out
+}
+
+// isBannedTag reports whether tag is a standard tag of an earlier version
+// that GEDCOM 7.0 removed.
+func isBannedTag(tag string) bool {
+	info, ok := gedcom.LookupStandardTag(tag)
+	return ok && !info.InVersion(gedcom.Version70)
+}
+
+// bannedTagError describes the first banned tag, noting how many more
+// there are, or returns nil if there are none.
+func bannedTagError(banned []string) error {
+	switch len(banned) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("%w: %s", ErrBannedTag, banned[0])
+	default:
+		return fmt.Errorf("%w: %s (and %d more)", ErrBannedTag, banned[0], len(banned)-1)
+	}
+}
@@ -0,0 +1,297 @@
+package encoder
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func TestGEDCOM7Tags(t *testing.T) {
+	const uuid = "8b5a0e3c-1d2f-4a6b-9c8d-7e6f5a4b3c2d"
+	tests := []struct {
+		name      string
+		value     string
+		tags      []*gedcom.Tag
+		wantValue string
+		want      string
+	}{
+		{
+			name: "CONC joined onto its line and CONT",
+			tags: []*gedcom.Tag{
+				{Level: 1, Tag: "NOTE", Value: "Long "},
+				{Level: 2, Tag: "CONC", Value: "note"},
+				{Level: 2, Tag: "CONT", Value: "Second "},
+				{Level: 2, Tag: "CONC", Value: "line"},
+				{Level: 2, Tag: "SOUR", Value: "@S1@"},
+			},
+			want: "1 NOTE Long note|2 CONT Second line|2 SOUR @S1@",
+		},
+		{
+			name:      "CONC of the record line",
+			value:     "Shared ",
+			tags:      []*gedcom.Tag{{Level: 1, Tag: "CONC", Value: "note"}, {Level: 1, Tag: "LANG", Value: "en"}},
+			wantValue: "Shared note",
+			want:      "1 LANG en",
+		},
+		{
+			name: "line breaks become CONT",
+			tags: []*gedcom.Tag{
+				{Level: 1, Tag: "NOTE", Value: "One\nTwo\r\nThree"},
+				{Level: 2, Tag: "CONT", Value: "Four\rFive"},
+			},
+			want: "1 NOTE One|2 CONT Two|2 CONT Three|2 CONT Four|2 CONT Five",
+		},
+		{
+			name:      "record line breaks",
+			value:     "Top\nNext",
+			tags:      []*gedcom.Tag{{Level: 1, Tag: "MIME", Value: "text/plain"}},
+			wantValue: "Top",
+			want:      "1 CONT Next|1 MIME text/plain",
+		},
+		{
+			name: "_UID with a UUID becomes UID",
+			tags: []*gedcom.Tag{{Level: 1, Tag: "_UID", Value: uuid}},
+			want: "1 UID " + uuid,
+		},
+		{
+			name: "_UID kept without a UUID or beside a UID",
+			tags: []*gedcom.Tag{
+				{Level: 1, Tag: "_UID", Value: "A1B2C3D4E5F6A7B8C9D0E1F2A3B4C5D6E7F8"},
+				{Level: 1, Tag: "UID", Value: uuid},
+				{Level: 1, Tag: "_UID", Value: uuid},
+			},
+			want: "1 _UID A1B2C3D4E5F6A7B8C9D0E1F2A3B4C5D6E7F8|1 UID " + uuid + "|1 _UID " + uuid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := tagLines(tt.tags)
+			value, tags := gedcom7Tags(tt.value, tt.tags)
+			if value != tt.wantValue {
+				t.Errorf("value = %q, want %q", value, tt.wantValue)
+			}
+			if got := tagLines(tags); got != tt.want {
+				t.Errorf("tags = %q, want %q", got, tt.want)
+			}
+			if tagLines(tt.tags) != before {
+				t.Error("gedcom7Tags modified its input")
+			}
+		})
+	}
+
+	plain := []*gedcom.Tag{{Level: 1, Tag: "NAME", Value: "John /Smith/"}}
+	if _, tags := gedcom7Tags("", plain); &tags[0] != &plain[0] {
+		t.Error("gedcom7Tags copied tags that need no change")
+	}
+}
+
+func tagLines(tags []*gedcom.Tag) string {
+	lines := make([]string, len(tags))
+	for i, tag := range tags {
+		lines[i] = strings.TrimSpace(strings.Join([]string{string(rune('0' + tag.Level)), tag.Tag, tag.Value}, " "))
+	}
+	return strings.Join(lines, "|")
+}
+
+func TestIsUUID(t *testing.T) {
+	for s, want := range map[string]bool{
+		"8b5a0e3c-1d2f-4a6b-9c8d-7e6f5a4b3c2d": true,
+		"8B5A0E3C-1D2F-4A6B-9C8D-7E6F5A4B3C2D": true,
+		"8b5a0e3c1d2f4a6b9c8d7e6f5a4b3c2d":     false,
+		"8b5a0e3c-1d2f-4a6b-9c8d-7e6f5a4b3c2g": false,
+		"8b5a0e3c-1d2f-4a6b9-c8d-7e6f5a4b3c2d": false,
+		"":                                     false,
+	} {
+		if got := isUUID(s); got != want {
+			t.Errorf("isUUID(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+const gedcom551Input = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+2 FORM LINEAGE-LINKED
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /Smith/
+1 NOTE A note that was sp
+2 CONC lit across two lines
+2 CONT and continued
+0 TRLR
+`
+
+// TestEncode_GEDCOM7 writes a decoded 5.5.1 document as GEDCOM 7.0: no
+// CONC, no CHAR, and a long entity note written on one line.
+func TestEncode_GEDCOM7(t *testing.T) {
+	doc, err := decoder.Decode(strings.NewReader(gedcom551Input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	doc.Records = append(doc.Records, &gedcom.Record{
+		XRef:   "@I2@",
+		Type:   gedcom.RecordTypeIndividual,
+		Entity: &gedcom.Individual{XRef: "@I2@", Notes: []string{strings.Repeat("word ", 100)}},
+	})
+
+	opts := DefaultOptions()
+	opts.TargetVersion = gedcom.Version70
+	var buf bytes.Buffer
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Fatalf("EncodeWithOptions() error = %v", err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "0 HEAD\n1 GEDC\n2 VERS 7.0\n0 @I1@") {
+		t.Errorf("header =\n%s", out)
+	}
+	if strings.Contains(out, "CONC") || strings.Contains(out, "CHAR") {
+		t.Errorf("7.0 output has CONC or CHAR:\n%s", out)
+	}
+	if !strings.Contains(out, "1 NOTE A note that was split across two lines\n2 CONT and continued\n") {
+		t.Errorf("CONC was not joined:\n%s", out)
+	}
+	if !strings.Contains(out, "1 NOTE "+strings.Repeat("word ", 99)+"word\n") && !strings.Contains(out, "1 NOTE "+strings.Repeat("word ", 100)+"\n") {
+		t.Errorf("long entity note was split:\n%s", out)
+	}
+
+	// The same document as 5.5.1 keeps its CONC and CHAR lines
+	buf.Reset()
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "2 CONC lit across two lines") || !strings.Contains(buf.String(), "1 CHAR UTF-8") {
+		t.Errorf("5.5.1 output changed:\n%s", buf.String())
+	}
+}
+
+func TestEncode_GEDCOM7PreservedHeader(t *testing.T) {
+	doc, err := decoder.DecodeWithOptions(strings.NewReader("0 HEAD\n1 CHAR UTF-8\n1 SOUR App\n0 TRLR\n"), &decoder.DecodeOptions{PreserveFormatting: true})
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	opts := DefaultOptions()
+	opts.PreserveFormatting = true
+	opts.TargetVersion = gedcom.Version70
+	var buf bytes.Buffer
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Fatalf("EncodeWithOptions() error = %v", err)
+	}
+	if want := "0 HEAD\n1 GEDC\n2 VERS 7.0\n1 SOUR App\n0 TRLR\n"; buf.String() != want {
+		t.Errorf("output =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+func TestEncode_GEDCOM7BannedTags(t *testing.T) {
+	doc, err := decoder.Decode(strings.NewReader(`0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+2 ROMN Jon /Smit/
+1 AFN 1234-567
+0 @SUB1@ SUBN
+1 FAMF family.ged
+0 TRLR
+`))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	opts := DefaultOptions()
+	opts.TargetVersion = gedcom.Version70
+	var buf bytes.Buffer
+	err = EncodeWithOptions(&buf, doc, opts)
+	if !errors.Is(err, ErrBannedTag) {
+		t.Fatalf("EncodeWithOptions() error = %v, want ErrBannedTag", err)
+	}
+	if !strings.Contains(err.Error(), "ROMN in INDI @I1@ (and 3 more)") {
+		t.Errorf("error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("wrote %d bytes before failing", buf.Len())
+	}
+
+	report := Plan(doc, opts)
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0].Reason, "ROMN") {
+		t.Errorf("Plan warnings = %+v", report.Warnings)
+	}
+
+	// 5.5.1 output and lenient 7.0 output write the tags
+	if err := Encode(&buf, doc); err != nil {
+		t.Errorf("Encode(5.5.1) error = %v", err)
+	}
+	opts.Strictness = gedcom.StrictnessLenient
+	buf.Reset()
+	if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+		t.Fatalf("EncodeWithOptions(lenient) error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "1 AFN 1234-567") {
+		t.Errorf("lenient output lacks AFN:\n%s", buf.String())
+	}
+
+	// The streaming encoder refuses the records one at a time
+	opts.Strictness = gedcom.StrictnessNormal
+	buf.Reset()
+	enc := NewStreamEncoderWithOptions(&buf, opts)
+	if err := enc.WriteHeader(doc.Header); err != nil {
+		t.Fatalf("WriteHeader() error = %v", err)
+	}
+	if err := enc.WriteRecord(doc.Records[0]); !errors.Is(err, ErrBannedTag) {
+		t.Errorf("WriteRecord(INDI) error = %v, want ErrBannedTag", err)
+	}
+	if err := enc.WriteRecord(&gedcom.Record{XRef: "@I2@", Type: gedcom.RecordTypeIndividual}); err != nil {
+		t.Errorf("WriteRecord(clean) error = %v", err)
+	}
+}
+
+// TestEncode_GEDCOM7ExtensionTags checks tags under extension tags and in
+// extension records are not taken for the standard tags 7.0 removed.
+func TestEncode_GEDCOM7ExtensionTags(t *testing.T) {
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version70},
+		Records: []*gedcom.Record{
+			{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Tags: []*gedcom.Tag{
+				{Level: 1, Tag: "_TODO"},
+				{Level: 2, Tag: "DESC", Value: "Obtain"},
+				{Level: 1, Tag: "NAME", Value: "John /Smith/"},
+			}},
+			{Type: "_EVDEF", Value: "BIRT", Tags: []*gedcom.Tag{{Level: 1, Tag: "DESC", Value: "N"}}},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	doc.Records[0].Tags = append(doc.Records[0].Tags, &gedcom.Tag{Level: 2, Tag: "ROMN", Value: "Jon"})
+	if err := Encode(&buf, doc); !errors.Is(err, ErrBannedTag) {
+		t.Errorf("Encode() with ROMN error = %v, want ErrBannedTag", err)
+	}
+}
+
+func TestEncodeRecord_GEDCOM7(t *testing.T) {
+	record := &gedcom.Record{XRef: "@N1@", Type: gedcom.RecordTypeNote, Value: "First", Tags: []*gedcom.Tag{
+		{Level: 1, Tag: "CONC", Value: " part"},
+	}}
+	got, err := EncodeRecord(record, gedcom.Version70)
+	if err != nil || got != "0 @N1@ NOTE First part\n" {
+		t.Errorf("EncodeRecord(7.0) = %q, %v", got, err)
+	}
+	got, err = EncodeRecord(record, gedcom.Version551)
+	if err != nil || got != "0 @N1@ NOTE First\n1 CONC  part\n" {
+		t.Errorf("EncodeRecord(5.5.1) = %q, %v", got, err)
+	}
+
+	// Tags 7.0 removed fail as they do in EncodeWithOptions
+	banned := &gedcom.Record{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Tags: []*gedcom.Tag{{Level: 1, Tag: "AFN", Value: "1234"}}}
+	if _, err := EncodeRecord(banned, gedcom.Version70); !errors.Is(err, ErrBannedTag) {
+		t.Errorf("EncodeRecord(7.0, AFN) error = %v, want ErrBannedTag", err)
+	}
+	if _, err := EncodeRecord(banned, gedcom.Version551); err != nil {
+		t.Errorf("EncodeRecord(5.5.1, AFN) error = %v", err)
+	}
+}
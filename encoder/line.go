@@ -0,0 +1,96 @@
+package encoder
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// bufferSize is the size of the buffered writers Encode writes through.
+const bufferSize = 64 * 1024
+
+// maxPooledLine is the capacity beyond which a line buffer is not
+// returned to the pool, so one huge value does not pin its memory.
+const maxPooledLine = 4 * 1024
+
+var writerPool = sync.Pool{
+	New: func() any { return bufio.NewWriterSize(nil, bufferSize) },
+}
+
+var linePool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 256)
+		return &b
+	},
+}
+
+// getWriter returns a pooled buffered writer that writes to w. Flush it,
+// then return it with putWriter.
+func getWriter(w io.Writer) *bufio.Writer {
+	bw := writerPool.Get().(*bufio.Writer)
+	bw.Reset(w)
+	return bw
+}
+
+// putWriter returns bw to the pool.
+func putWriter(bw *bufio.Writer) {
+	bw.Reset(nil)
+	writerPool.Put(bw)
+}
+
+// writeBuffered runs write with a pooled buffered writer over w and
+// flushes it, so that every line costs one copy rather than one write to
+// w.
+func writeBuffered(w io.Writer, write func(io.Writer) error) error {
+	bw := getWriter(w)
+	defer putWriter(bw)
+	if err := write(bw); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// writeLine writes one GEDCOM line in a single Write:
+// "level [xref ]tag[ value]" and the line ending. An empty xref or value
+// is left out.
+func writeLine(w io.Writer, level int, xref, tag, value, ending string) error {
+	bp := linePool.Get().(*[]byte)
+	b := appendLine((*bp)[:0], level, xref, tag, value, ending)
+	_, err := w.Write(b)
+	if cap(b) <= maxPooledLine {
+		*bp = b
+		linePool.Put(bp)
+	}
+	return err
+}
+
+// appendLine appends a GEDCOM line to b, as written by writeLine.
+func appendLine(b []byte, level int, xref, tag, value, ending string) []byte {
+	if level >= 0 && level < 10 {
+		b = append(b, byte('0'+level))
+	} else {
+		b = strconv.AppendInt(b, int64(level), 10)
+	}
+	b = append(b, ' ')
+	if xref != "" {
+		b = append(b, xref...)
+		b = append(b, ' ')
+	}
+	b = append(b, tag...)
+	if value != "" {
+		b = append(b, ' ')
+		b = append(b, value...)
+	}
+	return append(b, ending...)
+}
+
+// writeRaw writes text, such as a preserved comment line, and the line
+// ending.
+func writeRaw(w io.Writer, text, ending string) error {
+	if _, err := io.WriteString(w, text); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, ending)
+	return err
+}
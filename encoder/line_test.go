@@ -0,0 +1,62 @@
+package encoder
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestAppendLine(t *testing.T) {
+	tests := []struct {
+		level                  int
+		xref, tag, value, want string
+	}{
+		{0, "", "HEAD", "", "0 HEAD\n"},
+		{0, "@I1@", "INDI", "", "0 @I1@ INDI\n"},
+		{0, "@N1@", "NOTE", "text", "0 @N1@ NOTE text\n"},
+		{2, "", "DATE", "1 JAN 1900", "2 DATE 1 JAN 1900\n"},
+		{12, "", "_DEEP", "x", "12 _DEEP x\n"},
+	}
+	for _, tt := range tests {
+		if got := string(appendLine(nil, tt.level, tt.xref, tt.tag, tt.value, "\n")); got != tt.want {
+			t.Errorf("appendLine(%d, %q, %q, %q) = %q, want %q", tt.level, tt.xref, tt.tag, tt.value, got, tt.want)
+		}
+	}
+	if got := string(appendLine(nil, 1, "", "NAME", "x", "\r\n")); got != "1 NAME x\r\n" {
+		t.Errorf("CRLF line = %q", got)
+	}
+}
+
+func TestWriteLine_LongValue(t *testing.T) {
+	var buf bytes.Buffer
+	long := strings.Repeat("x", 2*maxPooledLine)
+	for i := 0; i < 2; i++ {
+		if err := writeLine(&buf, 1, "", "NOTE", long, "\n"); err != nil {
+			t.Fatalf("writeLine() error = %v", err)
+		}
+	}
+	if want := strings.Repeat("1 NOTE "+long+"\n", 2); buf.String() != want {
+		t.Errorf("writeLine() wrote %d bytes, want %d", buf.Len(), len(want))
+	}
+}
+
+func TestWriteBuffered(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeBuffered(&buf, func(w io.Writer) error {
+		return writeLine(w, 0, "", "TRLR", "", "\n")
+	}); err != nil || buf.String() != "0 TRLR\n" {
+		t.Errorf("writeBuffered() = %v, wrote %q", err, buf.String())
+	}
+
+	want := errors.New("stop")
+	if err := writeBuffered(&buf, func(io.Writer) error { return want }); !errors.Is(err, want) {
+		t.Errorf("writeBuffered() error = %v, want %v", err, want)
+	}
+	if err := writeBuffered(&failWriter{}, func(w io.Writer) error {
+		return writeLine(w, 0, "", "TRLR", "", "\n")
+	}); err == nil {
+		t.Error("writeBuffered() should return the flush error")
+	}
+}
@@ -0,0 +1,215 @@
+package encoder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// Manifest lists the records of a document with their identity and content
+// hash, so two copies of a tree can be compared without exchanging the
+// records themselves. It encodes to JSON as:
+//
+//	{"version":"5.5.1","records":[{"uid":"...","xref":"@I1@","type":"INDI","hash":"..."}]}
+type Manifest struct {
+	// Version is the GEDCOM version the hashes were computed in.
+	Version string `json:"version,omitempty"`
+
+	// Records are the records with an XRef, in document order.
+	Records []ManifestEntry `json:"records"`
+}
+
+// ManifestEntry identifies one record and its content.
+type ManifestEntry struct {
+	// UID is the record's first UID (or _UID) value, if it has one.
+	UID string `json:"uid,omitempty"`
+
+	// XRef is the record's cross-reference identifier.
+	XRef string `json:"xref"`
+
+	// Type is the record type (INDI, FAM, ...).
+	Type string `json:"type"`
+
+	// Hash is the record's content hash, as returned by RecordHash.
+	Hash string `json:"hash"`
+}
+
+// ManifestDiff lists the records that differ between two manifests.
+type ManifestDiff struct {
+	// Missing are source records with no counterpart in the target.
+	Missing []ManifestEntry
+
+	// Changed are source records whose counterpart in the target has a
+	// different hash.
+	Changed []ManifestEntry
+
+	// Extra are target records with no counterpart in the source.
+	Extra []ManifestEntry
+}
+
+// Transfer returns the source records the target needs, Missing then
+// Changed.
+func (d *ManifestDiff) Transfer() []ManifestEntry {
+	out := make([]ManifestEntry, 0, len(d.Missing)+len(d.Changed))
+	out = append(out, d.Missing...)
+	return append(out, d.Changed...)
+}
+
+// IsEmpty reports whether the manifests describe the same records.
+func (d *ManifestDiff) IsEmpty() bool {
+	return len(d.Missing) == 0 && len(d.Changed) == 0 && len(d.Extra) == 0
+}
+
+// RecordHash returns the hex SHA-256 of the GEDCOM text EncodeRecord writes
+// for record in the given version. The text includes the record's own XRef
+// and the XRefs it points to, so renumbering a tree changes its hashes.
+func RecordHash(record *gedcom.Record, version gedcom.Version) (string, error) {
+	text, err := EncodeRecord(record, version)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// BuildManifest hashes every record of doc that has an XRef, in the
+// document's version.
+func BuildManifest(doc *gedcom.Document) (*Manifest, error) {
+	if doc == nil {
+		return nil, errors.New("document is required")
+	}
+	version := documentVersion(doc)
+	m := &Manifest{Version: string(version), Records: []ManifestEntry{}}
+	for _, record := range doc.Records {
+		if record == nil || record.XRef == "" {
+			continue
+		}
+		hash, err := RecordHash(record, version)
+		if err != nil {
+			return nil, err
+		}
+		m.Records = append(m.Records, ManifestEntry{
+			UID:  recordUID(record),
+			XRef: record.XRef,
+			Type: string(record.Type),
+			Hash: hash,
+		})
+	}
+	return m, nil
+}
+
+// WriteJSON writes the manifest as indented JSON.
+func (m *Manifest) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+// ReadManifest reads a manifest written by Manifest.WriteJSON.
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// CompareManifests reports which records of source the holder of target
+// needs. Records are paired by UID when both entries have one, and the
+// remaining records by XRef, so a record keeps its identity across copies
+// that renumber XRefs as long as it carries a UID. A nil manifest is
+// treated as empty.
+func CompareManifests(source, target *Manifest) *ManifestDiff {
+	var src, dst []ManifestEntry
+	if source != nil {
+		src = source.Records
+	}
+	if target != nil {
+		dst = target.Records
+	}
+
+	partner, matched := pairManifestEntries(src, dst)
+
+	diff := &ManifestDiff{}
+	for j, e := range src {
+		switch i := partner[j]; {
+		case i < 0:
+			diff.Missing = append(diff.Missing, e)
+		case dst[i].Hash != e.Hash:
+			diff.Changed = append(diff.Changed, e)
+		}
+	}
+	for i, e := range dst {
+		if !matched[i] {
+			diff.Extra = append(diff.Extra, e)
+		}
+	}
+	return diff
+}
+
+// pairManifestEntries pairs the entries of src with those of dst, by UID
+// first so an XRef match cannot take a UID's partner, then by XRef when
+// either entry lacks a UID. partner[j] is the index in dst of the partner
+// of src[j], or -1; matched[i] reports whether dst[i] has a partner.
+func pairManifestEntries(src, dst []ManifestEntry) (partner []int, matched []bool) {
+	byUID := make(map[string]int)
+	byXRef := make(map[string]int)
+	for i, e := range dst {
+		if e.UID != "" {
+			if _, dup := byUID[e.UID]; !dup {
+				byUID[e.UID] = i
+			}
+		}
+		if _, dup := byXRef[e.XRef]; !dup {
+			byXRef[e.XRef] = i
+		}
+	}
+
+	partner = make([]int, len(src))
+	matched = make([]bool, len(dst))
+	for j, e := range src {
+		partner[j] = -1
+		if i, ok := byUID[e.UID]; ok && e.UID != "" && !matched[i] {
+			partner[j], matched[i] = i, true
+		}
+	}
+	for j, e := range src {
+		if partner[j] >= 0 {
+			continue
+		}
+		if i, ok := byXRef[e.XRef]; ok && !matched[i] && (e.UID == "" || dst[i].UID == "") {
+			partner[j], matched[i] = i, true
+		}
+	}
+	return partner, matched
+}
+
+// recordUID returns the first UID or _UID value of record, from its raw
+// tags or, for records built without them, its entity.
+func recordUID(record *gedcom.Record) string {
+	if len(record.Tags) > 0 {
+		for _, tag := range record.Tags {
+			if tag != nil && tag.Level == 1 && (tag.Tag == "UID" || tag.Tag == "_UID") && tag.Value != "" {
+				return tag.Value
+			}
+		}
+		return ""
+	}
+	switch e := record.Entity.(type) {
+	case *gedcom.Individual:
+		return e.UID
+	case *gedcom.Family:
+		return e.UID
+	case *gedcom.Source:
+		return e.UID
+	case *gedcom.MediaObject:
+		if len(e.UIDs) > 0 {
+			return e.UIDs[0]
+		}
+	}
+	return ""
+}
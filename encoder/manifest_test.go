@@ -0,0 +1,151 @@
+package encoder
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func decodeManifestDoc(t *testing.T, input string) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestBuildManifest(t *testing.T) {
+	doc := decodeManifestDoc(t, `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 NAME John /Smith/
+1 UID 1b6f3a1e-5c2d-4a8e-9b0f-3d2c1e4f5a6b
+0 @I2@ INDI
+1 NAME Mary /Jones/
+0 TRLR
+`)
+	m, err := BuildManifest(doc)
+	if err != nil {
+		t.Fatalf("BuildManifest() error = %v", err)
+	}
+	if m.Version != "7.0" || len(m.Records) != 2 {
+		t.Fatalf("BuildManifest() = %+v", m)
+	}
+	first := m.Records[0]
+	if first.XRef != "@I1@" || first.Type != "INDI" || first.UID != "1b6f3a1e-5c2d-4a8e-9b0f-3d2c1e4f5a6b" || len(first.Hash) != 64 {
+		t.Errorf("Records[0] = %+v", first)
+	}
+	if m.Records[1].UID != "" {
+		t.Errorf("Records[1].UID = %q, want empty", m.Records[1].UID)
+	}
+
+	hash, err := RecordHash(doc.GetRecord("@I1@"), "7.0")
+	if err != nil || hash != first.Hash {
+		t.Errorf("RecordHash() = %q, %v; want %q", hash, err, first.Hash)
+	}
+
+	var buf bytes.Buffer
+	if err := m.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	read, err := ReadManifest(&buf)
+	if err != nil {
+		t.Fatalf("ReadManifest() error = %v", err)
+	}
+	if !reflect.DeepEqual(read, m) {
+		t.Errorf("ReadManifest() = %+v, want %+v", read, m)
+	}
+
+	if _, err := BuildManifest(nil); err == nil {
+		t.Error("BuildManifest(nil) should fail")
+	}
+	if _, err := ReadManifest(strings.NewReader("{")); err == nil {
+		t.Error("ReadManifest() should fail on malformed JSON")
+	}
+}
+
+func TestCompareManifests(t *testing.T) {
+	source := &Manifest{Records: []ManifestEntry{
+		{UID: "u1", XRef: "@I1@", Type: "INDI", Hash: "a"},
+		{UID: "u2", XRef: "@I2@", Type: "INDI", Hash: "b"},
+		{XRef: "@I3@", Type: "INDI", Hash: "c"},
+		{XRef: "@I4@", Type: "INDI", Hash: "d"},
+		{UID: "u5", XRef: "@I5@", Type: "INDI", Hash: "e"},
+	}}
+	target := &Manifest{Records: []ManifestEntry{
+		{UID: "u1", XRef: "@P9@", Type: "INDI", Hash: "a"}, // same record, renumbered
+		{UID: "u2", XRef: "@I2@", Type: "INDI", Hash: "x"}, // changed
+		{XRef: "@I3@", Type: "INDI", Hash: "c"},            // unchanged
+		{UID: "u9", XRef: "@I5@", Type: "INDI", Hash: "e"}, // different record, same XRef
+		{UID: "u8", XRef: "@I8@", Type: "INDI", Hash: "h"}, // only in target
+	}}
+
+	diff := CompareManifests(source, target)
+	xrefs := func(entries []ManifestEntry) []string {
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.XRef)
+		}
+		return out
+	}
+	if got := xrefs(diff.Missing); !reflect.DeepEqual(got, []string{"@I4@", "@I5@"}) {
+		t.Errorf("Missing = %v", got)
+	}
+	if got := xrefs(diff.Changed); !reflect.DeepEqual(got, []string{"@I2@"}) {
+		t.Errorf("Changed = %v", got)
+	}
+	if got := xrefs(diff.Extra); !reflect.DeepEqual(got, []string{"@I5@", "@I8@"}) {
+		t.Errorf("Extra = %v", got)
+	}
+	if got := xrefs(diff.Transfer()); !reflect.DeepEqual(got, []string{"@I4@", "@I5@", "@I2@"}) {
+		t.Errorf("Transfer() = %v", got)
+	}
+	if diff.IsEmpty() {
+		t.Error("IsEmpty() = true")
+	}
+
+	if !CompareManifests(source, source).IsEmpty() {
+		t.Error("a manifest should match itself")
+	}
+	if got := CompareManifests(source, nil); len(got.Missing) != len(source.Records) {
+		t.Errorf("against nil, Missing = %v", got.Missing)
+	}
+}
+
+func TestManifest_DocumentRoundTrip(t *testing.T) {
+	base := decodeManifestDoc(t, deltaBase)
+	modified := decodeManifestDoc(t, deltaModified)
+	baseManifest, err := BuildManifest(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modifiedManifest, err := BuildManifest(modified)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delta, err := DiffRecords(base, modified)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff := CompareManifests(modifiedManifest, baseManifest)
+	var missing, changed, extra []string
+	for _, e := range diff.Missing {
+		missing = append(missing, e.XRef)
+	}
+	for _, e := range diff.Changed {
+		changed = append(changed, e.XRef)
+	}
+	for _, e := range diff.Extra {
+		extra = append(extra, e.XRef)
+	}
+	if !reflect.DeepEqual(missing, delta.Added) || !reflect.DeepEqual(changed, delta.Changed) || !reflect.DeepEqual(extra, delta.Removed) {
+		t.Errorf("CompareManifests() = %v/%v/%v, DiffRecords() = %+v", missing, changed, extra, delta)
+	}
+}
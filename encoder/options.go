@@ -14,7 +14,10 @@ type EncodeOptions struct {
 
 	// MaxLineLength specifies the maximum length for line content before
 	// splitting with CONC tags. Default is 248 characters.
-	// Set to 0 to use the default value.
+	// Set to 0 to use the default value. Text written from typed entities
+	// and the text payloads of raw tags (NOTE, TEXT, CONT, ...) are split;
+	// pointers and other values are not. GEDCOM 7.0 output, which has no
+	// CONC, is never split, nor is output with PreserveFormatting.
 	MaxLineLength int
 
 	// DisableLineWrap disables automatic CONC splitting for long lines.
@@ -24,12 +27,82 @@ type EncodeOptions struct {
 	// TargetVersion specifies the GEDCOM version to target for output.
 	// This can affect header generation and tag validity.
 	// If empty, the version from the document header is preserved.
+	//
+	// GEDCOM 7.0 output follows the 7.0 line rules whatever the records
+	// were decoded from: CONC lines are joined onto the line they
+	// continue, line breaks in values are written as CONT lines, a _UID
+	// holding a UUID is written as UID, and the header always carries
+	// GEDC.VERS and never CHAR. Standard tags 7.0 removed fail encoding
+	// with an error wrapping ErrBannedTag unless Strictness is
+	// gedcom.StrictnessLenient; tags under extension tags are not checked.
 	TargetVersion gedcom.Version
 
 	// PreserveUnknownTags controls whether custom/unknown tags are included
 	// in the output. Custom tags are typically underscore-prefixed (e.g., _CUSTOM).
 	// Default: true (preserve all tags)
 	PreserveUnknownTags bool
+
+	// WriteBOM writes a UTF-8 byte order mark before the header. Some
+	// Windows products expect it; only use it for UTF-8 output.
+	WriteBOM bool
+
+	// EmailTag, when set, is written in place of both EMAIL and _EMAIL
+	// tags, for products that only read one of the two spellings.
+	EmailTag string
+
+	// SplitMidWord makes CONC splits fall between two non-space
+	// characters, as GEDCOM 5.5.1 recommends, for readers that trim
+	// whitespace at line ends. By default splits prefer word boundaries.
+	SplitMidWord bool
+
+	// CompatibilityTarget tunes the output for import into a named
+	// product (see TargetFamilySearch and the other targets). A target
+	// overrides LineEnding, WriteBOM, EmailTag, and SplitMidWord, and sets
+	// TargetVersion when it is empty. The target version only changes the
+	// header; convert documents between versions with the converter
+	// package first.
+	CompatibilityTarget CompatibilityTarget
+
+	// PreserveFormatting writes the layout a document was decoded with
+	// (see decoder.DecodeOptions.PreserveFormatting): its blank and comment
+	// lines, line endings, and byte order mark, and the header from its raw
+	// tags. An unchanged document is then written back byte-for-byte. The
+	// file's line endings and BOM override LineEnding and WriteBOM.
+	PreserveFormatting bool
+
+	// Gzip compresses the output as a gzip stream, for .ged.gz archives.
+	// The BOM and every line, including CONC continuations, are written
+	// inside the compressed stream exactly as without it; the decoder
+	// detects and decompresses such input automatically.
+	Gzip bool
+
+	// StrictCardinality makes encoding fail with an error wrapping
+	// ErrCardinality, before anything is written, when a record repeats a
+	// tag more often than the grammar of the output version allows (see
+	// gedcom.CheckTagCardinality), such as two SEX lines in an INDI
+	// record. By default such records are written as they are.
+	StrictCardinality bool
+
+	// Strictness is the strictness shared with the decoder, validator, and
+	// converter options. gedcom.StrictnessStrict implies StrictCardinality;
+	// the other levels write records as they are. StrictnessLenient also
+	// writes tags GEDCOM 7.0 removed into 7.0 output (see TargetVersion).
+	// Default: StrictnessNormal.
+	Strictness gedcom.Strictness
+
+	// Encoding selects the character set of the output and is written as
+	// the header's CHAR value. EncodingANSEL converts every line from
+	// UTF-8 to ANSEL, for GEDCOM 5.5 and 5.5.1 readers that reject UTF-8;
+	// characters ANSEL cannot represent are written as
+	// charset.ANSELReplacement, and Plan lists them. No byte order mark is
+	// written before ANSEL output. EncodingUTF8 and EncodingUNICODE only
+	// change the CHAR value. Other encodings, and ANSEL for GEDCOM 7.0
+	// output, fail with an error wrapping ErrUnsupportedEncoding.
+	// Default: the header's own CHAR value, with UTF-8 text.
+	Encoding gedcom.Encoding
+
+	// gedcom7 is set on resolved options for GEDCOM 7.0 output.
+	gedcom7 bool
 }
 
 // DefaultOptions returns the default encoding options.
@@ -39,9 +112,16 @@ func DefaultOptions() *EncodeOptions {
 		MaxLineLength:       DefaultMaxLineLength,
 		DisableLineWrap:     false,
 		PreserveUnknownTags: true,
+		Strictness:          gedcom.StrictnessNormal,
 	}
 }
 
+// strictCardinality reports whether repeated singleton tags fail encoding,
+// by StrictCardinality or Strictness.
+func (opts *EncodeOptions) strictCardinality() bool {
+	return opts.StrictCardinality || opts.Strictness == gedcom.StrictnessStrict
+}
+
 // effectiveMaxLineLength returns the max line length to use,
 // defaulting to DefaultMaxLineLength if not set.
 func (opts *EncodeOptions) effectiveMaxLineLength() int {
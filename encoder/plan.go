@@ -0,0 +1,407 @@
+package encoder
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// maxGEDCOMLineLength is the line length, in characters including the level
+// and tag, that GEDCOM readers are required to accept.
+const maxGEDCOMLineLength = 255
+
+// PlanReport describes what encoding a document with a set of options would
+// drop or change, without writing any output. See Plan.
+type PlanReport struct {
+	// SourceVersion is the version in the document header.
+	SourceVersion gedcom.Version
+
+	// TargetVersion is the version the header would declare.
+	TargetVersion gedcom.Version
+
+	// Dropped lists data that would not be written at all.
+	Dropped []gedcom.ConversionNote
+
+	// Altered lists data that would be written differently than stored.
+	Altered []gedcom.ConversionNote
+
+	// Warnings lists data that would be written as stored but that readers
+	// are likely to truncate, misparse, or reject.
+	Warnings []gedcom.ConversionNote
+}
+
+// HasLoss reports whether encoding would drop any data.
+func (r *PlanReport) HasLoss() bool {
+	return len(r.Dropped) > 0
+}
+
+// String returns a human-readable summary of the plan.
+func (r *PlanReport) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Encode: %s -> %s\n", r.SourceVersion, r.TargetVersion))
+	for _, section := range []struct {
+		name  string
+		notes []gedcom.ConversionNote
+	}{
+		{"Dropped", r.Dropped},
+		{"Altered", r.Altered},
+		{"Warnings", r.Warnings},
+	} {
+		if len(section.notes) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s: %d items\n", section.name, len(section.notes)))
+		for _, n := range section.notes {
+			sb.WriteString(fmt.Sprintf("  - %s\n", n.Path))
+			if n.Original != "" {
+				sb.WriteString(fmt.Sprintf("      Original: %s\n", n.Original))
+			}
+			if n.Result != "" {
+				sb.WriteString(fmt.Sprintf("      Result: %s\n", n.Result))
+			}
+			if n.Reason != "" {
+				sb.WriteString(fmt.Sprintf("      Reason: %s\n", n.Reason))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// Plan reports everything EncodeWithOptions would drop or alter when
+// writing doc with opts, without writing output, so an export can be
+// reviewed first. It reports:
+//
+//   - header data the encoder does not write (DATE, COPR, custom
+//     header tags, and substructures of SOUR and GEDC);
+//   - custom tags removed because PreserveUnknownTags is false;
+//   - records whose entity has no tag writer and no raw tags;
+//   - EMAIL tags renamed by EmailTag, and a header version changed by
+//     TargetVersion;
+//   - GEDCOM 7.0-only features written under a 5.5.x header;
+//   - values with embedded line breaks, which raw tags write verbatim,
+//     and lines longer than the 255 characters readers must accept that
+//     are not split with CONC (text values are, unless DisableLineWrap or
+//     PreserveFormatting is set), except in GEDCOM 7.0 output, which
+//     writes line breaks as CONT lines and has no length limit;
+//   - standard tags GEDCOM 7.0 removed, which fail 7.0 encoding;
+//   - a CHAR value changed by Encoding, and characters that ANSEL output
+//     cannot represent.
+//
+// A nil opts plans with DefaultOptions.
+func Plan(doc *gedcom.Document, opts *EncodeOptions) *PlanReport {
+	opts = opts.resolved()
+	report := &PlanReport{}
+	if doc == nil {
+		return report
+	}
+
+	header := doc.Header
+	if header == nil {
+		header = &gedcom.Header{}
+	}
+	report.SourceVersion = header.Version
+	report.TargetVersion = header.Version
+	if opts.TargetVersion != "" {
+		report.TargetVersion = opts.TargetVersion
+	}
+	opts = opts.forVersion(report.TargetVersion)
+	planHeader(report, header, opts)
+
+	if report.TargetVersion != gedcom.Version70 && report.TargetVersion != "" && doc.RequiresGEDCOM7() {
+		report.Warnings = append(report.Warnings, gedcom.ConversionNote{
+			Path:   "Document",
+			Reason: fmt.Sprintf("uses GEDCOM 7.0-only features that GEDCOM %s readers will not understand; convert with the converter package first", report.TargetVersion),
+		})
+	}
+
+	for _, record := range doc.Records {
+		if record != nil {
+			planRecord(report, record, opts)
+		}
+	}
+	if opts.rejectsBannedTags() {
+		if err := checkBannedTags(header, doc.Records, opts); err != nil {
+			report.Warnings = append(report.Warnings, gedcom.ConversionNote{
+				Path:   "Document",
+				Reason: "encoding would fail: " + err.Error(),
+			})
+		}
+	}
+	return report
+}
+
+// headerWritten lists the header structures writeHeader emits, each with
+// the substructures it emits.
+var headerWritten = map[string][]string{
+	"GEDC": {"VERS"},
+	"CHAR": nil,
+	"SOUR": nil,
+	"SUBM": nil,
+	"LANG": nil,
+	"PLAC": {"FORM"},
+}
+
+func planHeader(report *PlanReport, header *gedcom.Header, opts *EncodeOptions) {
+	planHeaderSettings(report, header, opts)
+	if len(header.Tags) == 0 {
+		planHeaderFields(report, header)
+		return
+	}
+
+	parent := ""
+	for _, tag := range header.Tags {
+		switch {
+		case tag.Level <= 1:
+			parent = tag.Tag
+			if _, ok := headerWritten[tag.Tag]; !ok {
+				report.Dropped = append(report.Dropped, gedcom.ConversionNote{
+					Path:     "Header > " + tag.Tag,
+					Original: tag.Value,
+					Reason:   "the encoder does not write this header structure",
+				})
+			}
+		case tag.Level == 2:
+			children, ok := headerWritten[parent]
+			if ok && !containsTag(children, tag.Tag) {
+				report.Dropped = append(report.Dropped, gedcom.ConversionNote{
+					Path:     "Header > " + parent + " > " + tag.Tag,
+					Original: tag.Value,
+					Reason:   "the encoder does not write this header structure",
+				})
+			}
+		}
+	}
+}
+
+// planHeaderSettings notes the header changes TargetVersion and Encoding
+// make, and warns about encodings that fail or clash with the byte order
+// mark.
+func planHeaderSettings(report *PlanReport, header *gedcom.Header, opts *EncodeOptions) {
+	if report.SourceVersion != "" && report.TargetVersion != report.SourceVersion {
+		report.Altered = append(report.Altered, gedcom.ConversionNote{
+			Path:     "Header > GEDC > VERS",
+			Original: string(report.SourceVersion),
+			Result:   string(report.TargetVersion),
+			Reason:   "TargetVersion changes only the header; records are written unconverted",
+		})
+	}
+	encoding := outputEncoding(header, opts)
+	if opts.Encoding != "" && opts.Encoding != header.Encoding {
+		report.Altered = append(report.Altered, gedcom.ConversionNote{
+			Path:     "Header > CHAR",
+			Original: string(header.Encoding),
+			Result:   string(opts.Encoding),
+			Reason:   "Encoding replaces the declared character set",
+		})
+	}
+	if err := checkEncoding(report.TargetVersion, opts); err != nil {
+		report.Warnings = append(report.Warnings, gedcom.ConversionNote{
+			Path:     "Header > CHAR",
+			Original: string(opts.Encoding),
+			Reason:   "encoding would fail: " + err.Error(),
+		})
+	}
+	if opts.writesBOM() && encoding != "" && encoding != gedcom.EncodingUTF8 {
+		report.Warnings = append(report.Warnings, gedcom.ConversionNote{
+			Path:     "Header > CHAR",
+			Original: string(encoding),
+			Reason:   "a UTF-8 byte order mark is written before a non-UTF-8 header",
+		})
+	}
+}
+
+// planHeaderFields notes the typed fields of a hand-built header that the
+// encoder skips.
+func planHeaderFields(report *PlanReport, header *gedcom.Header) {
+	for _, field := range []struct{ tag, value string }{
+		{"DATE", headerDate(header)},
+		{"COPR", header.Copyright},
+		{"SOUR > _TREE", header.AncestryTreeID},
+	} {
+		if field.value != "" {
+			report.Dropped = append(report.Dropped, gedcom.ConversionNote{
+				Path:     "Header > " + field.tag,
+				Original: field.value,
+				Reason:   "the encoder does not write this header field",
+			})
+		}
+	}
+}
+
+func headerDate(header *gedcom.Header) string {
+	if header.Date.IsZero() {
+		return ""
+	}
+	return strings.ToUpper(header.Date.Format("2 Jan 2006"))
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func planRecord(report *PlanReport, record *gedcom.Record, opts *EncodeOptions) {
+	label := recordLabel(record)
+	value, tags := planRecordTags(report, record, label, opts)
+
+	recordLine := fmt.Sprintf("0 %s", record.Type)
+	if record.XRef != "" {
+		recordLine = fmt.Sprintf("0 %s %s", record.XRef, record.Type)
+	}
+	if record.XRef != "" && value != "" {
+		recordLine += " " + value
+	}
+	planLine(report, label, recordLine, value, opts)
+
+	// path[i] holds the tag name at level i+1 of the current branch.
+	var path []string
+	skipUntilLevel := -1
+	for _, tag := range tags {
+		if skipUntilLevel >= 0 && tag.Level <= skipUntilLevel {
+			skipUntilLevel = -1
+		}
+		if skipUntilLevel >= 0 {
+			continue
+		}
+		if tag.Level >= 1 && tag.Level <= len(path)+1 {
+			path = append(path[:tag.Level-1], tag.Tag)
+		}
+		if !planTag(report, label+" > "+strings.Join(path, " > "), tag, opts) {
+			skipUntilLevel = tag.Level
+		}
+	}
+}
+
+// planRecordTags returns the record value and the tags the encoder writes
+// for record: its raw tags, or those of its entity, wrapped as the output
+// requires. It notes entities the encoder cannot write.
+func planRecordTags(report *PlanReport, record *gedcom.Record, label string, opts *EncodeOptions) (string, []*gedcom.Tag) {
+	tags := record.Tags
+	value := record.Value
+	if len(tags) == 0 && record.Entity != nil {
+		if !hasEntityWriter(record) {
+			report.Dropped = append(report.Dropped, gedcom.ConversionNote{
+				Path:     label,
+				Original: fmt.Sprintf("%T", record.Entity),
+				Reason:   "the record has no raw tags and the encoder cannot write this entity; only the record line is written",
+			})
+		}
+		tags = entityToTags(record, opts)
+		if value == "" {
+			var contTags []*gedcom.Tag
+			value, contTags = entityRecordText(record, opts)
+			tags = append(contTags, tags...)
+		}
+	}
+	if opts.wrapsRawLines() {
+		value, tags = wrapLongLines(value, tags, string(record.Type), opts)
+	}
+	return value, tags
+}
+
+// planTag plans the line of tag, at tagPath, and reports whether it is
+// written; a custom tag dropped by PreserveUnknownTags is not, nor is its
+// subtree.
+func planTag(report *PlanReport, tagPath string, tag *gedcom.Tag, opts *EncodeOptions) bool {
+	if !opts.PreserveUnknownTags && isCustomTag(tag.Tag) {
+		report.Dropped = append(report.Dropped, gedcom.ConversionNote{
+			Path:     tagPath,
+			Original: tag.Value,
+			Reason:   "custom tag removed because PreserveUnknownTags is false",
+		})
+		return false
+	}
+
+	name := tag.Tag
+	if opts.EmailTag != "" && (name == "EMAIL" || name == "_EMAIL") && name != opts.EmailTag {
+		report.Altered = append(report.Altered, gedcom.ConversionNote{
+			Path:     tagPath,
+			Original: name,
+			Result:   opts.EmailTag,
+			Reason:   "EmailTag renames email tags",
+		})
+		name = opts.EmailTag
+	}
+
+	line := fmt.Sprintf("%d %s", tag.Level, name)
+	if tag.Value != "" {
+		line += " " + tag.Value
+	}
+	planLine(report, tagPath, line, tag.Value, opts)
+	return true
+}
+
+// planLine warns about a line that readers are likely to mishandle, and
+// notes characters the output encoding cannot represent.
+func planLine(report *PlanReport, path, line, value string, opts *EncodeOptions) {
+	planEncoding(report, path, value, opts)
+	if opts.gedcom7 {
+		return
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		report.Warnings = append(report.Warnings, gedcom.ConversionNote{
+			Path:     path,
+			Original: value,
+			Reason:   "the value contains a line break, which is written verbatim and breaks the line structure; use CONT",
+		})
+		return
+	}
+	if n := utf8.RuneCountInString(line); n > maxGEDCOMLineLength {
+		report.Warnings = append(report.Warnings, gedcom.ConversionNote{
+			Path:     path,
+			Original: value,
+			Reason:   fmt.Sprintf("the line is %d characters, over the %d readers must accept; some readers truncate it", n, maxGEDCOMLineLength),
+		})
+	}
+}
+
+// hasEntityWriter reports whether entityToTags can write the record's entity.
+func hasEntityWriter(record *gedcom.Record) bool {
+	switch record.Entity.(type) {
+	case *gedcom.Individual:
+		return record.Type == gedcom.RecordTypeIndividual
+	case *gedcom.Family:
+		return record.Type == gedcom.RecordTypeFamily
+	case *gedcom.Source:
+		return record.Type == gedcom.RecordTypeSource
+	case *gedcom.Submitter:
+		return record.Type == gedcom.RecordTypeSubmitter
+	case *gedcom.Repository:
+		return record.Type == gedcom.RecordTypeRepository
+	case *gedcom.Note:
+		return record.Type == gedcom.RecordTypeNote
+	case *gedcom.MediaObject:
+		return record.Type == gedcom.RecordTypeMedia
+	case *gedcom.SharedNote:
+		return record.Type == gedcom.RecordTypeSharedNote
+	}
+	return false
+}
+
+// recordLabels maps record types to the labels used in report paths.
+var recordLabels = map[gedcom.RecordType]string{
+	gedcom.RecordTypeIndividual: "Individual",
+	gedcom.RecordTypeFamily:     "Family",
+	gedcom.RecordTypeSource:     "Source",
+	gedcom.RecordTypeRepository: "Repository",
+	gedcom.RecordTypeNote:       "Note",
+	gedcom.RecordTypeMedia:      "MediaObject",
+	gedcom.RecordTypeSubmitter:  "Submitter",
+}
+
+func recordLabel(record *gedcom.Record) string {
+	label, ok := recordLabels[record.Type]
+	if !ok {
+		label = string(record.Type)
+	}
+	if record.XRef == "" {
+		return label
+	}
+	return label + " " + record.XRef
+}
@@ -0,0 +1,131 @@
+package encoder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const planInput = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+2 FORM LINEAGE-LINKED
+1 CHAR UTF-8
+1 SOUR Ancestry.com
+2 _TREE Smith Family
+1 DATE 1 JAN 2020
+1 _RTLSAVE RTL
+0 @I1@ INDI
+1 NAME John /Smith/
+1 _UID 1234
+2 _SRC private
+1 BIRT
+2 DATE 1850
+0 @U1@ SUBM
+1 NAME Jane
+1 _EMAIL jane@example.com
+0 TRLR
+`
+
+func planPaths(notes []gedcom.ConversionNote) string {
+	var paths []string
+	for _, n := range notes {
+		paths = append(paths, n.Path)
+	}
+	return strings.Join(paths, "; ")
+}
+
+func TestPlan(t *testing.T) {
+	doc, err := decoder.Decode(strings.NewReader(planInput))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	report := Plan(doc, nil)
+	if got := planPaths(report.Dropped); got != "Header > GEDC > FORM; Header > SOUR > _TREE; Header > DATE; Header > _RTLSAVE" {
+		t.Errorf("default Dropped = %q", got)
+	}
+	if len(report.Altered) != 0 || len(report.Warnings) != 0 {
+		t.Errorf("default Altered = %v, Warnings = %v", report.Altered, report.Warnings)
+	}
+
+	opts := DefaultOptions()
+	opts.PreserveUnknownTags = false
+	opts.TargetVersion = gedcom.Version70
+	report = Plan(doc, opts)
+	if !report.HasLoss() {
+		t.Error("HasLoss() = false")
+	}
+	// Each custom subtree is reported once, at its root.
+	if got := planPaths(report.Dropped[4:]); got != "Individual @I1@ > _UID; Submitter @U1@ > _EMAIL" {
+		t.Errorf("Dropped = %q", got)
+	}
+	if got := planPaths(report.Altered); got != "Header > GEDC > VERS" {
+		t.Errorf("Altered = %q", got)
+	}
+	if report.SourceVersion != gedcom.Version551 || report.TargetVersion != gedcom.Version70 {
+		t.Errorf("versions = %s -> %s", report.SourceVersion, report.TargetVersion)
+	}
+	if s := report.String(); !strings.Contains(s, "Encode: 5.5.1 -> 7.0") || !strings.Contains(s, "Dropped: 6 items") {
+		t.Errorf("String() = %q", s)
+	}
+
+	opts = DefaultOptions()
+	opts.CompatibilityTarget = TargetGramps
+	report = Plan(doc, opts)
+	if got := planPaths(report.Altered); got != "Submitter @U1@ > _EMAIL" {
+		t.Errorf("Gramps Altered = %q", got)
+	}
+	if len(report.Altered) == 1 && report.Altered[0].Result != "EMAIL" {
+		t.Errorf("Gramps Altered result = %q", report.Altered[0].Result)
+	}
+}
+
+func TestPlan_Warnings(t *testing.T) {
+	long := strings.Repeat("x", 300)
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version551, Encoding: gedcom.EncodingANSEL, Copyright: "(c) 2020"},
+		Records: []*gedcom.Record{
+			{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Tags: []*gedcom.Tag{
+				{Level: 1, Tag: "NOTE", Value: "line one\nline two"},
+				{Level: 1, Tag: "OCCU", Value: long},
+				{Level: 1, Tag: "_CODE", Value: long},
+			}},
+			{XRef: "@I2@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Individual{
+				XRef:        "@I2@",
+				InlineNotes: []string{long},
+				ExternalIDs: []*gedcom.ExternalID{{Value: "123", Type: "https://example.com"}},
+			}},
+			{XRef: "@X1@", Type: gedcom.RecordType("_LOC"), Entity: &gedcom.Individual{XRef: "@X1@"}},
+		},
+	}
+	opts := DefaultOptions()
+	opts.WriteBOM = true
+	report := Plan(doc, opts)
+
+	if got := planPaths(report.Dropped); got != "Header > COPR; _LOC @X1@" {
+		t.Errorf("Dropped = %q", got)
+	}
+	// The long note and OCCU text are wrapped with CONC, so only the
+	// custom _CODE line is too long.
+	if got := planPaths(report.Warnings); got != "Header > CHAR; Document; Individual @I1@ > NOTE; Individual @I1@ > _CODE" {
+		t.Errorf("Warnings = %q", got)
+	}
+
+	opts.DisableLineWrap = true
+	if got := planPaths(Plan(doc, opts).Warnings); !strings.HasSuffix(got, "Individual @I1@ > OCCU; Individual @I1@ > _CODE; Individual @I2@ > NOTE") {
+		t.Errorf("DisableLineWrap Warnings = %q", got)
+	}
+}
+
+func TestPlan_Clean(t *testing.T) {
+	report := Plan(compatDocument(), nil)
+	if report.HasLoss() || len(report.Altered) != 0 || len(report.Warnings) != 0 {
+		t.Errorf("Plan() = %+v, want empty", report)
+	}
+	if Plan(nil, nil).HasLoss() {
+		t.Error("Plan(nil) HasLoss() = true")
+	}
+}
@@ -2,10 +2,12 @@ package encoder
 
 import (
 	"bufio"
+	"compress/gzip"
 	"errors"
 	"fmt"
 	"io"
 
+	"github.com/cacack/gedcom-go/v2/charset"
 	"github.com/cacack/gedcom-go/v2/gedcom"
 )
 
@@ -77,9 +79,16 @@ func (s encodeState) String() string {
 //	}
 type StreamEncoder struct {
 	writer  *bufio.Writer
+	gzip    *gzip.Writer         // non-nil when EncodeOptions.Gzip is set
+	ansel   *charset.ANSELWriter // non-nil when EncodeOptions.Encoding is ANSEL
 	options *EncodeOptions
+	version gedcom.Version // output version, for StrictCardinality
 	state   encodeState
 	err     error // sticky error for early exit
+
+	// autoTrailer makes Close write the trailer when it is missing; set
+	// by NewStreamEncoderWithHeader.
+	autoTrailer bool
 }
 
 // Errors returned by StreamEncoder for invalid state transitions.
@@ -99,15 +108,55 @@ func NewStreamEncoder(w io.Writer) *StreamEncoder {
 
 // NewStreamEncoderWithOptions creates a new StreamEncoder with custom options.
 // If opts is nil, default options are used.
+//
+// With EncodeOptions.Gzip, records are compressed as they are written;
+// Flush also flushes the compressor so that the output so far can be
+// decompressed, and Close ends the gzip stream. With EncodeOptions.Encoding
+// set to ANSEL, lines are converted to ANSEL as they are written; an
+// unsupported Encoding is returned by WriteHeader.
 func NewStreamEncoderWithOptions(w io.Writer, opts *EncodeOptions) *StreamEncoder {
-	if opts == nil {
-		opts = DefaultOptions()
-	}
-	return &StreamEncoder{
-		writer:  bufio.NewWriter(w),
-		options: opts,
+	e := &StreamEncoder{
+		options: opts.resolved(),
 		state:   stateInitial,
 	}
+	if e.options.Gzip {
+		e.gzip = gzip.NewWriter(w)
+		w = e.gzip
+	}
+	if e.options.Encoding == gedcom.EncodingANSEL {
+		e.ansel = charset.NewANSELWriter(w)
+		w = e.ansel
+	}
+	e.writer = bufio.NewWriterSize(w, bufferSize)
+	return e
+}
+
+// NewStreamEncoderWithHeader creates a StreamEncoder that has already
+// written header, for pipelines that only emit records:
+//
+//	enc := encoder.NewStreamEncoderWithHeader(w, header, nil)
+//	for rec := range records {
+//	    if err := enc.WriteRecord(rec); err != nil {
+//	        return err
+//	    }
+//	}
+//	return enc.Close() // writes 0 TRLR and flushes
+//
+// Close writes the trailer if WriteTrailer has not been called, so a
+// file is complete once Close succeeds. A nil header writes an empty
+// "0 HEAD". If opts is nil, default options are used. An error writing the
+// header is returned by the first later call and by Err.
+//
+// Paired with a record-at-a-time decoder, memory stays bounded by the
+// largest record rather than the document.
+func NewStreamEncoderWithHeader(w io.Writer, header *gedcom.Header, opts *EncodeOptions) *StreamEncoder {
+	e := NewStreamEncoderWithOptions(w, opts)
+	e.autoTrailer = true
+	if header == nil {
+		header = &gedcom.Header{}
+	}
+	_ = e.WriteHeader(header) // sticky on failure
+	return e
 }
 
 // WriteHeader writes the GEDCOM header. This must be the first method called
@@ -129,11 +178,24 @@ func (e *StreamEncoder) WriteHeader(h *gedcom.Header) error {
 		return ErrEncodingComplete
 	}
 
-	if err := writeHeader(e.writer, h, e.options); err != nil {
+	version := outputVersion(h, e.options)
+	if err := checkEncoding(version, e.options); err != nil {
+		e.err = err
+		return err
+	}
+	e.options = e.options.forVersion(version)
+	if e.options.rejectsBannedTags() {
+		if err := checkBannedTags(h, nil, e.options); err != nil {
+			e.err = err
+			return err
+		}
+	}
+	if err := writeHeader(e.writer, h, nil, e.options); err != nil {
 		e.err = err
 		return err
 	}
 
+	e.version = version
 	e.state = stateHeaderWritten
 	return nil
 }
@@ -143,7 +205,11 @@ func (e *StreamEncoder) WriteHeader(h *gedcom.Header) error {
 // multiple records.
 //
 // Returns ErrHeaderNotWritten if the header has not been written,
-// or ErrEncodingComplete if the encoding is already complete.
+// or ErrEncodingComplete if the encoding is already complete. With
+// EncodeOptions.StrictCardinality, a record that repeats a singleton tag is
+// not written and an error wrapping ErrCardinality is returned; later
+// records can still be written. GEDCOM 7.0 records holding tags 7.0
+// removed are refused the same way, with ErrBannedTag.
 func (e *StreamEncoder) WriteRecord(r *gedcom.Record) error {
 	if e.err != nil {
 		return e.err
@@ -158,6 +224,17 @@ func (e *StreamEncoder) WriteRecord(r *gedcom.Record) error {
 		return ErrEncodingComplete
 	}
 
+	if e.options.strictCardinality() {
+		if err := checkRecordCardinality(r, e.version, e.options); err != nil {
+			return err
+		}
+	}
+	if e.options.rejectsBannedTags() {
+		if err := bannedTagError(recordBannedTags(r, e.options)); err != nil {
+			return err
+		}
+	}
+
 	if err := writeRecord(e.writer, r, e.options); err != nil {
 		e.err = err
 		return err
@@ -205,6 +282,18 @@ func (e *StreamEncoder) Flush() error {
 		e.err = err
 		return err
 	}
+	if e.ansel != nil {
+		if err := e.ansel.Flush(); err != nil {
+			e.err = err
+			return err
+		}
+	}
+	if e.gzip != nil {
+		if err := e.gzip.Flush(); err != nil {
+			e.err = err
+			return err
+		}
+	}
 	return nil
 }
 
@@ -214,10 +303,28 @@ func (e *StreamEncoder) Flush() error {
 // file). Always check Close's return value rather than using a bare
 // `defer enc.Close()`, which silently drops the error.
 //
+// An encoder created with NewStreamEncoderWithHeader writes the missing
+// trailer itself instead of returning [ErrTrailerNotWritten].
+//
 // After Close is called, no further writes are allowed.
 func (e *StreamEncoder) Close() error {
+	if e.autoTrailer && e.err == nil && (e.state == stateHeaderWritten || e.state == stateRecordsWritten) {
+		_ = e.WriteTrailer() // sticky on failure
+	}
+
 	// Always flush, even if there's an error
 	flushErr := e.writer.Flush()
+	if e.ansel != nil {
+		if err := e.ansel.Flush(); flushErr == nil {
+			flushErr = err
+		}
+	}
+	if e.gzip != nil {
+		// Close ends the gzip stream but not the underlying writer.
+		if err := e.gzip.Close(); flushErr == nil {
+			flushErr = err
+		}
+	}
 
 	// If we already have a sticky error, return it
 	if e.err != nil {
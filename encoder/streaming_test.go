@@ -553,6 +553,60 @@ func TestStreamEncoder_WriteErrors(t *testing.T) {
 
 }
 
+func TestNewStreamEncoderWithHeader(t *testing.T) {
+	t.Run("records then Close", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := NewStreamEncoderWithHeader(&buf, &gedcom.Header{Version: "5.5.1", Encoding: "UTF-8"}, nil)
+		if enc.State() != "HeaderWritten" {
+			t.Fatalf("State() = %v, want HeaderWritten", enc.State())
+		}
+		for _, xref := range []string{"@I1@", "@I2@"} {
+			record := &gedcom.Record{XRef: xref, Type: gedcom.RecordTypeIndividual, Tags: []*gedcom.Tag{
+				{Level: 1, Tag: "NAME", Value: "John /Smith/"},
+			}}
+			if err := enc.WriteRecord(record); err != nil {
+				t.Fatalf("WriteRecord() error = %v", err)
+			}
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+
+		want := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 CHAR UTF-8\n" +
+			"0 @I1@ INDI\n1 NAME John /Smith/\n0 @I2@ INDI\n1 NAME John /Smith/\n0 TRLR\n"
+		if buf.String() != want {
+			t.Errorf("output = %q, want %q", buf.String(), want)
+		}
+		if err := enc.WriteRecord(&gedcom.Record{XRef: "@I3@"}); !errors.Is(err, ErrEncodingComplete) {
+			t.Errorf("WriteRecord() after Close error = %v, want ErrEncodingComplete", err)
+		}
+	})
+
+	t.Run("explicit trailer is not repeated", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := NewStreamEncoderWithHeader(&buf, nil, &EncodeOptions{LineEnding: "\r\n"})
+		if err := enc.WriteTrailer(); err != nil {
+			t.Fatalf("WriteTrailer() error = %v", err)
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+		if buf.String() != "0 HEAD\r\n0 TRLR\r\n" {
+			t.Errorf("output = %q", buf.String())
+		}
+	})
+
+	t.Run("write failure is sticky", func(t *testing.T) {
+		enc := NewStreamEncoderWithHeader(&streamFailWriter{failAfterBytes: 0}, &gedcom.Header{}, nil)
+		if err := enc.Close(); err == nil {
+			t.Error("Close() should fail when the underlying writer fails")
+		}
+		if enc.Err() == nil {
+			t.Error("Err() should report the failure")
+		}
+	})
+}
+
 func TestStreamEncoder_Err(t *testing.T) {
 	var buf bytes.Buffer
 	enc := NewStreamEncoder(&buf)
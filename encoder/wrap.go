@@ -0,0 +1,82 @@
+package encoder
+
+import (
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// wrapsRawLines reports whether long values of raw tags are split with
+// CONC: for GEDCOM 5.5 and 5.5.1 output, unless line wrapping is disabled
+// or the decoded layout is preserved byte for byte.
+func (opts *EncodeOptions) wrapsRawLines() bool {
+	return !opts.gedcom7 && !opts.DisableLineWrap && !opts.PreserveFormatting
+}
+
+// wrapLongLines returns the record line value and tags of a record of type
+// recordType with every text value longer than MaxLineLength split into
+// CONC lines, as textToTags splits the text of typed entities. The CONC
+// lines of a value follow its line, at the level of its CONT lines, so
+// the joined text is unchanged. Only text payloads (NOTE, TEXT, TITL, ...)
+// and CONT lines are split; pointers and values of other tags are written
+// as they are. Changed tags are copies; tags is not modified.
+func wrapLongLines(value string, tags []*gedcom.Tag, recordType string, opts *EncodeOptions) (string, []*gedcom.Tag) {
+	maxLen := opts.effectiveMaxLineLength()
+	if !hasLongText(value, tags, recordType, maxLen) {
+		return value, tags
+	}
+
+	out := make([]*gedcom.Tag, 0, len(tags)+1)
+	if wrapsText(recordType, value, maxLen) {
+		segments := splitLineForLength(value, opts)
+		value = segments[0]
+		for _, segment := range segments[1:] {
+			out = append(out, &gedcom.Tag{Level: 1, Tag: "CONC", Value: segment})
+		}
+	}
+	for _, tag := range tags {
+		if !wrapsText(tag.Tag, tag.Value, maxLen) {
+			out = append(out, tag)
+			continue
+		}
+		level := tag.Level + 1
+		if tag.Tag == "CONT" || tag.Tag == "CONC" {
+			level = tag.Level
+		}
+		segments := splitLineForLength(tag.Value, opts)
+		first := *tag
+		first.Value = segments[0]
+		out = append(out, &first)
+		for _, segment := range segments[1:] {
+			out = append(out, &gedcom.Tag{Level: level, Tag: "CONC", Value: segment})
+		}
+	}
+	return value, out
+}
+
+// hasLongText reports whether wrapLongLines would split anything.
+func hasLongText(value string, tags []*gedcom.Tag, recordType string, maxLen int) bool {
+	if wrapsText(recordType, value, maxLen) {
+		return true
+	}
+	for _, tag := range tags {
+		if wrapsText(tag.Tag, tag.Value, maxLen) {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapsText reports whether the value of tag is text longer than maxLen
+// that CONC may continue: the payload of a continuation line or of a
+// standard tag with a text payload, and not a pointer.
+func wrapsText(tag, value string, maxLen int) bool {
+	if len(value) <= maxLen || strings.HasPrefix(value, "@") && !strings.HasPrefix(value, "@@") {
+		return false
+	}
+	if tag == "CONT" || tag == "CONC" {
+		return true
+	}
+	info, ok := gedcom.LookupStandardTag(tag)
+	return ok && info.Payload == gedcom.TagPayloadText
+}
@@ -0,0 +1,124 @@
+package encoder
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func TestWrapLongLines(t *testing.T) {
+	long := strings.Repeat("abcdefghij", 3) // 30 characters
+	opts := DefaultOptions()
+	opts.MaxLineLength = 12
+
+	tests := []struct {
+		name       string
+		value      string
+		recordType string
+		tags       []*gedcom.Tag
+		wantValue  string
+		want       string
+	}{
+		{
+			name:       "NOTE value",
+			recordType: "INDI",
+			tags:       []*gedcom.Tag{{Level: 1, Tag: "NOTE", Value: long}, {Level: 2, Tag: "SOUR", Value: "@S1@"}},
+			want:       "1 NOTE abcdefghijab|2 CONC cdefghijabcd|2 CONC efghij|2 SOUR @S1@",
+		},
+		{
+			name:       "CONT line",
+			recordType: "INDI",
+			tags:       []*gedcom.Tag{{Level: 1, Tag: "NOTE", Value: "short"}, {Level: 2, Tag: "CONT", Value: long}},
+			want:       "1 NOTE short|2 CONT abcdefghijab|2 CONC cdefghijabcd|2 CONC efghij",
+		},
+		{
+			name:       "record line of a shared note",
+			value:      long,
+			recordType: "NOTE",
+			tags:       []*gedcom.Tag{{Level: 1, Tag: "CONT", Value: "next"}},
+			wantValue:  "abcdefghijab",
+			want:       "1 CONC cdefghijabcd|1 CONC efghij|1 CONT next",
+		},
+		{
+			name:       "pointers, non-text and custom tags left alone",
+			recordType: "INDI",
+			tags: []*gedcom.Tag{
+				{Level: 1, Tag: "NOTE", Value: "@" + long + "@"},
+				{Level: 1, Tag: "SEX", Value: long},
+				{Level: 1, Tag: "_CODE", Value: long},
+			},
+			want: "1 NOTE @" + long + "@|1 SEX " + long + "|1 _CODE " + long,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := tagLines(tt.tags)
+			value, tags := wrapLongLines(tt.value, tt.tags, tt.recordType, opts)
+			if value != tt.wantValue {
+				t.Errorf("value = %q, want %q", value, tt.wantValue)
+			}
+			if got := tagLines(tags); got != tt.want {
+				t.Errorf("tags = %q, want %q", got, tt.want)
+			}
+			if tagLines(tt.tags) != before {
+				t.Error("wrapLongLines modified its input")
+			}
+			// Joining the CONC lines gives back the original text
+			if joined, _ := gedcom7Tags(value, tags); joined != tt.value {
+				t.Errorf("joined value = %q, want %q", joined, tt.value)
+			}
+			if _, joined := gedcom7Tags(value, tags); tagLines(joined) != before {
+				t.Errorf("joined tags = %q, want %q", tagLines(joined), before)
+			}
+		})
+	}
+
+	short := []*gedcom.Tag{{Level: 1, Tag: "NOTE", Value: "short"}}
+	if _, tags := wrapLongLines("", short, "INDI", opts); &tags[0] != &short[0] {
+		t.Error("wrapLongLines copied tags that need no change")
+	}
+}
+
+func TestEncode_WrapsRawText(t *testing.T) {
+	long := strings.Repeat("word ", 60) // 300 characters
+	doc := &gedcom.Document{
+		Header: &gedcom.Header{Version: gedcom.Version551, Encoding: gedcom.EncodingUTF8},
+		Records: []*gedcom.Record{{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Tags: []*gedcom.Tag{
+			{Level: 1, Tag: "NOTE", Value: long},
+		}}},
+	}
+
+	encode := func(opts *EncodeOptions) string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := EncodeWithOptions(&buf, doc, opts); err != nil {
+			t.Fatalf("EncodeWithOptions() error = %v", err)
+		}
+		return buf.String()
+	}
+
+	out := encode(DefaultOptions())
+	if !strings.Contains(out, "1 NOTE "+strings.Repeat("word ", 49)+"\n2 CONC "+strings.Repeat("word ", 11)+"\n") {
+		t.Errorf("default output =\n%s", out)
+	}
+
+	opts := DefaultOptions()
+	opts.MaxLineLength = 100
+	if got := strings.Count(encode(opts), "2 CONC "); got != 2 {
+		t.Errorf("MaxLineLength 100 wrote %d CONC lines, want 2", got)
+	}
+
+	for name, set := range map[string]func(*EncodeOptions){
+		"DisableLineWrap":    func(o *EncodeOptions) { o.DisableLineWrap = true },
+		"PreserveFormatting": func(o *EncodeOptions) { o.PreserveFormatting = true },
+		"GEDCOM 7.0":         func(o *EncodeOptions) { o.TargetVersion = gedcom.Version70 },
+	} {
+		opts := DefaultOptions()
+		set(opts)
+		if out := encode(opts); strings.Contains(out, "CONC") || !strings.Contains(out, "1 NOTE "+long+"\n") {
+			t.Errorf("%s output =\n%s", name, out)
+		}
+	}
+}
@@ -50,11 +50,8 @@ func main() {
 				fmt.Printf(" (%s)", ind.Sex)
 			}
 			// Show birth year if available
-			for _, event := range ind.Events {
-				if event.Type == "BIRT" && event.Date != "" {
-					fmt.Printf(" - Born: %s", event.Date)
-					break
-				}
+			if birth := ind.BirthEvent(); birth != nil && birth.Date != "" {
+				fmt.Printf(" - Born: %s", birth.Date)
 			}
 			fmt.Println()
 		}
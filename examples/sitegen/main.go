@@ -0,0 +1,375 @@
+// Package main generates a static HTML site from a GEDCOM file: a page per
+// individual and family, a surname index, a source list, and a GeoJSON map
+// of located events, with living individuals redacted before publishing.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+	"github.com/cacack/gedcom-go/v2/place"
+	"github.com/cacack/gedcom-go/v2/privacy"
+	"github.com/cacack/gedcom-go/v2/report"
+)
+
+func main() {
+	out := flag.String("out", "site", "output directory")
+	living := flag.String("living", "anonymize", "living individuals: anonymize, strip, or show")
+	maxAge := flag.Int("max-age", privacy.DefaultMaxAge, "age beyond which an individual is presumed dead")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: go run . [flags] <gedcom_file>")
+		fmt.Fprintln(os.Stderr, "Example: go run . -out /tmp/royal92 ../../testdata/gedcom-5.5/royal92.ged")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	filename := filepath.Clean(flag.Arg(0))
+	f, err := os.Open(filename)
+	if err != nil {
+		log.Fatalf("Failed to open file: %v", err)
+	}
+	defer f.Close()
+
+	doc, err := decoder.Decode(f)
+	if err != nil {
+		log.Fatalf("Failed to decode GEDCOM: %v", err)
+	}
+
+	// Redact living individuals before anything is rendered
+	if *living != "show" {
+		opts := &privacy.Options{MaxAge: *maxAge, KeepSurnames: true}
+		switch *living {
+		case "anonymize":
+		case "strip":
+			opts.Mode = privacy.ModeStrip
+		default:
+			log.Fatalf("Unknown -living value %q (want anonymize, strip, or show)", *living)
+		}
+		redacted, rep, err := privacy.Redact(doc, opts)
+		if err != nil {
+			log.Fatalf("Failed to redact: %v", err)
+		}
+		doc = redacted
+		fmt.Printf("Redacted %d living and %d restricted individuals (%s), removed %d records\n",
+			len(rep.Living), len(rep.Restricted), opts.Mode, len(rep.Removed))
+	}
+
+	s := &site{doc: doc, dir: *out, tmpl: template.Must(template.New("site").Parse(pageTemplates))}
+	if err := s.generate(); err != nil {
+		log.Fatalf("Failed to generate site: %v", err)
+	}
+	fmt.Printf("Wrote %d individuals and %d families to %s\n", len(doc.Individuals()), len(doc.Families()), *out)
+}
+
+// site renders the pages of one document into dir.
+type site struct {
+	doc  *gedcom.Document
+	dir  string
+	tmpl *template.Template
+}
+
+// link is a hyperlink to another page of the site.
+type link struct {
+	Href string
+	Text string
+}
+
+// eventRow is one event of a family page.
+type eventRow struct {
+	Name, Date, Place string
+}
+
+// stop is one located stop on an individual's path, with a map link if it
+// has coordinates.
+type stop struct {
+	Name, Date, Place string
+	Map               string
+}
+
+// personPage is the data of an individual's page.
+type personPage struct {
+	Title     string
+	Lifespan  string
+	Narrative template.HTML
+	Notes     []template.HTML
+	Parents   []link
+	Families  []link
+	Stops     []stop
+}
+
+// familyPage is the data of a family's page.
+type familyPage struct {
+	Title    string
+	Partners []link
+	Events   []eventRow
+	Children []link
+}
+
+// surnameGroup is one surname of the index and its bearers.
+type surnameGroup struct {
+	Surname string
+	People  []link
+}
+
+// sourceEntry is one source of the source list.
+type sourceEntry struct {
+	Text      template.HTML
+	Citations []citationEntry
+}
+
+// citationEntry is one citation of a source and the pages citing it.
+type citationEntry struct {
+	Text    template.HTML
+	CitedBy []link
+}
+
+// generate writes every page of the site.
+func (s *site) generate() error {
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return err
+	}
+	for _, ind := range s.doc.Individuals() {
+		page, err := s.personPage(ind)
+		if err != nil {
+			return err
+		}
+		if err := s.write(pageName(ind.XRef), "person", page); err != nil {
+			return err
+		}
+	}
+	for _, fam := range s.doc.Families() {
+		if err := s.write(pageName(fam.XRef), "family", s.familyPage(fam)); err != nil {
+			return err
+		}
+	}
+	if err := s.write("index.html", "index", s.surnameIndex()); err != nil {
+		return err
+	}
+	if err := s.write("sources.html", "sources", s.sources()); err != nil {
+		return err
+	}
+	return s.writeMap()
+}
+
+// personPage collects the data of an individual's page: the narrative of
+// their life from the report package, their family links, and the path of
+// their located events from the place package.
+func (s *site) personPage(ind *gedcom.Individual) (*personPage, error) {
+	page := &personPage{Title: s.name(ind), Lifespan: lifespan(ind)}
+
+	narrative, err := report.IndividualNarrative(s.doc, ind.XRef, &report.NarrativeOptions{Markup: report.MarkupHTML})
+	if err != nil {
+		return nil, err
+	}
+	// The narrative is HTML with the document's text already escaped
+	page.Narrative = template.HTML(narrative.Text) //nolint:gosec // escaped by report.MarkupHTML
+	for _, note := range narrative.Notes {
+		page.Notes = append(page.Notes, template.HTML(note)) //nolint:gosec // escaped by report.MarkupHTML
+	}
+
+	for _, parent := range ind.Parents(s.doc) {
+		page.Parents = append(page.Parents, s.individualLink(parent))
+	}
+	for _, fam := range ind.SpouseFamilies(s.doc) {
+		page.Families = append(page.Families, s.familyLink(fam))
+	}
+
+	if path := place.IndividualPath(s.doc, ind.XRef, nil); path != nil {
+		for _, st := range path.Stops {
+			row := stop{Name: eventName(st.EventType), Date: st.Date, Place: st.Place}
+			if st.HasCoordinates {
+				row.Map = fmt.Sprintf("https://www.openstreetmap.org/?mlat=%.5f&mlon=%.5f#map=10/%.5f/%.5f",
+					st.Latitude, st.Longitude, st.Latitude, st.Longitude)
+			}
+			page.Stops = append(page.Stops, row)
+		}
+	}
+	return page, nil
+}
+
+// familyPage collects the data of a family's page.
+func (s *site) familyPage(fam *gedcom.Family) *familyPage {
+	page := &familyPage{Title: s.familyLink(fam).Text}
+	for _, partner := range fam.PartnerIndividuals(s.doc) {
+		page.Partners = append(page.Partners, s.individualLink(partner))
+	}
+	for _, event := range fam.EventsChronological() {
+		if event.IsNegative {
+			continue
+		}
+		page.Events = append(page.Events, eventRow{Name: eventName(event.Type), Date: event.Date, Place: event.Place})
+	}
+	for _, child := range fam.ChildrenIndividuals(s.doc) {
+		page.Children = append(page.Children, s.individualLink(child))
+	}
+	return page
+}
+
+// surnameIndex groups the individuals by surname, in surname order, with
+// the individuals without a surname last.
+func (s *site) surnameIndex() []surnameGroup {
+	const noSurname = "(no surname)"
+	bySurname := make(map[string]*surnameGroup)
+	var groups []*surnameGroup
+	for _, ind := range s.doc.Individuals(gedcom.SortByName) {
+		surname := noSurname
+		if len(ind.Names) > 0 && ind.Names[0].Surname != "" {
+			surname = ind.Names[0].Surname
+		}
+		key := strings.ToLower(surname)
+		g := bySurname[key]
+		if g == nil {
+			g = &surnameGroup{Surname: surname}
+			bySurname[key] = g
+			groups = append(groups, g)
+		}
+		g.People = append(g.People, s.individualLink(ind))
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if (groups[i].Surname == noSurname) != (groups[j].Surname == noSurname) {
+			return groups[j].Surname == noSurname
+		}
+		return strings.ToLower(groups[i].Surname) < strings.ToLower(groups[j].Surname)
+	})
+	result := make([]surnameGroup, len(groups))
+	for i, g := range groups {
+		result[i] = *g
+	}
+	return result
+}
+
+// sources formats the source list with the report package's bibliography.
+func (s *site) sources() []sourceEntry {
+	var entries []sourceEntry
+	for _, entry := range report.Bibliography(s.doc, &report.BibliographyOptions{Markup: report.MarkupHTML}) {
+		e := sourceEntry{Text: template.HTML(entry.Text)} //nolint:gosec // escaped by report.MarkupHTML
+		for _, c := range entry.Citations {
+			citation := citationEntry{Text: template.HTML(c.Text)} //nolint:gosec // escaped by report.MarkupHTML
+			for _, xref := range c.CitedBy {
+				if l, ok := s.recordLink(xref); ok {
+					citation.CitedBy = append(citation.CitedBy, l)
+				}
+			}
+			e.Citations = append(e.Citations, citation)
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// writeMap writes the located events as GeoJSON with the place package.
+func (s *site) writeMap() error {
+	f, err := os.Create(filepath.Join(s.dir, "places.geojson"))
+	if err != nil {
+		return err
+	}
+	if err := place.WriteGeoJSON(f, s.doc, nil); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// write renders the named template with data into the file name.
+func (s *site) write(name, tmpl string, data interface{}) error {
+	f, err := os.Create(filepath.Join(s.dir, name))
+	if err != nil {
+		return err
+	}
+	if err := s.tmpl.ExecuteTemplate(f, tmpl, data); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return f.Close()
+}
+
+// name returns the display name of ind.
+func (s *site) name(ind *gedcom.Individual) string {
+	if name := gedcom.DefaultNameFormatter.FormatIndividual(ind); name != "" {
+		return name
+	}
+	return "(unnamed)"
+}
+
+// individualLink links to the page of ind.
+func (s *site) individualLink(ind *gedcom.Individual) link {
+	text := s.name(ind)
+	if span := lifespan(ind); span != "" {
+		text += " " + span
+	}
+	return link{Href: pageName(ind.XRef), Text: text}
+}
+
+// familyLink links to the page of fam, named after its partners.
+func (s *site) familyLink(fam *gedcom.Family) link {
+	var names []string
+	for _, partner := range fam.PartnerIndividuals(s.doc) {
+		names = append(names, s.name(partner))
+	}
+	text := strings.Join(names, " & ")
+	if text == "" {
+		text = "Family " + strings.Trim(fam.XRef, "@")
+	}
+	return link{Href: pageName(fam.XRef), Text: text}
+}
+
+// recordLink links to the page of an individual or family.
+func (s *site) recordLink(xref string) (link, bool) {
+	if ind := s.doc.GetIndividual(xref); ind != nil {
+		return s.individualLink(ind), true
+	}
+	if fam := s.doc.GetFamily(xref); fam != nil {
+		return s.familyLink(fam), true
+	}
+	return link{}, false
+}
+
+// pageName returns the file name of the page of a record: its XRef
+// without the @ signs, with characters unsafe in file names replaced.
+func pageName(xref string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, strings.Trim(xref, "@"))
+	return name + ".html"
+}
+
+// lifespan returns "(1850–1920)", with either year left out when unknown,
+// or "" if both are.
+func lifespan(ind *gedcom.Individual) string {
+	year := func(d *gedcom.Date) string {
+		if d == nil || d.Year == 0 {
+			return ""
+		}
+		return fmt.Sprint(d.Year)
+	}
+	born, died := year(ind.BirthDate()), year(ind.DeathDate())
+	if born == "" && died == "" {
+		return ""
+	}
+	return "(" + born + "–" + died + ")"
+}
+
+// eventName returns the display name of an event type from the event
+// registry, or the tag for unregistered types.
+func eventName(t gedcom.EventType) string {
+	if info, ok := gedcom.LookupEventType(string(t)); ok && info.Description != "" {
+		return info.Description
+	}
+	return string(t)
+}
@@ -0,0 +1,63 @@
+package main
+
+// pageTemplates are the templates of the site's pages: "person", "family",
+// "index", and "sources", sharing a "header" and "footer".
+const pageTemplates = `
+{{define "header"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.}}</title>
+<style>
+body { font-family: Georgia, serif; max-width: 48em; margin: 2em auto; padding: 0 1em; line-height: 1.5; }
+nav { border-bottom: 1px solid #ccc; margin-bottom: 1em; padding-bottom: .5em; }
+h1 small { color: #666; font-weight: normal; }
+table { border-collapse: collapse; }
+td, th { padding: .2em .8em .2em 0; text-align: left; vertical-align: top; }
+.notes { font-size: .9em; color: #444; }
+</style>
+</head>
+<body>
+<nav><a href="index.html">Surnames</a> · <a href="sources.html">Sources</a> · <a href="places.geojson">Map data</a></nav>
+{{end}}
+
+{{define "footer"}}</body>
+</html>
+{{end}}
+
+{{define "person"}}{{template "header" .Title}}
+<h1>{{.Title}} <small>{{.Lifespan}}</small></h1>
+{{with .Narrative}}<p>{{.}}</p>{{end}}
+{{with .Notes}}<ol class="notes">{{range .}}<li>{{.}}</li>{{end}}</ol>{{end}}
+{{with .Parents}}<h2>Parents</h2>
+<ul>{{range .}}<li><a href="{{.Href}}">{{.Text}}</a></li>{{end}}</ul>{{end}}
+{{with .Families}}<h2>Families</h2>
+<ul>{{range .}}<li><a href="{{.Href}}">{{.Text}}</a></li>{{end}}</ul>{{end}}
+{{with .Stops}}<h2>Places</h2>
+<table>{{range .}}<tr><td>{{.Date}}</td><td>{{.Name}}</td><td>{{if .Map}}<a href="{{.Map}}">{{.Place}}</a>{{else}}{{.Place}}{{end}}</td></tr>{{end}}</table>{{end}}
+{{template "footer"}}{{end}}
+
+{{define "family"}}{{template "header" .Title}}
+<h1>{{.Title}}</h1>
+{{with .Partners}}<h2>Partners</h2>
+<ul>{{range .}}<li><a href="{{.Href}}">{{.Text}}</a></li>{{end}}</ul>{{end}}
+{{with .Events}}<h2>Events</h2>
+<table>{{range .}}<tr><td>{{.Name}}</td><td>{{.Date}}</td><td>{{.Place}}</td></tr>{{end}}</table>{{end}}
+{{with .Children}}<h2>Children</h2>
+<ol>{{range .}}<li><a href="{{.Href}}">{{.Text}}</a></li>{{end}}</ol>{{end}}
+{{template "footer"}}{{end}}
+
+{{define "index"}}{{template "header" "Surnames"}}
+<h1>Surnames</h1>
+<p>{{range $i, $g := .}}{{if $i}} · {{end}}<a href="#{{$g.Surname}}">{{$g.Surname}}</a>{{end}}</p>
+{{range .}}<h2 id="{{.Surname}}">{{.Surname}}</h2>
+<ul>{{range .People}}<li><a href="{{.Href}}">{{.Text}}</a></li>{{end}}</ul>
+{{end}}{{template "footer"}}{{end}}
+
+{{define "sources"}}{{template "header" "Sources"}}
+<h1>Sources</h1>
+{{range .}}<h2>{{.Text}}</h2>
+{{with .Citations}}<ul>{{range .}}<li>{{.Text}}{{with .CitedBy}} — cited by {{range $i, $l := .}}{{if $i}}, {{end}}<a href="{{$l.Href}}">{{$l.Text}}</a>{{end}}{{end}}</li>{{end}}</ul>{{end}}
+{{else}}<p>No sources.</p>
+{{end}}{{template "footer"}}{{end}}
+`
@@ -0,0 +1,291 @@
+package gedcom
+
+import "sort"
+
+// AliasGroup is a set of INDI records that describe the same person.
+type AliasGroup struct {
+	// XRefs are the individuals of the group, in document order.
+	XRefs []string
+
+	// Primary is the record the others are aliases of. ResolveAliases
+	// picks the first member that is not itself the target of an ALIA
+	// link within the group; ChoosePrimary overrides it.
+	Primary string
+}
+
+// Others returns the members of the group other than Primary, in
+// document order.
+func (g *AliasGroup) Others() []string {
+	others := make([]string, 0, len(g.XRefs))
+	for _, xref := range g.XRefs {
+		if xref != g.Primary {
+			others = append(others, xref)
+		}
+	}
+	return others
+}
+
+// ChoosePrimary sets Primary to the member with the highest score, resolved
+// against doc. Ties keep the earliest member in document order; members that
+// do not resolve to an individual are not considered.
+func (g *AliasGroup) ChoosePrimary(doc *Document, score func(*Individual) int) {
+	best, bestScore := "", 0
+	for _, xref := range g.XRefs {
+		ind := doc.GetIndividual(xref)
+		if ind == nil {
+			continue
+		}
+		if s := score(ind); best == "" || s > bestScore {
+			best, bestScore = xref, s
+		}
+	}
+	if best != "" {
+		g.Primary = best
+	}
+}
+
+// DetailScore is a ChoosePrimary score that counts an individual's names,
+// events, attributes, family links, source citations, and media links,
+// favoring the most fully researched record.
+func DetailScore(ind *Individual) int {
+	return len(ind.Names) + len(ind.Events) + len(ind.Attributes) +
+		len(ind.ChildInFamilies) + len(ind.SpouseInFamilies) +
+		len(ind.SourceCitations) + len(ind.Media)
+}
+
+// AliasOption configures ResolveAliases.
+type AliasOption func(*aliasConfig)
+
+type aliasConfig struct {
+	refn bool
+	uid  bool
+}
+
+// WithREFNEquivalence treats individuals that share a REFN value (with the
+// same REFN.TYPE, if any) as aliases of each other.
+func WithREFNEquivalence() AliasOption {
+	return func(c *aliasConfig) { c.refn = true }
+}
+
+// WithUIDEquivalence treats individuals that share a UID as aliases of each
+// other.
+func WithUIDEquivalence() AliasOption {
+	return func(c *aliasConfig) { c.uid = true }
+}
+
+// ResolveAliases groups the individuals of d that describe the same person.
+// Individuals are linked by ALIA pointers in either direction and, when
+// enabled by options, by shared REFN or UID values. Linking is transitive.
+// ALIA values that are not pointers to an individual in d are ignored.
+//
+// Only groups with at least two members are returned, ordered by the
+// position of their first member.
+func (d *Document) ResolveAliases(opts ...AliasOption) []AliasGroup {
+	if d == nil {
+		return nil
+	}
+	cfg := &aliasConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	xrefs, index := individualPositions(d)
+	r := &aliasResolver{
+		cfg:     cfg,
+		index:   index,
+		parent:  make([]int, len(xrefs)),
+		keys:    make(map[string]int),
+		targets: make(map[string]bool),
+	}
+	for i := range r.parent {
+		r.parent[i] = i
+	}
+	for _, record := range d.Records {
+		if record == nil || record.Type != RecordTypeIndividual {
+			continue
+		}
+		if i, ok := index[record.XRef]; ok {
+			r.linkRecord(record, i)
+		}
+	}
+	return r.groups(xrefs)
+}
+
+// individualPositions returns the distinct XRefs of the individuals of d in
+// document order, and the position of each.
+func individualPositions(d *Document) ([]string, map[string]int) {
+	var xrefs []string
+	index := make(map[string]int)
+	for _, record := range d.Records {
+		if record == nil || record.Type != RecordTypeIndividual || record.XRef == "" {
+			continue
+		}
+		if _, dup := index[record.XRef]; !dup {
+			index[record.XRef] = len(xrefs)
+			xrefs = append(xrefs, record.XRef)
+		}
+	}
+	return xrefs, index
+}
+
+// aliasResolver links individuals, by position, with a union-find whose
+// roots are the lowest position of each group.
+type aliasResolver struct {
+	cfg    *aliasConfig
+	index  map[string]int
+	parent []int
+
+	// keys maps each REFN or UID key to the first individual with it.
+	keys map[string]int
+
+	// targets holds the XRefs named by another individual's ALIA.
+	targets map[string]bool
+}
+
+func (r *aliasResolver) find(i int) int {
+	for r.parent[i] != i {
+		r.parent[i] = r.parent[r.parent[i]]
+		i = r.parent[i]
+	}
+	return i
+}
+
+func (r *aliasResolver) union(i, j int) {
+	ri, rj := r.find(i), r.find(j)
+	if rj < ri {
+		ri, rj = rj, ri
+	}
+	r.parent[rj] = ri
+}
+
+// link joins individual i with the first individual that had key.
+func (r *aliasResolver) link(key string, i int) {
+	if j, ok := r.keys[key]; ok {
+		r.union(i, j)
+		return
+	}
+	r.keys[key] = i
+}
+
+// linkRecord joins the individual record at position i with those it is
+// an alias of.
+func (r *aliasResolver) linkRecord(record *Record, i int) {
+	ind, _ := record.GetIndividual()
+	for _, alias := range individualAliases(record, ind) {
+		if j, ok := r.index[alias]; ok && IsPointerXRef(alias) {
+			r.union(i, j)
+			if alias != record.XRef {
+				r.targets[alias] = true
+			}
+		}
+	}
+	if r.cfg.refn {
+		for _, key := range individualREFNKeys(record, ind) {
+			r.link("REFN\x00"+key, i)
+		}
+	}
+	if r.cfg.uid {
+		for _, uid := range individualUIDs(record, ind) {
+			r.link("UID\x00"+uid, i)
+		}
+	}
+}
+
+// groups returns the groups of at least two of xrefs, ordered by their
+// first member, with the first member no other individual names as an
+// alias as primary.
+func (r *aliasResolver) groups(xrefs []string) []AliasGroup {
+	members := make(map[int][]string)
+	var roots []int
+	for i, xref := range xrefs {
+		root := r.find(i)
+		if _, seen := members[root]; !seen {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], xref)
+	}
+	sort.Ints(roots)
+
+	var groups []AliasGroup
+	for _, root := range roots {
+		group := members[root]
+		if len(group) < 2 {
+			continue
+		}
+		primary := group[0]
+		for _, xref := range group {
+			if !r.targets[xref] {
+				primary = xref
+				break
+			}
+		}
+		groups = append(groups, AliasGroup{XRefs: group, Primary: primary})
+	}
+	return groups
+}
+
+// individualAliases returns the ALIA values of an individual, from raw tags
+// when present and from the entity otherwise.
+func individualAliases(record *Record, ind *Individual) []string {
+	if len(record.Tags) > 0 {
+		var aliases []string
+		for _, tag := range record.Tags {
+			if tag.Level == 1 && tag.Tag == "ALIA" {
+				aliases = append(aliases, tag.Value)
+			}
+		}
+		return aliases
+	}
+	if ind != nil {
+		return ind.Aliases
+	}
+	return nil
+}
+
+// individualREFNKeys returns "TYPE\x00value" keys for the REFN structures of
+// an individual.
+func individualREFNKeys(record *Record, ind *Individual) []string {
+	if len(record.Tags) > 0 {
+		var keys []string
+		for i, tag := range record.Tags {
+			if tag.Level != 1 || tag.Tag != "REFN" || tag.Value == "" {
+				continue
+			}
+			refType := ""
+			for j := i + 1; j < len(record.Tags) && record.Tags[j].Level > 1; j++ {
+				if record.Tags[j].Level == 2 && record.Tags[j].Tag == "TYPE" {
+					refType = record.Tags[j].Value
+				}
+			}
+			keys = append(keys, refType+"\x00"+tag.Value)
+		}
+		return keys
+	}
+	if ind == nil {
+		return nil
+	}
+	var keys []string
+	for _, ref := range ind.AllUserReferences() {
+		if ref != nil && ref.Number != "" {
+			keys = append(keys, ref.Type+"\x00"+ref.Number)
+		}
+	}
+	return keys
+}
+
+// individualUIDs returns the UID values of an individual.
+func individualUIDs(record *Record, ind *Individual) []string {
+	if len(record.Tags) > 0 {
+		var uids []string
+		for _, tag := range record.Tags {
+			if tag.Level == 1 && (tag.Tag == "UID" || tag.Tag == "_UID") && tag.Value != "" {
+				uids = append(uids, tag.Value)
+			}
+		}
+		return uids
+	}
+	if ind != nil && ind.UID != "" {
+		return []string{ind.UID}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func aliasRecord(xref string, tags ...*Tag) *Record {
+	ind := &Individual{XRef: xref, Tags: tags}
+	for _, tag := range tags {
+		if tag.Level != 1 {
+			continue
+		}
+		switch tag.Tag {
+		case "ALIA":
+			ind.Aliases = append(ind.Aliases, tag.Value)
+		case "NAME":
+			ind.Names = append(ind.Names, &PersonalName{Full: tag.Value})
+		case "REFN":
+			ind.RefNumber = tag.Value
+		case "UID", "_UID":
+			ind.UID = tag.Value
+		}
+	}
+	return &Record{XRef: xref, Type: RecordTypeIndividual, Entity: ind, Tags: tags}
+}
+
+func aliasDocument(records ...*Record) *Document {
+	doc := &Document{Records: records, XRefMap: make(map[string]*Record)}
+	for _, r := range records {
+		doc.XRefMap[r.XRef] = r
+	}
+	return doc
+}
+
+func TestResolveAliases(t *testing.T) {
+	doc := aliasDocument(
+		aliasRecord("@I1@", &Tag{Level: 1, Tag: "NAME", Value: "John /Doe/"}),
+		aliasRecord("@I2@", &Tag{Level: 1, Tag: "ALIA", Value: "@I1@"}),
+		aliasRecord("@I3@", &Tag{Level: 1, Tag: "ALIA", Value: "@I2@"}),
+		aliasRecord("@I4@", &Tag{Level: 1, Tag: "ALIA", Value: "Johnny"}),
+		aliasRecord("@I5@", &Tag{Level: 1, Tag: "ALIA", Value: "@I9@"}),
+		aliasRecord("@I6@"),
+		aliasRecord("@I7@", &Tag{Level: 1, Tag: "ALIA", Value: "@I6@"}),
+	)
+
+	got := doc.ResolveAliases()
+	want := []AliasGroup{
+		{XRefs: []string{"@I1@", "@I2@", "@I3@"}, Primary: "@I3@"},
+		{XRefs: []string{"@I6@", "@I7@"}, Primary: "@I7@"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveAliases() = %+v, want %+v", got, want)
+	}
+}
+
+func TestResolveAliases_EntityOnly(t *testing.T) {
+	doc := aliasDocument(
+		&Record{XRef: "@I1@", Type: RecordTypeIndividual, Entity: &Individual{XRef: "@I1@", Aliases: []string{"@I2@"}}},
+		&Record{XRef: "@I2@", Type: RecordTypeIndividual, Entity: &Individual{XRef: "@I2@"}},
+	)
+	got := doc.ResolveAliases()
+	if len(got) != 1 || got[0].Primary != "@I1@" {
+		t.Errorf("ResolveAliases() = %+v, want one group with primary @I1@", got)
+	}
+}
+
+func TestResolveAliases_Options(t *testing.T) {
+	doc := aliasDocument(
+		aliasRecord("@I1@", &Tag{Level: 1, Tag: "REFN", Value: "42"}, &Tag{Level: 2, Tag: "TYPE", Value: "user"}),
+		aliasRecord("@I2@", &Tag{Level: 1, Tag: "REFN", Value: "42"}, &Tag{Level: 2, Tag: "TYPE", Value: "user"}),
+		aliasRecord("@I3@", &Tag{Level: 1, Tag: "REFN", Value: "42"}),
+		aliasRecord("@I4@", &Tag{Level: 1, Tag: "_UID", Value: "ABC"}),
+		aliasRecord("@I5@", &Tag{Level: 1, Tag: "UID", Value: "ABC"}),
+	)
+
+	if got := doc.ResolveAliases(); len(got) != 0 {
+		t.Errorf("ResolveAliases() without options = %+v, want none", got)
+	}
+
+	got := doc.ResolveAliases(WithREFNEquivalence())
+	if len(got) != 1 || !reflect.DeepEqual(got[0].XRefs, []string{"@I1@", "@I2@"}) {
+		t.Errorf("ResolveAliases(REFN) = %+v, want [@I1@ @I2@]", got)
+	}
+
+	got = doc.ResolveAliases(WithUIDEquivalence())
+	if len(got) != 1 || !reflect.DeepEqual(got[0].XRefs, []string{"@I4@", "@I5@"}) {
+		t.Errorf("ResolveAliases(UID) = %+v, want [@I4@ @I5@]", got)
+	}
+
+	if got := doc.ResolveAliases(WithREFNEquivalence(), WithUIDEquivalence()); len(got) != 2 {
+		t.Errorf("ResolveAliases(REFN, UID) returned %d groups, want 2", len(got))
+	}
+}
+
+func TestResolveAliases_Nil(t *testing.T) {
+	var doc *Document
+	if got := doc.ResolveAliases(); got != nil {
+		t.Errorf("ResolveAliases() on nil = %+v, want nil", got)
+	}
+}
+
+func TestAliasGroup_ChoosePrimary(t *testing.T) {
+	doc := aliasDocument(
+		aliasRecord("@I1@", &Tag{Level: 1, Tag: "ALIA", Value: "@I2@"}),
+		aliasRecord("@I2@",
+			&Tag{Level: 1, Tag: "NAME", Value: "John /Doe/"},
+			&Tag{Level: 1, Tag: "NAME", Value: "Johnny /Doe/"}),
+		aliasRecord("@I3@", &Tag{Level: 1, Tag: "ALIA", Value: "@I2@"}),
+	)
+	groups := doc.ResolveAliases()
+	if len(groups) != 1 {
+		t.Fatalf("ResolveAliases() returned %d groups, want 1", len(groups))
+	}
+	group := groups[0]
+	if group.Primary != "@I1@" {
+		t.Errorf("default Primary = %s, want @I1@", group.Primary)
+	}
+
+	group.ChoosePrimary(doc, DetailScore)
+	if group.Primary != "@I2@" {
+		t.Errorf("ChoosePrimary(DetailScore) = %s, want @I2@", group.Primary)
+	}
+	if got, want := group.Others(), []string{"@I1@", "@I3@"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Others() = %v, want %v", got, want)
+	}
+
+	group.ChoosePrimary(doc, func(*Individual) int { return 0 })
+	if group.Primary != "@I1@" {
+		t.Errorf("ChoosePrimary() tie = %s, want earliest member @I1@", group.Primary)
+	}
+}
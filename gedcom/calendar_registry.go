@@ -0,0 +1,213 @@
+package gedcom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CalendarOptions describes a custom calendar passed to RegisterCalendar.
+type CalendarOptions struct {
+	// Name is the human-readable name returned by Calendar.String
+	// (e.g., "Swedish"). If empty, the escape name is used.
+	Name string
+
+	// Months maps month codes, matched case-insensitively, to month
+	// numbers starting at 1 (e.g., {"JAN": 1, ...}).
+	Months map[string]int
+
+	// ToJDN converts a date in the calendar to a Julian Day Number. The
+	// year is astronomical (1 BC is 0, 2 BC is -1); month and day are
+	// always at least 1.
+	ToJDN func(year, month, day int) int
+
+	// FromJDN converts a Julian Day Number to a date in the calendar,
+	// returning an astronomical year.
+	FromJDN func(jdn int) (year, month, day int)
+}
+
+// CalendarInfo describes one entry in the calendar registry.
+type CalendarInfo struct {
+	// Calendar is the value stored in Date.Calendar.
+	Calendar Calendar
+
+	// Escape is the name in the date escape, e.g. "JULIAN" for
+	// "@#DJULIAN@".
+	Escape string
+
+	// Name is the human-readable calendar name.
+	Name string
+
+	// Custom is true for calendars added with RegisterCalendar.
+	Custom bool
+}
+
+// customCalendar is a registered calendar with its conversion functions.
+type customCalendar struct {
+	info    CalendarInfo
+	months  map[string]int
+	toJDN   func(year, month, day int) int
+	fromJDN func(jdn int) (year, month, day int)
+}
+
+// calendarRegistry is the process-wide table of custom calendars consulted
+// when parsing date escapes, month codes, and converting dates.
+var calendarRegistry = struct {
+	sync.RWMutex
+	byEscape map[string]*customCalendar
+	byValue  map[Calendar]*customCalendar
+	next     Calendar
+}{
+	byEscape: make(map[string]*customCalendar),
+	byValue:  make(map[Calendar]*customCalendar),
+	next:     CalendarFrenchRepublican + 1,
+}
+
+// builtinCalendars lists the calendars defined by the GEDCOM specification.
+var builtinCalendars = []CalendarInfo{
+	{Calendar: CalendarGregorian, Escape: "GREGORIAN", Name: "Gregorian"},
+	{Calendar: CalendarJulian, Escape: "JULIAN", Name: "Julian"},
+	{Calendar: CalendarHebrew, Escape: "HEBREW", Name: "Hebrew"},
+	{Calendar: CalendarFrenchRepublican, Escape: "FRENCH R", Name: "French Republican"},
+}
+
+// RegisterCalendar adds a custom calendar so that dates with the escape
+// "@#D<escape>@" parse with its month codes and convert through its Julian
+// Day Number functions (for comparison, ToGregorian, and ToCalendar). It
+// returns the Calendar value that parsed dates carry.
+//
+//	swedish, err := gedcom.RegisterCalendar("SWEDISH", gedcom.CalendarOptions{
+//	    Name:    "Swedish",
+//	    Months:  months,
+//	    ToJDN:   swedishToJDN,
+//	    FromJDN: jdnToSwedish,
+//	})
+//
+// The escape is matched case-insensitively and may contain letters,
+// digits, spaces, and underscores. The built-in calendars cannot be
+// replaced; registering an escape again replaces its options but keeps its
+// Calendar value. The registry is process-wide and safe for concurrent
+// use; register calendars before decoding documents that use them.
+func RegisterCalendar(escape string, opts CalendarOptions) (Calendar, error) {
+	escape = strings.ToUpper(strings.TrimSpace(escape))
+	if !isCalendarEscape(escape) {
+		return 0, fmt.Errorf("gedcom: invalid calendar escape %q (must match [A-Z0-9 _]+)", escape)
+	}
+	if _, ok := builtinCalendar(escape); ok {
+		return 0, fmt.Errorf("gedcom: calendar %q is built in and cannot be replaced", escape)
+	}
+	if len(opts.Months) == 0 || opts.ToJDN == nil || opts.FromJDN == nil {
+		return 0, fmt.Errorf("gedcom: calendar %q needs Months, ToJDN, and FromJDN", escape)
+	}
+	if opts.Name == "" {
+		opts.Name = escape
+	}
+	months := make(map[string]int, len(opts.Months))
+	for code, month := range opts.Months {
+		months[strings.ToUpper(code)] = month
+	}
+
+	calendarRegistry.Lock()
+	defer calendarRegistry.Unlock()
+	value := calendarRegistry.next
+	if existing, ok := calendarRegistry.byEscape[escape]; ok {
+		value = existing.info.Calendar
+	} else {
+		calendarRegistry.next++
+	}
+	entry := &customCalendar{
+		info:    CalendarInfo{Calendar: value, Escape: escape, Name: opts.Name, Custom: true},
+		months:  months,
+		toJDN:   opts.ToJDN,
+		fromJDN: opts.FromJDN,
+	}
+	calendarRegistry.byEscape[escape] = entry
+	calendarRegistry.byValue[value] = entry
+	return value, nil
+}
+
+// UnregisterCalendar removes a custom calendar from the registry and
+// reports whether it was present. Dates already parsed keep its Calendar
+// value, which then reports as "Unknown" and no longer converts; the value
+// is never reused.
+func UnregisterCalendar(escape string) bool {
+	escape = strings.ToUpper(strings.TrimSpace(escape))
+	calendarRegistry.Lock()
+	defer calendarRegistry.Unlock()
+	entry, ok := calendarRegistry.byEscape[escape]
+	if !ok {
+		return false
+	}
+	delete(calendarRegistry.byEscape, escape)
+	delete(calendarRegistry.byValue, entry.info.Calendar)
+	return true
+}
+
+// LookupCalendar returns the registry entry for a date escape name, built
+// in or custom, e.g. "JULIAN" or "FRENCH R".
+func LookupCalendar(escape string) (CalendarInfo, bool) {
+	escape = strings.ToUpper(strings.TrimSpace(escape))
+	if info, ok := builtinCalendar(escape); ok {
+		return info, true
+	}
+	entry, ok := lookupCustomEscape(escape)
+	if !ok {
+		return CalendarInfo{}, false
+	}
+	return entry.info, true
+}
+
+// RegisteredCalendars returns every registered calendar, built-in calendars
+// first, then custom calendars sorted by escape.
+func RegisteredCalendars() []CalendarInfo {
+	result := append([]CalendarInfo(nil), builtinCalendars...)
+
+	calendarRegistry.RLock()
+	custom := make([]CalendarInfo, 0, len(calendarRegistry.byEscape))
+	for _, entry := range calendarRegistry.byEscape {
+		custom = append(custom, entry.info)
+	}
+	calendarRegistry.RUnlock()
+
+	sort.Slice(custom, func(i, j int) bool {
+		return custom[i].Escape < custom[j].Escape
+	})
+	return append(result, custom...)
+}
+
+func builtinCalendar(escape string) (CalendarInfo, bool) {
+	for _, info := range builtinCalendars {
+		if info.Escape == escape {
+			return info, true
+		}
+	}
+	return CalendarInfo{}, false
+}
+
+func lookupCustomEscape(escape string) (*customCalendar, bool) {
+	calendarRegistry.RLock()
+	defer calendarRegistry.RUnlock()
+	entry, ok := calendarRegistry.byEscape[escape]
+	return entry, ok
+}
+
+func lookupCustomCalendar(c Calendar) (*customCalendar, bool) {
+	calendarRegistry.RLock()
+	defer calendarRegistry.RUnlock()
+	entry, ok := calendarRegistry.byValue[c]
+	return entry, ok
+}
+
+// isCalendarEscape reports whether s is a valid calendar escape name.
+func isCalendarEscape(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != ' ' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
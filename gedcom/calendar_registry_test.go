@@ -0,0 +1,113 @@
+package gedcom
+
+import "testing"
+
+// registerSwedish registers the Swedish calendar of 1700-1712, which ran
+// one day ahead of the Julian calendar.
+func registerSwedish(t *testing.T) Calendar {
+	t.Helper()
+	swedish, err := RegisterCalendar("swedish", CalendarOptions{
+		Name:   "Swedish",
+		Months: monthNames,
+		ToJDN: func(year, month, day int) int {
+			return JulianToJDN(year, month, day) - 1
+		},
+		FromJDN: func(jdn int) (int, int, int) {
+			return JDNToJulian(jdn + 1)
+		},
+	})
+	if err != nil {
+		t.Fatalf("RegisterCalendar() error = %v", err)
+	}
+	t.Cleanup(func() { UnregisterCalendar("SWEDISH") })
+	return swedish
+}
+
+func TestRegisterCalendar(t *testing.T) {
+	swedish := registerSwedish(t)
+	if swedish.String() != "Swedish" {
+		t.Errorf("String() = %q, want Swedish", swedish.String())
+	}
+
+	date, err := ParseDate("@#DSWEDISH@ 30 FEB 1712")
+	if err != nil {
+		t.Fatalf("ParseDate() error = %v", err)
+	}
+	if date.Calendar != swedish || date.Day != 30 || date.Month != 2 || date.Year != 1712 {
+		t.Fatalf("ParseDate() = %+v", date)
+	}
+
+	greg, err := date.ToGregorian()
+	if err != nil {
+		t.Fatalf("ToGregorian() error = %v", err)
+	}
+	if greg.Day != 11 || greg.Month != 3 || greg.Year != 1712 {
+		t.Errorf("ToGregorian() = %d/%d/%d, want 11/3/1712", greg.Day, greg.Month, greg.Year)
+	}
+
+	// The toy FromJDN cannot produce 30 FEB, so round-trip another day.
+	summer, _ := ParseDate("25 JUN 1705")
+	back, err := summer.ToCalendar(swedish)
+	if err != nil {
+		t.Fatalf("ToCalendar() error = %v", err)
+	}
+	if back.Day != 15 || back.Month != 6 || back.Year != 1705 || back.Calendar != swedish {
+		t.Errorf("ToCalendar() = %+v", back)
+	}
+
+	julian, _ := ParseDate("@#DJULIAN@ 29 FEB 1712")
+	if date.Compare(julian) != 0 {
+		t.Errorf("Compare(Julian 29 FEB 1712) = %d, want 0", date.Compare(julian))
+	}
+
+	// Registering again keeps the Calendar value.
+	again, err := RegisterCalendar("SWEDISH", CalendarOptions{Name: "Svensk", Months: monthNames,
+		ToJDN: JulianToJDN, FromJDN: JDNToJulian})
+	if err != nil || again != swedish || swedish.String() != "Svensk" {
+		t.Errorf("re-register = %v, %v, %q", again, err, swedish.String())
+	}
+
+	info, ok := LookupCalendar("swedish")
+	if !ok || info.Calendar != swedish || !info.Custom {
+		t.Errorf("LookupCalendar() = %+v, %v", info, ok)
+	}
+	all := RegisteredCalendars()
+	if len(all) != 5 || all[4].Escape != "SWEDISH" || all[0].Escape != "GREGORIAN" {
+		t.Errorf("RegisteredCalendars() = %+v", all)
+	}
+
+	if !UnregisterCalendar("SWEDISH") || UnregisterCalendar("SWEDISH") {
+		t.Error("UnregisterCalendar() should succeed exactly once")
+	}
+	if swedish.String() != "Unknown" {
+		t.Errorf("String() after unregister = %q", swedish.String())
+	}
+	if _, err := date.ToGregorian(); err == nil {
+		t.Error("ToGregorian() after unregister: expected error")
+	}
+}
+
+func TestRegisterCalendar_Invalid(t *testing.T) {
+	valid := CalendarOptions{Months: monthNames, ToJDN: JulianToJDN, FromJDN: JDNToJulian}
+	for _, escape := range []string{"", "BAD@", "JULIAN", "french r"} {
+		if _, err := RegisterCalendar(escape, valid); err == nil {
+			t.Errorf("RegisterCalendar(%q) error = nil", escape)
+		}
+	}
+	if _, err := RegisterCalendar("BYZANTINE", CalendarOptions{Months: monthNames}); err == nil {
+		t.Error("RegisterCalendar() without conversions: error = nil")
+	}
+	if _, err := ParseDate("@#DBYZANTINE@ 1 JAN 7200"); err == nil {
+		t.Error("ParseDate() with an unregistered escape: error = nil")
+	}
+}
+
+func TestLookupCalendar_Builtin(t *testing.T) {
+	info, ok := LookupCalendar("French R")
+	if !ok || info.Calendar != CalendarFrenchRepublican || info.Custom {
+		t.Errorf("LookupCalendar(French R) = %+v, %v", info, ok)
+	}
+	if _, ok := LookupCalendar("ROMAN"); ok {
+		t.Error("LookupCalendar(ROMAN) found")
+	}
+}
@@ -0,0 +1,255 @@
+package gedcom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CardinalityViolation reports a tag that appears under its parent more
+// often than the GEDCOM grammar allows, such as two SEX lines in one INDI
+// record or two HUSB lines in one FAM record.
+type CardinalityViolation struct {
+	// XRef is the record containing the violation; it is empty for the
+	// header and for tags checked with CheckTagCardinality.
+	XRef string
+
+	// Path is the dotted tag path of the parent structure, starting with
+	// the record tag (e.g., "INDI", "INDI.BIRT", "HEAD.GEDC").
+	Path string
+
+	// Tag is the repeated tag.
+	Tag string
+
+	// Count is the number of occurrences; Max is the grammar's limit.
+	Count int
+	Max   int
+
+	// LineNumber is the source line of the first occurrence beyond Max,
+	// or 0 for tags that were not decoded from a file.
+	LineNumber int
+
+	// index orders violations by the position of that occurrence.
+	index int
+}
+
+// String describes the violation, e.g. "@I1@: INDI.SEX appears 2 times (at most 1)".
+func (v CardinalityViolation) String() string {
+	s := fmt.Sprintf("%s.%s appears %d times (at most %d)", v.Path, v.Tag, v.Count, v.Max)
+	if v.XRef != "" {
+		s = v.XRef + ": " + s
+	}
+	return s
+}
+
+// singletonTags lists, by cardinality context (see cardinalityContext), the
+// tags that may appear at most once in every GEDCOM version.
+var singletonTags = map[string][]string{
+	"HEAD":          {"GEDC", "SOUR", "DEST", "DATE", "SUBM", "SUBN", "FILE", "COPR", "CHAR", "LANG", "PLAC", "NOTE", "SCHMA"},
+	"HEAD.GEDC":     {"VERS", "FORM"},
+	"HEAD.SOUR":     {"VERS", "NAME", "CORP", "DATA"},
+	"HEAD.PLAC":     {"FORM"},
+	"INDI":          {"SEX", "RESN", "RIN", "AFN", "RFN", "CHAN", "CREA"},
+	"FAM":           {"HUSB", "WIFE", "RESN", "NCHI", "RIN", "CHAN", "CREA"},
+	"SOUR":          {"DATA", "AUTH", "TITL", "ABBR", "PUBL", "TEXT", "RIN", "CHAN", "CREA"},
+	"REPO":          {"NAME", "ADDR", "RIN", "CHAN", "CREA"},
+	"SUBM":          {"NAME", "ADDR", "RIN", "RFN", "CHAN", "CREA"},
+	"OBJE":          {"RESN", "RIN", "CHAN", "CREA"},
+	"NOTE":          {"RIN", "CHAN", "CREA"},
+	"SNOTE":         {"MIME", "LANG", "CHAN", "CREA"},
+	"NAME":          {"TYPE"},
+	"FAMC":          {"PEDI", "STAT"},
+	"fact":          {"TYPE", "DATE", "PLAC", "ADDR", "AGE", "AGNC", "RELI", "CAUS", "RESN", "SDATE", "HUSB", "WIFE", "FAMC", "TEMP", "STAT"},
+	"citation":      {"PAGE", "EVEN", "DATA", "QUAY"},
+	"citation.EVEN": {"ROLE"},
+	"citation.DATA": {"DATE"},
+	"PLAC":          {"FORM", "MAP"},
+	"MAP":           {"LATI", "LONG"},
+	"ADDR":          {"ADR1", "ADR2", "ADR3", "CITY", "STAE", "POST", "CTRY"},
+	"DATE":          {"TIME", "PHRASE"},
+	"CHAN":          {"DATE"},
+}
+
+// singletonTagsBefore70 lists the tags that are singletons in GEDCOM 5.5
+// and 5.5.1 but may repeat in 7.0, which allows several name pieces of
+// each kind.
+var singletonTagsBefore70 = map[string][]string{
+	"NAME": {"NPFX", "GIVN", "NICK", "SPFX", "SURN", "NSFX"},
+}
+
+// factTags are the individual and family attribute and LDS ordinance tags
+// that share the event substructure rules; registered event types are
+// recognized through IsEventType.
+var factTags = map[string]bool{
+	"OCCU": true, "CAST": true, "DSCR": true, "EDUC": true, "IDNO": true, "NATI": true,
+	"SSN": true, "TITL": true, "RELI": true, "NCHI": true, "NMR": true, "PROP": true,
+	"FACT": true, "RESI": true, "EVEN": true,
+	"BAPL": true, "CONL": true, "ENDL": true, "SLGC": true, "SLGS": true, "INIL": true,
+}
+
+// MaxOccurrences returns the maximum number of times tag may appear
+// directly under the structure at path (its dotted tag path from the
+// record tag, e.g. "INDI" or "INDI.NAME") in the given GEDCOM version, or
+// 0 if the grammar does not limit it. An empty version is read as 5.5.1.
+func MaxOccurrences(version Version, path, tag string) int {
+	context := cardinalityContext(strings.Split(path, "."))
+	if containsTag(singletonTags[context], tag) {
+		return 1
+	}
+	if version == "" {
+		version = Version551
+	}
+	if version.Before(Version70) && containsTag(singletonTagsBefore70[context], tag) {
+		return 1
+	}
+	return 0
+}
+
+// CheckCardinality reports every tag in the header and records of doc that
+// is repeated beyond its maximum, according to the document's version.
+// Records are checked through their raw tags, so records built in code
+// without tags are not checked. A file with two HEAD records decodes into
+// one header with repeated GEDC and SOUR lines, which are reported.
+func CheckCardinality(doc *Document) []CardinalityViolation {
+	if doc == nil {
+		return nil
+	}
+	var version Version
+	var violations []CardinalityViolation
+	if doc.Header != nil {
+		version = doc.Header.Version
+		violations = append(violations, CheckTagCardinality(version, "HEAD", doc.Header.Tags)...)
+	}
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		for _, v := range CheckTagCardinality(version, string(record.Type), record.Tags) {
+			v.XRef = record.XRef
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+// CheckTagCardinality reports the tags of one record (the level 1 and
+// deeper tags under a level 0 line of type root, e.g. "INDI" or "HEAD")
+// that are repeated beyond their maximum in version, in the order of the
+// first excess occurrence.
+func CheckTagCardinality(version Version, root string, tags []*Tag) []CardinalityViolation {
+	type frame struct {
+		path   []string
+		counts map[string]int
+		excess []*CardinalityViolation
+	}
+	var violations []CardinalityViolation
+	closeFrame := func(f *frame) {
+		for _, v := range f.excess {
+			v.Count = f.counts[v.Tag]
+			violations = append(violations, *v)
+		}
+	}
+
+	stack := []*frame{{path: []string{root}, counts: make(map[string]int)}}
+	for i, tag := range tags {
+		if tag == nil {
+			continue
+		}
+		level := tag.Level
+		if level < 1 {
+			level = 1
+		}
+		for len(stack) > level {
+			closeFrame(stack[len(stack)-1])
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1]
+		parent.counts[tag.Tag]++
+		if limit := MaxOccurrences(version, strings.Join(parent.path, "."), tag.Tag); limit > 0 && parent.counts[tag.Tag] == limit+1 {
+			parent.excess = append(parent.excess, &CardinalityViolation{
+				Path:       strings.Join(parent.path, "."),
+				Tag:        tag.Tag,
+				Max:        limit,
+				LineNumber: tag.LineNumber,
+				index:      i,
+			})
+		}
+		path := append(append([]string(nil), parent.path...), tag.Tag)
+		stack = append(stack, &frame{path: path, counts: make(map[string]int)})
+	}
+	for len(stack) > 0 {
+		closeFrame(stack[len(stack)-1])
+		stack = stack[:len(stack)-1]
+	}
+
+	sort.SliceStable(violations, func(a, b int) bool { return violations[a].index < violations[b].index })
+	return violations
+}
+
+// tagContexts maps the tags whose cardinality rules do not depend on where
+// they appear to their key in singletonTags.
+var tagContexts = map[string]string{
+	"SOUR": "citation",
+	"CREA": "CHAN",
+	"CHAN": "CHAN",
+	"PLAC": "PLAC",
+	"MAP":  "MAP",
+	"ADDR": "ADDR",
+	"DATE": "DATE",
+}
+
+// citationContexts maps the substructures of a citation (a SOUR below
+// level 1) with their own rules to their key in singletonTags.
+var citationContexts = map[string]string{
+	"EVEN": "citation.EVEN",
+	"DATA": "citation.DATA",
+}
+
+// recordContexts maps, per record type, the level-1 structures with their
+// own rules to their key in singletonTags.
+var recordContexts = map[string]map[string]string{
+	"INDI": {"NAME": "NAME", "FAMC": "FAMC"},
+}
+
+// cardinalityContext maps a structure path to its key in singletonTags.
+// Records are keyed by their tag; substructures whose rules do not depend
+// on where they appear (citations, places, addresses, ...) share a key.
+func cardinalityContext(path []string) string {
+	if len(path) == 1 {
+		return path[0]
+	}
+	last := path[len(path)-1]
+	if len(path) == 2 {
+		if key, ok := levelOneContext(path[0], last); ok {
+			return key
+		}
+	} else if key, ok := citationContexts[last]; ok && path[0] != "HEAD" && path[len(path)-2] == "SOUR" {
+		return key
+	}
+	return tagContexts[last]
+}
+
+// levelOneContext returns the key of the level-1 structure tag of a record,
+// when it has rules of its own: header structures are keyed by their path,
+// individual and family facts share "fact", and the rest are looked up in
+// recordContexts.
+func levelOneContext(record, tag string) (string, bool) {
+	if record == "HEAD" {
+		return "HEAD." + tag, true
+	}
+	if (record == "INDI" || record == "FAM") && (factTags[tag] || IsEventType(tag, EventScopeIndividual|EventScopeFamily)) {
+		return "fact", true
+	}
+	key, ok := recordContexts[record][tag]
+	return key, ok
+}
+
+// containsTag reports whether tags contains tag.
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package gedcom
+
+import "testing"
+
+func TestMaxOccurrences(t *testing.T) {
+	tests := []struct {
+		version   Version
+		path, tag string
+		want      int
+	}{
+		{Version551, "INDI", "SEX", 1},
+		{Version551, "INDI", "NAME", 0},
+		{Version70, "FAM", "HUSB", 1},
+		{Version551, "FAM", "CHIL", 0},
+		{Version551, "INDI.BIRT", "DATE", 1},
+		{Version551, "FAM.MARR", "PLAC", 1},
+		{Version551, "INDI.OCCU", "SOUR", 0},
+		{Version551, "INDI.BIRT.SOUR", "PAGE", 1},
+		{Version551, "INDI.BIRT.SOUR.EVEN", "ROLE", 1},
+		{Version551, "SOUR.DATA", "EVEN", 0},
+		{Version551, "HEAD", "GEDC", 1},
+		{Version551, "HEAD.GEDC", "VERS", 1},
+		{Version551, "INDI.NAME", "GIVN", 1},
+		{"", "INDI.NAME", "GIVN", 1},
+		{Version70, "INDI.NAME", "GIVN", 0},
+		{Version70, "INDI.NAME", "TYPE", 1},
+		{Version70, "INDI.BIRT.PLAC.MAP", "LATI", 1},
+		{Version551, "INDI", "_CUSTOM", 0},
+	}
+	for _, tt := range tests {
+		if got := MaxOccurrences(tt.version, tt.path, tt.tag); got != tt.want {
+			t.Errorf("MaxOccurrences(%q, %q, %q) = %d, want %d", tt.version, tt.path, tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestCheckCardinality(t *testing.T) {
+	tag := func(level int, name, value string, line int) *Tag {
+		return &Tag{Level: level, Tag: name, Value: value, LineNumber: line}
+	}
+	doc := &Document{
+		Header: &Header{Version: Version551, Tags: []*Tag{
+			tag(1, "GEDC", "", 2), tag(2, "VERS", "5.5.1", 3),
+			tag(1, "GEDC", "", 4), tag(2, "VERS", "5.5.1", 5),
+		}},
+		Records: []*Record{
+			{XRef: "@I1@", Type: RecordTypeIndividual, Tags: []*Tag{
+				tag(1, "NAME", "John /Smith/", 7),
+				tag(2, "GIVN", "John", 8),
+				tag(2, "GIVN", "Jack", 9),
+				tag(1, "SEX", "M", 10),
+				tag(1, "BIRT", "", 11),
+				tag(2, "DATE", "1850", 12),
+				tag(2, "DATE", "1851", 13),
+				tag(1, "SEX", "F", 14),
+				tag(1, "SEX", "U", 15),
+				tag(1, "DEAT", "", 16),
+				tag(2, "DATE", "1900", 17),
+			}},
+			{XRef: "@F1@", Type: RecordTypeFamily, Tags: []*Tag{
+				tag(1, "HUSB", "@I1@", 19),
+				tag(1, "CHIL", "@I2@", 20),
+				tag(1, "CHIL", "@I3@", 21),
+			}},
+		},
+	}
+
+	got := CheckCardinality(doc)
+	want := []CardinalityViolation{
+		{Path: "HEAD", Tag: "GEDC", Count: 2, Max: 1, LineNumber: 4},
+		{XRef: "@I1@", Path: "INDI.NAME", Tag: "GIVN", Count: 2, Max: 1, LineNumber: 9},
+		{XRef: "@I1@", Path: "INDI.BIRT", Tag: "DATE", Count: 2, Max: 1, LineNumber: 13},
+		{XRef: "@I1@", Path: "INDI", Tag: "SEX", Count: 3, Max: 1, LineNumber: 14},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("CheckCardinality() = %v, want %d violations", got, len(want))
+	}
+	for i := range want {
+		got[i].index = 0
+		if got[i] != want[i] {
+			t.Errorf("violation %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if s := got[3].String(); s != "@I1@: INDI.SEX appears 3 times (at most 1)" {
+		t.Errorf("String() = %q", s)
+	}
+
+	// GEDCOM 7.0 allows repeated name pieces.
+	doc.Header.Version = Version70
+	if got := CheckCardinality(doc); len(got) != 3 {
+		t.Errorf("7.0 violations = %v, want 3", got)
+	}
+	if CheckCardinality(nil) != nil {
+		t.Error("CheckCardinality(nil) should return nil")
+	}
+}
@@ -2,18 +2,19 @@ package gedcom
 
 // Clone returns a deep copy of the document. The returned document
 // shares no pointers with the original; mutating one will not affect
-// the other. Returns nil if d is nil.
+// the other. The copy has the same lock mode. Returns nil if d is nil.
 func (d *Document) Clone() *Document {
 	if d == nil {
 		return nil
 	}
 
 	copied := &Document{
-		Header:  d.Header.Clone(),
-		Trailer: d.Trailer.Clone(),
-		XRefMap: make(map[string]*Record),
-		Vendor:  d.Vendor,
-		Schema:  cloneSchemaDefinition(d.Schema),
+		Header:     d.Header.Clone(),
+		Trailer:    d.Trailer.Clone(),
+		XRefMap:    make(map[string]*Record),
+		Vendor:     d.Vendor,
+		Schema:     cloneSchemaDefinition(d.Schema),
+		Formatting: d.Formatting.Clone(),
 	}
 
 	copied.Records = make([]*Record, len(d.Records))
@@ -24,6 +25,15 @@ func (d *Document) Clone() *Document {
 			copied.XRefMap[copiedRecord.XRef] = copiedRecord
 		}
 	}
+	if d.LockMode() != LockNone {
+		copied.SetLockMode(d.LockMode())
+	}
+	if d.AutoTimestamps() {
+		copied.SetAutoTimestamps(true)
+	}
+	if d.XRefStrategy() != XRefSequential {
+		copied.SetXRefStrategy(d.XRefStrategy())
+	}
 
 	return copied
 }
@@ -46,8 +56,10 @@ func (h *Header) Clone() *Header {
 		Language:       h.Language,
 		Copyright:      h.Copyright,
 		Submitter:      h.Submitter,
+		PlaceForm:      h.PlaceForm,
 		AncestryTreeID: h.AncestryTreeID,
 		Tags:           CloneTags(h.Tags),
+		Trivia:         cloneTrivia(h.Trivia),
 	}
 }
 
@@ -56,7 +68,7 @@ func (t *Trailer) Clone() *Trailer {
 	if t == nil {
 		return nil
 	}
-	return &Trailer{LineNumber: t.LineNumber}
+	return &Trailer{LineNumber: t.LineNumber, Trivia: cloneTrivia(t.Trivia)}
 }
 
 // Clone returns a deep copy of the record. The Entity field is
@@ -73,6 +85,7 @@ func (r *Record) Clone() *Record {
 		LineNumber: r.LineNumber,
 		Entity:     cloneEntity(r.Entity),
 		Tags:       CloneTags(r.Tags),
+		Trivia:     cloneTrivia(r.Trivia),
 	}
 }
 
@@ -99,13 +112,17 @@ func (i *Individual) Clone() *Individual {
 	}
 
 	copied := &Individual{
-		XRef:             i.XRef,
-		Sex:              i.Sex,
-		SpouseInFamilies: cloneStringSlice(i.SpouseInFamilies),
-		Notes:            cloneStringSlice(i.Notes),
-		RefNumber:        i.RefNumber,
-		UID:              i.UID,
-		FamilySearchID:   i.FamilySearchID,
+		XRef:              i.XRef,
+		Sex:               i.Sex,
+		Restriction:       i.Restriction,
+		SpouseInFamilies:  cloneStringSlice(i.SpouseInFamilies),
+		Aliases:           cloneStringSlice(i.Aliases),
+		Notes:             cloneStringSlice(i.Notes),
+		UserReferences:    cloneUserReferences(i.UserReferences),
+		RefNumber:         i.RefNumber,
+		AutomatedRecordID: i.AutomatedRecordID,
+		UID:               i.UID,
+		FamilySearchID:    i.FamilySearchID,
 	}
 
 	if i.Names != nil {
@@ -174,8 +191,16 @@ func (i *Individual) Clone() *Individual {
 		}
 	}
 
+	if i.Tasks != nil {
+		copied.Tasks = make([]*Task, len(i.Tasks))
+		for k, task := range i.Tasks {
+			copied.Tasks[k] = cloneTask(task)
+		}
+	}
+
 	copied.ChangeDate = cloneChangeDate(i.ChangeDate)
 	copied.CreationDate = cloneChangeDate(i.CreationDate)
+	copied.ExtensionData = cloneExtensionData(i.ExtensionData)
 	copied.Tags = CloneTags(i.Tags)
 
 	return copied
@@ -188,14 +213,34 @@ func (f *Family) Clone() *Family {
 	}
 
 	copied := &Family{
-		XRef:             f.XRef,
-		Husband:          f.Husband,
-		Wife:             f.Wife,
-		Children:         cloneStringSlice(f.Children),
-		NumberOfChildren: f.NumberOfChildren,
-		Notes:            cloneStringSlice(f.Notes),
-		RefNumber:        f.RefNumber,
-		UID:              f.UID,
+		XRef:              f.XRef,
+		Husband:           f.Husband,
+		Wife:              f.Wife,
+		Children:          cloneStringSlice(f.Children),
+		Restriction:       f.Restriction,
+		NumberOfChildren:  f.NumberOfChildren,
+		Notes:             cloneStringSlice(f.Notes),
+		UserReferences:    cloneUserReferences(f.UserReferences),
+		RefNumber:         f.RefNumber,
+		AutomatedRecordID: f.AutomatedRecordID,
+		UID:               f.UID,
+	}
+
+	if f.Spouses != nil {
+		copied.Spouses = make([]*FamilySpouse, len(f.Spouses))
+		for k, spouse := range f.Spouses {
+			if spouse != nil {
+				s := *spouse
+				copied.Spouses[k] = &s
+			}
+		}
+	}
+
+	if f.Associations != nil {
+		copied.Associations = make([]*Association, len(f.Associations))
+		for k, assoc := range f.Associations {
+			copied.Associations[k] = cloneAssociation(assoc)
+		}
 	}
 
 	if f.Events != nil {
@@ -228,6 +273,7 @@ func (f *Family) Clone() *Family {
 
 	copied.ChangeDate = cloneChangeDate(f.ChangeDate)
 	copied.CreationDate = cloneChangeDate(f.CreationDate)
+	copied.ExtensionData = cloneExtensionData(f.ExtensionData)
 	copied.Tags = CloneTags(f.Tags)
 
 	return copied
@@ -240,15 +286,17 @@ func (s *Source) Clone() *Source {
 	}
 
 	copied := &Source{
-		XRef:          s.XRef,
-		Title:         s.Title,
-		Author:        s.Author,
-		Publication:   s.Publication,
-		Text:          s.Text,
-		RepositoryRef: s.RepositoryRef,
-		Notes:         cloneStringSlice(s.Notes),
-		RefNumber:     s.RefNumber,
-		UID:           s.UID,
+		XRef:              s.XRef,
+		Title:             s.Title,
+		Author:            s.Author,
+		Publication:       s.Publication,
+		Text:              s.Text,
+		RepositoryRef:     s.RepositoryRef,
+		Notes:             cloneStringSlice(s.Notes),
+		UserReferences:    cloneUserReferences(s.UserReferences),
+		RefNumber:         s.RefNumber,
+		AutomatedRecordID: s.AutomatedRecordID,
+		UID:               s.UID,
 	}
 
 	if s.Repository != nil {
@@ -256,6 +304,17 @@ func (s *Source) Clone() *Source {
 	}
 
 	copied.RepositoryLink = cloneSourceRepositoryLink(s.RepositoryLink)
+	if s.RepositoryLinks != nil {
+		// Keep RepositoryLink aliased to its entry in RepositoryLinks.
+		copied.RepositoryLinks = make([]*SourceRepositoryLink, len(s.RepositoryLinks))
+		for i, link := range s.RepositoryLinks {
+			if link != nil && link == s.RepositoryLink {
+				copied.RepositoryLinks[i] = copied.RepositoryLink
+			} else {
+				copied.RepositoryLinks[i] = cloneSourceRepositoryLink(link)
+			}
+		}
+	}
 
 	if s.Media != nil {
 		copied.Media = make([]*MediaLink, len(s.Media))
@@ -266,6 +325,7 @@ func (s *Source) Clone() *Source {
 
 	copied.ChangeDate = cloneChangeDate(s.ChangeDate)
 	copied.CreationDate = cloneChangeDate(s.CreationDate)
+	copied.ExtensionData = cloneExtensionData(s.ExtensionData)
 	copied.Tags = CloneTags(s.Tags)
 
 	return copied
@@ -278,11 +338,13 @@ func (r *Repository) Clone() *Repository {
 	}
 
 	return &Repository{
-		XRef:    r.XRef,
-		Name:    r.Name,
-		Address: cloneAddress(r.Address),
-		Notes:   cloneStringSlice(r.Notes),
-		Tags:    CloneTags(r.Tags),
+		XRef:              r.XRef,
+		Name:              r.Name,
+		Address:           cloneAddress(r.Address),
+		Notes:             cloneStringSlice(r.Notes),
+		AutomatedRecordID: r.AutomatedRecordID,
+		Tags:              CloneTags(r.Tags),
+		ExtensionData:     cloneExtensionData(r.ExtensionData),
 	}
 }
 
@@ -300,6 +362,10 @@ func cloneSourceRepositoryLink(link *SourceRepositoryLink) *SourceRepositoryLink
 		Notes:       cloneStringSlice(link.Notes),
 	}
 
+	if link.Calls != nil {
+		copied.Calls = append([]CallNumber(nil), link.Calls...)
+	}
+
 	if link.Inline != nil {
 		copied.Inline = &InlineRepository{Name: link.Inline.Name}
 	}
@@ -321,10 +387,11 @@ func (n *Note) Clone() *Note {
 	}
 
 	return &Note{
-		XRef:         n.XRef,
-		Text:         n.Text,
-		Continuation: cloneStringSlice(n.Continuation),
-		Tags:         CloneTags(n.Tags),
+		XRef:              n.XRef,
+		Text:              n.Text,
+		Continuation:      cloneStringSlice(n.Continuation),
+		AutomatedRecordID: n.AutomatedRecordID,
+		Tags:              CloneTags(n.Tags),
 	}
 }
 
@@ -335,11 +402,12 @@ func (m *MediaObject) Clone() *MediaObject {
 	}
 
 	copied := &MediaObject{
-		XRef:        m.XRef,
-		Notes:       cloneStringSlice(m.Notes),
-		RefNumbers:  cloneStringSlice(m.RefNumbers),
-		Restriction: m.Restriction,
-		UIDs:        cloneStringSlice(m.UIDs),
+		XRef:              m.XRef,
+		Notes:             cloneStringSlice(m.Notes),
+		RefNumbers:        cloneStringSlice(m.RefNumbers),
+		Restriction:       m.Restriction,
+		UIDs:              cloneStringSlice(m.UIDs),
+		AutomatedRecordID: m.AutomatedRecordID,
 	}
 
 	if m.Files != nil {
@@ -358,6 +426,7 @@ func (m *MediaObject) Clone() *MediaObject {
 
 	copied.ChangeDate = cloneChangeDate(m.ChangeDate)
 	copied.CreationDate = cloneChangeDate(m.CreationDate)
+	copied.ExtensionData = cloneExtensionData(m.ExtensionData)
 	copied.Tags = CloneTags(m.Tags)
 
 	return copied
@@ -370,14 +439,16 @@ func (s *Submitter) Clone() *Submitter {
 	}
 
 	return &Submitter{
-		XRef:     s.XRef,
-		Name:     s.Name,
-		Address:  cloneAddress(s.Address),
-		Phone:    cloneStringSlice(s.Phone),
-		Email:    cloneStringSlice(s.Email),
-		Language: cloneStringSlice(s.Language),
-		Notes:    cloneStringSlice(s.Notes),
-		Tags:     CloneTags(s.Tags),
+		XRef:              s.XRef,
+		Name:              s.Name,
+		Address:           cloneAddress(s.Address),
+		Phone:             cloneStringSlice(s.Phone),
+		Email:             cloneStringSlice(s.Email),
+		Language:          cloneStringSlice(s.Language),
+		Notes:             cloneStringSlice(s.Notes),
+		AutomatedRecordID: s.AutomatedRecordID,
+		Tags:              CloneTags(s.Tags),
+		ExtensionData:     cloneExtensionData(s.ExtensionData),
 	}
 }
 
@@ -563,6 +634,7 @@ func cloneEvent(e *Event) *Event {
 		Restriction:     e.Restriction,
 		UID:             e.UID,
 		SortDate:        e.SortDate,
+		VendorSortDate:  e.VendorSortDate,
 		Notes:           cloneStringSlice(e.Notes),
 		Phone:           cloneStringSlice(e.Phone),
 		Email:           cloneStringSlice(e.Email),
@@ -588,6 +660,13 @@ func cloneEvent(e *Event) *Event {
 		}
 	}
 
+	if e.Associations != nil {
+		copied.Associations = make([]*Association, len(e.Associations))
+		for i, assoc := range e.Associations {
+			copied.Associations[i] = cloneAssociation(assoc)
+		}
+	}
+
 	copied.Tags = CloneTags(e.Tags)
 	return copied
 }
@@ -681,14 +760,33 @@ func cloneSourceCitation(sc *SourceCitation) *SourceCitation {
 
 	copied := &SourceCitation{
 		SourceXRef: sc.SourceXRef,
+		InlineText: sc.InlineText,
 		Page:       sc.Page,
+		Event:      sc.Event,
+		Role:       sc.Role,
 		Quality:    sc.Quality,
+		HasQuality: sc.HasQuality,
+		Surety:     sc.Surety,
+		SuretyTag:  sc.SuretyTag,
+	}
+
+	if sc.Text != nil {
+		copied.Text = append([]string(nil), sc.Text...)
 	}
 
 	if sc.Data != nil {
 		copied.Data = &SourceCitationData{
-			Date: sc.Data.Date,
-			Text: sc.Data.Text,
+			Date:           sc.Data.Date,
+			Text:           sc.Data.Text,
+			AdditionalText: cloneStringSlice(sc.Data.AdditionalText),
+			Website:        cloneStringSlice(sc.Data.Website),
+		}
+	}
+
+	if sc.Media != nil {
+		copied.Media = make([]*MediaLink, len(sc.Media))
+		for k, media := range sc.Media {
+			copied.Media[k] = cloneMediaLink(media)
 		}
 	}
 
@@ -772,6 +870,15 @@ func cloneLDSOrdinance(ord *LDSOrdinance) *LDSOrdinance {
 	}
 }
 
+func cloneTask(t *Task) *Task {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	copied.Notes = cloneStringSlice(t.Notes)
+	return &copied
+}
+
 func cloneChangeDate(cd *ChangeDate) *ChangeDate {
 	if cd == nil {
 		return nil
@@ -792,6 +899,20 @@ func cloneExternalID(ext *ExternalID) *ExternalID {
 	}
 }
 
+func cloneUserReferences(refs []*UserReference) []*UserReference {
+	if refs == nil {
+		return nil
+	}
+	copied := make([]*UserReference, len(refs))
+	for k, ref := range refs {
+		if ref != nil {
+			r := *ref
+			copied[k] = &r
+		}
+	}
+	return copied
+}
+
 func cloneStringSlice(s []string) []string {
 	if s == nil {
 		return nil
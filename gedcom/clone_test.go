@@ -449,6 +449,7 @@ func TestIndividualClone(t *testing.T) {
 			Sex:              "M",
 			SpouseInFamilies: []string{"@F1@"},
 			Notes:            []string{"@N1@"},
+			UserReferences:   []*UserReference{{Number: "123", Type: "user"}},
 			RefNumber:        "123",
 			UID:              "uid-123",
 			FamilySearchID:   "FSID",
@@ -491,6 +492,10 @@ func TestIndividualClone(t *testing.T) {
 		if len(copied.ExternalIDs) != 1 || copied.ExternalIDs[0] == original.ExternalIDs[0] {
 			t.Error("ExternalIDs should be deep copied")
 		}
+		if len(copied.UserReferences) != 1 || copied.UserReferences[0] == original.UserReferences[0] ||
+			*copied.UserReferences[0] != *original.UserReferences[0] {
+			t.Error("UserReferences should be deep copied")
+		}
 		if copied.ChangeDate == original.ChangeDate {
 			t.Error("ChangeDate should have different pointer")
 		}
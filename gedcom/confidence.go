@@ -0,0 +1,320 @@
+package gedcom
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Confidence is how strongly the evidence supports a fact. The levels
+// follow the GEDCOM QUAY scale, with ConfidenceUnknown below all of them
+// for citations that carry no assessment.
+type Confidence int
+
+const (
+	// ConfidenceUnknown means no quality assessment was recorded.
+	ConfidenceUnknown Confidence = iota
+
+	// ConfidenceUnreliable is unreliable evidence or estimated data (QUAY 0).
+	ConfidenceUnreliable
+
+	// ConfidenceQuestionable is evidence of questionable reliability
+	// (QUAY 1), e.g. interviews, census, oral genealogies, or potential
+	// for bias.
+	ConfidenceQuestionable
+
+	// ConfidenceSecondary is secondary evidence, officially recorded some
+	// time after the event (QUAY 2).
+	ConfidenceSecondary
+
+	// ConfidencePrimary is direct and primary evidence, or evidence that
+	// prevails by dominance (QUAY 3).
+	ConfidencePrimary
+)
+
+// String returns the lowercase name of the level, e.g. "secondary".
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceUnreliable:
+		return "unreliable"
+	case ConfidenceQuestionable:
+		return "questionable"
+	case ConfidenceSecondary:
+		return "secondary"
+	case ConfidencePrimary:
+		return "primary"
+	default:
+		return "unknown"
+	}
+}
+
+// Quality returns the QUAY value of the level (0-3), or -1 for
+// ConfidenceUnknown.
+func (c Confidence) Quality() int {
+	if c < ConfidenceUnreliable || c > ConfidencePrimary {
+		return -1
+	}
+	return int(c - ConfidenceUnreliable)
+}
+
+// ConfidenceFromQuality maps a QUAY value (0-3) to a Confidence. Values
+// outside the scale map to ConfidenceUnknown.
+func ConfidenceFromQuality(quay int) Confidence {
+	if quay < 0 || quay > 3 {
+		return ConfidenceUnknown
+	}
+	return ConfidenceUnreliable + Confidence(quay)
+}
+
+// Quality is a QUAY value, the GEDCOM 0-3 evidence quality scale.
+type Quality int
+
+// The QUAY values.
+const (
+	// QualityUnreliable is unreliable evidence or estimated data (QUAY 0).
+	QualityUnreliable Quality = iota
+
+	// QualityQuestionable is evidence of questionable reliability (QUAY 1).
+	QualityQuestionable
+
+	// QualitySecondary is secondary evidence, officially recorded some
+	// time after the event (QUAY 2).
+	QualitySecondary
+
+	// QualityPrimary is direct and primary evidence (QUAY 3).
+	QualityPrimary
+)
+
+// ParseQuality parses a QUAY value. It reports false for values other
+// than the digits 0-3, ignoring surrounding space.
+func ParseQuality(value string) (Quality, bool) {
+	switch strings.TrimSpace(value) {
+	case "0":
+		return QualityUnreliable, true
+	case "1":
+		return QualityQuestionable, true
+	case "2":
+		return QualitySecondary, true
+	case "3":
+		return QualityPrimary, true
+	}
+	return 0, false
+}
+
+// String returns the QUAY value, e.g. "2".
+func (q Quality) String() string { return strconv.Itoa(int(q)) }
+
+// Confidence returns the Confidence level of the value.
+func (q Quality) Confidence() Confidence { return ConfidenceFromQuality(int(q)) }
+
+// SuretyTags are the vendor citation tags read as surety assessments, in
+// addition to QUAY. The first entry is used when writing a Surety without
+// a SuretyTag.
+var SuretyTags = []string{"_SURE", "_SURETY", "_QUAL", "_CONF"}
+
+// IsSuretyTag reports whether tag is one of SuretyTags.
+func IsSuretyTag(tag string) bool {
+	for _, t := range SuretyTags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSurety interprets a vendor surety value. Numbers 0-3 are read as
+// QUAY values; words are matched case-insensitively: "primary", "direct",
+// "proven", or "high" (primary); "secondary", "probable", or "medium"
+// (secondary); "questionable", "possible", "doubtful", or "low"
+// (questionable); "unreliable", "estimated", "disproven", or "none"
+// (unreliable). Anything else is ConfidenceUnknown.
+func ParseSurety(value string) Confidence {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if n, err := strconv.Atoi(value); err == nil {
+		return ConfidenceFromQuality(n)
+	}
+	switch value {
+	case "primary", "direct", "proven", "high":
+		return ConfidencePrimary
+	case "secondary", "probable", "medium":
+		return ConfidenceSecondary
+	case "questionable", "possible", "doubtful", "low":
+		return ConfidenceQuestionable
+	case "unreliable", "estimated", "disproven", "none":
+		return ConfidenceUnreliable
+	}
+	return ConfidenceUnknown
+}
+
+// Confidence returns the confidence of the citation: its QUAY value when
+// present, otherwise its vendor surety. A citation built in code with a
+// nonzero Quality but no HasQuality flag is read by Quality.
+func (c *SourceCitation) Confidence() Confidence {
+	if c == nil {
+		return ConfidenceUnknown
+	}
+	if c.HasQuality || c.Quality > 0 {
+		return c.Quality.Confidence()
+	}
+	return ParseSurety(c.Surety)
+}
+
+// LowestConfidence returns the lowest confidence among the assessed
+// citations, or ConfidenceUnknown if none is assessed. A fact is only as
+// reliable as its weakest cited evidence.
+func LowestConfidence(citations []*SourceCitation) Confidence {
+	lowest := ConfidenceUnknown
+	for _, cite := range citations {
+		c := cite.Confidence()
+		if c != ConfidenceUnknown && (lowest == ConfidenceUnknown || c < lowest) {
+			lowest = c
+		}
+	}
+	return lowest
+}
+
+// Confidence returns the lowest confidence among the event's citations.
+func (e *Event) Confidence() Confidence {
+	if e == nil {
+		return ConfidenceUnknown
+	}
+	return LowestConfidence(e.SourceCitations)
+}
+
+// Confidence returns the lowest confidence among the attribute's
+// citations.
+func (a *Attribute) Confidence() Confidence {
+	if a == nil {
+		return ConfidenceUnknown
+	}
+	return LowestConfidence(a.SourceCitations)
+}
+
+// ConfidenceFilter selects facts (events and attributes) by confidence.
+type ConfidenceFilter struct {
+	// Min is the lowest confidence kept.
+	Min Confidence
+
+	// KeepUnknown keeps facts without any assessed citation, which would
+	// otherwise fall below any Min above ConfidenceUnknown.
+	KeepUnknown bool
+}
+
+// Keep reports whether a fact with confidence c passes the filter.
+func (f ConfidenceFilter) Keep(c Confidence) bool {
+	if c == ConfidenceUnknown && f.KeepUnknown {
+		return true
+	}
+	return c >= f.Min
+}
+
+// FilterByConfidence returns a copy of d without the individual and family
+// events and attributes that f rejects, together with the number of facts
+// removed. Both the typed entities and the raw tags are filtered, so the
+// result encodes without the removed facts. The source document is not
+// mutated.
+func (d *Document) FilterByConfidence(f ConfidenceFilter) (*Document, int, error) {
+	if d == nil {
+		return nil, 0, errors.New("gedcom: document is nil")
+	}
+	out := d.Clone()
+	removed := 0
+	for _, record := range out.Records {
+		if record == nil {
+			continue
+		}
+		factTags := make(map[string]bool)
+		switch entity := record.Entity.(type) {
+		case *Individual:
+			for _, e := range entity.Events {
+				factTags[string(e.Type)] = true
+			}
+			for _, a := range entity.Attributes {
+				factTags[a.Type] = true
+			}
+			var n int
+			entity.Events, n = filterFacts(entity.Events, f)
+			removed += n
+			entity.Attributes, n = filterFacts(entity.Attributes, f)
+			removed += n
+		case *Family:
+			for _, e := range entity.Events {
+				factTags[string(e.Type)] = true
+			}
+			var n int
+			entity.Events, n = filterFacts(entity.Events, f)
+			removed += n
+		default:
+			continue
+		}
+		if len(record.Tags) > 0 {
+			record.Tags = filterFactTags(record.Tags, factTags, f)
+			switch entity := record.Entity.(type) {
+			case *Individual:
+				entity.Tags = record.Tags
+			case *Family:
+				entity.Tags = record.Tags
+			}
+		}
+	}
+	return out, removed, nil
+}
+
+// filterFacts keeps the facts f accepts and returns how many were dropped.
+func filterFacts[T interface{ Confidence() Confidence }](facts []T, f ConfidenceFilter) ([]T, int) {
+	kept := facts[:0]
+	for _, fact := range facts {
+		if f.Keep(fact.Confidence()) {
+			kept = append(kept, fact)
+		}
+	}
+	return kept, len(facts) - len(kept)
+}
+
+// filterFactTags drops the level-1 structures of tags whose tag is in
+// factTags and whose citations f rejects.
+func filterFactTags(tags []*Tag, factTags map[string]bool, f ConfidenceFilter) []*Tag {
+	kept := make([]*Tag, 0, len(tags))
+	for i := 0; i < len(tags); {
+		j := i + 1
+		for j < len(tags) && tags[j].Level > tags[i].Level {
+			j++
+		}
+		block := tags[i:j]
+		if tags[i].Level != 1 || !factTags[tags[i].Tag] || f.Keep(tagsConfidence(block)) {
+			kept = append(kept, block...)
+		}
+		i = j
+	}
+	return kept
+}
+
+// tagsConfidence returns the lowest confidence among the level-2 SOUR
+// citations of a raw fact structure, read the same way the decoder reads
+// QUAY and surety tags.
+func tagsConfidence(block []*Tag) Confidence {
+	var citations []*SourceCitation
+	for i, tag := range block {
+		if tag.Level != 2 || tag.Tag != "SOUR" {
+			continue
+		}
+		cite := &SourceCitation{}
+		for _, sub := range block[i+1:] {
+			if sub.Level <= 2 {
+				break
+			}
+			if sub.Level != 3 {
+				continue
+			}
+			if sub.Tag == "QUAY" {
+				if q, ok := ParseQuality(sub.Value); ok {
+					cite.Quality, cite.HasQuality = q, true
+				}
+			} else if IsSuretyTag(sub.Tag) {
+				cite.Surety = sub.Value
+			}
+		}
+		citations = append(citations, cite)
+	}
+	return LowestConfidence(citations)
+}
@@ -0,0 +1,205 @@
+package gedcom
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfidenceFromQuality(t *testing.T) {
+	tests := []struct {
+		quay int
+		want Confidence
+	}{
+		{-1, ConfidenceUnknown},
+		{0, ConfidenceUnreliable},
+		{1, ConfidenceQuestionable},
+		{2, ConfidenceSecondary},
+		{3, ConfidencePrimary},
+		{4, ConfidenceUnknown},
+	}
+	for _, tt := range tests {
+		got := ConfidenceFromQuality(tt.quay)
+		if got != tt.want {
+			t.Errorf("ConfidenceFromQuality(%d) = %v, want %v", tt.quay, got, tt.want)
+		}
+		if tt.want != ConfidenceUnknown && got.Quality() != tt.quay {
+			t.Errorf("%v.Quality() = %d, want %d", got, got.Quality(), tt.quay)
+		}
+	}
+	if ConfidenceUnknown.Quality() != -1 {
+		t.Errorf("ConfidenceUnknown.Quality() = %d, want -1", ConfidenceUnknown.Quality())
+	}
+}
+
+func TestParseQuality(t *testing.T) {
+	for value, want := range map[string]Quality{
+		"0":   QualityUnreliable,
+		"1":   QualityQuestionable,
+		" 2 ": QualitySecondary,
+		"3":   QualityPrimary,
+	} {
+		got, ok := ParseQuality(value)
+		if !ok || got != want {
+			t.Errorf("ParseQuality(%q) = %v, %v; want %v", value, got, ok, want)
+		}
+	}
+	for _, value := range []string{"", "4", "-1", "high", "02"} {
+		if _, ok := ParseQuality(value); ok {
+			t.Errorf("ParseQuality(%q) ok = true, want false", value)
+		}
+	}
+	if QualitySecondary.String() != "2" || QualitySecondary.Confidence() != ConfidenceSecondary {
+		t.Error("Quality.String() or Confidence() mismatch")
+	}
+}
+
+func TestConfidence_String(t *testing.T) {
+	for c, want := range map[Confidence]string{
+		ConfidenceUnknown:      "unknown",
+		ConfidenceUnreliable:   "unreliable",
+		ConfidenceQuestionable: "questionable",
+		ConfidenceSecondary:    "secondary",
+		ConfidencePrimary:      "primary",
+	} {
+		if got := c.String(); got != want {
+			t.Errorf("Confidence(%d).String() = %q, want %q", c, got, want)
+		}
+	}
+}
+
+func TestParseSurety(t *testing.T) {
+	for value, want := range map[string]Confidence{
+		"3":         ConfidencePrimary,
+		" Primary ": ConfidencePrimary,
+		"Direct":    ConfidencePrimary,
+		"probable":  ConfidenceSecondary,
+		"LOW":       ConfidenceQuestionable,
+		"estimated": ConfidenceUnreliable,
+		"0":         ConfidenceUnreliable,
+		"":          ConfidenceUnknown,
+		"maybe":     ConfidenceUnknown,
+		"7":         ConfidenceUnknown,
+	} {
+		if got := ParseSurety(value); got != want {
+			t.Errorf("ParseSurety(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestSourceCitation_Confidence(t *testing.T) {
+	tests := []struct {
+		name string
+		cite *SourceCitation
+		want Confidence
+	}{
+		{"nil", nil, ConfidenceUnknown},
+		{"no assessment", &SourceCitation{}, ConfidenceUnknown},
+		{"QUAY 0", &SourceCitation{HasQuality: true}, ConfidenceUnreliable},
+		{"Quality set in code", &SourceCitation{Quality: 2}, ConfidenceSecondary},
+		{"surety", &SourceCitation{Surety: "Primary", SuretyTag: "_SURE"}, ConfidencePrimary},
+		{"QUAY wins over surety", &SourceCitation{Quality: 1, HasQuality: true, Surety: "3"}, ConfidenceQuestionable},
+	}
+	for _, tt := range tests {
+		if got := tt.cite.Confidence(); got != tt.want {
+			t.Errorf("%s: Confidence() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLowestConfidence(t *testing.T) {
+	citations := []*SourceCitation{
+		{Quality: 3},
+		{},
+		{Quality: 2},
+	}
+	if got := LowestConfidence(citations); got != ConfidenceSecondary {
+		t.Errorf("LowestConfidence() = %v, want secondary (unassessed citations ignored)", got)
+	}
+	if got := LowestConfidence([]*SourceCitation{{}}); got != ConfidenceUnknown {
+		t.Errorf("LowestConfidence(unassessed) = %v, want unknown", got)
+	}
+	if got := (&Event{SourceCitations: citations}).Confidence(); got != ConfidenceSecondary {
+		t.Errorf("Event.Confidence() = %v, want secondary", got)
+	}
+	if got := (&Attribute{}).Confidence(); got != ConfidenceUnknown {
+		t.Errorf("Attribute.Confidence() = %v, want unknown", got)
+	}
+}
+
+func TestConfidenceFilter_Keep(t *testing.T) {
+	f := ConfidenceFilter{Min: ConfidenceSecondary}
+	if f.Keep(ConfidenceQuestionable) || !f.Keep(ConfidenceSecondary) || !f.Keep(ConfidencePrimary) || f.Keep(ConfidenceUnknown) {
+		t.Error("ConfidenceFilter{Min: secondary} kept the wrong levels")
+	}
+	f.KeepUnknown = true
+	if !f.Keep(ConfidenceUnknown) {
+		t.Error("KeepUnknown did not keep unknown")
+	}
+}
+
+func TestDocument_FilterByConfidence(t *testing.T) {
+	tags := []*Tag{
+		{Level: 1, Tag: "NAME", Value: "John /Doe/"},
+		{Level: 1, Tag: "BIRT"},
+		{Level: 2, Tag: "SOUR", Value: "@S1@"},
+		{Level: 3, Tag: "QUAY", Value: "3"},
+		{Level: 1, Tag: "DEAT"},
+		{Level: 2, Tag: "SOUR", Value: "@S1@"},
+		{Level: 3, Tag: "QUAY", Value: "1"},
+		{Level: 1, Tag: "OCCU", Value: "Farmer"},
+		{Level: 2, Tag: "SOUR", Value: "@S1@"},
+		{Level: 3, Tag: "_SURE", Value: "secondary"},
+		{Level: 1, Tag: "RESI"},
+	}
+	ind := &Individual{
+		XRef: "@I1@",
+		Events: []*Event{
+			{Type: EventBirth, SourceCitations: []*SourceCitation{{SourceXRef: "@S1@", Quality: 3, HasQuality: true}}},
+			{Type: EventDeath, SourceCitations: []*SourceCitation{{SourceXRef: "@S1@", Quality: 1, HasQuality: true}}},
+			{Type: EventResidence},
+		},
+		Attributes: []*Attribute{
+			{Type: "OCCU", Value: "Farmer", SourceCitations: []*SourceCitation{{SourceXRef: "@S1@", Surety: "secondary"}}},
+		},
+		Tags: tags,
+	}
+	doc := &Document{Records: []*Record{{XRef: "@I1@", Type: RecordTypeIndividual, Entity: ind, Tags: tags}}}
+	doc.XRefMap = map[string]*Record{"@I1@": doc.Records[0]}
+
+	out, removed, err := doc.FilterByConfidence(ConfidenceFilter{Min: ConfidenceSecondary})
+	if err != nil {
+		t.Fatalf("FilterByConfidence() error = %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("removed = %d, want 2 (DEAT, RESI)", removed)
+	}
+	got := out.GetIndividual("@I1@")
+	if len(got.Events) != 1 || got.Events[0].Type != EventBirth || len(got.Attributes) != 1 {
+		t.Errorf("kept events = %+v, attributes = %+v", got.Events, got.Attributes)
+	}
+	var kept []string
+	for _, tag := range out.Records[0].Tags {
+		if tag.Level == 1 {
+			kept = append(kept, tag.Tag)
+		}
+	}
+	if want := "NAME BIRT OCCU"; strings.Join(kept, " ") != want {
+		t.Errorf("raw level-1 tags = %v, want %s", kept, want)
+	}
+	if len(got.Tags) != len(out.Records[0].Tags) {
+		t.Error("entity Tags not synchronized with record Tags")
+	}
+	if len(ind.Events) != 3 || len(doc.Records[0].Tags) != len(tags) {
+		t.Error("source document was mutated")
+	}
+
+	_, removed, _ = doc.FilterByConfidence(ConfidenceFilter{Min: ConfidenceSecondary, KeepUnknown: true})
+	if removed != 1 {
+		t.Errorf("KeepUnknown: removed = %d, want 1 (DEAT)", removed)
+	}
+
+	var nilDoc *Document
+	if _, _, err := nilDoc.FilterByConfidence(ConfidenceFilter{}); err == nil {
+		t.Error("nil document: expected error")
+	}
+}
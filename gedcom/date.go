@@ -33,6 +33,9 @@ func (c Calendar) String() string {
 	case CalendarFrenchRepublican:
 		return "French Republican"
 	default:
+		if entry, ok := lookupCustomCalendar(c); ok {
+			return entry.info.Name
+		}
 		return "Unknown"
 	}
 }
@@ -250,7 +253,7 @@ func ParseDate(s string) (*Date, error) {
 			return parseDatePeriod(s, original, modifier)
 		case ModifierInterpreted:
 			// INT date (original phrase)
-			return parseInterpretedDate(s, original)
+			return parseInterpretedDate(s, original, date.Calendar)
 		}
 	}
 
@@ -289,7 +292,11 @@ func parseCalendarEscape(s string) (Calendar, string, bool) {
 	case "FRENCH R":
 		calendar = CalendarFrenchRepublican
 	default:
-		return CalendarGregorian, s, false
+		entry, ok := lookupCustomEscape(strings.ToUpper(calendarName))
+		if !ok {
+			return CalendarGregorian, s, false
+		}
+		calendar = entry.info.Calendar
 	}
 
 	return calendar, rest, true
@@ -408,13 +415,19 @@ func parseDatePeriod(s, original string, modifier DateModifier) (*Date, error) {
 //   - "1850 (about eighteen fifty)" -> Year=1850, InterpretedFrom="about eighteen fifty"
 //   - "25 DEC 1850 (Christmas day)" -> Day=25, Month=12, Year=1850, InterpretedFrom="Christmas day"
 //   - "1850" -> Year=1850, InterpretedFrom="" (no phrase is valid)
-func parseInterpretedDate(s, original string) (*Date, error) {
+//
+// The calendar escape may precede INT (the calendar argument) or follow
+// it: "INT @#DJULIAN@ 1750 (x)".
+func parseInterpretedDate(s, original string, calendar Calendar) (*Date, error) {
 	date := &Date{
 		Original:      original,
-		Calendar:      CalendarGregorian,
+		Calendar:      calendar,
 		Modifier:      ModifierInterpreted,
 		IsInterpreted: true,
 	}
+	if c, rest, found := parseCalendarEscape(s); found {
+		date.Calendar, s = c, rest
+	}
 
 	// Find the opening parenthesis to separate date from phrase
 	parenIndex := strings.Index(s, "(")
@@ -553,7 +566,11 @@ func parseMonthForCalendar(s string, calendar Calendar) (int, error) {
 	case CalendarFrenchRepublican:
 		monthMap = frenchMonthNames
 	default:
-		return 0, fmt.Errorf("unsupported calendar type: %s", calendar)
+		entry, ok := lookupCustomCalendar(calendar)
+		if !ok {
+			return 0, fmt.Errorf("unsupported calendar type: %s", calendar)
+		}
+		monthMap = entry.months
 	}
 
 	month, ok := monthMap[upperMonth]
@@ -826,12 +843,41 @@ func (d *Date) toJDN() (int, error) {
 	case CalendarFrenchRepublican:
 		jdn = FrenchToJDN(d.Year, month, day)
 	default:
-		return 0, fmt.Errorf("unsupported calendar: %s", d.Calendar)
+		entry, ok := lookupCustomCalendar(d.Calendar)
+		if !ok {
+			return 0, fmt.Errorf("unsupported calendar: %s", d.Calendar)
+		}
+		jdn = entry.toJDN(astroYear, month, day)
 	}
 
 	return jdn, nil
 }
 
+// fromJDN converts a Julian Day Number to a GEDCOM year, month, and day
+// in the given calendar.
+func fromJDN(calendar Calendar, jdn int) (year, month, day int, isBC bool, err error) {
+	switch calendar {
+	case CalendarGregorian:
+		year, month, day = JDNToGregorian(jdn)
+	case CalendarJulian:
+		year, month, day = JDNToJulian(jdn)
+	case CalendarHebrew:
+		year, month, day = JDNToHebrew(jdn)
+		return year, month, day, false, nil
+	case CalendarFrenchRepublican:
+		year, month, day = JDNToFrench(jdn)
+		return year, month, day, false, nil
+	default:
+		entry, ok := lookupCustomCalendar(calendar)
+		if !ok {
+			return 0, 0, 0, false, fmt.Errorf("unsupported calendar: %s", calendar)
+		}
+		year, month, day = entry.fromJDN(jdn)
+	}
+	year, isBC = FromAstronomicalYear(year)
+	return year, month, day, isBC, nil
+}
+
 // ToGregorian converts the date to the Gregorian calendar.
 // Returns a new Date with Calendar set to CalendarGregorian.
 //
@@ -841,34 +887,38 @@ func (d *Date) toJDN() (int, error) {
 //
 // The Original field is preserved from the source date.
 func (d *Date) ToGregorian() (*Date, error) {
-	// If already Gregorian, return a copy
-	if d.Calendar == CalendarGregorian {
+	return d.ToCalendar(CalendarGregorian)
+}
+
+// ToCalendar converts the date to another calendar, built in or registered
+// with RegisterCalendar, through its Julian Day Number. It follows the
+// rules of ToGregorian: a date already in the calendar is copied, partial
+// dates keep their precision, a missing year is an error, and Original is
+// preserved.
+func (d *Date) ToCalendar(calendar Calendar) (*Date, error) {
+	if d.Calendar == calendar {
 		result := *d
 		return &result, nil
 	}
 
 	// Need at least a year to convert
 	if d.Year == 0 {
-		return nil, fmt.Errorf("cannot convert date to Gregorian: year is missing")
+		return nil, fmt.Errorf("cannot convert date to %s: year is missing", calendar)
 	}
 
-	// Convert to JDN first
 	jdn, err := d.toJDN()
 	if err != nil {
 		return nil, err
 	}
+	year, month, day, isBC, err := fromJDN(calendar, jdn)
+	if err != nil {
+		return nil, err
+	}
 
-	// Convert JDN to Gregorian
-	astroYear, month, day := JDNToGregorian(jdn)
-
-	// Convert astronomical year back to GEDCOM format
-	year, isBC := FromAstronomicalYear(astroYear)
-
-	// Create new date with Gregorian calendar
 	result := &Date{
 		Original: d.Original, // Preserve original for lossless representation
 		Year:     year,
-		Calendar: CalendarGregorian,
+		Calendar: calendar,
 		IsBC:     isBC,
 	}
 
@@ -876,12 +926,9 @@ func (d *Date) ToGregorian() (*Date, error) {
 	switch {
 	case d.Month == 0:
 		// Year-only: don't set month or day
-		result.Month = 0
-		result.Day = 0
 	case d.Day == 0:
 		// Month+Year: set month but not day
 		result.Month = month
-		result.Day = 0
 	default:
 		// Complete date: set both month and day
 		result.Month = month
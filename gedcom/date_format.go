@@ -0,0 +1,248 @@
+package gedcom
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Escape returns the GEDCOM 5.5.1 calendar escape that prefixes dates in
+// the calendar, e.g. "@#DHEBREW@", or "" for the Gregorian calendar.
+func (c Calendar) Escape() string {
+	switch c {
+	case CalendarGregorian:
+		return ""
+	case CalendarJulian:
+		return "@#DJULIAN@"
+	case CalendarHebrew:
+		return "@#DHEBREW@"
+	case CalendarFrenchRepublican:
+		return "@#DFRENCH R@"
+	}
+	if entry, ok := lookupCustomCalendar(c); ok {
+		return "@#D" + entry.info.Escape + "@"
+	}
+	return ""
+}
+
+var (
+	englishGregorianMonths = []string{"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December"}
+	frenchRepublicanMonths = []string{"Vendémiaire", "Brumaire", "Frimaire", "Nivôse", "Pluviôse", "Ventôse",
+		"Germinal", "Floréal", "Prairial", "Messidor", "Thermidor", "Fructidor"}
+)
+
+// monthCatalog holds month names by calendar and language, consulted by
+// MonthName and Date.FormatLocalized.
+var monthCatalog = struct {
+	sync.RWMutex
+	names map[Calendar]map[string][]string
+}{names: map[Calendar]map[string][]string{
+	CalendarGregorian: {
+		"en": englishGregorianMonths,
+		"de": {"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember"},
+		"fr": {"janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+	},
+	CalendarHebrew: {
+		"en": {"Tishrei", "Cheshvan", "Kislev", "Tevet", "Shevat", "Adar",
+			"Adar II", "Nisan", "Iyar", "Sivan", "Tammuz", "Av", "Elul"},
+		"he": {"תשרי", "חשוון", "כסלו", "טבת", "שבט", "אדר",
+			"אדר ב׳", "ניסן", "אייר", "סיוון", "תמוז", "אב", "אלול"},
+	},
+	CalendarFrenchRepublican: {
+		"en": append(append([]string(nil), frenchRepublicanMonths...), "Complementary days"),
+		"fr": append(append([]string(nil), frenchRepublicanMonths...), "jours complémentaires"),
+	},
+}}
+
+// RegisterMonthNames sets the month names of a calendar in a language, so
+// MonthName and Date.FormatLocalized can render it. names[0] is month 1.
+// The language is a BCP 47 tag such as "sv" or "pt-BR"; registering it
+// again replaces the names. The Julian calendar uses the Gregorian names
+// unless it has its own.
+func RegisterMonthNames(calendar Calendar, lang string, names []string) {
+	lang = strings.ToLower(lang)
+	monthCatalog.Lock()
+	defer monthCatalog.Unlock()
+	if monthCatalog.names[calendar] == nil {
+		monthCatalog.names[calendar] = make(map[string][]string)
+	}
+	monthCatalog.names[calendar][lang] = append([]string(nil), names...)
+}
+
+// MonthName returns the name of a month in a calendar for a language,
+// e.g. MonthName(CalendarHebrew, 8, "en") is "Nisan". It falls back from
+// a regional tag to its base language ("fr-CA" to "fr"), then to English,
+// then to the GEDCOM month code.
+func MonthName(calendar Calendar, month int, lang string) string {
+	lang = strings.ToLower(lang)
+	candidates := []string{lang}
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		candidates = append(candidates, lang[:i])
+	}
+	candidates = append(candidates, "en")
+
+	monthCatalog.RLock()
+	byLang := monthCatalog.names[calendar]
+	if byLang == nil && calendar == CalendarJulian {
+		byLang = monthCatalog.names[CalendarGregorian]
+	}
+	for _, l := range candidates {
+		if names := byLang[l]; month >= 1 && month <= len(names) {
+			monthCatalog.RUnlock()
+			return names[month-1]
+		}
+	}
+	monthCatalog.RUnlock()
+	return monthCode(calendar, month)
+}
+
+// monthCode returns the GEDCOM month code for a month in a calendar, or
+// the month number if the calendar has no code for it.
+func monthCode(calendar Calendar, month int) string {
+	var codes map[string]int
+	switch calendar {
+	case CalendarGregorian, CalendarJulian:
+		codes = monthNames
+	case CalendarHebrew:
+		codes = hebrewMonthNames
+	case CalendarFrenchRepublican:
+		codes = frenchMonthNames
+	default:
+		if entry, ok := lookupCustomCalendar(calendar); ok {
+			codes = entry.months
+		}
+	}
+	// A custom calendar may give a month several codes; prefer the
+	// shortest, then the first alphabetically.
+	var matches []string
+	for code, m := range codes {
+		if m == month {
+			matches = append(matches, code)
+		}
+	}
+	if len(matches) == 0 {
+		return strconv.Itoa(month)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if len(matches[i]) != len(matches[j]) {
+			return len(matches[i]) < len(matches[j])
+		}
+		return matches[i] < matches[j]
+	})
+	return matches[0]
+}
+
+// FormatCode returns the date in GEDCOM syntax with the month codes of its
+// calendar, e.g. "15 NSN 5785", "ABT JAN 1850", or "BET 1850 AND 1860".
+// The calendar escape is not included; prefix Calendar.Escape for a
+// complete GEDCOM date value. Unlike String, the result is rebuilt from the
+// parsed fields.
+func (d *Date) FormatCode() string {
+	if d == nil {
+		return ""
+	}
+	return d.format(func(c Calendar, m int) string { return monthCode(c, m) }, codeWords)
+}
+
+// FormatLocalized returns a human-readable rendering of the date with the
+// month names of its calendar in the given language (see MonthName), e.g.
+// "15 Nisan 5785" or "about 3 Floréal 7". Modifiers are written as
+// English words ("about", "between ... and ...").
+func (d *Date) FormatLocalized(lang string) string {
+	if d == nil {
+		return ""
+	}
+	return d.format(func(c Calendar, m int) string { return MonthName(c, m, lang) }, englishWords)
+}
+
+// dateWords holds the modifier keywords used by Date.format.
+type dateWords struct {
+	about, calculated, estimated, before, after string
+	between, and, from, to, interpreted         string
+	phrase                                      func(string) string
+}
+
+var codeWords = dateWords{
+	about: "ABT", calculated: "CAL", estimated: "EST", before: "BEF", after: "AFT",
+	between: "BET", and: "AND", from: "FROM", to: "TO", interpreted: "INT",
+	phrase: func(s string) string { return "(" + s + ")" },
+}
+
+var englishWords = dateWords{
+	about: "about", calculated: "calculated", estimated: "estimated", before: "before", after: "after",
+	between: "between", and: "and", from: "from", to: "to",
+	phrase: func(s string) string { return s },
+}
+
+func (d *Date) format(month func(Calendar, int) string, words dateWords) string {
+	if d.IsPhrase {
+		return words.phrase(d.Phrase)
+	}
+	main := d.formatComponents(month)
+	prefix := func(word, s string) string {
+		if word == "" {
+			return s
+		}
+		return word + " " + s
+	}
+	switch d.Modifier {
+	case ModifierAbout:
+		return prefix(words.about, main)
+	case ModifierCalculated:
+		return prefix(words.calculated, main)
+	case ModifierEstimated:
+		return prefix(words.estimated, main)
+	case ModifierBefore:
+		return prefix(words.before, main)
+	case ModifierAfter:
+		return prefix(words.after, main)
+	case ModifierFrom:
+		return prefix(words.from, main)
+	case ModifierTo:
+		return prefix(words.to, main)
+	case ModifierBetween, ModifierFromTo:
+		first, second := words.between, words.and
+		if d.Modifier == ModifierFromTo {
+			first, second = words.from, words.to
+		}
+		end := ""
+		if d.EndDate != nil {
+			end = d.EndDate.formatComponents(month)
+		}
+		return fmt.Sprintf("%s %s %s %s", first, main, second, end)
+	case ModifierInterpreted:
+		s := prefix(words.interpreted, main)
+		if d.InterpretedFrom != "" {
+			s += " (" + d.InterpretedFrom + ")"
+		}
+		return s
+	}
+	return main
+}
+
+// formatComponents renders day, month, year, dual year, and era.
+func (d *Date) formatComponents(month func(Calendar, int) string) string {
+	var parts []string
+	if d.Day > 0 {
+		parts = append(parts, strconv.Itoa(d.Day))
+	}
+	if d.Month > 0 {
+		parts = append(parts, month(d.Calendar, d.Month))
+	}
+	if d.Year != 0 {
+		year := strconv.Itoa(d.Year)
+		if d.DualYear != 0 {
+			year += fmt.Sprintf("/%02d", d.DualYear%100)
+		}
+		parts = append(parts, year)
+		if d.IsBC {
+			parts = append(parts, "BC")
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,77 @@
+package gedcom
+
+import "testing"
+
+func TestDate_FormatCodeAndLocalized(t *testing.T) {
+	tests := []struct {
+		input     string
+		lang      string
+		code      string
+		localized string
+	}{
+		{"@#DHEBREW@ 15 NSN 5785", "en", "15 NSN 5785", "15 Nisan 5785"},
+		{"@#DHEBREW@ 15 nsn 5785", "he", "15 NSN 5785", "15 ניסן 5785"},
+		{"@#DFRENCH R@ ABT 3 FLOR 7", "fr", "ABT 3 FLOR 7", "about 3 Floréal 7"},
+		{"@#DFRENCH R@ COMP 11", "fr-FR", "COMP 11", "jours complémentaires 11"},
+		{"@#DJULIAN@ 21 FEB 1750/51", "de", "21 FEB 1750/51", "21 Februar 1750/51"},
+		{"BET JAN 1850 AND 1860", "fr", "BET JAN 1850 AND 1860", "between janvier 1850 and 1860"},
+		{"FROM 1880 TO 1920", "en", "FROM 1880 TO 1920", "from 1880 to 1920"},
+		{"44 BC", "en", "44 BC", "44 BC"},
+		{"INT 1850 (about eighteen fifty)", "en", "INT 1850 (about eighteen fifty)", "1850 (about eighteen fifty)"},
+		{"(unknown)", "en", "(unknown)", "unknown"},
+		{"25 DEC 2020", "xx", "25 DEC 2020", "25 December 2020"},
+	}
+	for _, tt := range tests {
+		date, err := ParseDate(tt.input)
+		if err != nil {
+			t.Fatalf("ParseDate(%q) error = %v", tt.input, err)
+		}
+		if got := date.FormatCode(); got != tt.code {
+			t.Errorf("FormatCode(%q) = %q, want %q", tt.input, got, tt.code)
+		}
+		if got := date.FormatLocalized(tt.lang); got != tt.localized {
+			t.Errorf("FormatLocalized(%q, %q) = %q, want %q", tt.input, tt.lang, got, tt.localized)
+		}
+	}
+}
+
+func TestRegisterMonthNames(t *testing.T) {
+	RegisterMonthNames(CalendarGregorian, "SV", []string{"januari", "februari", "mars"})
+	t.Cleanup(func() {
+		monthCatalog.Lock()
+		delete(monthCatalog.names[CalendarGregorian], "sv")
+		monthCatalog.Unlock()
+	})
+	if got := MonthName(CalendarGregorian, 3, "sv-SE"); got != "mars" {
+		t.Errorf("MonthName(3, sv-SE) = %q, want mars", got)
+	}
+	// Months missing from the catalog fall back to English.
+	if got := MonthName(CalendarGregorian, 4, "sv"); got != "April" {
+		t.Errorf("MonthName(4, sv) = %q, want April", got)
+	}
+	if got := MonthName(CalendarHebrew, 14, "en"); got != "14" {
+		t.Errorf("MonthName(Hebrew 14) = %q, want 14", got)
+	}
+}
+
+func TestCalendar_Escape(t *testing.T) {
+	if CalendarGregorian.Escape() != "" || CalendarFrenchRepublican.Escape() != "@#DFRENCH R@" {
+		t.Error("unexpected built-in escapes")
+	}
+	swedish := registerSwedish(t)
+	if swedish.Escape() != "@#DSWEDISH@" {
+		t.Errorf("Escape() = %q", swedish.Escape())
+	}
+	date, _ := ParseDate("@#DSWEDISH@ 30 FEB 1712")
+	// Without a catalog the month code is used.
+	if got := date.FormatLocalized("en"); got != "30 FEB 1712" {
+		t.Errorf("custom calendar FormatLocalized() = %q", got)
+	}
+	RegisterMonthNames(swedish, "sv", []string{"januari", "februari"})
+	if got := date.FormatLocalized("sv"); got != "30 februari 1712" {
+		t.Errorf("custom calendar FormatLocalized(sv) = %q", got)
+	}
+	if got := date.FormatCode(); got != "30 FEB 1712" {
+		t.Errorf("custom calendar FormatCode() = %q", got)
+	}
+}
@@ -0,0 +1,162 @@
+package gedcom
+
+import "math"
+
+// DateTolerance configures uncertain-date matching (see Date.Matches).
+type DateTolerance struct {
+	// ApproximateYears widens ABT, CAL, and EST dates by this many years
+	// on each side.
+	ApproximateYears int
+}
+
+// DefaultDateTolerance returns the tolerance used by the library's own
+// matching: ABT, CAL, and EST dates cover five years either side.
+func DefaultDateTolerance() DateTolerance {
+	return DateTolerance{ApproximateYears: approximateYears}
+}
+
+// DateInterval is the span of days a date can denote, as Julian Day
+// Numbers. OpenStart and OpenEnd mark half-open intervals (BEF, AFT, TO,
+// FROM), whose From or To is then meaningless.
+type DateInterval struct {
+	From, To           int
+	OpenStart, OpenEnd bool
+}
+
+// Overlaps reports whether the two intervals share at least one day.
+func (i DateInterval) Overlaps(other DateInterval) bool {
+	return (i.OpenStart || other.OpenEnd || i.From <= other.To) &&
+		(i.OpenEnd || other.OpenStart || other.From <= i.To)
+}
+
+// Interval returns the days the date can denote under tolerance t:
+//
+//   - a partial date covers its whole month or year ("1850" is 1 JAN to
+//     31 DEC 1850);
+//   - ABT, CAL, and EST widen that by t.ApproximateYears on each side;
+//   - BEF and TO are open at the start, AFT and FROM at the end;
+//   - BET...AND and FROM...TO run from the start of the first date to the
+//     end of the second.
+//
+// It returns false for date phrases and dates that cannot be placed on the
+// Julian Day scale (no year, or an unregistered calendar).
+func (d *Date) Interval(t DateTolerance) (DateInterval, bool) {
+	if d == nil || d.IsPhrase {
+		return DateInterval{}, false
+	}
+	lo, hi, ok := d.dayRange()
+	if !ok {
+		return DateInterval{}, false
+	}
+	iv := DateInterval{From: lo, To: hi}
+	switch d.Modifier {
+	case ModifierAbout, ModifierCalculated, ModifierEstimated:
+		days := int(math.Round(float64(t.ApproximateYears) * 365.25))
+		iv.From -= days
+		iv.To += days
+	case ModifierBefore, ModifierTo:
+		iv.OpenStart = true
+	case ModifierAfter, ModifierFrom:
+		iv.OpenEnd = true
+	case ModifierBetween, ModifierFromTo:
+		if d.EndDate != nil {
+			if _, endHi, ok := d.EndDate.dayRange(); ok && endHi > hi {
+				iv.To = endHi
+			}
+		}
+	}
+	return iv, true
+}
+
+// Matches reports whether d and other can denote the same day under
+// tolerance t: "ABT 1850" matches "1853" with the default tolerance,
+// "BEF 1900" matches "1850", and "1850" never matches "1851". Dates
+// without an interval (see Interval) only match when their original text
+// is equal.
+func (d *Date) Matches(other *Date, t DateTolerance) bool {
+	if d == nil || other == nil {
+		return false
+	}
+	a, okA := d.Interval(t)
+	b, okB := other.Interval(t)
+	if !okA || !okB {
+		return d.Original != "" && d.Original == other.Original
+	}
+	return a.Overlaps(b)
+}
+
+// CompareTolerant compares two dates like Compare, but returns 0 whenever
+// they match under tolerance t. Otherwise their intervals are disjoint and
+// the result orders them. Dates without an interval fall back to Compare.
+func (d *Date) CompareTolerant(other *Date, t DateTolerance) int {
+	a, okA := d.Interval(t)
+	b, okB := other.Interval(t)
+	if !okA || !okB {
+		return d.Compare(other)
+	}
+	switch {
+	case a.Overlaps(b):
+		return 0
+	case !a.OpenEnd && !b.OpenStart && a.To < b.From:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// dayRange returns the Julian Day Numbers of the first and last day
+// covered by the date's own day, month, and year.
+func (d *Date) dayRange() (lo, hi int, ok bool) {
+	lo, err := d.toJDN()
+	if err != nil {
+		return 0, 0, false
+	}
+	switch {
+	case d.Day > 0:
+		return lo, lo, true
+	case d.Month > 0:
+		if days := monthLength(d); days > 0 {
+			return lo, lo + days - 1, true
+		}
+		next := *d
+		next.Month++
+		if end, err := next.toJDN(); err == nil && end > lo {
+			return lo, end - 1, true
+		}
+		return lo, lo, true
+	default:
+		next := *d
+		next.Year, next.IsBC = FromAstronomicalYear(AstronomicalYear(d.Year, d.IsBC) + 1)
+		if d.Calendar == CalendarHebrew || d.Calendar == CalendarFrenchRepublican {
+			next.Year, next.IsBC = d.Year+1, false
+		}
+		end, err := next.toJDN()
+		if err != nil || end <= lo {
+			return lo, lo, true
+		}
+		return lo, end - 1, true
+	}
+}
+
+// monthLength returns the number of days in the date's month for the
+// built-in calendars, or 0 when it is not known directly.
+func monthLength(d *Date) int {
+	year := AstronomicalYear(d.Year, d.IsBC)
+	switch d.Calendar {
+	case CalendarGregorian:
+		return GregorianToJDN(year, d.Month+1, 1) - GregorianToJDN(year, d.Month, 1)
+	case CalendarJulian:
+		return JulianToJDN(year, d.Month+1, 1) - JulianToJDN(year, d.Month, 1)
+	case CalendarHebrew:
+		return HebrewDaysInMonth(d.Year, d.Month)
+	case CalendarFrenchRepublican:
+		if d.Month == 13 {
+			if IsFrenchLeapYear(d.Year) {
+				return 6
+			}
+			return 5
+		}
+		return 30
+	}
+	return 0
+}
@@ -0,0 +1,94 @@
+package gedcom
+
+import "testing"
+
+func TestDateInterval(t *testing.T) {
+	tol := DefaultDateTolerance()
+	tests := []struct {
+		date               string
+		from, to           int
+		openStart, openEnd bool
+	}{
+		{"15 MAR 1850", GregorianToJDN(1850, 3, 15), GregorianToJDN(1850, 3, 15), false, false},
+		{"FEB 1852", GregorianToJDN(1852, 2, 1), GregorianToJDN(1852, 2, 29), false, false},
+		{"1850", GregorianToJDN(1850, 1, 1), GregorianToJDN(1850, 12, 31), false, false},
+		{"@#DJULIAN@ FEB 1700", JulianToJDN(1700, 2, 1), JulianToJDN(1700, 2, 29), false, false},
+		{"ABT 1850", GregorianToJDN(1850, 1, 1) - 1826, GregorianToJDN(1850, 12, 31) + 1826, false, false},
+		{"BET 1850 AND MAR 1855", GregorianToJDN(1850, 1, 1), GregorianToJDN(1855, 3, 31), false, false},
+		{"BEF 1850", 0, GregorianToJDN(1850, 12, 31), true, false},
+		{"AFT 1850", GregorianToJDN(1850, 1, 1), 0, false, true},
+		{"@#DFRENCH R@ COMP 2", FrenchToJDN(2, 13, 1), FrenchToJDN(2, 13, 5), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			iv, ok := mustParseDate(tt.date).Interval(tol)
+			if !ok {
+				t.Fatal("Interval() ok = false")
+			}
+			if iv.OpenStart != tt.openStart || iv.OpenEnd != tt.openEnd {
+				t.Errorf("open = %v/%v, want %v/%v", iv.OpenStart, iv.OpenEnd, tt.openStart, tt.openEnd)
+			}
+			if !tt.openStart && iv.From != tt.from {
+				t.Errorf("From = %d, want %d", iv.From, tt.from)
+			}
+			if !tt.openEnd && iv.To != tt.to {
+				t.Errorf("To = %d, want %d", iv.To, tt.to)
+			}
+		})
+	}
+
+	if _, ok := mustParseDate("(unknown)").Interval(tol); ok {
+		t.Error("phrase Interval() ok = true")
+	}
+	var nilDate *Date
+	if _, ok := nilDate.Interval(tol); ok {
+		t.Error("nil Interval() ok = true")
+	}
+}
+
+func TestDateMatches(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int // CompareTolerant(a, b)
+	}{
+		{"ABT 1850", "1853", 0},
+		{"ABT 1850", "1856", -1},
+		{"1850", "EST 1847", 0},
+		{"1850", "1851", -1},
+		{"15 MAR 1850", "MAR 1850", 0},
+		{"BEF 1900", "1850", 0},
+		{"BEF 1900", "1901", -1},
+		{"AFT 1900", "1850", 1},
+		{"AFT 1900", "BEF 1950", 0},
+		{"BET 1850 AND 1860", "1855", 0},
+		{"BET 1850 AND 1860", "1861", -1},
+		{"FROM 1850", "TO 1849", 1},
+		{"(about then)", "(about then)", 0},
+	}
+	tol := DefaultDateTolerance()
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, b := mustParseDate(tt.a), mustParseDate(tt.b)
+			if got := a.Matches(b, tol); got != (tt.want == 0) {
+				t.Errorf("Matches() = %v, want %v", got, tt.want == 0)
+			}
+			if got := b.Matches(a, tol); got != (tt.want == 0) {
+				t.Errorf("reverse Matches() = %v, want %v", got, tt.want == 0)
+			}
+			if got := a.CompareTolerant(b, tol); got != tt.want {
+				t.Errorf("CompareTolerant() = %d, want %d", got, tt.want)
+			}
+			if got := b.CompareTolerant(a, tol); got != -tt.want {
+				t.Errorf("reverse CompareTolerant() = %d, want %d", got, -tt.want)
+			}
+		})
+	}
+
+	// A wider tolerance brings approximate dates together.
+	if !mustParseDate("ABT 1850").Matches(mustParseDate("1858"), DateTolerance{ApproximateYears: 10}) {
+		t.Error("Matches() with 10-year tolerance = false")
+	}
+	if mustParseDate("ABT 1850").Matches(nil, tol) {
+		t.Error("Matches(nil) = true")
+	}
+}
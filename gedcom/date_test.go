@@ -2645,6 +2645,43 @@ func TestParseDate_InterpretedRoundtrip(t *testing.T) {
 	}
 }
 
+// TestParseDate_InterpretedCalendar tests INT dates with a calendar escape
+// before or after the modifier
+func TestParseDate_InterpretedCalendar(t *testing.T) {
+	tests := []struct {
+		input        string
+		wantCalendar Calendar
+		wantMonth    int
+		wantCode     string
+	}{
+		{"@#DJULIAN@ INT 1750 (from age at burial)", CalendarJulian, 0, "INT 1750 (from age at burial)"},
+		{"INT @#DJULIAN@ 1750 (from age at burial)", CalendarJulian, 0, "INT 1750 (from age at burial)"},
+		{"INT @#DFRENCH R@ VEND 7 (an VII)", CalendarFrenchRepublican, 1, "INT VEND 7 (an VII)"},
+		{"INT @#DGREGORIAN@ 1850", CalendarGregorian, 0, "INT 1850"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			date, err := ParseDate(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error = %v", tt.input, err)
+			}
+			if date.Calendar != tt.wantCalendar {
+				t.Errorf("Calendar = %v, want %v", date.Calendar, tt.wantCalendar)
+			}
+			if date.Month != tt.wantMonth {
+				t.Errorf("Month = %d, want %d", date.Month, tt.wantMonth)
+			}
+			if !date.IsInterpreted || date.Modifier != ModifierInterpreted {
+				t.Errorf("Modifier = %v, IsInterpreted = %v, want interpreted", date.Modifier, date.IsInterpreted)
+			}
+			if got := date.FormatCode(); got != tt.wantCode {
+				t.Errorf("FormatCode() = %q, want %q", got, tt.wantCode)
+			}
+		})
+	}
+}
+
 // TestParseDate_InterpretedErrors tests error cases for INT dates
 func TestParseDate_InterpretedErrors(t *testing.T) {
 	tests := []struct {
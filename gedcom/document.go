@@ -22,11 +22,20 @@ type Document struct {
 	// Schema contains GEDCOM 7.0 schema definitions that map custom tags to URIs.
 	// Extracted from the HEAD.SCHMA structure during decoding.
 	Schema *SchemaDefinition
+
+	// Formatting records the line endings and byte order mark of the
+	// source file when the decoder preserves formatting; nil otherwise.
+	Formatting *Formatting
+
+	// index holds the per-type record index and lock; see SetLockMode
+	// and Reindex.
+	index *documentIndex
 }
 
 // GetRecord returns the record with the given cross-reference ID.
 // Returns nil if the record is not found.
 func (d *Document) GetRecord(xref string) *Record {
+	defer d.rlock()()
 	if d.XRefMap == nil {
 		return nil
 	}
@@ -72,10 +81,11 @@ func (d *Document) GetSource(xref string) *Source {
 	return nil
 }
 
-// Individuals returns all individual records in the document.
-func (d *Document) Individuals() []*Individual {
+// Individuals returns all individual records in the document, in file
+// order or in the given SortOrder.
+func (d *Document) Individuals(order ...SortOrder) []*Individual {
 	var individuals []*Individual
-	for _, record := range d.Records {
+	for _, record := range d.sortedRecordsOfType(RecordTypeIndividual, order) {
 		if ind, ok := record.GetIndividual(); ok {
 			individuals = append(individuals, ind)
 		}
@@ -83,10 +93,11 @@ func (d *Document) Individuals() []*Individual {
 	return individuals
 }
 
-// Families returns all family records in the document.
-func (d *Document) Families() []*Family {
+// Families returns all family records in the document, in file order or
+// in the given SortOrder.
+func (d *Document) Families(order ...SortOrder) []*Family {
 	var families []*Family
-	for _, record := range d.Records {
+	for _, record := range d.sortedRecordsOfType(RecordTypeFamily, order) {
 		if fam, ok := record.GetFamily(); ok {
 			families = append(families, fam)
 		}
@@ -94,10 +105,11 @@ func (d *Document) Families() []*Family {
 	return families
 }
 
-// Sources returns all source records in the document.
-func (d *Document) Sources() []*Source {
+// Sources returns all source records in the document, in file order or in
+// the given SortOrder.
+func (d *Document) Sources(order ...SortOrder) []*Source {
 	var sources []*Source
-	for _, record := range d.Records {
+	for _, record := range d.sortedRecordsOfType(RecordTypeSource, order) {
 		if src, ok := record.GetSource(); ok {
 			sources = append(sources, src)
 		}
@@ -121,7 +133,7 @@ func (d *Document) GetSubmitter(xref string) *Submitter {
 // Submitters returns all submitter records in the document.
 func (d *Document) Submitters() []*Submitter {
 	var submitters []*Submitter
-	for _, record := range d.Records {
+	for _, record := range d.recordsOfType(RecordTypeSubmitter) {
 		if subm, ok := record.GetSubmitter(); ok {
 			submitters = append(submitters, subm)
 		}
@@ -145,7 +157,7 @@ func (d *Document) GetRepository(xref string) *Repository {
 // Repositories returns all repository records in the document.
 func (d *Document) Repositories() []*Repository {
 	var repositories []*Repository
-	for _, record := range d.Records {
+	for _, record := range d.recordsOfType(RecordTypeRepository) {
 		if repo, ok := record.GetRepository(); ok {
 			repositories = append(repositories, repo)
 		}
@@ -153,6 +165,19 @@ func (d *Document) Repositories() []*Repository {
 	return repositories
 }
 
+// SourcesInRepository returns the sources that reference the repository with
+// the given XRef through any of their REPO links, in document order. Use
+// Source.RepositoryLinksTo to read the call numbers at that repository.
+func (d *Document) SourcesInRepository(xref string) []*Source {
+	var sources []*Source
+	for _, src := range d.Sources() {
+		if len(src.RepositoryLinksTo(xref)) > 0 {
+			sources = append(sources, src)
+		}
+	}
+	return sources
+}
+
 // GetNote returns the note record with the given XRef.
 // Returns nil if not found or if the record is not a note.
 func (d *Document) GetNote(xref string) *Note {
@@ -169,7 +194,7 @@ func (d *Document) GetNote(xref string) *Note {
 // Notes returns all note records in the document.
 func (d *Document) Notes() []*Note {
 	var notes []*Note
-	for _, record := range d.Records {
+	for _, record := range d.recordsOfType(RecordTypeNote) {
 		if note, ok := record.GetNote(); ok {
 			notes = append(notes, note)
 		}
@@ -193,7 +218,7 @@ func (d *Document) GetMediaObject(xref string) *MediaObject {
 // MediaObjects returns all media object records in the document.
 func (d *Document) MediaObjects() []*MediaObject {
 	var objects []*MediaObject
-	for _, record := range d.Records {
+	for _, record := range d.recordsOfType(RecordTypeMedia) {
 		if media, ok := record.GetMediaObject(); ok {
 			objects = append(objects, media)
 		}
@@ -217,7 +242,7 @@ func (d *Document) GetSharedNote(xref string) *SharedNote {
 // SharedNotes returns all shared note records in the document (GEDCOM 7.0).
 func (d *Document) SharedNotes() []*SharedNote {
 	var notes []*SharedNote
-	for _, record := range d.Records {
+	for _, record := range d.recordsOfType(RecordTypeSharedNote) {
 		if snote, ok := record.GetSharedNote(); ok {
 			notes = append(notes, snote)
 		}
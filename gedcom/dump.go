@@ -0,0 +1,275 @@
+package gedcom
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DumpOptions configures Dump.
+type DumpOptions struct {
+	// Indent is written once per level before each line. If empty, two
+	// spaces are used.
+	Indent string
+
+	// LineNumbers prefixes each line with the source line it was decoded
+	// from, where known.
+	LineNumbers bool
+
+	// XRefs limits the dump to the records with these cross-reference
+	// IDs, leaving out the header and trailer. Default: every record.
+	XRefs []string
+
+	// MaxValueLength, when positive, shortens values longer than
+	// MaxValueLength characters, ending them with "...".
+	MaxValueLength int
+}
+
+// dumpDescriptionLength caps the record descriptions Dump writes beside
+// pointers and record lines.
+const dumpDescriptionLength = 60
+
+// Dump writes a human-readable rendering of doc to w for debugging: each
+// record's raw tags indented by level, annotated with what the decoder
+// made of them.
+//
+//	0 @I1@ INDI  # John Smith, b. ABT 1850
+//	  1 NAME John /Smith/
+//	  1 BIRT
+//	    2 DATE ABT 1850  # about 1850
+//	  1 FAMS @F1@  # FAM: John Smith & Mary Jones
+//	  1 FAMC @F9@  # missing record
+//
+// Pointers are followed by a description of the record they resolve to,
+// dates by their parsed form (or the parse error), and record lines by a
+// description of the record's entity. Records built in code without raw
+// tags are noted as such. The output is for people, not parsers; its
+// format may change between releases.
+func Dump(doc *Document, w io.Writer, opts DumpOptions) error {
+	if doc == nil {
+		return errors.New("gedcom: document is nil")
+	}
+	if opts.Indent == "" {
+		opts.Indent = "  "
+	}
+	d := &dumper{doc: doc, w: bufio.NewWriter(w), opts: opts}
+
+	if len(opts.XRefs) > 0 {
+		for _, xref := range opts.XRefs {
+			if record := doc.GetRecord(xref); record != nil {
+				d.record(record)
+			} else {
+				d.line(0, 0, xref, "record not found")
+			}
+		}
+		return d.w.Flush()
+	}
+
+	d.header(doc.Header)
+	for _, record := range doc.Records {
+		if record != nil {
+			d.record(record)
+		}
+	}
+	d.line(0, 0, "0 TRLR", "")
+	return d.w.Flush()
+}
+
+// dumper writes the lines of Dump.
+type dumper struct {
+	doc  *Document
+	w    *bufio.Writer
+	opts DumpOptions
+}
+
+func (d *dumper) header(h *Header) {
+	if h == nil {
+		d.line(0, 0, "0 HEAD", "no header")
+		return
+	}
+	var about []string
+	if h.Version != "" {
+		about = append(about, "GEDCOM "+string(h.Version))
+	}
+	if h.Encoding != "" {
+		about = append(about, string(h.Encoding))
+	}
+	if len(h.Tags) == 0 {
+		about = append(about, "no raw tags")
+	}
+	d.line(0, 0, "0 HEAD", strings.Join(about, ", "))
+	d.tags(h.Tags)
+}
+
+func (d *dumper) record(r *Record) {
+	text := fmt.Sprintf("0 %s", r.Type)
+	if r.XRef != "" {
+		text = fmt.Sprintf("0 %s %s", r.XRef, r.Type)
+	}
+	if r.Value != "" {
+		text += " " + d.shorten(r.Value)
+	}
+	about := describeRecord(d.doc, r)
+	if about == r.Value {
+		about = "" // a note's first line
+	}
+	if len(r.Tags) == 0 && r.Entity != nil {
+		about = joinNonEmpty(about, fmt.Sprintf("built from %T, no raw tags", r.Entity))
+	}
+	d.line(0, r.LineNumber, text, about)
+	d.tags(r.Tags)
+}
+
+func (d *dumper) tags(tags []*Tag) {
+	for _, tag := range tags {
+		if tag == nil || tag.Level < 1 {
+			continue
+		}
+		text := fmt.Sprintf("%d %s", tag.Level, tag.Tag)
+		if tag.XRef != "" {
+			text = fmt.Sprintf("%d %s %s", tag.Level, tag.XRef, tag.Tag)
+		}
+		if tag.Value != "" {
+			text += " " + d.shorten(tag.Value)
+		}
+		d.line(tag.Level, tag.LineNumber, text, d.annotate(tag))
+	}
+}
+
+// annotate returns what the decoder makes of a tag's value: the record a
+// pointer resolves to, or the parsed form of a date.
+func (d *dumper) annotate(tag *Tag) string {
+	switch {
+	case tag.Value == "@VOID@":
+		return "void pointer"
+	case IsPointerXRef(tag.Value):
+		target := d.doc.GetRecord(tag.Value)
+		if target == nil {
+			return "missing record"
+		}
+		return joinWith(": ", string(target.Type), describeRecord(d.doc, target))
+	case tag.Tag == "DATE" && tag.Value != "":
+		return describeDate(tag.Value)
+	}
+	return ""
+}
+
+// line writes one line of the dump at the given level.
+func (d *dumper) line(level, lineNumber int, text, about string) {
+	if d.opts.LineNumbers {
+		if lineNumber > 0 {
+			fmt.Fprintf(d.w, "%6d  ", lineNumber)
+		} else {
+			d.w.WriteString("        ")
+		}
+	}
+	d.w.WriteString(strings.Repeat(d.opts.Indent, level))
+	d.w.WriteString(text)
+	if about != "" {
+		d.w.WriteString("  # ")
+		d.w.WriteString(about)
+	}
+	d.w.WriteString("\n")
+}
+
+// shorten applies MaxValueLength to s.
+func (d *dumper) shorten(s string) string {
+	if d.opts.MaxValueLength > 0 {
+		return truncateRunes(s, d.opts.MaxValueLength)
+	}
+	return s
+}
+
+// describeRecord returns a one-line description of a record's entity: a
+// person's name and life dates, a couple's names, a source's title, and
+// so on. It returns "" when there is nothing to describe.
+func describeRecord(doc *Document, r *Record) string {
+	var s string
+	switch e := r.Entity.(type) {
+	case *Individual:
+		s = DefaultNameFormatter.FormatIndividual(e)
+		if birth := e.BirthDate(); birth != nil {
+			s += ", b. " + birth.Original
+		}
+		if death := e.DeathDate(); death != nil {
+			s += ", d. " + death.Original
+		}
+	case *Family:
+		s = spouseName(doc, e.Husband) + " & " + spouseName(doc, e.Wife)
+	case *Source:
+		s = e.Title
+	case *Repository:
+		s = e.Name
+	case *Submitter:
+		s = e.Name
+	case *Note:
+		s = e.Text
+	case *SharedNote:
+		s = e.Text
+	case *MediaObject:
+		for _, file := range e.Files {
+			if file != nil {
+				s = joinWith(" ", file.Title, file.FileRef)
+				break
+			}
+		}
+	}
+	if i := strings.IndexAny(s, "\r\n"); i >= 0 {
+		s = s[:i] + "..."
+	}
+	return truncateRunes(s, dumpDescriptionLength)
+}
+
+// spouseName returns the name of the individual xref points to, or "?".
+func spouseName(doc *Document, xref string) string {
+	if ind := doc.GetIndividual(xref); ind != nil {
+		return DefaultNameFormatter.FormatIndividual(ind)
+	}
+	return "?"
+}
+
+// describeDate returns how a date value parses.
+func describeDate(value string) string {
+	date, err := ParseDate(value)
+	if err != nil {
+		return "unparsed: " + err.Error()
+	}
+	if date.IsPhrase {
+		return "phrase: " + date.Phrase
+	}
+	s := date.FormatLocalized("en")
+	if date.Calendar != CalendarGregorian {
+		s += " (" + date.Calendar.String() + ")"
+	}
+	if err := date.Validate(); err != nil {
+		s += "; " + err.Error()
+	}
+	return s
+}
+
+// truncateRunes shortens s to max characters, ending it with "...".
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max]) + "..."
+}
+
+// joinWith joins the non-empty parts with sep.
+func joinWith(sep string, parts ...string) string {
+	var nonEmpty []string
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, sep)
+}
+
+// joinNonEmpty joins the non-empty parts with "; ".
+func joinNonEmpty(parts ...string) string {
+	return joinWith("; ", parts...)
+}
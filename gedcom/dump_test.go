@@ -0,0 +1,126 @@
+package gedcom_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const dumpInput = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE ABT 1850
+1 DEAT
+2 DATE @#DJULIAN@ 5 MAR 1901
+1 FAMS @F1@
+1 FAMC @F9@
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 BURI
+2 DATE 31 FEB 1900
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 MARR
+2 DATE (after the war)
+0 @S1@ SOUR
+1 TITL Parish register of St. Mary
+0 @N1@ NOTE First line
+1 CONT Second line
+0 TRLR
+`
+
+func decodeDumpInput(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(dumpInput))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestDump(t *testing.T) {
+	doc := decodeDumpInput(t)
+	var buf bytes.Buffer
+	if err := gedcom.Dump(doc, &buf, gedcom.DumpOptions{}); err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"0 HEAD  # GEDCOM 5.5.1, UTF-8\n  1 GEDC\n    2 VERS 5.5.1\n",
+		"0 @I1@ INDI  # John Smith, b. ABT 1850, d. @#DJULIAN@ 5 MAR 1901\n",
+		"    2 DATE ABT 1850  # about 1850\n",
+		"    2 DATE @#DJULIAN@ 5 MAR 1901  # 5 March 1901 (Julian)\n",
+		"  1 FAMS @F1@  # FAM: John Smith & Mary Jones\n",
+		"  1 FAMC @F9@  # missing record\n",
+		"    2 DATE 31 FEB 1900  # 31 February 1900; invalid date: February has 28 days",
+		"  1 HUSB @I1@  # INDI: John Smith, b. ABT 1850",
+		"    2 DATE (after the war)  # phrase: after the war\n",
+		"0 @S1@ SOUR  # Parish register of St. Mary\n",
+		"0 @N1@ NOTE First line\n  1 CONT Second line\n",
+		"0 TRLR\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Dump() lacks %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestDump_Options(t *testing.T) {
+	doc := decodeDumpInput(t)
+	var buf bytes.Buffer
+	err := gedcom.Dump(doc, &buf, gedcom.DumpOptions{
+		Indent:         "\t",
+		LineNumbers:    true,
+		XRefs:          []string{"@S1@", "@X9@"},
+		MaxValueLength: 10,
+	})
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	want := "    22  0 @S1@ SOUR  # Parish register of St. Mary\n" +
+		"    23  \t1 TITL Parish reg...\n" +
+		"        @X9@  # record not found\n"
+	if buf.String() != want {
+		t.Errorf("Dump() =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+func TestDump_BuiltInCode(t *testing.T) {
+	ind := &gedcom.Individual{XRef: "@I1@", Names: []*gedcom.PersonalName{{Full: "Ada /Lovelace/"}}}
+	doc := &gedcom.Document{
+		Records: []*gedcom.Record{{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: ind}},
+	}
+	doc.Reindex()
+	var buf bytes.Buffer
+	if err := gedcom.Dump(doc, &buf, gedcom.DumpOptions{}); err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	want := "0 HEAD  # no header\n" +
+		"0 @I1@ INDI  # Ada Lovelace; built from *gedcom.Individual, no raw tags\n" +
+		"0 TRLR\n"
+	if buf.String() != want {
+		t.Errorf("Dump() =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestDump_Errors(t *testing.T) {
+	if err := gedcom.Dump(nil, &bytes.Buffer{}, gedcom.DumpOptions{}); err == nil {
+		t.Error("Dump(nil) error = nil")
+	}
+	if err := gedcom.Dump(decodeDumpInput(t), errWriter{}, gedcom.DumpOptions{}); err == nil {
+		t.Error("Dump() to a failing writer error = nil")
+	}
+}
@@ -55,6 +55,12 @@ const (
 	// EventCremation represents a cremation event.
 	EventCremation EventType = "CREM"
 
+	// EventGeneric represents a generic event, described by its TYPE
+	// (Event.EventTypeDetail). It applies to both individuals and families.
+	EventGeneric EventType = "EVEN"
+
+	// Family events. EventCensus and EventGeneric also apply to families.
+
 	// EventMarriage represents a marriage event.
 	EventMarriage EventType = "MARR"
 	// EventDivorce represents a divorce event.
@@ -147,7 +153,7 @@ type Event struct {
 
 	// Restriction notice for privacy controls (RESN subordinate)
 	// Common values: "confidential", "locked", "privacy" (or combinations)
-	Restriction string
+	Restriction Restriction
 
 	// UID is a unique identifier for the event (UID subordinate)
 	UID string
@@ -156,6 +162,11 @@ type Event struct {
 	// Typically in ISO 8601 format (e.g., "1900-01-01")
 	SortDate string
 
+	// VendorSortDate is a sort date from a vendor extension (_SDATE, or
+	// _SORT when it holds a date), used by desktop programs before GEDCOM
+	// 7.0 added SDATE. Encoded as _SDATE.
+	VendorSortDate string
+
 	// IsNegative indicates this is a GEDCOM 7.0 negative assertion.
 	// When true, it means the event did NOT occur (e.g., NO MARR = never married).
 	// The NO tag is used to record explicit conclusions from research that an event
@@ -171,6 +182,31 @@ type Event struct {
 	// Media are references to media objects with optional crop/title
 	Media []*MediaLink
 
+	// Associations link other individuals to this event with a role, such
+	// as witnesses or godparents (ASSO within an event, GEDCOM 7.0)
+	Associations []*Association
+
 	// Tags contains all raw tags for this event (for unknown/custom fields)
 	Tags []*Tag
 }
+
+// eventsOfType returns the events of type t, skipping nil entries.
+func eventsOfType(events []*Event, t EventType) []*Event {
+	var result []*Event
+	for _, event := range events {
+		if event != nil && event.Type == t {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// firstEventOfType returns the first event of type t, or nil.
+func firstEventOfType(events []*Event, t EventType) *Event {
+	for _, event := range events {
+		if event != nil && event.Type == t {
+			return event
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package gedcom
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ParseSortDate parses a sort date (SDATE, _SDATE, or _SORT payload): a
+// GEDCOM date, or an ISO 8601 calendar date such as "1900-01-02" as some
+// programs write.
+func ParseSortDate(s string) (*Date, error) {
+	d, err := ParseDate(s)
+	if err == nil {
+		return d, nil
+	}
+	if t, terr := time.Parse("2006-01-02", s); terr == nil {
+		return ParseDate(strings.ToUpper(t.Format("2 Jan 2006")))
+	}
+	if t, terr := time.Parse("2006-01", s); terr == nil {
+		return ParseDate(strings.ToUpper(t.Format("Jan 2006")))
+	}
+	return nil, err
+}
+
+// OrderDate returns the date that places e in a chronological sequence:
+// its SDATE when that parses, else its vendor sort date, else its parsed
+// DATE. It returns nil when e has none of these, so that user-chosen
+// orderings from desktop programs win over the display date.
+func (e *Event) OrderDate() *Date {
+	if e == nil {
+		return nil
+	}
+	for _, s := range []string{e.SortDate, e.VendorSortDate} {
+		if s == "" {
+			continue
+		}
+		if d, err := ParseSortDate(s); err == nil {
+			return d
+		}
+	}
+	return e.ParsedDate
+}
+
+// SortEvents returns events in chronological order by OrderDate, without
+// modifying the slice. An event without an order date takes the date of
+// the event before it, so undated events keep their place after it, and
+// undated events at the start stay first. Events with equal dates keep
+// their order. Nil entries are dropped.
+func SortEvents(events []*Event) []*Event {
+	sorted := make([]*Event, 0, len(events))
+	var keys []*Date
+	var last *Date
+	for _, e := range events {
+		if e == nil {
+			continue
+		}
+		if d := e.OrderDate(); d != nil {
+			last = d
+		}
+		sorted = append(sorted, e)
+		keys = append(keys, last)
+	}
+	order := make([]int, len(sorted))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ka, kb := keys[order[a]], keys[order[b]]
+		if ka == nil || kb == nil {
+			return ka == nil && kb != nil
+		}
+		return ka.Compare(kb) < 0
+	})
+	result := make([]*Event, len(sorted))
+	for i, j := range order {
+		result[i] = sorted[j]
+	}
+	return result
+}
+
+// EventsChronological returns this individual's events sorted with
+// SortEvents, honoring SDATE and vendor sort dates.
+func (i *Individual) EventsChronological() []*Event {
+	return SortEvents(i.Events)
+}
+
+// EventsChronological returns this family's events sorted with
+// SortEvents, honoring SDATE and vendor sort dates.
+func (f *Family) EventsChronological() []*Event {
+	return SortEvents(f.Events)
+}
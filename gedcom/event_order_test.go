@@ -0,0 +1,97 @@
+package gedcom
+
+import (
+	"testing"
+)
+
+func TestParseSortDate(t *testing.T) {
+	for in, want := range map[string]string{
+		"1 JAN 1900": "1 JAN 1900",
+		"1900-01-02": "2 JAN 1900",
+		"1900-12":    "DEC 1900",
+		"ABT 1900":   "ABT 1900",
+	} {
+		d, err := ParseSortDate(in)
+		if err != nil {
+			t.Errorf("ParseSortDate(%q) error = %v", in, err)
+			continue
+		}
+		if got := d.String(); got != want {
+			t.Errorf("ParseSortDate(%q) = %q, want %q", in, got, want)
+		}
+	}
+	for _, in := range []string{"", "Main", "1900-13-01"} {
+		if _, err := ParseSortDate(in); err == nil {
+			t.Errorf("ParseSortDate(%q) should fail", in)
+		}
+	}
+}
+
+func TestEvent_OrderDate(t *testing.T) {
+	date := func(s string) *Date {
+		d, err := ParseDate(s)
+		if err != nil {
+			t.Fatalf("ParseDate(%q) error = %v", s, err)
+		}
+		return d
+	}
+	tests := []struct {
+		name  string
+		event *Event
+		want  string
+	}{
+		{"SDATE", &Event{ParsedDate: date("1900"), SortDate: "1905", VendorSortDate: "1 JAN 1910"}, "1905"},
+		{"vendor", &Event{ParsedDate: date("1900"), VendorSortDate: "1910-01-01"}, "1 JAN 1910"},
+		{"unparsable SDATE", &Event{ParsedDate: date("1900"), SortDate: "soon"}, "1900"},
+		{"DATE", &Event{ParsedDate: date("1900")}, "1900"},
+	}
+	for _, tt := range tests {
+		got := tt.event.OrderDate()
+		if got == nil || got.String() != tt.want {
+			t.Errorf("%s: OrderDate() = %v, want %s", tt.name, got, tt.want)
+		}
+	}
+	if (&Event{}).OrderDate() != nil || (*Event)(nil).OrderDate() != nil {
+		t.Error("OrderDate() of an undated event should be nil")
+	}
+}
+
+func TestSortEvents(t *testing.T) {
+	parse := func(s string) *Date {
+		d, _ := ParseDate(s)
+		return d
+	}
+	undatedFirst := &Event{Type: EventGeneric}
+	death := &Event{Type: EventDeath, ParsedDate: parse("1970")}
+	birth := &Event{Type: EventBirth, ParsedDate: parse("1900")}
+	residence := &Event{Type: EventResidence} // follows birth
+	// The user placed the census before the graduation with SDATE.
+	census := &Event{Type: EventCensus, ParsedDate: parse("1930"), SortDate: "1919"}
+	graduation := &Event{Type: EventGraduation, ParsedDate: parse("1920")}
+	events := []*Event{undatedFirst, death, birth, residence, nil, graduation, census}
+
+	got := SortEvents(events)
+	want := []*Event{undatedFirst, birth, residence, census, graduation, death}
+	if len(got) != len(want) {
+		t.Fatalf("SortEvents() = %d events, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("SortEvents()[%d] = %s, want %s", i, got[i].Type, want[i].Type)
+		}
+	}
+	if events[1] != death {
+		t.Error("SortEvents() modified its argument")
+	}
+
+	ind := &Individual{Events: []*Event{death, birth}}
+	if got := ind.EventsChronological(); got[0] != birth || got[1] != death {
+		t.Error("Individual.EventsChronological() not sorted")
+	}
+	divorce := &Event{Type: EventDivorce, ParsedDate: parse("1950")}
+	marriage := &Event{Type: EventMarriage, ParsedDate: parse("1925")}
+	fam := &Family{Events: []*Event{divorce, marriage}}
+	if got := fam.EventsChronological(); got[0] != marriage || got[1] != divorce {
+		t.Error("Family.EventsChronological() not sorted")
+	}
+}
@@ -0,0 +1,231 @@
+package gedcom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// EventScope identifies the record types an event type applies to.
+// Scopes are bit flags and may be combined.
+type EventScope int
+
+const (
+	// EventScopeIndividual marks an event that appears under INDI records.
+	EventScopeIndividual EventScope = 1 << iota
+
+	// EventScopeFamily marks an event that appears under FAM records.
+	EventScopeFamily
+)
+
+// EventTypeOptions configures a custom event type passed to RegisterEventType.
+type EventTypeOptions struct {
+	// Scope selects the records the event is parsed from. If zero,
+	// EventScopeIndividual is assumed.
+	Scope EventScope
+
+	// Description is a human-readable explanation of the event.
+	Description string
+}
+
+// EventTypeInfo describes one entry in the event-type registry.
+type EventTypeInfo struct {
+	// Type is the event tag (e.g., "BIRT", "_MILT").
+	Type EventType
+
+	// Scope is the set of records the event appears under.
+	Scope EventScope
+
+	// Description is a human-readable explanation of the event.
+	Description string
+
+	// Custom is true for event types added with RegisterEventType.
+	Custom bool
+}
+
+// AppliesTo reports whether the event type is valid under the given scope.
+func (i EventTypeInfo) AppliesTo(scope EventScope) bool {
+	return i.Scope&scope != 0
+}
+
+// eventRegistry is the process-wide event-type table consulted by the
+// decoder when classifying level-1 tags of INDI and FAM records.
+var eventRegistry = struct {
+	sync.RWMutex
+	types map[EventType]EventTypeInfo
+}{types: builtinEventTypes()}
+
+// builtinEvent is one standard GEDCOM event in builtinEvents.
+type builtinEvent struct {
+	scope EventScope
+	typ   EventType
+	desc  string
+}
+
+// builtinEvents lists the standard GEDCOM events: individual events, then
+// family events, then the generic EVEN. It is the single source for the registry, StandardEventTypes, and so for
+// the decoder, encoder, and validator.
+var builtinEvents = []builtinEvent{
+	{EventScopeIndividual, EventBirth, "Birth"},
+	{EventScopeIndividual, EventDeath, "Death"},
+	{EventScopeIndividual, EventBaptism, "Baptism"},
+	{EventScopeIndividual, EventBurial, "Burial"},
+	{EventScopeIndividual | EventScopeFamily, EventCensus, "Census"},
+	{EventScopeIndividual, EventChristening, "Christening"},
+	{EventScopeIndividual, EventAdoption, "Adoption"},
+	{EventScopeIndividual, EventResidence, "Residence"},
+	{EventScopeIndividual, EventImmigration, "Immigration"},
+	{EventScopeIndividual, EventEmigration, "Emigration"},
+	{EventScopeIndividual, EventBarMitzvah, "Bar Mitzvah"},
+	{EventScopeIndividual, EventBasMitzvah, "Bas Mitzvah"},
+	{EventScopeIndividual, EventBlessing, "Blessing"},
+	{EventScopeIndividual, EventAdultChristening, "Adult christening"},
+	{EventScopeIndividual, EventConfirmation, "Confirmation"},
+	{EventScopeIndividual, EventFirstCommunion, "First communion"},
+	{EventScopeIndividual, EventGraduation, "Graduation"},
+	{EventScopeIndividual, EventRetirement, "Retirement"},
+	{EventScopeIndividual, EventNaturalization, "Naturalization"},
+	{EventScopeIndividual, EventOrdination, "Ordination"},
+	{EventScopeIndividual, EventProbate, "Probate"},
+	{EventScopeIndividual, EventWill, "Will"},
+	{EventScopeIndividual, EventCremation, "Cremation"},
+
+	{EventScopeFamily, EventAnnulment, "Annulment"},
+	{EventScopeFamily, EventDivorce, "Divorce"},
+	{EventScopeFamily, EventDivorceFiling, "Divorce filing"},
+	{EventScopeFamily, EventEngagement, "Engagement"},
+	{EventScopeFamily, EventMarriageBann, "Marriage bann"},
+	{EventScopeFamily, EventMarriageContract, "Marriage contract"},
+	{EventScopeFamily, EventMarriageLicense, "Marriage license"},
+	{EventScopeFamily, EventMarriage, "Marriage"},
+	{EventScopeFamily, EventMarriageSettlement, "Marriage settlement"},
+
+	{EventScopeIndividual | EventScopeFamily, EventGeneric, "Generic event"},
+}
+
+// builtinEventTypes returns the standard GEDCOM events known to the decoder.
+func builtinEventTypes() map[EventType]EventTypeInfo {
+	types := make(map[EventType]EventTypeInfo, len(builtinEvents))
+	for _, e := range builtinEvents {
+		types[e.typ] = EventTypeInfo{Type: e.typ, Scope: e.scope, Description: e.desc}
+	}
+	return types
+}
+
+// StandardEventTypes returns the standard GEDCOM event types valid under
+// scope, individual events before family events. Custom types added with RegisterEventType
+// are not included; use RegisteredEventTypes for those.
+//
+//	gedcom.StandardEventTypes(gedcom.EventScopeFamily)
+//	// CENS ANUL DIV DIVF ENGA MARB MARC MARL MARR MARS EVEN
+func StandardEventTypes(scope EventScope) []EventType {
+	var types []EventType
+	for _, e := range builtinEvents {
+		if e.scope&scope != 0 {
+			types = append(types, e.typ)
+		}
+	}
+	return types
+}
+
+// IsIndividualEvent reports whether t is a registered event type valid
+// under INDI records.
+func (t EventType) IsIndividualEvent() bool {
+	return IsEventType(string(t), EventScopeIndividual)
+}
+
+// IsFamilyEvent reports whether t is a registered event type valid under
+// FAM records.
+func (t EventType) IsFamilyEvent() bool {
+	return IsEventType(string(t), EventScopeFamily)
+}
+
+// RegisterEventType adds a custom event type to the registry so that
+// decoders parse it into an Event (with date, place, citations and so on)
+// instead of leaving it as raw tags. Encoders emit events by their Type, so
+// a registered vendor event round-trips unchanged.
+//
+//	gedcom.RegisterEventType("_MILT", gedcom.EventTypeOptions{
+//	    Description: "Military service",
+//	})
+//
+// Only extension tags (beginning with an underscore, followed by uppercase
+// letters, digits, or underscores) may be registered; standard tags are
+// fixed by the specification. Registering a tag that is already registered
+// replaces its options. The registry is process-wide and safe for
+// concurrent use; register types before decoding documents that use them.
+func RegisterEventType(tag string, opts EventTypeOptions) error {
+	if !isExtensionTag(tag) {
+		return fmt.Errorf("gedcom: event type %q is not an extension tag (must match _[A-Z0-9_]+)", tag)
+	}
+	if opts.Scope == 0 {
+		opts.Scope = EventScopeIndividual
+	}
+
+	eventRegistry.Lock()
+	defer eventRegistry.Unlock()
+	eventRegistry.types[EventType(tag)] = EventTypeInfo{
+		Type:        EventType(tag),
+		Scope:       opts.Scope,
+		Description: opts.Description,
+		Custom:      true,
+	}
+	return nil
+}
+
+// UnregisterEventType removes a custom event type from the registry and
+// reports whether it was present. Built-in event types cannot be removed.
+func UnregisterEventType(tag string) bool {
+	eventRegistry.Lock()
+	defer eventRegistry.Unlock()
+	info, ok := eventRegistry.types[EventType(tag)]
+	if !ok || !info.Custom {
+		return false
+	}
+	delete(eventRegistry.types, EventType(tag))
+	return true
+}
+
+// LookupEventType returns the registry entry for tag.
+func LookupEventType(tag string) (EventTypeInfo, bool) {
+	eventRegistry.RLock()
+	defer eventRegistry.RUnlock()
+	info, ok := eventRegistry.types[EventType(tag)]
+	return info, ok
+}
+
+// IsEventType reports whether tag is a registered event type valid under scope.
+func IsEventType(tag string, scope EventScope) bool {
+	info, ok := LookupEventType(tag)
+	return ok && info.AppliesTo(scope)
+}
+
+// RegisteredEventTypes returns every registered event type, built-in and
+// custom, sorted by tag.
+func RegisteredEventTypes() []EventTypeInfo {
+	eventRegistry.RLock()
+	result := make([]EventTypeInfo, 0, len(eventRegistry.types))
+	for _, info := range eventRegistry.types {
+		result = append(result, info)
+	}
+	eventRegistry.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Type < result[j].Type
+	})
+	return result
+}
+
+// isExtensionTag reports whether tag is a syntactically valid extension tag.
+func isExtensionTag(tag string) bool {
+	if len(tag) < 2 || !strings.HasPrefix(tag, "_") {
+		return false
+	}
+	for _, r := range tag[1:] {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+package gedcom
+
+import "testing"
+
+func TestLookupEventType_Builtin(t *testing.T) {
+	tests := []struct {
+		tag   string
+		scope EventScope
+	}{
+		{"BIRT", EventScopeIndividual},
+		{"CREM", EventScopeIndividual},
+		{"MARR", EventScopeFamily},
+		{"EVEN", EventScopeFamily},
+		{"EVEN", EventScopeIndividual},
+		{"CENS", EventScopeFamily},
+	}
+	for _, tt := range tests {
+		info, ok := LookupEventType(tt.tag)
+		if !ok {
+			t.Errorf("LookupEventType(%q) not found", tt.tag)
+			continue
+		}
+		if !info.AppliesTo(tt.scope) || info.Custom {
+			t.Errorf("LookupEventType(%q) = %+v", tt.tag, info)
+		}
+	}
+
+	if IsEventType("MARR", EventScopeIndividual) {
+		t.Error("MARR should not be an individual event")
+	}
+	if IsEventType("OCCU", EventScopeIndividual) {
+		t.Error("OCCU is an attribute, not an event")
+	}
+}
+
+func TestRegisterEventType(t *testing.T) {
+	if err := RegisterEventType("_MILT", EventTypeOptions{Description: "Military service"}); err != nil {
+		t.Fatalf("RegisterEventType() error = %v", err)
+	}
+	defer UnregisterEventType("_MILT")
+
+	info, ok := LookupEventType("_MILT")
+	if !ok || !info.Custom || info.Scope != EventScopeIndividual || info.Description != "Military service" {
+		t.Errorf("LookupEventType(_MILT) = %+v, %v", info, ok)
+	}
+
+	// Re-registering replaces the options.
+	if err := RegisterEventType("_MILT", EventTypeOptions{Scope: EventScopeIndividual | EventScopeFamily}); err != nil {
+		t.Fatalf("re-register error = %v", err)
+	}
+	if !IsEventType("_MILT", EventScopeFamily) {
+		t.Error("re-registered _MILT should apply to families")
+	}
+
+	found := false
+	for _, info := range RegisteredEventTypes() {
+		if info.Type == "_MILT" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("RegisteredEventTypes() missing _MILT")
+	}
+}
+
+func TestRegisterEventType_Invalid(t *testing.T) {
+	for _, tag := range []string{"", "_", "MILT", "BIRT", "_milt", "_MI LT"} {
+		if err := RegisterEventType(tag, EventTypeOptions{}); err == nil {
+			t.Errorf("RegisterEventType(%q) should fail", tag)
+			UnregisterEventType(tag)
+		}
+	}
+}
+
+func TestUnregisterEventType(t *testing.T) {
+	if UnregisterEventType("BIRT") {
+		t.Error("built-in event types must not be removable")
+	}
+	if UnregisterEventType("_NOPE") {
+		t.Error("unregistering an unknown type should report false")
+	}
+	if err := RegisterEventType("_DNA", EventTypeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !UnregisterEventType("_DNA") {
+		t.Error("UnregisterEventType(_DNA) = false")
+	}
+	if _, ok := LookupEventType("_DNA"); ok {
+		t.Error("_DNA still registered")
+	}
+}
+
+func TestStandardEventTypes(t *testing.T) {
+	family := StandardEventTypes(EventScopeFamily)
+	want := []EventType{
+		EventCensus, EventAnnulment, EventDivorce, EventDivorceFiling, EventEngagement, EventMarriageBann,
+		EventMarriageContract, EventMarriageLicense, EventMarriage, EventMarriageSettlement, EventGeneric,
+	}
+	if len(family) != len(want) {
+		t.Fatalf("StandardEventTypes(family) = %v, want %v", family, want)
+	}
+	for i := range want {
+		if family[i] != want[i] {
+			t.Errorf("StandardEventTypes(family)[%d] = %s, want %s", i, family[i], want[i])
+		}
+	}
+
+	individual := StandardEventTypes(EventScopeIndividual)
+	for _, typ := range individual {
+		if !typ.IsIndividualEvent() {
+			t.Errorf("%s.IsIndividualEvent() = false", typ)
+		}
+	}
+	for _, typ := range family {
+		if !typ.IsFamilyEvent() {
+			t.Errorf("%s.IsFamilyEvent() = false", typ)
+		}
+	}
+	if EventMarriage.IsIndividualEvent() || EventBirth.IsFamilyEvent() {
+		t.Error("MARR and BIRT should each apply to one scope only")
+	}
+
+	all := StandardEventTypes(EventScopeIndividual | EventScopeFamily)
+	if len(all) != len(individual)+len(family)-2 {
+		t.Errorf("StandardEventTypes(both) has %d types, want %d (CENS and EVEN shared)", len(all), len(individual)+len(family)-2)
+	}
+
+	if err := RegisterEventType("_SEPR", EventTypeOptions{Scope: EventScopeFamily}); err != nil {
+		t.Fatal(err)
+	}
+	defer UnregisterEventType("_SEPR")
+	if !EventType("_SEPR").IsFamilyEvent() {
+		t.Error("registered _SEPR should be a family event")
+	}
+	for _, typ := range StandardEventTypes(EventScopeFamily) {
+		if typ == "_SEPR" {
+			t.Error("StandardEventTypes() should not include custom types")
+		}
+	}
+}
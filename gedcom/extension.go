@@ -0,0 +1,425 @@
+package gedcom
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// ExtensionPayload describes the expected payload (line value) of an
+// extension structure.
+type ExtensionPayload int
+
+const (
+	// ExtensionPayloadAny accepts any payload, including none.
+	ExtensionPayloadAny ExtensionPayload = iota
+
+	// ExtensionPayloadNone requires an empty payload.
+	ExtensionPayloadNone
+
+	// ExtensionPayloadText requires a non-empty payload.
+	ExtensionPayloadText
+
+	// ExtensionPayloadInteger requires a base-10 integer.
+	ExtensionPayloadInteger
+
+	// ExtensionPayloadPointer requires a cross-reference pointer (e.g., "@I1@").
+	ExtensionPayloadPointer
+
+	// ExtensionPayloadYesNo requires "Y" or "N".
+	ExtensionPayloadYesNo
+
+	// ExtensionPayloadDate requires a GEDCOM date accepted by ParseDate.
+	ExtensionPayloadDate
+)
+
+// String returns a human-readable name for the payload type.
+func (p ExtensionPayload) String() string {
+	switch p {
+	case ExtensionPayloadAny:
+		return "any"
+	case ExtensionPayloadNone:
+		return "none"
+	case ExtensionPayloadText:
+		return "text"
+	case ExtensionPayloadInteger:
+		return "integer"
+	case ExtensionPayloadPointer:
+		return "pointer"
+	case ExtensionPayloadYesNo:
+		return "Y/N"
+	case ExtensionPayloadDate:
+		return "date"
+	default:
+		return fmt.Sprintf("ExtensionPayload(%d)", int(p))
+	}
+}
+
+// Accepts reports whether value is a valid payload of this type.
+func (p ExtensionPayload) Accepts(value string) bool {
+	switch p {
+	case ExtensionPayloadNone:
+		return value == ""
+	case ExtensionPayloadText:
+		return value != ""
+	case ExtensionPayloadInteger:
+		_, err := strconv.Atoi(value)
+		return err == nil
+	case ExtensionPayloadPointer:
+		return IsPointerXRef(value)
+	case ExtensionPayloadYesNo:
+		return value == "Y" || value == "N"
+	case ExtensionPayloadDate:
+		_, err := ParseDate(value)
+		return err == nil
+	default:
+		return true
+	}
+}
+
+// ExtensionSchema declares the structure of an application-defined
+// extension tag. A root schema is registered with RegisterExtension; its
+// Children describe the substructures allowed beneath it, recursively.
+//
+//	gedcom.RegisterExtension(gedcom.ExtensionSchema{
+//	    Tag:     "_MYAPP",
+//	    URI:     "https://example.com/gedcom/myapp",
+//	    Records: []gedcom.RecordType{gedcom.RecordTypeIndividual},
+//	    Payload: gedcom.ExtensionPayloadNone,
+//	    Children: []gedcom.ExtensionSchema{
+//	        {Tag: "_ID", Payload: gedcom.ExtensionPayloadText, Required: true},
+//	        {Tag: "_TAG", Payload: gedcom.ExtensionPayloadText, Repeatable: true},
+//	    },
+//	})
+type ExtensionSchema struct {
+	// Tag is the structure's tag. Root tags must be extension tags
+	// (an underscore followed by uppercase letters, digits, or underscores).
+	Tag string
+
+	// URI optionally identifies the extension, as in a GEDCOM 7.0
+	// HEAD.SCHMA.TAG declaration. Only meaningful on root schemas.
+	URI string
+
+	// Description is a human-readable explanation of the structure.
+	Description string
+
+	// Records lists the record types a root extension may appear under at
+	// level 1. Empty means any record type. Ignored on child schemas.
+	Records []RecordType
+
+	// Payload is the expected line value.
+	Payload ExtensionPayload
+
+	// Required marks a child that must appear at least once under its parent.
+	Required bool
+
+	// Repeatable allows a child to appear more than once under its parent.
+	Repeatable bool
+
+	// Children lists the substructures allowed beneath this one.
+	Children []ExtensionSchema
+}
+
+// Child returns the schema of the child with the given tag.
+func (s *ExtensionSchema) Child(tag string) (*ExtensionSchema, bool) {
+	for i := range s.Children {
+		if s.Children[i].Tag == tag {
+			return &s.Children[i], true
+		}
+	}
+	return nil, false
+}
+
+// AppliesTo reports whether the root extension may appear under recordType.
+func (s *ExtensionSchema) AppliesTo(recordType RecordType) bool {
+	if len(s.Records) == 0 {
+		return true
+	}
+	for _, rt := range s.Records {
+		if rt == recordType {
+			return true
+		}
+	}
+	return false
+}
+
+// extensionRegistry is the process-wide table of application extension
+// schemas consulted by the decoder when populating ExtensionData.
+var extensionRegistry = struct {
+	sync.RWMutex
+	schemas map[string]ExtensionSchema
+}{schemas: make(map[string]ExtensionSchema)}
+
+// RegisterExtension declares an application extension namespace. Once
+// registered, decoders collect occurrences of schema.Tag at level 1 of
+// matching records into the entity's ExtensionData, the validator checks
+// them against the schema, and encoders re-emit ExtensionData for entities
+// built without raw tags.
+//
+// The root tag must be an extension tag and child tags must be non-empty
+// and unique among their siblings. Registering a tag that is already
+// registered replaces its schema. The registry is process-wide and safe for
+// concurrent use; register schemas before decoding documents that use them.
+func RegisterExtension(schema ExtensionSchema) error {
+	if !isExtensionTag(schema.Tag) {
+		return fmt.Errorf("gedcom: extension %q is not an extension tag (must match _[A-Z0-9_]+)", schema.Tag)
+	}
+	if err := checkExtensionChildren(schema.Tag, schema.Children); err != nil {
+		return err
+	}
+
+	extensionRegistry.Lock()
+	defer extensionRegistry.Unlock()
+	extensionRegistry.schemas[schema.Tag] = schema
+	return nil
+}
+
+// checkExtensionChildren verifies that child tags are non-empty and unique.
+func checkExtensionChildren(path string, children []ExtensionSchema) error {
+	seen := make(map[string]bool, len(children))
+	for _, child := range children {
+		if child.Tag == "" {
+			return fmt.Errorf("gedcom: extension %s has a child with an empty tag", path)
+		}
+		if seen[child.Tag] {
+			return fmt.Errorf("gedcom: extension %s declares child %s more than once", path, child.Tag)
+		}
+		seen[child.Tag] = true
+		if err := checkExtensionChildren(path+"."+child.Tag, child.Children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnregisterExtension removes an extension schema and reports whether it
+// was registered.
+func UnregisterExtension(tag string) bool {
+	extensionRegistry.Lock()
+	defer extensionRegistry.Unlock()
+	if _, ok := extensionRegistry.schemas[tag]; !ok {
+		return false
+	}
+	delete(extensionRegistry.schemas, tag)
+	return true
+}
+
+// LookupExtension returns the registered schema for tag.
+func LookupExtension(tag string) (ExtensionSchema, bool) {
+	extensionRegistry.RLock()
+	defer extensionRegistry.RUnlock()
+	schema, ok := extensionRegistry.schemas[tag]
+	return schema, ok
+}
+
+// RegisteredExtensions returns every registered extension schema, sorted by tag.
+func RegisteredExtensions() []ExtensionSchema {
+	extensionRegistry.RLock()
+	result := make([]ExtensionSchema, 0, len(extensionRegistry.schemas))
+	for _, schema := range extensionRegistry.schemas {
+		result = append(result, schema)
+	}
+	extensionRegistry.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Tag < result[j].Tag
+	})
+	return result
+}
+
+// Extension is one occurrence of an extension structure: a tag, its
+// payload, and its substructures.
+type Extension struct {
+	// Tag is the structure's tag (e.g., "_MYAPP").
+	Tag string
+
+	// Value is the line value, with CONT/CONC continuations folded in.
+	Value string
+
+	// LineNumber is the source line of the tag (0 for hand-built extensions).
+	LineNumber int
+
+	// Children are the substructures, in file order.
+	Children []*Extension
+}
+
+// Child returns the first child with the given tag, or nil.
+func (e *Extension) Child(tag string) *Extension {
+	if e == nil {
+		return nil
+	}
+	for _, child := range e.Children {
+		if child != nil && child.Tag == tag {
+			return child
+		}
+	}
+	return nil
+}
+
+// ChildValue returns the value of the first child with the given tag, or "".
+func (e *Extension) ChildValue(tag string) string {
+	if child := e.Child(tag); child != nil {
+		return child.Value
+	}
+	return ""
+}
+
+// ExtensionFromTags builds an Extension from the tag at idx and its
+// subordinates. CONT and CONC subordinates are folded into Value.
+func ExtensionFromTags(tags []*Tag, idx int) *Extension {
+	ext, _ := extensionFromTags(tags, idx)
+	return ext
+}
+
+// extensionFromTags builds an Extension and returns the index just past
+// its subordinate block.
+func extensionFromTags(tags []*Tag, idx int) (*Extension, int) {
+	tag := tags[idx]
+	ext := &Extension{Tag: tag.Tag, Value: tag.Value, LineNumber: tag.LineNumber}
+	i := idx + 1
+	for i < len(tags) && tags[i].Level > tag.Level {
+		sub := tags[i]
+		if sub.Level != tag.Level+1 {
+			i++
+			continue
+		}
+		switch sub.Tag {
+		case "CONT":
+			ext.Value += "\n" + sub.Value
+			i++
+		case "CONC":
+			ext.Value += sub.Value
+			i++
+		default:
+			var child *Extension
+			child, i = extensionFromTags(tags, i)
+			ext.Children = append(ext.Children, child)
+		}
+	}
+	return ext, i
+}
+
+// ExtensionData holds the application extensions of an entity, keyed by
+// root tag. Each tag maps to its occurrences in file order.
+type ExtensionData map[string][]*Extension
+
+// Get returns the first occurrence of tag, or nil.
+func (d ExtensionData) Get(tag string) *Extension {
+	if len(d[tag]) == 0 {
+		return nil
+	}
+	return d[tag][0]
+}
+
+// Add appends an occurrence of ext.Tag.
+func (d ExtensionData) Add(ext *Extension) {
+	d[ext.Tag] = append(d[ext.Tag], ext)
+}
+
+// SortedTags returns the root tags present, sorted.
+func (d ExtensionData) SortedTags() []string {
+	tags := make([]string, 0, len(d))
+	for tag := range d {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// ExtractExtensionData collects the registered extensions that appear at
+// level 1 of tags and apply to recordType. It returns nil if there are none.
+func ExtractExtensionData(recordType RecordType, tags []*Tag) ExtensionData {
+	var data ExtensionData
+	for i, tag := range tags {
+		if tag.Level != 1 || !isExtensionTag(tag.Tag) {
+			continue
+		}
+		schema, ok := LookupExtension(tag.Tag)
+		if !ok || !schema.AppliesTo(recordType) {
+			continue
+		}
+		if data == nil {
+			data = make(ExtensionData)
+		}
+		data.Add(ExtensionFromTags(tags, i))
+	}
+	return data
+}
+
+// cloneExtensionData returns a deep copy of d.
+func cloneExtensionData(d ExtensionData) ExtensionData {
+	if d == nil {
+		return nil
+	}
+	copied := make(ExtensionData, len(d))
+	for tag, exts := range d {
+		list := make([]*Extension, len(exts))
+		for i, ext := range exts {
+			list[i] = cloneExtension(ext)
+		}
+		copied[tag] = list
+	}
+	return copied
+}
+
+// cloneExtension returns a deep copy of e.
+func cloneExtension(e *Extension) *Extension {
+	if e == nil {
+		return nil
+	}
+	copied := &Extension{Tag: e.Tag, Value: e.Value, LineNumber: e.LineNumber}
+	if e.Children != nil {
+		copied.Children = make([]*Extension, len(e.Children))
+		for i, child := range e.Children {
+			copied.Children[i] = cloneExtension(child)
+		}
+	}
+	return copied
+}
+
+// ExtensionData returns the ExtensionData of the record's entity, or nil
+// if the entity type does not carry extensions.
+func (r *Record) ExtensionData() ExtensionData {
+	switch e := r.Entity.(type) {
+	case *Individual:
+		return e.ExtensionData
+	case *Family:
+		return e.ExtensionData
+	case *Source:
+		return e.ExtensionData
+	case *Repository:
+		return e.ExtensionData
+	case *Submitter:
+		return e.ExtensionData
+	case *MediaObject:
+		return e.ExtensionData
+	}
+	return nil
+}
+
+// setExtensionData stores data on the record's entity, if it carries extensions.
+func (r *Record) setExtensionData(data ExtensionData) {
+	switch e := r.Entity.(type) {
+	case *Individual:
+		e.ExtensionData = data
+	case *Family:
+		e.ExtensionData = data
+	case *Source:
+		e.ExtensionData = data
+	case *Repository:
+		e.ExtensionData = data
+	case *Submitter:
+		e.ExtensionData = data
+	case *MediaObject:
+		e.ExtensionData = data
+	}
+}
+
+// PopulateExtensionData sets the entity's ExtensionData from the record's
+// raw tags, using the current extension registry. Decoders call this for
+// every record; call it again after registering schemas to refresh a
+// document decoded earlier.
+func (r *Record) PopulateExtensionData() {
+	r.setExtensionData(ExtractExtensionData(r.Type, r.Tags))
+}
@@ -0,0 +1,172 @@
+package gedcom
+
+import "testing"
+
+func testExtensionSchema() ExtensionSchema {
+	return ExtensionSchema{
+		Tag:     "_MYAPP",
+		URI:     "https://example.com/gedcom/myapp",
+		Records: []RecordType{RecordTypeIndividual},
+		Payload: ExtensionPayloadNone,
+		Children: []ExtensionSchema{
+			{Tag: "_ID", Payload: ExtensionPayloadText, Required: true},
+			{Tag: "_TAG", Payload: ExtensionPayloadText, Repeatable: true},
+		},
+	}
+}
+
+func TestRegisterExtension(t *testing.T) {
+	if err := RegisterExtension(testExtensionSchema()); err != nil {
+		t.Fatalf("RegisterExtension() error = %v", err)
+	}
+	defer UnregisterExtension("_MYAPP")
+
+	schema, ok := LookupExtension("_MYAPP")
+	if !ok || schema.URI != "https://example.com/gedcom/myapp" {
+		t.Fatalf("LookupExtension() = %+v, %v", schema, ok)
+	}
+	if child, ok := schema.Child("_ID"); !ok || !child.Required {
+		t.Errorf("Child(_ID) = %+v, %v", child, ok)
+	}
+	if _, ok := schema.Child("_NOPE"); ok {
+		t.Error("Child(_NOPE) should not be found")
+	}
+	if !schema.AppliesTo(RecordTypeIndividual) || schema.AppliesTo(RecordTypeFamily) {
+		t.Error("AppliesTo() should allow only INDI")
+	}
+
+	found := false
+	for _, s := range RegisteredExtensions() {
+		if s.Tag == "_MYAPP" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("RegisteredExtensions() missing _MYAPP")
+	}
+
+	if !UnregisterExtension("_MYAPP") {
+		t.Error("UnregisterExtension() = false, want true")
+	}
+	if UnregisterExtension("_MYAPP") {
+		t.Error("second UnregisterExtension() = true, want false")
+	}
+}
+
+func TestRegisterExtension_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema ExtensionSchema
+	}{
+		{"standard tag", ExtensionSchema{Tag: "NOTE"}},
+		{"lowercase", ExtensionSchema{Tag: "_myapp"}},
+		{"empty child", ExtensionSchema{Tag: "_X", Children: []ExtensionSchema{{}}}},
+		{"duplicate child", ExtensionSchema{Tag: "_X", Children: []ExtensionSchema{{Tag: "_A"}, {Tag: "_A"}}}},
+		{"nested duplicate", ExtensionSchema{Tag: "_X", Children: []ExtensionSchema{
+			{Tag: "_A", Children: []ExtensionSchema{{Tag: "_B"}, {Tag: "_B"}}},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterExtension(tt.schema); err == nil {
+				UnregisterExtension(tt.schema.Tag)
+				t.Error("RegisterExtension() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestExtensionPayload_Accepts(t *testing.T) {
+	tests := []struct {
+		payload ExtensionPayload
+		value   string
+		want    bool
+	}{
+		{ExtensionPayloadAny, "", true},
+		{ExtensionPayloadAny, "x", true},
+		{ExtensionPayloadNone, "", true},
+		{ExtensionPayloadNone, "x", false},
+		{ExtensionPayloadText, "x", true},
+		{ExtensionPayloadText, "", false},
+		{ExtensionPayloadInteger, "42", true},
+		{ExtensionPayloadInteger, "4x", false},
+		{ExtensionPayloadPointer, "@I1@", true},
+		{ExtensionPayloadPointer, "I1", false},
+		{ExtensionPayloadYesNo, "Y", true},
+		{ExtensionPayloadYesNo, "yes", false},
+		{ExtensionPayloadDate, "1 JAN 1900", true},
+		{ExtensionPayloadDate, "not a date at all", false},
+	}
+	for _, tt := range tests {
+		if got := tt.payload.Accepts(tt.value); got != tt.want {
+			t.Errorf("%s.Accepts(%q) = %v, want %v", tt.payload, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestExtractExtensionData(t *testing.T) {
+	if err := RegisterExtension(testExtensionSchema()); err != nil {
+		t.Fatal(err)
+	}
+	defer UnregisterExtension("_MYAPP")
+
+	tags := []*Tag{
+		{Level: 1, Tag: "NAME", Value: "John /Doe/"},
+		{Level: 1, Tag: "_MYAPP", LineNumber: 3},
+		{Level: 2, Tag: "_ID", Value: "abc"},
+		{Level: 2, Tag: "_TAG", Value: "first line"},
+		{Level: 3, Tag: "CONT", Value: "second line"},
+		{Level: 2, Tag: "_TAG", Value: "one"},
+		{Level: 3, Tag: "CONC", Value: " more"},
+		{Level: 1, Tag: "_OTHER", Value: "ignored"},
+	}
+
+	data := ExtractExtensionData(RecordTypeIndividual, tags)
+	ext := data.Get("_MYAPP")
+	if ext == nil || ext.LineNumber != 3 {
+		t.Fatalf("Get(_MYAPP) = %+v", ext)
+	}
+	if got := ext.ChildValue("_ID"); got != "abc" {
+		t.Errorf("ChildValue(_ID) = %q, want %q", got, "abc")
+	}
+	if len(ext.Children) != 3 || ext.Children[1].Value != "first line\nsecond line" || ext.Children[2].Value != "one more" {
+		t.Errorf("Children = %+v", ext.Children)
+	}
+	if data.Get("_OTHER") != nil {
+		t.Error("unregistered extension should not be extracted")
+	}
+	if ExtractExtensionData(RecordTypeFamily, tags) != nil {
+		t.Error("extension should not be extracted from a disallowed record type")
+	}
+	if ext.Child("_MISSING") != nil || ext.ChildValue("_MISSING") != "" {
+		t.Error("missing child should be nil/empty")
+	}
+}
+
+func TestExtensionData_CloneAndPopulate(t *testing.T) {
+	if err := RegisterExtension(testExtensionSchema()); err != nil {
+		t.Fatal(err)
+	}
+	defer UnregisterExtension("_MYAPP")
+
+	indi := &Individual{XRef: "@I1@"}
+	record := &Record{
+		XRef:   "@I1@",
+		Type:   RecordTypeIndividual,
+		Entity: indi,
+		Tags: []*Tag{
+			{Level: 1, Tag: "_MYAPP"},
+			{Level: 2, Tag: "_ID", Value: "abc"},
+		},
+	}
+	record.PopulateExtensionData()
+	if record.ExtensionData().Get("_MYAPP") == nil {
+		t.Fatal("PopulateExtensionData() did not set ExtensionData")
+	}
+
+	copied := indi.Clone()
+	copied.ExtensionData.Get("_MYAPP").Children[0].Value = "changed"
+	if indi.ExtensionData.Get("_MYAPP").ChildValue("_ID") != "abc" {
+		t.Error("Clone() shares extension data with the original")
+	}
+}
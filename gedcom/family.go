@@ -1,5 +1,7 @@
 package gedcom
 
+import "strings"
+
 // Family represents a family unit (husband, wife, and children).
 type Family struct {
 	// XRef is the cross-reference identifier for this family
@@ -11,12 +13,30 @@ type Family struct {
 	// Wife is the XRef to the wife individual
 	Wife string
 
+	// Spouses lists every partner line of the family in file order: each
+	// HUSB and WIFE (a family may have two of either, as some 7.0 and
+	// vendor files write for same-sex couples or unclear records) and each
+	// ASSO whose role marks a partner (see IsPartnerRole). Husband and
+	// Wife stand for the first HUSB and WIFE entries, so assigning them
+	// directly replaces those; SetHusband and SetWife also update the
+	// entries. The encoder writes OtherSpouses' HUSB and WIFE entries;
+	// ASSO partners are written from Associations.
+	Spouses []*FamilySpouse
+
 	// Children are XRefs to child individuals
 	Children []string
 
+	// Restriction is the record-level access restriction (RESN tag). Use
+	// its Level or Has method to interpret it.
+	Restriction Restriction
+
 	// NumberOfChildren is the declared number of children (NCHI tag)
 	NumberOfChildren string
 
+	// Associations are the individuals associated with the family (ASSO
+	// tags, GEDCOM 7.0), such as witnesses or, in some files, partners.
+	Associations []*Association
+
 	// Events contains family events (marriage, divorce, etc.)
 	Events []*Event
 
@@ -50,9 +70,21 @@ type Family struct {
 	// CreationDate is when the record was created (CREA tag, GEDCOM 7.0)
 	CreationDate *ChangeDate
 
-	// RefNumber is the user reference number (REFN tag)
+	// UserReferences are the user reference numbers (REFN tags, can have
+	// multiple), each with its optional TYPE.
+	UserReferences []*UserReference
+
+	// RefNumber is deprecated: use UserReferences instead. It is kept for
+	// backward compatibility and populated during decode with the number of
+	// the first REFN. The encoder writes it only when UserReferences is empty.
+	//
+	// Deprecated: use UserReferences.
 	RefNumber string
 
+	// AutomatedRecordID is the record ID assigned by the application that
+	// produced the file (RIN tag, GEDCOM 5.5.x)
+	AutomatedRecordID string
+
 	// UID is the unique identifier (UID tag)
 	UID string
 
@@ -60,10 +92,137 @@ type Family struct {
 	// Links this record to external systems like FamilySearch, Ancestry, etc.
 	ExternalIDs []*ExternalID
 
+	// ExtensionData holds the registered application extensions of this
+	// family (see RegisterExtension), keyed by root tag.
+	ExtensionData ExtensionData
+
 	// Tags contains all raw tags for this family (for unknown/custom tags)
 	Tags []*Tag
 }
 
+// FamilySpouse is one partner line of a family.
+type FamilySpouse struct {
+	// XRef is the partner's individual XRef.
+	XRef string
+
+	// Tag is the line the partner comes from: "HUSB", "WIFE", or "ASSO".
+	Tag string
+
+	// Role is the ROLE (or 5.5.1 RELA) of an ASSO partner.
+	Role string
+}
+
+// IsPartnerRole reports whether an association role names a partner: the
+// GEDCOM 7.0 roles HUSB, WIFE, and SPOU, or the words husband, wife,
+// spouse, and partner used in 5.5.1 RELA values. Case is ignored.
+func IsPartnerRole(role string) bool {
+	switch strings.ToUpper(strings.TrimSpace(role)) {
+	case "HUSB", "WIFE", "SPOU", "HUSBAND", "SPOUSE", "PARTNER":
+		return true
+	}
+	return false
+}
+
+// SetHusband sets Husband to xref and keeps Spouses in step: the first
+// HUSB entry, the one Husband stands for, is re-pointed at xref, or
+// removed when xref is empty, in which case the next HUSB entry, if any,
+// becomes the husband.
+func (f *Family) SetHusband(xref string) {
+	f.Husband = f.setSpouse("HUSB", xref)
+}
+
+// SetWife sets Wife to xref and keeps Spouses in step, as SetHusband does
+// for Husband.
+func (f *Family) SetWife(xref string) {
+	f.Wife = f.setSpouse("WIFE", xref)
+}
+
+// setSpouse replaces the first tag entry of Spouses with xref, removing it
+// when xref is empty and adding one when there is none, and returns the
+// XRef now in that slot.
+func (f *Family) setSpouse(tag, xref string) string {
+	i := f.spouseSlot(tag)
+	switch {
+	case xref == "" && i >= 0:
+		f.Spouses = append(f.Spouses[:i:i], f.Spouses[i+1:]...)
+	case i >= 0:
+		replaced := *f.Spouses[i]
+		replaced.XRef = xref
+		f.Spouses[i] = &replaced
+	case xref != "":
+		f.Spouses = append(f.Spouses, &FamilySpouse{XRef: xref, Tag: tag})
+	}
+	return f.slotXRef(tag)
+}
+
+// spouseSlot returns the index of the first tag (HUSB or WIFE) entry of
+// Spouses, the one Husband or Wife stands for, or -1 if there is none.
+func (f *Family) spouseSlot(tag string) int {
+	for i, spouse := range f.Spouses {
+		if spouse != nil && spouse.Tag == tag {
+			return i
+		}
+	}
+	return -1
+}
+
+// slotXRef returns the XRef of the first tag entry of Spouses, or "".
+func (f *Family) slotXRef(tag string) string {
+	if i := f.spouseSlot(tag); i >= 0 {
+		return f.Spouses[i].XRef
+	}
+	return ""
+}
+
+// OtherSpouses returns the entries of Spouses beyond the first HUSB and
+// the first WIFE: those are the slots Husband and Wife stand for, whatever
+// XRef they hold, so assigning Husband or Wife directly replaces them.
+func (f *Family) OtherSpouses() []*FamilySpouse {
+	husband, wife := f.spouseSlot("HUSB"), f.spouseSlot("WIFE")
+	var others []*FamilySpouse
+	for i, spouse := range f.Spouses {
+		if spouse != nil && i != husband && i != wife {
+			others = append(others, spouse)
+		}
+	}
+	return others
+}
+
+// Partners returns the XRefs of the family's partners without
+// duplicates: Husband, Wife, then OtherSpouses in file order.
+func (f *Family) Partners() []string {
+	var partners []string
+	seen := make(map[string]bool)
+	add := func(xref string) {
+		if xref != "" && !seen[xref] {
+			seen[xref] = true
+			partners = append(partners, xref)
+		}
+	}
+	add(f.Husband)
+	add(f.Wife)
+	for _, spouse := range f.OtherSpouses() {
+		add(spouse.XRef)
+	}
+	return partners
+}
+
+// PartnerIndividuals returns the Individual records of Partners. Invalid
+// xrefs are filtered out; returns an empty slice if the document is nil.
+func (f *Family) PartnerIndividuals(doc *Document) []*Individual {
+	if doc == nil {
+		return []*Individual{}
+	}
+	partners := f.Partners()
+	result := make([]*Individual, 0, len(partners))
+	for _, xref := range partners {
+		if indi := doc.GetIndividual(xref); indi != nil {
+			result = append(result, indi)
+		}
+	}
+	return result
+}
+
 // AllNotes returns this family's inline notes followed by the text of any
 // shared notes referenced by NoteXRefs, resolved against doc. Shared notes that
 // do not resolve are skipped. Returns nil when there are no notes.
@@ -71,6 +230,28 @@ func (f *Family) AllNotes(doc *Document) []string {
 	return allNotes(doc, f.InlineNotes, f.NoteXRefs)
 }
 
+// EventsOfType returns this family's events of type t in file order, or
+// nil if there are none. Negative assertions (GEDCOM 7.0 NO) are included;
+// check Event.IsNegative to tell them apart.
+func (f *Family) EventsOfType(t EventType) []*Event {
+	return eventsOfType(f.Events, t)
+}
+
+// FirstEvent returns this family's first event of type t, or nil if none found.
+func (f *Family) FirstEvent(t EventType) *Event {
+	return firstEventOfType(f.Events, t)
+}
+
+// Marriage returns the first marriage event for this family, or nil if none found.
+func (f *Family) Marriage() *Event {
+	return f.FirstEvent(EventMarriage)
+}
+
+// Divorce returns the first divorce event for this family, or nil if none found.
+func (f *Family) Divorce() *Event {
+	return f.FirstEvent(EventDivorce)
+}
+
 // HusbandIndividual returns the Individual record for the husband.
 // Returns nil if the document is nil, Husband xref is empty, or the individual is not found.
 func (f *Family) HusbandIndividual(doc *Document) *Individual {
@@ -105,22 +286,16 @@ func (f *Family) ChildrenIndividuals(doc *Document) []*Individual {
 	return result
 }
 
-// AllMembers returns all Individual records for this family (husband, wife, children).
-// Order: husband first (if present), wife second (if present), then children.
+// AllMembers returns all Individual records for this family (partners, children).
+// Order: husband first (if present), wife second (if present), any other
+// partners of Spouses, then children.
 // Invalid xrefs are filtered out.
 // Returns an empty slice if the document is nil or no members are found.
 func (f *Family) AllMembers(doc *Document) []*Individual {
 	if doc == nil {
 		return []*Individual{}
 	}
-	result := make([]*Individual, 0, 2+len(f.Children))
-
-	if husband := f.HusbandIndividual(doc); husband != nil {
-		result = append(result, husband)
-	}
-	if wife := f.WifeIndividual(doc); wife != nil {
-		result = append(result, wife)
-	}
+	result := f.PartnerIndividuals(doc)
 	result = append(result, f.ChildrenIndividuals(doc)...)
 	return result
 }
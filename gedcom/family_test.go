@@ -1,6 +1,9 @@
 package gedcom
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 // Helper function to create a test document with individuals and families for family tests
 func createFamilyTestDocument() *Document {
@@ -293,6 +296,14 @@ func TestFamily_AllMembers(t *testing.T) {
 			doc:       doc,
 			wantXRefs: []string{"@I1@", "@I2@", "@I3@", "@I4@"},
 		},
+		{
+			name: "two husbands from Spouses",
+			family: &Family{Husband: "@I1@", Children: []string{"@I3@"}, Spouses: []*FamilySpouse{
+				{XRef: "@I1@", Tag: "HUSB"}, {XRef: "@I5@", Tag: "HUSB"},
+			}},
+			doc:       doc,
+			wantXRefs: []string{"@I1@", "@I5@", "@I3@"},
+		},
 		{
 			name:      "husband only with child",
 			family:    &Family{Husband: "@I5@", Children: []string{"@I6@"}},
@@ -368,6 +379,102 @@ func TestFamily_AllMembers(t *testing.T) {
 }
 
 // TestFamily_OrderPreservation verifies that order is preserved correctly.
+func TestFamily_Partners(t *testing.T) {
+	fam := &Family{
+		Husband: "@I1@",
+		Spouses: []*FamilySpouse{
+			{XRef: "@I1@", Tag: "HUSB"},
+			{XRef: "@I2@", Tag: "HUSB"},
+			nil,
+			{XRef: "@I3@", Tag: "ASSO", Role: "SPOU"},
+			{XRef: "@I2@", Tag: "ASSO", Role: "SPOU"},
+		},
+	}
+	got := fam.Partners()
+	want := []string{"@I1@", "@I2@", "@I3@"}
+	if len(got) != len(want) {
+		t.Fatalf("Partners() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Partners()[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+	if (&Family{}).Partners() != nil {
+		t.Error("Partners() of an empty family should be nil")
+	}
+	if members := fam.PartnerIndividuals(createFamilyTestDocument()); len(members) != 3 {
+		t.Errorf("PartnerIndividuals() returned %d individuals, want 3", len(members))
+	}
+	if members := fam.PartnerIndividuals(nil); len(members) != 0 {
+		t.Error("PartnerIndividuals(nil) should be empty")
+	}
+}
+
+func TestFamily_SetHusbandAndWife(t *testing.T) {
+	fam := &Family{
+		Husband: "@I1@",
+		Wife:    "@I2@",
+		Spouses: []*FamilySpouse{
+			{XRef: "@I1@", Tag: "HUSB"},
+			{XRef: "@I2@", Tag: "WIFE"},
+			{XRef: "@I4@", Tag: "HUSB"},
+		},
+	}
+	original := fam.Spouses[0]
+
+	fam.SetHusband("@I3@")
+	if fam.Husband != "@I3@" || original.XRef != "@I1@" {
+		t.Errorf("Husband = %s, original entry = %s", fam.Husband, original.XRef)
+	}
+	if got := fam.Partners(); !reflect.DeepEqual(got, []string{"@I3@", "@I2@", "@I4@"}) {
+		t.Errorf("Partners() after SetHusband = %v", got)
+	}
+
+	fam.SetWife("")
+	if fam.Wife != "" || len(fam.Spouses) != 2 {
+		t.Errorf("SetWife(\"\") left Wife %q and %d spouses", fam.Wife, len(fam.Spouses))
+	}
+
+	fam.SetWife("@I5@")
+	if got := fam.Partners(); !reflect.DeepEqual(got, []string{"@I3@", "@I5@", "@I4@"}) {
+		t.Errorf("Partners() after SetWife = %v", got)
+	}
+
+	// Clearing the husband promotes the next HUSB entry
+	fam.SetHusband("")
+	if fam.Husband != "@I4@" || len(fam.OtherSpouses()) != 0 {
+		t.Errorf("SetHusband(\"\") left Husband %q and others %v", fam.Husband, fam.OtherSpouses())
+	}
+
+	// Direct assignment replaces the slot rather than adding a partner
+	fam.Husband = "@I6@"
+	if got := fam.Partners(); !reflect.DeepEqual(got, []string{"@I6@", "@I5@"}) {
+		t.Errorf("Partners() after assigning Husband = %v", got)
+	}
+
+	// A hand-built family without Spouses gains an entry only when set
+	bare := &Family{}
+	bare.SetHusband("")
+	bare.SetHusband("@I1@")
+	if bare.Husband != "@I1@" || len(bare.Spouses) != 1 || bare.Spouses[0].Tag != "HUSB" {
+		t.Errorf("bare family = %+v", bare)
+	}
+}
+
+func TestIsPartnerRole(t *testing.T) {
+	for _, role := range []string{"HUSB", "WIFE", "SPOU", "spou", " Partner ", "Husband", "spouse"} {
+		if !IsPartnerRole(role) {
+			t.Errorf("IsPartnerRole(%q) = false, want true", role)
+		}
+	}
+	for _, role := range []string{"", "WITN", "GODP", "FRIEND", "CHIL"} {
+		if IsPartnerRole(role) {
+			t.Errorf("IsPartnerRole(%q) = true, want false", role)
+		}
+	}
+}
+
 func TestFamily_OrderPreservation(t *testing.T) {
 	doc := createFamilyTestDocument()
 
@@ -412,3 +519,30 @@ func TestFamily_OrderPreservation(t *testing.T) {
 		}
 	})
 }
+
+func TestFamily_EventAccessors(t *testing.T) {
+	marr := &Event{Type: EventMarriage, Date: "1870"}
+	div := &Event{Type: EventDivorce, Date: "1880"}
+	f := &Family{Events: []*Event{
+		{Type: EventEngagement},
+		marr,
+		{Type: EventMarriage, Date: "1871"},
+		div,
+	}}
+
+	if got := f.Marriage(); got != marr {
+		t.Errorf("Marriage() = %v, want first marriage", got)
+	}
+	if got := f.Divorce(); got != div {
+		t.Errorf("Divorce() = %v, want divorce", got)
+	}
+	if got := f.EventsOfType(EventMarriage); len(got) != 2 {
+		t.Errorf("EventsOfType(MARR) returned %d events, want 2", len(got))
+	}
+	if got := f.FirstEvent(EventAnnulment); got != nil {
+		t.Errorf("FirstEvent(ANUL) = %v, want nil", got)
+	}
+	if got := (&Family{}).Marriage(); got != nil {
+		t.Errorf("Marriage() on empty family = %v, want nil", got)
+	}
+}
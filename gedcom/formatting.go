@@ -0,0 +1,46 @@
+package gedcom
+
+// Trivia is a source line that carries no GEDCOM data: a blank line, or a
+// vendor comment line starting with "#" or "//". The decoder keeps trivia
+// only when asked to preserve formatting, so an unchanged document can be
+// written back byte-for-byte.
+type Trivia struct {
+	// Offset places the line within its owner: it is the number of the
+	// owner's own lines that precede it. For a record, 0 is before the
+	// level-0 line and n is after the record's n-th line (the level-0 line
+	// counts as the first).
+	Offset int
+
+	// Text is the line without its line terminator; empty for a blank line.
+	Text string
+}
+
+// Formatting records the file-level layout of a decoded GEDCOM file.
+type Formatting struct {
+	// LineEnding is the first line terminator found in the file: "\n",
+	// "\r\n", or "\r".
+	LineEnding string
+
+	// BOM reports whether the file started with a UTF-8 byte order mark.
+	BOM bool
+
+	// NoFinalLineEnding reports that the last line of the file had no
+	// line terminator.
+	NoFinalLineEnding bool
+}
+
+// Clone returns a copy of the formatting. Returns nil if f is nil.
+func (f *Formatting) Clone() *Formatting {
+	if f == nil {
+		return nil
+	}
+	copied := *f
+	return &copied
+}
+
+func cloneTrivia(trivia []Trivia) []Trivia {
+	if trivia == nil {
+		return nil
+	}
+	return append([]Trivia(nil), trivia...)
+}
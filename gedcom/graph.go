@@ -17,7 +17,10 @@ package gedcom
 // Ordering: descendants are returned in BFS order (closer descendants
 // before more distant ones). Ties within a generation follow the source
 // document order.
-func (d *Document) Descendants(xref string) []string {
+//
+// An optional maxGenerations limits the walk: 1 returns the children, 2
+// the children and grandchildren, and so on. Zero or less means no limit.
+func (d *Document) Descendants(xref string, maxGenerations ...int) []string {
 	if d == nil || xref == "" {
 		return nil
 	}
@@ -25,11 +28,16 @@ func (d *Document) Descendants(xref string) []string {
 		return nil
 	}
 
+	limit := generationLimit(maxGenerations)
 	visited := map[string]bool{xref: true}
 	result := []string{}
 	queue := []string{xref}
+	depth := []int{0}
 
 	for head := 0; head < len(queue); head++ {
+		if limit > 0 && depth[head] >= limit {
+			continue
+		}
 		ind := d.GetIndividual(queue[head])
 		if ind == nil {
 			continue
@@ -50,6 +58,7 @@ func (d *Document) Descendants(xref string) []string {
 				visited[childXRef] = true
 				result = append(result, childXRef)
 				queue = append(queue, childXRef)
+				depth = append(depth, depth[head]+1)
 			}
 		}
 	}
@@ -78,7 +87,10 @@ func (d *Document) Descendants(xref string) []string {
 // Ordering: ancestors are returned in BFS order (parents before
 // grandparents). Within a generation, husband precedes wife and order
 // across multiple parent families follows source document order.
-func (d *Document) Ancestors(xref string) []string {
+//
+// An optional maxGenerations limits the walk: 1 returns the parents, 2
+// the parents and grandparents, and so on. Zero or less means no limit.
+func (d *Document) Ancestors(xref string, maxGenerations ...int) []string {
 	if d == nil || xref == "" {
 		return nil
 	}
@@ -86,11 +98,16 @@ func (d *Document) Ancestors(xref string) []string {
 		return nil
 	}
 
+	limit := generationLimit(maxGenerations)
 	visited := map[string]bool{xref: true}
 	result := []string{}
 	queue := []string{xref}
+	depth := []int{0}
 
 	for head := 0; head < len(queue); head++ {
+		if limit > 0 && depth[head] >= limit {
+			continue
+		}
 		ind := d.GetIndividual(queue[head])
 		if ind == nil {
 			continue
@@ -111,6 +128,7 @@ func (d *Document) Ancestors(xref string) []string {
 				visited[parent] = true
 				result = append(result, parent)
 				queue = append(queue, parent)
+				depth = append(depth, depth[head]+1)
 			}
 		}
 	}
@@ -119,47 +137,178 @@ func (d *Document) Ancestors(xref string) []string {
 }
 
 // Descendants returns the Individuals that are transitive descendants
-// of this individual. Convenience wrapper over Document.Descendants
-// that resolves XRefs to Individual records.
+// of this individual, up to the optional maxGenerations. Convenience
+// wrapper over Document.Descendants that resolves XRefs to Individual
+// records.
 //
 // Return contract matches Document.Descendants:
 //   - nil — receiver is nil, doc is nil, or this individual is not in
 //     the document
 //   - non-nil []*Individual (possibly empty) — otherwise; the slice
 //     holds the resolved descendants in BFS order
-func (i *Individual) Descendants(doc *Document) []*Individual {
+func (i *Individual) Descendants(doc *Document, maxGenerations ...int) []*Individual {
 	if i == nil || doc == nil || doc.GetIndividual(i.XRef) == nil {
 		return nil
 	}
-	xrefs := doc.Descendants(i.XRef)
-	result := make([]*Individual, 0, len(xrefs))
-	for _, x := range xrefs {
-		if ind := doc.GetIndividual(x); ind != nil {
-			result = append(result, ind)
-		}
-	}
-	return result
+	return doc.resolveIndividuals(doc.Descendants(i.XRef, maxGenerations...))
 }
 
 // Ancestors returns the Individuals that are transitive ancestors of
-// this individual. Convenience wrapper over Document.Ancestors that
-// resolves XRefs to Individual records.
+// this individual, up to the optional maxGenerations. Convenience wrapper
+// over Document.Ancestors that resolves XRefs to Individual records.
 //
 // Return contract matches Document.Ancestors:
 //   - nil — receiver is nil, doc is nil, or this individual is not in
 //     the document
 //   - non-nil []*Individual (possibly empty) — otherwise; the slice
 //     holds the resolved ancestors in BFS order
-func (i *Individual) Ancestors(doc *Document) []*Individual {
+func (i *Individual) Ancestors(doc *Document, maxGenerations ...int) []*Individual {
 	if i == nil || doc == nil || doc.GetIndividual(i.XRef) == nil {
 		return nil
 	}
-	xrefs := doc.Ancestors(i.XRef)
+	return doc.resolveIndividuals(doc.Ancestors(i.XRef, maxGenerations...))
+}
+
+// Parents returns the XRefs of the husband and wife of each family in
+// which the individual identified by xref is a child, so step-parents and
+// adoptive parents recorded that way are included. Each parent appears
+// once; order follows the ChildInFamilies links, husband before wife.
+//
+// Return contract matches Descendants: nil for invalid input, otherwise a
+// non-nil slice, empty if there are no parents. Links to missing records
+// are skipped.
+func (d *Document) Parents(xref string) []string {
+	ind := d.navigationSeed(xref)
+	if ind == nil {
+		return nil
+	}
+	result := newXRefSet(xref)
+	for _, link := range ind.ChildInFamilies {
+		if fam := d.GetFamily(link.FamilyXRef); fam != nil {
+			d.addIndividuals(result, fam.Husband, fam.Wife)
+		}
+	}
+	return result.xrefs
+}
+
+// Children returns the XRefs of the children of each family in which the
+// individual identified by xref is a spouse. Each child appears once;
+// order follows the SpouseInFamilies links, then each family's CHIL order.
+// The return contract matches Parents.
+func (d *Document) Children(xref string) []string {
+	ind := d.navigationSeed(xref)
+	if ind == nil {
+		return nil
+	}
+	result := newXRefSet(xref)
+	for _, famXRef := range ind.SpouseInFamilies {
+		if fam := d.GetFamily(famXRef); fam != nil {
+			d.addIndividuals(result, fam.Children...)
+		}
+	}
+	return result.xrefs
+}
+
+// Spouses returns the XRefs of the other spouse of each family in which
+// the individual identified by xref is a spouse. Each spouse appears once,
+// in SpouseInFamilies order. The return contract matches Parents.
+func (d *Document) Spouses(xref string) []string {
+	ind := d.navigationSeed(xref)
+	if ind == nil {
+		return nil
+	}
+	result := newXRefSet(xref)
+	for _, famXRef := range ind.SpouseInFamilies {
+		if fam := d.GetFamily(famXRef); fam != nil {
+			d.addIndividuals(result, fam.Husband, fam.Wife)
+		}
+	}
+	return result.xrefs
+}
+
+// Siblings returns the XRefs of the other children of each family in which
+// the individual identified by xref is a child. Half-siblings from a
+// parent's other families are not included; union the Children of the
+// individual's Parents for those. Each sibling appears once; order follows
+// the ChildInFamilies links, then each family's CHIL order. The return
+// contract matches Parents.
+func (d *Document) Siblings(xref string) []string {
+	ind := d.navigationSeed(xref)
+	if ind == nil {
+		return nil
+	}
+	result := newXRefSet(xref)
+	for _, link := range ind.ChildInFamilies {
+		if fam := d.GetFamily(link.FamilyXRef); fam != nil {
+			d.addIndividuals(result, fam.Children...)
+		}
+	}
+	return result.xrefs
+}
+
+// Siblings returns the Individuals that share a parent family with this
+// individual. Convenience wrapper over Document.Siblings that resolves
+// XRefs to Individual records; nil if the receiver or doc is nil or this
+// individual is not in the document.
+func (i *Individual) Siblings(doc *Document) []*Individual {
+	if i == nil || doc == nil || doc.GetIndividual(i.XRef) == nil {
+		return nil
+	}
+	return doc.resolveIndividuals(doc.Siblings(i.XRef))
+}
+
+// navigationSeed returns the individual identified by xref, or nil if the
+// document is nil or has no such individual.
+func (d *Document) navigationSeed(xref string) *Individual {
+	if d == nil || xref == "" {
+		return nil
+	}
+	return d.GetIndividual(xref)
+}
+
+// xrefSet collects XRefs in insertion order without duplicates.
+type xrefSet struct {
+	seen  map[string]bool
+	xrefs []string
+}
+
+// newXRefSet returns an empty set that never admits the excluded XRefs.
+func newXRefSet(excluded ...string) *xrefSet {
+	s := &xrefSet{seen: make(map[string]bool), xrefs: []string{}}
+	for _, x := range excluded {
+		s.seen[x] = true
+	}
+	return s
+}
+
+// addIndividuals adds the XRefs that identify individuals of d to s.
+func (d *Document) addIndividuals(s *xrefSet, xrefs ...string) {
+	for _, x := range xrefs {
+		if x == "" || s.seen[x] || d.GetIndividual(x) == nil {
+			continue
+		}
+		s.seen[x] = true
+		s.xrefs = append(s.xrefs, x)
+	}
+}
+
+// resolveIndividuals returns the individuals identified by xrefs,
+// skipping any that are missing.
+func (d *Document) resolveIndividuals(xrefs []string) []*Individual {
 	result := make([]*Individual, 0, len(xrefs))
 	for _, x := range xrefs {
-		if ind := doc.GetIndividual(x); ind != nil {
+		if ind := d.GetIndividual(x); ind != nil {
 			result = append(result, ind)
 		}
 	}
 	return result
 }
+
+// generationLimit returns the optional generation limit of a walk, or 0
+// for none.
+func generationLimit(maxGenerations []int) int {
+	if len(maxGenerations) == 0 {
+		return 0
+	}
+	return maxGenerations[0]
+}
@@ -324,3 +324,100 @@ func TestIndividualAncestors_NotInDocReturnsNil(t *testing.T) {
 		t.Errorf("Individual not in doc should return nil, got %v", got)
 	}
 }
+
+func TestDescendants_MaxGenerations(t *testing.T) {
+	doc := buildGenealogyFixture()
+	tests := []struct {
+		max  int
+		want []string
+	}{
+		{1, []string{"@I3@", "@I7@"}},
+		{2, []string{"@I3@", "@I7@", "@I5@", "@I6@", "@I9@"}},
+		{0, []string{"@I3@", "@I7@", "@I5@", "@I6@", "@I9@"}},
+	}
+	for _, tt := range tests {
+		if got := doc.Descendants("@I1@", tt.max); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Descendants(@I1@, %d) = %v, want %v", tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestAncestors_MaxGenerations(t *testing.T) {
+	doc := buildGenealogyFixture()
+	if got, want := doc.Ancestors("@I5@", 1), []string{"@I3@", "@I4@"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Ancestors(@I5@, 1) = %v, want %v", got, want)
+	}
+	if got := doc.GetIndividual("@I5@").Ancestors(doc, 1); len(got) != 2 {
+		t.Errorf("Individual.Ancestors(doc, 1) returned %d individuals, want 2", len(got))
+	}
+	if got := doc.GetIndividual("@I1@").Descendants(doc, 1); len(got) != 2 {
+		t.Errorf("Individual.Descendants(doc, 1) returned %d individuals, want 2", len(got))
+	}
+}
+
+func TestNavigation(t *testing.T) {
+	doc := buildGenealogyFixture()
+	tests := []struct {
+		name string
+		fn   func(string) []string
+		xref string
+		want []string
+	}{
+		{"Parents", doc.Parents, "@I5@", []string{"@I3@", "@I4@"}},
+		{"Parents of root", doc.Parents, "@I1@", []string{}},
+		{"Children across marriages", doc.Children, "@I3@", []string{"@I5@", "@I6@", "@I9@"}},
+		{"Children of leaf", doc.Children, "@I5@", []string{}},
+		{"Spouses", doc.Spouses, "@I3@", []string{"@I4@", "@I8@"}},
+		{"Spouses of wife", doc.Spouses, "@I4@", []string{"@I3@"}},
+		{"Siblings", doc.Siblings, "@I5@", []string{"@I6@"}},
+		{"Siblings excludes half-siblings", doc.Siblings, "@I9@", []string{}},
+		{"Siblings of uncle", doc.Siblings, "@I7@", []string{"@I3@"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(tt.xref); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s(%s) = %v, want %v", tt.name, tt.xref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNavigation_InvalidInput(t *testing.T) {
+	doc := buildGenealogyFixture()
+	for _, xref := range []string{"", "@MISSING@", "@F1@"} {
+		if got := doc.Parents(xref); got != nil {
+			t.Errorf("Parents(%q) = %v, want nil", xref, got)
+		}
+		if got := doc.Siblings(xref); got != nil {
+			t.Errorf("Siblings(%q) = %v, want nil", xref, got)
+		}
+	}
+	var nilDoc *Document
+	if got := nilDoc.Children("@I1@"); got != nil {
+		t.Errorf("nil Document Children() = %v, want nil", got)
+	}
+	if got := nilDoc.Spouses("@I1@"); got != nil {
+		t.Errorf("nil Document Spouses() = %v, want nil", got)
+	}
+}
+
+func TestNavigation_DeduplicatesRepeatedLinks(t *testing.T) {
+	doc := buildGenealogyFixture()
+	// A duplicated FAMC link must not list the parents twice.
+	child := doc.GetIndividual("@I5@")
+	child.ChildInFamilies = append(child.ChildInFamilies, FamilyLink{FamilyXRef: "@F2@"})
+	if got, want := doc.Parents("@I5@"), []string{"@I3@", "@I4@"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Parents(@I5@) = %v, want %v", got, want)
+	}
+}
+
+func TestIndividualSiblings(t *testing.T) {
+	doc := buildGenealogyFixture()
+	got := doc.GetIndividual("@I6@").Siblings(doc)
+	if len(got) != 1 || got[0].XRef != "@I5@" {
+		t.Errorf("Individual.Siblings() = %v, want [@I5@]", got)
+	}
+	if got := (&Individual{XRef: "@NOT_IN_DOC@"}).Siblings(doc); got != nil {
+		t.Errorf("Individual not in doc Siblings() = %v, want nil", got)
+	}
+}
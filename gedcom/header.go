@@ -25,6 +25,11 @@ type Header struct {
 	// Submitter reference (optional)
 	Submitter string
 
+	// PlaceForm is the default place hierarchy from HEAD.PLAC.FORM
+	// (e.g., "City, County, State, Country"), applying to every PLAC
+	// without its own FORM (optional)
+	PlaceForm string
+
 	// AncestryTreeID is the Ancestry.com tree identifier from HEAD.SOUR._TREE.
 	// This is an Ancestry.com vendor extension that identifies the family tree
 	// this GEDCOM was exported from.
@@ -34,4 +39,9 @@ type Header struct {
 	// lossless record of the header (including custom/unmapped tags such as
 	// _RTLSAVE or header NOTEs) alongside the typed fields above.
 	Tags []*Tag
+
+	// Trivia holds the blank and comment lines within and before the
+	// header when the decoder preserves formatting; offsets count the
+	// "0 HEAD" line and then Tags.
+	Trivia []Trivia
 }
@@ -0,0 +1,264 @@
+package gedcom
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// LockMode selects how a Document synchronizes access to its records.
+type LockMode int
+
+const (
+	// LockNone performs no locking. This is the default; a Document is then
+	// safe for concurrent reads only.
+	LockNone LockMode = iota
+
+	// LockRW guards record lookups and mutations with a read-write mutex,
+	// so goroutines may add and remove records while others look them up.
+	LockRW
+)
+
+// documentIndex holds the per-type record slices and the lock of an
+// indexed Document.
+type documentIndex struct {
+	mu     sync.RWMutex
+	mode   LockMode
+	byType map[RecordType][]*Record
+
+	// records is a copy of Records as indexed. The listings compare it with
+	// Records element by element, so direct changes to Records are never
+	// served from a stale index.
+	records []*Record
+
+	// tracked is Records as the mutation API last left it, sharing its
+	// array. The mutations compare only its length and array, which catches
+	// Records being replaced or resized without rescanning it.
+	tracked []*Record
+
+	// stale is set by a listing that found Records changed directly, so
+	// that the next mutation rebuilds the index.
+	stale atomic.Bool
+
+	// timestamps enables CREA and CHAN maintenance; see SetAutoTimestamps.
+	timestamps bool
+
+	// nextXRef holds, per prefix, the number NextXRef last returned.
+	nextXRef map[string]int
+
+	// xrefStrategy allocates XRefs in AddIndividual and AddFamily; see
+	// SetXRefStrategy.
+	xrefStrategy XRefStrategy
+
+	// sorted caches byType in each SortOrder; cleared, and generation
+	// incremented, whenever byType changes. sortMu guards both, since the
+	// cache is filled by readers.
+	sortMu     sync.Mutex
+	sorted     map[sortKey][]*Record
+	generation uint64
+}
+
+// SetLockMode sets how the document synchronizes access to its records.
+// With LockRW, GetRecord and the typed lookups (GetIndividual, ...), the
+// typed listings and pages (Individuals, IndividualsPage, ...), AddRecord, RemoveRecord, the
+// typed mutations (AddIndividual, LinkChild, ...), and Reindex are safe to
+// call concurrently. Code that reads or writes Records
+// or XRefMap directly must synchronize itself. SetLockMode itself must be
+// called before the document is shared between goroutines.
+func (d *Document) SetLockMode(mode LockMode) {
+	if d.index == nil {
+		d.index = &documentIndex{}
+	}
+	d.index.mode = mode
+}
+
+// LockMode returns the document's lock mode.
+func (d *Document) LockMode() LockMode {
+	if d.index == nil {
+		return LockNone
+	}
+	return d.index.mode
+}
+
+// lock acquires the document's write lock in LockRW mode and returns the
+// function that releases it.
+func (d *Document) lock() func() {
+	if d.index == nil || d.index.mode != LockRW {
+		return func() {}
+	}
+	d.index.mu.Lock()
+	return d.index.mu.Unlock
+}
+
+// rlock acquires the document's read lock in LockRW mode and returns the
+// function that releases it.
+func (d *Document) rlock() func() {
+	if d.index == nil || d.index.mode != LockRW {
+		return func() {}
+	}
+	d.index.mu.RLock()
+	return d.index.mu.RUnlock
+}
+
+// Reindex rebuilds XRefMap and the per-type record index from Records.
+// AddRecord and RemoveRecord keep both up to date incrementally; call
+// Reindex after changing Records directly, such as after a bulk append.
+// The typed listings check the index against Records and scan Records
+// when it was changed directly, but GetRecord and the typed lookups rely
+// on XRefMap.
+// When several records share an XRef, the first one is indexed.
+func (d *Document) Reindex() {
+	defer d.lock()()
+	d.reindex()
+}
+
+// reindex rebuilds the indexes; the caller holds the write lock.
+func (d *Document) reindex() {
+	if d.index == nil {
+		d.index = &documentIndex{}
+	}
+	d.XRefMap = make(map[string]*Record, len(d.Records))
+	d.index.byType = make(map[RecordType][]*Record)
+	d.index.records = append([]*Record(nil), d.Records...)
+	d.index.tracked = d.Records
+	d.index.stale.Store(false)
+	d.index.clearSorted()
+	for _, record := range d.Records {
+		if record == nil {
+			continue
+		}
+		if record.XRef != "" {
+			if _, exists := d.XRefMap[record.XRef]; !exists {
+				d.XRefMap[record.XRef] = record
+			}
+		}
+		d.index.byType[record.Type] = append(d.index.byType[record.Type], record)
+	}
+}
+
+// AddRecord appends record to the document, updating XRefMap and the
+// per-type index without rescanning the other records. With
+// SetAutoTimestamps, the record gets CREA and CHAN dates. It returns an error
+// if the record is nil, has no XRef, or its XRef is already in use.
+func (d *Document) AddRecord(record *Record) error {
+	if record == nil {
+		return errors.New("gedcom: record is nil")
+	}
+	if record.XRef == "" {
+		return fmt.Errorf("gedcom: %s record has no XRef", record.Type)
+	}
+
+	defer d.lock()()
+	if !d.indexed() {
+		d.reindex()
+	}
+	return d.addRecord(record)
+}
+
+// addRecord appends record and indexes it; the caller holds the write lock
+// and has brought the index up to date.
+func (d *Document) addRecord(record *Record) error {
+	if _, exists := d.XRefMap[record.XRef]; exists {
+		return fmt.Errorf("gedcom: XRef %s already in use", record.XRef)
+	}
+	d.appendRecord(record)
+	if d.index.timestamps {
+		d.stamp(record, true)
+	}
+	return nil
+}
+
+// appendRecord appends record to Records and the indexes; the caller holds
+// the write lock and has checked that its XRef is free.
+func (d *Document) appendRecord(record *Record) {
+	d.Records = append(d.Records, record)
+	d.XRefMap[record.XRef] = record
+	d.index.byType[record.Type] = append(d.index.byType[record.Type], record)
+	d.index.records = append(d.index.records, record)
+	d.index.tracked = d.Records
+	d.index.clearSorted()
+}
+
+// RemoveRecord removes the record with the given XRef from the document,
+// XRefMap, and the per-type index, and returns it, or nil if there is no
+// such record. References to it from other records are left in place.
+func (d *Document) RemoveRecord(xref string) *Record {
+	defer d.lock()()
+	if !d.indexed() {
+		d.reindex()
+	}
+	record := d.XRefMap[xref]
+	if record == nil {
+		return nil
+	}
+	d.removeRecord(record)
+	return record
+}
+
+// removeRecord removes an indexed record from Records and the indexes; the
+// caller holds the write lock.
+func (d *Document) removeRecord(record *Record) {
+	d.Records = removeRecord(d.Records, record)
+	delete(d.XRefMap, record.XRef)
+	d.index.byType[record.Type] = removeRecord(d.index.byType[record.Type], record)
+	d.index.records = removeRecord(d.index.records, record)
+	d.index.tracked = d.Records
+	d.index.clearSorted()
+}
+
+// indexed reports whether the mutations may use the indexes: they have been
+// built, and Records has not since been replaced, resized, or found changed
+// by a listing. The mutations rebuild them otherwise.
+func (d *Document) indexed() bool {
+	x := d.index
+	if x == nil || x.byType == nil || d.XRefMap == nil || x.stale.Load() {
+		return false
+	}
+	return len(x.tracked) == len(d.Records) &&
+		(len(d.Records) == 0 || &x.tracked[0] == &d.Records[0])
+}
+
+// current reports whether the per-type index matches Records record for
+// record, so that a listing may be served from it. A listing that finds
+// Records changed marks the index stale. The caller holds the read lock.
+func (d *Document) current() bool {
+	if !d.indexed() {
+		return false
+	}
+	if !slices.Equal(d.index.records, d.Records) {
+		d.index.stale.Store(true)
+		return false
+	}
+	return true
+}
+
+// recordsOfType returns a copy of the records of type t in document order,
+// from the per-type index when it is current and by scanning Records
+// otherwise.
+func (d *Document) recordsOfType(t RecordType) []*Record {
+	defer d.rlock()()
+	if d.current() {
+		return append([]*Record(nil), d.index.byType[t]...)
+	}
+	var records []*Record
+	for _, record := range d.Records {
+		if record != nil && record.Type == t {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// removeRecord removes the first occurrence of record from records,
+// preserving order. The result does not share the tail of records, so a
+// slice of Records a caller took earlier keeps its contents.
+func removeRecord(records []*Record, record *Record) []*Record {
+	for i, r := range records {
+		if r == record {
+			return append(records[:i:i], records[i+1:]...)
+		}
+	}
+	return records
+}
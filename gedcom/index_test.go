@@ -0,0 +1,212 @@
+package gedcom
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestDocument_AddRemoveRecord(t *testing.T) {
+	doc := &Document{}
+	for i := 1; i <= 3; i++ {
+		xref := fmt.Sprintf("@I%d@", i)
+		if err := doc.AddRecord(&Record{XRef: xref, Type: RecordTypeIndividual, Entity: &Individual{XRef: xref}}); err != nil {
+			t.Fatalf("AddRecord(%s) error = %v", xref, err)
+		}
+	}
+	if err := doc.AddRecord(&Record{XRef: "@F1@", Type: RecordTypeFamily, Entity: &Family{XRef: "@F1@"}}); err != nil {
+		t.Fatalf("AddRecord(@F1@) error = %v", err)
+	}
+
+	if err := doc.AddRecord(&Record{XRef: "@I2@", Type: RecordTypeIndividual}); err == nil {
+		t.Error("AddRecord() with a duplicate XRef should fail")
+	}
+	if err := doc.AddRecord(&Record{Type: RecordTypeNote}); err == nil {
+		t.Error("AddRecord() without an XRef should fail")
+	}
+	if err := doc.AddRecord(nil); err == nil {
+		t.Error("AddRecord(nil) should fail")
+	}
+
+	if len(doc.Records) != 4 || doc.GetIndividual("@I3@") == nil || len(doc.Individuals()) != 3 || len(doc.Families()) != 1 {
+		t.Fatalf("after adds: %d records, %d individuals", len(doc.Records), len(doc.Individuals()))
+	}
+
+	removed := doc.RemoveRecord("@I2@")
+	if removed == nil || removed.XRef != "@I2@" {
+		t.Fatalf("RemoveRecord(@I2@) = %v", removed)
+	}
+	if doc.RemoveRecord("@I2@") != nil {
+		t.Error("RemoveRecord() of a missing XRef should return nil")
+	}
+	if doc.GetRecord("@I2@") != nil || len(doc.Records) != 3 {
+		t.Errorf("after remove: GetRecord = %v, %d records", doc.GetRecord("@I2@"), len(doc.Records))
+	}
+	inds := doc.Individuals()
+	if len(inds) != 2 || inds[0].XRef != "@I1@" || inds[1].XRef != "@I3@" {
+		t.Errorf("Individuals() = %v", inds)
+	}
+}
+
+func TestDocument_RemoveRecordKeepsCallerSlice(t *testing.T) {
+	doc := &Document{}
+	for _, xref := range []string{"@I1@", "@I2@", "@I3@"} {
+		if err := doc.AddRecord(&Record{XRef: xref, Type: RecordTypeIndividual, Entity: &Individual{XRef: xref}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := doc.AddRecord(&Record{XRef: "@F1@", Type: RecordTypeFamily, Entity: &Family{XRef: "@F1@"}}); err != nil {
+		t.Fatal(err)
+	}
+	records := doc.Records
+
+	doc.RemoveIndividual("@I1@")
+	var got []string
+	for _, record := range records {
+		got = append(got, record.XRef)
+	}
+	if want := []string{"@I1@", "@I2@", "@I3@", "@F1@"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("earlier Records slice = %v, want %v", got, want)
+	}
+	if len(doc.Records) != 3 || doc.Records[0].XRef != "@I2@" {
+		t.Errorf("Records after removal = %v", doc.Records)
+	}
+	if doc.GetIndividual("@I3@") == nil || len(doc.Individuals()) != 2 {
+		t.Error("index should stay usable after the removal")
+	}
+}
+
+func TestDocument_Reindex(t *testing.T) {
+	doc := &Document{}
+	if err := doc.AddRecord(&Record{XRef: "@I1@", Type: RecordTypeIndividual, Entity: &Individual{XRef: "@I1@"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Direct changes to Records are picked up by the listings...
+	doc.Records = append(doc.Records, &Record{XRef: "@I2@", Type: RecordTypeIndividual, Entity: &Individual{XRef: "@I2@"}})
+	if len(doc.Individuals()) != 2 {
+		t.Errorf("Individuals() after append = %d, want 2", len(doc.Individuals()))
+	}
+	if doc.GetRecord("@I2@") != nil {
+		t.Error("XRefMap should not change before Reindex")
+	}
+
+	// ...and Reindex brings XRefMap up to date.
+	doc.Reindex()
+	if doc.GetIndividual("@I2@") == nil {
+		t.Error("GetIndividual(@I2@) = nil after Reindex")
+	}
+
+	// Replacing a record in place reaches XRefMap after Reindex.
+	doc.Records[1] = &Record{XRef: "@S1@", Type: RecordTypeSource, Entity: &Source{XRef: "@S1@"}}
+	doc.Reindex()
+	if len(doc.Individuals()) != 1 || len(doc.Sources()) != 1 || doc.GetRecord("@I2@") != nil {
+		t.Errorf("after replace: %d individuals, %d sources", len(doc.Individuals()), len(doc.Sources()))
+	}
+}
+
+func TestDocument_DirectChangesAfterIndexing(t *testing.T) {
+	newDoc := func(t *testing.T) *Document {
+		t.Helper()
+		doc := &Document{}
+		for _, xref := range []string{"@I1@", "@I2@", "@I3@"} {
+			if err := doc.AddRecord(&Record{XRef: xref, Type: RecordTypeIndividual, Entity: &Individual{XRef: xref}}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return doc
+	}
+	xrefs := func(inds []*Individual) []string {
+		var out []string
+		for _, ind := range inds {
+			out = append(out, ind.XRef)
+		}
+		return out
+	}
+
+	t.Run("replace in place", func(t *testing.T) {
+		doc := newDoc(t)
+		doc.Records[1] = &Record{XRef: "@F1@", Type: RecordTypeFamily, Entity: &Family{XRef: "@F1@"}}
+		if got := xrefs(doc.Individuals()); fmt.Sprint(got) != "[@I1@ @I3@]" {
+			t.Errorf("Individuals() = %v, want [@I1@ @I3@]", got)
+		}
+		if got, total := doc.IndividualsPage(0, 10, SortByXRef); total != 2 || len(got) != 2 {
+			t.Errorf("IndividualsPage() = %v, %d; want 2 individuals", xrefs(got), total)
+		}
+		if len(doc.Families()) != 1 {
+			t.Errorf("Families() = %d, want 1", len(doc.Families()))
+		}
+
+		// The next mutation rebuilds the index instead of trusting it.
+		if err := doc.AddRecord(&Record{XRef: "@F1@", Type: RecordTypeFamily}); err == nil {
+			t.Error("AddRecord(@F1@) should fail after the index is rebuilt")
+		}
+		if doc.GetIndividual("@I2@") != nil {
+			t.Error("GetIndividual(@I2@) should be nil after the index is rebuilt")
+		}
+	})
+
+	t.Run("remove and append", func(t *testing.T) {
+		doc := newDoc(t)
+		// Same length and array, different records.
+		doc.Records = append(doc.Records[:0], doc.Records[1:]...)
+		doc.Records = append(doc.Records, &Record{XRef: "@I4@", Type: RecordTypeIndividual, Entity: &Individual{XRef: "@I4@"}})
+		if got := xrefs(doc.Individuals()); fmt.Sprint(got) != "[@I2@ @I3@ @I4@]" {
+			t.Errorf("Individuals() = %v, want [@I2@ @I3@ @I4@]", got)
+		}
+		if got, _ := doc.IndividualsPage(0, 1, SortFileOrder); len(got) != 1 || got[0].XRef != "@I2@" {
+			t.Errorf("IndividualsPage(0, 1) = %v, want [@I2@]", xrefs(got))
+		}
+	})
+
+	t.Run("listing not shifted by removal", func(t *testing.T) {
+		doc := newDoc(t)
+		records := doc.recordsOfType(RecordTypeIndividual)
+		doc.RemoveRecord("@I1@")
+		if records[0].XRef != "@I1@" || records[2].XRef != "@I3@" {
+			t.Errorf("listing after RemoveRecord = %v %v %v, want it unchanged", records[0].XRef, records[1].XRef, records[2].XRef)
+		}
+	})
+}
+
+func TestDocument_LockRWConcurrentAccess(t *testing.T) {
+	doc := &Document{}
+	doc.SetLockMode(LockRW)
+	if doc.LockMode() != LockRW || doc.Clone().LockMode() != LockRW {
+		t.Fatal("LockMode() not set")
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				xref := fmt.Sprintf("@I%d_%d@", w, i)
+				if err := doc.AddRecord(&Record{XRef: xref, Type: RecordTypeIndividual, Entity: &Individual{XRef: xref}}); err != nil {
+					t.Error(err)
+					return
+				}
+				if i%2 == 1 {
+					doc.RemoveRecord(xref)
+				}
+			}
+		}(w)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_ = doc.GetIndividual(fmt.Sprintf("@I%d_%d@", w, i))
+				_ = doc.Individuals()
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if got := len(doc.Individuals()); got != 200 {
+		t.Errorf("Individuals() = %d, want 200", got)
+	}
+	if len(doc.XRefMap) != 200 || len(doc.Records) != 200 {
+		t.Errorf("XRefMap = %d, Records = %d, want 200", len(doc.XRefMap), len(doc.Records))
+	}
+}
@@ -11,6 +11,11 @@ type Individual struct {
 	// Sex is the person's sex (M, F, X, U for unknown)
 	Sex string
 
+	// Restriction is the record-level access restriction (RESN tag), e.g.
+	// "confidential" or, in GEDCOM 7.0, "CONFIDENTIAL, LOCKED". Use its
+	// Level or Has method to interpret it.
+	Restriction Restriction
+
 	// Events contains life events (birth, death, marriage, etc.)
 	Events []*Event
 
@@ -26,6 +31,11 @@ type Individual struct {
 	// Associations are links to associated individuals (godparents, witnesses, etc.)
 	Associations []*Association
 
+	// Aliases are the values of ALIA tags: normally XRef pointers to other
+	// INDI records describing the same person (see Document.ResolveAliases).
+	// Some vendors write free text here instead; such values are kept as-is.
+	Aliases []string
+
 	// SourceCitations are source citations with page/quality details
 	SourceCitations []*SourceCitation
 
@@ -56,9 +66,21 @@ type Individual struct {
 	// CreationDate is when the record was created (CREA tag, GEDCOM 7.0)
 	CreationDate *ChangeDate
 
-	// RefNumber is the user reference number (REFN tag)
+	// UserReferences are the user reference numbers (REFN tags, can have
+	// multiple), each with its optional TYPE.
+	UserReferences []*UserReference
+
+	// RefNumber is deprecated: use UserReferences instead. It is kept for
+	// backward compatibility and populated during decode with the number of
+	// the first REFN. The encoder writes it only when UserReferences is empty.
+	//
+	// Deprecated: use UserReferences.
 	RefNumber string
 
+	// AutomatedRecordID is the record ID assigned by the application that
+	// produced the file (RIN tag, GEDCOM 5.5.x)
+	AutomatedRecordID string
+
 	// UID is the unique identifier (UID tag)
 	UID string
 
@@ -71,6 +93,14 @@ type Individual struct {
 	// an individual in their Family Tree database. Format: alphanumeric like "KWCJ-QN7".
 	FamilySearchID string
 
+	// Tasks are research to-do items (_TODO or _RESEARCH vendor tags).
+	// Use Record.AddTask to add one so the raw tags stay in sync.
+	Tasks []*Task
+
+	// ExtensionData holds the registered application extensions of this
+	// individual (see RegisterExtension), keyed by root tag.
+	ExtensionData ExtensionData
+
 	// Tags contains all raw tags for this individual (for unknown/custom tags)
 	Tags []*Tag
 }
@@ -152,8 +182,9 @@ type FamilyLink struct {
 	FamilyXRef string
 
 	// Pedigree is the pedigree linkage type (e.g., "birth", "adopted", "foster", "sealing")
-	// Empty string if not specified. Preserves original casing from GEDCOM.
-	Pedigree string
+	// Empty string if not specified. Preserves original casing from GEDCOM;
+	// use Canonical or IsBirth to interpret it.
+	Pedigree Pedigree
 }
 
 // Association represents a link to an associated individual with a role.
@@ -201,24 +232,26 @@ type Attribute struct {
 	SourceCitations []*SourceCitation
 }
 
+// EventsOfType returns this individual's events of type t in file order,
+// or nil if there are none. Negative assertions (GEDCOM 7.0 NO) are
+// included; check Event.IsNegative to tell them apart.
+func (i *Individual) EventsOfType(t EventType) []*Event {
+	return eventsOfType(i.Events, t)
+}
+
+// FirstEvent returns this individual's first event of type t, or nil if none found.
+func (i *Individual) FirstEvent(t EventType) *Event {
+	return firstEventOfType(i.Events, t)
+}
+
 // BirthEvent returns the first birth event for this individual, or nil if none found.
 func (i *Individual) BirthEvent() *Event {
-	for _, event := range i.Events {
-		if event.Type == EventBirth {
-			return event
-		}
-	}
-	return nil
+	return i.FirstEvent(EventBirth)
 }
 
 // DeathEvent returns the first death event for this individual, or nil if none found.
 func (i *Individual) DeathEvent() *Event {
-	for _, event := range i.Events {
-		if event.Type == EventDeath {
-			return event
-		}
-	}
-	return nil
+	return i.FirstEvent(EventDeath)
 }
 
 // BirthDate returns the parsed birth date for this individual, or nil if no birth event
@@ -303,20 +336,14 @@ func (i *Individual) Spouses(doc *Document) []*Individual {
 		if fam == nil {
 			continue
 		}
-		// Determine which spouse is "the other one"
-		if fam.Husband == i.XRef {
-			// This individual is the husband, spouse is wife
-			if fam.Wife != "" {
-				if wife := doc.GetIndividual(fam.Wife); wife != nil {
-					spouses = append(spouses, wife)
-				}
+		// Every partner other than this individual, which also covers
+		// families with two HUSB or two WIFE lines
+		for _, xref := range fam.Partners() {
+			if xref == i.XRef {
+				continue
 			}
-		} else {
-			// This individual is the wife, spouse is husband
-			if fam.Husband != "" {
-				if husband := doc.GetIndividual(fam.Husband); husband != nil {
-					spouses = append(spouses, husband)
-				}
+			if spouse := doc.GetIndividual(xref); spouse != nil {
+				spouses = append(spouses, spouse)
 			}
 		}
 	}
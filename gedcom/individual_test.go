@@ -69,6 +69,35 @@ func TestIndividual_BirthEvent(t *testing.T) {
 	}
 }
 
+func TestIndividual_EventsOfType(t *testing.T) {
+	resi1 := &Event{Type: EventResidence, Place: "Boston"}
+	resi2 := &Event{Type: EventResidence, Place: "Chicago"}
+	i := &Individual{Events: []*Event{
+		{Type: EventBirth},
+		resi1,
+		nil,
+		{Type: EventDeath},
+		resi2,
+	}}
+
+	got := i.EventsOfType(EventResidence)
+	if len(got) != 2 || got[0] != resi1 || got[1] != resi2 {
+		t.Errorf("EventsOfType(RESI) = %v, want [resi1 resi2]", got)
+	}
+	if got := i.EventsOfType(EventBurial); got != nil {
+		t.Errorf("EventsOfType(BURI) = %v, want nil", got)
+	}
+	if got := i.FirstEvent(EventResidence); got != resi1 {
+		t.Errorf("FirstEvent(RESI) = %v, want resi1", got)
+	}
+	if got := i.FirstEvent(EventBurial); got != nil {
+		t.Errorf("FirstEvent(BURI) = %v, want nil", got)
+	}
+	if got := (&Individual{}).FirstEvent(EventBirth); got != nil {
+		t.Errorf("FirstEvent on empty individual = %v, want nil", got)
+	}
+}
+
 func TestIndividual_DeathEvent(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -668,6 +697,25 @@ func TestIndividual_Spouses(t *testing.T) {
 	}
 }
 
+func TestIndividual_Spouses_SameSex(t *testing.T) {
+	first := &Individual{XRef: "@I1@", SpouseInFamilies: []string{"@F1@"}}
+	second := &Individual{XRef: "@I2@", SpouseInFamilies: []string{"@F1@"}}
+	fam := &Family{XRef: "@F1@", Husband: "@I1@", Spouses: []*FamilySpouse{
+		{XRef: "@I1@", Tag: "HUSB"}, {XRef: "@I2@", Tag: "HUSB"},
+	}}
+	doc := &Document{XRefMap: map[string]*Record{
+		"@I1@": {XRef: "@I1@", Type: RecordTypeIndividual, Entity: first},
+		"@I2@": {XRef: "@I2@", Type: RecordTypeIndividual, Entity: second},
+		"@F1@": {XRef: "@F1@", Type: RecordTypeFamily, Entity: fam},
+	}}
+	if got := first.Spouses(doc); len(got) != 1 || got[0] != second {
+		t.Errorf("first.Spouses() = %v, want the second husband", got)
+	}
+	if got := second.Spouses(doc); len(got) != 1 || got[0] != first {
+		t.Errorf("second.Spouses() = %v, want the first husband", got)
+	}
+}
+
 // TestIndividual_Children tests the Children relationship traversal method.
 func TestIndividual_Children(t *testing.T) {
 	// Parent with children
@@ -0,0 +1,218 @@
+package gedcom
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// languageNames pairs the language names of the GEDCOM 5.5.1 LANGUAGE_ID
+// list with the BCP 47 tags GEDCOM 7.0 uses for them.
+var languageNames = []struct {
+	name string
+	tag  string
+}{
+	{"Afrikaans", "af"},
+	{"Albanian", "sq"},
+	{"Amharic", "am"},
+	{"Anglo-Saxon", "ang"},
+	{"Arabic", "ar"},
+	{"Armenian", "hy"},
+	{"Assamese", "as"},
+	{"Belorusian", "be"},
+	{"Bengali", "bn"},
+	{"Braj", "bra"},
+	{"Bulgarian", "bg"},
+	{"Burmese", "my"},
+	{"Cantonese", "yue"},
+	{"Catalan", "ca"},
+	{"Catalan_Spn", "ca-ES"},
+	{"Church-Slavic", "cu"},
+	{"Czech", "cs"},
+	{"Danish", "da"},
+	{"Dogri", "doi"},
+	{"Dutch", "nl"},
+	{"English", "en"},
+	{"Esperanto", "eo"},
+	{"Estonian", "et"},
+	{"Faroese", "fo"},
+	{"Finnish", "fi"},
+	{"French", "fr"},
+	{"Georgian", "ka"},
+	{"German", "de"},
+	{"Greek", "el"},
+	{"Gujarati", "gu"},
+	{"Hawaiian", "haw"},
+	{"Hebrew", "he"},
+	{"Hindi", "hi"},
+	{"Hungarian", "hu"},
+	{"Icelandic", "is"},
+	{"Indonesian", "id"},
+	{"Italian", "it"},
+	{"Japanese", "ja"},
+	{"Kannada", "kn"},
+	{"Khmer", "km"},
+	{"Konkani", "kok"},
+	{"Korean", "ko"},
+	{"Lahnda", "lah"},
+	{"Lao", "lo"},
+	{"Latvian", "lv"},
+	{"Lithuanian", "lt"},
+	{"Macedonian", "mk"},
+	{"Maithili", "mai"},
+	{"Malayalam", "ml"},
+	{"Mandrin", "cmn"},
+	{"Manipuri", "mni"},
+	{"Marathi", "mr"},
+	{"Mewari", "mtr"},
+	{"Navaho", "nv"},
+	{"Nepali", "ne"},
+	{"Norwegian", "no"},
+	{"Oriya", "or"},
+	{"Pahari", "him"},
+	{"Pali", "pi"},
+	{"Panjabi", "pa"},
+	{"Persian", "fa"},
+	{"Polish", "pl"},
+	{"Portuguese", "pt"},
+	{"Prakrit", "pra"},
+	{"Pusto", "ps"},
+	{"Rajasthani", "raj"},
+	{"Romanian", "ro"},
+	{"Russian", "ru"},
+	{"Sanskrit", "sa"},
+	{"Serb", "sr"},
+	{"Serbo_Croa", "hbs"},
+	{"Slovak", "sk"},
+	{"Slovene", "sl"},
+	{"Spanish", "es"},
+	{"Swedish", "sv"},
+	{"Tagalog", "tl"},
+	{"Tamil", "ta"},
+	{"Telugu", "te"},
+	{"Thai", "th"},
+	{"Tibetan", "bo"},
+	{"Turkish", "tr"},
+	{"Ukrainian", "uk"},
+	{"Urdu", "ur"},
+	{"Vietnamese", "vi"},
+	{"Wendic", "wen"},
+	{"Yiddish", "yi"},
+}
+
+// languageTagsByName indexes languageNames by languageKey of the name.
+var languageTagsByName = func() map[string]string {
+	m := make(map[string]string, len(languageNames))
+	for _, l := range languageNames {
+		m[languageKey(l.name)] = l.tag
+	}
+	return m
+}()
+
+// languageNamesByTag indexes languageNames by lower-case tag.
+var languageNamesByTag = func() map[string]string {
+	m := make(map[string]string, len(languageNames))
+	for _, l := range languageNames {
+		m[strings.ToLower(l.tag)] = l.name
+	}
+	return m
+}()
+
+// languageKey lower-cases a language name and treats spaces, hyphens, and
+// underscores alike, so "Church Slavic" finds "Church-Slavic".
+func languageKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return '_'
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(name)))
+}
+
+// LanguageTag returns the BCP 47 tag for a GEDCOM 5.5/5.5.1 language name,
+// e.g. "en" for "English". Names are matched ignoring case, and spaces,
+// hyphens, and underscores are interchangeable. It returns false for names
+// not in the 5.5.1 LANGUAGE_ID list.
+func LanguageTag(name string) (string, bool) {
+	tag, ok := languageTagsByName[languageKey(name)]
+	return tag, ok
+}
+
+// LanguageName returns the GEDCOM 5.5/5.5.1 language name for a BCP 47
+// tag, the inverse of LanguageTag: "English" for "en". A tag with subtags
+// the 5.5.1 list does not distinguish falls back to its primary language
+// ("English" for "en-GB"), and exact is false. It returns false for
+// languages not in the list.
+func LanguageName(tag string) (name string, exact, ok bool) {
+	key := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if name, ok := languageNamesByTag[key]; ok {
+		return name, true, true
+	}
+	if i := strings.IndexByte(key, '-'); i > 0 {
+		if name, ok := languageNamesByTag[key[:i]]; ok {
+			return name, false, true
+		}
+	}
+	return "", false, false
+}
+
+// IsLanguageTag reports whether value is a well-formed BCP 47 language tag
+// whose subtags are registered, as GEDCOM 7.0 requires of LANG payloads.
+// Subtags are case-insensitive; an underscore separator ("en_US") is not
+// accepted.
+func IsLanguageTag(value string) bool {
+	if value == "" || strings.ContainsAny(value, "_ \t") {
+		return false
+	}
+	_, err := language.Parse(value)
+	return err == nil
+}
+
+// NormalizeLanguage returns the canonical BCP 47 form of a LANG payload:
+// a 5.5.1 language name is mapped with LanguageTag ("english" to "en"), and
+// a tag is given the conventional case and separators ("EN_us" to "en-US",
+// "zh-hans" to "zh-Hans"). The subtags of a tag are kept, so deprecated
+// tags are not replaced. It returns false when value is neither.
+func NormalizeLanguage(value string) (string, bool) {
+	if tag, ok := LanguageTag(value); ok {
+		return tag, true
+	}
+	value = strings.ReplaceAll(strings.TrimSpace(value), "_", "-")
+	if !IsLanguageTag(value) {
+		return "", false
+	}
+	return formatLanguageTag(value), true
+}
+
+// formatLanguageTag applies the RFC 5646 case conventions to a well-formed
+// tag: lower case, except title case scripts and upper case regions before
+// any singleton extension.
+func formatLanguageTag(tag string) string {
+	subtags := strings.Split(strings.ToLower(tag), "-")
+	if len(subtags[0]) == 1 {
+		return strings.Join(subtags, "-")
+	}
+	for i := 1; i < len(subtags); i++ {
+		s := subtags[i]
+		if len(s) == 1 {
+			break
+		}
+		switch {
+		case len(s) == 4 && isASCIILetters(s):
+			subtags[i] = strings.ToUpper(s[:1]) + s[1:]
+		case len(s) == 2 && isASCIILetters(s):
+			subtags[i] = strings.ToUpper(s)
+		}
+	}
+	return strings.Join(subtags, "-")
+}
+
+// isASCIILetters reports whether s consists of ASCII letters only.
+func isASCIILetters(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package gedcom
+
+import "testing"
+
+func TestLanguageTag(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{"English", "en", true},
+		{"english", "en", true},
+		{"GERMAN", "de", true},
+		{"Church Slavic", "cu", true},
+		{"Catalan_Spn", "ca-ES", true},
+		{"Serbo-Croa", "hbs", true},
+		{"Klingon", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := LanguageTag(tt.name)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("LanguageTag(%q) = %q, %v; want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestLanguageName(t *testing.T) {
+	tests := []struct {
+		tag   string
+		want  string
+		exact bool
+		ok    bool
+	}{
+		{"en", "English", true, true},
+		{"DE", "German", true, true},
+		{"ca-ES", "Catalan_Spn", true, true},
+		{"ca", "Catalan", true, true},
+		{"en-GB", "English", false, true},
+		{"zh-Hans", "", false, false},
+		{"", "", false, false},
+	}
+	for _, tt := range tests {
+		got, exact, ok := LanguageName(tt.tag)
+		if got != tt.want || exact != tt.exact || ok != tt.ok {
+			t.Errorf("LanguageName(%q) = %q, %v, %v; want %q, %v, %v", tt.tag, got, exact, ok, tt.want, tt.exact, tt.ok)
+		}
+	}
+	for _, l := range languageNames {
+		if tag, _ := LanguageTag(l.name); tag != l.tag {
+			t.Errorf("LanguageTag(%q) = %q, want %q", l.name, tag, l.tag)
+		}
+		if name, exact, _ := LanguageName(l.tag); name != l.name || !exact {
+			t.Errorf("LanguageName(%q) = %q, %v; want %q", l.tag, name, exact, l.name)
+		}
+	}
+}
+
+func TestLanguageNamesAreCanonicalTags(t *testing.T) {
+	for _, l := range languageNames {
+		if !IsLanguageTag(l.tag) {
+			t.Errorf("%s: %q is not a valid tag", l.name, l.tag)
+		}
+		if got, _ := NormalizeLanguage(l.tag); got != l.tag {
+			t.Errorf("%s: NormalizeLanguage(%q) = %q", l.name, l.tag, got)
+		}
+	}
+}
+
+func TestIsLanguageTag(t *testing.T) {
+	for _, v := range []string{"en", "en-US", "EN-us", "zh-Hans-CN", "de-DE-1996", "x-private", "und", "qaa"} {
+		if !IsLanguageTag(v) {
+			t.Errorf("IsLanguageTag(%q) = false", v)
+		}
+	}
+	for _, v := range []string{"", "English", "en_US", "en--US", "xyz", "en US"} {
+		if IsLanguageTag(v) {
+			t.Errorf("IsLanguageTag(%q) = true", v)
+		}
+	}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{"english", "en", true},
+		{" French ", "fr", true},
+		{"Lao", "lo", true},
+		{"EN_us", "en-US", true},
+		{"zh-hans", "zh-Hans", true},
+		{"en", "en", true},
+		{"tl", "tl", true},
+		{"SR-latn-rs", "sr-Latn-RS", true},
+		{"en-x-TWO", "en-x-two", true},
+		{"X-AB", "x-ab", true},
+		{"Elvish", "", false},
+		{"en US", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := NormalizeLanguage(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NormalizeLanguage(%q) = %q, %v; want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
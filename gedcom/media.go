@@ -84,14 +84,22 @@ type MediaObject struct {
 	SharedNoteXRefs []string
 
 	// Restriction is the access restriction level (RESN tag)
-	Restriction string
+	Restriction Restriction
 
 	// SourceCitations are source citations with page/quality details
 	SourceCitations []*SourceCitation
 
+	// ExtensionData holds the registered application extensions of this
+	// media object (see RegisterExtension), keyed by root tag.
+	ExtensionData ExtensionData
+
 	// Tags contains all raw tags for this media object (for unknown/custom tags)
 	Tags []*Tag
 
+	// AutomatedRecordID is the record ID assigned by the application that
+	// produced the file (RIN tag, GEDCOM 5.5.x)
+	AutomatedRecordID string
+
 	// UIDs are unique identifiers (UID tag, can have multiple in GEDCOM 7.0)
 	UIDs []string
 
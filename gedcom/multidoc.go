@@ -0,0 +1,358 @@
+package gedcom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MultiDocument presents several loaded Documents as one read-only view,
+// so queries can span files without merging them. Each document is added
+// under a namespace, and its records are addressed by qualified XRefs such
+// as "@smith:I1@" (see QualifyXRef). Individuals that appear in more than
+// one file (a shared grandparent in two cousins' trees) can be declared
+// the same person with Link, after which family navigation crosses files.
+//
+//	m := gedcom.NewMultiDocument()
+//	_ = m.Add("smith", smithDoc)
+//	_ = m.Add("jones", jonesDoc)
+//	_ = m.Link("@smith:I7@", "@jones:I3@")
+//	common := m.CommonAncestors("@smith:I1@", "@jones:I1@")
+//
+// The documents are never modified, and entities are returned as stored,
+// so their own link fields (Husband, ChildInFamilies, ...) hold local
+// XRefs; use the MultiDocument methods to follow links. A MultiDocument is
+// safe for concurrent reads once all Add and Link calls have returned.
+type MultiDocument struct {
+	namespaces []string
+	docs       map[string]*Document
+	// links is a union-find forest over qualified individual XRefs joined
+	// by Link; XRefs never linked are absent.
+	links map[string]string
+}
+
+// NewMultiDocument returns an empty MultiDocument.
+func NewMultiDocument() *MultiDocument {
+	return &MultiDocument{
+		docs:  make(map[string]*Document),
+		links: make(map[string]string),
+	}
+}
+
+// QualifyXRef returns the qualified form of a document-local XRef, e.g.
+// QualifyXRef("smith", "@I1@") is "@smith:I1@".
+func QualifyXRef(namespace, xref string) string {
+	return "@" + namespace + ":" + strings.Trim(xref, "@") + "@"
+}
+
+// SplitXRef splits a qualified XRef into its namespace and local XRef, e.g.
+// "@smith:I1@" into "smith" and "@I1@". It reports false if xref is not
+// qualified.
+func SplitXRef(xref string) (namespace, local string, ok bool) {
+	if len(xref) < 2 || xref[0] != '@' || xref[len(xref)-1] != '@' {
+		return "", "", false
+	}
+	namespace, id, found := strings.Cut(xref[1:len(xref)-1], ":")
+	if !found || namespace == "" || id == "" {
+		return "", "", false
+	}
+	return namespace, "@" + id + "@", true
+}
+
+// Add includes doc in the view under namespace. The namespace must be
+// non-empty, unique, and free of "@" and ":".
+func (m *MultiDocument) Add(namespace string, doc *Document) error {
+	if namespace == "" || strings.ContainsAny(namespace, "@:") {
+		return fmt.Errorf("gedcom: invalid namespace %q (must be non-empty without '@' or ':')", namespace)
+	}
+	if doc == nil {
+		return fmt.Errorf("gedcom: document for namespace %q is nil", namespace)
+	}
+	if _, exists := m.docs[namespace]; exists {
+		return fmt.Errorf("gedcom: namespace %q already added", namespace)
+	}
+	m.namespaces = append(m.namespaces, namespace)
+	m.docs[namespace] = doc
+	return nil
+}
+
+// Namespaces returns the namespaces in the order they were added.
+func (m *MultiDocument) Namespaces() []string {
+	return append([]string(nil), m.namespaces...)
+}
+
+// Document returns the document added under namespace, or nil.
+func (m *MultiDocument) Document(namespace string) *Document {
+	return m.docs[namespace]
+}
+
+// GetRecord returns the record with the given qualified XRef, or nil.
+func (m *MultiDocument) GetRecord(xref string) *Record {
+	doc, local := m.resolve(xref)
+	if doc == nil {
+		return nil
+	}
+	return doc.GetRecord(local)
+}
+
+// GetIndividual returns the individual with the given qualified XRef, or nil.
+func (m *MultiDocument) GetIndividual(xref string) *Individual {
+	doc, local := m.resolve(xref)
+	if doc == nil {
+		return nil
+	}
+	return doc.GetIndividual(local)
+}
+
+// GetFamily returns the family with the given qualified XRef, or nil.
+func (m *MultiDocument) GetFamily(xref string) *Family {
+	doc, local := m.resolve(xref)
+	if doc == nil {
+		return nil
+	}
+	return doc.GetFamily(local)
+}
+
+// GetSource returns the source with the given qualified XRef, or nil.
+func (m *MultiDocument) GetSource(xref string) *Source {
+	doc, local := m.resolve(xref)
+	if doc == nil {
+		return nil
+	}
+	return doc.GetSource(local)
+}
+
+// XRefs returns the qualified XRefs of every record of the given type, in
+// namespace order and then document order.
+func (m *MultiDocument) XRefs(recordType RecordType) []string {
+	var result []string
+	for _, ns := range m.namespaces {
+		for _, record := range m.docs[ns].Records {
+			if record != nil && record.Type == recordType && record.XRef != "" {
+				result = append(result, QualifyXRef(ns, record.XRef))
+			}
+		}
+	}
+	return result
+}
+
+// FindIndividuals returns the qualified XRefs of the individuals, across
+// all documents, for which match returns true.
+func (m *MultiDocument) FindIndividuals(match func(*Individual) bool) []string {
+	var result []string
+	for _, ns := range m.namespaces {
+		for _, ind := range m.docs[ns].Individuals() {
+			if match(ind) {
+				result = append(result, QualifyXRef(ns, ind.XRef))
+			}
+		}
+	}
+	return result
+}
+
+// Link declares that two qualified individual XRefs, usually in different
+// documents, are the same person. Links are symmetric and transitive.
+// Returns an error if either XRef is not an individual of the view.
+func (m *MultiDocument) Link(a, b string) error {
+	for _, xref := range []string{a, b} {
+		if m.GetIndividual(xref) == nil {
+			return fmt.Errorf("gedcom: %s is not an individual of the view", xref)
+		}
+	}
+	ra, rb := m.root(a), m.root(b)
+	if ra != rb {
+		m.links[ra] = rb
+		if _, ok := m.links[rb]; !ok {
+			m.links[rb] = rb
+		}
+	}
+	return nil
+}
+
+// Identities returns every qualified XRef linked to xref, including xref
+// itself, in namespace order. It returns nil if xref is not an individual
+// of the view.
+func (m *MultiDocument) Identities(xref string) []string {
+	if m.GetIndividual(xref) == nil {
+		return nil
+	}
+	if _, linked := m.links[xref]; !linked {
+		return []string{xref}
+	}
+	root := m.root(xref)
+	var result []string
+	for member := range m.links {
+		if m.root(member) == root {
+			result = append(result, member)
+		}
+	}
+	m.sortXRefs(result)
+	return result
+}
+
+// Parents returns the qualified XRefs of the parents (husband and wife of
+// each FAMC family) of the individual in every document it is linked in.
+// People linked to each other are listed once.
+func (m *MultiDocument) Parents(xref string) []string {
+	return m.related(xref, func(doc *Document, ind *Individual) []string {
+		var result []string
+		for _, link := range ind.ChildInFamilies {
+			if fam := doc.GetFamily(link.FamilyXRef); fam != nil {
+				result = append(result, fam.Husband, fam.Wife)
+			}
+		}
+		return result
+	})
+}
+
+// Children returns the qualified XRefs of the children of the individual
+// in every document it is linked in.
+func (m *MultiDocument) Children(xref string) []string {
+	return m.related(xref, func(doc *Document, ind *Individual) []string {
+		var result []string
+		for _, famXRef := range ind.SpouseInFamilies {
+			if fam := doc.GetFamily(famXRef); fam != nil {
+				result = append(result, fam.Children...)
+			}
+		}
+		return result
+	})
+}
+
+// Spouses returns the qualified XRefs of the spouses of the individual in
+// every document it is linked in.
+func (m *MultiDocument) Spouses(xref string) []string {
+	return m.related(xref, func(doc *Document, ind *Individual) []string {
+		var result []string
+		for _, famXRef := range ind.SpouseInFamilies {
+			if fam := doc.GetFamily(famXRef); fam != nil {
+				for _, spouse := range []string{fam.Husband, fam.Wife} {
+					if spouse != ind.XRef {
+						result = append(result, spouse)
+					}
+				}
+			}
+		}
+		return result
+	})
+}
+
+// Ancestors returns the qualified XRefs of all transitive ancestors of the
+// individual across linked documents, breadth-first, like
+// Document.Ancestors. Each linked person appears once, under the first
+// XRef reached. Returns nil if xref is not an individual of the view.
+func (m *MultiDocument) Ancestors(xref string) []string {
+	return m.walk(xref, m.Parents)
+}
+
+// Descendants returns the qualified XRefs of all transitive descendants of
+// the individual across linked documents, breadth-first, like
+// Document.Descendants.
+func (m *MultiDocument) Descendants(xref string) []string {
+	return m.walk(xref, m.Children)
+}
+
+// CommonAncestors returns the ancestors shared by two individuals, which
+// may be in different documents, ordered nearest to a first. An individual
+// counts as its own ancestor here, so if b descends from a, a is returned.
+// This is the starting point for working out how two people are related.
+func (m *MultiDocument) CommonAncestors(a, b string) []string {
+	if m.GetIndividual(a) == nil || m.GetIndividual(b) == nil {
+		return nil
+	}
+	ofB := make(map[string]bool)
+	for _, x := range append([]string{b}, m.Ancestors(b)...) {
+		ofB[m.root(x)] = true
+	}
+	result := []string{}
+	for _, x := range append([]string{a}, m.Ancestors(a)...) {
+		if ofB[m.root(x)] {
+			result = append(result, x)
+		}
+	}
+	return result
+}
+
+// resolve returns the document and local XRef of a qualified XRef.
+func (m *MultiDocument) resolve(xref string) (*Document, string) {
+	ns, local, ok := SplitXRef(xref)
+	if !ok {
+		return nil, ""
+	}
+	return m.docs[ns], local
+}
+
+// root returns the representative of xref's link set; unlinked XRefs are
+// their own representative.
+func (m *MultiDocument) root(xref string) string {
+	for {
+		parent, ok := m.links[xref]
+		if !ok || parent == xref {
+			return xref
+		}
+		xref = parent
+	}
+}
+
+// related collects the individuals returned by links for every identity
+// of xref, qualified and listed once per linked person.
+func (m *MultiDocument) related(xref string, links func(*Document, *Individual) []string) []string {
+	identities := m.Identities(xref)
+	if identities == nil {
+		return nil
+	}
+	self := m.root(xref)
+	seen := make(map[string]bool)
+	result := []string{}
+	for _, identity := range identities {
+		doc, local := m.resolve(identity)
+		ns, _, _ := SplitXRef(identity)
+		for _, other := range links(doc, doc.GetIndividual(local)) {
+			if other == "" || doc.GetIndividual(other) == nil {
+				continue
+			}
+			q := QualifyXRef(ns, other)
+			if r := m.root(q); r != self && !seen[r] {
+				seen[r] = true
+				result = append(result, q)
+			}
+		}
+	}
+	return result
+}
+
+// walk returns the breadth-first closure of next from xref, excluding xref.
+func (m *MultiDocument) walk(xref string, next func(string) []string) []string {
+	if m.GetIndividual(xref) == nil {
+		return nil
+	}
+	visited := map[string]bool{m.root(xref): true}
+	result := []string{}
+	queue := []string{xref}
+	for head := 0; head < len(queue); head++ {
+		for _, other := range next(queue[head]) {
+			if r := m.root(other); !visited[r] {
+				visited[r] = true
+				result = append(result, other)
+				queue = append(queue, other)
+			}
+		}
+	}
+	return result
+}
+
+// sortXRefs orders qualified XRefs by namespace order, then by XRef.
+func (m *MultiDocument) sortXRefs(xrefs []string) {
+	order := make(map[string]int, len(m.namespaces))
+	for i, ns := range m.namespaces {
+		order[ns] = i
+	}
+	sort.Slice(xrefs, func(i, j int) bool {
+		nsI, _, _ := SplitXRef(xrefs[i])
+		nsJ, _, _ := SplitXRef(xrefs[j])
+		if order[nsI] != order[nsJ] {
+			return order[nsI] < order[nsJ]
+		}
+		return xrefs[i] < xrefs[j]
+	})
+}
@@ -0,0 +1,170 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+)
+
+// buildCousinFixture is a second file sharing the grandfather of
+// buildGenealogyFixture under its own XRefs:
+//
+//	@I1@ (grandpa, same person as smith @I1@) + @I2@ -> @F1@
+//	  child: @I3@ + @I4@ -> @F2@
+//	    child: @I5@ (cousin)
+func buildCousinFixture() *Document {
+	doc := &Document{
+		Header:  &Header{Version: Version551, Encoding: EncodingUTF8},
+		XRefMap: make(map[string]*Record),
+	}
+	add := func(xref string, entity interface{}, recordType RecordType) {
+		rec := &Record{XRef: xref, Type: recordType, Entity: entity}
+		doc.Records = append(doc.Records, rec)
+		doc.XRefMap[xref] = rec
+	}
+	add("@I1@", &Individual{XRef: "@I1@", SpouseInFamilies: []string{"@F1@"}}, RecordTypeIndividual)
+	add("@I2@", &Individual{XRef: "@I2@", SpouseInFamilies: []string{"@F1@"}}, RecordTypeIndividual)
+	add("@I3@", &Individual{XRef: "@I3@", SpouseInFamilies: []string{"@F2@"},
+		ChildInFamilies: []FamilyLink{{FamilyXRef: "@F1@"}}}, RecordTypeIndividual)
+	add("@I4@", &Individual{XRef: "@I4@", SpouseInFamilies: []string{"@F2@"}}, RecordTypeIndividual)
+	add("@I5@", &Individual{XRef: "@I5@", Sex: "F",
+		ChildInFamilies: []FamilyLink{{FamilyXRef: "@F2@"}}}, RecordTypeIndividual)
+	add("@F1@", &Family{XRef: "@F1@", Husband: "@I1@", Wife: "@I2@", Children: []string{"@I3@"}}, RecordTypeFamily)
+	add("@F2@", &Family{XRef: "@F2@", Husband: "@I3@", Wife: "@I4@", Children: []string{"@I5@"}}, RecordTypeFamily)
+	return doc
+}
+
+func buildMultiDocument(t *testing.T) *MultiDocument {
+	t.Helper()
+	m := NewMultiDocument()
+	if err := m.Add("smith", buildGenealogyFixture()); err != nil {
+		t.Fatalf("Add(smith) error = %v", err)
+	}
+	if err := m.Add("jones", buildCousinFixture()); err != nil {
+		t.Fatalf("Add(jones) error = %v", err)
+	}
+	return m
+}
+
+func TestQualifyXRef(t *testing.T) {
+	q := QualifyXRef("smith", "@I1@")
+	if q != "@smith:I1@" {
+		t.Fatalf("QualifyXRef() = %q", q)
+	}
+	ns, local, ok := SplitXRef(q)
+	if !ok || ns != "smith" || local != "@I1@" {
+		t.Errorf("SplitXRef(%q) = %q, %q, %v", q, ns, local, ok)
+	}
+	for _, bad := range []string{"@I1@", "smith:I1", "@:I1@", "@smith:@", ""} {
+		if _, _, ok := SplitXRef(bad); ok {
+			t.Errorf("SplitXRef(%q) ok = true", bad)
+		}
+	}
+}
+
+func TestMultiDocument_Add(t *testing.T) {
+	m := NewMultiDocument()
+	doc := buildCousinFixture()
+	if err := m.Add("a", doc); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	for _, tt := range []struct {
+		ns  string
+		doc *Document
+	}{
+		{"", doc}, {"a:b", doc}, {"a@", doc}, {"a", doc}, {"b", nil},
+	} {
+		if err := m.Add(tt.ns, tt.doc); err == nil {
+			t.Errorf("Add(%q) error = nil", tt.ns)
+		}
+	}
+	if got := m.Namespaces(); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("Namespaces() = %v", got)
+	}
+	if m.Document("a") != doc {
+		t.Error("Document(a) did not return the added document")
+	}
+}
+
+func TestMultiDocument_Lookup(t *testing.T) {
+	m := buildMultiDocument(t)
+	if ind := m.GetIndividual("@jones:I5@"); ind == nil || ind.Sex != "F" {
+		t.Errorf("GetIndividual(@jones:I5@) = %v", ind)
+	}
+	if fam := m.GetFamily("@smith:F3@"); fam == nil || fam.Wife != "@I8@" {
+		t.Errorf("GetFamily(@smith:F3@) = %v", fam)
+	}
+	if m.GetRecord("@I1@") != nil || m.GetRecord("@other:I1@") != nil || m.GetSource("@smith:I1@") != nil {
+		t.Error("lookups of unqualified, unknown or mistyped XRefs should return nil")
+	}
+
+	if got := m.XRefs(RecordTypeFamily); len(got) != 5 || got[0] != "@smith:F1@" || got[4] != "@jones:F2@" {
+		t.Errorf("XRefs(FAM) = %v", got)
+	}
+	got := m.FindIndividuals(func(ind *Individual) bool { return len(ind.ChildInFamilies) == 0 })
+	want := []string{"@smith:I1@", "@smith:I2@", "@smith:I4@", "@smith:I8@", "@jones:I1@", "@jones:I2@", "@jones:I4@"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindIndividuals() = %v, want %v", got, want)
+	}
+}
+
+func TestMultiDocument_Link(t *testing.T) {
+	m := buildMultiDocument(t)
+	if err := m.Link("@smith:I1@", "@jones:F1@"); err == nil {
+		t.Error("Link() to a family should fail")
+	}
+	if err := m.Link("@jones:I1@", "@smith:I1@"); err != nil {
+		t.Fatalf("Link() error = %v", err)
+	}
+	want := []string{"@smith:I1@", "@jones:I1@"}
+	if got := m.Identities("@jones:I1@"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Identities() = %v, want %v", got, want)
+	}
+	if got := m.Identities("@smith:I5@"); !reflect.DeepEqual(got, []string{"@smith:I5@"}) {
+		t.Errorf("Identities(unlinked) = %v", got)
+	}
+
+	// The linked grandfather has children and spouses from both files.
+	if got := m.Children("@smith:I1@"); !reflect.DeepEqual(got, []string{"@smith:I3@", "@smith:I7@", "@jones:I3@"}) {
+		t.Errorf("Children() = %v", got)
+	}
+	if got := m.Spouses("@jones:I1@"); !reflect.DeepEqual(got, []string{"@smith:I2@", "@jones:I2@"}) {
+		t.Errorf("Spouses() = %v", got)
+	}
+	if got := m.Parents("@jones:I3@"); !reflect.DeepEqual(got, []string{"@jones:I1@", "@jones:I2@"}) {
+		t.Errorf("Parents() = %v", got)
+	}
+}
+
+func TestMultiDocument_AncestorsAcrossFiles(t *testing.T) {
+	m := buildMultiDocument(t)
+	if err := m.Link("@smith:I1@", "@jones:I1@"); err != nil {
+		t.Fatalf("Link() error = %v", err)
+	}
+
+	got := m.Ancestors("@jones:I5@")
+	want := []string{"@jones:I3@", "@jones:I4@", "@jones:I1@", "@jones:I2@"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Ancestors() = %v, want %v", got, want)
+	}
+	desc := m.Descendants("@jones:I1@")
+	if len(desc) != 7 || desc[0] != "@smith:I3@" {
+		t.Errorf("Descendants() = %v", desc)
+	}
+
+	// The cousins share only the linked grandfather.
+	if got := m.CommonAncestors("@smith:I5@", "@jones:I5@"); !reflect.DeepEqual(got, []string{"@smith:I1@"}) {
+		t.Errorf("CommonAncestors() = %v", got)
+	}
+	if got := m.CommonAncestors("@smith:I5@", "@smith:I3@"); !reflect.DeepEqual(got, []string{"@smith:I3@", "@smith:I1@", "@smith:I2@"}) {
+		t.Errorf("CommonAncestors(descendant) = %v", got)
+	}
+	if m.Ancestors("@smith:F1@") != nil || m.CommonAncestors("@smith:I5@", "@nobody:I1@") != nil {
+		t.Error("invalid XRefs should return nil")
+	}
+
+	// Without the link the files are unrelated.
+	unlinked := buildMultiDocument(t)
+	if got := unlinked.CommonAncestors("@smith:I5@", "@jones:I5@"); len(got) != 0 {
+		t.Errorf("CommonAncestors(unlinked) = %v", got)
+	}
+}
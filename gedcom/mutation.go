@@ -0,0 +1,564 @@
+package gedcom
+
+import (
+	"errors"
+	"fmt"
+)
+
+// NextXRef returns an XRef of the form @<prefix><n>@ (such as "@I12@" for
+// prefix "I") that no record of the document uses. Numbering resumes from
+// the last XRef it returned for the prefix, so removing a record does not
+// free its number for reuse, and building a large document stays linear.
+// NextXRef is sequential whatever the document's XRefStrategy.
+func (d *Document) NextXRef(prefix string) string {
+	defer d.lock()()
+	if !d.indexed() {
+		d.reindex()
+	}
+	return d.nextXRef(prefix)
+}
+
+// nextXRef implements NextXRef; the caller holds the write lock and has
+// brought the index up to date.
+func (d *Document) nextXRef(prefix string) string {
+	if d.index.nextXRef == nil {
+		d.index.nextXRef = make(map[string]int)
+	}
+	n := d.index.nextXRef[prefix]
+	if n < 1 {
+		n = 1
+	}
+	for {
+		xref := fmt.Sprintf("@%s%d@", prefix, n)
+		if _, used := d.XRefMap[xref]; !used {
+			d.index.nextXRef[prefix] = n
+			return xref
+		}
+		n++
+	}
+}
+
+// AddIndividual adds indi to the document as a new INDI record and returns
+// its XRef. An individual without an XRef is given one under the
+// document's XRefStrategy, by default NextXRef("I").
+// Families already in the document that indi names in ChildInFamilies or
+// SpouseInFamilies get the matching CHIL, HUSB, or WIFE link; a spouse takes
+// the free HUSB or WIFE slot, WIFE first for Sex F. Links to families not
+// yet added are kept as given, and AddFamily completes them.
+//
+// It returns an error if indi is nil, its XRef is in use, a family link
+// names a record of another type, or a family it joins as a spouse already
+// has two. The document is unchanged on error.
+func (d *Document) AddIndividual(indi *Individual) (string, error) {
+	if indi == nil {
+		return "", errors.New("gedcom: individual is nil")
+	}
+
+	defer d.lock()()
+	if !d.indexed() {
+		d.reindex()
+	}
+	xref := indi.XRef
+	if xref == "" {
+		xref = d.allocateXRef("I", &Record{Type: RecordTypeIndividual, Entity: indi})
+	} else if _, exists := d.XRefMap[xref]; exists {
+		return "", fmt.Errorf("gedcom: XRef %s already in use", xref)
+	}
+
+	type spouseLink struct {
+		fam  *Record
+		role string
+	}
+	var spouses []spouseLink
+	for _, famXRef := range indi.SpouseInFamilies {
+		fam, err := d.familyRecord(famXRef)
+		if err != nil {
+			return "", err
+		}
+		if fam == nil {
+			continue
+		}
+		role := spouseRole(fam, xref, indi.Sex)
+		if role == "" {
+			return "", fmt.Errorf("gedcom: family %s already has two spouses", famXRef)
+		}
+		spouses = append(spouses, spouseLink{fam, role})
+	}
+	var parents []*Record
+	var pedigrees []Pedigree
+	for _, link := range indi.ChildInFamilies {
+		fam, err := d.familyRecord(link.FamilyXRef)
+		if err != nil {
+			return "", err
+		}
+		if fam != nil {
+			parents = append(parents, fam)
+			pedigrees = append(pedigrees, link.Pedigree)
+		}
+	}
+
+	indi.XRef = xref
+	record := &Record{XRef: xref, Type: RecordTypeIndividual, Entity: indi}
+	if err := d.addRecord(record); err != nil {
+		return "", err
+	}
+	for _, s := range spouses {
+		d.linkSpouse(s.fam, record, s.role)
+	}
+	for i, fam := range parents {
+		d.linkChild(fam, record, pedigrees[i])
+	}
+	return xref, nil
+}
+
+// AddFamily adds fam to the document as a new FAM record and returns its
+// XRef. A family without an XRef is given one under the document's
+// XRefStrategy, by default NextXRef("F").
+// Individuals already in the document that fam names as Husband, Wife, or
+// Children get the matching FAMS or FAMC link; links to individuals not yet
+// added are kept as given, and AddIndividual completes them.
+//
+// It returns an error if fam is nil, its XRef is in use, or a member names
+// a record of another type. The document is unchanged on error.
+func (d *Document) AddFamily(fam *Family) (string, error) {
+	if fam == nil {
+		return "", errors.New("gedcom: family is nil")
+	}
+
+	defer d.lock()()
+	if !d.indexed() {
+		d.reindex()
+	}
+	xref := fam.XRef
+	if xref == "" {
+		xref = d.allocateXRef("F", &Record{Type: RecordTypeFamily, Entity: fam})
+	} else if _, exists := d.XRefMap[xref]; exists {
+		return "", fmt.Errorf("gedcom: XRef %s already in use", xref)
+	}
+
+	members := make(map[string]*Record)
+	for _, member := range append([]string{fam.Husband, fam.Wife}, fam.Children...) {
+		if member == "" {
+			continue
+		}
+		indi, err := d.individualRecord(member)
+		if err != nil {
+			return "", err
+		}
+		members[member] = indi
+	}
+
+	fam.XRef = xref
+	record := &Record{XRef: xref, Type: RecordTypeFamily, Entity: fam}
+	if err := d.addRecord(record); err != nil {
+		return "", err
+	}
+	if indi := members[fam.Husband]; indi != nil {
+		d.linkSpouse(record, indi, "HUSB")
+	}
+	if indi := members[fam.Wife]; indi != nil {
+		d.linkSpouse(record, indi, "WIFE")
+	}
+	for _, child := range fam.Children {
+		if indi := members[child]; indi != nil {
+			d.linkChild(record, indi, "")
+		}
+	}
+	return xref, nil
+}
+
+// LinkChild makes the individual a child of the family, adding the CHIL
+// link to the family and the FAMC link to the individual where missing.
+// It returns an error unless famXRef is a FAM record and childXRef an INDI
+// record of the document.
+func (d *Document) LinkChild(famXRef, childXRef string) error {
+	defer d.lock()()
+	fam, indi, err := d.familyMember(famXRef, childXRef)
+	if err != nil {
+		return err
+	}
+	d.linkChild(fam, indi, "")
+	return nil
+}
+
+// LinkSpouse makes the individual a spouse in the family, adding the
+// HUSB or WIFE link to the family (the free slot, WIFE first for Sex F) and
+// the FAMS link to the individual where missing. It returns an error unless
+// famXRef is a FAM record and spouseXRef an INDI record of the document, or
+// if the family already has two other spouses.
+func (d *Document) LinkSpouse(famXRef, spouseXRef string) error {
+	defer d.lock()()
+	fam, indi, err := d.familyMember(famXRef, spouseXRef)
+	if err != nil {
+		return err
+	}
+	role := spouseRole(fam, spouseXRef, individualSex(indi))
+	if role == "" {
+		return fmt.Errorf("gedcom: family %s already has two spouses", famXRef)
+	}
+	d.linkSpouse(fam, indi, role)
+	return nil
+}
+
+// Unlink removes the individual from the family: the family's HUSB, WIFE,
+// and CHIL links to the individual and the individual's FAMS and FAMC links
+// to the family. Both records stay in the document. It returns an error
+// unless famXRef is a FAM record and indiXRef an INDI record of the
+// document.
+func (d *Document) Unlink(famXRef, indiXRef string) error {
+	defer d.lock()()
+	fam, indi, err := d.familyMember(famXRef, indiXRef)
+	if err != nil {
+		return err
+	}
+	if unlinkMember(fam, indiXRef) {
+		d.touch(fam)
+	}
+	if unlinkFamily(indi, famXRef) {
+		d.touch(indi)
+	}
+	return nil
+}
+
+// RemoveIndividual removes the INDI record with the given XRef, as
+// RemoveRecord does, along with the HUSB, WIFE, and CHIL links of every
+// family to it, and returns the record. Other references to it, such as
+// associations, are left in place. It returns nil, removing nothing, if
+// there is no INDI record with that XRef.
+func (d *Document) RemoveIndividual(xref string) *Record {
+	defer d.lock()()
+	if !d.indexed() {
+		d.reindex()
+	}
+	record := d.XRefMap[xref]
+	if record == nil || record.Type != RecordTypeIndividual {
+		return nil
+	}
+	for _, fam := range d.index.byType[RecordTypeFamily] {
+		if unlinkMember(fam, xref) {
+			d.touch(fam)
+		}
+	}
+	d.removeRecord(record)
+	return record
+}
+
+// RemoveFamily removes the FAM record with the given XRef, as RemoveRecord
+// does, along with the FAMS and FAMC links of every individual to it, and
+// returns the record. Other references to it are left in place. It returns
+// nil, removing nothing, if there is no FAM record with that XRef.
+func (d *Document) RemoveFamily(xref string) *Record {
+	defer d.lock()()
+	if !d.indexed() {
+		d.reindex()
+	}
+	record := d.XRefMap[xref]
+	if record == nil || record.Type != RecordTypeFamily {
+		return nil
+	}
+	for _, indi := range d.index.byType[RecordTypeIndividual] {
+		if unlinkFamily(indi, xref) {
+			d.touch(indi)
+		}
+	}
+	d.removeRecord(record)
+	return record
+}
+
+// familyRecord returns the FAM record with the given XRef, nil if there is
+// no record, or an error if the record has another type. The caller holds
+// the lock and has brought the index up to date.
+func (d *Document) familyRecord(xref string) (*Record, error) {
+	record := d.XRefMap[xref]
+	if record != nil && record.Type != RecordTypeFamily {
+		return nil, fmt.Errorf("gedcom: XRef %s is a %s record, not a family", xref, record.Type)
+	}
+	return record, nil
+}
+
+// individualRecord is the INDI counterpart of familyRecord.
+func (d *Document) individualRecord(xref string) (*Record, error) {
+	record := d.XRefMap[xref]
+	if record != nil && record.Type != RecordTypeIndividual {
+		return nil, fmt.Errorf("gedcom: XRef %s is a %s record, not an individual", xref, record.Type)
+	}
+	return record, nil
+}
+
+// familyMember returns the FAM and INDI records of a link, both of which
+// must exist. The caller holds the write lock.
+func (d *Document) familyMember(famXRef, indiXRef string) (fam, indi *Record, err error) {
+	if !d.indexed() {
+		d.reindex()
+	}
+	if fam, err = d.familyRecord(famXRef); err == nil && fam == nil {
+		err = fmt.Errorf("gedcom: family %s not found", famXRef)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if indi, err = d.individualRecord(indiXRef); err == nil && indi == nil {
+		err = fmt.Errorf("gedcom: individual %s not found", indiXRef)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return fam, indi, nil
+}
+
+// linkChild adds the CHIL link from fam to indi and the FAMC link back,
+// each where missing.
+func (d *Document) linkChild(fam, indi *Record, pedigree Pedigree) {
+	changed := false
+	if f, ok := fam.Entity.(*Family); ok && !containsString(f.Children, indi.XRef) {
+		f.Children = append(f.Children, indi.XRef)
+		changed = true
+	}
+	if addLinkTag(fam, "CHIL", indi.XRef, "") || changed {
+		d.touch(fam)
+	}
+
+	changed = false
+	if i, ok := indi.Entity.(*Individual); ok && !hasFamilyLink(i.ChildInFamilies, fam.XRef) {
+		i.ChildInFamilies = append(i.ChildInFamilies, FamilyLink{FamilyXRef: fam.XRef, Pedigree: pedigree})
+		changed = true
+	}
+	if addLinkTag(indi, "FAMC", fam.XRef, string(pedigree)) || changed {
+		d.touch(indi)
+	}
+}
+
+// linkSpouse sets the HUSB or WIFE (role) link of fam to indi and adds the
+// FAMS link back, each where missing.
+func (d *Document) linkSpouse(fam, indi *Record, role string) {
+	changed := false
+	if f, ok := fam.Entity.(*Family); ok {
+		switch {
+		case role == "WIFE" && f.Wife != indi.XRef:
+			f.SetWife(indi.XRef)
+			changed = true
+		case role != "WIFE" && f.Husband != indi.XRef:
+			f.SetHusband(indi.XRef)
+			changed = true
+		}
+	}
+	if addLinkTag(fam, role, indi.XRef, "") || changed {
+		d.touch(fam)
+	}
+
+	changed = false
+	if i, ok := indi.Entity.(*Individual); ok && !containsString(i.SpouseInFamilies, fam.XRef) {
+		i.SpouseInFamilies = append(i.SpouseInFamilies, fam.XRef)
+		changed = true
+	}
+	if addLinkTag(indi, "FAMS", fam.XRef, "") || changed {
+		d.touch(indi)
+	}
+}
+
+// touch updates the CHAN of a record changed as a side effect, when
+// automatic timestamps are on.
+func (d *Document) touch(record *Record) {
+	if d.index != nil && d.index.timestamps {
+		d.stamp(record, false)
+	}
+}
+
+// spouseRole returns the family slot, HUSB or WIFE, the individual holds or
+// would take as a spouse of fam, or "" if both are held by others.
+func spouseRole(fam *Record, xref, sex string) string {
+	husband, wife := familySpouses(fam)
+	switch {
+	case husband == xref:
+		return "HUSB"
+	case wife == xref:
+		return "WIFE"
+	case sex == "F" && wife == "":
+		return "WIFE"
+	case husband == "":
+		return "HUSB"
+	case wife == "":
+		return "WIFE"
+	}
+	return ""
+}
+
+// familySpouses returns the HUSB and WIFE of fam, from its entity or, for
+// a record without one, its raw tags.
+func familySpouses(fam *Record) (husband, wife string) {
+	if f, ok := fam.Entity.(*Family); ok {
+		return f.Husband, f.Wife
+	}
+	for _, tag := range fam.Tags {
+		if tag == nil || tag.Level != 1 {
+			continue
+		}
+		switch tag.Tag {
+		case "HUSB":
+			husband = tag.Value
+		case "WIFE":
+			wife = tag.Value
+		}
+	}
+	return husband, wife
+}
+
+// individualSex returns the SEX of indi, from its entity or, for a record
+// without one, its raw tags.
+func individualSex(indi *Record) string {
+	if i, ok := indi.Entity.(*Individual); ok {
+		return i.Sex
+	}
+	for _, tag := range indi.Tags {
+		if tag != nil && tag.Level == 1 && tag.Tag == "SEX" {
+			return tag.Value
+		}
+	}
+	return ""
+}
+
+// unlinkMember removes the HUSB, WIFE, and CHIL links of fam to xref and
+// reports whether there were any.
+func unlinkMember(fam *Record, xref string) bool {
+	changed := false
+	if f, ok := fam.Entity.(*Family); ok {
+		changed = unlinkSpouse(f, xref)
+		if children := removeString(f.Children, xref); len(children) != len(f.Children) {
+			f.Children = children
+			changed = true
+		}
+	}
+	return removeLinkTags(fam, xref, "HUSB", "WIFE", "CHIL") || changed
+}
+
+// unlinkSpouse removes the HUSB and WIFE entries of f for xref and
+// reports whether there were any. When xref is the husband or wife, the
+// next remaining HUSB or WIFE entry takes its place, as with SetHusband("").
+func unlinkSpouse(f *Family, xref string) bool {
+	husband, wife := f.Husband == xref, f.Wife == xref
+	if husband {
+		f.SetHusband("")
+	}
+	if wife {
+		f.SetWife("")
+	}
+	changed := husband || wife
+	var spouses []*FamilySpouse
+	for _, spouse := range f.Spouses {
+		if spouse != nil && spouse.XRef == xref && spouse.Tag != "ASSO" {
+			changed = true
+			continue
+		}
+		spouses = append(spouses, spouse)
+	}
+	f.Spouses = spouses
+	if husband {
+		f.Husband = f.slotXRef("HUSB")
+	}
+	if wife {
+		f.Wife = f.slotXRef("WIFE")
+	}
+	return changed
+}
+
+// unlinkFamily removes the FAMS and FAMC links of indi to famXRef and
+// reports whether there were any.
+func unlinkFamily(indi *Record, famXRef string) bool {
+	changed := false
+	if i, ok := indi.Entity.(*Individual); ok {
+		if spouses := removeString(i.SpouseInFamilies, famXRef); len(spouses) != len(i.SpouseInFamilies) {
+			i.SpouseInFamilies = spouses
+			changed = true
+		}
+		var kept []FamilyLink
+		for _, link := range i.ChildInFamilies {
+			if link.FamilyXRef != famXRef {
+				kept = append(kept, link)
+			}
+		}
+		if len(kept) != len(i.ChildInFamilies) {
+			i.ChildInFamilies = kept
+			changed = true
+		}
+	}
+	return removeLinkTags(indi, famXRef, "FAMS", "FAMC") || changed
+}
+
+// addLinkTag appends a level 1 tag pointing at xref, with a PEDI
+// subordinate if pedigree is set, to the raw Tags of record unless it has
+// one already, and reports whether it did. A record without raw Tags is
+// written from its entity, so it is left alone.
+func addLinkTag(record *Record, tag, xref, pedigree string) bool {
+	if len(record.Tags) == 0 {
+		return false
+	}
+	for _, t := range record.Tags {
+		if t != nil && t.Level == 1 && t.Tag == tag && t.Value == xref {
+			return false
+		}
+	}
+	tags := append(record.Tags, &Tag{Level: 1, Tag: tag, Value: xref})
+	if pedigree != "" {
+		tags = append(tags, &Tag{Level: 2, Tag: "PEDI", Value: pedigree})
+	}
+	setRecordTags(record, tags)
+	return true
+}
+
+// removeLinkTags removes the level 1 structures of record's raw Tags that
+// have one of the given tags and point at xref, and reports whether there
+// were any.
+func removeLinkTags(record *Record, xref string, names ...string) bool {
+	var kept []*Tag
+	removing, changed := false, false
+	for _, tag := range record.Tags {
+		if tag != nil && tag.Level <= 1 {
+			removing = tag.Level == 1 && tag.Value == xref && containsString(names, tag.Tag)
+			changed = changed || removing
+		}
+		if !removing {
+			kept = append(kept, tag)
+		}
+	}
+	if changed {
+		setRecordTags(record, kept)
+	}
+	return changed
+}
+
+// setRecordTags replaces the raw Tags of record and of its individual or
+// family entity, which share them.
+func setRecordTags(record *Record, tags []*Tag) {
+	record.Tags = tags
+	switch entity := record.Entity.(type) {
+	case *Individual:
+		entity.Tags = tags
+	case *Family:
+		entity.Tags = tags
+	}
+}
+
+// hasFamilyLink reports whether links include one to famXRef.
+func hasFamilyLink(links []FamilyLink, famXRef string) bool {
+	for _, link := range links {
+		if link.FamilyXRef == famXRef {
+			return true
+		}
+	}
+	return false
+}
+
+// removeString returns xs without the elements equal to s; xs itself is
+// returned when there are none.
+func removeString(xs []string, s string) []string {
+	if !containsString(xs, s) {
+		return xs
+	}
+	kept := make([]string, 0, len(xs)-1)
+	for _, x := range xs {
+		if x != s {
+			kept = append(kept, x)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,344 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDocument_NextXRef(t *testing.T) {
+	doc := &Document{}
+	if got := doc.NextXRef("I"); got != "@I1@" {
+		t.Errorf("NextXRef(I) on empty document = %s, want @I1@", got)
+	}
+	// Unused, so returned again.
+	if got := doc.NextXRef("I"); got != "@I1@" {
+		t.Errorf("repeated NextXRef(I) = %s, want @I1@", got)
+	}
+
+	for _, xref := range []string{"@I1@", "@I2@", "@I4@"} {
+		if err := doc.AddRecord(&Record{XRef: xref, Type: RecordTypeIndividual}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := doc.NextXRef("I"); got != "@I3@" {
+		t.Errorf("NextXRef(I) = %s, want @I3@", got)
+	}
+	if err := doc.AddRecord(&Record{XRef: "@I3@", Type: RecordTypeIndividual}); err != nil {
+		t.Fatal(err)
+	}
+	if got := doc.NextXRef("I"); got != "@I5@" {
+		t.Errorf("NextXRef(I) = %s, want @I5@", got)
+	}
+	if got := doc.NextXRef("SRC"); got != "@SRC1@" {
+		t.Errorf("NextXRef(SRC) = %s, want @SRC1@", got)
+	}
+
+	// Removed numbers are not reused.
+	doc.RemoveRecord("@I2@")
+	if got := doc.NextXRef("I"); got != "@I5@" {
+		t.Errorf("NextXRef(I) after removal = %s, want @I5@", got)
+	}
+}
+
+func TestDocument_AddIndividualAndFamily(t *testing.T) {
+	doc := &Document{}
+	john := &Individual{Sex: "M", Names: []*PersonalName{{Full: "John /Doe/"}}}
+	jane := &Individual{Sex: "F"}
+
+	johnXRef, err := doc.AddIndividual(john)
+	if err != nil || johnXRef != "@I1@" || john.XRef != "@I1@" {
+		t.Fatalf("AddIndividual(john) = %s, %v; XRef %s", johnXRef, err, john.XRef)
+	}
+	janeXRef, err := doc.AddIndividual(jane)
+	if err != nil || janeXRef != "@I2@" {
+		t.Fatalf("AddIndividual(jane) = %s, %v", janeXRef, err)
+	}
+	if doc.GetIndividual("@I1@") != john {
+		t.Error("GetIndividual(@I1@) should return the added individual")
+	}
+
+	fam := &Family{Husband: johnXRef, Wife: janeXRef}
+	famXRef, err := doc.AddFamily(fam)
+	if err != nil || famXRef != "@F1@" {
+		t.Fatalf("AddFamily() = %s, %v", famXRef, err)
+	}
+	if !reflect.DeepEqual(john.SpouseInFamilies, []string{"@F1@"}) || !reflect.DeepEqual(jane.SpouseInFamilies, []string{"@F1@"}) {
+		t.Errorf("FAMS links = %v, %v, want [@F1@]", john.SpouseInFamilies, jane.SpouseInFamilies)
+	}
+
+	// A child naming the family as FAMC gets a CHIL link.
+	child := &Individual{ChildInFamilies: []FamilyLink{{FamilyXRef: famXRef, Pedigree: "birth"}}}
+	childXRef, err := doc.AddIndividual(child)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fam.Children, []string{childXRef}) {
+		t.Errorf("Children = %v, want [%s]", fam.Children, childXRef)
+	}
+	if len(child.ChildInFamilies) != 1 {
+		t.Errorf("ChildInFamilies = %v, want one link", child.ChildInFamilies)
+	}
+
+	if len(doc.Individuals()) != 3 || len(doc.Families()) != 1 || len(doc.XRefMap) != 4 {
+		t.Errorf("document has %d individuals, %d families, %d XRefs", len(doc.Individuals()), len(doc.Families()), len(doc.XRefMap))
+	}
+}
+
+func TestDocument_AddIndividual_SpouseRole(t *testing.T) {
+	doc := &Document{}
+	if _, err := doc.AddFamily(&Family{XRef: "@F1@"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Sex F takes the WIFE slot; others take HUSB first.
+	if _, err := doc.AddIndividual(&Individual{XRef: "@W@", Sex: "F", SpouseInFamilies: []string{"@F1@"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := doc.AddIndividual(&Individual{XRef: "@H@", Sex: "U", SpouseInFamilies: []string{"@F1@"}}); err != nil {
+		t.Fatal(err)
+	}
+	fam := doc.GetFamily("@F1@")
+	if fam.Husband != "@H@" || fam.Wife != "@W@" {
+		t.Errorf("Husband, Wife = %s, %s, want @H@, @W@", fam.Husband, fam.Wife)
+	}
+
+	// A third spouse is refused without changing the document.
+	if _, err := doc.AddIndividual(&Individual{SpouseInFamilies: []string{"@F1@"}}); err == nil {
+		t.Error("AddIndividual() as a third spouse should fail")
+	}
+	if len(doc.Records) != 3 {
+		t.Errorf("document has %d records after a failed add, want 3", len(doc.Records))
+	}
+}
+
+func TestDocument_AddErrors(t *testing.T) {
+	doc := &Document{}
+	if err := doc.AddRecord(&Record{XRef: "@N1@", Type: RecordTypeNote}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := doc.AddIndividual(nil); err == nil {
+		t.Error("AddIndividual(nil) should fail")
+	}
+	if _, err := doc.AddFamily(nil); err == nil {
+		t.Error("AddFamily(nil) should fail")
+	}
+	if _, err := doc.AddIndividual(&Individual{XRef: "@N1@"}); err == nil {
+		t.Error("AddIndividual() with an XRef in use should fail")
+	}
+	indi := &Individual{ChildInFamilies: []FamilyLink{{FamilyXRef: "@N1@"}}}
+	if _, err := doc.AddIndividual(indi); err == nil {
+		t.Error("AddIndividual() linking to a note as a family should fail")
+	}
+	if indi.XRef != "" {
+		t.Errorf("failed AddIndividual() set XRef %s", indi.XRef)
+	}
+	if _, err := doc.AddFamily(&Family{Children: []string{"@N1@"}}); err == nil {
+		t.Error("AddFamily() with a note as a child should fail")
+	}
+
+	// Links to records not yet added are kept.
+	xref, err := doc.AddFamily(&Family{Husband: "@I9@"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.GetFamily(xref).Husband != "@I9@" {
+		t.Error("AddFamily() should keep a link to a missing individual")
+	}
+}
+
+func TestDocument_LinkAndUnlink(t *testing.T) {
+	doc := rawFamilyDocument(t)
+
+	if err := doc.LinkChild("@F1@", "@I3@"); err != nil {
+		t.Fatal(err)
+	}
+	fam := doc.GetRecord("@F1@")
+	if !hasLinkTag(fam, "CHIL", "@I3@") || !hasLinkTag(doc.GetRecord("@I3@"), "FAMC", "@F1@") {
+		t.Error("LinkChild() should add CHIL and FAMC tags")
+	}
+	if got := doc.GetFamily("@F1@").Children; !reflect.DeepEqual(got, []string{"@I3@"}) {
+		t.Errorf("Children = %v, want [@I3@]", got)
+	}
+	// Linking again adds nothing.
+	n := len(fam.Tags)
+	if err := doc.LinkChild("@F1@", "@I3@"); err != nil || len(fam.Tags) != n {
+		t.Errorf("repeated LinkChild() = %v, tags %d -> %d", err, n, len(fam.Tags))
+	}
+
+	if err := doc.Unlink("@F1@", "@I1@"); err != nil {
+		t.Fatal(err)
+	}
+	if hasLinkTag(fam, "HUSB", "@I1@") || doc.GetFamily("@F1@").Husband != "" {
+		t.Error("Unlink() should remove the HUSB link")
+	}
+	husband := doc.GetRecord("@I1@")
+	if hasLinkTag(husband, "FAMS", "@F1@") || len(doc.GetIndividual("@I1@").SpouseInFamilies) != 0 {
+		t.Error("Unlink() should remove the FAMS link")
+	}
+	if husband.Tags[len(husband.Tags)-1].Tag != "SEX" {
+		t.Errorf("Unlink() removed the wrong tags: %v", husband.Tags)
+	}
+
+	if err := doc.LinkSpouse("@F1@", "@I1@"); err != nil {
+		t.Fatal(err)
+	}
+	if !hasLinkTag(fam, "HUSB", "@I1@") || !hasLinkTag(husband, "FAMS", "@F1@") {
+		t.Error("LinkSpouse() should restore the HUSB and FAMS links")
+	}
+	if err := doc.LinkSpouse("@F1@", "@I3@"); err == nil {
+		t.Error("LinkSpouse() of a third spouse should fail")
+	}
+
+	if err := doc.LinkChild("@I1@", "@I3@"); err == nil {
+		t.Error("LinkChild() with an individual as the family should fail")
+	}
+	if err := doc.Unlink("@F9@", "@I1@"); err == nil {
+		t.Error("Unlink() with a missing family should fail")
+	}
+	if err := doc.LinkSpouse("@F1@", "@I9@"); err == nil {
+		t.Error("LinkSpouse() with a missing individual should fail")
+	}
+}
+
+// TestDocument_LinkSpouseKeepsSpouses checks LinkSpouse updates Spouses
+// along with Husband, so the family is not written with two HUSB lines.
+func TestDocument_LinkSpouseKeepsSpouses(t *testing.T) {
+	doc := rawFamilyDocument(t)
+	fam := doc.GetFamily("@F1@")
+	fam.Spouses = []*FamilySpouse{{XRef: "@I1@", Tag: "HUSB"}, {XRef: "@I2@", Tag: "WIFE"}}
+
+	if err := doc.Unlink("@F1@", "@I1@"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.LinkSpouse("@F1@", "@I3@"); err != nil {
+		t.Fatal(err)
+	}
+	if got := fam.Partners(); !reflect.DeepEqual(got, []string{"@I3@", "@I2@"}) {
+		t.Errorf("Partners() = %v, want [@I3@ @I2@]", got)
+	}
+	husbands := 0
+	for _, spouse := range fam.Spouses {
+		if spouse.Tag == "HUSB" {
+			husbands++
+		}
+	}
+	if husbands != 1 {
+		t.Errorf("Spouses has %d HUSB entries, want 1: %+v", husbands, fam.Spouses)
+	}
+}
+
+func TestDocument_RemoveIndividualAndFamily(t *testing.T) {
+	doc := rawFamilyDocument(t)
+	if err := doc.LinkChild("@F1@", "@I3@"); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.RemoveIndividual("@F1@") != nil {
+		t.Error("RemoveIndividual() of a family should return nil")
+	}
+	removed := doc.RemoveIndividual("@I2@")
+	if removed == nil || removed.XRef != "@I2@" || doc.GetRecord("@I2@") != nil {
+		t.Fatalf("RemoveIndividual(@I2@) = %v", removed)
+	}
+	fam := doc.GetRecord("@F1@")
+	if hasLinkTag(fam, "WIFE", "@I2@") || doc.GetFamily("@F1@").Wife != "" {
+		t.Error("RemoveIndividual() should remove the family's WIFE link")
+	}
+
+	if doc.RemoveFamily("@I1@") != nil {
+		t.Error("RemoveFamily() of an individual should return nil")
+	}
+	if removed := doc.RemoveFamily("@F1@"); removed == nil || doc.GetRecord("@F1@") != nil {
+		t.Fatalf("RemoveFamily(@F1@) = %v", removed)
+	}
+	if hasLinkTag(doc.GetRecord("@I1@"), "FAMS", "@F1@") || len(doc.GetIndividual("@I1@").SpouseInFamilies) != 0 {
+		t.Error("RemoveFamily() should remove the FAMS link")
+	}
+	if hasLinkTag(doc.GetRecord("@I3@"), "FAMC", "@F1@") || len(doc.GetIndividual("@I3@").ChildInFamilies) != 0 {
+		t.Error("RemoveFamily() should remove the FAMC link")
+	}
+	if len(doc.Records) != 2 || len(doc.XRefMap) != 2 {
+		t.Errorf("document has %d records, %d XRefs, want 2", len(doc.Records), len(doc.XRefMap))
+	}
+}
+
+func TestDocument_RemoveIndividualPromotesSpouse(t *testing.T) {
+	doc := rawFamilyDocument(t)
+	fam := doc.GetFamily("@F1@")
+	fam.Spouses = []*FamilySpouse{
+		{XRef: "@I1@", Tag: "HUSB"},
+		{XRef: "@I2@", Tag: "WIFE"},
+		{XRef: "@I3@", Tag: "HUSB"},
+	}
+	spouses := fam.Spouses
+
+	doc.RemoveIndividual("@I1@")
+	if fam.Husband != "@I3@" || len(fam.Spouses) != 2 || len(fam.OtherSpouses()) != 0 {
+		t.Errorf("after removing the husband: Husband = %q, Spouses = %v", fam.Husband, fam.Spouses)
+	}
+	if spouses[0].XRef != "@I1@" || spouses[2].XRef != "@I3@" {
+		t.Error("RemoveIndividual() should not rewrite the previous Spouses slice")
+	}
+}
+
+func TestDocument_LinkTouchesRecords(t *testing.T) {
+	defer func(orig func() time.Time) { timeNow = orig }(timeNow)
+	timeNow = func() time.Time { return time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC) }
+
+	doc := &Document{Header: &Header{Version: Version70}}
+	doc.SetAutoTimestamps(true)
+	husband := &Individual{}
+	if _, err := doc.AddIndividual(husband); err != nil {
+		t.Fatal(err)
+	}
+	husband.ChangeDate = nil
+
+	if _, err := doc.AddFamily(&Family{Husband: husband.XRef}); err != nil {
+		t.Fatal(err)
+	}
+	if husband.ChangeDate == nil || husband.ChangeDate.Date != "5 MAR 2024" {
+		t.Errorf("linked individual ChangeDate = %+v, want 5 MAR 2024", husband.ChangeDate)
+	}
+}
+
+// rawFamilyDocument returns a document of decoded-style records, with raw
+// tags and entities: @I1@ and @I2@ married in @F1@, and @I3@ unlinked.
+func rawFamilyDocument(t *testing.T) *Document {
+	t.Helper()
+	doc := &Document{}
+	records := []*Record{
+		{XRef: "@I1@", Type: RecordTypeIndividual, Tags: []*Tag{
+			{Level: 1, Tag: "FAMS", Value: "@F1@"},
+			{Level: 2, Tag: "NOTE", Value: "first marriage"},
+			{Level: 1, Tag: "SEX", Value: "M"},
+		}, Entity: &Individual{XRef: "@I1@", Sex: "M", SpouseInFamilies: []string{"@F1@"}}},
+		{XRef: "@I2@", Type: RecordTypeIndividual, Tags: []*Tag{
+			{Level: 1, Tag: "SEX", Value: "F"},
+			{Level: 1, Tag: "FAMS", Value: "@F1@"},
+		}, Entity: &Individual{XRef: "@I2@", Sex: "F", SpouseInFamilies: []string{"@F1@"}}},
+		{XRef: "@I3@", Type: RecordTypeIndividual, Tags: []*Tag{
+			{Level: 1, Tag: "SEX", Value: "U"},
+		}, Entity: &Individual{XRef: "@I3@", Sex: "U"}},
+		{XRef: "@F1@", Type: RecordTypeFamily, Tags: []*Tag{
+			{Level: 1, Tag: "HUSB", Value: "@I1@"},
+			{Level: 1, Tag: "WIFE", Value: "@I2@"},
+		}, Entity: &Family{XRef: "@F1@", Husband: "@I1@", Wife: "@I2@"}},
+	}
+	for _, record := range records {
+		if err := doc.AddRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return doc
+}
+
+func hasLinkTag(record *Record, tag, xref string) bool {
+	for _, t := range record.Tags {
+		if t.Level == 1 && t.Tag == tag && t.Value == xref {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package gedcom
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Name display patterns for NewNameFormatter. A pattern is made of the
+// component words Prefix, Given, Nickname, Surname, SURNAME (the surname
+// in upper case), and Suffix, joined by spaces and punctuation.
+const (
+	// NameFormatGivenSurname is the usual reading order: "Dr. John Smith Jr.".
+	NameFormatGivenSurname = "Prefix Given Surname Suffix"
+
+	// NameFormatSurnameGiven sorts by surname: "Smith, John Jr.".
+	NameFormatSurnameGiven = "Surname, Given Suffix"
+
+	// NameFormatGivenSURNAME marks the surname in capitals, as is common
+	// in genealogical writing: "John SMITH Jr.".
+	NameFormatGivenSURNAME = "Given SURNAME Suffix"
+
+	// NameFormatFull includes every component: `Dr. John "Jack" Smith Jr.`.
+	NameFormatFull = `Prefix Given "Nickname" Surname Suffix`
+)
+
+// NameFormatter renders personal names for display according to a
+// pattern such as "Surname, Given". Components that are missing are left
+// out together with the spaces and punctuation that separate them, so
+// "Surname, Given" renders a name without a given name as "Smith" rather
+// than "Smith, ". Quotes and brackets written directly against a
+// component, as in `"Nickname"` or "(Nickname)", are kept with it.
+//
+// The zero value formats with NameFormatGivenSurname.
+type NameFormatter struct {
+	// Unknown stands in for a missing given name or surname when the other
+	// is known, e.g. "?" or "[unknown]". If empty, the missing component is
+	// left out.
+	Unknown string
+
+	parts []namePart
+}
+
+// namePart is one component of a pattern with the text around it.
+type namePart struct {
+	separator string // text between the previous component and this one
+	open      string // text attached before the component
+	component string
+	close     string // text attached after the component
+}
+
+// nameComponentPattern finds the component words of a pattern.
+var nameComponentPattern = regexp.MustCompile(`\b(Prefix|Given|Nickname|Surname|SURNAME|Suffix)\b`)
+
+// DefaultNameFormatter formats names with NameFormatGivenSurname.
+var DefaultNameFormatter = NewNameFormatter(NameFormatGivenSurname)
+
+// NewNameFormatter returns a formatter for pattern (see the NameFormat
+// constants). Text in the pattern that is not a component word is kept
+// as literal punctuation.
+func NewNameFormatter(pattern string) *NameFormatter {
+	return &NameFormatter{parts: parseNamePattern(pattern)}
+}
+
+// parseNamePattern splits a pattern into components and the text between
+// them. Spaces, commas, and semicolons separate components; any other
+// text touching a component is attached to it.
+func parseNamePattern(pattern string) []namePart {
+	matches := nameComponentPattern.FindAllStringSubmatchIndex(pattern, -1)
+	parts := make([]namePart, len(matches))
+	for i, m := range matches {
+		parts[i].component = pattern[m[2]:m[3]]
+		if i == 0 {
+			parts[i].open = pattern[:m[0]]
+			continue
+		}
+		between := pattern[matches[i-1][1]:m[0]]
+		first := strings.IndexAny(between, nameSeparators)
+		if first < 0 {
+			parts[i-1].close = between
+			continue
+		}
+		last := strings.LastIndexAny(between, nameSeparators)
+		parts[i-1].close = between[:first]
+		parts[i].separator = between[first : last+1]
+		parts[i].open = between[last+1:]
+	}
+	if n := len(parts); n > 0 {
+		parts[n-1].close += pattern[matches[n-1][1]:]
+	}
+	return parts
+}
+
+// nameSeparators are the characters that separate name components.
+const nameSeparators = " ,;"
+
+// Format renders name. It returns "" for a nil name or one with no
+// components.
+func (f *NameFormatter) Format(name *PersonalName) string {
+	if name == nil {
+		return ""
+	}
+	parts := f.parts
+	if parts == nil {
+		parts = DefaultNameFormatter.parts
+	}
+	values := nameComponents(name)
+	if f.Unknown != "" && (values["Given"] == "") != (values["Surname"] == "") {
+		if values["Given"] == "" {
+			values["Given"] = f.Unknown
+		} else {
+			values["Surname"] = f.Unknown
+		}
+	}
+	values["SURNAME"] = strings.ToUpper(values["Surname"])
+	if values["SURNAME"] != "" && name.SurnamePrefix != "" {
+		values["SURNAME"] = name.SurnamePrefix + " " + strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(values["Surname"], name.SurnamePrefix)))
+	}
+
+	var b strings.Builder
+	for _, p := range parts {
+		value := values[p.component]
+		if value == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(p.separator)
+		}
+		b.WriteString(p.open)
+		b.WriteString(value)
+		b.WriteString(p.close)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// FormatIndividual renders the first name of ind that has any components,
+// or returns ind's XRef if it has none.
+func (f *NameFormatter) FormatIndividual(ind *Individual) string {
+	if ind == nil {
+		return ""
+	}
+	for _, name := range ind.Names {
+		if s := f.Format(name); s != "" {
+			return s
+		}
+	}
+	return ind.XRef
+}
+
+// fullNameParts splits a GEDCOM name value "Given /Surname/ Suffix".
+var fullNameParts = regexp.MustCompile(`^([^/]*)(?:/([^/]*)/?(.*))?$`)
+
+// nameComponents returns the components of name, taking the given name,
+// surname, and suffix from the full name value when the name has no
+// separate GIVN, SURN, or NSFX.
+func nameComponents(name *PersonalName) map[string]string {
+	var given, surname, suffix string
+	if m := fullNameParts.FindStringSubmatch(name.Full); m != nil {
+		given, surname, suffix = m[1], m[2], m[3]
+		// A prefix written in the name value is not part of the given name.
+		if name.Prefix != "" {
+			given = strings.TrimPrefix(strings.TrimSpace(given), name.Prefix)
+		}
+	}
+	if name.Given != "" {
+		given = name.Given
+	}
+	if name.Surname != "" {
+		surname = name.Surname
+		if name.SurnamePrefix != "" && !strings.HasPrefix(surname, name.SurnamePrefix) {
+			surname = name.SurnamePrefix + " " + surname
+		}
+	}
+	if name.Suffix != "" {
+		suffix = name.Suffix
+	}
+	clean := func(s string) string { return strings.Join(strings.Fields(s), " ") }
+	return map[string]string{
+		"Prefix":   clean(name.Prefix),
+		"Given":    clean(given),
+		"Nickname": clean(name.Nickname),
+		"Surname":  clean(surname),
+		"Suffix":   clean(suffix),
+	}
+}
@@ -0,0 +1,81 @@
+package gedcom
+
+import "testing"
+
+func TestNameFormatter_Format(t *testing.T) {
+	full := &PersonalName{
+		Full:     "Dr. John /Smith/ Jr.",
+		Given:    "John",
+		Surname:  "Smith",
+		Prefix:   "Dr.",
+		Suffix:   "Jr.",
+		Nickname: "Jack",
+	}
+	tests := []struct {
+		name    string
+		pattern string
+		unknown string
+		pn      *PersonalName
+		want    string
+	}{
+		{"given surname", NameFormatGivenSurname, "", full, "Dr. John Smith Jr."},
+		{"surname given", NameFormatSurnameGiven, "", full, "Smith, John Jr."},
+		{"given SURNAME", NameFormatGivenSURNAME, "", full, "John SMITH Jr."},
+		{"full with nickname", NameFormatFull, "", full, `Dr. John "Jack" Smith Jr.`},
+		{"brackets", "Given (Nickname) Surname", "", full, "John (Jack) Smith"},
+		{"missing nickname drops quotes", NameFormatFull, "", &PersonalName{Full: "John /Smith/"}, "John Smith"},
+		{"value only", NameFormatSurnameGiven, "", &PersonalName{Full: "Mary Ann /Jones/"}, "Jones, Mary Ann"},
+		{"missing given", NameFormatSurnameGiven, "", &PersonalName{Full: "/Jones/"}, "Jones"},
+		{"missing surname", NameFormatSurnameGiven, "", &PersonalName{Full: "Mary"}, "Mary"},
+		{"unknown given", NameFormatSurnameGiven, "?", &PersonalName{Full: "/Jones/"}, "Jones, ?"},
+		{"unknown surname", NameFormatGivenSURNAME, "[unknown]", &PersonalName{Full: "Mary //"}, "Mary [UNKNOWN]"},
+		{"no components", NameFormatSurnameGiven, "?", &PersonalName{Full: "//"}, ""},
+		{"prefix in value", NameFormatGivenSurname, "", &PersonalName{Full: "Rev. Thomas /Hall/", Prefix: "Rev."}, "Rev. Thomas Hall"},
+		{
+			"surname prefix",
+			NameFormatGivenSURNAME, "",
+			&PersonalName{Full: "Jan /van der Berg/", Given: "Jan", Surname: "Berg", SurnamePrefix: "van der"},
+			"Jan van der BERG",
+		},
+		{
+			"surname prefix sorted",
+			NameFormatSurnameGiven, "",
+			&PersonalName{Given: "Jan", Surname: "Berg", SurnamePrefix: "van der"},
+			"van der Berg, Jan",
+		},
+		{"nil name", NameFormatGivenSurname, "", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewNameFormatter(tt.pattern)
+			f.Unknown = tt.unknown
+			if got := f.Format(tt.pn); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNameFormatter_ZeroValue(t *testing.T) {
+	var f NameFormatter
+	if got := f.Format(&PersonalName{Full: "John /Smith/"}); got != "John Smith" {
+		t.Errorf("Format() = %q, want %q", got, "John Smith")
+	}
+}
+
+func TestNameFormatter_FormatIndividual(t *testing.T) {
+	f := NewNameFormatter(NameFormatSurnameGiven)
+	ind := &Individual{
+		XRef:  "@I1@",
+		Names: []*PersonalName{nil, {Full: "//"}, {Full: "John /Smith/"}},
+	}
+	if got := f.FormatIndividual(ind); got != "Smith, John" {
+		t.Errorf("FormatIndividual() = %q, want %q", got, "Smith, John")
+	}
+	if got := f.FormatIndividual(&Individual{XRef: "@I2@"}); got != "@I2@" {
+		t.Errorf("FormatIndividual() without names = %q, want XRef", got)
+	}
+	if got := f.FormatIndividual(nil); got != "" {
+		t.Errorf("FormatIndividual(nil) = %q, want empty", got)
+	}
+}
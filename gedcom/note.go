@@ -11,6 +11,10 @@ type Note struct {
 	// Continuation lines for multi-line notes
 	Continuation []string
 
+	// AutomatedRecordID is the record ID assigned by the application that
+	// produced the file (RIN tag, GEDCOM 5.5.x)
+	AutomatedRecordID string
+
 	// ExternalIDs are external identifiers (EXID tags, GEDCOM 7.0).
 	// Links this record to external systems like FamilySearch, Ancestry, etc.
 	ExternalIDs []*ExternalID
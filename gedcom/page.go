@@ -0,0 +1,82 @@
+package gedcom
+
+// IndividualsPage returns at most limit individuals starting at offset in
+// the given order, and the total number of individuals, for paging a list
+// without copying the whole collection. A limit of zero or less means no
+// limit; an offset past the end returns no individuals.
+//
+// On an indexed document (see Reindex), file-order pages are sliced from
+// the per-type index and sorted orders are computed once and cached until
+// the next AddRecord, RemoveRecord, or Reindex. Call Reindex after editing
+// the names or XRefs the order depends on.
+func (d *Document) IndividualsPage(offset, limit int, order SortOrder) ([]*Individual, int) {
+	records, total := d.recordPage(RecordTypeIndividual, offset, limit, order)
+	individuals := make([]*Individual, 0, len(records))
+	for _, record := range records {
+		if ind, ok := record.GetIndividual(); ok {
+			individuals = append(individuals, ind)
+		}
+	}
+	return individuals, total
+}
+
+// FamiliesPage returns at most limit families starting at offset in the
+// given order, and the total number of families. See IndividualsPage.
+func (d *Document) FamiliesPage(offset, limit int, order SortOrder) ([]*Family, int) {
+	records, total := d.recordPage(RecordTypeFamily, offset, limit, order)
+	families := make([]*Family, 0, len(records))
+	for _, record := range records {
+		if fam, ok := record.GetFamily(); ok {
+			families = append(families, fam)
+		}
+	}
+	return families, total
+}
+
+// SourcesPage returns at most limit sources starting at offset in the
+// given order, and the total number of sources. See IndividualsPage.
+func (d *Document) SourcesPage(offset, limit int, order SortOrder) ([]*Source, int) {
+	records, total := d.recordPage(RecordTypeSource, offset, limit, order)
+	sources := make([]*Source, 0, len(records))
+	for _, record := range records {
+		if src, ok := record.GetSource(); ok {
+			sources = append(sources, src)
+		}
+	}
+	return sources, total
+}
+
+// recordPage returns a copy of one page of the records of type t, and the
+// number of such records.
+func (d *Document) recordPage(t RecordType, offset, limit int, order SortOrder) ([]*Record, int) {
+	if order == SortByXRef || order == SortByName {
+		return pageOf(d.sortedRecordsOfType(t, []SortOrder{order}), offset, limit)
+	}
+
+	// File order: slice the index under the read lock rather than copying
+	// the whole listing as recordsOfType does.
+	unlock := d.rlock()
+	if d.current() {
+		defer unlock()
+		return pageOf(d.index.byType[t], offset, limit)
+	}
+	unlock()
+	return pageOf(d.recordsOfType(t), offset, limit)
+}
+
+// pageOf returns a copy of records[offset:offset+limit], clamped to the
+// slice, and len(records).
+func pageOf(records []*Record, offset, limit int) ([]*Record, int) {
+	total := len(records)
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= total {
+		return nil, total
+	}
+	end := total
+	if limit > 0 && limit < total-offset {
+		end = offset + limit
+	}
+	return append([]*Record(nil), records[offset:end]...), total
+}
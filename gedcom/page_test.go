@@ -0,0 +1,104 @@
+package gedcom
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestDocument_IndividualsPage(t *testing.T) {
+	doc := sortTestDocument()
+	tests := []struct {
+		offset, limit int
+		order         SortOrder
+		want          []string
+	}{
+		{0, 2, SortFileOrder, []string{"@I10@", "@I2@"}},
+		{2, 2, SortFileOrder, []string{"@I1@", "@I3@"}},
+		{3, 5, SortFileOrder, []string{"@I3@"}},
+		{0, 0, SortFileOrder, []string{"@I10@", "@I2@", "@I1@", "@I3@"}},
+		{-1, 1, SortFileOrder, []string{"@I10@"}},
+		{4, 2, SortFileOrder, nil},
+		{1, math.MaxInt, SortFileOrder, []string{"@I2@", "@I1@", "@I3@"}},
+		{1, 2, SortByXRef, []string{"@I2@", "@I3@"}},
+		{0, 3, SortByName, []string{"@I1@", "@I10@", "@I3@"}},
+	}
+	for _, tt := range tests {
+		page, total := doc.IndividualsPage(tt.offset, tt.limit, tt.order)
+		if got := individualXRefs(page); !reflect.DeepEqual(got, tt.want) || total != 4 {
+			t.Errorf("IndividualsPage(%d, %d, %s) = %v, %d; want %v, 4", tt.offset, tt.limit, tt.order, got, total, tt.want)
+		}
+	}
+
+	families, total := doc.FamiliesPage(0, 1, SortByXRef)
+	if total != 3 || len(families) != 1 || families[0].XRef != "@F1@" {
+		t.Errorf("FamiliesPage() = %v, %d", families, total)
+	}
+	sources, total := doc.SourcesPage(1, 1, SortByName)
+	if total != 2 || len(sources) != 1 || sources[0].XRef != "@S2@" {
+		t.Errorf("SourcesPage() = %v, %d", sources, total)
+	}
+}
+
+func TestDocument_PageCacheInvalidation(t *testing.T) {
+	doc := sortTestDocument()
+	if page, _ := doc.IndividualsPage(0, 1, SortByXRef); page[0].XRef != "@I1@" {
+		t.Fatalf("first page = %v", individualXRefs(page))
+	}
+
+	if err := doc.AddRecord(&Record{XRef: "@I0@", Type: RecordTypeIndividual, Entity: &Individual{XRef: "@I0@"}}); err != nil {
+		t.Fatal(err)
+	}
+	page, total := doc.IndividualsPage(0, 1, SortByXRef)
+	if total != 5 || page[0].XRef != "@I0@" {
+		t.Errorf("after AddRecord, page = %v, total %d", individualXRefs(page), total)
+	}
+
+	doc.RemoveRecord("@I0@")
+	if page, total := doc.IndividualsPage(0, 1, SortByXRef); total != 4 || page[0].XRef != "@I1@" {
+		t.Errorf("after RemoveRecord, page = %v, total %d", individualXRefs(page), total)
+	}
+
+	// Direct changes to Records bypass the cache until Reindex.
+	doc.Records = append(doc.Records, &Record{XRef: "@I00@", Type: RecordTypeIndividual, Entity: &Individual{XRef: "@I00@"}})
+	if _, total := doc.IndividualsPage(0, 1, SortByXRef); total != 5 {
+		t.Errorf("after a direct append, total = %d, want 5", total)
+	}
+}
+
+func TestDocument_PagesConcurrent(t *testing.T) {
+	doc := &Document{}
+	doc.SetLockMode(LockRW)
+	for i := 0; i < 50; i++ {
+		xref := fmt.Sprintf("@I%d@", i)
+		if err := doc.AddRecord(&Record{XRef: xref, Type: RecordTypeIndividual, Entity: &Individual{XRef: xref}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				doc.IndividualsPage(i, 10, SortByXRef)
+				doc.IndividualsPage(i, 10, SortFileOrder)
+			}
+		}()
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				xref := fmt.Sprintf("@N%d_%d@", g, i)
+				_ = doc.AddRecord(&Record{XRef: xref, Type: RecordTypeIndividual, Entity: &Individual{XRef: xref}})
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if page, total := doc.IndividualsPage(0, 0, SortByXRef); total != 130 || len(page) != 130 {
+		t.Errorf("IndividualsPage() after concurrent adds = %d of %d, want 130", len(page), total)
+	}
+}
@@ -0,0 +1,61 @@
+package gedcom
+
+import "strings"
+
+// Pedigree is a PEDI value as written in the file: lowercase in GEDCOM 5.5
+// and 5.5.1 ("birth"), uppercase in 7.0 ("BIRTH"). The original text is
+// kept so documents round-trip; switch on Canonical rather than on the
+// value itself.
+type Pedigree string
+
+// Standard pedigree linkage types, in their canonical (GEDCOM 7.0) form.
+const (
+	// PedigreeBirth links a child to its birth parents.
+	PedigreeBirth Pedigree = "BIRTH"
+
+	// PedigreeAdopted links a child to its adoptive parents.
+	PedigreeAdopted Pedigree = "ADOPTED"
+
+	// PedigreeFoster links a child to its foster parents.
+	PedigreeFoster Pedigree = "FOSTER"
+
+	// PedigreeSealing links a child to parents it was sealed to (LDS).
+	PedigreeSealing Pedigree = "SEALING"
+
+	// PedigreeOther is any other relationship; GEDCOM 7.0 describes it
+	// with a PHRASE.
+	PedigreeOther Pedigree = "OTHER"
+)
+
+// ParsePedigree returns the canonical form of a PEDI value, in any case:
+// one of the Pedigree constants, PedigreeOther for unrecognized values,
+// or "" for an empty value.
+func ParsePedigree(value string) Pedigree {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+	switch p := Pedigree(strings.ToUpper(value)); p {
+	case PedigreeBirth, PedigreeAdopted, PedigreeFoster, PedigreeSealing:
+		return p
+	}
+	return PedigreeOther
+}
+
+// String returns the value as written.
+func (p Pedigree) String() string { return string(p) }
+
+// Canonical returns ParsePedigree of the value.
+//
+//	switch link.Pedigree.Canonical() {
+//	case gedcom.PedigreeAdopted, gedcom.PedigreeFoster:
+//	    ...
+//	}
+func (p Pedigree) Canonical() Pedigree { return ParsePedigree(string(p)) }
+
+// IsBirth reports whether the value is a birth link. An empty value counts
+// as birth, the GEDCOM default when PEDI is omitted.
+func (p Pedigree) IsBirth() bool {
+	c := p.Canonical()
+	return c == "" || c == PedigreeBirth
+}
@@ -0,0 +1,38 @@
+package gedcom
+
+import "testing"
+
+func TestParsePedigree(t *testing.T) {
+	for value, want := range map[string]Pedigree{
+		"":         "",
+		"birth":    PedigreeBirth,
+		"BIRTH":    PedigreeBirth,
+		" Adopted": PedigreeAdopted,
+		"foster":   PedigreeFoster,
+		"SEALING":  PedigreeSealing,
+		"OTHER":    PedigreeOther,
+		"step":     PedigreeOther,
+	} {
+		if got := ParsePedigree(value); got != want {
+			t.Errorf("ParsePedigree(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestPedigree_Methods(t *testing.T) {
+	p := Pedigree("adopted")
+	if p.String() != "adopted" || p.Canonical() != PedigreeAdopted {
+		t.Errorf("String() = %q, Canonical() = %q", p, p.Canonical())
+	}
+	for p, want := range map[Pedigree]bool{
+		"":        true,
+		"birth":   true,
+		"BIRTH":   true,
+		"adopted": false,
+		"unknown": false,
+	} {
+		if got := p.IsBirth(); got != want {
+			t.Errorf("Pedigree(%q).IsBirth() = %v, want %v", p, got, want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package gedcom
+
+import (
+	"sort"
+	"strings"
+)
+
+// EventAtPlace is an event together with the record that owns it.
+type EventAtPlace struct {
+	// RecordXRef is the XRef of the individual or family owning the event.
+	RecordXRef string
+
+	// Event is the event itself.
+	Event *Event
+}
+
+// PlaceIndex indexes the individual and family events of a document by
+// normalized place name. Build one with NewPlaceIndex when running many
+// lookups against the same document; the index does not follow later
+// changes to the document.
+type PlaceIndex struct {
+	entries    []EventAtPlace
+	keys       [][]string
+	byPlace    map[string][]int
+	byLocality map[string][]int
+}
+
+// NewPlaceIndex indexes the events of doc that carry a place.
+func NewPlaceIndex(doc *Document) *PlaceIndex {
+	ix := &PlaceIndex{
+		byPlace:    make(map[string][]int),
+		byLocality: make(map[string][]int),
+	}
+	if doc == nil {
+		return ix
+	}
+	add := func(xref string, events []*Event) {
+		for _, event := range events {
+			if event == nil {
+				continue
+			}
+			name := event.Place
+			if name == "" && event.PlaceDetail != nil {
+				name = event.PlaceDetail.Name
+			}
+			key := placeComponents(name)
+			if len(key) == 0 {
+				continue
+			}
+			i := len(ix.entries)
+			ix.entries = append(ix.entries, EventAtPlace{RecordXRef: xref, Event: event})
+			ix.keys = append(ix.keys, key)
+			full := strings.Join(key, ",")
+			ix.byPlace[full] = append(ix.byPlace[full], i)
+			ix.byLocality[key[0]] = append(ix.byLocality[key[0]], i)
+		}
+	}
+	for _, record := range doc.Records {
+		if ind, ok := record.GetIndividual(); ok {
+			add(ind.XRef, ind.Events)
+		} else if fam, ok := record.GetFamily(); ok {
+			add(fam.XRef, fam.Events)
+		}
+	}
+	return ix
+}
+
+// Lookup returns the indexed events at place, in document order.
+//
+// Place names are compared component by component, ignoring case, periods,
+// and surrounding whitespace. Without fuzzy, every component must match.
+// With fuzzy, the first component (the locality) must match and the
+// remaining jurisdictions of the less specific name must appear, in order,
+// among those of the more specific one: "Ballyduff, Ireland" finds events
+// in "Ballyduff, Kerry, Ireland", and "Ballyduff" alone finds every
+// Ballyduff. Abbreviations are not expanded (see the place package for
+// variant clustering).
+func (ix *PlaceIndex) Lookup(place string, fuzzy bool) []EventAtPlace {
+	if ix == nil {
+		return nil
+	}
+	key := placeComponents(place)
+	if len(key) == 0 {
+		return nil
+	}
+	var matches []int
+	if !fuzzy {
+		matches = ix.byPlace[strings.Join(key, ",")]
+	} else {
+		for _, i := range ix.byLocality[key[0]] {
+			if jurisdictionsCompatible(key[1:], ix.keys[i][1:]) {
+				matches = append(matches, i)
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+	sort.Ints(matches)
+	result := make([]EventAtPlace, len(matches))
+	for n, i := range matches {
+		result[n] = ix.entries[i]
+	}
+	return result
+}
+
+// EventsAtPlace returns the individual and family events that occurred at
+// place, each with the XRef of its owning record, in document order. See
+// PlaceIndex.Lookup for how names are matched. Each call builds a new
+// PlaceIndex; use NewPlaceIndex directly for repeated lookups.
+func (d *Document) EventsAtPlace(place string, fuzzy bool) []EventAtPlace {
+	return NewPlaceIndex(d).Lookup(place, fuzzy)
+}
+
+// placeComponents splits a place name on commas and normalizes each
+// component for comparison. Empty jurisdiction components are dropped;
+// an empty locality yields nil.
+func placeComponents(place string) []string {
+	var key []string
+	for i, part := range strings.Split(place, ",") {
+		part = strings.ToLower(strings.ReplaceAll(part, ".", ""))
+		part = strings.Join(strings.Fields(part), " ")
+		if part == "" {
+			if i == 0 {
+				return nil
+			}
+			continue
+		}
+		key = append(key, part)
+	}
+	return key
+}
+
+// jurisdictionsCompatible reports whether the shorter of a and b appears
+// in the longer, in order but not necessarily contiguously.
+func jurisdictionsCompatible(a, b []string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	i := 0
+	for _, s := range b {
+		if i < len(a) && a[i] == s {
+			i++
+		}
+	}
+	return i == len(a)
+}
@@ -0,0 +1,66 @@
+package gedcom
+
+import "testing"
+
+func placeIndexDocument() *Document {
+	ind1 := &Individual{XRef: "@I1@", Events: []*Event{
+		{Type: EventBirth, Place: "Ballyduff, Kerry, Ireland"},
+		{Type: EventDeath, Place: "Boston, Suffolk, Massachusetts, USA"},
+	}}
+	ind2 := &Individual{XRef: "@I2@", Events: []*Event{
+		{Type: EventBaptism, PlaceDetail: &PlaceDetail{Name: "ballyduff,  kerry, ireland"}},
+		{Type: EventResidence, Place: "Ballyduff, Waterford, Ireland"},
+		{Type: EventBurial},
+	}}
+	fam := &Family{XRef: "@F1@", Events: []*Event{
+		{Type: EventMarriage, Place: "Ballyduff, Ireland"},
+	}}
+	return &Document{Records: []*Record{
+		{XRef: "@I1@", Type: RecordTypeIndividual, Entity: ind1},
+		{XRef: "@I2@", Type: RecordTypeIndividual, Entity: ind2},
+		{XRef: "@F1@", Type: RecordTypeFamily, Entity: fam},
+	}}
+}
+
+func TestDocument_EventsAtPlace(t *testing.T) {
+	doc := placeIndexDocument()
+	tests := []struct {
+		place string
+		fuzzy bool
+		want  []string
+	}{
+		{"Ballyduff, Kerry, Ireland", false, []string{"@I1@ BIRT", "@I2@ BAPM"}},
+		{"Ballyduff, Ireland", false, []string{"@F1@ MARR"}},
+		{"Ballyduff, Ireland", true, []string{"@I1@ BIRT", "@I2@ BAPM", "@I2@ RESI", "@F1@ MARR"}},
+		{"Ballyduff, Kerry", true, []string{"@I1@ BIRT", "@I2@ BAPM"}},
+		{"Ballyduff", true, []string{"@I1@ BIRT", "@I2@ BAPM", "@I2@ RESI", "@F1@ MARR"}},
+		{"Boston, Mass.", true, nil},
+		{"", true, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, e := range doc.EventsAtPlace(tt.place, tt.fuzzy) {
+			got = append(got, e.RecordXRef+" "+string(e.Event.Type))
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("EventsAtPlace(%q, %v) = %v, want %v", tt.place, tt.fuzzy, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("EventsAtPlace(%q, %v) = %v, want %v", tt.place, tt.fuzzy, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestPlaceIndex_Nil(t *testing.T) {
+	var ix *PlaceIndex
+	if ix.Lookup("Boston", true) != nil {
+		t.Error("nil index: expected nil")
+	}
+	if NewPlaceIndex(nil).Lookup("Boston", false) != nil {
+		t.Error("nil document: expected nil")
+	}
+}
@@ -47,6 +47,10 @@ type Record struct {
 	// LineNumber is the line number where the record starts
 	LineNumber int
 
+	// Trivia holds the blank and comment lines within and before the
+	// record when the decoder preserves formatting.
+	Trivia []Trivia
+
 	// Parsed entity (one of: Individual, Family, Source, Repository, Note, MediaObject)
 	// Will be populated during decoding based on the Type
 	Entity interface{}
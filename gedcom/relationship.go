@@ -0,0 +1,265 @@
+package gedcom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Relationship describes how two individuals are related by descent: the
+// nearest ancestors they share and the number of generations from each of
+// them up to those ancestors.
+type Relationship struct {
+	// CommonAncestors are the XRefs of the nearest common ancestors, usually
+	// a couple, or a single individual for half relationships. When one
+	// individual descends from the other, it is that individual.
+	CommonAncestors []string
+
+	// Generations1 and Generations2 are the generations from the first and
+	// second individual up to the common ancestors: 1 for a parent, 2 for a
+	// grandparent, 0 for the ancestor itself.
+	Generations1 int
+	Generations2 int
+
+	// Degree is the cousin degree (1 for first cousins) and Removed the
+	// number of generations between them; both are 0 unless the two are
+	// cousins.
+	Degree  int
+	Removed int
+
+	// Half reports that the two descend from different families of the
+	// common ancestor, so they share one ancestor rather than a couple
+	// (half-siblings, half first cousins).
+	Half bool
+
+	// Description names what the second individual is to the first, such
+	// as "great-grandfather", "sister", or "2nd cousin once removed".
+	// Gendered terms follow the second individual's SEX.
+	Description string
+}
+
+// String returns the Description.
+func (r *Relationship) String() string {
+	return r.Description
+}
+
+// Relationship computes how the individual identified by xref2 is related
+// to the one identified by xref1, by finding their nearest common
+// ancestors through ChildInFamilies links: the common ancestor with the
+// fewest generations to both, ties broken by the first individual's
+// Ancestors order. Relationships by marriage (in-laws, step-relations) are
+// not considered.
+//
+// It returns nil if either XRef is not an individual of the document or
+// the two share no ancestor.
+func (d *Document) Relationship(xref1, xref2 string) *Relationship {
+	if d.navigationSeed(xref1) == nil || d.navigationSeed(xref2) == nil {
+		return nil
+	}
+
+	lines1 := d.ancestorLines(xref1)
+	lines2 := d.ancestorLines(xref2)
+	anchor := lines1.nearestCommon(lines2)
+	if anchor == "" {
+		return nil
+	}
+
+	r := &Relationship{
+		Generations1: lines1.individuals[anchor],
+		Generations2: lines2.individuals[anchor],
+	}
+	for _, x := range lines1.order {
+		if g2, ok := lines2.individuals[x]; ok && g2 == r.Generations2 && lines1.individuals[x] == r.Generations1 {
+			r.CommonAncestors = append(r.CommonAncestors, x)
+		}
+	}
+	if r.Generations1 > 0 && r.Generations2 > 0 {
+		r.Half = !lines1.shareFamily(lines2, r.Generations1, r.Generations2)
+	}
+	if r.Generations1 >= 2 && r.Generations2 >= 2 {
+		r.Degree = min(r.Generations1, r.Generations2) - 1
+		r.Removed = r.Generations1 - r.Generations2
+		if r.Removed < 0 {
+			r.Removed = -r.Removed
+		}
+	}
+
+	sex := ""
+	if ind := d.GetIndividual(xref2); ind != nil {
+		sex = ind.Sex
+	}
+	r.Description = describeRelationship(r, sex)
+	return r
+}
+
+// ancestorLines holds the generation of every ancestor of an individual,
+// the individual itself at 0, and of every family those ancestors were
+// children of, numbered by the generation of its parents.
+type ancestorLines struct {
+	individuals map[string]int
+	families    map[string]int
+	order       []string // individuals in BFS order
+}
+
+// nearestCommon returns the ancestor in both l and other with the fewest
+// generations to both, ties broken by l's order, or "" if there is none.
+func (l *ancestorLines) nearestCommon(other *ancestorLines) string {
+	anchor, bestSum := "", -1
+	for _, x := range l.order {
+		g2, ok := other.individuals[x]
+		if !ok {
+			continue
+		}
+		if sum := l.individuals[x] + g2; bestSum < 0 || sum < bestSum {
+			anchor, bestSum = x, sum
+		}
+	}
+	return anchor
+}
+
+// shareFamily reports whether a family is in both l, at generation g1, and
+// other, at generation g2: whether the common ancestors are a couple
+// rather than one parent.
+func (l *ancestorLines) shareFamily(other *ancestorLines, g1, g2 int) bool {
+	for fam, mine := range l.families {
+		if theirs, ok := other.families[fam]; ok && mine == g1 && theirs == g2 {
+			return true
+		}
+	}
+	return false
+}
+
+// ancestorLines walks the ancestors of xref breadth-first, so each
+// ancestor is recorded at its nearest generation.
+func (d *Document) ancestorLines(xref string) *ancestorLines {
+	lines := &ancestorLines{
+		individuals: map[string]int{xref: 0},
+		families:    map[string]int{},
+		order:       []string{xref},
+	}
+	for head := 0; head < len(lines.order); head++ {
+		x := lines.order[head]
+		ind := d.GetIndividual(x)
+		if ind == nil {
+			continue
+		}
+		generation := lines.individuals[x] + 1
+		for _, link := range ind.ChildInFamilies {
+			fam := d.GetFamily(link.FamilyXRef)
+			if fam == nil {
+				continue
+			}
+			if _, seen := lines.families[link.FamilyXRef]; !seen {
+				lines.families[link.FamilyXRef] = generation
+			}
+			for _, parent := range []string{fam.Husband, fam.Wife} {
+				if parent == "" || d.GetIndividual(parent) == nil {
+					continue
+				}
+				if _, seen := lines.individuals[parent]; seen {
+					continue
+				}
+				lines.individuals[parent] = generation
+				lines.order = append(lines.order, parent)
+			}
+		}
+	}
+	return lines
+}
+
+// describeRelationship names r from the second individual's point of
+// view, using sex for gendered terms.
+func describeRelationship(r *Relationship, sex string) string {
+	up, down := r.Generations1, r.Generations2
+	switch {
+	case up == 0 && down == 0:
+		return "self"
+	case down == 0:
+		return lineal(up, gendered(sex, "father", "mother", "parent"))
+	case up == 0:
+		return lineal(down, gendered(sex, "son", "daughter", "child"))
+	case up == 1 && down == 1:
+		return halfOf(r, gendered(sex, "brother", "sister", "sibling"))
+	case down == 1:
+		return halfOf(r, greats(up-2)+gendered(sex, "uncle", "aunt", "aunt/uncle"))
+	case up == 1:
+		return halfOf(r, greats(down-2)+gendered(sex, "nephew", "niece", "niece/nephew"))
+	}
+
+	desc := ordinal(r.Degree) + " cousin"
+	if r.Half {
+		desc = "half " + desc
+	}
+	switch r.Removed {
+	case 0:
+	case 1:
+		desc += " once removed"
+	case 2:
+		desc += " twice removed"
+	default:
+		desc += fmt.Sprintf(" %d times removed", r.Removed)
+	}
+	return desc
+}
+
+// halfOf prefixes term with "half-" for half relationships, or "half "
+// before a compound term such as "great-uncle".
+func halfOf(r *Relationship, term string) string {
+	switch {
+	case !r.Half:
+		return term
+	case strings.Contains(term, "-") || strings.Contains(term, " "):
+		return "half " + term
+	default:
+		return "half-" + term
+	}
+}
+
+// lineal names a direct ancestor or descendant the given number of
+// generations away: "father", "grandfather", "great-grandfather", ...
+func lineal(generations int, term string) string {
+	if generations == 1 {
+		return term
+	}
+	return greats(generations-2) + "grand" + term
+}
+
+// greats returns the "great-" prefix repeated n times, or as an ordinal
+// ("3rd great-") from three on.
+func greats(n int) string {
+	switch {
+	case n <= 0:
+		return ""
+	case n <= 2:
+		return strings.Repeat("great-", n)
+	default:
+		return ordinal(n) + " great-"
+	}
+}
+
+// gendered picks the male, female, or neutral term for sex.
+func gendered(sex, male, female, neutral string) string {
+	switch strings.ToUpper(sex) {
+	case "M":
+		return male
+	case "F":
+		return female
+	default:
+		return neutral
+	}
+}
+
+// ordinal returns n with its English ordinal suffix: 1st, 2nd, 11th, 23rd.
+func ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
@@ -0,0 +1,151 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+)
+
+// buildRelationshipFixture extends buildGenealogyFixture with a third
+// generation of cousins:
+//
+//	@I7@ (uncle) + @I11@ -> @F4@, child @I10@ (1st cousin of @I5@)
+//	@I5@ + @I13@ -> @F5@, child @I12@
+func buildRelationshipFixture() *Document {
+	doc := buildGenealogyFixture()
+	add := func(xref, sex string, fams []string, famc string) {
+		ind := &Individual{XRef: xref, Sex: sex, SpouseInFamilies: fams}
+		if famc != "" {
+			ind.ChildInFamilies = []FamilyLink{{FamilyXRef: famc}}
+		}
+		rec := &Record{XRef: xref, Type: RecordTypeIndividual, Entity: ind}
+		doc.Records = append(doc.Records, rec)
+		doc.XRefMap[xref] = rec
+	}
+	addFamily := func(xref, husband, wife string, children ...string) {
+		rec := &Record{XRef: xref, Type: RecordTypeFamily, Entity: &Family{XRef: xref, Husband: husband, Wife: wife, Children: children}}
+		doc.Records = append(doc.Records, rec)
+		doc.XRefMap[xref] = rec
+	}
+
+	doc.GetIndividual("@I1@").Sex = "M"
+	doc.GetIndividual("@I6@").Sex = "F"
+	doc.GetIndividual("@I7@").Sex = "M"
+	doc.GetIndividual("@I7@").SpouseInFamilies = []string{"@F4@"}
+	doc.GetIndividual("@I5@").SpouseInFamilies = []string{"@F5@"}
+	add("@I10@", "F", nil, "@F4@")
+	add("@I11@", "F", []string{"@F4@"}, "")
+	add("@I12@", "M", nil, "@F5@")
+	add("@I13@", "F", []string{"@F5@"}, "")
+	addFamily("@F4@", "@I7@", "@I11@", "@I10@")
+	addFamily("@F5@", "@I5@", "@I13@", "@I12@")
+	return doc
+}
+
+func TestDocument_Relationship(t *testing.T) {
+	doc := buildRelationshipFixture()
+	tests := []struct {
+		xref1, xref2 string
+		want         string
+	}{
+		{"@I5@", "@I5@", "self"},
+		{"@I5@", "@I3@", "parent"},
+		{"@I5@", "@I1@", "grandfather"},
+		{"@I12@", "@I1@", "great-grandfather"},
+		{"@I1@", "@I12@", "great-grandson"},
+		{"@I3@", "@I5@", "child"},
+		{"@I5@", "@I6@", "sister"},
+		{"@I5@", "@I9@", "half-sibling"},
+		{"@I5@", "@I7@", "uncle"},
+		{"@I12@", "@I7@", "great-uncle"},
+		{"@I7@", "@I5@", "niece/nephew"},
+		{"@I7@", "@I12@", "great-nephew"},
+		{"@I5@", "@I10@", "1st cousin"},
+		{"@I10@", "@I12@", "1st cousin once removed"},
+		{"@I9@", "@I10@", "1st cousin"},
+	}
+	for _, tt := range tests {
+		r := doc.Relationship(tt.xref1, tt.xref2)
+		if r == nil {
+			t.Errorf("Relationship(%s, %s) = nil, want %q", tt.xref1, tt.xref2, tt.want)
+			continue
+		}
+		if r.String() != tt.want {
+			t.Errorf("Relationship(%s, %s) = %q, want %q", tt.xref1, tt.xref2, r, tt.want)
+		}
+	}
+}
+
+func TestDocument_Relationship_Fields(t *testing.T) {
+	doc := buildRelationshipFixture()
+
+	r := doc.Relationship("@I12@", "@I10@")
+	want := &Relationship{
+		CommonAncestors: []string{"@I1@", "@I2@"},
+		Generations1:    3,
+		Generations2:    2,
+		Degree:          1,
+		Removed:         1,
+		Description:     "1st cousin once removed",
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("Relationship(@I12@, @I10@) = %+v, want %+v", r, want)
+	}
+
+	half := doc.Relationship("@I9@", "@I6@")
+	if half == nil || !half.Half || !reflect.DeepEqual(half.CommonAncestors, []string{"@I3@"}) || half.Description != "half-sister" {
+		t.Errorf("Relationship(@I9@, @I6@) = %+v, want half-sister via @I3@", half)
+	}
+
+	lineal := doc.Relationship("@I5@", "@I1@")
+	if lineal.Half || lineal.Degree != 0 || !reflect.DeepEqual(lineal.CommonAncestors, []string{"@I1@"}) {
+		t.Errorf("Relationship(@I5@, @I1@) = %+v", lineal)
+	}
+}
+
+func TestDocument_Relationship_Unrelated(t *testing.T) {
+	doc := buildRelationshipFixture()
+	// Spouses share no ancestor.
+	if r := doc.Relationship("@I3@", "@I4@"); r != nil {
+		t.Errorf("Relationship(@I3@, @I4@) = %+v, want nil", r)
+	}
+	for _, pair := range [][2]string{{"@I1@", "@MISSING@"}, {"@F1@", "@I1@"}, {"", "@I1@"}} {
+		if r := doc.Relationship(pair[0], pair[1]); r != nil {
+			t.Errorf("Relationship(%q, %q) = %+v, want nil", pair[0], pair[1], r)
+		}
+	}
+	var nilDoc *Document
+	if r := nilDoc.Relationship("@I1@", "@I2@"); r != nil {
+		t.Errorf("nil Document Relationship() = %+v, want nil", r)
+	}
+}
+
+func TestDescribeRelationship(t *testing.T) {
+	tests := []struct {
+		r    Relationship
+		sex  string
+		want string
+	}{
+		{Relationship{Generations1: 4}, "F", "great-great-grandmother"},
+		{Relationship{Generations1: 5}, "M", "3rd great-grandfather"},
+		{Relationship{Generations2: 3}, "F", "great-granddaughter"},
+		{Relationship{Generations1: 3, Generations2: 1}, "F", "great-aunt"},
+		{Relationship{Generations1: 3, Generations2: 1, Half: true}, "M", "half great-uncle"},
+		{Relationship{Generations1: 2, Generations2: 1, Half: true}, "m", "half-uncle"},
+		{Relationship{Generations1: 5, Generations2: 5, Degree: 4}, "", "4th cousin"},
+		{Relationship{Generations1: 2, Generations2: 4, Degree: 1, Removed: 2}, "", "1st cousin twice removed"},
+		{Relationship{Generations1: 7, Generations2: 3, Degree: 2, Removed: 4, Half: true}, "", "half 2nd cousin 4 times removed"},
+	}
+	for _, tt := range tests {
+		if got := describeRelationship(&tt.r, tt.sex); got != tt.want {
+			t.Errorf("describeRelationship(%+v, %q) = %q, want %q", tt.r, tt.sex, got, tt.want)
+		}
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	for n, want := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 22: "22nd", 111: "111th"} {
+		if got := ordinal(n); got != want {
+			t.Errorf("ordinal(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
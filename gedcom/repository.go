@@ -26,10 +26,18 @@ type Repository struct {
 	// Deprecated: use NoteXRefs and InlineNotes.
 	Notes []string
 
+	// AutomatedRecordID is the record ID assigned by the application that
+	// produced the file (RIN tag, GEDCOM 5.5.x)
+	AutomatedRecordID string
+
 	// ExternalIDs are external identifiers (EXID tags, GEDCOM 7.0).
 	// Links this record to external systems like FamilySearch, Ancestry, etc.
 	ExternalIDs []*ExternalID
 
+	// ExtensionData holds the registered application extensions of this
+	// repository (see RegisterExtension), keyed by root tag.
+	ExtensionData ExtensionData
+
 	// Tags contains all raw tags for this repository (for unknown/custom tags)
 	Tags []*Tag
 }
@@ -85,10 +93,56 @@ type SourceRepositoryLink struct {
 	// wins (last-writer-wins); CallNumbers still retains both entries.
 	CallNumberMedia map[string]string
 
+	// Calls holds the CALN entries in order, each paired with its own MEDI.
+	// Unlike CallNumberMedia it keeps duplicate call numbers with different
+	// media types apart. The decoder populates it alongside CallNumbers; when
+	// non-empty, the encoder writes Calls instead of CallNumbers.
+	Calls []CallNumber
+
 	// Notes carries NOTE subordinates of the REPO link (not the source).
 	Notes []string
 }
 
+// CallNumber is a CALN call number together with its MEDI media type.
+type CallNumber struct {
+	// Number is the call number (CALN value).
+	Number string
+
+	// Media is the media type (MEDI subordinate), e.g. "Book" or "Microfilm".
+	Media string
+}
+
+// CallNumberEntries returns the link's call numbers paired with their media
+// types: Calls when set, otherwise pairs built from CallNumbers,
+// CallNumberMedia, and (for a single call number) MediaType.
+func (l *SourceRepositoryLink) CallNumberEntries() []CallNumber {
+	if l == nil {
+		return nil
+	}
+	if len(l.Calls) > 0 {
+		return l.Calls
+	}
+	entries := make([]CallNumber, 0, len(l.CallNumbers))
+	for _, caln := range l.CallNumbers {
+		media := l.CallNumberMedia[caln]
+		if media == "" && len(l.CallNumbers) == 1 {
+			media = l.MediaType
+		}
+		entries = append(entries, CallNumber{Number: caln, Media: media})
+	}
+	return entries
+}
+
+// Sources returns the sources in doc that reference this repository through
+// any of their REPO links. It is computed on each call, so it reflects the
+// current state of doc.
+func (r *Repository) Sources(doc *Document) []*Source {
+	if r == nil || doc == nil || r.XRef == "" {
+		return nil
+	}
+	return doc.SourcesInRepository(r.XRef)
+}
+
 // Address represents a physical or digital address.
 type Address struct {
 	// Line1 is the first address line
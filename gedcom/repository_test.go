@@ -0,0 +1,101 @@
+package gedcom
+
+import "testing"
+
+func TestSourceRepositoryLink_CallNumberEntries(t *testing.T) {
+	var nilLink *SourceRepositoryLink
+	if nilLink.CallNumberEntries() != nil {
+		t.Error("nil link: expected nil entries")
+	}
+
+	legacy := &SourceRepositoryLink{CallNumbers: []string{"A-1"}, MediaType: "Book"}
+	if got := legacy.CallNumberEntries(); len(got) != 1 || got[0] != (CallNumber{Number: "A-1", Media: "Book"}) {
+		t.Errorf("single CALN entries = %+v", got)
+	}
+
+	multi := &SourceRepositoryLink{
+		CallNumbers:     []string{"A-1", "A-2"},
+		CallNumberMedia: map[string]string{"A-2": "Film"},
+		MediaType:       "Film",
+	}
+	if got := multi.CallNumberEntries(); len(got) != 2 || got[0].Media != "" || got[1].Media != "Film" {
+		t.Errorf("multi CALN entries = %+v", got)
+	}
+
+	paired := &SourceRepositoryLink{
+		CallNumbers: []string{"A-1"},
+		Calls:       []CallNumber{{Number: "A-1", Media: "Book"}, {Number: "A-1", Media: "Film"}},
+	}
+	if got := paired.CallNumberEntries(); len(got) != 2 {
+		t.Errorf("Calls not preferred: %+v", got)
+	}
+}
+
+func TestSource_AllRepositoryLinks(t *testing.T) {
+	first := &SourceRepositoryLink{XRef: "@R1@"}
+	src := &Source{
+		RepositoryLink:  first,
+		RepositoryLinks: []*SourceRepositoryLink{first, {XRef: "@R2@"}, {XRef: "@R1@", CallNumbers: []string{"B-9"}}},
+	}
+	if got := src.AllRepositoryLinks(); len(got) != 3 || got[0] != first {
+		t.Errorf("AllRepositoryLinks() = %+v", got)
+	}
+	if got := src.RepositoryLinksTo("@R1@"); len(got) != 2 {
+		t.Errorf("RepositoryLinksTo(@R1@) returned %d links, want 2", len(got))
+	}
+	if got := src.RepositoryLinksTo(""); got != nil {
+		t.Errorf("RepositoryLinksTo(\"\") = %+v, want nil", got)
+	}
+
+	legacy := &Source{RepositoryRef: "@R3@"}
+	if got := legacy.AllRepositoryLinks(); len(got) != 1 || got[0].XRef != "@R3@" {
+		t.Errorf("legacy AllRepositoryLinks() = %+v", got)
+	}
+	if (&Source{}).AllRepositoryLinks() != nil {
+		t.Error("source without links: expected nil")
+	}
+}
+
+func TestDocument_SourcesInRepository(t *testing.T) {
+	s1 := &Source{XRef: "@S1@", RepositoryLinks: []*SourceRepositoryLink{{XRef: "@R2@"}, {XRef: "@R1@"}}}
+	s2 := &Source{XRef: "@S2@", RepositoryRef: "@R1@"}
+	s3 := &Source{XRef: "@S3@", RepositoryLink: &SourceRepositoryLink{XRef: "@R2@"}}
+	repo := &Repository{XRef: "@R1@"}
+	doc := &Document{Records: []*Record{
+		{XRef: "@S1@", Type: RecordTypeSource, Entity: s1},
+		{XRef: "@S2@", Type: RecordTypeSource, Entity: s2},
+		{XRef: "@S3@", Type: RecordTypeSource, Entity: s3},
+		{XRef: "@R1@", Type: RecordTypeRepository, Entity: repo},
+	}}
+
+	got := doc.SourcesInRepository("@R1@")
+	if len(got) != 2 || got[0] != s1 || got[1] != s2 {
+		t.Errorf("SourcesInRepository(@R1@) = %+v", got)
+	}
+	if got := repo.Sources(doc); len(got) != 2 {
+		t.Errorf("Repository.Sources() returned %d sources, want 2", len(got))
+	}
+	if got := doc.SourcesInRepository("@R9@"); got != nil {
+		t.Errorf("SourcesInRepository(@R9@) = %+v, want nil", got)
+	}
+	if repo.Sources(nil) != nil {
+		t.Error("Sources(nil) should return nil")
+	}
+}
+
+func TestSource_CloneRepositoryLinks(t *testing.T) {
+	first := &SourceRepositoryLink{XRef: "@R1@", Calls: []CallNumber{{Number: "A-1"}}}
+	src := &Source{RepositoryLink: first, RepositoryLinks: []*SourceRepositoryLink{first, {XRef: "@R2@"}}}
+
+	copied := src.Clone()
+	if copied.RepositoryLink != copied.RepositoryLinks[0] {
+		t.Error("clone should keep RepositoryLink aliased to RepositoryLinks[0]")
+	}
+	if copied.RepositoryLinks[1] == src.RepositoryLinks[1] {
+		t.Error("RepositoryLinks entries should be deep copied")
+	}
+	copied.RepositoryLink.Calls[0].Number = "changed"
+	if first.Calls[0].Number != "A-1" {
+		t.Error("Calls shares backing storage with original")
+	}
+}
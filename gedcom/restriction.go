@@ -0,0 +1,438 @@
+package gedcom
+
+import "strings"
+
+// RestrictionLevel orders RESN values by how far they restrict sharing.
+type RestrictionLevel int
+
+const (
+	// RestrictionNone means no RESN value (or an unrecognized one).
+	RestrictionNone RestrictionLevel = iota
+
+	// RestrictionLocked means the data should not be modified; it may
+	// still be shared.
+	RestrictionLocked
+
+	// RestrictionPrivacy means some information was or should be withheld
+	// for privacy reasons.
+	RestrictionPrivacy
+
+	// RestrictionConfidential means the data should not be distributed or
+	// exported.
+	RestrictionConfidential
+)
+
+// String returns the GEDCOM 5.5.1 RESN value of the level, e.g.
+// "confidential", or "none".
+func (l RestrictionLevel) String() string {
+	switch l {
+	case RestrictionLocked:
+		return "locked"
+	case RestrictionPrivacy:
+		return "privacy"
+	case RestrictionConfidential:
+		return "confidential"
+	default:
+		return "none"
+	}
+}
+
+// Restriction is a RESN value as written in the file: a single lowercase
+// value in GEDCOM 5.5.1 ("confidential"), a comma-separated uppercase list
+// in 7.0 ("CONFIDENTIAL, LOCKED"). The original text is kept so documents
+// round-trip; use Level or Has rather than comparing strings.
+type Restriction string
+
+// String returns the value as written.
+func (r Restriction) String() string { return string(r) }
+
+// Level returns the most restrictive level the value names.
+func (r Restriction) Level() RestrictionLevel { return ParseRestriction(string(r)) }
+
+// Has reports whether the value names level, in any case. A 7.0 list such
+// as "LOCKED, PRIVACY" has both levels.
+func (r Restriction) Has(level RestrictionLevel) bool {
+	for _, part := range strings.Split(string(r), ",") {
+		if level != RestrictionNone && ParseRestriction(part) == level {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRestriction returns the most restrictive level named by a RESN
+// value. Both the 5.5.1 single lowercase value and the 7.0
+// comma-separated list ("CONFIDENTIAL, LOCKED") are accepted, in any case.
+// Unknown values are ignored.
+func ParseRestriction(value string) RestrictionLevel {
+	level := RestrictionNone
+	for _, part := range strings.Split(value, ",") {
+		var l RestrictionLevel
+		switch strings.ToLower(strings.TrimSpace(part)) {
+		case "locked":
+			l = RestrictionLocked
+		case "privacy":
+			l = RestrictionPrivacy
+		case "confidential":
+			l = RestrictionConfidential
+		}
+		if l > level {
+			level = l
+		}
+	}
+	return level
+}
+
+// Restriction returns the record-level restriction of an individual,
+// family, or media object record, or RestrictionNone for other records.
+func (r *Record) Restriction() RestrictionLevel {
+	if r == nil {
+		return RestrictionNone
+	}
+	switch entity := r.Entity.(type) {
+	case *Individual:
+		return entity.Restriction.Level()
+	case *Family:
+		return entity.Restriction.Level()
+	case *MediaObject:
+		return entity.Restriction.Level()
+	}
+	return RestrictionNone
+}
+
+// View is a read-only snapshot of a document with restricted data removed.
+// It owns a filtered copy of the document, so the source document is never
+// affected; the records it returns belong to the view and must not be
+// modified. Use Document for an editable copy, or encoder.EncodeView to
+// export the view.
+type View struct {
+	doc    *Document
+	max    RestrictionLevel
+	hidden []string
+}
+
+// ViewOptions configures ViewWithOptions.
+type ViewOptions struct {
+	// MaxRestriction is the most restrictive level the view admits.
+	MaxRestriction RestrictionLevel
+
+	// PropagateToFamilies extends a spouse's restriction to the family:
+	// a family whose every spouse (HUSB, WIFE) is withheld is withheld
+	// too, and a family with one withheld spouse loses its events, which
+	// describe that spouse as much as the family.
+	PropagateToFamilies bool
+
+	// Hide lists the XRefs of further records to withhold, whatever their
+	// RESN. Links to them are removed as for restricted records.
+	Hide []string
+}
+
+// View returns a snapshot of d without the data restricted beyond max:
+//
+//   - individual, family, and media object records whose RESN exceeds max,
+//     together with every link to them (FAMS, FAMC, HUSB, WIFE, CHIL, ASSO,
+//     ALIA, OBJE), so the view has no dangling pointers;
+//   - events and other facts whose own RESN exceeds max.
+//
+// View(RestrictionPrivacy) withholds only confidential data;
+// View(RestrictionNone) also withholds locked and privacy-marked data.
+func (d *Document) View(max RestrictionLevel) *View {
+	return d.ViewWithOptions(&ViewOptions{MaxRestriction: max})
+}
+
+// ViewWithOptions is View with options; nil options behave like
+// View(RestrictionNone).
+func (d *Document) ViewWithOptions(opts *ViewOptions) *View {
+	if opts == nil {
+		opts = &ViewOptions{}
+	}
+	max := opts.MaxRestriction
+	v := &View{max: max}
+	if d == nil {
+		v.doc = &Document{XRefMap: make(map[string]*Record)}
+		return v
+	}
+	out := d.Clone()
+
+	hidden, withheldEvents := viewHidden(out, opts)
+	kept := out.Records[:0]
+	for _, record := range out.Records {
+		if record != nil && hidden[record.XRef] {
+			v.hidden = append(v.hidden, record.XRef)
+			delete(out.XRefMap, record.XRef)
+			continue
+		}
+		kept = append(kept, record)
+	}
+	out.Records = kept
+
+	for _, record := range out.Records {
+		if record != nil {
+			filterRecordView(record, hidden, max, withheldEvents[record.XRef])
+		}
+	}
+	v.doc = out
+	return v
+}
+
+// viewHidden returns the XRefs of the records of doc that opts withholds,
+// and of the families that keep their records but lose their events.
+func viewHidden(doc *Document, opts *ViewOptions) (hidden, withheldEvents map[string]bool) {
+	hidden = make(map[string]bool)
+	for _, record := range doc.Records {
+		if record != nil && record.XRef != "" && record.Restriction() > opts.MaxRestriction {
+			hidden[record.XRef] = true
+		}
+	}
+	for _, xref := range opts.Hide {
+		hidden[xref] = true
+	}
+	withheldEvents = make(map[string]bool)
+	if !opts.PropagateToFamilies {
+		return hidden, withheldEvents
+	}
+	for _, record := range doc.Records {
+		fam, ok := record.Entity.(*Family)
+		if !ok || hidden[record.XRef] {
+			continue
+		}
+		switch spousesHidden(fam, hidden) {
+		case allSpousesHidden:
+			hidden[record.XRef] = true
+		case someSpousesHidden:
+			withheldEvents[record.XRef] = true
+		}
+	}
+	return hidden, withheldEvents
+}
+
+// filterRecordView removes from a record kept in a view its data
+// restricted beyond max and its links to hidden records, and the family
+// events too when withholdEvents is set.
+func filterRecordView(record *Record, hidden map[string]bool, max RestrictionLevel, withholdEvents bool) {
+	if len(record.Tags) > 0 {
+		record.Tags = filterRestrictedTags(record.Tags, hidden, max)
+		if withholdEvents {
+			record.Tags = dropFamilyEventTags(record.Tags)
+		}
+	}
+	switch entity := record.Entity.(type) {
+	case *Individual:
+		filterIndividualView(entity, hidden, max)
+		entity.Tags = record.Tags
+	case *Family:
+		filterFamilyView(entity, hidden, max)
+		if withholdEvents {
+			entity.Events = nil
+		}
+		entity.Tags = record.Tags
+	case *Source:
+		entity.Media = dropMediaLinks(entity.Media, hidden)
+		entity.Tags = record.Tags
+	case *MediaObject:
+		entity.Tags = record.Tags
+	}
+}
+
+// MaxRestriction returns the most restrictive level the view admits.
+func (v *View) MaxRestriction() RestrictionLevel { return v.max }
+
+// HiddenXRefs returns the XRefs of the records withheld from the view, in
+// document order.
+func (v *View) HiddenXRefs() []string {
+	return append([]string(nil), v.hidden...)
+}
+
+// Header returns the header of the view.
+func (v *View) Header() *Header { return v.doc.Header }
+
+// Schema returns the document's SCHMA declarations.
+func (v *View) Schema() *SchemaDefinition { return v.doc.Schema }
+
+// Records returns the records in the view, in document order.
+func (v *View) Records() []*Record {
+	return append([]*Record(nil), v.doc.Records...)
+}
+
+// GetRecord returns the record with the given XRef, or nil if it is not in
+// the view.
+func (v *View) GetRecord(xref string) *Record { return v.doc.GetRecord(xref) }
+
+// GetIndividual returns the individual with the given XRef, or nil if it
+// is not in the view.
+func (v *View) GetIndividual(xref string) *Individual { return v.doc.GetIndividual(xref) }
+
+// GetFamily returns the family with the given XRef, or nil if it is not in
+// the view.
+func (v *View) GetFamily(xref string) *Family { return v.doc.GetFamily(xref) }
+
+// Individuals returns the individuals in the view, in file order or in the
+// given SortOrder.
+func (v *View) Individuals(order ...SortOrder) []*Individual { return v.doc.Individuals(order...) }
+
+// Families returns the families in the view, in file order or in the given
+// SortOrder.
+func (v *View) Families(order ...SortOrder) []*Family { return v.doc.Families(order...) }
+
+// Document returns an editable deep copy of the view's document.
+func (v *View) Document() *Document { return v.doc.Clone() }
+
+// Results of spousesHidden.
+const (
+	noSpousesHidden = iota
+	someSpousesHidden
+	allSpousesHidden
+)
+
+// spousesHidden reports how many of the spouses of fam are hidden. A
+// family without spouses has none hidden.
+func spousesHidden(fam *Family, hidden map[string]bool) int {
+	var spouses, withheld int
+	for _, xref := range []string{fam.Husband, fam.Wife} {
+		if xref == "" {
+			continue
+		}
+		spouses++
+		if hidden[xref] {
+			withheld++
+		}
+	}
+	switch {
+	case withheld == 0:
+		return noSpousesHidden
+	case withheld == spouses:
+		return allSpousesHidden
+	}
+	return someSpousesHidden
+}
+
+// filterIndividualView removes restricted events and links to hidden
+// records from ind.
+func filterIndividualView(ind *Individual, hidden map[string]bool, max RestrictionLevel) {
+	ind.Events = filterViewEvents(ind.Events, hidden, max)
+	ind.SpouseInFamilies = dropXRefs(ind.SpouseInFamilies, hidden)
+	ind.Aliases = dropXRefs(ind.Aliases, hidden)
+	links := ind.ChildInFamilies[:0]
+	for _, link := range ind.ChildInFamilies {
+		if !hidden[link.FamilyXRef] {
+			links = append(links, link)
+		}
+	}
+	ind.ChildInFamilies = links
+	ind.Associations = dropAssociations(ind.Associations, hidden)
+	ind.Media = dropMediaLinks(ind.Media, hidden)
+}
+
+// filterFamilyView removes restricted events and links to hidden records
+// from fam.
+func filterFamilyView(fam *Family, hidden map[string]bool, max RestrictionLevel) {
+	fam.Events = filterViewEvents(fam.Events, hidden, max)
+	if hidden[fam.Husband] {
+		fam.Husband = ""
+	}
+	if hidden[fam.Wife] {
+		fam.Wife = ""
+	}
+	fam.Children = dropXRefs(fam.Children, hidden)
+	spouses := fam.Spouses[:0]
+	for _, spouse := range fam.Spouses {
+		if spouse != nil && !hidden[spouse.XRef] {
+			spouses = append(spouses, spouse)
+		}
+	}
+	fam.Spouses = spouses
+	fam.Associations = dropAssociations(fam.Associations, hidden)
+	fam.Media = dropMediaLinks(fam.Media, hidden)
+}
+
+// filterViewEvents drops the events restricted beyond max and the links
+// from the remaining events to hidden records.
+func filterViewEvents(events []*Event, hidden map[string]bool, max RestrictionLevel) []*Event {
+	kept := events[:0]
+	for _, e := range events {
+		if e == nil || e.Restriction.Level() > max {
+			continue
+		}
+		e.Media = dropMediaLinks(e.Media, hidden)
+		e.Associations = dropAssociations(e.Associations, hidden)
+		kept = append(kept, e)
+	}
+	return kept
+}
+
+func dropXRefs(xrefs []string, hidden map[string]bool) []string {
+	kept := xrefs[:0]
+	for _, xref := range xrefs {
+		if !hidden[xref] {
+			kept = append(kept, xref)
+		}
+	}
+	return kept
+}
+
+func dropAssociations(assocs []*Association, hidden map[string]bool) []*Association {
+	kept := assocs[:0]
+	for _, a := range assocs {
+		if a != nil && !hidden[a.IndividualXRef] {
+			kept = append(kept, a)
+		}
+	}
+	return kept
+}
+
+func dropMediaLinks(links []*MediaLink, hidden map[string]bool) []*MediaLink {
+	kept := links[:0]
+	for _, link := range links {
+		if link != nil && !hidden[link.MediaXRef] {
+			kept = append(kept, link)
+		}
+	}
+	return kept
+}
+
+// filterRestrictedTags drops from raw record tags every structure that
+// points at a hidden record, and every structure below the record level
+// whose own RESN subordinate is restricted beyond max.
+func filterRestrictedTags(tags []*Tag, hidden map[string]bool, max RestrictionLevel) []*Tag {
+	kept := make([]*Tag, 0, len(tags))
+	for i := 0; i < len(tags); {
+		j := i + 1
+		for j < len(tags) && tags[j].Level > tags[i].Level {
+			j++
+		}
+		if hidden[tags[i].Value] || hidden[tags[i].XRef] || blockRestriction(tags[i:j]) > max {
+			i = j
+			continue
+		}
+		kept = append(kept, tags[i])
+		i++
+	}
+	return kept
+}
+
+// dropFamilyEventTags drops the level-1 family event structures from raw
+// family tags.
+func dropFamilyEventTags(tags []*Tag) []*Tag {
+	kept := tags[:0]
+	skip := false
+	for _, tag := range tags {
+		if tag.Level <= 1 {
+			skip = tag.Level == 1 && EventType(tag.Tag).IsFamilyEvent()
+		}
+		if !skip {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
+
+// blockRestriction returns the restriction of the structure block[0] from
+// its direct RESN subordinate.
+func blockRestriction(block []*Tag) RestrictionLevel {
+	for _, tag := range block[1:] {
+		if tag.Level == block[0].Level+1 && tag.Tag == "RESN" {
+			return ParseRestriction(tag.Value)
+		}
+	}
+	return RestrictionNone
+}
@@ -0,0 +1,191 @@
+package gedcom
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRestriction(t *testing.T) {
+	for value, want := range map[string]RestrictionLevel{
+		"":                     RestrictionNone,
+		"locked":               RestrictionLocked,
+		"privacy":              RestrictionPrivacy,
+		"confidential":         RestrictionConfidential,
+		"CONFIDENTIAL, LOCKED": RestrictionConfidential,
+		"LOCKED, PRIVACY":      RestrictionPrivacy,
+		"secret":               RestrictionNone,
+	} {
+		if got := ParseRestriction(value); got != want {
+			t.Errorf("ParseRestriction(%q) = %v, want %v", value, got, want)
+		}
+	}
+	if RestrictionConfidential.String() != "confidential" || RestrictionNone.String() != "none" {
+		t.Error("RestrictionLevel.String() mismatch")
+	}
+}
+
+func TestRestriction(t *testing.T) {
+	r := Restriction("Locked, PRIVACY")
+	if r.String() != "Locked, PRIVACY" || r.Level() != RestrictionPrivacy {
+		t.Errorf("String() = %q, Level() = %v", r, r.Level())
+	}
+	if !r.Has(RestrictionLocked) || !r.Has(RestrictionPrivacy) || r.Has(RestrictionConfidential) || r.Has(RestrictionNone) {
+		t.Errorf("Has() mismatch for %q", r)
+	}
+	if Restriction("").Has(RestrictionNone) {
+		t.Error(`Restriction("").Has(RestrictionNone) = true`)
+	}
+}
+
+func restrictedDocument() *Document {
+	tags := func(lines ...string) []*Tag {
+		var out []*Tag
+		for _, line := range lines {
+			parts := strings.SplitN(line, " ", 3)
+			tag := &Tag{Tag: parts[1]}
+			tag.Level = int(parts[0][0] - '0')
+			if len(parts) == 3 {
+				tag.Value = parts[2]
+			}
+			out = append(out, tag)
+		}
+		return out
+	}
+	i1Tags := tags("1 NAME John /Doe/", "1 BIRT", "2 DATE 1900", "1 DEAT", "2 DATE 1970", "2 RESN privacy", "1 FAMS @F1@", "1 ASSO @I2@", "2 RELA Friend", "1 OBJE @O1@")
+	i1 := &Individual{
+		XRef:             "@I1@",
+		Events:           []*Event{{Type: EventBirth, Date: "1900"}, {Type: EventDeath, Date: "1970", Restriction: "privacy"}},
+		SpouseInFamilies: []string{"@F1@"},
+		Associations:     []*Association{{IndividualXRef: "@I2@", Role: "Friend"}},
+		Media:            []*MediaLink{{MediaXRef: "@O1@"}},
+		Tags:             i1Tags,
+	}
+	i2Tags := tags("1 RESN confidential", "1 NAME Jane /Doe/", "1 FAMS @F1@")
+	i2 := &Individual{XRef: "@I2@", Restriction: "confidential", SpouseInFamilies: []string{"@F1@"}, Tags: i2Tags}
+	fTags := tags("1 HUSB @I1@", "1 WIFE @I2@", "1 MARR", "2 DATE 1925")
+	fam := &Family{XRef: "@F1@", Husband: "@I1@", Wife: "@I2@", Events: []*Event{{Type: EventMarriage, Date: "1925"}}, Tags: fTags}
+	obje := &MediaObject{XRef: "@O1@", Restriction: "LOCKED"}
+
+	doc := &Document{
+		Header: &Header{Version: Version70},
+		Records: []*Record{
+			{XRef: "@I1@", Type: RecordTypeIndividual, Entity: i1, Tags: i1Tags},
+			{XRef: "@I2@", Type: RecordTypeIndividual, Entity: i2, Tags: i2Tags},
+			{XRef: "@F1@", Type: RecordTypeFamily, Entity: fam, Tags: fTags},
+			{XRef: "@O1@", Type: RecordTypeMedia, Entity: obje},
+		},
+		XRefMap: make(map[string]*Record),
+	}
+	for _, r := range doc.Records {
+		doc.XRefMap[r.XRef] = r
+	}
+	return doc
+}
+
+func TestDocument_View(t *testing.T) {
+	doc := restrictedDocument()
+
+	v := doc.View(RestrictionPrivacy)
+	if got := strings.Join(v.HiddenXRefs(), " "); got != "@I2@" {
+		t.Errorf("HiddenXRefs() = %q, want @I2@", got)
+	}
+	if v.GetIndividual("@I2@") != nil || len(v.Records()) != 3 {
+		t.Error("confidential individual still in view")
+	}
+	i1 := v.GetIndividual("@I1@")
+	if len(i1.Associations) != 0 || len(i1.Events) != 2 || len(i1.Media) != 1 {
+		t.Errorf("I1 in privacy view = %+v", i1)
+	}
+	if fam := v.GetFamily("@F1@"); fam.Wife != "" || fam.Husband != "@I1@" {
+		t.Errorf("family links = HUSB %q WIFE %q", fam.Husband, fam.Wife)
+	}
+	for _, tag := range v.GetRecord("@F1@").Tags {
+		if tag.Value == "@I2@" {
+			t.Error("raw WIFE link to hidden record kept")
+		}
+	}
+
+	strict := doc.View(RestrictionNone)
+	if got := strings.Join(strict.HiddenXRefs(), " "); got != "@I2@ @O1@" {
+		t.Errorf("strict HiddenXRefs() = %q", got)
+	}
+	i1 = strict.GetIndividual("@I1@")
+	if len(i1.Events) != 1 || len(i1.Media) != 0 {
+		t.Errorf("I1 in strict view: events %d, media %d", len(i1.Events), len(i1.Media))
+	}
+	var raw []string
+	for _, tag := range strict.GetRecord("@I1@").Tags {
+		if tag.Level == 1 {
+			raw = append(raw, tag.Tag)
+		}
+	}
+	if got := strings.Join(raw, " "); got != "NAME BIRT FAMS" {
+		t.Errorf("strict raw level-1 tags = %q, want NAME BIRT FAMS", got)
+	}
+
+	if len(doc.Records) != 4 || len(doc.GetIndividual("@I1@").Events) != 2 || doc.GetFamily("@F1@").Wife != "@I2@" {
+		t.Error("source document was mutated")
+	}
+	if doc.View(RestrictionConfidential).HiddenXRefs() != nil {
+		t.Error("confidential view should hide nothing")
+	}
+
+	edit := v.Document()
+	edit.GetIndividual("@I1@").Sex = "M"
+	if v.GetIndividual("@I1@").Sex != "" {
+		t.Error("Document() should return a copy")
+	}
+}
+
+func TestDocument_ViewNil(t *testing.T) {
+	var doc *Document
+	v := doc.View(RestrictionNone)
+	if len(v.Records()) != 0 || v.GetIndividual("@I1@") != nil {
+		t.Error("nil document view should be empty")
+	}
+}
+
+func TestDocument_ViewWithOptions(t *testing.T) {
+	doc := restrictedDocument()
+
+	v := doc.ViewWithOptions(&ViewOptions{MaxRestriction: RestrictionPrivacy, PropagateToFamilies: true})
+	fam := v.GetFamily("@F1@")
+	if fam == nil || fam.Husband != "@I1@" || len(fam.Events) != 0 {
+		t.Fatalf("family with a hidden spouse = %+v, want HUSB kept and no events", fam)
+	}
+	for _, tag := range v.GetRecord("@F1@").Tags {
+		if tag.Tag == "MARR" || tag.Tag == "DATE" {
+			t.Errorf("raw family event tag %s kept", tag.Tag)
+		}
+	}
+	if len(doc.GetFamily("@F1@").Events) != 1 {
+		t.Error("source document was mutated")
+	}
+	if plain := doc.View(RestrictionPrivacy); len(plain.GetFamily("@F1@").Events) != 1 {
+		t.Error("View() should not propagate to families")
+	}
+
+	doc.GetIndividual("@I1@").Restriction = "confidential"
+	v = doc.ViewWithOptions(&ViewOptions{MaxRestriction: RestrictionPrivacy, PropagateToFamilies: true})
+	if got := strings.Join(v.HiddenXRefs(), " "); got != "@I1@ @I2@ @F1@" {
+		t.Errorf("HiddenXRefs() = %q, want @I1@ @I2@ @F1@", got)
+	}
+
+	doc.GetIndividual("@I1@").Restriction = ""
+	doc.GetFamily("@F1@").Spouses = []*FamilySpouse{{XRef: "@I1@", Tag: "HUSB"}, {XRef: "@I2@", Tag: "WIFE"}}
+	v = doc.ViewWithOptions(&ViewOptions{MaxRestriction: RestrictionConfidential, Hide: []string{"@I1@"}})
+	if got := strings.Join(v.HiddenXRefs(), " "); got != "@I1@" {
+		t.Errorf("Hide: HiddenXRefs() = %q, want @I1@", got)
+	}
+	fam = v.GetFamily("@F1@")
+	if fam.Husband != "" || len(fam.Spouses) != 1 || fam.Spouses[0].XRef != "@I2@" {
+		t.Errorf("Hide: family = %+v, want the husband unlinked", fam)
+	}
+	if v.GetIndividual("@I2@") == nil {
+		t.Error("Hide: confidential @I2@ should be admitted at RestrictionConfidential")
+	}
+
+	if got := doc.ViewWithOptions(nil).MaxRestriction(); got != RestrictionNone {
+		t.Errorf("nil options MaxRestriction() = %v", got)
+	}
+}
@@ -0,0 +1,71 @@
+package gedcom
+
+// AutomatedRecordID returns the RIN of the record: the AutomatedRecordID of
+// its entity, or the value of its level 1 RIN tag when the entity has none.
+// Returns "" when the record has no RIN.
+func (r *Record) AutomatedRecordID() string {
+	var rin string
+	switch entity := r.Entity.(type) {
+	case *Individual:
+		rin = entity.AutomatedRecordID
+	case *Family:
+		rin = entity.AutomatedRecordID
+	case *Source:
+		rin = entity.AutomatedRecordID
+	case *Repository:
+		rin = entity.AutomatedRecordID
+	case *Submitter:
+		rin = entity.AutomatedRecordID
+	case *Note:
+		rin = entity.AutomatedRecordID
+	case *MediaObject:
+		rin = entity.AutomatedRecordID
+	}
+	if rin != "" {
+		return rin
+	}
+	for _, tag := range r.Tags {
+		if tag != nil && tag.Level == 1 && tag.Tag == "RIN" {
+			return tag.Value
+		}
+	}
+	return ""
+}
+
+// RINIndex indexes the records of a document by RIN (automated record ID),
+// the key several desktop programs use for their own databases. Build one
+// with NewRINIndex when running many lookups against the same document; the
+// index does not follow later changes to the document.
+type RINIndex struct {
+	records map[string]*Record
+}
+
+// NewRINIndex indexes the records of doc that carry a RIN. When several
+// records share a RIN, the first one is indexed.
+func NewRINIndex(doc *Document) *RINIndex {
+	ix := &RINIndex{records: make(map[string]*Record)}
+	if doc == nil {
+		return ix
+	}
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		rin := record.AutomatedRecordID()
+		if rin == "" {
+			continue
+		}
+		if _, exists := ix.records[rin]; !exists {
+			ix.records[rin] = record
+		}
+	}
+	return ix
+}
+
+// Lookup returns the record with the given RIN, or nil if there is none.
+func (ix *RINIndex) Lookup(rin string) *Record {
+	if ix == nil {
+		return nil
+	}
+	return ix.records[rin]
+}
@@ -0,0 +1,57 @@
+package gedcom
+
+import "testing"
+
+func TestRecord_AutomatedRecordID(t *testing.T) {
+	tests := []struct {
+		name   string
+		record *Record
+		want   string
+	}{
+		{"individual", &Record{Entity: &Individual{AutomatedRecordID: "12"}}, "12"},
+		{"family", &Record{Entity: &Family{AutomatedRecordID: "F12"}}, "F12"},
+		{"source", &Record{Entity: &Source{AutomatedRecordID: "S12"}}, "S12"},
+		{"repository", &Record{Entity: &Repository{AutomatedRecordID: "R12"}}, "R12"},
+		{"submitter", &Record{Entity: &Submitter{AutomatedRecordID: "U12"}}, "U12"},
+		{"note", &Record{Entity: &Note{AutomatedRecordID: "N12"}}, "N12"},
+		{"media", &Record{Entity: &MediaObject{AutomatedRecordID: "O12"}}, "O12"},
+		{"raw tags", &Record{Tags: []*Tag{
+			{Level: 1, Tag: "NAME", Value: "x"},
+			{Level: 2, Tag: "RIN", Value: "nested"},
+			{Level: 1, Tag: "RIN", Value: "T12"},
+		}}, "T12"},
+		{"none", &Record{Entity: &Individual{}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.record.AutomatedRecordID(); got != tt.want {
+				t.Errorf("AutomatedRecordID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRINIndex(t *testing.T) {
+	doc := &Document{Records: []*Record{
+		{XRef: "@I1@", Type: RecordTypeIndividual, Entity: &Individual{AutomatedRecordID: "1"}},
+		{XRef: "@I2@", Type: RecordTypeIndividual, Entity: &Individual{}},
+		{XRef: "@F1@", Type: RecordTypeFamily, Entity: &Family{AutomatedRecordID: "2"}},
+		{XRef: "@S1@", Type: RecordTypeSource, Entity: &Source{AutomatedRecordID: "1"}},
+		nil,
+	}}
+	ix := NewRINIndex(doc)
+	if got := ix.Lookup("1"); got == nil || got.XRef != "@I1@" {
+		t.Errorf("Lookup(1) = %v, want @I1@", got)
+	}
+	if got := ix.Lookup("2"); got == nil || got.XRef != "@F1@" {
+		t.Errorf("Lookup(2) = %v, want @F1@", got)
+	}
+	if got := ix.Lookup(""); got != nil {
+		t.Errorf("Lookup(\"\") = %v, want nil", got)
+	}
+
+	var nilIndex *RINIndex
+	if nilIndex.Lookup("1") != nil || NewRINIndex(nil).Lookup("1") != nil {
+		t.Error("empty index should find nothing")
+	}
+}
@@ -0,0 +1,201 @@
+package gedcom
+
+import (
+	"sort"
+	"strings"
+)
+
+// SortOrder selects the order in which Document.Individuals,
+// Document.Families, and Document.Sources return records. The accessors take
+// it as an optional argument; without one, or with an unknown value, they
+// return file order.
+type SortOrder int
+
+const (
+	// SortFileOrder returns records in the order of Document.Records: the
+	// order of the decoded file, with records added later at the end. It is
+	// the default.
+	SortFileOrder SortOrder = iota
+
+	// SortByXRef orders records by XRef, comparing runs of digits by
+	// numeric value, so @I2@ comes before @I10@.
+	SortByXRef
+
+	// SortByName orders individuals by the surname and then the given name
+	// of their first NAME, families by the names of the husband and then
+	// the wife, and sources by title, ignoring case. Records without a
+	// name come last. Other record types keep file order.
+	SortByName
+)
+
+// String returns the name of the sort order.
+func (o SortOrder) String() string {
+	switch o {
+	case SortFileOrder:
+		return "file"
+	case SortByXRef:
+		return "xref"
+	case SortByName:
+		return "name"
+	default:
+		return "unknown"
+	}
+}
+
+// sortKey identifies a cached sorted listing.
+type sortKey struct {
+	recordType RecordType
+	order      SortOrder
+}
+
+// clearSorted drops the cached sorted listings.
+func (x *documentIndex) clearSorted() {
+	x.sortMu.Lock()
+	x.sorted = nil
+	x.generation++
+	x.sortMu.Unlock()
+}
+
+// sortedRecordsOfType returns the records of type t in the given order.
+// Ties keep file order. When the document is indexed, sorted listings are
+// cached until the index next changes. The result may be shared with the
+// index, so callers must not modify it.
+func (d *Document) sortedRecordsOfType(t RecordType, order []SortOrder) []*Record {
+	if len(order) == 0 || (order[0] != SortByXRef && order[0] != SortByName) {
+		return d.recordsOfType(t)
+	}
+	key := sortKey{t, order[0]}
+
+	unlock := d.rlock()
+	index := d.index
+	cacheable := d.current()
+	var generation uint64
+	if cacheable {
+		index.sortMu.Lock()
+		cached, ok := index.sorted[key]
+		generation = index.generation
+		index.sortMu.Unlock()
+		if ok {
+			unlock()
+			return cached
+		}
+	}
+	unlock()
+
+	records := d.sortRecords(d.recordsOfType(t), key.order)
+
+	if cacheable {
+		index.sortMu.Lock()
+		if index.generation == generation {
+			if index.sorted == nil {
+				index.sorted = make(map[sortKey][]*Record)
+			}
+			index.sorted[key] = records
+		}
+		index.sortMu.Unlock()
+	}
+	return records
+}
+
+// sortRecords returns a sorted copy of records.
+func (d *Document) sortRecords(records []*Record, order SortOrder) []*Record {
+	records = append([]*Record(nil), records...)
+	switch order {
+	case SortByXRef:
+		sort.SliceStable(records, func(i, j int) bool {
+			return CompareXRefs(records[i].XRef, records[j].XRef) < 0
+		})
+	case SortByName:
+		keys := make(map[*Record]string, len(records))
+		for _, record := range records {
+			keys[record] = d.recordSortName(record)
+		}
+		sort.SliceStable(records, func(i, j int) bool {
+			a, b := keys[records[i]], keys[records[j]]
+			if (a == "") != (b == "") {
+				return b == ""
+			}
+			return a < b
+		})
+	}
+	return records
+}
+
+// recordSortName returns the SortByName key of record, or "" if it has no
+// name.
+func (d *Document) recordSortName(record *Record) string {
+	switch entity := record.Entity.(type) {
+	case *Individual:
+		return individualSortName(entity)
+	case *Family:
+		var husband, wife string
+		if ind := d.GetIndividual(entity.Husband); ind != nil {
+			husband = individualSortName(ind)
+		}
+		if ind := d.GetIndividual(entity.Wife); ind != nil {
+			wife = individualSortName(ind)
+		}
+		if husband == "" && wife == "" {
+			return ""
+		}
+		return husband + "\x01" + wife
+	case *Source:
+		return strings.ToLower(strings.TrimSpace(entity.Title))
+	}
+	return ""
+}
+
+// individualSortName returns the surname, given name, and full form of the
+// first name of ind, lower-cased and separated so that surnames compare
+// first.
+func individualSortName(ind *Individual) string {
+	if len(ind.Names) == 0 || ind.Names[0] == nil {
+		return ""
+	}
+	name := ind.Names[0]
+	surname := strings.TrimSpace(name.Surname)
+	given := strings.TrimSpace(name.Given)
+	full := strings.TrimSpace(strings.ReplaceAll(name.Full, "/", ""))
+	if surname == "" && given == "" && full == "" {
+		return ""
+	}
+	return strings.ToLower(surname + "\x00" + given + "\x00" + full)
+}
+
+// CompareXRefs compares two XRefs the way SortByXRef orders them: runs of
+// digits by numeric value and everything else byte by byte, so "@I2@"
+// sorts before "@I10@". It returns -1, 0, or +1.
+func CompareXRefs(a, b string) int {
+	for a != "" && b != "" {
+		da, db := leadingDigits(a), leadingDigits(b)
+		if da > 0 && db > 0 {
+			na := strings.TrimLeft(a[:da], "0")
+			nb := strings.TrimLeft(b[:db], "0")
+			if len(na) != len(nb) {
+				return compareInts(len(na), len(nb))
+			}
+			if c := strings.Compare(na, nb); c != 0 {
+				return c
+			}
+			if da != db {
+				return compareInts(da, db)
+			}
+			a, b = a[da:], b[db:]
+			continue
+		}
+		if a[0] != b[0] {
+			return compareInts(int(a[0]), int(b[0]))
+		}
+		a, b = a[1:], b[1:]
+	}
+	return compareInts(len(a), len(b))
+}
+
+// leadingDigits returns the number of ASCII digits at the start of s.
+func leadingDigits(s string) int {
+	n := 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	return n
+}
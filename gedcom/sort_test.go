@@ -0,0 +1,126 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func sortTestDocument() *Document {
+	person := func(xref, given, surname string) *Record {
+		ind := &Individual{XRef: xref}
+		if given != "" || surname != "" {
+			ind.Names = []*PersonalName{{Full: given + " /" + surname + "/", Given: given, Surname: surname}}
+		}
+		return &Record{XRef: xref, Type: RecordTypeIndividual, Entity: ind}
+	}
+	family := func(xref, husband, wife string) *Record {
+		return &Record{XRef: xref, Type: RecordTypeFamily, Entity: &Family{XRef: xref, Husband: husband, Wife: wife}}
+	}
+	source := func(xref, title string) *Record {
+		return &Record{XRef: xref, Type: RecordTypeSource, Entity: &Source{XRef: xref, Title: title}}
+	}
+	doc := &Document{Records: []*Record{
+		person("@I10@", "Ann", "Smith"),
+		person("@I2@", "", ""),
+		person("@I1@", "Zoe", "adams"),
+		person("@I3@", "Bob", "Smith"),
+		family("@F2@", "@I3@", "@I10@"),
+		family("@F1@", "", "@I1@"),
+		family("@F10@", "", ""),
+		source("@S2@", "parish registers"),
+		source("@S1@", "Census 1851"),
+	}}
+	doc.Reindex()
+	return doc
+}
+
+func individualXRefs(inds []*Individual) []string {
+	var out []string
+	for _, ind := range inds {
+		out = append(out, ind.XRef)
+	}
+	return out
+}
+
+func TestDocument_IndividualsSorted(t *testing.T) {
+	doc := sortTestDocument()
+	tests := []struct {
+		order []SortOrder
+		want  []string
+	}{
+		{nil, []string{"@I10@", "@I2@", "@I1@", "@I3@"}},
+		{[]SortOrder{SortFileOrder}, []string{"@I10@", "@I2@", "@I1@", "@I3@"}},
+		{[]SortOrder{SortByXRef}, []string{"@I1@", "@I2@", "@I3@", "@I10@"}},
+		{[]SortOrder{SortByName}, []string{"@I1@", "@I10@", "@I3@", "@I2@"}},
+		{[]SortOrder{SortOrder(99)}, []string{"@I10@", "@I2@", "@I1@", "@I3@"}},
+	}
+	for _, tt := range tests {
+		if got := individualXRefs(doc.Individuals(tt.order...)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Individuals(%v) = %v, want %v", tt.order, got, tt.want)
+		}
+	}
+
+	// Sorting must not reorder the index or the records.
+	if got := individualXRefs(doc.Individuals()); !reflect.DeepEqual(got, tests[0].want) {
+		t.Errorf("file order changed after sorting: %v", got)
+	}
+	if got := individualXRefs(doc.View(RestrictionNone).Individuals(SortByXRef)); !reflect.DeepEqual(got, tests[2].want) {
+		t.Errorf("View.Individuals(SortByXRef) = %v", got)
+	}
+}
+
+func TestDocument_FamiliesAndSourcesSorted(t *testing.T) {
+	doc := sortTestDocument()
+	var families []string
+	for _, fam := range doc.Families(SortByName) {
+		families = append(families, fam.XRef)
+	}
+	// F1 has only a wife, Adams; F2's husband is Bob Smith; F10 has no one.
+	if want := []string{"@F1@", "@F2@", "@F10@"}; !reflect.DeepEqual(families, want) {
+		t.Errorf("Families(SortByName) = %v, want %v", families, want)
+	}
+	families = nil
+	for _, fam := range doc.Families(SortByXRef) {
+		families = append(families, fam.XRef)
+	}
+	if want := []string{"@F1@", "@F2@", "@F10@"}; !reflect.DeepEqual(families, want) {
+		t.Errorf("Families(SortByXRef) = %v, want %v", families, want)
+	}
+
+	var sources []string
+	for _, src := range doc.Sources(SortByName) {
+		sources = append(sources, src.XRef)
+	}
+	if want := []string{"@S1@", "@S2@"}; !reflect.DeepEqual(sources, want) {
+		t.Errorf("Sources(SortByName) = %v, want %v", sources, want)
+	}
+}
+
+func TestCompareXRefs(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"@I2@", "@I10@", -1},
+		{"@I10@", "@I2@", 1},
+		{"@I1@", "@I1@", 0},
+		{"@F1@", "@I1@", -1},
+		{"@I01@", "@I1@", 1},
+		{"@I1@", "@I1A@", -1},
+		{"@X@", "@X1@", 1}, // "@" sorts after digits
+		{"", "@I1@", -1},
+	}
+	for _, tt := range tests {
+		if got := CompareXRefs(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareXRefs(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSortOrder_String(t *testing.T) {
+	for order, want := range map[SortOrder]string{SortFileOrder: "file", SortByXRef: "xref", SortByName: "name", 7: "unknown"} {
+		if got := order.String(); got != want {
+			t.Errorf("%d.String() = %q, want %q", int(order), got, want)
+		}
+	}
+}
@@ -23,6 +23,11 @@ type Source struct {
 	// which only expose the bare pointer or inline name.
 	RepositoryLink *SourceRepositoryLink
 
+	// RepositoryLinks holds every REPO link of the source in order, for
+	// sources held at more than one repository. The decoder sets
+	// RepositoryLink to the first entry (the same pointer).
+	RepositoryLinks []*SourceRepositoryLink
+
 	// RepositoryRef is the XRef to the repository where this source is stored.
 	//
 	// Superseded by RepositoryLink (use RepositoryLink.XRef). Retained for
@@ -62,9 +67,21 @@ type Source struct {
 	// CreationDate is when the record was created (CREA tag, GEDCOM 7.0)
 	CreationDate *ChangeDate
 
-	// RefNumber is the user reference number (REFN tag)
+	// UserReferences are the user reference numbers (REFN tags, can have
+	// multiple), each with its optional TYPE.
+	UserReferences []*UserReference
+
+	// RefNumber is deprecated: use UserReferences instead. It is kept for
+	// backward compatibility and populated during decode with the number of
+	// the first REFN. The encoder writes it only when UserReferences is empty.
+	//
+	// Deprecated: use UserReferences.
 	RefNumber string
 
+	// AutomatedRecordID is the record ID assigned by the application that
+	// produced the file (RIN tag, GEDCOM 5.5.x)
+	AutomatedRecordID string
+
 	// UID is the unique identifier (UID tag)
 	UID string
 
@@ -72,6 +89,10 @@ type Source struct {
 	// Links this record to external systems like FamilySearch, Ancestry, etc.
 	ExternalIDs []*ExternalID
 
+	// ExtensionData holds the registered application extensions of this
+	// source (see RegisterExtension), keyed by root tag.
+	ExtensionData ExtensionData
+
 	// Tags contains all raw tags for this source (for unknown/custom tags)
 	Tags []*Tag
 }
@@ -83,36 +104,126 @@ func (s *Source) AllNotes(doc *Document) []string {
 	return allNotes(doc, s.InlineNotes, s.NoteXRefs)
 }
 
+// AllRepositoryLinks returns every repository link of the source:
+// RepositoryLink followed by the other entries of RepositoryLinks. A source
+// that only sets the legacy RepositoryRef or Repository fields yields a
+// single link built from them. Returns nil when the source has no link.
+func (s *Source) AllRepositoryLinks() []*SourceRepositoryLink {
+	if s == nil {
+		return nil
+	}
+	var links []*SourceRepositoryLink
+	if s.RepositoryLink != nil {
+		links = append(links, s.RepositoryLink)
+	}
+	for _, link := range s.RepositoryLinks {
+		if link != nil && link != s.RepositoryLink {
+			links = append(links, link)
+		}
+	}
+	if len(links) == 0 {
+		switch {
+		case s.RepositoryRef != "":
+			links = append(links, &SourceRepositoryLink{XRef: s.RepositoryRef})
+		case s.Repository != nil:
+			links = append(links, &SourceRepositoryLink{Inline: s.Repository})
+		}
+	}
+	return links
+}
+
+// RepositoryLinksTo returns the source's links to the repository record with
+// the given XRef, carrying the call numbers under which it is held there.
+func (s *Source) RepositoryLinksTo(xref string) []*SourceRepositoryLink {
+	var links []*SourceRepositoryLink
+	for _, link := range s.AllRepositoryLinks() {
+		if xref != "" && link.XRef == xref {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
 // SourceCitationData represents extracted text and date from a source citation.
 type SourceCitationData struct {
 	// Date is the date extracted from the source
 	Date string
 
-	// Text is the quoted text from the source
+	// Text is the quoted text from the source (the first TEXT)
 	Text string
+
+	// AdditionalText holds the TEXT values after the first, for citations
+	// that quote several passages.
+	AdditionalText []string
+
+	// Website holds WWW subordinates: the addresses of online copies of the
+	// record, as written by Ancestry and other online services.
+	Website []string
 }
 
 // SourceCitation represents a citation of a source with location and quality information.
 type SourceCitation struct {
-	// SourceXRef is the cross-reference to the source record (e.g., "@S1@")
+	// SourceXRef is the cross-reference to the source record (e.g., "@S1@").
+	// It is empty for embedded citations (see InlineText).
 	SourceXRef string
 
+	// InlineText is the source description of a GEDCOM 5.5/5.5.1 embedded
+	// citation ("n SOUR <text>" with no pointer), with CONT/CONC
+	// continuation lines folded in. GEDCOM 7.0 forbids embedded citations;
+	// the converter promotes them to SOUR records when upgrading.
+	InlineText string
+
+	// Text holds the TEXT subordinates of an embedded citation: excerpts
+	// quoted from the source, with continuation lines folded in. Pointer
+	// citations carry quoted text in Data.Text instead.
+	Text []string
+
 	// Page is the page or location within the source (e.g., "Page 42, Entry 103")
 	Page string
 
+	// Event is the EVEN subordinate: the type of event the source
+	// documents (e.g., "BIRT" for a birth register cited for a
+	// christening), which need not be the fact the citation supports.
+	Event string
+
+	// Role is the ROLE under EVEN: the person's role in that event, such
+	// as "CHIL", "WITN", or "FATH". GEDCOM 5.5.1 writes other roles in
+	// parentheses, e.g. "(Godmother)".
+	Role string
+
 	// Quality is the evidence quality assessment (0-3 scale per GEDCOM spec)
 	// 0 = unreliable evidence or estimated data
 	// 1 = questionable reliability of evidence
 	// 2 = secondary evidence, data officially recorded sometime after event
 	// 3 = direct and primary evidence used, or by dominance of the evidence
-	Quality int
+	Quality Quality
+
+	// HasQuality reports whether Quality was read from a QUAY tag, which
+	// distinguishes QUAY 0 from a citation with no quality assessment.
+	HasQuality bool
+
+	// Surety is the value of a vendor surety tag on the citation (see
+	// SuretyTags), such as "Primary" or "2"; SuretyTag is the tag it was
+	// read from. Use Confidence to interpret it.
+	Surety    string
+	SuretyTag string
 
 	// Data contains optional extracted text and date from the source
 	Data *SourceCitationData
 
+	// Media are OBJE links on the citation, such as the record image
+	// attached to an Ancestry citation.
+	Media []*MediaLink
+
 	// AncestryAPID is the Ancestry Permanent Identifier from the _APID tag.
 	// This is an Ancestry.com vendor extension that links the citation to a
 	// specific record in an Ancestry database. Use AncestryAPID.URL() to
 	// reconstruct the original Ancestry.com record URL.
 	AncestryAPID *AncestryAPID
 }
+
+// IsInline reports whether the citation is a GEDCOM 5.5/5.5.1 embedded
+// citation: free text in place of a pointer to a SOUR record.
+func (c *SourceCitation) IsInline() bool {
+	return c != nil && c.SourceXRef == "" && c.InlineText != ""
+}
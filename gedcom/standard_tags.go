@@ -0,0 +1,414 @@
+package gedcom
+
+import (
+	"sort"
+	"sync"
+)
+
+// TagPayload describes the payload (line value) a standard tag takes.
+type TagPayload string
+
+const (
+	// TagPayloadNone means the tag has no payload.
+	TagPayloadNone TagPayload = "none"
+
+	// TagPayloadText is free text.
+	TagPayloadText TagPayload = "text"
+
+	// TagPayloadPointer is a cross-reference pointer such as "@I1@".
+	TagPayloadPointer TagPayload = "pointer"
+
+	// TagPayloadDate is a GEDCOM date.
+	TagPayloadDate TagPayload = "date"
+
+	// TagPayloadTime is a time of day ("12:30:00").
+	TagPayloadTime TagPayload = "time"
+
+	// TagPayloadAge is an age ("42y 3m").
+	TagPayloadAge TagPayload = "age"
+
+	// TagPayloadInteger is a non-negative integer.
+	TagPayloadInteger TagPayload = "integer"
+
+	// TagPayloadEnum is one value of an enumeration (SEX, PEDI, QUAY, ...).
+	TagPayloadEnum TagPayload = "enum"
+
+	// TagPayloadY is "Y" or empty, as on events asserted without details.
+	TagPayloadY TagPayload = "Y"
+
+	// TagPayloadLanguage is a language: a language name in 5.5 and 5.5.1,
+	// a BCP 47 tag in 7.0.
+	TagPayloadLanguage TagPayload = "language"
+
+	// TagPayloadMediaType is a media type such as "text/html".
+	TagPayloadMediaType TagPayload = "media-type"
+
+	// TagPayloadURI is a URI or URL.
+	TagPayloadURI TagPayload = "uri"
+)
+
+// TagInfo describes a standard GEDCOM tag.
+type TagInfo struct {
+	// Tag is the tag name, e.g. "BIRT".
+	Tag string
+
+	// Description is a short human-readable name of the structure.
+	Description string
+
+	// Payload is the payload the tag takes. Tags with different payloads
+	// in different contexts (SOUR is a record, a pointer in a citation,
+	// and text in the header) give the payload of their most common use.
+	Payload TagPayload
+
+	// Record is true for tags that start a level-0 record (INDI, SOUR,
+	// ...), including HEAD and TRLR.
+	Record bool
+
+	// Parents lists, sorted, the tags the tag may appear directly under.
+	// It is empty for tags that only start records, and for CONT and CONC,
+	// which continue the text payload of any tag.
+	Parents []string
+
+	// Since is the first version that defines the tag.
+	Since Version
+
+	// DeprecatedIn is the version that deprecates or removes the tag, or
+	// empty. Replacement names what to use instead, when there is one.
+	DeprecatedIn Version
+	Replacement  string
+}
+
+// InVersion reports whether the tag is standard in version v: defined in
+// it and not deprecated by it.
+func (i TagInfo) InVersion(v Version) bool {
+	if v.Before(i.Since) {
+		return false
+	}
+	return i.DeprecatedIn == "" || v.Before(i.DeprecatedIn)
+}
+
+// AllowedUnder reports whether the tag may appear directly under parent.
+func (i TagInfo) AllowedUnder(parent string) bool {
+	n := sort.SearchStrings(i.Parents, parent)
+	return n < len(i.Parents) && i.Parents[n] == parent
+}
+
+// levelZero marks record tags in the parents of standardTagTable entries.
+const levelZero = "0"
+
+// standardTag is one entry of standardTagTable.
+type standardTag struct {
+	tag         string
+	desc        string
+	payload     TagPayload
+	parents     []string
+	since       Version
+	deprecated  Version
+	replacement string
+}
+
+// Parent groups of standardTagTable, completed by initStandardTags from
+// builtinEvents.
+var (
+	indiAttributeTags = []string{"CAST", "DSCR", "EDUC", "IDNO", "NATI", "NCHI", "NMR", "OCCU", "PROP", "RELI", "SSN", "TITL", "FACT"}
+	indiOrdinanceTags = []string{"BAPL", "CONL", "ENDL", "INIL", "SLGC"}
+	famOrdinanceTags  = []string{"SLGS"}
+	recordTags        = []string{"INDI", "FAM", "SOUR", "REPO", "OBJE", "NOTE", "SUBM", "SNOTE"}
+	addressParents    = []string{"CORP", "REPO", "SUBM", "event"}
+	citationParents   = []string{"INDI", "FAM", "NAME", "ASSO", "FAMC", "event", "attribute", "NOTE", "SNOTE", "OBJE", "ordinance"}
+)
+
+// standardTagTable is the single source of the standard tag tables. Parent
+// names in lowercase ("event", "attribute", "ordinance") stand for every
+// tag of that group, and levelZero for the record level; standard events
+// are added from builtinEvents.
+var standardTagTable = []standardTag{
+	// Records
+	{"HEAD", "Header", TagPayloadNone, []string{levelZero}, Version55, "", ""},
+	{"TRLR", "Trailer", TagPayloadNone, []string{levelZero}, Version55, "", ""},
+	{"INDI", "Individual", TagPayloadNone, []string{levelZero}, Version55, "", ""},
+	{"FAM", "Family", TagPayloadNone, []string{levelZero}, Version55, "", ""},
+	{"SOUR", "Source", TagPayloadPointer, append([]string{levelZero, "HEAD"}, citationParents...), Version55, "", ""},
+	{"REPO", "Repository", TagPayloadPointer, []string{levelZero, "SOUR"}, Version55, "", ""},
+	{"OBJE", "Multimedia object", TagPayloadPointer, []string{levelZero, "INDI", "FAM", "SOUR", "SUBM", "event", "attribute", "citation"}, Version55, "", ""},
+	{"NOTE", "Note", TagPayloadText, []string{levelZero, "HEAD", "INDI", "FAM", "SOUR", "REPO", "OBJE", "SUBM", "NAME", "PLAC", "ASSO", "FAMC", "FAMS", "event", "attribute", "ordinance", "citation", "CHAN", "CALN", "FILE", "SUBN"}, Version55, "", ""},
+	{"SUBM", "Submitter", TagPayloadPointer, []string{levelZero, "HEAD", "INDI", "FAM", "SUBN"}, Version55, "", ""},
+	{"SUBN", "Submission", TagPayloadPointer, []string{levelZero, "HEAD"}, Version55, Version70, ""},
+	{"SNOTE", "Shared note", TagPayloadPointer, []string{levelZero, "HEAD", "INDI", "FAM", "SOUR", "REPO", "OBJE", "SUBM", "NAME", "PLAC", "ASSO", "FAMC", "FAMS", "event", "attribute", "ordinance", "citation", "CHAN", "FILE"}, Version70, "", ""},
+
+	// Header
+	{"GEDC", "GEDCOM format", TagPayloadNone, []string{"HEAD"}, Version55, "", ""},
+	{"VERS", "Version", TagPayloadText, []string{"GEDC", "SOUR", "CHAR"}, Version55, "", ""},
+	{"FORM", "Format", TagPayloadText, []string{"GEDC", "PLAC", "FILE", "OBJE"}, Version55, "", ""},
+	{"CHAR", "Character set", TagPayloadEnum, []string{"HEAD"}, Version55, Version70, ""},
+	{"DEST", "Receiving system", TagPayloadText, []string{"HEAD"}, Version55, "", ""},
+	{"FILE", "File", TagPayloadText, []string{"HEAD", "OBJE"}, Version55, "", ""},
+	{"COPR", "Copyright", TagPayloadText, []string{"HEAD", "DATA", "FILE"}, Version55, "", ""},
+	{"CORP", "Corporation", TagPayloadText, []string{"SOUR"}, Version55, "", ""},
+	{"SCHMA", "Extension schema", TagPayloadNone, []string{"HEAD"}, Version70, "", ""},
+	{"TAG", "Extension tag declaration", TagPayloadText, []string{"SCHMA"}, Version70, "", ""},
+	{"LANG", "Language", TagPayloadLanguage, []string{"HEAD", "SUBM", "NOTE", "SNOTE", "TRAN", "PLAC", "TEXT"}, Version55, "", ""},
+
+	// Dates and change tracking
+	{"DATE", "Date", TagPayloadDate, []string{"HEAD", "DATA", "CHAN", "CREA", "event", "attribute", "ordinance", "EVEN", "NO"}, Version55, "", ""},
+	{"TIME", "Time", TagPayloadTime, []string{"DATE"}, Version55, "", ""},
+	{"PHRASE", "Phrase", TagPayloadText, []string{"DATE", "AGE", "ROLE", "PEDI", "SEX", "ALIA", "HUSB", "WIFE", "CHIL", "MEDI", "TYPE", "STAT", "ASSO", "EVEN"}, Version70, "", ""},
+	{"SDATE", "Sort date", TagPayloadDate, []string{"event", "attribute"}, Version70, "", ""},
+	{"CHAN", "Change", TagPayloadNone, recordTags, Version55, "", ""},
+	{"CREA", "Creation", TagPayloadNone, recordTags, Version70, "", ""},
+
+	// Identifiers
+	{"RIN", "Record ID number", TagPayloadText, recordTags, Version55, "", ""},
+	{"REFN", "User reference number", TagPayloadText, recordTags, Version55, "", ""},
+	{"UID", "Unique identifier", TagPayloadText, append([]string{"event", "attribute", "ordinance"}, recordTags...), Version70, "", ""},
+	{"EXID", "External identifier", TagPayloadText, recordTags, Version70, "", ""},
+	{"AFN", "Ancestral File number", TagPayloadText, []string{"INDI"}, Version55, Version70, "EXID with TYPE https://gedcom.io/exid-type/FamilySearch-AFN"},
+	{"RFN", "Record file number", TagPayloadText, []string{"INDI", "SUBM"}, Version55, Version70, "EXID"},
+	{"TYPE", "Type", TagPayloadText, []string{"NAME", "event", "attribute", "REFN", "EXID", "MEDI", "MEDI"}, Version55, "", ""},
+
+	// Individuals
+	{"NAME", "Name", TagPayloadText, []string{"INDI", "SOUR", "REPO", "SUBM"}, Version55, "", ""},
+	{"NPFX", "Name prefix", TagPayloadText, []string{"NAME", "TRAN", "ROMN", "FONE"}, Version55, "", ""},
+	{"GIVN", "Given name", TagPayloadText, []string{"NAME", "TRAN", "ROMN", "FONE"}, Version55, "", ""},
+	{"NICK", "Nickname", TagPayloadText, []string{"NAME", "TRAN", "ROMN", "FONE"}, Version55, "", ""},
+	{"SPFX", "Surname prefix", TagPayloadText, []string{"NAME", "TRAN", "ROMN", "FONE"}, Version55, "", ""},
+	{"SURN", "Surname", TagPayloadText, []string{"NAME", "TRAN", "ROMN", "FONE"}, Version55, "", ""},
+	{"NSFX", "Name suffix", TagPayloadText, []string{"NAME", "TRAN", "ROMN", "FONE"}, Version55, "", ""},
+	{"ROMN", "Romanized variation", TagPayloadText, []string{"NAME", "PLAC"}, Version551, Version70, "TRAN"},
+	{"FONE", "Phonetic variation", TagPayloadText, []string{"NAME", "PLAC"}, Version551, Version70, "TRAN"},
+	{"TRAN", "Translation", TagPayloadText, []string{"NAME", "PLAC", "NOTE", "SNOTE", "FILE"}, Version70, "", ""},
+	{"SEX", "Sex", TagPayloadEnum, []string{"INDI"}, Version55, "", ""},
+	{"RESN", "Restriction", TagPayloadEnum, []string{"INDI", "FAM", "OBJE", "event", "attribute"}, Version55, "", ""},
+	{"FAMC", "Child to family link", TagPayloadPointer, []string{"INDI", "BIRT", "CHR", "ADOP", "SLGC"}, Version55, "", ""},
+	{"FAMS", "Spouse to family link", TagPayloadPointer, []string{"INDI"}, Version55, "", ""},
+	{"PEDI", "Pedigree linkage", TagPayloadEnum, []string{"FAMC"}, Version55, "", ""},
+	{"STAT", "Status", TagPayloadEnum, []string{"FAMC", "ordinance"}, Version55, "", ""},
+	{"ASSO", "Association", TagPayloadPointer, []string{"INDI", "event", "attribute"}, Version55, "", ""},
+	{"RELA", "Relationship", TagPayloadText, []string{"ASSO"}, Version55, Version70, "ROLE"},
+	{"ROLE", "Role", TagPayloadEnum, []string{"ASSO", "EVEN"}, Version55, "", ""},
+	{"ALIA", "Alias", TagPayloadPointer, []string{"INDI"}, Version55, "", ""},
+	{"ANCI", "Ancestor interest", TagPayloadPointer, []string{"INDI"}, Version55, "", ""},
+	{"DESI", "Descendant interest", TagPayloadPointer, []string{"INDI"}, Version55, "", ""},
+	{"NO", "Did not happen", TagPayloadEnum, []string{"INDI", "FAM"}, Version70, "", ""},
+
+	// Families
+	{"HUSB", "Husband", TagPayloadPointer, []string{"FAM", "event"}, Version55, "", ""},
+	{"WIFE", "Wife", TagPayloadPointer, []string{"FAM", "event"}, Version55, "", ""},
+	{"CHIL", "Child", TagPayloadPointer, []string{"FAM"}, Version55, "", ""},
+
+	// Event details
+	{"PLAC", "Place", TagPayloadText, []string{"HEAD", "event", "attribute", "ordinance", "EVEN"}, Version55, "", ""},
+	{"MAP", "Map coordinates", TagPayloadNone, []string{"PLAC"}, Version551, "", ""},
+	{"LATI", "Latitude", TagPayloadText, []string{"MAP"}, Version551, "", ""},
+	{"LONG", "Longitude", TagPayloadText, []string{"MAP"}, Version551, "", ""},
+	{"AGE", "Age at event", TagPayloadAge, []string{"event", "attribute", "HUSB", "WIFE"}, Version55, "", ""},
+	{"AGNC", "Responsible agency", TagPayloadText, []string{"event", "attribute", "DATA"}, Version55, "", ""},
+	{"CAUS", "Cause", TagPayloadText, []string{"event", "attribute"}, Version55, "", ""},
+	{"RELI", "Religion", TagPayloadText, []string{"INDI", "event", "attribute"}, Version55, "", ""},
+	{"TEMP", "Temple", TagPayloadText, []string{"ordinance"}, Version55, "", ""},
+
+	// Addresses
+	{"ADDR", "Address", TagPayloadText, addressParents, Version55, "", ""},
+	{"ADR1", "Address line 1", TagPayloadText, []string{"ADDR"}, Version55, "", ""},
+	{"ADR2", "Address line 2", TagPayloadText, []string{"ADDR"}, Version55, "", ""},
+	{"ADR3", "Address line 3", TagPayloadText, []string{"ADDR"}, Version551, "", ""},
+	{"CITY", "City", TagPayloadText, []string{"ADDR"}, Version55, "", ""},
+	{"STAE", "State", TagPayloadText, []string{"ADDR"}, Version55, "", ""},
+	{"POST", "Postal code", TagPayloadText, []string{"ADDR"}, Version55, "", ""},
+	{"CTRY", "Country", TagPayloadText, []string{"ADDR"}, Version55, "", ""},
+	{"PHON", "Phone", TagPayloadText, addressParents, Version55, "", ""},
+	{"EMAIL", "Email", TagPayloadText, addressParents, Version551, "", ""},
+	{"FAX", "Fax", TagPayloadText, addressParents, Version551, "", ""},
+	{"WWW", "Web page", TagPayloadURI, addressParents, Version551, "", ""},
+
+	// Sources and citations
+	{"DATA", "Data", TagPayloadText, []string{"SOUR", "SOUR", "citation"}, Version55, "", ""},
+	{"EVEN", "Event", TagPayloadText, []string{"DATA", "citation"}, Version55, "", ""},
+	{"AUTH", "Author", TagPayloadText, []string{"SOUR"}, Version55, "", ""},
+	{"TITL", "Title", TagPayloadText, []string{"SOUR", "OBJE", "FILE"}, Version55, "", ""},
+	{"ABBR", "Abbreviation", TagPayloadText, []string{"SOUR"}, Version55, "", ""},
+	{"PUBL", "Publication", TagPayloadText, []string{"SOUR"}, Version55, "", ""},
+	{"TEXT", "Text from source", TagPayloadText, []string{"SOUR", "DATA"}, Version55, "", ""},
+	{"PAGE", "Where within source", TagPayloadText, []string{"citation"}, Version55, "", ""},
+	{"QUAY", "Quality of data", TagPayloadEnum, []string{"citation"}, Version55, "", ""},
+	{"CALN", "Call number", TagPayloadText, []string{"REPO"}, Version55, "", ""},
+	{"MEDI", "Medium", TagPayloadEnum, []string{"CALN", "FORM"}, Version55, "", ""},
+
+	// Multimedia
+	{"BLOB", "Binary object", TagPayloadText, []string{"OBJE"}, Version55, Version551, "FILE"},
+	{"MIME", "Media type", TagPayloadMediaType, []string{"NOTE", "SNOTE", "TRAN"}, Version70, "", ""},
+	{"CROP", "Crop", TagPayloadNone, []string{"OBJE"}, Version70, "", ""},
+	{"TOP", "Crop top", TagPayloadInteger, []string{"CROP"}, Version70, "", ""},
+	{"LEFT", "Crop left", TagPayloadInteger, []string{"CROP"}, Version70, "", ""},
+	{"HEIGHT", "Crop height", TagPayloadInteger, []string{"CROP"}, Version70, "", ""},
+	{"WIDTH", "Crop width", TagPayloadInteger, []string{"CROP"}, Version70, "", ""},
+
+	// Submissions
+	{"FAMF", "Family file", TagPayloadText, []string{"SUBN"}, Version55, Version70, ""},
+	{"ANCE", "Generations of ancestors", TagPayloadInteger, []string{"SUBN"}, Version55, Version70, ""},
+	{"DESC", "Generations of descendants", TagPayloadInteger, []string{"SUBN"}, Version55, Version70, ""},
+	{"ORDI", "Ordinance process flag", TagPayloadEnum, []string{"SUBN"}, Version55, Version70, ""},
+
+	// Text continuation
+	{"CONT", "Continued on next line", TagPayloadText, nil, Version55, "", ""},
+	{"CONC", "Concatenation", TagPayloadText, nil, Version55, Version70, "CONT"},
+}
+
+// standardAttributes describes the individual attributes and ordinances
+// of standardTagTable.
+var standardAttributes = []standardTag{
+	{"CAST", "Caste", TagPayloadText, []string{"INDI"}, Version55, "", ""},
+	{"DSCR", "Physical description", TagPayloadText, []string{"INDI"}, Version55, "", ""},
+	{"EDUC", "Education", TagPayloadText, []string{"INDI"}, Version55, "", ""},
+	{"IDNO", "Identification number", TagPayloadText, []string{"INDI"}, Version55, "", ""},
+	{"NATI", "Nationality", TagPayloadText, []string{"INDI"}, Version55, "", ""},
+	{"NCHI", "Number of children", TagPayloadInteger, []string{"INDI", "FAM"}, Version55, "", ""},
+	{"NMR", "Number of marriages", TagPayloadInteger, []string{"INDI"}, Version55, "", ""},
+	{"OCCU", "Occupation", TagPayloadText, []string{"INDI"}, Version55, "", ""},
+	{"PROP", "Property", TagPayloadText, []string{"INDI"}, Version55, "", ""},
+	{"SSN", "Social security number", TagPayloadText, []string{"INDI"}, Version55, "", ""},
+	{"FACT", "Fact", TagPayloadText, []string{"INDI", "FAM"}, Version551, "", ""},
+	{"BAPL", "LDS baptism", TagPayloadNone, []string{"INDI"}, Version55, "", ""},
+	{"CONL", "LDS confirmation", TagPayloadNone, []string{"INDI"}, Version55, "", ""},
+	{"ENDL", "LDS endowment", TagPayloadNone, []string{"INDI"}, Version55, "", ""},
+	{"INIL", "LDS initiatory", TagPayloadNone, []string{"INDI"}, Version70, "", ""},
+	{"SLGC", "LDS sealing child", TagPayloadNone, []string{"INDI"}, Version55, "", ""},
+	{"SLGS", "LDS sealing spouse", TagPayloadNone, []string{"FAM"}, Version55, "", ""},
+}
+
+var (
+	standardTagsOnce sync.Once
+	standardTagsAll  map[string]TagInfo
+)
+
+// initStandardTags builds standardTagsAll from standardTagTable,
+// standardAttributes, and builtinEvents, expanding parent groups.
+func initStandardTags() {
+	groups := standardTagGroups()
+	all := make(map[string]TagInfo)
+	for _, t := range standardTagTable {
+		addStandardTag(all, groups, t)
+	}
+	for _, t := range standardAttributes {
+		addStandardTag(all, groups, t)
+	}
+	for _, e := range builtinEvents {
+		addStandardTag(all, groups, eventStandardTag(e))
+	}
+
+	for tag, info := range all {
+		sort.Strings(info.Parents)
+		parents := info.Parents[:0]
+		for i, p := range info.Parents {
+			if i == 0 || p != info.Parents[i-1] {
+				parents = append(parents, p)
+			}
+		}
+		info.Parents = parents
+		all[tag] = info
+	}
+	standardTagsAll = all
+}
+
+// standardTagGroups returns the parent groups standardTag.parents may
+// name, expanded to their tags.
+func standardTagGroups() map[string][]string {
+	var indiEvents, famEvents []string
+	for _, e := range builtinEvents {
+		if e.scope&EventScopeIndividual != 0 {
+			indiEvents = append(indiEvents, string(e.typ))
+		}
+		if e.scope&EventScopeFamily != 0 {
+			famEvents = append(famEvents, string(e.typ))
+		}
+	}
+	return map[string][]string{
+		"event":     append(append([]string(nil), indiEvents...), famEvents...),
+		"attribute": indiAttributeTags,
+		"ordinance": append(append([]string(nil), indiOrdinanceTags...), famOrdinanceTags...),
+		"citation":  {"SOUR"},
+	}
+}
+
+// addStandardTag adds t to all, merging its parents, with groups expanded,
+// into those of an earlier entry for the same tag.
+func addStandardTag(all map[string]TagInfo, groups map[string][]string, t standardTag) {
+	info := all[t.tag]
+	if info.Tag == "" {
+		info = TagInfo{
+			Tag:          t.tag,
+			Description:  t.desc,
+			Payload:      t.payload,
+			Since:        t.since,
+			DeprecatedIn: t.deprecated,
+			Replacement:  t.replacement,
+		}
+	}
+	for _, parent := range t.parents {
+		switch {
+		case parent == levelZero:
+			info.Record = true
+		case groups[parent] != nil:
+			info.Parents = append(info.Parents, groups[parent]...)
+		default:
+			info.Parents = append(info.Parents, parent)
+		}
+	}
+	all[t.tag] = info
+}
+
+// eventStandardTag returns the table entry of a built-in event.
+func eventStandardTag(e builtinEvent) standardTag {
+	var parents []string
+	if e.scope&EventScopeIndividual != 0 {
+		parents = append(parents, "INDI")
+	}
+	if e.scope&EventScopeFamily != 0 {
+		parents = append(parents, "FAM")
+	}
+	payload := TagPayloadY
+	if e.typ == EventGeneric || e.typ == EventResidence {
+		payload = TagPayloadText
+	}
+	return standardTag{string(e.typ), e.desc, payload, parents, Version55, "", ""}
+}
+
+// StandardTags returns the standard tags of GEDCOM version v (5.5, 5.5.1,
+// or 7.0) with their metadata, keyed by tag: the tags v defines and does
+// not deprecate. It returns nil for an unknown version. The map is a copy
+// that callers may modify.
+//
+//	tags := gedcom.StandardTags(gedcom.Version70)
+//	tags["SDATE"].Parents // [ADOP ANUL BAPM ...]
+func StandardTags(v Version) map[string]TagInfo {
+	if !v.IsValid() {
+		return nil
+	}
+	standardTagsOnce.Do(initStandardTags)
+	tags := make(map[string]TagInfo)
+	for tag, info := range standardTagsAll {
+		if info.InVersion(v) {
+			info.Parents = append([]string(nil), info.Parents...)
+			tags[tag] = info
+		}
+	}
+	return tags
+}
+
+// LookupStandardTag returns the metadata of a standard tag of any
+// version, including tags a later version deprecates, so that callers can
+// tell an obsolete standard tag from an unknown one.
+func LookupStandardTag(tag string) (TagInfo, bool) {
+	standardTagsOnce.Do(initStandardTags)
+	info, ok := standardTagsAll[tag]
+	if ok {
+		info.Parents = append([]string(nil), info.Parents...)
+	}
+	return info, ok
+}
+
+// IsStandardTag reports whether tag is a standard tag of version v.
+func IsStandardTag(v Version, tag string) bool {
+	info, ok := LookupStandardTag(tag)
+	return ok && v.IsValid() && info.InVersion(v)
+}
@@ -0,0 +1,109 @@
+package gedcom
+
+import (
+	"testing"
+)
+
+func TestStandardTags(t *testing.T) {
+	v55 := StandardTags(Version55)
+	v551 := StandardTags(Version551)
+	v70 := StandardTags(Version70)
+
+	tests := []struct {
+		tag            string
+		in55, in551    bool
+		in70           bool
+		payload        TagPayload
+		parent         string
+		notUnderParent string
+	}{
+		{"BIRT", true, true, true, TagPayloadY, "INDI", "FAM"},
+		{"MARR", true, true, true, TagPayloadY, "FAM", "INDI"},
+		{"EVEN", true, true, true, TagPayloadText, "FAM", "HEAD"},
+		{"DATE", true, true, true, TagPayloadDate, "BIRT", "INDI"},
+		{"EMAIL", false, true, true, TagPayloadText, "REPO", "ADDR"},
+		{"MAP", false, true, true, TagPayloadNone, "PLAC", "INDI"},
+		{"BLOB", true, false, false, TagPayloadText, "OBJE", "INDI"},
+		{"AFN", true, true, false, TagPayloadText, "INDI", "FAM"},
+		{"CONC", true, true, false, TagPayloadText, "", "INDI"},
+		{"SDATE", false, false, true, TagPayloadDate, "OCCU", "INDI"},
+		{"SNOTE", false, false, true, TagPayloadPointer, "SLGS", "TRLR"},
+		{"NCHI", true, true, true, TagPayloadInteger, "FAM", "HEAD"},
+	}
+	for _, tt := range tests {
+		_, in55 := v55[tt.tag]
+		_, in551 := v551[tt.tag]
+		info, in70 := v70[tt.tag]
+		if in55 != tt.in55 || in551 != tt.in551 || in70 != tt.in70 {
+			t.Errorf("%s in 5.5/5.5.1/7.0 = %v/%v/%v, want %v/%v/%v", tt.tag, in55, in551, in70, tt.in55, tt.in551, tt.in70)
+		}
+		if !in70 {
+			info, _ = LookupStandardTag(tt.tag)
+		}
+		if info.Payload != tt.payload {
+			t.Errorf("%s payload = %s, want %s", tt.tag, info.Payload, tt.payload)
+		}
+		if tt.parent != "" && !info.AllowedUnder(tt.parent) {
+			t.Errorf("%s should be allowed under %s (parents %v)", tt.tag, tt.parent, info.Parents)
+		}
+		if info.AllowedUnder(tt.notUnderParent) {
+			t.Errorf("%s should not be allowed under %s", tt.tag, tt.notUnderParent)
+		}
+	}
+
+	if StandardTags("4.0") != nil {
+		t.Error("StandardTags() of an unknown version should be nil")
+	}
+	if info := v70["INDI"]; !info.Record || info.Description != "Individual" || info.Since != Version55 {
+		t.Errorf("INDI = %+v", info)
+	}
+	if info := v70["SOUR"]; !info.Record || !info.AllowedUnder("HEAD") || !info.AllowedUnder("BIRT") {
+		t.Errorf("SOUR = %+v", info)
+	}
+
+	v70["BIRT"] = TagInfo{}
+	if StandardTags(Version70)["BIRT"].Tag != "BIRT" {
+		t.Error("StandardTags() should return a copy")
+	}
+}
+
+func TestStandardTags_Consistency(t *testing.T) {
+	for _, v := range []Version{Version55, Version551, Version70} {
+		for tag, info := range StandardTags(v) {
+			if info.Tag != tag || info.Description == "" || info.Payload == "" || info.Since == "" {
+				t.Errorf("%s %s: incomplete metadata %+v", v, tag, info)
+			}
+			if !info.Record && len(info.Parents) == 0 && tag != "CONT" && tag != "CONC" {
+				t.Errorf("%s %s: no parents", v, tag)
+			}
+			for i := 1; i < len(info.Parents); i++ {
+				if info.Parents[i-1] >= info.Parents[i] {
+					t.Errorf("%s %s: parents not sorted and unique: %v", v, tag, info.Parents)
+					break
+				}
+			}
+		}
+	}
+	for _, typ := range StandardEventTypes(EventScopeIndividual | EventScopeFamily) {
+		if !IsStandardTag(Version55, string(typ)) {
+			t.Errorf("event %s missing from the standard tags", typ)
+		}
+	}
+}
+
+func TestLookupStandardTag(t *testing.T) {
+	info, ok := LookupStandardTag("ROMN")
+	if !ok || info.Since != Version551 || info.DeprecatedIn != Version70 || info.Replacement != "TRAN" {
+		t.Errorf("LookupStandardTag(ROMN) = %+v, %v", info, ok)
+	}
+	if _, ok := LookupStandardTag("_MILT"); ok {
+		t.Error("LookupStandardTag(_MILT) should fail")
+	}
+	if IsStandardTag(Version70, "ROMN") || !IsStandardTag(Version551, "ROMN") || IsStandardTag("4.0", "BIRT") {
+		t.Error("IsStandardTag() mismatch")
+	}
+	// GEDCOM 7.0 keeps the submitter interest pointers of INDI
+	if !IsStandardTag(Version70, "ANCI") || !IsStandardTag(Version70, "DESI") {
+		t.Error("ANCI and DESI should be standard in 7.0")
+	}
+}
@@ -0,0 +1,67 @@
+package gedcom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Strictness selects how strictly nonconforming data is treated. The
+// decoder, validator, converter, and encoder options all accept it, so one
+// setting can govern a whole decode, validate, convert, and encode run:
+//
+//   - decoder: with StrictnessStrict, DecodeWithDiagnostics fails on the
+//     first syntax error; the other levels recover and report diagnostics
+//     (DecodeWithOptions fails on syntax errors at every level)
+//   - validator: StrictnessLenient reports errors only, StrictnessNormal
+//     errors and warnings, and StrictnessStrict every issue
+//   - converter: StrictnessStrict fails a conversion that would lose data
+//   - encoder: StrictnessStrict fails on records that repeat a tag more
+//     often than the output version allows, and StrictnessNormal and
+//     above fail GEDCOM 7.0 output holding tags 7.0 removed
+//
+// The levels are ordered, so s >= StrictnessNormal means "at least normal".
+// The zero value is StrictnessLenient; each package's DefaultOptions uses
+// StrictnessNormal.
+type Strictness int
+
+const (
+	// StrictnessLenient accepts whatever can be read, reporting only errors.
+	StrictnessLenient Strictness = iota
+
+	// StrictnessNormal recovers from nonconforming data and reports errors
+	// and warnings.
+	StrictnessNormal
+
+	// StrictnessStrict rejects nonconforming data and reports every issue.
+	StrictnessStrict
+)
+
+// String returns "lenient", "normal", or "strict".
+func (s Strictness) String() string {
+	switch s {
+	case StrictnessLenient:
+		return "lenient"
+	case StrictnessNormal:
+		return "normal"
+	case StrictnessStrict:
+		return "strict"
+	default:
+		return fmt.Sprintf("Strictness(%d)", int(s))
+	}
+}
+
+// ParseStrictness returns the Strictness named by s ("lenient", "normal",
+// or "strict", in any case), for command-line flags and configuration
+// files.
+func ParseStrictness(s string) (Strictness, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "lenient":
+		return StrictnessLenient, nil
+	case "normal":
+		return StrictnessNormal, nil
+	case "strict":
+		return StrictnessStrict, nil
+	default:
+		return 0, fmt.Errorf("gedcom: unknown strictness %q", s)
+	}
+}
@@ -0,0 +1,45 @@
+package gedcom
+
+import "testing"
+
+func TestStrictness_String(t *testing.T) {
+	tests := []struct {
+		s    Strictness
+		want string
+	}{
+		{StrictnessLenient, "lenient"},
+		{StrictnessNormal, "normal"},
+		{StrictnessStrict, "strict"},
+		{Strictness(7), "Strictness(7)"},
+	}
+	for _, tt := range tests {
+		if got := tt.s.String(); got != tt.want {
+			t.Errorf("Strictness(%d).String() = %q, want %q", int(tt.s), got, tt.want)
+		}
+	}
+}
+
+func TestParseStrictness(t *testing.T) {
+	for _, s := range []Strictness{StrictnessLenient, StrictnessNormal, StrictnessStrict} {
+		got, err := ParseStrictness(s.String())
+		if err != nil || got != s {
+			t.Errorf("ParseStrictness(%q) = %v, %v, want %v", s.String(), got, err, s)
+		}
+	}
+	if got, err := ParseStrictness(" Strict "); err != nil || got != StrictnessStrict {
+		t.Errorf("ParseStrictness(\" Strict \") = %v, %v, want strict", got, err)
+	}
+	if _, err := ParseStrictness("pedantic"); err == nil {
+		t.Error("ParseStrictness(pedantic) should fail")
+	}
+}
+
+func TestStrictness_Order(t *testing.T) {
+	if !(StrictnessLenient < StrictnessNormal && StrictnessNormal < StrictnessStrict) {
+		t.Error("strictness levels should be ordered lenient < normal < strict")
+	}
+	var zero Strictness
+	if zero != StrictnessLenient {
+		t.Errorf("zero Strictness = %v, want lenient", zero)
+	}
+}
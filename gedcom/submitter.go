@@ -1,5 +1,10 @@
 package gedcom
 
+import (
+	"errors"
+	"fmt"
+)
+
 // Submitter represents a person or organization who submitted genealogical data.
 // In GEDCOM files, submitters are identified by SUBM records and provide attribution
 // and contact information for data sources.
@@ -37,10 +42,18 @@ type Submitter struct {
 	// Deprecated: use NoteXRefs and InlineNotes.
 	Notes []string
 
+	// AutomatedRecordID is the record ID assigned by the application that
+	// produced the file (RIN tag, GEDCOM 5.5.x)
+	AutomatedRecordID string
+
 	// ExternalIDs are external identifiers (EXID tags, GEDCOM 7.0).
 	// Links this record to external systems like FamilySearch, Ancestry, etc.
 	ExternalIDs []*ExternalID
 
+	// ExtensionData holds the registered application extensions of this
+	// submitter (see RegisterExtension), keyed by root tag.
+	ExtensionData ExtensionData
+
 	// Tags contains all raw tags for this submitter (for unknown/custom tags)
 	Tags []*Tag
 }
@@ -51,3 +64,81 @@ type Submitter struct {
 func (s *Submitter) AllNotes(doc *Document) []string {
 	return allNotes(doc, s.InlineNotes, s.NoteXRefs)
 }
+
+// SetSubmitter makes subm the document's submitter: it adds a SUBM record
+// for subm, or replaces the entity of the existing submitter record with
+// subm's XRef, and points the header's SUBM at it. Both changes are made
+// under one lock. The replaced record's raw Tags are dropped so the
+// encoder writes the new entity. It returns an error if subm is nil, has no
+// XRef, or its XRef belongs to a record of another type.
+func (d *Document) SetSubmitter(subm *Submitter) error {
+	if subm == nil {
+		return errors.New("gedcom: submitter is nil")
+	}
+	if subm.XRef == "" {
+		return errors.New("gedcom: submitter has no XRef")
+	}
+
+	defer d.lock()()
+	if !d.indexed() {
+		d.reindex()
+	}
+	record := d.XRefMap[subm.XRef]
+	switch {
+	case record == nil:
+		record = &Record{XRef: subm.XRef, Type: RecordTypeSubmitter, Entity: subm}
+		d.appendRecord(record)
+		if d.index.timestamps {
+			d.stamp(record, true)
+		}
+	case record.Type != RecordTypeSubmitter:
+		return fmt.Errorf("gedcom: XRef %s is a %s record, not a submitter", subm.XRef, record.Type)
+	default:
+		record.Entity = subm
+		record.Tags = nil
+		if d.index.timestamps {
+			d.stamp(record, false)
+		}
+	}
+
+	if d.Header == nil {
+		d.Header = &Header{}
+	}
+	d.Header.Submitter = subm.XRef
+	if len(d.Header.Tags) > 0 {
+		setHeaderSubmitterTag(d.Header, subm.XRef)
+	}
+	return nil
+}
+
+// setHeaderSubmitterTag sets the value of the level 1 SUBM of the raw
+// header tags to xref, inserting one after SOUR and its substructures (or
+// at the start) when there is none. Trivia after the insertion point moves
+// down a line.
+func setHeaderSubmitterTag(h *Header, xref string) {
+	insert := 0
+	for i, tag := range h.Tags {
+		if tag == nil || tag.Level != 1 {
+			continue
+		}
+		switch tag.Tag {
+		case "SUBM":
+			h.Tags[i] = &Tag{Level: 1, Tag: "SUBM", Value: xref}
+			return
+		case "SOUR":
+			insert = i + 1
+			for insert < len(h.Tags) && (h.Tags[insert] == nil || h.Tags[insert].Level > 1) {
+				insert++
+			}
+		}
+	}
+	tags := make([]*Tag, 0, len(h.Tags)+1)
+	tags = append(tags, h.Tags[:insert]...)
+	tags = append(tags, &Tag{Level: 1, Tag: "SUBM", Value: xref})
+	h.Tags = append(tags, h.Tags[insert:]...)
+	for i := range h.Trivia {
+		if h.Trivia[i].Offset > insert {
+			h.Trivia[i].Offset++
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package gedcom
+
+import "testing"
+
+func TestDocument_SetSubmitter(t *testing.T) {
+	doc := &Document{}
+	if err := doc.SetSubmitter(nil); err == nil {
+		t.Error("SetSubmitter(nil) should fail")
+	}
+	if err := doc.SetSubmitter(&Submitter{Name: "Jane"}); err == nil {
+		t.Error("SetSubmitter without XRef should fail")
+	}
+
+	subm := &Submitter{XRef: "@U1@", Name: "Jane"}
+	if err := doc.SetSubmitter(subm); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Header == nil || doc.Header.Submitter != "@U1@" {
+		t.Fatalf("Header = %+v, want Submitter @U1@", doc.Header)
+	}
+	if got := doc.GetSubmitter("@U1@"); got != subm {
+		t.Errorf("GetSubmitter() = %+v, want %+v", got, subm)
+	}
+
+	doc.Records[0].Tags = []*Tag{{Level: 1, Tag: "NAME", Value: "Jane"}}
+	updated := &Submitter{XRef: "@U1@", Name: "Jane Doe"}
+	if err := doc.SetSubmitter(updated); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Records) != 1 || doc.GetSubmitter("@U1@") != updated {
+		t.Errorf("submitter not replaced in place: %d records", len(doc.Records))
+	}
+	if doc.Records[0].Tags != nil {
+		t.Errorf("stale raw tags kept: %v", doc.Records[0].Tags)
+	}
+	if len(doc.Submitters()) != 1 {
+		t.Errorf("Submitters() = %d, want 1", len(doc.Submitters()))
+	}
+
+	if err := doc.AddRecord(&Record{XRef: "@I1@", Type: RecordTypeIndividual, Entity: &Individual{XRef: "@I1@"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.SetSubmitter(&Submitter{XRef: "@I1@"}); err == nil {
+		t.Error("SetSubmitter over an individual should fail")
+	}
+	if doc.Header.Submitter != "@U1@" {
+		t.Errorf("failed SetSubmitter changed header to %q", doc.Header.Submitter)
+	}
+}
+
+func TestDocument_SetSubmitter_HeaderTags(t *testing.T) {
+	doc := &Document{Header: &Header{
+		Tags: []*Tag{
+			{Level: 1, Tag: "GEDC"},
+			{Level: 2, Tag: "VERS", Value: "5.5.1"},
+			{Level: 1, Tag: "SOUR", Value: "App"},
+			{Level: 2, Tag: "VERS", Value: "1.0"},
+			{Level: 1, Tag: "CHAR", Value: "UTF-8"},
+		},
+		Trivia: []Trivia{{Offset: 2, Text: "// version"}, {Offset: 5, Text: "// charset"}},
+	}}
+	if err := doc.SetSubmitter(&Submitter{XRef: "@U1@"}); err != nil {
+		t.Fatal(err)
+	}
+	tags := doc.Header.Tags
+	if len(tags) != 6 || tags[4].Tag != "SUBM" || tags[4].Value != "@U1@" || tags[5].Tag != "CHAR" {
+		t.Fatalf("SUBM not inserted after SOUR: %+v", tags)
+	}
+	if got := doc.Header.Trivia; got[0].Offset != 2 || got[1].Offset != 6 {
+		t.Errorf("Trivia offsets = %d, %d, want 2, 6", got[0].Offset, got[1].Offset)
+	}
+
+	if err := doc.SetSubmitter(&Submitter{XRef: "@U2@"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Header.Tags) != 6 || doc.Header.Tags[4].Value != "@U2@" {
+		t.Errorf("existing SUBM not updated: %+v", doc.Header.Tags)
+	}
+}
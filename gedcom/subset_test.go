@@ -198,6 +198,21 @@ func TestSubset_ClosureContainsExpectedRecords(t *testing.T) {
 	}
 }
 
+func TestSubset_FollowsCitationMedia(t *testing.T) {
+	doc := buildRichFixture()
+	doc.GetIndividual("@I3@").SourceCitations = []*SourceCitation{{
+		SourceXRef: "@S2@",
+		Media:      []*MediaLink{{MediaXRef: "@M1@"}},
+	}}
+	sub, err := doc.Subset([]string{"@I3@"})
+	if err != nil {
+		t.Fatalf("Subset errored: %v", err)
+	}
+	if sub.GetRecord("@M1@") == nil {
+		t.Error("media linked from a citation is missing from the closure")
+	}
+}
+
 func TestSubset_UnreferencedRecordsExcluded(t *testing.T) {
 	doc := buildRichFixture()
 	sub, err := doc.Subset([]string{"@I1@"})
@@ -1,5 +1,7 @@
 package gedcom
 
+import "strings"
+
 // Tag represents a GEDCOM tag-value pair with hierarchical level information.
 // Tags are the fundamental building blocks of GEDCOM files, representing
 // structured data in a hierarchical format.
@@ -31,3 +33,31 @@ func (t *Tag) HasValue() bool {
 func (t *Tag) HasXRef() bool {
 	return t.XRef != ""
 }
+
+// FoldText returns value, the payload of a line at level, with the CONT and
+// CONC lines directly beneath it folded in: CONT starts a new line and CONC
+// continues the current one. tags are the lines that follow it; folding
+// stops at the first one no deeper than level.
+func FoldText(value string, level int, tags []*Tag) string {
+	var sb strings.Builder
+	sb.WriteString(value)
+	for _, tag := range tags {
+		if tag == nil {
+			continue
+		}
+		if tag.Level <= level {
+			break
+		}
+		if tag.Level != level+1 {
+			continue
+		}
+		switch tag.Tag {
+		case "CONT":
+			sb.WriteString("\n")
+			sb.WriteString(tag.Value)
+		case "CONC":
+			sb.WriteString(tag.Value)
+		}
+	}
+	return sb.String()
+}
@@ -48,3 +48,34 @@ func TestTag_HasXRef(t *testing.T) {
 		})
 	}
 }
+
+func TestFoldText(t *testing.T) {
+	tags := []*Tag{
+		{Level: 2, Tag: "CONC", Value: "text"},
+		{Level: 2, Tag: "CONT", Value: "Next "},
+		{Level: 2, Tag: "SOUR", Value: "@S1@"},
+		{Level: 3, Tag: "CONT", Value: "not folded"},
+		{Level: 2, Tag: "CONC", Value: "line"},
+		{Level: 1, Tag: "NOTE", Value: "Other"},
+		{Level: 2, Tag: "CONT", Value: "past the end"},
+	}
+	tests := []struct {
+		name  string
+		value string
+		level int
+		tags  []*Tag
+		want  string
+	}{
+		{"CONT and CONC folded", "Long ", 1, tags, "Long text\nNext line"},
+		{"no subordinates", "Short", 1, nil, "Short"},
+		{"stops at a shallower line", "", 1, tags[5:], ""},
+		{"record level", "Shared ", 0, []*Tag{{Level: 1, Tag: "CONC", Value: "note"}}, "Shared note"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FoldText(tt.value, tt.level, tt.tags); got != tt.want {
+				t.Errorf("FoldText(%q, %d) = %q, want %q", tt.value, tt.level, got, tt.want)
+			}
+		})
+	}
+}
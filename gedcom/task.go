@@ -0,0 +1,259 @@
+package gedcom
+
+import (
+	"errors"
+	"strings"
+)
+
+// Task is a research to-do item attached to an individual. Vendors export
+// tasks as _TODO (Legacy Family Tree) or _RESEARCH structures:
+//
+//	1 _TODO
+//	2 DESC Obtain
+//	2 _CAT Birth Certificate
+//	2 _LOCL Somewhere over the rainbow
+//	2 DATE 29 Sep 2016
+//	2 STAT Open
+//	2 PRTY 5
+//	2 REPO @R0@
+type Task struct {
+	// Tag is the vendor tag the task was read from or is written as:
+	// "_TODO" (default when empty) or "_RESEARCH".
+	Tag string
+
+	// Description says what to do (DESC, or the value of the task line).
+	Description string
+
+	// Category classifies the task (_CAT), e.g. "Birth Certificate".
+	Category string
+
+	// Locality is where the research should happen (_LOCL).
+	Locality string
+
+	// Date is when the task was created (DATE).
+	Date string
+
+	// ReminderDate is when to be reminded of the task (_RDATE).
+	ReminderDate string
+
+	// CompletedDate is when the task was completed (_CDATE).
+	CompletedDate string
+
+	// Status is the task state (STAT), e.g. "Open" or "Completed".
+	Status string
+
+	// Type is the vendor's task type code (TYPE).
+	Type string
+
+	// Priority is the vendor's priority value (PRTY).
+	Priority string
+
+	// RepositoryXRef points to the repository to search (REPO).
+	RepositoryXRef string
+
+	// Result records findings so far (DATA).
+	Result string
+
+	// Notes are inline notes on the task (NOTE).
+	Notes []string
+}
+
+// Task tags recognized on INDI records.
+const (
+	TaskTagTodo     = "_TODO"
+	TaskTagResearch = "_RESEARCH"
+)
+
+// IsOpen reports whether the task is still to be done: it has no completion
+// date and its status is not "Completed", "Closed", or "Done".
+func (t *Task) IsOpen() bool {
+	if t.CompletedDate != "" {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(t.Status)) {
+	case "completed", "complete", "closed", "done":
+		return false
+	}
+	return true
+}
+
+// String returns a one-line summary of the task, e.g.
+// "Obtain (Birth Certificate) @ Boston [Open]".
+func (t *Task) String() string {
+	var sb strings.Builder
+	sb.WriteString(t.Description)
+	if t.Category != "" {
+		sb.WriteString(" (" + t.Category + ")")
+	}
+	if t.Locality != "" {
+		sb.WriteString(" @ " + t.Locality)
+	}
+	if t.Status != "" {
+		sb.WriteString(" [" + t.Status + "]")
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// TaskFromTags builds a Task from the _TODO or _RESEARCH tag at idx and its
+// subordinates. CONT and CONC lines are folded into the values they
+// continue; unrecognized subordinates are ignored (they remain in the raw
+// tags).
+func TaskFromTags(tags []*Tag, idx int) *Task {
+	base := tags[idx]
+	task := &Task{Tag: base.Tag, Description: FoldText(base.Value, base.Level, tags[idx+1:])}
+
+	for i := idx + 1; i < len(tags) && tags[i].Level > base.Level; i++ {
+		sub := tags[i]
+		if sub.Level != base.Level+1 {
+			continue
+		}
+		switch sub.Tag {
+		case "DESC":
+			task.Description = FoldText(sub.Value, sub.Level, tags[i+1:])
+		case "DATA":
+			task.Result = FoldText(sub.Value, sub.Level, tags[i+1:])
+		case "NOTE":
+			task.Notes = append(task.Notes, FoldText(sub.Value, sub.Level, tags[i+1:]))
+		default:
+			if field := task.valueField(sub.Tag); field != nil {
+				*field = sub.Value
+			}
+		}
+	}
+	return task
+}
+
+// valueField returns the field holding the value of the single-line task
+// subordinate tag, or nil if tag is not one.
+func (t *Task) valueField(tag string) *string {
+	switch tag {
+	case "_CAT":
+		return &t.Category
+	case "_LOCL":
+		return &t.Locality
+	case "DATE":
+		return &t.Date
+	case "_RDATE":
+		return &t.ReminderDate
+	case "_CDATE":
+		return &t.CompletedDate
+	case "STAT":
+		return &t.Status
+	case "TYPE":
+		return &t.Type
+	case "PRTY":
+		return &t.Priority
+	case "REPO":
+		return &t.RepositoryXRef
+	}
+	return nil
+}
+
+// ToTags returns the task as a vendor tag structure rooted at level.
+// Multi-line values are written with CONT lines.
+func (t *Task) ToTags(level int) []*Tag {
+	tag := t.Tag
+	if tag == "" {
+		tag = TaskTagTodo
+	}
+	tags := []*Tag{{Level: level, Tag: tag}}
+	add := func(name, value string) {
+		if value != "" {
+			tags = append(tags, textTags(level+1, name, value)...)
+		}
+	}
+	add("DESC", t.Description)
+	add("_CAT", t.Category)
+	add("_LOCL", t.Locality)
+	add("DATE", t.Date)
+	add("_RDATE", t.ReminderDate)
+	add("_CDATE", t.CompletedDate)
+	add("STAT", t.Status)
+	add("TYPE", t.Type)
+	add("PRTY", t.Priority)
+	add("REPO", t.RepositoryXRef)
+	add("DATA", t.Result)
+	for _, note := range t.Notes {
+		add("NOTE", note)
+	}
+	return tags
+}
+
+// NoteText renders the task as note text, for applications that ignore
+// vendor task tags. The first line is "To do: " followed by String();
+// further lines carry the dates, repository, result, and notes.
+func (t *Task) NoteText() string {
+	lines := []string{"To do: " + t.String()}
+	add := func(label, value string) {
+		if value != "" {
+			lines = append(lines, label+": "+value)
+		}
+	}
+	add("Created", t.Date)
+	add("Reminder", t.ReminderDate)
+	add("Completed", t.CompletedDate)
+	add("Priority", t.Priority)
+	add("Repository", t.RepositoryXRef)
+	add("Result", t.Result)
+	lines = append(lines, t.Notes...)
+	return strings.Join(lines, "\n")
+}
+
+// TaskFormat selects how AddTask stores a task.
+type TaskFormat int
+
+const (
+	// TaskFormatTag stores the task as a _TODO (or Task.Tag) structure and
+	// adds it to Individual.Tasks.
+	TaskFormatTag TaskFormat = iota
+
+	// TaskFormatNote stores the task as an inline NOTE with NoteText, for
+	// applications that do not read vendor task tags.
+	TaskFormatNote
+)
+
+// AddTask attaches task to an individual record, updating both the
+// Individual entity and the record's raw tags so the task survives
+// encoding. Returns an error if the record is not an individual or task
+// is nil.
+func (r *Record) AddTask(task *Task, format TaskFormat) error {
+	if task == nil {
+		return errors.New("gedcom: task is nil")
+	}
+	indi, ok := r.GetIndividual()
+	if !ok || indi == nil {
+		return errors.New("gedcom: tasks can only be added to individual records")
+	}
+
+	var tags []*Tag
+	switch format {
+	case TaskFormatNote:
+		text := task.NoteText()
+		indi.InlineNotes = append(indi.InlineNotes, text)
+		indi.Notes = append(indi.Notes, text)
+		tags = textTags(1, "NOTE", text)
+	default:
+		if task.Tag == "" {
+			task.Tag = TaskTagTodo
+		}
+		indi.Tasks = append(indi.Tasks, task)
+		tags = task.ToTags(1)
+	}
+
+	if len(r.Tags) > 0 {
+		r.Tags = append(r.Tags, tags...)
+		indi.Tags = r.Tags
+	}
+	return nil
+}
+
+// textTags returns a tag carrying value, with a CONT subordinate for each
+// additional line.
+func textTags(level int, tag, value string) []*Tag {
+	lines := strings.Split(value, "\n")
+	tags := []*Tag{{Level: level, Tag: tag, Value: lines[0]}}
+	for _, line := range lines[1:] {
+		tags = append(tags, &Tag{Level: level + 1, Tag: "CONT", Value: line})
+	}
+	return tags
+}
@@ -0,0 +1,153 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func legacyTaskTags() []*Tag {
+	return []*Tag{
+		{Level: 1, Tag: "NAME", Value: "John /Doe/"},
+		{Level: 1, Tag: "_TODO"},
+		{Level: 2, Tag: "DESC", Value: "Obtain"},
+		{Level: 3, Tag: "CONC", Value: " certificate"},
+		{Level: 2, Tag: "_CAT", Value: "Birth Certificate"},
+		{Level: 2, Tag: "_LOCL", Value: "Boston"},
+		{Level: 2, Tag: "DATE", Value: "29 Sep 2016"},
+		{Level: 2, Tag: "_RDATE", Value: "30 Sep 2016"},
+		{Level: 2, Tag: "STAT", Value: "Open"},
+		{Level: 2, Tag: "TYPE", Value: "0"},
+		{Level: 2, Tag: "PRTY", Value: "5"},
+		{Level: 2, Tag: "REPO", Value: "@R0@"},
+		{Level: 2, Tag: "DATA", Value: "Still waiting"},
+		{Level: 3, Tag: "CONT", Value: "Wrote again"},
+		{Level: 2, Tag: "_XYZ", Value: "ignored"},
+		{Level: 1, Tag: "_RESEARCH", Value: "Check census"},
+		{Level: 2, Tag: "_CDATE", Value: "1 Oct 2016"},
+		{Level: 2, Tag: "NOTE", Value: "Found in 1900"},
+	}
+}
+
+func TestTaskFromTags(t *testing.T) {
+	tags := legacyTaskTags()
+
+	got := TaskFromTags(tags, 1)
+	want := &Task{
+		Tag:            "_TODO",
+		Description:    "Obtain certificate",
+		Category:       "Birth Certificate",
+		Locality:       "Boston",
+		Date:           "29 Sep 2016",
+		ReminderDate:   "30 Sep 2016",
+		Status:         "Open",
+		Type:           "0",
+		Priority:       "5",
+		RepositoryXRef: "@R0@",
+		Result:         "Still waiting\nWrote again",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TaskFromTags(_TODO) = %+v, want %+v", got, want)
+	}
+	if !got.IsOpen() {
+		t.Error("open task reported closed")
+	}
+
+	research := TaskFromTags(tags, 15)
+	if research.Tag != "_RESEARCH" || research.Description != "Check census" || research.CompletedDate != "1 Oct 2016" {
+		t.Errorf("TaskFromTags(_RESEARCH) = %+v", research)
+	}
+	if !reflect.DeepEqual(research.Notes, []string{"Found in 1900"}) {
+		t.Errorf("Notes = %v", research.Notes)
+	}
+	if research.IsOpen() {
+		t.Error("task with completion date reported open")
+	}
+}
+
+func TestTask_IsOpen(t *testing.T) {
+	for status, want := range map[string]bool{"": true, "Open": true, "Completed": false, " done ": false, "CLOSED": false} {
+		if got := (&Task{Status: status}).IsOpen(); got != want {
+			t.Errorf("IsOpen(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestTask_ToTagsRoundTrip(t *testing.T) {
+	task := TaskFromTags(legacyTaskTags(), 1)
+	tags := task.ToTags(1)
+	if tags[0].Tag != "_TODO" || tags[0].Level != 1 {
+		t.Fatalf("root = %+v", tags[0])
+	}
+	if got := TaskFromTags(tags, 0); !reflect.DeepEqual(got, task) {
+		t.Errorf("round trip = %+v, want %+v", got, task)
+	}
+
+	if tags := (&Task{Description: "x"}).ToTags(2); tags[0].Tag != "_TODO" || tags[1].Level != 3 {
+		t.Errorf("default tag ToTags = %+v", tags)
+	}
+}
+
+func TestTask_StringAndNoteText(t *testing.T) {
+	task := &Task{Description: "Obtain", Category: "Birth Certificate", Locality: "Boston", Status: "Open", Date: "1 JAN 2020", Notes: []string{"Ask cousin"}}
+	if got := task.String(); got != "Obtain (Birth Certificate) @ Boston [Open]" {
+		t.Errorf("String() = %q", got)
+	}
+	want := "To do: Obtain (Birth Certificate) @ Boston [Open]\nCreated: 1 JAN 2020\nAsk cousin"
+	if got := task.NoteText(); got != want {
+		t.Errorf("NoteText() = %q, want %q", got, want)
+	}
+}
+
+func TestRecord_AddTask(t *testing.T) {
+	tags := []*Tag{{Level: 1, Tag: "NAME", Value: "John /Doe/"}}
+	indi := &Individual{XRef: "@I1@", Tags: tags}
+	record := &Record{XRef: "@I1@", Type: RecordTypeIndividual, Entity: indi, Tags: tags}
+
+	if err := record.AddTask(&Task{Description: "Find birth"}, TaskFormatTag); err != nil {
+		t.Fatalf("AddTask() error = %v", err)
+	}
+	if len(indi.Tasks) != 1 || indi.Tasks[0].Tag != TaskTagTodo {
+		t.Errorf("Tasks = %+v", indi.Tasks)
+	}
+	if len(record.Tags) != 3 || record.Tags[1].Tag != "_TODO" || record.Tags[2].Value != "Find birth" {
+		t.Errorf("raw tags = %+v", record.Tags)
+	}
+
+	if err := record.AddTask(&Task{Description: "Find death", Notes: []string{"try obituaries"}}, TaskFormatNote); err != nil {
+		t.Fatalf("AddTask(note) error = %v", err)
+	}
+	if len(indi.Tasks) != 1 || len(indi.InlineNotes) != 1 || indi.InlineNotes[0] != "To do: Find death\ntry obituaries" {
+		t.Errorf("InlineNotes = %q", indi.InlineNotes)
+	}
+	last := record.Tags[len(record.Tags)-2:]
+	if last[0].Tag != "NOTE" || last[1].Tag != "CONT" || last[1].Level != 2 {
+		t.Errorf("note tags = %+v %+v", last[0], last[1])
+	}
+	if len(indi.Tags) != len(record.Tags) {
+		t.Error("entity tags out of sync with record tags")
+	}
+
+	// Hand-built records without raw tags only get the entity update.
+	bare := &Record{Type: RecordTypeIndividual, Entity: &Individual{}}
+	if err := bare.AddTask(&Task{Description: "x"}, TaskFormatTag); err != nil || len(bare.Tags) != 0 {
+		t.Errorf("bare AddTask: err = %v, tags = %v", err, bare.Tags)
+	}
+
+	if err := record.AddTask(nil, TaskFormatTag); err == nil {
+		t.Error("AddTask(nil) should fail")
+	}
+	fam := &Record{Type: RecordTypeFamily, Entity: &Family{}}
+	if err := fam.AddTask(&Task{}, TaskFormatTag); err == nil {
+		t.Error("AddTask on a family should fail")
+	}
+}
+
+func TestIndividual_CloneTasks(t *testing.T) {
+	indi := &Individual{Tasks: []*Task{{Description: "a", Notes: []string{"n"}}}}
+	copied := indi.Clone()
+	copied.Tasks[0].Description = "b"
+	copied.Tasks[0].Notes[0] = "m"
+	if indi.Tasks[0].Description != "a" || indi.Tasks[0].Notes[0] != "n" {
+		t.Error("Clone shares task state")
+	}
+}
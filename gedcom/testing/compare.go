@@ -2,6 +2,7 @@ package testing
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/cacack/gedcom-go/v2/gedcom"
 )
@@ -145,17 +146,50 @@ func compareRecords(before, after *gedcom.Record, index int, report *RoundTripRe
 		)
 	}
 
-	// Compare Value (record-level value, used for NOTE records)
-	if before.Value != after.Value {
+	// Compare Value (record-level value, used for NOTE records) and tags,
+	// with CONT and CONC lines folded: where a value is split is not content
+	beforeValue, beforeTags := foldContinuations(before.Value, before.Tags)
+	afterValue, afterTags := foldContinuations(after.Value, after.Tags)
+	if beforeValue != afterValue {
 		report.AddDifference(
 			pathPrefix+".Value",
-			before.Value,
-			after.Value,
+			beforeValue,
+			afterValue,
 		)
 	}
 
 	// Compare tags
-	compareTags(before.Tags, after.Tags, pathPrefix+".Tags", report)
+	compareTags(beforeTags, afterTags, pathPrefix+".Tags", report)
+
+	// Records built in code carry only an entity; compare those directly
+	if len(before.Tags) == 0 && len(after.Tags) == 0 && !reflect.DeepEqual(before.Entity, after.Entity) {
+		report.AddDifference(
+			pathPrefix+".Entity",
+			fmt.Sprintf("%+v", before.Entity),
+			fmt.Sprintf("%+v", after.Entity),
+		)
+	}
+}
+
+// foldContinuations returns a record's line value and tags with every CONT
+// and CONC line folded into the line it continues, so that values split
+// at different points compare equal. Tag positions in difference paths
+// count the folded tags.
+func foldContinuations(value string, tags []*gedcom.Tag) (string, []*gedcom.Tag) {
+	value = gedcom.FoldText(value, 0, tags)
+	folded := make([]*gedcom.Tag, 0, len(tags))
+	for i, tag := range tags {
+		if tag.Tag == "CONT" || tag.Tag == "CONC" {
+			continue
+		}
+		if text := gedcom.FoldText(tag.Value, tag.Level, tags[i+1:]); text != tag.Value {
+			joined := *tag
+			joined.Value = text
+			tag = &joined
+		}
+		folded = append(folded, tag)
+	}
+	return value, folded
 }
 
 // compareTags compares two tag slices recursively.
@@ -0,0 +1,221 @@
+package testing
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/converter"
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// AssertConvertRoundTrip decodes input, converts it to the via version and
+// back to its own version, and asserts that nothing was lost beyond the
+// allowed transformations listed on CheckConvertRoundTrip. It fails the
+// test with the path of every difference.
+//
+// Example:
+//
+//	func TestMyGEDCOMSurvives70(t *testing.T) {
+//	    data, _ := os.ReadFile("family.ged") // GEDCOM 5.5.1
+//	    gedcomtesting.AssertConvertRoundTrip(t, data, gedcom.Version70)
+//	}
+func AssertConvertRoundTrip(t *testing.T, input []byte, via gedcom.Version, opts ...Option) {
+	t.Helper()
+
+	report, err := CheckConvertRoundTrip(bytes.NewReader(input), via, opts...)
+	if err != nil {
+		t.Fatalf("conversion round-trip check failed: %v", err)
+	}
+
+	if !report.Equal {
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("conversion round-trip via %s produced differences (%d found):\n", via, len(report.Differences)))
+		writeDifferences(&sb, report.Differences)
+		t.Error(sb.String())
+	}
+}
+
+// CheckConvertRoundTrip converts a document to the via version and back and
+// returns a report of what changed. It is the non-test form of
+// AssertConvertRoundTrip.
+//
+// The function:
+//  1. Decodes the input GEDCOM
+//  2. Converts it to via with the converter's default options
+//  3. Converts the result back to the input's version
+//  4. Compares the two documents as AssertDocumentsEqual does, after
+//     normalizing the allowed transformations in both
+//
+// Allowed transformations, which are not reported:
+//   - Header.Encoding, since GEDCOM 7.0 files are always UTF-8
+//   - CONC/CONT reorganization; values are compared with their
+//     continuation lines folded in
+//   - XRef case, since conversion to 7.0 uppercases XRefs
+//   - Inline source citations promoted to SOUR records on the way to 7.0;
+//     the promoted records are folded back into their citations
+//
+// The intermediate document is kept in memory rather than encoded, so the
+// report reflects the converter alone.
+func CheckConvertRoundTrip(input io.Reader, via gedcom.Version, opts ...Option) (*RoundTripReport, error) {
+	cfg := applyOptions(opts...)
+
+	original, err := decoder.Decode(input)
+	if err != nil {
+		return nil, err
+	}
+	source := original.Header.Version
+	if source == "" {
+		source = gedcom.Version55
+	}
+	if via == source {
+		return nil, fmt.Errorf("document is already GEDCOM %s", via)
+	}
+
+	converted, _, err := converter.Convert(original, via)
+	if err != nil {
+		return nil, fmt.Errorf("convert to %s: %w", via, err)
+	}
+	restored, _, err := converter.Convert(converted, source)
+	if err != nil {
+		return nil, fmt.Errorf("convert back to %s: %w", source, err)
+	}
+
+	before := original.Clone()
+	originalXRefs := normalizeConverted(before, nil)
+	normalizeConverted(restored, originalXRefs)
+
+	report := &RoundTripReport{Equal: true}
+	compareDocuments(before, restored, report, cfg)
+	return report, nil
+}
+
+// normalizeConverted rewrites doc in place so that the allowed conversion
+// transformations compare equal, and returns the set of its record XRefs.
+// SOUR records whose XRefs are not in original, when original is non-nil,
+// are taken to be promoted inline citations: they are folded back into the
+// citations that point at them and removed.
+func normalizeConverted(doc *gedcom.Document, original map[string]bool) map[string]bool {
+	if doc.Header != nil {
+		doc.Header.Encoding = ""
+		doc.Header.Tags = normalizeTags(doc.Header.Tags)
+	}
+
+	xrefs := make(map[string]bool, len(doc.Records))
+	for _, record := range doc.Records {
+		record.XRef = strings.ToUpper(record.XRef)
+		record.Tags = normalizeTags(record.Tags)
+		xrefs[record.XRef] = true
+	}
+
+	if original != nil {
+		promoted := make(map[string]*gedcom.Record)
+		kept := doc.Records[:0]
+		for _, record := range doc.Records {
+			if record.Type == gedcom.RecordTypeSource && record.XRef != "" && !original[record.XRef] {
+				promoted[record.XRef] = record
+				continue
+			}
+			kept = append(kept, record)
+		}
+		doc.Records = kept
+		if len(promoted) > 0 {
+			for _, record := range doc.Records {
+				record.Tags = inlineCitations(record.Tags, promoted)
+			}
+		}
+	}
+
+	for _, record := range doc.Records {
+		record.Tags = sortInlineCitations(record.Tags)
+	}
+	return xrefs
+}
+
+// normalizeTags returns copies of tags with CONC and CONT lines folded into
+// their parent values and pointer values uppercased.
+func normalizeTags(tags []*gedcom.Tag) []*gedcom.Tag {
+	result := make([]*gedcom.Tag, 0, len(tags))
+	for i := 0; i < len(tags); i++ {
+		if tags[i] == nil {
+			continue
+		}
+		tag := *tags[i]
+		if gedcom.IsPointerXRef(tag.Value) {
+			tag.Value = strings.ToUpper(tag.Value)
+		}
+		for i+1 < len(tags) && tags[i+1] != nil && tags[i+1].Level == tag.Level+1 && (tags[i+1].Tag == "CONC" || tags[i+1].Tag == "CONT") {
+			i++
+			if tags[i].Tag == "CONT" {
+				tag.Value += "\n"
+			}
+			tag.Value += tags[i].Value
+		}
+		result = append(result, &tag)
+	}
+	return result
+}
+
+// inlineCitations replaces each citation pointing at a promoted record with
+// the inline form it was promoted from: the record's TITL as the citation
+// value and its TEXT as the citation's TEXT.
+func inlineCitations(tags []*gedcom.Tag, promoted map[string]*gedcom.Record) []*gedcom.Tag {
+	result := make([]*gedcom.Tag, 0, len(tags))
+	for _, tag := range tags {
+		source, ok := promoted[tag.Value]
+		if tag.Tag != "SOUR" || !ok {
+			result = append(result, tag)
+			continue
+		}
+		inline := *tag
+		inline.Value = ""
+		var texts []*gedcom.Tag
+		for _, sub := range source.Tags {
+			switch {
+			case sub.Level != 1:
+			case sub.Tag == "TITL":
+				inline.Value = sub.Value
+			case sub.Tag == "TEXT":
+				texts = append(texts, &gedcom.Tag{Level: tag.Level + 1, Tag: "TEXT", Value: sub.Value})
+			}
+		}
+		result = append(result, &inline)
+		result = append(result, texts...)
+	}
+	return result
+}
+
+// sortInlineCitations orders the direct substructures of every inline
+// (non-pointer) source citation by tag name, keeping each substructure's
+// own subordinates with it, since promotion does not keep TEXT in place.
+func sortInlineCitations(tags []*gedcom.Tag) []*gedcom.Tag {
+	for i := 0; i < len(tags); i++ {
+		tag := tags[i]
+		if tag.Tag != "SOUR" || tag.Value == "" || gedcom.IsPointerXRef(tag.Value) {
+			continue
+		}
+		end := i + 1
+		for end < len(tags) && tags[end].Level > tag.Level {
+			end++
+		}
+		var groups [][]*gedcom.Tag
+		for j := i + 1; j < end; j++ {
+			if tags[j].Level == tag.Level+1 || len(groups) == 0 {
+				groups = append(groups, nil)
+			}
+			groups[len(groups)-1] = append(groups[len(groups)-1], tags[j])
+		}
+		sort.SliceStable(groups, func(a, b int) bool { return groups[a][0].Tag < groups[b][0].Tag })
+		sorted := make([]*gedcom.Tag, 0, end-i-1)
+		for _, group := range groups {
+			sorted = append(sorted, group...)
+		}
+		copy(tags[i+1:end], sorted)
+		i = end - 1
+	}
+	return tags
+}
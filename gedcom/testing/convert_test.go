@@ -0,0 +1,131 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// TestAssertConvertRoundTrip tests that a simple 5.5.1 file survives 7.0.
+func TestAssertConvertRoundTrip(t *testing.T) {
+	AssertConvertRoundTrip(t, []byte(validMinimalGEDCOM), gedcom.Version70)
+}
+
+// TestCheckConvertRoundTripAllowed tests that the documented conversion
+// transformations are not reported as loss.
+func TestCheckConvertRoundTripAllowed(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name: "CONC and CONT",
+			input: `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 CHAR ANSEL
+0 @N1@ NOTE First line that is
+1 CONC  continued
+1 CONT Second line
+0 TRLR
+`,
+		},
+		{
+			name: "lowercase xrefs",
+			input: `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @i1@ INDI
+1 FAMS @f1@
+0 @f1@ FAM
+1 HUSB @i1@
+0 TRLR
+`,
+		},
+		{
+			name: "inline citation",
+			input: `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Doe/
+1 SOUR Parish register of St. Mary
+2 PAGE folio 12
+2 TEXT Baptized John, son of Peter
+0 TRLR
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := CheckConvertRoundTrip(strings.NewReader(tt.input), gedcom.Version70)
+			if err != nil {
+				t.Fatalf("CheckConvertRoundTrip() error = %v", err)
+			}
+			if !report.Equal {
+				t.Errorf("unexpected differences:\n%s", report.String())
+			}
+		})
+	}
+}
+
+// TestCheckConvertRoundTripLoss tests that real loss is reported.
+func TestCheckConvertRoundTripLoss(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+1 LANG en-US
+0 @I1@ INDI
+1 NAME John /Doe/
+0 TRLR
+`
+	report, err := CheckConvertRoundTrip(strings.NewReader(input), gedcom.Version551)
+	if err != nil {
+		t.Fatalf("CheckConvertRoundTrip() error = %v", err)
+	}
+	if report.Equal {
+		t.Fatal("expected the narrowed language tag to be reported")
+	}
+	if diff := report.Differences[0]; diff.Path != "Header.Language" {
+		t.Errorf("unexpected difference: %+v", diff)
+	}
+}
+
+// TestCheckConvertRoundTripSameVersion tests that converting to the source
+// version is rejected.
+func TestCheckConvertRoundTripSameVersion(t *testing.T) {
+	_, err := CheckConvertRoundTrip(strings.NewReader(validMinimalGEDCOM), gedcom.Version551)
+	if err == nil {
+		t.Error("expected an error for via equal to the source version")
+	}
+}
+
+// TestAssertConvertRoundTripTestdata tests the 5.5 and 5.5.1 sample files
+// through GEDCOM 7.0.
+func TestAssertConvertRoundTripTestdata(t *testing.T) {
+	var files []string
+	for _, dir := range []string{"gedcom-5.5", "gedcom-5.5.1"} {
+		matches, err := filepath.Glob(filepath.Join("..", "..", "testdata", dir, "*.ged"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		t.Skip("no testdata files found")
+	}
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			AssertConvertRoundTrip(t, data, gedcom.Version70)
+		})
+	}
+}
@@ -23,6 +23,18 @@
 //	    }
 //	}
 //
+// To compare two documents that did not come from a round-trip, such as
+// converter output against a golden document, use AssertDocumentsEqual (or
+// CompareDocuments for a report):
+//
+//	gedcomtesting.AssertDocumentsEqual(t, want, got)
+//
+// To check that version conversion loses nothing, use AssertConvertRoundTrip,
+// which converts through another version and back (e.g. 5.5.1 → 7.0 → 5.5.1)
+// and ignores the transformations the converter is documented to make:
+//
+//	gedcomtesting.AssertConvertRoundTrip(t, data, gedcom.Version70)
+//
 // # Fidelity Contract
 //
 // Round-trip testing compares documents at the semantic level using Record.Tags
@@ -53,6 +65,18 @@
 //     - Skips LineNumber field (expected to change)
 //     - Reports path-based differences for easy debugging
 //
+// # Vendor Interop Harness
+//
+// A vendor corpus is a directory with one subdirectory of .ged files per
+// exporting application. AssertVendorCorpus runs each file through decode,
+// validation, round-trip, and conversion to every other GEDCOM version as a
+// subtest; RunInterop does the same without a *testing.T and returns an
+// InteropMatrix whose Markdown method renders a compatibility table:
+//
+//	samples, _ := gedcomtesting.DiscoverVendorCorpus("testdata/vendors")
+//	matrix := gedcomtesting.RunInterop(samples)
+//	os.WriteFile("COMPATIBILITY.md", []byte(matrix.Markdown()), 0o644)
+//
 // # Design Rationale
 //
 // Record.Tags is the source of truth for lossless preservation, not the Entity
@@ -0,0 +1,53 @@
+package testing
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// AssertDocumentsEqual asserts that two documents are semantically equal,
+// using the same comparison as AssertRoundTrip. It fails the test with the
+// path of every difference, reporting values from want as Before and from
+// got as After.
+//
+// Use it outside round-trip scenarios, such as comparing converter output to
+// a golden document:
+//
+//	got, _, _ := converter.Convert(doc, gedcom.Version70)
+//	want, _ := decoder.Decode(golden)
+//	gedcomtesting.AssertDocumentsEqual(t, want, got)
+func AssertDocumentsEqual(t *testing.T, want, got *gedcom.Document, opts ...Option) {
+	t.Helper()
+
+	report := CompareDocuments(want, got, opts...)
+	if !report.Equal {
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("documents differ (%d differences found):\n", len(report.Differences)))
+		writeDifferences(&sb, report.Differences)
+		t.Error(sb.String())
+	}
+}
+
+// CompareDocuments compares two documents semantically and returns the
+// differences, with values from a as Before and from b as After. Records
+// are compared by position through their Tags; records that have no Tags
+// on either side (built in code rather than decoded) are compared by
+// entity. A nil document differs from any non-nil one.
+func CompareDocuments(a, b *gedcom.Document, opts ...Option) *RoundTripReport {
+	cfg := applyOptions(opts...)
+	report := &RoundTripReport{Equal: true}
+
+	switch {
+	case a == nil && b == nil:
+	case a == nil:
+		report.AddDifference("Document", "nil", "present")
+	case b == nil:
+		report.AddDifference("Document", "present", "nil")
+	default:
+		compareDocuments(a, b, report, cfg)
+	}
+	return report
+}
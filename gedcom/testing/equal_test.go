@@ -0,0 +1,86 @@
+package testing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func decodeString(t *testing.T, input string) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+// TestAssertDocumentsEqual tests that equal documents decoded separately pass.
+func TestAssertDocumentsEqual(t *testing.T) {
+	a := decodeString(t, validMinimalGEDCOM)
+	b := decodeString(t, validMinimalGEDCOM)
+	AssertDocumentsEqual(t, a, b)
+}
+
+// TestCompareDocuments tests the differences reported for arbitrary documents.
+func TestCompareDocuments(t *testing.T) {
+	t.Run("different values", func(t *testing.T) {
+		a := decodeString(t, validMinimalGEDCOM)
+		b := decodeString(t, strings.Replace(validMinimalGEDCOM, "1 SEX M", "1 SEX F", 1))
+
+		report := CompareDocuments(a, b)
+		if report.Equal || len(report.Differences) != 1 {
+			t.Fatalf("expected 1 difference, got %+v", report.Differences)
+		}
+		diff := report.Differences[0]
+		if diff.Path != "Record[@I1@].Tags[3].Value" || diff.Before != "M" || diff.After != "F" {
+			t.Errorf("unexpected difference: %+v", diff)
+		}
+	})
+
+	t.Run("nil documents", func(t *testing.T) {
+		if !CompareDocuments(nil, nil).Equal {
+			t.Error("two nil documents should be equal")
+		}
+		doc := decodeString(t, validMinimalGEDCOM)
+		if report := CompareDocuments(doc, nil); report.Equal || report.Differences[0].Path != "Document" {
+			t.Errorf("nil after: %+v", report.Differences)
+		}
+		if report := CompareDocuments(nil, doc); report.Equal {
+			t.Error("nil before should differ")
+		}
+	})
+
+	t.Run("entity-only records", func(t *testing.T) {
+		build := func(name string) *gedcom.Document {
+			return &gedcom.Document{
+				Header: &gedcom.Header{Version: gedcom.Version70},
+				Records: []*gedcom.Record{{
+					XRef:   "@I1@",
+					Type:   gedcom.RecordTypeIndividual,
+					Entity: &gedcom.Individual{XRef: "@I1@", Names: []*gedcom.PersonalName{{Full: name}}},
+				}},
+			}
+		}
+		if report := CompareDocuments(build("John /Doe/"), build("John /Doe/")); !report.Equal {
+			t.Errorf("equal entities reported different: %+v", report.Differences)
+		}
+		report := CompareDocuments(build("John /Doe/"), build("Jane /Doe/"))
+		if report.Equal || report.Differences[0].Path != "Record[@I1@].Entity" {
+			t.Errorf("entity difference not reported: %+v", report.Differences)
+		}
+	})
+
+	t.Run("header tag option", func(t *testing.T) {
+		a := decodeString(t, validMinimalGEDCOM)
+		b := decodeString(t, strings.Replace(validMinimalGEDCOM, "1 CHAR UTF-8\n", "1 CHAR UTF-8\n1 _X y\n", 1))
+		if !CompareDocuments(a, b).Equal {
+			t.Error("header tags compared without WithHeaderTagComparison")
+		}
+		if CompareDocuments(a, b, WithHeaderTagComparison()).Equal {
+			t.Error("header tags not compared with WithHeaderTagComparison")
+		}
+	})
+}
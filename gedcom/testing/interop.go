@@ -0,0 +1,350 @@
+package testing
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/converter"
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+	"github.com/cacack/gedcom-go/v2/validator"
+)
+
+// VendorSample is one GEDCOM file in a vendor interop corpus.
+type VendorSample struct {
+	// Vendor is the name of the exporting software, taken from the
+	// sample's directory (e.g., "rootsmagic").
+	Vendor string
+
+	// Path is the file's location on disk.
+	Path string
+}
+
+// Name returns the sample's file name without its directory.
+func (s VendorSample) Name() string {
+	return filepath.Base(s.Path)
+}
+
+// DiscoverVendorCorpus returns the GEDCOM files of a vendor corpus laid out
+// as one directory per vendor:
+//
+//	root/
+//	├── ancestry/
+//	│   └── export.ged
+//	└── rootsmagic/
+//	    └── export.ged
+//
+// Files with a ".ged" extension (any case) are collected; other files and
+// nested directories are ignored. Samples are sorted by vendor, then path.
+func DiscoverVendorCorpus(root string) ([]VendorSample, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []VendorSample
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(root, entry.Name())
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if file.IsDir() || !strings.EqualFold(filepath.Ext(file.Name()), ".ged") {
+				continue
+			}
+			samples = append(samples, VendorSample{
+				Vendor: entry.Name(),
+				Path:   filepath.Join(dir, file.Name()),
+			})
+		}
+	}
+
+	sort.Slice(samples, func(i, j int) bool {
+		if samples[i].Vendor != samples[j].Vendor {
+			return samples[i].Vendor < samples[j].Vendor
+		}
+		return samples[i].Path < samples[j].Path
+	})
+	return samples, nil
+}
+
+// ConversionResult is the outcome of converting a sample to one target version.
+type ConversionResult struct {
+	// Target is the version converted to.
+	Target gedcom.Version
+
+	// Err is set if conversion, encoding, or re-decoding the output failed.
+	Err error
+
+	// DataLoss is the number of data-loss items in the conversion report.
+	DataLoss int
+}
+
+// InteropResult is the outcome of running one sample through the
+// decode, validate, convert, and round-trip pipeline.
+type InteropResult struct {
+	// Sample is the file that was checked.
+	Sample VendorSample
+
+	// Err is set if the sample could not be read or decoded; the remaining
+	// fields are then zero.
+	Err error
+
+	// Version is the GEDCOM version declared by the sample.
+	Version gedcom.Version
+
+	// Records is the number of top-level records decoded.
+	Records int
+
+	// Diagnostics is the number of decoder diagnostics.
+	Diagnostics int
+
+	// ValidationErrors and ValidationWarnings count validator issues by severity.
+	ValidationErrors   int
+	ValidationWarnings int
+
+	// RoundTrip is the decode/encode/decode comparison report.
+	RoundTrip *RoundTripReport
+
+	// Conversions holds one result per GEDCOM version other than Version.
+	Conversions []ConversionResult
+}
+
+// Passed reports whether the sample decoded, round-tripped without
+// differences, and converted to every other version. Validation issues and
+// data loss are reported but do not fail a sample; vendor files are rarely
+// fully spec-compliant.
+func (r *InteropResult) Passed() bool {
+	if r.Err != nil || r.RoundTrip == nil || !r.RoundTrip.Equal {
+		return false
+	}
+	for _, c := range r.Conversions {
+		if c.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// interopVersions are the conversion targets exercised by CheckInterop.
+var interopVersions = []gedcom.Version{gedcom.Version55, gedcom.Version551, gedcom.Version70}
+
+// CheckInterop runs a single sample through the interop pipeline:
+//  1. Decode it, counting diagnostics
+//  2. Validate it with the default validator
+//  3. Round-trip it as CheckRoundTrip does
+//  4. Convert it to every other GEDCOM version, encode the result, and
+//     decode the output again
+//
+// It never returns nil; failures are recorded in the result.
+func CheckInterop(sample VendorSample, opts ...Option) *InteropResult {
+	result := &InteropResult{Sample: sample}
+
+	data, err := os.ReadFile(sample.Path)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	decoded, err := decoder.DecodeWithDiagnostics(bytes.NewReader(data), nil)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	doc := decoded.Document
+	result.Diagnostics = len(decoded.Diagnostics)
+	result.Records = len(doc.Records)
+	if doc.Header != nil {
+		result.Version = doc.Header.Version
+	}
+
+	for _, issue := range validator.New().ValidateAll(doc) {
+		switch issue.Severity {
+		case validator.SeverityError:
+			result.ValidationErrors++
+		case validator.SeverityWarning:
+			result.ValidationWarnings++
+		}
+	}
+
+	result.RoundTrip, err = CheckRoundTrip(bytes.NewReader(data), opts...)
+	if err != nil {
+		result.RoundTrip = &RoundTripReport{}
+		result.RoundTrip.AddDifference("RoundTrip", "", err.Error())
+	}
+
+	for _, target := range interopVersions {
+		if target == result.Version {
+			continue
+		}
+		result.Conversions = append(result.Conversions, checkConversion(doc, target))
+	}
+
+	return result
+}
+
+// checkConversion converts doc to target, encodes it, and decodes the output.
+func checkConversion(doc *gedcom.Document, target gedcom.Version) ConversionResult {
+	res := ConversionResult{Target: target}
+
+	converted, report, err := converter.Convert(doc, target)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	res.DataLoss = len(report.DataLoss)
+
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, converted); err != nil {
+		res.Err = fmt.Errorf("encode %s: %w", target, err)
+		return res
+	}
+	if _, err := decoder.Decode(&buf); err != nil {
+		res.Err = fmt.Errorf("decode %s output: %w", target, err)
+	}
+	return res
+}
+
+// InteropMatrix collects the results of a corpus run.
+type InteropMatrix struct {
+	Results []*InteropResult
+}
+
+// RunInterop checks every sample and returns the combined matrix.
+func RunInterop(samples []VendorSample, opts ...Option) *InteropMatrix {
+	matrix := &InteropMatrix{}
+	for _, sample := range samples {
+		matrix.Results = append(matrix.Results, CheckInterop(sample, opts...))
+	}
+	return matrix
+}
+
+// Passed reports whether every sample passed.
+func (m *InteropMatrix) Passed() bool {
+	for _, r := range m.Results {
+		if !r.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Markdown renders the matrix as a Markdown compatibility document with
+// one row per sample.
+func (m *InteropMatrix) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("# Vendor Compatibility Matrix\n\n")
+	sb.WriteString("| Vendor | File | Version | Records | Diagnostics | Errors | Warnings | Round-trip | → 5.5 | → 5.5.1 | → 7.0 | Result |\n")
+	sb.WriteString("|--------|------|---------|---------|-------------|--------|----------|------------|-------|---------|-------|--------|\n")
+
+	for _, r := range m.Results {
+		if r.Err != nil {
+			sb.WriteString(fmt.Sprintf("| %s | %s | | | | | | | | | | FAIL: %s |\n",
+				r.Sample.Vendor, r.Sample.Name(), escapeCell(r.Err.Error())))
+			continue
+		}
+
+		roundTrip := "ok"
+		if !r.RoundTrip.Equal {
+			roundTrip = fmt.Sprintf("%d diffs", len(r.RoundTrip.Differences))
+		}
+		cells := make([]string, 0, len(interopVersions))
+		for _, target := range interopVersions {
+			cells = append(cells, conversionCell(r, target))
+		}
+		status := "PASS"
+		if !r.Passed() {
+			status = "FAIL"
+		}
+
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %d | %d | %d | %s | %s | %s |\n",
+			r.Sample.Vendor, r.Sample.Name(), r.Version, r.Records, r.Diagnostics,
+			r.ValidationErrors, r.ValidationWarnings, roundTrip, strings.Join(cells, " | "), status))
+	}
+
+	return sb.String()
+}
+
+// conversionCell formats the outcome of converting r to target.
+func conversionCell(r *InteropResult, target gedcom.Version) string {
+	if target == r.Version {
+		return "—"
+	}
+	for _, c := range r.Conversions {
+		if c.Target != target {
+			continue
+		}
+		switch {
+		case c.Err != nil:
+			return "FAIL"
+		case c.DataLoss > 0:
+			return fmt.Sprintf("ok (%d lost)", c.DataLoss)
+		default:
+			return "ok"
+		}
+	}
+	return ""
+}
+
+// escapeCell makes s safe for a Markdown table cell.
+func escapeCell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", `\|`), "\n", " ")
+}
+
+// AssertVendorCorpus runs every sample under root (see DiscoverVendorCorpus)
+// as a subtest named "<vendor>/<file>" and fails each one that does not pass.
+// The test is skipped if root does not exist, so a corpus of local,
+// uncommitted vendor files can be wired into a test suite unconditionally.
+// Samples marked with WithKnownIssue are skipped while they still fail.
+//
+// Example:
+//
+//	func TestMyVendorFiles(t *testing.T) {
+//	    gedcomtesting.AssertVendorCorpus(t, "testdata/vendors")
+//	}
+func AssertVendorCorpus(t *testing.T, root string, opts ...Option) *InteropMatrix {
+	t.Helper()
+
+	samples, err := DiscoverVendorCorpus(root)
+	if os.IsNotExist(err) {
+		t.Skipf("vendor corpus %s not found", root)
+	}
+	if err != nil {
+		t.Fatalf("discover vendor corpus: %v", err)
+	}
+
+	cfg := applyOptions(opts...)
+	matrix := &InteropMatrix{}
+	for _, sample := range samples {
+		result := CheckInterop(sample, opts...)
+		matrix.Results = append(matrix.Results, result)
+		name := sample.Vendor + "/" + sample.Name()
+		t.Run(name, func(t *testing.T) {
+			if reason, ok := cfg.knownIssues[name]; ok && !result.Passed() {
+				t.Skipf("known issue: %s", reason)
+			}
+			switch {
+			case result.Err != nil:
+				t.Errorf("decode failed: %v", result.Err)
+			case !result.RoundTrip.Equal:
+				t.Errorf("round-trip produced differences:\n%s", result.RoundTrip.String())
+			}
+			for _, c := range result.Conversions {
+				if c.Err != nil {
+					t.Errorf("conversion to %s failed: %v", c.Target, c.Err)
+				}
+			}
+		})
+	}
+	return matrix
+}
@@ -0,0 +1,115 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// TestVendorCorpus runs the committed vendor corpus through the interop
+// harness. Set GEDCOM_INTEROP_MATRIX to a file path to write the resulting
+// compatibility matrix.
+func TestVendorCorpus(t *testing.T) {
+	const newlines70 = "7.0 encoder writes embedded newlines instead of CONT lines"
+	const extensionRoots = "encoder loses values on custom extension root records"
+	matrix := AssertVendorCorpus(t, "../../testdata/vendors",
+		WithKnownIssue("ancestry/ancestry-2025-export.ged", newlines70),
+		WithKnownIssue("familysearch/familysearch-2025-export.ged", newlines70),
+		WithKnownIssue("gramps/gramps-2025-export.ged", newlines70),
+		WithKnownIssue("gramps/vendor-gramps.ged", newlines70),
+		WithKnownIssue("legacy/vendor-legacy.ged", extensionRoots+" (_EVENT_DEFN); "+newlines70),
+		WithKnownIssue("rootsmagic/rootsmagic-2026-export.ged", extensionRoots+" (_EVDEF); "+newlines70),
+	)
+	if len(matrix.Results) == 0 {
+		t.Fatal("vendor corpus is empty")
+	}
+
+	if path := os.Getenv("GEDCOM_INTEROP_MATRIX"); path != "" {
+		if err := os.WriteFile(path, []byte(matrix.Markdown()), 0o644); err != nil {
+			t.Fatalf("write matrix: %v", err)
+		}
+	}
+}
+
+// TestLocalVendorCorpus runs uncommitted vendor files dropped into
+// testdata/vendors-local/<vendor>/. It is skipped when that directory is absent.
+func TestLocalVendorCorpus(t *testing.T) {
+	AssertVendorCorpus(t, "../../testdata/vendors-local")
+}
+
+func TestDiscoverVendorCorpus(t *testing.T) {
+	root := t.TempDir()
+	writeSample := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	minimal := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 CHAR UTF-8\n0 @I1@ INDI\n1 NAME John /Doe/\n0 TRLR\n"
+	writeSample("zeta/export.GED", minimal)
+	writeSample("alpha/b.ged", minimal)
+	writeSample("alpha/a.ged", minimal)
+	writeSample("alpha/readme.txt", "not a sample")
+	writeSample("loose.ged", minimal)
+
+	samples, err := DiscoverVendorCorpus(root)
+	if err != nil {
+		t.Fatalf("DiscoverVendorCorpus() error = %v", err)
+	}
+	var got []string
+	for _, s := range samples {
+		got = append(got, s.Vendor+"/"+s.Name())
+	}
+	want := "alpha/a.ged,alpha/b.ged,zeta/export.GED"
+	if strings.Join(got, ",") != want {
+		t.Errorf("samples = %v, want %s", got, want)
+	}
+
+	if _, err := DiscoverVendorCorpus(filepath.Join(root, "missing")); !os.IsNotExist(err) {
+		t.Errorf("missing root error = %v, want not-exist", err)
+	}
+}
+
+func TestCheckInterop(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "sample.ged")
+	content := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 CHAR UTF-8\n0 @I1@ INDI\n1 NAME John /Doe/\n1 BIRT\n2 DATE 1 JAN 1900\n0 TRLR\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := CheckInterop(VendorSample{Vendor: "test", Path: path})
+	if result.Err != nil {
+		t.Fatalf("CheckInterop() Err = %v", result.Err)
+	}
+	if result.Version != gedcom.Version551 || result.Records != 1 {
+		t.Errorf("Version = %s, Records = %d", result.Version, result.Records)
+	}
+	if len(result.Conversions) != 2 {
+		t.Fatalf("Conversions = %+v, want 2 targets", result.Conversions)
+	}
+	if !result.Passed() {
+		t.Errorf("Passed() = false: %+v", result)
+	}
+
+	matrix := &InteropMatrix{Results: []*InteropResult{
+		result,
+		CheckInterop(VendorSample{Vendor: "test", Path: filepath.Join(root, "missing.ged")}),
+	}}
+	if matrix.Passed() {
+		t.Error("matrix with a missing file should not pass")
+	}
+	md := matrix.Markdown()
+	for _, want := range []string{"| test | sample.ged | 5.5.1 | 1 |", "| — |", "PASS", "| test | missing.ged |", "FAIL:"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown() missing %q:\n%s", want, md)
+		}
+	}
+}
@@ -9,6 +9,10 @@ type roundTripConfig struct {
 	// By default, header tags are not compared because the encoder
 	// reconstructs the header from Header fields.
 	compareHeaderTags bool
+
+	// knownIssues maps "<vendor>/<file>" corpus samples to the reason they
+	// are expected to fail; AssertVendorCorpus skips them.
+	knownIssues map[string]string
 }
 
 // defaultConfig returns the default configuration.
@@ -36,3 +40,16 @@ func WithHeaderTagComparison() Option {
 		cfg.compareHeaderTags = true
 	}
 }
+
+// WithKnownIssue marks a vendor corpus sample, named "<vendor>/<file>", as
+// expected to fail for the given reason. AssertVendorCorpus skips the
+// sample's subtest instead of failing it; the sample is still checked and
+// reported in the returned matrix.
+func WithKnownIssue(sample, reason string) Option {
+	return func(cfg *roundTripConfig) {
+		if cfg.knownIssues == nil {
+			cfg.knownIssues = make(map[string]string)
+		}
+		cfg.knownIssues[sample] = reason
+	}
+}
@@ -47,14 +47,18 @@ func (r *RoundTripReport) String() string {
 
 	sb.WriteString(fmt.Sprintf("Round-trip: FAILED (%d differences found)\n", len(r.Differences)))
 	sb.WriteString("\n")
+	writeDifferences(&sb, r.Differences)
 
-	for i, diff := range r.Differences {
+	return sb.String()
+}
+
+// writeDifferences writes one numbered entry per difference to sb.
+func writeDifferences(sb *strings.Builder, diffs []Difference) {
+	for i, diff := range diffs {
 		sb.WriteString(fmt.Sprintf("  [%d] %s\n", i+1, diff.Path))
 		sb.WriteString(fmt.Sprintf("      Before: %q\n", diff.Before))
 		sb.WriteString(fmt.Sprintf("      After:  %q\n", diff.After))
 	}
-
-	return sb.String()
 }
 
 // AddDifference adds a difference to the report and sets Equal to false.
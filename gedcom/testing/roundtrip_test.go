@@ -684,15 +684,15 @@ func TestCheckRoundTrip_WithRealFiles(t *testing.T) {
 		},
 		{
 			name: "vendor tags (Ancestry)",
-			file: "../../testdata/edge-cases/ancestry-extensions.ged",
+			file: "../../testdata/vendors/ancestry/ancestry-extensions.ged",
 		},
 		{
 			name: "vendor tags (FamilySearch)",
-			file: "../../testdata/edge-cases/familysearch-extensions.ged",
+			file: "../../testdata/vendors/familysearch/familysearch-extensions.ged",
 		},
 		{
 			name: "vendor tags (Gramps)",
-			file: "../../testdata/edge-cases/vendor-gramps.ged",
+			file: "../../testdata/vendors/gramps/vendor-gramps.ged",
 		},
 
 		// GEDCOM 5.5 complex structures
@@ -837,51 +837,51 @@ func TestCheckRoundTrip_WithRealFiles(t *testing.T) {
 		// Real vendor exports (2025/2026)
 		{
 			name: "Ancestry 2025 export",
-			file: "../../testdata/edge-cases/ancestry-2025-export.ged",
+			file: "../../testdata/vendors/ancestry/ancestry-2025-export.ged",
 		},
 		{
 			name: "Gramps 2025 export",
-			file: "../../testdata/edge-cases/gramps-2025-export.ged",
+			file: "../../testdata/vendors/gramps/gramps-2025-export.ged",
 		},
 		{
 			name: "FamilySearch 2025 export",
-			file: "../../testdata/edge-cases/familysearch-2025-export.ged",
+			file: "../../testdata/vendors/familysearch/familysearch-2025-export.ged",
 		},
 		{
 			name: "MyHeritage 2025 export",
-			file: "../../testdata/edge-cases/myheritage-2025-export.ged",
+			file: "../../testdata/vendors/myheritage/myheritage-2025-export.ged",
 		},
 		{
 			name: "RootsMagic 2026 export",
-			file: "../../testdata/edge-cases/rootsmagic-2026-export.ged",
+			file: "../../testdata/vendors/rootsmagic/rootsmagic-2026-export.ged",
 			skip: "encoder loses values on custom extension root records (_EVDEF)",
 		},
 
 		// Vendor-specific files
 		{
 			name: "vendor Family Historian",
-			file: "../../testdata/edge-cases/vendor-familyhistorian.ged",
+			file: "../../testdata/vendors/familyhistorian/vendor-familyhistorian.ged",
 		},
 		{
 			name: "vendor FTM",
-			file: "../../testdata/edge-cases/vendor-ftm.ged",
+			file: "../../testdata/vendors/ftm/vendor-ftm.ged",
 		},
 		{
 			name: "vendor Heredis",
-			file: "../../testdata/edge-cases/vendor-heredis.ged",
+			file: "../../testdata/vendors/heredis/vendor-heredis.ged",
 		},
 		{
 			name: "vendor Legacy",
-			file: "../../testdata/edge-cases/vendor-legacy.ged",
+			file: "../../testdata/vendors/legacy/vendor-legacy.ged",
 			skip: "encoder loses values on custom extension root records (_EVENT_DEFN)",
 		},
 		{
 			name: "vendor MyHeritage",
-			file: "../../testdata/edge-cases/vendor-myheritage.ged",
+			file: "../../testdata/vendors/myheritage/vendor-myheritage.ged",
 		},
 		{
 			name: "vendor RootsMagic",
-			file: "../../testdata/edge-cases/vendor-rootsmagic.ged",
+			file: "../../testdata/vendors/rootsmagic/vendor-rootsmagic.ged",
 		},
 
 		// FTM-specific edge cases
@@ -891,7 +891,7 @@ func TestCheckRoundTrip_WithRealFiles(t *testing.T) {
 		},
 		{
 			name: "FTM general",
-			file: "../../testdata/edge-cases/ftm-general.ged",
+			file: "../../testdata/vendors/ftm/ftm-general.ged",
 		},
 		{
 			name: "FTM link test",
@@ -1122,3 +1122,33 @@ func TestCheckRoundTrip_ComplexStructures(t *testing.T) {
 		})
 	}
 }
+
+// TestFoldContinuations tests that records split at different CONT and
+// CONC points compare equal.
+func TestFoldContinuations(t *testing.T) {
+	value, tags := foldContinuations("Shared ", []*gedcom.Tag{
+		{Level: 1, Tag: "CONC", Value: "note"},
+		{Level: 1, Tag: "NOTE", Value: "Long "},
+		{Level: 2, Tag: "CONC", Value: "text"},
+		{Level: 2, Tag: "CONT", Value: "Next "},
+		{Level: 2, Tag: "CONC", Value: "line"},
+		{Level: 2, Tag: "SOUR", Value: "@S1@"},
+	})
+	if value != "Shared note" {
+		t.Errorf("value = %q, want %q", value, "Shared note")
+	}
+	want := []string{"NOTE Long text\nNext line", "SOUR @S1@"}
+	if len(tags) != len(want) {
+		t.Fatalf("got %d tags, want %d", len(tags), len(want))
+	}
+	for i, tag := range tags {
+		if got := tag.Tag + " " + tag.Value; got != want[i] {
+			t.Errorf("tags[%d] = %q, want %q", i, got, want[i])
+		}
+	}
+
+	// Long raw text survives the encoder's CONC splitting
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 CHAR UTF-8\n0 @I1@ INDI\n1 NOTE " +
+		strings.Repeat("word ", 80) + "end\n0 TRLR\n"
+	AssertRoundTrip(t, []byte(input))
+}
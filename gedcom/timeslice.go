@@ -0,0 +1,401 @@
+package gedcom
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// YearRange is an inclusive range of years, e.g. YearRange{From: 1850, To:
+// 1860}. A range with To before From matches nothing.
+type YearRange struct {
+	From int
+	To   int
+}
+
+// String returns "1850" for a single year and "1850-1860" otherwise.
+func (r YearRange) String() string {
+	if r.From == r.To {
+		return strconv.Itoa(r.From)
+	}
+	return fmt.Sprintf("%d-%d", r.From, r.To)
+}
+
+// TimeSliceMatch is one result of a time-slice query (AliveIn, MarriedIn,
+// ResidentsOf).
+type TimeSliceMatch struct {
+	// Individual is the matching individual.
+	Individual *Individual
+
+	// Family is the family whose marriage matched (MarriedIn only).
+	Family *Family
+
+	// Event is the event that placed the individual at the place
+	// (ResidentsOf only).
+	Event *Event
+
+	// Certain is true when the evidence establishes the match for every
+	// reading of the dates involved; false when the match depends on
+	// approximate, open-ended, or inferred dates.
+	Certain bool
+
+	// Evidence explains which dates satisfied the query, one entry per
+	// piece of evidence, e.g. "BIRT ABT 1820 (1815-1825)" or "no death
+	// date: assumed within 100 years of birth".
+	Evidence []string
+}
+
+const (
+	// approximateYears is how far either side of its year an ABT, CAL, or
+	// EST date may fall.
+	approximateYears = 5
+
+	// maximumLifespan bounds a life that lacks a birth or death date.
+	maximumLifespan = 100
+
+	// openYear stands in for an unbounded end of a year span.
+	openYear = 1 << 30
+)
+
+// yearSpan is the range of years a date may denote. exact is set when
+// the bounds are not widened or open-ended; period is set for FROM...TO
+// dates, which hold throughout the span rather than at one point in it.
+type yearSpan struct {
+	lo, hi int
+	exact  bool
+	period bool
+}
+
+// AliveIn returns the individuals who may have been alive in year, in
+// document order.
+//
+// Birth is read from BIRT, or inferred from CHR, BAPM, or CHRA (up to
+// five years earlier); death from DEAT, or inferred from BURI or CREM (at
+// or before). Any other dated event shows the individual was alive then. ABT, CAL, and EST dates are widened by
+// five years either way, BEF/AFT/FROM/TO dates are open-ended, and a
+// missing birth or death is bounded by a 100-year lifespan. Individuals
+// with no usable date at all are not returned. A match is Certain only if
+// the individual was alive in year under every reading of the evidence.
+func (d *Document) AliveIn(year int) []TimeSliceMatch {
+	var matches []TimeSliceMatch
+	for _, ind := range d.Individuals() {
+		life, evidence, ok := lifeSpan(ind)
+		if !ok || year < life.birth.lo || year > life.death.hi {
+			continue
+		}
+		matches = append(matches, TimeSliceMatch{
+			Individual: ind,
+			Certain:    year >= life.birth.hi && year <= life.death.lo,
+			Evidence:   evidence,
+		})
+	}
+	return matches
+}
+
+// MarriedIn returns the spouses of families that may have been married at
+// some time within r, one match per spouse, in document order.
+//
+// A marriage begins at its MARR date and ends at the earlier of a DIV
+// date and either spouse's death (estimated as for AliveIn), or 100 years
+// after the marriage when neither is known. A match is Certain only if the
+// couple was married within r under every reading of the evidence.
+func (d *Document) MarriedIn(r YearRange) []TimeSliceMatch {
+	var matches []TimeSliceMatch
+	for _, fam := range d.Families() {
+		marr := datedEvent(fam.Events, EventMarriage)
+		if marr == nil {
+			continue
+		}
+		begin, _ := eventYearSpan(marr)
+		evidence := []string{describeEvent(marr, begin)}
+
+		end := yearSpan{lo: begin.hi, hi: begin.hi + maximumLifespan}
+		endKnown := false
+		if div := datedEvent(fam.Events, EventDivorce); div != nil {
+			span, _ := eventYearSpan(div)
+			end = span
+			endKnown = true
+			evidence = append(evidence, describeEvent(div, span))
+		}
+		spouses := d.spouses(fam)
+		for _, spouse := range spouses {
+			life, _, ok := lifeSpan(spouse)
+			if !ok {
+				continue
+			}
+			if !endKnown || life.death.hi < end.hi {
+				end.hi = life.death.hi
+			}
+			if !endKnown || life.death.lo < end.lo {
+				end.lo = life.death.lo
+			}
+			endKnown = true
+			evidence = append(evidence, spouse.XRef+" "+life.deathNote)
+		}
+		if !endKnown {
+			evidence = append(evidence, fmt.Sprintf("no end of marriage: assumed within %d years", maximumLifespan))
+		}
+
+		if begin.lo > r.To || end.hi < r.From {
+			continue
+		}
+		certain := begin.hi <= r.To && end.lo >= max(r.From, begin.hi)
+		for _, spouse := range spouses {
+			matches = append(matches, TimeSliceMatch{
+				Individual: spouse,
+				Family:     fam,
+				Certain:    certain,
+				Evidence:   evidence,
+			})
+		}
+	}
+	return matches
+}
+
+// ResidentsOf returns the individuals with a dated event at place within
+// r, in document order. Places match as in EventsAtPlace with fuzzy
+// matching, and any event at the place counts (residence and census
+// records, but also births, marriages, and burials). Family events count
+// for both spouses. An individual is returned once, with the first
+// matching event; the match is Certain when that event's date lies within
+// r, or is a FROM...TO period overlapping r, under every reading.
+func (d *Document) ResidentsOf(place string, r YearRange) []TimeSliceMatch {
+	var matches []TimeSliceMatch
+	seen := make(map[*Individual]bool)
+	for _, at := range d.EventsAtPlace(place, true) {
+		span, ok := eventYearSpan(at.Event)
+		if !ok || span.lo > r.To || span.hi < r.From {
+			continue
+		}
+		certain := span.exact && (span.period || span.lo >= r.From && span.hi <= r.To)
+		var people []*Individual
+		if ind := d.GetIndividual(at.RecordXRef); ind != nil {
+			people = append(people, ind)
+		} else if fam := d.GetFamily(at.RecordXRef); fam != nil {
+			people = d.spouses(fam)
+		}
+		for _, ind := range people {
+			if seen[ind] {
+				continue
+			}
+			seen[ind] = true
+			matches = append(matches, TimeSliceMatch{
+				Individual: ind,
+				Event:      at.Event,
+				Certain:    certain,
+				Evidence:   []string{describeEvent(at.Event, span) + " at " + at.Event.Place},
+			})
+		}
+	}
+	return matches
+}
+
+// spouses returns the husband and wife of fam that resolve in d.
+func (d *Document) spouses(fam *Family) []*Individual {
+	var spouses []*Individual
+	for _, xref := range []string{fam.Husband, fam.Wife} {
+		if ind := d.GetIndividual(xref); xref != "" && ind != nil {
+			spouses = append(spouses, ind)
+		}
+	}
+	return spouses
+}
+
+// life is the estimated birth and death year spans of an individual.
+type life struct {
+	birth, death yearSpan
+	deathNote    string
+}
+
+var (
+	birthEvents      = []EventType{EventBirth, EventChristening, EventBaptism, EventAdultChristening}
+	deathEvents      = []EventType{EventDeath, EventBurial, EventCremation}
+	postMortemEvents = map[EventType]bool{EventBurial: true, EventCremation: true, EventProbate: true}
+)
+
+// lifeSpan estimates when ind was born and died, with the evidence used.
+// ok is false when ind has no usable date.
+func lifeSpan(ind *Individual) (l life, evidence []string, ok bool) {
+	birth, birthEvent := firstSpan(ind.Events, birthEvents)
+	death, deathEvent := firstSpan(ind.Events, deathEvents)
+
+	firstAlive, lastAlive, aliveEvidence := aliveYears(ind.Events, birthEvent, deathEvent)
+	if birthEvent == nil && deathEvent == nil && len(aliveEvidence) == 0 {
+		return life{}, nil, false
+	}
+
+	if birthEvent == nil {
+		birth = yearSpan{lo: -openYear, hi: openYear}
+	} else {
+		if birthEvent.Type != EventBirth {
+			// Born on or before the baptism, usually shortly before.
+			birth.lo -= approximateYears
+		}
+		evidence = append(evidence, describeEvent(birthEvent, birth))
+	}
+	if deathEvent == nil {
+		death = yearSpan{lo: -openYear, hi: openYear}
+	} else {
+		if deathEvent.Type != EventDeath {
+			// Died on or before the burial; how long before is unknown.
+			death.lo = -openYear
+		}
+		l.deathNote = describeEvent(deathEvent, death)
+		evidence = append(evidence, l.deathNote)
+	}
+	evidence = append(evidence, aliveEvidence...)
+
+	birth.hi = min(birth.hi, firstAlive, death.hi)
+	death.lo = max(death.lo, lastAlive, birth.lo)
+	l.birth, l.death = birth, death
+	evidence = l.closeOpenEnds(evidence, birthEvent == nil)
+	return l, evidence, true
+}
+
+// aliveYears returns the years of the dated events other than the birth
+// and death events and those after death, which show the individual alive
+// at the time: born by the earliest of them and not dead before the latest.
+func aliveYears(events []*Event, birthEvent, deathEvent *Event) (firstAlive, lastAlive int, evidence []string) {
+	firstAlive, lastAlive = openYear, -openYear
+	for _, e := range events {
+		if e == nil || e.IsNegative || e == birthEvent || e == deathEvent || postMortemEvents[e.Type] || e.Type == EventDeath {
+			continue
+		}
+		span, ok := eventYearSpan(e)
+		if !ok {
+			continue
+		}
+		if span.period {
+			firstAlive = min(firstAlive, span.lo)
+			lastAlive = max(lastAlive, span.hi)
+		} else {
+			firstAlive = min(firstAlive, span.hi)
+			lastAlive = max(lastAlive, span.lo)
+		}
+		evidence = append(evidence, describeEvent(e, span))
+	}
+	return firstAlive, lastAlive, evidence
+}
+
+// closeOpenEnds closes the open ends of l with the maximum lifespan and
+// returns evidence with the assumptions made. noBirth reports whether the
+// individual had no birth event.
+func (l *life) closeOpenEnds(evidence []string, noBirth bool) []string {
+	if l.death.hi >= openYear && l.birth.hi < openYear {
+		l.death.hi = l.birth.hi + maximumLifespan
+		l.deathNote = fmt.Sprintf("no death date (assumed by %d)", l.death.hi)
+		evidence = append(evidence, fmt.Sprintf("no death date: assumed within %d years of birth", maximumLifespan))
+	}
+	if l.birth.lo <= -openYear {
+		if l.death.lo > -openYear {
+			l.birth.lo = l.death.lo - maximumLifespan
+		} else {
+			l.birth.lo = l.birth.hi - maximumLifespan
+		}
+		if noBirth {
+			evidence = append(evidence, fmt.Sprintf("no birth date: assumed within %d years of death or last event", maximumLifespan))
+		}
+	}
+	return evidence
+}
+
+// firstSpan returns the year span of the first dated event whose type is
+// earliest in types.
+func firstSpan(events []*Event, types []EventType) (yearSpan, *Event) {
+	for _, t := range types {
+		if e := datedEvent(events, t); e != nil {
+			span, _ := eventYearSpan(e)
+			return span, e
+		}
+	}
+	return yearSpan{}, nil
+}
+
+// datedEvent returns the first non-negative event of type t with a usable
+// date, or nil.
+func datedEvent(events []*Event, t EventType) *Event {
+	for _, e := range events {
+		if e == nil || e.Type != t || e.IsNegative {
+			continue
+		}
+		if _, ok := eventYearSpan(e); ok {
+			return e
+		}
+	}
+	return nil
+}
+
+// eventYearSpan returns the years the event's date may denote. ok is
+// false when the date is missing, a phrase, or cannot be placed on the
+// Gregorian calendar.
+func eventYearSpan(e *Event) (yearSpan, bool) {
+	date := e.ParsedDate
+	if date == nil && e.Date != "" {
+		date, _ = ParseDate(e.Date)
+	}
+	return dateYearSpan(date)
+}
+
+// dateYearSpan converts a date to the range of Gregorian years it may
+// denote, widening approximate dates and opening BEF/AFT/FROM/TO dates.
+func dateYearSpan(date *Date) (yearSpan, bool) {
+	lo, ok := gregorianYear(date)
+	if !ok {
+		return yearSpan{}, false
+	}
+	hi := lo
+	if date.DualYear != 0 && date.Calendar == CalendarGregorian {
+		hi = lo + 1
+	}
+	span := yearSpan{lo: lo, hi: hi, exact: true}
+	switch date.Modifier {
+	case ModifierAbout, ModifierCalculated, ModifierEstimated:
+		span = yearSpan{lo: lo - approximateYears, hi: hi + approximateYears}
+	case ModifierBefore, ModifierTo:
+		span = yearSpan{lo: -openYear, hi: hi}
+	case ModifierAfter, ModifierFrom:
+		span = yearSpan{lo: lo, hi: openYear}
+	case ModifierBetween, ModifierFromTo:
+		end, ok := gregorianYear(date.EndDate)
+		if !ok {
+			end = openYear
+		}
+		span = yearSpan{lo: lo, hi: end, exact: ok, period: date.Modifier == ModifierFromTo}
+	}
+	return span, true
+}
+
+// gregorianYear returns the Gregorian year of date, negative for B.C.
+func gregorianYear(date *Date) (int, bool) {
+	if date == nil || date.IsPhrase || date.Year == 0 {
+		return 0, false
+	}
+	if date.Calendar != CalendarGregorian {
+		converted, err := date.ToGregorian()
+		if err != nil {
+			return 0, false
+		}
+		date = converted
+	}
+	if date.IsBC {
+		return -date.Year, true
+	}
+	return date.Year, true
+}
+
+// describeEvent renders an event and its year span for TimeSliceMatch
+// evidence, e.g. "BIRT ABT 1820 (1815-1825)".
+func describeEvent(e *Event, span yearSpan) string {
+	s := string(e.Type) + " " + e.Date
+	if e.Date == "" && e.ParsedDate != nil {
+		s = string(e.Type) + " " + e.ParsedDate.String()
+	}
+	switch {
+	case span.lo <= -openYear && span.hi < openYear:
+		s += fmt.Sprintf(" (by %d)", span.hi)
+	case span.hi >= openYear && span.lo > -openYear:
+		s += fmt.Sprintf(" (from %d)", span.lo)
+	case span.lo != span.hi:
+		s += fmt.Sprintf(" (%d-%d)", span.lo, span.hi)
+	}
+	return s
+}
@@ -0,0 +1,168 @@
+package gedcom
+
+import (
+	"strings"
+	"testing"
+)
+
+func timeSliceDocument() *Document {
+	event := func(t EventType, date, place string) *Event {
+		parsed, _ := ParseDate(date)
+		return &Event{Type: t, Date: date, ParsedDate: parsed, Place: place}
+	}
+	individuals := []*Individual{
+		{XRef: "@I1@", Events: []*Event{
+			event(EventBirth, "1800", "Ballyduff, Kerry, Ireland"),
+			event(EventDeath, "1870", ""),
+		}},
+		{XRef: "@I2@", Events: []*Event{
+			event(EventChristening, "ABT 1805", ""),
+			event(EventResidence, "FROM 1840 TO 1850", "Ballyduff, Ireland"),
+		}},
+		{XRef: "@I3@", Events: []*Event{
+			event(EventCensus, "1851", "Boston, Massachusetts"),
+			event(EventBurial, "1880", ""),
+		}},
+		{XRef: "@I4@", Events: []*Event{
+			event(EventResidence, "(unknown)", "Ballyduff"),
+		}},
+	}
+	fam := &Family{XRef: "@F1@", Husband: "@I1@", Wife: "@I2@", Events: []*Event{
+		event(EventMarriage, "BET 1825 AND 1830", "Ballyduff, Kerry, Ireland"),
+	}}
+
+	doc := &Document{XRefMap: make(map[string]*Record)}
+	for _, ind := range individuals {
+		doc.Records = append(doc.Records, &Record{XRef: ind.XRef, Type: RecordTypeIndividual, Entity: ind})
+	}
+	doc.Records = append(doc.Records, &Record{XRef: fam.XRef, Type: RecordTypeFamily, Entity: fam})
+	for _, record := range doc.Records {
+		doc.XRefMap[record.XRef] = record
+	}
+	return doc
+}
+
+// matchSummary renders matches as "XREF" or "XREF?" for uncertain ones.
+func matchSummary(matches []TimeSliceMatch) string {
+	var parts []string
+	for _, m := range matches {
+		s := m.Individual.XRef
+		if !m.Certain {
+			s += "?"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestDocument_AliveIn(t *testing.T) {
+	doc := timeSliceDocument()
+	tests := []struct {
+		year int
+		want string
+	}{
+		{1795, "@I2@? @I3@?"},
+		{1802, "@I1@ @I2@? @I3@?"},
+		{1845, "@I1@ @I2@ @I3@?"},
+		{1860, "@I1@ @I2@? @I3@?"},
+		{1851, "@I1@ @I2@? @I3@"},
+		{1875, "@I2@? @I3@?"},
+		{1960, ""},
+	}
+	for _, tt := range tests {
+		if got := matchSummary(doc.AliveIn(tt.year)); got != tt.want {
+			t.Errorf("AliveIn(%d) = %q, want %q", tt.year, got, tt.want)
+		}
+	}
+
+	evidence := strings.Join(doc.AliveIn(1845)[1].Evidence, "; ")
+	for _, want := range []string{"CHR ABT 1805 (1795-1810)", "RESI FROM 1840 TO 1850 (1840-1850)", "no death date"} {
+		if !strings.Contains(evidence, want) {
+			t.Errorf("evidence %q missing %q", evidence, want)
+		}
+	}
+}
+
+func TestDocument_MarriedIn(t *testing.T) {
+	doc := timeSliceDocument()
+	tests := []struct {
+		r    YearRange
+		want string
+	}{
+		{YearRange{From: 1820, To: 1824}, ""},
+		{YearRange{From: 1828, To: 1828}, "@I1@? @I2@?"},
+		{YearRange{From: 1840, To: 1850}, "@I1@ @I2@"},
+		{YearRange{From: 1871, To: 1880}, ""},
+	}
+	for _, tt := range tests {
+		if got := matchSummary(doc.MarriedIn(tt.r)); got != tt.want {
+			t.Errorf("MarriedIn(%v) = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+	if m := doc.MarriedIn(YearRange{From: 1840, To: 1850}); m[0].Family.XRef != "@F1@" || !strings.Contains(strings.Join(m[0].Evidence, "; "), "@I1@ DEAT 1870") {
+		t.Errorf("MarriedIn evidence = %+v", m[0])
+	}
+}
+
+func TestDocument_ResidentsOf(t *testing.T) {
+	doc := timeSliceDocument()
+	tests := []struct {
+		place string
+		r     YearRange
+		want  string
+	}{
+		{"Ballyduff", YearRange{From: 1800, To: 1800}, "@I1@"},
+		{"Ballyduff", YearRange{From: 1826, To: 1826}, "@I1@? @I2@?"},
+		{"Ballyduff, Ireland", YearRange{From: 1845, To: 1845}, "@I2@"},
+		{"Boston", YearRange{From: 1850, To: 1860}, "@I3@"},
+		{"Boston", YearRange{From: 1800, To: 1810}, ""},
+	}
+	for _, tt := range tests {
+		if got := matchSummary(doc.ResidentsOf(tt.place, tt.r)); got != tt.want {
+			t.Errorf("ResidentsOf(%q, %v) = %q, want %q", tt.place, tt.r, got, tt.want)
+		}
+	}
+	m := doc.ResidentsOf("Boston", YearRange{From: 1851, To: 1851})
+	if len(m) != 1 || m[0].Event.Type != EventCensus || m[0].Evidence[0] != "CENS 1851 at Boston, Massachusetts" {
+		t.Errorf("ResidentsOf evidence = %+v", m)
+	}
+}
+
+func TestDateYearSpan(t *testing.T) {
+	tests := []struct {
+		date   string
+		lo, hi int
+		exact  bool
+	}{
+		{"1850", 1850, 1850, true},
+		{"12 MAR 1750/51", 1750, 1751, true},
+		{"ABT 1850", 1845, 1855, false},
+		{"BEF 1850", -openYear, 1850, false},
+		{"AFT 1850", 1850, openYear, false},
+		{"BET 1850 AND 1860", 1850, 1860, true},
+		{"FROM 1850", 1850, openYear, false},
+		{"44 BC", -44, -44, true},
+	}
+	for _, tt := range tests {
+		date, err := ParseDate(tt.date)
+		if err != nil {
+			t.Fatalf("ParseDate(%q) error = %v", tt.date, err)
+		}
+		span, ok := dateYearSpan(date)
+		if !ok || span.lo != tt.lo || span.hi != tt.hi || span.exact != tt.exact {
+			t.Errorf("dateYearSpan(%q) = %+v, %v; want [%d, %d] exact=%v", tt.date, span, ok, tt.lo, tt.hi, tt.exact)
+		}
+	}
+	if _, ok := dateYearSpan(&Date{IsPhrase: true, Phrase: "unknown"}); ok {
+		t.Error("phrase date: expected ok = false")
+	}
+}
+
+func TestYearRange_String(t *testing.T) {
+	if got := (YearRange{From: 1850, To: 1850}).String(); got != "1850" {
+		t.Errorf("String() = %q, want 1850", got)
+	}
+	if got := (YearRange{From: 1850, To: 1860}).String(); got != "1850-1860" {
+		t.Errorf("String() = %q, want 1850-1860", got)
+	}
+}
@@ -0,0 +1,128 @@
+package gedcom
+
+import (
+	"strings"
+	"time"
+)
+
+// timeNow returns the current time; tests replace it.
+var timeNow = time.Now
+
+// SetAutoTimestamps turns automatic CREA and CHAN maintenance on or off.
+// When on, AddRecord gives each new record a CHAN of the current time, and
+// a CREA if it has none and the document is GEDCOM 7.0 (5.5.1 has no CREA);
+// TouchRecord updates the CHAN of a modified record. Times are UTC, marked
+// with Z in 7.0. It is off by default, so records keep the dates they were
+// decoded or built with.
+//
+// The dates are set on the record's raw Tags, which the encoder writes when
+// present, and on the ChangeDate and CreationDate fields of its entity. A
+// record without Tags whose entity lacks the field (repositories,
+// submitters, and notes have none) keeps no date.
+func (d *Document) SetAutoTimestamps(enabled bool) {
+	if d.index == nil {
+		d.index = &documentIndex{}
+	}
+	d.index.timestamps = enabled
+}
+
+// AutoTimestamps reports whether automatic CREA and CHAN maintenance is on.
+func (d *Document) AutoTimestamps() bool {
+	return d.index != nil && d.index.timestamps
+}
+
+// TouchRecord sets the CHAN of the record with the given XRef to the current
+// time, whether or not automatic timestamps are on, and reports whether the
+// record exists. Call it after modifying a record.
+func (d *Document) TouchRecord(xref string) bool {
+	defer d.lock()()
+	if !d.indexed() {
+		d.reindex()
+	}
+	record := d.XRefMap[xref]
+	if record == nil {
+		return false
+	}
+	d.stamp(record, false)
+	return true
+}
+
+// stamp sets the CHAN of record to the current time, and its CREA too when
+// created is true and it has none.
+func (d *Document) stamp(record *Record, created bool) {
+	// Without a header the version defaults as the decoder and encoder do,
+	// to 5.5, not 7.0.
+	v7 := d.Header != nil && d.Header.Version == Version70
+	now := timeNow().UTC()
+	cd := &ChangeDate{
+		Date: strings.ToUpper(now.Format("2 Jan 2006")),
+		Time: now.Format("15:04:05"),
+	}
+	if v7 {
+		cd.Time += "Z"
+	}
+
+	if created && v7 {
+		setRecordChangeDate(record, "CREA", cd)
+	}
+	setRecordChangeDate(record, "CHAN", cd)
+}
+
+// setRecordChangeDate sets the CHAN or CREA (name) of record to cd. An
+// existing CREA is kept.
+func setRecordChangeDate(record *Record, name string, cd *ChangeDate) {
+	replace := name == "CHAN"
+	if len(record.Tags) > 0 {
+		record.Tags = setChangeDateTags(record.Tags, name, cd, replace)
+	}
+
+	var chanField, creaField **ChangeDate
+	switch entity := record.Entity.(type) {
+	case *Individual:
+		chanField, creaField = &entity.ChangeDate, &entity.CreationDate
+	case *Family:
+		chanField, creaField = &entity.ChangeDate, &entity.CreationDate
+	case *Source:
+		chanField, creaField = &entity.ChangeDate, &entity.CreationDate
+	case *MediaObject:
+		chanField, creaField = &entity.ChangeDate, &entity.CreationDate
+	case *SharedNote:
+		chanField = &entity.ChangeDate
+	}
+	field := chanField
+	if name == "CREA" {
+		field = creaField
+	}
+	if field != nil && (replace || *field == nil) {
+		copied := *cd
+		*field = &copied
+	}
+}
+
+// setChangeDateTags replaces the level 1 name structure of tags with one
+// holding cd, or appends one if there is none. An existing structure is
+// kept when replace is false.
+func setChangeDateTags(tags []*Tag, name string, cd *ChangeDate, replace bool) []*Tag {
+	replacement := []*Tag{
+		{Level: 1, Tag: name},
+		{Level: 2, Tag: "DATE", Value: cd.Date},
+		{Level: 3, Tag: "TIME", Value: cd.Time},
+	}
+	for i, tag := range tags {
+		if tag == nil || tag.Level != 1 || tag.Tag != name {
+			continue
+		}
+		if !replace {
+			return tags
+		}
+		end := i + 1
+		for end < len(tags) && (tags[end] == nil || tags[end].Level > 1) {
+			end++
+		}
+		result := make([]*Tag, 0, len(tags)-(end-i)+len(replacement))
+		result = append(result, tags[:i]...)
+		result = append(result, replacement...)
+		return append(result, tags[end:]...)
+	}
+	return append(tags, replacement...)
+}
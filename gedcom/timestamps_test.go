@@ -0,0 +1,145 @@
+package gedcom
+
+import (
+	"testing"
+	"time"
+)
+
+func fixedNow(t *testing.T) {
+	t.Helper()
+	saved := timeNow
+	timeNow = func() time.Time { return time.Date(2026, 3, 5, 14, 7, 9, 0, time.FixedZone("EST", -5*3600)) }
+	t.Cleanup(func() { timeNow = saved })
+}
+
+func TestDocument_AutoTimestamps_AddRecord(t *testing.T) {
+	fixedNow(t)
+	doc := &Document{Header: &Header{Version: Version70}}
+	if doc.AutoTimestamps() {
+		t.Fatal("AutoTimestamps() should be off by default")
+	}
+	if err := doc.AddRecord(&Record{XRef: "@I1@", Type: RecordTypeIndividual, Entity: &Individual{XRef: "@I1@"}}); err != nil {
+		t.Fatal(err)
+	}
+	if ind := doc.GetIndividual("@I1@"); ind.ChangeDate != nil || ind.CreationDate != nil {
+		t.Error("dates set with AutoTimestamps off")
+	}
+
+	doc.SetAutoTimestamps(true)
+	existing := &ChangeDate{Date: "1 JAN 2000"}
+	if err := doc.AddRecord(&Record{XRef: "@I2@", Type: RecordTypeIndividual, Entity: &Individual{XRef: "@I2@", CreationDate: existing}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.AddRecord(&Record{XRef: "@F1@", Type: RecordTypeFamily, Entity: &Family{XRef: "@F1@"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := ChangeDate{Date: "5 MAR 2026", Time: "19:07:09Z"}
+	ind := doc.GetIndividual("@I2@")
+	if ind.ChangeDate == nil || *ind.ChangeDate != want {
+		t.Errorf("CHAN = %+v, want %+v", ind.ChangeDate, want)
+	}
+	if ind.CreationDate != existing {
+		t.Errorf("existing CREA replaced: %+v", ind.CreationDate)
+	}
+	fam := doc.GetFamily("@F1@")
+	if fam.CreationDate == nil || *fam.CreationDate != want || fam.ChangeDate == nil || *fam.ChangeDate != want {
+		t.Errorf("family CREA = %+v, CHAN = %+v", fam.CreationDate, fam.ChangeDate)
+	}
+	if fam.ChangeDate == fam.CreationDate {
+		t.Error("CREA and CHAN share a ChangeDate")
+	}
+}
+
+func TestDocument_AutoTimestamps_Version551(t *testing.T) {
+	fixedNow(t)
+	doc := &Document{Header: &Header{Version: Version551}}
+	doc.SetAutoTimestamps(true)
+	if err := doc.AddRecord(&Record{XRef: "@S1@", Type: RecordTypeSource, Entity: &Source{XRef: "@S1@"}}); err != nil {
+		t.Fatal(err)
+	}
+	src := doc.GetSource("@S1@")
+	if src.CreationDate != nil {
+		t.Errorf("CREA set in a 5.5.1 document: %+v", src.CreationDate)
+	}
+	if src.ChangeDate == nil || src.ChangeDate.Time != "19:07:09" {
+		t.Errorf("CHAN = %+v, want UTC time without Z", src.ChangeDate)
+	}
+}
+
+func TestDocument_AutoTimestamps_NoHeader(t *testing.T) {
+	fixedNow(t)
+	doc := &Document{}
+	doc.SetAutoTimestamps(true)
+	if err := doc.AddRecord(&Record{XRef: "@S1@", Type: RecordTypeSource, Entity: &Source{XRef: "@S1@"}}); err != nil {
+		t.Fatal(err)
+	}
+	src := doc.GetSource("@S1@")
+	if src.CreationDate != nil {
+		t.Errorf("CREA set in a document without a header: %+v", src.CreationDate)
+	}
+	if src.ChangeDate == nil || src.ChangeDate.Time != "19:07:09" {
+		t.Errorf("CHAN = %+v, want UTC time without Z", src.ChangeDate)
+	}
+}
+
+func TestDocument_TouchRecord(t *testing.T) {
+	fixedNow(t)
+	ind := &Individual{XRef: "@I1@", ChangeDate: &ChangeDate{Date: "1 JAN 2000", Time: "10:00:00"}}
+	doc := &Document{
+		Header: &Header{Version: Version70},
+		Records: []*Record{
+			{XRef: "@I1@", Type: RecordTypeIndividual, Entity: ind, Tags: []*Tag{
+				{Level: 1, Tag: "NAME", Value: "John /Smith/"},
+				{Level: 1, Tag: "CHAN"},
+				{Level: 2, Tag: "DATE", Value: "1 JAN 2000"},
+				{Level: 3, Tag: "TIME", Value: "10:00:00"},
+				{Level: 1, Tag: "SEX", Value: "M"},
+			}},
+			{XRef: "@R1@", Type: RecordTypeRepository, Tags: []*Tag{
+				{Level: 1, Tag: "NAME", Value: "Archive"},
+			}},
+		},
+	}
+
+	if !doc.TouchRecord("@I1@") {
+		t.Fatal("TouchRecord(@I1@) = false")
+	}
+	if ind.ChangeDate.Date != "5 MAR 2026" || ind.ChangeDate.Time != "19:07:09Z" {
+		t.Errorf("CHAN = %+v", ind.ChangeDate)
+	}
+	if ind.CreationDate != nil {
+		t.Error("TouchRecord set CREA")
+	}
+	wantTags := []string{"NAME", "CHAN", "DATE 5 MAR 2026", "TIME 19:07:09Z", "SEX"}
+	assertTagLines(t, doc.GetRecord("@I1@").Tags, wantTags)
+
+	if !doc.TouchRecord("@R1@") {
+		t.Fatal("TouchRecord(@R1@) = false")
+	}
+	assertTagLines(t, doc.GetRecord("@R1@").Tags, []string{"NAME", "CHAN", "DATE 5 MAR 2026", "TIME 19:07:09Z"})
+
+	if doc.TouchRecord("@X9@") {
+		t.Error("TouchRecord() of a missing XRef should return false")
+	}
+}
+
+func assertTagLines(t *testing.T, tags []*Tag, want []string) {
+	t.Helper()
+	var got []string
+	for _, tag := range tags {
+		line := tag.Tag
+		if tag.Tag != "NAME" && tag.Tag != "SEX" && tag.Value != "" {
+			line += " " + tag.Value
+		}
+		got = append(got, line)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("tags = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("tags = %v, want %v", got, want)
+		}
+	}
+}
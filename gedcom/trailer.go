@@ -5,4 +5,9 @@ package gedcom
 type Trailer struct {
 	// LineNumber is the line number where the trailer appears
 	LineNumber int
+
+	// Trivia holds the blank and comment lines around the trailer when
+	// the decoder preserves formatting: Offset 0 is before "0 TRLR" and 1
+	// is after it.
+	Trivia []Trivia
 }
@@ -0,0 +1,132 @@
+package gedcom
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxUnknownTagSamples caps the distinct sample values kept per unknown tag.
+const maxUnknownTagSamples = 5
+
+// UnknownTag summarizes every occurrence of one non-standard tag in a
+// document, as returned by Document.UnknownTags.
+type UnknownTag struct {
+	// Tag is the tag name (e.g., "_MILT").
+	Tag string
+
+	// Count is the number of occurrences.
+	Count int
+
+	// Samples holds up to five distinct non-empty values, in file order.
+	Samples []string
+
+	// Locations lists every occurrence, in file order.
+	Locations []TagLocation
+
+	// URI is the tag's URI from the header's SCHMA declarations, if any.
+	URI string
+
+	// Registered reports whether an extension schema is registered for the
+	// tag with RegisterExtension.
+	Registered bool
+}
+
+// TagLocation identifies one occurrence of a tag.
+type TagLocation struct {
+	// RecordXRef is the XRef of the enclosing record, or "" in the header.
+	RecordXRef string
+
+	// Path is the chain of tags from the record down to the tag itself,
+	// separated by dots (e.g., "INDI.BIRT._PRIM" or "HEAD._TREE").
+	Path string
+
+	// LineNumber is the source line of the tag (0 for hand-built tags).
+	LineNumber int
+}
+
+// UnknownTags returns every non-standard (underscore-prefixed) tag found in
+// the header and record tags, most frequent first and then by tag name.
+// Use it to decide which vendor extensions a document needs handled, for
+// example by checking each tag against a validator registry or registering
+// an extension schema for it.
+//
+// Only raw tags are inspected, so records built without Tags contribute
+// nothing.
+func (d *Document) UnknownTags() []*UnknownTag {
+	if d == nil {
+		return nil
+	}
+	defer d.rlock()()
+
+	c := &unknownTagCollector{byTag: make(map[string]*UnknownTag)}
+	if d.Header != nil {
+		c.collect("HEAD", "", d.Header.Tags)
+	}
+	for _, record := range d.Records {
+		if record != nil {
+			c.collect(string(record.Type), record.XRef, record.Tags)
+		}
+	}
+
+	order := c.order
+	for _, unknown := range order {
+		if d.Schema != nil {
+			unknown.URI = d.Schema.TagMappings[unknown.Tag]
+		}
+		_, unknown.Registered = LookupExtension(unknown.Tag)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if order[i].Count != order[j].Count {
+			return order[i].Count > order[j].Count
+		}
+		return order[i].Tag < order[j].Tag
+	})
+	return order
+}
+
+// unknownTagCollector gathers the non-standard tags of a document, in the
+// order they are first seen.
+type unknownTagCollector struct {
+	byTag map[string]*UnknownTag
+	order []*UnknownTag
+}
+
+// collect records the non-standard tags among tags, the tags of the record
+// xref whose paths start at root.
+func (c *unknownTagCollector) collect(root, xref string, tags []*Tag) {
+	path := []string{root}
+	for _, tag := range tags {
+		if tag == nil || tag.Level < 1 {
+			continue
+		}
+		if tag.Level < len(path) {
+			path = path[:tag.Level]
+		}
+		for len(path) < tag.Level {
+			path = append(path, "")
+		}
+		path = append(path, tag.Tag)
+		if strings.HasPrefix(tag.Tag, "_") {
+			c.add(tag, TagLocation{
+				RecordXRef: xref,
+				Path:       strings.Join(path, "."),
+				LineNumber: tag.LineNumber,
+			})
+		}
+	}
+}
+
+// add records one occurrence of the non-standard tag at loc.
+func (c *unknownTagCollector) add(tag *Tag, loc TagLocation) {
+	unknown, ok := c.byTag[tag.Tag]
+	if !ok {
+		unknown = &UnknownTag{Tag: tag.Tag}
+		c.byTag[tag.Tag] = unknown
+		c.order = append(c.order, unknown)
+	}
+	unknown.Count++
+	unknown.Locations = append(unknown.Locations, loc)
+	if tag.Value != "" && len(unknown.Samples) < maxUnknownTagSamples && !containsString(unknown.Samples, tag.Value) {
+		unknown.Samples = append(unknown.Samples, tag.Value)
+	}
+}
@@ -0,0 +1,95 @@
+package gedcom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDocument_UnknownTags(t *testing.T) {
+	if err := RegisterExtension(ExtensionSchema{Tag: "_REGD"}); err != nil {
+		t.Fatal(err)
+	}
+	defer UnregisterExtension("_REGD")
+
+	doc := &Document{
+		Header: &Header{Tags: []*Tag{
+			{Level: 1, Tag: "SOUR", Value: "Ancestry.com"},
+			{Level: 1, Tag: "_TREE", Value: "My Tree", LineNumber: 3},
+		}},
+		Schema: &SchemaDefinition{TagMappings: map[string]string{"_PRIM": "https://example.com/prim"}},
+		Records: []*Record{
+			{XRef: "@I1@", Type: RecordTypeIndividual, Tags: []*Tag{
+				{Level: 1, Tag: "NAME", Value: "John /Doe/"},
+				{Level: 1, Tag: "BIRT"},
+				{Level: 2, Tag: "_PRIM", Value: "Y", LineNumber: 12},
+				{Level: 1, Tag: "OBJE"},
+				{Level: 2, Tag: "_PRIM", Value: "Y", LineNumber: 14},
+				{Level: 1, Tag: "_REGD"},
+			}},
+			nil,
+			{XRef: "@I2@", Type: RecordTypeIndividual, Tags: []*Tag{
+				{Level: 1, Tag: "BIRT"},
+				{Level: 2, Tag: "_PRIM", Value: "N", LineNumber: 20},
+			}},
+			{XRef: "@I3@", Type: RecordTypeIndividual, Entity: &Individual{}},
+		},
+	}
+
+	got := doc.UnknownTags()
+	if len(got) != 3 {
+		t.Fatalf("UnknownTags() returned %d tags, want 3", len(got))
+	}
+
+	prim := got[0]
+	if prim.Tag != "_PRIM" || prim.Count != 3 {
+		t.Errorf("first tag = %s x%d, want _PRIM x3", prim.Tag, prim.Count)
+	}
+	if !reflect.DeepEqual(prim.Samples, []string{"Y", "N"}) {
+		t.Errorf("Samples = %v, want [Y N]", prim.Samples)
+	}
+	wantLocations := []TagLocation{
+		{RecordXRef: "@I1@", Path: "INDI.BIRT._PRIM", LineNumber: 12},
+		{RecordXRef: "@I1@", Path: "INDI.OBJE._PRIM", LineNumber: 14},
+		{RecordXRef: "@I2@", Path: "INDI.BIRT._PRIM", LineNumber: 20},
+	}
+	if !reflect.DeepEqual(prim.Locations, wantLocations) {
+		t.Errorf("Locations = %+v, want %+v", prim.Locations, wantLocations)
+	}
+	if prim.URI != "https://example.com/prim" || prim.Registered {
+		t.Errorf("URI = %q, Registered = %v", prim.URI, prim.Registered)
+	}
+
+	regd, tree := got[1], got[2]
+	if regd.Tag != "_REGD" || !regd.Registered || regd.Samples != nil {
+		t.Errorf("second tag = %+v, want registered _REGD without samples", regd)
+	}
+	if tree.Tag != "_TREE" || tree.Locations[0].RecordXRef != "" || tree.Locations[0].Path != "HEAD._TREE" {
+		t.Errorf("third tag = %+v, want header _TREE", tree)
+	}
+}
+
+func TestDocument_UnknownTagsSamplesCapped(t *testing.T) {
+	record := &Record{XRef: "@I1@", Type: RecordTypeIndividual}
+	for _, v := range []string{"a", "b", "a", "c", "d", "e", "f", "g"} {
+		record.Tags = append(record.Tags, &Tag{Level: 1, Tag: "_X", Value: v})
+	}
+	doc := &Document{Records: []*Record{record}}
+
+	got := doc.UnknownTags()
+	if len(got) != 1 || got[0].Count != 8 {
+		t.Fatalf("UnknownTags() = %+v", got)
+	}
+	if !reflect.DeepEqual(got[0].Samples, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("Samples = %v", got[0].Samples)
+	}
+}
+
+func TestDocument_UnknownTagsNil(t *testing.T) {
+	var doc *Document
+	if got := doc.UnknownTags(); got != nil {
+		t.Errorf("UnknownTags() on nil document = %v", got)
+	}
+	if got := (&Document{}).UnknownTags(); len(got) != 0 {
+		t.Errorf("UnknownTags() on empty document = %v", got)
+	}
+}
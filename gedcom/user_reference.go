@@ -0,0 +1,107 @@
+package gedcom
+
+// UserReference represents a user reference number (REFN tag) with its
+// optional type.
+//
+// GEDCOM structure:
+//
+//	n REFN <Text>
+//	  +1 TYPE <Text>
+//
+// Example:
+//
+//	1 REFN 1042
+//	  2 TYPE Ahnentafel
+type UserReference struct {
+	// Number is the reference number (REFN value)
+	Number string
+
+	// Type describes the kind of reference (from TYPE subordinate)
+	Type string
+}
+
+// userReferenceKey identifies a REFN by type and number.
+type userReferenceKey struct {
+	refType string
+	number  string
+}
+
+// UserReferenceIndex indexes the individuals, families, and sources of a
+// document by REFN type and number. Build one with NewUserReferenceIndex
+// when running many lookups against the same document; the index does not
+// follow later changes to the document.
+type UserReferenceIndex struct {
+	records map[userReferenceKey][]*Record
+}
+
+// NewUserReferenceIndex indexes the REFN structures of the individuals,
+// families, and sources of doc.
+func NewUserReferenceIndex(doc *Document) *UserReferenceIndex {
+	ix := &UserReferenceIndex{records: make(map[userReferenceKey][]*Record)}
+	if doc == nil {
+		return ix
+	}
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		var refs []*UserReference
+		switch entity := record.Entity.(type) {
+		case *Individual:
+			refs = entity.AllUserReferences()
+		case *Family:
+			refs = entity.AllUserReferences()
+		case *Source:
+			refs = entity.AllUserReferences()
+		}
+		for _, ref := range refs {
+			if ref == nil || ref.Number == "" {
+				continue
+			}
+			key := userReferenceKey{refType: ref.Type, number: ref.Number}
+			if list := ix.records[key]; len(list) == 0 || list[len(list)-1] != record {
+				ix.records[key] = append(list, record)
+			}
+		}
+	}
+	return ix
+}
+
+// Lookup returns the records with a REFN of the given type and number, in
+// document order. An empty refType matches only untyped references.
+func (ix *UserReferenceIndex) Lookup(refType, number string) []*Record {
+	if ix == nil {
+		return nil
+	}
+	return ix.records[userReferenceKey{refType: refType, number: number}]
+}
+
+// AllUserReferences returns the individual's REFN structures:
+// UserReferences, or the deprecated RefNumber as an untyped reference when
+// UserReferences is empty.
+func (i *Individual) AllUserReferences() []*UserReference {
+	return userReferences(i.UserReferences, i.RefNumber)
+}
+
+// AllUserReferences returns the family's REFN structures: UserReferences,
+// or the deprecated RefNumber as an untyped reference when UserReferences
+// is empty.
+func (f *Family) AllUserReferences() []*UserReference {
+	return userReferences(f.UserReferences, f.RefNumber)
+}
+
+// AllUserReferences returns the source's REFN structures: UserReferences,
+// or the deprecated RefNumber as an untyped reference when UserReferences
+// is empty.
+func (s *Source) AllUserReferences() []*UserReference {
+	return userReferences(s.UserReferences, s.RefNumber)
+}
+
+// userReferences returns refs, or legacy as an untyped reference when refs
+// is empty.
+func userReferences(refs []*UserReference, legacy string) []*UserReference {
+	if len(refs) > 0 || legacy == "" {
+		return refs
+	}
+	return []*UserReference{{Number: legacy}}
+}
@@ -0,0 +1,71 @@
+package gedcom
+
+import "testing"
+
+func TestUserReferenceIndex(t *testing.T) {
+	doc := &Document{Records: []*Record{
+		{XRef: "@I1@", Type: RecordTypeIndividual, Entity: &Individual{XRef: "@I1@", UserReferences: []*UserReference{
+			{Number: "1042", Type: "Ahnentafel"},
+			{Number: "7"},
+		}}},
+		{XRef: "@I2@", Type: RecordTypeIndividual, Entity: &Individual{XRef: "@I2@", RefNumber: "7"}},
+		{XRef: "@F1@", Type: RecordTypeFamily, Entity: &Family{XRef: "@F1@", UserReferences: []*UserReference{
+			{Number: "1042", Type: "family"},
+		}}},
+		{XRef: "@S1@", Type: RecordTypeSource, Entity: &Source{XRef: "@S1@", UserReferences: []*UserReference{
+			{Number: "1042", Type: "Ahnentafel"},
+			{Number: "1042", Type: "Ahnentafel"},
+		}}},
+	}}
+	ix := NewUserReferenceIndex(doc)
+
+	xrefs := func(records []*Record) []string {
+		var out []string
+		for _, r := range records {
+			out = append(out, r.XRef)
+		}
+		return out
+	}
+	tests := []struct {
+		refType, number string
+		want            []string
+	}{
+		{"Ahnentafel", "1042", []string{"@I1@", "@S1@"}},
+		{"family", "1042", []string{"@F1@"}},
+		{"", "7", []string{"@I1@", "@I2@"}},
+		{"", "1042", nil},
+		{"Ahnentafel", "7", nil},
+	}
+	for _, tt := range tests {
+		got := xrefs(ix.Lookup(tt.refType, tt.number))
+		if len(got) != len(tt.want) {
+			t.Errorf("Lookup(%q, %q) = %v, want %v", tt.refType, tt.number, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Lookup(%q, %q) = %v, want %v", tt.refType, tt.number, got, tt.want)
+				break
+			}
+		}
+	}
+
+	var nilIndex *UserReferenceIndex
+	if nilIndex.Lookup("", "7") != nil || NewUserReferenceIndex(nil).Lookup("", "7") != nil {
+		t.Error("empty index should find nothing")
+	}
+}
+
+func TestAllUserReferences(t *testing.T) {
+	ind := &Individual{RefNumber: "OLD"}
+	if refs := ind.AllUserReferences(); len(refs) != 1 || *refs[0] != (UserReference{Number: "OLD"}) {
+		t.Errorf("legacy RefNumber: %+v", refs)
+	}
+	ind.UserReferences = []*UserReference{{Number: "NEW", Type: "user"}}
+	if refs := ind.AllUserReferences(); len(refs) != 1 || refs[0].Number != "NEW" {
+		t.Errorf("UserReferences not preferred: %+v", refs)
+	}
+	if refs := (&Source{}).AllUserReferences(); refs != nil {
+		t.Errorf("empty source: %+v", refs)
+	}
+}
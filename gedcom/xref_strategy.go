@@ -0,0 +1,167 @@
+package gedcom
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// XRefStrategy selects how new XRefs are allocated for records that do not
+// have one. Every strategy is deterministic: the same records added in the
+// same order get the same XRefs, so repeated runs of a pipeline produce
+// identical output.
+type XRefStrategy int
+
+const (
+	// XRefSequential numbers records per prefix: @I1@, @I2@, ... This is
+	// the default.
+	XRefSequential XRefStrategy = iota
+
+	// XRefUID derives the XRef from the record's UID (UID or _UID tag, or
+	// the entity's UID field), such as @I9B2C4E1A0F7D4C21@ for UID
+	// 9b2c4e1a-0f7d-4c21-.... Records without a UID fall back to
+	// XRefContentHash.
+	XRefUID
+
+	// XRefContentHash derives the XRef from a SHA-256 hash of the record's
+	// content, so the same record gets the same XRef whatever order it is
+	// added in.
+	XRefContentHash
+)
+
+// derivedXRefLength is the number of hex digits a derived XRef keeps, which
+// keeps "@I...@" within GEDCOM 5.5's 22-character limit.
+const derivedXRefLength = 16
+
+// String returns "sequential", "uid", or "content-hash".
+func (s XRefStrategy) String() string {
+	switch s {
+	case XRefSequential:
+		return "sequential"
+	case XRefUID:
+		return "uid"
+	case XRefContentHash:
+		return "content-hash"
+	default:
+		return fmt.Sprintf("XRefStrategy(%d)", int(s))
+	}
+}
+
+// DeriveXRef returns the XRef strategy s derives for record under prefix:
+// @<prefix><digits>@, where the digits come from the record's UID or
+// content hash. If that XRef is in use according to inUse (which may be
+// nil), a suffix _2, _3, ... is added until it is free. It returns "" for
+// XRefSequential, which depends on document state rather than the record;
+// use Document.NextXRef for it.
+func (s XRefStrategy) DeriveXRef(prefix string, record *Record, inUse func(xref string) bool) string {
+	var digits string
+	switch s {
+	case XRefUID:
+		digits = uidDigits(recordUID(record))
+		if digits == "" {
+			digits = contentDigits(prefix, record)
+		}
+	case XRefContentHash:
+		digits = contentDigits(prefix, record)
+	default:
+		return ""
+	}
+
+	base := "@" + prefix + digits
+	xref := base + "@"
+	for n := 2; inUse != nil && inUse(xref); n++ {
+		xref = fmt.Sprintf("%s_%d@", base, n)
+	}
+	return xref
+}
+
+// SetXRefStrategy sets the strategy AddIndividual and AddFamily use for
+// records without an XRef. NextXRef is always sequential.
+func (d *Document) SetXRefStrategy(s XRefStrategy) {
+	if d.index == nil {
+		d.index = &documentIndex{}
+	}
+	d.index.xrefStrategy = s
+}
+
+// XRefStrategy returns the document's XRef allocation strategy.
+func (d *Document) XRefStrategy() XRefStrategy {
+	if d.index == nil {
+		return XRefSequential
+	}
+	return d.index.xrefStrategy
+}
+
+// allocateXRef returns a free XRef for record under the document's
+// strategy; the caller holds the write lock and has brought the index up
+// to date.
+func (d *Document) allocateXRef(prefix string, record *Record) string {
+	if d.index.xrefStrategy == XRefSequential {
+		return d.nextXRef(prefix)
+	}
+	return d.index.xrefStrategy.DeriveXRef(prefix, record, func(xref string) bool {
+		_, used := d.XRefMap[xref]
+		return used
+	})
+}
+
+// recordUID returns the first UID of record, from its raw tags if it has
+// any and otherwise from its entity.
+func recordUID(record *Record) string {
+	if record == nil {
+		return ""
+	}
+	for _, tag := range record.Tags {
+		if tag.Level == 1 && (tag.Tag == "UID" || tag.Tag == "_UID") && tag.Value != "" {
+			return tag.Value
+		}
+	}
+	switch e := record.Entity.(type) {
+	case *Individual:
+		return e.UID
+	case *Family:
+		return e.UID
+	case *Source:
+		return e.UID
+	case *MediaObject:
+		if len(e.UIDs) > 0 {
+			return e.UIDs[0]
+		}
+	}
+	return ""
+}
+
+// uidDigits returns the letters and digits of uid, upper-cased and
+// truncated to derivedXRefLength.
+func uidDigits(uid string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(uid) {
+		if (r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') {
+			b.WriteRune(r)
+			if b.Len() == derivedXRefLength {
+				break
+			}
+		}
+	}
+	return b.String()
+}
+
+// contentDigits hashes prefix and the record's content: its raw tags if it
+// has any, and otherwise its entity encoded as JSON.
+func contentDigits(prefix string, record *Record) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00", prefix)
+	if record != nil {
+		fmt.Fprintf(h, "%s\x00", record.Type)
+		if len(record.Tags) > 0 {
+			for _, tag := range record.Tags {
+				fmt.Fprintf(h, "%d %s %s %s\x00", tag.Level, tag.XRef, tag.Tag, tag.Value)
+			}
+		} else if data, err := json.Marshal(record.Entity); err == nil {
+			h.Write(data)
+		}
+	}
+	return strings.ToUpper(hex.EncodeToString(h.Sum(nil)))[:derivedXRefLength]
+}
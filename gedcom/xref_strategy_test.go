@@ -0,0 +1,113 @@
+package gedcom
+
+import "testing"
+
+func TestXRefStrategy_String(t *testing.T) {
+	tests := map[XRefStrategy]string{
+		XRefSequential:   "sequential",
+		XRefUID:          "uid",
+		XRefContentHash:  "content-hash",
+		XRefStrategy(42): "XRefStrategy(42)",
+	}
+	for s, want := range tests {
+		if got := s.String(); got != want {
+			t.Errorf("%d.String() = %q, want %q", int(s), got, want)
+		}
+	}
+}
+
+func TestXRefStrategy_DeriveXRef(t *testing.T) {
+	withUID := &Record{Type: RecordTypeIndividual, Entity: &Individual{UID: "9b2c4e1a-0f7d-4c21-8e3b-5a6d7c8e9f01"}}
+	tagged := &Record{Type: RecordTypeIndividual, Tags: []*Tag{
+		{Level: 1, Tag: "NAME", Value: "John /Doe/"},
+		{Level: 1, Tag: "_UID", Value: "ABCDEF12-3456"},
+	}}
+	plain := &Record{Type: RecordTypeIndividual, Entity: &Individual{Sex: "M"}}
+
+	if got := XRefSequential.DeriveXRef("I", withUID, nil); got != "" {
+		t.Errorf("XRefSequential.DeriveXRef() = %q, want empty", got)
+	}
+	if got := XRefUID.DeriveXRef("I", withUID, nil); got != "@I9B2C4E1A0F7D4C21@" {
+		t.Errorf("XRefUID.DeriveXRef(entity UID) = %q, want @I9B2C4E1A0F7D4C21@", got)
+	}
+	if got := XRefUID.DeriveXRef("I", tagged, nil); got != "@IABCDEF123456@" {
+		t.Errorf("XRefUID.DeriveXRef(_UID tag) = %q, want @IABCDEF123456@", got)
+	}
+
+	hash := XRefContentHash.DeriveXRef("I", plain, nil)
+	if len(hash) != len("@I@")+derivedXRefLength {
+		t.Errorf("XRefContentHash.DeriveXRef() = %q, want %d digits", hash, derivedXRefLength)
+	}
+	if got := XRefUID.DeriveXRef("I", plain, nil); got != hash {
+		t.Errorf("XRefUID.DeriveXRef(no UID) = %q, want content hash %q", got, hash)
+	}
+	same := &Record{Type: RecordTypeIndividual, Entity: &Individual{Sex: "M"}}
+	if got := XRefContentHash.DeriveXRef("I", same, nil); got != hash {
+		t.Errorf("equal content hashed to %q and %q", hash, got)
+	}
+	other := &Record{Type: RecordTypeIndividual, Entity: &Individual{Sex: "F"}}
+	if got := XRefContentHash.DeriveXRef("I", other, nil); got == hash {
+		t.Errorf("different content both hashed to %q", got)
+	}
+
+	used := map[string]bool{hash: true, hash[:len(hash)-1] + "_2@": true}
+	got := XRefContentHash.DeriveXRef("I", plain, func(x string) bool { return used[x] })
+	if want := hash[:len(hash)-1] + "_3@"; got != want {
+		t.Errorf("DeriveXRef() with collisions = %q, want %q", got, want)
+	}
+}
+
+func TestDocument_XRefStrategy(t *testing.T) {
+	build := func(strategy XRefStrategy) []string {
+		doc := &Document{}
+		doc.SetXRefStrategy(strategy)
+		if got := doc.XRefStrategy(); got != strategy {
+			t.Fatalf("XRefStrategy() = %v, want %v", got, strategy)
+		}
+		var xrefs []string
+		for _, indi := range []*Individual{
+			{UID: "11111111-2222-3333-4444-555555555555", Sex: "M"},
+			{Sex: "F"},
+			{Sex: "F"},
+		} {
+			xref, err := doc.AddIndividual(indi)
+			if err != nil {
+				t.Fatalf("AddIndividual() error = %v", err)
+			}
+			xrefs = append(xrefs, xref)
+		}
+		xref, err := doc.AddFamily(&Family{Husband: xrefs[0], Wife: xrefs[1]})
+		if err != nil {
+			t.Fatalf("AddFamily() error = %v", err)
+		}
+		if doc.Clone().XRefStrategy() != strategy {
+			t.Errorf("Clone() lost XRefStrategy %v", strategy)
+		}
+		return append(xrefs, xref)
+	}
+
+	if got := build(XRefSequential); got[0] != "@I1@" || got[3] != "@F1@" {
+		t.Errorf("XRefSequential XRefs = %v, want @I1@ ... @F1@", got)
+	}
+
+	byUID := build(XRefUID)
+	if byUID[0] != "@I1111111122223333@" {
+		t.Errorf("XRefUID XRefs[0] = %q, want @I1111111122223333@", byUID[0])
+	}
+	// The two identical individuals get the same hash, the second with a
+	// suffix.
+	if byUID[2] != byUID[1][:len(byUID[1])-1]+"_2@" {
+		t.Errorf("XRefUID XRefs = %v, want the third to suffix the second", byUID)
+	}
+
+	first, second := build(XRefContentHash), build(XRefContentHash)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("XRefContentHash run 1 XRefs[%d] = %q, run 2 = %q", i, first[i], second[i])
+		}
+	}
+
+	if got := (&Document{}).XRefStrategy(); got != XRefSequential {
+		t.Errorf("zero Document XRefStrategy() = %v, want XRefSequential", got)
+	}
+}
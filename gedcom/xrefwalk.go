@@ -331,19 +331,13 @@ func walkIndividual(i *Individual, cb refCallback) {
 	for k := range i.SpouseInFamilies {
 		cb(&i.SpouseInFamilies[k])
 	}
+	for k := range i.Aliases {
+		cb(&i.Aliases[k])
+	}
 	for k := range i.Notes {
 		cb(&i.Notes[k])
 	}
-	for _, a := range i.Associations {
-		if a == nil {
-			continue
-		}
-		cb(&a.IndividualXRef)
-		for k := range a.Notes {
-			cb(&a.Notes[k])
-		}
-		walkCitations(a.SourceCitations, cb)
-	}
+	walkAssociations(i.Associations, cb)
 	walkCitations(i.SourceCitations, cb)
 	walkMediaLinks(i.Media, cb)
 	for _, ev := range i.Events {
@@ -358,6 +352,12 @@ func walkIndividual(i *Individual, cb refCallback) {
 		}
 		cb(&ord.FamilyXRef)
 	}
+	for _, task := range i.Tasks {
+		if task == nil {
+			continue
+		}
+		cb(&task.RepositoryXRef)
+	}
 	for _, t := range i.Tags {
 		walkTag(t, cb)
 	}
@@ -369,12 +369,18 @@ func walkFamily(f *Family, cb refCallback) {
 	}
 	cb(&f.Husband)
 	cb(&f.Wife)
+	for _, spouse := range f.Spouses {
+		if spouse != nil {
+			cb(&spouse.XRef)
+		}
+	}
 	for k := range f.Children {
 		cb(&f.Children[k])
 	}
 	for k := range f.Notes {
 		cb(&f.Notes[k])
 	}
+	walkAssociations(f.Associations, cb)
 	walkCitations(f.SourceCitations, cb)
 	walkMediaLinks(f.Media, cb)
 	for _, ev := range f.Events {
@@ -406,6 +412,11 @@ func walkSource(s *Source, cb refCallback) {
 	} else {
 		cb(&s.RepositoryRef)
 	}
+	for _, link := range s.RepositoryLinks {
+		if link != nil && link != s.RepositoryLink {
+			cb(&link.XRef)
+		}
+	}
 	for k := range s.Notes {
 		cb(&s.Notes[k])
 	}
@@ -480,11 +491,25 @@ func walkEvent(e *Event, cb refCallback) {
 	}
 	walkCitations(e.SourceCitations, cb)
 	walkMediaLinks(e.Media, cb)
+	walkAssociations(e.Associations, cb)
 	for _, t := range e.Tags {
 		walkTag(t, cb)
 	}
 }
 
+func walkAssociations(associations []*Association, cb refCallback) {
+	for _, a := range associations {
+		if a == nil {
+			continue
+		}
+		cb(&a.IndividualXRef)
+		for k := range a.Notes {
+			cb(&a.Notes[k])
+		}
+		walkCitations(a.SourceCitations, cb)
+	}
+}
+
 func walkAttribute(a *Attribute, cb refCallback) {
 	if a == nil {
 		return
@@ -498,6 +523,7 @@ func walkCitations(citations []*SourceCitation, cb refCallback) {
 			continue
 		}
 		cb(&sc.SourceXRef)
+		walkMediaLinks(sc.Media, cb)
 	}
 }
 
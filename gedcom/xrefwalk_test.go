@@ -629,3 +629,15 @@ func TestApply_NilTypedEntity(t *testing.T) {
 		Visit(rec, func(string) {})
 	}
 }
+
+func TestApply_SourceRepositoryLinks(t *testing.T) {
+	first := &SourceRepositoryLink{XRef: "@R1@"}
+	second := &SourceRepositoryLink{XRef: "@R2@"}
+	src := &Source{XRef: "@S1@", RepositoryLink: first, RepositoryRef: "@R1@", RepositoryLinks: []*SourceRepositoryLink{first, second}}
+	doc := &Document{Records: []*Record{{XRef: "@S1@", Type: RecordTypeSource, Entity: src}}}
+
+	Apply(doc, map[string]string{"@R1@": "@R10@", "@R2@": "@R20@"})
+	if first.XRef != "@R10@" || src.RepositoryRef != "@R10@" || second.XRef != "@R20@" {
+		t.Errorf("links = %q, %q (RepositoryRef %q)", first.XRef, second.XRef, src.RepositoryRef)
+	}
+}
@@ -88,6 +88,10 @@ type (
 
 	// ValidateOptions configures GEDCOM validation. See [validator.ValidateOptions].
 	ValidateOptions = validator.ValidateOptions
+
+	// Strictness is the strictness level all the option types accept.
+	// See [gedcom.Strictness].
+	Strictness = gedcom.Strictness
 )
 
 // DefaultDecodeOptions returns the default decoding options.
@@ -117,6 +121,18 @@ const (
 	Version70 Version = gedcom.Version70
 )
 
+// Strictness constants for convenience.
+const (
+	// StrictnessLenient accepts whatever can be read, reporting only errors.
+	StrictnessLenient Strictness = gedcom.StrictnessLenient
+
+	// StrictnessNormal recovers from nonconforming data (default).
+	StrictnessNormal Strictness = gedcom.StrictnessNormal
+
+	// StrictnessStrict rejects nonconforming data and reports every issue.
+	StrictnessStrict Strictness = gedcom.StrictnessStrict
+)
+
 // Decode parses a GEDCOM file from an io.Reader and returns a Document.
 // This is the simplest way to parse a GEDCOM file using default options.
 //
@@ -672,3 +672,29 @@ func TestValidateAllNilDocument(t *testing.T) {
 		t.Errorf("ValidateAll(nil) should return nil or empty, got %v", issues)
 	}
 }
+
+func TestStrictnessAcrossOptions(t *testing.T) {
+	// One strictness value configures every package.
+	strict := StrictnessStrict
+	input := strings.Replace(testGedcomMinimal, "0 TRLR", "not a gedcom line\n0 TRLR", 1)
+
+	if _, err := decoder.DecodeWithDiagnostics(strings.NewReader(input), &DecodeOptions{Strictness: strict}); err == nil {
+		t.Error("DecodeWithDiagnostics(strict) should fail on an invalid line")
+	}
+	if _, err := decoder.DecodeWithDiagnostics(strings.NewReader(input), &DecodeOptions{Strictness: StrictnessNormal}); err != nil {
+		t.Errorf("DecodeWithDiagnostics(normal) error = %v", err)
+	}
+
+	if got := DefaultValidateOptions().Strictness; got != StrictnessNormal {
+		t.Errorf("DefaultValidateOptions().Strictness = %v, want normal", got)
+	}
+	if got := DefaultDecodeOptions().Strictness; got != StrictnessNormal {
+		t.Errorf("DefaultDecodeOptions().Strictness = %v, want normal", got)
+	}
+	if got := DefaultEncodeOptions().Strictness; got != StrictnessNormal {
+		t.Errorf("DefaultEncodeOptions().Strictness = %v, want normal", got)
+	}
+	_ = &ValidateOptions{Strictness: strict}
+	_ = &EncodeOptions{Strictness: strict}
+	_ = &converter.ConvertOptions{Strictness: strict}
+}
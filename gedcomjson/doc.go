@@ -0,0 +1,46 @@
+// Package gedcomjson serializes GEDCOM documents as JSON and reads them
+// back, for web frontends and services that exchange documents as JSON
+// rather than GEDCOM text.
+//
+// The JSON form holds the whole document: the header, the raw tags of
+// every record, the typed entities, and the decoder's formatting and
+// trivia, so Unmarshal(Marshal(doc)) encodes to the same GEDCOM as doc.
+//
+// # Schema
+//
+// A document is an object with these members; members whose value is
+// empty are omitted:
+//
+//	{
+//	  "format": "gedcom-go",      // always FormatName
+//	  "version": 1,               // FormatVersion
+//	  "header": {
+//	    "version": "5.5.1", "encoding": "UTF-8", "sourceSystem": "...",
+//	    "date": "2024-01-02T00:00:00Z", "language": "...", "copyright": "...",
+//	    "submitter": "@U1@", "placeForm": "...", "ancestryTreeID": "...",
+//	    "tags": [...], "trivia": [...]
+//	  },
+//	  "vendor": "ancestry",
+//	  "schema": {"_SKYPEID": "http://xmlns.com/foaf/0.1/skypeID"},
+//	  "formatting": {"lineEnding": "\r\n", "bom": true, "noFinalLineEnding": true},
+//	  "records": [
+//	    {
+//	      "xref": "@I1@", "type": "INDI", "value": "...", "line": 12,
+//	      "tags": [{"level": 1, "tag": "NAME", "value": "John /Doe/", "xref": "", "line": 13}],
+//	      "trivia": [{"offset": 0, "text": "// comment"}],
+//	      "entity": {"XRef": "@I1@", "Names": [...], ...},
+//	      "sharedTags": true
+//	    }
+//	  ],
+//	  "trailer": {"line": 40, "trivia": [...]}
+//	}
+//
+// A record's "entity" is its typed entity (gedcom.Individual for INDI,
+// gedcom.Family for FAM, and so on), keyed by the entity type's exported
+// field names, which are stable within a major version of this module.
+// "sharedTags" marks an entity whose Tags are the record's tags, as after
+// decoding; they are written once, under the record.
+//
+// The "version" member changes only for incompatible schema changes;
+// Unmarshal rejects versions it does not know.
+package gedcomjson
@@ -0,0 +1,395 @@
+package gedcomjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// FormatName identifies the JSON form in its "format" member.
+const FormatName = "gedcom-go"
+
+// FormatVersion is the schema version Marshal writes and Unmarshal reads.
+const FormatVersion = 1
+
+type documentJSON struct {
+	Format     string            `json:"format"`
+	Version    int               `json:"version"`
+	Header     *headerJSON       `json:"header,omitempty"`
+	Vendor     gedcom.Vendor     `json:"vendor,omitempty"`
+	Schema     map[string]string `json:"schema,omitempty"`
+	Formatting *formattingJSON   `json:"formatting,omitempty"`
+	Records    []*recordJSON     `json:"records"`
+	Trailer    *trailerJSON      `json:"trailer,omitempty"`
+}
+
+type headerJSON struct {
+	Version        gedcom.Version  `json:"version,omitempty"`
+	Encoding       gedcom.Encoding `json:"encoding,omitempty"`
+	SourceSystem   string          `json:"sourceSystem,omitempty"`
+	Date           *time.Time      `json:"date,omitempty"`
+	Language       string          `json:"language,omitempty"`
+	Copyright      string          `json:"copyright,omitempty"`
+	Submitter      string          `json:"submitter,omitempty"`
+	PlaceForm      string          `json:"placeForm,omitempty"`
+	AncestryTreeID string          `json:"ancestryTreeID,omitempty"`
+	Tags           []tagJSON       `json:"tags,omitempty"`
+	Trivia         []triviaJSON    `json:"trivia,omitempty"`
+}
+
+type formattingJSON struct {
+	LineEnding        string `json:"lineEnding,omitempty"`
+	BOM               bool   `json:"bom,omitempty"`
+	NoFinalLineEnding bool   `json:"noFinalLineEnding,omitempty"`
+}
+
+type recordJSON struct {
+	XRef       string            `json:"xref,omitempty"`
+	Type       gedcom.RecordType `json:"type"`
+	Value      string            `json:"value,omitempty"`
+	Line       int               `json:"line,omitempty"`
+	Tags       []tagJSON         `json:"tags,omitempty"`
+	Trivia     []triviaJSON      `json:"trivia,omitempty"`
+	Entity     json.RawMessage   `json:"entity,omitempty"`
+	SharedTags bool              `json:"sharedTags,omitempty"`
+}
+
+type tagJSON struct {
+	Level int    `json:"level"`
+	Tag   string `json:"tag"`
+	Value string `json:"value,omitempty"`
+	XRef  string `json:"xref,omitempty"`
+	Line  int    `json:"line,omitempty"`
+}
+
+type triviaJSON struct {
+	Offset int    `json:"offset"`
+	Text   string `json:"text"`
+}
+
+type trailerJSON struct {
+	Line   int          `json:"line,omitempty"`
+	Trivia []triviaJSON `json:"trivia,omitempty"`
+}
+
+// entityTypes creates an empty entity for each record type that has one.
+var entityTypes = map[gedcom.RecordType]func() interface{}{
+	gedcom.RecordTypeIndividual: func() interface{} { return &gedcom.Individual{} },
+	gedcom.RecordTypeFamily:     func() interface{} { return &gedcom.Family{} },
+	gedcom.RecordTypeSource:     func() interface{} { return &gedcom.Source{} },
+	gedcom.RecordTypeRepository: func() interface{} { return &gedcom.Repository{} },
+	gedcom.RecordTypeNote:       func() interface{} { return &gedcom.Note{} },
+	gedcom.RecordTypeMedia:      func() interface{} { return &gedcom.MediaObject{} },
+	gedcom.RecordTypeSubmitter:  func() interface{} { return &gedcom.Submitter{} },
+	gedcom.RecordTypeSharedNote: func() interface{} { return &gedcom.SharedNote{} },
+}
+
+// Marshal returns the JSON form of doc. It returns an error if doc is nil
+// or a record's entity is not the entity type of its record type.
+func Marshal(doc *gedcom.Document) ([]byte, error) {
+	out, err := toJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(out)
+}
+
+// Encode writes the JSON form of doc to w, followed by a newline.
+func Encode(w io.Writer, doc *gedcom.Document) error {
+	out, err := toJSON(doc)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(out)
+}
+
+// Unmarshal reads a document from its JSON form. It returns an error if
+// data is not valid JSON, names another format or an unknown version, or
+// holds an entity that does not match its record type.
+func Unmarshal(data []byte) (*gedcom.Document, error) {
+	return Decode(bytes.NewReader(data))
+}
+
+// Decode reads a document from the JSON form in r.
+func Decode(r io.Reader) (*gedcom.Document, error) {
+	var in documentJSON
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return nil, fmt.Errorf("gedcomjson: %w", err)
+	}
+	return fromJSON(&in)
+}
+
+// MarshalRecord returns the JSON form of a single record: the object that
+// appears in a document's "records" array. It returns an error if record
+// is nil or its entity is not the entity type of its record type.
+func MarshalRecord(record *gedcom.Record) ([]byte, error) {
+	if record == nil {
+		return nil, errors.New("gedcomjson: record is nil")
+	}
+	rj, err := recordToJSON(record)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(rj)
+}
+
+// UnmarshalRecord reads a single record from the JSON form written by
+// MarshalRecord.
+func UnmarshalRecord(data []byte) (*gedcom.Record, error) {
+	var rj recordJSON
+	if err := json.Unmarshal(data, &rj); err != nil {
+		return nil, fmt.Errorf("gedcomjson: %w", err)
+	}
+	return recordFromJSON(&rj)
+}
+
+// toJSON converts doc to its JSON form.
+func toJSON(doc *gedcom.Document) (*documentJSON, error) {
+	if doc == nil {
+		return nil, errors.New("gedcomjson: document is nil")
+	}
+	out := &documentJSON{
+		Format:  FormatName,
+		Version: FormatVersion,
+		Vendor:  doc.Vendor,
+		Records: make([]*recordJSON, 0, len(doc.Records)),
+	}
+	if h := doc.Header; h != nil {
+		out.Header = &headerJSON{
+			Version:        h.Version,
+			Encoding:       h.Encoding,
+			SourceSystem:   h.SourceSystem,
+			Language:       h.Language,
+			Copyright:      h.Copyright,
+			Submitter:      h.Submitter,
+			PlaceForm:      h.PlaceForm,
+			AncestryTreeID: h.AncestryTreeID,
+			Tags:           tagsToJSON(h.Tags),
+			Trivia:         triviaToJSON(h.Trivia),
+		}
+		if !h.Date.IsZero() {
+			date := h.Date
+			out.Header.Date = &date
+		}
+	}
+	if doc.Schema != nil {
+		out.Schema = doc.Schema.TagMappings
+	}
+	if f := doc.Formatting; f != nil {
+		out.Formatting = &formattingJSON{LineEnding: f.LineEnding, BOM: f.BOM, NoFinalLineEnding: f.NoFinalLineEnding}
+	}
+	if t := doc.Trailer; t != nil {
+		out.Trailer = &trailerJSON{Line: t.LineNumber, Trivia: triviaToJSON(t.Trivia)}
+	}
+
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		rj, err := recordToJSON(record)
+		if err != nil {
+			return nil, err
+		}
+		out.Records = append(out.Records, rj)
+	}
+	return out, nil
+}
+
+// recordToJSON converts a record to its JSON form.
+func recordToJSON(record *gedcom.Record) (*recordJSON, error) {
+	rj := &recordJSON{
+		XRef:   record.XRef,
+		Type:   record.Type,
+		Value:  record.Value,
+		Line:   record.LineNumber,
+		Tags:   tagsToJSON(record.Tags),
+		Trivia: triviaToJSON(record.Trivia),
+	}
+	if record.Entity != nil {
+		entity, shared, err := entityToJSON(record)
+		if err != nil {
+			return nil, err
+		}
+		rj.Entity, rj.SharedTags = entity, shared
+	}
+	return rj, nil
+}
+
+// entityToJSON marshals the entity of record. An entity whose Tags are the
+// record's own tags is marshaled without them, and shared is true.
+func entityToJSON(record *gedcom.Record) (data json.RawMessage, shared bool, err error) {
+	create, ok := entityTypes[record.Type]
+	if !ok || reflect.TypeOf(record.Entity) != reflect.TypeOf(create()) {
+		return nil, false, fmt.Errorf("gedcomjson: record %s: entity %T does not match record type %s", record.XRef, record.Entity, record.Type)
+	}
+
+	entity := reflect.ValueOf(record.Entity)
+	if entity.IsNil() {
+		return nil, false, nil
+	}
+	value := entity.Interface()
+	if tags := entity.Elem().FieldByName("Tags"); tags.IsValid() && sameTags(tags.Interface().([]*gedcom.Tag), record.Tags) {
+		trimmed := reflect.New(entity.Elem().Type())
+		trimmed.Elem().Set(entity.Elem())
+		trimmed.Elem().FieldByName("Tags").Set(reflect.Zero(tags.Type()))
+		value, shared = trimmed.Interface(), true
+	}
+	data, err = json.Marshal(value)
+	if err != nil {
+		return nil, false, fmt.Errorf("gedcomjson: record %s: %w", record.XRef, err)
+	}
+	return data, shared, nil
+}
+
+// sameTags reports whether a and b are the same non-empty slice.
+func sameTags(a, b []*gedcom.Tag) bool {
+	return len(a) > 0 && len(a) == len(b) && &a[0] == &b[0]
+}
+
+// fromJSON builds a document from its JSON form.
+func fromJSON(in *documentJSON) (*gedcom.Document, error) {
+	if in.Format != FormatName {
+		return nil, fmt.Errorf("gedcomjson: format %q is not %q", in.Format, FormatName)
+	}
+	if in.Version != FormatVersion {
+		return nil, fmt.Errorf("gedcomjson: unsupported version %d", in.Version)
+	}
+
+	doc := &gedcom.Document{
+		Vendor:  in.Vendor,
+		XRefMap: make(map[string]*gedcom.Record),
+	}
+	if h := in.Header; h != nil {
+		doc.Header = &gedcom.Header{
+			Version:        h.Version,
+			Encoding:       h.Encoding,
+			SourceSystem:   h.SourceSystem,
+			Language:       h.Language,
+			Copyright:      h.Copyright,
+			Submitter:      h.Submitter,
+			PlaceForm:      h.PlaceForm,
+			AncestryTreeID: h.AncestryTreeID,
+			Tags:           tagsFromJSON(h.Tags),
+			Trivia:         triviaFromJSON(h.Trivia),
+		}
+		if h.Date != nil {
+			doc.Header.Date = *h.Date
+		}
+	}
+	if in.Schema != nil {
+		doc.Schema = &gedcom.SchemaDefinition{TagMappings: in.Schema}
+	}
+	if f := in.Formatting; f != nil {
+		doc.Formatting = &gedcom.Formatting{LineEnding: f.LineEnding, BOM: f.BOM, NoFinalLineEnding: f.NoFinalLineEnding}
+	}
+	if t := in.Trailer; t != nil {
+		doc.Trailer = &gedcom.Trailer{LineNumber: t.Line, Trivia: triviaFromJSON(t.Trivia)}
+	}
+
+	doc.Records = make([]*gedcom.Record, 0, len(in.Records))
+	for _, rj := range in.Records {
+		if rj == nil {
+			continue
+		}
+		record, err := recordFromJSON(rj)
+		if err != nil {
+			return nil, err
+		}
+		doc.Records = append(doc.Records, record)
+		if record.XRef != "" {
+			if _, exists := doc.XRefMap[record.XRef]; !exists {
+				doc.XRefMap[record.XRef] = record
+			}
+		}
+	}
+	return doc, nil
+}
+
+// recordFromJSON builds a record from its JSON form.
+func recordFromJSON(rj *recordJSON) (*gedcom.Record, error) {
+	record := &gedcom.Record{
+		XRef:       rj.XRef,
+		Type:       rj.Type,
+		Value:      rj.Value,
+		LineNumber: rj.Line,
+		Tags:       tagsFromJSON(rj.Tags),
+		Trivia:     triviaFromJSON(rj.Trivia),
+	}
+	if len(rj.Entity) > 0 {
+		if err := entityFromJSON(record, rj); err != nil {
+			return nil, err
+		}
+	}
+	return record, nil
+}
+
+// entityFromJSON unmarshals the entity of rj into record.
+func entityFromJSON(record *gedcom.Record, rj *recordJSON) error {
+	create, ok := entityTypes[record.Type]
+	if !ok {
+		return fmt.Errorf("gedcomjson: record %s: record type %s has no entity", record.XRef, record.Type)
+	}
+	entity := create()
+	if err := json.Unmarshal(rj.Entity, entity); err != nil {
+		return fmt.Errorf("gedcomjson: record %s: %w", record.XRef, err)
+	}
+	if rj.SharedTags {
+		if tags := reflect.ValueOf(entity).Elem().FieldByName("Tags"); tags.IsValid() {
+			tags.Set(reflect.ValueOf(record.Tags))
+		}
+	}
+	record.Entity = entity
+	return nil
+}
+
+func tagsToJSON(tags []*gedcom.Tag) []tagJSON {
+	if len(tags) == 0 {
+		return nil
+	}
+	out := make([]tagJSON, 0, len(tags))
+	for _, tag := range tags {
+		if tag != nil {
+			out = append(out, tagJSON{Level: tag.Level, Tag: tag.Tag, Value: tag.Value, XRef: tag.XRef, Line: tag.LineNumber})
+		}
+	}
+	return out
+}
+
+func tagsFromJSON(tags []tagJSON) []*gedcom.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+	out := make([]*gedcom.Tag, len(tags))
+	for i, tag := range tags {
+		out[i] = &gedcom.Tag{Level: tag.Level, Tag: tag.Tag, Value: tag.Value, XRef: tag.XRef, LineNumber: tag.Line}
+	}
+	return out
+}
+
+func triviaToJSON(trivia []gedcom.Trivia) []triviaJSON {
+	if len(trivia) == 0 {
+		return nil
+	}
+	out := make([]triviaJSON, len(trivia))
+	for i, t := range trivia {
+		out[i] = triviaJSON{Offset: t.Offset, Text: t.Text}
+	}
+	return out
+}
+
+func triviaFromJSON(trivia []triviaJSON) []gedcom.Trivia {
+	if len(trivia) == 0 {
+		return nil
+	}
+	out := make([]gedcom.Trivia, len(trivia))
+	for i, t := range trivia {
+		out[i] = gedcom.Trivia{Offset: t.Offset, Text: t.Text}
+	}
+	return out
+}
@@ -0,0 +1,234 @@
+package gedcomjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// testdataFiles returns the GEDCOM files under testdata that decode
+// without error.
+func testdataFiles(t *testing.T) []string {
+	t.Helper()
+	var files []string
+	err := filepath.WalkDir("../testdata", func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".ged") && !strings.Contains(path, "malformed") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestRoundTrip_Testdata(t *testing.T) {
+	for _, path := range testdataFiles(t) {
+		t.Run(path, func(t *testing.T) {
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			doc, err := decoder.DecodeWithOptions(f, &decoder.DecodeOptions{PreserveFormatting: true})
+			if err != nil {
+				t.Skipf("decode: %v", err)
+			}
+
+			data, err := Marshal(doc)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			got, err := Unmarshal(data)
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			if len(got.Records) != len(doc.Records) {
+				t.Fatalf("got %d records, want %d", len(got.Records), len(doc.Records))
+			}
+			for i, record := range doc.Records {
+				if !reflect.DeepEqual(got.Records[i], record) {
+					t.Errorf("record %d (%s) differs after round trip", i, record.XRef)
+				}
+			}
+			if !reflect.DeepEqual(got.Trailer, doc.Trailer) || !reflect.DeepEqual(got.Formatting, doc.Formatting) ||
+				!reflect.DeepEqual(got.Schema, doc.Schema) || got.Vendor != doc.Vendor {
+				t.Errorf("document fields differ after round trip")
+			}
+			if !got.Header.Date.Equal(doc.Header.Date) {
+				t.Errorf("header date = %v, want %v", got.Header.Date, doc.Header.Date)
+			}
+
+			opts := &encoder.EncodeOptions{LineEnding: "\n", PreserveFormatting: true}
+			var want, have bytes.Buffer
+			if err := encoder.EncodeWithOptions(&want, doc, opts); err != nil {
+				t.Fatal(err)
+			}
+			if err := encoder.EncodeWithOptions(&have, got, opts); err != nil {
+				t.Fatal(err)
+			}
+			if have.String() != want.String() {
+				t.Errorf("encoded output differs after round trip")
+			}
+		})
+	}
+}
+
+func TestMarshal_Schema(t *testing.T) {
+	doc, err := decoder.Decode(strings.NewReader(`0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Doe/
+0 TRLR
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var out struct {
+		Format  string `json:"format"`
+		Version int    `json:"version"`
+		Header  struct {
+			Version string `json:"version"`
+		} `json:"header"`
+		Records []struct {
+			XRef       string                   `json:"xref"`
+			Type       string                   `json:"type"`
+			Tags       []map[string]interface{} `json:"tags"`
+			Entity     map[string]interface{}   `json:"entity"`
+			SharedTags bool                     `json:"sharedTags"`
+		} `json:"records"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Format != FormatName || out.Version != FormatVersion || out.Header.Version != "5.5.1" {
+		t.Errorf("envelope = %q v%d header %q", out.Format, out.Version, out.Header.Version)
+	}
+	if len(out.Records) != 1 {
+		t.Fatalf("got %d records, want 1", len(out.Records))
+	}
+	rec := out.Records[0]
+	if rec.XRef != "@I1@" || rec.Type != "INDI" || !rec.SharedTags {
+		t.Errorf("record = %+v", rec)
+	}
+	if len(rec.Tags) != 1 || rec.Tags[0]["tag"] != "NAME" || rec.Tags[0]["value"] != "John /Doe/" || rec.Tags[0]["level"] != 1.0 {
+		t.Errorf("tags = %v", rec.Tags)
+	}
+	if rec.Entity["Tags"] != nil {
+		t.Errorf("shared entity tags written twice: %v", rec.Entity["Tags"])
+	}
+	if _, ok := rec.Entity["Names"]; !ok {
+		t.Errorf("entity = %v, want Names", rec.Entity)
+	}
+}
+
+func TestRoundTrip_BuiltDocument(t *testing.T) {
+	doc := &gedcom.Document{Header: &gedcom.Header{Version: gedcom.Version70}}
+	indi := &gedcom.Individual{
+		Names:  []*gedcom.PersonalName{{Full: "Ann /Lee/", Given: "Ann", Surname: "Lee"}},
+		Events: []*gedcom.Event{{Type: gedcom.EventBirth, Date: "1 JAN 1900"}},
+	}
+	if _, err := doc.AddIndividual(indi); err != nil {
+		t.Fatal(err)
+	}
+	doc.Records[0].Tags = []*gedcom.Tag{{Level: 1, Tag: "_NOTE", Value: "raw only"}}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	got, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	record := got.GetRecord("@I1@")
+	if record == nil {
+		t.Fatal("@I1@ missing after round trip")
+	}
+	ann, ok := record.GetIndividual()
+	if !ok || ann.Names[0].Full != "Ann /Lee/" || ann.Events[0].Date != "1 JAN 1900" {
+		t.Errorf("individual = %+v", ann)
+	}
+	if ann.Tags != nil || len(record.Tags) != 1 {
+		t.Errorf("entity tags %v, record tags %v; want the record's own tags kept apart", ann.Tags, record.Tags)
+	}
+	if got.GetIndividual("@I1@") != ann {
+		t.Error("XRefMap does not resolve the decoded record")
+	}
+}
+
+func TestMarshalRecord(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 7.0\n0 @I1@ INDI\n1 NAME Ann /Lee/\n1 BIRT\n2 DATE 1 JAN 1900\n0 TRLR\n"
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := MarshalRecord(doc.GetRecord("@I1@"))
+	if err != nil {
+		t.Fatalf("MarshalRecord() error = %v", err)
+	}
+	record, err := UnmarshalRecord(data)
+	if err != nil {
+		t.Fatalf("UnmarshalRecord() error = %v", err)
+	}
+	ann, ok := record.GetIndividual()
+	if !ok || record.XRef != "@I1@" || ann.Names[0].Full != "Ann /Lee/" || ann.Events[0].Date != "1 JAN 1900" {
+		t.Fatalf("record = %+v, entity = %+v", record, record.Entity)
+	}
+	if len(record.Tags) != 3 || !sameTags(ann.Tags, record.Tags) {
+		t.Errorf("entity tags %v, record tags %v; want shared tags", ann.Tags, record.Tags)
+	}
+
+	if _, err := MarshalRecord(nil); err == nil {
+		t.Error("MarshalRecord(nil) error = nil, want error")
+	}
+	if _, err := UnmarshalRecord([]byte(`{"type": "SUBN", "entity": {}}`)); err == nil {
+		t.Error("UnmarshalRecord(entity mismatch) error = nil, want error")
+	}
+}
+
+func TestMarshal_Errors(t *testing.T) {
+	if _, err := Marshal(nil); err == nil {
+		t.Error("Marshal(nil) error = nil, want error")
+	}
+	doc := &gedcom.Document{Records: []*gedcom.Record{
+		{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Family{}},
+	}}
+	if _, err := Marshal(doc); err == nil {
+		t.Error("Marshal(mismatched entity) error = nil, want error")
+	}
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	tests := map[string]string{
+		"invalid JSON":     `{`,
+		"other format":     `{"format": "gedcomx", "version": 1}`,
+		"unknown version":  `{"format": "gedcom-go", "version": 99}`,
+		"entity mismatch":  `{"format": "gedcom-go", "version": 1, "records": [{"type": "SUBN", "entity": {}}]}`,
+		"malformed entity": `{"format": "gedcom-go", "version": 1, "records": [{"type": "INDI", "entity": {"Names": 3}}]}`,
+	}
+	for name, input := range tests {
+		if _, err := Unmarshal([]byte(input)); err == nil {
+			t.Errorf("%s: Unmarshal() error = nil, want error", name)
+		}
+	}
+}
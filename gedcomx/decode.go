@@ -0,0 +1,430 @@
+package gedcomx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// document is the top-level GEDCOM X JSON object.
+type document struct {
+	Persons            []person            `json:"persons"`
+	Relationships      []relationship      `json:"relationships"`
+	ChildAndParents    []childAndParents   `json:"childAndParentsRelationships"`
+	SourceDescriptions []sourceDescription `json:"sourceDescriptions"`
+}
+
+type person struct {
+	ID      string            `json:"id"`
+	Gender  *typed            `json:"gender"`
+	Names   []name            `json:"names"`
+	Facts   []fact            `json:"facts"`
+	Sources []sourceReference `json:"sources"`
+	Notes   []note            `json:"notes"`
+}
+
+type relationship struct {
+	ID      string            `json:"id"`
+	Type    string            `json:"type"`
+	Person1 *resource         `json:"person1"`
+	Person2 *resource         `json:"person2"`
+	Facts   []fact            `json:"facts"`
+	Sources []sourceReference `json:"sources"`
+	Notes   []note            `json:"notes"`
+}
+
+// childAndParents is the FamilySearch extension relating a child to both
+// parents at once. Older files name the parents father and mother.
+type childAndParents struct {
+	Parent1 *resource `json:"parent1"`
+	Parent2 *resource `json:"parent2"`
+	Father  *resource `json:"father"`
+	Mother  *resource `json:"mother"`
+	Child   *resource `json:"child"`
+}
+
+type sourceDescription struct {
+	ID        string      `json:"id"`
+	Titles    []textValue `json:"titles"`
+	Citations []textValue `json:"citations"`
+	Notes     []note      `json:"notes"`
+}
+
+type typed struct {
+	Type string `json:"type"`
+}
+
+type name struct {
+	Type      string     `json:"type"`
+	NameForms []nameForm `json:"nameForms"`
+}
+
+type nameForm struct {
+	FullText string     `json:"fullText"`
+	Parts    []namePart `json:"parts"`
+}
+
+type namePart struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type fact struct {
+	Type    string            `json:"type"`
+	Date    *date             `json:"date"`
+	Place   *place            `json:"place"`
+	Value   string            `json:"value"`
+	Sources []sourceReference `json:"sources"`
+	Notes   []note            `json:"notes"`
+}
+
+type date struct {
+	Original string `json:"original"`
+	Formal   string `json:"formal"`
+}
+
+type place struct {
+	Original string `json:"original"`
+}
+
+type sourceReference struct {
+	Description   string `json:"description"`
+	DescriptionID string `json:"descriptionId"`
+}
+
+type resource struct {
+	Resource   string `json:"resource"`
+	ResourceID string `json:"resourceId"`
+}
+
+type note struct {
+	Text string `json:"text"`
+}
+
+type textValue struct {
+	Value string `json:"value"`
+}
+
+// Relationship types.
+const (
+	typeCouple      = "http://gedcomx.org/Couple"
+	typeParentChild = "http://gedcomx.org/ParentChild"
+)
+
+// Decode reads a GEDCOM X JSON document from r and returns it as a GEDCOM
+// 7.0 document. It returns an error if r is not a GEDCOM X JSON object.
+func Decode(r io.Reader) (*gedcom.Document, error) {
+	var in document
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return nil, fmt.Errorf("gedcomx: %w", err)
+	}
+
+	b := &builder{
+		doc: &gedcom.Document{
+			Header: &gedcom.Header{
+				Version:  gedcom.Version70,
+				Encoding: gedcom.EncodingUTF8,
+			},
+			XRefMap: make(map[string]*gedcom.Record),
+		},
+		sources:  make(map[string]string),
+		persons:  make(map[string]*gedcom.Individual),
+		families: make(map[string]*gedcom.Family),
+	}
+	for i := range in.SourceDescriptions {
+		if err := b.source(&in.SourceDescriptions[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range in.Persons {
+		if err := b.person(&in.Persons[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range in.Relationships {
+		if rel := &in.Relationships[i]; rel.Type == typeCouple {
+			b.couple(rel)
+		}
+	}
+	for i := range in.Relationships {
+		if rel := &in.Relationships[i]; rel.Type == typeParentChild {
+			b.addParent(rel.Person2, rel.Person1)
+		}
+	}
+	for _, rel := range in.ChildAndParents {
+		for _, parent := range []*resource{rel.Parent1, rel.Parent2, rel.Father, rel.Mother} {
+			b.addParent(rel.Child, parent)
+		}
+	}
+	if err := b.addFamilies(); err != nil {
+		return nil, err
+	}
+	return b.doc, nil
+}
+
+// builder accumulates the records of a decoded document. Individuals are
+// added as they are read; families are collected first, since a family
+// gains its children from relationships that may come in any order.
+type builder struct {
+	doc *gedcom.Document
+
+	sources map[string]string             // source description id -> XRef
+	persons map[string]*gedcom.Individual // person id -> individual
+
+	families   map[string]*gedcom.Family // parent key -> family
+	familyList []*gedcom.Family          // in creation order
+	childOrder []string                  // children in order of first parent link
+	parents    map[string][]string       // child id -> parent ids
+}
+
+// source adds a SOUR record for d.
+func (b *builder) source(d *sourceDescription) error {
+	src := &gedcom.Source{
+		XRef:              b.doc.NextXRef("S"),
+		AutomatedRecordID: d.ID,
+		InlineNotes:       noteTexts(d.Notes),
+	}
+	src.Notes = src.InlineNotes
+	if len(d.Titles) > 0 {
+		src.Title = d.Titles[0].Value
+	}
+	if len(d.Citations) > 0 {
+		if src.Title == "" {
+			src.Title = d.Citations[0].Value
+		} else {
+			src.Text = d.Citations[0].Value
+		}
+	}
+	if err := b.doc.AddRecord(&gedcom.Record{XRef: src.XRef, Type: gedcom.RecordTypeSource, Entity: src}); err != nil {
+		return fmt.Errorf("gedcomx: source %s: %w", d.ID, err)
+	}
+	if d.ID != "" {
+		b.sources[d.ID] = src.XRef
+	}
+	return nil
+}
+
+// person adds an INDI record for p.
+func (b *builder) person(p *person) error {
+	indi := &gedcom.Individual{
+		AutomatedRecordID: p.ID,
+		SourceCitations:   b.citations(p.Sources),
+		InlineNotes:       noteTexts(p.Notes),
+	}
+	indi.Notes = indi.InlineNotes
+	if p.Gender != nil {
+		indi.Sex = genders[trimType(p.Gender.Type)]
+	}
+	for i := range p.Names {
+		if n := personalName(&p.Names[i]); n != nil {
+			indi.Names = append(indi.Names, n)
+		}
+	}
+	for i := range p.Facts {
+		f := &p.Facts[i]
+		if tag, ok := attributes[trimType(f.Type)]; ok {
+			text, parsed := factDate(f.Date)
+			indi.Attributes = append(indi.Attributes, &gedcom.Attribute{
+				Type:            tag,
+				Value:           f.Value,
+				Date:            text,
+				ParsedDate:      parsed,
+				Place:           factPlace(f.Place),
+				SourceCitations: b.citations(f.Sources),
+			})
+			continue
+		}
+		indi.Events = append(indi.Events, b.event(f, personEvents))
+	}
+
+	if _, err := b.doc.AddIndividual(indi); err != nil {
+		return fmt.Errorf("gedcomx: person %s: %w", p.ID, err)
+	}
+	if p.ID != "" {
+		b.persons[p.ID] = indi
+	}
+	return nil
+}
+
+// couple starts the family of a Couple relationship.
+func (b *builder) couple(rel *relationship) {
+	var spouses []*gedcom.Individual
+	for _, ref := range []*resource{rel.Person1, rel.Person2} {
+		if indi := b.individual(ref); indi != nil {
+			spouses = append(spouses, indi)
+		}
+	}
+	if len(spouses) == 0 {
+		return
+	}
+	fam := b.family(spouses)
+	fam.AutomatedRecordID = rel.ID
+	fam.SourceCitations = append(fam.SourceCitations, b.citations(rel.Sources)...)
+	fam.InlineNotes = append(fam.InlineNotes, noteTexts(rel.Notes)...)
+	fam.Notes = fam.InlineNotes
+	for i := range rel.Facts {
+		fam.Events = append(fam.Events, b.event(&rel.Facts[i], coupleEvents))
+	}
+}
+
+// addParent records parent as a parent of child.
+func (b *builder) addParent(child, parent *resource) {
+	c, p := b.individual(child), b.individual(parent)
+	if c == nil || p == nil || c == p {
+		return
+	}
+	childID, parentID := c.AutomatedRecordID, p.AutomatedRecordID
+	if b.parents == nil {
+		b.parents = make(map[string][]string)
+	}
+	known, seen := b.parents[childID]
+	if !seen {
+		b.childOrder = append(b.childOrder, childID)
+	}
+	for _, id := range known {
+		if id == parentID {
+			return
+		}
+	}
+	b.parents[childID] = append(known, parentID)
+}
+
+// addFamilies adds every child to the family of its parents, then adds the
+// families to the document. A child with more than two parents, such as
+// birth and adoptive parents, joins the family of its first two.
+func (b *builder) addFamilies() error {
+	for _, childID := range b.childOrder {
+		ids := b.parents[childID]
+		if len(ids) > 2 {
+			ids = ids[:2]
+		}
+		parents := make([]*gedcom.Individual, len(ids))
+		for i, id := range ids {
+			parents[i] = b.persons[id]
+		}
+		fam := b.family(parents)
+		fam.Children = append(fam.Children, b.persons[childID].XRef)
+	}
+	for _, fam := range b.familyList {
+		if _, err := b.doc.AddFamily(fam); err != nil {
+			return fmt.Errorf("gedcomx: relationship %s: %w", fam.AutomatedRecordID, err)
+		}
+	}
+	return nil
+}
+
+// family returns the family of the given one or two parents, creating it
+// when it does not exist yet. The husband is the male parent, or the first
+// one when neither or both are male.
+func (b *builder) family(parents []*gedcom.Individual) *gedcom.Family {
+	ids := make([]string, len(parents))
+	for i, p := range parents {
+		ids[i] = p.AutomatedRecordID
+	}
+	sort.Strings(ids)
+	key := strings.Join(ids, "\x00")
+	if fam, ok := b.families[key]; ok {
+		return fam
+	}
+
+	husband, wife := parents[0], (*gedcom.Individual)(nil)
+	if len(parents) > 1 {
+		wife = parents[1]
+		if (husband.Sex == "F" && wife.Sex != "F") || (wife.Sex == "M" && husband.Sex != "M") {
+			husband, wife = wife, husband
+		}
+	} else if husband.Sex == "F" {
+		husband, wife = nil, husband
+	}
+	fam := &gedcom.Family{}
+	if husband != nil {
+		fam.Husband = husband.XRef
+	}
+	if wife != nil {
+		fam.Wife = wife.XRef
+	}
+	b.families[key] = fam
+	b.familyList = append(b.familyList, fam)
+	return fam
+}
+
+// event converts f to an event, using types to name standard facts.
+func (b *builder) event(f *fact, types map[string]gedcom.EventType) *gedcom.Event {
+	text, parsed := factDate(f.Date)
+	event := &gedcom.Event{
+		Date:            text,
+		ParsedDate:      parsed,
+		Place:           factPlace(f.Place),
+		Description:     f.Value,
+		SourceCitations: b.citations(f.Sources),
+		Notes:           noteTexts(f.Notes),
+	}
+	name := trimType(f.Type)
+	if t, ok := types[name]; ok {
+		event.Type = t
+	} else {
+		event.Type = gedcom.EventGeneric
+		event.EventTypeDetail = name
+	}
+	return event
+}
+
+// citations converts source references to citations of the SOUR records
+// they describe, skipping references to descriptions not in the file.
+func (b *builder) citations(refs []sourceReference) []*gedcom.SourceCitation {
+	var citations []*gedcom.SourceCitation
+	for _, ref := range refs {
+		id := ref.DescriptionID
+		if id == "" {
+			id = localID(ref.Description)
+		}
+		if xref, ok := b.sources[id]; ok {
+			citations = append(citations, &gedcom.SourceCitation{SourceXRef: xref})
+		}
+	}
+	return citations
+}
+
+// individual returns the individual ref points at, or nil.
+func (b *builder) individual(ref *resource) *gedcom.Individual {
+	if ref == nil {
+		return nil
+	}
+	id := ref.ResourceID
+	if id == "" {
+		id = localID(ref.Resource)
+	}
+	return b.persons[id]
+}
+
+// localID returns the id a resource URI refers to: the fragment of
+// "#P1" or "https://example.com/tree#P1", or the URI itself.
+func localID(uri string) string {
+	if i := strings.LastIndex(uri, "#"); i >= 0 {
+		return uri[i+1:]
+	}
+	return uri
+}
+
+// noteTexts returns the text of each note, skipping empty ones.
+func noteTexts(notes []note) []string {
+	var texts []string
+	for _, n := range notes {
+		if n.Text != "" {
+			texts = append(texts, n.Text)
+		}
+	}
+	return texts
+}
+
+// factPlace returns the original text of p.
+func factPlace(p *place) string {
+	if p == nil {
+		return ""
+	}
+	return p.Original
+}
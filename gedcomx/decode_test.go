@@ -0,0 +1,182 @@
+package gedcomx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const testGEDCOMX = `{
+  "sourceDescriptions": [
+    {"id": "S1", "titles": [{"value": "1900 Census"}], "citations": [{"value": "United States Census, 1900, Boston"}]}
+  ],
+  "persons": [
+    {
+      "id": "P1",
+      "gender": {"type": "http://gedcomx.org/Male"},
+      "names": [{"nameForms": [{"fullText": "John Doe", "parts": [
+        {"type": "http://gedcomx.org/Given", "value": "John"},
+        {"type": "http://gedcomx.org/Surname", "value": "Doe"}
+      ]}]}],
+      "facts": [
+        {"type": "http://gedcomx.org/Birth", "date": {"original": "1 Jan 1870", "formal": "+1870-01-01"}, "place": {"original": "Boston, Massachusetts"}},
+        {"type": "http://gedcomx.org/Occupation", "value": "Carpenter"},
+        {"type": "data:,Militia", "value": "Private", "date": {"original": "the spring of 1898", "formal": "A+1898"}}
+      ],
+      "sources": [{"description": "#S1"}],
+      "notes": [{"text": "Called Jack."}]
+    },
+    {
+      "id": "P2",
+      "gender": {"type": "http://gedcomx.org/Female"},
+      "names": [{"type": "http://gedcomx.org/BirthName", "nameForms": [{"fullText": "Mary Smith"}]}]
+    },
+    {
+      "id": "P3",
+      "names": [{"nameForms": [{"parts": [
+        {"type": "http://gedcomx.org/Given", "value": "Ann"},
+        {"type": "http://gedcomx.org/Surname", "value": "Doe"}
+      ]}]}]
+    },
+    {"id": "P4", "gender": {"type": "http://gedcomx.org/Female"}}
+  ],
+  "relationships": [
+    {"id": "R1", "type": "http://gedcomx.org/Couple",
+     "person1": {"resource": "#P2"}, "person2": {"resource": "#P1"},
+     "facts": [{"type": "http://gedcomx.org/Marriage", "date": {"formal": "+1895-06"}}],
+     "sources": [{"descriptionId": "S1"}]},
+    {"type": "http://gedcomx.org/ParentChild", "person1": {"resource": "#P1"}, "person2": {"resource": "#P3"}},
+    {"type": "http://gedcomx.org/ParentChild", "person1": {"resource": "#P2"}, "person2": {"resource": "#P3"}},
+    {"type": "http://gedcomx.org/ParentChild", "person1": {"resource": "#P9"}, "person2": {"resource": "#P3"}}
+  ],
+  "childAndParentsRelationships": [
+    {"parent2": {"resourceId": "P4"}, "child": {"resource": "https://example.com/tree#P1"}}
+  ]
+}`
+
+func decodeTest(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := Decode(strings.NewReader(testGEDCOMX))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestDecode_Persons(t *testing.T) {
+	doc := decodeTest(t)
+	if doc.Header.Version != gedcom.Version70 {
+		t.Errorf("Version = %q, want 7.0", doc.Header.Version)
+	}
+	if got := len(doc.Individuals()); got != 4 {
+		t.Fatalf("got %d individuals, want 4", got)
+	}
+
+	john := doc.GetIndividual("@I1@")
+	if john == nil || john.AutomatedRecordID != "P1" || john.Sex != "M" {
+		t.Fatalf("@I1@ = %+v, want male P1", john)
+	}
+	if len(john.Names) != 1 || john.Names[0].Full != "John /Doe/" || john.Names[0].Given != "John" || john.Names[0].Surname != "Doe" {
+		t.Errorf("Names = %+v, want John /Doe/", john.Names[0])
+	}
+	birth := john.FirstEvent(gedcom.EventBirth)
+	if birth == nil || birth.Date != "1 Jan 1870" || birth.ParsedDate == nil || birth.Place != "Boston, Massachusetts" {
+		t.Errorf("birth = %+v", birth)
+	}
+	if len(john.Attributes) != 1 || john.Attributes[0].Type != "OCCU" || john.Attributes[0].Value != "Carpenter" {
+		t.Errorf("Attributes = %+v, want OCCU Carpenter", john.Attributes)
+	}
+	custom := john.FirstEvent(gedcom.EventGeneric)
+	if custom == nil || custom.EventTypeDetail != "Militia" || custom.Description != "Private" || custom.Date != "ABT 1898" {
+		t.Errorf("custom event = %+v, want EVEN Militia ABT 1898", custom)
+	}
+	if len(john.SourceCitations) != 1 || john.SourceCitations[0].SourceXRef != "@S1@" {
+		t.Errorf("SourceCitations = %+v, want @S1@", john.SourceCitations)
+	}
+	if len(john.InlineNotes) != 1 || john.InlineNotes[0] != "Called Jack." {
+		t.Errorf("InlineNotes = %v", john.InlineNotes)
+	}
+
+	mary := doc.GetIndividual("@I2@")
+	if mary.Names[0].Full != "Mary Smith" || mary.Names[0].Type != "BIRTH" {
+		t.Errorf("Mary's name = %+v, want Mary Smith of type BIRTH", mary.Names[0])
+	}
+
+	src := doc.GetSource("@S1@")
+	if src == nil || src.Title != "1900 Census" || src.Text != "United States Census, 1900, Boston" || src.AutomatedRecordID != "S1" {
+		t.Errorf("source = %+v", src)
+	}
+}
+
+func TestDecode_Families(t *testing.T) {
+	doc := decodeTest(t)
+	families := doc.Families()
+	if len(families) != 2 {
+		t.Fatalf("got %d families, want 2", len(families))
+	}
+
+	couple := doc.GetFamily("@F1@")
+	if couple.Husband != "@I1@" || couple.Wife != "@I2@" || couple.AutomatedRecordID != "R1" {
+		t.Errorf("couple = %+v, want husband @I1@ and wife @I2@", couple)
+	}
+	if len(couple.Children) != 1 || couple.Children[0] != "@I3@" {
+		t.Errorf("Children = %v, want [@I3@] (the unknown third parent is skipped)", couple.Children)
+	}
+	marriage := couple.Events
+	if len(marriage) != 1 || marriage[0].Type != gedcom.EventMarriage || marriage[0].Date != "JUN 1895" {
+		t.Errorf("Events = %+v, want MARR JUN 1895", marriage)
+	}
+	if len(couple.SourceCitations) != 1 {
+		t.Errorf("SourceCitations = %+v", couple.SourceCitations)
+	}
+
+	single := doc.GetFamily("@F2@")
+	if single.Husband != "" || single.Wife != "@I4@" || len(single.Children) != 1 || single.Children[0] != "@I1@" {
+		t.Errorf("single-parent family = %+v, want wife @I4@ with child @I1@", single)
+	}
+
+	ann := doc.GetIndividual("@I3@")
+	if len(ann.ChildInFamilies) != 1 || ann.ChildInFamilies[0].FamilyXRef != "@F1@" {
+		t.Errorf("Ann's FAMC = %+v, want @F1@", ann.ChildInFamilies)
+	}
+	john := doc.GetIndividual("@I1@")
+	if len(john.SpouseInFamilies) != 1 || john.SpouseInFamilies[0] != "@F1@" {
+		t.Errorf("John's FAMS = %v, want [@F1@]", john.SpouseInFamilies)
+	}
+}
+
+func TestDecode_Encode(t *testing.T) {
+	doc := decodeTest(t)
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	decoded, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("decoder.Decode() error = %v", err)
+	}
+	if len(decoded.Individuals()) != 4 || len(decoded.Families()) != 2 || len(decoded.Sources()) != 1 {
+		t.Errorf("re-decoded %d individuals, %d families, %d sources; want 4, 2, 1",
+			len(decoded.Individuals()), len(decoded.Families()), len(decoded.Sources()))
+	}
+	if ann := decoded.GetIndividual("@I3@"); ann == nil || len(ann.ChildInFamilies) != 1 {
+		t.Errorf("re-decoded @I3@ = %+v, want a child of @F1@", ann)
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	for _, input := range []string{"", "not json", `["persons"]`} {
+		if _, err := Decode(strings.NewReader(input)); err == nil {
+			t.Errorf("Decode(%q) error = nil, want error", input)
+		}
+	}
+
+	doc, err := Decode(strings.NewReader(`{}`))
+	if err != nil || len(doc.Records) != 0 {
+		t.Errorf("Decode({}) = %d records, %v; want empty document", len(doc.Records), err)
+	}
+}
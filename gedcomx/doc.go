@@ -0,0 +1,34 @@
+// Package gedcomx imports GEDCOM X JSON (application/x-gedcomx-v1+json),
+// the data model of the FamilySearch API, into GEDCOM documents.
+//
+// Decode maps the GEDCOM X conceptual model onto GEDCOM records:
+//
+//   - persons become INDI records, with their names, gender, facts, notes,
+//     and source references; the GEDCOM X id is kept as the record's
+//     AutomatedRecordID (RIN)
+//   - source descriptions become SOUR records
+//   - Couple relationships become FAM records with HUSB and WIFE, their
+//     facts becoming family events
+//   - ParentChild relationships, and FamilySearch
+//     childAndParentsRelationships, add each child to the family of its
+//     parents, creating a family for parents without a Couple relationship
+//
+// Facts of a standard type (http://gedcomx.org/Birth, .../Occupation, ...)
+// become the matching event or attribute; other types become EVEN events
+// whose TYPE names the fact type. Dates keep their original text when it
+// parses as a GEDCOM date and are otherwise converted from the formal
+// GEDCOM X date.
+//
+// Example usage:
+//
+//	f, _ := os.Open("tree.gedcomx.json")
+//	defer f.Close()
+//	doc, err := gedcomx.Decode(f)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	_ = encoder.Encode(out, doc)
+//
+// References to persons or source descriptions not in the file are
+// skipped rather than reported as errors.
+package gedcomx
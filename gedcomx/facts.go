@@ -0,0 +1,230 @@
+package gedcomx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// typePrefix starts every standard GEDCOM X type URI.
+const typePrefix = "http://gedcomx.org/"
+
+// personEvents maps standard person fact types to GEDCOM events.
+var personEvents = map[string]gedcom.EventType{
+	"Adoption":         gedcom.EventAdoption,
+	"AdultChristening": gedcom.EventAdultChristening,
+	"Baptism":          gedcom.EventBaptism,
+	"BarMitzvah":       gedcom.EventBarMitzvah,
+	"BatMitzvah":       gedcom.EventBasMitzvah,
+	"Birth":            gedcom.EventBirth,
+	"Blessing":         gedcom.EventBlessing,
+	"Burial":           gedcom.EventBurial,
+	"Census":           gedcom.EventCensus,
+	"Christening":      gedcom.EventChristening,
+	"Confirmation":     gedcom.EventConfirmation,
+	"Cremation":        gedcom.EventCremation,
+	"Death":            gedcom.EventDeath,
+	"Emigration":       gedcom.EventEmigration,
+	"FirstCommunion":   gedcom.EventFirstCommunion,
+	"Graduation":       gedcom.EventGraduation,
+	"Immigration":      gedcom.EventImmigration,
+	"Naturalization":   gedcom.EventNaturalization,
+	"Ordination":       gedcom.EventOrdination,
+	"Probate":          gedcom.EventProbate,
+	"Residence":        gedcom.EventResidence,
+	"Retirement":       gedcom.EventRetirement,
+	"Will":             gedcom.EventWill,
+}
+
+// attributes maps standard person fact types to GEDCOM attribute tags.
+var attributes = map[string]string{
+	"Caste":               "CAST",
+	"Education":           "EDUC",
+	"NationalId":          "IDNO",
+	"Nationality":         "NATI",
+	"NumberOfChildren":    "NCHI",
+	"NumberOfMarriages":   "NMR",
+	"Occupation":          "OCCU",
+	"PhysicalDescription": "DSCR",
+	"Religion":            "RELI",
+}
+
+// coupleEvents maps standard couple fact types to GEDCOM family events.
+var coupleEvents = map[string]gedcom.EventType{
+	"Annulment":        gedcom.EventAnnulment,
+	"Divorce":          gedcom.EventDivorce,
+	"DivorceFiling":    gedcom.EventDivorceFiling,
+	"Engagement":       gedcom.EventEngagement,
+	"Marriage":         gedcom.EventMarriage,
+	"MarriageBanns":    gedcom.EventMarriageBann,
+	"MarriageContract": gedcom.EventMarriageContract,
+	"MarriageLicense":  gedcom.EventMarriageLicense,
+}
+
+// genders maps gender types to SEX values.
+var genders = map[string]string{
+	"Male":     "M",
+	"Female":   "F",
+	"Intersex": "X",
+	"Unknown":  "U",
+}
+
+// nameTypes maps name types to GEDCOM 7.0 NAME.TYPE values.
+var nameTypes = map[string]string{
+	"BirthName":     "BIRTH",
+	"MarriedName":   "MARRIED",
+	"AlsoKnownAs":   "AKA",
+	"Nickname":      "AKA",
+	"AdoptiveName":  "OTHER",
+	"FormalName":    "OTHER",
+	"ReligiousName": "OTHER",
+}
+
+// months are the GEDCOM month abbreviations.
+var months = [...]string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+
+// trimType returns the name of a type URI: the part after
+// "http://gedcomx.org/" for standard types, or after the last "/", "#",
+// or ":" for others ("data:,LifeSketch" gives "LifeSketch").
+func trimType(uri string) string {
+	if name, ok := strings.CutPrefix(uri, typePrefix); ok {
+		return name
+	}
+	if i := strings.LastIndexAny(uri, "/#:,"); i >= 0 {
+		return uri[i+1:]
+	}
+	return uri
+}
+
+// personalName converts the first name form of n.
+func personalName(n *name) *gedcom.PersonalName {
+	if len(n.NameForms) == 0 {
+		return nil
+	}
+	form := n.NameForms[0]
+	pn := &gedcom.PersonalName{Type: nameTypes[trimType(n.Type)]}
+	var given []string
+	for _, part := range form.Parts {
+		switch trimType(part.Type) {
+		case "Given":
+			given = append(given, part.Value)
+		case "Surname":
+			pn.Surname = strings.TrimSpace(pn.Surname + " " + part.Value)
+		case "Prefix":
+			pn.Prefix = part.Value
+		case "Suffix":
+			pn.Suffix = part.Value
+		}
+	}
+	pn.Given = strings.Join(given, " ")
+	if trimType(n.Type) == "Nickname" {
+		pn.Nickname = form.FullText
+	}
+
+	if pn.Surname == "" && form.FullText != "" {
+		pn.Full = form.FullText
+		return pn
+	}
+	full := strings.TrimSpace(pn.Prefix + " " + pn.Given)
+	full = strings.TrimSpace(full + " /" + pn.Surname + "/")
+	if pn.Suffix != "" {
+		full += " " + pn.Suffix
+	}
+	pn.Full = full
+	return pn
+}
+
+// factDate returns the GEDCOM date text of d and its parse: the original
+// text when it parses as a GEDCOM date, else the formal date converted,
+// else the original text unparsed.
+func factDate(d *date) (string, *gedcom.Date) {
+	if d == nil {
+		return "", nil
+	}
+	if d.Original != "" {
+		if parsed, err := gedcom.ParseDate(d.Original); err == nil {
+			return d.Original, parsed
+		}
+	}
+	if text := formalDate(d.Formal); text != "" {
+		parsed, _ := gedcom.ParseDate(text)
+		return text, parsed
+	}
+	if d.Original != "" {
+		return d.Original, nil
+	}
+	return "", nil
+}
+
+// formalDate converts a GEDCOM X formal date ("+1900-01-02", "A+1900",
+// "+1900/+1910", "/+1900", "+1900/") to GEDCOM date text, or returns ""
+// for forms it cannot express, such as recurring dates.
+func formalDate(formal string) string {
+	if formal == "" || strings.HasPrefix(formal, "R") {
+		return ""
+	}
+	approximate := strings.HasPrefix(formal, "A")
+	formal = strings.TrimPrefix(formal, "A")
+
+	start, end, isRange := strings.Cut(formal, "/")
+	if !isRange {
+		text := simpleDate(start)
+		if text != "" && approximate {
+			text = "ABT " + text
+		}
+		return text
+	}
+	from, to := simpleDate(start), simpleDate(end)
+	switch {
+	case start != "" && from == "", end != "" && to == "":
+		return ""
+	case from != "" && to != "":
+		return fmt.Sprintf("BET %s AND %s", from, to)
+	case from != "":
+		return "AFT " + from
+	case to != "":
+		return "BEF " + to
+	}
+	return ""
+}
+
+// simpleDate converts a formal simple date, "+YYYY[-MM[-DD]]" with an
+// optional time, to GEDCOM date text. Years before 1 are not supported.
+func simpleDate(s string) string {
+	s, _, _ = strings.Cut(s, "T")
+	s, ok := strings.CutPrefix(s, "+")
+	if !ok {
+		return ""
+	}
+	parts := strings.Split(s, "-")
+	if len(parts) > 3 {
+		return ""
+	}
+	nums := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return ""
+		}
+		nums[i] = n
+	}
+	if nums[0] < 1 {
+		return ""
+	}
+	switch len(nums) {
+	case 1:
+		return strconv.Itoa(nums[0])
+	case 2:
+		if nums[1] < 1 || nums[1] > 12 {
+			return ""
+		}
+		return fmt.Sprintf("%s %d", months[nums[1]-1], nums[0])
+	default:
+		if nums[1] < 1 || nums[1] > 12 || nums[2] < 1 || nums[2] > 31 {
+			return ""
+		}
+		return fmt.Sprintf("%d %s %d", nums[2], months[nums[1]-1], nums[0])
+	}
+}
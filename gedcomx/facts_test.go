@@ -0,0 +1,77 @@
+package gedcomx
+
+import "testing"
+
+func TestFormalDate(t *testing.T) {
+	tests := []struct {
+		formal string
+		want   string
+	}{
+		{"+1900", "1900"},
+		{"+1900-03", "MAR 1900"},
+		{"+1900-03-07", "7 MAR 1900"},
+		{"+1900-03-07T12:00:00", "7 MAR 1900"},
+		{"A+1900", "ABT 1900"},
+		{"+1900/+1910", "BET 1900 AND 1910"},
+		{"A+1900/+1910", "BET 1900 AND 1910"},
+		{"+1900/", "AFT 1900"},
+		{"/+1900-05", "BEF MAY 1900"},
+		{"", ""},
+		{"R3/+1900/P1Y", ""},
+		{"-0100", ""},
+		{"+1900-13", ""},
+		{"+1900/x", ""},
+		{"1900", ""},
+	}
+	for _, tt := range tests {
+		if got := formalDate(tt.formal); got != tt.want {
+			t.Errorf("formalDate(%q) = %q, want %q", tt.formal, got, tt.want)
+		}
+	}
+}
+
+func TestFactDate(t *testing.T) {
+	if text, parsed := factDate(&date{Original: "about 1850", Formal: "A+1850"}); text != "ABT 1850" || parsed == nil {
+		t.Errorf("unparseable original: got %q, %v; want ABT 1850 from the formal date", text, parsed)
+	}
+	if text, parsed := factDate(&date{Original: "sometime"}); text != "sometime" || parsed != nil {
+		t.Errorf("original only: got %q, %v; want the original unparsed", text, parsed)
+	}
+	if text, parsed := factDate(nil); text != "" || parsed != nil {
+		t.Errorf("nil date: got %q, %v", text, parsed)
+	}
+}
+
+func TestTrimType(t *testing.T) {
+	tests := map[string]string{
+		"http://gedcomx.org/Birth":                 "Birth",
+		"data:,LifeSketch":                         "LifeSketch",
+		"http://familysearch.org/v1/TribeName":     "TribeName",
+		"http://example.com/types#MilitaryService": "MilitaryService",
+		"Custom": "Custom",
+	}
+	for uri, want := range tests {
+		if got := trimType(uri); got != want {
+			t.Errorf("trimType(%q) = %q, want %q", uri, got, want)
+		}
+	}
+}
+
+func TestPersonalName(t *testing.T) {
+	n := personalName(&name{
+		Type: "http://gedcomx.org/MarriedName",
+		NameForms: []nameForm{{Parts: []namePart{
+			{Type: "http://gedcomx.org/Prefix", Value: "Dr."},
+			{Type: "http://gedcomx.org/Given", Value: "Anna"},
+			{Type: "http://gedcomx.org/Given", Value: "Maria"},
+			{Type: "http://gedcomx.org/Surname", Value: "van Berg"},
+			{Type: "http://gedcomx.org/Suffix", Value: "Jr."},
+		}}},
+	})
+	if n.Full != "Dr. Anna Maria /van Berg/ Jr." || n.Given != "Anna Maria" || n.Type != "MARRIED" {
+		t.Errorf("personalName() = %+v", n)
+	}
+	if n := personalName(&name{}); n != nil {
+		t.Errorf("personalName(no forms) = %+v, want nil", n)
+	}
+}
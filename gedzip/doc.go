@@ -0,0 +1,38 @@
+// Package gedzip reads and writes GEDZip archives (.gdz), the GEDCOM 7.0
+// packaging format that bundles a dataset with the media files it
+// references.
+//
+// A GEDZip archive is a ZIP file containing the dataset as "gedcom.ged" at
+// its root, plus any number of other files. FILE payloads in the dataset
+// that are relative paths refer to the packaged file at that path, with
+// URL percent-encoding where needed.
+//
+// Reading:
+//
+//	r, err := gedzip.OpenReader("family.gdz")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer r.Close()
+//	doc, err := r.Decode()
+//	for _, f := range r.MediaFiles() {
+//	    fmt.Println(f.Path, f.Size)
+//	}
+//	rc, err := r.Open("photos/grandma.jpg") // or a FILE payload
+//
+// Writing:
+//
+//	err := gedzip.Write(w, doc, map[string][]byte{
+//	    "photos/grandma.jpg": jpegBytes,
+//	}, nil)
+//
+// or, for media too large to hold in memory, with a Writer:
+//
+//	zw := gedzip.NewWriter(w)
+//	if err := zw.WriteDocument(doc, nil); err != nil { ... }
+//	if err := zw.AddMedia("video/wedding.mp4", f); err != nil { ... }
+//	err = zw.Close()
+//
+// The dataset is encoded as given; GEDZip is defined for GEDCOM 7.0, so
+// convert older documents with converter.Convert first.
+package gedzip
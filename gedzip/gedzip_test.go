@@ -0,0 +1,291 @@
+package gedzip
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const testGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 NAME John /Doe/
+1 OBJE @M1@
+0 @M1@ OBJE
+1 FILE photos/john%20doe.jpg
+2 FORM image/jpeg
+0 @M2@ OBJE
+1 FILE scans/census.png
+2 FORM image/png
+0 @M3@ OBJE
+1 FILE https://example.com/remote.jpg
+2 FORM image/jpeg
+0 TRLR
+`
+
+func decodeTest(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(testGEDCOM))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func newTestReader(t *testing.T, data []byte) *Reader {
+	t.Helper()
+	r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+	return r
+}
+
+// rawArchive builds a zip archive from name/content pairs.
+func rawArchive(t *testing.T, files ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i := 0; i+1 < len(files); i += 2 {
+		f, err := zw.Create(files[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(files[i+1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestWriteAndRead(t *testing.T) {
+	doc := decodeTest(t)
+	var buf bytes.Buffer
+	err := Write(&buf, doc, map[string][]byte{
+		"scans/census.png":    []byte("png"),
+		"photos/john doe.jpg": []byte("jpeg data"),
+	}, nil)
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	r := newTestReader(t, buf.Bytes())
+	defer r.Close()
+
+	got, err := r.Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got.GetIndividual("@I1@") == nil {
+		t.Error("decoded document is missing @I1@")
+	}
+	if got.Header.Version != gedcom.Version70 {
+		t.Errorf("Version = %q, want 7.0", got.Header.Version)
+	}
+
+	files := r.MediaFiles()
+	want := []MediaFile{{Path: "photos/john doe.jpg", Size: 9}, {Path: "scans/census.png", Size: 3}}
+	if len(files) != len(want) {
+		t.Fatalf("MediaFiles() = %v, want %v", files, want)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("MediaFiles()[%d] = %v, want %v", i, files[i], want[i])
+		}
+	}
+
+	rc, err := r.Open("photos/john%20doe.jpg")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "jpeg data" {
+		t.Errorf("Open() content = %q", data)
+	}
+
+	if missing := r.MissingMedia(got); len(missing) != 0 {
+		t.Errorf("MissingMedia() = %v, want none", missing)
+	}
+}
+
+func TestReader_Open(t *testing.T) {
+	r := newTestReader(t, rawArchive(t, DatasetPath, testGEDCOM, "a b.jpg", "x", "dir/c.png", "y"))
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"a b.jpg", true},
+		{"a%20b.jpg", true},
+		{"./dir/c.png", true},
+		{"dir/c.png", true},
+		{"c.png", false},
+		{"missing.jpg", false},
+		{"%zz", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := r.Has(tt.path); got != tt.want {
+				t.Errorf("Has(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+			rc, err := r.Open(tt.path)
+			if tt.want {
+				if err != nil {
+					t.Fatalf("Open(%q) error = %v", tt.path, err)
+				}
+				rc.Close()
+				return
+			}
+			if !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("Open(%q) error = %v, want fs.ErrNotExist", tt.path, err)
+			}
+		})
+	}
+}
+
+func TestReader_MissingMedia(t *testing.T) {
+	r := newTestReader(t, rawArchive(t, DatasetPath, testGEDCOM, "photos/john doe.jpg", "x"))
+	doc, err := r.Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	missing := r.MissingMedia(doc)
+	if len(missing) != 1 || missing[0] != "scans/census.png" {
+		t.Errorf("MissingMedia() = %v, want [scans/census.png]", missing)
+	}
+	if got := r.MissingMedia(nil); got != nil {
+		t.Errorf("MissingMedia(nil) = %v, want nil", got)
+	}
+}
+
+func TestReader_MediaFilesSkipsDirectories(t *testing.T) {
+	r := newTestReader(t, rawArchive(t, "photos/", "", DatasetPath, testGEDCOM, "photos/a.jpg", "x"))
+	files := r.MediaFiles()
+	if len(files) != 1 || files[0].Path != "photos/a.jpg" {
+		t.Errorf("MediaFiles() = %v, want [photos/a.jpg]", files)
+	}
+}
+
+func TestNewReader_Errors(t *testing.T) {
+	data := rawArchive(t, "other.ged", testGEDCOM)
+	if _, err := NewReader(bytes.NewReader(data), int64(len(data))); !errors.Is(err, ErrNoDataset) {
+		t.Errorf("NewReader() error = %v, want ErrNoDataset", err)
+	}
+
+	if _, err := NewReader(strings.NewReader("not a zip"), 9); err == nil {
+		t.Error("NewReader() on non-zip data should fail")
+	}
+}
+
+func TestOpenReader(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "family.gdz")
+	if err := os.WriteFile(name, rawArchive(t, DatasetPath, testGEDCOM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	r, err := OpenReader(name)
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+	doc, err := r.DecodeWithOptions(nil)
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+	if len(doc.Individuals()) != 1 {
+		t.Errorf("Individuals() = %d, want 1", len(doc.Individuals()))
+	}
+	if err := r.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+
+	if _, err := OpenReader(filepath.Join(t.TempDir(), "missing.gdz")); err == nil {
+		t.Error("OpenReader() on a missing file should fail")
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.gdz")
+	if err := os.WriteFile(empty, rawArchive(t, "a.jpg", "x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenReader(empty); !errors.Is(err, ErrNoDataset) {
+		t.Errorf("OpenReader() error = %v, want ErrNoDataset", err)
+	}
+}
+
+func TestWriter_AddMediaPaths(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{"photo.jpg", false},
+		{"photos/2020/a.jpg", false},
+		{"", true},
+		{DatasetPath, true},
+		{"/abs.jpg", true},
+		{"../escape.jpg", true},
+		{"photos/../a.jpg", true},
+		{"./a.jpg", true},
+		{`photos\a.jpg`, true},
+		{"photos/", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := NewWriter(io.Discard)
+			err := w.AddMedia(tt.path, strings.NewReader("x"))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AddMedia(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriter_Errors(t *testing.T) {
+	doc := decodeTest(t)
+
+	w := NewWriter(io.Discard)
+	if err := w.WriteDocument(nil, nil); err == nil {
+		t.Error("WriteDocument(nil) should fail")
+	}
+	if err := w.WriteDocument(doc, nil); err != nil {
+		t.Fatalf("WriteDocument() error = %v", err)
+	}
+	if err := w.WriteDocument(doc, nil); err == nil {
+		t.Error("second WriteDocument() should fail")
+	}
+	if err := w.AddMedia("a.jpg", strings.NewReader("x")); err != nil {
+		t.Fatalf("AddMedia() error = %v", err)
+	}
+	if err := w.AddMedia("a.jpg", strings.NewReader("x")); err == nil {
+		t.Error("duplicate AddMedia() should fail")
+	}
+	if err := w.AddMedia("b.jpg", iotest.ErrReader(errors.New("read failed"))); err == nil {
+		t.Error("AddMedia() with a failing reader should fail")
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+
+	if err := NewWriter(io.Discard).Close(); !errors.Is(err, ErrNoDataset) {
+		t.Errorf("Close() without a dataset error = %v, want ErrNoDataset", err)
+	}
+
+	if err := Write(io.Discard, nil, nil, nil); err == nil {
+		t.Error("Write(nil document) should fail")
+	}
+	if err := Write(io.Discard, doc, map[string][]byte{"../x": nil}, nil); err == nil {
+		t.Error("Write() with an invalid media path should fail")
+	}
+}
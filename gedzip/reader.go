@@ -0,0 +1,188 @@
+package gedzip
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// DatasetPath is the archive path of the GEDCOM dataset.
+const DatasetPath = "gedcom.ged"
+
+// ErrNoDataset is returned when an archive has no gedcom.ged.
+var ErrNoDataset = errors.New("gedzip: archive has no " + DatasetPath)
+
+// MediaFile describes a packaged file other than the dataset.
+type MediaFile struct {
+	// Path is the file's path within the archive, with forward slashes.
+	Path string
+
+	// Size is the uncompressed size in bytes.
+	Size int64
+}
+
+// Reader reads a GEDZip archive.
+type Reader struct {
+	zr      *zip.Reader
+	closer  io.Closer
+	files   map[string]*zip.File
+	dataset *zip.File
+}
+
+// OpenReader opens the GEDZip archive at name. Close the Reader when done.
+func OpenReader(name string) (*Reader, error) {
+	rc, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, fmt.Errorf("gedzip: %w", err)
+	}
+	r, err := newReader(&rc.Reader)
+	if err != nil {
+		_ = rc.Close()
+		return nil, err
+	}
+	r.closer = rc
+	return r, nil
+}
+
+// NewReader reads a GEDZip archive of the given size from r.
+// Returns ErrNoDataset if the archive has no gedcom.ged.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("gedzip: %w", err)
+	}
+	return newReader(zr)
+}
+
+func newReader(zr *zip.Reader) (*Reader, error) {
+	r := &Reader{zr: zr, files: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if _, dup := r.files[f.Name]; !dup {
+			r.files[f.Name] = f
+		}
+	}
+	r.dataset = r.files[DatasetPath]
+	if r.dataset == nil {
+		return nil, ErrNoDataset
+	}
+	return r, nil
+}
+
+// Close closes the archive file opened by OpenReader. It is a no-op for a
+// Reader created with NewReader.
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// Decode decodes the archive's gedcom.ged with default options.
+func (r *Reader) Decode() (*gedcom.Document, error) {
+	return r.DecodeWithOptions(nil)
+}
+
+// DecodeWithOptions decodes the archive's gedcom.ged with custom options.
+// If opts is nil, default options are used.
+func (r *Reader) DecodeWithOptions(opts *decoder.DecodeOptions) (*gedcom.Document, error) {
+	rc, err := r.dataset.Open()
+	if err != nil {
+		return nil, fmt.Errorf("gedzip: %w", err)
+	}
+	defer rc.Close()
+	return decoder.DecodeWithOptions(rc, opts)
+}
+
+// MediaFiles returns the packaged files other than gedcom.ged, in archive
+// order. Directory entries are omitted.
+func (r *Reader) MediaFiles() []MediaFile {
+	var files []MediaFile
+	for _, f := range r.zr.File {
+		if f.Name == DatasetPath || f.FileInfo().IsDir() || r.files[f.Name] != f {
+			continue
+		}
+		files = append(files, MediaFile{Path: f.Name, Size: int64(f.UncompressedSize64)})
+	}
+	return files
+}
+
+// Open opens the packaged file at path, which may be an archive path or a
+// FILE payload naming one (percent-encoded, optionally starting with "./").
+// Returns an error wrapping fs.ErrNotExist if the archive has no such file.
+func (r *Reader) Open(path string) (io.ReadCloser, error) {
+	f := r.lookup(path)
+	if f == nil {
+		return nil, fmt.Errorf("gedzip: open %s: %w", path, fs.ErrNotExist)
+	}
+	return f.Open()
+}
+
+// Has reports whether the archive packages the file at path, interpreted
+// as by Open.
+func (r *Reader) Has(path string) bool {
+	return r.lookup(path) != nil
+}
+
+// MissingMedia returns the FILE payloads of doc's media objects that are
+// archive-relative paths but are not packaged, in document order. URLs
+// (with a scheme) and absolute paths are not expected in the archive and
+// are skipped.
+func (r *Reader) MissingMedia(doc *gedcom.Document) []string {
+	var missing []string
+	for _, ref := range localFileRefs(doc) {
+		if !r.Has(ref) {
+			missing = append(missing, ref)
+		}
+	}
+	return missing
+}
+
+func (r *Reader) lookup(path string) *zip.File {
+	path = strings.TrimPrefix(path, "./")
+	if f := r.files[path]; f != nil {
+		return f
+	}
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		return r.files[unescaped]
+	}
+	return nil
+}
+
+// localFileRefs returns the FILE payloads of doc's media objects that are
+// relative paths, in document order.
+func localFileRefs(doc *gedcom.Document) []string {
+	if doc == nil {
+		return nil
+	}
+	var refs []string
+	for _, m := range doc.MediaObjects() {
+		for _, file := range m.Files {
+			if file != nil && isLocalRef(file.FileRef) {
+				refs = append(refs, file.FileRef)
+			}
+		}
+	}
+	return refs
+}
+
+// isLocalRef reports whether ref is a relative path rather than a URL or
+// an absolute path.
+func isLocalRef(ref string) bool {
+	if ref == "" || strings.HasPrefix(ref, "/") || strings.HasPrefix(ref, `\`) {
+		return false
+	}
+	if u, err := url.Parse(ref); err != nil || u.Scheme != "" {
+		return false
+	}
+	return true
+}
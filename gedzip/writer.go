@@ -0,0 +1,123 @@
+package gedzip
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// Writer writes a GEDZip archive. Call WriteDocument once and AddMedia for
+// each packaged file, in any order, then Close.
+type Writer struct {
+	zw      *zip.Writer
+	written map[string]bool
+	dataset bool
+}
+
+// NewWriter creates a Writer that writes an archive to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{zw: zip.NewWriter(w), written: make(map[string]bool)}
+}
+
+// WriteDocument encodes doc as the archive's gedcom.ged. If opts is nil,
+// default options are used. It returns an error if called twice.
+func (w *Writer) WriteDocument(doc *gedcom.Document, opts *encoder.EncodeOptions) error {
+	if doc == nil {
+		return errors.New("gedzip: document is nil")
+	}
+	if w.dataset {
+		return fmt.Errorf("gedzip: %s already written", DatasetPath)
+	}
+	var buf bytes.Buffer
+	if err := encoder.EncodeWithOptions(&buf, doc, opts); err != nil {
+		return fmt.Errorf("gedzip: encode %s: %w", DatasetPath, err)
+	}
+	if err := w.create(DatasetPath, &buf); err != nil {
+		return err
+	}
+	w.dataset = true
+	return nil
+}
+
+// AddMedia packages the contents of r at path. The path must be relative,
+// use forward slashes, and stay within the archive; it must not be
+// gedcom.ged or a path already added.
+func (w *Writer) AddMedia(name string, r io.Reader) error {
+	if err := checkMediaPath(name); err != nil {
+		return err
+	}
+	return w.create(name, r)
+}
+
+func (w *Writer) create(name string, r io.Reader) error {
+	if w.written[name] {
+		return fmt.Errorf("gedzip: duplicate file %s", name)
+	}
+	f, err := w.zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("gedzip: %w", err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		return fmt.Errorf("gedzip: write %s: %w", name, err)
+	}
+	w.written[name] = true
+	return nil
+}
+
+// Close finishes the archive. It returns ErrNoDataset if WriteDocument was
+// not called; the archive is finished regardless. Close does not close the
+// underlying writer.
+func (w *Writer) Close() error {
+	if err := w.zw.Close(); err != nil {
+		return fmt.Errorf("gedzip: %w", err)
+	}
+	if !w.dataset {
+		return ErrNoDataset
+	}
+	return nil
+}
+
+// Write writes a complete archive of doc and media, which maps archive
+// paths to file contents, to w. Media files are written in path order. If
+// opts is nil, default options are used.
+func Write(w io.Writer, doc *gedcom.Document, media map[string][]byte, opts *encoder.EncodeOptions) error {
+	zw := NewWriter(w)
+	if err := zw.WriteDocument(doc, opts); err != nil {
+		return err
+	}
+	paths := make([]string, 0, len(media))
+	for p := range media {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if err := zw.AddMedia(p, bytes.NewReader(media[p])); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// checkMediaPath validates a packaged file path.
+func checkMediaPath(name string) error {
+	switch {
+	case name == "":
+		return errors.New("gedzip: empty media path")
+	case name == DatasetPath:
+		return fmt.Errorf("gedzip: media path %s is reserved for the dataset", DatasetPath)
+	case strings.Contains(name, `\`):
+		return fmt.Errorf("gedzip: media path %q must use forward slashes", name)
+	case !fs.ValidPath(name) || path.Clean(name) != name:
+		return fmt.Errorf("gedzip: media path %q must be a clean relative path within the archive", name)
+	}
+	return nil
+}
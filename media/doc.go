@@ -0,0 +1,24 @@
+// Package media audits the multimedia files a GEDCOM document references
+// and exports them as a manifest.
+//
+// Build lists every FILE of every OBJE record, with its alternate versions
+// (TRAN, often thumbnails), the records and structures that link to the
+// object, the crop region and title each link asks for, and whether the
+// file exists. WriteJSON and WriteCSV export the manifest for website
+// generators and other tools that copy and resize a tree's images.
+//
+// Example usage:
+//
+//	doc, _ := decoder.Decode(f)
+//	out, _ := os.Create("media.json")
+//	defer out.Close()
+//	opts := &media.Options{FS: os.DirFS(filepath.Dir(gedcomPath))}
+//	if err := media.WriteJSON(out, doc, opts); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Links are read from the typed entities (records, their events and
+// attributes, and source citations on either), so records without an
+// entity contribute none. Links to objects that are not OBJE records of
+// the document are listed as dangling rather than reported as errors.
+package media
@@ -0,0 +1,75 @@
+package media
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// csvHeader names the columns of WriteCSV.
+var csvHeader = []string{
+	"Path", "Form", "MediaType", "Title", "MediaXRef", "Status", "TranslationOf",
+	"RecordXRef", "RecordType", "Context", "LinkTitle", "CropTop", "CropLeft", "CropWidth", "CropHeight",
+}
+
+// WriteJSON writes the media manifest of doc as indented JSON: an object
+// with "files" and "dangling" arrays, using the json tags of File,
+// Translation, and Reference. If opts is nil, default options are used.
+func WriteJSON(w io.Writer, doc *gedcom.Document, opts *Options) error {
+	return Build(doc, opts).WriteJSON(w)
+}
+
+// WriteCSV writes the media manifest of doc as CSV, one row per file and
+// link: a file linked from three places takes three rows, and an unused
+// file one row with empty link columns. Each translation follows its file
+// as a row of its own, with TranslationOf set to the file's path and no
+// link columns. Dangling links are not files and are left out. If opts is
+// nil, default options are used.
+func WriteCSV(w io.Writer, doc *gedcom.Document, opts *Options) error {
+	return Build(doc, opts).WriteCSV(w)
+}
+
+// WriteJSON writes m as indented JSON; see the package-level WriteJSON.
+func (m *Manifest) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+// WriteCSV writes m as CSV; see the package-level WriteCSV for the rows.
+func (m *Manifest) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, f := range m.Files {
+		file := []string{f.Path, f.Form, f.MediaType, f.Title, f.MediaXRef, string(f.Status), ""}
+		if len(f.References) == 0 {
+			if err := cw.Write(append(file, "", "", "", "", "", "", "", "")); err != nil {
+				return err
+			}
+		}
+		for _, ref := range f.References {
+			row := append(append([]string(nil), file...), ref.RecordXRef, string(ref.RecordType), ref.Context, ref.Title)
+			if c := ref.Crop; c != nil {
+				row = append(row, strconv.Itoa(c.Top), strconv.Itoa(c.Left), strconv.Itoa(c.Width), strconv.Itoa(c.Height))
+			} else {
+				row = append(row, "", "", "", "")
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+		for _, t := range f.Translations {
+			row := []string{t.Path, t.Form, "", "", f.MediaXRef, string(t.Status), f.Path, "", "", "", "", "", "", "", ""}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,294 @@
+package media
+
+import (
+	"io/fs"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// Status says whether a referenced file was found.
+type Status string
+
+const (
+	// StatusFound is a relative path that exists in Options.FS.
+	StatusFound Status = "found"
+
+	// StatusMissing is a relative path that does not exist in Options.FS.
+	StatusMissing Status = "missing"
+
+	// StatusRemote is a URL such as https://..., which is not fetched.
+	StatusRemote Status = "remote"
+
+	// StatusUnchecked is an absolute path or file: URL, or any path when
+	// Options.FS is nil.
+	StatusUnchecked Status = "unchecked"
+)
+
+// Options configures Build.
+type Options struct {
+	// FS resolves relative FILE paths to check that they exist, such as
+	// os.DirFS of the directory holding the GEDCOM file or a *zip.Reader
+	// over a GEDZip archive. Default (nil): files are not checked.
+	FS fs.FS
+}
+
+// Manifest lists the media files of a document.
+type Manifest struct {
+	// Files holds every FILE of every OBJE record, in document order.
+	Files []*File `json:"files"`
+
+	// Dangling holds the links to objects that are not OBJE records of
+	// the document, in document order.
+	Dangling []Reference `json:"dangling,omitempty"`
+}
+
+// File is one FILE of a media object.
+type File struct {
+	// Path is the FILE payload: a relative or absolute path, or a URL.
+	Path string `json:"path"`
+
+	// Form is the media type (FORM), such as "image/jpeg" or "jpg".
+	Form string `json:"form,omitempty"`
+
+	// MediaType is the MEDI category, such as PHOTO or DOCUMENT.
+	MediaType string `json:"mediaType,omitempty"`
+
+	// Title is the FILE's TITL.
+	Title string `json:"title,omitempty"`
+
+	// MediaXRef is the OBJE record the file belongs to.
+	MediaXRef string `json:"mediaXRef"`
+
+	// Status says whether the file was found.
+	Status Status `json:"status"`
+
+	// Translations are the alternate versions of the file (TRAN).
+	Translations []*Translation `json:"translations,omitempty"`
+
+	// References are the links to the OBJE record, in document order. A
+	// file without references belongs to an unused object.
+	References []Reference `json:"references,omitempty"`
+}
+
+// Translation is an alternate version of a file, such as a thumbnail or
+// a transcript.
+type Translation struct {
+	// Path is the TRAN payload.
+	Path string `json:"path"`
+
+	// Form is the media type of the translation.
+	Form string `json:"form,omitempty"`
+
+	// Status says whether the translation was found.
+	Status Status `json:"status"`
+}
+
+// Reference is one link to a media object.
+type Reference struct {
+	// MediaXRef is the linked OBJE record.
+	MediaXRef string `json:"mediaXRef"`
+
+	// RecordXRef and RecordType identify the linking record.
+	RecordXRef string            `json:"recordXRef"`
+	RecordType gedcom.RecordType `json:"recordType"`
+
+	// Context is the path of the structure holding the link, such as
+	// "INDI", "INDI.BIRT", or "FAM.MARR.SOUR" for a citation on an event.
+	Context string `json:"context"`
+
+	// Title is the link's own title, overriding the file's.
+	Title string `json:"title,omitempty"`
+
+	// Crop is the part of the image the link shows, if any.
+	Crop *Crop `json:"crop,omitempty"`
+}
+
+// Crop is a crop region in pixels (GEDCOM 7.0 CROP).
+type Crop struct {
+	Top    int `json:"top"`
+	Left   int `json:"left"`
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+}
+
+// Build lists the media files of doc. If opts is nil, default options are
+// used. Returns an empty manifest if doc is nil.
+func Build(doc *gedcom.Document, opts *Options) *Manifest {
+	m := &Manifest{Files: []*File{}}
+	if doc == nil {
+		return m
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	byXRef := make(map[string][]*File)
+	for _, record := range doc.Records {
+		if record == nil || record.Type != gedcom.RecordTypeMedia {
+			continue
+		}
+		obj, ok := record.Entity.(*gedcom.MediaObject)
+		if !ok || obj == nil {
+			continue
+		}
+		for _, mf := range obj.Files {
+			if mf == nil {
+				continue
+			}
+			file := newFile(record.XRef, mf, opts.FS)
+			m.Files = append(m.Files, file)
+			byXRef[record.XRef] = append(byXRef[record.XRef], file)
+		}
+	}
+
+	m.linkReferences(doc, byXRef)
+	return m
+}
+
+// linkReferences adds the media links of the records of doc to the files of
+// the media records they point to, byXRef, or to Dangling when there is no
+// such record.
+func (m *Manifest) linkReferences(doc *gedcom.Document, byXRef map[string][]*File) {
+	add := func(ref Reference) {
+		files, ok := byXRef[ref.MediaXRef]
+		if !ok {
+			if record := doc.GetRecord(ref.MediaXRef); record == nil || record.Type != gedcom.RecordTypeMedia {
+				m.Dangling = append(m.Dangling, ref)
+			}
+			return
+		}
+		for _, file := range files {
+			file.References = append(file.References, ref)
+		}
+	}
+	for _, record := range doc.Records {
+		if record != nil {
+			collectLinks(record, add)
+		}
+	}
+}
+
+// newFile returns the manifest entry of mf, a file of the media record
+// xref, with its status looked up in fsys.
+func newFile(xref string, mf *gedcom.MediaFile, fsys fs.FS) *File {
+	file := &File{
+		Path:      mf.FileRef,
+		Form:      mf.Form,
+		MediaType: mf.MediaType,
+		Title:     mf.Title,
+		MediaXRef: xref,
+		Status:    status(fsys, mf.FileRef),
+	}
+	for _, tran := range mf.Translations {
+		if tran != nil {
+			file.Translations = append(file.Translations, &Translation{
+				Path:   tran.FileRef,
+				Form:   tran.Form,
+				Status: status(fsys, tran.FileRef),
+			})
+		}
+	}
+	return file
+}
+
+// collectLinks passes every media link of record's entity to add.
+func collectLinks(record *gedcom.Record, add func(Reference)) {
+	root := string(record.Type)
+	links := func(context string, media []*gedcom.MediaLink) {
+		for _, link := range media {
+			if link == nil || link.MediaXRef == "" {
+				continue
+			}
+			ref := Reference{
+				MediaXRef:  link.MediaXRef,
+				RecordXRef: record.XRef,
+				RecordType: record.Type,
+				Context:    context,
+				Title:      link.Title,
+			}
+			if c := link.Crop; c != nil {
+				ref.Crop = &Crop{Top: c.Top, Left: c.Left, Width: c.Width, Height: c.Height}
+			}
+			add(ref)
+		}
+	}
+	citations := func(context string, cites []*gedcom.SourceCitation) {
+		for _, cite := range cites {
+			if cite != nil {
+				links(context+".SOUR", cite.Media)
+			}
+		}
+	}
+	events := func(events []*gedcom.Event) {
+		for _, event := range events {
+			if event != nil {
+				context := root + "." + string(event.Type)
+				links(context, event.Media)
+				citations(context, event.SourceCitations)
+			}
+		}
+	}
+
+	switch entity := record.Entity.(type) {
+	case *gedcom.Individual:
+		links(root, entity.Media)
+		events(entity.Events)
+		for _, attr := range entity.Attributes {
+			if attr != nil {
+				citations(root+"."+attr.Type, attr.SourceCitations)
+			}
+		}
+		citations(root, entity.SourceCitations)
+	case *gedcom.Family:
+		links(root, entity.Media)
+		events(entity.Events)
+		citations(root, entity.SourceCitations)
+	case *gedcom.Source:
+		links(root, entity.Media)
+	}
+}
+
+// status checks ref against fsys.
+func status(fsys fs.FS, ref string) Status {
+	switch {
+	case isRemote(ref):
+		return StatusRemote
+	case fsys == nil || isAbsolute(ref):
+		return StatusUnchecked
+	}
+	name := path.Clean(strings.ReplaceAll(ref, `\`, "/"))
+	if exists(fsys, name) {
+		return StatusFound
+	}
+	if unescaped, err := url.PathUnescape(name); err == nil && unescaped != name && exists(fsys, unescaped) {
+		return StatusFound
+	}
+	return StatusMissing
+}
+
+func exists(fsys fs.FS, name string) bool {
+	if !fs.ValidPath(name) {
+		return false
+	}
+	_, err := fs.Stat(fsys, name)
+	return err == nil
+}
+
+// isRemote reports whether ref is a URL other than a file: URL. Windows
+// drive letters ("C:\...") are not schemes.
+func isRemote(ref string) bool {
+	u, err := url.Parse(ref)
+	return err == nil && len(u.Scheme) > 1 && u.Scheme != "file"
+}
+
+// isAbsolute reports whether ref is an absolute path, a Windows path with
+// a drive letter, or a file: URL.
+func isAbsolute(ref string) bool {
+	if strings.HasPrefix(ref, "/") || strings.HasPrefix(ref, `\`) || strings.HasPrefix(strings.ToLower(ref), "file:") {
+		return true
+	}
+	return len(ref) >= 2 && ref[1] == ':' && (ref[0] >= 'A' && ref[0] <= 'Z' || ref[0] >= 'a' && ref[0] <= 'z')
+}
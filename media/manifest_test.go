@@ -0,0 +1,199 @@
+package media
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const testGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 NAME John /Doe/
+1 OBJE @O1@
+2 TITL Portrait
+2 CROP
+3 TOP 10
+3 LEFT 20
+3 HEIGHT 100
+3 WIDTH 80
+1 BIRT
+2 SOUR @S1@
+3 OBJE @O2@
+1 OBJE @O9@
+0 @F1@ FAM
+1 OBJE @O1@
+0 @S1@ SOUR
+1 TITL Register
+0 @O1@ OBJE
+1 FILE photos/john.jpg
+2 FORM image/jpeg
+3 MEDI PHOTO
+2 TITL John
+2 TRAN thumbs/john.jpg
+3 FORM image/jpeg
+0 @O2@ OBJE
+1 FILE https://example.com/register.png
+2 FORM image/png
+0 @O3@ OBJE
+1 FILE C:\Scans\unused.tif
+2 FORM image/tiff
+0 @O4@ OBJE
+1 FILE photos/missing.jpg
+2 FORM image/jpeg
+0 TRLR
+`
+
+var testFS = fstest.MapFS{
+	"photos/john.jpg": {Data: []byte("jpeg")},
+}
+
+func buildTest(t *testing.T) *Manifest {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(testGEDCOM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Build(doc, &Options{FS: testFS})
+}
+
+func TestBuild(t *testing.T) {
+	m := buildTest(t)
+	if len(m.Files) != 4 {
+		t.Fatalf("got %d files, want 4", len(m.Files))
+	}
+
+	john := m.Files[0]
+	if john.Path != "photos/john.jpg" || john.MediaXRef != "@O1@" || john.Form != "image/jpeg" || john.Status != StatusFound {
+		t.Errorf("file 0 = %+v", john)
+	}
+	if len(john.Translations) != 1 || john.Translations[0].Path != "thumbs/john.jpg" || john.Translations[0].Status != StatusMissing {
+		t.Errorf("Translations = %+v, want missing thumbs/john.jpg", john.Translations)
+	}
+	if len(john.References) != 2 {
+		t.Fatalf("References = %+v, want INDI and FAM links", john.References)
+	}
+	portrait := john.References[0]
+	if portrait.RecordXRef != "@I1@" || portrait.Context != "INDI" || portrait.Title != "Portrait" {
+		t.Errorf("reference 0 = %+v", portrait)
+	}
+	if c := portrait.Crop; c == nil || *c != (Crop{Top: 10, Left: 20, Width: 80, Height: 100}) {
+		t.Errorf("Crop = %+v", portrait.Crop)
+	}
+	if fam := john.References[1]; fam.RecordXRef != "@F1@" || fam.RecordType != gedcom.RecordTypeFamily || fam.Crop != nil {
+		t.Errorf("reference 1 = %+v", fam)
+	}
+
+	register := m.Files[1]
+	if register.Status != StatusRemote || len(register.References) != 1 || register.References[0].Context != "INDI.BIRT.SOUR" {
+		t.Errorf("file 1 = %+v, want a remote file cited on the birth", register)
+	}
+	if unused := m.Files[2]; unused.Status != StatusUnchecked || len(unused.References) != 0 {
+		t.Errorf("file 2 = %+v, want an unchecked, unused file", unused)
+	}
+	if missing := m.Files[3]; missing.Status != StatusMissing {
+		t.Errorf("file 3 status = %q, want missing", missing.Status)
+	}
+
+	if len(m.Dangling) != 1 || m.Dangling[0].MediaXRef != "@O9@" || m.Dangling[0].RecordXRef != "@I1@" {
+		t.Errorf("Dangling = %+v, want the link to @O9@", m.Dangling)
+	}
+}
+
+func TestBuild_NoFS(t *testing.T) {
+	doc, err := decoder.Decode(strings.NewReader(testGEDCOM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := Build(doc, nil)
+	if m.Files[0].Status != StatusUnchecked || m.Files[1].Status != StatusRemote {
+		t.Errorf("statuses = %q, %q; want unchecked, remote", m.Files[0].Status, m.Files[1].Status)
+	}
+	if got := Build(nil, nil); got == nil || len(got.Files) != 0 {
+		t.Errorf("Build(nil) = %+v, want empty manifest", got)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	fsys := fstest.MapFS{"a b/c.jpg": {Data: []byte("x")}}
+	tests := map[string]Status{
+		"a b/c.jpg":          StatusFound,
+		"./a b/c.jpg":        StatusFound,
+		`a b\c.jpg`:          StatusFound,
+		"a%20b/c.jpg":        StatusFound,
+		"../c.jpg":           StatusMissing,
+		"/home/c.jpg":        StatusUnchecked,
+		`D:\c.jpg`:           StatusUnchecked,
+		"file:///tmp/c.jpg":  StatusUnchecked,
+		"http://x.org/c.jpg": StatusRemote,
+	}
+	for ref, want := range tests {
+		if got := status(fsys, ref); got != want {
+			t.Errorf("status(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := buildTest(t).WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var out struct {
+		Files []struct {
+			Path       string `json:"path"`
+			Status     string `json:"status"`
+			References []struct {
+				Context string         `json:"context"`
+				Crop    map[string]int `json:"crop"`
+			} `json:"references"`
+		} `json:"files"`
+		Dangling []map[string]interface{} `json:"dangling"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Files) != 4 || out.Files[0].Status != "found" || out.Files[0].References[0].Crop["width"] != 80 {
+		t.Errorf("JSON = %s", buf.String())
+	}
+	if len(out.Dangling) != 1 || out.Dangling[0]["mediaXRef"] != "@O9@" {
+		t.Errorf("dangling = %v", out.Dangling)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	doc, err := decoder.Decode(strings.NewReader(testGEDCOM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, doc, &Options{FS: testFS}); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Header, two links and a translation for @O1@, then one row for each
+	// other file.
+	if len(rows) != 7 {
+		t.Fatalf("got %d rows, want 7:\n%v", len(rows), rows)
+	}
+	want := []string{"photos/john.jpg", "image/jpeg", "PHOTO", "John", "@O1@", "found", "", "@I1@", "INDI", "INDI", "Portrait", "10", "20", "80", "100"}
+	if strings.Join(rows[1], "|") != strings.Join(want, "|") {
+		t.Errorf("row 1 = %q, want %q", rows[1], want)
+	}
+	if rows[3][0] != "thumbs/john.jpg" || rows[3][6] != "photos/john.jpg" || rows[3][5] != "missing" {
+		t.Errorf("translation row = %q", rows[3])
+	}
+	if rows[5][0] != `C:\Scans\unused.tif` || rows[5][7] != "" {
+		t.Errorf("unused file row = %q", rows[5])
+	}
+}
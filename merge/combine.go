@@ -24,7 +24,8 @@ const (
 	PrefixDoc2
 	// RenumberDoc2 renumbers every colliding XRef in doc2 to a fresh
 	// sequential ID per record type, starting after the highest
-	// numeric ID in doc1 for that type. Non-colliding XRefs are kept.
+	// numeric ID in doc1 for that type, or to an ID derived under
+	// CombineOptions.XRefStrategy. Non-colliding XRefs are kept.
 	RenumberDoc2
 )
 
@@ -38,6 +39,13 @@ type CombineOptions struct {
 	// CollisionStrategy == PrefixDoc2. Required for that strategy;
 	// must contain no whitespace and must not be empty.
 	Prefix string
+	// XRefStrategy chooses the new XRefs RenumberDoc2 gives colliding
+	// records. The zero value (gedcom.XRefSequential) numbers them
+	// after the highest existing ID; gedcom.XRefUID and
+	// gedcom.XRefContentHash derive them from each record's UID or
+	// content, so the same inputs always combine to the same output
+	// whatever else the documents contain.
+	XRefStrategy gedcom.XRefStrategy
 }
 
 // CombineReport describes what Combine did on success. Combine returns
@@ -316,7 +324,7 @@ func applyStrategy(doc1, doc2 *gedcom.Document, collisions []string, opts Combin
 		return applyPrefixStrategy(doc1, doc2, collisions, opts.Prefix)
 
 	case RenumberDoc2:
-		transform := renumberTransform(doc1, doc2, collisions)
+		transform := renumberTransform(doc1, doc2, collisions, opts.XRefStrategy)
 		out, mapping, err := RemapXRefs(doc2, transform)
 		if err != nil {
 			return nil, nil, err
@@ -413,20 +421,11 @@ func prefixTransform(prefix string, collisions map[string]bool) func(string) str
 //
 // For each colliding record we look up its RecordType in doc2 and
 // pick the next sequential number for that letter, starting at
-// max(doc1ID, doc2ID) + 1 to avoid producing another collision.
-func renumberTransform(doc1, doc2 *gedcom.Document, collisions []string) func(string) string {
-	// Map RecordType → letter prefix used in XRef bodies.
-	typePrefix := map[gedcom.RecordType]string{
-		gedcom.RecordTypeIndividual: "I",
-		gedcom.RecordTypeFamily:     "F",
-		gedcom.RecordTypeSource:     "S",
-		gedcom.RecordTypeRepository: "R",
-		gedcom.RecordTypeNote:       "N",
-		gedcom.RecordTypeMedia:      "M",
-		gedcom.RecordTypeSubmitter:  "U",
-		gedcom.RecordTypeSharedNote: "SNOTE",
-	}
-
+// max(doc1ID, doc2ID) + 1 to avoid producing another collision. With
+// a non-sequential strategy the new XRef is instead derived from the
+// record by strategy.DeriveXRef, avoiding every XRef of both
+// documents and those already assigned.
+func renumberTransform(doc1, doc2 *gedcom.Document, collisions []string, strategy gedcom.XRefStrategy) func(string) string {
 	// counters[prefix] holds the next free numeric suffix for that
 	// prefix. Seed from doc1 AND doc2's existing numeric XRefs so we
 	// never collide with anything already present.
@@ -451,11 +450,20 @@ func renumberTransform(doc1, doc2 *gedcom.Document, collisions []string) func(st
 	// Build a record-type lookup keyed by doc2 XRef so the transform
 	// closure can pick the right prefix per colliding XRef.
 	doc2Type := make(map[string]gedcom.RecordType, len(doc2.Records))
+	doc2Records := make(map[string]*gedcom.Record, len(doc2.Records))
 	for _, r := range doc2.Records {
 		if r == nil || r.XRef == "" {
 			continue
 		}
 		doc2Type[r.XRef] = r.Type
+		doc2Records[r.XRef] = r
+	}
+
+	// used holds every XRef a derived XRef must avoid.
+	used := make(map[string]bool)
+	if strategy != gedcom.XRefSequential {
+		addXRefs(used, doc1)
+		addXRefs(used, doc2)
 	}
 
 	collisionSet := make(map[string]bool, len(collisions))
@@ -467,16 +475,11 @@ func renumberTransform(doc1, doc2 *gedcom.Document, collisions []string) func(st
 	// transform closure is cheap and deterministic.
 	resolved := make(map[string]string, len(collisions))
 	for _, old := range collisions {
-		typ := doc2Type[old]
-		prefix, ok := typePrefix[typ]
-		if !ok {
-			// Unknown record type — fall back to extracting the
-			// existing XRef's letter prefix, or "X" if that fails.
-			if p, _, ok := extractIDNumber(old); ok {
-				prefix = p
-			} else {
-				prefix = "X"
-			}
+		prefix := renumberPrefix(doc2Type[old], old)
+		if xref := strategy.DeriveXRef(prefix, doc2Records[old], func(x string) bool { return used[x] }); xref != "" {
+			used[xref] = true
+			resolved[old] = xref
+			continue
 		}
 		n := counters[prefix]
 		counters[prefix] = n + 1
@@ -491,6 +494,42 @@ func renumberTransform(doc1, doc2 *gedcom.Document, collisions []string) func(st
 	}
 }
 
+// recordTypePrefixes maps a RecordType to the letter prefix used in the
+// XRef bodies renumberTransform assigns.
+var recordTypePrefixes = map[gedcom.RecordType]string{
+	gedcom.RecordTypeIndividual: "I",
+	gedcom.RecordTypeFamily:     "F",
+	gedcom.RecordTypeSource:     "S",
+	gedcom.RecordTypeRepository: "R",
+	gedcom.RecordTypeNote:       "N",
+	gedcom.RecordTypeMedia:      "M",
+	gedcom.RecordTypeSubmitter:  "U",
+	gedcom.RecordTypeSharedNote: "SNOTE",
+}
+
+// renumberPrefix returns the XRef prefix for a renumbered record of type
+// typ whose XRef was old.
+func renumberPrefix(typ gedcom.RecordType, old string) string {
+	if prefix, ok := recordTypePrefixes[typ]; ok {
+		return prefix
+	}
+	// Unknown record type — fall back to extracting the existing XRef's
+	// letter prefix, or "X" if that fails.
+	if p, _, ok := extractIDNumber(old); ok {
+		return p
+	}
+	return "X"
+}
+
+// addXRefs adds the XRefs of the records of doc to xrefs.
+func addXRefs(xrefs map[string]bool, doc *gedcom.Document) {
+	for _, r := range doc.Records {
+		if r != nil && r.XRef != "" {
+			xrefs[r.XRef] = true
+		}
+	}
+}
+
 // extractIDNumber parses "@I5@" into ("I", 5, true). Returns ok=false
 // for XRefs that don't fit the letter-prefix-then-digits shape (e.g.
 // "@MYID@", "@SUBM_A@").
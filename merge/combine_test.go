@@ -450,6 +450,52 @@ func TestCombine_RenumberDoc2_PerTypeSequential(t *testing.T) {
 	}
 }
 
+func TestCombine_RenumberDoc2_DerivedXRefs(t *testing.T) {
+	build := func() *gedcom.Document {
+		d := &gedcom.Document{
+			Header:  &gedcom.Header{Version: gedcom.Version70},
+			XRefMap: make(map[string]*gedcom.Record),
+		}
+		for _, ind := range []*gedcom.Individual{
+			{XRef: "@I1@", UID: "9b2c4e1a-0f7d-4c21-8e3b-5a6d7c8e9f01"},
+			{XRef: "@I2@"},
+		} {
+			rec := &gedcom.Record{XRef: ind.XRef, Type: gedcom.RecordTypeIndividual, Entity: ind}
+			d.Records = append(d.Records, rec)
+			d.XRefMap[ind.XRef] = rec
+		}
+		return d
+	}
+
+	combine := func(strategy gedcom.XRefStrategy) map[string]string {
+		t.Helper()
+		_, report, err := merge.Combine(build(), build(), merge.CombineOptions{
+			CollisionStrategy: merge.RenumberDoc2,
+			XRefStrategy:      strategy,
+		})
+		if err != nil {
+			t.Fatalf("Combine(%v) returned error: %v", strategy, err)
+		}
+		return report.RemappedXRefs
+	}
+
+	byUID := combine(gedcom.XRefUID)
+	if got := byUID["@I1@"]; got != "@I9B2C4E1A0F7D4C21@" {
+		t.Errorf("XRefUID: RemappedXRefs[@I1@] = %q, want @I9B2C4E1A0F7D4C21@", got)
+	}
+	// @I2@ has no UID and falls back to its content hash.
+	byHash := combine(gedcom.XRefContentHash)
+	if got := byUID["@I2@"]; got == "" || got != byHash["@I2@"] {
+		t.Errorf("XRefUID: RemappedXRefs[@I2@] = %q, want content hash %q", got, byHash["@I2@"])
+	}
+	if !reflect.DeepEqual(byHash, combine(gedcom.XRefContentHash)) {
+		t.Error("XRefContentHash: repeated Combine produced different XRefs")
+	}
+	if byHash["@I1@"] == byHash["@I2@"] {
+		t.Errorf("XRefContentHash: distinct records share XRef %q", byHash["@I1@"])
+	}
+}
+
 func TestCombine_VersionMismatch(t *testing.T) {
 	doc1 := buildDoc("a_")
 	doc2 := buildDoc("b_")
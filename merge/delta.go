@@ -0,0 +1,47 @@
+package merge
+
+import (
+	"errors"
+
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// ApplyDelta returns a copy of base updated by delta, an update file
+// written by encoder.EncodeDelta and decoded:
+//
+//   - each delta record with an XRef replaces the base record with that
+//     XRef in place, or is appended if base has none;
+//   - each record listed under the delta's encoder.DeltaRecordTag record
+//     is removed, leaving references to it in place as EncodeDelta's
+//     changed records already account for them;
+//   - the delta's header replaces the base header.
+//
+// Removing a record base does not have is not an error, so applying the
+// same delta twice gives the same result. Records of the delta without an
+// XRef, other than the removal record, are ignored. Neither input is
+// modified.
+func ApplyDelta(base, delta *gedcom.Document) (*gedcom.Document, error) {
+	if base == nil || delta == nil {
+		return nil, errors.New("merge: base and delta documents are required")
+	}
+
+	p := newPatchedDocument(base)
+	if delta.Header != nil {
+		p.doc.Header = delta.Header.Clone()
+	}
+	for _, record := range delta.Records {
+		switch {
+		case record == nil:
+		case record.XRef != "":
+			p.replace(record.Clone())
+		case string(record.Type) == encoder.DeltaRecordTag:
+			for _, tag := range record.Tags {
+				if tag.Level == 1 && tag.Tag == encoder.DeltaRemoveTag {
+					p.remove(tag.Value)
+				}
+			}
+		}
+	}
+	return p.finish(), nil
+}
@@ -0,0 +1,110 @@
+package merge_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+	"github.com/cacack/gedcom-go/v2/merge"
+)
+
+func decodeText(t *testing.T, text string) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func encodeText(t *testing.T, doc *gedcom.Document) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	return buf.String()
+}
+
+// TestApplyDelta_Roundtrip syncs a copy of a document through a delta
+// file: EncodeDelta → decode → ApplyDelta must reproduce the modified
+// document.
+func TestApplyDelta_Roundtrip(t *testing.T) {
+	base := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 FAMS @F1@
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 FAMS @F1@
+0 @I3@ INDI
+1 NAME Old /Entry/
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+0 TRLR
+`
+	modified := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 FAMS @F1@
+1 DEAT
+2 DATE 1910
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 FAMS @F1@
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 CHIL @I4@
+0 @I4@ INDI
+1 NAME Tom /Smith/
+1 FAMC @F1@
+0 TRLR
+`
+	baseDoc := decodeText(t, base)
+	var buf bytes.Buffer
+	delta, err := encoder.EncodeDelta(&buf, baseDoc, decodeText(t, modified), nil)
+	if err != nil {
+		t.Fatalf("EncodeDelta() error = %v", err)
+	}
+	if len(delta.Changed) != 2 || len(delta.Added) != 1 || len(delta.Removed) != 1 {
+		t.Errorf("delta = %+v", delta)
+	}
+
+	updated, err := merge.ApplyDelta(baseDoc, decodeText(t, buf.String()))
+	if err != nil {
+		t.Fatalf("ApplyDelta() error = %v", err)
+	}
+	if got := encodeText(t, updated); got != modified {
+		t.Errorf("applied document:\n%s\nwant:\n%s", got, modified)
+	}
+	if updated.GetIndividual("@I4@") == nil || updated.GetRecord("@I3@") != nil {
+		t.Error("index not updated after ApplyDelta")
+	}
+	if encodeText(t, baseDoc) != base {
+		t.Error("ApplyDelta modified the base document")
+	}
+
+	// Applying the same delta again changes nothing.
+	again, err := merge.ApplyDelta(updated, decodeText(t, buf.String()))
+	if err != nil || encodeText(t, again) != modified {
+		t.Errorf("second ApplyDelta() = %v", err)
+	}
+}
+
+func TestApplyDelta_NilInput(t *testing.T) {
+	if _, err := merge.ApplyDelta(nil, &gedcom.Document{}); err == nil {
+		t.Error("ApplyDelta(nil, delta) should fail")
+	}
+	if _, err := merge.ApplyDelta(&gedcom.Document{}, nil); err == nil {
+		t.Error("ApplyDelta(base, nil) should fail")
+	}
+}
@@ -16,6 +16,12 @@
 //     strategy (ErrorOnCollision, PrefixDoc2, RenumberDoc2), returning
 //     a fresh document plus a report describing what was remapped and
 //     which header fields conflicted.
+//   - ApplyDelta: apply an update file written by encoder.EncodeDelta,
+//     replacing, adding, and removing records by XRef.
+//   - NewPatch / ApplyPatch: exchange record changes as a JSON patch and
+//     apply them to another copy of the base document, detecting records
+//     the target has changed since (ErrorOnConflict, SkipConflicts,
+//     OverwriteConflicts).
 //
 // What this package does NOT do:
 //
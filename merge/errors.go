@@ -3,6 +3,7 @@ package merge
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // ErrInvalidRemap is returned by RemapXRefs when the caller-supplied
@@ -31,3 +32,26 @@ func (e *RemapError) Error() string {
 func (e *RemapError) Is(target error) bool {
 	return target == ErrInvalidRemap
 }
+
+// ErrPatchConflict is returned by ApplyPatch under ErrorOnConflict when
+// the target has diverged from the patch's base. Use errors.As with
+// *PatchConflictError to recover the conflicts.
+var ErrPatchConflict = errors.New("patch conflict")
+
+// PatchConflictError lists the conflicts that stopped ApplyPatch. It is
+// returned wrapped with ErrPatchConflict.
+type PatchConflictError struct {
+	Conflicts []PatchConflict
+}
+
+func (e *PatchConflictError) Error() string {
+	xrefs := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		xrefs[i] = c.XRef
+	}
+	return fmt.Sprintf("merge: %d conflicting change(s): %s", len(e.Conflicts), strings.Join(xrefs, ", "))
+}
+
+func (e *PatchConflictError) Is(target error) bool {
+	return target == ErrPatchConflict
+}
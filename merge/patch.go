@@ -0,0 +1,408 @@
+package merge
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+	"github.com/cacack/gedcom-go/v2/gedcomjson"
+)
+
+// PatchFormat identifies the JSON form of a patch in its "format" member.
+const PatchFormat = "gedcom-go-patch"
+
+// PatchFormatVersion is the patch schema version NewPatch writes and
+// UnmarshalPatch reads.
+const PatchFormatVersion = 1
+
+// PatchOp is the kind of a patch change.
+type PatchOp string
+
+// Patch operations.
+const (
+	// PatchAdd adds a record the base document did not have.
+	PatchAdd PatchOp = "add"
+
+	// PatchReplace replaces a record of the base document.
+	PatchReplace PatchOp = "replace"
+
+	// PatchRemove removes a record of the base document.
+	PatchRemove PatchOp = "remove"
+)
+
+// Patch is a serializable set of record-level changes, made by NewPatch
+// from two versions of a document and applied to another copy of the
+// base version with ApplyPatch. Marshal it with json.Marshal; read it back
+// with UnmarshalPatch.
+type Patch struct {
+	// Format is PatchFormat.
+	Format string `json:"format"`
+
+	// Version is the schema version of the patch, PatchFormatVersion.
+	Version int `json:"version"`
+
+	// RecordVersion is the GEDCOM version the Base texts were encoded in.
+	RecordVersion gedcom.Version `json:"recordVersion,omitempty"`
+
+	// Changes are the record changes, in the order they are applied:
+	// additions and replacements in the modified document's order, then
+	// removals in the base document's order.
+	Changes []*PatchChange `json:"changes"`
+}
+
+// PatchChange is the change of one record.
+type PatchChange struct {
+	// Op is the kind of change.
+	Op PatchOp `json:"op"`
+
+	// XRef identifies the record.
+	XRef string `json:"xref"`
+
+	// Base is the GEDCOM text of the record before the change, as written
+	// by encoder.EncodeRecord; empty for PatchAdd. ApplyPatch compares it
+	// with the target's record to detect conflicting edits.
+	Base string `json:"base,omitempty"`
+
+	// Record is the record after the change, in the JSON form of
+	// gedcomjson.MarshalRecord; empty for PatchRemove.
+	Record json.RawMessage `json:"record,omitempty"`
+}
+
+// NewPatch returns the changes that turn base into modified, compared by
+// XRef and encoded text as by encoder.DiffRecords. Headers are not part of
+// a patch, and records without an XRef are not compared. Neither input is
+// modified.
+func NewPatch(base, modified *gedcom.Document) (*Patch, error) {
+	if base == nil || modified == nil {
+		return nil, errors.New("merge: base and modified documents are required")
+	}
+	delta, err := encoder.DiffRecords(base, modified)
+	if err != nil {
+		return nil, err
+	}
+	patch := &Patch{Format: PatchFormat, Version: PatchFormatVersion, RecordVersion: documentVersion(modified)}
+
+	ops := make(map[string]PatchOp, len(delta.Added)+len(delta.Changed))
+	for _, xref := range delta.Added {
+		ops[xref] = PatchAdd
+	}
+	for _, xref := range delta.Changed {
+		ops[xref] = PatchReplace
+	}
+	for _, record := range modified.Records {
+		if record == nil {
+			continue
+		}
+		op, ok := ops[record.XRef]
+		if !ok {
+			continue
+		}
+		delete(ops, record.XRef)
+		change := &PatchChange{Op: op, XRef: record.XRef}
+		if op == PatchReplace {
+			if change.Base, err = encoder.EncodeRecord(base.GetRecord(record.XRef), patch.RecordVersion); err != nil {
+				return nil, err
+			}
+		}
+		if change.Record, err = gedcomjson.MarshalRecord(record); err != nil {
+			return nil, err
+		}
+		patch.Changes = append(patch.Changes, change)
+	}
+	for _, xref := range delta.Removed {
+		text, err := encoder.EncodeRecord(base.GetRecord(xref), patch.RecordVersion)
+		if err != nil {
+			return nil, err
+		}
+		patch.Changes = append(patch.Changes, &PatchChange{Op: PatchRemove, XRef: xref, Base: text})
+	}
+	return patch, nil
+}
+
+// IsEmpty reports whether the patch has no changes.
+func (p *Patch) IsEmpty() bool {
+	return p == nil || len(p.Changes) == 0
+}
+
+// UnmarshalPatch reads a patch from its JSON form. It returns an error if
+// the data is not a patch of a supported version.
+func UnmarshalPatch(data []byte) (*Patch, error) {
+	var p Patch
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("merge: %w", err)
+	}
+	if p.Format != PatchFormat {
+		return nil, fmt.Errorf("merge: not a patch (format %q)", p.Format)
+	}
+	if p.Version != PatchFormatVersion {
+		return nil, fmt.Errorf("merge: unsupported patch version %d", p.Version)
+	}
+	return &p, nil
+}
+
+// ConflictStrategy chooses how ApplyPatch handles changes to records the
+// target document has changed too.
+type ConflictStrategy int
+
+const (
+	// ErrorOnConflict (zero value) applies nothing and returns an error
+	// wrapping ErrPatchConflict if any change conflicts.
+	ErrorOnConflict ConflictStrategy = iota
+	// SkipConflicts applies the changes that do not conflict and lists
+	// the others in the report.
+	SkipConflicts
+	// OverwriteConflicts applies every change, replacing or removing the
+	// target's version of conflicting records. Conflicts are still
+	// reported.
+	OverwriteConflicts
+)
+
+// PatchOptions configures ApplyPatch.
+type PatchOptions struct {
+	// ConflictStrategy chooses how conflicting changes are handled. The
+	// zero value (ErrorOnConflict) refuses any conflict.
+	ConflictStrategy ConflictStrategy
+}
+
+// PatchConflict is a change whose record the target document changed
+// differently.
+type PatchConflict struct {
+	// Op and XRef identify the change.
+	Op   PatchOp
+	XRef string
+
+	// Reason describes how the target diverged.
+	Reason string
+}
+
+// PatchReport describes the outcome of ApplyPatch, by XRef in patch
+// order.
+type PatchReport struct {
+	// Applied are the records added, replaced, or removed.
+	Applied []string
+
+	// Unchanged are the records the target already had in their patched
+	// state, such as when a patch is applied twice.
+	Unchanged []string
+
+	// Conflicts are the changes whose record the target changed too.
+	// Under SkipConflicts they were not applied; under OverwriteConflicts
+	// they were.
+	Conflicts []PatchConflict
+}
+
+// ApplyPatch returns a copy of target with the changes of patch applied,
+// and a report of what happened to each change. Records are replaced in
+// place, added at the end, or removed; references to removed records are
+// left in place, as with ApplyDelta.
+//
+// A change conflicts when the target's record is not in the state the
+// patch expects: a replaced or removed record that the target changed
+// (its encoded text differs from PatchChange.Base) or removed, or an added
+// record the target has with different content. A change whose result the
+// target already has is not a conflict and is listed as unchanged, so
+// applying a patch twice gives the same document. Conflicts are handled
+// by opts.ConflictStrategy. If opts is nil, default options are used.
+// Neither input is modified.
+func ApplyPatch(target *gedcom.Document, patch *Patch, opts *PatchOptions) (*gedcom.Document, *PatchReport, error) {
+	if target == nil || patch == nil {
+		return nil, nil, errors.New("merge: target and patch are required")
+	}
+	if opts == nil {
+		opts = &PatchOptions{}
+	}
+
+	report := &PatchReport{}
+	var steps []patchStep
+	for _, change := range patch.Changes {
+		if change == nil {
+			continue
+		}
+		step, apply, err := checkChange(target, patch.RecordVersion, change, opts.ConflictStrategy, report)
+		if err != nil {
+			return nil, nil, err
+		}
+		if apply {
+			steps = append(steps, step)
+		}
+	}
+	if len(report.Conflicts) > 0 && opts.ConflictStrategy == ErrorOnConflict {
+		return nil, report, &PatchConflictError{Conflicts: report.Conflicts}
+	}
+
+	p := newPatchedDocument(target)
+	for _, step := range steps {
+		report.Applied = append(report.Applied, step.change.XRef)
+		switch step.change.Op {
+		case PatchAdd:
+			p.add(step.record)
+		case PatchRemove:
+			p.remove(step.change.XRef)
+		default:
+			p.replace(step.record)
+		}
+	}
+	return p.finish(), report, nil
+}
+
+// patchStep is a change to apply, with its decoded record (nil for
+// removals).
+type patchStep struct {
+	change *PatchChange
+	record *gedcom.Record
+}
+
+// checkChange decodes change and compares it with the target's record,
+// recording an unchanged record or a conflict in report. It reports
+// whether the change is to be applied: it is not when the target already
+// has its result, or when it conflicts and strategy skips conflicts.
+func checkChange(target *gedcom.Document, version gedcom.Version, change *PatchChange, strategy ConflictStrategy, report *PatchReport) (patchStep, bool, error) {
+	step := patchStep{change: change}
+	if change.Op != PatchRemove {
+		record, err := gedcomjson.UnmarshalRecord(change.Record)
+		if err != nil {
+			return step, false, fmt.Errorf("merge: change to %s: %w", change.XRef, err)
+		}
+		if record.XRef != change.XRef {
+			return step, false, fmt.Errorf("merge: change to %s holds record %s", change.XRef, record.XRef)
+		}
+		step.record = record
+	}
+
+	state, reason, err := targetState(target.GetRecord(change.XRef), change, step.record, version)
+	if err != nil {
+		return step, false, err
+	}
+	switch state {
+	case statePatched:
+		report.Unchanged = append(report.Unchanged, change.XRef)
+		return step, false, nil
+	case stateConflict:
+		report.Conflicts = append(report.Conflicts, PatchConflict{Op: change.Op, XRef: change.XRef, Reason: reason})
+		return step, strategy != SkipConflicts, nil
+	}
+	return step, true, nil
+}
+
+// patchedDocument is a copy of the target that ApplyPatch and ApplyDelta
+// apply changes to.
+type patchedDocument struct {
+	doc      *gedcom.Document
+	position map[string]int  // index in doc.Records of the first record of each XRef
+	removed  map[string]bool // XRefs to drop in finish
+}
+
+func newPatchedDocument(target *gedcom.Document) *patchedDocument {
+	p := &patchedDocument{
+		doc:      target.Clone(),
+		position: make(map[string]int, len(target.Records)),
+		removed:  make(map[string]bool),
+	}
+	for i, record := range p.doc.Records {
+		if record == nil || record.XRef == "" {
+			continue
+		}
+		if _, dup := p.position[record.XRef]; !dup {
+			p.position[record.XRef] = i
+		}
+	}
+	return p
+}
+
+// add appends record, or replaces the target's own record of that XRef
+// when an add conflict is overwritten.
+func (p *patchedDocument) add(record *gedcom.Record) {
+	if _, ok := p.position[record.XRef]; ok {
+		p.replace(record)
+		return
+	}
+	p.position[record.XRef] = len(p.doc.Records)
+	p.doc.Records = append(p.doc.Records, record)
+}
+
+// replace replaces the record of record's XRef in place, or appends record
+// when the target removed it and the conflict is overwritten.
+func (p *patchedDocument) replace(record *gedcom.Record) {
+	i, ok := p.position[record.XRef]
+	if !ok {
+		p.add(record)
+		return
+	}
+	p.doc.Records[i] = record
+}
+
+// remove marks the record xref for removal.
+func (p *patchedDocument) remove(xref string) {
+	p.removed[xref] = true
+}
+
+// finish drops the removed records, reindexes, and returns the document.
+func (p *patchedDocument) finish() *gedcom.Document {
+	if len(p.removed) > 0 {
+		kept := p.doc.Records[:0]
+		for _, record := range p.doc.Records {
+			if record == nil || !p.removed[record.XRef] {
+				kept = append(kept, record)
+			}
+		}
+		p.doc.Records = kept
+	}
+	p.doc.Reindex()
+	return p.doc
+}
+
+// States of a target record relative to a patch change.
+const (
+	stateBase     = iota // as the patch expects; the change applies
+	statePatched         // already in the patched state
+	stateConflict        // changed in another way
+)
+
+// targetState compares the target's record (nil if it has none) with the
+// state change expects before and leaves after, given as patched (nil for
+// removals). Records are compared by their text in version.
+func targetState(current *gedcom.Record, change *PatchChange, patched *gedcom.Record, version gedcom.Version) (int, string, error) {
+	if current == nil {
+		switch change.Op {
+		case PatchAdd:
+			return stateBase, "", nil
+		case PatchRemove:
+			return statePatched, "", nil
+		}
+		return stateConflict, "removed in target", nil
+	}
+
+	text, err := encoder.EncodeRecord(current, version)
+	if err != nil {
+		return 0, "", err
+	}
+	if patched != nil {
+		patchedText, err := encoder.EncodeRecord(patched, version)
+		if err != nil {
+			return 0, "", err
+		}
+		if text == patchedText {
+			return statePatched, "", nil
+		}
+	}
+	switch change.Op {
+	case PatchAdd:
+		return stateConflict, "added in target with different content", nil
+	case PatchReplace, PatchRemove:
+		if text == change.Base {
+			return stateBase, "", nil
+		}
+		return stateConflict, "changed in target", nil
+	}
+	return 0, "", fmt.Errorf("merge: change to %s has unknown op %q", change.XRef, change.Op)
+}
+
+// documentVersion returns the header version of doc, or "" if it has none.
+func documentVersion(doc *gedcom.Document) gedcom.Version {
+	if doc.Header == nil || !doc.Header.Version.IsValid() {
+		return ""
+	}
+	return doc.Header.Version
+}
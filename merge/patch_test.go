@@ -0,0 +1,266 @@
+package merge_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+	"github.com/cacack/gedcom-go/v2/merge"
+)
+
+const patchBase = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 FAMS @F1@
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 FAMS @F1@
+0 @I3@ INDI
+1 NAME Old /Entry/
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+0 TRLR
+`
+
+const patchModified = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 FAMS @F1@
+1 DEAT
+2 DATE 1910
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 FAMS @F1@
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 CHIL @I4@
+0 @I4@ INDI
+1 NAME Tom /Smith/
+1 FAMC @F1@
+0 TRLR
+`
+
+// newTestPatch returns the patch from patchBase to patchModified, after a
+// round trip through JSON.
+func newTestPatch(t *testing.T) *merge.Patch {
+	t.Helper()
+	patch, err := merge.NewPatch(decodeText(t, patchBase), decodeText(t, patchModified))
+	if err != nil {
+		t.Fatalf("NewPatch() error = %v", err)
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	patch, err = merge.UnmarshalPatch(data)
+	if err != nil {
+		t.Fatalf("UnmarshalPatch() error = %v", err)
+	}
+	return patch
+}
+
+func TestNewPatch(t *testing.T) {
+	patch := newTestPatch(t)
+	if patch.Format != merge.PatchFormat || patch.Version != merge.PatchFormatVersion || patch.RecordVersion != gedcom.Version551 {
+		t.Errorf("patch = %q v%d, records %q", patch.Format, patch.Version, patch.RecordVersion)
+	}
+	var got []string
+	for _, c := range patch.Changes {
+		got = append(got, string(c.Op)+" "+c.XRef)
+	}
+	want := "replace @I1@, replace @F1@, add @I4@, remove @I3@"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("changes = %v, want %s", got, want)
+	}
+	if c := patch.Changes[0]; !strings.Contains(c.Base, "NAME John /Smith/") || strings.Contains(c.Base, "DEAT") {
+		t.Errorf("replace base = %q", c.Base)
+	}
+	if c := patch.Changes[2]; c.Base != "" || len(c.Record) == 0 {
+		t.Errorf("add change = %+v", c)
+	}
+	if c := patch.Changes[3]; c.Record != nil || !strings.Contains(c.Base, "Old /Entry/") {
+		t.Errorf("remove change = %+v", c)
+	}
+
+	same, err := merge.NewPatch(decodeText(t, patchBase), decodeText(t, patchBase))
+	if err != nil || !same.IsEmpty() {
+		t.Errorf("NewPatch(base, base) = %+v, %v; want empty", same, err)
+	}
+	if _, err := merge.NewPatch(nil, decodeText(t, patchBase)); err == nil {
+		t.Error("NewPatch(nil, doc) should fail")
+	}
+}
+
+// TestApplyPatch_Roundtrip applies a patch to another copy of the base
+// document: the result must encode like the modified document, and
+// applying the patch again must change nothing.
+func TestApplyPatch_Roundtrip(t *testing.T) {
+	patch := newTestPatch(t)
+	target := decodeText(t, patchBase)
+	before := encodeText(t, target)
+
+	out, report, err := merge.ApplyPatch(target, patch, nil)
+	if err != nil {
+		t.Fatalf("ApplyPatch() error = %v", err)
+	}
+	if got, want := encodeText(t, out), encodeText(t, decodeText(t, patchModified)); got != want {
+		t.Errorf("ApplyPatch() =\n%s\nwant\n%s", got, want)
+	}
+	if strings.Join(report.Applied, " ") != "@I1@ @F1@ @I4@ @I3@" || len(report.Unchanged) != 0 || len(report.Conflicts) != 0 {
+		t.Errorf("report = %+v", report)
+	}
+	if encodeText(t, target) != before {
+		t.Error("ApplyPatch() modified the target")
+	}
+	if out.GetIndividual("@I4@") == nil || out.GetIndividual("@I3@") != nil {
+		t.Error("ApplyPatch() result is not reindexed")
+	}
+
+	again, report, err := merge.ApplyPatch(out, patch, nil)
+	if err != nil {
+		t.Fatalf("ApplyPatch() twice error = %v", err)
+	}
+	if encodeText(t, again) != encodeText(t, out) {
+		t.Error("applying the patch twice changed the document")
+	}
+	if len(report.Applied) != 0 || len(report.Unchanged) != 4 {
+		t.Errorf("second report = %+v", report)
+	}
+}
+
+// divergedTarget is patchBase edited by another researcher: I1 changed,
+// I3 changed, and I4 added with other content.
+const divergedTarget = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 FAMS @F1@
+1 BIRT
+2 DATE 1850
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 FAMS @F1@
+0 @I3@ INDI
+1 NAME Corrected /Entry/
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+0 @I4@ INDI
+1 NAME Someone /Else/
+0 TRLR
+`
+
+func TestApplyPatch_Conflicts(t *testing.T) {
+	patch := newTestPatch(t)
+	target := decodeText(t, divergedTarget)
+
+	_, report, err := merge.ApplyPatch(target, patch, nil)
+	if !errors.Is(err, merge.ErrPatchConflict) {
+		t.Fatalf("ApplyPatch() error = %v, want ErrPatchConflict", err)
+	}
+	var conflictErr *merge.PatchConflictError
+	if !errors.As(err, &conflictErr) || len(conflictErr.Conflicts) != 3 {
+		t.Fatalf("ApplyPatch() error = %#v", err)
+	}
+	want := []merge.PatchConflict{
+		{Op: merge.PatchReplace, XRef: "@I1@", Reason: "changed in target"},
+		{Op: merge.PatchAdd, XRef: "@I4@", Reason: "added in target with different content"},
+		{Op: merge.PatchRemove, XRef: "@I3@", Reason: "changed in target"},
+	}
+	for i, c := range report.Conflicts {
+		if c != want[i] {
+			t.Errorf("conflict %d = %+v, want %+v", i, c, want[i])
+		}
+	}
+
+	out, report, err := merge.ApplyPatch(target, patch, &merge.PatchOptions{ConflictStrategy: merge.SkipConflicts})
+	if err != nil {
+		t.Fatalf("ApplyPatch(SkipConflicts) error = %v", err)
+	}
+	if strings.Join(report.Applied, " ") != "@F1@" || len(report.Conflicts) != 3 {
+		t.Errorf("skip report = %+v", report)
+	}
+	if ind := out.GetIndividual("@I1@"); ind == nil || ind.DeathDate() != nil || ind.BirthDate() == nil {
+		t.Error("SkipConflicts replaced a conflicting record")
+	}
+	if out.GetIndividual("@I3@") == nil || out.GetIndividual("@I4@").Names[0].Surname != "Else" {
+		t.Error("SkipConflicts applied a conflicting change")
+	}
+	if fam := out.GetFamily("@F1@"); fam == nil || len(fam.Children) != 1 {
+		t.Error("SkipConflicts did not apply a clean change")
+	}
+
+	out, report, err = merge.ApplyPatch(target, patch, &merge.PatchOptions{ConflictStrategy: merge.OverwriteConflicts})
+	if err != nil {
+		t.Fatalf("ApplyPatch(OverwriteConflicts) error = %v", err)
+	}
+	if len(report.Applied) != 4 || len(report.Conflicts) != 3 {
+		t.Errorf("overwrite report = %+v", report)
+	}
+	if got, want := encodeText(t, out), encodeText(t, decodeText(t, patchModified)); got != want {
+		t.Errorf("ApplyPatch(OverwriteConflicts) =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestApplyPatch_RemovedInTarget(t *testing.T) {
+	patch := newTestPatch(t)
+	target := decodeText(t, strings.Replace(patchBase, "0 @I1@ INDI\n1 NAME John /Smith/\n1 FAMS @F1@\n", "", 1))
+
+	_, report, err := merge.ApplyPatch(target, patch, &merge.PatchOptions{ConflictStrategy: merge.SkipConflicts})
+	if err != nil {
+		t.Fatalf("ApplyPatch() error = %v", err)
+	}
+	if len(report.Conflicts) != 1 || report.Conflicts[0].XRef != "@I1@" || report.Conflicts[0].Reason != "removed in target" {
+		t.Errorf("conflicts = %+v", report.Conflicts)
+	}
+}
+
+func TestApplyPatch_Invalid(t *testing.T) {
+	doc := decodeText(t, patchBase)
+	if _, _, err := merge.ApplyPatch(nil, &merge.Patch{}, nil); err == nil {
+		t.Error("ApplyPatch(nil target) should fail")
+	}
+	if _, _, err := merge.ApplyPatch(doc, nil, nil); err == nil {
+		t.Error("ApplyPatch(nil patch) should fail")
+	}
+
+	patch := newTestPatch(t)
+	patch.Changes[0].XRef = "@I9@"
+	if _, _, err := merge.ApplyPatch(doc, patch, nil); err == nil {
+		t.Error("ApplyPatch() should reject a change whose record has another XRef")
+	}
+
+	patch = newTestPatch(t)
+	patch.Changes[0].Record = json.RawMessage(`{"bad"`)
+	if _, _, err := merge.ApplyPatch(doc, patch, nil); err == nil {
+		t.Error("ApplyPatch() should reject a malformed record")
+	}
+
+	patch = newTestPatch(t)
+	patch.Changes[3].Op = "move"
+	if _, _, err := merge.ApplyPatch(doc, patch, nil); err == nil {
+		t.Error("ApplyPatch() should reject an unknown op")
+	}
+}
+
+func TestUnmarshalPatch_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"malformed":    `{"format":`,
+		"wrong format": `{"format":"other","version":1}`,
+		"bad version":  `{"format":"gedcom-go-patch","version":99}`,
+	}
+	for name, data := range tests {
+		if _, err := merge.UnmarshalPatch([]byte(data)); err == nil {
+			t.Errorf("%s: UnmarshalPatch() should fail", name)
+		}
+	}
+}
@@ -19,4 +19,9 @@ type Line struct {
 	// LineNumber is the line number in the source file (1-based)
 	// Used for error reporting
 	LineNumber int
+
+	// Trivia holds the blank and comment lines immediately before this
+	// line, in order. It is populated only when ParseOptions.PreserveTrivia
+	// is set.
+	Trivia []string
 }
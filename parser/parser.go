@@ -2,6 +2,7 @@ package parser
 
 import (
 	"bufio"
+	"errors"
 	"io"
 	"strconv"
 	"strings"
@@ -10,10 +11,16 @@ import (
 // MaxNestingDepth is the maximum allowed nesting depth to prevent stack overflow.
 const MaxNestingDepth = 100
 
+// ErrNestingTooDeep is wrapped by the ParseError for a line whose level
+// exceeds the maximum nesting depth.
+var ErrNestingTooDeep = errors.New("maximum nesting depth exceeded")
+
 // Parser parses GEDCOM files into Line structures.
 type Parser struct {
 	lineNumber int
 	lastLevel  int
+	trailing   []string
+	maxDepth   int
 }
 
 // ParseOptions configures the behavior of ParseWithOptions.
@@ -27,6 +34,22 @@ type ParseOptions struct {
 	// When reached, parsing continues but errors are no longer collected.
 	// A value of 0 means unlimited errors will be collected.
 	MaxErrors int
+
+	// PreserveTrivia keeps blank lines and comment lines (lines starting
+	// with "#" or "//") instead of treating them as errors. Each is
+	// attached to the Trivia of the next parsed line; trivia after the
+	// last line is available from TrailingTrivia.
+	PreserveTrivia bool
+
+	// MaxNestingDepth is the deepest level accepted; deeper lines are
+	// parse errors wrapping ErrNestingTooDeep. A value of 0 means
+	// MaxNestingDepth.
+	MaxNestingDepth int
+
+	// MaxLineLength is the longest line, in bytes, that is read. A longer
+	// line stops parsing with a fatal error wrapping bufio.ErrTooLong. A
+	// value of 0 means MaxLineBytes.
+	MaxLineLength int
 }
 
 // NewParser creates a new Parser instance.
@@ -41,6 +64,21 @@ func NewParser() *Parser {
 func (p *Parser) Reset() {
 	p.lineNumber = 0
 	p.lastLevel = -1
+	p.trailing = nil
+	p.maxDepth = 0
+}
+
+// TrailingTrivia returns the blank and comment lines after the last parsed
+// line of the most recent ParseWithOptions call with PreserveTrivia set.
+func (p *Parser) TrailingTrivia() []string {
+	return p.trailing
+}
+
+// IsTrivia reports whether a raw line carries no GEDCOM data: it is blank,
+// or it is a vendor comment starting with "#" or "//".
+func IsTrivia(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//")
 }
 
 // ParseLine parses a single GEDCOM line.
@@ -79,8 +117,12 @@ func (p *Parser) ParseLine(input string) (*Line, error) {
 	}
 
 	// Check nesting depth
-	if level > MaxNestingDepth {
-		return nil, newParseError(p.lineNumber, "maximum nesting depth exceeded", line)
+	maxDepth := p.maxDepth
+	if maxDepth <= 0 {
+		maxDepth = MaxNestingDepth
+	}
+	if level > maxDepth {
+		return nil, wrapParseError(p.lineNumber, "maximum nesting depth exceeded", line, ErrNestingTooDeep)
 	}
 
 	// Parse XRef and Tag
@@ -170,12 +212,28 @@ func (p *Parser) ParseWithOptions(r io.Reader, opts *ParseOptions) (
 	if opts.MaxErrors < 0 {
 		opts.MaxErrors = 0
 	}
+	p.maxDepth = opts.MaxNestingDepth
 
+	maxLine := opts.MaxLineLength
+	if maxLine <= 0 {
+		maxLine = MaxLineBytes
+	}
 	scanner := bufio.NewScanner(r)
 	scanner.Split(scanGEDCOMLines)
+	// Leave room for the line ending, which the buffer must also hold.
+	scanner.Buffer(make([]byte, 0, min(4096, maxLine+2)), maxLine+2)
 
+	var trivia []string
 	for scanner.Scan() {
+		if len(scanner.Bytes()) > maxLine {
+			return lines, parseErrors, wrapParseError(p.lineNumber, "error reading input", "", bufio.ErrTooLong)
+		}
 		text := scanner.Text()
+		if opts.PreserveTrivia && IsTrivia(text) {
+			p.lineNumber++
+			trivia = append(trivia, text)
+			continue
+		}
 		line, err := p.ParseLine(text)
 		if err != nil {
 			if !opts.Lenient {
@@ -204,8 +262,11 @@ func (p *Parser) ParseWithOptions(r io.Reader, opts *ParseOptions) (
 			// Skip the problematic line and continue parsing
 			continue
 		}
+		line.Trivia = trivia
+		trivia = nil
 		lines = append(lines, line)
 	}
+	p.trailing = trivia
 
 	// Scanner errors are I/O errors - always fatal
 	if err := scanner.Err(); err != nil {
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
 	"strings"
@@ -943,3 +944,56 @@ func TestParseWithOptions_MatchesParseBehavior(t *testing.T) {
 		}
 	}
 }
+
+// TestParseWithOptions_PreserveTrivia verifies that blank and comment lines
+// are attached to the following line instead of being reported as errors.
+func TestParseWithOptions_PreserveTrivia(t *testing.T) {
+	input := "# exported\n0 HEAD\n\n  // note\n0 TRLR\n\n"
+	p := NewParser()
+	lines, errs, err := p.ParseWithOptions(strings.NewReader(input), &ParseOptions{PreserveTrivia: true})
+	if err != nil || len(errs) != 0 {
+		t.Fatalf("ParseWithOptions() = %v, %v", errs, err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	if len(lines[0].Trivia) != 1 || lines[0].Trivia[0] != "# exported" {
+		t.Errorf("HEAD trivia = %q", lines[0].Trivia)
+	}
+	if len(lines[1].Trivia) != 2 || lines[1].Trivia[1] != "  // note" || lines[1].LineNumber != 5 {
+		t.Errorf("TRLR trivia = %q, line %d", lines[1].Trivia, lines[1].LineNumber)
+	}
+	if got := p.TrailingTrivia(); len(got) != 1 || got[0] != "" {
+		t.Errorf("TrailingTrivia() = %q", got)
+	}
+
+	// Without the option, the same input fails in strict mode.
+	if _, _, err := NewParser().ParseWithOptions(strings.NewReader(input), nil); err == nil {
+		t.Error("expected error without PreserveTrivia")
+	}
+}
+
+func TestParseWithOptions_Limits(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n0 TRLR\n"
+
+	_, errs, err := NewParser().ParseWithOptions(strings.NewReader(input), &ParseOptions{Lenient: true, MaxNestingDepth: 1})
+	if err != nil || len(errs) != 1 || !errors.Is(errs[0], ErrNestingTooDeep) || errs[0].Line != 3 {
+		t.Errorf("MaxNestingDepth: errs = %v, err = %v", errs, err)
+	}
+
+	lines, _, err := NewParser().ParseWithOptions(strings.NewReader(input), &ParseOptions{MaxLineLength: 8})
+	if !errors.Is(err, bufio.ErrTooLong) || len(lines) != 2 {
+		t.Errorf("MaxLineLength: lines = %d, err = %v", len(lines), err)
+	}
+	if _, _, err := NewParser().ParseWithOptions(strings.NewReader(input), &ParseOptions{MaxLineLength: 12}); err != nil {
+		t.Errorf("MaxLineLength at longest line: err = %v", err)
+	}
+}
+
+func TestIsTrivia(t *testing.T) {
+	for line, want := range map[string]bool{"": true, "  \t": true, "# x": true, "// x": true, "0 HEAD": false, "1 NOTE # x": false} {
+		if got := IsTrivia(line); got != want {
+			t.Errorf("IsTrivia(%q) = %v, want %v", line, got, want)
+		}
+	}
+}
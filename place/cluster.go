@@ -0,0 +1,279 @@
+package place
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// jurisdictionAliases maps abbreviated or alternate jurisdiction names
+// (lowercased, without periods) to a single comparison form. The table
+// covers US states and common country spellings, which account for most
+// place variants in real-world files.
+var jurisdictionAliases = map[string]string{
+	"al": "alabama", "ak": "alaska", "az": "arizona", "ar": "arkansas",
+	"ca": "california", "co": "colorado", "ct": "connecticut", "de": "delaware",
+	"dc": "district of columbia", "fl": "florida", "ga": "georgia", "hi": "hawaii",
+	"id": "idaho", "il": "illinois", "in": "indiana", "ia": "iowa",
+	"ks": "kansas", "ky": "kentucky", "la": "louisiana", "me": "maine",
+	"md": "maryland", "ma": "massachusetts", "mi": "michigan", "mn": "minnesota",
+	"ms": "mississippi", "mo": "missouri", "mt": "montana", "ne": "nebraska",
+	"nv": "nevada", "nh": "new hampshire", "nj": "new jersey", "nm": "new mexico",
+	"ny": "new york", "nc": "north carolina", "nd": "north dakota", "oh": "ohio",
+	"ok": "oklahoma", "or": "oregon", "pa": "pennsylvania", "ri": "rhode island",
+	"sc": "south carolina", "sd": "south dakota", "tn": "tennessee", "tx": "texas",
+	"ut": "utah", "vt": "vermont", "va": "virginia", "wa": "washington",
+	"wv": "west virginia", "wi": "wisconsin", "wy": "wyoming",
+
+	"us": "usa", "united states": "usa", "united states of america": "usa",
+	"uk": "united kingdom", "great britain": "united kingdom",
+}
+
+// Variant is one spelling of a place and how often it occurs.
+type Variant struct {
+	// Name is the PLAC payload exactly as it appears in the document.
+	Name string
+
+	// Count is the number of events, attributes, and ordinances using Name.
+	Count int
+}
+
+// Cluster is a group of place spellings that appear to name the same place.
+type Cluster struct {
+	// Canonical is the proposed standard form: the variant with the most
+	// jurisdiction components, then the most uses, then the first in
+	// lexical order.
+	Canonical string
+
+	// Variants are all spellings in the cluster, canonical first, then by
+	// descending count and name.
+	Variants []Variant
+}
+
+// ClusterPlaces groups the place strings used in doc into clusters of
+// probable variants and proposes a canonical form for each.
+//
+// Places are split on commas into a locality (the first component) and
+// its jurisdictions. Components are compared case-insensitively with
+// periods removed and well-known abbreviations expanded ("IL" and
+// "Illinois", "USA" and "United States"). Two places cluster when their
+// localities match and the jurisdictions of one are an ordered subset of
+// the other's, so "Springfield, IL" joins "Springfield, Sangamon,
+// Illinois, USA" but not "Springfield, Massachusetts". A place that would
+// fit more than one cluster, such as a bare "Springfield", is left in a
+// cluster of its own rather than guessed.
+//
+// Clusters are returned sorted by canonical form. Single-variant clusters
+// are included so the result covers every place in the document.
+func ClusterPlaces(doc *gedcom.Document) []*Cluster {
+	counts := make(map[string]int)
+	var order []string
+	visitPlaces(doc, func(p *string) {
+		name := strings.TrimSpace(*p)
+		if name == "" {
+			return
+		}
+		if _, seen := counts[*p]; !seen {
+			order = append(order, *p)
+		}
+		counts[*p]++
+	})
+
+	// Group spellings by normalized locality.
+	byLocality := make(map[string][]placeKey)
+	var localities []string
+	for _, name := range order {
+		key := newPlaceKey(name, counts[name])
+		if _, ok := byLocality[key.locality]; !ok {
+			localities = append(localities, key.locality)
+		}
+		byLocality[key.locality] = append(byLocality[key.locality], key)
+	}
+
+	var clusters []*Cluster
+	for _, locality := range localities {
+		clusters = append(clusters, clusterLocality(byLocality[locality])...)
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return clusters[i].Canonical < clusters[j].Canonical
+	})
+	return clusters
+}
+
+// CanonicalMapping returns a variant → canonical mapping for every
+// non-canonical variant in clusters, suitable for Standardize. Callers
+// may edit the mapping (or build their own) before applying it.
+func CanonicalMapping(clusters []*Cluster) map[string]string {
+	mapping := make(map[string]string)
+	for _, c := range clusters {
+		for _, v := range c.Variants {
+			if v.Name != c.Canonical {
+				mapping[v.Name] = c.Canonical
+			}
+		}
+	}
+	return mapping
+}
+
+// placeKey is the normalized form of one place spelling.
+type placeKey struct {
+	name          string
+	count         int
+	locality      string
+	jurisdictions []string
+}
+
+// newPlaceKey normalizes a place string for comparison.
+func newPlaceKey(name string, count int) placeKey {
+	key := placeKey{name: name, count: count}
+	for i, part := range strings.Split(name, ",") {
+		norm := normalizeComponent(part)
+		if i == 0 {
+			key.locality = norm
+			continue
+		}
+		if norm != "" {
+			key.jurisdictions = append(key.jurisdictions, norm)
+		}
+	}
+	return key
+}
+
+// normalizeComponent lowercases a place component, removes periods,
+// collapses whitespace, and expands known abbreviations.
+func normalizeComponent(s string) string {
+	s = strings.ToLower(strings.ReplaceAll(s, ".", ""))
+	s = strings.Join(strings.Fields(s), " ")
+	if alias, ok := jurisdictionAliases[s]; ok {
+		return alias
+	}
+	return s
+}
+
+// isSubsequence reports whether short appears in long in order,
+// not necessarily contiguously.
+func isSubsequence(short, long []string) bool {
+	i := 0
+	for _, s := range long {
+		if i < len(short) && short[i] == s {
+			i++
+		}
+	}
+	return i == len(short)
+}
+
+// compatible reports whether two keys with the same locality can name the
+// same place: the jurisdictions of the less specific one must be an
+// ordered subset of the more specific one's.
+func compatible(a, b placeKey) bool {
+	if len(a.jurisdictions) <= len(b.jurisdictions) {
+		return isSubsequence(a.jurisdictions, b.jurisdictions)
+	}
+	return isSubsequence(b.jurisdictions, a.jurisdictions)
+}
+
+// clusterLocality clusters the spellings sharing one locality. The most
+// specific spellings seed clusters; each less specific spelling joins the
+// single cluster it is compatible with, or seeds its own cluster when it
+// is compatible with none or with several.
+func clusterLocality(keys []placeKey) []*Cluster {
+	sort.SliceStable(keys, func(i, j int) bool {
+		if len(keys[i].jurisdictions) != len(keys[j].jurisdictions) {
+			return len(keys[i].jurisdictions) > len(keys[j].jurisdictions)
+		}
+		if keys[i].count != keys[j].count {
+			return keys[i].count > keys[j].count
+		}
+		return keys[i].name < keys[j].name
+	})
+
+	type group struct {
+		seed    placeKey
+		members []placeKey
+	}
+	var groups []*group
+	for _, key := range keys {
+		var match *group
+		ambiguous := false
+		for _, g := range groups {
+			if !compatible(key, g.seed) {
+				continue
+			}
+			if match != nil {
+				ambiguous = true
+				break
+			}
+			match = g
+		}
+		if match == nil || ambiguous {
+			groups = append(groups, &group{seed: key, members: []placeKey{key}})
+			continue
+		}
+		match.members = append(match.members, key)
+	}
+
+	clusters := make([]*Cluster, 0, len(groups))
+	for _, g := range groups {
+		c := &Cluster{Canonical: g.seed.name}
+		for _, m := range g.members {
+			c.Variants = append(c.Variants, Variant{Name: m.name, Count: m.count})
+		}
+		sort.SliceStable(c.Variants[1:], func(i, j int) bool {
+			vi, vj := c.Variants[1+i], c.Variants[1+j]
+			if vi.Count != vj.Count {
+				return vi.Count > vj.Count
+			}
+			return vi.Name < vj.Name
+		})
+		clusters = append(clusters, c)
+	}
+	return clusters
+}
+
+// visitPlaces calls visit with a pointer to every place string held by
+// the typed entities of doc (see visitRecordPlaces).
+func visitPlaces(doc *gedcom.Document, visit func(*string)) {
+	if doc == nil {
+		return
+	}
+	for _, record := range doc.Records {
+		visitRecordPlaces(record, visit)
+	}
+}
+
+// visitRecordPlaces calls visit with a pointer to every place string held
+// by the typed entity of record: individual and family event places,
+// individual attribute places, and LDS ordinance places.
+func visitRecordPlaces(record *gedcom.Record, visit func(*string)) {
+	if record == nil {
+		return
+	}
+	visitEvents := func(events []*gedcom.Event) {
+		for _, e := range events {
+			if e != nil {
+				visit(&e.Place)
+			}
+		}
+	}
+	visitOrdinances := func(ords []*gedcom.LDSOrdinance) {
+		for _, o := range ords {
+			if o != nil {
+				visit(&o.Place)
+			}
+		}
+	}
+	if ind, ok := record.GetIndividual(); ok {
+		visitEvents(ind.Events)
+		for _, a := range ind.Attributes {
+			if a != nil {
+				visit(&a.Place)
+			}
+		}
+		visitOrdinances(ind.LDSOrdinances)
+	} else if fam, ok := record.GetFamily(); ok {
+		visitEvents(fam.Events)
+		visitOrdinances(fam.LDSOrdinances)
+	}
+}
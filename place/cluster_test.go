@@ -0,0 +1,207 @@
+package place
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const clusterTestGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE 1850
+2 PLAC Springfield, IL
+1 RESI
+2 PLAC Springfield, Sangamon, Illinois, USA
+1 DEAT
+2 PLAC Springfield, Ill., United States
+1 BURI
+2 PLAC Springfield
+1 OCCU Farmer
+2 PLAC Springfield, Illinois
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 BIRT
+2 PLAC Springfield, Massachusetts
+1 DEAT
+2 PLAC Springfield, MA, USA
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 MARR
+2 PLAC Springfield, IL
+0 TRLR
+`
+
+func decodeClusterDoc(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(clusterTestGEDCOM))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func findCluster(clusters []*Cluster, canonical string) *Cluster {
+	for _, c := range clusters {
+		if c.Canonical == canonical {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestClusterPlaces(t *testing.T) {
+	doc := decodeClusterDoc(t)
+
+	clusters := ClusterPlaces(doc)
+
+	il := findCluster(clusters, "Springfield, Sangamon, Illinois, USA")
+	if il == nil {
+		t.Fatalf("no Illinois cluster in %+v", clusters)
+	}
+	names := make(map[string]int)
+	for _, v := range il.Variants {
+		names[v.Name] = v.Count
+	}
+	for _, want := range []string{"Springfield, IL", "Springfield, Illinois", "Springfield, Sangamon, Illinois, USA"} {
+		if _, ok := names[want]; !ok {
+			t.Errorf("Illinois cluster missing %q: %v", want, names)
+		}
+	}
+	if names["Springfield, IL"] != 2 {
+		t.Errorf("Springfield, IL count = %d, want 2 (birth and marriage)", names["Springfield, IL"])
+	}
+	if il.Variants[0].Name != il.Canonical {
+		t.Errorf("first variant = %q, want canonical first", il.Variants[0].Name)
+	}
+	// "Ill." is not a known abbreviation, so it stays separate.
+	if _, ok := names["Springfield, Ill., United States"]; ok {
+		t.Error("unknown abbreviation should not be clustered")
+	}
+
+	ma := findCluster(clusters, "Springfield, MA, USA")
+	if ma == nil || len(ma.Variants) != 2 {
+		t.Fatalf("Massachusetts cluster = %+v, want 2 variants", ma)
+	}
+
+	bare := findCluster(clusters, "Springfield")
+	if bare == nil || len(bare.Variants) != 1 {
+		t.Errorf("ambiguous bare place should be its own cluster, got %+v", bare)
+	}
+}
+
+func TestCanonicalMapping(t *testing.T) {
+	clusters := []*Cluster{
+		{Canonical: "A, B", Variants: []Variant{{Name: "A, B"}, {Name: "a, b"}}},
+		{Canonical: "C", Variants: []Variant{{Name: "C"}}},
+	}
+	mapping := CanonicalMapping(clusters)
+	if len(mapping) != 1 || mapping["a, b"] != "A, B" {
+		t.Errorf("CanonicalMapping() = %v", mapping)
+	}
+}
+
+func TestNormalizeComponent(t *testing.T) {
+	tests := map[string]string{
+		" IL ":                     "illinois",
+		"U.S.":                     "usa",
+		"United  States":           "usa",
+		"Sangamon":                 "sangamon",
+		"United States of America": "usa",
+	}
+	for in, want := range tests {
+		if got := normalizeComponent(in); got != want {
+			t.Errorf("normalizeComponent(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestStandardize(t *testing.T) {
+	doc := decodeClusterDoc(t)
+	mapping := CanonicalMapping(ClusterPlaces(doc))
+
+	out, report, err := Standardize(doc, mapping, &StandardizeOptions{PreserveOriginal: true})
+	if err != nil {
+		t.Fatalf("Standardize() error = %v", err)
+	}
+
+	// Input is not mutated.
+	if doc.GetIndividual("@I1@").Events[0].Place != "Springfield, IL" {
+		t.Error("Standardize mutated the input document")
+	}
+
+	john := out.GetIndividual("@I1@")
+	if got := john.Events[0].Place; got != "Springfield, Sangamon, Illinois, USA" {
+		t.Errorf("birth place = %q", got)
+	}
+	if got := john.Events[0].PlaceDetail.Name; got != "Springfield, Sangamon, Illinois, USA" {
+		t.Errorf("birth PlaceDetail.Name = %q", got)
+	}
+	if got := john.Attributes[0].Place; got != "Springfield, Sangamon, Illinois, USA" {
+		t.Errorf("occupation place = %q", got)
+	}
+	if got := out.GetFamily("@F1@").Events[0].Place; got != "Springfield, Sangamon, Illinois, USA" {
+		t.Errorf("marriage place = %q", got)
+	}
+
+	// BIRT, OCCU, DEAT (Mary), MARR.
+	if len(report.Changes) != 4 {
+		t.Errorf("report has %d changes, want 4: %+v", len(report.Changes), report.Changes)
+	}
+	if report.Changes[0].LineNumber == 0 || report.Changes[0].Original != "Springfield, IL" {
+		t.Errorf("first change = %+v", report.Changes[0])
+	}
+
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, out); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	encoded := buf.String()
+	if !strings.Contains(encoded, "2 PLAC Springfield, Sangamon, Illinois, USA\n3 NOTE Original place: Springfield, IL\n") {
+		t.Errorf("encoded output missing rewritten place with original note:\n%s", encoded)
+	}
+}
+
+func TestStandardize_EntityOnly(t *testing.T) {
+	doc := &gedcom.Document{XRefMap: map[string]*gedcom.Record{}}
+	rec := &gedcom.Record{
+		XRef: "@I1@",
+		Type: gedcom.RecordTypeIndividual,
+		Entity: &gedcom.Individual{
+			XRef:   "@I1@",
+			Events: []*gedcom.Event{{Type: gedcom.EventBirth, Place: "x"}},
+		},
+	}
+	doc.Records = append(doc.Records, rec)
+	doc.XRefMap["@I1@"] = rec
+
+	out, report, err := Standardize(doc, map[string]string{"x": "X"}, nil)
+	if err != nil {
+		t.Fatalf("Standardize() error = %v", err)
+	}
+	if out.GetIndividual("@I1@").Events[0].Place != "X" {
+		t.Error("entity-only place not rewritten")
+	}
+	if len(report.Changes) != 1 || report.Changes[0].LineNumber != 0 {
+		t.Errorf("report = %+v, want one entity change", report.Changes)
+	}
+}
+
+func TestStandardize_Errors(t *testing.T) {
+	if _, _, err := Standardize(nil, nil, nil); err == nil {
+		t.Error("Standardize(nil) should return an error")
+	}
+	out, report, err := Standardize(&gedcom.Document{}, nil, nil)
+	if err != nil || out == nil || len(report.Changes) != 0 {
+		t.Errorf("empty mapping: out=%v report=%+v err=%v", out, report, err)
+	}
+}
@@ -0,0 +1,48 @@
+// Package place provides place-centric views of GEDCOM documents.
+//
+// The gedcom package stores places exactly as they appear in the file:
+// a PLAC payload string plus an optional PlaceDetail carrying FORM and
+// MAP/LATI/LONG coordinates. This package builds on that representation
+// to answer questions about where events happened, without changing how
+// places are decoded or encoded.
+//
+// Map export:
+//
+//   - Locations: collect the geocoded events of a document (birth,
+//     marriage, death and residence by default) with the owning record,
+//     a display name, the event type, date, and decimal coordinates.
+//   - WriteGeoJSON: write those locations as a GeoJSON FeatureCollection
+//     (RFC 7946) of Point features.
+//   - WriteKML: write those locations as a KML 2.2 document of Placemarks.
+//
+// Migration analysis:
+//
+//   - IndividualPath: order an individual's dated, located events
+//     chronologically and emit movement Segments between consecutive
+//     places, with great-circle distances where both ends are geocoded.
+//   - Migrations, GroupBySurname, Lineage: aggregate paths per surname
+//     or per line of descent for migration studies.
+//
+// Place standardization:
+//
+//   - ClusterPlaces: group variant spellings of the same place
+//     ("Springfield, IL" and "Springfield, Sangamon, Illinois, USA") and
+//     propose a canonical form for each cluster.
+//   - CanonicalMapping: turn clusters into a variant → canonical mapping
+//     for review or editing.
+//   - Standardize: apply a mapping to a copy of the document, optionally
+//     keeping each original spelling in a NOTE under the PLAC.
+//
+// Example usage:
+//
+//	doc, _ := decoder.Decode(f)
+//	out, _ := os.Create("events.geojson")
+//	defer out.Close()
+//	if err := place.WriteGeoJSON(out, doc, nil); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Events without coordinates, or whose coordinates fail
+// gedcom.Coordinates.AsDecimal, are skipped rather than reported as
+// errors; map exports are a best-effort view of the geocoded subset.
+package place
@@ -0,0 +1,276 @@
+package place
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const geoTestGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE 12 MAR 1850
+2 PLAC Boston, Suffolk, Massachusetts, USA
+3 MAP
+4 LATI N42.3601
+4 LONG W71.0589
+1 RESI
+2 DATE 1880
+2 PLAC Springfield, Illinois, USA
+3 MAP
+4 LATI N39.7817
+4 LONG W89.6501
+1 CENS
+2 DATE 1900
+2 PLAC Chicago, Illinois, USA
+3 MAP
+4 LATI N41.8781
+4 LONG W87.6298
+1 DEAT
+2 PLAC Nowhere
+1 FAMS @F1@
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 BIRT
+2 PLAC Bad Coordinates
+3 MAP
+4 LATI X12
+4 LONG W1
+1 FAMS @F1@
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 MARR
+2 DATE 1875
+2 PLAC London, England
+3 MAP
+4 LATI N51.5074
+4 LONG W0.1278
+0 TRLR
+`
+
+func decodeGeoTestDoc(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(geoTestGEDCOM))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestLocations_Defaults(t *testing.T) {
+	doc := decodeGeoTestDoc(t)
+
+	locs := Locations(doc, nil)
+	if len(locs) != 3 {
+		t.Fatalf("Locations() returned %d locations, want 3: %+v", len(locs), locs)
+	}
+
+	want := []struct {
+		xref  string
+		name  string
+		event gedcom.EventType
+	}{
+		{"@I1@", "John Smith", gedcom.EventBirth},
+		{"@I1@", "John Smith", gedcom.EventResidence},
+		{"@F1@", "John Smith & Mary Jones", gedcom.EventMarriage},
+	}
+	for i, w := range want {
+		if locs[i].RecordXRef != w.xref || locs[i].Name != w.name || locs[i].EventType != w.event {
+			t.Errorf("locs[%d] = {%s %q %s}, want {%s %q %s}",
+				i, locs[i].RecordXRef, locs[i].Name, locs[i].EventType, w.xref, w.name, w.event)
+		}
+	}
+
+	if locs[0].Latitude != 42.3601 || locs[0].Longitude != -71.0589 {
+		t.Errorf("birth coordinates = (%v, %v), want (42.3601, -71.0589)", locs[0].Latitude, locs[0].Longitude)
+	}
+	if locs[0].Date != "12 MAR 1850" || locs[0].ParsedDate == nil {
+		t.Errorf("birth date = %q (parsed %v), want parsed 12 MAR 1850", locs[0].Date, locs[0].ParsedDate)
+	}
+}
+
+func TestLocations_EventTypesFilter(t *testing.T) {
+	doc := decodeGeoTestDoc(t)
+
+	locs := Locations(doc, &ExportOptions{EventTypes: []gedcom.EventType{gedcom.EventCensus}})
+	if len(locs) != 1 || locs[0].Place != "Chicago, Illinois, USA" {
+		t.Fatalf("Locations(CENS) = %+v, want the single census event", locs)
+	}
+}
+
+func TestLocations_NegativeEvents(t *testing.T) {
+	doc := &gedcom.Document{
+		Records: []*gedcom.Record{{
+			XRef: "@I1@",
+			Type: gedcom.RecordTypeIndividual,
+			Entity: &gedcom.Individual{
+				XRef: "@I1@",
+				Events: []*gedcom.Event{{
+					Type:       gedcom.EventResidence,
+					IsNegative: true,
+					PlaceDetail: &gedcom.PlaceDetail{
+						Coordinates: &gedcom.Coordinates{Latitude: "N1", Longitude: "E1"},
+					},
+				}},
+			},
+		}},
+	}
+
+	if got := Locations(doc, nil); len(got) != 0 {
+		t.Errorf("Locations() included negative assertion: %+v", got)
+	}
+	got := Locations(doc, &ExportOptions{IncludeNegative: true})
+	if len(got) != 1 {
+		t.Fatalf("Locations(IncludeNegative) = %d locations, want 1", len(got))
+	}
+	if got[0].Name != "@I1@" {
+		t.Errorf("unnamed individual Name = %q, want XRef fallback", got[0].Name)
+	}
+}
+
+func TestLocations_NilDocument(t *testing.T) {
+	if got := Locations(nil, nil); got != nil {
+		t.Errorf("Locations(nil) = %v, want nil", got)
+	}
+}
+
+func TestWriteGeoJSON(t *testing.T) {
+	doc := decodeGeoTestDoc(t)
+
+	var buf bytes.Buffer
+	if err := WriteGeoJSON(&buf, doc, nil); err != nil {
+		t.Fatalf("WriteGeoJSON() error = %v", err)
+	}
+
+	var got struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Type     string `json:"type"`
+			Geometry struct {
+				Type        string    `json:"type"`
+				Coordinates []float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if got.Type != "FeatureCollection" {
+		t.Errorf("type = %q, want FeatureCollection", got.Type)
+	}
+	if len(got.Features) != 3 {
+		t.Fatalf("features = %d, want 3", len(got.Features))
+	}
+
+	birth := got.Features[0]
+	if birth.Geometry.Type != "Point" {
+		t.Errorf("geometry type = %q, want Point", birth.Geometry.Type)
+	}
+	if len(birth.Geometry.Coordinates) != 2 || birth.Geometry.Coordinates[0] != -71.0589 || birth.Geometry.Coordinates[1] != 42.3601 {
+		t.Errorf("coordinates = %v, want [lon, lat] = [-71.0589 42.3601]", birth.Geometry.Coordinates)
+	}
+	props := birth.Properties
+	if props["xref"] != "@I1@" || props["name"] != "John Smith" || props["event"] != "BIRT" ||
+		props["date"] != "12 MAR 1850" || props["year"] != float64(1850) {
+		t.Errorf("properties = %v", props)
+	}
+}
+
+func TestWriteGeoJSON_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGeoJSON(&buf, &gedcom.Document{}, nil); err != nil {
+		t.Fatalf("WriteGeoJSON() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"features": []`) {
+		t.Errorf("empty export should contain an empty features array, got:\n%s", buf.String())
+	}
+}
+
+func TestWriteKML(t *testing.T) {
+	doc := decodeGeoTestDoc(t)
+
+	var buf bytes.Buffer
+	if err := WriteKML(&buf, doc, nil); err != nil {
+		t.Fatalf("WriteKML() error = %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, xml.Header) {
+		t.Error("KML output should start with the XML declaration")
+	}
+
+	var got kmlRoot
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, out)
+	}
+	if got.XMLName.Space != kmlNamespace {
+		t.Errorf("namespace = %q, want %q", got.XMLName.Space, kmlNamespace)
+	}
+	if len(got.Document.Placemarks) != 3 {
+		t.Fatalf("placemarks = %d, want 3", len(got.Document.Placemarks))
+	}
+
+	birth := got.Document.Placemarks[0]
+	if birth.Name != "John Smith (BIRT)" {
+		t.Errorf("placemark name = %q", birth.Name)
+	}
+	if birth.Point.Coordinates != "-71.0589,42.3601" {
+		t.Errorf("coordinates = %q, want lon,lat", birth.Point.Coordinates)
+	}
+	if birth.TimeStamp == nil || birth.TimeStamp.When != "1850" {
+		t.Errorf("timestamp = %+v, want 1850", birth.TimeStamp)
+	}
+	if birth.Description != "12 MAR 1850, Boston, Suffolk, Massachusetts, USA" {
+		t.Errorf("description = %q", birth.Description)
+	}
+}
+
+func TestLocationDescription(t *testing.T) {
+	tests := []struct {
+		loc  Location
+		want string
+	}{
+		{Location{Date: "1900", Place: "Paris"}, "1900, Paris"},
+		{Location{Date: "1900"}, "1900"},
+		{Location{Place: "Paris"}, "Paris"},
+		{Location{}, ""},
+	}
+	for _, tt := range tests {
+		if got := locationDescription(tt.loc); got != tt.want {
+			t.Errorf("locationDescription(%+v) = %q, want %q", tt.loc, got, tt.want)
+		}
+	}
+}
+
+func TestLocationYear(t *testing.T) {
+	bc, _ := gedcom.ParseDate("44 BC")
+	hebrew, _ := gedcom.ParseDate("@#DHEBREW@ 1 TSH 5785")
+	tests := []struct {
+		name string
+		date *gedcom.Date
+		want int
+	}{
+		{"nil", nil, 0},
+		{"bc", bc, -44},
+		{"hebrew", hebrew, 2024},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := locationYear(Location{ParsedDate: tt.date}); got != tt.want {
+				t.Errorf("locationYear() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package place
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// geoJSONFeatureCollection is the top-level GeoJSON object (RFC 7946).
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+// geoJSONFeature is a single Point feature with event properties.
+type geoJSONFeature struct {
+	Type       string            `json:"type"`
+	Geometry   geoJSONGeometry   `json:"geometry"`
+	Properties geoJSONProperties `json:"properties"`
+}
+
+// geoJSONGeometry is a GeoJSON Point. Coordinates are [longitude, latitude]
+// as required by RFC 7946.
+type geoJSONGeometry struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// geoJSONProperties carries the event attributes of a feature.
+type geoJSONProperties struct {
+	XRef  string `json:"xref"`
+	Name  string `json:"name"`
+	Event string `json:"event"`
+	Date  string `json:"date,omitempty"`
+	Year  int    `json:"year,omitempty"`
+	Place string `json:"place,omitempty"`
+}
+
+// WriteGeoJSON writes the geocoded events of doc as an indented GeoJSON
+// FeatureCollection of Point features. Each feature's properties hold the
+// owning record XRef ("xref"), display name ("name"), event tag ("event"),
+// raw date ("date"), parsed year ("year", omitted when unknown), and place
+// ("place"). If opts is nil, default options are used.
+//
+// A document with no geocoded events produces a valid, empty collection.
+func WriteGeoJSON(w io.Writer, doc *gedcom.Document, opts *ExportOptions) error {
+	collection := geoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: []geoJSONFeature{},
+	}
+
+	for _, loc := range Locations(doc, opts) {
+		collection.Features = append(collection.Features, geoJSONFeature{
+			Type: "Feature",
+			Geometry: geoJSONGeometry{
+				Type:        "Point",
+				Coordinates: [2]float64{loc.Longitude, loc.Latitude},
+			},
+			Properties: geoJSONProperties{
+				XRef:  loc.RecordXRef,
+				Name:  loc.Name,
+				Event: string(loc.EventType),
+				Date:  loc.Date,
+				Year:  locationYear(loc),
+				Place: loc.Place,
+			},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(collection)
+}
+
+// locationYear returns the Gregorian year of a location's date (negative
+// for B.C.), or 0 when the date did not parse, has no year, or cannot be
+// converted to the Gregorian calendar.
+func locationYear(loc Location) int {
+	if loc.ParsedDate == nil || loc.ParsedDate.Year == 0 {
+		return 0
+	}
+	greg, err := loc.ParsedDate.ToGregorian()
+	if err != nil {
+		return 0
+	}
+	if greg.IsBC {
+		return -greg.Year
+	}
+	return greg.Year
+}
@@ -0,0 +1,118 @@
+package place
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// kmlNamespace is the OGC KML 2.2 namespace.
+const kmlNamespace = "http://www.opengis.net/kml/2.2"
+
+// kmlRoot is the top-level <kml> element.
+type kmlRoot struct {
+	XMLName  xml.Name    `xml:"kml"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Document kmlDocument `xml:"Document"`
+}
+
+// kmlDocument is the <Document> container of Placemarks.
+type kmlDocument struct {
+	Name       string         `xml:"name,omitempty"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+// kmlPlacemark is a single event location.
+type kmlPlacemark struct {
+	Name         string        `xml:"name"`
+	Description  string        `xml:"description,omitempty"`
+	TimeStamp    *kmlTimeStamp `xml:"TimeStamp,omitempty"`
+	ExtendedData kmlExtended   `xml:"ExtendedData"`
+	Point        kmlPoint      `xml:"Point"`
+}
+
+// kmlTimeStamp positions a Placemark on the KML time slider.
+type kmlTimeStamp struct {
+	When string `xml:"when"`
+}
+
+// kmlExtended holds typed name/value pairs for a Placemark.
+type kmlExtended struct {
+	Data []kmlData `xml:"Data"`
+}
+
+// kmlData is a single <Data name="..."><value>...</value></Data> entry.
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// kmlPoint is a KML Point; coordinates are "longitude,latitude".
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+// WriteKML writes the geocoded events of doc as a KML 2.2 document with
+// one Placemark per event. Placemark names read "<name> (<event>)"; the
+// description holds the date and place. Events with a parsed date carry a
+// TimeStamp (year precision) so viewers such as Google Earth can animate
+// movement over time. The same attributes as WriteGeoJSON are exported as
+// ExtendedData. If opts is nil, default options are used.
+func WriteKML(w io.Writer, doc *gedcom.Document, opts *ExportOptions) error {
+	root := kmlRoot{
+		Xmlns:    kmlNamespace,
+		Document: kmlDocument{Name: "GEDCOM event locations"},
+	}
+
+	for _, loc := range Locations(doc, opts) {
+		pm := kmlPlacemark{
+			Name:        fmt.Sprintf("%s (%s)", loc.Name, loc.EventType),
+			Description: locationDescription(loc),
+			ExtendedData: kmlExtended{Data: []kmlData{
+				{Name: "xref", Value: loc.RecordXRef},
+				{Name: "name", Value: loc.Name},
+				{Name: "event", Value: string(loc.EventType)},
+				{Name: "date", Value: loc.Date},
+				{Name: "place", Value: loc.Place},
+			}},
+			Point: kmlPoint{
+				Coordinates: strconv.FormatFloat(loc.Longitude, 'f', -1, 64) + "," +
+					strconv.FormatFloat(loc.Latitude, 'f', -1, 64),
+			},
+		}
+		// KML uses xsd:gYear, which only covers positive years.
+		if year := locationYear(loc); year > 0 {
+			pm.TimeStamp = &kmlTimeStamp{When: fmt.Sprintf("%04d", year)}
+		}
+		root.Document.Placemarks = append(root.Document.Placemarks, pm)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// locationDescription joins the date and place of a location for display.
+func locationDescription(loc Location) string {
+	switch {
+	case loc.Date != "" && loc.Place != "":
+		return loc.Date + ", " + loc.Place
+	case loc.Date != "":
+		return loc.Date
+	default:
+		return loc.Place
+	}
+}
@@ -0,0 +1,145 @@
+package place
+
+import (
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// DefaultEventTypes are the event types collected by Locations when
+// ExportOptions.EventTypes is empty: the vital events plus residence,
+// which together trace where a person lived over time.
+var DefaultEventTypes = []gedcom.EventType{
+	gedcom.EventBirth,
+	gedcom.EventMarriage,
+	gedcom.EventDeath,
+	gedcom.EventResidence,
+}
+
+// ExportOptions configures which events are collected for map export.
+type ExportOptions struct {
+	// EventTypes limits the export to the listed event types.
+	// If empty, DefaultEventTypes is used.
+	EventTypes []gedcom.EventType
+
+	// IncludeNegative includes GEDCOM 7.0 negative assertions (NO tags).
+	// Default: false, since an event that did not happen has no location.
+	IncludeNegative bool
+}
+
+// Location is a single geocoded event.
+type Location struct {
+	// RecordXRef is the XRef of the individual or family owning the event.
+	RecordXRef string
+
+	// Name is a display name for the owning record: the individual's
+	// primary name with surname slashes removed, or "Husband & Wife" for
+	// family events.
+	Name string
+
+	// EventType is the GEDCOM tag of the event (e.g., BIRT, MARR).
+	EventType gedcom.EventType
+
+	// Date is the raw GEDCOM date of the event, if any.
+	Date string
+
+	// ParsedDate is the parsed form of Date, or nil if it did not parse.
+	ParsedDate *gedcom.Date
+
+	// Place is the PLAC payload of the event.
+	Place string
+
+	// Latitude and Longitude are signed decimal degrees (N/E positive).
+	Latitude  float64
+	Longitude float64
+}
+
+// Locations returns the geocoded events of doc that match opts, in
+// document order: records in file order, events in record order.
+// Individual events come from Individual.Events and family events from
+// Family.Events. If opts is nil, default options are used.
+//
+// Events without coordinates, or with coordinates that
+// gedcom.Coordinates.AsDecimal rejects, are skipped.
+func Locations(doc *gedcom.Document, opts *ExportOptions) []Location {
+	if doc == nil {
+		return nil
+	}
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+
+	wanted := make(map[gedcom.EventType]bool)
+	types := opts.EventTypes
+	if len(types) == 0 {
+		types = DefaultEventTypes
+	}
+	for _, t := range types {
+		wanted[t] = true
+	}
+
+	var locations []Location
+	collect := func(xref, name string, events []*gedcom.Event) {
+		for _, event := range events {
+			if event == nil || !wanted[event.Type] {
+				continue
+			}
+			if event.IsNegative && !opts.IncludeNegative {
+				continue
+			}
+			lat, long, ok := eventCoordinates(event)
+			if !ok {
+				continue
+			}
+			locations = append(locations, Location{
+				RecordXRef: xref,
+				Name:       name,
+				EventType:  event.Type,
+				Date:       event.Date,
+				ParsedDate: event.ParsedDate,
+				Place:      event.Place,
+				Latitude:   lat,
+				Longitude:  long,
+			})
+		}
+	}
+
+	for _, record := range doc.Records {
+		if ind, ok := record.GetIndividual(); ok {
+			collect(ind.XRef, gedcom.DefaultNameFormatter.FormatIndividual(ind), ind.Events)
+		} else if fam, ok := record.GetFamily(); ok {
+			collect(fam.XRef, familyName(doc, fam), fam.Events)
+		}
+	}
+
+	return locations
+}
+
+// eventCoordinates returns the decimal coordinates of an event's place.
+// ok is false when the event has no usable coordinates.
+func eventCoordinates(event *gedcom.Event) (lat, long float64, ok bool) {
+	if event.PlaceDetail == nil || event.PlaceDetail.Coordinates.IsEmpty() {
+		return 0, 0, false
+	}
+	lat, long, err := event.PlaceDetail.Coordinates.AsDecimal()
+	if err != nil {
+		return 0, 0, false
+	}
+	return lat, long, true
+}
+
+// familyName returns "Husband & Wife" for fam, omitting missing spouses,
+// or the family XRef if neither spouse resolves.
+func familyName(doc *gedcom.Document, fam *gedcom.Family) string {
+	var parts []string
+	if husband := fam.HusbandIndividual(doc); husband != nil {
+		parts = append(parts, gedcom.DefaultNameFormatter.FormatIndividual(husband))
+	}
+	if wife := fam.WifeIndividual(doc); wife != nil {
+		parts = append(parts, gedcom.DefaultNameFormatter.FormatIndividual(wife))
+	}
+	if len(parts) == 0 {
+		return fam.XRef
+	}
+	return strings.Join(parts, " & ")
+}
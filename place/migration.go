@@ -0,0 +1,327 @@
+package place
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// earthRadiusKm is the mean Earth radius used for great-circle distances.
+const earthRadiusKm = 6371.0
+
+// MigrationOptions configures migration path analysis.
+type MigrationOptions struct {
+	// EventTypes limits the events considered as stops.
+	// If empty, every dated event with a place is considered.
+	EventTypes []gedcom.EventType
+}
+
+// Stop is a dated, located event on an individual's path.
+type Stop struct {
+	// EventType is the GEDCOM tag of the event.
+	EventType gedcom.EventType
+
+	// Date is the raw GEDCOM date and ParsedDate its parsed form.
+	Date       string
+	ParsedDate *gedcom.Date
+
+	// Place is the PLAC payload of the event.
+	Place string
+
+	// HasCoordinates reports whether Latitude and Longitude are set.
+	HasCoordinates bool
+	Latitude       float64
+	Longitude      float64
+
+	order *gedcom.Date // the event's OrderDate
+}
+
+// Segment is a move between two consecutive stops at different places.
+type Segment struct {
+	// IndividualXRef is the individual who moved.
+	IndividualXRef string
+
+	// From and To are the stops at either end of the move; the move
+	// happened between From.Date and To.Date.
+	From Stop
+	To   Stop
+
+	// HasDistance reports whether DistanceKm was computed, which requires
+	// coordinates on both stops.
+	HasDistance bool
+
+	// DistanceKm is the great-circle distance between the two stops.
+	DistanceKm float64
+}
+
+// Path is the chronological sequence of located events for one individual.
+type Path struct {
+	// IndividualXRef is the individual the path belongs to.
+	IndividualXRef string
+
+	// Name is the individual's display name.
+	Name string
+
+	// Surname is the individual's primary surname, used for grouping.
+	Surname string
+
+	// Stops are the dated, located events in chronological order.
+	Stops []Stop
+
+	// Segments are the moves between consecutive stops whose places differ.
+	Segments []Segment
+}
+
+// TotalDistanceKm returns the summed distance of all segments with a
+// known distance.
+func (p *Path) TotalDistanceKm() float64 {
+	var total float64
+	for _, seg := range p.Segments {
+		if seg.HasDistance {
+			total += seg.DistanceKm
+		}
+	}
+	return total
+}
+
+// IndividualPath returns the migration path of the individual identified
+// by xref, or nil if doc is nil or xref is not an individual.
+//
+// Stops are the individual's own events plus the events of families in
+// which they are a spouse (marriage, divorce, and so on). Only events with
+// both a non-empty place and a parsed date are used, since undated events
+// cannot be placed in sequence. Stops are ordered by gedcom.Event.OrderDate,
+// so an SDATE or vendor sort date overrides the date; events with equal
+// dates keep their record order. Negative assertions
+// (GEDCOM 7.0 NO tags) are ignored.
+//
+// Consecutive stops at the same place (compared case-insensitively,
+// ignoring surrounding whitespace) do not produce a segment.
+func IndividualPath(doc *gedcom.Document, xref string, opts *MigrationOptions) *Path {
+	if doc == nil {
+		return nil
+	}
+	ind := doc.GetIndividual(xref)
+	if ind == nil {
+		return nil
+	}
+	if opts == nil {
+		opts = &MigrationOptions{}
+	}
+
+	var wanted map[gedcom.EventType]bool
+	if len(opts.EventTypes) > 0 {
+		wanted = make(map[gedcom.EventType]bool, len(opts.EventTypes))
+		for _, t := range opts.EventTypes {
+			wanted[t] = true
+		}
+	}
+
+	path := &Path{
+		IndividualXRef: ind.XRef,
+		Name:           gedcom.DefaultNameFormatter.FormatIndividual(ind),
+		Surname:        primarySurname(ind),
+	}
+	path.Stops = appendStops(path.Stops, ind.Events, wanted)
+	for _, fam := range ind.SpouseFamilies(doc) {
+		path.Stops = appendStops(path.Stops, fam.Events, wanted)
+	}
+
+	sort.SliceStable(path.Stops, func(i, j int) bool {
+		return path.Stops[i].orderDate().Compare(path.Stops[j].orderDate()) < 0
+	})
+	path.Segments = stopSegments(ind.XRef, path.Stops)
+	return path
+}
+
+// appendStops appends a stop for each dated, located, positive event of
+// events whose type is wanted (all types when wanted is nil).
+func appendStops(stops []Stop, events []*gedcom.Event, wanted map[gedcom.EventType]bool) []Stop {
+	for _, event := range events {
+		if event == nil || event.IsNegative || event.ParsedDate == nil {
+			continue
+		}
+		if strings.TrimSpace(event.Place) == "" {
+			continue
+		}
+		if wanted != nil && !wanted[event.Type] {
+			continue
+		}
+		stop := Stop{
+			EventType:  event.Type,
+			Date:       event.Date,
+			ParsedDate: event.ParsedDate,
+			Place:      event.Place,
+			order:      event.OrderDate(),
+		}
+		if lat, long, ok := eventCoordinates(event); ok {
+			stop.HasCoordinates = true
+			stop.Latitude = lat
+			stop.Longitude = long
+		}
+		stops = append(stops, stop)
+	}
+	return stops
+}
+
+// stopSegments returns the segments between consecutive stops at
+// different places of the individual xref.
+func stopSegments(xref string, stops []Stop) []Segment {
+	var segments []Segment
+	for i := 1; i < len(stops); i++ {
+		from, to := stops[i-1], stops[i]
+		if samePlace(from.Place, to.Place) {
+			continue
+		}
+		seg := Segment{IndividualXRef: xref, From: from, To: to}
+		if from.HasCoordinates && to.HasCoordinates {
+			seg.HasDistance = true
+			seg.DistanceKm = HaversineKm(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+		}
+		segments = append(segments, seg)
+	}
+	return segments
+}
+
+// Migrations returns the migration path of every individual in doc, in
+// document order. Individuals with no dated, located events are included
+// with empty Stops so that callers can report coverage.
+func Migrations(doc *gedcom.Document, opts *MigrationOptions) []*Path {
+	if doc == nil {
+		return nil
+	}
+	var paths []*Path
+	for _, ind := range doc.Individuals() {
+		if path := IndividualPath(doc, ind.XRef, opts); path != nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// Group aggregates the migration paths of several individuals, such as
+// everyone sharing a surname or a lineage descending from one ancestor.
+type Group struct {
+	// Key identifies the group: the surname for GroupBySurname, or the
+	// root individual's XRef for Lineage.
+	Key string
+
+	// Paths are the member paths.
+	Paths []*Path
+}
+
+// Segments returns all member segments ordered by the date the move
+// ended, so the group's movements read as one chronological narrative.
+func (g *Group) Segments() []Segment {
+	var segments []Segment
+	for _, p := range g.Paths {
+		segments = append(segments, p.Segments...)
+	}
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].To.orderDate().Compare(segments[j].To.orderDate()) < 0
+	})
+	return segments
+}
+
+// orderDate returns the date that orders s: its event's OrderDate, or
+// ParsedDate for stops built outside IndividualPath.
+func (s Stop) orderDate() *gedcom.Date {
+	if s.order != nil {
+		return s.order
+	}
+	return s.ParsedDate
+}
+
+// TotalDistanceKm returns the summed known distance of all member segments.
+func (g *Group) TotalDistanceKm() float64 {
+	var total float64
+	for _, p := range g.Paths {
+		total += p.TotalDistanceKm()
+	}
+	return total
+}
+
+// GroupBySurname groups paths by Path.Surname, matching surnames
+// case-insensitively. Groups are sorted by key; the key keeps the casing
+// of the first path seen. Paths with no surname are grouped under "".
+func GroupBySurname(paths []*Path) []*Group {
+	byKey := make(map[string]*Group)
+	var groups []*Group
+	for _, p := range paths {
+		if p == nil {
+			continue
+		}
+		key := strings.ToUpper(p.Surname)
+		g, ok := byKey[key]
+		if !ok {
+			g = &Group{Key: p.Surname}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.Paths = append(g.Paths, p)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return strings.ToUpper(groups[i].Key) < strings.ToUpper(groups[j].Key)
+	})
+	return groups
+}
+
+// Lineage returns the paths of the individual identified by rootXRef and
+// all of their descendants (see gedcom.Document.Descendants), keyed by
+// rootXRef. Returns nil if rootXRef is not an individual in doc.
+func Lineage(doc *gedcom.Document, rootXRef string, opts *MigrationOptions) *Group {
+	root := IndividualPath(doc, rootXRef, opts)
+	if root == nil {
+		return nil
+	}
+	group := &Group{Key: rootXRef, Paths: []*Path{root}}
+	for _, xref := range doc.Descendants(rootXRef) {
+		if p := IndividualPath(doc, xref, opts); p != nil {
+			group.Paths = append(group.Paths, p)
+		}
+	}
+	return group
+}
+
+// HaversineKm returns the great-circle distance in kilometers between two
+// points given in signed decimal degrees.
+func HaversineKm(lat1, long1, lat2, long2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLong := toRad(long2 - long1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLong/2)*math.Sin(dLong/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// samePlace reports whether two PLAC payloads name the same place,
+// ignoring case and surrounding whitespace.
+func samePlace(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+// primarySurname returns the surname of the individual's first name that
+// has one, taken from the SURN component or, failing that, from the
+// slash-delimited part of the full name.
+func primarySurname(ind *gedcom.Individual) string {
+	for _, name := range ind.Names {
+		if name == nil {
+			continue
+		}
+		if name.Surname != "" {
+			return name.Surname
+		}
+		if start := strings.Index(name.Full, "/"); start >= 0 {
+			rest := name.Full[start+1:]
+			if end := strings.Index(rest, "/"); end >= 0 {
+				rest = rest[:end]
+			}
+			if s := strings.TrimSpace(rest); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,243 @@
+package place
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const migrationTestGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /Smith/
+1 RESI
+2 DATE 1880
+2 PLAC Chicago, Illinois, USA
+3 MAP
+4 LATI N41.8781
+4 LONG W87.6298
+1 BIRT
+2 DATE 12 MAR 1850
+2 PLAC Boston, Massachusetts, USA
+3 MAP
+4 LATI N42.3601
+4 LONG W71.0589
+1 CENS
+2 DATE 1890
+2 PLAC chicago, illinois, usa
+1 DEAT
+2 DATE 1910
+2 PLAC Denver, Colorado, USA
+1 BURI
+2 PLAC Undated Cemetery
+1 FAMS @F1@
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 FAMS @F1@
+0 @I3@ INDI
+1 NAME Tom /Smith/
+1 BIRT
+2 DATE 1877
+2 PLAC Springfield, Illinois, USA
+1 RESI
+2 DATE 1900
+2 PLAC Omaha, Nebraska, USA
+1 FAMC @F1@
+0 @I4@ INDI
+1 NAME Anna
+2 SURN smith
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 CHIL @I3@
+1 MARR
+2 DATE 1875
+2 PLAC Springfield, Illinois, USA
+0 TRLR
+`
+
+func decodeMigrationDoc(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(migrationTestGEDCOM))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestIndividualPath(t *testing.T) {
+	doc := decodeMigrationDoc(t)
+
+	path := IndividualPath(doc, "@I1@", nil)
+	if path == nil {
+		t.Fatal("IndividualPath() returned nil")
+	}
+	if path.Name != "John Smith" || path.Surname != "Smith" {
+		t.Errorf("Name/Surname = %q/%q", path.Name, path.Surname)
+	}
+
+	// BIRT 1850, MARR 1875 (family), RESI 1880, CENS 1890, DEAT 1910; BURI is undated.
+	wantStops := []gedcom.EventType{
+		gedcom.EventBirth, gedcom.EventMarriage, gedcom.EventResidence, gedcom.EventCensus, gedcom.EventDeath,
+	}
+	if len(path.Stops) != len(wantStops) {
+		t.Fatalf("Stops = %d, want %d", len(path.Stops), len(wantStops))
+	}
+	for i, want := range wantStops {
+		if path.Stops[i].EventType != want {
+			t.Errorf("Stops[%d] = %s, want %s", i, path.Stops[i].EventType, want)
+		}
+	}
+
+	// Chicago RESI -> chicago CENS is the same place and produces no segment.
+	if len(path.Segments) != 3 {
+		t.Fatalf("Segments = %d, want 3", len(path.Segments))
+	}
+	first := path.Segments[0]
+	if first.From.Place != "Boston, Massachusetts, USA" || first.To.Place != "Springfield, Illinois, USA" {
+		t.Errorf("first segment = %q -> %q", first.From.Place, first.To.Place)
+	}
+	if first.HasDistance {
+		t.Error("segment without coordinates on both ends should have no distance")
+	}
+	last := path.Segments[2]
+	if last.From.EventType != gedcom.EventCensus || last.To.EventType != gedcom.EventDeath {
+		t.Errorf("last segment = %s -> %s, want CENS -> DEAT", last.From.EventType, last.To.EventType)
+	}
+	if last.IndividualXRef != "@I1@" {
+		t.Errorf("IndividualXRef = %q", last.IndividualXRef)
+	}
+}
+
+func TestIndividualPath_SortDate(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 RESI
+2 DATE BET 1880 AND 1900
+2 PLAC Chicago
+2 SDATE 1895
+1 CENS
+2 DATE 1890
+2 PLAC Boston
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	path := IndividualPath(doc, "@I1@", nil)
+	if len(path.Stops) != 2 || path.Stops[0].Place != "Boston" || path.Stops[1].Place != "Chicago" {
+		t.Errorf("Stops = %+v, want Boston then Chicago by SDATE", path.Stops)
+	}
+}
+
+func TestIndividualPath_Distance(t *testing.T) {
+	doc := decodeMigrationDoc(t)
+
+	path := IndividualPath(doc, "@I1@", &MigrationOptions{
+		EventTypes: []gedcom.EventType{gedcom.EventBirth, gedcom.EventResidence},
+	})
+	if len(path.Segments) != 1 {
+		t.Fatalf("Segments = %d, want 1", len(path.Segments))
+	}
+	seg := path.Segments[0]
+	if !seg.HasDistance {
+		t.Fatal("Boston -> Chicago segment should have a distance")
+	}
+	// Boston to Chicago is roughly 1,370 km.
+	if seg.DistanceKm < 1300 || seg.DistanceKm > 1450 {
+		t.Errorf("DistanceKm = %.1f, want about 1370", seg.DistanceKm)
+	}
+	if path.TotalDistanceKm() != seg.DistanceKm {
+		t.Errorf("TotalDistanceKm() = %v, want %v", path.TotalDistanceKm(), seg.DistanceKm)
+	}
+}
+
+func TestIndividualPath_InvalidInput(t *testing.T) {
+	doc := decodeMigrationDoc(t)
+	if IndividualPath(nil, "@I1@", nil) != nil {
+		t.Error("nil document should return nil")
+	}
+	if IndividualPath(doc, "@F1@", nil) != nil {
+		t.Error("family XRef should return nil")
+	}
+	if IndividualPath(doc, "@MISSING@", nil) != nil {
+		t.Error("unknown XRef should return nil")
+	}
+}
+
+func TestMigrationsAndGroupBySurname(t *testing.T) {
+	doc := decodeMigrationDoc(t)
+
+	paths := Migrations(doc, nil)
+	if len(paths) != 4 {
+		t.Fatalf("Migrations() = %d paths, want 4", len(paths))
+	}
+
+	groups := GroupBySurname(paths)
+	if len(groups) != 2 {
+		t.Fatalf("GroupBySurname() = %d groups, want 2", len(groups))
+	}
+	if groups[0].Key != "Jones" || groups[1].Key != "Smith" {
+		t.Errorf("group keys = %q, %q", groups[0].Key, groups[1].Key)
+	}
+	// John, Tom, and Anna (SURN "smith") share a group.
+	if len(groups[1].Paths) != 3 {
+		t.Errorf("Smith group has %d paths, want 3", len(groups[1].Paths))
+	}
+
+	segments := groups[1].Segments()
+	if len(segments) != 4 {
+		t.Fatalf("Smith segments = %d, want 4", len(segments))
+	}
+	for i := 1; i < len(segments); i++ {
+		if segments[i].To.ParsedDate.Compare(segments[i-1].To.ParsedDate) < 0 {
+			t.Errorf("segments not in chronological order at %d", i)
+		}
+	}
+	if Migrations(nil, nil) != nil {
+		t.Error("Migrations(nil) should return nil")
+	}
+}
+
+func TestLineage(t *testing.T) {
+	doc := decodeMigrationDoc(t)
+
+	group := Lineage(doc, "@I1@", nil)
+	if group == nil {
+		t.Fatal("Lineage() returned nil")
+	}
+	if group.Key != "@I1@" || len(group.Paths) != 2 {
+		t.Fatalf("Lineage() = key %q with %d paths, want @I1@ with 2", group.Key, len(group.Paths))
+	}
+	if group.Paths[1].IndividualXRef != "@I3@" {
+		t.Errorf("descendant path = %q, want @I3@", group.Paths[1].IndividualXRef)
+	}
+	located := Lineage(doc, "@I1@", &MigrationOptions{
+		EventTypes: []gedcom.EventType{gedcom.EventBirth, gedcom.EventResidence},
+	})
+	if located.TotalDistanceKm() <= 0 {
+		t.Error("lineage should include the Boston -> Chicago distance")
+	}
+	if Lineage(doc, "@MISSING@", nil) != nil {
+		t.Error("unknown root should return nil")
+	}
+}
+
+func TestHaversineKm(t *testing.T) {
+	if d := HaversineKm(10, 20, 10, 20); d != 0 {
+		t.Errorf("same point distance = %v, want 0", d)
+	}
+	// A quarter of the equator.
+	want := math.Pi * earthRadiusKm / 2
+	if d := HaversineKm(0, 0, 0, 90); math.Abs(d-want) > 0.001 {
+		t.Errorf("HaversineKm(0,0,0,90) = %v, want %v", d, want)
+	}
+}
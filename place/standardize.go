@@ -0,0 +1,158 @@
+package place
+
+import (
+	"errors"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// OriginalPlaceNotePrefix begins the NOTE text Standardize records under a
+// rewritten PLAC when StandardizeOptions.PreserveOriginal is set.
+const OriginalPlaceNotePrefix = "Original place: "
+
+// StandardizeOptions configures Standardize.
+type StandardizeOptions struct {
+	// PreserveOriginal records each replaced PLAC payload in a NOTE
+	// subordinate of the rewritten PLAC ("Original place: <text>"), so the
+	// original spelling survives in the output file. NOTE is used because
+	// it is a valid PLAC substructure in every GEDCOM version, whereas
+	// PHRASE is not defined under PLAC.
+	//
+	// The note is written to the raw record tags only; typed entities have
+	// no field for place notes.
+	PreserveOriginal bool
+}
+
+// PlaceChange records one rewritten place.
+type PlaceChange struct {
+	// RecordXRef is the record containing the place.
+	RecordXRef string
+
+	// LineNumber is the source line of the PLAC tag, or 0 when the change
+	// was made to a typed entity of a record with no raw tags.
+	LineNumber int
+
+	// Original is the place before rewriting and Canonical the place after.
+	Original  string
+	Canonical string
+}
+
+// StandardizeReport describes what Standardize changed.
+type StandardizeReport struct {
+	// Changes lists each rewritten PLAC, in document order.
+	Changes []PlaceChange
+}
+
+// Standardize returns a copy of doc in which every place whose payload is
+// a key of mapping is rewritten to the mapped value. The input document is
+// never mutated. Use ClusterPlaces and CanonicalMapping to propose a
+// mapping, or supply one directly. If opts is nil, default options are used.
+//
+// Both the raw PLAC tags and the typed entity fields (Event.Place,
+// Event.PlaceDetail.Name, Attribute.Place, LDSOrdinance.Place) are
+// rewritten, so the result encodes and queries consistently. Matching is
+// exact: callers wanting case-insensitive matching should include each
+// spelling in the mapping, as CanonicalMapping does.
+//
+// Returns an error if doc is nil.
+func Standardize(doc *gedcom.Document, mapping map[string]string, opts *StandardizeOptions) (*gedcom.Document, *StandardizeReport, error) {
+	if doc == nil {
+		return nil, nil, errors.New("place: document is nil")
+	}
+	if opts == nil {
+		opts = &StandardizeOptions{}
+	}
+
+	out := doc.Clone()
+	report := &StandardizeReport{}
+	if len(mapping) == 0 {
+		return out, report, nil
+	}
+
+	for _, record := range out.Records {
+		if record == nil {
+			continue
+		}
+		if len(record.Tags) > 0 {
+			record.Tags = rewritePlaceTags(record, mapping, opts, report)
+			switch entity := record.Entity.(type) {
+			case *gedcom.Individual:
+				entity.Tags = record.Tags
+			case *gedcom.Family:
+				entity.Tags = record.Tags
+			}
+		}
+		rewriteEntityPlaces(record, mapping, len(record.Tags) == 0, report)
+	}
+
+	return out, report, nil
+}
+
+// rewritePlaceTags rewrites mapped PLAC payloads in record.Tags, optionally
+// inserting a NOTE with the original payload, and returns the new slice.
+func rewritePlaceTags(record *gedcom.Record, mapping map[string]string, opts *StandardizeOptions, report *StandardizeReport) []*gedcom.Tag {
+	result := make([]*gedcom.Tag, 0, len(record.Tags))
+	for _, tag := range record.Tags {
+		result = append(result, tag)
+		if tag == nil || tag.Tag != "PLAC" {
+			continue
+		}
+		canonical, ok := mapping[tag.Value]
+		if !ok || canonical == tag.Value {
+			continue
+		}
+		report.Changes = append(report.Changes, PlaceChange{
+			RecordXRef: record.XRef,
+			LineNumber: tag.LineNumber,
+			Original:   tag.Value,
+			Canonical:  canonical,
+		})
+		original := tag.Value
+		tag.Value = canonical
+		if opts.PreserveOriginal {
+			result = append(result, &gedcom.Tag{
+				Level: tag.Level + 1,
+				Tag:   "NOTE",
+				Value: OriginalPlaceNotePrefix + original,
+			})
+		}
+	}
+	return result
+}
+
+// rewriteEntityPlaces rewrites mapped places in the typed entity of
+// record. When reportChanges is true, each change is recorded; this is
+// used for entity-only records whose changes were not already reported
+// from tags.
+func rewriteEntityPlaces(record *gedcom.Record, mapping map[string]string, reportChanges bool, report *StandardizeReport) {
+	visitRecordPlaces(record, func(p *string) {
+		canonical, ok := mapping[*p]
+		if !ok || canonical == *p {
+			return
+		}
+		if reportChanges {
+			report.Changes = append(report.Changes, PlaceChange{
+				RecordXRef: record.XRef,
+				Original:   *p,
+				Canonical:  canonical,
+			})
+		}
+		*p = canonical
+	})
+
+	var events []*gedcom.Event
+	switch entity := record.Entity.(type) {
+	case *gedcom.Individual:
+		events = entity.Events
+	case *gedcom.Family:
+		events = entity.Events
+	}
+	for _, e := range events {
+		if e == nil || e.PlaceDetail == nil {
+			continue
+		}
+		if canonical, ok := mapping[e.PlaceDetail.Name]; ok {
+			e.PlaceDetail.Name = canonical
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// Package privacy redacts GEDCOM documents for publishing: it finds the
+// individuals presumed living and those marked private with RESN, and
+// anonymizes or removes them together with the notes, media, and family
+// details that would reveal them.
+//
+// Redact never modifies its input; it works on a copy and reports what it
+// redacted:
+//
+//	out, report, err := privacy.Redact(doc, &privacy.Options{
+//	    MaxAge: 110,
+//	    Mode:   privacy.ModeStrip,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d living, %d restricted, %d records removed\n",
+//	    len(report.Living), len(report.Restricted), len(report.Removed))
+//	err = encoder.Encode(w, out)
+//
+// For the in-place placeholder step used in transform pipelines, see
+// transforms.Privatize, which shares the IsLiving rule.
+package privacy
@@ -0,0 +1,47 @@
+package privacy
+
+import (
+	"time"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// DefaultMaxAge is the age beyond which an individual without a death
+// event is no longer presumed living.
+const DefaultMaxAge = 100
+
+// endOfLifeEvents are the events whose presence means an individual is
+// not living.
+var endOfLifeEvents = map[gedcom.EventType]bool{
+	gedcom.EventDeath:     true,
+	gedcom.EventBurial:    true,
+	gedcom.EventCremation: true,
+	gedcom.EventProbate:   true,
+}
+
+// IsLiving reports whether ind is presumed living as of asOf: it has no
+// death, burial, cremation, or probate event (negative assertions such as
+// "NO DEAT" do not count) and either no birth year or a birth year within
+// maxAge years of asOf. An unknown birth year errs on the side of privacy.
+// A maxAge of 0 or less means DefaultMaxAge.
+func IsLiving(ind *gedcom.Individual, asOf time.Time, maxAge int) bool {
+	if ind == nil {
+		return false
+	}
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	birthYear := 0
+	for _, event := range ind.Events {
+		if event == nil {
+			continue
+		}
+		if endOfLifeEvents[event.Type] && !event.IsNegative {
+			return false
+		}
+		if event.Type == gedcom.EventBirth && event.ParsedDate != nil && birthYear == 0 {
+			birthYear = event.ParsedDate.Year
+		}
+	}
+	return birthYear == 0 || asOf.Year()-birthYear <= maxAge
+}
@@ -0,0 +1,39 @@
+package privacy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+var testAsOf = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func born(year int) *gedcom.Event {
+	return &gedcom.Event{Type: gedcom.EventBirth, ParsedDate: &gedcom.Date{Year: year}}
+}
+
+func TestIsLiving(t *testing.T) {
+	tests := []struct {
+		name   string
+		ind    *gedcom.Individual
+		maxAge int
+		want   bool
+	}{
+		{"nil", nil, 0, false},
+		{"no events", &gedcom.Individual{}, 0, true},
+		{"born recently", &gedcom.Individual{Events: []*gedcom.Event{born(1980)}}, 0, true},
+		{"born beyond default max age", &gedcom.Individual{Events: []*gedcom.Event{born(1900)}}, 0, false},
+		{"born beyond max age", &gedcom.Individual{Events: []*gedcom.Event{born(1980)}}, 30, false},
+		{"died", &gedcom.Individual{Events: []*gedcom.Event{born(1980), {Type: gedcom.EventDeath}}}, 0, false},
+		{"buried", &gedcom.Individual{Events: []*gedcom.Event{{Type: gedcom.EventBurial}}}, 0, false},
+		{"negative death", &gedcom.Individual{Events: []*gedcom.Event{{Type: gedcom.EventDeath, IsNegative: true}}}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsLiving(tt.ind, testAsOf, tt.maxAge); got != tt.want {
+				t.Errorf("IsLiving() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
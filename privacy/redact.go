@@ -0,0 +1,357 @@
+package privacy
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// DefaultName is the name Redact gives redacted individuals.
+const DefaultName = "Living"
+
+var errNilDocument = errors.New("privacy: document is nil")
+
+// Mode selects what Redact does with the individuals it redacts.
+type Mode int
+
+const (
+	// ModeAnonymize replaces a redacted individual with a placeholder
+	// that keeps only its sex and family links, so the tree stays
+	// connected.
+	ModeAnonymize Mode = iota
+
+	// ModeStrip removes redacted individuals together with every link to
+	// them, and the families whose every spouse was removed.
+	ModeStrip
+)
+
+// String returns "anonymize" or "strip".
+func (m Mode) String() string {
+	if m == ModeStrip {
+		return "strip"
+	}
+	return "anonymize"
+}
+
+// Options configures Redact.
+type Options struct {
+	// MaxAge is the age beyond which an individual without a death event
+	// is no longer presumed living. Default (0): DefaultMaxAge.
+	MaxAge int
+
+	// AsOf is the date used to compute ages. Default (zero value): the
+	// time Redact is called.
+	AsOf time.Time
+
+	// Mode selects whether redacted individuals are anonymized or
+	// removed. Default: ModeAnonymize.
+	Mode Mode
+
+	// Name replaces the names of anonymized individuals.
+	// Default (""): DefaultName.
+	Name string
+
+	// KeepSurnames keeps the surname of anonymized individuals, as in
+	// "Living /Smith/", so the placeholders can still be told apart.
+	KeepSurnames bool
+
+	// KeepRestricted keeps the data marked RESN privacy or confidential.
+	// By default it is withheld: individuals with such a record-level
+	// RESN are redacted like living ones, and other records, events, and
+	// facts with one are removed.
+	KeepRestricted bool
+}
+
+// Report lists what Redact did, by XRef in document order.
+type Report struct {
+	// Living are the individuals redacted because they are presumed
+	// living.
+	Living []string
+
+	// Restricted are the individuals redacted because of their RESN.
+	Restricted []string
+
+	// Families are the families whose details were withheld because a
+	// spouse was redacted.
+	Families []string
+
+	// Removed are the records removed from the document: stripped
+	// individuals and the families left empty, restricted records, and
+	// notes and media objects that only redacted records referred to.
+	Removed []string
+}
+
+// privacyKeptIndividualTags are the level-1 structures an anonymized
+// individual keeps, so family links survive.
+var privacyKeptIndividualTags = map[string]bool{"SEX": true, "FAMC": true, "FAMS": true}
+
+// privacyKeptFamilyTags are the level-1 structures a family with a
+// redacted spouse keeps.
+var privacyKeptFamilyTags = map[string]bool{"HUSB": true, "WIFE": true, "CHIL": true}
+
+// Redact returns a copy of doc that is safe to publish, and a report of
+// what was redacted. doc is not modified.
+//
+// Individuals presumed living (see IsLiving) are redacted: anonymized or
+// removed depending on opts.Mode, taking their names, events, facts,
+// notes, sources, and media with them. Families with a redacted spouse
+// keep only their HUSB, WIFE, and CHIL links. Unless opts.KeepRestricted
+// is set, individuals with a record-level RESN of privacy or confidential
+// are redacted too, and the remaining data restricted beyond locked is
+// withheld as by gedcom.Document.View. Shared notes and media objects
+// that only redacted records referred to are removed.
+//
+// If opts is nil, default options are used.
+func Redact(doc *gedcom.Document, opts *Options) (*gedcom.Document, *Report, error) {
+	if doc == nil {
+		return nil, nil, errNilDocument
+	}
+	o := redactOptions(opts)
+	out := doc.Clone()
+	report := &Report{}
+	candidates := make(map[string]bool) // notes and media objects referred to by redacted records
+
+	redacted := redactIndividuals(out, &o, report, candidates)
+	reduceFamilies(out, redacted, report, candidates)
+
+	view := out.ViewWithOptions(redactViewOptions(&o, report))
+	out = view.Document()
+	report.Removed = view.HiddenXRefs()
+
+	report.Removed = append(report.Removed, removeOrphans(out, candidates)...)
+	return out, report, nil
+}
+
+// redactOptions returns opts, or the zero Options, with defaults filled in.
+func redactOptions(opts *Options) Options {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.AsOf.IsZero() {
+		o.AsOf = time.Now()
+	}
+	if o.Name == "" {
+		o.Name = DefaultName
+	}
+	return o
+}
+
+// redactIndividuals finds the living and restricted individuals of doc,
+// listing them in report and anonymizing them in ModeAnonymize, and adds
+// the notes and media objects they refer to to candidates. It returns the
+// XRefs of the individuals found.
+func redactIndividuals(doc *gedcom.Document, o *Options, report *Report, candidates map[string]bool) map[string]bool {
+	redacted := make(map[string]bool)
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		ind, ok := record.GetIndividual()
+		if !ok || ind == nil {
+			continue
+		}
+		switch {
+		case IsLiving(ind, o.AsOf, o.MaxAge):
+			report.Living = append(report.Living, record.XRef)
+		case !o.KeepRestricted && ind.Restriction.Level() >= gedcom.RestrictionPrivacy:
+			report.Restricted = append(report.Restricted, record.XRef)
+		default:
+			continue
+		}
+		redacted[record.XRef] = true
+		collectCandidates(doc, record, candidates)
+		if o.Mode == ModeAnonymize {
+			anonymizeIndividual(record, ind, o)
+		}
+	}
+	return redacted
+}
+
+// reduceFamilies strips the families with a redacted spouse down to their
+// links, listing them in report and adding the notes and media objects
+// they referred to to candidates.
+func reduceFamilies(doc *gedcom.Document, redacted map[string]bool, report *Report, candidates map[string]bool) {
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		fam, ok := record.GetFamily()
+		if !ok || fam == nil || !hasRedactedSpouse(fam, redacted) {
+			continue
+		}
+		if len(fam.Events) == 0 && len(fam.SourceCitations) == 0 && !hasOtherTags(record.Tags, privacyKeptFamilyTags) {
+			continue
+		}
+		collectCandidates(doc, record, candidates)
+		reduceFamily(record, fam)
+		report.Families = append(report.Families, record.XRef)
+	}
+}
+
+// redactViewOptions returns the view that withholds restricted records
+// and, in ModeStrip, the individuals report lists.
+func redactViewOptions(o *Options, report *Report) *gedcom.ViewOptions {
+	viewOpts := &gedcom.ViewOptions{MaxRestriction: gedcom.RestrictionLocked}
+	if o.KeepRestricted {
+		viewOpts.MaxRestriction = gedcom.RestrictionConfidential
+	}
+	if o.Mode == ModeStrip {
+		viewOpts.Hide = append(append(viewOpts.Hide, report.Living...), report.Restricted...)
+		viewOpts.PropagateToFamilies = true
+	}
+	return viewOpts
+}
+
+// anonymizeIndividual replaces ind with a placeholder keeping its sex and
+// family links, in both the raw tags and the entity.
+func anonymizeIndividual(record *gedcom.Record, ind *gedcom.Individual, o *Options) {
+	name := &gedcom.PersonalName{Full: o.Name, Given: o.Name}
+	if surname := surnameOf(ind); o.KeepSurnames && surname != "" {
+		name.Full = o.Name + " /" + surname + "/"
+		name.Surname = surname
+	}
+	if len(record.Tags) > 0 {
+		tags := []*gedcom.Tag{{Level: 1, Tag: "NAME", Value: name.Full}}
+		record.Tags = append(tags, keepBlocks(record.Tags, privacyKeptIndividualTags)...)
+	}
+	*ind = gedcom.Individual{
+		XRef:             ind.XRef,
+		Names:            []*gedcom.PersonalName{name},
+		Sex:              ind.Sex,
+		ChildInFamilies:  ind.ChildInFamilies,
+		SpouseInFamilies: ind.SpouseInFamilies,
+		Tags:             record.Tags,
+	}
+}
+
+// surnameOf returns the surname of the first name of ind.
+func surnameOf(ind *gedcom.Individual) string {
+	if len(ind.Names) == 0 || ind.Names[0] == nil {
+		return ""
+	}
+	name := ind.Names[0]
+	if name.Surname != "" {
+		return name.Surname
+	}
+	if start := strings.Index(name.Full, "/"); start >= 0 {
+		if end := strings.Index(name.Full[start+1:], "/"); end >= 0 {
+			return strings.TrimSpace(name.Full[start+1 : start+1+end])
+		}
+	}
+	return ""
+}
+
+// hasRedactedSpouse reports whether a spouse of fam was redacted.
+func hasRedactedSpouse(fam *gedcom.Family, redacted map[string]bool) bool {
+	for _, xref := range fam.Partners() {
+		if redacted[xref] {
+			return true
+		}
+	}
+	return false
+}
+
+// reduceFamily reduces fam to its spouse and child links.
+func reduceFamily(record *gedcom.Record, fam *gedcom.Family) {
+	if len(record.Tags) > 0 {
+		record.Tags = keepBlocks(record.Tags, privacyKeptFamilyTags)
+	}
+	var spouses []*gedcom.FamilySpouse
+	for _, spouse := range fam.Spouses {
+		if spouse != nil && spouse.Tag != "ASSO" {
+			spouses = append(spouses, spouse)
+		}
+	}
+	*fam = gedcom.Family{
+		XRef:     fam.XRef,
+		Husband:  fam.Husband,
+		Wife:     fam.Wife,
+		Spouses:  spouses,
+		Children: fam.Children,
+		Tags:     record.Tags,
+	}
+}
+
+// collectCandidates adds the note, shared note, and media object records
+// that record refers to to candidates.
+func collectCandidates(doc *gedcom.Document, record *gedcom.Record, candidates map[string]bool) {
+	gedcom.Visit(record, func(xref string) {
+		target := doc.GetRecord(xref)
+		if target == nil {
+			return
+		}
+		switch target.Type {
+		case gedcom.RecordTypeNote, gedcom.RecordTypeSharedNote, gedcom.RecordTypeMedia:
+			candidates[xref] = true
+		}
+	})
+}
+
+// removeOrphans removes the candidate records that no other record or the
+// header refers to any more, and returns their XRefs in document order.
+func removeOrphans(doc *gedcom.Document, candidates map[string]bool) []string {
+	if len(candidates) == 0 {
+		return nil
+	}
+	referenced := make(map[string]bool)
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		gedcom.Visit(record, func(xref string) {
+			if xref != record.XRef {
+				referenced[xref] = true
+			}
+		})
+	}
+	if doc.Header != nil {
+		for _, tag := range doc.Header.Tags {
+			if tag != nil {
+				referenced[tag.Value] = true
+			}
+		}
+	}
+
+	var removed []string
+	for _, record := range append([]*gedcom.Record(nil), doc.Records...) {
+		if record == nil || !candidates[record.XRef] || referenced[record.XRef] {
+			continue
+		}
+		if doc.RemoveRecord(record.XRef) != nil {
+			removed = append(removed, record.XRef)
+		}
+	}
+	return removed
+}
+
+// keepBlocks returns the level-1 structures of tags whose tag is in keep,
+// with their subordinates.
+func keepBlocks(tags []*gedcom.Tag, keep map[string]bool) []*gedcom.Tag {
+	var kept []*gedcom.Tag
+	keeping := false
+	for _, tag := range tags {
+		if tag == nil {
+			continue
+		}
+		if tag.Level <= 1 {
+			keeping = keep[tag.Tag]
+		}
+		if keeping {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
+
+// hasOtherTags reports whether tags has a level-1 structure not in keep.
+func hasOtherTags(tags []*gedcom.Tag, keep map[string]bool) bool {
+	for _, tag := range tags {
+		if tag != nil && tag.Level == 1 && !keep[tag.Tag] {
+			return true
+		}
+	}
+	return false
+}
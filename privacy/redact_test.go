@@ -0,0 +1,212 @@
+package privacy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const testGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /Smith/
+1 SEX M
+1 BIRT
+2 DATE 1920
+1 DEAT
+2 DATE 1990
+1 OCCU Farmer
+2 RESN privacy
+1 FAMS @F1@
+1 NOTE @N2@
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 SEX F
+1 BIRT
+2 DATE 1925
+1 DEAT
+2 DATE 2000
+1 FAMS @F1@
+0 @I3@ INDI
+1 NAME Alice /Smith/
+1 SEX F
+1 BIRT
+2 DATE 1955
+2 PLAC Springfield
+1 FAMC @F1@
+1 FAMS @F2@
+1 NOTE @N1@
+1 NOTE @N2@
+1 OBJE @O1@
+0 @I4@ INDI
+1 NAME Bob /Brown/
+1 SEX M
+1 RESN confidential
+1 BIRT
+2 DATE 1900
+1 DEAT
+2 DATE 1980
+1 FAMS @F2@
+0 @I5@ INDI
+1 NAME Carol /Brown/
+1 BIRT
+2 DATE 1985
+1 FAMC @F2@
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 CHIL @I3@
+1 MARR
+2 DATE 1950
+0 @F2@ FAM
+1 HUSB @I4@
+1 WIFE @I3@
+1 CHIL @I5@
+1 MARR
+2 DATE 1980
+0 @N1@ NOTE Alice's phone number
+0 @N2@ NOTE Family bible
+0 @O1@ OBJE
+1 FILE alice.jpg
+0 TRLR
+`
+
+func decodeTest(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(testGEDCOM))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func encodeTest(t *testing.T, doc *gedcom.Document) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	return buf.String()
+}
+
+func TestRedact(t *testing.T) {
+	doc := decodeTest(t)
+	out, report, err := Redact(doc, &Options{AsOf: testAsOf})
+	if err != nil {
+		t.Fatalf("Redact() error = %v", err)
+	}
+
+	checks := []struct{ name, got, want string }{
+		{"Living", strings.Join(report.Living, " "), "@I3@ @I5@"},
+		{"Restricted", strings.Join(report.Restricted, " "), "@I4@"},
+		{"Families", strings.Join(report.Families, " "), "@F2@"},
+		{"Removed", strings.Join(report.Removed, " "), "@N1@ @O1@"},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("report.%s = %q, want %q", c.name, c.got, c.want)
+		}
+	}
+
+	alice := out.GetIndividual("@I3@")
+	if alice.Names[0].Full != DefaultName || alice.Sex != "F" || len(alice.Events) != 0 || len(alice.Notes) != 0 {
+		t.Errorf("living individual not anonymized: %+v", alice)
+	}
+	if bob := out.GetIndividual("@I4@"); bob.Names[0].Full != DefaultName || bob.Restriction != "" {
+		t.Errorf("confidential individual not anonymized: %+v", bob)
+	}
+	if fam := out.GetFamily("@F2@"); fam.Husband != "@I4@" || fam.Wife != "@I3@" || len(fam.Events) != 0 {
+		t.Errorf("family of redacted spouses = %+v", fam)
+	}
+	if fam := out.GetFamily("@F1@"); len(fam.Events) != 1 || len(fam.Children) != 1 {
+		t.Errorf("family of deceased spouses was changed: %+v", fam)
+	}
+	if john := out.GetIndividual("@I1@"); john.Names[0].Full != "John /Smith/" || len(john.Events) != 2 {
+		t.Errorf("deceased individual was changed: %+v", john)
+	}
+	if out.GetRecord("@N2@") == nil {
+		t.Error("note shared with a deceased individual was removed")
+	}
+
+	encoded := encodeTest(t, out)
+	for _, want := range []string{
+		"0 @I3@ INDI\n1 NAME Living\n1 SEX F\n1 FAMC @F1@\n1 FAMS @F2@\n0 @I4@",
+		"0 @F2@ FAM\n1 HUSB @I4@\n1 WIFE @I3@\n1 CHIL @I5@\n0 @N2@",
+	} {
+		if !strings.Contains(encoded, want) {
+			t.Errorf("encoded output:\n%s\nwant it to contain\n%s", encoded, want)
+		}
+	}
+	for _, leak := range []string{"Alice", "Springfield", "Bob", "phone", "alice.jpg", "Farmer"} {
+		if strings.Contains(encoded, leak) {
+			t.Errorf("encoded output leaks %q", leak)
+		}
+	}
+
+	if doc.GetIndividual("@I3@").Names[0].Full != "Alice /Smith/" || doc.GetRecord("@N1@") == nil {
+		t.Error("source document was mutated")
+	}
+}
+
+func TestRedact_Strip(t *testing.T) {
+	out, report, err := Redact(decodeTest(t), &Options{AsOf: testAsOf, Mode: ModeStrip})
+	if err != nil {
+		t.Fatalf("Redact() error = %v", err)
+	}
+	if got := strings.Join(report.Removed, " "); got != "@I3@ @I4@ @I5@ @F2@ @N1@ @O1@" {
+		t.Errorf("Removed = %q", got)
+	}
+	if fam := out.GetFamily("@F1@"); len(fam.Children) != 0 || len(fam.Events) != 1 {
+		t.Errorf("family of deceased spouses = %+v, want the stripped child unlinked", fam)
+	}
+	if encoded := encodeTest(t, out); strings.Contains(encoded, "@I3@") {
+		t.Errorf("encoded output still links to a stripped individual:\n%s", encoded)
+	}
+}
+
+func TestRedact_Options(t *testing.T) {
+	out, report, err := Redact(decodeTest(t), &Options{AsOf: testAsOf, KeepRestricted: true, KeepSurnames: true})
+	if err != nil {
+		t.Fatalf("Redact() error = %v", err)
+	}
+	if len(report.Restricted) != 0 {
+		t.Errorf("Restricted = %v, want none with KeepRestricted", report.Restricted)
+	}
+	if bob := out.GetIndividual("@I4@"); bob.Names[0].Full != "Bob /Brown/" {
+		t.Errorf("restricted individual redacted with KeepRestricted: %+v", bob)
+	}
+	if encoded := encodeTest(t, out); !strings.Contains(encoded, "1 OCCU Farmer\n2 RESN privacy\n") {
+		t.Errorf("privacy-marked OCCU withheld with KeepRestricted:\n%s", encoded)
+	}
+	alice := out.GetIndividual("@I3@")
+	if name := alice.Names[0]; name.Full != "Living /Smith/" || name.Surname != "Smith" {
+		t.Errorf("KeepSurnames name = %+v", name)
+	}
+
+	_, report, _ = Redact(decodeTest(t), &Options{AsOf: testAsOf, MaxAge: 50, Name: "Private"})
+	if got := strings.Join(report.Living, " "); got != "@I5@" {
+		t.Errorf("MaxAge 50: Living = %q, want @I5@", got)
+	}
+}
+
+func TestRedact_Nil(t *testing.T) {
+	if _, _, err := Redact(nil, nil); err == nil {
+		t.Error("Redact(nil) should return an error")
+	}
+	out, report, err := Redact(&gedcom.Document{}, nil)
+	if err != nil || out == nil || len(report.Living) != 0 {
+		t.Errorf("Redact(empty) = %v, %+v, %v", out, report, err)
+	}
+}
+
+func TestMode_String(t *testing.T) {
+	if ModeAnonymize.String() != "anonymize" || ModeStrip.String() != "strip" {
+		t.Error("Mode.String() mismatch")
+	}
+}
@@ -0,0 +1,353 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// Style is a citation style.
+type Style string
+
+// Supported citation styles.
+const (
+	// StyleEvidenceExplained follows Elizabeth Shown Mills' Evidence
+	// Explained, which adds the repository and call number to both the
+	// source list entry and the reference note.
+	StyleEvidenceExplained Style = "evidence-explained"
+
+	// StyleChicago follows the notes-bibliography system of the Chicago
+	// Manual of Style.
+	StyleChicago Style = "chicago"
+)
+
+// BibliographyOptions configures Bibliography and WriteBibliography.
+type BibliographyOptions struct {
+	// Style is the citation style. Default: StyleEvidenceExplained.
+	Style Style
+
+	// Markup is the output format. Default: MarkupText.
+	Markup Markup
+
+	// Heading is the title of the references section written by
+	// WriteBibliography. Default: "References".
+	Heading string
+
+	// OmitCitations leaves the reference notes for individual citations out
+	// of the written section, listing only the sources.
+	OmitCitations bool
+}
+
+// BibliographyEntry is one source of the bibliography.
+type BibliographyEntry struct {
+	// SourceXRef identifies the Source record.
+	SourceXRef string
+
+	// Text is the formatted source list entry.
+	Text string
+
+	// Citations are the distinct citations of the source, in the order
+	// they first appear in the document.
+	Citations []CitationEntry
+}
+
+// CitationEntry is one distinct citation of a source: the records that
+// cite the same page (or the source as a whole, if Page is empty).
+type CitationEntry struct {
+	// Page is the citation's PAGE, the location within the source.
+	Page string
+
+	// Text is the formatted full reference note.
+	Text string
+
+	// CitedBy are the XRefs of the records making the citation, in
+	// document order.
+	CitedBy []string
+}
+
+// Bibliography returns an entry for every Source record of doc, sorted by
+// author and title, with the citations made to it from individuals,
+// families, media objects, and shared notes, including those on their
+// events, attributes, and associations. Embedded citations, which have no
+// Source record, are not included. Returns nil if doc is nil.
+func Bibliography(doc *gedcom.Document, opts *BibliographyOptions) []BibliographyEntry {
+	if doc == nil {
+		return nil
+	}
+	opts = bibliographyDefaults(opts)
+
+	var entries []BibliographyEntry
+	index := make(map[string]int)
+	for _, src := range doc.Sources() {
+		if _, dup := index[src.XRef]; dup {
+			continue
+		}
+		index[src.XRef] = len(entries)
+		entries = append(entries, BibliographyEntry{
+			SourceXRef: src.XRef,
+			Text:       FormatSource(doc, src, opts.Style, opts.Markup),
+		})
+	}
+
+	pages := make(map[string]map[string]int) // source XRef -> page -> citation index
+	cite := func(recordXRef string, citations []*gedcom.SourceCitation) {
+		for _, c := range citations {
+			if c == nil || c.SourceXRef == "" {
+				continue
+			}
+			i, ok := index[c.SourceXRef]
+			if !ok {
+				continue
+			}
+			entry := &entries[i]
+			page := strings.TrimSpace(c.Page)
+			if pages[c.SourceXRef] == nil {
+				pages[c.SourceXRef] = make(map[string]int)
+			}
+			j, seen := pages[c.SourceXRef][page]
+			if !seen {
+				j = len(entry.Citations)
+				pages[c.SourceXRef][page] = j
+				entry.Citations = append(entry.Citations, CitationEntry{
+					Page: page,
+					Text: FormatCitation(doc, c, opts.Style, opts.Markup),
+				})
+			}
+			citedBy := entry.Citations[j].CitedBy
+			if len(citedBy) == 0 || citedBy[len(citedBy)-1] != recordXRef {
+				entry.Citations[j].CitedBy = append(citedBy, recordXRef)
+			}
+		}
+	}
+	for _, record := range doc.Records {
+		if record != nil {
+			forEachCitationList(record, func(citations []*gedcom.SourceCitation) {
+				cite(record.XRef, citations)
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(a, b int) bool {
+		return sortKey(doc.GetSource(entries[a].SourceXRef)) < sortKey(doc.GetSource(entries[b].SourceXRef))
+	})
+	return entries
+}
+
+// WriteBibliography writes the bibliography of doc to w as a references
+// section: a heading followed by the source list entries, each followed by
+// the reference notes of its citations unless opts.OmitCitations is set.
+func WriteBibliography(w io.Writer, doc *gedcom.Document, opts *BibliographyOptions) error {
+	opts = bibliographyDefaults(opts)
+	entries := Bibliography(doc, opts)
+
+	bw := bufio.NewWriter(w)
+	heading := opts.Markup.escape(opts.Heading)
+	switch opts.Markup {
+	case MarkupMarkdown:
+		fmt.Fprintf(bw, "## %s\n\n", heading)
+		for _, entry := range entries {
+			fmt.Fprintf(bw, "- %s\n", entry.Text)
+			if !opts.OmitCitations {
+				for _, c := range entry.Citations {
+					fmt.Fprintf(bw, "  - %s\n", c.Text)
+				}
+			}
+		}
+	case MarkupHTML:
+		fmt.Fprintf(bw, "<h2>%s</h2>\n<ul>\n", heading)
+		for _, entry := range entries {
+			fmt.Fprintf(bw, "<li>%s", entry.Text)
+			if !opts.OmitCitations && len(entry.Citations) > 0 {
+				bw.WriteString("\n<ul>\n")
+				for _, c := range entry.Citations {
+					fmt.Fprintf(bw, "<li>%s</li>\n", c.Text)
+				}
+				bw.WriteString("</ul>\n")
+			}
+			bw.WriteString("</li>\n")
+		}
+		bw.WriteString("</ul>\n")
+	default:
+		fmt.Fprintf(bw, "%s\n\n", heading)
+		for _, entry := range entries {
+			fmt.Fprintf(bw, "%s\n", entry.Text)
+			if !opts.OmitCitations {
+				for _, c := range entry.Citations {
+					fmt.Fprintf(bw, "    %s\n", c.Text)
+				}
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// FormatSource formats src as a source list (bibliography) entry:
+//
+//	Evidence Explained: Author. Title. Publication. Repository, call number.
+//	Chicago:            Author. Title. Publication.
+//
+// Missing parts are left out; a source without a title is shown as
+// "[Untitled source]".
+func FormatSource(doc *gedcom.Document, src *gedcom.Source, style Style, markup Markup) string {
+	if src == nil {
+		return ""
+	}
+	parts := []string{
+		markup.escape(clean(src.Author)),
+		markup.emphasize(title(src)),
+		markup.escape(clean(src.Publication)),
+	}
+	if style != StyleChicago {
+		parts = append(parts, markup.escape(repository(doc, src)))
+	}
+	return joinParts(parts, ". ") + "."
+}
+
+// FormatCitation formats c as a full reference note:
+//
+//	Evidence Explained: Author, Title (Publication), page; Repository, call number.
+//	Chicago:            Author, Title (Publication), page.
+//
+// The source is looked up in doc by c.SourceXRef; an embedded citation is
+// shown by its description.
+func FormatCitation(doc *gedcom.Document, c *gedcom.SourceCitation, style Style, markup Markup) string {
+	if c == nil {
+		return ""
+	}
+	var src *gedcom.Source
+	if doc != nil && c.SourceXRef != "" {
+		src = doc.GetSource(c.SourceXRef)
+	}
+	if src == nil {
+		text := clean(c.InlineText)
+		if text == "" {
+			text = "[Unknown source]"
+		}
+		return joinParts([]string{markup.escape(text), markup.escape(clean(c.Page))}, ", ") + "."
+	}
+
+	note := markup.emphasize(title(src))
+	if pub := clean(src.Publication); pub != "" {
+		note += " (" + markup.escape(pub) + ")"
+	}
+	note = joinParts([]string{markup.escape(clean(src.Author)), note, markup.escape(clean(c.Page))}, ", ")
+	if style != StyleChicago {
+		note = joinParts([]string{note, markup.escape(repository(doc, src))}, "; ")
+	}
+	return note + "."
+}
+
+// bibliographyDefaults returns a copy of opts with defaults filled in.
+func bibliographyDefaults(opts *BibliographyOptions) *BibliographyOptions {
+	o := BibliographyOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Style == "" {
+		o.Style = StyleEvidenceExplained
+	}
+	if o.Heading == "" {
+		o.Heading = "References"
+	}
+	return &o
+}
+
+// forEachCitationList calls fn with each list of source citations held by
+// record's entity.
+func forEachCitationList(record *gedcom.Record, fn func([]*gedcom.SourceCitation)) {
+	switch e := record.Entity.(type) {
+	case *gedcom.Individual:
+		fn(e.SourceCitations)
+		for _, event := range e.Events {
+			if event != nil {
+				fn(event.SourceCitations)
+			}
+		}
+		for _, attr := range e.Attributes {
+			if attr != nil {
+				fn(attr.SourceCitations)
+			}
+		}
+		for _, asso := range e.Associations {
+			if asso != nil {
+				fn(asso.SourceCitations)
+			}
+		}
+	case *gedcom.Family:
+		fn(e.SourceCitations)
+		for _, event := range e.Events {
+			if event != nil {
+				fn(event.SourceCitations)
+			}
+		}
+	case *gedcom.MediaObject:
+		fn(e.SourceCitations)
+	case *gedcom.SharedNote:
+		fn(e.SourceCitations)
+	}
+}
+
+// repository describes where src is held: the name of its first
+// repository and, if given, the call number.
+func repository(doc *gedcom.Document, src *gedcom.Source) string {
+	for _, link := range src.AllRepositoryLinks() {
+		var name string
+		switch {
+		case link.Inline != nil:
+			name = link.Inline.Name
+		case doc != nil && link.XRef != "":
+			if repo := doc.GetRepository(link.XRef); repo != nil {
+				name = repo.Name
+			}
+		}
+		name = clean(name)
+		if len(link.CallNumbers) == 0 || clean(link.CallNumbers[0]) == "" {
+			if name != "" {
+				return name
+			}
+			continue
+		}
+		if name == "" {
+			return "Call number " + clean(link.CallNumbers[0])
+		}
+		return name + ", call number " + clean(link.CallNumbers[0])
+	}
+	return ""
+}
+
+// title returns the source's title, or a placeholder if it has none.
+func title(src *gedcom.Source) string {
+	if t := clean(src.Title); t != "" {
+		return t
+	}
+	return "[Untitled source]"
+}
+
+// sortKey orders sources by author, then title.
+func sortKey(src *gedcom.Source) string {
+	if src == nil {
+		return ""
+	}
+	return strings.ToLower(clean(src.Author) + "\x00" + clean(src.Title))
+}
+
+// clean collapses whitespace, including continuation newlines, and drops
+// trailing periods so parts can be punctuated consistently.
+func clean(s string) string {
+	return strings.TrimRight(strings.Join(strings.Fields(s), " "), ".")
+}
+
+// joinParts joins the non-empty parts with sep.
+func joinParts(parts []string, sep string) string {
+	var kept []string
+	for _, p := range parts {
+		if p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
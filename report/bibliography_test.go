@@ -0,0 +1,159 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const bibliographyGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE 1 JAN 1850
+2 SOUR @S1@
+3 PAGE p. 12, entry 4
+1 DEAT
+2 SOUR @S1@
+3 PAGE p. 40
+1 OCCU Farmer
+2 SOUR @S2@
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 SOUR @S1@
+2 PAGE p. 12, entry 4
+1 SOUR Family bible, privately held
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 MARR
+2 SOUR @S3@
+0 @S1@ SOUR
+1 AUTH Springfield Parish.
+1 TITL Parish Registers, 1840-1900
+1 PUBL Springfield: Diocesan Press, 1901
+1 REPO @R1@
+2 CALN FHL film 123456
+0 @S2@ SOUR
+1 TITL 1880 U.S. Census
+1 REPO
+2 CALN T9-0001
+0 @S3@ SOUR
+1 AUTH Adams, Ann
+1 TITL Notes on *Springfield* Families
+0 @R1@ REPO
+1 NAME Family History Library
+0 TRLR
+`
+
+func decodeBibliographyDoc(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(bibliographyGEDCOM))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestBibliography_EvidenceExplained(t *testing.T) {
+	entries := Bibliography(decodeBibliographyDoc(t), nil)
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+
+	// Sorted by author then title; a source without an author sorts first.
+	wantOrder := []string{"@S2@", "@S3@", "@S1@"}
+	for i, xref := range wantOrder {
+		if entries[i].SourceXRef != xref {
+			t.Errorf("entry %d = %s, want %s", i, entries[i].SourceXRef, xref)
+		}
+	}
+
+	parish := entries[2]
+	wantText := "Springfield Parish. Parish Registers, 1840-1900. Springfield: Diocesan Press, 1901. Family History Library, call number FHL film 123456."
+	if parish.Text != wantText {
+		t.Errorf("Text = %q\nwant %q", parish.Text, wantText)
+	}
+	if len(parish.Citations) != 2 {
+		t.Fatalf("got %d citations, want 2: %+v", len(parish.Citations), parish.Citations)
+	}
+	first := parish.Citations[0]
+	wantNote := "Springfield Parish, Parish Registers, 1840-1900 (Springfield: Diocesan Press, 1901), p. 12, entry 4; Family History Library, call number FHL film 123456."
+	if first.Page != "p. 12, entry 4" || first.Text != wantNote {
+		t.Errorf("first citation = %+v\nwant text %q", first, wantNote)
+	}
+	if len(first.CitedBy) != 2 || first.CitedBy[0] != "@I1@" || first.CitedBy[1] != "@I2@" {
+		t.Errorf("CitedBy = %v", first.CitedBy)
+	}
+
+	if census := entries[0]; census.Text != "1880 U.S. Census. Call number T9-0001." || len(census.Citations) != 1 {
+		t.Errorf("census entry = %+v", census)
+	}
+	if marr := entries[1].Citations; len(marr) != 1 || marr[0].CitedBy[0] != "@F1@" {
+		t.Errorf("family citation = %+v", marr)
+	}
+}
+
+func TestBibliography_Chicago(t *testing.T) {
+	entries := Bibliography(decodeBibliographyDoc(t), &BibliographyOptions{Style: StyleChicago, Markup: MarkupMarkdown})
+	parish := entries[2]
+	if want := "Springfield Parish. *Parish Registers, 1840-1900*. Springfield: Diocesan Press, 1901."; parish.Text != want {
+		t.Errorf("Text = %q, want %q", parish.Text, want)
+	}
+	if want := "Springfield Parish, *Parish Registers, 1840-1900* (Springfield: Diocesan Press, 1901), p. 40."; parish.Citations[1].Text != want {
+		t.Errorf("citation = %q, want %q", parish.Citations[1].Text, want)
+	}
+	if want := `Adams, Ann. *Notes on \*Springfield\* Families*.`; entries[1].Text != want {
+		t.Errorf("escaped Text = %q, want %q", entries[1].Text, want)
+	}
+	if Bibliography(nil, nil) != nil {
+		t.Error("Bibliography(nil) should return nil")
+	}
+}
+
+func TestWriteBibliography(t *testing.T) {
+	doc := decodeBibliographyDoc(t)
+
+	var buf bytes.Buffer
+	if err := WriteBibliography(&buf, doc, nil); err != nil {
+		t.Fatalf("WriteBibliography() error = %v", err)
+	}
+	text := buf.String()
+	if !strings.HasPrefix(text, "References\n\n1880 U.S. Census.") || !strings.Contains(text, "\n    Springfield Parish, Parish Registers") {
+		t.Errorf("text output:\n%s", text)
+	}
+
+	buf.Reset()
+	opts := &BibliographyOptions{Markup: MarkupHTML, Heading: "Sources & Notes", OmitCitations: true}
+	if err := WriteBibliography(&buf, doc, opts); err != nil {
+		t.Fatalf("WriteBibliography() error = %v", err)
+	}
+	html := buf.String()
+	if !strings.HasPrefix(html, "<h2>Sources &amp; Notes</h2>\n<ul>\n") || strings.Contains(html, "p. 12") ||
+		!strings.Contains(html, "<li>Adams, Ann. <i>Notes on *Springfield* Families</i>.</li>") {
+		t.Errorf("HTML output:\n%s", html)
+	}
+
+	buf.Reset()
+	if err := WriteBibliography(&buf, doc, &BibliographyOptions{Markup: MarkupMarkdown}); err != nil {
+		t.Fatalf("WriteBibliography() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "## References\n\n- *1880 U.S. Census*. Call number T9-0001.\n") || !strings.Contains(buf.String(), "\n  - Springfield Parish") {
+		t.Errorf("Markdown output:\n%s", buf.String())
+	}
+}
+
+func TestFormatCitation_Embedded(t *testing.T) {
+	c := &gedcom.SourceCitation{InlineText: "Family bible, privately held", Page: "flyleaf"}
+	if got := FormatCitation(nil, c, StyleEvidenceExplained, MarkupText); got != "Family bible, privately held, flyleaf." {
+		t.Errorf("FormatCitation() = %q", got)
+	}
+	if got := FormatCitation(nil, &gedcom.SourceCitation{SourceXRef: "@S9@"}, StyleChicago, MarkupText); got != "[Unknown source]." {
+		t.Errorf("FormatCitation() for a missing source = %q", got)
+	}
+}
@@ -0,0 +1,256 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// MaxChartGenerations caps PedigreeOptions.Generations. A pedigree has up
+// to 2^n individuals in generation n, so deeper charts are not drawable
+// and would only cost memory.
+const MaxChartGenerations = 16
+
+// PedigreeOptions configures Pedigree.
+type PedigreeOptions struct {
+	// Generations is the number of generations of the chart, counting the
+	// root individual as the first. Default: 5. Values above
+	// MaxChartGenerations are capped.
+	Generations int
+
+	// Names formats the individuals' names.
+	// Default: gedcom.DefaultNameFormatter.
+	Names *gedcom.NameFormatter
+}
+
+// ChartPerson is one position of a pedigree: a known ancestor, or a
+// placeholder for a parent the document does not record.
+type ChartPerson struct {
+	// XRef identifies the individual. Empty for a placeholder.
+	XRef string
+
+	// Name is the formatted name. Empty for a placeholder.
+	Name string
+
+	// Sex is the individual's SEX value. A placeholder has the sex of the
+	// position, "M" for a father and "F" for a mother.
+	Sex string
+
+	// Birth and Death are the raw dates of the first birth and death
+	// events, and BirthYear and DeathYear their parsed years (0 if unknown).
+	Birth     string
+	Death     string
+	BirthYear int
+	DeathYear int
+
+	// Generation is 0 for the root individual, 1 for the parents, and so on.
+	Generation int
+
+	// Position is the index within the generation, 0 to 2^Generation-1,
+	// counting from the father's father's side. Fathers have even and
+	// mothers odd positions.
+	Position int
+
+	// Ahnentafel is the Ahnentafel number: 1 for the root, 2n for the
+	// father and 2n+1 for the mother of number n.
+	Ahnentafel int
+
+	// Placeholder marks a parent the document does not record.
+	Placeholder bool
+
+	// RepeatOf is the Ahnentafel number at which the individual first
+	// appears when they appear more than once (pedigree collapse), or 0.
+	RepeatOf int
+
+	// Father and Mother are the parents in the next generation, nil beyond
+	// the last generation of the chart or above a placeholder.
+	Father *ChartPerson
+	Mother *ChartPerson
+
+	// KnownAncestors counts the known individuals above this one within
+	// the chart, and PossibleAncestors the positions they could fill.
+	KnownAncestors    int
+	PossibleAncestors int
+}
+
+// Span returns the share of a fan chart's arc taken by the person, as
+// fractions from 0 to 1: the father's side runs from 0 to 0.5 and the
+// mother's from 0.5 to 1. The root spans the whole arc.
+func (p *ChartPerson) Span() (start, end float64) {
+	size := float64(int(1) << p.Generation)
+	return float64(p.Position) / size, float64(p.Position+1) / size
+}
+
+// Completeness returns the share of the positions above p that are known
+// ancestors, from 0 to 1. It returns 1 if the chart ends at p.
+func (p *ChartPerson) Completeness() float64 {
+	if p.PossibleAncestors == 0 {
+		return 1
+	}
+	return float64(p.KnownAncestors) / float64(p.PossibleAncestors)
+}
+
+// GenerationStats counts the known ancestors of one generation.
+type GenerationStats struct {
+	Generation int
+	Known      int
+	Possible   int
+}
+
+// Chart is the ancestor tree of an individual, laid out for pedigree and
+// fan charts.
+type Chart struct {
+	// Root is the individual the chart is drawn for.
+	Root *ChartPerson
+
+	// Generations is the number of generations of the chart.
+	Generations int
+
+	// Stats holds the known and possible ancestors of each generation,
+	// starting with the root's.
+	Stats []GenerationStats
+}
+
+// People returns the chart's people, placeholders included, in Ahnentafel
+// order: generation by generation, fathers before mothers.
+func (c *Chart) People() []*ChartPerson {
+	var people []*ChartPerson
+	queue := []*ChartPerson{c.Root}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if p == nil {
+			continue
+		}
+		people = append(people, p)
+		queue = append(queue, p.Father, p.Mother)
+	}
+	return people
+}
+
+// Pedigree returns the ancestor tree of the individual xref for drawing a
+// pedigree or fan chart. Parents come from the individual's first birth
+// family: the first FAMC with no PEDI or PEDI birth, otherwise the first
+// FAMC. A parent the family does not name, or a known individual with no
+// family, is shown by a placeholder, whose own parents are left out.
+func Pedigree(doc *gedcom.Document, xref string, opts *PedigreeOptions) (*Chart, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("report: no document")
+	}
+	ind := doc.GetIndividual(xref)
+	if ind == nil {
+		return nil, fmt.Errorf("report: individual %s not found", xref)
+	}
+	o := PedigreeOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Generations <= 0 {
+		o.Generations = 5
+	}
+	o.Generations = min(o.Generations, MaxChartGenerations)
+	if o.Names == nil {
+		o.Names = gedcom.DefaultNameFormatter
+	}
+
+	b := &pedigreeBuilder{
+		doc:   doc,
+		opts:  &o,
+		seen:  make(map[string]int),
+		stats: make([]GenerationStats, o.Generations),
+	}
+	for g := range b.stats {
+		b.stats[g] = GenerationStats{Generation: g, Possible: 1 << g}
+	}
+	return &Chart{
+		Root:        b.person(ind, "", 0, 0),
+		Generations: o.Generations,
+		Stats:       b.stats,
+	}, nil
+}
+
+// pedigreeBuilder holds the state of one Pedigree call.
+type pedigreeBuilder struct {
+	doc   *gedcom.Document
+	opts  *PedigreeOptions
+	seen  map[string]int // XRef -> first Ahnentafel number
+	stats []GenerationStats
+}
+
+// person builds the chart position (generation, position) for ind, or a
+// placeholder of the given sex if ind is nil, with its ancestors.
+func (b *pedigreeBuilder) person(ind *gedcom.Individual, sex string, generation, position int) *ChartPerson {
+	p := &ChartPerson{
+		Generation:  generation,
+		Position:    position,
+		Ahnentafel:  1<<generation + position,
+		Placeholder: ind == nil,
+		Sex:         sex,
+	}
+	if ind == nil {
+		return p
+	}
+	b.stats[generation].Known++
+
+	p.XRef = ind.XRef
+	p.Name = b.opts.Names.FormatIndividual(ind)
+	if ind.Sex != "" {
+		p.Sex = ind.Sex
+	}
+	if e := ind.BirthEvent(); e != nil {
+		p.Birth, p.BirthYear = e.Date, year(e.ParsedDate)
+	}
+	if e := ind.DeathEvent(); e != nil {
+		p.Death, p.DeathYear = e.Date, year(e.ParsedDate)
+	}
+	if first, ok := b.seen[ind.XRef]; ok {
+		p.RepeatOf = first
+	} else {
+		b.seen[ind.XRef] = p.Ahnentafel
+	}
+
+	if generation+1 == b.opts.Generations {
+		return p
+	}
+	father, mother := b.parents(ind)
+	p.Father = b.person(father, "M", generation+1, 2*position)
+	p.Mother = b.person(mother, "F", generation+1, 2*position+1)
+	above := b.opts.Generations - generation - 1
+	p.PossibleAncestors = 1<<(above+1) - 2
+	for _, parent := range []*ChartPerson{p.Father, p.Mother} {
+		if !parent.Placeholder {
+			p.KnownAncestors += 1 + parent.KnownAncestors
+		}
+	}
+	return p
+}
+
+// parents returns the father and mother of ind from its birth family.
+func (b *pedigreeBuilder) parents(ind *gedcom.Individual) (father, mother *gedcom.Individual) {
+	var fam *gedcom.Family
+	for _, link := range ind.ChildInFamilies {
+		f := b.doc.GetFamily(link.FamilyXRef)
+		if f == nil {
+			continue
+		}
+		if link.Pedigree.IsBirth() {
+			fam = f
+			break
+		}
+		if fam == nil {
+			fam = f
+		}
+	}
+	if fam == nil {
+		return nil, nil
+	}
+	return b.doc.GetIndividual(fam.Husband), b.doc.GetIndividual(fam.Wife)
+}
+
+// year returns the year of d, or 0 if d is nil.
+func year(d *gedcom.Date) int {
+	if d == nil {
+		return 0
+	}
+	return d.Year
+}
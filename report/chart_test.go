@@ -0,0 +1,159 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const chartGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME Ann /Smith/
+1 SEX F
+1 BIRT
+2 DATE 4 MAY 1900
+1 FAMC @F2@
+2 PEDI adopted
+1 FAMC @F1@
+0 @I2@ INDI
+1 NAME John /Smith/
+1 SEX M
+1 BIRT
+2 DATE ABT 1870
+1 DEAT
+2 DATE 1931
+1 FAMC @F3@
+0 @I3@ INDI
+1 NAME Mary /Jones/
+1 SEX F
+1 FAMC @F3@
+0 @I4@ INDI
+1 NAME Tom /Smith/
+1 SEX M
+0 @I5@ INDI
+1 NAME Foster /Parent/
+0 @F1@ FAM
+1 HUSB @I2@
+1 WIFE @I3@
+1 CHIL @I1@
+0 @F2@ FAM
+1 HUSB @I5@
+1 CHIL @I1@
+0 @F3@ FAM
+1 HUSB @I4@
+1 CHIL @I2@
+1 CHIL @I3@
+0 TRLR
+`
+
+func decodeChartDoc(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(chartGEDCOM))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestPedigree(t *testing.T) {
+	chart, err := Pedigree(decodeChartDoc(t), "@I1@", &PedigreeOptions{Generations: 3})
+	if err != nil {
+		t.Fatalf("Pedigree() error = %v", err)
+	}
+
+	root := chart.Root
+	if root.Name != "Ann Smith" || root.Birth != "4 MAY 1900" || root.BirthYear != 1900 || root.Ahnentafel != 1 {
+		t.Errorf("root = %+v", root)
+	}
+	// The birth family is preferred over the earlier adoptive one.
+	father := root.Father
+	if father.XRef != "@I2@" || father.Ahnentafel != 2 || father.Position != 0 || father.BirthYear != 1870 || father.DeathYear != 1931 {
+		t.Errorf("father = %+v", father)
+	}
+	if root.Mother.XRef != "@I3@" || root.Mother.Ahnentafel != 3 {
+		t.Errorf("mother = %+v", root.Mother)
+	}
+
+	// Siblings I2 and I3 share a father (pedigree collapse) and have no
+	// recorded mother.
+	if gf := father.Father; gf.XRef != "@I4@" || gf.RepeatOf != 0 || gf.Father != nil {
+		t.Errorf("father's father = %+v", gf)
+	}
+	if gf := root.Mother.Father; gf.XRef != "@I4@" || gf.RepeatOf != 4 || gf.Ahnentafel != 6 {
+		t.Errorf("mother's father = %+v", gf)
+	}
+	if gm := father.Mother; !gm.Placeholder || gm.Sex != "F" || gm.XRef != "" || gm.Ahnentafel != 5 {
+		t.Errorf("father's mother = %+v", gm)
+	}
+
+	if start, end := root.Mother.Father.Span(); start != 0.5 || end != 0.75 {
+		t.Errorf("Span() = %v, %v; want 0.5, 0.75", start, end)
+	}
+	if start, end := root.Span(); start != 0 || end != 1 {
+		t.Errorf("root Span() = %v, %v", start, end)
+	}
+
+	if root.KnownAncestors != 4 || root.PossibleAncestors != 6 {
+		t.Errorf("root ancestors = %d of %d, want 4 of 6", root.KnownAncestors, root.PossibleAncestors)
+	}
+	if got := father.Completeness(); got != 0.5 {
+		t.Errorf("father Completeness() = %v, want 0.5", got)
+	}
+	want := []GenerationStats{{0, 1, 1}, {1, 2, 2}, {2, 2, 4}}
+	for i, s := range chart.Stats {
+		if s != want[i] {
+			t.Errorf("Stats[%d] = %+v, want %+v", i, s, want[i])
+		}
+	}
+
+	people := chart.People()
+	if len(people) != 7 {
+		t.Fatalf("People() returned %d, want 7", len(people))
+	}
+	for i, p := range people {
+		if p.Ahnentafel != i+1 {
+			t.Errorf("People()[%d].Ahnentafel = %d", i, p.Ahnentafel)
+		}
+	}
+}
+
+func TestPedigree_Options(t *testing.T) {
+	doc := decodeChartDoc(t)
+
+	chart, err := Pedigree(doc, "@I4@", nil)
+	if err != nil {
+		t.Fatalf("Pedigree() error = %v", err)
+	}
+	// A person with no family gets placeholder parents and nothing above.
+	if chart.Generations != 5 || !chart.Root.Father.Placeholder || chart.Root.Father.Father != nil {
+		t.Errorf("chart = %+v, father = %+v", chart, chart.Root.Father)
+	}
+	if chart.Root.PossibleAncestors != 30 || chart.Root.Completeness() != 0 {
+		t.Errorf("root ancestors = %d of %d", chart.Root.KnownAncestors, chart.Root.PossibleAncestors)
+	}
+
+	chart, err = Pedigree(doc, "@I1@", &PedigreeOptions{
+		Generations: 1,
+		Names:       gedcom.NewNameFormatter(gedcom.NameFormatSurnameGiven),
+	})
+	if err != nil {
+		t.Fatalf("Pedigree() error = %v", err)
+	}
+	if chart.Root.Name != "Smith, Ann" || chart.Root.Father != nil || chart.Root.Completeness() != 1 {
+		t.Errorf("root = %+v", chart.Root)
+	}
+
+	if chart, _ := Pedigree(doc, "@I1@", &PedigreeOptions{Generations: 100}); chart.Generations != MaxChartGenerations {
+		t.Errorf("Generations = %d, want %d", chart.Generations, MaxChartGenerations)
+	}
+	if _, err := Pedigree(doc, "@X1@", nil); err == nil {
+		t.Error("Pedigree() of a missing individual should fail")
+	}
+	if _, err := Pedigree(nil, "@I1@", nil); err == nil {
+		t.Error("Pedigree(nil) should fail")
+	}
+}
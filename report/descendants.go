@@ -0,0 +1,188 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// DescendantOptions configures Descendants.
+type DescendantOptions struct {
+	// Generations is the number of generations of the chart, counting the
+	// root individual as the first. Default: 5. Values above
+	// MaxChartGenerations are capped.
+	Generations int
+
+	// IncludeSpouses adds each family's other spouse to the chart.
+	IncludeSpouses bool
+
+	// CollapseDuplicates shows the descendants of an individual only the
+	// first time they appear, as happens when cousins marry; later
+	// appearances are marked Collapsed.
+	CollapseDuplicates bool
+
+	// Names formats the individuals' names.
+	// Default: gedcom.DefaultNameFormatter.
+	Names *gedcom.NameFormatter
+}
+
+// DescendantPerson is an individual of a descendant chart, or the spouse
+// of one.
+type DescendantPerson struct {
+	// XRef identifies the individual.
+	XRef string
+
+	// Name is the formatted name.
+	Name string
+
+	// Sex is the individual's SEX value.
+	Sex string
+
+	// Birth and Death are the raw dates of the first birth and death
+	// events, and BirthYear and DeathYear their parsed years (0 if unknown).
+	Birth     string
+	Death     string
+	BirthYear int
+	DeathYear int
+
+	// Generation is 0 for the root individual, 1 for the children, and so
+	// on. A spouse has the generation of the person they married.
+	Generation int
+
+	// Repeat marks a descendant already shown elsewhere in the chart.
+	Repeat bool
+
+	// Collapsed marks a repeat whose families are left out because
+	// DescendantOptions.CollapseDuplicates is set.
+	Collapsed bool
+
+	// Families are the families in which the individual is a spouse, in
+	// FAMS order. Nil for a spouse, the last generation of the chart, and
+	// a collapsed repeat.
+	Families []*DescendantFamily
+}
+
+// DescendantFamily is one marriage or partnership of a descendant with the
+// children born to it.
+type DescendantFamily struct {
+	// XRef identifies the Family record.
+	XRef string
+
+	// Spouse is the other partner, or nil if the family has none or
+	// DescendantOptions.IncludeSpouses is not set.
+	Spouse *DescendantPerson
+
+	// Marriage is the raw date of the first marriage event, and
+	// MarriageYear its parsed year (0 if unknown).
+	Marriage     string
+	MarriageYear int
+
+	// Children are the family's children, in CHIL order.
+	Children []*DescendantPerson
+}
+
+// DescendantChart is the descendant tree of an individual.
+type DescendantChart struct {
+	// Root is the individual the chart is drawn for.
+	Root *DescendantPerson
+
+	// Generations is the number of generations of the chart.
+	Generations int
+
+	// Count is the number of descendants in the chart, the root and
+	// repeats included and spouses not.
+	Count int
+}
+
+// Descendants returns the descendant tree of the individual xref for
+// drawing a descendant chart, grouped by family: each person lists the
+// families they are a spouse in, and each family its children.
+func Descendants(doc *gedcom.Document, xref string, opts *DescendantOptions) (*DescendantChart, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("report: no document")
+	}
+	ind := doc.GetIndividual(xref)
+	if ind == nil {
+		return nil, fmt.Errorf("report: individual %s not found", xref)
+	}
+	o := DescendantOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Generations <= 0 {
+		o.Generations = 5
+	}
+	o.Generations = min(o.Generations, MaxChartGenerations)
+	if o.Names == nil {
+		o.Names = gedcom.DefaultNameFormatter
+	}
+
+	b := &descendantBuilder{doc: doc, opts: &o, seen: make(map[string]bool)}
+	chart := &DescendantChart{Generations: o.Generations}
+	chart.Root = b.person(ind, 0)
+	chart.Count = b.count
+	return chart, nil
+}
+
+// descendantBuilder holds the state of one Descendants call.
+type descendantBuilder struct {
+	doc   *gedcom.Document
+	opts  *DescendantOptions
+	seen  map[string]bool
+	count int
+}
+
+// person builds the chart entry for the descendant ind and their families.
+func (b *descendantBuilder) person(ind *gedcom.Individual, generation int) *DescendantPerson {
+	p := b.individual(ind, generation)
+	b.count++
+	p.Repeat = b.seen[ind.XRef]
+	b.seen[ind.XRef] = true
+	if p.Repeat && b.opts.CollapseDuplicates {
+		p.Collapsed = true
+		return p
+	}
+	if generation+1 == b.opts.Generations {
+		return p
+	}
+
+	for _, fam := range ind.SpouseFamilies(b.doc) {
+		f := &DescendantFamily{XRef: fam.XRef}
+		if e := fam.Marriage(); e != nil {
+			f.Marriage, f.MarriageYear = e.Date, year(e.ParsedDate)
+		}
+		if b.opts.IncludeSpouses {
+			spouseXRef := fam.Husband
+			if spouseXRef == ind.XRef {
+				spouseXRef = fam.Wife
+			}
+			if spouse := b.doc.GetIndividual(spouseXRef); spouse != nil {
+				f.Spouse = b.individual(spouse, generation)
+			}
+		}
+		for _, childXRef := range fam.Children {
+			if child := b.doc.GetIndividual(childXRef); child != nil {
+				f.Children = append(f.Children, b.person(child, generation+1))
+			}
+		}
+		p.Families = append(p.Families, f)
+	}
+	return p
+}
+
+// individual returns the chart entry for ind without families.
+func (b *descendantBuilder) individual(ind *gedcom.Individual, generation int) *DescendantPerson {
+	p := &DescendantPerson{
+		XRef:       ind.XRef,
+		Name:       b.opts.Names.FormatIndividual(ind),
+		Sex:        ind.Sex,
+		Generation: generation,
+	}
+	if e := ind.BirthEvent(); e != nil {
+		p.Birth, p.BirthYear = e.Date, year(e.ParsedDate)
+	}
+	if e := ind.DeathEvent(); e != nil {
+		p.Death, p.DeathYear = e.Date, year(e.ParsedDate)
+	}
+	return p
+}
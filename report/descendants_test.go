@@ -0,0 +1,138 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// Cousins C and D, grandchildren of R, marry and have a child E.
+const descendantGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @R@ INDI
+1 NAME Robert /Root/
+1 SEX M
+1 FAMS @F1@
+0 @W@ INDI
+1 NAME Wilma /West/
+1 SEX F
+1 FAMS @F1@
+0 @A@ INDI
+1 NAME Alan /Root/
+1 BIRT
+2 DATE 1900
+1 FAMS @F2@
+0 @B@ INDI
+1 NAME Beth /Root/
+1 FAMS @F3@
+0 @C@ INDI
+1 NAME Carl /Root/
+1 FAMS @F4@
+0 @D@ INDI
+1 NAME Dora /Hill/
+1 FAMS @F4@
+0 @E@ INDI
+1 NAME Eve /Root/
+0 @F1@ FAM
+1 HUSB @R@
+1 WIFE @W@
+1 MARR
+2 DATE 12 JUN 1895
+1 CHIL @A@
+1 CHIL @B@
+0 @F2@ FAM
+1 HUSB @A@
+1 CHIL @C@
+0 @F3@ FAM
+1 WIFE @B@
+1 CHIL @D@
+0 @F4@ FAM
+1 HUSB @C@
+1 WIFE @D@
+1 CHIL @E@
+0 TRLR
+`
+
+func decodeDescendantDoc(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(descendantGEDCOM))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestDescendants(t *testing.T) {
+	doc := decodeDescendantDoc(t)
+	chart, err := Descendants(doc, "@R@", &DescendantOptions{IncludeSpouses: true})
+	if err != nil {
+		t.Fatalf("Descendants() error = %v", err)
+	}
+	root := chart.Root
+	if root.Name != "Robert Root" || len(root.Families) != 1 {
+		t.Fatalf("root = %+v", root)
+	}
+	fam := root.Families[0]
+	if fam.XRef != "@F1@" || fam.Marriage != "12 JUN 1895" || fam.MarriageYear != 1895 {
+		t.Errorf("family = %+v", fam)
+	}
+	if fam.Spouse == nil || fam.Spouse.XRef != "@W@" || fam.Spouse.Generation != 0 || fam.Spouse.Families != nil {
+		t.Errorf("spouse = %+v", fam.Spouse)
+	}
+	if len(fam.Children) != 2 || fam.Children[0].XRef != "@A@" || fam.Children[0].BirthYear != 1900 || fam.Children[0].Generation != 1 {
+		t.Fatalf("children = %+v", fam.Children)
+	}
+
+	// E is reached through both cousins and is shown twice.
+	c := fam.Children[0].Families[0].Children[0]
+	d := fam.Children[1].Families[0].Children[0]
+	if c.XRef != "@C@" || d.XRef != "@D@" {
+		t.Fatalf("grandchildren = %s, %s", c.XRef, d.XRef)
+	}
+	if c.Families[0].Spouse.XRef != "@D@" || d.Families[0].Spouse.XRef != "@C@" {
+		t.Errorf("cousin spouses = %+v, %+v", c.Families[0].Spouse, d.Families[0].Spouse)
+	}
+	first, second := c.Families[0].Children[0], d.Families[0].Children[0]
+	if first.Repeat || !second.Repeat || second.Collapsed {
+		t.Errorf("E = %+v then %+v", first, second)
+	}
+	if chart.Count != 7 {
+		t.Errorf("Count = %d, want 7", chart.Count)
+	}
+}
+
+func TestDescendants_Options(t *testing.T) {
+	doc := decodeDescendantDoc(t)
+
+	chart, err := Descendants(doc, "@R@", &DescendantOptions{CollapseDuplicates: true})
+	if err != nil {
+		t.Fatalf("Descendants() error = %v", err)
+	}
+	b := chart.Root.Families[0].Children[1]
+	d := b.Families[0].Children[0]
+	if b.Families[0].Spouse != nil || d.Families[0].Spouse != nil {
+		t.Error("spouses should be left out without IncludeSpouses")
+	}
+	// D's family is still listed, but E, already shown under C, is collapsed.
+	if e := d.Families[0].Children[0]; !e.Repeat || !e.Collapsed || e.Families != nil {
+		t.Errorf("E = %+v", e)
+	}
+
+	chart, err = Descendants(doc, "@R@", &DescendantOptions{Generations: 2})
+	if err != nil {
+		t.Fatalf("Descendants() error = %v", err)
+	}
+	if a := chart.Root.Families[0].Children[0]; a.Families != nil || chart.Count != 3 {
+		t.Errorf("Generations 2: child families = %+v, Count = %d", a.Families, chart.Count)
+	}
+
+	if _, err := Descendants(doc, "@X@", nil); err == nil {
+		t.Error("Descendants() of a missing individual should fail")
+	}
+	if _, err := Descendants(nil, "@R@", nil); err == nil {
+		t.Error("Descendants(nil) should fail")
+	}
+}
@@ -0,0 +1,37 @@
+// Package report renders GEDCOM documents as the parts of a written
+// family history.
+//
+// Bibliography collects every Source record of a document together with
+// the distinct citations made to it and formats them in a citation style,
+// Evidence Explained or Chicago, as a source list entry for the source and
+// a full reference note for each citation. WriteBibliography writes the
+// result as a references section in plain text, Markdown, or HTML.
+//
+// IndividualNarrative tells the story of one individual's life as a
+// paragraph, one sentence per event in date order, with citation markers
+// keyed to reference notes. Sentences come from Templates, which map event
+// types to sentence templates with placeholders such as {date} and
+// {place} (see ParseTemplate). English templates are built in; other
+// languages are added with RegisterTemplates, and an application can pass
+// its own Templates to override any sentence.
+//
+// Pedigree lays out an individual's ancestors for pedigree and fan charts:
+// a tree of ChartPerson values with generation, position, Ahnentafel
+// number, and the known share of each branch, with placeholders for
+// parents the document does not record, ready for an SVG or canvas
+// renderer to draw without walking families itself. Descendants does the
+// same for descendant charts, grouping each person's children under the
+// family they were born to.
+//
+// Example usage:
+//
+//	doc, _ := decoder.Decode(f)
+//	err := report.WriteBibliography(os.Stdout, doc, &report.BibliographyOptions{
+//	    Style:  report.StyleChicago,
+//	    Markup: report.MarkupMarkdown,
+//	})
+//
+// GEDCOM stores authors, titles, and publication facts as free text, so
+// the formatter arranges and punctuates them but does not rewrite them;
+// the result is only as consistent as the source records it is given.
+package report
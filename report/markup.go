@@ -0,0 +1,50 @@
+package report
+
+import (
+	"html"
+	"strings"
+)
+
+// Markup selects the output format of a report.
+type Markup int
+
+const (
+	// MarkupText writes plain text; titles are not emphasized.
+	MarkupText Markup = iota
+
+	// MarkupMarkdown writes Markdown, with titles in *italics*.
+	MarkupMarkdown
+
+	// MarkupHTML writes an HTML fragment, with titles in <i> elements.
+	MarkupHTML
+)
+
+// markdownEscaper escapes the characters Markdown would read as formatting.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, `*`, `\*`, `_`, `\_`, "`", "\\`", `[`, `\[`, `]`, `\]`, `<`, `\<`,
+)
+
+// escape escapes text from the document for m.
+func (m Markup) escape(s string) string {
+	switch m {
+	case MarkupMarkdown:
+		return markdownEscaper.Replace(s)
+	case MarkupHTML:
+		return html.EscapeString(s)
+	}
+	return s
+}
+
+// emphasize escapes s and marks it as a title.
+func (m Markup) emphasize(s string) string {
+	if s == "" {
+		return ""
+	}
+	switch m {
+	case MarkupMarkdown:
+		return "*" + m.escape(s) + "*"
+	case MarkupHTML:
+		return "<i>" + m.escape(s) + "</i>"
+	}
+	return s
+}
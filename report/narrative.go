@@ -0,0 +1,475 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// Templates are the sentence templates of one language, used to narrate
+// events. Templates may use these placeholders:
+//
+//	subject  the individual's name in the first sentence, then a pronoun
+//	name     the individual's full name
+//	given    the individual's given name
+//	surname  the individual's surname
+//	date     the date with its preposition (see DatePhrase)
+//	place    the event place
+//	age      the individual's age in years at the event
+//	cause    the cause of the event (CAUS)
+//	value    the attribute value, such as the occupation
+//	type     the event's TYPE, or its name from EventNames
+//	spouse   the other spouse's name, for family events
+//	sources  citation markers for the event's sources
+type Templates struct {
+	// Events maps event and attribute types to sentence templates.
+	Events map[gedcom.EventType]string
+
+	// Fallback is the template for event types not in Events.
+	Fallback string
+
+	// EventNames are the names of event types for the {type} placeholder
+	// of events without a TYPE; unnamed types use the lower-case tag.
+	EventNames map[gedcom.EventType]string
+
+	// Pronouns map a SEX value ("M", "F") to the pronoun used as the
+	// subject after the first sentence. For other values, the given name
+	// is used.
+	Pronouns map[string]string
+
+	// DatePhrase renders a date with its preposition, e.g. "on 3 May 1850"
+	// or "in 1850". If nil, the date is localized without a preposition.
+	DatePhrase func(date *gedcom.Date, lang string) string
+}
+
+// EnglishTemplates are the built-in English templates, registered as "en".
+var EnglishTemplates = &Templates{
+	Events: map[gedcom.EventType]string{
+		gedcom.EventBirth:            "{Subject} was born[ {date}][ in {place}].[{sources}]",
+		gedcom.EventChristening:      "{Subject} was christened[ {date}][ in {place}].[{sources}]",
+		gedcom.EventBaptism:          "{Subject} was baptized[ {date}][ in {place}].[{sources}]",
+		gedcom.EventAdultChristening: "{Subject} was christened as an adult[ {date}][ in {place}].[{sources}]",
+		gedcom.EventBlessing:         "{Subject} was blessed[ {date}][ in {place}].[{sources}]",
+		gedcom.EventAdoption:         "{Subject} was adopted[ {date}][ in {place}].[{sources}]",
+		gedcom.EventBarMitzvah:       "{Subject} celebrated a bar mitzvah[ {date}][ in {place}].[{sources}]",
+		gedcom.EventBasMitzvah:       "{Subject} celebrated a bat mitzvah[ {date}][ in {place}].[{sources}]",
+		gedcom.EventConfirmation:     "{Subject} was confirmed[ {date}][ in {place}].[{sources}]",
+		gedcom.EventFirstCommunion:   "{Subject} received first communion[ {date}][ in {place}].[{sources}]",
+		gedcom.EventGraduation:       "{Subject} graduated[ {date}][ in {place}].[{sources}]",
+		gedcom.EventOrdination:       "{Subject} was ordained[ {date}][ in {place}].[{sources}]",
+		gedcom.EventCensus:           "{Subject} was enumerated in the census[ {date}][ in {place}].[{sources}]",
+		gedcom.EventResidence:        "{Subject} lived[ in {place}][ {date}].[{sources}]",
+		gedcom.EventEmigration:       "{Subject} emigrated[ from {place}][ {date}].[{sources}]",
+		gedcom.EventImmigration:      "{Subject} immigrated[ to {place}][ {date}].[{sources}]",
+		gedcom.EventNaturalization:   "{Subject} was naturalized[ {date}][ in {place}].[{sources}]",
+		gedcom.EventOccupation:       "{Subject} worked as {value}[ {date}][ in {place}].[{sources}]",
+		gedcom.EventRetirement:       "{Subject} retired[ {date}][ in {place}].[{sources}]",
+		gedcom.EventWill:             "{Subject} made a will[ {date}][ in {place}].[{sources}]",
+		gedcom.EventDeath:            "{Subject} died[ {date}][ in {place}][ at the age of {age}][ of {cause}].[{sources}]",
+		gedcom.EventBurial:           "{Subject} was buried[ {date}][ in {place}].[{sources}]",
+		gedcom.EventCremation:        "{Subject} was cremated[ {date}][ in {place}].[{sources}]",
+		gedcom.EventEngagement:       "{Subject} became engaged[ to {spouse}][ {date}][ in {place}].[{sources}]",
+		gedcom.EventMarriage:         "{Subject} married[ {spouse}][ {date}][ in {place}].[{sources}]",
+		gedcom.EventDivorce:          "{Subject} was divorced[ from {spouse}][ {date}][ in {place}].[{sources}]",
+		"EDUC":                       "{Subject} was educated[ at {value}][ {date}][ in {place}].[{sources}]",
+	},
+	Fallback: "{Type}[ {date}][ in {place}][: {value}].[{sources}]",
+	EventNames: map[gedcom.EventType]string{
+		gedcom.EventProbate:            "probate",
+		gedcom.EventAnnulment:          "annulment",
+		gedcom.EventMarriageBann:       "marriage banns",
+		gedcom.EventMarriageContract:   "marriage contract",
+		gedcom.EventMarriageLicense:    "marriage license",
+		gedcom.EventMarriageSettlement: "marriage settlement",
+		gedcom.EventDivorceFiling:      "divorce filing",
+		"CAST":                         "caste",
+		"DSCR":                         "physical description",
+		"IDNO":                         "identification number",
+		"NATI":                         "nationality",
+		"NCHI":                         "number of children",
+		"NMR":                          "number of marriages",
+		"PROP":                         "property",
+		"RELI":                         "religion",
+		"SSN":                          "social security number",
+		"TITL":                         "title",
+	},
+	Pronouns:   map[string]string{"M": "he", "F": "she"},
+	DatePhrase: englishDatePhrase,
+}
+
+// englishDatePhrase writes "on" before full dates and "in" before months
+// and years; dates with a modifier ("about 1850") stand alone.
+func englishDatePhrase(date *gedcom.Date, lang string) string {
+	text := date.FormatLocalized(lang)
+	switch {
+	case date.IsPhrase || date.Modifier != gedcom.ModifierNone:
+		return text
+	case date.Day > 0:
+		return "on " + text
+	}
+	return "in " + text
+}
+
+// templateCatalog holds the registered templates by lower-case language tag.
+var templateCatalog = struct {
+	sync.RWMutex
+	byLang map[string]*Templates
+}{byLang: map[string]*Templates{"en": EnglishTemplates}}
+
+// RegisterTemplates registers the templates for a language (a BCP 47 tag
+// such as "de" or "pt-BR"), replacing any registered before.
+func RegisterTemplates(lang string, t *Templates) {
+	templateCatalog.Lock()
+	defer templateCatalog.Unlock()
+	templateCatalog.byLang[strings.ToLower(lang)] = t
+}
+
+// TemplatesFor returns the templates for a language, falling back from a
+// regional tag to its base language ("pt-BR" to "pt"), then to English.
+func TemplatesFor(lang string) *Templates {
+	lang = strings.ToLower(lang)
+	candidates := []string{lang}
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		candidates = append(candidates, lang[:i])
+	}
+	templateCatalog.RLock()
+	defer templateCatalog.RUnlock()
+	for _, l := range candidates {
+		if t := templateCatalog.byLang[l]; t != nil {
+			return t
+		}
+	}
+	return templateCatalog.byLang["en"]
+}
+
+// NarrativeOptions configures IndividualNarrative.
+type NarrativeOptions struct {
+	// Language selects the templates (see TemplatesFor) and the language of
+	// month names. Default: "en".
+	Language string
+
+	// Templates overrides the templates registered for Language. Event
+	// types it has no template for use its Fallback.
+	Templates *Templates
+
+	// Style is the citation style of the reference notes.
+	// Default: StyleEvidenceExplained.
+	Style Style
+
+	// Markup is the output format. Default: MarkupText.
+	Markup Markup
+
+	// Names formats the names of the individual and their spouses.
+	// Default: gedcom.DefaultNameFormatter.
+	Names *gedcom.NameFormatter
+}
+
+// Narrative is the story of one individual's life events.
+type Narrative struct {
+	// Sentences holds one sentence per event, in date order.
+	Sentences []string
+
+	// Text is the sentences joined into a paragraph.
+	Text string
+
+	// Notes are the reference notes for the citation markers in the
+	// sentences: marker n refers to Notes[n-1].
+	Notes []string
+}
+
+// IndividualNarrative narrates the events and attributes of the individual
+// with the given XRef, and the events of the families in which they are a
+// spouse, in date order. Undated events keep their place after the event
+// they follow in the file. Negative assertions (GEDCOM 7.0 NO) are skipped.
+func IndividualNarrative(doc *gedcom.Document, xref string, opts *NarrativeOptions) (*Narrative, error) {
+	if doc == nil {
+		return nil, errors.New("report: document is nil")
+	}
+	ind := doc.GetIndividual(xref)
+	if ind == nil {
+		return nil, fmt.Errorf("report: individual %s not found", xref)
+	}
+	o := NarrativeOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Language == "" {
+		o.Language = "en"
+	}
+	if o.Style == "" {
+		o.Style = StyleEvidenceExplained
+	}
+	if o.Names == nil {
+		o.Names = gedcom.DefaultNameFormatter
+	}
+	templates := o.Templates
+	if templates == nil {
+		templates = TemplatesFor(o.Language)
+	}
+
+	n := &narrator{
+		doc:       doc,
+		ind:       ind,
+		opts:      &o,
+		templates: templates,
+		parsed:    make(map[string]*Template),
+		notes:     make(map[string]int),
+		result:    &Narrative{},
+	}
+	for i, f := range n.facts() {
+		sentence, err := n.sentence(f, i == 0)
+		if err != nil {
+			return nil, err
+		}
+		if sentence != "" {
+			n.result.Sentences = append(n.result.Sentences, sentence)
+		}
+	}
+	n.result.Text = strings.Join(n.result.Sentences, " ")
+	return n.result, nil
+}
+
+// fact is an event or attribute to narrate.
+type fact struct {
+	kind      gedcom.EventType
+	date      string
+	parsed    *gedcom.Date
+	order     *gedcom.Date // parsed, or the event's sort date
+	place     string
+	value     string
+	typeName  string
+	cause     string
+	age       string
+	spouse    string
+	citations []*gedcom.SourceCitation
+}
+
+// narrator renders the narrative of one individual.
+type narrator struct {
+	doc       *gedcom.Document
+	ind       *gedcom.Individual
+	opts      *NarrativeOptions
+	templates *Templates
+	parsed    map[string]*Template
+	notes     map[string]int // note text -> marker number
+	result    *Narrative
+}
+
+// facts collects the individual's events, attributes, and family events,
+// sorted by date; events with an SDATE or vendor sort date sort by it.
+func (n *narrator) facts() []fact {
+	var facts []fact
+	for _, e := range n.ind.Events {
+		if e != nil && !e.IsNegative {
+			facts = append(facts, eventFact(e, ""))
+		}
+	}
+	for _, a := range n.ind.Attributes {
+		if a == nil {
+			continue
+		}
+		facts = append(facts, fact{
+			kind:      gedcom.EventType(a.Type),
+			date:      a.Date,
+			parsed:    a.ParsedDate,
+			order:     a.ParsedDate,
+			place:     a.Place,
+			value:     a.Value,
+			citations: a.SourceCitations,
+		})
+	}
+	return sortFacts(append(facts, n.familyFacts()...))
+}
+
+// familyFacts collects the events of the individual's families, naming
+// the spouse.
+func (n *narrator) familyFacts() []fact {
+	var facts []fact
+	for _, fam := range n.ind.SpouseFamilies(n.doc) {
+		spouseXRef := fam.Husband
+		if spouseXRef == n.ind.XRef {
+			spouseXRef = fam.Wife
+		}
+		var spouse string
+		if s := n.doc.GetIndividual(spouseXRef); s != nil {
+			spouse = n.opts.Names.FormatIndividual(s)
+		}
+		for _, e := range fam.Events {
+			if e != nil && !e.IsNegative {
+				facts = append(facts, eventFact(e, spouse))
+			}
+		}
+	}
+	return facts
+}
+
+// sortFacts returns facts sorted by date. Undated facts take the date of
+// the fact before them so that they keep their place in the file.
+func sortFacts(facts []fact) []fact {
+	keys := make([]*gedcom.Date, len(facts))
+	var last *gedcom.Date
+	for i := range facts {
+		if facts[i].order != nil {
+			last = facts[i].order
+		}
+		keys[i] = last
+	}
+	order := make([]int, len(facts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ka, kb := keys[order[a]], keys[order[b]]
+		if ka == nil || kb == nil {
+			return ka == nil && kb != nil
+		}
+		return ka.Compare(kb) < 0
+	})
+	sorted := make([]fact, len(facts))
+	for i, j := range order {
+		sorted[i] = facts[j]
+	}
+	return sorted
+}
+
+// eventFact converts an event to a fact.
+func eventFact(e *gedcom.Event, spouse string) fact {
+	return fact{
+		kind:      e.Type,
+		date:      e.Date,
+		parsed:    e.ParsedDate,
+		order:     e.OrderDate(),
+		place:     e.Place,
+		value:     e.Description,
+		typeName:  e.EventTypeDetail,
+		cause:     e.Cause,
+		age:       e.Age,
+		spouse:    spouse,
+		citations: e.SourceCitations,
+	}
+}
+
+// sentence renders the sentence for f.
+func (n *narrator) sentence(f fact, first bool) (string, error) {
+	text, ok := n.templates.Events[f.kind]
+	if !ok {
+		text = n.templates.Fallback
+	}
+	if text == "" {
+		return "", nil
+	}
+	tmpl := n.parsed[text]
+	if tmpl == nil {
+		var err error
+		if tmpl, err = ParseTemplate(text); err != nil {
+			return "", err
+		}
+		n.parsed[text] = tmpl
+	}
+
+	m := n.opts.Markup
+	name := n.opts.Names.FormatIndividual(n.ind)
+	var given, surname string
+	for _, pn := range n.ind.Names {
+		if pn != nil {
+			given, surname = pn.Given, pn.Surname
+			break
+		}
+	}
+	subject := name
+	if !first {
+		if pronoun := n.templates.Pronouns[n.ind.Sex]; pronoun != "" {
+			subject = pronoun
+		} else if g := strings.TrimSpace(given); g != "" {
+			subject = g
+		}
+	}
+
+	typeName := clean(f.typeName)
+	if typeName == "" {
+		typeName = n.templates.EventNames[f.kind]
+	}
+	if typeName == "" {
+		typeName = strings.ToLower(string(f.kind))
+	}
+
+	fields := Fields{
+		"subject": m.escape(subject),
+		"name":    m.escape(name),
+		"given":   m.escape(strings.TrimSpace(given)),
+		"surname": m.escape(strings.TrimSpace(surname)),
+		"date":    m.escape(n.datePhrase(f)),
+		"place":   m.escape(clean(f.place)),
+		"age":     m.escape(n.age(f)),
+		"cause":   m.escape(clean(f.cause)),
+		"value":   m.escape(clean(f.value)),
+		"type":    m.escape(typeName),
+		"spouse":  m.escape(f.spouse),
+		"sources": n.markers(f.citations),
+	}
+	return tmpl.Render(fields), nil
+}
+
+// datePhrase renders the fact's date for the templates' language.
+func (n *narrator) datePhrase(f fact) string {
+	if f.parsed == nil {
+		return clean(f.date)
+	}
+	if n.templates.DatePhrase != nil {
+		return n.templates.DatePhrase(f.parsed, n.opts.Language)
+	}
+	return f.parsed.FormatLocalized(n.opts.Language)
+}
+
+// ageYears matches the years of a GEDCOM age value, e.g. "32y 3m".
+var ageYears = regexp.MustCompile(`^\s*[<>]?\s*(\d+)y`)
+
+// age returns the individual's age in years at the fact, from its AGE or
+// from exact birth and event dates.
+func (n *narrator) age(f fact) string {
+	if m := ageYears.FindStringSubmatch(f.age); m != nil {
+		return m[1]
+	}
+	if f.kind == gedcom.EventBirth || f.parsed == nil || f.parsed.Modifier != gedcom.ModifierNone {
+		return ""
+	}
+	birth := n.ind.BirthDate()
+	if birth == nil || birth.Modifier != gedcom.ModifierNone {
+		return ""
+	}
+	years, _, err := gedcom.YearsBetween(birth, f.parsed)
+	if err != nil {
+		return ""
+	}
+	return strconv.Itoa(years)
+}
+
+// markers returns the citation markers for citations, adding their
+// reference notes to the narrative; a repeated note reuses its number.
+func (n *narrator) markers(citations []*gedcom.SourceCitation) string {
+	var b strings.Builder
+	for _, c := range citations {
+		if c == nil {
+			continue
+		}
+		note := FormatCitation(n.doc, c, n.opts.Style, n.opts.Markup)
+		num, ok := n.notes[note]
+		if !ok {
+			n.result.Notes = append(n.result.Notes, note)
+			num = len(n.result.Notes)
+			n.notes[note] = num
+		}
+		if n.opts.Markup == MarkupHTML {
+			fmt.Fprintf(&b, "<sup>%d</sup>", num)
+		} else {
+			fmt.Fprintf(&b, "[%d]", num)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,187 @@
+package report
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const narrativeGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Smith/
+2 GIVN John
+2 SURN Smith
+1 SEX M
+1 DEAT
+2 DATE 3 MAR 1910
+2 PLAC Springfield
+2 CAUS pneumonia
+2 SOUR @S1@
+3 PAGE p. 40
+1 BIRT
+2 DATE 1 JAN 1850
+2 PLAC Boston
+2 SOUR @S1@
+3 PAGE p. 12
+1 RESI
+2 PLAC Salem
+1 PROB
+2 DATE 1911
+2 PLAC Springfield
+1 OCCU farmer
+2 DATE ABT 1880
+1 FAMS @F1@
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 SEX F
+1 FAMS @F1@
+0 @I3@ INDI
+1 NAME Pat /Doe/
+1 BIRT
+2 PLAC Boston
+1 BURI
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 MARR
+2 DATE JUN 1875
+2 PLAC Boston
+2 SOUR @S1@
+3 PAGE p. 12
+0 @S1@ SOUR
+1 TITL Town Records
+0 TRLR
+`
+
+func decodeNarrativeDoc(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(narrativeGEDCOM))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestIndividualNarrative(t *testing.T) {
+	doc := decodeNarrativeDoc(t)
+	n, err := IndividualNarrative(doc, "@I1@", nil)
+	if err != nil {
+		t.Fatalf("IndividualNarrative() error = %v", err)
+	}
+	want := []string{
+		"John Smith was born on 1 January 1850 in Boston.[1]",
+		"He lived in Salem.",
+		"He married Mary Jones in June 1875 in Boston.[1]",
+		"He worked as farmer about 1880.",
+		"He died on 3 March 1910 in Springfield at the age of 60 of pneumonia.[2]",
+		"Probate in 1911 in Springfield.",
+	}
+	if !reflect.DeepEqual(n.Sentences, want) {
+		t.Errorf("Sentences =\n%q\nwant\n%q", n.Sentences, want)
+	}
+	if n.Text != strings.Join(want, " ") {
+		t.Errorf("Text = %q", n.Text)
+	}
+	wantNotes := []string{"Town Records, p. 12.", "Town Records, p. 40."}
+	if !reflect.DeepEqual(n.Notes, wantNotes) {
+		t.Errorf("Notes = %q, want %q", n.Notes, wantNotes)
+	}
+
+	// Without a pronoun, later sentences use the given name.
+	n, err = IndividualNarrative(doc, "@I3@", &NarrativeOptions{Markup: MarkupHTML})
+	if err != nil {
+		t.Fatalf("IndividualNarrative() error = %v", err)
+	}
+	if want := "Pat Doe was born in Boston. Pat was buried."; n.Text != want {
+		t.Errorf("Text = %q, want %q", n.Text, want)
+	}
+
+	n, err = IndividualNarrative(doc, "@I3@", &NarrativeOptions{Names: gedcom.NewNameFormatter(gedcom.NameFormatGivenSURNAME)})
+	if err != nil {
+		t.Fatalf("IndividualNarrative() error = %v", err)
+	}
+	if want := "Pat DOE was born in Boston."; !strings.HasPrefix(n.Text, want) {
+		t.Errorf("Text = %q, want prefix %q", n.Text, want)
+	}
+
+	if _, err := IndividualNarrative(doc, "@X9@", nil); err == nil {
+		t.Error("IndividualNarrative() of a missing individual should fail")
+	}
+	if _, err := IndividualNarrative(nil, "@I1@", nil); err == nil {
+		t.Error("IndividualNarrative(nil) should fail")
+	}
+}
+
+func TestIndividualNarrative_SortDate(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME Ann /Lee/
+1 SEX F
+1 GRAD
+2 DATE 1920
+2 PLAC Yale
+1 RESI
+2 DATE FROM 1915 TO 1925
+2 PLAC Boston
+2 _SDATE 1921
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	n, err := IndividualNarrative(doc, "@I1@", nil)
+	if err != nil {
+		t.Fatalf("IndividualNarrative() error = %v", err)
+	}
+	if len(n.Sentences) != 2 || !strings.Contains(n.Sentences[1], "Boston") {
+		t.Errorf("Sentences = %q, want the residence last by its sort date", n.Sentences)
+	}
+}
+
+func TestIndividualNarrative_CustomTemplates(t *testing.T) {
+	doc := decodeNarrativeDoc(t)
+	german := &Templates{
+		Events: map[gedcom.EventType]string{
+			gedcom.EventBirth: "{Subject} wurde[ am {date}][ in {place}] geboren.[{sources}]",
+		},
+		Pronouns: map[string]string{"M": "er", "F": "sie"},
+	}
+	RegisterTemplates("de", german)
+	defer RegisterTemplates("de", nil)
+
+	if TemplatesFor("de-AT") != german || TemplatesFor("fr") != EnglishTemplates {
+		t.Fatal("TemplatesFor() did not fall back as expected")
+	}
+	n, err := IndividualNarrative(doc, "@I1@", &NarrativeOptions{Language: "de-AT", Style: StyleChicago})
+	if err != nil {
+		t.Fatalf("IndividualNarrative() error = %v", err)
+	}
+	if want := []string{"John Smith wurde am 1 Januar 1850 in Boston geboren.[1]"}; !reflect.DeepEqual(n.Sentences, want) {
+		t.Errorf("Sentences = %q, want %q", n.Sentences, want)
+	}
+
+	// An application can override a single template.
+	custom := *EnglishTemplates
+	custom.Events = map[gedcom.EventType]string{gedcom.EventBirth: "Born: {date}.[{sources}]"}
+	custom.Fallback = ""
+	n, err = IndividualNarrative(doc, "@I1@", &NarrativeOptions{Templates: &custom, Markup: MarkupHTML})
+	if err != nil {
+		t.Fatalf("IndividualNarrative() error = %v", err)
+	}
+	if n.Text != "Born: on 1 January 1850.<sup>1</sup>" {
+		t.Errorf("Text = %q", n.Text)
+	}
+
+	custom.Events = map[gedcom.EventType]string{gedcom.EventBirth: "born [{date}"}
+	if _, err := IndividualNarrative(doc, "@I1@", &NarrativeOptions{Templates: &custom}); err == nil {
+		t.Error("IndividualNarrative() with a malformed template should fail")
+	}
+}
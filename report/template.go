@@ -0,0 +1,168 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Fields are the values substituted into a template's placeholders, keyed
+// by lower-case placeholder name.
+type Fields map[string]string
+
+// Template is a parsed sentence template.
+//
+// Placeholders in braces are replaced by field values, e.g. {date} or
+// {place}. A placeholder starting with an upper-case letter, such as
+// {Subject}, capitalizes the first letter of the "subject" field. Text in
+// square brackets is optional: it is rendered only when every placeholder
+// inside it has a non-empty value, so "born[ {date}][ in {place}]." reads
+// naturally whatever is known. Optional text does not nest. A backslash
+// escapes the next character, e.g. "\[".
+type Template struct {
+	text     string
+	segments []segment
+}
+
+// segment is a run of parts that is rendered as a whole or, if optional
+// and missing a field, not at all.
+type segment struct {
+	optional bool
+	parts    []part
+}
+
+// part is literal text or a placeholder.
+type part struct {
+	literal    string
+	field      string
+	capitalize bool
+}
+
+// ParseTemplate parses a sentence template.
+func ParseTemplate(text string) (*Template, error) {
+	t := &Template{text: text}
+	current := segment{}
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			current.parts = append(current.parts, part{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+	closeSegment := func() {
+		flush()
+		if len(current.parts) > 0 {
+			t.segments = append(t.segments, current)
+		}
+		current = segment{}
+	}
+
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; c {
+		case '\\':
+			if i+1 == len(text) {
+				return nil, fmt.Errorf("report: template %q ends with a backslash", text)
+			}
+			i++
+			literal.WriteByte(text[i])
+		case '[':
+			if current.optional {
+				return nil, fmt.Errorf("report: template %q nests optional text", text)
+			}
+			closeSegment()
+			current.optional = true
+		case ']':
+			if !current.optional {
+				return nil, fmt.Errorf("report: template %q has an unmatched ']'", text)
+			}
+			closeSegment()
+		case '{':
+			end := strings.IndexByte(text[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("report: template %q has an unclosed placeholder", text)
+			}
+			name := text[i+1 : i+end]
+			if !validFieldName(name) {
+				return nil, fmt.Errorf("report: template %q has an invalid placeholder {%s}", text, name)
+			}
+			flush()
+			first, _ := utf8.DecodeRuneInString(name)
+			current.parts = append(current.parts, part{
+				field:      strings.ToLower(name),
+				capitalize: unicode.IsUpper(first),
+			})
+			i += end
+		case '}':
+			return nil, fmt.Errorf("report: template %q has an unmatched '}'", text)
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	if current.optional {
+		return nil, fmt.Errorf("report: template %q has an unclosed '['", text)
+	}
+	closeSegment()
+	return t, nil
+}
+
+// Render substitutes fields into the template. Required placeholders with
+// no value are left empty.
+func (t *Template) Render(fields Fields) string {
+	var b strings.Builder
+	for _, seg := range t.segments {
+		if seg.optional && !seg.complete(fields) {
+			continue
+		}
+		for _, p := range seg.parts {
+			if p.field == "" {
+				b.WriteString(p.literal)
+				continue
+			}
+			value := fields[p.field]
+			if p.capitalize {
+				value = capitalize(value)
+			}
+			b.WriteString(value)
+		}
+	}
+	return b.String()
+}
+
+// String returns the template's source text.
+func (t *Template) String() string {
+	return t.text
+}
+
+// complete reports whether every placeholder of the segment has a value.
+func (s segment) complete(fields Fields) bool {
+	for _, p := range s.parts {
+		if p.field != "" && fields[p.field] == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// validFieldName reports whether name is a non-empty run of letters,
+// digits, and underscores.
+func validFieldName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// capitalize upper-cases the first letter of s.
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,44 @@
+package report
+
+import "testing"
+
+func TestTemplate_Render(t *testing.T) {
+	tmpl, err := ParseTemplate(`{Subject} was born[ {date}][ in {place}].[{sources}] \[sic\]`)
+	if err != nil {
+		t.Fatalf("ParseTemplate() error = %v", err)
+	}
+	tests := []struct {
+		fields Fields
+		want   string
+	}{
+		{Fields{"subject": "john", "date": "in 1850", "place": "Boston"}, "John was born in 1850 in Boston. [sic]"},
+		{Fields{"subject": "john", "place": "Boston", "sources": "[1]"}, "John was born in Boston.[1] [sic]"},
+		{Fields{"subject": "élise"}, "Élise was born. [sic]"},
+		{Fields{}, " was born. [sic]"},
+	}
+	for _, tt := range tests {
+		if got := tmpl.Render(tt.fields); got != tt.want {
+			t.Errorf("Render(%v) = %q, want %q", tt.fields, got, tt.want)
+		}
+	}
+	if tmpl.String() != `{Subject} was born[ {date}][ in {place}].[{sources}] \[sic\]` {
+		t.Errorf("String() = %q", tmpl.String())
+	}
+}
+
+func TestParseTemplate_Errors(t *testing.T) {
+	for _, text := range []string{
+		"born[ on [{date}]]",
+		"born]",
+		"born[ {date}",
+		"born {date",
+		"born {}",
+		"born {da te}",
+		"born }",
+		`born \`,
+	} {
+		if _, err := ParseTemplate(text); err == nil {
+			t.Errorf("ParseTemplate(%q) should fail", text)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Package social builds social networks from GEDCOM documents for social
+// network analysis (SNA).
+//
+// Family links say who is related; a genealogical community is more than
+// that. This package collects the other ties a GEDCOM file records between
+// people and exports them as typed edges:
+//
+//   - association: an ASSO structure on an individual, e.g. a godparent
+//     or friend, with its RELA/ROLE.
+//   - event-role: another person taking part in someone's event, e.g. a
+//     witness at a marriage, recorded as an ASSO within the event (GEDCOM
+//     7.0) or as a _SHAR shared event (Family Historian, RootsMagic).
+//   - co-resident: two people with a residence or census event at the same
+//     place on the same date, i.e. the same household.
+//   - spouse and parent-child: family links, included on request so that
+//     kinship and social ties can be analyzed together.
+//
+// Build returns the network as a Graph; WriteEdgeCSV, WriteNodeCSV, and
+// WriteGraphML export it for tools such as Gephi, Cytoscape, igraph, or
+// NetworkX.
+//
+// Example usage:
+//
+//	doc, _ := decoder.Decode(f)
+//	out, _ := os.Create("community.graphml")
+//	defer out.Close()
+//	if err := social.WriteGraphML(out, doc, &social.Options{IncludeFamily: true}); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Links to records that are not individuals of the document, such as
+// dangling pointers or _SHAN name-only participants, are skipped rather
+// than reported as errors.
+package social
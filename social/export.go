@@ -0,0 +1,188 @@
+package social
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// edgeCSVHeader follows the Gephi spreadsheet import convention: Source,
+// Target, and Type (Directed or Undirected) columns, then edge attributes.
+var edgeCSVHeader = []string{"Source", "Target", "Type", "Relation", "Role", "Event", "Date", "Place"}
+
+// nodeCSVHeader follows the Gephi spreadsheet import convention for nodes.
+var nodeCSVHeader = []string{"Id", "Label", "Sex"}
+
+// WriteEdgeCSV writes the social network of doc as a CSV edge list with
+// the columns Source, Target, Type ("Directed" or "Undirected"), Relation
+// (the EdgeType), Role, Event, Date, and Place. If opts is nil, default
+// options are used.
+func WriteEdgeCSV(w io.Writer, doc *gedcom.Document, opts *Options) error {
+	return Build(doc, opts).WriteEdgeCSV(w)
+}
+
+// WriteNodeCSV writes the individuals of doc as a CSV node list with the
+// columns Id (the XRef), Label (the name), and Sex.
+func WriteNodeCSV(w io.Writer, doc *gedcom.Document) error {
+	return Build(doc, nil).WriteNodeCSV(w)
+}
+
+// WriteGraphML writes the social network of doc as a GraphML document.
+// If opts is nil, default options are used.
+func WriteGraphML(w io.Writer, doc *gedcom.Document, opts *Options) error {
+	return Build(doc, opts).WriteGraphML(w)
+}
+
+// WriteEdgeCSV writes the edges of g as a CSV edge list; see the
+// package-level WriteEdgeCSV for the columns.
+func (g *Graph) WriteEdgeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(edgeCSVHeader); err != nil {
+		return err
+	}
+	for _, e := range g.Edges {
+		direction := "Undirected"
+		if e.Directed() {
+			direction = "Directed"
+		}
+		row := []string{e.Source, e.Target, direction, string(e.Type), e.Role, string(e.EventType), e.Date, e.Place}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteNodeCSV writes the nodes of g as a CSV node list; see the
+// package-level WriteNodeCSV for the columns.
+func (g *Graph) WriteNodeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(nodeCSVHeader); err != nil {
+		return err
+	}
+	for _, n := range g.Nodes {
+		if err := cw.Write([]string{n.XRef, n.Name, n.Sex}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// graphmlNamespace is the GraphML 1.0 namespace.
+const graphmlNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+// graphmlRoot is the top-level <graphml> element.
+type graphmlRoot struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphmlKey `xml:"key"`
+	Graph   graphmlGraph `xml:"graph"`
+}
+
+// graphmlKey declares a node or edge attribute.
+type graphmlKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+// graphmlGraph holds the nodes and edges. Edges are directed by default;
+// undirected edges say so individually.
+type graphmlGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphmlNode `xml:"node"`
+	Edges       []graphmlEdge `xml:"edge"`
+}
+
+// graphmlNode is one individual.
+type graphmlNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphmlData `xml:"data"`
+}
+
+// graphmlEdge is one tie.
+type graphmlEdge struct {
+	Source   string        `xml:"source,attr"`
+	Target   string        `xml:"target,attr"`
+	Directed string        `xml:"directed,attr,omitempty"`
+	Data     []graphmlData `xml:"data"`
+}
+
+// graphmlData is a single <data key="...">value</data> attribute value.
+type graphmlData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// graphmlKeys declares the attributes written by WriteGraphML.
+var graphmlKeys = []graphmlKey{
+	{ID: "name", For: "node", AttrName: "name", AttrType: "string"},
+	{ID: "sex", For: "node", AttrName: "sex", AttrType: "string"},
+	{ID: "relation", For: "edge", AttrName: "relation", AttrType: "string"},
+	{ID: "role", For: "edge", AttrName: "role", AttrType: "string"},
+	{ID: "event", For: "edge", AttrName: "event", AttrType: "string"},
+	{ID: "date", For: "edge", AttrName: "date", AttrType: "string"},
+	{ID: "place", For: "edge", AttrName: "place", AttrType: "string"},
+}
+
+// WriteGraphML writes g as a GraphML document. Nodes are identified by
+// XRef and carry name and sex attributes; edges carry relation (the
+// EdgeType), role, event, date, and place attributes, omitting empty ones.
+// Undirected edges are marked directed="false".
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	root := graphmlRoot{
+		Xmlns: graphmlNamespace,
+		Keys:  graphmlKeys,
+		Graph: graphmlGraph{ID: "G", EdgeDefault: "directed"},
+	}
+	for _, n := range g.Nodes {
+		root.Graph.Nodes = append(root.Graph.Nodes, graphmlNode{
+			ID:   n.XRef,
+			Data: graphmlValues("name", n.Name, "sex", n.Sex),
+		})
+	}
+	for _, e := range g.Edges {
+		edge := graphmlEdge{
+			Source: e.Source,
+			Target: e.Target,
+			Data: graphmlValues("relation", string(e.Type), "role", e.Role,
+				"event", string(e.EventType), "date", e.Date, "place", e.Place),
+		}
+		if !e.Directed() {
+			edge.Directed = "false"
+		}
+		root.Graph.Edges = append(root.Graph.Edges, edge)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// graphmlValues builds data elements from key/value pairs, skipping empty
+// values.
+func graphmlValues(pairs ...string) []graphmlData {
+	var data []graphmlData
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			data = append(data, graphmlData{Key: pairs[i], Value: pairs[i+1]})
+		}
+	}
+	return data
+}
@@ -0,0 +1,349 @@
+package social
+
+import (
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// EdgeType classifies the tie an Edge represents.
+type EdgeType string
+
+// Edge types produced by Build.
+const (
+	// EdgeAssociation is an individual-level ASSO: Target is the associate
+	// of Source, in the role given by Edge.Role.
+	EdgeAssociation EdgeType = "association"
+
+	// EdgeEventRole links the principal of an event (Source) to another
+	// participant (Target), such as a witness or godparent.
+	EdgeEventRole EdgeType = "event-role"
+
+	// EdgeCoResident links two individuals living at the same place on the
+	// same date. It is undirected.
+	EdgeCoResident EdgeType = "co-resident"
+
+	// EdgeSpouse links the husband and wife of a family. It is undirected.
+	EdgeSpouse EdgeType = "spouse"
+
+	// EdgeParentChild links a parent (Source) to a child (Target).
+	EdgeParentChild EdgeType = "parent-child"
+)
+
+// DefaultResidenceEvents are the event types compared for co-residence when
+// Options.ResidenceEvents is empty.
+var DefaultResidenceEvents = []gedcom.EventType{
+	gedcom.EventResidence,
+	gedcom.EventCensus,
+}
+
+// Options configures which ties Build collects.
+type Options struct {
+	// IncludeFamily adds spouse and parent-child edges from FAM records.
+	// Default: false, since the package is about ties beyond family links.
+	IncludeFamily bool
+
+	// ResidenceEvents are the event types whose shared place and date make
+	// two individuals co-residents. If empty, DefaultResidenceEvents is used.
+	ResidenceEvents []gedcom.EventType
+
+	// IgnoreResidenceDate links residents of the same place regardless of
+	// date, and includes undated residence events.
+	IgnoreResidenceDate bool
+}
+
+// Node is an individual of the network.
+type Node struct {
+	// XRef identifies the individual.
+	XRef string
+
+	// Name is the individual's primary name with surname slashes removed,
+	// or the XRef if the individual has no name.
+	Name string
+
+	// Sex is the individual's SEX value, if any.
+	Sex string
+}
+
+// Edge is a typed tie between two individuals.
+type Edge struct {
+	// Source and Target are individual XRefs.
+	Source string
+	Target string
+
+	// Type classifies the tie.
+	Type EdgeType
+
+	// Role is the ASSO role (RELA or ROLE) or _SHAR ROLE, if any.
+	Role string
+
+	// EventType is the tag of the event behind an event-role or
+	// co-resident edge.
+	EventType gedcom.EventType
+
+	// Date and Place are the raw date and place of that event.
+	Date  string
+	Place string
+}
+
+// Directed reports whether the edge has a direction. Co-resident and spouse
+// edges do not.
+func (e Edge) Directed() bool {
+	return e.Type != EdgeCoResident && e.Type != EdgeSpouse
+}
+
+// Graph is the social network of a document.
+type Graph struct {
+	// Nodes holds every individual of the document, in document order,
+	// including those without edges.
+	Nodes []Node
+
+	// Edges holds the ties, grouped by the record they were found on, in
+	// document order.
+	Edges []Edge
+}
+
+// Build collects the social network of doc. If opts is nil, default
+// options are used. Returns an empty graph if doc is nil.
+func Build(doc *gedcom.Document, opts *Options) *Graph {
+	g := &Graph{}
+	if doc == nil {
+		return g
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	b := &graphBuilder{g: g, opts: opts, people: make(map[string]bool), residences: newHouseholds(opts)}
+	for _, record := range doc.Records {
+		if ind, ok := record.GetIndividual(); ok {
+			b.people[ind.XRef] = true
+			g.Nodes = append(g.Nodes, Node{XRef: ind.XRef, Name: gedcom.DefaultNameFormatter.FormatIndividual(ind), Sex: ind.Sex})
+		}
+	}
+	for _, record := range doc.Records {
+		if ind, ok := record.GetIndividual(); ok {
+			b.addIndividual(record, ind)
+		} else if fam, ok := record.GetFamily(); ok {
+			b.addFamily(record, fam)
+		}
+	}
+	for _, e := range b.residences.edges() {
+		b.add(e)
+	}
+
+	return g
+}
+
+// graphBuilder collects the edges of a Graph.
+type graphBuilder struct {
+	g          *Graph
+	opts       *Options
+	people     map[string]bool // XRefs of the document's individuals
+	residences *households
+}
+
+// add adds e when it ties two distinct individuals of the document.
+func (b *graphBuilder) add(e Edge) {
+	if b.people[e.Source] && b.people[e.Target] && e.Source != e.Target {
+		b.g.Edges = append(b.g.Edges, e)
+	}
+}
+
+// addIndividual adds the associations and event roles of an individual
+// and collects its residences.
+func (b *graphBuilder) addIndividual(record *gedcom.Record, ind *gedcom.Individual) {
+	for _, assoc := range ind.Associations {
+		if assoc != nil {
+			b.add(Edge{Source: ind.XRef, Target: assoc.IndividualXRef, Type: EdgeAssociation, Role: assoc.Role})
+		}
+	}
+	principals := []string{ind.XRef}
+	for _, event := range ind.Events {
+		addEventRoles(b.add, principals, event)
+		b.residences.add(ind.XRef, event)
+	}
+	addSharedEvents(b.add, principals, record.Tags)
+}
+
+// addFamily adds the event roles of a family, whose spouses are their
+// principals, and with IncludeFamily its spouse and parent-child edges.
+func (b *graphBuilder) addFamily(record *gedcom.Record, fam *gedcom.Family) {
+	var principals []string
+	for _, spouse := range []string{fam.Husband, fam.Wife} {
+		if spouse != "" {
+			principals = append(principals, spouse)
+		}
+	}
+	for _, event := range fam.Events {
+		addEventRoles(b.add, principals, event)
+	}
+	addSharedEvents(b.add, principals, record.Tags)
+	if !b.opts.IncludeFamily {
+		return
+	}
+	if fam.Husband != "" && fam.Wife != "" {
+		b.add(Edge{Source: fam.Husband, Target: fam.Wife, Type: EdgeSpouse})
+	}
+	for _, parent := range principals {
+		for _, child := range fam.Children {
+			b.add(Edge{Source: parent, Target: child, Type: EdgeParentChild})
+		}
+	}
+}
+
+// addEventRoles adds an event-role edge from each principal to each
+// participant linked by an ASSO within event.
+func addEventRoles(add func(Edge), principals []string, event *gedcom.Event) {
+	if event == nil || event.IsNegative {
+		return
+	}
+	for _, assoc := range event.Associations {
+		if assoc == nil {
+			continue
+		}
+		for _, principal := range principals {
+			add(Edge{
+				Source:    principal,
+				Target:    assoc.IndividualXRef,
+				Type:      EdgeEventRole,
+				Role:      assoc.Role,
+				EventType: event.Type,
+				Date:      event.Date,
+				Place:     event.Place,
+			})
+		}
+	}
+}
+
+// addSharedEvents adds an event-role edge from each principal to each
+// participant of a _SHAR shared event in the raw record tags:
+//
+//	1 BIRT
+//	2 DATE 1 JAN 1940
+//	2 _SHAR @I3@
+//	3 ROLE Witness
+func addSharedEvents(add func(Edge), principals []string, tags []*gedcom.Tag) {
+	for i := 0; i < len(tags); i++ {
+		if tags[i].Level != 1 {
+			continue
+		}
+		event := tags[i]
+		var date, place string
+		var shares []Edge
+		for j := i + 1; j < len(tags) && tags[j].Level > 1; j++ {
+			sub := tags[j]
+			if sub.Level != 2 {
+				continue
+			}
+			switch sub.Tag {
+			case "DATE":
+				date = sub.Value
+			case "PLAC":
+				place = sub.Value
+			case "_SHAR":
+				share := Edge{Target: sub.Value, Type: EdgeEventRole, EventType: gedcom.EventType(event.Tag)}
+				for k := j + 1; k < len(tags) && tags[k].Level > 2; k++ {
+					if tags[k].Level == 3 && tags[k].Tag == "ROLE" {
+						share.Role = tags[k].Value
+					}
+				}
+				shares = append(shares, share)
+			}
+		}
+		for _, share := range shares {
+			share.Date, share.Place = date, place
+			for _, principal := range principals {
+				share.Source = principal
+				add(share)
+			}
+		}
+	}
+}
+
+// households groups residence events by place (and date) in order of
+// first appearance.
+type households struct {
+	wanted     map[gedcom.EventType]bool
+	ignoreDate bool
+	keys       []string
+	members    map[string][]resident
+}
+
+// resident is one individual's residence event.
+type resident struct {
+	xref  string
+	event *gedcom.Event
+}
+
+func newHouseholds(opts *Options) *households {
+	types := opts.ResidenceEvents
+	if len(types) == 0 {
+		types = DefaultResidenceEvents
+	}
+	h := &households{
+		wanted:     make(map[gedcom.EventType]bool),
+		ignoreDate: opts.IgnoreResidenceDate,
+		members:    make(map[string][]resident),
+	}
+	for _, t := range types {
+		h.wanted[t] = true
+	}
+	return h
+}
+
+// add records a residence event of the individual xref.
+func (h *households) add(xref string, event *gedcom.Event) {
+	if event == nil || event.IsNegative || !h.wanted[event.Type] {
+		return
+	}
+	place := normalize(event.Place)
+	if place == "" {
+		return
+	}
+	key := place
+	if !h.ignoreDate {
+		date := normalize(event.Date)
+		if date == "" {
+			return
+		}
+		key += "\x00" + date
+	}
+	for _, r := range h.members[key] {
+		if r.xref == xref {
+			return
+		}
+	}
+	if _, ok := h.members[key]; !ok {
+		h.keys = append(h.keys, key)
+	}
+	h.members[key] = append(h.members[key], resident{xref: xref, event: event})
+}
+
+// edges returns a co-resident edge for each pair of members of each
+// household. The edge carries the first member's event details.
+func (h *households) edges() []Edge {
+	var edges []Edge
+	for _, key := range h.keys {
+		members := h.members[key]
+		for i, a := range members {
+			for _, b := range members[i+1:] {
+				edges = append(edges, Edge{
+					Source:    a.xref,
+					Target:    b.xref,
+					Type:      EdgeCoResident,
+					EventType: a.event.Type,
+					Date:      a.event.Date,
+					Place:     a.event.Place,
+				})
+			}
+		}
+	}
+	return edges
+}
+
+// normalize lowercases s and collapses whitespace, for comparing places
+// and dates.
+func normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
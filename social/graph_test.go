@@ -0,0 +1,198 @@
+package social
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const socialTestGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 NAME John /Smith/
+1 SEX M
+1 ASSO @I3@
+2 ROLE GODP
+1 ASSO @I99@
+2 ROLE FRIEND
+1 RESI
+2 DATE 1880
+2 PLAC Springfield, Illinois
+1 FAMS @F1@
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 SEX F
+1 BIRT
+2 DATE 1 JAN 1855
+2 _SHAR @I4@
+3 ROLE Midwife
+2 _SHAN Wally Witness
+1 CENS
+2 DATE 1880
+2 PLAC springfield,  illinois
+1 FAMS @F1@
+0 @I3@ INDI
+1 NAME Peter /Brown/
+1 RESI
+2 DATE 1880
+2 PLAC Springfield, Illinois
+1 RESI
+2 DATE 1900
+2 PLAC Chicago
+0 @I4@ INDI
+1 NAME Anna /White/
+1 RESI
+2 PLAC Chicago
+0 @I5@ INDI
+1 NAME Child /Smith/
+1 FAMC @F1@
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 CHIL @I5@
+1 MARR
+2 DATE 1875
+2 PLAC Boston
+2 ASSO @I3@
+3 ROLE WITN
+0 TRLR
+`
+
+func decodeSocial(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(socialTestGEDCOM))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestBuild_Defaults(t *testing.T) {
+	g := Build(decodeSocial(t), nil)
+
+	if len(g.Nodes) != 5 {
+		t.Fatalf("Nodes = %d, want 5", len(g.Nodes))
+	}
+	if want := (Node{XRef: "@I1@", Name: "John Smith", Sex: "M"}); g.Nodes[0] != want {
+		t.Errorf("Nodes[0] = %+v, want %+v", g.Nodes[0], want)
+	}
+
+	want := []Edge{
+		{Source: "@I1@", Target: "@I3@", Type: EdgeAssociation, Role: "GODP"},
+		{Source: "@I2@", Target: "@I4@", Type: EdgeEventRole, Role: "Midwife", EventType: gedcom.EventBirth, Date: "1 JAN 1855"},
+		{Source: "@I1@", Target: "@I3@", Type: EdgeEventRole, Role: "WITN", EventType: gedcom.EventMarriage, Date: "1875", Place: "Boston"},
+		{Source: "@I2@", Target: "@I3@", Type: EdgeEventRole, Role: "WITN", EventType: gedcom.EventMarriage, Date: "1875", Place: "Boston"},
+		{Source: "@I1@", Target: "@I2@", Type: EdgeCoResident, EventType: gedcom.EventResidence, Date: "1880", Place: "Springfield, Illinois"},
+		{Source: "@I1@", Target: "@I3@", Type: EdgeCoResident, EventType: gedcom.EventResidence, Date: "1880", Place: "Springfield, Illinois"},
+		{Source: "@I2@", Target: "@I3@", Type: EdgeCoResident, EventType: gedcom.EventCensus, Date: "1880", Place: "springfield,  illinois"},
+	}
+	if !reflect.DeepEqual(g.Edges, want) {
+		t.Errorf("Edges =\n%+v\nwant\n%+v", g.Edges, want)
+	}
+}
+
+func TestBuild_Options(t *testing.T) {
+	g := Build(decodeSocial(t), &Options{
+		IncludeFamily:       true,
+		ResidenceEvents:     []gedcom.EventType{gedcom.EventResidence},
+		IgnoreResidenceDate: true,
+	})
+
+	counts := make(map[EdgeType]int)
+	for _, e := range g.Edges {
+		counts[e.Type]++
+	}
+	// I1-I3 in Springfield, I3-I4 in Chicago (undated); I2's census is excluded.
+	if counts[EdgeCoResident] != 2 {
+		t.Errorf("co-resident edges = %d, want 2", counts[EdgeCoResident])
+	}
+	if counts[EdgeSpouse] != 1 || counts[EdgeParentChild] != 2 {
+		t.Errorf("spouse/parent-child edges = %d/%d, want 1/2", counts[EdgeSpouse], counts[EdgeParentChild])
+	}
+}
+
+func TestBuild_NilDocument(t *testing.T) {
+	g := Build(nil, nil)
+	if g == nil || len(g.Nodes) != 0 || len(g.Edges) != 0 {
+		t.Errorf("Build(nil) = %+v, want empty graph", g)
+	}
+}
+
+func TestEdge_Directed(t *testing.T) {
+	for typ, want := range map[EdgeType]bool{
+		EdgeAssociation: true, EdgeEventRole: true, EdgeParentChild: true,
+		EdgeCoResident: false, EdgeSpouse: false,
+	} {
+		if got := (Edge{Type: typ}).Directed(); got != want {
+			t.Errorf("Edge{Type: %s}.Directed() = %v, want %v", typ, got, want)
+		}
+	}
+}
+
+func TestWriteEdgeCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteEdgeCSV(&buf, decodeSocial(t), nil); err != nil {
+		t.Fatalf("WriteEdgeCSV() error = %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if !reflect.DeepEqual(rows[0], edgeCSVHeader) {
+		t.Errorf("header = %v", rows[0])
+	}
+	if len(rows) != 8 {
+		t.Fatalf("rows = %d, want 8", len(rows))
+	}
+	if want := []string{"@I1@", "@I3@", "Directed", "association", "GODP", "", "", ""}; !reflect.DeepEqual(rows[1], want) {
+		t.Errorf("rows[1] = %v, want %v", rows[1], want)
+	}
+	if rows[5][2] != "Undirected" || rows[5][3] != "co-resident" {
+		t.Errorf("rows[5] = %v, want an undirected co-resident edge", rows[5])
+	}
+}
+
+func TestWriteNodeCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteNodeCSV(&buf, decodeSocial(t)); err != nil {
+		t.Fatalf("WriteNodeCSV() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 6 || lines[0] != "Id,Label,Sex" || lines[2] != "@I2@,Mary Jones,F" {
+		t.Errorf("WriteNodeCSV() =\n%s", buf.String())
+	}
+}
+
+func TestWriteGraphML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGraphML(&buf, decodeSocial(t), &Options{IncludeFamily: true}); err != nil {
+		t.Fatalf("WriteGraphML() error = %v", err)
+	}
+	out := buf.String()
+
+	var parsed graphmlRoot
+	if err := xml.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, out)
+	}
+	if len(parsed.Graph.Nodes) != 5 || len(parsed.Graph.Edges) != 10 {
+		t.Errorf("nodes/edges = %d/%d, want 5/10", len(parsed.Graph.Nodes), len(parsed.Graph.Edges))
+	}
+	for _, want := range []string{
+		`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`,
+		`<key id="relation" for="edge" attr.name="relation" attr.type="string"></key>`,
+		`<graph id="G" edgedefault="directed">`,
+		`<edge source="@I1@" target="@I2@" directed="false">`,
+		`<data key="role">Midwife</data>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Package storage persists GEDCOM documents in a SQLite database and
+// loads them back, so that applications can keep a large tree in a
+// database and read single records or run SQL queries against it without
+// reparsing the GEDCOM file.
+//
+// The package uses database/sql and does not import a SQLite driver, to
+// keep the module free of third-party dependencies. Open the database
+// with the driver of your choice (modernc.org/sqlite,
+// github.com/mattn/go-sqlite3, ...) and pass it to New.
+//
+// # Schema
+//
+// Save writes the tables created by Init (see Schema). The document and
+// records tables hold everything Load needs; the other tables are indexes
+// for queries, rewritten by every Save:
+//
+//	document         the header, trailer, and formatting (one row)
+//	records          every record in document order, as JSON
+//	tags             the raw tags of every record (record, seq, level, tag, value, xref)
+//	individuals      xref, name, given, surname, sex
+//	families         xref, husband, wife
+//	family_children  family, child, seq
+//	events           individual and family events: owner xref, tag, type, date, place, year
+//	sources          xref, title, author
+//	citations        owner xref, source xref, page
+//
+// The "record" column of the index tables is the position of the record
+// in the records table. Records are stored as the JSON form of the
+// gedcomjson package, so Load returns a document that encodes to the same
+// GEDCOM as the saved one.
+//
+// Example usage:
+//
+//	db, _ := sql.Open("sqlite", "tree.db")
+//	store := storage.New(db)
+//	if err := store.Save(ctx, doc); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	// Later, without reparsing:
+//	smiths, _ := store.IndividualsBySurname(ctx, "Smith")
+//	born, _ := store.Query(ctx,
+//	    "SELECT xref FROM events WHERE tag = ? AND year BETWEEN ? AND ?", "BIRT", 1850, 1860)
+package storage
@@ -0,0 +1,87 @@
+package storage
+
+// SchemaVersion is the version of the table layout, stored with the
+// document. Load rejects databases written with another version.
+const SchemaVersion = 1
+
+// Schema lists the statements Init runs to create the tables, in order.
+// They use "IF NOT EXISTS", so running them on an initialized database is
+// harmless.
+var Schema = []string{
+	`CREATE TABLE IF NOT EXISTS document (
+	id INTEGER PRIMARY KEY,
+	schema_version INTEGER NOT NULL,
+	data TEXT NOT NULL
+)`,
+	`CREATE TABLE IF NOT EXISTS records (
+	position INTEGER PRIMARY KEY,
+	xref TEXT NOT NULL,
+	type TEXT NOT NULL,
+	data TEXT NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS records_xref ON records (xref)`,
+	`CREATE TABLE IF NOT EXISTS tags (
+	record INTEGER NOT NULL,
+	seq INTEGER NOT NULL,
+	level INTEGER NOT NULL,
+	tag TEXT NOT NULL,
+	value TEXT NOT NULL,
+	xref TEXT NOT NULL,
+	PRIMARY KEY (record, seq)
+)`,
+	`CREATE INDEX IF NOT EXISTS tags_tag ON tags (tag)`,
+	`CREATE TABLE IF NOT EXISTS individuals (
+	xref TEXT NOT NULL,
+	record INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	given TEXT NOT NULL,
+	surname TEXT NOT NULL,
+	sex TEXT NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS individuals_xref ON individuals (xref)`,
+	`CREATE INDEX IF NOT EXISTS individuals_surname ON individuals (surname)`,
+	`CREATE TABLE IF NOT EXISTS families (
+	xref TEXT NOT NULL,
+	record INTEGER NOT NULL,
+	husband TEXT NOT NULL,
+	wife TEXT NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS families_xref ON families (xref)`,
+	`CREATE TABLE IF NOT EXISTS family_children (
+	family TEXT NOT NULL,
+	child TEXT NOT NULL,
+	seq INTEGER NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS family_children_child ON family_children (child)`,
+	`CREATE TABLE IF NOT EXISTS events (
+	record INTEGER NOT NULL,
+	xref TEXT NOT NULL,
+	seq INTEGER NOT NULL,
+	tag TEXT NOT NULL,
+	type TEXT NOT NULL,
+	date TEXT NOT NULL,
+	place TEXT NOT NULL,
+	year INTEGER
+)`,
+	`CREATE INDEX IF NOT EXISTS events_tag_year ON events (tag, year)`,
+	`CREATE TABLE IF NOT EXISTS sources (
+	xref TEXT NOT NULL,
+	record INTEGER NOT NULL,
+	title TEXT NOT NULL,
+	author TEXT NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS sources_xref ON sources (xref)`,
+	`CREATE TABLE IF NOT EXISTS citations (
+	record INTEGER NOT NULL,
+	xref TEXT NOT NULL,
+	source TEXT NOT NULL,
+	page TEXT NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS citations_source ON citations (source)`,
+}
+
+// tables lists the tables Save clears before writing a document.
+var tables = []string{
+	"document", "records", "tags", "individuals", "families",
+	"family_children", "events", "sources", "citations",
+}
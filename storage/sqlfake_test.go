@@ -0,0 +1,292 @@
+package storage
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeDriver is a database/sql driver for tests that understands the
+// statements this package runs: CREATE (ignored), DELETE FROM t,
+// INSERT INTO t (...) VALUES (...), and SELECT ... FROM t with "c = ?"
+// and "c BETWEEN ? AND ?" conditions joined by AND, and ORDER BY. Each
+// data source name is a separate in-memory database.
+type fakeDriver struct {
+	mu  sync.Mutex
+	dbs map[string]*fakeDB
+}
+
+type fakeDB struct {
+	mu     sync.Mutex
+	tables map[string][]map[string]driver.Value
+
+	// failOn, if set, makes every statement containing it fail, and
+	// BEGIN and COMMIT fail the transaction calls. failRows, if set,
+	// makes iterating the rows of a query containing it fail.
+	failOn   string
+	failRows string
+}
+
+// errFake is the error of the statements a failing fake database fails.
+var errFake = errors.New("fake: injected failure")
+
+var fake = &fakeDriver{dbs: make(map[string]*fakeDB)}
+
+func init() {
+	sql.Register("storagefake", fake)
+}
+
+// openFake opens a new fake database for t.
+func openFake(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("storagefake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// failFake makes the fake database db, opened by openFake for t, fail
+// the statements containing failOn and the reading of the rows of queries
+// containing failRows.
+func failFake(t *testing.T, db *sql.DB, failOn, failRows string) {
+	t.Helper()
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	fdb := fakeDatabase(t)
+	fdb.mu.Lock()
+	fdb.failOn, fdb.failRows = failOn, failRows
+	fdb.mu.Unlock()
+}
+
+// fakeDatabase returns the fake database opened by openFake for t.
+func fakeDatabase(t *testing.T) *fakeDB {
+	t.Helper()
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	db, ok := fake.dbs[t.Name()]
+	if !ok {
+		t.Fatalf("fake: no database for %s", t.Name())
+	}
+	return db
+}
+
+// fails reports whether the statement or call named query should fail.
+func (db *fakeDB) fails(query string) bool {
+	return db.failOn != "" && strings.Contains(query, db.failOn)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	db, ok := d.dbs[name]
+	if !ok {
+		db = &fakeDB{tables: make(map[string][]map[string]driver.Value)}
+		d.dbs[name] = db
+	}
+	return &fakeConn{db: db}, nil
+}
+
+type fakeConn struct {
+	db       *fakeDB
+	snapshot map[string][]map[string]driver.Value
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: strings.Join(strings.Fields(query), " ")}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	if c.db.fails("BEGIN") {
+		return nil, errFake
+	}
+	c.snapshot = make(map[string][]map[string]driver.Value, len(c.db.tables))
+	for name, rows := range c.db.tables {
+		c.snapshot[name] = append([]map[string]driver.Value(nil), rows...)
+	}
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	if c.db.fails("COMMIT") {
+		c.db.tables, c.snapshot = c.snapshot, nil
+		return errFake
+	}
+	c.snapshot = nil
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.tables, c.snapshot = c.snapshot, nil
+	return nil
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+var (
+	insertRE  = regexp.MustCompile(`^INSERT INTO (\w+) \(([^)]*)\) VALUES`)
+	selectRE  = regexp.MustCompile(`^SELECT (.+?) FROM (\w+)(?: WHERE (.+?))?(?: ORDER BY (.+))?$`)
+	betweenRE = regexp.MustCompile(`^(\w+) BETWEEN \? AND \?`)
+	equalRE   = regexp.MustCompile(`^(\w+) = \?`)
+)
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	db := s.conn.db
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.fails(s.query) {
+		return nil, errFake
+	}
+	switch {
+	case strings.HasPrefix(s.query, "CREATE "):
+	case strings.HasPrefix(s.query, "DELETE FROM "):
+		delete(db.tables, strings.TrimPrefix(s.query, "DELETE FROM "))
+	default:
+		m := insertRE.FindStringSubmatch(s.query)
+		if m == nil {
+			return nil, fmt.Errorf("fake: unsupported statement %q", s.query)
+		}
+		columns := strings.Split(m[2], ", ")
+		if len(columns) != len(args) {
+			return nil, fmt.Errorf("fake: %d columns, %d values", len(columns), len(args))
+		}
+		row := make(map[string]driver.Value, len(columns))
+		for i, column := range columns {
+			row[column] = args[i]
+		}
+		db.tables[m[1]] = append(db.tables[m[1]], row)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	m := selectRE.FindStringSubmatch(s.query)
+	if m == nil {
+		return nil, fmt.Errorf("fake: unsupported query %q", s.query)
+	}
+	columns := strings.Split(m[1], ", ")
+	db := s.conn.db
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.fails(s.query) {
+		return nil, errFake
+	}
+
+	var matched []map[string]driver.Value
+	for _, row := range db.tables[m[2]] {
+		ok, err := matches(row, m[3], args)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, row)
+		}
+	}
+	if m[4] != "" {
+		order := strings.Split(m[4], ", ")
+		sort.SliceStable(matched, func(i, j int) bool {
+			for _, column := range order {
+				if c := compareValues(matched[i][column], matched[j][column]); c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+	}
+	rows := &fakeRows{columns: columns, rows: matched}
+	if db.failRows != "" && strings.Contains(s.query, db.failRows) {
+		rows.err = errFake
+	}
+	return rows, nil
+}
+
+// matches evaluates a WHERE clause against row.
+func matches(row map[string]driver.Value, where string, args []driver.Value) (bool, error) {
+	for where != "" {
+		if m := betweenRE.FindStringSubmatch(where); m != nil {
+			if row[m[1]] == nil || compareValues(row[m[1]], args[0]) < 0 || compareValues(row[m[1]], args[1]) > 0 {
+				return false, nil
+			}
+			where, args = where[len(m[0]):], args[2:]
+		} else if m := equalRE.FindStringSubmatch(where); m != nil {
+			if row[m[1]] == nil || compareValues(row[m[1]], args[0]) != 0 {
+				return false, nil
+			}
+			where, args = where[len(m[0]):], args[1:]
+		} else {
+			return false, fmt.Errorf("fake: unsupported condition %q", where)
+		}
+		where = strings.TrimPrefix(where, " AND ")
+	}
+	return true, nil
+}
+
+// compareValues orders int64 and string values; nil sorts first.
+func compareValues(a, b driver.Value) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	ai, aInt := a.(int64)
+	bi, bInt := b.(int64)
+	if aInt && bInt {
+		switch {
+		case ai < bi:
+			return -1
+		case ai > bi:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+type fakeRows struct {
+	columns []string
+	rows    []map[string]driver.Value
+	err     error // returned instead of io.EOF after the rows
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		if r.err != nil {
+			return r.err
+		}
+		return io.EOF
+	}
+	for i, column := range r.columns {
+		dest[i] = r.rows[0][column]
+	}
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,5 @@
+// Package sqlitetest runs the storage package against a real SQLite
+// database, through the modernc.org/sqlite driver. It is a separate module
+// so that the gedcom-go module itself stays free of third-party
+// dependencies; run its tests from this directory with go test.
+package sqlitetest
@@ -0,0 +1,180 @@
+package sqlitetest
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+	"github.com/cacack/gedcom-go/v2/storage"
+)
+
+const sampleGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /Smith/
+1 SEX M
+1 BIRT
+2 DATE 12 MAR 1852
+2 PLAC Boston
+2 SOUR @S1@
+3 PAGE p. 4
+1 FAMS @F1@
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 SEX F
+1 FAMS @F1@
+0 @I3@ INDI
+1 NAME Ann /Smith/
+1 BIRT
+2 DATE ABT 1880
+1 FAMC @F1@
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 CHIL @I3@
+1 MARR
+2 DATE 1875
+0 @S1@ SOUR
+1 TITL Boston Births
+1 AUTH City Clerk
+0 TRLR
+`
+
+// openSQLite opens a new SQLite database file for t.
+func openSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "tree.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func decode(t *testing.T, r io.Reader) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.DecodeWithOptions(r, &decoder.DecodeOptions{PreserveFormatting: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func encode(t *testing.T, doc *gedcom.Document) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	return buf.String()
+}
+
+func xrefs(records []*gedcom.Record) string {
+	var out []string
+	for _, r := range records {
+		out = append(out, r.XRef)
+	}
+	return strings.Join(out, " ")
+}
+
+func TestSQLite_SaveLoad(t *testing.T) {
+	ctx := context.Background()
+	for _, path := range []string{
+		"../../testdata/gedcom-5.5.1/comprehensive.ged",
+		"../../testdata/gedcom-7.0/maximal70.ged",
+	} {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			doc := decode(t, f)
+
+			store := storage.New(openSQLite(t))
+			if err := store.Save(ctx, doc); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			// A second save replaces the first.
+			if err := store.Save(ctx, doc); err != nil {
+				t.Fatalf("second Save() error = %v", err)
+			}
+			got, err := store.Load(ctx)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if encode(t, got) != encode(t, doc) {
+				t.Error("loaded document does not encode to the saved one")
+			}
+		})
+	}
+}
+
+func TestSQLite_Queries(t *testing.T) {
+	ctx := context.Background()
+	store := storage.New(openSQLite(t))
+	if err := store.Save(ctx, decode(t, strings.NewReader(sampleGEDCOM))); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	record, err := store.Record(ctx, "@I3@")
+	if err != nil || record.XRef != "@I3@" {
+		t.Fatalf("Record(@I3@) = %v, %v", record, err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		args  []interface{}
+		want  string
+	}{
+		{"surname", "SELECT xref FROM individuals WHERE surname = ? ORDER BY record", []interface{}{"Smith"}, "@I1@ @I3@"},
+		{"children", "SELECT child FROM family_children WHERE family = ? ORDER BY seq", []interface{}{"@F1@"}, "@I3@"},
+		{"births by year", "SELECT xref FROM events WHERE tag = ? AND year BETWEEN ? AND ?", []interface{}{"BIRT", 1850, 1860}, "@I1@"},
+		{"citing records", "SELECT xref, page FROM citations WHERE source = ?", []interface{}{"@S1@"}, "@I1@"},
+		{"raw tags", "SELECT DISTINCT r.xref FROM tags t JOIN records r ON r.position = t.record WHERE t.tag = ? ORDER BY r.position", []interface{}{"CHIL"}, "@F1@"},
+		{"spouses", "SELECT husband FROM families UNION ALL SELECT wife FROM families", nil, "@I1@ @I2@"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := store.Query(ctx, tt.query, tt.args...)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if got := xrefs(records); got != tt.want {
+				t.Errorf("records = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLite_FailedSaveKeepsDocument(t *testing.T) {
+	ctx := context.Background()
+	store := storage.New(openSQLite(t))
+	if err := store.Save(ctx, decode(t, strings.NewReader(sampleGEDCOM))); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	bad := &gedcom.Document{Records: []*gedcom.Record{
+		{XRef: "@I9@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Family{}},
+	}}
+	if err := store.Save(ctx, bad); err == nil {
+		t.Fatal("Save(mismatched entity) error = nil, want error")
+	}
+	smiths, err := store.IndividualsBySurname(ctx, "Smith")
+	if err != nil || xrefs(smiths) != "@I1@ @I3@" {
+		t.Errorf("after failed save, IndividualsBySurname() = %q, %v", xrefs(smiths), err)
+	}
+}
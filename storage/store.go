@@ -0,0 +1,339 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+	"github.com/cacack/gedcom-go/v2/gedcomjson"
+)
+
+// ErrNoDocument is returned by Load when the database holds no document.
+var ErrNoDocument = errors.New("storage: no document saved")
+
+// ErrRecordNotFound is returned by Record when no record has the XRef.
+var ErrRecordNotFound = errors.New("storage: record not found")
+
+// Store saves documents to and loads them from a SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+// New returns a Store for db, which must be a SQLite database opened with
+// a driver registered by the caller.
+func New(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// Init creates the tables and indexes of Schema that do not exist yet.
+func (s *Store) Init(ctx context.Context) error {
+	for _, stmt := range Schema {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("storage: init: %w", err)
+		}
+	}
+	return nil
+}
+
+// Save replaces the stored document with doc, creating the tables first
+// if needed. It writes in a single transaction: on error the previous
+// document is kept.
+func (s *Store) Save(ctx context.Context, doc *gedcom.Document) error {
+	if doc == nil {
+		return errors.New("storage: document is nil")
+	}
+	if err := s.Init(ctx); err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("storage: %w", err)
+	}
+	w := &writer{ctx: ctx, tx: tx}
+	w.document(doc)
+	if w.err != nil {
+		_ = tx.Rollback()
+		return w.err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("storage: %w", err)
+	}
+	return nil
+}
+
+// Load reads the stored document. It returns ErrNoDocument if nothing has
+// been saved.
+func (s *Store) Load(ctx context.Context) (*gedcom.Document, error) {
+	var version int
+	var data string
+	err := s.db.QueryRowContext(ctx, "SELECT schema_version, data FROM document WHERE id = ?", 1).Scan(&version, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNoDocument
+	}
+	if err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+	if version != SchemaVersion {
+		return nil, fmt.Errorf("storage: unsupported schema version %d", version)
+	}
+	doc, err := gedcomjson.Unmarshal([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT data FROM records ORDER BY position")
+	if err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		doc.Records = append(doc.Records, record)
+		if record.XRef != "" {
+			if _, exists := doc.XRefMap[record.XRef]; !exists {
+				doc.XRefMap[record.XRef] = record
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+	return doc, nil
+}
+
+// Record reads the record with the given XRef, with its entity, without
+// loading the rest of the document. It returns ErrRecordNotFound if no
+// record has the XRef.
+func (s *Store) Record(ctx context.Context, xref string) (*gedcom.Record, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT data FROM records WHERE xref = ? ORDER BY position", xref)
+	if err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("storage: %w", err)
+		}
+		return nil, ErrRecordNotFound
+	}
+	return scanRecord(rows)
+}
+
+// Query runs a SQL query whose first column is an XRef and returns the
+// records, in row order. XRefs that name no record are skipped.
+//
+//	recs, err := store.Query(ctx, "SELECT child FROM family_children WHERE family = ?", "@F1@")
+func (s *Store) Query(ctx context.Context, query string, args ...interface{}) ([]*gedcom.Record, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+	var xrefs []string
+	for rows.Next() {
+		dest := make([]interface{}, len(columns))
+		var xref sql.NullString
+		dest[0] = &xref
+		for i := 1; i < len(dest); i++ {
+			dest[i] = new(interface{})
+		}
+		if err := rows.Scan(dest...); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("storage: %w", err)
+		}
+		if xref.Valid && xref.String != "" {
+			xrefs = append(xrefs, xref.String)
+		}
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+
+	records := make([]*gedcom.Record, 0, len(xrefs))
+	for _, xref := range xrefs {
+		record, err := s.Record(ctx, xref)
+		if errors.Is(err, ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// IndividualsBySurname returns the INDI records whose first name has the
+// given surname, in document order.
+func (s *Store) IndividualsBySurname(ctx context.Context, surname string) ([]*gedcom.Record, error) {
+	return s.Query(ctx, "SELECT xref FROM individuals WHERE surname = ? ORDER BY record", surname)
+}
+
+// scanRecord reads a record from the data column of rows.
+func scanRecord(rows *sql.Rows) (*gedcom.Record, error) {
+	var data string
+	if err := rows.Scan(&data); err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+	record, err := gedcomjson.UnmarshalRecord([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+	return record, nil
+}
+
+// writer writes a document in a transaction, keeping the first error.
+type writer struct {
+	ctx context.Context
+	tx  *sql.Tx
+	err error
+}
+
+// exec runs a statement unless an earlier one failed.
+func (w *writer) exec(query string, args ...interface{}) {
+	if w.err != nil {
+		return
+	}
+	if _, err := w.tx.ExecContext(w.ctx, query, args...); err != nil {
+		w.err = fmt.Errorf("storage: %w", err)
+	}
+}
+
+// document clears the tables and writes doc.
+func (w *writer) document(doc *gedcom.Document) {
+	for _, table := range tables {
+		w.exec("DELETE FROM " + table)
+	}
+
+	// The document row holds everything but the records.
+	data, err := gedcomjson.Marshal(&gedcom.Document{
+		Header:     doc.Header,
+		Trailer:    doc.Trailer,
+		Vendor:     doc.Vendor,
+		Schema:     doc.Schema,
+		Formatting: doc.Formatting,
+	})
+	if err != nil {
+		w.err = fmt.Errorf("storage: %w", err)
+		return
+	}
+	w.exec("INSERT INTO document (id, schema_version, data) VALUES (?, ?, ?)", 1, SchemaVersion, string(data))
+
+	position := 0
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		position++
+		w.record(position, record)
+	}
+}
+
+// record writes a record and its index rows.
+func (w *writer) record(position int, record *gedcom.Record) {
+	data, err := gedcomjson.MarshalRecord(record)
+	if err != nil {
+		w.err = fmt.Errorf("storage: %w", err)
+		return
+	}
+	w.exec("INSERT INTO records (position, xref, type, data) VALUES (?, ?, ?, ?)",
+		position, record.XRef, string(record.Type), string(data))
+
+	for seq, tag := range record.Tags {
+		if tag != nil {
+			w.exec("INSERT INTO tags (record, seq, level, tag, value, xref) VALUES (?, ?, ?, ?, ?, ?)",
+				position, seq, tag.Level, tag.Tag, tag.Value, tag.XRef)
+		}
+	}
+
+	switch e := record.Entity.(type) {
+	case *gedcom.Individual:
+		var name gedcom.PersonalName
+		if len(e.Names) > 0 && e.Names[0] != nil {
+			name = *e.Names[0]
+		}
+		w.exec("INSERT INTO individuals (xref, record, name, given, surname, sex) VALUES (?, ?, ?, ?, ?, ?)",
+			record.XRef, position, name.Full, name.Given, name.Surname, e.Sex)
+		w.events(position, record.XRef, e.Events)
+	case *gedcom.Family:
+		w.exec("INSERT INTO families (xref, record, husband, wife) VALUES (?, ?, ?, ?)",
+			record.XRef, position, e.Husband, e.Wife)
+		for seq, child := range e.Children {
+			w.exec("INSERT INTO family_children (family, child, seq) VALUES (?, ?, ?)", record.XRef, child, seq)
+		}
+		w.events(position, record.XRef, e.Events)
+	case *gedcom.Source:
+		w.exec("INSERT INTO sources (xref, record, title, author) VALUES (?, ?, ?, ?)",
+			record.XRef, position, e.Title, e.Author)
+	}
+
+	forEachCitation(record.Entity, func(c *gedcom.SourceCitation) {
+		if c.SourceXRef != "" {
+			w.exec("INSERT INTO citations (record, xref, source, page) VALUES (?, ?, ?, ?)",
+				position, record.XRef, c.SourceXRef, c.Page)
+		}
+	})
+}
+
+// events writes the event rows of a record. The year is that of the
+// event's sort date (see gedcom.Event.OrderDate), or NULL.
+func (w *writer) events(position int, xref string, events []*gedcom.Event) {
+	for seq, e := range events {
+		if e == nil {
+			continue
+		}
+		var year sql.NullInt64
+		if d := e.OrderDate(); d != nil && d.Year != 0 {
+			year = sql.NullInt64{Int64: int64(d.Year), Valid: true}
+		}
+		w.exec("INSERT INTO events (record, xref, seq, tag, type, date, place, year) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			position, xref, seq, string(e.Type), e.EventTypeDetail, e.Date, e.Place, year)
+	}
+}
+
+// forEachCitation calls fn for every source citation held by a typed entity.
+func forEachCitation(entity interface{}, fn func(*gedcom.SourceCitation)) {
+	visit := func(citations []*gedcom.SourceCitation) {
+		for _, c := range citations {
+			if c != nil {
+				fn(c)
+			}
+		}
+	}
+	visitEvents := func(events []*gedcom.Event) {
+		for _, e := range events {
+			if e != nil {
+				visit(e.SourceCitations)
+			}
+		}
+	}
+
+	switch e := entity.(type) {
+	case *gedcom.Individual:
+		visit(e.SourceCitations)
+		visitEvents(e.Events)
+		for _, a := range e.Attributes {
+			if a != nil {
+				visit(a.SourceCitations)
+			}
+		}
+	case *gedcom.Family:
+		visit(e.SourceCitations)
+		visitEvents(e.Events)
+	case *gedcom.MediaObject:
+		visit(e.SourceCitations)
+	case *gedcom.SharedNote:
+		visit(e.SourceCitations)
+	}
+}
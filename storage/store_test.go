@@ -0,0 +1,360 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const sampleGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /Smith/
+1 SEX M
+1 BIRT
+2 DATE 12 MAR 1852
+2 PLAC Boston
+2 SOUR @S1@
+3 PAGE p. 4
+1 FAMS @F1@
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 SEX F
+1 FAMS @F1@
+0 @I3@ INDI
+1 NAME Ann /Smith/
+1 BIRT
+2 DATE ABT 1880
+1 FAMC @F1@
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 CHIL @I3@
+1 MARR
+2 DATE 1875
+0 @S1@ SOUR
+1 TITL Boston Births
+1 AUTH City Clerk
+0 TRLR
+`
+
+func decodeSample(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(sampleGEDCOM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func encode(t *testing.T, doc *gedcom.Document) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	return buf.String()
+}
+
+func TestStore_SaveLoad(t *testing.T) {
+	ctx := context.Background()
+	store := New(openFake(t))
+	doc := decodeSample(t)
+	if err := store.Save(ctx, doc); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if want := encode(t, doc); encode(t, got) != want {
+		t.Errorf("loaded document encodes to\n%s\nwant\n%s", encode(t, got), want)
+	}
+	if got.GetIndividual("@I1@") == nil || got.GetFamily("@F1@").Husband != "@I1@" {
+		t.Error("loaded document does not resolve its records")
+	}
+}
+
+func TestStore_SaveLoad_Testdata(t *testing.T) {
+	ctx := context.Background()
+	for _, path := range []string{
+		"../testdata/gedcom-5.5.1/comprehensive.ged",
+		"../testdata/gedcom-7.0/maximal70.ged",
+	} {
+		t.Run(path, func(t *testing.T) {
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			doc, err := decoder.DecodeWithOptions(f, &decoder.DecodeOptions{PreserveFormatting: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			store := New(openFake(t))
+			if err := store.Save(ctx, doc); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			got, err := store.Load(ctx)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if encode(t, got) != encode(t, doc) {
+				t.Error("loaded document does not encode to the saved one")
+			}
+		})
+	}
+}
+
+func TestStore_Queries(t *testing.T) {
+	ctx := context.Background()
+	store := New(openFake(t))
+	if err := store.Save(ctx, decodeSample(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := store.Record(ctx, "@I3@")
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if ann, ok := record.GetIndividual(); !ok || ann.Names[0].Full != "Ann /Smith/" {
+		t.Errorf("Record(@I3@) = %+v", record.Entity)
+	}
+	if _, err := store.Record(ctx, "@X9@"); !errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("Record(@X9@) error = %v, want ErrRecordNotFound", err)
+	}
+
+	tests := []struct {
+		name  string
+		query func() ([]*gedcom.Record, error)
+		want  string
+	}{
+		{"surname", func() ([]*gedcom.Record, error) { return store.IndividualsBySurname(ctx, "Smith") }, "@I1@ @I3@"},
+		{"children", func() ([]*gedcom.Record, error) {
+			return store.Query(ctx, "SELECT child FROM family_children WHERE family = ? ORDER BY seq", "@F1@")
+		}, "@I3@"},
+		{"births by year", func() ([]*gedcom.Record, error) {
+			return store.Query(ctx, "SELECT xref FROM events WHERE tag = ? AND year BETWEEN ? AND ?", "BIRT", 1850, 1860)
+		}, "@I1@"},
+		{"citing records", func() ([]*gedcom.Record, error) {
+			return store.Query(ctx, "SELECT xref, page FROM citations WHERE source = ?", "@S1@")
+		}, "@I1@"},
+		{"sources", func() ([]*gedcom.Record, error) {
+			return store.Query(ctx, "SELECT xref FROM sources WHERE author = ?", "City Clerk")
+		}, "@S1@"},
+		{"raw tags", func() ([]*gedcom.Record, error) {
+			return store.Query(ctx, "SELECT xref FROM records WHERE type = ? ORDER BY position", "FAM")
+		}, "@F1@"},
+		{"unknown xrefs skipped", func() ([]*gedcom.Record, error) {
+			return store.Query(ctx, "SELECT husband FROM families WHERE xref = ?", "@F9@")
+		}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := tt.query()
+			if err != nil {
+				t.Fatalf("query error = %v", err)
+			}
+			var xrefs []string
+			for _, r := range records {
+				xrefs = append(xrefs, r.XRef)
+			}
+			if got := strings.Join(xrefs, " "); got != tt.want {
+				t.Errorf("records = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStore_SaveReplaces(t *testing.T) {
+	ctx := context.Background()
+	store := New(openFake(t))
+	if err := store.Save(ctx, decodeSample(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	doc := &gedcom.Document{Header: &gedcom.Header{Version: gedcom.Version70}}
+	if _, err := doc.AddIndividual(&gedcom.Individual{Names: []*gedcom.PersonalName{{Full: "Eve /Smith/", Surname: "Smith"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(ctx, doc); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	smiths, err := store.IndividualsBySurname(ctx, "Smith")
+	if err != nil || len(smiths) != 1 {
+		t.Fatalf("IndividualsBySurname() = %d records, %v; want only the new document's", len(smiths), err)
+	}
+
+	// A failed save keeps the previous document.
+	bad := &gedcom.Document{Records: []*gedcom.Record{
+		{XRef: "@I9@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Family{}},
+	}}
+	if err := store.Save(ctx, bad); err == nil {
+		t.Fatal("Save(mismatched entity) error = nil, want error")
+	}
+	got, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got.Header.Version != gedcom.Version70 || len(got.Records) != 1 {
+		t.Errorf("after failed save, loaded %d records of version %s", len(got.Records), got.Header.Version)
+	}
+}
+
+func TestStore_Errors(t *testing.T) {
+	ctx := context.Background()
+	store := New(openFake(t))
+	if err := store.Save(ctx, nil); err == nil {
+		t.Error("Save(nil) error = nil, want error")
+	}
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if _, err := store.Load(ctx); !errors.Is(err, ErrNoDocument) {
+		t.Errorf("Load() error = %v, want ErrNoDocument", err)
+	}
+}
+
+func TestStore_SaveDriverErrors(t *testing.T) {
+	ctx := context.Background()
+	for _, failOn := range []string{"CREATE", "BEGIN", "DELETE FROM", "INSERT INTO document", "INSERT INTO records", "INSERT INTO individuals", "COMMIT"} {
+		t.Run(failOn, func(t *testing.T) {
+			db := openFake(t)
+			store := New(db)
+			if err := store.Save(ctx, decodeSample(t)); err != nil {
+				t.Fatal(err)
+			}
+			failFake(t, db, failOn, "")
+			doc := &gedcom.Document{Header: &gedcom.Header{Version: gedcom.Version70}}
+			if _, err := doc.AddIndividual(&gedcom.Individual{}); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Save(ctx, doc); !errors.Is(err, errFake) {
+				t.Fatalf("Save() error = %v, want the driver's", err)
+			}
+
+			// The failed save is rolled back.
+			failFake(t, db, "", "")
+			got, err := store.Load(ctx)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if len(got.Records) != len(decodeSample(t).Records) {
+				t.Errorf("after failed save, loaded %d records", len(got.Records))
+			}
+		})
+	}
+}
+
+func TestStore_ReadDriverErrors(t *testing.T) {
+	ctx := context.Background()
+	surnames := func(store *Store) error {
+		_, err := store.IndividualsBySurname(ctx, "Smith")
+		return err
+	}
+	load := func(store *Store) error {
+		_, err := store.Load(ctx)
+		return err
+	}
+	record := func(store *Store) error {
+		_, err := store.Record(ctx, "@I1@")
+		return err
+	}
+	missing := func(store *Store) error {
+		_, err := store.Record(ctx, "@X9@")
+		return err
+	}
+	tests := []struct {
+		name             string
+		failOn, failRows string
+		read             func(*Store) error
+	}{
+		{"load document", "FROM document", "", load},
+		{"load records", "FROM records", "", load},
+		{"load record rows", "", "FROM records", load},
+		{"record", "FROM records", "", record},
+		{"record rows", "", "FROM records", missing},
+		{"query", "FROM individuals", "", surnames},
+		{"query rows", "", "FROM individuals", surnames},
+		{"query records", "FROM records", "", surnames},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openFake(t)
+			store := New(db)
+			if err := store.Save(ctx, decodeSample(t)); err != nil {
+				t.Fatal(err)
+			}
+			failFake(t, db, tt.failOn, tt.failRows)
+			if err := tt.read(store); !errors.Is(err, errFake) {
+				t.Errorf("error = %v, want the driver's", err)
+			}
+		})
+	}
+}
+
+func TestStore_ReadBadRows(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		insert [][]interface{}
+		read   func(*Store) error
+	}{
+		{"schema version", [][]interface{}{{"INSERT INTO document (id, schema_version, data) VALUES (?, ?, ?)", 1, 99, "{}"}}, func(store *Store) error {
+			_, err := store.Load(ctx)
+			return err
+		}},
+		{"document JSON", [][]interface{}{{"INSERT INTO document (id, schema_version, data) VALUES (?, ?, ?)", 1, 1, "not json"}}, func(store *Store) error {
+			_, err := store.Load(ctx)
+			return err
+		}},
+		{"record JSON", [][]interface{}{{"INSERT INTO records (position, xref, type, data) VALUES (?, ?, ?, ?)", 1, "@I1@", "INDI", "not json"}}, func(store *Store) error {
+			_, err := store.Record(ctx, "@I1@")
+			return err
+		}},
+		{"NULL record", [][]interface{}{{"INSERT INTO records (position, xref, type, data) VALUES (?, ?, ?, ?)", 1, "@I1@", "INDI", nil}}, func(store *Store) error {
+			_, err := store.Record(ctx, "@I1@")
+			return err
+		}},
+		{"loaded record JSON", [][]interface{}{
+			{"INSERT INTO document (id, schema_version, data) VALUES (?, ?, ?)", 1, 1, "{}"},
+			{"INSERT INTO records (position, xref, type, data) VALUES (?, ?, ?, ?)", 1, "@I1@", "INDI", "not json"},
+		}, func(store *Store) error {
+			_, err := store.Load(ctx)
+			return err
+		}},
+		{"queried record JSON", [][]interface{}{
+			{"INSERT INTO individuals (xref, record, name, given, surname, sex) VALUES (?, ?, ?, ?, ?, ?)", "@I1@", 1, "", "", "Smith", ""},
+			{"INSERT INTO records (position, xref, type, data) VALUES (?, ?, ?, ?)", 1, "@I1@", "INDI", "not json"},
+		}, func(store *Store) error {
+			_, err := store.IndividualsBySurname(ctx, "Smith")
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openFake(t)
+			store := New(db)
+			if err := store.Init(ctx); err != nil {
+				t.Fatal(err)
+			}
+			for _, stmt := range tt.insert {
+				if _, err := db.ExecContext(ctx, stmt[0].(string), stmt[1:]...); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := tt.read(store); err == nil {
+				t.Error("error = nil, want error")
+			}
+		})
+	}
+}
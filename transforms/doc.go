@@ -0,0 +1,40 @@
+// Package transforms provides composable document rewrites for GEDCOM
+// documents, the building blocks of clean-up and export workflows.
+//
+// A Transform rewrites a document in place and reports what it changed.
+// Transforms can be run on their own or chained with a Pipeline, which
+// applies them in sequence and combines their reports:
+//
+//	p := transforms.NewPipeline(
+//	    transforms.FixHeaders(nil),
+//	    transforms.NormalizePlaces(nil, nil),
+//	    transforms.Privatize(nil),
+//	    transforms.StripMedia(),
+//	    transforms.RenumberXRefs(),
+//	)
+//	report, err := p.Apply(doc)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Print(report)
+//
+// Built-in transforms:
+//
+//   - Privatize: replace the details of presumed-living individuals, and
+//     the events of their families, with a "Living" placeholder.
+//   - NormalizePlaces: rewrite variant place spellings to a canonical form
+//     (see place.Standardize).
+//   - RenumberXRefs: renumber every record sequentially per type
+//     (@I1@, @F1@, @S1@, ...), updating all references.
+//   - StripMedia: remove media object records and every link to them.
+//   - StripNotes: remove every note, or truncate long ones, for small
+//     structure-only exports.
+//   - FixHeaders: fill in missing header fields (version, encoding,
+//     source system, submitter).
+//
+// Custom steps can be written as types implementing Transform or with
+// Func. Transforms mutate the document they are given; apply them to
+// doc.Clone() to keep the original. Records are kept consistent in both
+// their raw Tags and typed entities, so the result encodes and queries
+// alike.
+package transforms
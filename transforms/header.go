@@ -0,0 +1,217 @@
+package transforms
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// DefaultSourceSystem is the HEAD.SOUR FixHeaders writes when a header
+// names no source system.
+const DefaultSourceSystem = "gedcom-go"
+
+// HeaderOptions configures FixHeaders.
+type HeaderOptions struct {
+	// Version is written when the header has none.
+	// Default (""): the document's MinimumVersion.
+	Version gedcom.Version
+
+	// SourceSystem is written when the header has none.
+	// Default (""): DefaultSourceSystem.
+	SourceSystem string
+
+	// SubmitterName names the submitter record created when a GEDCOM 5.5
+	// or 5.5.1 document has none. Default (""): "Unknown".
+	SubmitterName string
+}
+
+type fixHeaders struct {
+	opts HeaderOptions
+}
+
+// FixHeaders returns a transform that fills in the header fields a reader
+// needs:
+//
+//   - a header, when the document has none;
+//   - the GEDCOM version, when missing;
+//   - the character encoding, when missing or when a GEDCOM 7.0 header
+//     names anything but UTF-8;
+//   - the source system, when missing;
+//   - for GEDCOM 5.5 and 5.5.1, the required SUBM link: to the first
+//     submitter record, or to a new one when there is none;
+//   - for GEDCOM 7.0, where SUBM is optional, a SUBM link to a missing
+//     submitter is dropped;
+//   - a SUBN link to a missing submission record is dropped.
+//
+// The typed Header fields and, when present, the raw header tags are both
+// updated. If opts is nil, default options are used.
+func FixHeaders(opts *HeaderOptions) Transform {
+	t := &fixHeaders{}
+	if opts != nil {
+		t.opts = *opts
+	}
+	if t.opts.SourceSystem == "" {
+		t.opts.SourceSystem = DefaultSourceSystem
+	}
+	if t.opts.SubmitterName == "" {
+		t.opts.SubmitterName = "Unknown"
+	}
+	return t
+}
+
+// Name returns "fix-headers".
+func (t *fixHeaders) Name() string { return "fix-headers" }
+
+// Apply repairs the header of doc.
+func (t *fixHeaders) Apply(doc *gedcom.Document) (*Report, error) {
+	report := newReport(t.Name())
+	if doc == nil {
+		return report, errNilDocument
+	}
+	if doc.Header == nil {
+		doc.Header = &gedcom.Header{}
+		report.add("", "header created")
+	}
+	t.fixFields(doc, report)
+
+	h := doc.Header
+	if h.Version == gedcom.Version55 || h.Version == gedcom.Version551 {
+		if err := t.fixSubmitter(doc, report); err != nil {
+			return report, err
+		}
+	} else if h.Submitter != "" && doc.GetSubmitter(h.Submitter) == nil {
+		report.add("", "dangling submitter link %s removed", h.Submitter)
+		h.Submitter = ""
+		removeHeaderTag(h, "SUBM")
+	}
+	fixSubmission(doc, report)
+	return report, nil
+}
+
+// fixFields fills in a missing version and source system, and a missing
+// encoding or one GEDCOM 7.0 does not allow.
+func (t *fixHeaders) fixFields(doc *gedcom.Document, report *Report) {
+	h := doc.Header
+	if h.Version == "" {
+		h.Version = t.opts.Version
+		if h.Version == "" {
+			h.Version = doc.MinimumVersion()
+		}
+		setHeaderTag(h, string(h.Version), "GEDC", "VERS")
+		report.add("", "version set to %s", h.Version)
+	}
+	if h.Encoding == "" || (h.Version == gedcom.Version70 && h.Encoding != gedcom.EncodingUTF8) {
+		h.Encoding = gedcom.EncodingUTF8
+		setHeaderTag(h, string(h.Encoding), "CHAR")
+		report.add("", "encoding set to %s", h.Encoding)
+	}
+	if h.SourceSystem == "" {
+		h.SourceSystem = t.opts.SourceSystem
+		setHeaderTag(h, h.SourceSystem, "SOUR")
+		report.add("", "source system set to %s", h.SourceSystem)
+	}
+}
+
+// fixSubmission removes the header's SUBN link when it does not point to
+// a submission record.
+func fixSubmission(doc *gedcom.Document, report *Report) {
+	h := doc.Header
+	for _, tag := range h.Tags {
+		if tag == nil || tag.Level != 1 || tag.Tag != "SUBN" {
+			continue
+		}
+		if record := doc.GetRecord(tag.Value); record == nil || record.Type != "SUBN" {
+			report.add("", "dangling submission link %s removed", tag.Value)
+			removeHeaderTag(h, "SUBN")
+			return
+		}
+	}
+}
+
+// fixSubmitter links the header to a submitter record, creating one if
+// the document has none. A link to a missing record is replaced.
+func (t *fixHeaders) fixSubmitter(doc *gedcom.Document, report *Report) error {
+	h := doc.Header
+	if h.Submitter != "" && doc.GetSubmitter(h.Submitter) != nil {
+		return nil
+	}
+	if subs := doc.Submitters(); len(subs) > 0 && subs[0].XRef != "" {
+		h.Submitter = subs[0].XRef
+		setHeaderTag(h, h.Submitter, "SUBM")
+		report.add("", "submitter linked to %s", h.Submitter)
+		return nil
+	}
+
+	xref := ""
+	for n := 1; xref == "" || doc.GetRecord(xref) != nil; n++ {
+		xref = fmt.Sprintf("@U%d@", n)
+	}
+	if err := doc.SetSubmitter(&gedcom.Submitter{XRef: xref, Name: t.opts.SubmitterName}); err != nil {
+		return err
+	}
+	report.add(xref, "submitter record created and linked")
+	return nil
+}
+
+// removeHeaderTag removes every level 1 structure named tag, with its
+// subordinates, from h.Tags.
+func removeHeaderTag(h *gedcom.Header, tag string) {
+	kept := h.Tags[:0]
+	removing := false
+	for _, sub := range h.Tags {
+		if sub == nil {
+			continue
+		}
+		if sub.Level <= 1 {
+			removing = sub.Tag == tag
+		}
+		if !removing {
+			kept = append(kept, sub)
+		}
+	}
+	for i := len(kept); i < len(h.Tags); i++ {
+		h.Tags[i] = nil
+	}
+	h.Tags = kept
+}
+
+// setHeaderTag sets the value of the header tag at path (one or two tags
+// deep) in h.Tags, appending the missing structures at the end of the
+// header. Headers without raw tags are left alone; the encoder writes
+// them from the typed fields.
+func setHeaderTag(h *gedcom.Header, value string, path ...string) {
+	if len(h.Tags) == 0 {
+		return
+	}
+	parent := -1
+	for i, tag := range h.Tags {
+		if tag != nil && tag.Level == 1 && tag.Tag == path[0] {
+			parent = i
+			break
+		}
+	}
+	if len(path) == 1 {
+		if parent >= 0 {
+			h.Tags[parent].Value = value
+		} else {
+			h.Tags = append(h.Tags, &gedcom.Tag{Level: 1, Tag: path[0], Value: value})
+		}
+		return
+	}
+
+	if parent < 0 {
+		h.Tags = append(h.Tags,
+			&gedcom.Tag{Level: 1, Tag: path[0]},
+			&gedcom.Tag{Level: 2, Tag: path[1], Value: value})
+		return
+	}
+	end := parent + 1
+	for ; end < len(h.Tags) && h.Tags[end] != nil && h.Tags[end].Level > 1; end++ {
+		if h.Tags[end].Level == 2 && h.Tags[end].Tag == path[1] {
+			h.Tags[end].Value = value
+			return
+		}
+	}
+	child := &gedcom.Tag{Level: 2, Tag: path[1], Value: value}
+	h.Tags = append(h.Tags[:end], append([]*gedcom.Tag{child}, h.Tags[end:]...)...)
+}
@@ -0,0 +1,120 @@
+package transforms
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func TestFixHeaders(t *testing.T) {
+	t.Run("complete header is unchanged", func(t *testing.T) {
+		doc := decodeTest(t, testGEDCOM)
+		report, err := FixHeaders(nil).Apply(doc)
+		if err != nil || report.Changed() {
+			t.Errorf("Apply() = %+v, %v; want no changes", report.Changes, err)
+		}
+	})
+
+	t.Run("missing fields are filled in", func(t *testing.T) {
+		doc := decodeTest(t, `0 HEAD
+1 GEDC
+1 _APP custom
+0 @I1@ INDI
+1 NAME John /Doe/
+0 TRLR
+`)
+		doc.Header.Version = "" // the decoder assumes 5.5 when GEDC has no VERS
+		report, err := FixHeaders(&HeaderOptions{SourceSystem: "MyTool", SubmitterName: "Jane"}).Apply(doc)
+		if err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		h := doc.Header
+		if h.Version != gedcom.Version551 || h.Encoding != gedcom.EncodingUTF8 || h.SourceSystem != "MyTool" || h.Submitter != "@U1@" {
+			t.Errorf("header = %+v", h)
+		}
+		if subm := doc.GetSubmitter("@U1@"); subm == nil || subm.Name != "Jane" {
+			t.Errorf("submitter = %+v", subm)
+		}
+		if len(report.Changes) != 4 {
+			t.Errorf("Changes = %+v", report.Changes)
+		}
+
+		var lines []string
+		for _, tag := range h.Tags {
+			lines = append(lines, strings.TrimSpace(strings.Join([]string{string(rune('0' + tag.Level)), tag.Tag, tag.Value}, " ")))
+		}
+		got := strings.Join(lines, "|")
+		want := "1 GEDC|2 VERS 5.5.1|1 _APP custom|1 CHAR UTF-8|1 SOUR MyTool|1 SUBM @U1@"
+		if got != want {
+			t.Errorf("raw header tags = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("existing submitter is linked", func(t *testing.T) {
+		doc := decodeTest(t, `0 HEAD
+1 SOUR App
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+1 SUBM @MISSING@
+0 @SUB1@ SUBM
+1 NAME Tester
+0 TRLR
+`)
+		report, err := FixHeaders(nil).Apply(doc)
+		if err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if doc.Header.Submitter != "@SUB1@" || len(report.Changes) != 1 {
+			t.Errorf("Submitter = %q, Changes = %+v", doc.Header.Submitter, report.Changes)
+		}
+	})
+
+	t.Run("no header", func(t *testing.T) {
+		doc := &gedcom.Document{Records: []*gedcom.Record{
+			{XRef: "@N1@", Type: gedcom.RecordTypeSharedNote, Entity: &gedcom.SharedNote{XRef: "@N1@", Text: "x"}},
+		}}
+		if _, err := FixHeaders(nil).Apply(doc); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		h := doc.Header
+		if h == nil || h.Version != gedcom.Version70 || h.SourceSystem != DefaultSourceSystem || h.Submitter != "" {
+			t.Errorf("header = %+v", h)
+		}
+	})
+
+	t.Run("7.0 header forced to UTF-8", func(t *testing.T) {
+		doc := &gedcom.Document{Header: &gedcom.Header{Version: gedcom.Version70, Encoding: gedcom.EncodingANSEL, SourceSystem: "x"}}
+		report, err := FixHeaders(nil).Apply(doc)
+		if err != nil || doc.Header.Encoding != gedcom.EncodingUTF8 || len(report.Changes) != 1 {
+			t.Errorf("Encoding = %s, Changes = %+v, err = %v", doc.Header.Encoding, report.Changes, err)
+		}
+	})
+
+	t.Run("dangling 7.0 links are dropped", func(t *testing.T) {
+		doc := decodeTest(t, `0 HEAD
+1 SOUR App
+1 GEDC
+2 VERS 7.0
+1 SUBM @U9@
+1 SUBN @SN9@
+0 TRLR
+`)
+		report, err := FixHeaders(nil).Apply(doc)
+		if err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if doc.Header.Submitter != "" {
+			t.Errorf("Submitter = %q, want empty", doc.Header.Submitter)
+		}
+		for _, tag := range doc.Header.Tags {
+			if tag.Tag == "SUBM" || tag.Tag == "SUBN" {
+				t.Errorf("header still has %s %s", tag.Tag, tag.Value)
+			}
+		}
+		if len(report.Changes) != 3 {
+			t.Errorf("Changes = %+v, want encoding, submitter, and submission fixes", report.Changes)
+		}
+	})
+}
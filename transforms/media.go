@@ -0,0 +1,140 @@
+package transforms
+
+import (
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+type stripMedia struct{}
+
+// StripMedia returns a transform that removes every media object record
+// and every OBJE structure from the remaining records, including inline
+// multimedia links and links from events and source citations. The report
+// counts "media objects removed" and "media links removed".
+func StripMedia() Transform {
+	return stripMedia{}
+}
+
+// Name returns "strip-media".
+func (stripMedia) Name() string { return "strip-media" }
+
+// Apply removes the media of doc.
+func (t stripMedia) Apply(doc *gedcom.Document) (*Report, error) {
+	report := newReport(t.Name())
+	if doc == nil {
+		return report, errNilDocument
+	}
+
+	kept := doc.Records[:0]
+	removed := false
+	for _, record := range doc.Records {
+		if record != nil && record.Type == gedcom.RecordTypeMedia {
+			report.add(record.XRef, "media object removed")
+			report.count("media objects removed", 1)
+			removed = true
+			continue
+		}
+		kept = append(kept, record)
+	}
+	for i := len(kept); i < len(doc.Records); i++ {
+		doc.Records[i] = nil
+	}
+	doc.Records = kept
+
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		links := stripMediaTags(record)
+		links += stripEntityMedia(record)
+		if links > 0 {
+			report.add(record.XRef, "%d media link(s) removed", links)
+			report.count("media links removed", links)
+		}
+	}
+	if removed {
+		doc.Reindex()
+	}
+	return report, nil
+}
+
+// stripMediaTags removes the OBJE structures from record.Tags and returns
+// how many were removed.
+func stripMediaTags(record *gedcom.Record) int {
+	if len(record.Tags) == 0 {
+		return 0
+	}
+	result := make([]*gedcom.Tag, 0, len(record.Tags))
+	count := 0
+	for i := 0; i < len(record.Tags); i++ {
+		tag := record.Tags[i]
+		if tag == nil || tag.Tag != "OBJE" {
+			result = append(result, tag)
+			continue
+		}
+		count++
+		for i+1 < len(record.Tags) && record.Tags[i+1] != nil && record.Tags[i+1].Level > tag.Level {
+			i++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	record.Tags = result
+	syncEntityTags(record)
+	return count
+}
+
+// stripEntityMedia clears the media links of record's entity. For records
+// without raw tags it returns how many were removed; with raw tags the
+// links were already counted by stripMediaTags.
+func stripEntityMedia(record *gedcom.Record) int {
+	count := 0
+	switch entity := record.Entity.(type) {
+	case *gedcom.Individual:
+		count += clearMedia(&entity.Media)
+		count += clearEventMedia(entity.Events)
+		count += clearCitationMedia(entity.SourceCitations)
+		for _, attr := range entity.Attributes {
+			if attr != nil {
+				count += clearCitationMedia(attr.SourceCitations)
+			}
+		}
+	case *gedcom.Family:
+		count += clearMedia(&entity.Media)
+		count += clearEventMedia(entity.Events)
+		count += clearCitationMedia(entity.SourceCitations)
+	case *gedcom.Source:
+		count += clearMedia(&entity.Media)
+	}
+	if len(record.Tags) > 0 {
+		return 0
+	}
+	return count
+}
+
+func clearMedia(links *[]*gedcom.MediaLink) int {
+	n := len(*links)
+	*links = nil
+	return n
+}
+
+func clearEventMedia(events []*gedcom.Event) int {
+	count := 0
+	for _, event := range events {
+		if event != nil {
+			count += clearMedia(&event.Media)
+			count += clearCitationMedia(event.SourceCitations)
+		}
+	}
+	return count
+}
+
+func clearCitationMedia(citations []*gedcom.SourceCitation) int {
+	count := 0
+	for _, citation := range citations {
+		if citation != nil {
+			count += clearMedia(&citation.Media)
+		}
+	}
+	return count
+}
@@ -0,0 +1,68 @@
+package transforms
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func TestStripMedia(t *testing.T) {
+	doc := decodeTest(t, testGEDCOM)
+	report, err := StripMedia().Apply(doc)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	want := []string{
+		"[strip-media] @M1@: media object removed",
+		"[strip-media] @I7@: 1 media link(s) removed",
+		"[strip-media] @F3@: 1 media link(s) removed",
+	}
+	if len(report.Changes) != len(want) {
+		t.Fatalf("Changes = %+v", report.Changes)
+	}
+	for i, c := range report.Changes {
+		if c.String() != want[i] {
+			t.Errorf("change %d = %q, want %q", i, c.String(), want[i])
+		}
+	}
+
+	if report.Counts["media objects removed"] != 1 || report.Counts["media links removed"] != 2 {
+		t.Errorf("Counts = %v", report.Counts)
+	}
+	if doc.GetRecord("@M1@") != nil || len(doc.MediaObjects()) != 0 {
+		t.Error("media record still indexed")
+	}
+	if john := doc.GetIndividual("@I7@"); len(john.Media) != 0 {
+		t.Errorf("entity media links kept: %+v", john.Media)
+	}
+	if out := encodeTest(t, doc); strings.Contains(out, "OBJE") || strings.Contains(out, "FILE") {
+		t.Errorf("output still has media:\n%s", out)
+	}
+}
+
+func TestStripMediaEntityOnly(t *testing.T) {
+	doc := &gedcom.Document{Records: []*gedcom.Record{
+		{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Individual{
+			Media: []*gedcom.MediaLink{{MediaXRef: "@M1@"}},
+			Events: []*gedcom.Event{{
+				Type:            gedcom.EventBirth,
+				Media:           []*gedcom.MediaLink{{MediaXRef: "@M1@"}},
+				SourceCitations: []*gedcom.SourceCitation{{SourceXRef: "@S1@", Media: []*gedcom.MediaLink{{MediaXRef: "@M1@"}}}},
+			}},
+		}},
+		{XRef: "@S1@", Type: gedcom.RecordTypeSource, Entity: &gedcom.Source{Media: []*gedcom.MediaLink{{MediaXRef: "@M1@"}}}},
+	}}
+
+	report, err := StripMedia().Apply(doc)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(report.Changes) != 2 || report.Changes[0].Message != "3 media link(s) removed" {
+		t.Errorf("Changes = %+v", report.Changes)
+	}
+	ind := doc.Records[0].Entity.(*gedcom.Individual)
+	if ind.Media != nil || ind.Events[0].Media != nil || ind.Events[0].SourceCitations[0].Media != nil {
+		t.Errorf("media links kept: %+v", ind)
+	}
+}
@@ -0,0 +1,360 @@
+package transforms
+
+import (
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// TruncationMarker ends every note StripNotes truncates.
+const TruncationMarker = "..."
+
+// StripNotesOptions configures StripNotes.
+type StripNotesOptions struct {
+	// MaxLength, when positive, truncates note text longer than MaxLength
+	// characters (followed by TruncationMarker) instead of removing notes.
+	// Default (0): remove every note.
+	MaxLength int
+}
+
+type stripNotes struct {
+	maxLength int
+}
+
+// StripNotes returns a transform that removes every note: NOTE and SNOTE
+// records and every NOTE and SNOTE structure in the remaining records,
+// including notes on events, associations, and repository links. The
+// report counts "note records removed" and "notes removed".
+//
+// With StripNotesOptions.MaxLength set, notes are kept but any note text
+// longer than MaxLength characters is cut short, counted as "notes
+// truncated". Note pointers are left alone in that mode, since the shared
+// notes they point at are truncated themselves. If opts is nil, default
+// options are used.
+func StripNotes(opts *StripNotesOptions) Transform {
+	t := &stripNotes{}
+	if opts != nil && opts.MaxLength > 0 {
+		t.maxLength = opts.MaxLength
+	}
+	return t
+}
+
+// Name returns "strip-notes".
+func (t *stripNotes) Name() string { return "strip-notes" }
+
+// Apply removes or truncates the notes of doc.
+func (t *stripNotes) Apply(doc *gedcom.Document) (*Report, error) {
+	report := newReport(t.Name())
+	if doc == nil {
+		return report, errNilDocument
+	}
+	if t.maxLength > 0 {
+		t.truncate(doc, report)
+		return report, nil
+	}
+
+	removed := removeNoteRecords(doc, report)
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		if n := stripRecordNotes(record); n > 0 {
+			report.add(record.XRef, "%d note(s) removed", n)
+			report.count("notes removed", n)
+		}
+	}
+	if removed {
+		doc.Reindex()
+	}
+	return report, nil
+}
+
+// removeNoteRecords removes the NOTE and SNOTE records of doc and reports
+// whether there were any.
+func removeNoteRecords(doc *gedcom.Document, report *Report) bool {
+	kept := doc.Records[:0]
+	removed := false
+	for _, record := range doc.Records {
+		if record != nil && (record.Type == gedcom.RecordTypeNote || record.Type == gedcom.RecordTypeSharedNote) {
+			report.add(record.XRef, "note record removed")
+			report.count("note records removed", 1)
+			removed = true
+			continue
+		}
+		kept = append(kept, record)
+	}
+	for i := len(kept); i < len(doc.Records); i++ {
+		doc.Records[i] = nil
+	}
+	doc.Records = kept
+	return removed
+}
+
+// stripRecordNotes removes the notes of record, from its tags and its
+// entity, and returns how many were removed. Entity notes count only when
+// the record has no tags, since otherwise the tags already counted them.
+func stripRecordNotes(record *gedcom.Record) int {
+	n := stripNoteTags(record)
+	entityNotes := 0
+	if legacy, own := legacyNotes(record.Entity); legacy != nil {
+		if own {
+			entityNotes += len(*legacy)
+		}
+		*legacy = nil
+	}
+	for _, field := range noteFields(record.Entity) {
+		entityNotes += len(*field)
+		*field = nil
+	}
+	if len(record.Tags) == 0 {
+		n += entityNotes
+	}
+	return n
+}
+
+// stripNoteTags removes the NOTE and SNOTE structures from record.Tags and
+// returns how many were removed.
+func stripNoteTags(record *gedcom.Record) int {
+	if len(record.Tags) == 0 {
+		return 0
+	}
+	result := make([]*gedcom.Tag, 0, len(record.Tags))
+	count := 0
+	for i := 0; i < len(record.Tags); i++ {
+		tag := record.Tags[i]
+		if tag == nil || (tag.Tag != "NOTE" && tag.Tag != "SNOTE") {
+			result = append(result, tag)
+			continue
+		}
+		count++
+		for i+1 < len(record.Tags) && record.Tags[i+1] != nil && record.Tags[i+1].Level > tag.Level {
+			i++
+		}
+	}
+	if count > 0 {
+		record.Tags = result
+		syncEntityTags(record)
+	}
+	return count
+}
+
+// truncate shortens the long notes of doc.
+func (t *stripNotes) truncate(doc *gedcom.Document, report *Report) {
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		n := 0
+		switch record.Type {
+		case gedcom.RecordTypeNote, gedcom.RecordTypeSharedNote:
+			if t.truncateNoteRecord(record) {
+				n++
+			}
+		}
+		n += t.truncateNoteTags(record)
+
+		entityNotes := 0
+		if legacy, own := legacyNotes(record.Entity); legacy != nil {
+			if truncated := t.truncateAll(*legacy); own {
+				entityNotes += truncated
+			}
+		}
+		for _, field := range noteFields(record.Entity) {
+			entityNotes += t.truncateAll(*field)
+		}
+		if len(record.Tags) == 0 {
+			n += entityNotes
+		}
+		if n > 0 {
+			report.add(record.XRef, "%d note(s) truncated", n)
+			report.count("notes truncated", n)
+		}
+	}
+}
+
+// truncateAll truncates the long note texts of notes in place, skipping
+// note pointers, and returns how many it truncated.
+func (t *stripNotes) truncateAll(notes []string) int {
+	count := 0
+	for i, note := range notes {
+		if gedcom.IsPointerXRef(note) {
+			continue
+		}
+		if short, ok := truncateText(note, t.maxLength); ok {
+			notes[i] = short
+			count++
+		}
+	}
+	return count
+}
+
+// truncateNoteRecord truncates the text of a NOTE or SNOTE record and
+// reports whether it was too long.
+func (t *stripNotes) truncateNoteRecord(record *gedcom.Record) bool {
+	text := gedcom.FoldText(record.Value, 0, record.Tags)
+	rest := withoutContinuations(record.Tags, 0)
+	short, ok := truncateText(text, t.maxLength)
+	if !ok {
+		return false
+	}
+	lines := strings.Split(short, "\n")
+	record.Value = lines[0]
+	record.Tags = append(continuationTags(lines[1:], 1), rest...)
+	syncEntityTags(record)
+
+	switch entity := record.Entity.(type) {
+	case *gedcom.Note:
+		entity.Text = lines[0]
+		entity.Continuation = lines[1:]
+		if len(entity.Continuation) == 0 {
+			entity.Continuation = nil
+		}
+	case *gedcom.SharedNote:
+		entity.Text = short
+	}
+	return true
+}
+
+// truncateNoteTags truncates the inline NOTE structures of record.Tags and
+// returns how many were too long.
+func (t *stripNotes) truncateNoteTags(record *gedcom.Record) int {
+	if len(record.Tags) == 0 {
+		return 0
+	}
+	result := make([]*gedcom.Tag, 0, len(record.Tags))
+	count := 0
+	for i := 0; i < len(record.Tags); i++ {
+		tag := record.Tags[i]
+		result = append(result, tag)
+		if tag == nil || tag.Tag != "NOTE" || gedcom.IsPointerXRef(tag.Value) {
+			continue
+		}
+		end := i + 1
+		for end < len(record.Tags) && record.Tags[end] != nil && record.Tags[end].Level > tag.Level {
+			end++
+		}
+		text := gedcom.FoldText(tag.Value, tag.Level, record.Tags[i+1:end])
+		rest := withoutContinuations(record.Tags[i+1:end], tag.Level)
+		short, ok := truncateText(text, t.maxLength)
+		if !ok {
+			continue
+		}
+		count++
+		lines := strings.Split(short, "\n")
+		tag.Value = lines[0]
+		result = append(result, continuationTags(lines[1:], tag.Level+1)...)
+		result = append(result, rest...)
+		i = end - 1
+	}
+	if count > 0 {
+		record.Tags = result
+		syncEntityTags(record)
+	}
+	return count
+}
+
+// withoutContinuations returns subs, the subordinates of a structure at
+// level, without the CONT and CONC lines directly beneath it.
+func withoutContinuations(subs []*gedcom.Tag, level int) []*gedcom.Tag {
+	var rest []*gedcom.Tag
+	for _, tag := range subs {
+		if tag != nil && tag.Level == level+1 && (tag.Tag == "CONT" || tag.Tag == "CONC") {
+			continue
+		}
+		rest = append(rest, tag)
+	}
+	return rest
+}
+
+// continuationTags returns a CONT tag at level for each line.
+func continuationTags(lines []string, level int) []*gedcom.Tag {
+	tags := make([]*gedcom.Tag, 0, len(lines))
+	for _, line := range lines {
+		tags = append(tags, &gedcom.Tag{Level: level, Tag: "CONT", Value: line})
+	}
+	return tags
+}
+
+// truncateText cuts text to max characters followed by TruncationMarker,
+// and reports whether it was longer than max.
+func truncateText(text string, max int) (string, bool) {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text, false
+	}
+	return strings.TrimRight(string(runes[:max]), " \n") + TruncationMarker, true
+}
+
+// legacyNotes returns the deprecated Notes field of entity, which repeats
+// its NoteXRefs and InlineNotes, and whether those split fields are empty.
+// Only a legacy field without split fields holds notes of its own.
+func legacyNotes(entity interface{}) (*[]string, bool) {
+	switch e := entity.(type) {
+	case *gedcom.Individual:
+		return &e.Notes, len(e.NoteXRefs)+len(e.InlineNotes) == 0
+	case *gedcom.Family:
+		return &e.Notes, len(e.NoteXRefs)+len(e.InlineNotes) == 0
+	case *gedcom.Source:
+		return &e.Notes, len(e.NoteXRefs)+len(e.InlineNotes) == 0
+	case *gedcom.Repository:
+		return &e.Notes, len(e.NoteXRefs)+len(e.InlineNotes) == 0
+	case *gedcom.Submitter:
+		return &e.Notes, len(e.NoteXRefs)+len(e.InlineNotes) == 0
+	case *gedcom.MediaObject:
+		return &e.Notes, len(e.NoteXRefs)+len(e.InlineNotes) == 0
+	}
+	return nil, false
+}
+
+// noteFields returns the note slices of entity, other than the deprecated
+// Notes field, and of the events, associations, and repository links it
+// holds.
+func noteFields(entity interface{}) []*[]string {
+	var fields []*[]string
+	events := func(events []*gedcom.Event) {
+		for _, event := range events {
+			if event != nil {
+				fields = append(fields, &event.Notes)
+			}
+		}
+	}
+	switch e := entity.(type) {
+	case *gedcom.Individual:
+		fields = append(fields, &e.NoteXRefs, &e.InlineNotes)
+		events(e.Events)
+		for _, assoc := range e.Associations {
+			if assoc != nil {
+				fields = append(fields, &assoc.Notes)
+			}
+		}
+	case *gedcom.Family:
+		fields = append(fields, &e.NoteXRefs, &e.InlineNotes)
+		events(e.Events)
+	case *gedcom.Source:
+		fields = append(fields, &e.NoteXRefs, &e.InlineNotes)
+		for _, link := range e.RepositoryLinks {
+			if link != nil {
+				fields = append(fields, &link.Notes)
+			}
+		}
+		if e.RepositoryLink != nil && !containsLink(e.RepositoryLinks, e.RepositoryLink) {
+			fields = append(fields, &e.RepositoryLink.Notes)
+		}
+	case *gedcom.Repository:
+		fields = append(fields, &e.NoteXRefs, &e.InlineNotes)
+	case *gedcom.Submitter:
+		fields = append(fields, &e.NoteXRefs, &e.InlineNotes)
+	case *gedcom.MediaObject:
+		fields = append(fields, &e.NoteXRefs, &e.InlineNotes, &e.SharedNoteXRefs)
+	}
+	return fields
+}
+
+func containsLink(links []*gedcom.SourceRepositoryLink, link *gedcom.SourceRepositoryLink) bool {
+	for _, l := range links {
+		if l == link {
+			return true
+		}
+	}
+	return false
+}
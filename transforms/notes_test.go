@@ -0,0 +1,121 @@
+package transforms
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const notesGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /Smith/
+1 NOTE A short note
+1 NOTE A much longer note that
+2 CONC  goes on
+2 CONT and on
+2 SOUR @S1@
+1 NOTE @N1@
+1 BIRT
+2 DATE 1850
+2 NOTE Born at home
+0 @S1@ SOUR
+1 TITL Parish register
+0 @N1@ NOTE A shared note that is also long
+1 CONT with a second line
+0 TRLR
+`
+
+func TestStripNotes(t *testing.T) {
+	doc := decodeTest(t, notesGEDCOM)
+	report, err := StripNotes(nil).Apply(doc)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if report.Counts["note records removed"] != 1 || report.Counts["notes removed"] != 4 {
+		t.Errorf("Counts = %v", report.Counts)
+	}
+	if doc.GetNote("@N1@") != nil {
+		t.Error("note record still indexed")
+	}
+	john := doc.GetIndividual("@I1@")
+	if john.NoteXRefs != nil || john.InlineNotes != nil || john.Notes != nil || john.Events[0].Notes != nil {
+		t.Errorf("entity notes kept: %+v", john)
+	}
+	out := encodeTest(t, doc)
+	if strings.Contains(out, "NOTE") || strings.Contains(out, "CONT") {
+		t.Errorf("output still has notes:\n%s", out)
+	}
+	if !strings.Contains(out, "0 @S1@ SOUR") || !strings.Contains(out, "2 DATE 1850") {
+		t.Errorf("output lost non-note data:\n%s", out)
+	}
+}
+
+func TestStripNotesTruncate(t *testing.T) {
+	doc := decodeTest(t, notesGEDCOM)
+	report, err := StripNotes(&StripNotesOptions{MaxLength: 16}).Apply(doc)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if report.Counts["notes truncated"] != 2 {
+		t.Errorf("Counts = %v, want the long inline note and the note record", report.Counts)
+	}
+
+	out := encodeTest(t, doc)
+	for _, want := range []string{
+		"1 NOTE A short note\n",
+		"1 NOTE A much longer no...\n2 SOUR @S1@\n",
+		"0 @N1@ NOTE A shared note th...\n0 TRLR",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if note := doc.GetNote("@N1@"); note.FullText() != "A shared note th..." {
+		t.Errorf("Note entity = %q", note.FullText())
+	}
+	john := doc.GetIndividual("@I1@")
+	if john.InlineNotes[1] != "A much longer no..." || john.NoteXRefs[0] != "@N1@" {
+		t.Errorf("entity notes = %q, %q", john.InlineNotes, john.NoteXRefs)
+	}
+}
+
+func TestStripNotesTruncateMultiline(t *testing.T) {
+	record := &gedcom.Record{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Tags: []*gedcom.Tag{
+		{Level: 1, Tag: "NOTE", Value: "line one"},
+		{Level: 2, Tag: "CONT", Value: "line two is long"},
+	}}
+	record.Entity = &gedcom.Individual{XRef: "@I1@", InlineNotes: []string{"line one\nline two is long"}, Tags: record.Tags}
+	doc := &gedcom.Document{Records: []*gedcom.Record{record}}
+
+	if _, err := StripNotes(&StripNotesOptions{MaxLength: 13}).Apply(doc); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(record.Tags) != 2 || record.Tags[0].Value != "line one" || record.Tags[1].Tag != "CONT" || record.Tags[1].Value != "line..." {
+		t.Errorf("tags = %+v, %+v", record.Tags[0], record.Tags[1])
+	}
+	if ind := record.Entity.(*gedcom.Individual); ind.InlineNotes[0] != "line one\nline..." || &ind.Tags[0] != &record.Tags[0] {
+		t.Errorf("entity = %q", ind.InlineNotes)
+	}
+}
+
+func TestStripNotesEntityOnly(t *testing.T) {
+	doc := &gedcom.Document{Records: []*gedcom.Record{
+		{XRef: "@F1@", Type: gedcom.RecordTypeFamily, Entity: &gedcom.Family{
+			InlineNotes: []string{"a"},
+			Notes:       []string{"a"},
+			Events:      []*gedcom.Event{{Type: gedcom.EventMarriage, Notes: []string{"b"}}},
+		}},
+		{XRef: "@R1@", Type: gedcom.RecordTypeRepository, Entity: &gedcom.Repository{Notes: []string{"legacy only"}}},
+	}}
+	report, err := StripNotes(nil).Apply(doc)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(report.Changes) != 2 || report.Changes[0].Message != "2 note(s) removed" || report.Changes[1].Message != "1 note(s) removed" {
+		t.Errorf("Changes = %+v", report.Changes)
+	}
+}
@@ -0,0 +1,71 @@
+package transforms
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// errNilDocument is returned when a transform is applied to a nil document.
+var errNilDocument = errors.New("transforms: document is nil")
+
+// Pipeline applies transforms in sequence. A Pipeline is itself a
+// Transform, so pipelines can be nested.
+type Pipeline struct {
+	steps []Transform
+}
+
+// NewPipeline creates a pipeline of the given steps. Nil steps are ignored.
+func NewPipeline(steps ...Transform) *Pipeline {
+	p := &Pipeline{}
+	for _, step := range steps {
+		p.Add(step)
+	}
+	return p
+}
+
+// Add appends a step to the pipeline and returns the pipeline for chaining.
+func (p *Pipeline) Add(step Transform) *Pipeline {
+	if step != nil {
+		p.steps = append(p.steps, step)
+	}
+	return p
+}
+
+// Steps returns the pipeline's steps, in order.
+func (p *Pipeline) Steps() []Transform {
+	steps := make([]Transform, len(p.steps))
+	copy(steps, p.steps)
+	return steps
+}
+
+// Name returns "pipeline".
+func (p *Pipeline) Name() string { return "pipeline" }
+
+// Apply runs each step on doc in order. The returned report lists the
+// changes of every step in Changes and each step's own report in Steps.
+//
+// Apply stops at the first failing step and returns the report so far
+// together with an error naming the step; earlier steps' changes remain
+// applied to doc.
+func (p *Pipeline) Apply(doc *gedcom.Document) (*Report, error) {
+	report := newReport(p.Name())
+	if doc == nil {
+		return report, errNilDocument
+	}
+	for _, step := range p.steps {
+		stepReport, err := step.Apply(doc)
+		if stepReport != nil {
+			report.Steps = append(report.Steps, stepReport)
+			report.Changes = append(report.Changes, stepReport.Changes...)
+			for kind, n := range stepReport.Counts {
+				report.count(kind, n)
+			}
+		}
+		if err != nil {
+			return report, fmt.Errorf("transforms: %s: %w", step.Name(), err)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,107 @@
+package transforms
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func TestPipeline(t *testing.T) {
+	doc := decodeTest(t, testGEDCOM)
+	p := NewPipeline(
+		Privatize(&PrivatizeOptions{AsOf: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}),
+		nil,
+		StripMedia(),
+	).Add(RenumberXRefs())
+
+	if got := len(p.Steps()); got != 3 {
+		t.Fatalf("Steps() = %d, want 3", got)
+	}
+
+	report, err := p.Apply(doc)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if report.Transform != "pipeline" || len(report.Steps) != 3 {
+		t.Fatalf("report = %+v", report)
+	}
+	total := 0
+	for i, step := range report.Steps {
+		if step.Transform != p.Steps()[i].Name() {
+			t.Errorf("step %d report is %q, want %q", i, step.Transform, p.Steps()[i].Name())
+		}
+		total += len(step.Changes)
+	}
+	if total != len(report.Changes) || !report.Changed() {
+		t.Errorf("combined changes = %d, want %d", len(report.Changes), total)
+	}
+	if report.Counts["media objects removed"] != 1 || report.Counts["media links removed"] != 2 {
+		t.Errorf("combined Counts = %v", report.Counts)
+	}
+
+	out := encodeTest(t, doc)
+	for _, want := range []string{"0 @I2@ INDI\n1 NAME Living\n", "0 @I1@ INDI\n1 NAME John /Smith/", "1 FAMS @F1@"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"OBJE", "Engineer", "@I7@"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("output still contains %q:\n%s", unwanted, out)
+		}
+	}
+	if s := report.String(); !strings.HasPrefix(s, "pipeline: ") || !strings.Contains(s, "  media links removed: 2\n") ||
+		!strings.Contains(s, "[privatize] @I9@: individual privatized") {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+func TestPipelineError(t *testing.T) {
+	boom := errors.New("boom")
+	ran := false
+	p := NewPipeline(
+		Func("first", func(doc *gedcom.Document, r *Report) error {
+			r.add("", "touched")
+			return nil
+		}),
+		Func("failing", func(doc *gedcom.Document, r *Report) error {
+			return boom
+		}),
+		Func("never", func(doc *gedcom.Document, r *Report) error {
+			ran = true
+			return nil
+		}),
+	)
+
+	report, err := p.Apply(&gedcom.Document{})
+	if !errors.Is(err, boom) || !strings.Contains(err.Error(), "failing") {
+		t.Fatalf("Apply() error = %v, want wrapped boom naming the step", err)
+	}
+	if ran {
+		t.Error("steps after the failure should not run")
+	}
+	if len(report.Steps) != 2 || len(report.Changes) != 1 || report.Changes[0].Transform != "first" {
+		t.Errorf("report = %+v", report)
+	}
+}
+
+func TestNilDocument(t *testing.T) {
+	transforms := []Transform{
+		NewPipeline(),
+		Func("f", func(*gedcom.Document, *Report) error { return nil }),
+		Privatize(nil),
+		NormalizePlaces(nil, nil),
+		RenumberXRefs(),
+		StripMedia(),
+		StripNotes(nil),
+		FixHeaders(nil),
+	}
+	for _, tr := range transforms {
+		if _, err := tr.Apply(nil); err == nil {
+			t.Errorf("%s: Apply(nil) should fail", tr.Name())
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package transforms
+
+import (
+	"github.com/cacack/gedcom-go/v2/gedcom"
+	"github.com/cacack/gedcom-go/v2/place"
+)
+
+type normalizePlaces struct {
+	mapping map[string]string
+	opts    *place.StandardizeOptions
+}
+
+// NormalizePlaces returns a transform that rewrites every place whose
+// payload is a key of mapping to the mapped value, using place.Standardize.
+// If mapping is nil, it is proposed from the document being transformed
+// with place.ClusterPlaces and place.CanonicalMapping. If opts is nil,
+// default options are used.
+func NormalizePlaces(mapping map[string]string, opts *place.StandardizeOptions) Transform {
+	return &normalizePlaces{mapping: mapping, opts: opts}
+}
+
+// Name returns "normalize-places".
+func (t *normalizePlaces) Name() string { return "normalize-places" }
+
+// Apply rewrites the mapped places of doc.
+func (t *normalizePlaces) Apply(doc *gedcom.Document) (*Report, error) {
+	report := newReport(t.Name())
+	if doc == nil {
+		return report, errNilDocument
+	}
+	mapping := t.mapping
+	if mapping == nil {
+		mapping = place.CanonicalMapping(place.ClusterPlaces(doc))
+	}
+
+	out, result, err := place.Standardize(doc, mapping, t.opts)
+	if err != nil {
+		return report, err
+	}
+	if len(result.Changes) == 0 {
+		return report, nil
+	}
+	doc.Records = out.Records
+	doc.Reindex()
+	for _, change := range result.Changes {
+		report.add(change.RecordXRef, "place %q normalized to %q", change.Original, change.Canonical)
+	}
+	return report, nil
+}
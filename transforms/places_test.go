@@ -0,0 +1,46 @@
+package transforms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizePlaces(t *testing.T) {
+	t.Run("explicit mapping", func(t *testing.T) {
+		doc := decodeTest(t, testGEDCOM)
+		mapping := map[string]string{"Springfield, IL": "Springfield, Sangamon, Illinois, USA"}
+		report, err := NormalizePlaces(mapping, nil).Apply(doc)
+		if err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if len(report.Changes) != 2 || report.Changes[0].RecordXRef != "@I7@" || report.Changes[1].RecordXRef != "@I9@" {
+			t.Fatalf("Changes = %+v", report.Changes)
+		}
+		if got := doc.GetIndividual("@I9@").Events[0].Place; got != "Springfield, Sangamon, Illinois, USA" {
+			t.Errorf("indexed entity place = %q", got)
+		}
+		if out := encodeTest(t, doc); strings.Contains(out, "PLAC Springfield, IL\n") {
+			t.Errorf("output still has the variant:\n%s", out)
+		}
+	})
+
+	t.Run("proposed mapping", func(t *testing.T) {
+		doc := decodeTest(t, testGEDCOM)
+		report, err := NormalizePlaces(nil, nil).Apply(doc)
+		if err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+		if !report.Changed() {
+			t.Error("expected the Springfield variants to be normalized")
+		}
+	})
+
+	t.Run("nothing to change", func(t *testing.T) {
+		doc := decodeTest(t, testGEDCOM)
+		records := doc.Records
+		report, err := NormalizePlaces(map[string]string{}, nil).Apply(doc)
+		if err != nil || report.Changed() || &doc.Records[0] != &records[0] {
+			t.Errorf("Apply() = %+v, %v; want the document untouched", report.Changes, err)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package transforms
+
+import (
+	"time"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+	"github.com/cacack/gedcom-go/v2/privacy"
+)
+
+// DefaultLivingName is the name Privatize gives presumed-living individuals.
+const DefaultLivingName = "Living"
+
+// PrivatizeOptions configures Privatize.
+type PrivatizeOptions struct {
+	// MaxLivingAge is the age beyond which an individual without a death
+	// event is no longer presumed living. Default (0): 100.
+	MaxLivingAge int
+
+	// AsOf is the date used to compute ages. Default (zero value): the
+	// time the transform is applied.
+	AsOf time.Time
+
+	// Name replaces the names of presumed-living individuals.
+	// Default (""): DefaultLivingName.
+	Name string
+}
+
+// privatizeKeptIndividualTags are the level-1 individual structures a
+// privatized individual keeps, so family links survive.
+var privatizeKeptIndividualTags = map[string]bool{"SEX": true, "FAMC": true, "FAMS": true}
+
+// privatizeKeptFamilyTags are the level-1 family structures a family with
+// a living spouse keeps.
+var privatizeKeptFamilyTags = map[string]bool{"HUSB": true, "WIFE": true, "CHIL": true}
+
+type privatize struct {
+	opts PrivatizeOptions
+}
+
+// Privatize returns a transform that hides the details of presumed-living
+// individuals, as decided by privacy.IsLiving with MaxLivingAge: they have
+// no death, burial, cremation, or probate event and either no birth year
+// or a birth year within MaxLivingAge years of AsOf, the same rule the
+// validator's LINT_UNSOURCED_LIVING check uses. For RESN handling and
+// removal rather than placeholders, see privacy.Redact.
+//
+// A presumed-living individual keeps only its sex and family links, under
+// a single placeholder name. A family with a presumed-living spouse keeps
+// only its HUSB, WIFE, and CHIL links. If opts is nil, default options
+// are used.
+func Privatize(opts *PrivatizeOptions) Transform {
+	t := &privatize{}
+	if opts != nil {
+		t.opts = *opts
+	}
+	if t.opts.MaxLivingAge <= 0 {
+		t.opts.MaxLivingAge = 100
+	}
+	if t.opts.Name == "" {
+		t.opts.Name = DefaultLivingName
+	}
+	return t
+}
+
+// Name returns "privatize".
+func (t *privatize) Name() string { return "privatize" }
+
+// Apply privatizes the presumed-living individuals of doc and their
+// families.
+func (t *privatize) Apply(doc *gedcom.Document) (*Report, error) {
+	report := newReport(t.Name())
+	if doc == nil {
+		return report, errNilDocument
+	}
+	asOf := t.opts.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+
+	living := t.privatizeLiving(doc, asOf, report)
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		fam, ok := record.GetFamily()
+		if !ok || fam == nil || !(living[fam.Husband] || living[fam.Wife]) {
+			continue
+		}
+		if len(fam.Events) == 0 && len(fam.SourceCitations) == 0 && !hasOtherTags(record.Tags, privatizeKeptFamilyTags) {
+			continue
+		}
+		privatizeFamily(record, fam)
+		report.add(record.XRef, "family of living spouse privatized")
+	}
+	return report, nil
+}
+
+// privatizeLiving privatizes the individuals of doc living at asOf and
+// returns their XRefs.
+func (t *privatize) privatizeLiving(doc *gedcom.Document, asOf time.Time, report *Report) map[string]bool {
+	living := make(map[string]bool)
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		ind, ok := record.GetIndividual()
+		if !ok || ind == nil || !privacy.IsLiving(ind, asOf, t.opts.MaxLivingAge) {
+			continue
+		}
+		living[record.XRef] = true
+		t.privatizeIndividual(record, ind)
+		report.add(record.XRef, "individual privatized")
+	}
+	return living
+}
+
+// privatizeIndividual replaces ind with a placeholder keeping its sex and
+// family links, in both the raw tags and the entity.
+func (t *privatize) privatizeIndividual(record *gedcom.Record, ind *gedcom.Individual) {
+	if len(record.Tags) > 0 {
+		tags := []*gedcom.Tag{{Level: 1, Tag: "NAME", Value: t.opts.Name}}
+		record.Tags = append(tags, keepBlocks(record.Tags, privatizeKeptIndividualTags)...)
+	}
+	*ind = gedcom.Individual{
+		XRef:             ind.XRef,
+		Names:            []*gedcom.PersonalName{{Full: t.opts.Name}},
+		Sex:              ind.Sex,
+		ChildInFamilies:  ind.ChildInFamilies,
+		SpouseInFamilies: ind.SpouseInFamilies,
+		Tags:             record.Tags,
+	}
+}
+
+// privatizeFamily reduces fam to its spouse and child links.
+func privatizeFamily(record *gedcom.Record, fam *gedcom.Family) {
+	if len(record.Tags) > 0 {
+		record.Tags = keepBlocks(record.Tags, privatizeKeptFamilyTags)
+	}
+	*fam = gedcom.Family{
+		XRef:     fam.XRef,
+		Husband:  fam.Husband,
+		Wife:     fam.Wife,
+		Children: fam.Children,
+		Tags:     record.Tags,
+	}
+}
+
+// keepBlocks returns the level-1 structures of tags whose tag is in keep,
+// with their subordinates.
+func keepBlocks(tags []*gedcom.Tag, keep map[string]bool) []*gedcom.Tag {
+	var kept []*gedcom.Tag
+	keeping := false
+	for _, tag := range tags {
+		if tag == nil {
+			continue
+		}
+		if tag.Level <= 1 {
+			keeping = keep[tag.Tag]
+		}
+		if keeping {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
+
+// hasOtherTags reports whether tags has a level-1 structure not in keep.
+func hasOtherTags(tags []*gedcom.Tag, keep map[string]bool) bool {
+	for _, tag := range tags {
+		if tag != nil && tag.Level == 1 && !keep[tag.Tag] {
+			return true
+		}
+	}
+	return false
+}
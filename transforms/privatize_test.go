@@ -0,0 +1,97 @@
+package transforms
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+var privatizeAsOf = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestPrivatize(t *testing.T) {
+	doc := decodeTest(t, testGEDCOM)
+	report, err := Privatize(&PrivatizeOptions{AsOf: privatizeAsOf}).Apply(doc)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(report.Changes) != 1 || report.Changes[0].RecordXRef != "@I9@" {
+		t.Fatalf("Changes = %+v", report.Changes)
+	}
+
+	jane := doc.GetIndividual("@I9@")
+	if jane.Names[0].Full != DefaultLivingName || jane.Sex != "F" || len(jane.Events) != 0 || len(jane.Attributes) != 0 {
+		t.Errorf("entity not privatized: %+v", jane)
+	}
+	if len(jane.ChildInFamilies) != 1 || jane.ChildInFamilies[0].FamilyXRef != "@F3@" {
+		t.Errorf("family link lost: %+v", jane.ChildInFamilies)
+	}
+	if john := doc.GetIndividual("@I7@"); john.Names[0].Full != "John /Smith/" {
+		t.Errorf("deceased individual was changed: %+v", john.Names[0])
+	}
+	if fam := doc.GetFamily("@F3@"); len(fam.Events) != 1 {
+		t.Errorf("family without a living spouse was changed: %+v", fam)
+	}
+
+	out := encodeTest(t, doc)
+	if !strings.Contains(out, "0 @I9@ INDI\n1 NAME Living\n1 SEX F\n1 FAMC @F3@\n0 @F3@ FAM\n") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestPrivatizeOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *PrivatizeOptions
+		want int
+	}{
+		{"born within max age", &PrivatizeOptions{AsOf: privatizeAsOf}, 1},
+		{"born beyond max age", &PrivatizeOptions{AsOf: privatizeAsOf, MaxLivingAge: 30}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := decodeTest(t, testGEDCOM)
+			report, err := Privatize(tt.opts).Apply(doc)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if len(report.Changes) != tt.want {
+				t.Errorf("Changes = %+v, want %d", report.Changes, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrivatizeEntityOnly(t *testing.T) {
+	doc := &gedcom.Document{Records: []*gedcom.Record{
+		nil,
+		{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Individual{
+			XRef:   "@I1@",
+			Names:  []*gedcom.PersonalName{{Full: "Alex /Doe/"}},
+			Events: []*gedcom.Event{{Type: gedcom.EventBirth, Place: "Boston"}},
+		}},
+		{XRef: "@F1@", Type: gedcom.RecordTypeFamily, Entity: &gedcom.Family{XRef: "@F1@", Wife: "@I1@"}},
+		{XRef: "@F2@", Type: gedcom.RecordTypeFamily, Entity: &gedcom.Family{
+			XRef:     "@F2@",
+			Wife:     "@I1@",
+			Children: []string{"@I2@"},
+			Events:   []*gedcom.Event{{Type: gedcom.EventMarriage, Place: "Boston"}},
+		}},
+	}}
+
+	report, err := Privatize(&PrivatizeOptions{Name: "Private"}).Apply(doc)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(report.Changes) != 2 || report.Changes[1].RecordXRef != "@F2@" {
+		t.Errorf("Changes = %+v, want @I1@ and @F2@ (@F1@ has nothing to hide)", report.Changes)
+	}
+	if fam := doc.Records[3].Entity.(*gedcom.Family); len(fam.Events) != 0 || fam.Wife != "@I1@" || len(fam.Children) != 1 {
+		t.Errorf("family = %+v", fam)
+	}
+	ind := doc.Records[1].Entity.(*gedcom.Individual)
+	if ind.Names[0].Full != "Private" || len(ind.Events) != 0 || doc.Records[1].Tags != nil {
+		t.Errorf("individual = %+v", ind)
+	}
+}
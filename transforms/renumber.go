@@ -0,0 +1,80 @@
+package transforms
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// renumberPrefixes are the XRef prefixes RenumberXRefs uses per record
+// type, following the convention of merge.Combine's RenumberDoc2.
+var renumberPrefixes = map[gedcom.RecordType]string{
+	gedcom.RecordTypeIndividual: "I",
+	gedcom.RecordTypeFamily:     "F",
+	gedcom.RecordTypeSource:     "S",
+	gedcom.RecordTypeRepository: "R",
+	gedcom.RecordTypeNote:       "N",
+	gedcom.RecordTypeMedia:      "M",
+	gedcom.RecordTypeSubmitter:  "U",
+	gedcom.RecordTypeSharedNote: "SNOTE",
+}
+
+type renumberXRefs struct{}
+
+// RenumberXRefs returns a transform that renumbers every record XRef
+// sequentially per record type, in document order: individuals become
+// @I1@, @I2@, ..., families @F1@, ..., and so on, with records of other
+// types numbered under the "X" prefix. Every reference is rewritten with
+// gedcom.Apply, so the document keeps its referential integrity.
+//
+// Records sharing an XRef keep sharing the new one.
+func RenumberXRefs() Transform {
+	return renumberXRefs{}
+}
+
+// Name returns "renumber-xrefs".
+func (renumberXRefs) Name() string { return "renumber-xrefs" }
+
+// Apply renumbers the records of doc.
+func (t renumberXRefs) Apply(doc *gedcom.Document) (*Report, error) {
+	report := newReport(t.Name())
+	if doc == nil {
+		return report, errNilDocument
+	}
+
+	counters := make(map[string]int)
+	mapping := make(map[string]string)
+	var order []string
+	for _, record := range doc.Records {
+		if record == nil || record.XRef == "" {
+			continue
+		}
+		if _, seen := mapping[record.XRef]; seen {
+			continue
+		}
+		prefix, ok := renumberPrefixes[record.Type]
+		if !ok {
+			prefix = "X"
+		}
+		counters[prefix]++
+		mapping[record.XRef] = fmt.Sprintf("@%s%d@", prefix, counters[prefix])
+		order = append(order, record.XRef)
+	}
+
+	for _, old := range order {
+		if mapping[old] == old {
+			delete(mapping, old)
+		}
+	}
+	if len(mapping) == 0 {
+		return report, nil
+	}
+	gedcom.Apply(doc, mapping)
+	doc.Reindex()
+	for _, old := range order {
+		if xref, ok := mapping[old]; ok {
+			report.add(xref, "renumbered from %s", old)
+		}
+	}
+	return report, nil
+}
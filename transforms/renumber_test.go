@@ -0,0 +1,43 @@
+package transforms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenumberXRefs(t *testing.T) {
+	doc := decodeTest(t, testGEDCOM)
+	report, err := RenumberXRefs().Apply(doc)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	want := map[string]string{"@I1@": "@I7@", "@I2@": "@I9@", "@F1@": "@F3@"}
+	if len(report.Changes) != len(want) {
+		t.Fatalf("Changes = %+v", report.Changes)
+	}
+	for _, c := range report.Changes {
+		if c.Message != "renumbered from "+want[c.RecordXRef] {
+			t.Errorf("change %+v, want renumbered from %s", c, want[c.RecordXRef])
+		}
+	}
+
+	if doc.GetIndividual("@I1@") == nil || doc.GetIndividual("@I7@") != nil {
+		t.Error("XRef index not updated")
+	}
+	if fam := doc.GetFamily("@F1@"); fam == nil || fam.Husband != "@I1@" || fam.Children[0] != "@I2@" {
+		t.Errorf("family references not rewritten: %+v", fam)
+	}
+	out := encodeTest(t, doc)
+	for _, old := range []string{"@I7@", "@I9@", "@F3@"} {
+		if strings.Contains(out, old) {
+			t.Errorf("output still contains %s:\n%s", old, out)
+		}
+	}
+
+	// Already-sequential documents are left alone.
+	report, err = RenumberXRefs().Apply(doc)
+	if err != nil || report.Changed() {
+		t.Errorf("second Apply() = %+v, %v; want no changes", report.Changes, err)
+	}
+}
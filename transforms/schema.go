@@ -0,0 +1,121 @@
+package transforms
+
+import (
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// DefaultExtensionBaseURI is the placeholder URI prefix DeclareExtensions
+// gives extension tags that have no known URI.
+const DefaultExtensionBaseURI = "https://example.com/gedcom/extensions/"
+
+// DeclareExtensionsOptions configures DeclareExtensions.
+type DeclareExtensionsOptions struct {
+	// BaseURI prefixes the tag name to form the URI of an extension tag
+	// whose registered schema has none.
+	// Default (""): DefaultExtensionBaseURI.
+	BaseURI string
+}
+
+type declareExtensions struct {
+	baseURI string
+}
+
+// DeclareExtensions returns a transform that declares, in HEAD.SCHMA,
+// every extension tag of a GEDCOM 7.0 document that is not declared yet
+// (the tags validator.FindUndeclaredExtensions reports), so the file
+// validates against strict 7.0 checkers. Each tag is mapped to the URI of
+// its registered extension schema (see gedcom.RegisterExtension) or, when
+// it has none, to a placeholder URI built from BaseURI; replace
+// placeholders with the vendor's documented URIs where known. The report
+// counts "extensions declared".
+//
+// The document's Schema and, when present, the raw header tags are both
+// updated. Documents of other versions are left alone. If opts is nil,
+// default options are used.
+func DeclareExtensions(opts *DeclareExtensionsOptions) Transform {
+	t := &declareExtensions{baseURI: DefaultExtensionBaseURI}
+	if opts != nil && opts.BaseURI != "" {
+		t.baseURI = opts.BaseURI
+	}
+	return t
+}
+
+// Name returns "declare-extensions".
+func (t *declareExtensions) Name() string { return "declare-extensions" }
+
+// Apply adds the missing SCHMA declarations to doc.
+func (t *declareExtensions) Apply(doc *gedcom.Document) (*Report, error) {
+	report := newReport(t.Name())
+	if doc == nil {
+		return report, errNilDocument
+	}
+	if doc.Header == nil || doc.Header.Version != gedcom.Version70 {
+		return report, nil
+	}
+
+	for _, unknown := range doc.UnknownTags() {
+		if unknown.URI != "" {
+			continue
+		}
+		uri := t.baseURI + unknown.Tag
+		if schema, ok := gedcom.LookupExtension(unknown.Tag); ok && schema.URI != "" {
+			uri = schema.URI
+		}
+		if doc.Schema == nil {
+			doc.Schema = &gedcom.SchemaDefinition{}
+		}
+		if doc.Schema.TagMappings == nil {
+			doc.Schema.TagMappings = make(map[string]string)
+		}
+		doc.Schema.TagMappings[unknown.Tag] = uri
+		addSchemaTag(doc.Header, unknown.Tag, uri)
+		report.add("", "extension %s declared as %s", unknown.Tag, uri)
+		report.count("extensions declared", 1)
+	}
+	return report, nil
+}
+
+// addSchemaTag appends a HEAD.SCHMA.TAG declaration to h.Tags, creating the
+// SCHMA structure after GEDC when missing. Headers without raw tags are
+// left alone; the encoder writes SCHMA from the document's Schema.
+func addSchemaTag(h *gedcom.Header, tag, uri string) {
+	if len(h.Tags) == 0 {
+		return
+	}
+	decl := &gedcom.Tag{Level: 2, Tag: "TAG", Value: tag + " " + uri}
+
+	schema, after := -1, -1
+	for i, t := range h.Tags {
+		if t == nil || t.Level != 1 {
+			continue
+		}
+		if t.Tag == "SCHMA" {
+			schema = i
+			break
+		}
+		if t.Tag == "GEDC" {
+			after = i
+		}
+	}
+	if schema < 0 {
+		at := 0
+		if after >= 0 {
+			at = subtreeEnd(h.Tags, after)
+		}
+		block := []*gedcom.Tag{{Level: 1, Tag: "SCHMA"}, decl}
+		h.Tags = append(h.Tags[:at], append(block, h.Tags[at:]...)...)
+		return
+	}
+	end := subtreeEnd(h.Tags, schema)
+	h.Tags = append(h.Tags[:end], append([]*gedcom.Tag{decl}, h.Tags[end:]...)...)
+}
+
+// subtreeEnd returns the index just past the level 1 structure at i and
+// its subordinates.
+func subtreeEnd(tags []*gedcom.Tag, i int) int {
+	end := i + 1
+	for end < len(tags) && tags[end] != nil && tags[end].Level > 1 {
+		end++
+	}
+	return end
+}
@@ -0,0 +1,99 @@
+package transforms
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const extensionsGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 7.0
+1 SOUR FamilyApp
+0 @I1@ INDI
+1 NAME John /Doe/
+1 _MILT Army
+1 _MYAPP
+0 @I2@ INDI
+1 _MILT Navy
+0 TRLR
+`
+
+func TestDeclareExtensions(t *testing.T) {
+	err := gedcom.RegisterExtension(gedcom.ExtensionSchema{Tag: "_MYAPP", URI: "https://myapp.example/ext"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gedcom.UnregisterExtension("_MYAPP")
+
+	doc := decodeTest(t, extensionsGEDCOM)
+	report, err := DeclareExtensions(nil).Apply(doc)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if report.Counts["extensions declared"] != 2 {
+		t.Errorf("Counts = %v, want 2 extensions declared", report.Counts)
+	}
+	want := map[string]string{
+		"_MILT":  DefaultExtensionBaseURI + "_MILT",
+		"_MYAPP": "https://myapp.example/ext",
+	}
+	for tag, uri := range want {
+		if got := doc.Schema.TagMappings[tag]; got != uri {
+			t.Errorf("TagMappings[%s] = %q, want %q", tag, got, uri)
+		}
+	}
+
+	var lines []string
+	for _, tag := range doc.Header.Tags {
+		lines = append(lines, strings.TrimSpace(strings.Join([]string{string(rune('0' + tag.Level)), tag.Tag, tag.Value}, " ")))
+	}
+	got := strings.Join(lines, "|")
+	wantTags := "1 GEDC|2 VERS 7.0|1 SCHMA|2 TAG _MILT " + DefaultExtensionBaseURI + "_MILT|2 TAG _MYAPP https://myapp.example/ext|1 SOUR FamilyApp"
+	if got != wantTags {
+		t.Errorf("raw header tags = %q, want %q", got, wantTags)
+	}
+
+	// Declared tags are left alone on a second run.
+	report, err = DeclareExtensions(nil).Apply(doc)
+	if err != nil || report.Changed() {
+		t.Errorf("second Apply() = %+v, %v; want no changes", report.Changes, err)
+	}
+}
+
+func TestDeclareExtensions_EncodeRoundtrip(t *testing.T) {
+	doc := decodeTest(t, extensionsGEDCOM)
+	if _, err := DeclareExtensions(&DeclareExtensionsOptions{BaseURI: "https://tool.example/"}).Apply(doc); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "1 SCHMA\n2 TAG _MILT https://tool.example/_MILT\n") {
+		t.Errorf("output has no SCHMA declaration:\n%s", buf.String())
+	}
+	decoded, err := decoder.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if decoded.Schema == nil || decoded.Schema.TagMappings["_MILT"] != "https://tool.example/_MILT" {
+		t.Errorf("decoded Schema = %+v", decoded.Schema)
+	}
+}
+
+func TestDeclareExtensions_NotVersion70(t *testing.T) {
+	doc := decodeTest(t, strings.Replace(extensionsGEDCOM, "VERS 7.0", "VERS 5.5.1", 1))
+	report, err := DeclareExtensions(nil).Apply(doc)
+	if err != nil || report.Changed() || doc.Schema != nil {
+		t.Errorf("Apply() = %+v, %v, Schema %+v; want no changes", report.Changes, err, doc.Schema)
+	}
+	if _, err := DeclareExtensions(nil).Apply(nil); err == nil {
+		t.Error("Apply(nil) error = nil, want error")
+	}
+}
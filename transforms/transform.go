@@ -0,0 +1,165 @@
+package transforms
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// Transform is a document rewrite step.
+type Transform interface {
+	// Name identifies the transform in reports (e.g., "privatize").
+	Name() string
+
+	// Apply rewrites doc in place and reports what changed. On error the
+	// document may be partially rewritten.
+	Apply(doc *gedcom.Document) (*Report, error)
+}
+
+// Change describes one modification made by a transform.
+type Change struct {
+	// Transform is the name of the transform that made the change.
+	Transform string
+
+	// RecordXRef is the record that changed, or "" for header changes.
+	RecordXRef string
+
+	// Message describes the change.
+	Message string
+}
+
+// String returns the change formatted for display.
+func (c Change) String() string {
+	if c.RecordXRef == "" {
+		return fmt.Sprintf("[%s] %s", c.Transform, c.Message)
+	}
+	return fmt.Sprintf("[%s] %s: %s", c.Transform, c.RecordXRef, c.Message)
+}
+
+// Report describes what a transform changed.
+type Report struct {
+	// Transform is the name of the transform that produced the report.
+	Transform string
+
+	// Changes lists each modification, in the order it was made. A
+	// pipeline report holds the changes of all its steps.
+	Changes []Change
+
+	// Counts totals the changes by kind (e.g., "media links removed"). A
+	// pipeline report sums the counts of its steps.
+	Counts map[string]int
+
+	// Steps holds the report of each step of a pipeline, in order. It is
+	// empty for a single transform.
+	Steps []*Report
+}
+
+// newReport creates an empty report for the named transform.
+func newReport(name string) *Report {
+	return &Report{Transform: name}
+}
+
+// add records a change.
+func (r *Report) add(xref, format string, args ...interface{}) {
+	r.Changes = append(r.Changes, Change{
+		Transform:  r.Transform,
+		RecordXRef: xref,
+		Message:    fmt.Sprintf(format, args...),
+	})
+}
+
+// count adds n to the count of kind.
+func (r *Report) count(kind string, n int) {
+	if n == 0 {
+		return
+	}
+	if r.Counts == nil {
+		r.Counts = make(map[string]int)
+	}
+	r.Counts[kind] += n
+}
+
+// Changed reports whether anything was modified.
+func (r *Report) Changed() bool {
+	return r != nil && len(r.Changes) > 0
+}
+
+// String returns a human-readable summary of the report, one change per
+// line.
+func (r *Report) String() string {
+	if r == nil {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %d change(s)\n", r.Transform, len(r.Changes))
+	kinds := make([]string, 0, len(r.Counts))
+	for kind := range r.Counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		fmt.Fprintf(&sb, "  %s: %d\n", kind, r.Counts[kind])
+	}
+	for _, c := range r.Changes {
+		sb.WriteString("  ")
+		sb.WriteString(c.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// funcTransform adapts a function to the Transform interface.
+type funcTransform struct {
+	name string
+	fn   func(doc *gedcom.Document, report *Report) error
+}
+
+// Func returns a Transform named name that calls fn. fn records its
+// changes on the report it is given.
+//
+//	upper := transforms.Func("upper-surnames", func(doc *gedcom.Document, r *transforms.Report) error {
+//	    ...
+//	})
+func Func(name string, fn func(doc *gedcom.Document, report *Report) error) Transform {
+	return &funcTransform{name: name, fn: fn}
+}
+
+// Name returns the transform's name.
+func (t *funcTransform) Name() string { return t.name }
+
+// Apply calls the transform's function.
+func (t *funcTransform) Apply(doc *gedcom.Document) (*Report, error) {
+	report := newReport(t.name)
+	if doc == nil {
+		return report, errNilDocument
+	}
+	if err := t.fn(doc, report); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// syncEntityTags points the entity's raw tags at record.Tags after the
+// record's tags were replaced.
+func syncEntityTags(record *gedcom.Record) {
+	switch entity := record.Entity.(type) {
+	case *gedcom.Individual:
+		entity.Tags = record.Tags
+	case *gedcom.Family:
+		entity.Tags = record.Tags
+	case *gedcom.Source:
+		entity.Tags = record.Tags
+	case *gedcom.Repository:
+		entity.Tags = record.Tags
+	case *gedcom.Note:
+		entity.Tags = record.Tags
+	case *gedcom.SharedNote:
+		entity.Tags = record.Tags
+	case *gedcom.Submitter:
+		entity.Tags = record.Tags
+	case *gedcom.MediaObject:
+		entity.Tags = record.Tags
+	}
+}
@@ -0,0 +1,69 @@
+package transforms
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/encoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const testGEDCOM = `0 HEAD
+1 SOUR TestApp
+1 GEDC
+2 VERS 5.5.1
+1 CHAR UTF-8
+1 SUBM @U1@
+0 @U1@ SUBM
+1 NAME Tester
+0 @I7@ INDI
+1 NAME John /Smith/
+1 SEX M
+1 BIRT
+2 DATE 1850
+2 PLAC Springfield, IL
+1 DEAT
+2 DATE 1920
+2 PLAC Springfield, Illinois
+1 OBJE @M1@
+1 FAMS @F3@
+0 @I9@ INDI
+1 NAME Jane /Smith/
+1 SEX F
+1 BIRT
+2 DATE 1990
+2 PLAC Springfield, IL
+1 OCCU Engineer
+1 _PRIVATE secret
+1 FAMC @F3@
+0 @F3@ FAM
+1 HUSB @I7@
+1 CHIL @I9@
+1 MARR
+2 DATE 1875
+1 OBJE
+2 FILE photo.jpg
+0 @M1@ OBJE
+1 FILE portrait.jpg
+0 TRLR
+`
+
+func decodeTest(t *testing.T, input string) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func encodeTest(t *testing.T, doc *gedcom.Document) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := encoder.Encode(&buf, doc); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	return buf.String()
+}
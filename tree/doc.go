@@ -0,0 +1,25 @@
+// Package tree builds ancestor (pedigree) and descendant trees of an
+// individual as typed node structures for chart renderers.
+//
+// Ancestors follows FAMC links upward and Descendants follows FAMS links
+// downward. Both return a Tree of Node values, each holding its
+// *gedcom.Individual and the families that lead to the next generation:
+//
+//	t, err := tree.Ancestors(doc, "@I1@", &tree.Options{Generations: 4})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	t.Walk(func(n *tree.Node) bool {
+//	    fmt.Println(strings.Repeat("  ", n.Generation), n.Individual.XRef)
+//	    return true
+//	})
+//
+// An individual reached a second time, through pedigree collapse or cousin
+// marriage, appears again as a Node marked Repeat whose families are left
+// out, so trees stay finite even on documents with circular links.
+//
+// The nodes carry the document's own entities, leaving names, dates, and
+// layout to the renderer. For charts with formatted names and dates,
+// Ahnentafel numbers, and placeholders for unknown parents, see the
+// report package's Pedigree and Descendants.
+package tree
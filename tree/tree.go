@@ -0,0 +1,217 @@
+package tree
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// Options configures Ancestors and Descendants.
+type Options struct {
+	// Generations limits the depth of the tree: 1 includes the root's
+	// parents (or children), 2 also their parents (or children), and so
+	// on. Zero or less means no limit.
+	Generations int
+
+	// BirthFamiliesOnly restricts ancestor trees to birth families: FAMC
+	// links with no PEDI or PEDI birth. Adoptive, foster, and sealing
+	// families are included otherwise. It has no effect on descendant
+	// trees.
+	BirthFamiliesOnly bool
+}
+
+// Tree is the ancestor or descendant tree of an individual.
+type Tree struct {
+	// Root is the node of the individual the tree was built for.
+	Root *Node
+
+	// Generations is the deepest generation in the tree: 0 if the root
+	// has no recorded parents (or children).
+	Generations int
+
+	// Count is the number of nodes in the tree, the root and repeats
+	// included and spouses not.
+	Count int
+}
+
+// Node is one individual of a tree.
+type Node struct {
+	// Individual is the individual's record entity.
+	Individual *gedcom.Individual
+
+	// Generation is 0 for the root, 1 for its parents (or children), and
+	// so on. A spouse has the generation of the person they married.
+	Generation int
+
+	// Repeat marks an individual already expanded elsewhere in the tree.
+	// Its Families are left out.
+	Repeat bool
+
+	// Families lead to the next generation: the families the individual
+	// is a child of in an ancestor tree, in FAMC order, and the families
+	// they are a spouse in in a descendant tree, in FAMS order. Nil for a
+	// spouse, a repeat, and the last generation allowed by Options.
+	Families []*FamilyNode
+}
+
+// FamilyNode is a family connecting a node to the next generation.
+type FamilyNode struct {
+	// Family is the family's record entity.
+	Family *gedcom.Family
+
+	// Pedigree is the PEDI value of the FAMC link from the child, in
+	// ancestor trees; empty in descendant trees.
+	Pedigree gedcom.Pedigree
+
+	// Parents are the family's husband and wife, in that order and where
+	// recorded, in ancestor trees; nil in descendant trees.
+	Parents []*Node
+
+	// Spouse is the other partner of the family in a descendant tree, nil
+	// if the family has none and in ancestor trees. Its own families are
+	// not followed.
+	Spouse *Node
+
+	// Children are the family's children, in CHIL order, in descendant
+	// trees; nil in ancestor trees.
+	Children []*Node
+}
+
+// Next returns the nodes of the next generation reached through f: the
+// parents in an ancestor tree, the children in a descendant tree.
+func (f *FamilyNode) Next() []*Node {
+	if f.Parents != nil {
+		return f.Parents
+	}
+	return f.Children
+}
+
+// Walk calls fn for each node of the tree, depth first, parents (or
+// children) after the node they belong to. Spouses are not visited. If fn
+// returns false, the nodes above (or below) that node are skipped.
+func (t *Tree) Walk(fn func(*Node) bool) {
+	if t != nil && t.Root != nil {
+		walk(t.Root, fn)
+	}
+}
+
+func walk(n *Node, fn func(*Node) bool) {
+	if !fn(n) {
+		return
+	}
+	for _, f := range n.Families {
+		for _, next := range f.Next() {
+			walk(next, fn)
+		}
+	}
+}
+
+// Ancestors returns the ancestor tree of the individual xref, following
+// the FAMC links of each individual to the husband and wife of the family.
+func Ancestors(doc *gedcom.Document, xref string, opts *Options) (*Tree, error) {
+	b, root, err := newBuilder(doc, xref, opts)
+	if err != nil {
+		return nil, err
+	}
+	return b.tree(b.ancestor(root, 0)), nil
+}
+
+// Descendants returns the descendant tree of the individual xref,
+// following the FAMS links of each individual to the children of the
+// family.
+func Descendants(doc *gedcom.Document, xref string, opts *Options) (*Tree, error) {
+	b, root, err := newBuilder(doc, xref, opts)
+	if err != nil {
+		return nil, err
+	}
+	return b.tree(b.descendant(root, 0)), nil
+}
+
+// builder holds the state of one Ancestors or Descendants call.
+type builder struct {
+	doc   *gedcom.Document
+	opts  Options
+	seen  map[string]bool
+	count int
+	depth int
+}
+
+func newBuilder(doc *gedcom.Document, xref string, opts *Options) (*builder, *gedcom.Individual, error) {
+	if doc == nil {
+		return nil, nil, fmt.Errorf("tree: no document")
+	}
+	ind := doc.GetIndividual(xref)
+	if ind == nil {
+		return nil, nil, fmt.Errorf("tree: individual %s not found", xref)
+	}
+	b := &builder{doc: doc, seen: make(map[string]bool)}
+	if opts != nil {
+		b.opts = *opts
+	}
+	return b, ind, nil
+}
+
+func (b *builder) tree(root *Node) *Tree {
+	return &Tree{Root: root, Generations: b.depth, Count: b.count}
+}
+
+// node returns the node of ind and reports whether its families should be
+// followed: not for a repeat or at the generation limit.
+func (b *builder) node(ind *gedcom.Individual, generation int) (*Node, bool) {
+	n := &Node{Individual: ind, Generation: generation, Repeat: b.seen[ind.XRef]}
+	b.seen[ind.XRef] = true
+	b.count++
+	b.depth = max(b.depth, generation)
+	limited := b.opts.Generations > 0 && generation >= b.opts.Generations
+	return n, !n.Repeat && !limited
+}
+
+// ancestor builds the node of ind with its ancestors.
+func (b *builder) ancestor(ind *gedcom.Individual, generation int) *Node {
+	n, expand := b.node(ind, generation)
+	if !expand {
+		return n
+	}
+	for _, link := range ind.ChildInFamilies {
+		if b.opts.BirthFamiliesOnly && !link.Pedigree.IsBirth() {
+			continue
+		}
+		fam := b.doc.GetFamily(link.FamilyXRef)
+		if fam == nil {
+			continue
+		}
+		f := &FamilyNode{Family: fam, Pedigree: link.Pedigree, Parents: []*Node{}}
+		for _, xref := range []string{fam.Husband, fam.Wife} {
+			if parent := b.doc.GetIndividual(xref); parent != nil {
+				f.Parents = append(f.Parents, b.ancestor(parent, generation+1))
+			}
+		}
+		n.Families = append(n.Families, f)
+	}
+	return n
+}
+
+// descendant builds the node of ind with its descendants.
+func (b *builder) descendant(ind *gedcom.Individual, generation int) *Node {
+	n, expand := b.node(ind, generation)
+	if !expand {
+		return n
+	}
+	for _, fam := range ind.SpouseFamilies(b.doc) {
+		f := &FamilyNode{Family: fam}
+		spouseXRef := fam.Husband
+		if spouseXRef == ind.XRef {
+			spouseXRef = fam.Wife
+		}
+		if spouse := b.doc.GetIndividual(spouseXRef); spouse != nil {
+			f.Spouse = &Node{Individual: spouse, Generation: generation}
+		}
+		for _, xref := range fam.Children {
+			if child := b.doc.GetIndividual(xref); child != nil {
+				f.Children = append(f.Children, b.descendant(child, generation+1))
+			}
+		}
+		n.Families = append(n.Families, f)
+	}
+	return n
+}
@@ -0,0 +1,229 @@
+package tree
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// treeGEDCOM has an adopted child (@I1@) whose birth parents are siblings,
+// so the grandparents appear twice in her pedigree, and a grandfather with
+// a second family.
+const treeGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME Ann /Smith/
+1 FAMC @F2@
+2 PEDI adopted
+1 FAMC @F1@
+0 @I2@ INDI
+1 NAME John /Smith/
+1 FAMS @F1@
+1 FAMC @F3@
+0 @I3@ INDI
+1 NAME Mary /Smith/
+1 FAMS @F1@
+1 FAMC @F3@
+0 @I4@ INDI
+1 NAME Tom /Smith/
+1 FAMS @F3@
+1 FAMS @F4@
+0 @I5@ INDI
+1 NAME Foster /Parent/
+1 FAMS @F2@
+0 @I6@ INDI
+1 NAME Eve /Brown/
+1 FAMS @F3@
+0 @I7@ INDI
+1 NAME Jane /Green/
+1 FAMS @F4@
+0 @I8@ INDI
+1 NAME Sam /Smith/
+1 FAMC @F4@
+0 @F1@ FAM
+1 HUSB @I2@
+1 WIFE @I3@
+1 CHIL @I1@
+0 @F2@ FAM
+1 HUSB @I5@
+1 CHIL @I1@
+0 @F3@ FAM
+1 HUSB @I4@
+1 WIFE @I6@
+1 CHIL @I2@
+1 CHIL @I3@
+0 @F4@ FAM
+1 HUSB @I4@
+1 WIFE @I7@
+1 CHIL @I8@
+0 TRLR
+`
+
+func decodeTree(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(treeGEDCOM))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+// outline renders t one node per line, indented by generation, with
+// repeats marked by "*".
+func outline(t *Tree) []string {
+	var lines []string
+	t.Walk(func(n *Node) bool {
+		line := strings.Repeat(" ", n.Generation) + n.Individual.XRef
+		if n.Repeat {
+			line += "*"
+		}
+		lines = append(lines, line)
+		return true
+	})
+	return lines
+}
+
+func TestAncestors(t *testing.T) {
+	doc := decodeTree(t)
+	tr, err := Ancestors(doc, "@I1@", nil)
+	if err != nil {
+		t.Fatalf("Ancestors() error = %v", err)
+	}
+
+	want := []string{
+		"@I1@",
+		" @I5@",
+		" @I2@",
+		"  @I4@",
+		"  @I6@",
+		" @I3@",
+		"  @I4@*",
+		"  @I6@*",
+	}
+	if got := outline(tr); !reflect.DeepEqual(got, want) {
+		t.Errorf("outline = %q, want %q", got, want)
+	}
+	if tr.Generations != 2 || tr.Count != 8 {
+		t.Errorf("Generations, Count = %d, %d, want 2, 8", tr.Generations, tr.Count)
+	}
+
+	families := tr.Root.Families
+	if len(families) != 2 {
+		t.Fatalf("root has %d families, want 2", len(families))
+	}
+	if families[0].Family.XRef != "@F2@" || families[0].Pedigree != "adopted" {
+		t.Errorf("first family = %s (PEDI %q), want @F2@ adopted", families[0].Family.XRef, families[0].Pedigree)
+	}
+	if families[1].Children != nil || families[1].Spouse != nil {
+		t.Error("ancestor family has Children or Spouse set")
+	}
+	repeat := families[1].Parents[1].Families[0].Parents[0]
+	if !repeat.Repeat || repeat.Families != nil {
+		t.Errorf("repeated @I4@ = %+v, want Repeat with no families", repeat)
+	}
+}
+
+func TestAncestors_Options(t *testing.T) {
+	doc := decodeTree(t)
+
+	tr, err := Ancestors(doc, "@I1@", &Options{Generations: 1, BirthFamiliesOnly: true})
+	if err != nil {
+		t.Fatalf("Ancestors() error = %v", err)
+	}
+	want := []string{"@I1@", " @I2@", " @I3@"}
+	if got := outline(tr); !reflect.DeepEqual(got, want) {
+		t.Errorf("outline = %q, want %q", got, want)
+	}
+	if tr.Root.Families[0].Parents[0].Families != nil {
+		t.Error("node at the generation limit has families")
+	}
+
+	tr, err = Ancestors(doc, "@I4@", nil)
+	if err != nil {
+		t.Fatalf("Ancestors() error = %v", err)
+	}
+	if tr.Generations != 0 || tr.Count != 1 || tr.Root.Families != nil {
+		t.Errorf("tree of @I4@ = %+v, want the root alone", tr)
+	}
+}
+
+func TestDescendants(t *testing.T) {
+	doc := decodeTree(t)
+	tr, err := Descendants(doc, "@I4@", nil)
+	if err != nil {
+		t.Fatalf("Descendants() error = %v", err)
+	}
+
+	want := []string{
+		"@I4@",
+		" @I2@",
+		"  @I1@",
+		" @I3@",
+		"  @I1@*",
+		" @I8@",
+	}
+	if got := outline(tr); !reflect.DeepEqual(got, want) {
+		t.Errorf("outline = %q, want %q", got, want)
+	}
+	if tr.Generations != 2 || tr.Count != 6 {
+		t.Errorf("Generations, Count = %d, %d, want 2, 6", tr.Generations, tr.Count)
+	}
+
+	families := tr.Root.Families
+	if len(families) != 2 || families[0].Family.XRef != "@F3@" || families[1].Family.XRef != "@F4@" {
+		t.Fatalf("root families = %+v, want @F3@, @F4@", families)
+	}
+	if spouse := families[1].Spouse; spouse == nil || spouse.Individual.XRef != "@I7@" || spouse.Generation != 0 {
+		t.Errorf("second family spouse = %+v, want @I7@ at generation 0", spouse)
+	}
+	if families[0].Parents != nil || families[0].Pedigree != "" {
+		t.Error("descendant family has Parents or Pedigree set")
+	}
+
+	tr, err = Descendants(doc, "@I4@", &Options{Generations: 1})
+	if err != nil {
+		t.Fatalf("Descendants() error = %v", err)
+	}
+	if tr.Count != 4 || tr.Generations != 1 {
+		t.Errorf("limited Count, Generations = %d, %d, want 4, 1", tr.Count, tr.Generations)
+	}
+}
+
+func TestTree_WalkSkip(t *testing.T) {
+	tr, err := Descendants(decodeTree(t), "@I4@", nil)
+	if err != nil {
+		t.Fatalf("Descendants() error = %v", err)
+	}
+	var visited []string
+	tr.Walk(func(n *Node) bool {
+		visited = append(visited, n.Individual.XRef)
+		return n.Individual.XRef != "@I2@"
+	})
+	want := []string{"@I4@", "@I2@", "@I3@", "@I1@", "@I8@"}
+	if !reflect.DeepEqual(visited, want) {
+		t.Errorf("visited = %v, want %v", visited, want)
+	}
+
+	var nilTree *Tree
+	nilTree.Walk(func(*Node) bool {
+		t.Error("Walk on nil tree called fn")
+		return true
+	})
+}
+
+func TestErrors(t *testing.T) {
+	doc := decodeTree(t)
+	if _, err := Ancestors(nil, "@I1@", nil); err == nil {
+		t.Error("Ancestors(nil doc) error = nil")
+	}
+	if _, err := Descendants(doc, "@I99@", nil); err == nil {
+		t.Error("Descendants(missing) error = nil")
+	}
+	if _, err := Ancestors(doc, "@F1@", nil); err == nil {
+		t.Error("Ancestors(family XRef) error = nil")
+	}
+}
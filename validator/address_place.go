@@ -0,0 +1,145 @@
+// address_place.go provides event address versus place consistency checks.
+//
+// An event can carry both a PLAC, the jurisdiction, and an ADDR, the street
+// address. When the city, state, or country of the address names somewhere
+// the place does not, one of the two was usually copied from another event
+// and not updated.
+
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// AddressPlaceValidator checks that event addresses agree with event places.
+type AddressPlaceValidator struct{}
+
+// NewAddressPlaceValidator creates a new AddressPlaceValidator.
+func NewAddressPlaceValidator() *AddressPlaceValidator {
+	return &AddressPlaceValidator{}
+}
+
+// Validate compares the CITY, STAE, and CTRY of each individual and family
+// event address with the event's place. A field disagrees when neither it
+// nor any comma-separated component of the place contains the other as
+// whole words, ignoring case and punctuation. Names of three letters or
+// fewer are taken to be abbreviations ("MA", "USA"): such a field is not
+// compared, and a place containing one agrees with any state or country.
+// Addresses without these fields are skipped, since free-text addresses
+// cannot be compared reliably.
+//
+// Each disagreeing event produces one warning with "address", "place", and
+// "fields" (e.g. "CITY,STAE") details.
+func (a *AddressPlaceValidator) Validate(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	return a.visitor().run(doc)
+}
+
+// visitor returns the per-record form of Validate: individuals, then
+// families.
+func (a *AddressPlaceValidator) visitor() *recordVisitor {
+	var rv *recordVisitor
+	checkEvents := func(phase int, xref string, events []*gedcom.Event) {
+		for _, event := range events {
+			if event == nil || event.Address == nil || strings.TrimSpace(event.Place) == "" {
+				continue
+			}
+			addr := event.Address
+			var fields []string
+			for _, f := range []struct {
+				tag, value string
+				region     bool
+			}{
+				{"CITY", addr.City, false},
+				{"STAE", addr.State, true},
+				{"CTRY", addr.Country, true},
+			} {
+				if !placeMentions(event.Place, f.value, f.region) {
+					fields = append(fields, f.tag)
+				}
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			address := formatAddress(addr)
+			rv.add(phase, NewIssue(
+				SeverityWarning,
+				CodeAddressPlaceMismatch,
+				fmt.Sprintf("%s address %q does not match place %q", event.Type, address, event.Place),
+				xref,
+			).
+				WithDetail("tag", string(event.Type)).
+				WithDetail("address", address).
+				WithDetail("place", event.Place).
+				WithDetail("fields", strings.Join(fields, ",")))
+		}
+	}
+
+	rv = newRecordVisitor(2, func(_ *recordVisitor, record *gedcom.Record) {
+		if ind, ok := recordIndividual(record); ok {
+			checkEvents(0, ind.XRef, ind.Events)
+		}
+		if fam, ok := recordFamily(record); ok {
+			checkEvents(1, fam.XRef, fam.Events)
+		}
+	})
+	return rv
+}
+
+// placeMentions reports whether place agrees with the address field value:
+// the value is empty or an abbreviation, or it and a place component
+// contain one another as whole words. For a region (state or country), a
+// place with an abbreviated component also agrees, since "MA" or "USA"
+// may stand for the value.
+func placeMentions(place, value string, region bool) bool {
+	v := placeWords(value)
+	if isAbbreviation(v) {
+		return true
+	}
+	padded := " " + v + " "
+	for _, component := range strings.Split(place, ",") {
+		c := placeWords(component)
+		if c == "" {
+			continue
+		}
+		if region && isAbbreviation(c) {
+			return true
+		}
+		if strings.Contains(" "+c+" ", padded) || strings.Contains(padded, " "+c+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// isAbbreviation reports whether words, as returned by placeWords, has
+// three letters or fewer.
+func isAbbreviation(words string) bool {
+	return utf8.RuneCountInString(strings.ReplaceAll(words, " ", "")) <= 3
+}
+
+// placeWords lower-cases s and reduces it to words separated by single
+// spaces.
+func placeWords(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// formatAddress joins the non-empty lines and fields of addr with ", ".
+func formatAddress(addr *gedcom.Address) string {
+	var parts []string
+	for _, p := range []string{addr.Line1, addr.Line2, addr.Line3, addr.City, addr.State, addr.PostalCode, addr.Country} {
+		if p = strings.Join(strings.Fields(p), " "); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+)
+
+func TestAddressPlaceValidator_Validate(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Doe/
+1 BIRT
+2 PLAC Boston, Suffolk, Massachusetts, USA
+2 ADDR 12 Beacon St
+3 CITY Boston
+3 STAE MA
+3 CTRY United States
+1 RESI
+2 PLAC Springfield, Sangamon, Illinois, USA
+2 ADDR 40 Elm St
+3 CITY Boston
+3 STAE Massachusetts
+1 CENS
+2 PLAC New York City, New York, USA
+2 ADDR 5 Broadway
+3 CITY New York
+1 DEAT
+2 PLAC St. Louis, Missouri
+2 ADDR 8 Pine St
+3 CITY ST LOUIS
+1 BURI
+2 PLAC Salem
+2 ADDR Old Burying Point, Salem
+1 CHR
+2 PLAC Springfield, Sangamon, Illinois
+2 ADDR First Parish
+3 CITY Springfield
+3 STAE Massachusetts
+0 @F1@ FAM
+1 HUSB @I1@
+1 MARR
+2 PLAC Hartford, Connecticut, United States
+2 ADDR First Church
+3 CITY Hartford
+3 CTRY Canada
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	issues := NewAddressPlaceValidator().Validate(doc)
+	if len(issues) != 3 {
+		t.Fatalf("Validate() returned %d issues, want 3: %v", len(issues), issues)
+	}
+
+	resi := issues[0]
+	if resi.Code != CodeAddressPlaceMismatch || resi.Severity != SeverityWarning || resi.RecordXRef != "@I1@" {
+		t.Errorf("RESI issue = %+v", resi)
+	}
+	if resi.Details["tag"] != "RESI" || resi.Details["fields"] != "CITY" ||
+		resi.Details["address"] != "40 Elm St, Boston, Massachusetts" ||
+		resi.Details["place"] != "Springfield, Sangamon, Illinois, USA" {
+		t.Errorf("RESI details = %v", resi.Details)
+	}
+
+	// A USA place component could stand for any state; without one, the
+	// state is compared.
+	if chr := issues[1]; chr.Details["tag"] != "CHR" || chr.Details["fields"] != "STAE" {
+		t.Errorf("CHR issue = %+v", chr)
+	}
+
+	marr := issues[2]
+	if marr.RecordXRef != "@F1@" || marr.Details["fields"] != "CTRY" {
+		t.Errorf("MARR issue = %+v", marr)
+	}
+}
+
+func TestValidator_ValidateAddressPlaces(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Doe/
+1 RESI
+2 PLAC Springfield, Illinois
+2 ADDR 40 Elm St
+3 CITY Boston
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if issues := New().ValidateAddressPlaces(doc); len(issues) != 1 {
+		t.Errorf("ValidateAddressPlaces() = %v, want 1 issue", issues)
+	}
+	if issues := New().ValidateAll(doc); len(FilterByCode(issues, CodeAddressPlaceMismatch)) != 1 {
+		t.Errorf("ValidateAll() should include address/place issues, got %v", issues)
+	}
+	relaxed := NewWithOptions(&ValidateOptions{Strictness: StrictnessRelaxed})
+	if issues := relaxed.ValidateAddressPlaces(doc); len(issues) != 0 {
+		t.Errorf("relaxed strictness should drop warnings, got %v", issues)
+	}
+	if New().ValidateAddressPlaces(nil) != nil {
+		t.Error("ValidateAddressPlaces(nil) should return nil")
+	}
+}
@@ -0,0 +1,88 @@
+// cardinality.go provides maximum cardinality validation.
+//
+// The GEDCOM grammar limits how often many substructures may appear: one
+// SEX per INDI, at most one HUSB and one WIFE per FAM, one DATE and one
+// PLAC per event, one GEDC per header, and so on. Decoders keep repeated
+// singletons in the raw tags while the typed fields hold only one of them,
+// so the extra values are otherwise lost silently.
+
+package validator
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// CardinalityValidator checks that singleton tags are not repeated.
+type CardinalityValidator struct{}
+
+// NewCardinalityValidator creates a new CardinalityValidator.
+func NewCardinalityValidator() *CardinalityValidator {
+	return &CardinalityValidator{}
+}
+
+// Validate reports each tag of the header and records repeated beyond its
+// maximum for the document's GEDCOM version (see gedcom.CheckCardinality).
+// Issues are errors with "path", "tag", "count", "max", and, for decoded
+// files, "line" details, except that repeated HUSB or WIFE lines of a
+// family are CodeMultipleSpouses warnings.
+func (c *CardinalityValidator) Validate(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	return c.visitor(doc).run(doc)
+}
+
+// visitor returns the per-record form of Validate, with the header's
+// issues first. It checks each record as gedcom.CheckCardinality does.
+func (c *CardinalityValidator) visitor(doc *gedcom.Document) *recordVisitor {
+	var version gedcom.Version
+	if doc.Header != nil {
+		version = doc.Header.Version
+	}
+	rv := newRecordVisitor(1, func(rv *recordVisitor, record *gedcom.Record) {
+		for _, v := range gedcom.CheckTagCardinality(version, string(record.Type), record.Tags) {
+			v.XRef = record.XRef
+			if v.Path == "FAM" && (v.Tag == "HUSB" || v.Tag == "WIFE") {
+				rv.add(0, multipleSpousesIssue(v))
+				continue
+			}
+			rv.add(0, cardinalityIssue(v))
+		}
+	})
+	if doc.Header != nil {
+		for _, v := range gedcom.CheckTagCardinality(version, "HEAD", doc.Header.Tags) {
+			rv.add(0, cardinalityIssue(v))
+		}
+	}
+	return rv
+}
+
+// cardinalityIssue converts a violation to an issue.
+func cardinalityIssue(v gedcom.CardinalityViolation) Issue {
+	issue := NewIssue(
+		SeverityError,
+		CodeCardinalityExceeded,
+		fmt.Sprintf("%s appears %d times under %s; at most %d allowed", v.Tag, v.Count, v.Path, v.Max),
+		v.XRef,
+	).
+		WithDetail("path", v.Path).
+		WithDetail("tag", v.Tag).
+		WithDetail("count", fmt.Sprintf("%d", v.Count)).
+		WithDetail("max", fmt.Sprintf("%d", v.Max))
+	if v.LineNumber > 0 {
+		issue = issue.WithDetail("line", fmt.Sprintf("%d", v.LineNumber))
+	}
+	return issue
+}
+
+// multipleSpousesIssue converts a repeated FAM.HUSB or FAM.WIFE to a
+// warning with the details of cardinalityIssue.
+func multipleSpousesIssue(v gedcom.CardinalityViolation) Issue {
+	issue := cardinalityIssue(v)
+	issue.Severity = SeverityWarning
+	issue.Code = CodeMultipleSpouses
+	issue.Message = fmt.Sprintf("family has %d %s lines; the extra partners are kept in Family.Spouses", v.Count, v.Tag)
+	return issue
+}
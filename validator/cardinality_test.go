@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+)
+
+func TestCardinalityValidator_Validate(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME John /Doe/
+1 SEX M
+1 SEX F
+1 BIRT
+2 DATE 1850
+2 PLAC Boston
+2 PLAC Salem
+0 @F1@ FAM
+1 HUSB @I1@
+1 HUSB @I2@
+1 CHIL @I3@
+1 CHIL @I4@
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	issues := NewCardinalityValidator().Validate(doc)
+	if len(issues) != 3 {
+		t.Fatalf("Validate() returned %d issues, want 3: %v", len(issues), issues)
+	}
+	first := issues[0]
+	if first.Code != CodeCardinalityExceeded || first.Severity != SeverityError || first.RecordXRef != "@I1@" {
+		t.Errorf("first issue = %+v", first)
+	}
+	if first.Details["path"] != "INDI" || first.Details["tag"] != "SEX" || first.Details["count"] != "2" ||
+		first.Details["max"] != "1" || first.Details["line"] != "7" {
+		t.Errorf("first issue details = %v", first.Details)
+	}
+	if issues[1].Details["path"] != "INDI.BIRT" || issues[2].RecordXRef != "@F1@" {
+		t.Errorf("issues = %v", issues)
+	}
+	// Two HUSB lines are tolerated: a warning, not a cardinality error.
+	if issues[2].Code != CodeMultipleSpouses || issues[2].Severity != SeverityWarning || issues[2].Details["tag"] != "HUSB" {
+		t.Errorf("family issue = %+v, want a MULTIPLE_SPOUSES warning", issues[2])
+	}
+
+	all := New().ValidateAll(doc)
+	if len(FilterByCode(all, CodeCardinalityExceeded)) != 2 || len(FilterByCode(all, CodeMultipleSpouses)) != 1 {
+		t.Errorf("ValidateAll() did not include cardinality issues: %v", all)
+	}
+	if got := New().ValidateCardinality(doc); len(got) != 3 {
+		t.Errorf("ValidateCardinality() = %v", got)
+	}
+	if New().ValidateCardinality(nil) != nil {
+		t.Error("ValidateCardinality(nil) should return nil")
+	}
+}
+
+func TestValidatorConfig_Severity(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 SEX M
+0 @I2@ INDI
+1 SEX M
+0 @F1@ FAM
+1 HUSB @I1@
+1 HUSB @I2@
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	// Info is hidden at the default strictness.
+	relaxed := NewWithConfig(&ValidatorConfig{Severity: map[string]Severity{CodeMultipleSpouses: SeverityInfo}})
+	if got := FilterByCode(relaxed.ValidateAll(doc), CodeMultipleSpouses); len(got) != 0 {
+		t.Errorf("with SeverityInfo override, ValidateAll() = %v", got)
+	}
+
+	strict := NewWithConfig(&ValidatorConfig{
+		Strictness: StrictnessRelaxed,
+		Severity:   map[string]Severity{CodeMultipleSpouses: SeverityError},
+	})
+	got := FilterByCode(strict.ValidateCardinality(doc), CodeMultipleSpouses)
+	if len(got) != 1 || got[0].Severity != SeverityError {
+		t.Errorf("with SeverityError override, ValidateCardinality() = %v", got)
+	}
+	if New().ValidateCardinality(doc)[0].Severity != SeverityWarning {
+		t.Error("default severity of MULTIPLE_SPOUSES should be a warning")
+	}
+}
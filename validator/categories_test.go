@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+)
+
+func TestValidator_ValidateAll_Categories(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+1 LANG English
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE 1 JAN 1900
+2 PLAC Nowhere
+3 MAP
+4 LATI N0
+4 LONG E0
+1 DEAT
+2 DATE 1 JAN 1850
+1 FAMC @F9@
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	all := New().ValidateAll(doc)
+	byCategory := make(map[string]int)
+	for _, issue := range all {
+		if issue.Category == "" {
+			t.Errorf("issue %s has no category", issue.Code)
+		}
+		byCategory[issue.Category]++
+	}
+	for _, category := range []string{CategoryStructure, CategoryDates, CategoryReferences, CategoryQuality} {
+		if byCategory[category] == 0 {
+			t.Fatalf("fixture should produce %s issues, got %v", category, byCategory)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		categories []string
+	}{
+		{"dates", []string{CategoryDates}},
+		{"structure and references", []string{CategoryStructure, CategoryReferences}},
+		{"quality", []string{CategoryQuality}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := NewWithOptions(&ValidateOptions{Strictness: StrictnessNormal, Categories: tt.categories}).ValidateAll(doc)
+			want := 0
+			for _, category := range tt.categories {
+				want += byCategory[category]
+			}
+			if len(issues) != want {
+				t.Errorf("got %d issues, want %d", len(issues), want)
+			}
+			for _, issue := range issues {
+				found := false
+				for _, category := range tt.categories {
+					found = found || issue.Category == category
+				}
+				if !found {
+					t.Errorf("issue %s has category %q", issue.Code, issue.Category)
+				}
+			}
+		})
+	}
+
+	if issues := NewWithOptions(&ValidateOptions{Categories: []string{"unknown"}}).ValidateAll(doc); len(issues) != 0 {
+		t.Errorf("unknown category: got %d issues, want 0", len(issues))
+	}
+}
+
+func TestValidator_ValidateAll_SkipRulesSkipsChecks(t *testing.T) {
+	v := NewWithOptions(&ValidateOptions{SkipRules: []string{CodePotentialDuplicate}})
+	for _, check := range v.checks() {
+		if check.name == "duplicates" {
+			t.Error("duplicates check should not run when CodePotentialDuplicate is skipped")
+		}
+	}
+
+	// A check still runs while any of its codes is not skipped.
+	v = NewWithOptions(&ValidateOptions{SkipRules: []string{CodeOrphanedFAMC}})
+	found := false
+	for _, check := range v.checks() {
+		found = found || check.name == "references"
+	}
+	if !found {
+		t.Error("references check should run when only some of its codes are skipped")
+	}
+
+	doc := contextTestDocument(t)
+	run, err := NewWithOptions(&ValidateOptions{
+		SkipRules: []string{CodePotentialDuplicate},
+		Workers:   2,
+	}).ValidateAllContext(context.Background(), doc)
+	if err != nil {
+		t.Fatalf("ValidateAllContext() error = %v", err)
+	}
+	for _, timing := range run.Timings {
+		if timing.Check == "duplicates" {
+			t.Error("ValidateAllContext ran the skipped duplicates check")
+		}
+	}
+	for _, issue := range run.Issues {
+		if issue.Code == CodePotentialDuplicate {
+			t.Error("got a PotentialDuplicate issue despite SkipRules")
+		}
+	}
+}
@@ -0,0 +1,470 @@
+// completeness.go provides configurable record completeness profiles.
+//
+// A CompletenessProfile lists the requirements a record of one type should
+// meet (for example "at least one name, a sex, a birth date, and a source").
+// Evaluating a profile scores every matching record and aggregates coverage
+// per requirement, giving research to-do lists a ranked starting point.
+
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// CompletenessRequirement is one criterion of a CompletenessProfile.
+type CompletenessRequirement struct {
+	// Name identifies the requirement in reports (e.g., "BIRT date").
+	Name string
+
+	// Weight is the requirement's share of a record's score relative to the
+	// other requirements. Zero or negative weights count as 1.
+	Weight float64
+
+	// Check reports whether record meets the requirement. doc is the
+	// document being evaluated and may be used to follow references.
+	Check func(doc *gedcom.Document, record *gedcom.Record) bool
+}
+
+// weight returns the effective weight of the requirement.
+func (r CompletenessRequirement) weight() float64 {
+	if r.Weight <= 0 {
+		return 1
+	}
+	return r.Weight
+}
+
+// CompletenessProfile is a named set of requirements for records of one type.
+type CompletenessProfile struct {
+	// Name identifies the profile in reports.
+	Name string
+
+	// RecordType selects the records the profile applies to.
+	RecordType gedcom.RecordType
+
+	// Requirements are evaluated in order for every matching record.
+	Requirements []CompletenessRequirement
+}
+
+// DefaultIndividualProfile returns a profile requiring each individual to
+// have at least one name, a sex, a birth date, and at least one source
+// citation.
+func DefaultIndividualProfile() *CompletenessProfile {
+	return &CompletenessProfile{
+		Name:       "individual",
+		RecordType: gedcom.RecordTypeIndividual,
+		Requirements: []CompletenessRequirement{
+			RequireNames(1),
+			RequireSex(),
+			RequireEventDate(gedcom.EventBirth),
+			RequireSources(1),
+		},
+	}
+}
+
+// DefaultFamilyProfile returns a profile requiring each family to have both
+// spouses, a marriage date, and at least one source citation.
+func DefaultFamilyProfile() *CompletenessProfile {
+	return &CompletenessProfile{
+		Name:       "family",
+		RecordType: gedcom.RecordTypeFamily,
+		Requirements: []CompletenessRequirement{
+			RequireSpouses(),
+			RequireEventDate(gedcom.EventMarriage),
+			RequireSources(1),
+		},
+	}
+}
+
+// RequireNames requires an individual to have at least minCount names.
+func RequireNames(minCount int) CompletenessRequirement {
+	name := "name"
+	if minCount > 1 {
+		name = fmt.Sprintf("%d names", minCount)
+	}
+	return CompletenessRequirement{
+		Name: name,
+		Check: func(_ *gedcom.Document, record *gedcom.Record) bool {
+			ind, ok := record.GetIndividual()
+			return ok && len(ind.Names) >= minCount
+		},
+	}
+}
+
+// RequireSex requires an individual to have a known sex (anything but "U"
+// or empty).
+func RequireSex() CompletenessRequirement {
+	return CompletenessRequirement{
+		Name: "sex",
+		Check: func(_ *gedcom.Document, record *gedcom.Record) bool {
+			ind, ok := record.GetIndividual()
+			return ok && ind.Sex != "" && !strings.EqualFold(ind.Sex, "U")
+		},
+	}
+}
+
+// RequireEventDate requires an individual or family to have an event of the
+// given type with a date.
+func RequireEventDate(eventType gedcom.EventType) CompletenessRequirement {
+	return CompletenessRequirement{
+		Name: string(eventType) + " date",
+		Check: func(_ *gedcom.Document, record *gedcom.Record) bool {
+			for _, event := range recordEvents(record, eventType) {
+				if event.Date != "" {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// RequireEventPlace requires an individual or family to have an event of
+// the given type with a place.
+func RequireEventPlace(eventType gedcom.EventType) CompletenessRequirement {
+	return CompletenessRequirement{
+		Name: string(eventType) + " place",
+		Check: func(_ *gedcom.Document, record *gedcom.Record) bool {
+			for _, event := range recordEvents(record, eventType) {
+				if event.Place != "" || (event.PlaceDetail != nil && event.PlaceDetail.Name != "") {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// RequireSources requires at least minCount source citations anywhere in an
+// individual or family record, including on its events and attributes.
+func RequireSources(minCount int) CompletenessRequirement {
+	name := "source"
+	if minCount > 1 {
+		name = fmt.Sprintf("%d sources", minCount)
+	}
+	return CompletenessRequirement{
+		Name: name,
+		Check: func(_ *gedcom.Document, record *gedcom.Record) bool {
+			return citationCount(record) >= minCount
+		},
+	}
+}
+
+// RequireParents requires an individual to be a child in a family that
+// exists in the document.
+func RequireParents() CompletenessRequirement {
+	return CompletenessRequirement{
+		Name: "parents",
+		Check: func(doc *gedcom.Document, record *gedcom.Record) bool {
+			ind, ok := record.GetIndividual()
+			return ok && doc != nil && len(ind.ParentalFamilies(doc)) > 0
+		},
+	}
+}
+
+// RequireSpouses requires a family to name both a husband and a wife.
+func RequireSpouses() CompletenessRequirement {
+	return CompletenessRequirement{
+		Name: "spouses",
+		Check: func(_ *gedcom.Document, record *gedcom.Record) bool {
+			fam, ok := record.GetFamily()
+			return ok && fam.Husband != "" && fam.Wife != ""
+		},
+	}
+}
+
+// RequireTag requires a record to have at least one level-1 substructure
+// with the given tag and a value or children (e.g., "TITL" for sources).
+// It inspects the raw tags, so it works for every record type.
+func RequireTag(tag string) CompletenessRequirement {
+	return CompletenessRequirement{
+		Name: tag,
+		Check: func(_ *gedcom.Document, record *gedcom.Record) bool {
+			for i, t := range record.Tags {
+				if t.Level != 1 || t.Tag != tag {
+					continue
+				}
+				if t.Value != "" || (i+1 < len(record.Tags) && record.Tags[i+1].Level > 1) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// recordEvents returns the events of the given type on an individual or
+// family record.
+func recordEvents(record *gedcom.Record, eventType gedcom.EventType) []*gedcom.Event {
+	if ind, ok := record.GetIndividual(); ok {
+		return ind.EventsOfType(eventType)
+	}
+	if fam, ok := record.GetFamily(); ok {
+		return fam.EventsOfType(eventType)
+	}
+	return nil
+}
+
+// citationCount counts the source citations of an individual or family,
+// including those on its events and attributes.
+func citationCount(record *gedcom.Record) int {
+	n := 0
+	if ind, ok := record.GetIndividual(); ok {
+		n += len(ind.SourceCitations)
+		for _, event := range ind.Events {
+			n += len(event.SourceCitations)
+		}
+		for _, attr := range ind.Attributes {
+			n += len(attr.SourceCitations)
+		}
+	}
+	if fam, ok := record.GetFamily(); ok {
+		n += len(fam.SourceCitations)
+		for _, event := range fam.Events {
+			n += len(event.SourceCitations)
+		}
+	}
+	return n
+}
+
+// RecordCompleteness is the result of evaluating one record against a profile.
+type RecordCompleteness struct {
+	// XRef is the evaluated record.
+	XRef string `json:"xref"`
+
+	// Score is the weighted fraction of requirements met, from 0.0 to 1.0.
+	Score float64 `json:"score"`
+
+	// Missing lists the names of unmet requirements, in profile order.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// Complete reports whether every requirement was met.
+func (r RecordCompleteness) Complete() bool {
+	return len(r.Missing) == 0
+}
+
+// RequirementCoverage aggregates one requirement across all evaluated records.
+type RequirementCoverage struct {
+	// Name is the requirement's name.
+	Name string `json:"name"`
+
+	// Satisfied is the number of records meeting the requirement.
+	Satisfied int `json:"satisfied"`
+
+	// Coverage is Satisfied divided by the number of records (0.0 to 1.0).
+	Coverage float64 `json:"coverage"`
+}
+
+// CompletenessReport is the result of evaluating a profile over a document.
+type CompletenessReport struct {
+	// Profile is the name of the evaluated profile.
+	Profile string `json:"profile"`
+
+	// Records holds one result per matching record, in document order.
+	Records []RecordCompleteness `json:"records"`
+
+	// Requirements holds coverage per requirement, in profile order.
+	Requirements []RequirementCoverage `json:"requirements"`
+
+	// CompleteRecords is the number of records meeting every requirement.
+	CompleteRecords int `json:"complete_records"`
+
+	// AverageScore is the mean record score (0.0 to 1.0).
+	AverageScore float64 `json:"average_score"`
+}
+
+// Incomplete returns the records missing at least one requirement, lowest
+// score first; ties keep document order. This is the research to-do list.
+func (r *CompletenessReport) Incomplete() []RecordCompleteness {
+	var result []RecordCompleteness
+	for _, rec := range r.Records {
+		if !rec.Complete() {
+			result = append(result, rec)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score < result[j].Score
+	})
+	return result
+}
+
+// Issues returns one Info issue with code INCOMPLETE_RECORD per record
+// missing at least one requirement, in document order.
+func (r *CompletenessReport) Issues() []Issue {
+	var issues []Issue
+	for _, rec := range r.Records {
+		if rec.Complete() {
+			continue
+		}
+		issue := NewIssue(
+			SeverityInfo,
+			CodeIncompleteRecord,
+			fmt.Sprintf("record is missing %s (%s profile)", strings.Join(rec.Missing, ", "), r.Profile),
+			rec.XRef,
+		).
+			WithDetail("profile", r.Profile).
+			WithDetail("missing", strings.Join(rec.Missing, ",")).
+			WithDetail("score", fmt.Sprintf("%.2f", rec.Score))
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// EvaluateRecord scores a single record against the profile. The record is
+// evaluated regardless of its type.
+func (p *CompletenessProfile) EvaluateRecord(doc *gedcom.Document, record *gedcom.Record) RecordCompleteness {
+	result, _ := p.evaluate(doc, record)
+	return result
+}
+
+// evaluate scores record and also reports which requirements it met.
+func (p *CompletenessProfile) evaluate(doc *gedcom.Document, record *gedcom.Record) (RecordCompleteness, []bool) {
+	result := RecordCompleteness{XRef: record.XRef, Score: 1}
+	met := make([]bool, len(p.Requirements))
+
+	var total, metWeight float64
+	for i, req := range p.Requirements {
+		total += req.weight()
+		if req.Check != nil && req.Check(doc, record) {
+			met[i] = true
+			metWeight += req.weight()
+		} else {
+			result.Missing = append(result.Missing, req.Name)
+		}
+	}
+	if total > 0 {
+		result.Score = metWeight / total
+	}
+	return result, met
+}
+
+// Evaluate scores every record of the profile's type in doc and aggregates
+// coverage. Returns an empty report if doc is nil.
+func (p *CompletenessProfile) Evaluate(doc *gedcom.Document) *CompletenessReport {
+	report := &CompletenessReport{
+		Profile:      p.Name,
+		Records:      []RecordCompleteness{},
+		Requirements: make([]RequirementCoverage, len(p.Requirements)),
+	}
+	for i, req := range p.Requirements {
+		report.Requirements[i].Name = req.Name
+	}
+	if doc == nil {
+		return report
+	}
+
+	var scoreSum float64
+	for _, record := range doc.Records {
+		if record == nil || record.Type != p.RecordType {
+			continue
+		}
+		result, met := p.evaluate(doc, record)
+		report.Records = append(report.Records, result)
+		scoreSum += result.Score
+		if result.Complete() {
+			report.CompleteRecords++
+		}
+		for i, ok := range met {
+			if ok {
+				report.Requirements[i].Satisfied++
+			}
+		}
+	}
+
+	if n := len(report.Records); n > 0 {
+		report.AverageScore = scoreSum / float64(n)
+		for i := range report.Requirements {
+			report.Requirements[i].Coverage = float64(report.Requirements[i].Satisfied) / float64(n)
+		}
+	}
+	return report
+}
+
+// CheckCompleteness evaluates each profile against doc. If no profiles are
+// given, DefaultIndividualProfile and DefaultFamilyProfile are used.
+func (v *Validator) CheckCompleteness(doc *gedcom.Document, profiles ...*CompletenessProfile) []*CompletenessReport {
+	if len(profiles) == 0 {
+		profiles = []*CompletenessProfile{DefaultIndividualProfile(), DefaultFamilyProfile()}
+	}
+	reports := make([]*CompletenessReport, 0, len(profiles))
+	for _, profile := range profiles {
+		if profile != nil {
+			reports = append(reports, profile.Evaluate(doc))
+		}
+	}
+	return reports
+}
+
+// Tasks converts each unmet requirement into an open research task, keyed
+// by record XRef. Each task's Description is "Find <requirement>" and its
+// Category is the profile name.
+func (r *CompletenessReport) Tasks() map[string][]*gedcom.Task {
+	tasks := make(map[string][]*gedcom.Task)
+	for _, rec := range r.Records {
+		for _, missing := range rec.Missing {
+			tasks[rec.XRef] = append(tasks[rec.XRef], &gedcom.Task{
+				Tag:         gedcom.TaskTagTodo,
+				Description: "Find " + missing,
+				Category:    r.Profile,
+				Status:      "Open",
+			})
+		}
+	}
+	return tasks
+}
+
+// AddTasks attaches the tasks from Tasks to the individual records of doc
+// in the given format and returns how many were added. A task is skipped
+// if the individual already has an open task, or an inline note, with the
+// same text, so running AddTasks repeatedly does not duplicate tasks.
+// Records that are not individuals are skipped.
+func (r *CompletenessReport) AddTasks(doc *gedcom.Document, format gedcom.TaskFormat) (int, error) {
+	if doc == nil {
+		return 0, nil
+	}
+	tasks := r.Tasks()
+	added := 0
+	for _, rec := range r.Records {
+		record := doc.GetRecord(rec.XRef)
+		if record == nil {
+			continue
+		}
+		ind, ok := record.GetIndividual()
+		if !ok || ind == nil {
+			continue
+		}
+		for _, task := range tasks[rec.XRef] {
+			if hasTask(ind, task, format) {
+				continue
+			}
+			if err := record.AddTask(task, format); err != nil {
+				return added, err
+			}
+			added++
+		}
+	}
+	return added, nil
+}
+
+// hasTask reports whether ind already carries task in the given format.
+func hasTask(ind *gedcom.Individual, task *gedcom.Task, format gedcom.TaskFormat) bool {
+	if format == gedcom.TaskFormatNote {
+		text := task.NoteText()
+		for _, note := range ind.InlineNotes {
+			if note == text {
+				return true
+			}
+		}
+		return false
+	}
+	for _, existing := range ind.Tasks {
+		if existing != nil && existing.IsOpen() && existing.Description == task.Description {
+			return true
+		}
+	}
+	return false
+}
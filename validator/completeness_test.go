@@ -0,0 +1,207 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func completenessDocument(t *testing.T) *gedcom.Document {
+	t.Helper()
+	complete := &gedcom.Individual{
+		XRef:            "@I1@",
+		Names:           []*gedcom.PersonalName{{Full: "John /Doe/"}},
+		Sex:             "M",
+		Events:          []*gedcom.Event{{Type: gedcom.EventBirth, Date: "1 JAN 1900", Place: "Boston"}},
+		SourceCitations: []*gedcom.SourceCitation{{SourceXRef: "@S1@"}},
+	}
+	eventSourced := &gedcom.Individual{
+		XRef:  "@I2@",
+		Names: []*gedcom.PersonalName{{Full: "Jane /Doe/"}},
+		Sex:   "U",
+		Events: []*gedcom.Event{{
+			Type:            gedcom.EventBirth,
+			SourceCitations: []*gedcom.SourceCitation{{SourceXRef: "@S1@"}},
+		}},
+		ChildInFamilies: []gedcom.FamilyLink{{FamilyXRef: "@F1@"}},
+	}
+	empty := &gedcom.Individual{XRef: "@I3@"}
+	fam := &gedcom.Family{
+		XRef:     "@F1@",
+		Husband:  "@I1@",
+		Children: []string{"@I2@"},
+		Events:   []*gedcom.Event{{Type: gedcom.EventMarriage, Date: "1920"}},
+	}
+	return makeDocument([]*gedcom.Individual{complete, eventSourced, empty}, []*gedcom.Family{fam})
+}
+
+func TestCompletenessProfile_Evaluate(t *testing.T) {
+	doc := completenessDocument(t)
+	report := DefaultIndividualProfile().Evaluate(doc)
+
+	want := []RecordCompleteness{
+		{XRef: "@I1@", Score: 1},
+		{XRef: "@I2@", Score: 0.5, Missing: []string{"sex", "BIRT date"}},
+		{XRef: "@I3@", Score: 0, Missing: []string{"name", "sex", "BIRT date", "source"}},
+	}
+	if !reflect.DeepEqual(report.Records, want) {
+		t.Errorf("Records = %+v, want %+v", report.Records, want)
+	}
+	if report.CompleteRecords != 1 {
+		t.Errorf("CompleteRecords = %d, want 1", report.CompleteRecords)
+	}
+	if report.AverageScore != 0.5 {
+		t.Errorf("AverageScore = %v, want 0.5", report.AverageScore)
+	}
+
+	wantCoverage := []RequirementCoverage{
+		{Name: "name", Satisfied: 2, Coverage: 2.0 / 3},
+		{Name: "sex", Satisfied: 1, Coverage: 1.0 / 3},
+		{Name: "BIRT date", Satisfied: 1, Coverage: 1.0 / 3},
+		{Name: "source", Satisfied: 2, Coverage: 2.0 / 3},
+	}
+	if !reflect.DeepEqual(report.Requirements, wantCoverage) {
+		t.Errorf("Requirements = %+v, want %+v", report.Requirements, wantCoverage)
+	}
+
+	todo := report.Incomplete()
+	if len(todo) != 2 || todo[0].XRef != "@I3@" || todo[1].XRef != "@I2@" {
+		t.Errorf("Incomplete() = %+v, want @I3@ then @I2@", todo)
+	}
+}
+
+func TestCompletenessProfile_CustomRequirements(t *testing.T) {
+	doc := completenessDocument(t)
+	profile := &CompletenessProfile{
+		Name:       "research",
+		RecordType: gedcom.RecordTypeIndividual,
+		Requirements: []CompletenessRequirement{
+			{Name: "birth place", Weight: 3, Check: RequireEventPlace(gedcom.EventBirth).Check},
+			RequireParents(),
+			RequireNames(2),
+			{Name: "no check"},
+		},
+	}
+
+	got := profile.EvaluateRecord(doc, doc.GetRecord("@I1@"))
+	if got.Score != 0.5 || !reflect.DeepEqual(got.Missing, []string{"parents", "2 names", "no check"}) {
+		t.Errorf("@I1@ = %+v", got)
+	}
+	got = profile.EvaluateRecord(doc, doc.GetRecord("@I2@"))
+	if got.Score != 1.0/6 || !reflect.DeepEqual(got.Missing, []string{"birth place", "2 names", "no check"}) {
+		t.Errorf("@I2@ = %+v", got)
+	}
+
+	if got := (&CompletenessProfile{}).EvaluateRecord(doc, doc.GetRecord("@I1@")); got.Score != 1 || !got.Complete() {
+		t.Errorf("empty profile = %+v, want complete", got)
+	}
+}
+
+func TestCompletenessProfile_Family(t *testing.T) {
+	doc := completenessDocument(t)
+	report := DefaultFamilyProfile().Evaluate(doc)
+	if len(report.Records) != 1 {
+		t.Fatalf("Records = %+v", report.Records)
+	}
+	if got := report.Records[0].Missing; !reflect.DeepEqual(got, []string{"spouses", "source"}) {
+		t.Errorf("Missing = %v", got)
+	}
+}
+
+func TestCompletenessProfile_RequireTag(t *testing.T) {
+	doc := &gedcom.Document{Records: []*gedcom.Record{
+		{XRef: "@S1@", Type: gedcom.RecordTypeSource, Tags: []*gedcom.Tag{
+			{Level: 1, Tag: "TITL", Value: "Census"},
+			{Level: 1, Tag: "REPO"},
+			{Level: 2, Tag: "CALN", Value: "123"},
+		}},
+		{XRef: "@S2@", Type: gedcom.RecordTypeSource, Tags: []*gedcom.Tag{
+			{Level: 1, Tag: "TITL"},
+			{Level: 1, Tag: "REPO"},
+		}},
+	}}
+	profile := &CompletenessProfile{
+		Name:         "source",
+		RecordType:   gedcom.RecordTypeSource,
+		Requirements: []CompletenessRequirement{RequireTag("TITL"), RequireTag("REPO")},
+	}
+	report := profile.Evaluate(doc)
+	if report.CompleteRecords != 1 || report.Records[1].Score != 0 {
+		t.Errorf("report = %+v", report)
+	}
+}
+
+func TestCompletenessReport_Issues(t *testing.T) {
+	doc := completenessDocument(t)
+	issues := DefaultIndividualProfile().Evaluate(doc).Issues()
+	if len(issues) != 2 {
+		t.Fatalf("Issues() = %d, want 2", len(issues))
+	}
+	issue := issues[0]
+	if issue.Code != CodeIncompleteRecord || issue.Severity != SeverityInfo || issue.RecordXRef != "@I2@" {
+		t.Errorf("issue = %+v", issue)
+	}
+	if issue.Details["missing"] != "sex,BIRT date" || issue.Details["score"] != "0.50" || issue.Details["profile"] != "individual" {
+		t.Errorf("Details = %v", issue.Details)
+	}
+	if !strings.Contains(issue.Message, "sex, BIRT date") {
+		t.Errorf("Message = %q", issue.Message)
+	}
+}
+
+func TestValidator_CheckCompleteness(t *testing.T) {
+	doc := completenessDocument(t)
+	v := New()
+
+	reports := v.CheckCompleteness(doc)
+	if len(reports) != 2 || reports[0].Profile != "individual" || reports[1].Profile != "family" {
+		t.Fatalf("default reports = %+v", reports)
+	}
+
+	reports = v.CheckCompleteness(nil, DefaultIndividualProfile(), nil)
+	if len(reports) != 1 || len(reports[0].Records) != 0 || reports[0].AverageScore != 0 {
+		t.Errorf("nil document reports = %+v", reports)
+	}
+}
+
+func TestCompletenessReport_Tasks(t *testing.T) {
+	doc := completenessDocument(t)
+	report := DefaultIndividualProfile().Evaluate(doc)
+
+	tasks := report.Tasks()
+	if len(tasks) != 2 || len(tasks["@I2@"]) != 2 || len(tasks["@I3@"]) != 4 {
+		t.Fatalf("Tasks() = %+v", tasks)
+	}
+	task := tasks["@I2@"][0]
+	if task.Description != "Find sex" || task.Category != "individual" || task.Tag != gedcom.TaskTagTodo || !task.IsOpen() {
+		t.Errorf("task = %+v", task)
+	}
+
+	added, err := report.AddTasks(doc, gedcom.TaskFormatTag)
+	if err != nil || added != 6 {
+		t.Fatalf("AddTasks() = %d, %v; want 6", added, err)
+	}
+	if got := doc.GetIndividual("@I3@").Tasks; len(got) != 4 {
+		t.Errorf("@I3@ tasks = %d, want 4", len(got))
+	}
+	if added, _ := report.AddTasks(doc, gedcom.TaskFormatTag); added != 0 {
+		t.Errorf("second AddTasks() added %d, want 0", added)
+	}
+
+	added, err = report.AddTasks(doc, gedcom.TaskFormatNote)
+	if err != nil || added != 6 {
+		t.Fatalf("AddTasks(note) = %d, %v; want 6", added, err)
+	}
+	if notes := doc.GetIndividual("@I2@").InlineNotes; len(notes) != 2 || notes[0] != "To do: Find sex (individual) [Open]" {
+		t.Errorf("@I2@ notes = %q", notes)
+	}
+	if added, _ := report.AddTasks(doc, gedcom.TaskFormatNote); added != 0 {
+		t.Errorf("second AddTasks(note) added %d, want 0", added)
+	}
+
+	if added, err := report.AddTasks(nil, gedcom.TaskFormatTag); added != 0 || err != nil {
+		t.Errorf("AddTasks(nil) = %d, %v", added, err)
+	}
+}
@@ -0,0 +1,126 @@
+// context.go provides cancellable, optionally parallel, validation runs.
+//
+// ValidateAll runs a dozen checks on one goroutine, the record-level ones
+// in a single shared pass (see engine.go). ValidateAllContext runs the same
+// checks separately, so that it can stop when its context is cancelled,
+// run several checks at once, and report how long each check took.
+
+package validator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// ValidationRun is the result of ValidateAllContext.
+type ValidationRun struct {
+	// Issues are the issues found, filtered as by ValidateAll, in the order
+	// ValidateAll returns them.
+	Issues []Issue
+
+	// Timings has one entry per completed check, in check order.
+	Timings []CheckTiming
+
+	// Duration is the wall-clock time of the run.
+	Duration time.Duration
+}
+
+// CheckTiming records how long one check of a validation run took.
+type CheckTiming struct {
+	// Check names the check, e.g. "date_logic" or "duplicates".
+	Check string
+
+	// Duration is the time the check took.
+	Duration time.Duration
+
+	// Issues is the number of issues the check found, before filtering.
+	Issues int
+}
+
+// ValidateAllContext runs the checks of ValidateAll, stopping when ctx is
+// cancelled. ValidatorConfig.Workers sets how many checks run at once.
+//
+// Cancellation is checked before each check starts and while waiting for
+// running checks, so it returns promptly; a check already running when ctx
+// is cancelled finishes in the background and its issues are discarded. On
+// cancellation the run holds the issues and timings of the checks completed
+// so far, and the error is ctx.Err().
+func (v *Validator) ValidateAllContext(ctx context.Context, doc *gedcom.Document) (*ValidationRun, error) {
+	start := time.Now()
+	run := &ValidationRun{}
+	if doc == nil {
+		return run, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	checks := v.checks()
+	workers := 1
+	if v.config != nil && v.config.Workers > 1 {
+		workers = v.config.Workers
+	}
+
+	type result struct {
+		issues  []Issue
+		elapsed time.Duration
+	}
+	var (
+		mu      sync.Mutex // guards results
+		results = make([]*result, len(checks))
+		wg      sync.WaitGroup
+		slots   = make(chan struct{}, workers)
+		err     error
+	)
+	for i, check := range checks {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int, check validationCheck) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			begin := time.Now()
+			issues := check.runCategorized(doc)
+			r := &result{issues: issues, elapsed: time.Since(begin)}
+			mu.Lock()
+			results[i] = r
+			mu.Unlock()
+		}(i, check)
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	if err == nil {
+		select {
+		case <-finished:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+
+	mu.Lock()
+	var all []Issue
+	for i, r := range results {
+		if r == nil {
+			continue
+		}
+		all = append(all, r.issues...)
+		run.Timings = append(run.Timings, CheckTiming{Check: checks[i].name, Duration: r.elapsed, Issues: len(r.issues)})
+	}
+	mu.Unlock()
+
+	run.Issues = v.filterByStrictness(all)
+	run.Duration = time.Since(start)
+	return run, err
+}
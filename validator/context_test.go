@@ -0,0 +1,92 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func contextTestDocument(t *testing.T) *gedcom.Document {
+	t.Helper()
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+1 LANG English
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE 1 JAN 1900
+1 DEAT
+2 DATE 1 JAN 1850
+1 FAMC @F9@
+0 @I2@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE 1 JAN 1900
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestValidator_ValidateAllContext(t *testing.T) {
+	doc := contextTestDocument(t)
+	want := New().ValidateAll(doc)
+	if len(want) == 0 {
+		t.Fatal("fixture should produce issues")
+	}
+
+	for _, workers := range []int{0, 1, 4} {
+		v := NewWithOptions(&ValidateOptions{Workers: workers})
+		run, err := v.ValidateAllContext(context.Background(), doc)
+		if err != nil {
+			t.Fatalf("workers=%d: error = %v", workers, err)
+		}
+		if !reflect.DeepEqual(run.Issues, want) {
+			t.Errorf("workers=%d: issues differ from ValidateAll:\n got %v\nwant %v", workers, run.Issues, want)
+		}
+
+		var names []string
+		total := 0
+		for _, timing := range run.Timings {
+			names = append(names, timing.Check)
+			total += timing.Issues
+			if timing.Duration < 0 {
+				t.Errorf("%s duration = %v", timing.Check, timing.Duration)
+			}
+		}
+		if len(names) != len(v.checks()) || names[0] != "header" || names[len(names)-1] != "encoding" {
+			t.Errorf("workers=%d: timings = %v", workers, names)
+		}
+		if total < len(run.Issues) {
+			t.Errorf("workers=%d: timings count %d issues, run has %d", workers, total, len(run.Issues))
+		}
+		if run.Duration <= 0 {
+			t.Errorf("workers=%d: Duration = %v", workers, run.Duration)
+		}
+	}
+}
+
+func TestValidator_ValidateAllContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	run, err := New().ValidateAllContext(ctx, contextTestDocument(t))
+	if err != context.Canceled {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+	if run == nil || len(run.Timings) != 0 || len(run.Issues) != 0 {
+		t.Errorf("run = %+v, want no completed checks", run)
+	}
+
+	run, err = New().ValidateAllContext(context.Background(), nil)
+	if err != nil || run == nil || len(run.Issues) != 0 {
+		t.Errorf("nil document: run = %+v, err = %v", run, err)
+	}
+}
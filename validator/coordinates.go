@@ -0,0 +1,271 @@
+// coordinates.go provides place coordinate (MAP LATI/LONG) validation.
+//
+// GEDCOM writes a latitude as N or S followed by unsigned decimal degrees
+// ("N42.3601") and a longitude as E or W followed by the same
+// ("W71.0589"). Geocoders and hand entry often produce signed values,
+// swapped axes, or out-of-range numbers, and a failed lookup frequently
+// leaves (0, 0), a point in the Gulf of Guinea. Such values end up as
+// markers in the ocean of every map export.
+
+package validator
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// CoordinateConfig configures coordinate validation.
+type CoordinateConfig struct {
+	// Landmask enables the check that land events are located on land.
+	// Use CoarseLandmask or supply a finer mask. If nil, the check is off.
+	Landmask Landmask
+
+	// LandEvents are the event types expected to take place on land.
+	// If empty, DefaultLandEvents is used.
+	LandEvents []gedcom.EventType
+}
+
+// Landmask tells land from sea.
+type Landmask interface {
+	// IsLand reports whether the point at lat, long (signed decimal
+	// degrees) is on land.
+	IsLand(lat, long float64) bool
+}
+
+// DefaultLandEvents are the event types checked against the Landmask when
+// CoordinateConfig.LandEvents is empty. Births, deaths, and burials are
+// left out because they do happen at sea.
+var DefaultLandEvents = []gedcom.EventType{
+	gedcom.EventChristening,
+	gedcom.EventBaptism,
+	gedcom.EventMarriage,
+	gedcom.EventResidence,
+	gedcom.EventCensus,
+	gedcom.EventGraduation,
+	gedcom.EventProbate,
+	gedcom.EventCremation,
+}
+
+// CoarseLandmask treats every point as land except those in a few open
+// ocean areas far from any coast or island. It catches coordinates that
+// are grossly wrong, such as a sign flipped on the wrong axis, without
+// flagging coastal places.
+var CoarseLandmask Landmask = openOceanMask{}
+
+// openOceanMask is the Landmask behind CoarseLandmask.
+type openOceanMask struct{}
+
+// openOceans are latitude and longitude boxes (south, north, west, east)
+// containing no land.
+var openOceans = [][4]float64{
+	{10, 30, -55, -30},    // central North Atlantic
+	{41, 52, -45, -20},    // North Atlantic between the Azores and Iceland
+	{-30, -20, -25, -10},  // South Atlantic
+	{30, 48, -170, -135},  // northeast Pacific
+	{30, 45, 150, 175},    // northwest Pacific
+	{-20, 5, -130, -95},   // eastern equatorial Pacific
+	{-55, -35, -160, -90}, // South Pacific
+	{-35, -15, 70, 90},    // central Indian Ocean
+}
+
+// IsLand reports whether lat, long lies outside every open ocean box.
+func (openOceanMask) IsLand(lat, long float64) bool {
+	for _, box := range openOceans {
+		if lat >= box[0] && lat <= box[1] && long >= box[2] && long <= box[3] {
+			return false
+		}
+	}
+	return true
+}
+
+// CoordinateValidator checks the MAP coordinates of event places.
+type CoordinateValidator struct {
+	config *CoordinateConfig
+}
+
+// NewCoordinateValidator creates a new CoordinateValidator. If config is
+// nil, the landmask check is off.
+func NewCoordinateValidator(config *CoordinateConfig) *CoordinateValidator {
+	if config == nil {
+		config = &CoordinateConfig{}
+	}
+	return &CoordinateValidator{config: config}
+}
+
+// canonicalCoordinate is the payload syntax of LATI and LONG.
+var canonicalCoordinate = regexp.MustCompile(`^[NSEW][0-9]+(\.[0-9]+)?$`)
+
+// Validate checks the coordinates of individual and family events:
+//
+//   - CodeInvalidCoordinate (error): a missing latitude or longitude, a
+//     value that is not a number, or a direction on the wrong axis. Signed
+//     values without a direction get a "suggested" detail ("-71.5" →
+//     "W71.5").
+//   - CodeCoordinateOutOfRange (error): a latitude outside -90..90 or a
+//     longitude outside -180..180.
+//   - CodeNonstandardCoordinate: a readable value not in the form N12.34,
+//     such as "n12.34" or "N 12.34". An error in GEDCOM 7.0, which defines
+//     the payload syntax strictly, and a warning in earlier versions.
+//   - CodeZeroCoordinates (warning): the point (0, 0), usually a failed
+//     geocode.
+//   - CodeCoordinateInOcean (warning): a land event the Landmask places at
+//     sea. Only checked when a Landmask is configured.
+func (c *CoordinateValidator) Validate(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	return c.visitor(doc).run(doc)
+}
+
+// visitor returns the per-record form of Validate: individuals, then
+// families.
+func (c *CoordinateValidator) visitor(doc *gedcom.Document) *recordVisitor {
+	cc := &coordinateChecker{
+		strict:     doc.Header != nil && doc.Header.Version == gedcom.Version70,
+		landEvents: c.config.LandEvents,
+		landmask:   c.config.Landmask,
+	}
+	if len(cc.landEvents) == 0 {
+		cc.landEvents = DefaultLandEvents
+	}
+
+	return newRecordVisitor(2, func(rv *recordVisitor, record *gedcom.Record) {
+		if ind, ok := recordIndividual(record); ok {
+			rv.add(0, cc.eventsIssues(ind.XRef, ind.Events)...)
+		}
+		if fam, ok := recordFamily(record); ok {
+			rv.add(1, cc.eventsIssues(fam.XRef, fam.Events)...)
+		}
+	})
+}
+
+// coordinateChecker checks the coordinates of event places.
+type coordinateChecker struct {
+	strict     bool
+	landEvents []gedcom.EventType
+	landmask   Landmask
+}
+
+// eventsIssues returns the coordinate issues of the events of the record
+// xref.
+func (cc *coordinateChecker) eventsIssues(xref string, events []*gedcom.Event) []Issue {
+	var issues []Issue
+	for _, event := range events {
+		if event == nil || event.PlaceDetail == nil || event.PlaceDetail.Coordinates.IsEmpty() {
+			continue
+		}
+		issues = append(issues, cc.eventIssues(xref, event)...)
+	}
+	return issues
+}
+
+// eventIssues returns the issues of the coordinates of event, which has
+// some.
+func (cc *coordinateChecker) eventIssues(xref string, event *gedcom.Event) []Issue {
+	coords := event.PlaceDetail.Coordinates
+	tag := string(event.Type)
+	newIssue := func(severity Severity, code, message string) Issue {
+		return NewIssue(severity, code, fmt.Sprintf("%s place %q: %s", tag, event.Place, message), xref).
+			WithDetail("tag", tag).
+			WithDetail("place", event.Place).
+			WithDetail("latitude", coords.Latitude).
+			WithDetail("longitude", coords.Longitude)
+	}
+
+	var issues []Issue
+	lat, latIssue := checkCoordinate(coords.Latitude, "latitude", "NS", 90, cc.strict)
+	long, longIssue := checkCoordinate(coords.Longitude, "longitude", "EW", 180, cc.strict)
+	valid := true
+	for _, ci := range []*coordinateIssue{latIssue, longIssue} {
+		if ci == nil {
+			continue
+		}
+		issue := newIssue(ci.severity, ci.code, ci.message)
+		if ci.suggested != "" {
+			issue = issue.WithDetail("suggested", ci.suggested)
+		}
+		issues = append(issues, issue)
+		valid = valid && ci.code == CodeNonstandardCoordinate
+	}
+	if !valid {
+		return issues
+	}
+
+	switch {
+	case lat == 0 && long == 0:
+		issues = append(issues, newIssue(SeverityWarning, CodeZeroCoordinates,
+			"coordinates are (0, 0), likely a failed geocode"))
+	case cc.landmask != nil && slices.Contains(cc.landEvents, event.Type) && !cc.landmask.IsLand(lat, long):
+		issues = append(issues, newIssue(SeverityWarning, CodeCoordinateInOcean,
+			fmt.Sprintf("coordinates (%g, %g) are at sea", lat, long)))
+	}
+	return issues
+}
+
+// coordinateIssue is a problem with one coordinate component.
+type coordinateIssue struct {
+	severity  Severity
+	code      string
+	message   string
+	suggested string
+}
+
+// checkCoordinate parses one component of a coordinate pair. axis is the
+// pair of direction letters allowed, limit the largest absolute value. It
+// returns the signed value and the problem found, if any; the value is
+// meaningful only if there is no problem or a CodeNonstandardCoordinate
+// one.
+func checkCoordinate(value, name, axis string, limit float64, strict bool) (float64, *coordinateIssue) {
+	invalid := func(format string, args ...any) *coordinateIssue {
+		return &coordinateIssue{severity: SeverityError, code: CodeInvalidCoordinate, message: fmt.Sprintf(format, args...)}
+	}
+
+	s := strings.TrimSpace(value)
+	if s == "" {
+		return 0, invalid("%s is missing", name)
+	}
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		issue := invalid("%s %q has no %c/%c direction", name, value, axis[0], axis[1])
+		if direction := axis[0:1]; math.Abs(n) <= limit {
+			if n < 0 {
+				direction = axis[1:2]
+			}
+			issue.suggested = direction + strconv.FormatFloat(math.Abs(n), 'f', -1, 64)
+		}
+		return 0, issue
+	}
+	if !strings.Contains(axis, strings.ToUpper(s[:1])) {
+		return 0, invalid("%s %q must use %c or %c", name, value, axis[0], axis[1])
+	}
+	n, err := gedcom.ParseCoordinate(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		return 0, invalid("%s %q is not a number of degrees", name, value)
+	}
+	if math.Abs(n) > limit {
+		return 0, &coordinateIssue{
+			severity: SeverityError,
+			code:     CodeCoordinateOutOfRange,
+			message:  fmt.Sprintf("%s %q is outside -%g..%g", name, value, limit, limit),
+		}
+	}
+	if !canonicalCoordinate.MatchString(value) {
+		severity := SeverityWarning
+		if strict {
+			severity = SeverityError
+		}
+		suggested := strings.ToUpper(s[:1]) + strings.Join(strings.Fields(s[1:]), "")
+		return n, &coordinateIssue{
+			severity:  severity,
+			code:      CodeNonstandardCoordinate,
+			message:   fmt.Sprintf("%s %q is not in the form %c12.34", name, value, axis[0]),
+			suggested: suggested,
+		}
+	}
+	return n, nil
+}
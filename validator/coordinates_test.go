@@ -0,0 +1,131 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func decodeCoordinates(t *testing.T, version, lati, long string) *gedcom.Document {
+	t.Helper()
+	input := "0 HEAD\n1 GEDC\n2 VERS " + version + "\n0 @I1@ INDI\n1 NAME John /Doe/\n1 RESI\n2 PLAC Boston\n3 MAP\n"
+	if lati != "" {
+		input += "4 LATI " + lati + "\n"
+	}
+	if long != "" {
+		input += "4 LONG " + long + "\n"
+	}
+	doc, err := decoder.Decode(strings.NewReader(input + "0 TRLR\n"))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestCoordinateValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		lati      string
+		long      string
+		codes     []string
+		severity  Severity
+		suggested string
+	}{
+		{name: "valid", version: "5.5.1", lati: "N42.3601", long: "W71.0589"},
+		{name: "integer degrees", version: "7.0", lati: "S33", long: "E151"},
+		{name: "missing longitude", version: "5.5.1", lati: "N42.3601", codes: []string{CodeInvalidCoordinate}, severity: SeverityError},
+		{name: "signed value", version: "5.5.1", lati: "N42.3601", long: "-71.0589", codes: []string{CodeInvalidCoordinate}, severity: SeverityError, suggested: "W71.0589"},
+		{name: "wrong axis", version: "5.5.1", lati: "E42.3601", long: "W71.0589", codes: []string{CodeInvalidCoordinate}, severity: SeverityError},
+		{name: "not a number", version: "5.5.1", lati: "N42°21'", long: "W71.0589", codes: []string{CodeInvalidCoordinate}, severity: SeverityError},
+		{name: "latitude out of range", version: "5.5.1", lati: "N142.36", long: "W71.0589", codes: []string{CodeCoordinateOutOfRange}, severity: SeverityError},
+		{name: "longitude out of range", version: "5.5.1", lati: "N42.36", long: "W271.0589", codes: []string{CodeCoordinateOutOfRange}, severity: SeverityError},
+		{name: "lower case 5.5.1", version: "5.5.1", lati: "n42.3601", long: "W71.0589", codes: []string{CodeNonstandardCoordinate}, severity: SeverityWarning, suggested: "N42.3601"},
+		{name: "space 7.0", version: "7.0", lati: "N 42.3601", long: "W71.0589", codes: []string{CodeNonstandardCoordinate}, severity: SeverityError, suggested: "N42.3601"},
+		{name: "zero", version: "5.5.1", lati: "N0", long: "E0", codes: []string{CodeZeroCoordinates}, severity: SeverityWarning},
+		{name: "both invalid", version: "5.5.1", lati: "42", long: "71", codes: []string{CodeInvalidCoordinate, CodeInvalidCoordinate}, severity: SeverityError, suggested: "N42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := NewCoordinateValidator(nil).Validate(decodeCoordinates(t, tt.version, tt.lati, tt.long))
+			if len(issues) != len(tt.codes) {
+				t.Fatalf("Validate() returned %d issues, want %d: %v", len(issues), len(tt.codes), issues)
+			}
+			for i, issue := range issues {
+				if issue.Code != tt.codes[i] || issue.Severity != tt.severity || issue.RecordXRef != "@I1@" {
+					t.Errorf("issue %d = %+v", i, issue)
+				}
+				if issue.Details["tag"] != "RESI" || issue.Details["place"] != "Boston" {
+					t.Errorf("issue %d details = %v", i, issue.Details)
+				}
+			}
+			if len(issues) > 0 && issues[0].Details["suggested"] != tt.suggested {
+				t.Errorf("suggested = %q, want %q", issues[0].Details["suggested"], tt.suggested)
+			}
+		})
+	}
+}
+
+func TestCoordinateValidator_Landmask(t *testing.T) {
+	// A flipped sign that stays on land is not flagged.
+	doc := decodeCoordinates(t, "5.5.1", "N42.3601", "E71.0589")
+	if issues := NewCoordinateValidator(&CoordinateConfig{Landmask: CoarseLandmask}).Validate(doc); len(issues) != 0 {
+		t.Errorf("E71 is in Kazakhstan, got %v", issues)
+	}
+
+	doc = decodeCoordinates(t, "5.5.1", "N45", "W30")
+	if issues := NewCoordinateValidator(nil).Validate(doc); len(issues) != 0 {
+		t.Errorf("without a landmask, got %v", issues)
+	}
+	issues := NewCoordinateValidator(&CoordinateConfig{Landmask: CoarseLandmask}).Validate(doc)
+	if len(issues) != 1 || issues[0].Code != CodeCoordinateInOcean || issues[0].Severity != SeverityWarning {
+		t.Errorf("Validate() = %v, want one COORDINATE_IN_OCEAN", issues)
+	}
+
+	// Only the configured land events are checked.
+	config := &CoordinateConfig{Landmask: CoarseLandmask, LandEvents: []gedcom.EventType{gedcom.EventBirth}}
+	if issues := NewCoordinateValidator(config).Validate(doc); len(issues) != 0 {
+		t.Errorf("RESI is not a land event here, got %v", issues)
+	}
+}
+
+func TestCoarseLandmask(t *testing.T) {
+	land := [][2]float64{
+		{42.36, -71.06},  // Boston
+		{51.51, -0.13},   // London
+		{32.3, -64.78},   // Bermuda
+		{37.74, -25.67},  // Azores
+		{21.31, -157.86}, // Honolulu
+		{-15.92, -5.72},  // St Helena
+		{-33.87, 151.21}, // Sydney
+	}
+	for _, p := range land {
+		if !CoarseLandmask.IsLand(p[0], p[1]) {
+			t.Errorf("IsLand(%v, %v) = false, want true", p[0], p[1])
+		}
+	}
+	for _, p := range [][2]float64{{20, -40}, {40, -150}, {-25, 80}} {
+		if CoarseLandmask.IsLand(p[0], p[1]) {
+			t.Errorf("IsLand(%v, %v) = true, want false", p[0], p[1])
+		}
+	}
+}
+
+func TestValidator_ValidateCoordinates(t *testing.T) {
+	doc := decodeCoordinates(t, "5.5.1", "N0", "E0")
+	v := NewWithOptions(&ValidateOptions{Strictness: StrictnessRelaxed})
+	if issues := v.ValidateCoordinates(doc); len(issues) != 0 {
+		t.Errorf("relaxed strictness should drop warnings, got %v", issues)
+	}
+	if issues := New().ValidateCoordinates(doc); len(FilterByCode(issues, CodeZeroCoordinates)) != 1 {
+		t.Errorf("ValidateCoordinates() = %v", issues)
+	}
+	if issues := New().ValidateAll(doc); len(FilterByCode(issues, CodeZeroCoordinates)) != 1 {
+		t.Errorf("ValidateAll() should include coordinate issues, got %v", issues)
+	}
+	if New().ValidateCoordinates(nil) != nil {
+		t.Error("ValidateCoordinates(nil) should return nil")
+	}
+}
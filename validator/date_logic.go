@@ -7,11 +7,17 @@
 //   - Marriage before birth
 //   - Impossible ages (e.g., >120 years)
 //   - Unreasonable parent ages at child's birth
+//   - Events dated after the "as of" date
+//   - Change (CHAN) timestamps before creation (CREA) timestamps, and
+//     creation timestamps in the future
 
 package validator
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cacack/gedcom-go/v2/gedcom"
 )
@@ -33,6 +39,10 @@ type DateLogicConfig struct {
 	// MaxFatherAge is the maximum reasonable age for a father at child's birth.
 	// Fathers older than this generate a warning. Default: 90.
 	MaxFatherAge int
+
+	// AsOf is the date against which event and creation dates are checked
+	// for being in the future. Default (zero value): the time of validation.
+	AsOf time.Time
 }
 
 // DefaultDateLogicConfig returns a DateLogicConfig with reasonable defaults.
@@ -78,11 +88,21 @@ func (v *DateLogicValidator) Validate(doc *gedcom.Document) []Issue {
 		return nil
 	}
 
-	var issues []Issue
-	for _, ind := range doc.Individuals() {
-		issues = append(issues, v.ValidateIndividual(doc, ind)...)
-	}
-	return issues
+	return v.visitor(doc).run(doc)
+}
+
+// visitor returns the per-record form of Validate: individuals, then
+// family events, then the CREA and CHAN dates of every record.
+func (v *DateLogicValidator) visitor(doc *gedcom.Document) *recordVisitor {
+	return newRecordVisitor(3, func(rv *recordVisitor, record *gedcom.Record) {
+		if ind, ok := recordIndividual(record); ok {
+			rv.add(0, v.ValidateIndividual(doc, ind)...)
+		}
+		if fam, ok := recordFamily(record); ok {
+			rv.add(1, v.checkFutureEvents(fam.XRef, fam.Events, nil)...)
+		}
+		rv.add(2, v.checkTimestamps(record)...)
+	})
 }
 
 // ValidateIndividual runs all date logic validations on a single individual.
@@ -112,6 +132,9 @@ func (v *DateLogicValidator) ValidateIndividual(doc *gedcom.Document, ind *gedco
 	// Check reasonable parent age
 	issues = append(issues, v.checkReasonableParentAge(doc, ind)...)
 
+	// Check events dated in the future
+	issues = append(issues, v.checkFutureEvents(ind.XRef, ind.Events, ind.Attributes)...)
+
 	return issues
 }
 
@@ -208,11 +231,7 @@ func (v *DateLogicValidator) checkMarriageBeforeBirth(doc *gedcom.Document, ind
 		}
 
 		// Look for marriage event
-		for _, event := range fam.Events {
-			if event.Type != gedcom.EventMarriage {
-				continue
-			}
-
+		for _, event := range fam.EventsOfType(gedcom.EventMarriage) {
 			marriageDate := event.ParsedDate
 			if marriageDate == nil || marriageDate.Year == 0 {
 				continue
@@ -347,3 +366,208 @@ func (v *DateLogicValidator) checkReasonableParentAge(doc *gedcom.Document, ind
 
 	return issues
 }
+
+// asOfDate returns the configured AsOf date, or today, as a Gregorian Date.
+func (v *DateLogicValidator) asOfDate() *gedcom.Date {
+	asOf := v.config.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	return &gedcom.Date{
+		Original: asOf.Format("2 Jan 2006"),
+		Year:     asOf.Year(),
+		Month:    int(asOf.Month()),
+		Day:      asOf.Day(),
+		Calendar: gedcom.CalendarGregorian,
+	}
+}
+
+// isFutureDate reports whether the earliest point date can refer to is after
+// asOf. Dates bounded only from above (BEF, TO) and dates without a year are
+// never in the future.
+func isFutureDate(date, asOf *gedcom.Date) bool {
+	if date == nil || date.Year == 0 {
+		return false
+	}
+	if date.Modifier == gedcom.ModifierBefore || date.Modifier == gedcom.ModifierTo {
+		return false
+	}
+	return date.Compare(asOf) > 0
+}
+
+// checkFutureEvents checks the events and attributes of a record for dates
+// after the AsOf date. Returns an Issue with Warning severity for each one.
+func (v *DateLogicValidator) checkFutureEvents(xref string, events []*gedcom.Event, attrs []*gedcom.Attribute) []Issue {
+	asOf := v.asOfDate()
+	var issues []Issue
+
+	report := func(tag string, date *gedcom.Date) {
+		issue := NewIssue(
+			SeverityWarning,
+			CodeFutureDate,
+			fmt.Sprintf("%s date (%s) is in the future (as of %s)", tag, date.Original, asOf.Original),
+			xref,
+		).
+			WithDetail("tag", tag).
+			WithDetail("date", date.Original).
+			WithDetail("as_of", asOf.Original)
+		issues = append(issues, issue)
+	}
+
+	for _, event := range events {
+		if event != nil && isFutureDate(event.ParsedDate, asOf) {
+			report(string(event.Type), event.ParsedDate)
+		}
+	}
+	for _, attr := range attrs {
+		if attr != nil && isFutureDate(attr.ParsedDate, asOf) {
+			report(attr.Type, attr.ParsedDate)
+		}
+	}
+
+	return issues
+}
+
+// checkTimestamps checks a record's CHAN and CREA structures. Returns an Issue
+// with Error severity if the creation date is in the future, and one if the
+// change timestamp precedes the creation timestamp.
+func (v *DateLogicValidator) checkTimestamps(record *gedcom.Record) []Issue {
+	changed, created := recordTimestamps(record)
+	if created == nil {
+		return nil
+	}
+	createdDate := parseTimestampDate(created)
+	if createdDate == nil {
+		return nil
+	}
+
+	var issues []Issue
+
+	if asOf := v.asOfDate(); createdDate.Compare(asOf) > 0 {
+		issue := NewIssue(
+			SeverityError,
+			CodeFutureCreation,
+			fmt.Sprintf("creation date (%s) is in the future (as of %s)", created.Date, asOf.Original),
+			record.XRef,
+		).
+			WithDetail("creation_date", created.Date).
+			WithDetail("as_of", asOf.Original)
+		issues = append(issues, issue)
+	}
+
+	if changed != nil {
+		if changedDate := parseTimestampDate(changed); changedDate != nil && timestampBefore(changed, changedDate, created, createdDate) {
+			issue := NewIssue(
+				SeverityError,
+				CodeChangeBeforeCreation,
+				fmt.Sprintf("change date (%s) is before creation date (%s)", formatTimestamp(changed), formatTimestamp(created)),
+				record.XRef,
+			).
+				WithDetail("change_date", formatTimestamp(changed)).
+				WithDetail("creation_date", formatTimestamp(created))
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+// recordTimestamps returns the CHAN and CREA structures of a record, from its
+// entity when the entity models them and from its raw tags otherwise.
+func recordTimestamps(record *gedcom.Record) (changed, created *gedcom.ChangeDate) {
+	if record == nil {
+		return nil, nil
+	}
+	switch e := record.Entity.(type) {
+	case *gedcom.Individual:
+		return e.ChangeDate, e.CreationDate
+	case *gedcom.Family:
+		return e.ChangeDate, e.CreationDate
+	case *gedcom.Source:
+		return e.ChangeDate, e.CreationDate
+	case *gedcom.MediaObject:
+		return e.ChangeDate, e.CreationDate
+	}
+	return tagTimestamps(record.Tags)
+}
+
+// tagTimestamps returns the CHAN and CREA structures found in the raw tags
+// of a record.
+func tagTimestamps(tags []*gedcom.Tag) (changed, created *gedcom.ChangeDate) {
+	var current *gedcom.ChangeDate
+	for _, tag := range tags {
+		switch {
+		case tag.Level == 1 && tag.Tag == "CHAN":
+			changed = &gedcom.ChangeDate{}
+			current = changed
+		case tag.Level == 1 && tag.Tag == "CREA":
+			created = &gedcom.ChangeDate{}
+			current = created
+		case tag.Level == 1:
+			current = nil
+		case current != nil && tag.Level == 2 && tag.Tag == "DATE":
+			current.Date = tag.Value
+		case current != nil && tag.Level == 3 && tag.Tag == "TIME":
+			current.Time = tag.Value
+		}
+	}
+	return changed, created
+}
+
+// parseTimestampDate parses the date of a CHAN or CREA structure. Returns nil
+// unless it is an exact date with a year.
+func parseTimestampDate(cd *gedcom.ChangeDate) *gedcom.Date {
+	date, err := gedcom.ParseDate(cd.Date)
+	if err != nil || date.Year == 0 || date.Modifier != gedcom.ModifierNone {
+		return nil
+	}
+	return date
+}
+
+// timestampBefore reports whether timestamp a precedes timestamp b. Times are
+// compared only when both dates are equal and complete and both times parse.
+func timestampBefore(a *gedcom.ChangeDate, aDate *gedcom.Date, b *gedcom.ChangeDate, bDate *gedcom.Date) bool {
+	if cmp := aDate.Compare(bDate); cmp != 0 {
+		return cmp < 0
+	}
+	if aDate.Day == 0 || bDate.Day == 0 {
+		return false
+	}
+	aTime, aOK := parseClockTime(a.Time)
+	bTime, bOK := parseClockTime(b.Time)
+	return aOK && bOK && aTime < bTime
+}
+
+// parseClockTime parses a GEDCOM time (hh:mm[:ss[.fs]], optionally with a
+// trailing Z) into seconds since midnight.
+func parseClockTime(s string) (float64, bool) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "Z")
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, false
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, false
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || minutes < 0 || minutes > 59 {
+		return 0, false
+	}
+	var seconds float64
+	if len(parts) == 3 {
+		seconds, err = strconv.ParseFloat(parts[2], 64)
+		if err != nil || seconds < 0 || seconds >= 60 {
+			return 0, false
+		}
+	}
+	return float64(hours*3600+minutes*60) + seconds, true
+}
+
+// formatTimestamp renders a CHAN or CREA structure for messages.
+func formatTimestamp(cd *gedcom.ChangeDate) string {
+	if cd.Time == "" {
+		return cd.Date
+	}
+	return cd.Date + " " + cd.Time
+}
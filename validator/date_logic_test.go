@@ -1,6 +1,9 @@
 package validator
 
 import (
+	"strings"
+	"time"
+
 	"testing"
 
 	"github.com/cacack/gedcom-go/v2/gedcom"
@@ -908,3 +911,172 @@ func TestErrorCodeUnreasonableParentAge(t *testing.T) {
 		t.Errorf("CodeUnreasonableParentAge = %q, want %q", CodeUnreasonableParentAge, "UNREASONABLE_PARENT_AGE")
 	}
 }
+
+func issueCodes(issues []Issue) []string {
+	codes := make([]string, len(issues))
+	for i, issue := range issues {
+		codes[i] = issue.Code
+	}
+	return codes
+}
+
+func mustParseDate(t *testing.T, s string) *gedcom.Date {
+	t.Helper()
+	d, err := gedcom.ParseDate(s)
+	if err != nil {
+		t.Fatalf("ParseDate(%q) error = %v", s, err)
+	}
+	return d
+}
+
+func TestDateLogicValidator_FutureDates(t *testing.T) {
+	asOf := time.Date(2020, time.June, 15, 0, 0, 0, 0, time.UTC)
+	v := NewDateLogicValidator(&DateLogicConfig{AsOf: asOf})
+
+	tests := []struct {
+		date       string
+		wantFuture bool
+	}{
+		{"15 JUN 2020", false},
+		{"16 JUN 2020", true},
+		{"2020", false},
+		{"JUL 2020", true},
+		{"2021", true},
+		{"ABT 2030", true},
+		{"AFT 2025", true},
+		{"BEF 2030", false},
+		{"TO 2030", false},
+		{"BET 2019 AND 2030", false},
+		{"FROM 2021 TO 2030", true},
+		{"@#DJULIAN@ 10 JUN 2020", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			ind := &gedcom.Individual{XRef: "@I1@", Events: []*gedcom.Event{
+				{Type: gedcom.EventBirth, ParsedDate: mustParseDate(t, tt.date)},
+			}}
+			issues := v.Validate(makeDocument([]*gedcom.Individual{ind}, nil))
+			if got := len(issues) == 1 && issues[0].Code == CodeFutureDate; got != tt.wantFuture {
+				t.Errorf("future = %v, want %v (issues %v)", got, tt.wantFuture, issueCodes(issues))
+			}
+		})
+	}
+}
+
+func TestDateLogicValidator_FutureDates_FamiliesAndAttributes(t *testing.T) {
+	v := NewDateLogicValidator(&DateLogicConfig{AsOf: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)})
+
+	ind := &gedcom.Individual{XRef: "@I1@", Attributes: []*gedcom.Attribute{
+		{Type: "RESI", ParsedDate: mustParseDate(t, "2001")},
+	}}
+	fam := &gedcom.Family{XRef: "@F1@", Events: []*gedcom.Event{
+		{Type: gedcom.EventMarriage, ParsedDate: mustParseDate(t, "3 MAR 2005")},
+	}}
+	issues := v.Validate(makeDocument([]*gedcom.Individual{ind}, []*gedcom.Family{fam}))
+	if len(issues) != 2 {
+		t.Fatalf("issues = %v, want 2", issueCodes(issues))
+	}
+	if issues[0].RecordXRef != "@I1@" || issues[0].Details["tag"] != "RESI" {
+		t.Errorf("issue[0] = %+v", issues[0])
+	}
+	if issues[1].RecordXRef != "@F1@" || issues[1].Details["tag"] != "MARR" || issues[1].Severity != SeverityWarning {
+		t.Errorf("issue[1] = %+v", issues[1])
+	}
+}
+
+func TestDateLogicValidator_DefaultAsOfIsNow(t *testing.T) {
+	v := NewDateLogicValidator(nil)
+	next := time.Now().AddDate(2, 0, 0).Year()
+	ind := &gedcom.Individual{XRef: "@I1@", Events: []*gedcom.Event{
+		{Type: gedcom.EventBirth, ParsedDate: &gedcom.Date{Original: "future", Year: next}},
+		{Type: gedcom.EventDeath, ParsedDate: &gedcom.Date{Original: "past", Year: 1900}},
+	}}
+	issues := v.checkFutureEvents(ind.XRef, ind.Events, nil)
+	if len(issues) != 1 || issues[0].Details["date"] != "future" {
+		t.Errorf("issues = %+v", issues)
+	}
+}
+
+func TestDateLogicValidator_Timestamps(t *testing.T) {
+	v := NewDateLogicValidator(&DateLogicConfig{AsOf: time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC)})
+
+	tests := []struct {
+		name    string
+		changed *gedcom.ChangeDate
+		created *gedcom.ChangeDate
+		want    []string
+	}{
+		{"no timestamps", nil, nil, nil},
+		{"change only", &gedcom.ChangeDate{Date: "1 JAN 2030"}, nil, nil},
+		{"ordered", &gedcom.ChangeDate{Date: "2 JAN 2010"}, &gedcom.ChangeDate{Date: "1 JAN 2010"}, nil},
+		{"change before creation", &gedcom.ChangeDate{Date: "1 JAN 2009"}, &gedcom.ChangeDate{Date: "1 JAN 2010"}, []string{CodeChangeBeforeCreation}},
+		{"same day earlier time", &gedcom.ChangeDate{Date: "1 JAN 2010", Time: "09:30"}, &gedcom.ChangeDate{Date: "1 JAN 2010", Time: "10:15:00"}, []string{CodeChangeBeforeCreation}},
+		{"same day later time", &gedcom.ChangeDate{Date: "1 JAN 2010", Time: "10:15:01.5"}, &gedcom.ChangeDate{Date: "1 JAN 2010", Time: "10:15:00"}, nil},
+		{"same day missing time", &gedcom.ChangeDate{Date: "1 JAN 2010"}, &gedcom.ChangeDate{Date: "1 JAN 2010", Time: "10:15"}, nil},
+		{"future creation", &gedcom.ChangeDate{Date: "1 JAN 2031"}, &gedcom.ChangeDate{Date: "1 JAN 2030"}, []string{CodeFutureCreation}},
+		{"future creation and earlier change", &gedcom.ChangeDate{Date: "1 JAN 2010"}, &gedcom.ChangeDate{Date: "1 JAN 2030"}, []string{CodeFutureCreation, CodeChangeBeforeCreation}},
+		{"unparseable", &gedcom.ChangeDate{Date: "yesterday"}, &gedcom.ChangeDate{Date: "ABT 2030"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ind := &gedcom.Individual{XRef: "@I1@", ChangeDate: tt.changed, CreationDate: tt.created}
+			issues := v.Validate(makeDocument([]*gedcom.Individual{ind}, nil))
+			got := issueCodes(issues)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("codes = %v, want %v", got, tt.want)
+			}
+			for _, issue := range issues {
+				if issue.Severity != SeverityError || issue.RecordXRef != "@I1@" {
+					t.Errorf("issue = %+v", issue)
+				}
+			}
+		})
+	}
+}
+
+func TestDateLogicValidator_TimestampsFromRawTags(t *testing.T) {
+	v := NewDateLogicValidator(&DateLogicConfig{AsOf: time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC)})
+	record := &gedcom.Record{
+		XRef:   "@R1@",
+		Type:   gedcom.RecordTypeRepository,
+		Entity: &gedcom.Repository{XRef: "@R1@", Name: "Archive"},
+		Tags: []*gedcom.Tag{
+			{Level: 1, Tag: "NAME", Value: "Archive"},
+			{Level: 1, Tag: "CREA"},
+			{Level: 2, Tag: "DATE", Value: "5 MAY 2015"},
+			{Level: 3, Tag: "TIME", Value: "12:00"},
+			{Level: 1, Tag: "CHAN"},
+			{Level: 2, Tag: "DATE", Value: "5 MAY 2015"},
+			{Level: 3, Tag: "TIME", Value: "11:59:59"},
+		},
+	}
+	issues := v.checkTimestamps(record)
+	if len(issues) != 1 || issues[0].Code != CodeChangeBeforeCreation {
+		t.Fatalf("issues = %+v", issues)
+	}
+	if issues[0].Details["change_date"] != "5 MAY 2015 11:59:59" || issues[0].Details["creation_date"] != "5 MAY 2015 12:00" {
+		t.Errorf("details = %v", issues[0].Details)
+	}
+}
+
+func TestParseClockTime(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   float64
+		wantOK bool
+	}{
+		{"00:00", 0, true},
+		{"1:02:03", 3723, true},
+		{"23:59:59.5Z", 86399.5, true},
+		{"24:00", 0, false},
+		{"12", 0, false},
+		{"12:60", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseClockTime(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseClockTime(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
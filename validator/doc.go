@@ -40,6 +40,24 @@
 //	dateIssues := v.ValidateDateLogic(doc)      // Check date logic
 //	refIssues := v.FindOrphanedReferences(doc)  // Find broken references
 //	duplicates := v.FindPotentialDuplicates(doc) // Find potential duplicates
+//	families := v.FindPotentialDuplicateFamilies(doc) // Find duplicate families
+//	placeIssues := v.ValidatePlaceForms(doc)     // Check places against PLAC.FORM
+//	mapIssues := v.ValidateCoordinates(doc)      // Check MAP latitude/longitude
+//	addrIssues := v.ValidateAddressPlaces(doc)   // Check ADDR against PLAC
+//	langIssues := v.ValidateLanguages(doc)       // Check 7.0 LANG tags
+//	repeats := v.ValidateCardinality(doc)        // Find repeated singleton tags
+//	extIssues := v.ValidateExtensions(doc)       // Check registered extensions
+//
+// # Cancellable Runs
+//
+// ValidateAllContext runs the checks of ValidateAll under a context, in
+// parallel when ValidatorConfig.Workers is above one, and reports the time
+// each check took:
+//
+//	run, err := v.ValidateAllContext(ctx, doc)
+//	for _, t := range run.Timings {
+//	    fmt.Println(t.Check, t.Duration)
+//	}
 //
 // # Quality Reports
 //
@@ -50,20 +68,43 @@
 //	fmt.Printf("Errors: %d, Warnings: %d\n", report.ErrorCount, report.WarningCount)
 //	fmt.Printf("Birth date coverage: %.0f%%\n", report.BirthDateCoverage*100)
 //
+// # Completeness Profiles
+//
+// Score records against configurable requirements:
+//
+//	report := validator.DefaultIndividualProfile().Evaluate(doc)
+//	for _, rec := range report.Incomplete() {
+//	    fmt.Println(rec.XRef, rec.Missing) // research to-do list
+//	}
+//
+// # Best-Practice Linting
+//
+// [Linter] flags legal but discouraged practices, such as dates in notes or
+// names in capitals, separately from specification validation:
+//
+//	for _, issue := range validator.NewLinter(nil).Lint(doc) {
+//	    fmt.Println(issue, issue.Details["explanation"])
+//	}
+//
 // # Options
 //
 // Use [NewWithOptions] together with [ValidateOptions] to customize validation
 // behavior. [ValidatorConfig] is retained as a backward-compatible alias.
 // Call [DefaultOptions] for a populated starting point.
 //
-//   - Strictness             — StrictnessRelaxed | StrictnessNormal (default) | StrictnessStrict
+//   - Strictness             — StrictnessRelaxed | StrictnessNormal (default) | StrictnessStrict;
+//     the shared [gedcom.Strictness], so one value can configure every package
 //   - MaxErrors              — cap collected issues (0 = unlimited)
 //   - SkipRules              — issue codes to exclude (e.g. []string{"W001"})
-//   - DateLogic              — date-logic thresholds (e.g. MaxReasonableAge)
+//   - Severity               — severity overrides by issue code, applied before
+//     Strictness (e.g. CodeMultipleSpouses as SeverityInfo)
+//   - Categories             — run only these check categories (e.g. CategoryDates)
+//   - DateLogic              — date-logic thresholds (e.g. MaxReasonableAge, AsOf)
 //   - Duplicates             — duplicate-detection thresholds
 //   - TagRegistry            — definitions for custom (underscore) tags
 //   - ValidateCustomTags     — enable custom-tag validation against registry
 //   - SkipEncodingValidation — disable GEDCOM 7.0 encoding checks
+//   - Lint                   — best-practice rules used by Validator.Lint
 //
 // Example combining strictness with skip rules:
 //
@@ -37,6 +37,13 @@ type DuplicateConfig struct {
 	// Default: 2
 	MaxBirthYearDiff int
 
+	// BirthDateTolerance, if set, also scores birth dates further apart than
+	// MaxBirthYearDiff when they overlap as uncertain dates (see
+	// gedcom.Date.Matches): "ABT 1850" with "1854", or "BEF 1860" with
+	// "1840". Nil compares birth years only.
+	// Default: gedcom.DefaultDateTolerance()
+	BirthDateTolerance *gedcom.DateTolerance
+
 	// RequireBirthDate requires both individuals to have birth dates for a match.
 	// If true, missing birth dates prevent a match.
 	// Default: false
@@ -50,11 +57,13 @@ type DuplicateConfig struct {
 
 // DefaultDuplicateConfig returns a DuplicateConfig with default values.
 func DefaultDuplicateConfig() DuplicateConfig {
+	tolerance := gedcom.DefaultDateTolerance()
 	return DuplicateConfig{
 		RequireExactSurname: true,
 		NormalizeNames:      true,
 		MinNameSimilarity:   0.8,
 		MaxBirthYearDiff:    2,
+		BirthDateTolerance:  &tolerance,
 		RequireBirthDate:    false,
 		MinConfidence:       0.7,
 	}
@@ -96,21 +105,7 @@ func (p DuplicatePair) ToIssue() Issue {
 
 // getDisplayName returns a display name for an individual.
 func getDisplayName(ind *gedcom.Individual) string {
-	if ind == nil {
-		return ""
-	}
-	if len(ind.Names) == 0 {
-		return ind.XRef
-	}
-	name := ind.Names[0]
-	if name.Full != "" {
-		// Remove slashes from GEDCOM format
-		return strings.ReplaceAll(strings.ReplaceAll(name.Full, "/", ""), "  ", " ")
-	}
-	if name.Given != "" || name.Surname != "" {
-		return strings.TrimSpace(name.Given + " " + name.Surname)
-	}
-	return ind.XRef
+	return gedcom.DefaultNameFormatter.FormatIndividual(ind)
 }
 
 // DuplicateDetector detects potential duplicate individuals in a GEDCOM document.
@@ -300,6 +295,9 @@ func (d *DuplicateDetector) comparePair(ind1, ind2 *gedcom.Individual) (Duplicat
 		} else if yearDiff <= d.config.MaxBirthYearDiff {
 			confidence += 0.1
 			reasons = append(reasons, fmt.Sprintf("birth year within %d years", yearDiff))
+		} else if d.config.BirthDateTolerance != nil && birthDate1.Matches(birthDate2, *d.config.BirthDateTolerance) {
+			confidence += 0.1
+			reasons = append(reasons, "birth dates overlap within tolerance")
 		}
 	}
 
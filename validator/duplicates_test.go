@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/cacack/gedcom-go/v2/gedcom"
@@ -684,6 +685,47 @@ func TestFindDuplicates_BirthDateProximity(t *testing.T) {
 	}
 }
 
+func TestFindDuplicates_UncertainBirthDates(t *testing.T) {
+	tests := []struct {
+		date1, date2 string
+		want         bool
+	}{
+		{"ABT 1850", "1854", true},
+		{"BEF 1860", "1840", true},
+		{"AFT 1860", "1840", false},
+		{"1850", "1854", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date1+" vs "+tt.date2, func(t *testing.T) {
+			doc := &gedcom.Document{}
+			for i, date := range []string{tt.date1, tt.date2} {
+				parsed, err := gedcom.ParseDate(date)
+				if err != nil {
+					t.Fatalf("ParseDate(%q) error = %v", date, err)
+				}
+				ind := &gedcom.Individual{
+					XRef:   fmt.Sprintf("@I%d@", i+1),
+					Names:  []*gedcom.PersonalName{{Full: "John /Doe/"}},
+					Sex:    "M",
+					Events: []*gedcom.Event{{Type: gedcom.EventBirth, ParsedDate: parsed}},
+				}
+				doc.Records = append(doc.Records, &gedcom.Record{XRef: ind.XRef, Type: gedcom.RecordTypeIndividual, Entity: ind})
+			}
+
+			config := DefaultDuplicateConfig()
+			config.MinConfidence = 0.75
+			if got := len(NewDuplicateDetector(&config).FindDuplicates(doc)) == 1; got != tt.want {
+				t.Errorf("with tolerance: duplicate = %v, want %v", got, tt.want)
+			}
+
+			config.BirthDateTolerance = nil
+			if got := len(NewDuplicateDetector(&config).FindDuplicates(doc)); got != 0 {
+				t.Errorf("without tolerance: %d duplicates, want 0", got)
+			}
+		})
+	}
+}
+
 func TestFindDuplicates_SameBirthYear(t *testing.T) {
 	birthDate1, _ := gedcom.ParseDate("1 JAN 1900")
 	birthDate2, _ := gedcom.ParseDate("15 MAR 1900")
@@ -24,10 +24,34 @@ func NewEncodingValidator() *EncodingValidator {
 // Validate performs all encoding validations on the document.
 // It combines results from ValidateEncoding and ValidateControlCharacters.
 func (e *EncodingValidator) Validate(doc *gedcom.Document) []Issue {
-	var issues []Issue
-	issues = append(issues, e.ValidateEncoding(doc)...)
-	issues = append(issues, e.ValidateControlCharacters(doc)...)
-	return issues
+	return e.visitor(doc, true).run(doc)
+}
+
+// visitor returns the per-record form of ValidateControlCharacters,
+// preceded by the issues of ValidateEncoding if withEncoding is set, or nil
+// for documents other than GEDCOM 7.0.
+func (e *EncodingValidator) visitor(doc *gedcom.Document, withEncoding bool) *recordVisitor {
+	if doc == nil || doc.Header == nil || doc.Header.Version != gedcom.Version70 {
+		return nil
+	}
+
+	rv := newRecordVisitor(1, func(rv *recordVisitor, record *gedcom.Record) {
+		var issues []Issue
+		e.scanTagsForControlChars(record.Tags, record.XRef, &issues)
+
+		// Also check the record's value field
+		if record.Value != "" {
+			if issue := e.checkControlChars(record.Value, record.XRef, string(record.Type)); issue != nil {
+				issues = append(issues, *issue)
+			}
+		}
+		rv.add(0, issues...)
+	})
+	if withEncoding {
+		rv.add(0, e.ValidateEncoding(doc)...)
+	}
+	rv.add(0, e.headerControlCharacters(doc.Header)...)
+	return rv
 }
 
 // ValidateEncoding validates that GEDCOM 7.0 files use UTF-8 encoding.
@@ -95,15 +119,13 @@ func (e *EncodingValidator) ValidateEncoding(doc *gedcom.Document) []Issue {
 //
 // For GEDCOM 5.5 and 5.5.1, no control character restrictions are enforced.
 func (e *EncodingValidator) ValidateControlCharacters(doc *gedcom.Document) []Issue {
-	if doc == nil || doc.Header == nil {
-		return nil
-	}
-
-	// Only validate control characters for GEDCOM 7.0
-	if doc.Header.Version != gedcom.Version70 {
-		return nil
-	}
+	// Only GEDCOM 7.0 documents get a visitor
+	return e.visitor(doc, false).run(doc)
+}
 
+// headerControlCharacters scans the header's string fields and tags for
+// banned control characters.
+func (e *EncodingValidator) headerControlCharacters(header *gedcom.Header) []Issue {
 	var issues []Issue
 
 	// Scan header string fields
@@ -111,11 +133,11 @@ func (e *EncodingValidator) ValidateControlCharacters(doc *gedcom.Document) []Is
 		value string
 		field string
 	}{
-		{doc.Header.SourceSystem, "SOUR"},
-		{doc.Header.Language, "LANG"},
-		{doc.Header.Copyright, "COPR"},
-		{doc.Header.Submitter, "SUBM"},
-		{doc.Header.AncestryTreeID, "_TREE"},
+		{header.SourceSystem, "SOUR"},
+		{header.Language, "LANG"},
+		{header.Copyright, "COPR"},
+		{header.Submitter, "SUBM"},
+		{header.AncestryTreeID, "_TREE"},
 	}
 	for _, hf := range headerFields {
 		if hf.value != "" {
@@ -126,20 +148,8 @@ func (e *EncodingValidator) ValidateControlCharacters(doc *gedcom.Document) []Is
 	}
 
 	// Scan header tags
-	if doc.Header.Tags != nil {
-		e.scanTagsForControlChars(doc.Header.Tags, "", &issues)
-	}
-
-	// Scan all records
-	for _, record := range doc.Records {
-		e.scanTagsForControlChars(record.Tags, record.XRef, &issues)
-
-		// Also check the record's value field
-		if record.Value != "" {
-			if issue := e.checkControlChars(record.Value, record.XRef, string(record.Type)); issue != nil {
-				issues = append(issues, *issue)
-			}
-		}
+	if header.Tags != nil {
+		e.scanTagsForControlChars(header.Tags, "", &issues)
 	}
 
 	return issues
@@ -0,0 +1,114 @@
+// engine.go runs the record-level checks of ValidateAll in a single pass.
+//
+// Most checks look at each record on its own: the events of an individual,
+// the LANG tags of a note, the extensions of a source. Rather than each of
+// them walking Records (or the per-type lists) separately, such a check
+// provides a recordVisitor, and ValidateAll hands every record to all
+// enabled visitors in one traversal. Checks that compare records with one
+// another, such as duplicate detection, still run on their own.
+
+package validator
+
+import "github.com/cacack/gedcom-go/v2/gedcom"
+
+// recordVisitor is the per-record form of a check. Its visit function is
+// called for every non-nil record of the document in order.
+//
+// A check that used to walk the document several times, say all
+// individuals and then all families, appends the issues of each walk to its
+// own phase, so that a single pass returns them in the same order.
+type recordVisitor struct {
+	phases [][]Issue
+	visit  func(rv *recordVisitor, record *gedcom.Record)
+}
+
+// newRecordVisitor returns a visitor with the given number of phases.
+func newRecordVisitor(phases int, visit func(rv *recordVisitor, record *gedcom.Record)) *recordVisitor {
+	return &recordVisitor{phases: make([][]Issue, phases), visit: visit}
+}
+
+// add appends issues to a phase.
+func (rv *recordVisitor) add(phase int, issues ...Issue) {
+	rv.phases[phase] = append(rv.phases[phase], issues...)
+}
+
+// issues returns the issues of every phase, in phase order.
+func (rv *recordVisitor) issues() []Issue {
+	var all []Issue
+	for _, phase := range rv.phases {
+		all = append(all, phase...)
+	}
+	return all
+}
+
+// run visits the records of doc and returns the issues. A nil visitor, for
+// a check that does not apply to the document, returns nil.
+func (rv *recordVisitor) run(doc *gedcom.Document) []Issue {
+	if rv == nil {
+		return nil
+	}
+	visitRecords(doc, []*recordVisitor{rv})
+	return rv.issues()
+}
+
+// visitRecords makes the single pass over the records of doc.
+func visitRecords(doc *gedcom.Document, visitors []*recordVisitor) {
+	if len(visitors) == 0 {
+		return
+	}
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		for _, rv := range visitors {
+			rv.visit(rv, record)
+		}
+	}
+}
+
+// recordIndividual returns the individual of an INDI record, as
+// Document.Individuals lists them.
+func recordIndividual(record *gedcom.Record) (*gedcom.Individual, bool) {
+	if record.Type != gedcom.RecordTypeIndividual {
+		return nil, false
+	}
+	return record.GetIndividual()
+}
+
+// recordFamily returns the family of a FAM record, as Document.Families
+// lists them.
+func recordFamily(record *gedcom.Record) (*gedcom.Family, bool) {
+	if record.Type != gedcom.RecordTypeFamily {
+		return nil, false
+	}
+	return record.GetFamily()
+}
+
+// runChecks runs checks on doc, record-level ones in a single shared pass,
+// and returns their categorized issues in check order.
+func runChecks(doc *gedcom.Document, checks []validationCheck) []Issue {
+	results := make([][]Issue, len(checks))
+	visitors := make([]*recordVisitor, len(checks))
+	var active []*recordVisitor
+	for i, check := range checks {
+		if check.visitor == nil {
+			results[i] = check.runCategorized(doc)
+			continue
+		}
+		if rv := check.visitor(doc); rv != nil {
+			visitors[i] = rv
+			active = append(active, rv)
+		}
+	}
+
+	visitRecords(doc, active)
+
+	var all []Issue
+	for i, check := range checks {
+		if visitors[i] != nil {
+			results[i] = check.categorize(visitors[i].issues())
+		}
+		all = append(all, results[i]...)
+	}
+	return all
+}
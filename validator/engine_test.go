@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// engineTestGedcom interleaves individuals and families, so the single
+// pass must keep each check's issues in its former order: all individuals,
+// then all families, then all records.
+const engineTestGedcom = `0 HEAD
+1 GEDC
+2 VERS 7.0
+1 LANG English
+1 PLAC
+2 FORM City, County, State, Country
+0 @I1@ INDI
+1 NAME John /Smith/
+1 BIRT
+2 DATE 1 JAN 1900
+2 PLAC Boston, USA
+2 MAP
+3 LATI 42.36
+3 LONG W71.06
+1 DEAT
+2 DATE 1 JAN 1850
+1 FAMC @F9@
+1 SEX M
+1 SEX F
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I99@
+1 MARR
+2 DATE 1 JAN 2999
+2 PLAC Salem, Essex, Massachusetts, USA
+2 ADDR 1 Main St
+3 CITY Portland
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 LANG Klingon
+1 RESI
+2 PLAC Springfield
+2 ADDR 2 Elm St
+3 CITY Shelbyville
+1 FAMS @F8@
+0 @F2@ FAM
+1 CHIL @I77@
+1 MARR
+2 PLAC Nowhere
+2 MAP
+3 LATI N0
+3 LONG E0
+0 TRLR
+`
+
+// separateRuns runs every check of v on its own, as ValidateAll did before
+// the checks shared a pass.
+func separateRuns(v *Validator, doc *gedcom.Document) []Issue {
+	var all []Issue
+	for _, check := range v.checks() {
+		all = append(all, check.runCategorized(doc)...)
+	}
+	return v.filterByStrictness(all)
+}
+
+func TestValidateAll_SinglePassMatchesSeparateChecks(t *testing.T) {
+	docs := map[string]*gedcom.Document{}
+	doc, err := decoder.Decode(strings.NewReader(engineTestGedcom))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	docs["fixture"] = doc
+	for _, path := range []string{
+		"../testdata/gedcom-5.5/torture-test/TGC551.ged",
+		"../testdata/gedcom-5.5.1/comprehensive.ged",
+		"../testdata/gedcom-7.0/maximal70.ged",
+		"../testdata/edge-cases/vendor-customtags-torture.ged",
+	} {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("open %s: %v", path, err)
+		}
+		doc, err := decoder.Decode(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("Decode(%s) error = %v", path, err)
+		}
+		docs[path] = doc
+	}
+
+	registry := NewTagRegistry()
+	validators := map[string]*Validator{
+		"default": New(),
+		"strict":  NewWithConfig(&ValidatorConfig{Strictness: StrictnessStrict, TagRegistry: registry, ValidateCustomTags: true}),
+	}
+	for docName, doc := range docs {
+		for vName, v := range validators {
+			want := separateRuns(v, doc)
+			got := v.ValidateAll(doc)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s/%s: ValidateAll differs from separate checks:\n got %v\nwant %v", docName, vName, got, want)
+			}
+		}
+	}
+
+	if got := New().ValidateAll(docs["fixture"]); len(got) < 10 {
+		t.Errorf("fixture produced %d issues, want at least 10 across checks", len(got))
+	}
+}
+
+func TestRecordVisitor_Phases(t *testing.T) {
+	doc := &gedcom.Document{Records: []*gedcom.Record{
+		{XRef: "@I1@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Individual{XRef: "@I1@"}},
+		nil,
+		{XRef: "@F1@", Type: gedcom.RecordTypeFamily, Entity: &gedcom.Family{XRef: "@F1@"}},
+		{XRef: "@I2@", Type: gedcom.RecordTypeIndividual, Entity: &gedcom.Individual{XRef: "@I2@"}},
+	}}
+	rv := newRecordVisitor(2, func(rv *recordVisitor, record *gedcom.Record) {
+		if _, ok := recordIndividual(record); ok {
+			rv.add(0, Issue{RecordXRef: record.XRef})
+		}
+		if _, ok := recordFamily(record); ok {
+			rv.add(1, Issue{RecordXRef: record.XRef})
+		}
+	})
+
+	var got []string
+	for _, issue := range rv.run(doc) {
+		got = append(got, issue.RecordXRef)
+	}
+	if want := []string{"@I1@", "@I2@", "@F1@"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("issues = %v, want %v", got, want)
+	}
+
+	var nilVisitor *recordVisitor
+	if issues := nilVisitor.run(doc); issues != nil {
+		t.Errorf("nil visitor run() = %v, want nil", issues)
+	}
+}
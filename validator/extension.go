@@ -0,0 +1,184 @@
+// extension.go provides validation of application extensions against the
+// schemas registered with gedcom.RegisterExtension.
+//
+// Unlike the TagRegistry, which checks individual custom tags, an extension
+// schema describes a whole structure: where its root may appear, which
+// substructures it contains, how often, and what payload each carries.
+
+package validator
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// ExtensionValidator checks registered application extensions against
+// their schemas.
+type ExtensionValidator struct{}
+
+// NewExtensionValidator creates a new ExtensionValidator.
+func NewExtensionValidator() *ExtensionValidator {
+	return &ExtensionValidator{}
+}
+
+// Validate checks every record for registered extensions at level 1.
+// Extensions under a record type their schema does not allow are reported;
+// the rest are taken from the entity's ExtensionData (or, for records
+// without a typed entity, from the raw tags) and checked recursively for
+// undeclared, missing, and repeated children and for payload mismatches.
+// Extensions that are not registered are ignored.
+func (v *ExtensionValidator) Validate(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	return v.visitor().run(doc)
+}
+
+// visitor returns the per-record form of Validate.
+func (v *ExtensionValidator) visitor() *recordVisitor {
+	return newRecordVisitor(1, func(rv *recordVisitor, record *gedcom.Record) {
+		rv.add(0, v.checkRecord(record)...)
+	})
+}
+
+// ValidateDeclarations reports each extension tag of a GEDCOM 7.0 document
+// that is not declared in HEAD.SCHMA, once per tag with its first
+// location and occurrence count. The issue's source_system detail names
+// the HEAD.SOUR product that wrote the file, which usually identifies the
+// vendor whose extensions need declaring; transforms.DeclareExtensions
+// adds the missing declarations. Documents of other versions have no SCHMA
+// and are not checked.
+func (v *ExtensionValidator) ValidateDeclarations(doc *gedcom.Document) []Issue {
+	if doc == nil || doc.Header == nil || doc.Header.Version != gedcom.Version70 {
+		return nil
+	}
+	var issues []Issue
+	for _, unknown := range doc.UnknownTags() {
+		if unknown.URI != "" {
+			continue
+		}
+		first := unknown.Locations[0]
+		issue := NewIssue(
+			SeverityWarning,
+			CodeUndeclaredExtension,
+			fmt.Sprintf("extension tag %s is not declared in HEAD.SCHMA", unknown.Tag),
+			first.RecordXRef,
+		).
+			WithDetail("tag", unknown.Tag).
+			WithDetail("count", fmt.Sprintf("%d", unknown.Count)).
+			WithDetail("path", first.Path).
+			WithDetail("line", fmt.Sprintf("%d", first.LineNumber))
+		if doc.Header.SourceSystem != "" {
+			issue = issue.WithDetail("source_system", doc.Header.SourceSystem)
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// checkRecord validates the extensions of one record.
+func (v *ExtensionValidator) checkRecord(record *gedcom.Record) []Issue {
+	var issues []Issue
+	for _, tag := range record.Tags {
+		if tag.Level != 1 {
+			continue
+		}
+		schema, ok := gedcom.LookupExtension(tag.Tag)
+		if !ok || schema.AppliesTo(record.Type) {
+			continue
+		}
+		issues = append(issues, NewIssue(
+			SeverityWarning,
+			CodeExtensionInvalidRecord,
+			fmt.Sprintf("extension %s is not allowed in %s records", tag.Tag, record.Type),
+			record.XRef,
+		).
+			WithDetail("tag", tag.Tag).
+			WithDetail("record_type", string(record.Type)).
+			WithDetail("line", fmt.Sprintf("%d", tag.LineNumber)))
+	}
+
+	data := record.ExtensionData()
+	if data == nil && record.Entity == nil {
+		data = gedcom.ExtractExtensionData(record.Type, record.Tags)
+	}
+	for _, tag := range data.SortedTags() {
+		schema, ok := gedcom.LookupExtension(tag)
+		if !ok {
+			continue
+		}
+		for _, ext := range data[tag] {
+			issues = v.checkExtension(ext, &schema, tag, record.XRef, issues)
+		}
+	}
+	return issues
+}
+
+// checkExtension validates ext against schema and appends any issues.
+// path is the dotted tag path used in messages (e.g. "_MYAPP._ID").
+func (v *ExtensionValidator) checkExtension(ext *gedcom.Extension, schema *gedcom.ExtensionSchema, path, xref string, issues []Issue) []Issue {
+	if ext == nil {
+		return issues
+	}
+
+	if !schema.Payload.Accepts(ext.Value) {
+		issues = append(issues, NewIssue(
+			SeverityWarning,
+			CodeExtensionInvalidPayload,
+			fmt.Sprintf("extension %s payload %q is not a valid %s", path, ext.Value, schema.Payload),
+			xref,
+		).
+			WithDetail("path", path).
+			WithDetail("value", ext.Value).
+			WithDetail("expected", schema.Payload.String()))
+	}
+
+	counts := make(map[string]int)
+	for _, child := range ext.Children {
+		if child == nil {
+			continue
+		}
+		childPath := path + "." + child.Tag
+		childSchema, ok := schema.Child(child.Tag)
+		if !ok {
+			issues = append(issues, NewIssue(
+				SeverityWarning,
+				CodeExtensionUnknownChild,
+				fmt.Sprintf("extension %s does not declare substructure %s", path, child.Tag),
+				xref,
+			).
+				WithDetail("path", childPath).
+				WithDetail("tag", child.Tag))
+			continue
+		}
+		counts[child.Tag]++
+		if counts[child.Tag] == 2 && !childSchema.Repeatable {
+			issues = append(issues, NewIssue(
+				SeverityWarning,
+				CodeExtensionRepeatedChild,
+				fmt.Sprintf("extension %s allows at most one %s", path, child.Tag),
+				xref,
+			).
+				WithDetail("path", childPath).
+				WithDetail("tag", child.Tag))
+		}
+		issues = v.checkExtension(child, childSchema, childPath, xref, issues)
+	}
+
+	for i := range schema.Children {
+		child := &schema.Children[i]
+		if child.Required && counts[child.Tag] == 0 {
+			issues = append(issues, NewIssue(
+				SeverityWarning,
+				CodeExtensionMissingChild,
+				fmt.Sprintf("extension %s is missing required substructure %s", path, child.Tag),
+				xref,
+			).
+				WithDetail("path", path+"."+child.Tag).
+				WithDetail("tag", child.Tag))
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,177 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func TestExtensionValidator_Validate(t *testing.T) {
+	err := gedcom.RegisterExtension(gedcom.ExtensionSchema{
+		Tag:     "_MYAPP",
+		Records: []gedcom.RecordType{gedcom.RecordTypeIndividual},
+		Payload: gedcom.ExtensionPayloadNone,
+		Children: []gedcom.ExtensionSchema{
+			{Tag: "_ID", Payload: gedcom.ExtensionPayloadText, Required: true},
+			{Tag: "_RANK", Payload: gedcom.ExtensionPayloadInteger},
+			{Tag: "_TAG", Payload: gedcom.ExtensionPayloadText, Repeatable: true},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gedcom.UnregisterExtension("_MYAPP")
+
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 NAME Valid /Person/
+1 _MYAPP
+2 _ID abc
+2 _TAG one
+2 _TAG two
+0 @I2@ INDI
+1 NAME Broken /Person/
+1 _MYAPP
+2 _RANK high
+2 _RANK 3
+2 _COLOR blue
+0 @F1@ FAM
+1 _MYAPP
+2 _ID fam
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	issues := NewExtensionValidator().Validate(doc)
+	want := map[string]string{
+		CodeExtensionInvalidPayload: "@I2@",
+		CodeExtensionRepeatedChild:  "@I2@",
+		CodeExtensionUnknownChild:   "@I2@",
+		CodeExtensionMissingChild:   "@I2@",
+		CodeExtensionInvalidRecord:  "@F1@",
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("Validate() returned %d issues, want %d: %v", len(issues), len(want), issues)
+	}
+	for _, issue := range issues {
+		xref, ok := want[issue.Code]
+		if !ok || issue.RecordXRef != xref {
+			t.Errorf("unexpected issue %v", issue)
+		}
+	}
+
+	// ValidateAll includes extension issues.
+	found := false
+	for _, issue := range New().ValidateAll(doc) {
+		if issue.Code == CodeExtensionMissingChild {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("ValidateAll() missing EXTENSION_MISSING_CHILD")
+	}
+	if got := New().ValidateExtensions(doc); len(got) != len(want) {
+		t.Errorf("ValidateExtensions() returned %d issues, want %d", len(got), len(want))
+	}
+}
+
+func TestExtensionValidator_Unregistered(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 _UNKNOWNAPP
+2 _ANYTHING x
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if issues := NewExtensionValidator().Validate(doc); len(issues) != 0 {
+		t.Errorf("Validate() = %v, want no issues", issues)
+	}
+	if issues := NewExtensionValidator().Validate(nil); issues != nil {
+		t.Errorf("Validate(nil) = %v, want nil", issues)
+	}
+}
+
+func TestExtensionValidator_ValidateDeclarations(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+1 SCHMA
+2 TAG _SKYPEID http://xmlns.com/foaf/0.1/skypeID
+1 SOUR FamilyApp
+0 @I1@ INDI
+1 NAME John /Doe/
+1 _SKYPEID john.doe
+1 _MILT Army
+1 BIRT
+2 _PRIM Y
+0 @I2@ INDI
+1 _MILT Navy
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues := NewExtensionValidator().ValidateDeclarations(doc)
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues, want 2: %+v", len(issues), issues)
+	}
+	first := issues[0]
+	if first.Code != CodeUndeclaredExtension || first.Severity != SeverityWarning || first.RecordXRef != "@I1@" {
+		t.Errorf("issue = %+v", first)
+	}
+	want := map[string]string{"tag": "_MILT", "count": "2", "path": "INDI._MILT", "line": "10", "source_system": "FamilyApp"}
+	for key, value := range want {
+		if first.Details[key] != value {
+			t.Errorf("Details[%q] = %q, want %q", key, first.Details[key], value)
+		}
+	}
+	if issues[1].Details["tag"] != "_PRIM" || issues[1].Details["path"] != "INDI.BIRT._PRIM" {
+		t.Errorf("second issue = %+v, want _PRIM under BIRT", issues[1])
+	}
+
+	if got := New().FindUndeclaredExtensions(doc); len(got) != 2 {
+		t.Errorf("FindUndeclaredExtensions() returned %d issues, want 2", len(got))
+	}
+	found := 0
+	for _, issue := range New().ValidateAll(doc) {
+		if issue.Code == CodeUndeclaredExtension {
+			found++
+		}
+	}
+	if found != 2 {
+		t.Errorf("ValidateAll() reported %d undeclared extensions, want 2", found)
+	}
+}
+
+func TestExtensionValidator_ValidateDeclarations_NotVersion70(t *testing.T) {
+	doc, err := decoder.Decode(strings.NewReader(`0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 _MILT Army
+0 TRLR
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issues := NewExtensionValidator().ValidateDeclarations(doc); len(issues) != 0 {
+		t.Errorf("5.5.1 document: got %+v, want none", issues)
+	}
+	if issues := NewExtensionValidator().ValidateDeclarations(nil); issues != nil {
+		t.Errorf("nil document: got %+v, want nil", issues)
+	}
+}
@@ -0,0 +1,165 @@
+// family_duplicates.go provides duplicate family detection based on shared
+// spouses and children.
+//
+// Duplicate families typically appear when the same couple is entered twice,
+// or when a family imported with only one spouse is later re-created with
+// both. Unlike individuals, families are matched on XRef links rather than
+// names, so detection is only as good as the individual deduplication
+// that precedes it.
+
+package validator
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// FamilyDuplicatePair represents a potential duplicate pair of families.
+type FamilyDuplicatePair struct {
+	// Family1 is the first family in the pair, in document order.
+	Family1 *gedcom.Family
+
+	// Family2 is the second family in the pair.
+	Family2 *gedcom.Family
+
+	// Confidence is the overall match confidence score (0.0 to 1.0).
+	Confidence float64
+
+	// SharedChildren lists the XRefs of children in both families.
+	SharedChildren []string
+
+	// MatchReasons contains human-readable descriptions of why these families matched.
+	MatchReasons []string
+}
+
+// ToIssue converts the FamilyDuplicatePair to a validation Issue.
+func (p FamilyDuplicatePair) ToIssue() Issue {
+	message := fmt.Sprintf("Potential duplicate: family %s may be the same as %s (%.0f%% confidence)",
+		p.Family1.XRef, p.Family2.XRef, p.Confidence*100)
+
+	issue := NewIssue(SeverityInfo, CodePotentialDuplicate, message, p.Family1.XRef).
+		WithRelatedXRef(p.Family2.XRef).
+		WithDetail("confidence", fmt.Sprintf("%.2f", p.Confidence))
+
+	for i, reason := range p.MatchReasons {
+		issue = issue.WithDetail(fmt.Sprintf("reason_%d", i+1), reason)
+	}
+
+	return issue
+}
+
+// FindDuplicateFamilies analyzes all families in the document and returns
+// potential duplicates. Two families match when:
+//
+//   - they have the same husband and the same wife; or
+//   - their spouses agree wherever both are recorded, they share at least
+//     one spouse, and they share at least one child (e.g. "HUSB @I1@ WIFE
+//     @I2@" and "HUSB @I1@" with a common CHIL).
+//
+// Shared children raise the confidence; pairs below MinConfidence are
+// dropped. Families are compared only with families that share a spouse.
+// Confirmed pairs can be merged with dedup.MergeFamilies.
+func (d *DuplicateDetector) FindDuplicateFamilies(doc *gedcom.Document) []FamilyDuplicatePair {
+	if doc == nil {
+		return nil
+	}
+
+	families := doc.Families()
+	if len(families) < 2 {
+		return nil
+	}
+
+	// Index families by spouse so only candidates sharing a spouse are compared.
+	bySpouse := make(map[string][]int)
+	for i, fam := range families {
+		for _, spouse := range []string{fam.Husband, fam.Wife} {
+			if spouse != "" {
+				bySpouse[spouse] = append(bySpouse[spouse], i)
+			}
+		}
+	}
+
+	var duplicates []FamilyDuplicatePair
+	for i, fam := range families {
+		seen := make(map[int]bool)
+		for _, spouse := range []string{fam.Husband, fam.Wife} {
+			for _, j := range bySpouse[spouse] {
+				if j <= i || seen[j] {
+					continue
+				}
+				seen[j] = true
+				if pair, ok := d.compareFamilies(fam, families[j]); ok {
+					duplicates = append(duplicates, pair)
+				}
+			}
+		}
+	}
+
+	return duplicates
+}
+
+// compareFamilies compares two families and returns a FamilyDuplicatePair if they match.
+func (d *DuplicateDetector) compareFamilies(fam1, fam2 *gedcom.Family) (FamilyDuplicatePair, bool) {
+	if !spousesCompatible(fam1.Husband, fam2.Husband) || !spousesCompatible(fam1.Wife, fam2.Wife) {
+		return FamilyDuplicatePair{}, false
+	}
+
+	shared := sharedChildren(fam1.Children, fam2.Children)
+
+	var confidence float64
+	var reasons []string
+	switch {
+	case fam1.Husband != "" && fam1.Wife != "" && fam1.Husband == fam2.Husband && fam1.Wife == fam2.Wife:
+		confidence = 0.8
+		reasons = append(reasons, "same husband and wife")
+	case len(shared) > 0:
+		confidence = 0.5
+		reasons = append(reasons, "same spouse, other spouse missing from one family")
+	default:
+		return FamilyDuplicatePair{}, false
+	}
+
+	if len(shared) > 0 {
+		confidence += 0.2
+		if len(shared) == 1 {
+			reasons = append(reasons, "1 shared child")
+		} else {
+			reasons = append(reasons, fmt.Sprintf("%d shared children", len(shared)))
+		}
+	}
+
+	if confidence < d.config.MinConfidence {
+		return FamilyDuplicatePair{}, false
+	}
+
+	return FamilyDuplicatePair{
+		Family1:        fam1,
+		Family2:        fam2,
+		Confidence:     confidence,
+		SharedChildren: shared,
+		MatchReasons:   reasons,
+	}, true
+}
+
+// spousesCompatible reports whether two spouse XRefs can refer to the same
+// person: equal, or missing on at least one side.
+func spousesCompatible(a, b string) bool {
+	return a == "" || b == "" || a == b
+}
+
+// sharedChildren returns the children in both lists, in the order of the first.
+func sharedChildren(children1, children2 []string) []string {
+	inSecond := make(map[string]bool, len(children2))
+	for _, child := range children2 {
+		inSecond[child] = true
+	}
+	var shared []string
+	for _, child := range children1 {
+		if inSecond[child] {
+			shared = append(shared, child)
+			delete(inSecond, child)
+		}
+	}
+	return shared
+}
@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func familyDocument(families ...*gedcom.Family) *gedcom.Document {
+	doc := &gedcom.Document{}
+	for _, fam := range families {
+		doc.Records = append(doc.Records, &gedcom.Record{XRef: fam.XRef, Type: gedcom.RecordTypeFamily, Entity: fam})
+	}
+	return doc
+}
+
+func TestFindDuplicateFamilies(t *testing.T) {
+	tests := []struct {
+		name       string
+		fam2       *gedcom.Family
+		want       bool
+		confidence float64
+	}{
+		{"same spouses", &gedcom.Family{XRef: "@F2@", Husband: "@I1@", Wife: "@I2@"}, true, 0.8},
+		{"same spouses and child", &gedcom.Family{XRef: "@F2@", Husband: "@I1@", Wife: "@I2@", Children: []string{"@I3@"}}, true, 1.0},
+		{"missing wife, shared child", &gedcom.Family{XRef: "@F2@", Husband: "@I1@", Children: []string{"@I3@"}}, true, 0.7},
+		{"missing wife, no shared child", &gedcom.Family{XRef: "@F2@", Husband: "@I1@", Children: []string{"@I9@"}}, false, 0},
+		{"different wife", &gedcom.Family{XRef: "@F2@", Husband: "@I1@", Wife: "@I5@", Children: []string{"@I3@"}}, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fam1 := &gedcom.Family{XRef: "@F1@", Husband: "@I1@", Wife: "@I2@", Children: []string{"@I3@", "@I4@"}}
+			pairs := NewDuplicateDetector(nil).FindDuplicateFamilies(familyDocument(fam1, tt.fam2))
+			if got := len(pairs) == 1; got != tt.want {
+				t.Fatalf("FindDuplicateFamilies() = %d pairs, want match %v", len(pairs), tt.want)
+			}
+			if !tt.want {
+				return
+			}
+			pair := pairs[0]
+			if pair.Family1 != fam1 || pair.Family2 != tt.fam2 {
+				t.Errorf("pair = %s, %s", pair.Family1.XRef, pair.Family2.XRef)
+			}
+			if diff := pair.Confidence - tt.confidence; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("Confidence = %v, want %v", pair.Confidence, tt.confidence)
+			}
+		})
+	}
+}
+
+func TestFindDuplicateFamilies_EachPairOnce(t *testing.T) {
+	doc := familyDocument(
+		&gedcom.Family{XRef: "@F1@", Husband: "@I1@", Wife: "@I2@"},
+		&gedcom.Family{XRef: "@F2@", Husband: "@I1@", Wife: "@I2@", Children: []string{"@I3@"}},
+		&gedcom.Family{XRef: "@F3@", Husband: "@I1@", Wife: "@I2@"},
+		&gedcom.Family{XRef: "@F4@", Husband: "@I7@", Wife: "@I8@"},
+	)
+	pairs := NewDuplicateDetector(nil).FindDuplicateFamilies(doc)
+	if len(pairs) != 3 {
+		t.Fatalf("got %d pairs, want 3", len(pairs))
+	}
+	want := [][2]string{{"@F1@", "@F2@"}, {"@F1@", "@F3@"}, {"@F2@", "@F3@"}}
+	for i, pair := range pairs {
+		if got := [2]string{pair.Family1.XRef, pair.Family2.XRef}; got != want[i] {
+			t.Errorf("pair %d = %v, want %v", i, got, want[i])
+		}
+	}
+
+	if pairs := NewDuplicateDetector(nil).FindDuplicateFamilies(nil); pairs != nil {
+		t.Errorf("FindDuplicateFamilies(nil) = %v", pairs)
+	}
+}
+
+func TestFamilyDuplicatePairToIssue(t *testing.T) {
+	doc := familyDocument(
+		&gedcom.Family{XRef: "@F1@", Husband: "@I1@", Wife: "@I2@", Children: []string{"@I3@"}},
+		&gedcom.Family{XRef: "@F2@", Husband: "@I1@", Children: []string{"@I3@"}},
+	)
+	pairs := New().FindPotentialDuplicateFamilies(doc)
+	if len(pairs) != 1 {
+		t.Fatalf("got %d pairs, want 1", len(pairs))
+	}
+	if got := pairs[0].SharedChildren; len(got) != 1 || got[0] != "@I3@" {
+		t.Errorf("SharedChildren = %v", got)
+	}
+
+	issue := pairs[0].ToIssue()
+	if issue.Code != CodePotentialDuplicate || issue.RecordXRef != "@F1@" || issue.RelatedXRef != "@F2@" {
+		t.Errorf("ToIssue() = %+v", issue)
+	}
+	if issue.Details["reason_2"] != "1 shared child" {
+		t.Errorf("Details = %v", issue.Details)
+	}
+
+	found := false
+	strict := NewWithConfig(&ValidatorConfig{Strictness: StrictnessStrict})
+	for _, issue := range strict.ValidateAll(doc) {
+		if issue.Code == CodePotentialDuplicate && issue.RecordXRef == "@F1@" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("ValidateAll() did not report the duplicate family")
+	}
+}
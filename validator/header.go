@@ -2,7 +2,8 @@
 //
 // This module validates version-specific header requirements, including
 // the SUBM (Submitter) reference which is required in GEDCOM 5.5 and 5.5.1
-// but optional in GEDCOM 7.0.
+// but optional in GEDCOM 7.0, and that the header's SUBM and SUBN
+// references point to existing records.
 
 package validator
 
@@ -26,6 +27,11 @@ func NewHeaderValidator() *HeaderValidator {
 // Validations performed:
 //   - SUBM reference: Required for GEDCOM 5.5 and 5.5.1 (cardinality {1:1}),
 //     optional for GEDCOM 7.0.
+//   - SUBM target: When present, must point to an existing SUBM record.
+//   - SUBN target: When present, must point to an existing SUBN record.
+//
+// transforms.FixHeaders repairs all three: it links the header to a
+// submitter, creating a minimal one if needed, and drops dangling links.
 func (h *HeaderValidator) ValidateHeader(doc *gedcom.Document) []Issue {
 	if doc == nil || doc.Header == nil {
 		return nil
@@ -45,5 +51,33 @@ func (h *HeaderValidator) ValidateHeader(doc *gedcom.Document) []Issue {
 		).WithDetail("version", string(version)))
 	}
 
+	if xref := doc.Header.Submitter; xref != "" && doc.GetSubmitter(xref) == nil {
+		issues = append(issues, NewIssue(
+			SeverityError,
+			CodeOrphanedSUBM,
+			fmt.Sprintf("header SUBM reference to non-existent submitter %s", xref),
+			"",
+		).WithRelatedXRef(xref).
+			WithDetail("reference_type", string(RefTypeSUBM)).
+			WithDetail("field", "Header.Submitter"))
+	}
+
+	// SUBN records have no typed form, so the raw header tag is checked
+	for _, tag := range doc.Header.Tags {
+		if tag == nil || tag.Level != 1 || tag.Tag != "SUBN" || !gedcom.IsPointerXRef(tag.Value) {
+			continue
+		}
+		if record := doc.GetRecord(tag.Value); record == nil || record.Type != "SUBN" {
+			issues = append(issues, NewIssue(
+				SeverityError,
+				CodeOrphanedSUBN,
+				fmt.Sprintf("header SUBN reference to non-existent submission %s", tag.Value),
+				"",
+			).WithRelatedXRef(tag.Value).
+				WithDetail("reference_type", "SUBN").
+				WithDetail("field", "Header.SUBN"))
+		}
+	}
+
 	return issues
 }
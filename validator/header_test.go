@@ -1,8 +1,10 @@
 package validator
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/cacack/gedcom-go/v2/decoder"
 	"github.com/cacack/gedcom-go/v2/gedcom"
 )
 
@@ -99,6 +101,14 @@ func TestHeaderValidatorValidateHeader_SUBM(t *testing.T) {
 				Records: []*gedcom.Record{},
 				XRefMap: make(map[string]*gedcom.Record),
 			}
+			if tt.submitter != "" {
+				doc.Records = append(doc.Records, &gedcom.Record{
+					XRef:   tt.submitter,
+					Type:   gedcom.RecordTypeSubmitter,
+					Entity: &gedcom.Submitter{XRef: tt.submitter},
+				})
+				doc.Reindex()
+			}
 
 			issues := v.ValidateHeader(doc)
 
@@ -243,6 +253,56 @@ func TestHeaderValidatorValidateHeader_IssueImplementsError(t *testing.T) {
 	}
 }
 
+func TestHeaderValidatorValidateHeader_DanglingReferences(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 SUBM @U9@
+1 SUBN @SN9@
+0 @SN1@ SUBN
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	issues := NewHeaderValidator().ValidateHeader(doc)
+	if len(issues) != 2 {
+		t.Fatalf("ValidateHeader() returned %d issues, want 2: %v", len(issues), issues)
+	}
+	want := []struct{ code, xref, field string }{
+		{CodeOrphanedSUBM, "@U9@", "Header.Submitter"},
+		{CodeOrphanedSUBN, "@SN9@", "Header.SUBN"},
+	}
+	for i, w := range want {
+		issue := issues[i]
+		if issue.Code != w.code || issue.RelatedXRef != w.xref || issue.Details["field"] != w.field {
+			t.Errorf("issues[%d] = %s (%s, %v), want %s for %s", i, issue.Code, issue.RelatedXRef, issue.Details, w.code, w.xref)
+		}
+		if issue.Severity != SeverityError {
+			t.Errorf("issues[%d].Severity = %v, want error", i, issue.Severity)
+		}
+	}
+
+	// Pointing at existing records clears both issues
+	doc.Header.Submitter = "@U1@"
+	doc.Header.Tags[3].Value = "@SN1@"
+	if err := doc.SetSubmitter(&gedcom.Submitter{XRef: "@U1@", Name: "Jane"}); err != nil {
+		t.Fatal(err)
+	}
+	if issues := NewHeaderValidator().ValidateHeader(doc); len(issues) != 0 {
+		t.Errorf("ValidateHeader() = %v, want no issues", issues)
+	}
+
+	// A SUBN pointer to a record of another type is dangling too
+	doc.Header.Tags[3].Value = "@U1@"
+	issues = NewHeaderValidator().ValidateHeader(doc)
+	if len(issues) != 1 || issues[0].Code != CodeOrphanedSUBN {
+		t.Errorf("ValidateHeader() = %v, want one %s", issues, CodeOrphanedSUBN)
+	}
+}
+
 // contains checks if s contains substr
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || s != "" && containsHelper(s, substr))
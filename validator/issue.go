@@ -59,6 +59,18 @@ const (
 	// CodeUnreasonableParentAge indicates a parent's age at child's birth is implausible.
 	// Used when parent is too young (e.g., <12) or too old (e.g., mother >55, father >90).
 	CodeUnreasonableParentAge = "UNREASONABLE_PARENT_AGE"
+
+	// CodeFutureDate indicates an event or attribute is dated after the
+	// validation's "as of" date.
+	CodeFutureDate = "FUTURE_DATE"
+
+	// CodeChangeBeforeCreation indicates a record's CHAN timestamp precedes
+	// its CREA timestamp.
+	CodeChangeBeforeCreation = "CHANGE_BEFORE_CREATION"
+
+	// CodeFutureCreation indicates a record's CREA date is after the
+	// validation's "as of" date.
+	CodeFutureCreation = "FUTURE_CREATION"
 )
 
 // Error codes for cross-reference validation.
@@ -80,6 +92,14 @@ const (
 
 	// CodeOrphanedSOUR indicates a SOUR reference points to a non-existent source.
 	CodeOrphanedSOUR = "ORPHANED_SOUR"
+
+	// CodeOrphanedSUBM indicates a SUBM reference, in the header or in a
+	// record, points to a non-existent submitter.
+	CodeOrphanedSUBM = "ORPHANED_SUBM"
+
+	// CodeOrphanedSUBN indicates the header's SUBN reference points to a
+	// non-existent submission record.
+	CodeOrphanedSUBN = "ORPHANED_SUBN"
 )
 
 // Error codes for duplicate detection.
@@ -101,6 +121,10 @@ const (
 
 	// CodeNoSources indicates a record has no source citations.
 	CodeNoSources = "NO_SOURCES"
+
+	// CodeIncompleteRecord indicates a record does not meet every requirement
+	// of a completeness profile.
+	CodeIncompleteRecord = "INCOMPLETE_RECORD"
 )
 
 // Error codes for custom tag registry validation.
@@ -123,6 +147,20 @@ const (
 	CodeMissingSUBM = "MISSING_SUBM"
 )
 
+// Error codes for cardinality validation.
+const (
+	// CodeCardinalityExceeded indicates a tag repeated more often than the
+	// grammar of the document's GEDCOM version allows under its parent,
+	// such as a second SEX in an INDI record.
+	CodeCardinalityExceeded = "CARDINALITY_EXCEEDED"
+
+	// CodeMultipleSpouses indicates a family with more than one HUSB or
+	// more than one WIFE line. Some 7.0 and vendor files record same-sex
+	// couples this way, so it is a warning rather than a cardinality
+	// error; use ValidatorConfig.Severity to change it.
+	CodeMultipleSpouses = "MULTIPLE_SPOUSES"
+)
+
 // Error codes for XRef validation.
 const (
 	// CodeXRefTooLong indicates an XRef identifier exceeds the 20-character limit
@@ -141,6 +179,88 @@ const (
 	CodeBannedControlCharacter = "BANNED_CONTROL_CHARACTER"
 )
 
+// Error codes for place validation.
+const (
+	// CodePlaceFormMismatch indicates a place payload whose number of
+	// comma-separated components differs from its declared PLAC.FORM or
+	// HEAD.PLAC.FORM hierarchy.
+	CodePlaceFormMismatch = "PLACE_FORM_MISMATCH"
+
+	// CodeInvalidCoordinate indicates a MAP latitude or longitude that is
+	// missing, not a number, or uses the wrong direction letter.
+	CodeInvalidCoordinate = "INVALID_COORDINATE"
+
+	// CodeCoordinateOutOfRange indicates a latitude outside -90..90 or a
+	// longitude outside -180..180.
+	CodeCoordinateOutOfRange = "COORDINATE_OUT_OF_RANGE"
+
+	// CodeNonstandardCoordinate indicates a readable coordinate not written
+	// in the GEDCOM form, e.g. "n42.36" or "N 42.36" for "N42.36".
+	CodeNonstandardCoordinate = "NONSTANDARD_COORDINATE"
+
+	// CodeZeroCoordinates indicates coordinates of (0, 0), usually left by
+	// a failed geocode.
+	CodeZeroCoordinates = "ZERO_COORDINATES"
+
+	// CodeCoordinateInOcean indicates a land event whose coordinates fall
+	// at sea according to the configured landmask.
+	CodeCoordinateInOcean = "COORDINATE_IN_OCEAN"
+
+	// CodeAddressPlaceMismatch indicates an event whose ADDR city, state,
+	// or country does not appear in its PLAC.
+	CodeAddressPlaceMismatch = "ADDRESS_PLACE_MISMATCH"
+)
+
+// Error codes for language validation.
+const (
+	// CodeInvalidLanguageTag indicates a GEDCOM 7.0 LANG payload that is
+	// not a valid BCP 47 language tag, such as a 5.5 language name.
+	CodeInvalidLanguageTag = "INVALID_LANGUAGE_TAG"
+)
+
+// Error codes for RESN validation.
+const (
+	// CodeInvalidRestriction indicates a RESN value that names none of
+	// confidential, locked, or privacy.
+	CodeInvalidRestriction = "INVALID_RESTRICTION"
+
+	// CodeNonstandardRestriction indicates a recognized RESN value in the
+	// wrong form for the document's version, such as uppercase or a list
+	// in 5.5.1, or lowercase in 7.0.
+	CodeNonstandardRestriction = "NONSTANDARD_RESTRICTION"
+
+	// CodeRestrictionLeak indicates a family that is not confidential
+	// linking a confidential individual, whose data the family exposes.
+	CodeRestrictionLeak = "RESTRICTION_LEAK"
+)
+
+// Error codes for application extension schema validation.
+const (
+	// CodeExtensionInvalidRecord indicates a registered extension appears
+	// under a record type its schema does not allow.
+	CodeExtensionInvalidRecord = "EXTENSION_INVALID_RECORD"
+
+	// CodeExtensionUnknownChild indicates a substructure not declared by
+	// the extension schema.
+	CodeExtensionUnknownChild = "EXTENSION_UNKNOWN_CHILD"
+
+	// CodeExtensionMissingChild indicates a required substructure is absent.
+	CodeExtensionMissingChild = "EXTENSION_MISSING_CHILD"
+
+	// CodeExtensionRepeatedChild indicates a non-repeatable substructure
+	// appears more than once.
+	CodeExtensionRepeatedChild = "EXTENSION_REPEATED_CHILD"
+
+	// CodeExtensionInvalidPayload indicates a payload that does not match
+	// the schema's payload type.
+	CodeExtensionInvalidPayload = "EXTENSION_INVALID_PAYLOAD"
+
+	// CodeUndeclaredExtension indicates an extension tag in a GEDCOM 7.0
+	// document that HEAD.SCHMA does not map to a URI, an undocumented
+	// extension that strict 7.0 checkers reject.
+	CodeUndeclaredExtension = "UNDECLARED_EXTENSION"
+)
+
 // Issue represents a validation finding with severity, context, and actionable information.
 type Issue struct {
 	// Severity indicates the importance level of this issue.
@@ -164,8 +284,32 @@ type Issue struct {
 	// Details contains additional context as key-value pairs.
 	// Common keys include "field", "value", "expected", "actual".
 	Details map[string]string
+
+	// Category groups issues by the kind of check that produced them:
+	// CategoryStructure, CategoryDates, CategoryReferences, or
+	// CategoryQuality for issues from ValidateAll, CategoryLint for
+	// best-practice findings. Empty for issues from the individual
+	// validators.
+	Category string
 }
 
+// Categories of the checks run by ValidateAll; see ValidatorConfig.Categories.
+const (
+	// CategoryStructure covers header, XRef, cardinality, place hierarchy,
+	// language tag, extension, custom tag, and encoding checks.
+	CategoryStructure = "structure"
+
+	// CategoryDates covers chronological date logic checks.
+	CategoryDates = "dates"
+
+	// CategoryReferences covers broken cross-reference checks.
+	CategoryReferences = "references"
+
+	// CategoryQuality covers data quality checks: coordinates, addresses
+	// versus places, and duplicate detection, the most expensive check.
+	CategoryQuality = "quality"
+)
+
 // Error implements the error interface, returning a formatted error string.
 //
 //nolint:gocritic // Value receiver intentional for immutability
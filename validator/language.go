@@ -0,0 +1,95 @@
+// language.go provides GEDCOM 7.0 LANG payload validation.
+//
+// GEDCOM 7.0 requires every LANG payload to be a BCP 47 language tag. Files
+// converted from 5.5 often keep the 5.5 language names ("English"), which
+// 7.0 readers cannot interpret.
+
+package validator
+
+import (
+	"fmt"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// LanguageValidator checks LANG payloads against BCP 47.
+type LanguageValidator struct{}
+
+// NewLanguageValidator creates a new LanguageValidator.
+func NewLanguageValidator() *LanguageValidator {
+	return &LanguageValidator{}
+}
+
+// Validate checks every LANG tag of a GEDCOM 7.0 document, in the header,
+// submitters, notes, names, places, and any other structure. A payload that
+// is not a well-formed BCP 47 tag with registered subtags produces an error
+// with "tag" (the parent structure) and "value" details, and a "suggested"
+// detail when gedcom.NormalizeLanguage can map it ("English" to "en").
+//
+// Documents of other versions are not checked, since 5.5 and 5.5.1 use
+// language names.
+func (l *LanguageValidator) Validate(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	return l.visitor(doc).run(doc)
+}
+
+// visitor returns the per-record form of Validate, with the header's
+// issues first, or nil for documents other than GEDCOM 7.0.
+func (l *LanguageValidator) visitor(doc *gedcom.Document) *recordVisitor {
+	if doc.Header == nil || doc.Header.Version != gedcom.Version70 {
+		return nil
+	}
+
+	var rv *recordVisitor
+	check := func(xref, parent, value string) {
+		if gedcom.IsLanguageTag(value) {
+			return
+		}
+		issue := NewIssue(
+			SeverityError,
+			CodeInvalidLanguageTag,
+			fmt.Sprintf("%s LANG %q is not a BCP 47 language tag", parent, value),
+			xref,
+		).
+			WithDetail("tag", parent).
+			WithDetail("value", value)
+		if suggested, ok := gedcom.NormalizeLanguage(value); ok {
+			issue = issue.WithDetail("suggested", suggested)
+		}
+		rv.add(0, issue)
+	}
+	scan := func(xref, root string, tags []*gedcom.Tag) {
+		var parents []string
+		for _, tag := range tags {
+			if tag == nil || tag.Level < 1 {
+				continue
+			}
+			if len(parents) >= tag.Level {
+				parents = parents[:tag.Level-1]
+			}
+			for len(parents) < tag.Level-1 {
+				parents = append(parents, "")
+			}
+			if tag.Tag == "LANG" {
+				parent := root
+				if len(parents) > 0 && parents[len(parents)-1] != "" {
+					parent = parents[len(parents)-1]
+				}
+				check(xref, parent, tag.Value)
+			}
+			parents = append(parents, tag.Tag)
+		}
+	}
+
+	rv = newRecordVisitor(1, func(_ *recordVisitor, record *gedcom.Record) {
+		scan(record.XRef, string(record.Type), record.Tags)
+	})
+	if len(doc.Header.Tags) > 0 {
+		scan("", "HEAD", doc.Header.Tags)
+	} else if doc.Header.Language != "" {
+		check("", "HEAD", doc.Header.Language)
+	}
+	return rv
+}
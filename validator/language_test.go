@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+)
+
+func TestLanguageValidator_Validate(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+1 LANG English
+0 @U1@ SUBM
+1 NAME Ann Archivist
+1 LANG en-GB
+1 LANG de_DE
+0 @I1@ INDI
+1 NAME Jean /Dupont/
+2 TRAN Jean /Dupont/
+3 LANG Klingon
+1 BIRT
+2 PLAC Paris
+3 LANG fr
+0 @N1@ SNOTE Bonjour
+1 LANG fr-FR
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	issues := NewLanguageValidator().Validate(doc)
+	if len(issues) != 3 {
+		t.Fatalf("Validate() returned %d issues, want 3: %v", len(issues), issues)
+	}
+	want := []struct {
+		xref, tag, value, suggested string
+	}{
+		{"", "HEAD", "English", "en"},
+		{"@U1@", "SUBM", "de_DE", "de-DE"},
+		{"@I1@", "TRAN", "Klingon", ""},
+	}
+	for i, w := range want {
+		issue := issues[i]
+		if issue.Code != CodeInvalidLanguageTag || issue.Severity != SeverityError || issue.RecordXRef != w.xref {
+			t.Errorf("issue %d = %+v", i, issue)
+		}
+		if issue.Details["tag"] != w.tag || issue.Details["value"] != w.value || issue.Details["suggested"] != w.suggested {
+			t.Errorf("issue %d details = %v, want %+v", i, issue.Details, w)
+		}
+	}
+}
+
+func TestLanguageValidator_OnlyVersion70(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n1 LANG English\n0 TRLR\n"
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if issues := NewLanguageValidator().Validate(doc); len(issues) != 0 {
+		t.Errorf("5.5.1 LANG names are valid, got %v", issues)
+	}
+	if NewLanguageValidator().Validate(nil) != nil {
+		t.Error("Validate(nil) should return nil")
+	}
+}
+
+func TestValidator_ValidateLanguages(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 7.0\n1 LANG German\n0 TRLR\n"
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if issues := New().ValidateLanguages(doc); len(issues) != 1 || issues[0].Details["suggested"] != "de" {
+		t.Errorf("ValidateLanguages() = %v", issues)
+	}
+	if issues := New().ValidateAll(doc); len(FilterByCode(issues, CodeInvalidLanguageTag)) != 1 {
+		t.Errorf("ValidateAll() should include language issues, got %v", issues)
+	}
+	if New().ValidateLanguages(nil) != nil {
+		t.Error("ValidateLanguages(nil) should return nil")
+	}
+}
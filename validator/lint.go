@@ -0,0 +1,470 @@
+// lint.go provides an opinionated best-practices analyzer for GEDCOM data.
+//
+// Unlike the validators, which check a document against the specification
+// and against chronological and referential facts, the Linter flags data
+// that is legal but considered poor practice: dates buried in notes, places
+// typed into date fields, names in all capitals, living people without
+// sources, tags that newer GEDCOM versions deprecate, and citations whose
+// EVEN names a different event than the fact they support. Each rule has a
+// code, an explanation, and a default severity that callers can override or
+// disable, much like a golangci-lint configuration.
+
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// CategoryLint marks issues produced by the Linter, as distinct from
+// specification and data validation issues.
+const CategoryLint = "lint"
+
+// Codes for best-practice lint rules.
+const (
+	// CodeLintDateInNote indicates a note that contains a date, which
+	// belongs in the DATE of an event or attribute.
+	CodeLintDateInNote = "LINT_DATE_IN_NOTE"
+
+	// CodeLintPlaceInDate indicates a DATE value containing non-date text,
+	// typically a place typed into the wrong field.
+	CodeLintPlaceInDate = "LINT_PLACE_IN_DATE"
+
+	// CodeLintAllCapsName indicates a given name or surname written in
+	// capital letters.
+	CodeLintAllCapsName = "LINT_ALL_CAPS_NAME"
+
+	// CodeLintUnsourcedLiving indicates a presumed-living individual with no
+	// source citations.
+	CodeLintUnsourcedLiving = "LINT_UNSOURCED_LIVING"
+
+	// CodeLintDeprecatedTag indicates a tag that the document's GEDCOM
+	// version deprecates or replaces.
+	CodeLintDeprecatedTag = "LINT_DEPRECATED_TAG"
+
+	// CodeLintCitationEventMismatch indicates a citation on an event or
+	// attribute whose EVEN names a different type of event.
+	CodeLintCitationEventMismatch = "LINT_CITATION_EVENT_MISMATCH"
+)
+
+// LintRule describes a best-practice rule.
+type LintRule struct {
+	// Code identifies the rule; issues carry it as Issue.Code.
+	Code string
+
+	// Explanation says why the practice is discouraged and what to do
+	// instead. Issues carry it in the "explanation" detail.
+	Explanation string
+
+	// Severity is the rule's default severity.
+	Severity Severity
+}
+
+// lintRules lists the rules in the order they are checked.
+var lintRules = []LintRule{
+	{
+		Code:        CodeLintDateInNote,
+		Explanation: "Dates written in note text cannot be sorted, searched, or checked for consistency; record them as an event or attribute with a DATE.",
+		Severity:    SeverityInfo,
+	},
+	{
+		Code:        CodeLintPlaceInDate,
+		Explanation: "DATE values should hold only a date; move place names and other text to PLAC, NOTE, or a date PHRASE.",
+		Severity:    SeverityWarning,
+	},
+	{
+		Code:        CodeLintAllCapsName,
+		Explanation: "Names in capital letters lose their original capitalization; the surname is already marked by slashes, so applications can capitalize it for display.",
+		Severity:    SeverityInfo,
+	},
+	{
+		Code:        CodeLintUnsourcedLiving,
+		Explanation: "Information about living people should be sourced before it is shared, both for accuracy and for privacy.",
+		Severity:    SeverityWarning,
+	},
+	{
+		Code:        CodeLintDeprecatedTag,
+		Explanation: "Deprecated tags may be dropped or misread by applications that follow the document's GEDCOM version; use the replacement instead.",
+		Severity:    SeverityWarning,
+	},
+	{
+		Code:        CodeLintCitationEventMismatch,
+		Explanation: "A citation's EVEN records which event the source documents; when it differs from the fact being cited, the source supports that fact only indirectly (a burial register cited for a death) or the citation is attached to the wrong fact.",
+		Severity:    SeverityInfo,
+	},
+}
+
+// LintRules returns the best-practice rules with their default severities.
+func LintRules() []LintRule {
+	rules := make([]LintRule, len(lintRules))
+	copy(rules, lintRules)
+	return rules
+}
+
+// LintConfig configures the Linter.
+type LintConfig struct {
+	// Disabled lists rule codes that are not checked.
+	Disabled []string
+
+	// Severity overrides the default severity of rules, by code.
+	Severity map[string]Severity
+
+	// MaxLivingAge is the age beyond which an individual without a death
+	// event is no longer presumed living. Default (0): 100.
+	MaxLivingAge int
+
+	// AsOf is the date used to compute ages. Default (zero value): the time
+	// of linting.
+	AsOf time.Time
+}
+
+// Linter checks documents against best-practice rules.
+type Linter struct {
+	config   *LintConfig
+	rules    map[string]LintRule
+	disabled map[string]bool
+}
+
+// NewLinter creates a Linter with the given configuration.
+// If config is nil, all rules run with their default severities.
+func NewLinter(config *LintConfig) *Linter {
+	if config == nil {
+		config = &LintConfig{}
+	}
+	l := &Linter{
+		config:   config,
+		rules:    make(map[string]LintRule, len(lintRules)),
+		disabled: make(map[string]bool, len(config.Disabled)),
+	}
+	for _, rule := range lintRules {
+		if sev, ok := config.Severity[rule.Code]; ok {
+			rule.Severity = sev
+		}
+		l.rules[rule.Code] = rule
+	}
+	for _, code := range config.Disabled {
+		l.disabled[code] = true
+	}
+	return l
+}
+
+// Lint checks doc against every enabled rule. Issues have Category
+// CategoryLint and an "explanation" detail; they are not filtered by
+// severity.
+func (l *Linter) Lint(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+
+	version := gedcom.Version551
+	if doc.Header != nil && doc.Header.Version != "" {
+		version = doc.Header.Version
+	}
+
+	var issues []Issue
+	report := func(code, message, xref string, details ...string) {
+		if l.disabled[code] {
+			return
+		}
+		rule := l.rules[code]
+		issue := NewIssue(rule.Severity, code, message, xref).WithDetail("explanation", rule.Explanation)
+		for k := 0; k+1 < len(details); k += 2 {
+			issue = issue.WithDetail(details[k], details[k+1])
+		}
+		issue.Category = CategoryLint
+		issues = append(issues, issue)
+	}
+
+	if doc.Header != nil {
+		l.lintDeprecatedTags(report, "", doc.Header.Tags, version)
+	}
+	for _, record := range doc.Records {
+		if record == nil {
+			continue
+		}
+		switch entity := record.Entity.(type) {
+		case *gedcom.Individual:
+			l.lintNotes(report, entity.XRef, entity.InlineNotes)
+			l.lintNames(report, entity)
+			l.lintDates(report, entity.XRef, entity.Events, entity.Attributes)
+			l.lintCitationEvents(report, entity.XRef, entity.Events, entity.Attributes)
+			l.lintUnsourcedLiving(report, entity)
+		case *gedcom.Family:
+			l.lintNotes(report, entity.XRef, entity.InlineNotes)
+			l.lintDates(report, entity.XRef, entity.Events, nil)
+			l.lintCitationEvents(report, entity.XRef, entity.Events, nil)
+		case *gedcom.Note:
+			l.lintNotes(report, entity.XRef, []string{entity.FullText()})
+		}
+		if record.Type == "SUBN" && !version.Before(gedcom.Version70) {
+			report(CodeLintDeprecatedTag, "SUBN records are not part of GEDCOM 7.0", record.XRef, "tag", "SUBN")
+		}
+		l.lintDeprecatedTags(report, record.XRef, record.Tags, version)
+	}
+	return issues
+}
+
+// lintReporter records a lint issue with optional key/value details,
+// unless the rule is disabled.
+type lintReporter func(code, message, xref string, details ...string)
+
+// noteDatePattern matches day-month-year and month-year dates such as
+// "12 Mar 1850", "March 1850", and numeric dates such as "12/03/1850".
+var noteDatePattern = regexp.MustCompile(`(?i)\b(?:\d{1,2}\s+)?(?:jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sept?(?:ember)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)\.?\s+\d{3,4}\b|\b\d{1,2}[/.-]\d{1,2}[/.-]\d{4}\b`)
+
+// lintNotes flags notes that contain dates.
+func (l *Linter) lintNotes(report lintReporter, xref string, notes []string) {
+	for _, note := range notes {
+		if match := noteDatePattern.FindString(note); match != "" {
+			report(CodeLintDateInNote, fmt.Sprintf("note contains the date %q", match), xref, "date", match)
+		}
+	}
+}
+
+// dateWords are the words that may appear in a GEDCOM date value.
+var dateWords = map[string]bool{
+	"abt": true, "cal": true, "est": true, "bef": true, "aft": true, "bet": true,
+	"and": true, "from": true, "to": true, "int": true, "bc": true, "b.c.": true,
+	"bce": true,
+}
+
+// lintDates flags event and attribute dates that contain non-date text.
+func (l *Linter) lintDates(report lintReporter, xref string, events []*gedcom.Event, attrs []*gedcom.Attribute) {
+	check := func(tag, date string, parsed *gedcom.Date) {
+		if date == "" || parsed != nil && !strings.Contains(date, ",") {
+			return
+		}
+		if hasNonDateText(date) {
+			report(CodeLintPlaceInDate, fmt.Sprintf("%s date %q contains non-date text", tag, date), xref,
+				"tag", tag, "date", date)
+		}
+	}
+	for _, event := range events {
+		if event != nil {
+			check(string(event.Type), event.Date, event.ParsedDate)
+		}
+	}
+	for _, attr := range attrs {
+		if attr != nil {
+			check(attr.Type, attr.Date, attr.ParsedDate)
+		}
+	}
+}
+
+// hasNonDateText reports whether s, outside any parenthesized date
+// phrase, contains a comma or a word that is neither a month, a calendar
+// escape, nor a date keyword.
+func hasNonDateText(s string) bool {
+	if open := strings.Index(s, "("); open >= 0 {
+		s = s[:open]
+	}
+	if strings.Contains(s, ",") {
+		return true
+	}
+	for _, word := range strings.Fields(s) {
+		word = strings.ToLower(word)
+		if dateWords[word] || strings.HasPrefix(word, "@#d") || !unicode.IsLetter(rune(word[0])) {
+			continue
+		}
+		if monthNames[word] {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// monthNames are the Gregorian month abbreviations and names accepted in
+// dates.
+var monthNames = map[string]bool{
+	"jan": true, "feb": true, "mar": true, "apr": true, "may": true, "jun": true,
+	"jul": true, "aug": true, "sep": true, "oct": true, "nov": true, "dec": true,
+	"january": true, "february": true, "march": true, "april": true, "june": true,
+	"july": true, "august": true, "september": true, "october": true,
+	"november": true, "december": true,
+}
+
+// lintNames flags given names and surnames written in capitals.
+func (l *Linter) lintNames(report lintReporter, ind *gedcom.Individual) {
+	for _, name := range ind.Names {
+		if name == nil {
+			continue
+		}
+		given, surname := name.Given, name.Surname
+		if given == "" && surname == "" {
+			given, surname = splitFullName(name.Full)
+		}
+		for _, part := range []struct{ field, value string }{{"given", given}, {"surname", surname}} {
+			if isAllCaps(part.value) {
+				report(CodeLintAllCapsName, fmt.Sprintf("%s %q is in capital letters", part.field, part.value), ind.XRef,
+					"field", part.field, "value", part.value)
+			}
+		}
+	}
+}
+
+// splitFullName returns the given and surname parts of a NAME payload
+// ("John /Smith/").
+func splitFullName(full string) (given, surname string) {
+	start := strings.Index(full, "/")
+	if start < 0 {
+		return strings.TrimSpace(full), ""
+	}
+	given = strings.TrimSpace(full[:start])
+	rest := full[start+1:]
+	if end := strings.Index(rest, "/"); end >= 0 {
+		surname = strings.TrimSpace(rest[:end])
+	}
+	return given, surname
+}
+
+// isAllCaps reports whether s has at least three letters and none in lower
+// case. Short initialisms such as "JR" or "J" are not flagged.
+func isAllCaps(s string) bool {
+	letters := 0
+	for _, r := range s {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsUpper(r) {
+			letters++
+		}
+	}
+	return letters >= 3
+}
+
+// endOfLifeEvents are events that show an individual is no longer living.
+var endOfLifeEvents = map[gedcom.EventType]bool{
+	gedcom.EventDeath:     true,
+	gedcom.EventBurial:    true,
+	gedcom.EventCremation: true,
+	gedcom.EventProbate:   true,
+}
+
+// lintUnsourcedLiving flags presumed-living individuals without any source
+// citation. An individual is presumed living if they have no death,
+// burial, cremation, or probate event and either no birth year or a
+// birth year within MaxLivingAge years of AsOf.
+func (l *Linter) lintUnsourcedLiving(report lintReporter, ind *gedcom.Individual) {
+	birthYear, ok := unsourcedBirthYear(ind)
+	if !ok {
+		return
+	}
+
+	maxAge := l.config.MaxLivingAge
+	if maxAge <= 0 {
+		maxAge = 100
+	}
+	asOf := l.config.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	if birthYear != 0 && asOf.Year()-birthYear > maxAge {
+		return
+	}
+	report(CodeLintUnsourcedLiving, "presumed-living individual has no source citations", ind.XRef)
+}
+
+// unsourcedBirthYear returns the birth year of ind, 0 if unknown, and
+// whether ind has no end-of-life event and no source citation on the
+// individual or any of its events and attributes.
+func unsourcedBirthYear(ind *gedcom.Individual) (int, bool) {
+	if len(ind.SourceCitations) > 0 {
+		return 0, false
+	}
+	birthYear := 0
+	for _, event := range ind.Events {
+		if event == nil {
+			continue
+		}
+		if endOfLifeEvents[event.Type] && !event.IsNegative || len(event.SourceCitations) > 0 {
+			return 0, false
+		}
+		if event.Type == gedcom.EventBirth && event.ParsedDate != nil && birthYear == 0 {
+			birthYear = event.ParsedDate.Year
+		}
+	}
+	for _, attr := range ind.Attributes {
+		if attr != nil && len(attr.SourceCitations) > 0 {
+			return 0, false
+		}
+	}
+	return birthYear, true
+}
+
+// lintCitationEvents flags citations of events and attributes whose EVEN
+// names another event type. Generic EVEN and FACT facts are not checked,
+// since their type is free text.
+func (l *Linter) lintCitationEvents(report lintReporter, xref string, events []*gedcom.Event, attrs []*gedcom.Attribute) {
+	check := func(tag string, citations []*gedcom.SourceCitation) {
+		if tag == string(gedcom.EventGeneric) || tag == "FACT" {
+			return
+		}
+		for _, cite := range citations {
+			if cite == nil || cite.Event == "" || strings.EqualFold(strings.TrimSpace(cite.Event), tag) {
+				continue
+			}
+			details := []string{"tag", tag, "event", cite.Event}
+			if cite.Role != "" {
+				details = append(details, "role", cite.Role)
+			}
+			report(CodeLintCitationEventMismatch, fmt.Sprintf("%s citation documents a %s event", tag, cite.Event), xref, details...)
+		}
+	}
+	for _, event := range events {
+		if event != nil {
+			check(string(event.Type), event.SourceCitations)
+		}
+	}
+	for _, attr := range attrs {
+		if attr != nil {
+			check(attr.Type, attr.SourceCitations)
+		}
+	}
+}
+
+// deprecation describes a tag that a GEDCOM version deprecates.
+type deprecation struct {
+	since       gedcom.Version
+	replacement string
+}
+
+// deprecatedTags maps non-standard tags to the version that deprecates
+// them and their replacement. Standard tags come from
+// gedcom.LookupStandardTag; SUBN records are handled separately.
+var deprecatedTags = map[string]deprecation{
+	"EMAI":   {gedcom.Version551, "EMAIL"},
+	"_EMAIL": {gedcom.Version551, "EMAIL"},
+	"_UID":   {gedcom.Version70, "UID"},
+	"_SDATE": {gedcom.Version70, "SDATE"},
+}
+
+// lookupDeprecation returns the deprecation of tag: a vendor entry of
+// deprecatedTags, or a standard tag that a later version replaces.
+func lookupDeprecation(tag string) (deprecation, bool) {
+	if dep, ok := deprecatedTags[tag]; ok {
+		return dep, true
+	}
+	info, ok := gedcom.LookupStandardTag(tag)
+	if !ok || info.DeprecatedIn == "" || info.Replacement == "" {
+		return deprecation{}, false
+	}
+	return deprecation{info.DeprecatedIn, info.Replacement}, true
+}
+
+// lintDeprecatedTags flags raw tags deprecated in version.
+func (l *Linter) lintDeprecatedTags(report lintReporter, xref string, tags []*gedcom.Tag, version gedcom.Version) {
+	for _, tag := range tags {
+		dep, ok := lookupDeprecation(tag.Tag)
+		if !ok || version.Before(dep.since) {
+			continue
+		}
+		report(CodeLintDeprecatedTag, fmt.Sprintf("%s is deprecated in GEDCOM %s; use %s", tag.Tag, dep.since, dep.replacement), xref,
+			"tag", tag.Tag, "replacement", dep.replacement, "line", fmt.Sprintf("%d", tag.LineNumber))
+	}
+}
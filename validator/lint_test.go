@@ -0,0 +1,260 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+const lintTestGEDCOM = `0 HEAD
+1 GEDC
+2 VERS 7.0
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME John /SMITH/
+1 BIRT
+2 DATE 1990
+1 RESI
+2 DATE 2010 Boston, Mass
+1 NOTE Baptized 3 March 1851 at St Paul's
+1 _UID 1234
+0 @I2@ INDI
+1 NAME Mary /Jones/
+1 BIRT
+2 DATE ABT 1800
+2 SOUR @S1@
+1 RESI
+2 DATE (the summer before the war)
+1 ROMN Mary Jones
+0 @N1@ NOTE Moved to Ohio in Sept 1870
+0 @S1@ SOUR
+1 TITL Parish register
+0 TRLR
+`
+
+func decodeLintDoc(t *testing.T) *gedcom.Document {
+	t.Helper()
+	doc, err := decoder.Decode(strings.NewReader(lintTestGEDCOM))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	return doc
+}
+
+func TestLinter_Lint(t *testing.T) {
+	linter := NewLinter(&LintConfig{AsOf: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)})
+	issues := linter.Lint(decodeLintDoc(t))
+
+	want := map[string][]string{
+		CodeLintDateInNote:      {"@I1@", "@N1@"},
+		CodeLintPlaceInDate:     {"@I1@"},
+		CodeLintAllCapsName:     {"@I1@"},
+		CodeLintUnsourcedLiving: {"@I1@"},
+		CodeLintDeprecatedTag:   {"@I1@", "@I2@"},
+	}
+	got := make(map[string][]string)
+	for _, issue := range issues {
+		got[issue.Code] = append(got[issue.Code], issue.RecordXRef)
+		if issue.Category != CategoryLint {
+			t.Errorf("%s: Category = %q, want %q", issue.Code, issue.Category, CategoryLint)
+		}
+		if issue.Details["explanation"] == "" {
+			t.Errorf("%s: missing explanation", issue.Code)
+		}
+	}
+	for code, xrefs := range want {
+		if strings.Join(got[code], " ") != strings.Join(xrefs, " ") {
+			t.Errorf("%s reported for %v, want %v", code, got[code], xrefs)
+		}
+	}
+	if len(issues) != 7 {
+		t.Errorf("got %d issues, want 7: %v", len(issues), issues)
+	}
+
+	for _, issue := range FilterByCode(issues, CodeLintDeprecatedTag) {
+		if issue.RecordXRef == "@I1@" && issue.Details["replacement"] != "UID" {
+			t.Errorf("_UID replacement = %q, want UID", issue.Details["replacement"])
+		}
+	}
+	if issue := FilterByCode(issues, CodeLintAllCapsName)[0]; issue.Details["value"] != "SMITH" || issue.Severity != SeverityInfo {
+		t.Errorf("all-caps issue = %+v", issue)
+	}
+}
+
+func TestLinter_Config(t *testing.T) {
+	linter := NewLinter(&LintConfig{
+		Disabled: []string{CodeLintDateInNote, CodeLintUnsourcedLiving},
+		Severity: map[string]Severity{CodeLintAllCapsName: SeverityError},
+	})
+	issues := linter.Lint(decodeLintDoc(t))
+	if len(FilterByCode(issues, CodeLintDateInNote)) != 0 || len(FilterByCode(issues, CodeLintUnsourcedLiving)) != 0 {
+		t.Error("disabled rules were reported")
+	}
+	caps := FilterByCode(issues, CodeLintAllCapsName)
+	if len(caps) != 1 || caps[0].Severity != SeverityError {
+		t.Errorf("severity override not applied: %v", caps)
+	}
+}
+
+func TestLinter_DeprecatedTagsByVersion(t *testing.T) {
+	doc := decodeLintDoc(t)
+	doc.Header.Version = gedcom.Version551
+	if issues := FilterByCode(NewLinter(nil).Lint(doc), CodeLintDeprecatedTag); len(issues) != 0 {
+		t.Errorf("5.5.1 document: got %v, want no 7.0 deprecations", issues)
+	}
+}
+
+func TestLinter_DeprecatedStandardTags(t *testing.T) {
+	doc, err := decoder.Decode(strings.NewReader(`0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 ASSO @I2@
+2 RELA Godfather
+0 @I2@ INDI
+0 TRLR
+`))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	issues := FilterByCode(NewLinter(nil).Lint(doc), CodeLintDeprecatedTag)
+	if len(issues) != 1 || issues[0].Details["tag"] != "RELA" || issues[0].Details["replacement"] != "ROLE" {
+		t.Errorf("got %v, want RELA replaced by ROLE", issues)
+	}
+}
+
+func TestLinter_CitationEventMismatch(t *testing.T) {
+	doc, err := decoder.Decode(strings.NewReader(`0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 BIRT
+2 SOUR @S1@
+3 EVEN BIRT
+2 SOUR @S1@
+3 EVEN CHR
+4 ROLE CHIL
+1 DEAT
+2 SOUR @S1@
+1 OCCU Miller
+2 SOUR @S1@
+3 EVEN CENS
+0 @F1@ FAM
+1 MARR
+2 SOUR @S1@
+3 EVEN marr
+0 @S1@ SOUR
+1 TITL Parish register
+0 TRLR
+`))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	issues := FilterByCode(NewLinter(nil).Lint(doc), CodeLintCitationEventMismatch)
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues, want 2: %v", len(issues), issues)
+	}
+	if d := issues[0].Details; d["tag"] != "BIRT" || d["event"] != "CHR" || d["role"] != "CHIL" {
+		t.Errorf("first issue details = %v", d)
+	}
+	if d := issues[1].Details; d["tag"] != "OCCU" || d["event"] != "CENS" || d["role"] != "" {
+		t.Errorf("second issue details = %v", d)
+	}
+	if issues[0].Severity != SeverityInfo || issues[0].RecordXRef != "@I1@" {
+		t.Errorf("issue = %+v", issues[0])
+	}
+}
+
+func TestLinter_UnsourcedLivingAge(t *testing.T) {
+	ind := makeIndividual("@I1@", 1900, 0)
+	doc := makeDocument([]*gedcom.Individual{ind}, nil)
+	asOf := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if issues := NewLinter(&LintConfig{AsOf: asOf}).Lint(doc); len(FilterByCode(issues, CodeLintUnsourcedLiving)) != 0 {
+		t.Error("individual born 126 years ago presumed living")
+	}
+	if issues := NewLinter(&LintConfig{AsOf: asOf, MaxLivingAge: 130}).Lint(doc); len(FilterByCode(issues, CodeLintUnsourcedLiving)) != 1 {
+		t.Error("MaxLivingAge not applied")
+	}
+}
+
+func TestHasNonDateText(t *testing.T) {
+	tests := map[string]bool{
+		"12 MAR 1850":              false,
+		"ABT 1850":                 false,
+		"BET 1850 AND 1860":        false,
+		"March 1850":               false,
+		"@#DJULIAN@ 1700":          false,
+		"INT 1850 (in Boston, MA)": false,
+		"1850 Boston":              true,
+		"12 Mar 1850, Ohio":        true,
+		"married 1850":             true,
+	}
+	for date, want := range tests {
+		if got := hasNonDateText(date); got != want {
+			t.Errorf("hasNonDateText(%q) = %v, want %v", date, got, want)
+		}
+	}
+}
+
+func TestIsAllCaps(t *testing.T) {
+	tests := map[string]bool{
+		"SMITH":        true,
+		"Smith":        false,
+		"JR":           false,
+		"":             false,
+		"VAN DER BERG": true,
+		"McDONALD":     false,
+	}
+	for s, want := range tests {
+		if got := isAllCaps(s); got != want {
+			t.Errorf("isAllCaps(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestValidator_Lint(t *testing.T) {
+	doc := decodeLintDoc(t)
+
+	// Normal strictness hides Info findings (dates in notes, all-caps names).
+	issues := NewWithConfig(&ValidatorConfig{Strictness: StrictnessNormal}).Lint(doc)
+	for _, issue := range issues {
+		if issue.Severity == SeverityInfo {
+			t.Errorf("Info issue %s not filtered", issue.Code)
+		}
+	}
+	if len(issues) == 0 {
+		t.Error("expected warnings")
+	}
+
+	strict := NewWithConfig(&ValidatorConfig{
+		Strictness: StrictnessStrict,
+		Lint:       &LintConfig{Disabled: []string{CodeLintDeprecatedTag}},
+	}).Lint(doc)
+	if len(FilterByCode(strict, CodeLintDateInNote)) != 2 || len(FilterByCode(strict, CodeLintDeprecatedTag)) != 0 {
+		t.Errorf("strict lint = %v", strict)
+	}
+
+	for _, issue := range New().ValidateAll(doc) {
+		if issue.Category == CategoryLint {
+			t.Errorf("ValidateAll returned lint issue %s", issue.Code)
+		}
+	}
+	if New().Lint(nil) != nil {
+		t.Error("Lint(nil) should return nil")
+	}
+}
+
+func TestLintRules(t *testing.T) {
+	rules := LintRules()
+	if len(rules) != 6 {
+		t.Fatalf("LintRules() returned %d rules, want 6", len(rules))
+	}
+	rules[0].Severity = SeverityError
+	if LintRules()[0].Severity == SeverityError {
+		t.Error("LintRules() exposes the internal table")
+	}
+}
@@ -0,0 +1,136 @@
+// place_form.go provides place hierarchy (FORM) consistency validation.
+//
+// A PLAC payload lists jurisdictions from smallest to largest, separated by
+// commas. When a place hierarchy is declared, either for the whole file in
+// HEAD.PLAC.FORM or for a single place in PLAC.FORM, each payload should
+// have exactly one component per FORM jurisdiction, using empty components
+// for unknown levels (", Cache, Utah, USA").
+
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// PlaceFormValidator checks place payloads against their declared hierarchy.
+type PlaceFormValidator struct{}
+
+// NewPlaceFormValidator creates a new PlaceFormValidator.
+func NewPlaceFormValidator() *PlaceFormValidator {
+	return &PlaceFormValidator{}
+}
+
+// Validate checks the places of individual events, individual attributes,
+// and family events. Each place is compared with its own PLAC.FORM if
+// present, otherwise with HEAD.PLAC.FORM; places with no applicable form
+// are skipped.
+//
+// Each mismatch produces a warning whose "normalized" detail holds the
+// payload rewritten by NormalizePlaceToForm.
+func (p *PlaceFormValidator) Validate(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	return p.visitor(doc).run(doc)
+}
+
+// visitor returns the per-record form of Validate: individuals, then
+// families.
+func (p *PlaceFormValidator) visitor(doc *gedcom.Document) *recordVisitor {
+	defaultForm := ""
+	if doc.Header != nil {
+		defaultForm = doc.Header.PlaceForm
+	}
+
+	var rv *recordVisitor
+	check := func(phase int, xref, tag, place, form string) {
+		if form == "" {
+			form = defaultForm
+		}
+		if place == "" || form == "" {
+			return
+		}
+		expected := len(strings.Split(form, ","))
+		actual := len(strings.Split(place, ","))
+		if expected == actual {
+			return
+		}
+		rv.add(phase, NewIssue(
+			SeverityWarning,
+			CodePlaceFormMismatch,
+			fmt.Sprintf("%s place %q has %d components but FORM %q declares %d", tag, place, actual, form, expected),
+			xref,
+		).
+			WithDetail("tag", tag).
+			WithDetail("place", place).
+			WithDetail("form", form).
+			WithDetail("expected", fmt.Sprintf("%d", expected)).
+			WithDetail("actual", fmt.Sprintf("%d", actual)).
+			WithDetail("normalized", NormalizePlaceToForm(place, form)))
+	}
+	checkEvents := func(phase int, xref string, events []*gedcom.Event) {
+		for _, event := range events {
+			if event == nil {
+				continue
+			}
+			form := ""
+			if event.PlaceDetail != nil {
+				form = event.PlaceDetail.Form
+			}
+			check(phase, xref, string(event.Type), event.Place, form)
+		}
+	}
+
+	rv = newRecordVisitor(2, func(_ *recordVisitor, record *gedcom.Record) {
+		if ind, ok := recordIndividual(record); ok {
+			checkEvents(0, ind.XRef, ind.Events)
+			for _, attr := range ind.Attributes {
+				if attr != nil {
+					check(0, ind.XRef, attr.Type, attr.Place, "")
+				}
+			}
+		}
+		if fam, ok := recordFamily(record); ok {
+			checkEvents(1, fam.XRef, fam.Events)
+		}
+	})
+	return rv
+}
+
+// NormalizePlaceToForm rewrites place so that it has one component per
+// jurisdiction in form. A place with too few components is padded with
+// empty leading components, on the assumption that the larger
+// jurisdictions were recorded and the smaller ones omitted
+// ("Utah, USA" → ", , Utah, USA" for a four-level form). A place with too
+// many components has its leading components merged into the first, joined
+// by spaces ("12 Main St, Logan, Cache, Utah, USA" → "12 Main St Logan,
+// Cache, Utah, USA").
+//
+// Components are trimmed and rejoined with ", ". The place is returned
+// unchanged if it already matches form, or if either string is empty.
+func NormalizePlaceToForm(place, form string) string {
+	if place == "" || form == "" {
+		return place
+	}
+	expected := len(strings.Split(form, ","))
+	parts := strings.Split(place, ",")
+	if len(parts) == expected {
+		return place
+	}
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	if len(parts) < expected {
+		padded := make([]string, expected-len(parts), expected)
+		parts = append(padded, parts...)
+	} else {
+		extra := len(parts) - expected
+		merged := strings.Join(strings.Fields(strings.Join(parts[:extra+1], " ")), " ")
+		parts = append([]string{merged}, parts[extra+1:]...)
+	}
+	return strings.Join(parts, ", ")
+}
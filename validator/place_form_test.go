@@ -0,0 +1,130 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+)
+
+func TestPlaceFormValidator_Validate(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 PLAC
+2 FORM City, County, State, Country
+0 @I1@ INDI
+1 NAME John /Doe/
+1 BIRT
+2 PLAC Logan, Cache, Utah, USA
+1 DEAT
+2 PLAC Utah, USA
+1 RESI
+2 PLAC Logan, Utah
+3 FORM City, State
+1 OCCU Farmer
+2 PLAC 12 Main St, Logan, Cache, Utah, USA
+0 @F1@ FAM
+1 HUSB @I1@
+1 MARR
+2 PLAC , Cache, Utah, USA
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	issues := NewPlaceFormValidator().Validate(doc)
+	if len(issues) != 2 {
+		t.Fatalf("Validate() returned %d issues, want 2: %v", len(issues), issues)
+	}
+
+	deat := issues[0]
+	if deat.Code != CodePlaceFormMismatch || deat.Severity != SeverityWarning || deat.RecordXRef != "@I1@" {
+		t.Errorf("DEAT issue = %+v", deat)
+	}
+	if deat.Details["tag"] != "DEAT" || deat.Details["expected"] != "4" || deat.Details["actual"] != "2" {
+		t.Errorf("DEAT details = %v", deat.Details)
+	}
+	if deat.Details["normalized"] != ", , Utah, USA" {
+		t.Errorf("DEAT normalized = %q", deat.Details["normalized"])
+	}
+
+	occu := issues[1]
+	if occu.Details["tag"] != "OCCU" || occu.Details["normalized"] != "12 Main St Logan, Cache, Utah, USA" {
+		t.Errorf("OCCU issue details = %v", occu.Details)
+	}
+}
+
+func TestPlaceFormValidator_NoForm(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 BIRT
+2 PLAC Utah, USA
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if issues := NewPlaceFormValidator().Validate(doc); len(issues) != 0 {
+		t.Errorf("Validate() without FORM = %v, want none", issues)
+	}
+	if issues := NewPlaceFormValidator().Validate(nil); issues != nil {
+		t.Errorf("Validate(nil) = %v, want nil", issues)
+	}
+}
+
+func TestNormalizePlaceToForm(t *testing.T) {
+	tests := []struct {
+		place, form, want string
+	}{
+		{"Logan, Cache, Utah, USA", "City, County, State, Country", "Logan, Cache, Utah, USA"},
+		{"Utah,USA", "City, County, State, Country", ", , Utah, USA"},
+		{"A, B, C", "City, Country", "A B, C"},
+		{"Logan", "", "Logan"},
+		{"", "City, Country", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizePlaceToForm(tt.place, tt.form); got != tt.want {
+			t.Errorf("NormalizePlaceToForm(%q, %q) = %q, want %q", tt.place, tt.form, got, tt.want)
+		}
+	}
+}
+
+func TestValidator_ValidatePlaceForms(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+1 PLAC
+2 FORM City, Country
+0 @I1@ INDI
+1 BIRT
+2 PLAC Logan
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	v := New()
+	if issues := v.ValidatePlaceForms(doc); len(issues) != 1 {
+		t.Errorf("ValidatePlaceForms() = %v, want 1 issue", issues)
+	}
+	found := false
+	for _, issue := range v.ValidateAll(doc) {
+		if issue.Code == CodePlaceFormMismatch {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("ValidateAll() should include place form issues")
+	}
+	if v.ValidatePlaceForms(nil) != nil {
+		t.Error("ValidatePlaceForms(nil) should return nil")
+	}
+}
@@ -248,6 +248,9 @@ func (a *QualityAnalyzer) runDuplicateDetector(doc *gedcom.Document, report *Qua
 	for _, pair := range pairs {
 		report.DuplicateIssues = append(report.DuplicateIssues, pair.ToIssue())
 	}
+	for _, pair := range a.duplicates.FindDuplicateFamilies(doc) {
+		report.DuplicateIssues = append(report.DuplicateIssues, pair.ToIssue())
+	}
 }
 
 // runTagValidator runs custom tag validation if a tag validator is configured.
@@ -3,7 +3,9 @@
 //
 // This module validates that all cross-references in a GEDCOM document point to
 // existing records. It provides granular detection for different reference types:
-// FAMC (child-in-family), FAMS (spouse-in-family), HUSB, WIFE, CHIL, and SOUR.
+// FAMC (child-in-family), FAMS (spouse-in-family), HUSB, WIFE, CHIL, SOUR, and
+// SUBM (submitter) references made by records. The header's own SUBM and SUBN
+// references are checked by HeaderValidator.
 
 package validator
 
@@ -34,6 +36,9 @@ const (
 
 	// RefTypeSOUR is a source reference (SourceCitation.SourceXRef).
 	RefTypeSOUR ReferenceType = "SOUR"
+
+	// RefTypeSUBM is a submitter reference (a SUBM pointer in a record's tags).
+	RefTypeSUBM ReferenceType = "SUBM"
 )
 
 // ReferenceValidator provides typed validation of cross-references in GEDCOM documents.
@@ -54,21 +59,59 @@ func (v *ReferenceValidator) Validate(doc *gedcom.Document) []Issue {
 		return nil
 	}
 
-	var issues []Issue
+	return v.visitor(doc).run(doc)
+}
 
-	// Check individual references
-	for _, ind := range doc.Individuals() {
-		issues = append(issues, v.checkIndividualReferences(doc, ind)...)
-	}
+// visitor returns the per-record form of Validate: individual references,
+// then family references, then the SUBM references of every record.
+func (v *ReferenceValidator) visitor(doc *gedcom.Document) *recordVisitor {
+	return newRecordVisitor(3, func(rv *recordVisitor, record *gedcom.Record) {
+		if ind, ok := recordIndividual(record); ok {
+			rv.add(0, v.checkIndividualReferences(doc, ind)...)
+		}
+		if fam, ok := recordFamily(record); ok {
+			rv.add(1, v.checkFamilyReferences(doc, fam)...)
+		}
+		rv.add(2, v.checkSubmitterReferences(doc, record)...)
+	})
+}
 
-	// Check family references
-	for _, fam := range doc.Families() {
-		issues = append(issues, v.checkFamilyReferences(doc, fam)...)
+// checkSubmitterReferences validates the SUBM pointers in a record's tags,
+// such as the submitters of an individual, a family, or a submission record.
+// Submitter links are not parsed into typed fields, so the raw tags are used.
+func (v *ReferenceValidator) checkSubmitterReferences(doc *gedcom.Document, record *gedcom.Record) []Issue {
+	var issues []Issue
+	for i, xref := range submitterPointers(record) {
+		if doc.GetSubmitter(xref) == nil {
+			issue := NewIssue(
+				SeverityError,
+				CodeOrphanedSUBM,
+				fmt.Sprintf("SUBM reference to non-existent submitter %s", xref),
+				record.XRef,
+			).WithRelatedXRef(xref).
+				WithDetail("reference_type", string(RefTypeSUBM)).
+				WithDetail("field", fmt.Sprintf("SUBM[%d]", i))
+			issues = append(issues, issue)
+		}
 	}
-
 	return issues
 }
 
+// submitterPointers returns the values of the SUBM pointer tags of record,
+// at any level, in order.
+func submitterPointers(record *gedcom.Record) []string {
+	if record == nil {
+		return nil
+	}
+	var xrefs []string
+	for _, tag := range record.Tags {
+		if tag != nil && tag.Tag == "SUBM" && gedcom.IsPointerXRef(tag.Value) {
+			xrefs = append(xrefs, tag.Value)
+		}
+	}
+	return xrefs
+}
+
 // checkIndividualReferences validates all cross-references within an individual record.
 // This includes FAMC (child-in-family), FAMS (spouse-in-family), and SOUR references.
 func (v *ReferenceValidator) checkIndividualReferences(doc *gedcom.Document, ind *gedcom.Individual) []Issue {
@@ -166,6 +209,9 @@ func (v *ReferenceValidator) checkFamilyReferences(doc *gedcom.Document, fam *ge
 		}
 	}
 
+	// Check further HUSB and WIFE lines (same-sex or repeated spouses)
+	issues = append(issues, v.checkFamilySpouses(doc, fam)...)
+
 	// Check CHIL references
 	for i, childXRef := range fam.Children {
 		if childXRef == "" {
@@ -187,6 +233,43 @@ func (v *ReferenceValidator) checkFamilyReferences(doc *gedcom.Document, fam *ge
 	return issues
 }
 
+// checkFamilySpouses validates the HUSB and WIFE lines of a family beyond
+// the ones its Husband and Wife stand for.
+func (v *ReferenceValidator) checkFamilySpouses(doc *gedcom.Document, fam *gedcom.Family) []Issue {
+	var issues []Issue
+	husbandSeen, wifeSeen := false, false
+	for i, spouse := range fam.Spouses {
+		if spouse == nil {
+			continue
+		}
+		code, refType := CodeOrphanedHUSB, RefTypeHUSB
+		switch {
+		case spouse.Tag == "HUSB" && !husbandSeen:
+			husbandSeen = true
+			continue
+		case spouse.Tag == "WIFE" && !wifeSeen:
+			wifeSeen = true
+			continue
+		case spouse.XRef == "", spouse.Tag != "HUSB" && spouse.Tag != "WIFE":
+			continue
+		case spouse.Tag == "WIFE":
+			code, refType = CodeOrphanedWIFE, RefTypeWIFE
+		}
+		if doc.GetIndividual(spouse.XRef) == nil {
+			issue := NewIssue(
+				SeverityError,
+				code,
+				fmt.Sprintf("%s reference to non-existent individual %s", spouse.Tag, spouse.XRef),
+				fam.XRef,
+			).WithRelatedXRef(spouse.XRef).
+				WithDetail("reference_type", string(refType)).
+				WithDetail("field", fmt.Sprintf("Spouses[%d]", i))
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
 // ReferenceReport provides statistics about cross-references in a document.
 type ReferenceReport struct {
 	// TotalReferences is the total count of all cross-references found.
@@ -199,7 +282,7 @@ type ReferenceReport struct {
 	OrphanedReferences int
 
 	// ByType contains counts broken down by reference type.
-	// Keys are ReferenceType values (FAMC, FAMS, HUSB, WIFE, CHIL, SOUR).
+	// Keys are ReferenceType values (FAMC, FAMS, HUSB, WIFE, CHIL, SOUR, SUBM).
 	// Values are counts for that reference type.
 	ByType map[string]int
 
@@ -229,6 +312,20 @@ func (v *ReferenceValidator) Report(doc *gedcom.Document) *ReferenceReport {
 		v.countFamilyReferences(doc, fam, report)
 	}
 
+	// Count and validate SUBM references
+	for _, record := range doc.Records {
+		for _, xref := range submitterPointers(record) {
+			report.TotalReferences++
+			report.ByType[string(RefTypeSUBM)]++
+			if doc.GetSubmitter(xref) == nil {
+				report.OrphanedReferences++
+				report.OrphanedByType[string(RefTypeSUBM)]++
+			} else {
+				report.ValidReferences++
+			}
+		}
+	}
+
 	return report
 }
 
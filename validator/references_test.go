@@ -259,6 +259,32 @@ func TestReferenceValidatorValidate_OrphanedWIFE(t *testing.T) {
 	}
 }
 
+func TestReferenceValidatorValidate_OrphanedExtraSpouse(t *testing.T) {
+	v := NewReferenceValidator()
+	doc := newTestDocument()
+	addIndividual(doc, &gedcom.Individual{XRef: "@I1@"})
+
+	fam := &gedcom.Family{
+		XRef:    "@F1@",
+		Husband: "@I1@",
+		Spouses: []*gedcom.FamilySpouse{
+			{XRef: "@I1@", Tag: "HUSB"},
+			{XRef: "@I999@", Tag: "HUSB"}, // second HUSB, non-existent
+			{XRef: "@I998@", Tag: "ASSO", Role: "SPOU"},
+		},
+	}
+	addFamily(doc, fam)
+
+	issues := v.Validate(doc)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %v", len(issues), issues)
+	}
+	issue := issues[0]
+	if issue.Code != CodeOrphanedHUSB || issue.RelatedXRef != "@I999@" || issue.Details["field"] != "Spouses[1]" {
+		t.Errorf("issue = %+v", issue)
+	}
+}
+
 func TestReferenceValidatorValidate_OrphanedCHIL(t *testing.T) {
 	v := NewReferenceValidator()
 	doc := newTestDocument()
@@ -464,6 +490,50 @@ func TestReferenceValidatorValidate_IndexTracking(t *testing.T) {
 	}
 }
 
+func TestReferenceValidatorValidate_OrphanedSUBM(t *testing.T) {
+	doc := newTestDocument()
+	addIndividual(doc, &gedcom.Individual{XRef: "@I1@"})
+	doc.Records[0].Tags = []*gedcom.Tag{
+		{Level: 1, Tag: "NAME", Value: "John /Doe/"},
+		{Level: 1, Tag: "SUBM", Value: "@U1@"},
+		{Level: 1, Tag: "SUBM", Value: "@U2@"},
+	}
+	doc.Records = append(doc.Records, &gedcom.Record{
+		XRef: "@SN1@",
+		Type: "SUBN",
+		Tags: []*gedcom.Tag{{Level: 1, Tag: "SUBM", Value: "@U3@"}},
+	})
+	subm := &gedcom.Record{XRef: "@U1@", Type: gedcom.RecordTypeSubmitter, Entity: &gedcom.Submitter{XRef: "@U1@"}}
+	doc.Records = append(doc.Records, subm)
+	doc.Reindex()
+
+	v := NewReferenceValidator()
+	issues := v.Validate(doc)
+	if len(issues) != 2 {
+		t.Fatalf("Validate() returned %d issues, want 2: %v", len(issues), issues)
+	}
+	want := []struct{ record, xref, field string }{
+		{"@I1@", "@U2@", "SUBM[1]"},
+		{"@SN1@", "@U3@", "SUBM[0]"},
+	}
+	for i, w := range want {
+		issue := issues[i]
+		if issue.Code != CodeOrphanedSUBM || issue.RecordXRef != w.record || issue.RelatedXRef != w.xref {
+			t.Errorf("issues[%d] = %s on %s to %s, want %s on %s to %s",
+				i, issue.Code, issue.RecordXRef, issue.RelatedXRef, CodeOrphanedSUBM, w.record, w.xref)
+		}
+		if issue.Details["reference_type"] != string(RefTypeSUBM) || issue.Details["field"] != w.field {
+			t.Errorf("issues[%d].Details = %v, want SUBM %s", i, issue.Details, w.field)
+		}
+	}
+
+	report := v.Report(doc)
+	if report.ByType[string(RefTypeSUBM)] != 3 || report.OrphanedByType[string(RefTypeSUBM)] != 2 {
+		t.Errorf("Report() SUBM counts = %d total, %d orphaned, want 3 and 2",
+			report.ByType[string(RefTypeSUBM)], report.OrphanedByType[string(RefTypeSUBM)])
+	}
+}
+
 func TestReferenceValidatorReport_NilDocument(t *testing.T) {
 	v := NewReferenceValidator()
 	report := v.Report(nil)
@@ -706,6 +776,7 @@ func TestReferenceTypeConstants(t *testing.T) {
 		{RefTypeWIFE, "WIFE"},
 		{RefTypeCHIL, "CHIL"},
 		{RefTypeSOUR, "SOUR"},
+		{RefTypeSUBM, "SUBM"},
 	}
 
 	for _, tt := range types {
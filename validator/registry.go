@@ -28,6 +28,8 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
 )
 
 // Common regex patterns for tag value validation.
@@ -130,6 +132,20 @@ func (r *TagRegistry) Len() int {
 	return len(r.tags)
 }
 
+// Unregistered returns the tags from Document.UnknownTags that this registry
+// has no definition for, in their original order. Pair it with
+// RegistryForVendor to see which extensions in a file its vendor profile
+// does not cover.
+func (r *TagRegistry) Unregistered(unknown []*gedcom.UnknownTag) []*gedcom.UnknownTag {
+	var result []*gedcom.UnknownTag
+	for _, u := range unknown {
+		if u != nil && !r.IsKnown(u.Tag) {
+			result = append(result, u)
+		}
+	}
+	return result
+}
+
 // ValidateTag validates a tag occurrence against registry rules.
 // Returns nil if the tag is valid, or an Issue describing the validation failure.
 //
@@ -3,6 +3,8 @@ package validator
 import (
 	"regexp"
 	"testing"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
 )
 
 func TestNewTagRegistry(t *testing.T) {
@@ -292,6 +294,26 @@ func TestTagRegistry_Len(t *testing.T) {
 	}
 }
 
+func TestTagRegistry_Unregistered(t *testing.T) {
+	doc := &gedcom.Document{Records: []*gedcom.Record{{
+		XRef: "@I1@",
+		Type: gedcom.RecordTypeIndividual,
+		Tags: []*gedcom.Tag{
+			{Level: 1, Tag: "_MILT", Value: "Army"},
+			{Level: 1, Tag: "_CUSTOM", Value: "x"},
+			{Level: 1, Tag: "_CUSTOM", Value: "y"},
+		},
+	}}}
+
+	got := AncestryRegistry().Unregistered(doc.UnknownTags())
+	if len(got) != 1 || got[0].Tag != "_CUSTOM" {
+		t.Errorf("Unregistered() = %+v, want only _CUSTOM", got)
+	}
+	if got := NewTagRegistry().Unregistered(nil); got != nil {
+		t.Errorf("Unregistered(nil) = %v, want nil", got)
+	}
+}
+
 func TestTagRegistry_ValidateTag_ValidParent(t *testing.T) {
 	r := NewTagRegistry()
 	_ = r.Register("_MILT", TagDefinition{
@@ -0,0 +1,200 @@
+// restriction.go provides RESN validation.
+//
+// GEDCOM 5.5.1 defines RESN as a single lowercase value (confidential,
+// locked, or privacy); GEDCOM 7.0 makes it a comma-separated list of
+// uppercase values. Family records that share a confidential member can
+// also expose that member, since a family's links and events describe
+// its spouses and children.
+
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+// restrictionValues are the RESN enumeration values, in lowercase.
+var restrictionValues = map[string]bool{
+	"confidential": true,
+	"locked":       true,
+	"privacy":      true,
+}
+
+// RestrictionValidator checks RESN values and restriction propagation.
+type RestrictionValidator struct{}
+
+// NewRestrictionValidator creates a new RestrictionValidator.
+func NewRestrictionValidator() *RestrictionValidator {
+	return &RestrictionValidator{}
+}
+
+// Validate checks every RESN tag of the document against the enumeration
+// of its version, then looks for families that leak confidential members:
+//
+//   - a value that names no restriction produces an INVALID_RESTRICTION
+//     error with "tag" (the parent structure) and "value" details;
+//   - a recognized value in the wrong form for the version (uppercase or
+//     a list in 5.5.1, lowercase in 7.0) produces a
+//     NONSTANDARD_RESTRICTION warning with a "suggested" value;
+//   - a family that is not itself confidential but has a confidential
+//     HUSB, WIFE, or CHIL produces a RESTRICTION_LEAK warning with
+//     "individual" and "role" details.
+func (r *RestrictionValidator) Validate(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	return r.visitor(doc).run(doc)
+}
+
+// visitor returns the per-record form of Validate, with value issues
+// before leak issues.
+func (r *RestrictionValidator) visitor(doc *gedcom.Document) *recordVisitor {
+	v70 := doc.Header != nil && doc.Header.Version == gedcom.Version70
+	return newRecordVisitor(2, func(rv *recordVisitor, record *gedcom.Record) {
+		if len(record.Tags) > 0 {
+			rv.add(0, tagRestrictionIssues(record, v70)...)
+		} else {
+			rv.add(0, entityRestrictionIssues(record, v70)...)
+		}
+		if fam, ok := record.Entity.(*gedcom.Family); ok {
+			rv.add(1, restrictionLeaks(doc, record.XRef, fam)...)
+		}
+	})
+}
+
+// tagRestrictionIssues checks the RESN values in the raw tags of record.
+func tagRestrictionIssues(record *gedcom.Record, v70 bool) []Issue {
+	var issues []Issue
+	var parents []string
+	for _, tag := range record.Tags {
+		if tag == nil || tag.Level < 1 {
+			continue
+		}
+		if len(parents) >= tag.Level {
+			parents = parents[:tag.Level-1]
+		}
+		for len(parents) < tag.Level-1 {
+			parents = append(parents, "")
+		}
+		if tag.Tag == "RESN" {
+			parent := string(record.Type)
+			if len(parents) > 0 && parents[len(parents)-1] != "" {
+				parent = parents[len(parents)-1]
+			}
+			if issue, ok := checkRestrictionValue(record.XRef, parent, tag.Value, v70); ok {
+				issues = append(issues, issue)
+			}
+		}
+		parents = append(parents, tag.Tag)
+	}
+	return issues
+}
+
+// entityRestrictionIssues checks the RESN values of the entity of record,
+// covering records built in code, which have no raw tags.
+func entityRestrictionIssues(record *gedcom.Record, v70 bool) []Issue {
+	var own string
+	var events []*gedcom.Event
+	switch entity := record.Entity.(type) {
+	case *gedcom.Individual:
+		own, events = string(entity.Restriction), entity.Events
+	case *gedcom.Family:
+		own, events = string(entity.Restriction), entity.Events
+	case *gedcom.MediaObject:
+		own = string(entity.Restriction)
+	}
+
+	var issues []Issue
+	if own != "" {
+		if issue, ok := checkRestrictionValue(record.XRef, string(record.Type), own, v70); ok {
+			issues = append(issues, issue)
+		}
+	}
+	for _, e := range events {
+		if e == nil || e.Restriction == "" {
+			continue
+		}
+		if issue, ok := checkRestrictionValue(record.XRef, string(e.Type), string(e.Restriction), v70); ok {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// checkRestrictionValue returns the issue for a RESN value under parent,
+// if any.
+func checkRestrictionValue(xref, parent, value string, v70 bool) (Issue, bool) {
+	parts := strings.Split(value, ",")
+	normalized := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if !restrictionValues[strings.ToLower(part)] {
+			return NewIssue(
+				SeverityError,
+				CodeInvalidRestriction,
+				fmt.Sprintf("%s RESN %q is not a restriction value (confidential, locked, privacy)", parent, value),
+				xref,
+			).
+				WithDetail("tag", parent).
+				WithDetail("value", value), true
+		}
+		normalized = append(normalized, part)
+	}
+
+	var suggested string
+	if v70 {
+		if strings.ToUpper(value) == value {
+			return Issue{}, false
+		}
+		for i, part := range normalized {
+			normalized[i] = strings.ToUpper(part)
+		}
+		suggested = strings.Join(normalized, ", ")
+	} else {
+		suggested = gedcom.ParseRestriction(value).String()
+		if value == suggested {
+			return Issue{}, false
+		}
+	}
+	return NewIssue(
+		SeverityWarning,
+		CodeNonstandardRestriction,
+		fmt.Sprintf("%s RESN %q is not in the standard form; use %q", parent, value, suggested),
+		xref,
+	).
+		WithDetail("tag", parent).
+		WithDetail("value", value).
+		WithDetail("suggested", suggested), true
+}
+
+// restrictionLeaks reports the confidential members of a family that is
+// not itself confidential.
+func restrictionLeaks(doc *gedcom.Document, xref string, fam *gedcom.Family) []Issue {
+	famLevel := fam.Restriction.Level()
+	if famLevel >= gedcom.RestrictionConfidential {
+		return nil
+	}
+	var issues []Issue
+	leak := func(role, member string) {
+		if member == "" || doc.GetRecord(member).Restriction() < gedcom.RestrictionConfidential {
+			return
+		}
+		issues = append(issues, NewIssue(
+			SeverityWarning,
+			CodeRestrictionLeak,
+			fmt.Sprintf("family is %s but its %s %s is confidential; the family's links and events expose the individual", famLevel, role, member),
+			xref,
+		).
+			WithDetail("individual", member).
+			WithDetail("role", role).
+			WithDetail("family_restriction", famLevel.String()))
+	}
+	leak("HUSB", fam.Husband)
+	leak("WIFE", fam.Wife)
+	for _, child := range fam.Children {
+		leak("CHIL", child)
+	}
+	return issues
+}
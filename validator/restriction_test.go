@@ -0,0 +1,159 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cacack/gedcom-go/v2/decoder"
+	"github.com/cacack/gedcom-go/v2/gedcom"
+)
+
+func TestRestrictionValidator_Values551(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 RESN confidential
+1 BIRT
+2 RESN Privacy
+0 @I2@ INDI
+1 RESN secret
+0 @F1@ FAM
+1 RESN locked, privacy
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	issues := NewRestrictionValidator().Validate(doc)
+	want := []struct {
+		code, xref, tag, value, suggested string
+	}{
+		{CodeNonstandardRestriction, "@I1@", "BIRT", "Privacy", "privacy"},
+		{CodeInvalidRestriction, "@I2@", "INDI", "secret", ""},
+		{CodeNonstandardRestriction, "@F1@", "FAM", "locked, privacy", "privacy"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("Validate() returned %d issues, want %d: %v", len(issues), len(want), issues)
+	}
+	for i, w := range want {
+		issue := issues[i]
+		if issue.Code != w.code || issue.RecordXRef != w.xref {
+			t.Errorf("issue %d = %+v", i, issue)
+		}
+		if issue.Details["tag"] != w.tag || issue.Details["value"] != w.value || issue.Details["suggested"] != w.suggested {
+			t.Errorf("issue %d details = %v, want %+v", i, issue.Details, w)
+		}
+	}
+	if issues[1].Severity != SeverityError || issues[0].Severity != SeverityWarning {
+		t.Error("severity mismatch")
+	}
+}
+
+func TestRestrictionValidator_Values70(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 7.0
+0 @I1@ INDI
+1 RESN CONFIDENTIAL, LOCKED
+0 @I2@ INDI
+1 RESN locked,privacy
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	issues := NewRestrictionValidator().Validate(doc)
+	if len(issues) != 1 {
+		t.Fatalf("Validate() returned %d issues, want 1: %v", len(issues), issues)
+	}
+	if issues[0].Code != CodeNonstandardRestriction || issues[0].Details["suggested"] != "LOCKED, PRIVACY" {
+		t.Errorf("issue = %+v", issues[0])
+	}
+}
+
+func TestRestrictionValidator_Leaks(t *testing.T) {
+	input := `0 HEAD
+1 GEDC
+2 VERS 5.5.1
+0 @I1@ INDI
+1 RESN confidential
+1 FAMS @F1@
+0 @I2@ INDI
+1 FAMS @F1@
+0 @I3@ INDI
+1 RESN confidential
+1 FAMC @F1@
+1 FAMS @F2@
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 CHIL @I3@
+0 @F2@ FAM
+1 RESN confidential
+1 HUSB @I3@
+0 TRLR
+`
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	issues := NewRestrictionValidator().Validate(doc)
+	if len(issues) != 2 {
+		t.Fatalf("Validate() returned %d issues, want 2: %v", len(issues), issues)
+	}
+	for i, w := range []struct{ individual, role string }{{"@I1@", "HUSB"}, {"@I3@", "CHIL"}} {
+		issue := issues[i]
+		if issue.Code != CodeRestrictionLeak || issue.RecordXRef != "@F1@" {
+			t.Errorf("issue %d = %+v", i, issue)
+		}
+		if issue.Details["individual"] != w.individual || issue.Details["role"] != w.role || issue.Details["family_restriction"] != "none" {
+			t.Errorf("issue %d details = %v", i, issue.Details)
+		}
+	}
+}
+
+func TestRestrictionValidator_EntityOnly(t *testing.T) {
+	doc := &gedcom.Document{Header: &gedcom.Header{Version: gedcom.Version551}}
+	if _, err := doc.AddIndividual(&gedcom.Individual{
+		Restriction: "top secret",
+		Events:      []*gedcom.Event{{Type: gedcom.EventBirth, Restriction: "locked"}},
+	}); err != nil {
+		t.Fatalf("AddIndividual() error = %v", err)
+	}
+
+	issues := NewRestrictionValidator().Validate(doc)
+	if len(issues) != 1 || issues[0].Code != CodeInvalidRestriction || issues[0].Details["tag"] != "INDI" {
+		t.Errorf("Validate() = %v", issues)
+	}
+	if NewRestrictionValidator().Validate(nil) != nil {
+		t.Error("Validate(nil) should return nil")
+	}
+}
+
+func TestValidator_ValidateRestrictions(t *testing.T) {
+	input := "0 HEAD\n1 GEDC\n2 VERS 5.5.1\n0 @I1@ INDI\n1 RESN secret\n0 TRLR\n"
+	doc, err := decoder.Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	v := New()
+	if issues := v.ValidateRestrictions(doc); len(issues) != 1 {
+		t.Errorf("ValidateRestrictions() = %v", issues)
+	}
+	found := false
+	for _, issue := range v.ValidateAll(doc) {
+		found = found || issue.Code == CodeInvalidRestriction
+	}
+	if !found {
+		t.Error("ValidateAll() should include restriction issues")
+	}
+	if v.ValidateRestrictions(nil) != nil {
+		t.Error("ValidateRestrictions(nil) should return nil")
+	}
+}
@@ -105,6 +105,8 @@ func NewStreamingValidator(opts StreamingOptions) *StreamingValidator {
 //     RecordTypeObject, others: not validated (the record is still
 //     registered for XRef tracking, but no rules are evaluated)
 //
+// CHAN/CREA timestamp checks apply to every record type.
+//
 // Callers who need full validation coverage for all record types should use
 // the batch [Validator.Validate] / [Validator.ValidateAll] instead — they
 // trade memory for completeness.
@@ -140,6 +142,9 @@ func (sv *StreamingValidator) ValidateRecord(record *gedcom.Record) []Issue {
 		}
 	}
 
+	// CHAN/CREA timestamps are checked on every record type
+	issues = append(issues, sv.dateLogic.checkTimestamps(record)...)
+
 	return sv.filterByStrictness(issues)
 }
 
@@ -153,6 +158,7 @@ func (sv *StreamingValidator) validateIndividual(ind *gedcom.Individual) []Issue
 	if issue := sv.dateLogic.checkDeathBeforeBirth(ind); issue != nil {
 		issues = append(issues, *issue)
 	}
+	issues = append(issues, sv.dateLogic.checkFutureEvents(ind.XRef, ind.Events, ind.Attributes)...)
 
 	// Collect FAMC references
 	for i, link := range ind.ChildInFamilies {
@@ -218,10 +224,9 @@ func (sv *StreamingValidator) validateIndividual(ind *gedcom.Individual) []Issue
 }
 
 // validateFamily validates a Family record and collects XRef references.
-// Currently only collects references; validation rules may be added later.
-//
-//nolint:unparam // Returns nil now but signature kept for future validation rules
 func (sv *StreamingValidator) validateFamily(fam *gedcom.Family) []Issue {
+	issues := sv.dateLogic.checkFutureEvents(fam.XRef, fam.Events, nil)
+
 	// Collect HUSB reference
 	if fam.Husband != "" {
 		sv.usedXRefs[fam.Husband] = append(sv.usedXRefs[fam.Husband], usageLocation{
@@ -278,19 +283,21 @@ func (sv *StreamingValidator) validateFamily(fam *gedcom.Family) []Issue {
 		}
 	}
 
-	return nil
+	return issues
 }
 
 // collectSourceReferences collects XRef references from a Source record.
 func (sv *StreamingValidator) collectSourceReferences(src *gedcom.Source) {
-	// Collect REPO reference
-	if src.RepositoryRef != "" {
-		sv.usedXRefs[src.RepositoryRef] = append(sv.usedXRefs[src.RepositoryRef], usageLocation{
-			RecordXRef: src.XRef,
-			Context:    "REPO",
-			Field:      "RepositoryRef",
-			Index:      0,
-		})
+	// Collect REPO references
+	for i, link := range src.AllRepositoryLinks() {
+		if link.XRef != "" {
+			sv.usedXRefs[link.XRef] = append(sv.usedXRefs[link.XRef], usageLocation{
+				RecordXRef: src.XRef,
+				Context:    "REPO",
+				Field:      "RepositoryRef",
+				Index:      i,
+			})
+		}
 	}
 
 	// Collect NOTE references
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/cacack/gedcom-go/v2/gedcom"
 )
@@ -73,6 +74,35 @@ func TestStreamingValidator_ValidateRecord_ValidIndividual(t *testing.T) {
 	}
 }
 
+func TestStreamingValidator_ValidateRecord_FutureDatesAndTimestamps(t *testing.T) {
+	sv := NewStreamingValidator(StreamingOptions{
+		DateLogic:  &DateLogicConfig{AsOf: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+		Strictness: StrictnessNormal,
+	})
+
+	fam := &gedcom.Family{
+		XRef:   "@F1@",
+		Events: []*gedcom.Event{{Type: gedcom.EventMarriage, ParsedDate: &gedcom.Date{Original: "2010", Year: 2010}}},
+	}
+	issues := sv.ValidateRecord(&gedcom.Record{XRef: "@F1@", Type: gedcom.RecordTypeFamily, Entity: fam})
+	if len(issues) != 1 || issues[0].Code != CodeFutureDate {
+		t.Errorf("family issues = %+v, want one %s", issues, CodeFutureDate)
+	}
+
+	note := &gedcom.Record{
+		XRef: "@N1@",
+		Type: gedcom.RecordTypeNote,
+		Tags: []*gedcom.Tag{
+			{Level: 1, Tag: "CREA"},
+			{Level: 2, Tag: "DATE", Value: "1 JAN 2005"},
+		},
+	}
+	issues = sv.ValidateRecord(note)
+	if len(issues) != 1 || issues[0].Code != CodeFutureCreation {
+		t.Errorf("note issues = %+v, want one %s", issues, CodeFutureCreation)
+	}
+}
+
 func TestStreamingValidator_ValidateRecord_RegistersXRefs(t *testing.T) {
 	sv := NewStreamingValidator(StreamingOptions{})
 
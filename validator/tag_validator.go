@@ -45,17 +45,17 @@ func (v *TagValidator) Validate(doc *gedcom.Document) []Issue {
 		return nil
 	}
 
-	var issues []Issue
+	return v.visitor().run(doc)
+}
 
-	for _, record := range doc.Records {
+// visitor returns the per-record form of Validate.
+func (v *TagValidator) visitor() *recordVisitor {
+	return newRecordVisitor(1, func(rv *recordVisitor, record *gedcom.Record) {
+		var issues []Issue
 		// The record type tag (INDI, FAM, etc.) is the parent for level 1 tags
-		recordTypeTag := string(record.Type)
-
-		// Scan all tags in the record
-		v.scanTags(record.Tags, recordTypeTag, record.XRef, &issues)
-	}
-
-	return issues
+		v.scanTags(record.Tags, string(record.Type), record.XRef, &issues)
+		rv.add(0, issues...)
+	})
 }
 
 // scanTags recursively scans tags and validates custom tags.
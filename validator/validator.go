@@ -24,16 +24,17 @@ func (e *ValidationError) Error() string {
 	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
 }
 
-// Strictness defines the level of validation strictness.
-type Strictness int
+// Strictness defines the level of validation strictness. It is the
+// strictness shared with the decoder, converter, and encoder options.
+type Strictness = gedcom.Strictness
 
 const (
-	// StrictnessRelaxed reports only errors.
-	StrictnessRelaxed Strictness = iota
+	// StrictnessRelaxed reports only errors. It is gedcom.StrictnessLenient.
+	StrictnessRelaxed = gedcom.StrictnessLenient
 	// StrictnessNormal reports errors and warnings (default).
-	StrictnessNormal
+	StrictnessNormal = gedcom.StrictnessNormal
 	// StrictnessStrict reports all issues including info.
-	StrictnessStrict
+	StrictnessStrict = gedcom.StrictnessStrict
 )
 
 // ValidatorConfig contains configuration options for the Validator.
@@ -76,11 +77,39 @@ type ValidatorConfig struct {
 	// This is useful for large files where early termination is desired.
 	MaxErrors int
 
+	// Categories limits ValidateAll and ValidateAllContext to the checks of
+	// these categories (CategoryStructure, CategoryDates,
+	// CategoryReferences, CategoryQuality).
+	// Default: nil (all categories).
+	Categories []string
+
+	// Workers is the number of checks ValidateAllContext runs at once.
+	// Default: 0 (one check at a time, like ValidateAll).
+	Workers int
+
+	// Coordinates configures place coordinate validation.
+	// If nil, coordinates are checked without a landmask.
+	Coordinates *CoordinateConfig
+
+	// Lint configures the best-practices Linter used by Validator.Lint.
+	// If nil, all lint rules run with their default severities.
+	Lint *LintConfig
+
 	// SkipRules specifies issue codes to exclude from validation results.
-	// Issues with codes matching any entry in this slice are filtered out.
+	// Issues with codes matching any entry in this slice are filtered out,
+	// and ValidateAll does not run a check at all when every code it can
+	// report is listed (e.g. CodePotentialDuplicate skips duplicate
+	// detection).
 	// Example: []string{"W001", "I002"} to skip warning W001 and info I002.
 	// Default: nil (no rules skipped).
 	SkipRules []string
+
+	// Severity overrides the severity of issues, by code, before the
+	// Strictness filter applies: a file with same-sex families can keep
+	// CodeMultipleSpouses as SeverityInfo, or a strict pipeline can make
+	// it SeverityError.
+	// Default: nil (each check's own severity).
+	Severity map[string]Severity
 }
 
 // ValidateOptions configures validator behavior. It is the canonical options
@@ -117,7 +146,15 @@ type Validator struct {
 	tagValidator *TagValidator
 	header       *HeaderValidator
 	xref         *XRefValidator
+	cardinality  *CardinalityValidator
 	encoding     *EncodingValidator
+	placeForm    *PlaceFormValidator
+	coordinates  *CoordinateValidator
+	addressPlace *AddressPlaceValidator
+	language     *LanguageValidator
+	restriction  *RestrictionValidator
+	extension    *ExtensionValidator
+	linter       *Linter
 }
 
 // New creates a new Validator with default configuration.
@@ -221,6 +258,14 @@ func (v *Validator) getXRefValidator() *XRefValidator {
 	return v.xref
 }
 
+// getCardinalityValidator returns the cardinality validator, creating it lazily if needed.
+func (v *Validator) getCardinalityValidator() *CardinalityValidator {
+	if v.cardinality == nil {
+		v.cardinality = NewCardinalityValidator()
+	}
+	return v.cardinality
+}
+
 // getEncodingValidator returns the encoding validator, creating it lazily if needed.
 func (v *Validator) getEncodingValidator() *EncodingValidator {
 	if v.encoding == nil {
@@ -229,6 +274,70 @@ func (v *Validator) getEncodingValidator() *EncodingValidator {
 	return v.encoding
 }
 
+// getPlaceFormValidator returns the place form validator, creating it lazily if needed.
+func (v *Validator) getPlaceFormValidator() *PlaceFormValidator {
+	if v.placeForm == nil {
+		v.placeForm = NewPlaceFormValidator()
+	}
+	return v.placeForm
+}
+
+// getCoordinateValidator returns the coordinate validator, creating it lazily if needed.
+func (v *Validator) getCoordinateValidator() *CoordinateValidator {
+	if v.coordinates == nil {
+		var config *CoordinateConfig
+		if v.config != nil {
+			config = v.config.Coordinates
+		}
+		v.coordinates = NewCoordinateValidator(config)
+	}
+	return v.coordinates
+}
+
+// getAddressPlaceValidator returns the address/place validator, creating it lazily if needed.
+func (v *Validator) getAddressPlaceValidator() *AddressPlaceValidator {
+	if v.addressPlace == nil {
+		v.addressPlace = NewAddressPlaceValidator()
+	}
+	return v.addressPlace
+}
+
+// getLanguageValidator returns the language tag validator, creating it lazily if needed.
+func (v *Validator) getLanguageValidator() *LanguageValidator {
+	if v.language == nil {
+		v.language = NewLanguageValidator()
+	}
+	return v.language
+}
+
+// getRestrictionValidator returns the RESN validator, creating it lazily if needed.
+func (v *Validator) getRestrictionValidator() *RestrictionValidator {
+	if v.restriction == nil {
+		v.restriction = NewRestrictionValidator()
+	}
+	return v.restriction
+}
+
+// getExtensionValidator returns the extension validator, creating it lazily if needed.
+func (v *Validator) getExtensionValidator() *ExtensionValidator {
+	if v.extension == nil {
+		v.extension = NewExtensionValidator()
+	}
+	return v.extension
+}
+
+// getLinter returns the best-practices linter, creating it lazily if needed.
+func (v *Validator) getLinter() *Linter {
+	if v.linter == nil {
+		var config *LintConfig
+		if v.config != nil {
+			config = v.config.Lint
+		}
+		v.linter = NewLinter(config)
+	}
+	return v.linter
+}
+
 // Validate validates a GEDCOM document and returns any validation errors.
 func (v *Validator) Validate(doc *gedcom.Document) []error {
 	v.errors = make([]error, 0)
@@ -328,37 +437,183 @@ func (v *Validator) ValidateAll(doc *gedcom.Document) []Issue {
 		return nil
 	}
 
-	var allIssues []Issue
+	// Record-level checks share a single pass over the records.
+	allIssues := runChecks(doc, v.checks())
+
+	// Filter by strictness
+	return v.filterByStrictness(allIssues)
+}
 
-	// Run header validation
-	allIssues = append(allIssues, v.getHeaderValidator().ValidateHeader(doc)...)
+// validationCheck is one of the independent checks run by ValidateAll.
+type validationCheck struct {
+	name     string
+	category string
+	codes    []string // every code the check can report
+	run      func(doc *gedcom.Document) []Issue
 
-	// Run date logic validation
-	allIssues = append(allIssues, v.getDateLogicValidator().Validate(doc)...)
+	// visitor, if set, returns the per-record form of run, so ValidateAll
+	// can run the check during its single pass over the records.
+	visitor func(doc *gedcom.Document) *recordVisitor
+}
 
-	// Run reference validation
-	allIssues = append(allIssues, v.getReferenceValidator().Validate(doc)...)
+// recordCheck returns a check that runs through visitor.
+func recordCheck(name, category string, codes []string, visitor func(doc *gedcom.Document) *recordVisitor) validationCheck {
+	return validationCheck{
+		name:     name,
+		category: category,
+		codes:    codes,
+		run:      func(doc *gedcom.Document) []Issue { return visitor(doc).run(doc) },
+		visitor:  visitor,
+	}
+}
 
-	// Run XRef length validation
-	allIssues = append(allIssues, v.getXRefValidator().ValidateXRefs(doc)...)
+// runCategorized runs the check and sets the Category of its issues.
+func (c validationCheck) runCategorized(doc *gedcom.Document) []Issue {
+	return c.categorize(c.run(doc))
+}
 
-	// Run duplicate detection and convert to issues
-	for _, pair := range v.getDuplicateDetector().FindDuplicates(doc) {
-		allIssues = append(allIssues, pair.ToIssue())
+// categorize sets the Category of the check's issues that have none.
+func (c validationCheck) categorize(issues []Issue) []Issue {
+	for i := range issues {
+		if issues[i].Category == "" {
+			issues[i].Category = c.category
+		}
 	}
+	return issues
+}
+
+// checks returns the enabled checks of ValidateAll in order. The
+// validators are created here, so the checks may run concurrently.
+func (v *Validator) checks() []validationCheck {
+	extensions := v.getExtensionValidator()
+	addressPlaces := v.getAddressPlaceValidator()
+	checks := []validationCheck{
+		// Run header validation
+		{name: "header", category: CategoryStructure, codes: []string{CodeMissingSUBM, CodeOrphanedSUBM, CodeOrphanedSUBN},
+			run: v.getHeaderValidator().ValidateHeader},
+
+		// Run date logic validation
+		recordCheck("date_logic", CategoryDates, []string{
+			CodeDeathBeforeBirth, CodeChildBeforeParent, CodeMarriageBeforeBirth, CodeImpossibleAge,
+			CodeUnreasonableParentAge, CodeFutureDate, CodeChangeBeforeCreation, CodeFutureCreation,
+		}, v.getDateLogicValidator().visitor),
+
+		// Run reference validation
+		recordCheck("references", CategoryReferences, []string{
+			CodeOrphanedFAMC, CodeOrphanedFAMS, CodeOrphanedHUSB, CodeOrphanedWIFE, CodeOrphanedCHIL, CodeOrphanedSOUR, CodeOrphanedSUBM,
+		}, v.getReferenceValidator().visitor),
+
+		// Run XRef length validation
+		{name: "xrefs", category: CategoryStructure, codes: []string{CodeXRefTooLong},
+			run: v.getXRefValidator().ValidateXRefs},
+
+		// Run maximum cardinality validation
+		recordCheck("cardinality", CategoryStructure, []string{CodeCardinalityExceeded, CodeMultipleSpouses},
+			v.getCardinalityValidator().visitor),
+
+		// Run place hierarchy (FORM) validation
+		recordCheck("place_forms", CategoryStructure, []string{CodePlaceFormMismatch},
+			v.getPlaceFormValidator().visitor),
+
+		// Run place coordinate validation
+		recordCheck("coordinates", CategoryQuality, []string{
+			CodeInvalidCoordinate, CodeCoordinateOutOfRange, CodeNonstandardCoordinate, CodeZeroCoordinates, CodeCoordinateInOcean,
+		}, v.getCoordinateValidator().visitor),
+
+		// Run event address versus place validation
+		recordCheck("address_places", CategoryQuality, []string{CodeAddressPlaceMismatch},
+			func(*gedcom.Document) *recordVisitor { return addressPlaces.visitor() }),
+
+		// Run LANG payload validation
+		recordCheck("languages", CategoryStructure, []string{CodeInvalidLanguageTag},
+			v.getLanguageValidator().visitor),
+
+		// Run RESN value and propagation validation
+		recordCheck("restrictions", CategoryStructure, []string{
+			CodeInvalidRestriction, CodeNonstandardRestriction, CodeRestrictionLeak,
+		}, v.getRestrictionValidator().visitor),
+
+		// Run application extension schema validation
+		recordCheck("extensions", CategoryStructure, []string{
+			CodeExtensionInvalidRecord, CodeExtensionUnknownChild, CodeExtensionMissingChild,
+			CodeExtensionRepeatedChild, CodeExtensionInvalidPayload,
+		}, func(*gedcom.Document) *recordVisitor { return extensions.visitor() }),
+
+		// Run GEDCOM 7.0 extension declaration validation
+		{name: "extension_declarations", category: CategoryStructure, codes: []string{CodeUndeclaredExtension},
+			run: extensions.ValidateDeclarations},
+	}
+
+	// Run duplicate detection and convert to issues
+	detector := v.getDuplicateDetector()
+	checks = append(checks, validationCheck{name: "duplicates", category: CategoryQuality, codes: []string{CodePotentialDuplicate},
+		run: func(doc *gedcom.Document) []Issue {
+			var issues []Issue
+			for _, pair := range detector.FindDuplicates(doc) {
+				issues = append(issues, pair.ToIssue())
+			}
+			for _, pair := range detector.FindDuplicateFamilies(doc) {
+				issues = append(issues, pair.ToIssue())
+			}
+			return issues
+		}})
 
 	// Run custom tag validation if a registry is configured
 	if v.config != nil && v.config.TagRegistry != nil {
-		allIssues = append(allIssues, v.getTagValidator().Validate(doc)...)
+		tags := v.getTagValidator()
+		checks = append(checks, recordCheck("custom_tags", CategoryStructure,
+			[]string{CodeInvalidTagParent, CodeInvalidTagValue, CodeUnknownCustomTag},
+			func(*gedcom.Document) *recordVisitor { return tags.visitor() }))
 	}
 
 	// Run encoding validation (GEDCOM 7.0 specific)
 	if v.config == nil || !v.config.SkipEncodingValidation {
-		allIssues = append(allIssues, v.getEncodingValidator().Validate(doc)...)
+		encoding := v.getEncodingValidator()
+		checks = append(checks, recordCheck("encoding", CategoryStructure,
+			[]string{CodeInvalidEncodingForVersion, CodeBannedControlCharacter},
+			func(doc *gedcom.Document) *recordVisitor { return encoding.visitor(doc, true) }))
 	}
 
-	// Filter by strictness
-	return v.filterByStrictness(allIssues)
+	if v.config == nil || (len(v.config.Categories) == 0 && len(v.config.SkipRules) == 0) {
+		return checks
+	}
+	enabled := checks[:0]
+	for _, check := range checks {
+		if v.checkEnabled(check) {
+			enabled = append(enabled, check)
+		}
+	}
+	return enabled
+}
+
+// checkEnabled reports whether check belongs to one of the configured
+// Categories and can report a code not in SkipRules.
+func (v *Validator) checkEnabled(check validationCheck) bool {
+	if len(v.config.Categories) > 0 {
+		found := false
+		for _, category := range v.config.Categories {
+			if category == check.category {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, code := range check.codes {
+		skipped := false
+		for _, rule := range v.config.SkipRules {
+			if rule == code {
+				skipped = true
+				break
+			}
+		}
+		if !skipped {
+			return true
+		}
+	}
+	return false
 }
 
 // ValidateDateLogic runs date logic validation and returns any issues found.
@@ -398,7 +653,8 @@ func (v *Validator) ValidateCustomTags(doc *gedcom.Document) []Issue {
 }
 
 // FindPotentialDuplicates detects potential duplicate individuals based on
-// name similarity and birth date proximity.
+// name similarity and birth date proximity. For ranked matches scored on
+// phonetics, places, and relatives, see dedup.FindDuplicateIndividuals.
 func (v *Validator) FindPotentialDuplicates(doc *gedcom.Document) []DuplicatePair {
 	if doc == nil {
 		return nil
@@ -406,6 +662,15 @@ func (v *Validator) FindPotentialDuplicates(doc *gedcom.Document) []DuplicatePai
 	return v.getDuplicateDetector().FindDuplicates(doc)
 }
 
+// FindPotentialDuplicateFamilies detects potential duplicate families based
+// on shared spouses and children.
+func (v *Validator) FindPotentialDuplicateFamilies(doc *gedcom.Document) []FamilyDuplicatePair {
+	if doc == nil {
+		return nil
+	}
+	return v.getDuplicateDetector().FindDuplicateFamilies(doc)
+}
+
 // ValidateEncoding validates GEDCOM 7.0 encoding requirements.
 // This checks that GEDCOM 7.0 files use UTF-8 encoding and do not contain
 // banned C0 control characters.
@@ -418,6 +683,102 @@ func (v *Validator) ValidateEncoding(doc *gedcom.Document) []Issue {
 	return v.filterByStrictness(issues)
 }
 
+// ValidateCardinality reports tags repeated more often than the grammar of
+// the document's GEDCOM version allows, such as two SEX lines in one
+// individual or two HUSB lines in one family.
+func (v *Validator) ValidateCardinality(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	issues := v.getCardinalityValidator().Validate(doc)
+	return v.filterByStrictness(issues)
+}
+
+// ValidatePlaceForms checks that place payloads have one component per
+// jurisdiction of their declared PLAC.FORM or HEAD.PLAC.FORM. Each issue
+// carries a suggested rewrite in its "normalized" detail.
+func (v *Validator) ValidatePlaceForms(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	issues := v.getPlaceFormValidator().Validate(doc)
+	return v.filterByStrictness(issues)
+}
+
+// ValidateCoordinates checks the MAP latitude and longitude of event
+// places for syntax, range, and (0, 0) placeholders, and, if
+// ValidatorConfig.Coordinates has a Landmask, for land events at sea.
+func (v *Validator) ValidateCoordinates(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	issues := v.getCoordinateValidator().Validate(doc)
+	return v.filterByStrictness(issues)
+}
+
+// ValidateAddressPlaces reports events whose ADDR names a city, state, or
+// country that their PLAC does not, a sign of data copied between events.
+func (v *Validator) ValidateAddressPlaces(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	issues := v.getAddressPlaceValidator().Validate(doc)
+	return v.filterByStrictness(issues)
+}
+
+// ValidateLanguages checks that the LANG payloads of a GEDCOM 7.0 document
+// are BCP 47 language tags, suggesting tags for 5.5 language names.
+func (v *Validator) ValidateLanguages(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	issues := v.getLanguageValidator().Validate(doc)
+	return v.filterByStrictness(issues)
+}
+
+// ValidateRestrictions checks RESN values against the enumeration of the
+// document's version and reports families that expose confidential
+// members.
+func (v *Validator) ValidateRestrictions(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	issues := v.getRestrictionValidator().Validate(doc)
+	return v.filterByStrictness(issues)
+}
+
+// ValidateExtensions checks registered application extensions (see
+// gedcom.RegisterExtension) against their schemas.
+func (v *Validator) ValidateExtensions(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	issues := v.getExtensionValidator().Validate(doc)
+	return v.filterByStrictness(issues)
+}
+
+// FindUndeclaredExtensions reports the extension tags of a GEDCOM 7.0
+// document that HEAD.SCHMA does not declare.
+func (v *Validator) FindUndeclaredExtensions(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	issues := v.getExtensionValidator().ValidateDeclarations(doc)
+	return v.filterByStrictness(issues)
+}
+
+// Lint checks the document against opinionated best-practice rules (see
+// LintRules), configured by ValidatorConfig.Lint. Lint issues are not part
+// of ValidateAll; they carry Category CategoryLint and are filtered by the
+// configured Strictness.
+func (v *Validator) Lint(doc *gedcom.Document) []Issue {
+	if doc == nil {
+		return nil
+	}
+	issues := v.getLinter().Lint(doc)
+	return v.filterByStrictness(issues)
+}
+
 // QualityReport generates a comprehensive data quality report for the document.
 // The report includes all validation results and data completeness statistics.
 func (v *Validator) QualityReport(doc *gedcom.Document) *QualityReport {
@@ -441,6 +802,7 @@ func (v *Validator) filterByStrictness(issues []Issue) []Issue {
 		return issues
 	}
 
+	issues = v.applySeverity(issues)
 	strictness := StrictnessNormal
 	if v.config != nil {
 		strictness = v.config.Strictness
@@ -478,6 +840,29 @@ func (v *Validator) filterByStrictness(issues []Issue) []Issue {
 	return result
 }
 
+// applySeverity returns issues with the severities of the configured
+// Severity overrides, copying the slice if any change.
+func (v *Validator) applySeverity(issues []Issue) []Issue {
+	if v.config == nil || len(v.config.Severity) == 0 {
+		return issues
+	}
+	var result []Issue
+	for i, issue := range issues {
+		severity, ok := v.config.Severity[issue.Code]
+		if !ok || severity == issue.Severity {
+			continue
+		}
+		if result == nil {
+			result = append([]Issue(nil), issues...)
+		}
+		result[i].Severity = severity
+	}
+	if result == nil {
+		return issues
+	}
+	return result
+}
+
 // filterBySkipRules removes issues whose code matches any entry in SkipRules.
 func (v *Validator) filterBySkipRules(issues []Issue) []Issue {
 	if v.config == nil || len(v.config.SkipRules) == 0 {
@@ -1339,3 +1339,27 @@ func TestApplyMaxErrorsHelper(t *testing.T) {
 		}
 	})
 }
+
+func TestStrictness_SharedType(t *testing.T) {
+	// The validator's levels are the shared gedcom.Strictness values.
+	if StrictnessRelaxed != gedcom.StrictnessLenient || StrictnessNormal != gedcom.StrictnessNormal || StrictnessStrict != gedcom.StrictnessStrict {
+		t.Fatal("validator strictness levels should equal the gedcom ones")
+	}
+
+	doc := &gedcom.Document{
+		Records: []*gedcom.Record{
+			{XRef: "@F1@", Type: gedcom.RecordTypeFamily, Entity: &gedcom.Family{XRef: "@F1@", Husband: "@I9@"}},
+		},
+		XRefMap: map[string]*gedcom.Record{},
+	}
+	lenient := NewWithOptions(&ValidateOptions{Strictness: gedcom.StrictnessLenient}).ValidateAll(doc)
+	strict := NewWithOptions(&ValidateOptions{Strictness: gedcom.StrictnessStrict}).ValidateAll(doc)
+	if len(lenient) == 0 || len(strict) < len(lenient) {
+		t.Errorf("ValidateAll() issues: lenient %d, strict %d", len(lenient), len(strict))
+	}
+	for _, issue := range lenient {
+		if issue.Severity != SeverityError {
+			t.Errorf("lenient validation reported %v", issue)
+		}
+	}
+}